@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"library-management-system/internal/infrastructure/tsclient"
+)
+
+func main() {
+	var (
+		specPath = flag.String("spec", "docs/swagger.json", "OpenAPI document generated by swag")
+		out      = flag.String("out", "../frontend/src/api/client.ts", "TypeScript client to write")
+		handlers = flag.String("handlers", "internal/delivery/http/handlers", "Comma-separated directories whose @Router annotations must match the spec")
+		check    = flag.Bool("check", false, "Fail instead of writing when the client is stale or the spec has drifted from the routes")
+	)
+	flag.Parse()
+
+	spec, err := tsclient.LoadSpec(*specPath)
+	if err != nil {
+		log.Fatal("Failed to load spec:", err)
+	}
+	client := tsclient.Generate(spec, filepath.ToSlash(*specPath))
+
+	if !*check {
+		if err := os.MkdirAll(filepath.Dir(*out), 0o755); err != nil {
+			log.Fatal("Failed to create client directory:", err)
+		}
+		if err := os.WriteFile(*out, client, 0o644); err != nil {
+			log.Fatal("Failed to write client:", err)
+		}
+		fmt.Printf("✅ Client written to %s\n", *out)
+		return
+	}
+
+	problems := []string{}
+	annotated, err := tsclient.AnnotatedRoutes(strings.Split(*handlers, ",")...)
+	if err != nil {
+		log.Fatal("Failed to read route annotations:", err)
+	}
+	problems = append(problems, tsclient.Drift(annotated, spec.Routes())...)
+
+	current, err := os.ReadFile(*out)
+	if err != nil || !bytes.Equal(current, client) {
+		problems = append(problems, fmt.Sprintf("%s is not up to date with %s", *out, *specPath))
+	}
+
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			fmt.Println("❌", problem)
+		}
+		fmt.Println("Regenerate the spec with `swag init -g cmd/main.go` and the client with `make client`")
+		os.Exit(1)
+	}
+	fmt.Println("✅ Client and spec are up to date")
+}
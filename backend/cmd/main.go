@@ -1,22 +1,61 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
+	// Timezones of tenants and branches load without the zoneinfo of the image
+	_ "time/tzdata"
 
+	"library-management-system/internal/delivery/http/changelog"
 	"library-management-system/internal/delivery/http/handlers"
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/delivery/http/urlbuilder"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/events"
+	"library-management-system/internal/domain/idgen"
+	"library-management-system/internal/domain/pagetoken"
+	"library-management-system/internal/domain/repositories"
+	"library-management-system/internal/infrastructure/captcha"
 	"library-management-system/internal/infrastructure/config"
 	"library-management-system/internal/infrastructure/database"
+	"library-management-system/internal/infrastructure/davfs"
+	"library-management-system/internal/infrastructure/diskusage"
+	"library-management-system/internal/infrastructure/eventbus"
+	"library-management-system/internal/infrastructure/imaging"
+	"library-management-system/internal/infrastructure/jobs"
+	"library-management-system/internal/infrastructure/metrics"
+	"library-management-system/internal/infrastructure/notifier"
+	"library-management-system/internal/infrastructure/openlibrary"
+	"library-management-system/internal/infrastructure/pdf"
+	"library-management-system/internal/infrastructure/ratelimit"
+	"library-management-system/internal/infrastructure/redis"
+	"library-management-system/internal/infrastructure/scanner"
+	"library-management-system/internal/infrastructure/scheduler"
+	"library-management-system/internal/infrastructure/tracing"
+	"library-management-system/internal/infrastructure/webfetch"
 	"library-management-system/internal/repository"
 	"library-management-system/internal/usecase"
+	"library-management-system/web"
 
 	docs "library-management-system/docs"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"github.com/swaggo/swag"
 )
 
 // @title Library Management System API
@@ -25,15 +64,23 @@ import (
 // @host localhost:8080
 // @BasePath /api
 func main() {
+	selfTest := flag.Bool("selftest", false, "check the database, migrations, storage, cache and notification servers, then exit with status 1 if any check failed")
+	flag.Parse()
+
 	// Load configuration
 	cfg := config.Load()
 
+	if *selfTest {
+		os.Exit(runSelfTest(cfg))
+	}
+
 	// Configure Swagger metadata at runtime from config
 	docs.SwaggerInfo.Title = cfg.Swagger.Title
 	docs.SwaggerInfo.Description = cfg.Swagger.Description
 	docs.SwaggerInfo.Version = cfg.Swagger.Version
 	docs.SwaggerInfo.Host = fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
 	docs.SwaggerInfo.BasePath = cfg.API.Prefix
+	swag.Register(swaggerDocName, deprecatedSwagger{spec: docs.SwaggerInfo})
 
 	// Initialize application with configuration
 	app := NewApplication(cfg)
@@ -44,10 +91,41 @@ func main() {
 	}
 }
 
+// runSelfTest runs the self-test of the deployment without starting the server, printing each
+// check, and returns the exit status: 1 if any check failed
+func runSelfTest(cfg *config.Config) int {
+	db, err := database.Connect()
+	report := usecase.NewSelfTestUseCase(selfTestProbes(cfg, db, err, newSharedState(cfg.State, cfg.Redis))).Run(context.Background())
+	for _, check := range report.Checks {
+		fmt.Printf("%-4s %-10s %5dms  %s\n", strings.ToUpper(check.Status), check.Name, check.DurationMS, check.Detail)
+	}
+	if !report.Passed {
+		fmt.Println("Self-test failed")
+		return 1
+	}
+	fmt.Println("Self-test passed")
+	return 0
+}
+
 // Application represents the main application
 type Application struct {
-	config *config.Config
-	router *gin.Engine
+	config    *config.Config
+	router    *gin.Engine
+	readiness *handlers.ReadinessHandler
+	// Background work stopped on shutdown, in order
+	scheduler         *scheduler.Scheduler
+	jobQueue          *jobs.Queue
+	notificationQueue *jobs.Queue
+	// auditWriter is drained on shutdown, nil when audit entries are written during requests
+	auditWriter *repository.AuditWriter
+	// viewCounter writes the book views still counted in memory on shutdown
+	viewCounter *repository.ViewCounter
+	// analyticsCounter writes the usage events still counted in memory on shutdown, nil when
+	// analytics are opted out of
+	analyticsCounter *repository.AnalyticsCounter
+	// redisBus is nil when events stay in the instance
+	redisBus     *eventbus.RedisBus
+	dbSupervisor *database.Supervisor
 }
 
 // NewApplication creates a new application instance
@@ -64,36 +142,425 @@ func NewApplication(cfg *config.Config) *Application {
 	log.Printf("API Prefix: %s", cfg.API.Prefix)
 	log.Printf("Swagger enabled: %t", cfg.Swagger.Enabled)
 
+	// A sandbox resets its database nightly, so it must not be pointed at any other
+	if cfg.Sandbox.Enabled {
+		if !strings.Contains(strings.ToLower(cfg.Database.Name), "sandbox") {
+			log.Fatalf("Invalid SANDBOX_ENABLED: the database %s is not a sandbox database, its name must contain \"sandbox\"", cfg.Database.Name)
+		}
+		log.Println("Sandbox mode: data is reset nightly to its baseline")
+	}
+
+	idGenerator, err := idgen.New(cfg.Database.IDStrategy)
+	if err != nil {
+		log.Fatalf("Invalid DB_ID_STRATEGY: %v", err)
+	}
+	entities.SetIDGenerator(idGenerator)
+
 	// Initialize database
 	db, err := database.NewDatabase()
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
+	dbSupervisor := database.NewSupervisor(db, cfg.Database.HealthInterval)
+	if err := db.OnWriteRefused(dbSupervisor.MarkReadOnly); err != nil {
+		log.Fatal("Failed to watch refused database writes:", err)
+	}
+	dbSupervisor.Start()
 
-	// Initialize repositories
-	bookRepo := repository.NewBookRepository(db.GetDB())
-	urlRepo := repository.NewURLRepository()
+	// State instances must agree on is kept in Redis when they run side by side
+	sharedState := newSharedState(cfg.State, cfg.Redis)
+
+	// Initialize event bus and background job queue
+	var eventBus events.Bus = eventbus.NewInMemoryBus()
+	var redisBus *eventbus.RedisBus
+	if sharedState != nil {
+		redisBus = eventbus.NewRedisBus(sharedState, instanceID(cfg.Scheduler))
+		eventBus = redisBus
+	}
+	jobQueue := jobs.NewQueue(cfg.Jobs.Workers, cfg.Jobs.QueueSize, cfg.Jobs.RetryBackoff)
+	setPriorityWeights(jobQueue, cfg.Jobs.PriorityWeights)
+	jobQueue.Start()
+
+	// Initialize outbound notification channels on their own worker pool, so slow webhooks do not hold up other jobs
+	notificationQueue := jobs.NewQueue(cfg.Notifications.Workers, cfg.Jobs.QueueSize, cfg.Jobs.RetryBackoff)
+	setPriorityWeights(notificationQueue, cfg.Jobs.PriorityWeights)
+	notificationQueue.Start()
+	notificationDispatcher := notifier.NewDispatcher(notificationChannels(cfg.Notifications), cfg.Notifications.Routes, notificationQueue, cfg.Notifications.MaxAttempts)
+	notificationDispatcher.Subscribe(eventBus)
+
+	// Initialize repositories; the statements every repository runs, and the calls of the book
+	// repository by method, are recorded for the admin API
+	repositoryMetrics := metrics.NewRegistry()
+	if err := db.GetDB().Use(database.NewQueryMetrics(repositoryMetrics)); err != nil {
+		log.Fatal("Failed to record repository metrics:", err)
+	}
+	var bookStore repositories.BookRepository = repository.NewBookRepository(db.GetDB())
+	if cfg.ISBNCache.TTL > 0 {
+		bookStore = repository.NewBookRepositoryWithISBNCache(bookStore, cfg.ISBNCache.TTL, cfg.ISBNCache.MaxEntries)
+	}
+	bookRepo := repository.NewBookRepositoryWithMetrics(bookStore, repositoryMetrics)
+	bookDraftRepo := repository.NewBookDraftRepository(db.GetDB())
+	bookIdentifierRepo := repository.NewBookIdentifierRepository(db.GetDB())
+	bookCopyRepo := repository.NewBookCopyRepository(db.GetDB())
+	coverRepo := newCoverRepository(db, cfg.Covers)
+	urlCache := newURLCache(cfg.URLCache, cfg.Redis)
+	urlRepo := repository.NewURLRepository(db.GetDB(), urlCache)
+	notificationRepo := repository.NewNotificationRepository(db.GetDB())
+	usageRepo := repository.NewInMemoryUsageRepository()
+	if sharedState != nil {
+		usageRepo = repository.NewRedisUsageRepository(sharedState)
+	}
+	auditRepo := repository.NewAuditRepository(db.GetDB())
+	publisherRepo := repository.NewPublisherRepository(db.GetDB())
+	seriesRepo := repository.NewSeriesRepository(db.GetDB())
+	branchRepo := repository.NewBranchRepository(db.GetDB())
+	calendarRepo := repository.NewCalendarRepository(db.GetDB())
+	tenantRepo := repository.NewTenantRepository(db.GetDB())
+	workRepo := repository.NewWorkRepository(db.GetDB())
+	collectionRepo := repository.NewCollectionRepository(db.GetDB())
+	acquisitionRepo := repository.NewAcquisitionRepository(db.GetDB())
+	inventoryRepo := repository.NewInventoryRepository(db.GetDB())
+	reportSubscriptionRepo := repository.NewReportSubscriptionRepository(db.GetDB())
+	jobLockRepo := repository.NewJobLockRepository(db.GetDB())
+	deadLetterRepo := repository.NewDeadLetterRepository(db.GetDB())
+	setupRepo := repository.NewSetupRepository(db.GetDB())
+	metadataFieldRepo := repository.NewMetadataFieldRepository(db.GetDB())
+	validationRuleRepo := repository.NewValidationRuleRepository(db.GetDB())
+	importProfileRepo := repository.NewImportProfileRepository(db.GetDB())
+	importRunRepo := repository.NewImportRunRepository(db.GetDB())
+	userRepo := repository.NewUserRepository(db.GetDB())
+	notificationDispatcher.SetRecipients(userRepo)
+	accessTokenRepo := repository.NewAccessTokenRepository(db.GetDB())
+	sessionRepo := repository.NewSessionRepository(db.GetDB())
+	loanRepo := repository.NewLoanRepository(db.GetDB())
+	holdRepo := repository.NewHoldRepository(db.GetDB())
+	fineRepo := repository.NewFineRepository(db.GetDB())
+	policyRepo := repository.NewPolicyRepository(db.GetDB())
+	bookStatsRepo := repository.NewBookStatsRepository(db.GetDB())
+	analyticsRepo := repository.NewAnalyticsRepository(db.GetDB())
+	exploreRepo := repository.NewExploreRepository(db.GetDB())
+	statsRepo := repository.NewStatsRepository(db.GetDB())
+	relatedBookRepo := repository.NewRelatedBookRepository(db.GetDB())
+	sitemapJobRepo := repository.NewInMemorySitemapJobRepository(cfg.URLSitemap.JobsKept)
+	enrichmentJobRepo := repository.NewInMemoryEnrichmentJobRepository(cfg.Enrichment.JobsKept)
+	coverImportJobRepo := repository.NewInMemoryCoverImportJobRepository(cfg.Covers.ImportJobsKept)
 
 	// Initialize use cases
 	bookUseCase := usecase.NewBookUseCase(bookRepo)
+	auditWriter := newAuditWriter(cfg.Audit, auditRepo)
+	if auditWriter != nil {
+		bookUseCase.SetAuditRepository(auditWriter)
+	} else {
+		bookUseCase.SetAuditRepository(auditRepo)
+	}
+	bookUseCase.SetPublisherRepository(publisherRepo)
+	bookUseCase.SetSeriesRepository(seriesRepo)
+	bookUseCase.SetBranchRepository(branchRepo)
+	bookUseCase.SetDraftRepository(bookDraftRepo)
+	bookUseCase.SetCoverRepository(coverRepo)
+	bookUseCase.SetIdentifierRepository(bookIdentifierRepo)
+	bookUseCase.SetCopyRepository(bookCopyRepo)
+	bookUseCase.SetLoanRepository(loanRepo)
+	bookUseCase.SetAccessionFormat(cfg.Accession.Prefix, cfg.Accession.Digits)
+	profiles := validationProfiles(cfg.Validation)
+	bookUseCase.SetValidationProfiles(profiles)
+	bookUseCase.SetValidationRuleRepository(validationRuleRepo)
+	bookUseCase.SetEventBus(eventBus)
+	bookUseCase.SetImportChunkSize(cfg.Import.ChunkSize)
+	queryCache := newQueryCache(cfg.QueryCache)
+	if queryCache != nil && sharedState != nil {
+		queryCache.SetSharedVersion(repository.NewRedisCacheVersion(sharedState, "query-cache:version"))
+	}
+	bookUseCase.SetQueryCache(queryCache)
+	availabilityUseCase := usecase.NewAvailabilityUseCase(bookRepo)
+	availabilityUseCase.Subscribe(eventBus)
 	urlUseCase := usecase.NewURLUseCase(urlRepo)
+	if err := urlUseCase.SetProfiles(cfg.URLProfiles); err != nil {
+		log.Fatal("Invalid URL_PROFILES:", err)
+	}
+	if err := urlUseCase.SetDefaultOperation(cfg.URLDefaults.Operation); err != nil {
+		log.Fatal("Invalid URL_DEFAULT_OPERATION:", err)
+	}
+	urlUseCase.SetStrict(cfg.URLDefaults.Strict)
+	if urlCache != nil {
+		urlUseCase.SetCache(cfg.URLCache.Backend, cfg.URLCache.TTL)
+	}
+	sitemapUseCase := usecase.NewSitemapUseCase(sitemapJobRepo, urlUseCase, int64(cfg.URLSitemap.MaxBytes), cfg.URLSitemap.MaxEntries)
+	if cfg.URLSitemap.FetchEnabled {
+		sitemapUseCase.SetFetcher(webfetch.NewFetcher(int64(cfg.URLSitemap.MaxBytes), cfg.URLSitemap.FetchTimeout, cfg.URLSitemap.FetchPrivate))
+	}
+	sitemapUseCase.SetRunner(func(ctx context.Context, name string, run func(ctx context.Context) error) error {
+		trace, _ := tracing.FromContext(ctx)
+		return jobQueue.Enqueue(jobs.Job{Name: name, Priority: jobs.PriorityInteractive, Run: run, Trace: trace})
+	})
+	notificationUseCase := usecase.NewNotificationUseCase(notificationRepo)
+	notificationUseCase.Subscribe(eventBus)
+	usageUseCase := usecase.NewUsageUseCase(usageRepo, cfg.Quota.MonthlyLimit, cfg.Quota.Overrides)
+	timelineUseCase := usecase.NewTimelineUseCase(bookRepo, usecase.NewAuditTimelineSource(auditRepo), usecase.NewLoanTimelineSource(loanRepo))
+	bundleUseCase := usecase.NewBundleUseCase(bookRepo, auditRepo)
+	bundleUseCase.SetPublisherRepository(publisherRepo)
+	publisherUseCase := usecase.NewPublisherUseCase(publisherRepo, bookRepo)
+	seriesUseCase := usecase.NewSeriesUseCase(seriesRepo, bookRepo)
+	branchUseCase := usecase.NewBranchUseCase(branchRepo)
+	workUseCase := usecase.NewWorkUseCase(workRepo, bookRepo)
+	workUseCase.SetQueryCache(queryCache)
+	collectionUseCase := usecase.NewCollectionUseCase(collectionRepo, bookRepo)
+	acquisitionUseCase := usecase.NewAcquisitionUseCase(acquisitionRepo, bookRepo, bookUseCase)
+	acquisitionUseCase.SetEventBus(eventBus)
+	reportUseCase := usecase.NewReportUseCase(bookRepo, auditRepo)
+	inventoryUseCase := usecase.NewInventoryUseCase(inventoryRepo, bookRepo)
+	reportSubscriptionUseCase := usecase.NewReportSubscriptionUseCase(reportSubscriptionRepo, reportUseCase, reportSender(cfg.Notifications))
+	setupUseCase := usecase.NewSetupUseCase(setupRepo, cfg.Security.SetupToken)
+	setupUseCase.SetPasswordPolicy(passwordPolicy(cfg.Security))
+	cursorSigner := newCursorSigner(cfg.Security)
+	metadataUseCase := usecase.NewMetadataUseCase(metadataFieldRepo)
+	validationRuleUseCase := usecase.NewValidationRuleUseCase(validationRuleRepo)
+	validationRuleUseCase.SetValidationProfiles(profiles)
+	schemaUseCase := usecase.NewSchemaUseCase(validationRuleRepo, metadataFieldRepo)
+	schemaUseCase.SetValidationProfiles(profiles)
+	importProfileUseCase := usecase.NewImportProfileUseCase(importProfileRepo)
+	importRunUseCase := usecase.NewImportRunUseCase(importRunRepo, cfg.Import.DuplicateWindow, duplicateImportAction(cfg.Import.DuplicateAction))
+	importRunUseCase.SetCursorSigner(cursorSigner)
+	urlUseCase.SetCursorSigner(cursorSigner)
+	uploadScanUseCase := newUploadScanUseCase(cfg.UploadScan)
+	coverUseCase := usecase.NewCoverUseCase(bookRepo, coverRepo, cfg.Covers.MaxBytes, cfg.Covers.MaxDimension, cfg.Covers.MaxPixels)
+	bundleUseCase.SetCoverUseCase(coverUseCase)
+	coverUseCase.SetUploadScanUseCase(uploadScanUseCase)
+	coverFetcher := newCoverFetcher(cfg.Covers)
+	if cfg.Covers.FetchEnabled {
+		coverUseCase.SetFetcher(coverFetcher)
+	}
+	coverImportUseCase := usecase.NewCoverImportUseCase(coverImportJobRepo, bookRepo, coverUseCase, cfg.Covers.ImportMaxBytes, cfg.Covers.ImportMaxFiles)
+	coverImportUseCase.SetRunner(func(ctx context.Context, name string, run func(ctx context.Context) error) error {
+		trace, _ := tracing.FromContext(ctx)
+		return jobQueue.Enqueue(jobs.Job{Name: name, Priority: jobs.PriorityBackfill, Run: run, Trace: trace})
+	})
+	enrichmentUseCase := newEnrichmentUseCase(cfg, enrichmentJobRepo, bookRepo, bookUseCase, coverUseCase, coverFetcher, publisherRepo, jobQueue, eventBus)
+	adminAuthUseCase := usecase.NewAdminAuthUseCase(userRepo)
+	loanUseCase := usecase.NewLoanUseCase(loanRepo, holdRepo, fineRepo, policyRepo)
+	loanUseCase.SetEventBus(eventBus)
+	loanUseCase.SetBookRepository(bookRepo)
+	loanUseCase.SetAuditRepository(auditRepo)
+	loanUseCase.SetUserRepository(userRepo)
+	shifts, shiftLocation := circulationShifts(cfg.Circulation)
+	loanUseCase.SetShifts(shifts, shiftLocation)
+	calendarUseCase := usecase.NewCalendarUseCase(calendarRepo, branchRepo)
+	loanUseCase.SetCalendar(calendarUseCase)
+	timezoneUseCase := usecase.NewTimezoneUseCase(tenantRepo, branchRepo, circulationTimezone(cfg.Circulation))
+	loanUseCase.SetTimezones(timezoneUseCase)
+	reportSubscriptionUseCase.SetTimezones(timezoneUseCase)
+	memberUseCase := usecase.NewMemberUseCase(userRepo)
+	memberUseCase.SetLoanRepository(loanRepo)
+	memberUseCase.SetAuditRepository(auditRepo)
+	accessTokenUseCase := newAccessTokenUseCase(cfg.Security, accessTokenRepo, userRepo)
+	sessionUseCase := newSessionUseCase(cfg.Security, sessionRepo, userRepo)
+	sessionUseCase.SetAuditRepository(auditRepo)
+	storageUseCase := usecase.NewStorageUseCase(storageSources(db, cfg.Storage), storageLimits(cfg.Storage), cfg.Storage.WarnPercent)
+	storageUseCase.SetEventBus(eventBus)
+
+	// Jobs that fail all their attempts are kept as dead letters, to be requeued or discarded by an admin
+	deadLetterUseCase := usecase.NewDeadLetterUseCase(deadLetterRepo)
+	deadLetterUseCase.SetCursorSigner(cursorSigner)
+	for name, queue := range map[string]*jobs.Queue{"jobs": jobQueue, "notifications": notificationQueue} {
+		deadLetterUseCase.AddQueue(name, queue)
+		queue.OnFailure(recordDeadLetter(name, deadLetterUseCase))
+	}
+
+	// Recurring background jobs
+	jobScheduler := scheduler.New(jobQueue)
+	if cfg.Scheduler.LockEnabled {
+		jobScheduler.SetLocks(jobLockRepo, instanceID(cfg.Scheduler), cfg.Scheduler.LockTTL)
+	}
+	popularityUseCase := usecase.NewPopularityUseCase(bookStatsRepo, cfg.Popularity.WindowDays, int64(cfg.Popularity.LoanWeight))
+	statsUseCase := usecase.NewStatsUseCase(statsRepo, cfg.Stats.TopAuthors, cfg.Stats.MaxAge)
+	statsUseCase.SetTimezone(timezoneUseCase.Default())
+	relatedBookUseCase := usecase.NewRelatedBookUseCase(relatedBookRepo, bookRepo, entities.RelatedBookWeights{
+		SameAuthor:     cfg.RelatedBooks.AuthorWeight,
+		SameSeries:     cfg.RelatedBooks.SeriesWeight,
+		SharedCategory: cfg.RelatedBooks.CategoryWeight,
+		CoBorrowed:     cfg.RelatedBooks.CoBorrowedWeight,
+	}, cfg.RelatedBooks.Limit)
+	// Sandbox deployments are reset to their baseline by a job of their own
+	var sandboxUseCase *usecase.SandboxUseCase
+	if cfg.Sandbox.Enabled {
+		sandboxUseCase = usecase.NewSandboxUseCase(repository.NewSandboxRepository(db.GetDB()))
+	}
+	for _, spec := range scheduledJobs(cfg.Scheduler, bookUseCase, reportSubscriptionUseCase, popularityUseCase, storageUseCase, statsUseCase, relatedBookUseCase, memberUseCase, loanUseCase, urlUseCase, sandboxUseCase) {
+		if err := jobScheduler.Register(spec); err != nil {
+			log.Fatal("Failed to register scheduled job:", err)
+		}
+	}
+	if cfg.Scheduler.Enabled {
+		jobScheduler.Start()
+	}
+
+	var analyticsCounter *repository.AnalyticsCounter
+	if cfg.Analytics.Enabled {
+		analyticsCounter = repository.NewAnalyticsCounter(analyticsRepo, cfg.Analytics.FlushInterval)
+	}
+
+	changes, err := changelog.Load()
+	if err != nil {
+		log.Fatal("Invalid API changelog:", err)
+	}
+
+	feedUseCase := usecase.NewFeedUseCase(bookUseCase, coverRepo, cfg.Feeds.Items, cfg.Feeds.MaxItems)
 
 	// Initialize handlers
-	bookHandler := handlers.NewBookHandler(bookUseCase)
-	urlHandler := handlers.NewURLHandler(urlUseCase)
+	urlGuard := newURLGuard(cfg.URLGuard, sharedState)
+	allowlist := newIPAllowlist(cfg, auditRepo, auditWriter)
+	signIn := middleware.NewBruteForceGuard(newSignInLockout(cfg.SignIn, sharedState))
+	configHandler := handlers.NewConfigHandler(publicConfig(cfg))
+	h := &routeHandlers{
+		book:         handlers.NewBookHandler(bookUseCase),
+		bookDetail:   handlers.NewBookDetailHandler(usecase.NewBookDetailUseCase(bookRepo, publisherRepo, seriesRepo, bookIdentifierRepo, bookCopyRepo, loanRepo, holdRepo)),
+		url:          handlers.NewURLHandler(urlUseCase),
+		sitemap:      handlers.NewSitemapHandler(sitemapUseCase),
+		urlGuard:     handlers.NewURLGuardHandler(urlGuard),
+		bruteForce:   handlers.NewBruteForceHandler(signIn),
+		auditWriter:  handlers.NewAuditWriterHandler(auditBuffer(auditWriter)),
+		repoMetrics:  handlers.NewRepositoryMetricsHandler(repositoryMetrics),
+		readiness:    handlers.NewReadinessHandler(dbSupervisor),
+		notification: handlers.NewNotificationHandler(notificationUseCase),
+		me:           handlers.NewMeHandler(usageUseCase),
+		member:       handlers.NewMemberHandler(loanUseCase, memberUseCase),
+		accessToken:  handlers.NewAccessTokenHandler(accessTokenUseCase),
+		session:      handlers.NewSessionHandler(sessionUseCase),
+		loan:         handlers.NewLoanHandler(loanUseCase),
+		timeline:     handlers.NewTimelineHandler(timelineUseCase),
+		availability: handlers.NewAvailabilityHandler(availabilityUseCase, cfg.API.LongPollTimeout),
+		bundle:       handlers.NewBundleHandler(bundleUseCase),
+		cover:        handlers.NewCoverHandler(coverUseCase),
+		coverImport:  handlers.NewCoverImportHandler(coverImportUseCase),
+		publisher:    handlers.NewPublisherHandler(publisherUseCase),
+		series:       handlers.NewSeriesHandler(seriesUseCase),
+		branch:       handlers.NewBranchHandler(branchUseCase),
+		calendar:     handlers.NewCalendarHandler(calendarUseCase),
+		timezone:     handlers.NewTimezoneHandler(timezoneUseCase),
+		work:         handlers.NewWorkHandler(workUseCase),
+		collection:   handlers.NewCollectionHandler(collectionUseCase),
+		acquisition:  handlers.NewAcquisitionHandler(acquisitionUseCase),
+		report:       handlers.NewReportHandler(reportUseCase),
+		explore:      handlers.NewExploreHandler(usecase.NewExploreUseCase(exploreRepo)),
+		stats:        handlers.NewStatsHandler(statsUseCase),
+		relatedBook:  handlers.NewRelatedBookHandler(relatedBookUseCase),
+		feed:         handlers.NewFeedHandler(feedUseCase, cfg.Feeds.Title),
+		opds:         handlers.NewOPDSHandler(feedUseCase, opdsPrefix, cfg.OPDS.Title, cfg.OPDS.PageSize),
+		holdings:     handlers.NewHoldingsHandler(usecase.NewHoldingsUseCase(bookUseCase, loanRepo, holdRepo, cfg.Availability.MaxISBNs)),
+		inventory:    handlers.NewInventoryHandler(inventoryUseCase),
+		subscription: handlers.NewReportSubscriptionHandler(reportSubscriptionUseCase),
+		job:          handlers.NewJobHandler(jobScheduler),
+		workerPool:   handlers.NewWorkerPoolHandler(),
+		deadLetter:   handlers.NewDeadLetterHandler(deadLetterUseCase),
+		errorCatalog: handlers.NewErrorCatalogHandler(cfg.API.ErrorDocsURL),
+		changelog:    handlers.NewChangelogHandler(changes, cfg.Swagger.Version),
+		config:       configHandler,
+		bootstrap:    handlers.NewBootstrapHandler(configHandler, memberUseCase, notificationUseCase),
+		setup:        handlers.NewSetupHandler(setupUseCase),
+		metadata:     handlers.NewMetadataHandler(metadataUseCase),
+		schema:       handlers.NewSchemaHandler(schemaUseCase),
+		validation:   handlers.NewValidationRuleHandler(validationRuleUseCase),
+		imports:      handlers.NewImportProfileHandler(importProfileUseCase),
+		importRuns:   handlers.NewImportRunHandler(importRunUseCase),
+		storage:      handlers.NewStorageHandler(storageUseCase),
+		analytics:    handlers.NewAnalyticsHandler(usecase.NewAnalyticsUseCase(analyticsRepo, cfg.Analytics.Enabled)),
+		usageEvents:  analyticsCounter,
+		usage:        usageUseCase,
+		guard:        urlGuard,
+		allowlist:    allowlist,
+		signIn:       signIn,
+		searches:     newSearchLimiter(cfg.Search),
+		expensive:    newExpensiveLimiter(cfg.Expensive),
+		queues:       []middleware.QueueDepth{jobQueue, notificationQueue},
+		database:     dbSupervisor,
+		static:       newStaticHandler(cfg),
+		adminUI:      handlers.NewAdminUIHandler(bookUseCase, db),
+		artifacts:    newArtifactHandler(cfg),
+		sandbox:      newSandboxHandler(sandboxUseCase),
+		selfTest:     handlers.NewSelfTestHandler(usecase.NewSelfTestUseCase(selfTestProbes(cfg, db, nil, sharedState))),
+		adminAuth:    adminAuthUseCase,
+		accessTokens: accessTokenUseCase,
+		sessions:     sessionUseCase,
+	}
+
+	// Absolute links the API returns, such as canonical links of books and Location headers
+	links, err := urlbuilder.New(cfg.API.PublicBaseURL)
+	if err != nil {
+		log.Fatal("Invalid PUBLIC_BASE_URL:", err)
+	}
+	h.book.SetLinks(links.Under(cfg.API.Prefix))
+	h.book.SetMetadataUseCase(metadataUseCase)
+	h.book.SetImportProfileUseCase(importProfileUseCase)
+	h.book.SetImportRunUseCase(importRunUseCase)
+	h.book.SetUploadScanUseCase(uploadScanUseCase)
+	viewCounter := repository.NewViewCounter(bookStatsRepo, cfg.Popularity.ViewFlushInterval, cfg.Popularity.ViewDedupWindow)
+	h.book.SetViewRecorder(viewCounter)
+	h.sitemap.SetLinks(links)
+	h.coverImport.SetLinks(links)
+	h.feed.SetLinks(links, links.Under(cfg.API.Prefix))
+	h.opds.SetLinks(links, links.Under(cfg.API.Prefix))
+	if enrichmentUseCase != nil {
+		h.enrichment = handlers.NewEnrichmentHandler(enrichmentUseCase)
+		h.enrichment.SetLinks(links)
+	}
+
+	// What the deployment runs, read off the wiring above
+	h.capabilities = handlers.NewCapabilitiesHandler(capabilities(cfg, h, db.GetDB().Dialector.Name(), loanUseCase, notificationDispatcher, urlUseCase, queryCache, sharedState))
+
+	// Worker pools operators can resize at runtime
+	h.workerPool.AddPool("jobs", "Scheduled jobs and other background work", jobQueue)
+	h.workerPool.AddPool("notifications", "Email, webhook and Slack notification deliveries", notificationQueue)
+
+	// Printable reports
+	pageSize, ok := pdf.PageSizeByName(cfg.Reports.PDFPageSize)
+	if !ok {
+		log.Printf("Unknown PDF page size %q, using %s", cfg.Reports.PDFPageSize, pdf.A4.Name)
+		pageSize = pdf.A4
+	}
+	pdfRenderer := pdf.NewRenderer(pageSize)
+	h.report.SetRenderer(pdfRenderer)
+	h.inventory.SetRenderer(pdfRenderer)
+
+	// Exports written with sorted keys for diffing
+	h.bundle.SetSortedJSON(cfg.API.SortedJSONExports)
+	h.report.SetSortedJSON(cfg.API.SortedJSONExports)
+	h.inventory.SetSortedJSON(cfg.API.SortedJSONExports)
 
 	// Initialize router
 	router := gin.Default()
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatal("Invalid BACKEND_TRUSTED_PROXIES:", err)
+	}
 
 	// Add CORS middleware
 	router.Use(corsMiddleware(cfg.CORS))
 
+	// Continue or start the trace of each request
+	router.Use(middleware.Tracing())
+
+	// Tell integrators they are on the sandbox
+	if cfg.Sandbox.Enabled {
+		router.Use(middleware.Sandbox())
+	}
+
+	// Label the SQL log lines with the request their query runs for
+	router.Use(middleware.QueryLabels())
+
 	// Setup routes
-	setupRoutes(router, cfg, bookHandler, urlHandler)
+	setupRoutes(router, cfg, h)
 
 	return &Application{
-		config: cfg,
-		router: router,
+		config:            cfg,
+		router:            router,
+		readiness:         h.readiness,
+		scheduler:         jobScheduler,
+		jobQueue:          jobQueue,
+		notificationQueue: notificationQueue,
+		auditWriter:       auditWriter,
+		viewCounter:       viewCounter,
+		analyticsCounter:  analyticsCounter,
+		redisBus:          redisBus,
+		dbSupervisor:      dbSupervisor,
 	}
 }
 
@@ -101,7 +568,780 @@ func NewApplication(cfg *config.Config) *Application {
 func (app *Application) Start() error {
 	serverAddr := fmt.Sprintf("%s:%s", app.config.Server.Host, app.config.Server.Port)
 	log.Printf("Server starting on %s", serverAddr)
-	return app.router.Run(serverAddr)
+	server := &http.Server{Addr: serverAddr, Handler: app.router}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case err := <-serveErr:
+		return err
+	case sig := <-stop:
+		log.Printf("Received %s, shutting down", sig)
+	}
+
+	app.shutdown(server)
+	return nil
+}
+
+// shutdown stops the server without dropping work: it reports itself unready for the shutdown
+// delay so load balancers stop routing to it, stops accepting requests and finishes in-flight
+// ones, lets background jobs finish, then drains the audit buffer, all within the grace period
+func (app *Application) shutdown(server *http.Server) {
+	app.readiness.Drain()
+	if delay := app.config.Server.ShutdownDelay; delay > 0 {
+		log.Printf("Reporting unready for %s before shutting down", delay)
+		time.Sleep(delay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), app.config.Server.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Failed to finish in-flight requests: %v", err)
+	}
+
+	// Jobs are stopped after requests, which may enqueue them, and notifications after jobs
+	app.scheduler.Stop()
+	if err := within(ctx, app.jobQueue.Stop); err != nil {
+		log.Printf("Gave up waiting for background jobs: %v", err)
+	}
+	if err := within(ctx, app.notificationQueue.Stop); err != nil {
+		log.Printf("Gave up waiting for notification deliveries: %v", err)
+	}
+
+	// Requests and jobs both record audit entries
+	if app.auditWriter != nil {
+		if err := app.auditWriter.Close(ctx); err != nil {
+			log.Printf("Failed to drain the audit buffer: %v", err)
+		}
+	}
+	if err := app.viewCounter.Close(ctx); err != nil {
+		log.Printf("Failed to write the counted book views: %v", err)
+	}
+	if app.analyticsCounter != nil {
+		if err := app.analyticsCounter.Close(ctx); err != nil {
+			log.Printf("Failed to write the counted usage analytics: %v", err)
+		}
+	}
+	if app.redisBus != nil {
+		app.redisBus.Close()
+	}
+	app.dbSupervisor.Stop()
+}
+
+// within runs stop and waits for it to return until ctx ends
+func within(ctx context.Context, stop func()) error {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		stop()
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// notificationChannels builds the outbound notification channels enabled in configuration
+func notificationChannels(cfg config.NotificationConfig) []notifier.Notifier {
+	var channels []notifier.Notifier
+	if cfg.EmailEnabled {
+		channels = append(channels, notifier.NewEmailNotifier(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.EmailFrom))
+	}
+	if cfg.WebhookEnabled && cfg.WebhookURL != "" {
+		channels = append(channels, notifier.NewWebhookNotifier(cfg.WebhookURL))
+	}
+	if cfg.SlackEnabled && cfg.SlackWebhookURL != "" {
+		channels = append(channels, notifier.NewSlackNotifier(cfg.SlackWebhookURL))
+	}
+	return channels
+}
+
+// newURLGuard protects the anonymous URL processor with a per-IP rate limit and, depending on
+// URL_TOKEN_MODE, an access token or captcha requirement. The limit is counted in Redis when
+// instances share their state.
+func newURLGuard(cfg config.URLGuardConfig, sharedState *redis.Client) *middleware.URLGuard {
+	var limiter middleware.RateLimiter
+	switch {
+	case cfg.IPLimit <= 0:
+	case sharedState != nil:
+		limiter = ratelimit.NewRedisLimiter(sharedState, "ratelimit:url:", cfg.IPLimit, cfg.IPWindow)
+	default:
+		limiter = ratelimit.NewLimiter(cfg.IPLimit, cfg.IPWindow)
+	}
+
+	var verifier middleware.TokenVerifier
+	switch cfg.TokenMode {
+	case "", "none":
+	case "token":
+		verifier = captcha.NewTokens(cfg.Tokens)
+	case "captcha":
+		if cfg.CaptchaSecret == "" {
+			log.Fatal("URL_CAPTCHA_SECRET is required when URL_TOKEN_MODE is captcha")
+		}
+		verifier = captcha.NewSiteVerify(cfg.CaptchaVerifyURL, cfg.CaptchaSecret)
+	default:
+		log.Fatalf("Invalid URL_TOKEN_MODE %q, expected none, token or captcha", cfg.TokenMode)
+	}
+	return middleware.NewURLGuard(limiter, verifier)
+}
+
+// newSignInLockout tracks failed sign-ins in Redis when instances share state, so a ban holds on
+// all of them, and in memory otherwise
+func newSignInLockout(cfg config.SignInConfig, sharedState *redis.Client) middleware.FailureLockout {
+	policy := ratelimit.LockoutPolicy{
+		MaxFailures:    cfg.MaxFailures,
+		Window:         cfg.FailureWindow,
+		BanDuration:    cfg.BanDuration,
+		MaxBanDuration: cfg.MaxBanDuration,
+	}
+	if sharedState != nil {
+		return ratelimit.NewRedisLockout(sharedState, "lockout:signin:", policy)
+	}
+	return ratelimit.NewLockout(policy)
+}
+
+// newIPAllowlist restricts admin routes and deletions to ADMIN_ALLOWED_CIDRS, auditing the requests
+// it rejects through the audit writer when there is one
+func newIPAllowlist(cfg *config.Config, auditRepo repositories.AuditRepository, auditWriter *repository.AuditWriter) *middleware.IPAllowlist {
+	audit := auditRepo
+	if auditWriter != nil {
+		audit = auditWriter
+	}
+	allowlist, err := middleware.NewIPAllowlist(cfg.Security.AdminAllowedCIDRs, audit)
+	if err != nil {
+		log.Fatal("Invalid ADMIN_ALLOWED_CIDRS:", err)
+	}
+	if allowlist.Enabled() && (contains(cfg.Server.TrustedProxies, "0.0.0.0/0") || contains(cfg.Server.TrustedProxies, "::/0")) {
+		log.Printf("ADMIN_ALLOWED_CIDRS is set while BACKEND_TRUSTED_PROXIES trusts any proxy, so clients can choose the IP it checks with X-Forwarded-For")
+	}
+	return allowlist
+}
+
+// newAuditWriter puts a write-behind buffer in front of the audit repository, or returns nil when AUDIT_ASYNC is off
+func newAuditWriter(cfg config.AuditConfig, repo repositories.AuditRepository) *repository.AuditWriter {
+	if !cfg.Async {
+		return nil
+	}
+	return repository.NewAuditWriter(repo, cfg.BufferSize, cfg.BatchSize, cfg.FlushInterval, cfg.MaxAttempts)
+}
+
+// auditBuffer exposes the audit writer to its handler, keeping a missing writer a nil interface
+func auditBuffer(writer *repository.AuditWriter) handlers.AuditBuffer {
+	if writer == nil {
+		return nil
+	}
+	return writer
+}
+
+// newQueryCache creates the cache of book listings and searches, or returns nil when QUERY_CACHE_TTL is zero
+func newQueryCache(cfg config.QueryCacheConfig) *usecase.QueryCache {
+	if cfg.TTL <= 0 {
+		return nil
+	}
+	return usecase.NewQueryCache(cfg.TTL, cfg.Stale, cfg.MaxEntries)
+}
+
+// newSearchLimiter bounds the expensive book searches each caller runs at once, or returns nil
+// when SEARCH_EXPENSIVE_CONCURRENCY is zero
+func newSearchLimiter(cfg config.SearchConfig) *ratelimit.ConcurrencyLimiter {
+	if cfg.ExpensiveConcurrency <= 0 {
+		return nil
+	}
+	return ratelimit.NewConcurrencyLimiter(cfg.ExpensiveConcurrency, cfg.ExpensiveQueue, cfg.ExpensiveWait)
+}
+
+// searchThrottle returns the middleware throttling expensive book searches, a no-op without a limiter
+func searchThrottle(limiter *ratelimit.ConcurrencyLimiter) gin.HandlerFunc {
+	if limiter == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return middleware.SearchThrottle(limiter, handlers.ExpensiveBookSearch)
+}
+
+// newExpensiveLimiter bounds the expensive operations the server runs at once, or returns nil when
+// EXPENSIVE_CONCURRENCY is zero
+func newExpensiveLimiter(cfg config.ExpensiveConfig) *ratelimit.ConcurrencyLimiter {
+	if cfg.Concurrency <= 0 {
+		return nil
+	}
+	return ratelimit.NewConcurrencyLimiter(cfg.Concurrency, cfg.Queue, cfg.Wait)
+}
+
+// expensiveThrottle returns the middleware throttling the requests expensive picks out, or all of
+// them when it is nil, a no-op without a limiter
+func expensiveThrottle(limiter *ratelimit.ConcurrencyLimiter, expensive func(c *gin.Context) bool) gin.HandlerFunc {
+	if limiter == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+	return middleware.ExpensiveThrottle(limiter, expensive)
+}
+
+// newURLCache creates the cache of processed URLs selected by URL_CACHE_BACKEND, or nil when caching is off
+func newURLCache(cfg config.URLCacheConfig, redisCfg config.RedisConfig) repositories.URLCache {
+	if cfg.TTL <= 0 {
+		return nil
+	}
+	switch cfg.Backend {
+	case "none":
+		return nil
+	case "", "memory":
+		if cfg.MaxEntries <= 0 {
+			return nil
+		}
+		return repository.NewInMemoryURLCache(cfg.MaxEntries)
+	case "redis":
+		client := redis.NewClient(redis.Options{Addr: redisCfg.Addr, Password: redisCfg.Password, DB: redisCfg.DB})
+		// An unreachable Redis only costs cache misses, so it does not stop the server
+		if err := client.Ping(); err != nil {
+			log.Printf("Redis at %s is unreachable, URL results will be recomputed until it is back: %v", redisCfg.Addr, err)
+		}
+		return repository.NewRedisURLCache(client)
+	}
+	log.Fatalf("Invalid URL_CACHE_BACKEND %q, expected memory, redis or none", cfg.Backend)
+	return nil
+}
+
+// newCoverRepository creates the store of cover images selected by COVERS_STORAGE
+func newCoverRepository(db *database.Database, cfg config.CoversConfig) repositories.CoverRepository {
+	switch cfg.Storage {
+	case "", "filesystem":
+		return repository.NewCoverRepository(db.GetDB(), cfg.Dir)
+	}
+	log.Fatalf("Invalid COVERS_STORAGE %q, expected filesystem", cfg.Storage)
+	return nil
+}
+
+// publicConfig picks the settings clients need from the configuration, leaving out anything secret
+func publicConfig(cfg *config.Config) handlers.PublicConfig {
+	tokenMode := cfg.URLGuard.TokenMode
+	if tokenMode == "" {
+		tokenMode = "none"
+	}
+	return handlers.PublicConfig{
+		APIVersion: cfg.API.Version,
+		APIPrefix:  cfg.API.Prefix,
+		Features: handlers.PublicFeatures{
+			APIV2:        cfg.API.V2Enabled,
+			Quota:        cfg.Quota.Enabled,
+			URLTokenMode: tokenMode,
+			Swagger:      cfg.Swagger.Enabled,
+			AdminUI:      cfg.AdminUI.Enabled,
+			Sandbox:      cfg.Sandbox.Enabled,
+		},
+		Pagination:             publicPageSizes(pageLimits(cfg)),
+		LongPollTimeoutSeconds: int(cfg.API.LongPollTimeout.Seconds()),
+		Auth: handlers.PublicAuthPolicy{
+			Password:                      passwordPolicy(cfg.Security),
+			AccessTokenLifetimeSeconds:    int(cfg.Security.AccessTokenLifetime.Seconds()),
+			AccessTokenMaxLifetimeSeconds: int(cfg.Security.AccessTokenMaxLifetime.Seconds()),
+			SessionLifetimeSeconds:        int(cfg.Security.SessionLifetime.Seconds()),
+			MaxSessionsPerUser:            cfg.Security.SessionMaxPerUser,
+		},
+	}
+}
+
+// capabilities reports the optional subsystems of the deployment from the handlers, use cases and
+// clients wired for it, nil ones being turned off
+func capabilities(cfg *config.Config, h *routeHandlers, searchBackend string, loans *usecase.LoanUseCase, dispatcher *notifier.Dispatcher, urls *usecase.URLUseCase, queryCache *usecase.QueryCache, sharedState *redis.Client) handlers.Capabilities {
+	modes := []string{"identity_headers"}
+	if h.sessions != nil {
+		modes = append(modes, "sessions")
+	}
+	if h.accessTokens != nil {
+		modes = append(modes, "access_tokens")
+	}
+	urlCache := "none"
+	if stats := urls.CacheStats(); stats.Enabled {
+		urlCache = stats.Backend
+	}
+	state := "memory"
+	if sharedState != nil {
+		state = "redis"
+	}
+	coverStorage := cfg.Covers.Storage
+	if coverStorage == "" {
+		coverStorage = "filesystem"
+	}
+	channels := dispatcher.Channels()
+	return handlers.Capabilities{
+		Auth: handlers.AuthCapabilities{
+			Modes:                   modes,
+			AdminUI:                 h.adminUI != nil && cfg.AdminUI.Enabled,
+			AdminNetworksRestricted: h.allowlist.Enabled(),
+		},
+		Loans:         h.loan != nil,
+		Reservations:  h.loan != nil && loans.HoldsEnabled(),
+		Webhooks:      slices.Contains(channels, notifier.ChannelWebhook),
+		Notifications: channels,
+		Search: handlers.SearchCapabilities{
+			Backend:   searchBackend,
+			Cached:    queryCache != nil,
+			Throttled: h.searches != nil,
+		},
+		Storage: handlers.StorageCapabilities{
+			Covers:     coverStorage,
+			CoverFetch: cfg.Covers.FetchEnabled,
+			WebDAV:     h.artifacts != nil,
+		},
+		URLCache:   urlCache,
+		State:      state,
+		Enrichment: h.enrichment != nil,
+		Sandbox:    h.sandbox != nil,
+	}
+}
+
+// publicPageSizes lists the page sizes of every paginated listing
+func publicPageSizes(limits middleware.PageLimits) map[string]handlers.PageSizes {
+	sizes := make(map[string]handlers.PageSizes)
+	for _, route := range apiPaginatedRoutes() {
+		listing := limits.For(route.Listing)
+		sizes[route.Listing] = handlers.PageSizes{Default: listing.Default, Max: listing.Max}
+	}
+	return sizes
+}
+
+// pageLimits reads the page sizes of the paginated listings from the configuration, refusing
+// sizes that would let no page or every page through
+func pageLimits(cfg *config.Config) middleware.PageLimits {
+	check := func(name string, sizes config.PageSizeConfig) middleware.PageSizes {
+		if sizes.Default < 1 || sizes.Max < sizes.Default {
+			log.Fatalf("Invalid page sizes of %s: default %d must be at least 1 and at most max %d", name, sizes.Default, sizes.Max)
+		}
+		return middleware.PageSizes{Default: sizes.Default, Max: sizes.Max}
+	}
+	limits := middleware.PageLimits{
+		Default:  check("PAGE_SIZE_DEFAULT and PAGE_SIZE_MAX", config.PageSizeConfig{Default: cfg.API.DefaultPageSize, Max: cfg.API.MaxPageSize}),
+		Listings: make(map[string]middleware.PageSizes, len(cfg.API.PageSizes)),
+	}
+	for listing, sizes := range cfg.API.PageSizes {
+		limits.Listings[listing] = check(listing, sizes)
+	}
+	return limits
+}
+
+// validationProfiles reads the thresholds of the built-in validation of books from the
+// configuration, refusing unknown settings and thresholds no book could meet
+func validationProfiles(cfg config.ValidationConfig) entities.ValidationProfiles {
+	profiles := entities.ValidationProfiles{
+		Default: entities.ValidationProfile{
+			MinYear:        cfg.MinYear,
+			MaxYear:        cfg.MaxYear,
+			ISBNCheck:      cfg.ISBNCheck,
+			MinTitleLength: cfg.MinTitleLength,
+			MaxTitleLength: cfg.MaxTitleLength,
+		},
+		Tenants: make(map[string]entities.ValidationProfile, len(cfg.Tenants)),
+	}
+	if err := profiles.Default.Check(); err != nil {
+		log.Fatalf("Invalid validation settings: %v", err)
+	}
+	for tenant, settings := range cfg.Tenants {
+		profile := profiles.Default
+		for setting, value := range settings {
+			if setting == "isbn_check" {
+				profile.ISBNCheck = value
+				continue
+			}
+			target := map[string]*int{
+				"min_year":         &profile.MinYear,
+				"max_year":         &profile.MaxYear,
+				"min_title_length": &profile.MinTitleLength,
+				"max_title_length": &profile.MaxTitleLength,
+			}[setting]
+			if target == nil {
+				log.Fatalf("Invalid VALIDATION_TENANTS: unknown setting %q of tenant %s", setting, tenant)
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				log.Fatalf("Invalid VALIDATION_TENANTS: %s of tenant %s must be a number", setting, tenant)
+			}
+			*target = n
+		}
+		if err := profile.Check(); err != nil {
+			log.Fatalf("Invalid VALIDATION_TENANTS: tenant %s: %v", tenant, err)
+		}
+		profiles.Tenants[tenant] = profile
+	}
+	return profiles
+}
+
+// fieldNamingPolicy builds the field naming of v2 responses from the configuration, refusing
+// unknown namings
+func fieldNamingPolicy(cfg config.APIConfig) middleware.FieldNamingPolicy {
+	naming, err := middleware.ParseFieldNaming(cfg.V2FieldNaming)
+	if err != nil {
+		log.Fatalf("Invalid API_V2_FIELD_NAMING: %v", err)
+	}
+	policy := middleware.FieldNamingPolicy{Default: naming, Tenants: make(map[string]middleware.FieldNaming, len(cfg.V2FieldNamingTenants))}
+	for tenant, value := range cfg.V2FieldNamingTenants {
+		naming, err := middleware.ParseFieldNaming(value)
+		if err != nil {
+			log.Fatalf("Invalid API_V2_FIELD_NAMING_TENANTS: tenant %s: %v", tenant, err)
+		}
+		policy.Tenants[tenant] = naming
+	}
+	return policy
+}
+
+// selfTestProbes declares the checks of the self-test: the database connection, or connectErr when
+// it could not be opened, the migrations, the cover storage, the shared cache and the servers of
+// the notification channels. What the deployment does not use is skipped.
+func selfTestProbes(cfg *config.Config, db *database.Database, connectErr error, sharedState *redis.Client) []usecase.SelfTestProbe {
+	probes := []usecase.SelfTestProbe{
+		{Name: "database", Check: func(ctx context.Context) (string, error) {
+			if connectErr != nil {
+				return "", connectErr
+			}
+			if err := db.PingContext(ctx); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("connected to %s:%s/%s", cfg.Database.Host, cfg.Database.Port, cfg.Database.Name), nil
+		}},
+		{Name: "migrations", Check: func(ctx context.Context) (string, error) {
+			if connectErr != nil {
+				return "", errors.New("no database connection")
+			}
+			migrations, err := db.Migrations()
+			if err != nil {
+				return "", err
+			}
+			var pending []string
+			for _, migration := range migrations {
+				if !migration.Applied {
+					pending = append(pending, migration.ID)
+				}
+			}
+			if len(pending) > 0 {
+				return "", fmt.Errorf("%d of %d migrations not applied: %s", len(pending), len(migrations), strings.Join(pending, ", "))
+			}
+			return fmt.Sprintf("%d migrations applied", len(migrations)), nil
+		}},
+		{Name: "storage", Check: func(ctx context.Context) (string, error) {
+			// Covers are written like this, through a temporary file in their directory
+			if err := os.MkdirAll(cfg.Covers.Dir, 0o755); err != nil {
+				return "", err
+			}
+			file, err := os.CreateTemp(cfg.Covers.Dir, ".selftest.*.tmp")
+			if err != nil {
+				return "", err
+			}
+			defer os.Remove(file.Name())
+			if _, err := file.WriteString("selftest"); err != nil {
+				file.Close()
+				return "", err
+			}
+			if err := file.Close(); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("cover directory %s is writable", filepath.Clean(cfg.Covers.Dir)), nil
+		}},
+		{Name: "cache", Check: func(ctx context.Context) (string, error) {
+			if sharedState == nil {
+				return "", usecase.SkippedCheck("STATE_BACKEND is memory, nothing is shared")
+			}
+			if err := sharedState.Ping(); err != nil {
+				return "", err
+			}
+			return "redis at " + cfg.Redis.Addr + " answers", nil
+		}},
+	}
+
+	channels := map[string]notifier.Notifier{}
+	for _, channel := range notificationChannels(cfg.Notifications) {
+		channels[channel.Name()] = channel
+	}
+	for _, name := range []string{notifier.ChannelEmail, notifier.ChannelWebhook, notifier.ChannelSlack} {
+		channel, ok := channels[name].(notifier.Checker)
+		probes = append(probes, usecase.SelfTestProbe{Name: name, Check: func(ctx context.Context) (string, error) {
+			if !ok {
+				return "", usecase.SkippedCheck("channel not enabled")
+			}
+			if err := channel.Check(ctx); err != nil {
+				return "", err
+			}
+			return "server reachable", nil
+		}})
+	}
+	return probes
+}
+
+// newSandboxHandler saves the baseline of a sandbox deployment, or returns nil when it is not one
+func newSandboxHandler(sandbox *usecase.SandboxUseCase) *handlers.SandboxHandler {
+	if sandbox == nil {
+		return nil
+	}
+	return handlers.NewSandboxHandler(sandbox)
+}
+
+// newStaticHandler serves the frontend embedded in the binary, or returns nil when it was built
+// without one or FRONTEND_ENABLED is off
+func newStaticHandler(cfg *config.Config) *handlers.StaticHandler {
+	files := web.Files()
+	if files == nil || !cfg.Frontend.Enabled {
+		return nil
+	}
+	log.Println("Serving the embedded frontend under /")
+	return handlers.NewStaticHandler(files, cfg.API.Prefix, "/swagger", "/health", "/readyz", "/admin", artifactsPrefix, opdsPrefix)
+}
+
+// opdsPrefix is where the OPDS catalog is served
+const opdsPrefix = "/opds"
+
+// opdsCacheRules are the cache policies of the OPDS catalog, which is cached like listings; its
+// pages come as OPDS 1.2 or 2.0 by Accept. Search results are not cached.
+func opdsCacheRules(cfg *config.Config) []middleware.CacheRule {
+	catalog := middleware.CachePolicy{MaxAge: cfg.HTTPCache.ListingMaxAge, Vary: []string{"Accept", middleware.TenantHeader}}
+	return []middleware.CacheRule{
+		{Method: http.MethodGet, Route: "", Policy: catalog},
+		{Method: http.MethodGet, Route: "/new", Policy: catalog},
+		{Method: http.MethodGet, Route: "/books", Policy: catalog},
+		{Method: http.MethodGet, Route: "/authors", Policy: catalog},
+		{Method: http.MethodGet, Route: "/author", Policy: catalog},
+		{Method: http.MethodGet, Route: "/opensearch.xml", Policy: middleware.CachePolicy{MaxAge: 5 * time.Minute}},
+	}
+}
+
+// artifactsPrefix is where the storage areas are served over WebDAV
+const artifactsPrefix = "/dav"
+
+// newArtifactHandler serves the storage areas named by ARTIFACTS_AREAS over WebDAV, or returns nil
+// when none are
+func newArtifactHandler(cfg *config.Config) *handlers.ArtifactHandler {
+	if len(cfg.Artifacts.Areas) == 0 {
+		return nil
+	}
+	if cfg.Artifacts.Password == "" {
+		log.Fatal("ARTIFACTS_PASSWORD is required when ARTIFACTS_AREAS is set")
+	}
+	dirs := make(map[string]string, len(cfg.Artifacts.Areas))
+	for _, area := range cfg.Artifacts.Areas {
+		dir, ok := cfg.Storage.Directories[area]
+		if !ok {
+			log.Fatalf("ARTIFACTS_AREAS names %q, which is not an area of STORAGE_DIRECTORIES", area)
+		}
+		dirs[area] = dir
+	}
+	log.Printf("Serving storage areas %s read-only over WebDAV under %s", strings.Join(cfg.Artifacts.Areas, ", "), artifactsPrefix)
+	return handlers.NewArtifactHandler(artifactsPrefix, davfs.New(dirs))
+}
+
+// newSharedState connects to the Redis that instances share their state through when
+// STATE_BACKEND is redis, or returns nil when each instance keeps its own in memory
+func newSharedState(cfg config.StateConfig, redisCfg config.RedisConfig) *redis.Client {
+	switch cfg.Backend {
+	case "", "memory":
+		return nil
+	case "redis":
+		client := redis.NewClient(redis.Options{Addr: redisCfg.Addr, Password: redisCfg.Password, DB: redisCfg.DB})
+		// Every use of the shared state copes with an outage, so an unreachable Redis does not stop the server
+		if err := client.Ping(); err != nil {
+			log.Printf("Redis at %s is unreachable, shared state is unavailable until it is back: %v", redisCfg.Addr, err)
+		}
+		return client
+	}
+	log.Fatalf("Invalid STATE_BACKEND %q, expected memory or redis", cfg.Backend)
+	return nil
+}
+
+// reportSender delivers report subscriptions; email needs the SMTP channel to be enabled,
+// webhooks go to the URL of each subscription
+func reportSender(cfg config.NotificationConfig) *notifier.ReportSender {
+	var email *notifier.EmailNotifier
+	if cfg.EmailEnabled {
+		email = notifier.NewEmailNotifier(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.EmailFrom)
+	}
+	return notifier.NewReportSender(email)
+}
+
+// scheduledJobs declares the recurring background jobs. Each job can be disabled with
+// SCHEDULER_DISABLED_JOBS or given another schedule with SCHEDULER_SCHEDULES.
+func scheduledJobs(cfg config.SchedulerConfig, books *usecase.BookUseCase, subscriptions *usecase.ReportSubscriptionUseCase, popularity *usecase.PopularityUseCase, storage *usecase.StorageUseCase, stats *usecase.StatsUseCase, related *usecase.RelatedBookUseCase, members *usecase.MemberUseCase, loans *usecase.LoanUseCase, urls *usecase.URLUseCase, sandbox *usecase.SandboxUseCase) []scheduler.JobSpec {
+	specs := []scheduler.JobSpec{
+		{
+			Name:        "report_subscriptions",
+			Description: "Deliver the report subscriptions that are due",
+			Schedule:    "* * * * *",
+			Run: func(ctx context.Context) error {
+				ran, err := subscriptions.RunDue(ctx)
+				if ran > 0 {
+					log.Printf("Delivered %d scheduled report(s)", ran)
+				}
+				return err
+			},
+		},
+		{
+			Name:        "scheduled_publication",
+			Description: "Publish the books whose scheduled publication time has passed",
+			Schedule:    "* * * * *",
+			Run: func(ctx context.Context) error {
+				published, err := books.PublishScheduledBooks(time.Now())
+				if published > 0 {
+					log.Printf("Published %d scheduled book(s)", published)
+				}
+				return err
+			},
+		},
+		{
+			Name:        "trash_purge",
+			Description: fmt.Sprintf("Permanently delete books that have been in the trash for more than %d days", cfg.TrashRetentionDays),
+			Schedule:    "0 3 * * *",
+			Priority:    jobs.PriorityBackfill,
+			Run: func(ctx context.Context) error {
+				purged, err := books.PurgeDeletedBooks(time.Now().AddDate(0, 0, -cfg.TrashRetentionDays))
+				if purged > 0 {
+					log.Printf("Purged %d deleted book(s) from the trash", purged)
+				}
+				return err
+			},
+		},
+		{
+			Name:        "member_anonymization",
+			Description: fmt.Sprintf("Anonymize the accounts that have been deactivated for more than %d days", cfg.MemberRetentionDays),
+			Schedule:    "30 3 * * *",
+			Priority:    jobs.PriorityBackfill,
+			Run: func(ctx context.Context) error {
+				anonymized, err := members.AnonymizeDeactivated(time.Now().AddDate(0, 0, -cfg.MemberRetentionDays))
+				if anonymized > 0 {
+					log.Printf("Anonymized %d deactivated account(s)", anonymized)
+				}
+				return err
+			},
+		},
+		{
+			Name:        "loan_archive",
+			Description: fmt.Sprintf("Move the loans returned more than %d days ago to the loan history", cfg.LoanArchiveDays),
+			Schedule:    "45 3 * * *",
+			Priority:    jobs.PriorityBackfill,
+			Run: func(ctx context.Context) error {
+				archived, err := loans.ArchiveLoans(time.Now().AddDate(0, 0, -cfg.LoanArchiveDays))
+				if archived > 0 {
+					log.Printf("Archived %d returned loan(s)", archived)
+				}
+				return err
+			},
+		},
+		{
+			Name:        "loan_due_soon",
+			Description: fmt.Sprintf("Remind members of the loans due in %d days", cfg.LoanDueSoonDays),
+			Schedule:    "0 8 * * *",
+			Priority:    jobs.PriorityBackfill,
+			Run: func(ctx context.Context) error {
+				reminded, err := loans.NotifyDueSoon(cfg.LoanDueSoonDays)
+				if reminded > 0 {
+					log.Printf("Reminded members of %d loan(s) due soon", reminded)
+				}
+				return err
+			},
+		},
+		{
+			Name:        "url_history_retention",
+			Description: fmt.Sprintf("Delete the processed URLs recorded more than %d days ago from the URL history", cfg.URLHistoryRetentionDays),
+			Schedule:    "0 5 * * *",
+			Priority:    jobs.PriorityBackfill,
+			Run: func(ctx context.Context) error {
+				pruned, err := urls.PruneHistory(time.Now().AddDate(0, 0, -cfg.URLHistoryRetentionDays))
+				if pruned > 0 {
+					log.Printf("Pruned %d processed URL(s) from the history", pruned)
+				}
+				return err
+			},
+		},
+		{
+			Name:        "popularity",
+			Description: "Recompute the popularity of books from their recent views and loans",
+			Schedule:    "15 2 * * *",
+			Priority:    jobs.PriorityBackfill,
+			Run: func(ctx context.Context) error {
+				return popularity.Refresh()
+			},
+		},
+		{
+			Name:        "stats_refresh",
+			Description: "Recompute the books per year, loans per month and top authors served by the stats endpoint, and the related books",
+			Schedule:    "*/10 * * * *",
+			Priority:    jobs.PriorityBackfill,
+			Run: func(ctx context.Context) error {
+				if err := stats.Refresh(); err != nil {
+					return err
+				}
+				return related.Refresh()
+			},
+		},
+		{
+			Name:        "storage_monitor",
+			Description: "Measure the disk usage of the database and storage directories and warn about soft quotas crossed",
+			Schedule:    "*/15 * * * *",
+			Run: func(ctx context.Context) error {
+				storage.Check(ctx)
+				return nil
+			},
+		},
+	}
+	if sandbox != nil {
+		specs = append(specs, scheduler.JobSpec{
+			Name:        "sandbox_reset",
+			Description: "Restore the data of the sandbox to its baseline, discarding the changes of the day",
+			Schedule:    "0 4 * * *",
+			Run: func(ctx context.Context) error {
+				tables, saved, err := sandbox.Reset()
+				if err != nil {
+					return err
+				}
+				if saved {
+					log.Printf("Saved the sandbox baseline from %d table(s)", tables)
+				} else {
+					log.Printf("Reset the sandbox, restoring %d table(s)", tables)
+				}
+				return nil
+			},
+		})
+	}
+
+	for i := range specs {
+		specs[i].Enabled = !contains(cfg.DisabledJobs, specs[i].Name)
+		specs[i].Jitter = cfg.Jitter
+		if schedule, ok := cfg.Schedules[specs[i].Name]; ok {
+			specs[i].Schedule = schedule
+		}
+	}
+	return specs
+}
+
+// recordDeadLetter stores the jobs of a queue that failed all their attempts
+func recordDeadLetter(queue string, deadLetters *usecase.DeadLetterUseCase) func(jobs.Failure) {
+	return func(failure jobs.Failure) {
+		err := deadLetters.Record(&entities.DeadLetter{
+			Queue:       queue,
+			Kind:        failure.Kind,
+			Name:        failure.Name,
+			Payload:     string(failure.Payload),
+			Error:       failure.Err.Error(),
+			Attempts:    failure.Attempts,
+			MaxAttempts: failure.MaxAttempts,
+		})
+		if err != nil {
+			log.Printf("Failed to store dead letter for job %s: %v", failure.Name, err)
+		}
+	}
+}
+
+// instanceID names this instance in job locks
+func instanceID(cfg config.SchedulerConfig) string {
+	if cfg.InstanceID != "" {
+		return cfg.InstanceID
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
 }
 
 // corsMiddleware creates CORS middleware
@@ -116,6 +1356,7 @@ func corsMiddleware(cors config.CORSConfig) gin.HandlerFunc {
 
 		c.Header("Access-Control-Allow-Methods", strings.Join(cors.AllowedMethods, ","))
 		c.Header("Access-Control-Allow-Headers", strings.Join(cors.AllowedHeaders, ","))
+		c.Header("Access-Control-Expose-Headers", strings.Join([]string{tracing.HeaderTraceparent, tracing.HeaderTraceID, middleware.DeprecationHeader, middleware.SunsetHeader, middleware.WarningHeader, handlers.NextCursorHeader}, ","))
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(http.StatusOK)
@@ -126,35 +1367,112 @@ func corsMiddleware(cors config.CORSConfig) gin.HandlerFunc {
 	}
 }
 
-// setupRoutes sets up all application routes
-func setupRoutes(router *gin.Engine, cfg *config.Config, bookHandler *handlers.BookHandler, urlHandler *handlers.URLHandler) {
-	// API routes
-	api := router.Group(cfg.API.Prefix)
-	{
-		// Book management routes
-		books := api.Group("/books")
-		{
-			books.GET("", bookHandler.GetBooks)
-			books.POST("", bookHandler.CreateBook)
-			books.GET("/search", bookHandler.SearchBooks)
-			books.GET("/deleted", bookHandler.GetDeletedBooks)
-			books.GET("/:id", bookHandler.GetBook)
-			books.PUT("/:id", bookHandler.UpdateBook)
-			books.DELETE("/:id", bookHandler.DeleteBook)
-			books.POST("/:id/restore", bookHandler.RestoreBook)
-			books.DELETE("/:id/permanent", bookHandler.HardDeleteBook)
-		}
+// routeHandlers groups the HTTP handlers mounted by setupRoutes
+type routeHandlers struct {
+	book         *handlers.BookHandler
+	bookDetail   *handlers.BookDetailHandler
+	url          *handlers.URLHandler
+	sitemap      *handlers.SitemapHandler
+	urlGuard     *handlers.URLGuardHandler
+	bruteForce   *handlers.BruteForceHandler
+	auditWriter  *handlers.AuditWriterHandler
+	repoMetrics  *handlers.RepositoryMetricsHandler
+	readiness    *handlers.ReadinessHandler
+	notification *handlers.NotificationHandler
+	me           *handlers.MeHandler
+	member       *handlers.MemberHandler
+	accessToken  *handlers.AccessTokenHandler
+	session      *handlers.SessionHandler
+	loan         *handlers.LoanHandler
+	timeline     *handlers.TimelineHandler
+	availability *handlers.AvailabilityHandler
+	bundle       *handlers.BundleHandler
+	cover        *handlers.CoverHandler
+	coverImport  *handlers.CoverImportHandler
+	publisher    *handlers.PublisherHandler
+	series       *handlers.SeriesHandler
+	branch       *handlers.BranchHandler
+	calendar     *handlers.CalendarHandler
+	timezone     *handlers.TimezoneHandler
+	enrichment   *handlers.EnrichmentHandler
+	work         *handlers.WorkHandler
+	collection   *handlers.CollectionHandler
+	acquisition  *handlers.AcquisitionHandler
+	report       *handlers.ReportHandler
+	explore      *handlers.ExploreHandler
+	stats        *handlers.StatsHandler
+	relatedBook  *handlers.RelatedBookHandler
+	feed         *handlers.FeedHandler
+	opds         *handlers.OPDSHandler
+	holdings     *handlers.HoldingsHandler
+	inventory    *handlers.InventoryHandler
+	subscription *handlers.ReportSubscriptionHandler
+	job          *handlers.JobHandler
+	workerPool   *handlers.WorkerPoolHandler
+	deadLetter   *handlers.DeadLetterHandler
+	errorCatalog *handlers.ErrorCatalogHandler
+	changelog    *handlers.ChangelogHandler
+	config       *handlers.ConfigHandler
+	// capabilities reports the optional subsystems wired at startup
+	capabilities *handlers.CapabilitiesHandler
+	bootstrap    *handlers.BootstrapHandler
+	setup        *handlers.SetupHandler
+	metadata     *handlers.MetadataHandler
+	schema       *handlers.SchemaHandler
+	validation   *handlers.ValidationRuleHandler
+	imports      *handlers.ImportProfileHandler
+	importRuns   *handlers.ImportRunHandler
+	analytics    *handlers.AnalyticsHandler
+	storage      *handlers.StorageHandler
+	usage        *usecase.UsageUseCase
+	// usageEvents records anonymous usage analytics, nil when they are opted out of
+	usageEvents *repository.AnalyticsCounter
+	// guard protects the URL processor, on every API version alike
+	guard *middleware.URLGuard
+	// searches throttles expensive book searches per caller, nil when unlimited
+	searches *ratelimit.ConcurrencyLimiter
+	// expensive bounds the large imports and reports running server-wide, nil when unlimited
+	expensive *ratelimit.ConcurrencyLimiter
+	// queues are watched for backpressure
+	queues []middleware.QueueDepth
+	// signIn bans the client IPs and accounts that fail to sign in too often
+	signIn *middleware.BruteForceGuard
+	// allowlist turns requests to admin routes and deletions away from outside ADMIN_ALLOWED_CIDRS
+	allowlist *middleware.IPAllowlist
+	// database turns writes away while it is read-only
+	database middleware.WriteStatus
+	// static serves the embedded frontend, nil without one
+	static *handlers.StaticHandler
+	// adminUI serves the HTML admin pages to the admins adminAuth signs in
+	adminUI   *handlers.AdminUIHandler
+	adminAuth *usecase.AdminAuthUseCase
+	// accessTokens signs API requests in with personal access tokens
+	accessTokens *usecase.AccessTokenUseCase
+	// sessions signs API requests in with the sessions users start by signing in
+	sessions *usecase.SessionUseCase
+	// artifacts serves storage areas over WebDAV, nil when ARTIFACTS_AREAS is empty
+	artifacts *handlers.ArtifactHandler
+	// sandbox saves the baseline of a sandbox deployment, nil unless SANDBOX_ENABLED is set
+	sandbox  *handlers.SandboxHandler
+	selfTest *handlers.SelfTestHandler
+}
 
-		// URL processing routes
-		url := api.Group("/url")
-		{
-			url.POST("/process", urlHandler.ProcessURL)
-		}
+// setupRoutes sets up all application routes
+func setupRoutes(router *gin.Engine, cfg *config.Config, h *routeHandlers) {
+	// API routes. v2 shares the routes and use cases of v1 and only changes the response format;
+	// it is served on its own prefix and, on request, through the Accept header. v1 clients can
+	// also ask for the problem+json errors of v2 alone.
+	v1 := router.Group(cfg.API.Prefix, middleware.ProblemDetails(cfg.API.ErrorDocsURL))
+	if cfg.API.V2Enabled {
+		naming := fieldNamingPolicy(cfg.API)
+		v1.Use(middleware.APIV2(cfg.API.ErrorDocsURL, false, naming))
+		mountAPI(router.Group(cfg.API.Prefix+"/v2", middleware.APIV2(cfg.API.ErrorDocsURL, true, naming)), cfg, h)
 	}
+	mountAPI(v1, cfg, h)
 
 	// Swagger documentation
 	if cfg.Swagger.Enabled {
-		router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+		router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.InstanceName(swaggerDocName)))
 	}
 
 	// Health check
@@ -166,6 +1484,685 @@ func setupRoutes(router *gin.Engine, cfg *config.Config, bookHandler *handlers.B
 			"version": cfg.Swagger.Version,
 		})
 	})
+
+	// Readiness probe, failing while the database connection is down
+	router.GET("/readyz", h.readiness.GetReadiness)
+
+	// Server-rendered admin pages
+	if cfg.AdminUI.Enabled {
+		admin := router.Group("/admin", h.allowlist.Handler("/admin"), h.signIn.Handler(middleware.BasicAuthCredentials), middleware.AdminAuth(h.adminAuth))
+		admin.GET("", h.adminUI.Home)
+		admin.GET("/books", h.adminUI.ListBooks)
+		admin.GET("/books/new", h.adminUI.NewBook)
+		admin.POST("/books", h.adminUI.CreateBook)
+		admin.GET("/books/:id/edit", h.adminUI.EditBook)
+		admin.POST("/books/:id", h.adminUI.UpdateBook)
+		admin.POST("/books/:id/delete", h.adminUI.DeleteBook)
+		admin.GET("/trash", h.adminUI.Trash)
+		admin.POST("/trash/:id/restore", h.adminUI.RestoreBook)
+		admin.POST("/trash/:id/purge", h.adminUI.PurgeBook)
+		admin.GET("/migrations", h.adminUI.Migrations)
+	}
+
+	// OPDS catalog for e-reader apps
+	if cfg.OPDS.Enabled {
+		opds := router.Group(opdsPrefix, middleware.CacheControl(opdsPrefix, opdsCacheRules(cfg)))
+		opds.GET("", h.opds.GetRoot)
+		opds.GET("/new", h.opds.GetNewArrivals)
+		opds.GET("/books", h.opds.GetBooks)
+		opds.GET("/authors", h.opds.GetAuthors)
+		opds.GET("/author", h.opds.GetAuthorBooks)
+		opds.GET("/search", h.opds.Search)
+		opds.GET("/opensearch.xml", h.opds.GetOpenSearch)
+	}
+
+	// Read-only WebDAV access to exports, backups and other storage areas
+	if h.artifacts != nil {
+		dav := router.Group(artifactsPrefix, h.allowlist.Handler(artifactsPrefix), h.signIn.Handler(middleware.BasicAuthCredentials),
+			gin.BasicAuthForRealm(gin.Accounts{cfg.Artifacts.Username: cfg.Artifacts.Password}, "Artifacts"))
+		dav.Any("/*path", h.artifacts.ServeDAV)
+		for _, method := range []string{"PROPFIND", "PROPPATCH", "MKCOL", "COPY", "MOVE", "LOCK", "UNLOCK"} {
+			dav.Handle(method, "/*path", h.artifacts.ServeDAV)
+		}
+	}
+
+	// The embedded frontend answers every other path
+	if h.static != nil {
+		router.NoRoute(h.static.Serve)
+	}
+}
+
+// apiDeprecations announces the routes, and fields of their responses, that will change or be
+// removed. Clients are warned in the headers of their responses and in the OpenAPI document.
+func apiDeprecations() []middleware.Deprecation {
+	return []middleware.Deprecation{
+		{
+			Method:  http.MethodGet,
+			Route:   "/books/:id/slug",
+			Since:   time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+			Sunset:  dateOf(2027, time.April, 16),
+			Message: "read the slug of GET /books/{id} instead",
+		},
+	}
+}
+
+// setPriorityWeights gives a job queue the JOBS_PRIORITY_WEIGHTS, exiting when they are invalid
+func setPriorityWeights(queue *jobs.Queue, weights map[string]int64) {
+	byPriority := make(map[jobs.Priority]int, len(weights))
+	for priority, weight := range weights {
+		byPriority[jobs.Priority(priority)] = int(weight)
+	}
+	if err := queue.SetWeights(byPriority); err != nil {
+		log.Fatalf("Invalid JOBS_PRIORITY_WEIGHTS: %v", err)
+	}
+}
+
+// newCursorSigner signs the cursors of paginated listings with CURSOR_SECRET, or JWT_SECRET when
+// it is not set
+func newCursorSigner(cfg config.SecurityConfig) *pagetoken.Signer {
+	if cfg.CursorSecret == "" {
+		return pagetoken.NewSigner(cfg.JWTSecret)
+	}
+	return pagetoken.NewSigner(cfg.CursorSecret)
+}
+
+// duplicateImportAction checks what IMPORT_DUPLICATE_ACTION does with files imported again
+func duplicateImportAction(action string) string {
+	switch action {
+	case usecase.DuplicateImportReject, usecase.DuplicateImportWarn:
+		return action
+	}
+	log.Fatalf("Invalid IMPORT_DUPLICATE_ACTION %q, expected reject or warn", action)
+	return ""
+}
+
+// newUploadScanUseCase scans uploads with the configured scanner, recording them as skipped when
+// UPLOAD_SCANNER is none
+func newUploadScanUseCase(cfg config.UploadScanConfig) *usecase.UploadScanUseCase {
+	var fileScanner usecase.Scanner
+	switch cfg.Scanner {
+	case "none":
+		fileScanner = scanner.NoOp{}
+	case "clamav":
+		fileScanner = scanner.NewClamAV(cfg.ClamAVAddress)
+	default:
+		log.Fatalf("Invalid UPLOAD_SCANNER %q, expected none or clamav", cfg.Scanner)
+	}
+	for name, action := range map[string]string{"UPLOAD_SCAN_ON_INFECTED": cfg.OnInfected, "UPLOAD_SCAN_ON_ERROR": cfg.OnError} {
+		if action != usecase.ScanActionReject && action != usecase.ScanActionAccept {
+			log.Fatalf("Invalid %s %q, expected reject or accept", name, action)
+		}
+	}
+	return usecase.NewUploadScanUseCase(fileScanner, cfg.Timeout, cfg.OnInfected, cfg.OnError)
+}
+
+// storageSources lists the storage areas watched: the database, then the configured directories by name
+func storageSources(db *database.Database, cfg config.StorageConfig) []usecase.StorageSource {
+	sources := []usecase.StorageSource{{Name: "database", Size: db.Size}}
+	names := make([]string, 0, len(cfg.Directories))
+	for name := range cfg.Directories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		dir := cfg.Directories[name]
+		sources = append(sources, usecase.StorageSource{
+			Name: name,
+			Path: dir,
+			Size: func(ctx context.Context) (int64, error) { return diskusage.DirectorySize(ctx, dir) },
+		})
+	}
+	return sources
+}
+
+// storageLimits converts the soft quotas of the storage areas from MiB to bytes
+func storageLimits(cfg config.StorageConfig) map[string]int64 {
+	limits := make(map[string]int64, len(cfg.LimitsMB))
+	for name, mb := range cfg.LimitsMB {
+		limits[name] = mb << 20
+	}
+	return limits
+}
+
+// newCoverFetcher creates the client downloading cover images by URL, within the cover size limit
+// and for the image formats covers can have only
+func newCoverFetcher(cfg config.CoversConfig) *webfetch.Fetcher {
+	fetcher := webfetch.NewFetcher(cfg.MaxBytes, cfg.FetchTimeout, cfg.FetchPrivate)
+	contentTypes := make([]string, len(imaging.Formats))
+	for i, format := range imaging.Formats {
+		contentTypes[i] = format.ContentType()
+	}
+	fetcher.SetContentTypes(contentTypes...)
+	return fetcher
+}
+
+// newEnrichmentUseCase creates the use case of bulk metadata enrichment, with jobs running on the
+// job queue and covers downloaded by coverFetcher; nil when enrichment is disabled
+func newEnrichmentUseCase(cfg *config.Config, jobRepo repositories.EnrichmentJobRepository, bookRepo repositories.BookRepository, bookUseCase *usecase.BookUseCase, coverUseCase *usecase.CoverUseCase, coverFetcher usecase.CoverFetcher, publisherRepo repositories.PublisherRepository, queue *jobs.Queue, bus events.Bus) *usecase.EnrichmentUseCase {
+	if !cfg.Enrichment.Enabled {
+		return nil
+	}
+	provider := openlibrary.NewClient(cfg.Enrichment.ProviderURL, cfg.Enrichment.CoverURL, cfg.Enrichment.Timeout)
+	enrichmentUseCase := usecase.NewEnrichmentUseCase(jobRepo, bookRepo, bookUseCase, coverUseCase, publisherRepo, provider, cfg.Enrichment.RequestInterval, cfg.Enrichment.MaxBooks)
+	enrichmentUseCase.SetCoverFetcher(coverFetcher)
+	enrichmentUseCase.SetRunner(func(ctx context.Context, name string, run func(ctx context.Context) error) error {
+		trace, _ := tracing.FromContext(ctx)
+		return queue.Enqueue(jobs.Job{Name: name, Priority: jobs.PriorityBackfill, Run: run, Trace: trace})
+	})
+	enrichmentUseCase.SetEventBus(bus)
+	return enrichmentUseCase
+}
+
+// newAccessTokenUseCase creates the use case of personal access tokens, whose default lifetime
+// must fit in their longest
+func newAccessTokenUseCase(cfg config.SecurityConfig, tokenRepo repositories.AccessTokenRepository, userRepo repositories.UserRepository) *usecase.AccessTokenUseCase {
+	if cfg.AccessTokenLifetime < 0 || cfg.AccessTokenMaxLifetime < 0 {
+		log.Fatalf("Invalid access token lifetimes: ACCESS_TOKEN_LIFETIME and ACCESS_TOKEN_MAX_LIFETIME cannot be negative")
+	}
+	if cfg.AccessTokenMaxLifetime > 0 && (cfg.AccessTokenLifetime == 0 || cfg.AccessTokenLifetime > cfg.AccessTokenMaxLifetime) {
+		log.Fatalf("Invalid ACCESS_TOKEN_LIFETIME %s: tokens cannot last longer than ACCESS_TOKEN_MAX_LIFETIME %s", cfg.AccessTokenLifetime, cfg.AccessTokenMaxLifetime)
+	}
+	return usecase.NewAccessTokenUseCase(tokenRepo, userRepo, cfg.AccessTokenLifetime, cfg.AccessTokenMaxLifetime)
+}
+
+// newSessionUseCase creates the use case of sign-in sessions, which must expire
+func newSessionUseCase(cfg config.SecurityConfig, sessionRepo repositories.SessionRepository, userRepo repositories.UserRepository) *usecase.SessionUseCase {
+	if cfg.SessionLifetime <= 0 {
+		log.Fatalf("Invalid SESSION_LIFETIME %s: sessions must expire", cfg.SessionLifetime)
+	}
+	if cfg.SessionMaxPerUser < 0 {
+		log.Fatalf("Invalid SESSION_MAX_PER_USER %d: use 0 to leave sessions unlimited", cfg.SessionMaxPerUser)
+	}
+	if cfg.ImpersonationLifetime <= 0 || cfg.ImpersonationLifetime > cfg.SessionLifetime {
+		log.Fatalf("Invalid IMPERSONATION_LIFETIME %s: impersonations must expire, at most after SESSION_LIFETIME %s", cfg.ImpersonationLifetime, cfg.SessionLifetime)
+	}
+	sessionUseCase := usecase.NewSessionUseCase(sessionRepo, userRepo, cfg.SessionLifetime)
+	sessionUseCase.SetMaxPerUser(cfg.SessionMaxPerUser)
+	sessionUseCase.SetImpersonationLifetime(cfg.ImpersonationLifetime)
+	return sessionUseCase
+}
+
+// passwordPolicy reads the policy passwords are checked against, refusing one no password bcrypt
+// hashes in full could meet
+func passwordPolicy(cfg config.SecurityConfig) entities.PasswordPolicy {
+	policy := entities.PasswordPolicy{
+		MinLength:        cfg.PasswordMinLength,
+		RequireUppercase: cfg.PasswordRequireUppercase,
+		RequireLowercase: cfg.PasswordRequireLowercase,
+		RequireDigit:     cfg.PasswordRequireDigit,
+		RequireSymbol:    cfg.PasswordRequireSymbol,
+	}
+	if err := policy.Validate(); err != nil {
+		log.Fatalf("Invalid PASSWORD_MIN_LENGTH %d: %v", cfg.PasswordMinLength, err)
+	}
+	return policy
+}
+
+// circulationShifts parses the desk shifts policy overrides are reported by, ordered by start
+func circulationShifts(cfg config.CirculationConfig) ([]entities.Shift, *time.Location) {
+	location, err := time.LoadLocation(cfg.ShiftTimezone)
+	if err != nil {
+		log.Fatalf("Invalid CIRCULATION_SHIFT_TIMEZONE: %v", err)
+	}
+	shifts := make([]entities.Shift, 0, len(cfg.Shifts))
+	for name, span := range cfg.Shifts {
+		shift, err := entities.ParseShift(name, span)
+		if err != nil {
+			log.Fatalf("Invalid CIRCULATION_SHIFTS: %v", err)
+		}
+		shifts = append(shifts, shift)
+	}
+	sort.Slice(shifts, func(i, j int) bool { return shifts[i].Start < shifts[j].Start })
+	return shifts, location
+}
+
+// circulationTimezone loads the timezone of tenants and branches without one of their own
+func circulationTimezone(cfg config.CirculationConfig) *time.Location {
+	location, err := entities.LoadTimezone(cfg.Timezone)
+	if err != nil {
+		log.Fatalf("Invalid CIRCULATION_TIMEZONE: %v", err)
+	}
+	return location
+}
+
+// dateOf returns midnight UTC of a day, for deprecation sunsets
+func dateOf(year int, month time.Month, day int) *time.Time {
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return &date
+}
+
+// swaggerDocName is the OpenAPI document served at /swagger, with the deprecations added
+const swaggerDocName = "library"
+
+// apiPaginatedRoutes are the routes serving listings one page at a time, whose page sizes the
+// Pagination middleware enforces
+func apiPaginatedRoutes() []middleware.PaginatedRoute {
+	return []middleware.PaginatedRoute{
+		{Method: http.MethodGet, Route: "/books/:id/timeline", Param: "page_size", Listing: "timeline"},
+		{Method: http.MethodGet, Route: "/admin/reports/weeding", Param: "page_size", Listing: "reports"},
+		{Method: http.MethodGet, Route: "/imports", Param: "limit", Listing: "imports"},
+		{Method: http.MethodGet, Route: "/admin/report-subscriptions/:id/runs", Param: "limit", Listing: "report_runs"},
+		{Method: http.MethodGet, Route: "/admin/dead-letters", Param: "limit", Listing: "dead_letters"},
+		{Method: http.MethodGet, Route: "/admin/url-history", Param: "limit", Listing: "url_history"},
+	}
+}
+
+// apiCacheRules are the cache policies of the routes whose responses CDNs may keep; any other
+// response is sent with no-store. Listings vary by the headers that change what they list or how.
+func apiCacheRules(cfg *config.Config) []middleware.CacheRule {
+	listing := middleware.CachePolicy{MaxAge: cfg.HTTPCache.ListingMaxAge, Vary: []string{"Accept", middleware.TenantHeader}}
+	static := middleware.CachePolicy{MaxAge: 5 * time.Minute, Vary: []string{"Accept"}}
+	feed := middleware.CachePolicy{MaxAge: cfg.Feeds.MaxAge, Vary: []string{middleware.TenantHeader}}
+	return []middleware.CacheRule{
+		{Method: http.MethodGet, Route: "/books", Policy: listing},
+		{Method: http.MethodGet, Route: "/books/search", Policy: listing},
+		{Method: http.MethodGet, Route: "/publishers", Policy: listing},
+		{Method: http.MethodGet, Route: "/publishers/:id/imprints", Policy: listing},
+		{Method: http.MethodGet, Route: "/series", Policy: listing},
+		{Method: http.MethodGet, Route: "/works", Policy: listing},
+		{Method: http.MethodGet, Route: "/books/:id/related", Policy: listing},
+		{Method: http.MethodGet, Route: "/books/:id/cover", Policy: middleware.CachePolicy{MaxAge: cfg.HTTPCache.CoverMaxAge}},
+		{Method: http.MethodGet, Route: "/feeds/catalog.json", Policy: feed},
+		{Method: http.MethodGet, Route: "/feeds/catalog.rss", Policy: feed},
+		{Method: http.MethodGet, Route: "/feeds/catalog.atom", Policy: feed},
+		{Method: http.MethodGet, Route: "/availability", Policy: middleware.CachePolicy{MaxAge: cfg.Availability.MaxAge, StaleWhileRevalidate: cfg.Availability.Stale, Vary: []string{middleware.TenantHeader}}},
+		{Method: http.MethodGet, Route: "/config/public", Policy: static},
+		{Method: http.MethodGet, Route: "/capabilities", Policy: static},
+		{Method: http.MethodGet, Route: "/errors", Policy: static},
+		{Method: http.MethodGet, Route: "/changelog", Policy: static},
+	}
+}
+
+// deprecatedSwagger serves the generated OpenAPI document with the apiDeprecations added
+type deprecatedSwagger struct {
+	spec *swag.Spec
+}
+
+// ReadDoc returns the OpenAPI document, unchanged if the deprecations cannot be added
+func (s deprecatedSwagger) ReadDoc() string {
+	doc := s.spec.ReadDoc()
+	annotated, err := middleware.AnnotateOpenAPI([]byte(doc), apiDeprecations())
+	if err != nil {
+		log.Printf("Failed to add the deprecations to the OpenAPI document: %v", err)
+		return doc
+	}
+	return string(annotated)
+}
+
+// mountAPI mounts the API routes on a versioned group
+func mountAPI(api *gin.RouterGroup, cfg *config.Config, h *routeHandlers) {
+	api.Use(middleware.Deprecations(api.BasePath(), apiDeprecations()))
+	api.Use(middleware.Pagination(api.BasePath(), pageLimits(cfg), apiPaginatedRoutes()))
+	api.Use(middleware.CacheControl(api.BasePath(), apiCacheRules(cfg)))
+	// Sessions and personal access tokens sign requests in before anything reads the identity headers
+	api.Use(middleware.Sessions(h.sessions))
+	api.Use(middleware.AccessTokens(h.accessTokens, api.BasePath()))
+	if h.allowlist.Enabled() {
+		// Batch deletions are POSTs, so they are named to be restricted like other deletions
+		api.Use(h.allowlist.Handler(api.BasePath()+"/admin", api.BasePath()+"/books/batch-delete"))
+	}
+	// Contact details of members and fine amounts are only shown to admins and the members themselves
+	api.Use(middleware.Redaction(entities.User{}, entities.Fine{}, entities.MemberFines{}))
+	if h.usageEvents != nil {
+		api.Use(middleware.Analytics(h.usageEvents))
+	}
+	if cfg.Quota.Enabled {
+		api.Use(middleware.Quota(h.usage))
+	}
+	api.Use(middleware.ReadOnly(h.database, api.BasePath()+"/admin/worker-pools", api.BasePath()+"/admin/reports/explore"))
+	if cfg.Jobs.BackpressurePercent > 0 {
+		api.Use(middleware.Backpressure(cfg.Jobs.BackpressurePercent, h.queues, api.BasePath()+"/admin/worker-pools"))
+	}
+	{
+		// Book management routes
+		books := api.Group("/books")
+		{
+			books.GET("", h.book.GetBooks)
+			books.POST("", h.book.CreateBook)
+			books.POST("/import", expensiveThrottle(h.expensive, handlers.LargeBookBatch), h.book.ImportBooks)
+			books.PUT("/upsert", expensiveThrottle(h.expensive, handlers.LargeBookBatch), h.book.UpsertBooks)
+			books.POST("/batch-delete", h.book.BatchDeleteBooks)
+			books.POST("/batch-restore", h.book.BatchRestoreBooks)
+			books.GET("/search", searchThrottle(h.searches), h.book.SearchBooks)
+			books.GET("/lookup", h.book.LookupBook)
+			books.GET("/deleted", h.book.GetDeletedBooks)
+			books.GET("/scheduled", h.book.GetScheduledBooks)
+			books.GET("/by-slug/:slug", h.book.GetBookBySlug)
+			books.GET("/:id", h.book.GetBook)
+			books.GET("/:id/slug", h.book.GetBookSlug)
+			books.GET("/:id/full", h.bookDetail.GetBookDetail)
+			books.GET("/:id/neighbors", searchThrottle(h.searches), h.book.GetBookNeighbors)
+			books.POST("/:id/view", h.book.RecordBookView)
+			books.GET("/:id/timeline", h.timeline.GetTimeline)
+			books.GET("/:id/availability/poll", h.availability.PollAvailability)
+			books.GET("/:id/bundle", h.bundle.GetBundle)
+			books.GET("/:id/related", h.relatedBook.GetRelatedBooks)
+			books.GET("/:id/cover", h.cover.GetCover)
+			books.PUT("/:id/cover", h.cover.UploadCover)
+			books.DELETE("/:id/cover", h.cover.DeleteCover)
+			books.POST("/covers/import", h.coverImport.ImportCovers)
+			books.GET("/covers/import/:id", h.coverImport.GetCoverImport)
+			books.GET("/:id/identifiers", h.book.GetBookIdentifiers)
+			books.POST("/:id/identifiers", h.book.AddBookIdentifier)
+			books.DELETE("/:id/identifiers/:identifierId", h.book.RemoveBookIdentifier)
+			books.GET("/:id/copies", h.book.GetBookCopies)
+			books.POST("/:id/copies", h.book.AddBookCopy)
+			books.PUT("/:id", h.book.UpdateBook)
+			books.PUT("/:id/status", h.book.ChangeBookStatus)
+			books.DELETE("/:id", h.book.DeleteBook)
+			books.POST("/:id/restore", h.book.RestoreBook)
+			books.DELETE("/:id/permanent", h.book.HardDeleteBook)
+			books.GET("/:id/draft", h.book.GetBookDraft)
+			books.PUT("/:id/draft", h.book.SaveBookDraft)
+			books.DELETE("/:id/draft", h.book.DiscardBookDraft)
+			books.POST("/:id/draft/publish", h.book.PublishBookDraft)
+		}
+
+		// Copies of books, looked up by the accession number on their label
+		api.GET("/copies/:accessionNumber", h.book.GetCopy)
+
+		// Feeds of new arrivals other sites embed
+		feeds := api.Group("/feeds")
+		{
+			feeds.GET("/catalog.json", h.feed.GetCatalogJSON)
+			feeds.GET("/catalog.rss", h.feed.GetCatalogRSS)
+			feeds.GET("/catalog.atom", h.feed.GetCatalogAtom)
+		}
+
+		// Availability of batches of ISBNs, polled by discovery services
+		api.GET("/availability", h.holdings.GetAvailability)
+
+		// Publisher and imprint routes
+		publishers := api.Group("/publishers")
+		{
+			publishers.GET("", h.publisher.GetPublishers)
+			publishers.POST("", h.publisher.CreatePublisher)
+			publishers.GET("/:id", h.publisher.GetPublisher)
+			publishers.GET("/:id/imprints", h.publisher.GetImprints)
+			publishers.PUT("/:id", h.publisher.UpdatePublisher)
+			publishers.DELETE("/:id", h.publisher.DeletePublisher)
+		}
+
+		// Series routes
+		series := api.Group("/series")
+		{
+			series.GET("", h.series.GetAllSeries)
+			series.POST("", h.series.CreateSeries)
+			series.GET("/:id", h.series.GetSeries)
+			series.GET("/:id/books", h.series.GetSeriesBooks)
+		}
+
+		// Work and edition routes
+		works := api.Group("/works")
+		{
+			works.GET("", h.work.GetWorks)
+			works.POST("", h.work.CreateWork)
+			works.GET("/:id", h.work.GetWork)
+			works.POST("/:id/editions", h.work.GroupEditions)
+			works.DELETE("/:id/editions/:bookId", h.work.UngroupEdition)
+		}
+
+		// Custom metadata fields of the calling tenant's books
+		metadataFields := api.Group("/metadata-fields")
+		{
+			metadataFields.GET("", h.metadata.GetMetadataFields)
+			metadataFields.PUT("/:key", h.metadata.SaveMetadataField)
+			metadataFields.DELETE("/:key", h.metadata.DeleteMetadataField)
+		}
+
+		// Field-level schemas of entities, for forms built at runtime
+		api.GET("/schema/books", h.schema.GetBookSchema)
+
+		// History of book imports
+		imports := api.Group("/imports")
+		{
+			imports.GET("", h.importRuns.GetImportRuns)
+			imports.GET("/:id", h.importRuns.GetImportRun)
+		}
+
+		// CSV import profiles of the calling tenant
+		importProfiles := api.Group("/import-profiles")
+		{
+			importProfiles.GET("", h.imports.GetImportProfiles)
+			importProfiles.GET("/:name", h.imports.GetImportProfile)
+			importProfiles.PUT("/:name", h.imports.SaveImportProfile)
+			importProfiles.DELETE("/:name", h.imports.DeleteImportProfile)
+		}
+
+		// Collection routes; the shared view is public and read-only
+		collections := api.Group("/collections")
+		{
+			collections.GET("", h.collection.GetCollections)
+			collections.POST("", h.collection.CreateCollection)
+			collections.GET("/:id", h.collection.GetCollection)
+			collections.PUT("/:id", h.collection.UpdateCollection)
+			collections.DELETE("/:id", h.collection.DeleteCollection)
+			collections.POST("/:id/books", h.collection.AddBook)
+			collections.PUT("/:id/books", h.collection.ReorderBooks)
+			collections.DELETE("/:id/books/:bookId", h.collection.RemoveBook)
+			collections.POST("/:id/share", h.collection.ShareCollection)
+			collections.DELETE("/:id/share", h.collection.UnshareCollection)
+		}
+		api.GET("/shared/collections/:token", h.collection.GetSharedCollection)
+
+		// Acquisition routes: member suggestions and the librarians' purchasing queue
+		acquisitions := api.Group("/acquisitions")
+		{
+			acquisitions.GET("", h.acquisition.GetAcquisitions)
+			acquisitions.POST("/suggestions", h.acquisition.SuggestAcquisition)
+			acquisitions.GET("/:id", h.acquisition.GetAcquisition)
+			acquisitions.POST("/:id/approve", h.acquisition.ApproveAcquisition)
+			acquisitions.POST("/:id/reject", h.acquisition.RejectAcquisition)
+			acquisitions.POST("/:id/order", h.acquisition.OrderAcquisition)
+			acquisitions.POST("/:id/receive", h.acquisition.ReceiveAcquisition)
+			acquisitions.POST("/:id/cancel", h.acquisition.CancelAcquisition)
+		}
+
+		// Admin report routes
+		reports := api.Group("/admin/reports")
+		{
+			reports.GET("/weeding", expensiveThrottle(h.expensive, nil), h.report.GetWeedingReport)
+			reports.GET("/explore", h.explore.GetExploreEntities)
+			reports.POST("/explore", expensiveThrottle(h.expensive, nil), h.explore.Explore)
+			reports.GET("/overrides", h.loan.GetOverrideReport)
+			reports.GET("/impersonations", h.session.GetImpersonationReport)
+			reports.GET("/cover-duplicates", expensiveThrottle(h.expensive, nil), h.cover.GetCoverDuplicateReport)
+		}
+
+		// Scheduled report deliveries
+		subscriptions := api.Group("/admin/report-subscriptions")
+		{
+			subscriptions.GET("", h.subscription.GetReportSubscriptions)
+			subscriptions.POST("", h.subscription.CreateReportSubscription)
+			subscriptions.GET("/:id", h.subscription.GetReportSubscription)
+			subscriptions.PUT("/:id", h.subscription.UpdateReportSubscription)
+			subscriptions.DELETE("/:id", h.subscription.DeleteReportSubscription)
+			subscriptions.GET("/:id/runs", h.subscription.GetReportSubscriptionRuns)
+			subscriptions.POST("/:id/run", expensiveThrottle(h.expensive, nil), h.subscription.RunReportSubscription)
+		}
+
+		// Extra checks books must pass, on top of the built-in ones
+		validationRules := api.Group("/admin/validation-rules")
+		{
+			validationRules.GET("", h.validation.GetValidationRules)
+			validationRules.POST("", h.validation.CreateValidationRule)
+			validationRules.POST("/test", h.validation.TestValidationRules)
+			validationRules.GET("/:id", h.validation.GetValidationRule)
+			validationRules.PUT("/:id", h.validation.UpdateValidationRule)
+			validationRules.DELETE("/:id", h.validation.DeleteValidationRule)
+		}
+
+		// Catalog and circulation stats, read from the tables the stats_refresh job recomputes
+		api.GET("/stats", h.stats.GetStats)
+
+		// Recurring background job status
+		api.GET("/admin/jobs", h.job.GetJobs)
+
+		// Post-deploy check of everything the server depends on, as run by --selftest
+		api.GET("/admin/selftest", h.selfTest.GetSelfTest)
+
+		// The dataset sandbox deployments are reset to
+		if h.sandbox != nil {
+			api.POST("/admin/sandbox/baseline", h.sandbox.SaveBaseline)
+		}
+
+		// Background worker pools
+		api.GET("/admin/worker-pools", h.workerPool.GetWorkerPools)
+		api.PUT("/admin/worker-pools/:name", h.workerPool.ResizeWorkerPool)
+
+		// URL processor abuse protection and cache
+		api.GET("/admin/url-guard", h.urlGuard.GetURLGuard)
+		api.GET("/admin/url-cache", h.url.GetCacheStats)
+		api.GET("/admin/url-history", h.url.GetURLHistory)
+
+		// Branches of the library, whose librarians only change the books of their branch
+		branches := api.Group("/admin/branches")
+		{
+			branches.GET("", h.branch.GetBranches)
+			branches.POST("", h.branch.CreateBranch)
+			branches.GET("/:id", h.branch.GetBranch)
+		}
+
+		// Business hours and closed days of the library and its branches, which due dates follow
+		calendar := api.Group("/admin/calendar")
+		{
+			calendar.GET("", h.calendar.GetCalendar)
+			calendar.PUT("/hours", h.calendar.SetBusinessHours)
+			calendar.POST("/closed-days", h.calendar.CreateClosedDay)
+			calendar.DELETE("/closed-days/:id", h.calendar.DeleteClosedDay)
+		}
+
+		// Timezones of tenants and branches, which due dates, stats and report schedules are in
+		timezone := api.Group("/admin/timezone")
+		{
+			timezone.GET("", h.timezone.GetTimezone)
+			timezone.PUT("", h.timezone.SetTimezone)
+		}
+
+		// Descriptions, publishers and covers filled in from the metadata provider
+		if h.enrichment != nil {
+			api.POST("/admin/enrich", h.enrichment.StartEnrichment)
+			api.GET("/admin/enrich/:id", h.enrichment.GetEnrichmentJob)
+		}
+
+		// Client IPs and accounts banned after failing to sign in too often
+		api.GET("/admin/sign-in-bans", h.bruteForce.GetSignInBans)
+		api.DELETE("/admin/sign-in-bans/:key", h.bruteForce.LiftSignInBan)
+
+		// Write-behind buffer of audit entries
+		api.GET("/admin/audit-writer", h.auditWriter.GetAuditWriter)
+
+		// Calls made to the repositories
+		api.GET("/admin/repository-metrics", h.repoMetrics.GetRepositoryMetrics)
+
+		// Disk usage against soft quotas
+		api.GET("/admin/storage", h.storage.GetStorage)
+
+		// Anonymous usage analytics
+		api.GET("/admin/analytics", h.analytics.GetAnalytics)
+
+		// Deactivation, reactivation and erasure of member accounts
+		members := api.Group("/admin/members")
+		{
+			members.POST("/:id/deactivate", h.member.DeactivateMember)
+			members.POST("/:id/reactivate", h.member.ReactivateMember)
+			members.POST("/:id/erase", h.member.EraseMember)
+			members.POST("/:id/impersonate", h.session.ImpersonateMember)
+		}
+		api.DELETE("/admin/impersonations/:id", h.session.EndImpersonation)
+
+		// Background jobs that failed all their attempts
+		deadLetters := api.Group("/admin/dead-letters")
+		{
+			deadLetters.GET("", h.deadLetter.GetDeadLetters)
+			deadLetters.GET("/:id", h.deadLetter.GetDeadLetter)
+			deadLetters.POST("/:id/requeue", h.deadLetter.RequeueDeadLetter)
+			deadLetters.DELETE("/:id", h.deadLetter.DiscardDeadLetter)
+		}
+
+		// Machine-readable catalog of the errors the API returns
+		api.GET("/errors", h.errorCatalog.GetErrors)
+
+		// Machine-readable changes of every API version
+		api.GET("/changelog", h.changelog.GetChangelog)
+
+		// Settings clients need, so they do not hardcode them
+		api.GET("/config/public", h.config.GetPublicConfig)
+		// Optional subsystems of the deployment, so clients adapt at runtime
+		api.GET("/capabilities", h.capabilities.GetCapabilities)
+		// Everything a frontend loads first, in one request
+		api.GET("/bootstrap", h.bootstrap.GetBootstrap)
+
+		// One-time provisioning of fresh deployments, guarded by the setup token
+		setup := api.Group("/setup")
+		{
+			setup.GET("/status", h.setup.GetSetupStatus)
+			setup.POST("/bootstrap", h.signIn.Handler(middleware.HeaderCredentials("X-Setup-Token")), h.setup.Bootstrap)
+		}
+
+		// Inventory routes: shelf audits reconciled with the catalog
+		inventory := api.Group("/inventory/sessions")
+		{
+			inventory.GET("", h.inventory.GetInventorySessions)
+			inventory.POST("", h.inventory.OpenInventorySession)
+			inventory.GET("/:id", h.inventory.GetInventorySession)
+			inventory.POST("/:id/scans", h.inventory.RecordScans)
+			inventory.GET("/:id/report", h.inventory.GetInventoryReport)
+			inventory.POST("/:id/close", h.inventory.CloseInventorySession)
+		}
+
+		// URL processing routes
+		url := api.Group("/url", h.signIn.Handler(middleware.HeaderCredentials(middleware.URLTokenHeader)), h.guard.Handler())
+		{
+			url.POST("/process", h.url.ProcessURL)
+			url.POST("/batch", h.url.ProcessURLs)
+			url.GET("/profiles", h.url.ListProfiles)
+			url.POST("/sitemap", h.sitemap.SubmitSitemap)
+			url.GET("/sitemap/:id", h.sitemap.GetSitemapJob)
+			url.GET("/sitemap/:id/sitemap.xml", h.sitemap.DownloadSitemap)
+		}
+
+		// Circulation routes
+		loans := api.Group("/loans")
+		{
+			loans.POST("", h.loan.CheckoutLoan)
+			loans.POST("/:id/renew", h.loan.RenewLoan)
+			loans.POST("/:id/return", h.loan.ReturnLoan)
+		}
+
+		// Notification center routes
+		notifications := api.Group("/notifications")
+		{
+			notifications.GET("", h.notification.GetNotifications)
+			notifications.GET("/unread-count", h.notification.GetUnreadCount)
+			notifications.POST("/read-all", h.notification.MarkAllAsRead)
+			notifications.POST("/:id/read", h.notification.MarkAsRead)
+		}
+
+		// Sign-in sessions, started with the email and password of a user
+		api.POST("/auth/sessions", h.signIn.Handler(middleware.BasicAuthCredentials), h.session.SignIn)
+
+		// Caller-scoped routes
+		me := api.Group("/me")
+		{
+			me.GET("/usage", h.me.GetUsage)
+			me.GET("/profile", h.member.GetProfile)
+			me.PATCH("/contact", h.member.UpdateContact)
+			me.GET("/loans", h.member.GetLoans)
+			me.POST("/loans/:id/renew", h.member.RenewLoan)
+			me.GET("/holds", h.member.GetHolds)
+			me.GET("/fines", h.member.GetFines)
+			// Admins impersonating the caller cannot hand themselves lasting credentials or close the account
+			notImpersonated := middleware.NotImpersonated()
+			me.POST("/deactivate", notImpersonated, h.member.DeactivateAccount)
+			me.GET("/tokens", h.accessToken.GetAccessTokens)
+			me.POST("/tokens", notImpersonated, h.accessToken.CreateAccessToken)
+			me.DELETE("/tokens/:id", notImpersonated, h.accessToken.RevokeAccessToken)
+			me.GET("/sessions", h.session.GetSessions)
+			me.DELETE("/sessions", notImpersonated, h.session.RevokeOtherSessions)
+			me.DELETE("/sessions/:id", notImpersonated, h.session.RevokeSession)
+		}
+	}
 }
 
 // contains checks if a slice contains a string
@@ -105,6 +105,19 @@ func showHelp() {
 	fmt.Println("  20241201000000_create_books_table")
 	fmt.Println("  20241201000001_add_indexes_to_books")
 	fmt.Println("  20241201000002_add_soft_delete_to_books")
+	fmt.Println("  20261015000000_create_notifications_table")
+	fmt.Println("  20261015000001_create_audit_entries_table")
+	fmt.Println("  20261015000002_create_publishers_table")
+	fmt.Println("  20261015000003_extract_book_publishers")
+	fmt.Println("  20261015000004_create_series_table")
+	fmt.Println("  20261015000005_create_works_table")
+	fmt.Println("  20261015000006_create_collections_tables")
+	fmt.Println("  20261015000007_create_acquisitions_table")
+	fmt.Println("  20261015000008_create_inventory_tables")
+	fmt.Println("  20261015000009_create_report_subscriptions_tables")
+	fmt.Println("  20261015000010_create_job_locks_table")
+	fmt.Println("  20261015000011_create_dead_letters_table")
+	fmt.Println("  20261015000012_create_tenants_users_policies_tables")
 	fmt.Println()
 	fmt.Println("📝 Migration Naming Convention:")
 	fmt.Println("  Format: YYYYMMDDHHMMSS_descriptive_name")
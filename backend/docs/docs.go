@@ -15,9 +15,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/books": {
+        "/acquisitions": {
             "get": {
-                "description": "Retrieve all books from the library. Returns an array of all active (non-deleted) books with their complete information including timestamps.",
+                "description": "Retrieve the acquisitions queue, oldest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,29 +25,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "acquisitions"
+                ],
+                "summary": "List acquisitions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only return acquisitions with this status (suggested, approved, rejected, ordered, received, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all books",
                 "responses": {
                     "200": {
-                        "description": "List of all books retrieved successfully",
+                        "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/entities.Book"
+                                "$ref": "#/definitions/entities.Acquisition"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/acquisitions/suggestions": {
             "post": {
-                "description": "Create a new book in the library. The system will automatically generate a unique ID and set creation/update timestamps. ISBN must be unique and between 10-13 characters.",
+                "description": "Submit a suggestion for the library to acquire a book",
                 "consumes": [
                     "application/json"
                 ],
@@ -55,35 +65,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "acquisitions"
                 ],
-                "summary": "Create a new book",
+                "summary": "Suggest a book",
                 "parameters": [
                     {
-                        "description": "Book information including title, author, year, and ISBN",
-                        "name": "book",
+                        "type": "string",
+                        "description": "Caller ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Suggested book",
+                        "name": "suggestion",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateBookRequest"
+                            "$ref": "#/definitions/handlers.SuggestionRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Book created successfully with generated ID and timestamps",
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/entities.Book"
+                            "$ref": "#/definitions/entities.Acquisition"
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Validation error or ISBN already exists",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -91,9 +102,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/books/search": {
+        "/acquisitions/{id}": {
             "get": {
-                "description": "Search books by title, author, or year. All parameters are optional and can be combined for advanced filtering.",
+                "description": "Retrieve an acquisition and its order status",
                 "consumes": [
                     "application/json"
                 ],
@@ -101,44 +112,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "acquisitions"
                 ],
-                "summary": "Search books",
+                "summary": "Get an acquisition",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search by title (case-insensitive partial match)",
-                        "name": "title",
-                        "in": "query",
-                        "example": "Gatsby"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search by author (case-insensitive partial match)",
-                        "name": "author",
-                        "in": "query",
-                        "example": "Fitzgerald"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Search by exact publication year",
-                        "name": "year",
-                        "in": "query",
-                        "example": 1925
+                        "description": "Acquisition ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Search results returned successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entities.Book"
-                            }
+                            "$ref": "#/definitions/entities.Acquisition"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -152,9 +146,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/books/deleted": {
-            "get": {
-                "description": "Retrieve all soft-deleted books from the library. These books have been marked as deleted but remain in the database with deleted_at timestamps. They can be restored using the restore endpoint.",
+        "/acquisitions/{id}/approve": {
+            "post": {
+                "description": "Accept a suggestion for ordering",
                 "consumes": [
                     "application/json"
                 ],
@@ -162,21 +156,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "acquisitions"
+                ],
+                "summary": "Approve a suggestion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Librarian ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Acquisition ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get deleted books",
                 "responses": {
                     "200": {
-                        "description": "List of deleted books retrieved successfully",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entities.Book"
-                            }
+                            "$ref": "#/definitions/entities.Acquisition"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -184,9 +197,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/books/{id}": {
-            "get": {
-                "description": "Retrieve a specific book by its unique identifier. Returns the complete book information including all timestamps.",
+        "/acquisitions/{id}/cancel": {
+            "post": {
+                "description": "Stop an approved or ordered acquisition",
                 "consumes": [
                     "application/json"
                 ],
@@ -194,42 +207,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "acquisitions"
                 ],
-                "summary": "Get a book by ID",
+                "summary": "Cancel an acquisition",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Unique identifier of the book to retrieve",
+                        "description": "Librarian ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Acquisition ID",
                         "name": "id",
                         "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cancellation reason",
+                        "name": "reason",
+                        "in": "body",
                         "required": true,
-                        "example": "550e8400-e29b-41d4-a716-446655440000"
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AcquisitionReasonRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Book retrieved successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entities.Book"
+                            "$ref": "#/definitions/entities.Acquisition"
                         }
                     },
-                    "404": {
-                        "description": "Not Found - Book with specified ID does not exist",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update an existing book in the library. Only the specified fields will be updated, and the updated_at timestamp will be automatically set. ISBN must remain unique if changed.",
+            }
+        },
+        "/acquisitions/{id}/order": {
+            "post": {
+                "description": "Record that an approved acquisition was ordered from a vendor",
                 "consumes": [
                     "application/json"
                 ],
@@ -237,57 +267,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "acquisitions"
                 ],
-                "summary": "Update a book",
+                "summary": "Order an acquisition",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Unique identifier of the book to update",
+                        "description": "Librarian ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Acquisition ID",
                         "name": "id",
                         "in": "path",
-                        "required": true,
-                        "example": "550e8400-e29b-41d4-a716-446655440000"
+                        "required": true
                     },
                     {
-                        "description": "Updated book information (all fields are required)",
-                        "name": "book",
+                        "description": "Order details",
+                        "name": "order",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.UpdateBookRequest"
+                            "$ref": "#/definitions/handlers.OrderAcquisitionRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Book updated successfully with new updated_at timestamp",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/entities.Book"
+                            "$ref": "#/definitions/entities.Acquisition"
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Validation error or ISBN already exists",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found - Book with specified ID does not exist",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Soft delete a book from the library. The book is marked as deleted but remains in the database with a deleted_at timestamp. It can be restored later using the restore endpoint.",
+            }
+        },
+        "/acquisitions/{id}/receive": {
+            "post": {
+                "description": "Record the arrival of an ordered book and add it to the catalog",
                 "consumes": [
                     "application/json"
                 ],
@@ -295,40 +327,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "acquisitions"
                 ],
-                "summary": "Delete a book (soft delete)",
+                "summary": "Receive an order",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Unique identifier of the book to delete",
+                        "description": "Librarian ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Acquisition ID",
                         "name": "id",
                         "in": "path",
-                        "required": true,
-                        "example": "550e8400-e29b-41d4-a716-446655440000"
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Book soft-deleted successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.MessageResponse"
+                            "$ref": "#/definitions/entities.Acquisition"
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid book ID",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found - Book with specified ID does not exist",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -336,9 +368,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/books/{id}/restore": {
+        "/acquisitions/{id}/reject": {
             "post": {
-                "description": "Restore a soft-deleted book by clearing the deleted_at timestamp. The book becomes active again and can be accessed through normal endpoints.",
+                "description": "Turn a suggestion down with a reason for the member",
                 "consumes": [
                     "application/json"
                 ],
@@ -346,34 +378,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "acquisitions"
                 ],
-                "summary": "Restore a deleted book",
+                "summary": "Reject a suggestion",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Unique identifier of the soft-deleted book to restore",
+                        "description": "Librarian ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Acquisition ID",
                         "name": "id",
                         "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection reason",
+                        "name": "reason",
+                        "in": "body",
                         "required": true,
-                        "example": "550e8400-e29b-41d4-a716-446655440000"
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AcquisitionReasonRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Book restored successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.MessageResponse"
+                            "$ref": "#/definitions/entities.Acquisition"
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid book ID",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -381,9 +428,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/books/{id}/permanent": {
-            "delete": {
-                "description": "Permanently delete a book from the library. This operation cannot be undone and will remove the book completely from the database. Use with caution.",
+        "/admin/analytics": {
+            "get": {
+                "description": "Retrieve how often each endpoint was called and each optional feature was used over the last days, most used first. Counts are anonymous and recorded hourly; recent requests may take a minute to show.",
                 "consumes": [
                     "application/json"
                 ],
@@ -391,37 +438,85 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "books"
+                    "admin"
                 ],
-                "summary": "Permanently delete a book",
+                "summary": "Get usage analytics",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Unique identifier of the book to permanently delete",
-                        "name": "id",
-                        "in": "path",
-                        "required": true,
-                        "example": "550e8400-e29b-41d4-a716-446655440000"
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Number of days, 1 to 365",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Book permanently deleted successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.MessageResponse"
+                            "$ref": "#/definitions/entities.AnalyticsReport"
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid book ID",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found - Book with specified ID does not exist",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/admin/audit-writer": {
+            "get": {
+                "description": "Report how many audit entries wait to be written, how many were written and how many were dropped because the buffer was full or the database kept failing, since the server started",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the audit writer",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.AuditWriterStats"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/branches": {
+            "get": {
+                "description": "Retrieve the branches of the library ordered by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List branches",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.Branch"
+                            }
+                        }
                     },
                     "500": {
                         "description": "Internal Server Error",
@@ -430,11 +525,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/url/process": {
+            },
             "post": {
-                "description": "Process a URL according to the specified operation. Supports three operations: 'canonical' (removes tracking parameters), 'redirection' (follows redirects), and 'all' (combines both operations).",
+                "description": "Create a branch; books and copies may then be given to it, and librarians sending its ID in X-User-Branch can only change its books. Copies of branches with an accession prefix are numbered in a sequence of their own.",
                 "consumes": [
                     "application/json"
                 ],
@@ -442,35 +535,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "url"
+                    "admin"
                 ],
-                "summary": "Process URL",
+                "summary": "Create a branch",
                 "parameters": [
                     {
-                        "description": "URL processing request with URL and operation type",
-                        "name": "request",
+                        "description": "Branch information",
+                        "name": "branch",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entities.URLRequest"
+                            "$ref": "#/definitions/handlers.CreateBranchRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "URL processed successfully",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/entities.URLResponse"
+                            "$ref": "#/definitions/entities.Branch"
                         }
                     },
                     "400": {
-                        "description": "Bad Request - Invalid URL or operation",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -478,9 +565,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
+        "/admin/branches/{id}": {
             "get": {
-                "description": "Health check endpoint to verify service status",
+                "description": "Retrieve a specific branch by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -488,203 +575,13379 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "system"
+                    "admin"
+                ],
+                "summary": "Get a branch by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Branch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Health check",
                 "responses": {
                     "200": {
-                        "description": "Service is healthy",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "status": {
-                                    "type": "string",
-                                    "example": "ok"
-                                },
-                                "service": {
-                                    "type": "string",
-                                    "example": "Library Management System API"
-                                },
-                                "version": {
-                                    "type": "string",
-                                    "example": "1.0"
-                                }
-                            }
+                            "$ref": "#/definitions/entities.Branch"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "entities.Book": {
-            "type": "object",
-            "description": "A book entity representing a book in the library",
-            "properties": {
-                "id": {
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000",
-                    "description": "Unique identifier for the book"
-                },
-                "title": {
-                    "type": "string",
-                    "example": "The Great Gatsby",
-                    "description": "Title of the book"
-                },
-                "author": {
-                    "type": "string",
-                    "example": "F. Scott Fitzgerald",
-                    "description": "Author of the book"
-                },
+        },
+        "/admin/calendar": {
+            "get": {
+                "description": "Retrieve the business hours and closed days due dates follow: those of the library, or with branch_id those of a branch. Branches without hours of their own keep the hours of the library, and close on its closed days as well as their own.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the calendar of the library or a branch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Branch ID; without it the calendar of the whole library",
+                        "name": "branch_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Calendar"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/calendar/closed-days": {
+            "post": {
+                "description": "Close the library, or with branch_id a branch, on a date whatever its hours say. Yearly closed days are holidays falling on the same month and day every year from their date on. Due dates falling on closed days move to the next open day.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Close on a day",
+                "parameters": [
+                    {
+                        "description": "Closed day",
+                        "name": "day",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateClosedDayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ClosedDay"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/calendar/closed-days/{id}": {
+            "delete": {
+                "description": "Delete a closed day. Due dates already moved off it are kept.",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Open again on a closed day",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Closed day ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/calendar/hours": {
+            "put": {
+                "description": "Replace the weekly business hours of the library, or with branch_id of a branch. Weekdays without hours are closed, and due dates falling on them move to the next open day. No hours at all leave a branch with the hours of the library, and the library open every day.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Replace business hours",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Branch ID; without it the hours of the whole library",
+                        "name": "branch_id",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Hours by weekday",
+                        "name": "hours",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetBusinessHoursRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Calendar"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/dead-letters": {
+            "get": {
+                "description": "Retrieve the latest background jobs that failed all their attempts, newest first. When there are more, X-Next-Cursor holds the cursor of the next page, which only continues the listing of the same queue.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List dead letters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Worker pool, e.g. notifications",
+                        "name": "queue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Number of dead letters",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, from X-Next-Cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.DeadLetter"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page; absent on the last page"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/dead-letters/{id}": {
+            "get": {
+                "description": "Retrieve a failed background job with its payload and last error",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a dead letter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dead letter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.DeadLetter"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a failed job for good",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Discard a dead letter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dead letter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/dead-letters/{id}/requeue": {
+            "post": {
+                "description": "Run a failed job again with its original number of attempts; it is removed from the dead letters and comes back if it fails again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Requeue a dead letter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dead letter ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/enrich": {
+            "post": {
+                "description": "Start a job looking up the books missing a description, publisher or cover with the metadata provider, at a limited rate. Their changes are saved as drafts for review, and covers proposed on the job, unless apply is set. Books with a draft already are left alone. The caller, by X-User-ID, is notified of the summary when the job completes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Enrich the metadata of books",
+                "parameters": [
+                    {
+                        "description": "Enrichment options",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.EnrichmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entities.EnrichmentJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/enrich/{id}": {
+            "get": {
+                "description": "Retrieve the status of an enrichment job, with its summary and the books it updated once it completes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get an enrichment job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Enrichment job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.EnrichmentJob"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/impersonations/{id}": {
+            "delete": {
+                "description": "Revoke an active impersonation session before it expires; requests presenting its token are refused from then on",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "End an impersonation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin user ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Impersonation session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Session"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/jobs": {
+            "get": {
+                "description": "Retrieve the recurring background jobs with their schedule, next run and last run status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List scheduled jobs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.ScheduledJob"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/members/{id}/deactivate": {
+            "post": {
+                "description": "Deactivate the account of a member: they can no longer sign in or borrow, their loans and fines are kept, and the account is anonymized after MEMBER_RETENTION_DAYS unless it is reactivated first. Deactivating it again changes nothing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "Deactivate a member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.User"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/members/{id}/erase": {
+            "post": {
+                "description": "Anonymize the account of a member right away on their request to be forgotten, rather than after the retention window; their loans and fines are kept under the anonymized account. Members with books on loan are refused until they return them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "Erase a member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.User"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/members/{id}/impersonate": {
+            "post": {
+                "description": "Start a session for the calling admin to act as a member, such as to reproduce an issue they report. The session token, shown in this response only, is sent as \"Authorization: Bearer \u003ctoken\u003e\" until the session expires after IMPERSONATION_LIFETIME or is ended. Every request changing anything made with it is audited under the member, flagged with the admin, and it cannot create or revoke the member's access tokens or sessions, or deactivate their account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Impersonate a member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin user ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "impersonation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ImpersonateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SessionCreatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/members/{id}/reactivate": {
+            "post": {
+                "description": "Reactivate a deactivated account, so its member can sign in and borrow again. Anonymized accounts cannot be reactivated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "members"
+                ],
+                "summary": "Reactivate a member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.User"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/report-subscriptions": {
+            "get": {
+                "description": "Retrieve all scheduled report deliveries",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "List report subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.ReportSubscription"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Deliver a report by email or webhook on a cron schedule, evaluated in the timezone of the tenant sending X-Tenant-ID, or in the default CIRCULATION_TIMEZONE without it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Subscribe to a report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenant whose timezone the schedule is in",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Subscription",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReportSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ReportSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/report-subscriptions/{id}": {
+            "get": {
+                "description": "Retrieve a scheduled report delivery with its next and last run",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get a report subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ReportSubscription"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the report, delivery and schedule of a subscription; the next run is recomputed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Update a report subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Subscription",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReportSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ReportSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stop a scheduled report delivery and drop its run history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Delete a report subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/report-subscriptions/{id}/run": {
+            "post": {
+                "description": "Deliver a subscription immediately, e.g. to test its target; the schedule is unchanged",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Deliver a report now",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ReportSubscriptionRun"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/report-subscriptions/{id}/runs": {
+            "get": {
+                "description": "Retrieve the latest deliveries of a subscription, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Report subscription history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of runs",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.ReportSubscriptionRun"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reports/cover-duplicates": {
+            "get": {
+                "description": "List the groups of unrelated books whose covers are the same image, or look alike by their perceptual hashes, likely artwork attached to the wrong book. Books all of one work or one series are expected to share artwork and left out. WebP covers, and covers stored before perceptual hashes were computed, are only compared by content.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Duplicate cover report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 6,
+                        "description": "Number of bits of the 64 of perceptual hashes images looking alike may differ by, 0 to 16",
+                        "name": "max_distance",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.CoverDuplicateReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reports/explore": {
+            "get": {
+                "description": "List the entities and fields queries to POST /api/admin/reports/explore can filter, group and aggregate on, with the types of the fields",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "List what the query explorer can report on",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.ExploreEntity"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Count, sum, average or find the bounds of the fields of books, loans or acquisitions, filtered and grouped by other fields. Only the entities and fields of GET /api/admin/reports/explore can be named; eq, ne and in filter every field, gt, gte, lt and lte integer and time fields, and times are RFC 3339. Groups come in ascending order, at most limit of them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Run an ad-hoc report",
+                "parameters": [
+                    {
+                        "description": "Query",
+                        "name": "query",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.ExploreQuery"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ExploreResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reports/impersonations": {
+            "get": {
+                "description": "List the impersonations admins started in the last days, newest first, with the admin, member, reason and expiry of each and its audit entries: its start, every request changing anything made with it, and its end",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Impersonation report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Days covered, 1 to 365",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.Impersonation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reports/overrides": {
+            "get": {
+                "description": "Summarize the circulation policy overrides of an ISO week by block, by shift and by member of staff, with the reason of each",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Weekly policy override report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISO week, e.g. 2024-W03; the current week by default",
+                        "name": "week",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.OverrideReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reports/weeding": {
+            "get": {
+                "description": "List books never loaned or not loaned in the last N years, stalest first, to help retire stale stock. format=csv and format=pdf export every row.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Weeding report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Years without a loan",
+                        "name": "years",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Items per page",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default), csv or pdf",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sort the keys of the JSON report",
+                        "name": "sorted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Timezone (IANA name or UTC offset) to render timestamps in, UTC by default",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.WeedingReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/repository-metrics": {
+            "get": {
+                "description": "Report how many times each repository method was called on this instance since the server started, how many calls returned an error, and how long they took. The statements of every repository are reported by table and operation, as sql.\u003ctable\u003e.\u003coperation\u003e, and the calls of the book repository by method, as books.\u003cMethod\u003e.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get repository metrics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.OperationStats"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/sandbox/baseline": {
+            "post": {
+                "description": "Make the current data of a sandbox deployment the dataset the nightly sandbox_reset job restores. Only served when SANDBOX_ENABLED is set.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Save the sandbox baseline",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.SandboxBaseline"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/selftest": {
+            "get": {
+                "description": "Check the database connection, the migrations applied, the cover storage, the shared cache and the email, webhook and Slack servers, reporting each check as pass, fail or skip (not used by the deployment) with its detail. Answers 503 when any check fails, for post-deploy verification.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Run the self-test",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.SelfTestReport"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/entities.SelfTestReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/sign-in-bans": {
+            "get": {
+                "description": "Report the lockout policy of sign-ins, and the client IPs (ip:...) and accounts (account:...) banned now after failing too often, the longest banned first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List sign-in bans",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.BruteForceStats"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/sign-in-bans/{key}": {
+            "delete": {
+                "description": "Unblock a client IP or account before its ban ends, forgetting its failures and strikes so its next ban is a first one again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Lift a sign-in ban",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Banned key, e.g. ip:203.0.113.9 or account:admin@example.com",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/storage": {
+            "get": {
+                "description": "Measure the disk space used by the database and the configured directories against their soft quotas. An area that cannot be measured reports why in error.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get storage usage",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.StorageReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/timezone": {
+            "get": {
+                "description": "Retrieve the timezone due dates, stats and report schedules are worked out in: that of the branch with branch_id, else that of the tenant, else the default CIRCULATION_TIMEZONE, with where it comes from.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the timezone of a tenant or a branch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID; required without branch_id",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch ID",
+                        "name": "branch_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Timezone"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Set the timezone of the tenant, or with branch_id of a branch, by its IANA name. An empty timezone leaves a branch in the timezone of the tenant, and the tenant in the default one. Loans already made keep their due dates.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Set the timezone of a tenant or a branch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID; required without branch_id",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch ID",
+                        "name": "branch_id",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Timezone",
+                        "name": "timezone",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetTimezoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Timezone"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/url-cache": {
+            "get": {
+                "description": "Report the backend and TTL of the cache of processed URLs, and its hits, misses and errors since the server started",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the URL cache statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.URLCacheStats"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/url-guard": {
+            "get": {
+                "description": "Report the per-IP rate limit and token requirement of /url endpoints, and how many requests they rejected by error code since the server started",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get the URL processor's abuse protection",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/middleware.URLGuardStats"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/url-history": {
+            "get": {
+                "description": "Retrieve the latest URLs processed, newest first, with the operations applied and whether the result came from the cache, filtered by domain, including subdomains, by operation and by UTC dates. When there are more, X-Next-Cursor holds the cursor of the next page, which only continues the listing of the same filters. format=csv exports every matching URL.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List processed URLs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Host of the URLs, e.g. byfood.com, matching its subdomains too",
+                        "name": "domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Operation applied, alone or within a profile, e.g. canonical",
+                        "name": "operation",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day, e.g. 2024-01-01",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day, e.g. 2024-01-31; today by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Number of processed URLs",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, from X-Next-Cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.URLResult"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page; absent on the last page"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/validation-rules": {
+            "get": {
+                "description": "Retrieve all validation rules, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validation"
+                ],
+                "summary": "List validation rules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.ValidationRule"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a check that books must pass when they are created or updated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validation"
+                ],
+                "summary": "Add a validation rule",
+                "parameters": [
+                    {
+                        "description": "Validation rule",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ValidationRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/validation-rules/test": {
+            "post": {
+                "description": "Evaluate the enabled validation rules against a book payload without saving it, listing every rule it fails. The built-in checks of books are not included unless profile=true, which reports the first check of the validation profile of the tenant the book fails as a violation of validation_profile.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validation"
+                ],
+                "summary": "Test a book against the validation rules",
+                "parameters": [
+                    {
+                        "description": "Book payload",
+                        "name": "book",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationRuleTestRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the built-in checks of the validation profile",
+                        "name": "profile",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenant whose validation profile is checked",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationRuleTestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/validation-rules/{id}": {
+            "get": {
+                "description": "Retrieve a validation rule by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validation"
+                ],
+                "summary": "Get a validation rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Validation rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ValidationRule"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the check of a validation rule, or enable or disable it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validation"
+                ],
+                "summary": "Update a validation rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Validation rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Validation rule",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ValidationRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a validation rule; books already saved are not revalidated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "validation"
+                ],
+                "summary": "Delete a validation rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Validation rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/worker-pools": {
+            "get": {
+                "description": "Retrieve the background worker pools with their size and backlog, overall and by job priority",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List worker pools",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.WorkerPool"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/worker-pools/{name}": {
+            "put": {
+                "description": "Change the number of workers of a pool without a restart; no job is dropped and the size goes back to the configured one on restart",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Resize a worker pool",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pool name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New size",
+                        "name": "pool",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResizeWorkerPoolRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.WorkerPool"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions": {
+            "post": {
+                "description": "Sign in with the email and password of an active user, sent as HTTP Basic credentials, and start a session for the device, named by its User-Agent. The session token, shown in this response only, is sent as \"Authorization: Bearer \u003ctoken\u003e\" to sign requests in as the user until the session expires after SESSION_LIFETIME or is revoked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Sign in",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Basic credentials: email and password",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SessionCreatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/availability": {
+            "get": {
+                "description": "Batch lookup for discovery services polling the library: the holding of each ISBN, keyed by the normalized ISBN, or null when the library does not hold it. Its ETag is a hash of the response, so pollers can revalidate with If-None-Match, and any origin may read it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "availability"
+                ],
+                "summary": "Look up the availability of ISBNs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ISBNs separated by commas; the parameter may repeat",
+                        "name": "isbns",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the holdings the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/usecase.Holding"
+                            }
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Hash of the holdings"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The holdings have not changed"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books": {
+            "get": {
+                "description": "Retrieve all books from the library, except those scheduled for later publication",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get all books",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Timezone (IANA name or UTC offset) to render timestamps in",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional field groups, e.g. computed",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to work to return one edition per work",
+                        "name": "collapse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "application/hal+json adds hypermedia links to the books",
+                        "name": "Accept",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.BookResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new book in the library",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Create a new book",
+                "parameters": [
+                    {
+                        "description": "Book information",
+                        "name": "book",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateBookRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional field groups, e.g. computed",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "application/hal+json adds hypermedia links to the books",
+                        "name": "Accept",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/batch-delete": {
+            "post": {
+                "description": "Soft delete up to 1000 books at once, such as those of a bad import. Returns 200 when every book was deleted, else 207 Multi-Status with the status of each: missing and already deleted books get 404, books of another branch than the librarian's 403, and books out on loan 409 book_on_loan. The others are deleted in one transaction, or with atomic=true none is unless all can be. Each deletion is audited.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Delete books by ID",
+                "parameters": [
+                    {
+                        "description": "IDs of the books",
+                        "name": "ids",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookIDBatchRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete none of the books unless every one can be",
+                        "name": "atomic",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookBatchChangeBatchResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookBatchChangeBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/batch-restore": {
+            "post": {
+                "description": "Restore up to 1000 soft-deleted books at once. Returns 200 when every book was restored, else 207 Multi-Status with the status of each: missing books get 404, books that are not deleted 409 book_not_deleted, and books of another branch than the librarian's 403. The others are restored in one transaction, or with atomic=true none is unless all can be. Each restoration is audited.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Restore books by ID",
+                "parameters": [
+                    {
+                        "description": "IDs of the books",
+                        "name": "ids",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookIDBatchRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Restore none of the books unless every one can be",
+                        "name": "atomic",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookBatchChangeBatchResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookBatchChangeBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/by-slug/{slug}": {
+            "get": {
+                "description": "Retrieve a book by the slug of its page URL; deleted books are not found",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get a book by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Timezone (IANA name or UTC offset) to render timestamps in",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional field groups, e.g. computed",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "application/hal+json adds hypermedia links to the books",
+                        "name": "Accept",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Canonical URL of the book, by slug; absent without PUBLIC_BASE_URL"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/covers/import": {
+            "post": {
+                "description": "Start a job setting the covers of books from a ZIP archive of images, each named by the ISBN of its book, in any folder, such as covers/9780141439518.jpg. Send the archive as the request body, or as the file field of a multipart form. Each image is checked and stored like an upload to PUT /books/{id}/cover, its extension standing for its content type, and the job reports on each. Directories, __MACOSX/ and hidden files are skipped.",
+                "consumes": [
+                    "application/zip",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Import book covers from a ZIP archive",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "ZIP archive of cover images",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entities.CoverImportJob"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the job"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/covers/import/{id}": {
+            "get": {
+                "description": "Retrieve the status of a cover import, with the outcome of each image imported so far",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get a cover import",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cover import job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.CoverImportJob"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/deleted": {
+            "get": {
+                "description": "Retrieve all soft-deleted books",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get deleted books",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Timezone (IANA name or UTC offset) to render timestamps in",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional field groups, e.g. computed",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "application/hal+json adds hypermedia links to the books",
+                        "name": "Accept",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.BookResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/import": {
+            "post": {
+                "description": "Create up to 5000 books at once with batch inserts. Invalid books are rejected one by one and the others imported; books whose ISBN is taken are skipped, or overwritten with on_conflict=upsert. A text/csv body is read with the import profile of the tenant named by profile, or with field names as headers. A file imported again within the duplicate window is rejected with 409, or let through with a Warning header. With a malware scanner configured, files it finds malware in or fails to check may be rejected with 422 or 503. With Accept: application/x-ndjson the progress is streamed after each chunk written, ending with the result. The result is 200 when no book was rejected, else 207 Multi-Status, with the status of each book; with atomic=true no book is imported unless all are valid.",
+                "consumes": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Import books in bulk",
+                "parameters": [
+                    {
+                        "description": "Books to import",
+                        "name": "books",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ImportBooksRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tenant ID, required with a profile",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Import profile of a CSV import",
+                        "name": "profile",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "skip or upsert, for a CSV import",
+                        "name": "on_conflict",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Import none of the books unless all are valid",
+                        "name": "atomic",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookImportResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/lookup": {
+            "get": {
+                "description": "Retrieve the book having an identifier of a type, written in any form, or its ISBN with type=isbn. Deleted books are not found.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Look a book up by identifier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "isbn, issn, asin, doi or internal",
+                        "name": "type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Identifier, e.g. 10.1000/182",
+                        "name": "value",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Timezone (IANA name or UTC offset) to render timestamps in",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional field groups, e.g. computed",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "application/hal+json adds hypermedia links to the books",
+                        "name": "Accept",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/scheduled": {
+            "get": {
+                "description": "Retrieve the books waiting for their publication, soonest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get scheduled books",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Timezone (IANA name or UTC offset) to render timestamps in",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional field groups, e.g. computed",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "application/hal+json adds hypermedia links to the books",
+                        "name": "Accept",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.BookResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/search": {
+            "get": {
+                "description": "Search books by title, author, year, publisher (including its imprints), identifier, status or metadata. Drafts are only returned with status=draft. Metadata is filtered with meta.{key}={value} parameters. Title or author terms starting with a wildcard, with several wildcards or longer than 64 characters are expensive: each caller runs a few at once, and more wait briefly or get 429.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Search books",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search by title",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by author",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Search by year",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by publisher ID",
+                        "name": "publisher",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by ISBN or any other identifier, e.g. an ISSN or DOI, written in any form",
+                        "name": "identifier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return books with this status (draft, active, archived)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return books with this metadata value, e.g. meta.shelf_code=A12",
+                        "name": "meta.{key}",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Timezone (IANA name or UTC offset) to render timestamps in",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional field groups, e.g. computed",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to work to return one edition per work",
+                        "name": "collapse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to popularity to order by recent views and loans, most popular first",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "application/hal+json adds hypermedia links to the books",
+                        "name": "Accept",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.BookResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/upsert": {
+            "put": {
+                "description": "Create a book, or overwrite the title, author, year, publisher and metadata of the book having its ISBN, for sync clients that do not know book IDs. A single book returns 201 when created and 200 when updated. An array of up to 1000 books returns 200 when every book was written, else 207 Multi-Status, with the status of each; invalid books are rejected one by one and the others written all or nothing, or with atomic=true none is written unless all can be. Books with a base_updated_at that changed on the server since then are resolved with conflict_policy.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Create or update books by ISBN",
+                "parameters": [
+                    {
+                        "description": "Book, or an array of books",
+                        "name": "book",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpsertBookRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "client-wins (default), server-wins or merge",
+                        "name": "conflict_policy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Write none of a batch unless every book can be written",
+                        "name": "atomic",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookUpsertBatchResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookUpsertBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}": {
+            "get": {
+                "description": "Retrieve a specific book by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get a book by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Timezone (IANA name or UTC offset) to render timestamps in",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional field groups, e.g. computed",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default), or dc for a Dublin Core record, in XML unless Accept asks for application/json",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "application/hal+json adds hypermedia links to the books",
+                        "name": "Accept",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookResponse"
+                        },
+                        "headers": {
+                            "Link": {
+                                "type": "string",
+                                "description": "Canonical URL of the book, by slug; absent without PUBLIC_BASE_URL"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing book in the library",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Update a book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated book information",
+                        "name": "book",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateBookRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Optional field groups, e.g. computed",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "application/hal+json adds hypermedia links to the books",
+                        "name": "Accept",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft delete a book from the library",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Delete a book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/availability/poll": {
+            "get": {
+                "description": "Long poll for clients that cannot use streaming: the request is held until the availability differs from the one the client knows, or the timeout elapses",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Wait for a book's availability to change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Availability known to the client; without it the poll waits for the next change",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Seconds to wait, capped by the server",
+                        "name": "timeout",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AvailabilityPoll"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/bundle": {
+            "get": {
+                "description": "Download a zip archive with the book record, its history, its cover image if it has one and a manifest. sorted=true sorts the keys of its JSON documents, so that bundles can be diffed. format=dc downloads only a Dublin Core record of the book instead, in XML unless Accept asks for application/json.",
+                "produces": [
+                    "application/zip",
+                    "text/xml"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Export a book bundle",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sort the keys of the JSON documents",
+                        "name": "sorted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "zip (default) or dc",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/copies": {
+            "get": {
+                "description": "Retrieve the physical copies of a book by accession number",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "List the copies of a book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.BookCopy"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a physical copy of a book, numbered with the next accession number of its branch: the branch's accession prefix, else ACCESSION_PREFIX, followed by a number padded to ACCESSION_DIGITS. Librarians of a branch can only add copies to theirs.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Add a copy of a book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Branch of the copy",
+                        "name": "copy",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AddBookCopyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.BookCopy"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/cover": {
+            "get": {
+                "description": "Download the cover image of a book. Its ETag is the content hash of the image, so clients can revalidate with If-None-Match.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif",
+                    "image/webp"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get a book cover",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Set the cover image of a book, replacing its current one. Send the image as the request body, or as the file field of a multipart form, or send a JSON body with the url of the image for the server to download it, within the same size limit, accepting image content types only and refusing private addresses. The format is read from the magic bytes, and a Content-Type naming another image format is refused. Dimensions are checked from the header before the image is decoded, and metadata such as EXIF is stripped before storage. With a malware scanner configured, the scan result is recorded on the image, and images it finds malware in or fails to check may be rejected. Images are stored once per content hash, so books uploading identical artwork share it; references tells how many books use the image.",
+                "consumes": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif",
+                    "image/webp",
+                    "multipart/form-data",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Upload a book cover",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Cover image",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "description": "URL of the cover image",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CoverURLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.BookCover"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove the cover of a book. Its image is deleted unless other books use it too.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Delete a book cover",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/draft": {
+            "get": {
+                "description": "Retrieve the draft of a book with its changes to the live record, each as from and to values",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get a book draft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.BookDraft"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Store proposed changes to a book without touching the live record, replacing the book's previous draft. Drafts are validated like updates.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Save a book draft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Proposed book information",
+                        "name": "draft",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateBookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.BookDraft"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete the draft of a book, leaving the live record as it is",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Discard a book draft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/draft/publish": {
+            "post": {
+                "description": "Apply the draft of a book to the live record and delete the draft, atomically. Drafts of books updated since the draft was saved are refused with 409.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Publish a book draft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/full": {
+            "get": {
+                "description": "Return a book with its authors, categories, publisher, series, identifiers, copies and availability in one response, which a book page would otherwise load with several requests",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get the detail of a book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Timezone (IANA name or UTC offset) to render the timestamps of the book in",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/identifiers": {
+            "get": {
+                "description": "Retrieve the identifiers of a book besides its ISBN, such as ISSNs, ASINs, DOIs and internal codes, by type and value",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "List the identifiers of a book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.BookIdentifier"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Give a book an ISSN, ASIN, DOI or internal code. Values are stored normalized: ISSNs as 1234-567X, ASINs upper case, DOIs lower case without their doi.org link or doi: prefix. A value of a type identifies one book.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Add an identifier to a book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Identifier",
+                        "name": "identifier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AddBookIdentifierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.BookIdentifier"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/identifiers/{identifierId}": {
+            "delete": {
+                "description": "Remove an identifier from a book, freeing its value for other books",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Remove an identifier from a book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Identifier ID",
+                        "name": "identifierId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/neighbors": {
+            "get": {
+                "description": "Return the IDs of the books listed right before and after a book, and its position, so detail pages can link to the previous and next book without loading the listing. The search parameters, sort and collapse are those of GET /books/search; without search parameters the books are ordered like GET /books.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get the books around a book in a listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by title",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by author",
+                        "name": "author",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Search by year",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by publisher ID",
+                        "name": "publisher",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by ISBN or any other identifier",
+                        "name": "identifier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list books with this status (draft, active, archived)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list books with this metadata value",
+                        "name": "meta.{key}",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to work to list one edition per work",
+                        "name": "collapse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to popularity to order by recent views and loans, most popular first",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.BookNeighbors"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/permanent": {
+            "delete": {
+                "description": "Permanently delete a book from the library",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Permanently delete a book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/related": {
+            "get": {
+                "description": "Retrieve the books related to a book, ranked by score. Each signal adds its weight to the score: the same author, the same series, the same category metadata, and every member who borrowed both books. The ranking is recomputed by the stats_refresh job rather than live; refreshed_at tells when, also sent as Last-Modified, and books deleted or unlisted since are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get related books",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RelatedBooksResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/restore": {
+            "post": {
+                "description": "Restore a soft-deleted book",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Restore a deleted book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/slug": {
+            "get": {
+                "description": "Retrieve the slug naming a book in page URLs. Slugs are derived from the title: lowercase, transliterated to ASCII and made unique with a numeric suffix.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get the slug of a book",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookSlugResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/status": {
+            "put": {
+                "description": "Move a book to another status. Drafts can be made active, active books archived and archived books active again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/hal+json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Change the status of a book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ChangeBookStatusRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "application/hal+json adds hypermedia links to the books",
+                        "name": "Accept",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/timeline": {
+            "get": {
+                "description": "Retrieve the book's activity stream (audit history and loans) newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get a book's timeline",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.TimelinePage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/books/{id}/view": {
+            "post": {
+                "description": "Count a view of a book page for its popularity, for clients that show a book without reading it through the API. A visitor's views of a book are counted once per POPULARITY_VIEW_DEDUP_WINDOW; visitors are told apart by X-User-ID, else X-Session-ID, else IP, and are not stored.",
+                "tags": [
+                    "books"
+                ],
+                "summary": "Count a view of a book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Browser session the view belongs to",
+                        "name": "X-Session-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "View recorded"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bootstrap": {
+            "get": {
+                "description": "Return in one response the public configuration with its feature flags, the caller and their unread notification count, which a frontend would otherwise load with GET /config/public, GET /me/profile and GET /notifications/unread-count. Anonymous callers get a null user and count.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "config"
+                ],
+                "summary": "Bootstrap a frontend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BootstrapResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/capabilities": {
+            "get": {
+                "description": "Report which optional subsystems this deployment runs, as wired at startup: how callers sign in, loans and reservations, notification channels and webhooks, the search backend, cover storage, the URL cache, shared state, enrichment and sandbox mode",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "config"
+                ],
+                "summary": "Get the capabilities of the deployment",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Capabilities"
+                        }
+                    }
+                }
+            }
+        },
+        "/changelog": {
+            "get": {
+                "description": "Retrieve the changes of every API version, newest first, so integrators can check what changed between the versions they run against. With since, only the versions after it are listed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "changelog"
+                ],
+                "summary": "Get the API changelog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only list the versions after this one, e.g. 1.0",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ChangelogResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections": {
+            "get": {
+                "description": "Retrieve the caller's collections, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "List collections",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Caller ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.Collection"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create an empty collection owned by the caller",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Create a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Caller ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Collection information",
+                        "name": "collection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CollectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}": {
+            "get": {
+                "description": "Retrieve one of the caller's collections with its books in order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Get a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Caller ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Change the title and description of one of the caller's collections",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Update a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Caller ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Collection information",
+                        "name": "collection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CollectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete one of the caller's collections; its books stay in the catalog",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Delete a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Caller ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/books": {
+            "put": {
+                "description": "Put the books of one of the caller's collections in the given order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Reorder the books of a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Caller ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Every book of the collection in its new order",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReorderCollectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Insert a book at a position of one of the caller's collections, or append it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Add a book to a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Caller ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Book to add",
+                        "name": "book",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AddCollectionBookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/books/{bookId}": {
+            "delete": {
+                "description": "Remove a book from one of the caller's collections",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Remove a book from a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Caller ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "bookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CollectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/share": {
+            "post": {
+                "description": "Give one of the caller's collections a share token exposing a read-only view at /shared/collections/{token}",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Share a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Caller ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Revoke the share token of one of the caller's collections",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Stop sharing a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Caller ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Collection ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Collection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/config/public": {
+            "get": {
+                "description": "Retrieve the settings clients need, such as the API version, enabled features, page sizes, supported locales and the password, token and session policy, so they do not duplicate the server's configuration",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "config"
+                ],
+                "summary": "Get the public configuration",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublicConfig"
+                        }
+                    }
+                }
+            }
+        },
+        "/copies/{accessionNumber}": {
+            "get": {
+                "description": "Retrieve the copy with an accession number, e.g. scanned from its label, and its book",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Look a copy up by accession number",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Accession number",
+                        "name": "accessionNumber",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BookCopyLookupResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/errors": {
+            "get": {
+                "description": "Retrieve the catalog of domain errors so clients can map error responses to stable codes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "errors"
+                ],
+                "summary": "List error codes",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ErrorCode"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/catalog.atom": {
+            "get": {
+                "description": "Retrieve the books most recently added to the catalog, newest first, as an Atom feed. Covers are enclosure links of their entries. Any origin may read the feed, which clients can revalidate with If-Modified-Since.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Get the new arrivals as Atom",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of books, FEEDS_ITEMS by default and at most FEEDS_MAX_ITEMS",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/catalog.json": {
+            "get": {
+                "description": "Retrieve the books most recently added to the catalog, newest first, as a JSON Feed for other sites to embed. Each item links to the page of the book and to its cover, and carries the book as the API lists it under _book. Any origin may read the feed. Last-Modified is when the latest book of the feed changed, so clients can revalidate with If-Modified-Since.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Get the new arrivals as JSON Feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of books, FEEDS_ITEMS by default and at most FEEDS_MAX_ITEMS",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.JSONFeed"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/catalog.rss": {
+            "get": {
+                "description": "Retrieve the books most recently added to the catalog, newest first, as an RSS 2.0 feed. Covers are enclosures of their items. Any origin may read the feed, which clients can revalidate with If-Modified-Since.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "feeds"
+                ],
+                "summary": "Get the new arrivals as RSS",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of books, FEEDS_ITEMS by default and at most FEEDS_MAX_ITEMS",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/import-profiles": {
+            "get": {
+                "description": "Retrieve the CSV import profiles the tenant saved, ordered by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import-profiles"
+                ],
+                "summary": "Get import profiles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.ImportProfile"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/import-profiles/{name}": {
+            "get": {
+                "description": "Retrieve a CSV import profile of the tenant by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import-profiles"
+                ],
+                "summary": "Get an import profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Profile name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ImportProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Create or replace a named CSV column mapping of the tenant, selected with POST /books/import?profile={name}",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import-profiles"
+                ],
+                "summary": "Save an import profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Profile name, e.g. publisher-feed",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Column mapping",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SaveImportProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ImportProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a CSV import profile of the tenant",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import-profiles"
+                ],
+                "summary": "Delete an import profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Profile name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports": {
+            "get": {
+                "description": "Retrieve the latest book imports with the hash of their file and what they did, newest first. With a tenant, only its imports are listed. When there are more runs, X-Next-Cursor holds the cursor of the next page, which only continues the listing of the same tenant.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "List import runs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Number of runs",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, from X-Next-Cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.ImportRun"
+                            }
+                        },
+                        "headers": {
+                            "X-Next-Cursor": {
+                                "type": "string",
+                                "description": "Cursor of the next page; absent on the last page"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/{id}": {
+            "get": {
+                "description": "Retrieve a book import with the books it rejected, or the error that failed it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "books"
+                ],
+                "summary": "Get an import run",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import run ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.ImportRun"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/sessions": {
+            "get": {
+                "description": "Retrieve all inventory sessions, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "List inventory sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.InventorySession"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Start a shelf audit; scans can be sent over several visits until the session is closed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Open an inventory session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Librarian ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Session details",
+                        "name": "session",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OpenInventorySessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.InventorySession"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/sessions/{id}": {
+            "get": {
+                "description": "Retrieve an inventory session and its status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Get an inventory session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.InventorySession"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/sessions/{id}/close": {
+            "post": {
+                "description": "Stop accepting scans and store the reconciliation report of missing, misplaced and unexpected books",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Close an inventory session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.InventoryReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/sessions/{id}/report": {
+            "get": {
+                "description": "Retrieve the stored report of a closed session, or a preview of an open one. format=pdf returns a printable report.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Get a reconciliation report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or pdf",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sort the keys of the JSON report",
+                        "name": "sorted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Timezone (IANA name or UTC offset) to render timestamps in, UTC by default",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.InventoryReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/inventory/sessions/{id}/scans": {
+            "post": {
+                "description": "Add a batch of barcodes scanned at a location to an open session",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inventory"
+                ],
+                "summary": "Record scans",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Scanned barcodes",
+                        "name": "scans",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ScanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ScanBatchResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/loans": {
+            "post": {
+                "description": "Lend a book to a member for the loan period of the tenant. Checkout is denied with 409 once the member reaches the loan limit or while other members hold the book, unless staff give an override_reason; overrides are recorded in the audit trail.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loans"
+                ],
+                "summary": "Lend a book",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant whose circulation policies apply",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Member, book and optional override reason",
+                        "name": "loan",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CheckoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Loan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/loans/{id}/renew": {
+            "post": {
+                "description": "Push the due date of a loan back by the loan period of the tenant and notify the member. Renewal is denied with 409 past the renewal limit, while other members hold the book, or once the loan is returned; staff may renew past the first two with an override_reason, which is recorded in the audit trail.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loans"
+                ],
+                "summary": "Renew a loan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Loan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional override reason",
+                        "name": "renewal",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RenewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Loan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/loans/{id}/return": {
+            "post": {
+                "description": "Record that a lent book came back. The copy is kept for the next member waiting for the book, whose hold becomes ready, and the member is notified. A loan already returned is refused with 409.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "loans"
+                ],
+                "summary": "Return a loan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Loan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Loan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/contact": {
+            "patch": {
+                "description": "Change the caller's name, email or phone; omitted fields are kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Update my contact details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Contact details",
+                        "name": "contact",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ContactRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/deactivate": {
+            "post": {
+                "description": "Deactivate the caller's account: they can no longer sign in or borrow, their loans and fines are kept, and the account is anonymized after MEMBER_RETENTION_DAYS unless an admin reactivates it first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Deactivate my account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/fines": {
+            "get": {
+                "description": "Retrieve the fines charged to the caller, newest first, with the unpaid total",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "List my fines",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.MemberFines"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/holds": {
+            "get": {
+                "description": "Retrieve the caller's waiting and ready holds with their place in the queue and when a copy should come back for them, estimated from the due dates of the current loans of the book",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "List my holds",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.HoldPosition"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/loans": {
+            "get": {
+                "description": "Retrieve the books the caller has out on loan, soonest due first. With from or to, retrieve every loan the caller borrowed between those UTC dates instead, returned or not, most recently borrowed first, including loans moved to the history.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "List my loans",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day of the history, e.g. 2024-01-01; the first loan by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of the history, e.g. 2024-12-31; today by default",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.Loan"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/loans/{id}/renew": {
+            "post": {
+                "description": "Push the due date of one of the caller's loans back by the loan period. Loans cannot be renewed past the renewal limit of the tenant, nor while other members hold the book.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Renew a loan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Loan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Loan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/profile": {
+            "get": {
+                "description": "Retrieve the caller's account and contact details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Get my profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/sessions": {
+            "get": {
+                "description": "List the caller's active sessions, last seen first, with the device and IP each was last seen from. The session the request was signed in with is marked current.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "List my sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.SessionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Sign out every active session of the caller but the one the request was signed in with; requests not signed in with a session sign out all of them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Revoke my other sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RevokedSessionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/sessions/{id}": {
+            "delete": {
+                "description": "Sign out one of the caller's active sessions, such as a lost device, or the current one; requests presenting its token are refused from then on",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Revoke a session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Session"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/tokens": {
+            "get": {
+                "description": "List the caller's personal access tokens, newest first, revoked and expired ones included. The tokens themselves are not shown, only their hint.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "List my access tokens",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.AccessToken"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Issue a personal access token to the caller, sent as \"Authorization: Bearer \u003ctoken\u003e\" to sign requests in as the caller. The token only reaches the routes of its scopes: books:read and books:write for books and their copies, url:process for the URL processor, and admin:*, which only admins can grant, for every route. It expires after expires_in_days, at most ACCESS_TOKEN_MAX_LIFETIME. The token is shown in this response only.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Create an access token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Token",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateAccessTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AccessTokenCreatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/tokens/{id}": {
+            "delete": {
+                "description": "Revoke one of the caller's access tokens for good; requests presenting it are refused from then on",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Revoke an access token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.AccessToken"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/usage": {
+            "get": {
+                "description": "Report the caller's request consumption against its monthly quota. Requests signed in with an access token are metered against the token, those signed in with a session against the tenant of their user, others against their client IP.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "me"
+                ],
+                "summary": "Get API usage",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Usage"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metadata-fields": {
+            "get": {
+                "description": "Retrieve the custom metadata fields the tenant defined for its books, ordered by key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metadata"
+                ],
+                "summary": "Get metadata fields",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.MetadataField"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metadata-fields/{key}": {
+            "put": {
+                "description": "Create or replace a custom metadata field of the tenant. Once a tenant has fields, books saved for it may only carry those keys.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metadata"
+                ],
+                "summary": "Define a metadata field",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Metadata key, e.g. shelf_code",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Field definition",
+                        "name": "field",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SaveMetadataFieldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.MetadataField"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a custom metadata field of the tenant. Books keep the values they have.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "metadata"
+                ],
+                "summary": "Delete a metadata field",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID",
+                        "name": "X-Tenant-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Metadata key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "description": "Retrieve the caller's notifications, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "List notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Caller ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.Notification"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/read-all": {
+            "post": {
+                "description": "Mark every notification of the caller as read",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark all notifications as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Caller ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/unread-count": {
+            "get": {
+                "description": "Retrieve the number of unread notifications of the caller",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Count unread notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Caller ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UnreadCountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/read": {
+            "post": {
+                "description": "Mark one of the caller's notifications as read",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark a notification as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Caller ID",
+                        "name": "X-User-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/publishers": {
+            "get": {
+                "description": "Retrieve all publishers and imprints ordered by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Get all publishers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.Publisher"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a publisher, or an imprint of an existing publisher when parent_id is set",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Create a publisher",
+                "parameters": [
+                    {
+                        "description": "Publisher information",
+                        "name": "publisher",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublisherRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Publisher"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/publishers/{id}": {
+            "get": {
+                "description": "Retrieve a specific publisher by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Get a publisher by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Publisher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Publisher"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Rename a publisher or move it under another parent; omit parent_id to make it top-level",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Update a publisher",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Publisher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated publisher information",
+                        "name": "publisher",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublisherRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Publisher"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a publisher that has no imprints and no books",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "Delete a publisher",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Publisher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/publishers/{id}/imprints": {
+            "get": {
+                "description": "Retrieve the direct imprints of a publisher",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "publishers"
+                ],
+                "summary": "List imprints",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Publisher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.Publisher"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Report whether the server can take traffic: 200 while the database connection is up, and with status read_only while the database refuses writes but still serves reads, 503 while it is down or the server is shutting down, along with the connection state as last checked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Check readiness",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schema/books": {
+            "get": {
+                "description": "Describe the fields of books so forms and filters can be built without hardcoding them: their types, whether they are required, read-only or unique, the bounds of the built-in validation, the enabled validation rules checking them, and the search parameters filtering on them. With a tenant, the metadata fields it defined are described too.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "schema"
+                ],
+                "summary": "Get the schema of books",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tenant ID, to describe its metadata fields",
+                        "name": "X-Tenant-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.EntitySchema"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/series": {
+            "get": {
+                "description": "Retrieve all book series ordered by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Get all series",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.Series"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a book series",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Create a series",
+                "parameters": [
+                    {
+                        "description": "Series information",
+                        "name": "series",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateSeriesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Series"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/series/{id}": {
+            "get": {
+                "description": "Retrieve a specific series by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "Get a series by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Series"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/series/{id}/books": {
+            "get": {
+                "description": "Retrieve the books of a series in the series' ordering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "series"
+                ],
+                "summary": "List the books of a series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.BookResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/setup/bootstrap": {
+            "post": {
+                "description": "Create the first tenant, its admin user and the default policies. Requires the configured setup token and only succeeds once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "setup"
+                ],
+                "summary": "Bootstrap the deployment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Setup token",
+                        "name": "X-Setup-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "First tenant and admin",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.BootstrapRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/entities.Bootstrap"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/setup/status": {
+            "get": {
+                "description": "Report whether the deployment has been bootstrapped, so provisioning tools can skip the bootstrap call",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "setup"
+                ],
+                "summary": "Get setup status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetupStatus"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shared/collections/{token}": {
+            "get": {
+                "description": "Retrieve the read-only view of a collection through its share token; no caller ID is needed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "View a shared collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SharedCollectionResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "Retrieve the books per publication year, the loans per month and the most borrowed authors. They are read from tables the stats_refresh job recomputes, not live: refreshed_at tells when the least recently refreshed table was refreshed, also sent as Last-Modified, and stale is set when that is longer ago than STATS_MAX_AGE or a table was never refreshed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get library stats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.LibraryStats"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/url/batch": {
+            "post": {
+                "description": "Process up to 100 URLs as /url/process does, each with its own operation, profile and options. Returns 200 when every URL was processed, else 207 Multi-Status with the status of each; with atomic=true a URL failing drops the others, which are neither returned nor recorded in the history. The batch counts once against the rate limit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url"
+                ],
+                "summary": "Process URLs in a batch",
+                "parameters": [
+                    {
+                        "description": "URL processing requests",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProcessURLsRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return none of the URLs unless all are processed",
+                        "name": "atomic",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.URLBatchResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.URLBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/url/process": {
+            "post": {
+                "description": "Process a URL according to the specified operation (canonical, redirection, all, strip_tracking, strip_fragment, https or sort_query), or the operations of a profile, or else the default operation of the server when it has one. URLs without a scheme are taken for https ones, or rejected in strict mode. options tune canonical processing, with default ports, duplicate slashes, dot-segments and the root path, and how lowercasing treats percent-encoded characters of paths",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url"
+                ],
+                "summary": "Process URL",
+                "parameters": [
+                    {
+                        "description": "URL processing request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entities.URLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.URLResponse"
+                        },
+                        "headers": {
+                            "X-Cache": {
+                                "type": "string",
+                                "description": "HIT when the result came from the cache, MISS otherwise; absent without a cache"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/url/profiles": {
+            "get": {
+                "description": "List the named bundles of operations that can be given as profile to URL processing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url"
+                ],
+                "summary": "List URL profiles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/usecase.URLProfile"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/url/sitemap": {
+            "post": {
+                "description": "Start a job processing every entry of a sitemap (or sitemap index, optionally gzipped) with an operation or profile, the seo profile by default. Send JSON with the sitemap URL, or a multipart form with the sitemap as file. The rewritten sitemap is downloaded from the job once it completes.",
+                "consumes": [
+                    "application/json",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url"
+                ],
+                "summary": "Canonicalize a sitemap",
+                "parameters": [
+                    {
+                        "description": "Sitemap to fetch",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SitemapSubmission"
+                        }
+                    },
+                    {
+                        "type": "file",
+                        "description": "Sitemap to upload",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Operation applied to every entry",
+                        "name": "operation",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Profile applied to every entry",
+                        "name": "profile",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/entities.SitemapJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/url/sitemap/{id}": {
+            "get": {
+                "description": "Retrieve the status of a sitemap job, with its entry counts and failed entries once it completes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url"
+                ],
+                "summary": "Get a sitemap job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sitemap job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/entities.SitemapJob"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/url/sitemap/{id}/sitemap.xml": {
+            "get": {
+                "description": "Download the sitemap of a completed job, with its entries processed and everything else unchanged",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "url"
+                ],
+                "summary": "Download a rewritten sitemap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sitemap job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/works": {
+            "get": {
+                "description": "Retrieve all works ordered by title",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "works"
+                ],
+                "summary": "Get all works",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entities.Work"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a work, grouping the given books under it as editions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "works"
+                ],
+                "summary": "Create a work",
+                "parameters": [
+                    {
+                        "description": "Work information",
+                        "name": "work",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateWorkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WorkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/works/{id}": {
+            "get": {
+                "description": "Retrieve a work with its editions, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "works"
+                ],
+                "summary": "Get a work by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Work ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WorkResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/works/{id}/editions": {
+            "post": {
+                "description": "Add books to a work as editions; books of another work are moved over",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "works"
+                ],
+                "summary": "Group editions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Work ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Books to group",
+                        "name": "editions",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GroupEditionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WorkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/works/{id}/editions/{bookId}": {
+            "delete": {
+                "description": "Remove a book from a work",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "works"
+                ],
+                "summary": "Ungroup an edition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Work ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Book ID",
+                        "name": "bookId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WorkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "changelog.Change": {
+            "type": "object",
+            "properties": {
+                "routes": {
+                    "description": "Routes are the routes affected, as METHOD /path under the API prefix",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "changelog.Version": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/changelog.Change"
+                    }
+                },
+                "date": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.AccessToken": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt is nil for tokens that never expire",
+                    "type": "string"
+                },
+                "hint": {
+                    "description": "Hint is the start of the token, for telling tokens apart",
+                    "type": "string",
+                    "example": "lms_pat_3f2a"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "description": "LastUsedAt is updated at most once a minute",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Catalog sync script"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.Acquisition": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "book_id": {
+                    "description": "BookID is the catalog book the acquisition was received as",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isbn": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "ordered_at": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Reason explains a rejection or cancellation",
+                    "type": "string"
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "description": "ReviewedBy is the librarian who last moved the acquisition along",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "suggested_by": {
+                    "description": "SuggestedBy is the member who submitted the suggestion",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vendor": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.AnalyticsCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.AnalyticsReport": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "Enabled is false when ANALYTICS_ENABLED opted out; events recorded before are still reported",
+                    "type": "boolean"
+                },
+                "endpoints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.AnalyticsCount"
+                    }
+                },
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.AnalyticsCount"
+                    }
+                },
+                "requests": {
+                    "type": "integer"
+                },
+                "since": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "impersonator_id": {
+                    "description": "ImpersonatorID flags the changes an admin made while impersonating the actor",
+                    "type": "string"
+                }
+            }
+        },
+        "entities.AuditWriterStats": {
+            "type": "object",
+            "properties": {
+                "buffered": {
+                    "type": "integer"
+                },
+                "capacity": {
+                    "type": "integer"
+                },
+                "dropped_failed": {
+                    "description": "DroppedFailed is the number of entries dropped after every attempt to write their batch failed",
+                    "type": "integer"
+                },
+                "dropped_full": {
+                    "description": "DroppedFull is the number of entries dropped because the buffer was full",
+                    "type": "integer"
+                },
+                "enabled": {
+                    "description": "Enabled is false when audit entries are written during requests",
+                    "type": "boolean"
+                },
+                "failed_writes": {
+                    "description": "FailedWrites is the number of batch writes that failed, retried or not",
+                    "type": "integer"
+                },
+                "written": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.BookCopy": {
+            "type": "object",
+            "properties": {
+                "accession_number": {
+                    "description": "AccessionNumber is the prefix of the branch or library followed by the next number of its\nsequence, e.g. ACC-000042",
+                    "type": "string",
+                    "example": "ACC-000042"
+                },
+                "book_id": {
+                    "type": "string"
+                },
+                "branch_id": {
+                    "description": "BranchID is the branch holding the copy, empty for copies of the whole library",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.BookCover": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "description": "ContentType and Size describe the image, which References books share",
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "perceptual_hash": {
+                    "type": "string"
+                },
+                "references": {
+                    "type": "integer"
+                },
+                "scan": {
+                    "description": "Scan is the malware scan of the image",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entities.ScanResult"
+                        }
+                    ]
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.BookDraft": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "base_updated_at": {
+                    "description": "BaseUpdatedAt is when the book had last been updated as the draft was saved; drafts of\nbooks that changed since cannot be published",
+                    "type": "string"
+                },
+                "book_id": {
+                    "type": "string"
+                },
+                "changes": {
+                    "description": "Changes is the difference with the live book, filled in when the draft is read",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "isbn": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "publisher_id": {
+                    "type": "string"
+                },
+                "series_id": {
+                    "type": "string"
+                },
+                "series_position": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.BookIdentifier": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "doi"
+                },
+                "value": {
+                    "description": "Value is stored normalized, see NormalizeIdentifier",
+                    "type": "string",
+                    "example": "10.1000/182"
+                }
+            }
+        },
+        "entities.BooksPerYear": {
+            "type": "object",
+            "properties": {
+                "books": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.Bootstrap": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "$ref": "#/definitions/entities.User"
+                },
+                "policies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Policy"
+                    }
+                },
+                "tenant": {
+                    "$ref": "#/definitions/entities.Tenant"
+                }
+            }
+        },
+        "entities.Branch": {
+            "type": "object",
+            "properties": {
+                "accession_prefix": {
+                    "description": "AccessionPrefix starts the accession numbers of the branch's copies, which then have a\nsequence of their own; empty when they share the library's",
+                    "type": "string",
+                    "example": "HB-"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Timezone is the IANA timezone of the branch, empty when it is in the timezone of its tenant",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.BusinessHours": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "description": "BranchID is the branch the hours are of, nil for the hours of the whole library",
+                    "type": "string"
+                },
+                "closes": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "opens": {
+                    "description": "Opens and Closes are HH:MM",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "description": "Weekday is the lowercase English name of the day, e.g. monday",
+                    "type": "string"
+                }
+            }
+        },
+        "entities.Calendar": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "type": "string"
+                },
+                "closed_days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.ClosedDay"
+                    }
+                },
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.BusinessHours"
+                    }
+                }
+            }
+        },
+        "entities.ClosedDay": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "description": "BranchID is the branch that closes, nil when the whole library does",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "description": "Date is YYYY-MM-DD",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "yearly": {
+                    "description": "Yearly closed days are holidays falling on the same month and day every year; the year of\ntheir date is when they were first observed",
+                    "type": "boolean"
+                }
+            }
+        },
+        "entities.Collection": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "string"
+                },
+                "share_token": {
+                    "description": "ShareToken exposes a read-only view of the collection while it is set",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.CoverDuplicateBook": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "book_id": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "isbn": {
+                    "type": "string"
+                },
+                "perceptual_hash": {
+                    "type": "string",
+                    "example": "c4d2e8f0b1a39587"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.CoverDuplicateGroup": {
+            "type": "object",
+            "properties": {
+                "books": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.CoverDuplicateBook"
+                    }
+                },
+                "distance": {
+                    "description": "Distance is the largest number of bits the perceptual hashes of the group differ by",
+                    "type": "integer",
+                    "example": 3
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "similar"
+                }
+            }
+        },
+        "entities.CoverDuplicateReport": {
+            "type": "object",
+            "properties": {
+                "covers": {
+                    "description": "Covers counts the books with a cover compared, and Unhashed those compared by content only",
+                    "type": "integer",
+                    "example": 1250
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.CoverDuplicateGroup"
+                    }
+                },
+                "max_distance": {
+                    "description": "MaxDistance is the number of bits perceptual hashes may differ by for images to look alike",
+                    "type": "integer",
+                    "example": 6
+                },
+                "unhashed": {
+                    "type": "integer",
+                    "example": 40
+                }
+            }
+        },
+        "entities.CoverImportFile": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "code": {
+                    "description": "Code is the error code of failed images, when it is in the error catalog",
+                    "type": "string",
+                    "example": "malformed_cover"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "file": {
+                    "description": "File is the path of the image in the archive",
+                    "type": "string",
+                    "example": "covers/978-0-14-143951-8.jpg"
+                },
+                "isbn": {
+                    "description": "ISBN is read from the file name, normalized",
+                    "type": "string",
+                    "example": "9780141439518"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "imported"
+                }
+            }
+        },
+        "entities.CoverImportJob": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "files": {
+                    "description": "Files counts the images in the archive, Imported those set as covers and Failed the others",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "requested_by": {
+                    "type": "string"
+                },
+                "results": {
+                    "description": "Results reports on each image, in the order of the archive, once the job has run",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.CoverImportFile"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.DatabaseHealth": {
+            "type": "object",
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "failures": {
+                    "description": "Failures is the number of checks in a row that failed",
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "reconnects": {
+                    "description": "Reconnects is the number of times the connection came back after it was lost",
+                    "type": "integer"
+                },
+                "since": {
+                    "description": "Since is when the connection entered its state",
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.DeadLetter": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failed_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Kind selects the handler that runs the job from its payload",
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "Payload is the JSON input of the job",
+                    "type": "string"
+                },
+                "queue": {
+                    "description": "Queue is the worker pool the job ran on, e.g. notifications",
+                    "type": "string"
+                }
+            }
+        },
+        "entities.EnrichmentFailure": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.EnrichmentJob": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "integer"
+                },
+                "apply": {
+                    "description": "Apply updates the books; otherwise their changes are saved as drafts and covers only proposed",
+                    "type": "boolean"
+                },
+                "candidates": {
+                    "description": "Candidates counts the books missing a description, publisher or cover that were looked up",
+                    "type": "integer"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "drafted": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failures": {
+                    "description": "Failures lists the books that could not be enriched, up to a limit",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.EnrichmentFailure"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "not_found": {
+                    "description": "NotFound counts the books the provider does not know, Unchanged those it knew nothing new of",
+                    "type": "integer"
+                },
+                "requested_by": {
+                    "description": "RequestedBy is the user notified of the summary when the job completes",
+                    "type": "string"
+                },
+                "skipped": {
+                    "description": "Skipped counts the books left alone because they already have a draft",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updates": {
+                    "description": "Updates lists the books given new fields, up to a limit",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.EnrichmentUpdate"
+                    }
+                }
+            }
+        },
+        "entities.EnrichmentUpdate": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "cover_url": {
+                    "description": "CoverURL is the cover proposed for a book whose changes were drafted, drafts having no cover",
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "entities.EntitySchema": {
+            "type": "object",
+            "properties": {
+                "entity": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.FieldSchema"
+                    }
+                },
+                "metadata": {
+                    "description": "Metadata are the custom fields the tenant defined for the metadata object",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.FieldSchema"
+                    }
+                },
+                "sorts": {
+                    "description": "Sorts are the orders search results can be put in with the sort parameter",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "entities.ExploreAggregate": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "func": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ExploreEntity": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.ExploreField"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ExploreField": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type is string, integer, boolean or time; month fields are strings formatted as 2006-01",
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ExploreFilter": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "op": {
+                    "type": "string"
+                },
+                "value": {}
+            }
+        },
+        "entities.ExploreQuery": {
+            "type": "object",
+            "properties": {
+                "aggregates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.ExploreAggregate"
+                    }
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "filters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.ExploreFilter"
+                    }
+                },
+                "group_by": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.ExploreResult": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "description": "Columns are the group-by fields followed by the aggregates",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {}
+                    }
+                },
+                "truncated": {
+                    "description": "Truncated is set when more groups than the limit matched",
+                    "type": "boolean"
+                }
+            }
+        },
+        "entities.FieldRule": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Message is the error books failing the rule get",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.FieldSchema": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "enum": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "filter": {
+                    "description": "Filter is the query parameter of searches filtering on the field",
+                    "type": "string"
+                },
+                "filterable": {
+                    "type": "boolean"
+                },
+                "format": {
+                    "description": "Format refines the type, e.g. uuid or date-time",
+                    "type": "string"
+                },
+                "max_length": {
+                    "type": "integer"
+                },
+                "maximum": {
+                    "type": "integer"
+                },
+                "min_length": {
+                    "type": "integer"
+                },
+                "minimum": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nullable": {
+                    "type": "boolean"
+                },
+                "read_only": {
+                    "description": "ReadOnly fields are set by the server and ignored when sent",
+                    "type": "boolean"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "rules": {
+                    "description": "Rules are the enabled validation rules checking the field, some only in a category",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.FieldRule"
+                    }
+                },
+                "sortable": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "unique": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "entities.Fine": {
+            "type": "object",
+            "properties": {
+                "amount_cents": {
+                    "description": "AmountCents is the amount in the smallest unit of the library's currency",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "loan_id": {
+                    "description": "LoanID is the loan the fine was charged for, if any",
+                    "type": "string"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.HoldPosition": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "estimated_available_at": {
+                    "description": "EstimatedAvailableAt is when a copy should come back for the hold, going by the due dates of\nthe loans of the book. It is empty for ready holds, and when no copy is out on loan.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "queue_position": {
+                    "description": "QueuePosition is 1 for the next member to get a copy back, and 0 once the hold is ready",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.Impersonation": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "description": "Actions are the audit entries of the changes made during the impersonation, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.AuditEntry"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "device": {
+                    "description": "Device is the User-Agent the session was signed in with",
+                    "type": "string",
+                    "example": "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_5) Firefox/128.0"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "impersonation_reason": {
+                    "description": "ImpersonationReason is why the admin impersonates the user, such as the issue to reproduce",
+                    "type": "string",
+                    "example": "Reproduce ticket 4821: renewals refused"
+                },
+                "impersonator_id": {
+                    "description": "ImpersonatorID is the admin who started the session to act as its user, nil for sessions the\nuser signed in to themselves",
+                    "type": "string"
+                },
+                "ip": {
+                    "description": "IP is the client IP the session was last seen from",
+                    "type": "string",
+                    "example": "203.0.113.9"
+                },
+                "last_seen_at": {
+                    "description": "LastSeenAt is updated at most once a minute",
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ImportProfile": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "description": "Columns maps the CSV header of each imported column to a book field; other columns are ignored",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date_format": {
+                    "description": "DateFormat is the Go time layout of the year column, e.g. 02/01/2006, when the feed has\ndates rather than years; empty reads plain years",
+                    "type": "string"
+                },
+                "delimiter": {
+                    "description": "Delimiter separates the columns, a comma when empty",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ImportRun": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "duplicate_of": {
+                    "description": "DuplicateOf is the earlier run of the same file, when a duplicate was let through with a warning",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Error is why a failed run imported nothing, or stopped partway",
+                    "type": "string"
+                },
+                "errors": {
+                    "description": "Errors lists the books a completed run rejected",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.ImportRunError"
+                    }
+                },
+                "file_hash": {
+                    "description": "FileHash is the hex SHA-256 of the request body",
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "profile": {
+                    "description": "Profile is the import profile a CSV file was read with",
+                    "type": "string"
+                },
+                "received": {
+                    "description": "Received, Imported, Skipped and Rejected count the books of a completed run",
+                    "type": "integer"
+                },
+                "rejected": {
+                    "type": "integer"
+                },
+                "scan": {
+                    "description": "Scan is the malware scan of the file, when it was scanned",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entities.ScanResult"
+                        }
+                    ]
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "description": "TenantID is the tenant the file was imported for, empty without one",
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ImportRunError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "index": {
+                    "description": "Index is the position of the book in the file, from 0",
+                    "type": "integer"
+                },
+                "isbn": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.InventoryItem": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "book_id": {
+                    "type": "string"
+                },
+                "expected_location": {
+                    "type": "string"
+                },
+                "found_location": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.InventoryReport": {
+            "type": "object",
+            "properties": {
+                "final": {
+                    "description": "Final is false for previews of sessions that are still open",
+                    "type": "boolean"
+                },
+                "found": {
+                    "type": "integer"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "generated_at_iso_week": {
+                    "description": "GeneratedAtISOWeek is the ISO 8601 week of the report in the timezone it was asked in,\nfilled in by the API and not stored",
+                    "type": "string"
+                },
+                "misplaced": {
+                    "description": "Misplaced books were found somewhere other than where the previous audit found them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.InventoryItem"
+                    }
+                },
+                "missing": {
+                    "description": "Missing books are in the catalog but were not scanned",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.InventoryItem"
+                    }
+                },
+                "scanned": {
+                    "type": "integer"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "unexpected": {
+                    "description": "Unexpected barcodes do not belong to any book of the catalog",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.InventoryItem"
+                    }
+                }
+            }
+        },
+        "entities.InventorySession": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opened_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.JobPriorityStats": {
+            "type": "object",
+            "properties": {
+                "avg_wait_ms": {
+                    "description": "AvgWaitMs and MaxWaitMs are how long attempts waited for a worker, in milliseconds",
+                    "type": "integer"
+                },
+                "enqueued": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "max_wait_ms": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "description": "Priority is interactive, scheduled or backfill",
+                    "type": "string"
+                },
+                "queued": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "type": "integer"
+                },
+                "succeeded": {
+                    "description": "Succeeded and Failed count the jobs done, failed ones after all their attempts",
+                    "type": "integer"
+                },
+                "weight": {
+                    "description": "Weight is the share of the workers the priority gets while other priorities wait too",
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.LibraryStats": {
+            "type": "object",
+            "properties": {
+                "books_per_year": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.BooksPerYear"
+                    }
+                },
+                "loans_per_month": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.LoansPerMonth"
+                    }
+                },
+                "refreshed_at": {
+                    "description": "RefreshedAt is when the least recently refreshed table was refreshed, nil until every\ntable has been refreshed once",
+                    "type": "string"
+                },
+                "refreshes": {
+                    "description": "Refreshes tells when each table was last refreshed",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.StatsRefresh"
+                    }
+                },
+                "stale": {
+                    "description": "Stale is set when a table was not refreshed within the maximum age of the stats",
+                    "type": "boolean"
+                },
+                "top_authors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.TopAuthor"
+                    }
+                }
+            }
+        },
+        "entities.Loan": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "borrowed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "renewals": {
+                    "description": "Renewals counts how many times the due date was pushed back",
+                    "type": "integer"
+                },
+                "returned_at": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.LoansPerMonth": {
+            "type": "object",
+            "properties": {
+                "loans": {
+                    "type": "integer"
+                },
+                "month": {
+                    "type": "string"
+                },
+                "returned": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.MemberFines": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.Fine"
+                    }
+                },
+                "outstanding_cents": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.MetadataField": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "label": {
+                    "description": "Label is how the field is shown to librarians",
+                    "type": "string"
+                },
+                "required": {
+                    "description": "Required fields must be present on every book saved for the tenant",
+                    "type": "boolean"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.Notification": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "recipient_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.OperationStats": {
+            "type": "object",
+            "properties": {
+                "avg_ms": {
+                    "type": "number"
+                },
+                "calls": {
+                    "type": "integer"
+                },
+                "error_rate": {
+                    "description": "ErrorRate is errors over calls",
+                    "type": "number"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "max_ms": {
+                    "type": "number"
+                },
+                "name": {
+                    "description": "Name is the operation, e.g. books.GetByID",
+                    "type": "string",
+                    "example": "books.GetByID"
+                },
+                "total_ms": {
+                    "description": "TotalMs, AvgMs and MaxMs are the total, average and longest duration of the calls in\nmilliseconds",
+                    "type": "number"
+                }
+            }
+        },
+        "entities.OverrideReport": {
+            "type": "object",
+            "properties": {
+                "by_block": {
+                    "description": "ByBlock and ByShift count the overrides by block and by shift",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "by_shift": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "by_staff": {
+                    "description": "ByStaff counts the overrides of each member of staff, the most first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.StaffOverrides"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "overrides": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.PolicyOverride"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "week": {
+                    "description": "Week is the ISO week of the report, e.g. 2024-W03",
+                    "type": "string"
+                }
+            }
+        },
+        "entities.PasswordPolicy": {
+            "type": "object",
+            "properties": {
+                "min_length": {
+                    "description": "example: 12",
+                    "type": "integer"
+                },
+                "require_digit": {
+                    "type": "boolean"
+                },
+                "require_lowercase": {
+                    "type": "boolean"
+                },
+                "require_symbol": {
+                    "description": "Symbols are any character that is not a letter, a digit or a space",
+                    "type": "boolean"
+                },
+                "require_uppercase": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "entities.Policy": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.PolicyOverride": {
+            "type": "object",
+            "properties": {
+                "block": {
+                    "description": "Block is the code of the error the policy would have answered, e.g. loan_limit_reached",
+                    "type": "string"
+                },
+                "book_id": {
+                    "type": "string"
+                },
+                "loan_id": {
+                    "type": "string"
+                },
+                "member_id": {
+                    "type": "string"
+                },
+                "operation": {
+                    "description": "Operation is checkout or renewal",
+                    "type": "string"
+                },
+                "overridden_at": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "shift": {
+                    "description": "Shift is the shift of the staff at the time of the override",
+                    "type": "string"
+                },
+                "staff_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.Publisher": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ReportSubscription": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_run_at": {
+                    "type": "string"
+                },
+                "last_status": {
+                    "type": "string"
+                },
+                "next_run_at": {
+                    "type": "string"
+                },
+                "report": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "description": "Schedule is a five-field cron expression evaluated in the timezone of the tenant, e.g. \"0 8 * * mon\"",
+                    "type": "string"
+                },
+                "target": {
+                    "description": "Target is the email address or the webhook URL the report is delivered to",
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "description": "TenantID is the tenant whose timezone the schedule is in, nil for the default timezone",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ReportSubscriptionRun": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subscription_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.SandboxBaseline": {
+            "type": "object",
+            "properties": {
+                "saved_at": {
+                    "description": "example: 2026-10-16T04:00:00Z",
+                    "type": "string"
+                },
+                "tables": {
+                    "description": "Tables copied into the baseline\nexample: 42",
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.ScanResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error is why a failed scan failed",
+                    "type": "string"
+                },
+                "scanned_at": {
+                    "type": "string"
+                },
+                "scanner": {
+                    "description": "Scanner names the scanner, none when scanning is off",
+                    "type": "string"
+                },
+                "signature": {
+                    "description": "Signature is the malware the scanner found in an infected file",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ScheduledJob": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "last_duration_ms": {
+                    "description": "LastDurationMs is how long the last run took, in milliseconds",
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_run_at": {
+                    "type": "string"
+                },
+                "last_status": {
+                    "type": "string"
+                },
+                "lock_error_count": {
+                    "description": "LockErrorCount is how many runs failed because the lock could not be checked",
+                    "type": "integer"
+                },
+                "lock_miss_count": {
+                    "description": "LockMissCount is how many runs were left to another instance holding the lock",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next_run_at": {
+                    "type": "string"
+                },
+                "priority": {
+                    "description": "Priority is the priority the job runs with on the job queue",
+                    "type": "string"
+                },
+                "run_count": {
+                    "description": "RunCount is how many times this instance ran the job since it started",
+                    "type": "integer"
+                },
+                "running": {
+                    "type": "boolean"
+                },
+                "schedule": {
+                    "description": "Schedule is a five-field cron expression evaluated in UTC",
+                    "type": "string"
+                }
+            }
+        },
+        "entities.SelfTestCheck": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "description": "What passed, why the check failed or why it was skipped\nexample: connected",
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "example: database",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "pass, fail or skip\nexample: pass",
+                    "type": "string"
+                }
+            }
+        },
+        "entities.SelfTestReport": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.SelfTestCheck"
+                    }
+                },
+                "passed": {
+                    "type": "boolean"
+                },
+                "ran_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.Series": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ordering": {
+                    "description": "Ordering is either \"position\" (series_position) or \"publication\" (year)",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.Session": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "device": {
+                    "description": "Device is the User-Agent the session was signed in with",
+                    "type": "string",
+                    "example": "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_5) Firefox/128.0"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "impersonation_reason": {
+                    "description": "ImpersonationReason is why the admin impersonates the user, such as the issue to reproduce",
+                    "type": "string",
+                    "example": "Reproduce ticket 4821: renewals refused"
+                },
+                "impersonator_id": {
+                    "description": "ImpersonatorID is the admin who started the session to act as its user, nil for sessions the\nuser signed in to themselves",
+                    "type": "string"
+                },
+                "ip": {
+                    "description": "IP is the client IP the session was last seen from",
+                    "type": "string",
+                    "example": "203.0.113.9"
+                },
+                "last_seen_at": {
+                    "description": "LastSeenAt is updated at most once a minute",
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.SitemapFailure": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "loc": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.SitemapJob": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entries": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "failures": {
+                    "description": "Failures lists entries left unchanged because they could not be processed, up to a limit",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.SitemapFailure"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "operation": {
+                    "type": "string"
+                },
+                "profile": {
+                    "type": "string"
+                },
+                "rewritten": {
+                    "description": "Rewritten counts the entries whose URL changed",
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "Source is the URL the sitemap was fetched from, or \"upload\"",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.StaffOverrides": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "staff_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.StatsRefresh": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "refreshed_at": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.StorageArea": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error is why the area could not be measured",
+                    "type": "string"
+                },
+                "level": {
+                    "type": "string"
+                },
+                "limit_bytes": {
+                    "description": "LimitBytes is the soft quota of the area, zero without one",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Path is the directory of the area, empty for the database",
+                    "type": "string"
+                },
+                "used_bytes": {
+                    "type": "integer"
+                },
+                "used_percent": {
+                    "type": "number"
+                }
+            }
+        },
+        "entities.StorageReport": {
+            "type": "object",
+            "properties": {
+                "areas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.StorageArea"
+                    }
+                },
+                "checked_at": {
+                    "type": "string"
+                },
+                "warn_percent": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.Tenant": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Timezone is the IANA timezone due dates, stats and report schedules of the tenant are in,\nempty for the default timezone of the deployment",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.TimelineEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.TimelinePage": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.TimelineEvent"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.Timezone": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Source is branch, tenant or default",
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Name is the IANA name of the timezone, e.g. Asia/Tokyo",
+                    "type": "string"
+                }
+            }
+        },
+        "entities.TopAuthor": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "books": {
+                    "type": "integer"
+                },
+                "loans": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.URLOptions": {
+            "type": "object",
+            "properties": {
+                "collapse_slashes": {
+                    "description": "CollapseSlashes turns runs of slashes in the path into one",
+                    "type": "boolean"
+                },
+                "path_encoding": {
+                    "description": "PathEncoding is lowercase to lowercase percent-encodings too (default), strict to keep them\nupper-case as RFC 3986 does, or decode to show the characters they stand for",
+                    "type": "string"
+                },
+                "remove_dot_segments": {
+                    "description": "RemoveDotSegments resolves \".\" and \"..\" segments of the path",
+                    "type": "boolean"
+                },
+                "root_path": {
+                    "description": "RootPath is slash to keep \"/\" for the root (default) or empty to drop it",
+                    "type": "string"
+                },
+                "strip_default_port": {
+                    "description": "StripDefaultPort drops :80 from http URLs and :443 from https ones",
+                    "type": "boolean"
+                }
+            }
+        },
+        "entities.URLRequest": {
+            "type": "object",
+            "properties": {
+                "operation": {
+                    "type": "string"
+                },
+                "options": {
+                    "description": "Options tune canonical processing and lowercasing, by the canonical, redirection and all\noperations or within a profile",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entities.URLOptions"
+                        }
+                    ]
+                },
+                "profile": {
+                    "description": "Profile names a bundle of operations to apply instead of a single operation",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.URLResponse": {
+            "type": "object",
+            "properties": {
+                "processed_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.URLResult": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "description": "Cached is set when the result was served from the cache",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "host": {
+                    "description": "Host is the lower-case host of the URL, which the history is filtered by domain with",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "operations": {
+                    "description": "Operations are the operations applied in order, joined with commas",
+                    "type": "string"
+                },
+                "processed_url": {
+                    "type": "string"
+                },
+                "profile": {
+                    "description": "Profile is the profile the operations came from, if any",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.Usage": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "period": {
+                    "type": "string"
+                },
+                "remaining": {
+                    "type": "integer"
+                },
+                "resets_at": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "used": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.User": {
+            "type": "object",
+            "properties": {
+                "anonymized_at": {
+                    "description": "AnonymizedAt is set once the personal details of a deactivated account are erased for good",
+                    "type": "string"
+                },
+                "branch_id": {
+                    "description": "BranchID is the branch a librarian works for, nil for staff of the whole library. Requests\nsigned in as the user carry it in place of any X-User-Branch header the client sent.",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deactivated_at": {
+                    "description": "DeactivatedAt is set while the account is deactivated: its user can neither sign in nor\nborrow, and their loans and fines are kept",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "Email is stored normalized and is unique whatever its case, see NormalizeEmail",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "description": "Phone is where the library reaches a member besides email",
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "tenant_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ValidationRule": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "Category limits the rule to books whose category metadata equals it; empty applies the\nrule to every book",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "max_year": {
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "Message replaces the default error message of the rule",
+                    "type": "string"
+                },
+                "metadata_key": {
+                    "description": "MetadataKey is the metadata key that must be set, for required_metadata rules",
+                    "type": "string"
+                },
+                "min_year": {
+                    "description": "MinYear and MaxYear bound the year, inclusive, for year_range rules; either may be open",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pattern": {
+                    "description": "Pattern is the regular expression the start of the ISBN must match, for isbn_prefix rules",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.WeedingCandidate": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "book_id": {
+                    "type": "string"
+                },
+                "copies": {
+                    "type": "integer"
+                },
+                "isbn": {
+                    "type": "string"
+                },
+                "last_activity_at": {
+                    "description": "LastActivityAt is the latest loan or catalog change of the book",
+                    "type": "string"
+                },
+                "last_activity_at_iso_week": {
+                    "type": "string"
+                },
+                "last_loaned_at": {
+                    "description": "LastLoanedAt is empty for books that were never loaned",
+                    "type": "string"
+                },
+                "last_loaned_at_iso_week": {
+                    "description": "ISO 8601 weeks of the last loan and activity, e.g. 2024-W03, in the timezone the report was\nasked in; filled in by the API for spreadsheet users",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.WeedingReport": {
+            "type": "object",
+            "properties": {
+                "cutoff": {
+                    "type": "string"
+                },
+                "cutoff_iso_week": {
+                    "description": "CutoffISOWeek is the ISO 8601 week of the cutoff, filled in by the API",
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.WeedingCandidate"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "years": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.Work": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.WorkerPool": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "description": "Capacity is the number of jobs that can wait before producers block",
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priorities": {
+                    "description": "Priorities break the jobs of the pool down by priority, highest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.JobPriorityStats"
+                    }
+                },
+                "queued": {
+                    "description": "Queued is the number of jobs waiting for a worker",
+                    "type": "integer"
+                },
+                "rejected": {
+                    "description": "Rejected is the number of jobs refused because the queue was full",
+                    "type": "integer"
+                },
+                "workers": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.AccessTokenCreatedResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt is nil for tokens that never expire",
+                    "type": "string"
+                },
+                "hint": {
+                    "description": "Hint is the start of the token, for telling tokens apart",
+                    "type": "string",
+                    "example": "lms_pat_3f2a"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "description": "LastUsedAt is updated at most once a minute",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Catalog sync script"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token": {
+                    "type": "string",
+                    "example": "lms_pat_3f2a9c4be1d84e0f9a6b2c7d5e8f1a3b"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.AcquisitionReasonRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.AddBookCopyRequest": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "description": "BranchID is the branch holding the copy; by default the branch of the librarian, else of the book",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.AddBookIdentifierRequest": {
+            "type": "object",
+            "required": [
+                "type",
+                "value"
+            ],
+            "properties": {
+                "type": {
+                    "description": "issn, asin, doi or internal",
+                    "type": "string",
+                    "example": "doi"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "https://doi.org/10.1000/182"
+                }
+            }
+        },
+        "handlers.AddCollectionBookRequest": {
+            "type": "object",
+            "required": [
+                "book_id"
+            ],
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "position": {
+                    "description": "1-based position to insert the book at; the book is appended when omitted",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.AuthCapabilities": {
+            "type": "object",
+            "properties": {
+                "admin_networks_restricted": {
+                    "description": "Admin routes only accept requests from allowed networks",
+                    "type": "boolean"
+                },
+                "admin_ui": {
+                    "description": "Admins sign in to the HTML admin pages with their email and password",
+                    "type": "boolean"
+                },
+                "modes": {
+                    "description": "Ways callers are identified: identity_headers (X-User-ID and X-User-Role set by a trusted\ngateway), sessions and access_tokens (bearer tokens)\nexample: [\"identity_headers\",\"sessions\",\"access_tokens\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.AvailabilityPoll": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean"
+                },
+                "book_id": {
+                    "type": "string"
+                },
+                "changed": {
+                    "description": "Changed is false when the poll timed out without a change",
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.BatchItem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the error code of failed items, when it is in the error catalog",
+                    "type": "string",
+                    "example": "duplicate_isbn"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "index": {
+                    "description": "Index is the position of the item in the batch",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Status is the HTTP status the item would have had on its own; 424 for items of an atomic\nbatch left unwritten because others failed",
+                    "type": "integer",
+                    "example": 201
+                }
+            }
+        },
+        "handlers.BookBatchChangeBatchResponse": {
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BookBatchChangeResponse"
+                    }
+                },
+                "status": {
+                    "description": "succeeded when every item did, failed when none did, partial otherwise. Atomic batches are\nsucceeded or failed, as nothing is written when an item fails.",
+                    "type": "string",
+                    "example": "partial"
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.BookBatchChangeResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the error code of failed items, when it is in the error catalog",
+                    "type": "string",
+                    "example": "duplicate_isbn"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "index": {
+                    "description": "Index is the position of the item in the batch",
+                    "type": "integer"
+                },
+                "result": {
+                    "description": "deleted, restored, rejected or aborted",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is the HTTP status the item would have had on its own; 424 for items of an atomic\nbatch left unwritten because others failed",
+                    "type": "integer",
+                    "example": 201
+                }
+            }
+        },
+        "handlers.BookCopyLookupResponse": {
+            "type": "object",
+            "properties": {
+                "book": {
+                    "$ref": "#/definitions/handlers.BookResponse"
+                },
+                "copy": {
+                    "$ref": "#/definitions/entities.BookCopy"
+                }
+            }
+        },
+        "handlers.BookDetailResponse": {
+            "type": "object",
+            "properties": {
+                "authors": {
+                    "description": "Authors credited on the book, split from its author\nexample: [\"Terry Pratchett\",\"Neil Gaiman\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "availability": {
+                    "$ref": "#/definitions/usecase.Holding"
+                },
+                "book": {
+                    "$ref": "#/definitions/handlers.BookResponse"
+                },
+                "categories": {
+                    "description": "Category of the book from its metadata, empty when it has none\nexample: [\"Fantasy\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "copies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.BookCopy"
+                    }
+                },
+                "identifiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.BookIdentifier"
+                    }
+                },
+                "publisher": {
+                    "description": "Publisher of the book, null when it names none",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entities.Publisher"
+                        }
+                    ]
+                },
+                "series": {
+                    "description": "Series of the book, null when it is not part of one",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entities.Series"
+                        }
+                    ]
+                }
+            }
+        },
+        "handlers.BookIDBatchRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "550e8400-e29b-41d4-a716-446655440000"
+                    ]
+                }
+            }
+        },
+        "handlers.BookImportResponse": {
+            "type": "object",
+            "properties": {
+                "aborted": {
+                    "description": "Aborted is set when the import was atomic and books were rejected, so none was written",
+                    "type": "boolean"
+                },
+                "atomic": {
+                    "type": "boolean"
+                },
+                "duplicate_of": {
+                    "description": "DuplicateOf is the earlier run of the same file, when duplicates are only warned about",
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "imported": {
+                    "description": "Imported is the number of books inserted, or inserted and overwritten with upsert",
+                    "type": "integer"
+                },
+                "items": {
+                    "description": "Books imported or skipped are 200, rejected books 400 or the status of their error, and\nvalid books of an aborted atomic import 424",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BatchItem"
+                    }
+                },
+                "received": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.BookImportError"
+                    }
+                },
+                "run_id": {
+                    "description": "RunID is the import run the import is recorded as, when the import history is kept",
+                    "type": "string"
+                },
+                "skipped": {
+                    "description": "Skipped is the number of valid books left out because their ISBN was taken",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "succeeded when every item did, failed when none did, partial otherwise. Atomic batches are\nsucceeded or failed, as nothing is written when an item fails.",
+                    "type": "string",
+                    "example": "partial"
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.BookResponse": {
+            "type": "object",
+            "properties": {
+                "_links": {
+                    "description": "Links to the book and what can be done with it, only present with Accept: application/hal+json.\nBooks have self, update, delete and timeline links; deleted books restore and purge links.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/handlers.HALLink"
+                    }
+                },
+                "age_years": {
+                    "description": "Years since publication, only present with include=computed\nexample: 99",
+                    "type": "integer"
+                },
+                "author": {
+                    "description": "example: F. Scott Fitzgerald",
+                    "type": "string"
+                },
+                "available": {
+                    "description": "Whether the book is on the shelf; deleted and archived books are never available\nexample: true",
+                    "type": "boolean"
+                },
+                "branch_id": {
+                    "description": "Only present when the book belongs to a branch rather than the whole library\nexample: 0f8fad5b-d9cb-469f-a165-70867728950e",
+                    "type": "string"
+                },
+                "cover_color": {
+                    "description": "Dominant colour of the cover, for placeholder backgrounds while it loads; only present\nwhen the book has a JPEG, PNG or GIF cover\nexample: #1e3a8a",
+                    "type": "string"
+                },
+                "cover_palette": {
+                    "description": "Most common colours of the cover, the dominant one first\nexample: [\"#1e3a8a\",\"#f59e0b\",\"#f8fafc\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date_metadata": {
+                    "description": "Only present when a timezone was requested",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/handlers.DateMetadata"
+                        }
+                    ]
+                },
+                "days_in_catalog": {
+                    "description": "Whole days since the book was added, only present with include=computed\nexample: 12",
+                    "type": "integer"
+                },
+                "deleted_at": {
+                    "description": "Only present on the trash endpoint",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "Only present when the book has a description",
+                    "type": "string"
+                },
+                "edition_count": {
+                    "description": "Number of editions of the work in the results, only present with collapse=work\nexample: 3",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "example: 550e8400-e29b-41d4-a716-446655440000",
+                    "type": "string"
+                },
+                "isbn": {
+                    "description": "example: 9780743273565",
+                    "type": "string"
+                },
+                "metadata": {
+                    "description": "Custom fields of the library, only present when the book has some",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "publish_at": {
+                    "description": "Only present while the book is scheduled; it is hidden from public listings until then",
+                    "type": "string"
+                },
+                "publisher_id": {
+                    "description": "Only present when the book is linked to a publisher\nexample: 7c9e6679-7425-40de-944b-e07fc1f90ae7",
+                    "type": "string"
+                },
+                "series_id": {
+                    "description": "Only present when the book belongs to a series\nexample: 1b4e28ba-2fa1-11d2-883f-0016d3cca427",
+                    "type": "string"
+                },
+                "series_position": {
+                    "description": "example: 1",
+                    "type": "integer"
+                },
+                "slug": {
+                    "description": "Names the book in page URLs, see GET /api/books/by-slug/{slug}\nexample: the-great-gatsby",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "draft, active or archived; drafts are hidden from public listings\nexample: active",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "example: The Great Gatsby",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "work_id": {
+                    "description": "Only present when the book is an edition of a work\nexample: 9b2e8f4c-6d1a-4f3b-8c7e-2a5d9e0f1b3c",
+                    "type": "string"
+                },
+                "year": {
+                    "description": "example: 1925",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.BookSlugResponse": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "description": "example: 550e8400-e29b-41d4-a716-446655440000",
+                    "type": "string"
+                },
+                "slug": {
+                    "description": "example: the-great-gatsby",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.BookUpsertBatchResponse": {
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BookUpsertResponse"
+                    }
+                },
+                "status": {
+                    "description": "succeeded when every item did, failed when none did, partial otherwise. Atomic batches are\nsucceeded or failed, as nothing is written when an item fails.",
+                    "type": "string",
+                    "example": "partial"
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.BookUpsertResponse": {
+            "type": "object",
+            "properties": {
+                "book": {
+                    "$ref": "#/definitions/handlers.BookResponse"
+                },
+                "code": {
+                    "description": "Code is the error code of failed items, when it is in the error catalog",
+                    "type": "string",
+                    "example": "duplicate_isbn"
+                },
+                "conflicts": {
+                    "description": "Fields where the client and a book changed on the server since base_updated_at differ",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.FieldConflict"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "index": {
+                    "description": "Index is the position of the item in the batch",
+                    "type": "integer"
+                },
+                "isbn": {
+                    "type": "string"
+                },
+                "result": {
+                    "description": "created, updated, conflict, rejected or aborted",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is the HTTP status the item would have had on its own; 424 for items of an atomic\nbatch left unwritten because others failed",
+                    "type": "integer",
+                    "example": 201
+                }
+            }
+        },
+        "handlers.BootstrapRequest": {
+            "type": "object",
+            "required": [
+                "admin",
+                "tenant"
+            ],
+            "properties": {
+                "admin": {
+                    "type": "object",
+                    "required": [
+                        "email",
+                        "password"
+                    ],
+                    "properties": {
+                        "email": {
+                            "type": "string"
+                        },
+                        "name": {
+                            "type": "string"
+                        },
+                        "password": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "tenant": {
+                    "type": "object",
+                    "required": [
+                        "name"
+                    ],
+                    "properties": {
+                        "name": {
+                            "type": "string"
+                        },
+                        "slug": {
+                            "description": "Derived from the name when empty",
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "handlers.BootstrapResponse": {
+            "type": "object",
+            "properties": {
+                "config": {
+                    "description": "Public configuration, the feature flags under features included",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/handlers.PublicConfig"
+                        }
+                    ]
+                },
+                "unread_notifications": {
+                    "description": "Unread notifications of the caller, null when there is no user\nexample: 3",
+                    "type": "integer"
+                },
+                "user": {
+                    "description": "The caller, null when the request is anonymous or its user does not exist",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entities.User"
+                        }
+                    ]
+                }
+            }
+        },
+        "handlers.BusinessHoursRequest": {
+            "type": "object",
+            "required": [
+                "closes",
+                "opens",
+                "weekday"
+            ],
+            "properties": {
+                "closes": {
+                    "description": "example: 17:00",
+                    "type": "string"
+                },
+                "opens": {
+                    "description": "example: 09:00",
+                    "type": "string"
+                },
+                "weekday": {
+                    "description": "example: monday",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.Capabilities": {
+            "type": "object",
+            "properties": {
+                "auth": {
+                    "$ref": "#/definitions/handlers.AuthCapabilities"
+                },
+                "enrichment": {
+                    "description": "Books are enriched from Open Library by admin jobs",
+                    "type": "boolean"
+                },
+                "loans": {
+                    "description": "Members borrow, renew and return books",
+                    "type": "boolean"
+                },
+                "notifications": {
+                    "description": "Channels events are delivered through\nexample: [\"email\",\"webhook\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reservations": {
+                    "description": "Members place holds on books out on loan, kept for them once returned",
+                    "type": "boolean"
+                },
+                "sandbox": {
+                    "description": "The server is a sandbox, whose data is reset nightly",
+                    "type": "boolean"
+                },
+                "search": {
+                    "$ref": "#/definitions/handlers.SearchCapabilities"
+                },
+                "state": {
+                    "description": "Where instances keep the state they share, such as rate limits and events: memory (each\ninstance on its own) or redis\nexample: memory",
+                    "type": "string"
+                },
+                "storage": {
+                    "$ref": "#/definitions/handlers.StorageCapabilities"
+                },
+                "url_cache": {
+                    "description": "Cache of processed URLs: memory, redis or none\nexample: memory",
+                    "type": "string"
+                },
+                "webhooks": {
+                    "description": "Events are delivered to the webhook channel",
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.ChangeBookStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "description": "draft, active or archived",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ChangelogResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "Version of the API this server runs\nexample: 1.1",
+                    "type": "string"
+                },
+                "versions": {
+                    "description": "Versions newest first, with their changes since the previous version",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/changelog.Version"
+                    }
+                }
+            }
+        },
+        "handlers.CheckoutRequest": {
+            "type": "object",
+            "required": [
+                "book_id",
+                "member_id"
+            ],
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "member_id": {
+                    "type": "string"
+                },
+                "override_reason": {
+                    "description": "OverrideReason lends the book past the loan limit and holds of other members",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CollectionRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CollectionResponse": {
+            "type": "object",
+            "properties": {
+                "books": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BookResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "string"
+                },
+                "share_token": {
+                    "description": "ShareToken exposes a read-only view of the collection while it is set",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ContactRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "ada@example.com"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Ada Lovelace"
+                },
+                "phone": {
+                    "description": "Phone is cleared when empty",
+                    "type": "string",
+                    "example": "+44 20 7946 0958"
+                }
+            }
+        },
+        "handlers.CoverURLRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "url": {
+                    "type": "string",
+                    "example": "https://covers.openlibrary.org/b/id/8432047-L.jpg"
+                }
+            }
+        },
+        "handlers.CreateAccessTokenRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_in_days": {
+                    "description": "ExpiresInDays is the lifetime of the token; ACCESS_TOKEN_LIFETIME when omitted",
+                    "type": "integer",
+                    "example": 90
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Catalog sync script"
+                },
+                "scopes": {
+                    "description": "Scopes are books:read, books:write, url:process or admin:*",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "books:read",
+                        "books:write"
+                    ]
+                }
+            }
+        },
+        "handlers.CreateBookRequest": {
+            "type": "object",
+            "required": [
+                "author",
+                "isbn",
+                "title",
+                "year"
+            ],
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "branch_id": {
+                    "description": "Branch the book belongs to; librarians of a branch may leave it out to use theirs",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "isbn": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "description": "Custom fields as string, number or boolean values; tenants can restrict them with metadata fields",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "publish_at": {
+                    "description": "Hides the book from public listings until this time, RFC 3339; a past time publishes right away",
+                    "type": "string"
+                },
+                "publisher_id": {
+                    "type": "string"
+                },
+                "series_id": {
+                    "type": "string"
+                },
+                "series_position": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "draft or active (the default)",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.CreateBranchRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "accession_prefix": {
+                    "description": "AccessionPrefix gives the copies of the branch accession numbers of their own, e.g. HB-",
+                    "type": "string",
+                    "example": "HB-"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CreateClosedDayRequest": {
+            "type": "object",
+            "required": [
+                "date"
+            ],
+            "properties": {
+                "branch_id": {
+                    "description": "BranchID is the branch that closes; without it the whole library does",
+                    "type": "string"
+                },
+                "date": {
+                    "description": "example: 2026-12-25",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "example: Christmas Day",
+                    "type": "string"
+                },
+                "yearly": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.CreateSeriesRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "ordering": {
+                    "description": "How books are listed: position (default) or publication",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CreateWorkRequest": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "Defaults to the author of the first edition",
+                    "type": "string"
+                },
+                "book_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "description": "Defaults to the title of the first edition",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.DateMetadata": {
+            "type": "object",
+            "properties": {
+                "created_at_iso_week": {
+                    "description": "ISO 8601 week of the creation date\nexample: 2024-W03",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Timezone the timestamps are expressed in\nexample: Asia/Tokyo",
+                    "type": "string"
+                },
+                "updated_at_iso_week": {
+                    "description": "ISO 8601 week of the last update\nexample: 2024-W03",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.EnrichmentRequest": {
+            "type": "object",
+            "properties": {
+                "apply": {
+                    "description": "Apply updates the books right away; by default their changes are saved as drafts for review",
+                    "type": "boolean"
+                },
+                "limit": {
+                    "description": "Limit bounds the books looked up, ENRICH_MAX_BOOKS by default",
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "handlers.ErrorCode": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Stable machine-readable code\nexample: book_not_found",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "What the error means",
+                    "type": "string"
+                },
+                "docs": {
+                    "description": "Link to the documentation of the error",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Message returned in the \"error\" field of the response\nexample: book not found",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "HTTP status the error is usually returned with\nexample: 404",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error message\nexample: invalid request payload",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.GroupEditionsRequest": {
+            "type": "object",
+            "required": [
+                "book_ids"
+            ],
+            "properties": {
+                "book_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.HALLink": {
+            "type": "object",
+            "properties": {
+                "href": {
+                    "description": "example: /api/books/550e8400-e29b-41d4-a716-446655440000",
+                    "type": "string"
+                },
+                "method": {
+                    "description": "HTTP method to follow the link with, a hint for links other than GET\nexample: PUT",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ImpersonateRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Reproduce ticket 4821: renewals refused"
+                }
+            }
+        },
+        "handlers.ImportBookRequest": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "isbn": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "publisher_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "draft or active (the default)",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.ImportBooksRequest": {
+            "type": "object",
+            "required": [
+                "books"
+            ],
+            "properties": {
+                "books": {
+                    "type": "array",
+                    "maxItems": 5000,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/handlers.ImportBookRequest"
+                    }
+                },
+                "on_conflict": {
+                    "description": "skip (the default) keeps the existing book when an ISBN is taken, upsert overwrites it",
+                    "type": "string",
+                    "enum": [
+                        "skip",
+                        "upsert"
+                    ],
+                    "example": "skip"
+                }
+            }
+        },
+        "handlers.JSONFeed": {
+            "type": "object",
+            "properties": {
+                "feed_url": {
+                    "type": "string",
+                    "example": "https://library.example.com/api/feeds/catalog.json"
+                },
+                "home_page_url": {
+                    "type": "string",
+                    "example": "https://library.example.com/"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.JSONFeedItem"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "New arrivals"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "https://jsonfeed.org/version/1.1"
+                }
+            }
+        },
+        "handlers.JSONFeedAuthor": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Terry Pratchett"
+                }
+            }
+        },
+        "handlers.JSONFeedItem": {
+            "type": "object",
+            "properties": {
+                "_book": {
+                    "description": "Book is the book as the API lists it, an extension of the format",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/handlers.BookResponse"
+                        }
+                    ]
+                },
+                "authors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.JSONFeedAuthor"
+                    }
+                },
+                "content_text": {
+                    "type": "string",
+                    "example": "Death takes an apprentice."
+                },
+                "date_modified": {
+                    "type": "string"
+                },
+                "date_published": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "urn:uuid:3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21"
+                },
+                "image": {
+                    "description": "Image is the cover of the book, left out when it has none",
+                    "type": "string",
+                    "example": "https://library.example.com/api/books/3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21/cover"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Mort"
+                },
+                "url": {
+                    "description": "URL is the page of the book on the library site",
+                    "type": "string",
+                    "example": "https://library.example.com/books/3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21"
+                }
+            }
+        },
+        "handlers.MessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "Informational message\nexample: operation completed successfully",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.OpenInventorySessionRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "description": "example: Spring 2024 audit",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.OrderAcquisitionRequest": {
+            "type": "object",
+            "required": [
+                "vendor"
+            ],
+            "properties": {
+                "isbn": {
+                    "description": "Completes or corrects the ISBN of the suggestion",
+                    "type": "string"
+                },
+                "vendor": {
+                    "type": "string"
+                },
                 "year": {
+                    "description": "Completes or corrects the year of the suggestion",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.PageSizes": {
+            "type": "object",
+            "properties": {
+                "default": {
+                    "description": "example: 20",
+                    "type": "integer"
+                },
+                "max": {
+                    "description": "example: 100",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.ProcessURLsRequest": {
+            "type": "object",
+            "required": [
+                "requests"
+            ],
+            "properties": {
+                "requests": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/entities.URLRequest"
+                    }
+                }
+            }
+        },
+        "handlers.PublicAuthPolicy": {
+            "type": "object",
+            "properties": {
+                "access_token_lifetime_seconds": {
+                    "description": "Default and longest lifetime of personal access tokens, in seconds; zero lets them last forever\nexample: 7776000",
+                    "type": "integer"
+                },
+                "access_token_max_lifetime_seconds": {
+                    "description": "example: 31536000",
+                    "type": "integer"
+                },
+                "max_sessions_per_user": {
+                    "description": "Active sessions a user may have; signing in past it signs out the sessions seen least recently.\nZero leaves them unlimited.\nexample: 10",
+                    "type": "integer"
+                },
+                "password": {
+                    "$ref": "#/definitions/entities.PasswordPolicy"
+                },
+                "session_lifetime_seconds": {
+                    "description": "example: 2592000",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.PublicConfig": {
+            "type": "object",
+            "properties": {
+                "api_prefix": {
+                    "description": "example: /api",
+                    "type": "string"
+                },
+                "api_version": {
+                    "description": "example: v1",
+                    "type": "string"
+                },
+                "auth": {
+                    "$ref": "#/definitions/handlers.PublicAuthPolicy"
+                },
+                "features": {
+                    "$ref": "#/definitions/handlers.PublicFeatures"
+                },
+                "locales": {
+                    "description": "Locales the API writes messages in\nexample: [\"en\"]",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "long_poll_timeout_seconds": {
+                    "description": "Longest a long poll is held open, in seconds\nexample: 30",
+                    "type": "integer"
+                },
+                "pagination": {
+                    "description": "Page sizes of the paginated listings, by listing",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/handlers.PageSizes"
+                    }
+                }
+            }
+        },
+        "handlers.PublicFeatures": {
+            "type": "object",
+            "properties": {
+                "admin_ui": {
+                    "type": "boolean"
+                },
+                "api_v2": {
+                    "description": "The v2 response format is served under /v2 and through the Accept header",
+                    "type": "boolean"
+                },
+                "quota": {
+                    "description": "Requests are metered against a monthly quota, per signed-in user or else per client IP",
+                    "type": "boolean"
+                },
+                "sandbox": {
+                    "description": "The server is a sandbox, whose data is reset nightly",
+                    "type": "boolean"
+                },
+                "swagger": {
+                    "type": "boolean"
+                },
+                "url_token_mode": {
+                    "description": "The anonymous URL processor requires an X-URL-Token: none, token or captcha\nexample: none",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PublisherRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "description": "Parent publisher ID; set it to make the publisher an imprint",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "database": {
+                    "$ref": "#/definitions/entities.DatabaseHealth"
+                },
+                "status": {
+                    "description": "ready, read_only, unavailable or draining",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.RelatedBookResponse": {
+            "type": "object",
+            "properties": {
+                "book": {
+                    "$ref": "#/definitions/handlers.BookResponse"
+                },
+                "co_borrowers": {
+                    "description": "CoBorrowers counts the members who borrowed both books",
+                    "type": "integer",
+                    "example": 0
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reasons": {
+                    "description": "Reasons lists the signals relating the books: same_author, same_series, shared_category\nand co_borrowed",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "same_author",
+                        "same_series"
+                    ]
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 8
+                }
+            }
+        },
+        "handlers.RelatedBooksResponse": {
+            "type": "object",
+            "properties": {
+                "book_id": {
+                    "type": "string"
+                },
+                "refreshed_at": {
+                    "description": "RefreshedAt is when the ranking was computed, nil when the book has no related books",
+                    "type": "string"
+                },
+                "related": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.RelatedBookResponse"
+                    }
+                }
+            }
+        },
+        "handlers.RenewRequest": {
+            "type": "object",
+            "properties": {
+                "override_reason": {
+                    "description": "OverrideReason renews the loan past the renewal limit and holds of other members",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ReorderCollectionRequest": {
+            "type": "object",
+            "required": [
+                "book_ids"
+            ],
+            "properties": {
+                "book_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handlers.ReportSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "channel",
+                "report",
+                "schedule",
+                "target"
+            ],
+            "properties": {
+                "channel": {
+                    "description": "email or webhook\nexample: webhook",
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Defaults to true",
+                    "type": "boolean"
+                },
+                "report": {
+                    "description": "weekly_stats or weeding\nexample: weekly_stats",
+                    "type": "string"
+                },
+                "schedule": {
+                    "description": "Cron expression evaluated in the timezone of the tenant\nexample: 0 8 * * mon",
+                    "type": "string"
+                },
+                "target": {
+                    "description": "Email address or webhook URL\nexample: https://example.com/hooks/reports",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ResizeWorkerPoolRequest": {
+            "type": "object",
+            "required": [
+                "workers"
+            ],
+            "properties": {
+                "workers": {
+                    "description": "example: 8",
+                    "type": "integer",
+                    "maximum": 256,
+                    "minimum": 1
+                }
+            }
+        },
+        "handlers.RevokedSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "revoked": {
                     "type": "integer",
-                    "example": 1925,
-                    "description": "Publication year of the book"
+                    "example": 2
+                }
+            }
+        },
+        "handlers.SaveImportProfileRequest": {
+            "type": "object",
+            "required": [
+                "columns"
+            ],
+            "properties": {
+                "columns": {
+                    "description": "CSV header of each imported column mapped to title, author, year, isbn, publisher_id,\nstatus or metadata.{key}",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "date_format": {
+                    "description": "Go time layout of the year column when it holds dates, e.g. 02/01/2006",
+                    "type": "string",
+                    "example": "02/01/2006"
+                },
+                "delimiter": {
+                    "description": "Column separator, a comma by default",
+                    "type": "string",
+                    "example": ";"
+                }
+            }
+        },
+        "handlers.SaveMetadataFieldRequest": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "label": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "description": "string, number or boolean",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ScanRequest": {
+            "type": "object",
+            "required": [
+                "barcodes"
+            ],
+            "properties": {
+                "barcodes": {
+                    "description": "ISBNs read from the books, hyphens are ignored",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "location": {
+                    "description": "Shelf or room the barcodes were scanned at\nexample: Fiction A-F",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SearchCapabilities": {
+            "type": "object",
+            "properties": {
+                "backend": {
+                    "description": "example: postgres",
+                    "type": "string"
+                },
+                "cached": {
+                    "description": "Listings and searches are served from a cache, refreshed in the background once stale",
+                    "type": "boolean"
+                },
+                "throttled": {
+                    "description": "Expensive searches are limited per caller and may get 429",
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.SessionCreatedResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "device": {
+                    "description": "Device is the User-Agent the session was signed in with",
+                    "type": "string",
+                    "example": "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_5) Firefox/128.0"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "impersonation_reason": {
+                    "description": "ImpersonationReason is why the admin impersonates the user, such as the issue to reproduce",
+                    "type": "string",
+                    "example": "Reproduce ticket 4821: renewals refused"
+                },
+                "impersonator_id": {
+                    "description": "ImpersonatorID is the admin who started the session to act as its user, nil for sessions the\nuser signed in to themselves",
+                    "type": "string"
+                },
+                "ip": {
+                    "description": "IP is the client IP the session was last seen from",
+                    "type": "string",
+                    "example": "203.0.113.9"
+                },
+                "last_seen_at": {
+                    "description": "LastSeenAt is updated at most once a minute",
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "lms_sess_9c4be1d84e0f9a6b2c7d5e8f1a3b3f2a6d1e0c9b8a7f4e3d2c1b0a9f8e7d6c5b"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "description": "Current marks the session the request was signed in with",
+                    "type": "boolean"
+                },
+                "device": {
+                    "description": "Device is the User-Agent the session was signed in with",
+                    "type": "string",
+                    "example": "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_5) Firefox/128.0"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "impersonation_reason": {
+                    "description": "ImpersonationReason is why the admin impersonates the user, such as the issue to reproduce",
+                    "type": "string",
+                    "example": "Reproduce ticket 4821: renewals refused"
+                },
+                "impersonator_id": {
+                    "description": "ImpersonatorID is the admin who started the session to act as its user, nil for sessions the\nuser signed in to themselves",
+                    "type": "string"
+                },
+                "ip": {
+                    "description": "IP is the client IP the session was last seen from",
+                    "type": "string",
+                    "example": "203.0.113.9"
+                },
+                "last_seen_at": {
+                    "description": "LastSeenAt is updated at most once a minute",
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SetBusinessHoursRequest": {
+            "type": "object",
+            "properties": {
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BusinessHoursRequest"
+                    }
+                }
+            }
+        },
+        "handlers.SetTimezoneRequest": {
+            "type": "object",
+            "properties": {
+                "timezone": {
+                    "description": "IANA name of the timezone; empty to clear it\nexample: Asia/Tokyo",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SetupStatus": {
+            "type": "object",
+            "properties": {
+                "bootstrapped": {
+                    "description": "Whether the deployment already has users",
+                    "type": "boolean"
                 },
-                "isbn": {
-                    "type": "string",
-                    "example": "978-0743273565",
-                    "description": "International Standard Book Number"
+                "enabled": {
+                    "description": "Whether a setup token is configured",
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.SharedCollectionResponse": {
+            "type": "object",
+            "properties": {
+                "books": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BookResponse"
+                    }
                 },
-                "created_at": {
-                    "type": "string",
-                    "format": "date-time",
-                    "example": "2024-01-15T10:30:00Z",
-                    "description": "Timestamp when the book was created"
+                "description": {
+                    "description": "example: Books to take to the beach",
+                    "type": "string"
                 },
-                "updated_at": {
-                    "type": "string",
-                    "format": "date-time",
-                    "example": "2024-01-15T14:45:00Z",
-                    "description": "Timestamp when the book was last updated"
+                "title": {
+                    "description": "example: Summer reading",
+                    "type": "string"
                 },
-                "deleted_at": {
-                    "type": "string",
-                    "format": "date-time",
-                    "example": null,
-                    "description": "Timestamp when the book was soft-deleted (null if not deleted)"
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "entities.URLRequest": {
+        "handlers.SitemapSubmission": {
             "type": "object",
-            "description": "Request for URL processing operations",
-            "required": [
-                "url",
-                "operation"
-            ],
             "properties": {
-                "url": {
+                "operation": {
+                    "type": "string"
+                },
+                "profile": {
                     "type": "string",
-                    "example": "https://example.com/page?utm_source=google&utm_medium=cpc",
-                    "description": "The URL to be processed"
+                    "example": "seo"
                 },
-                "operation": {
+                "url": {
                     "type": "string",
-                    "example": "canonical",
-                    "description": "The type of processing operation (canonical, redirection, or all)",
-                    "enum": [
-                        "canonical",
-                        "redirection",
-                        "all"
-                    ]
+                    "example": "https://www.byfood.com/sitemap.xml"
                 }
             }
         },
-        "entities.URLResponse": {
+        "handlers.StorageCapabilities": {
             "type": "object",
-            "description": "Response containing the processed URL",
             "properties": {
-                "processed_url": {
-                    "type": "string",
-                    "example": "https://example.com/page",
-                    "description": "The processed URL after applying the specified operation"
+                "cover_fetch": {
+                    "description": "Covers can be set from a URL the server fetches",
+                    "type": "boolean"
+                },
+                "covers": {
+                    "description": "Where cover images are stored\nexample: filesystem",
+                    "type": "string"
+                },
+                "webdav": {
+                    "description": "Storage areas are served read-only over WebDAV",
+                    "type": "boolean"
                 }
             }
         },
-        "handlers.CreateBookRequest": {
+        "handlers.SuggestionRequest": {
             "type": "object",
-            "description": "Request model for creating a new book",
             "required": [
-                "title",
                 "author",
-                "year",
-                "isbn"
+                "title"
             ],
             "properties": {
-                "title": {
-                    "type": "string",
-                    "example": "To Kill a Mockingbird",
-                    "description": "Title of the book to create"
-                },
                 "author": {
-                    "type": "string",
-                    "example": "Harper Lee",
-                    "description": "Author of the book to create"
+                    "type": "string"
+                },
+                "isbn": {
+                    "type": "string"
+                },
+                "note": {
+                    "description": "Why the member would like the library to have the book",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
                 },
                 "year": {
-                    "type": "integer",
-                    "example": 1960,
-                    "description": "Publication year of the book to create"
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.URLBatchItem": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "type": "boolean"
                 },
-                "isbn": {
+                "code": {
+                    "description": "Code is the error code of failed items, when it is in the error catalog",
                     "type": "string",
-                    "example": "978-0446310789",
-                    "description": "International Standard Book Number (10-13 characters)"
+                    "example": "duplicate_isbn"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "index": {
+                    "description": "Index is the position of the item in the batch",
+                    "type": "integer"
+                },
+                "processed_url": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is the HTTP status the item would have had on its own; 424 for items of an atomic\nbatch left unwritten because others failed",
+                    "type": "integer",
+                    "example": 201
                 }
             }
         },
-        "handlers.ErrorResponse": {
+        "handlers.URLBatchResponse": {
             "type": "object",
-            "description": "Standard error response format",
             "properties": {
-                "error": {
-                    "description": "Error message describing what went wrong",
+                "atomic": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.URLBatchItem"
+                    }
+                },
+                "status": {
+                    "description": "succeeded when every item did, failed when none did, partial otherwise. Atomic batches are\nsucceeded or failed, as nothing is written when an item fails.",
                     "type": "string",
-                    "example": "book ISBN must be between 10 and 13 characters"
+                    "example": "partial"
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.MessageResponse": {
+        "handlers.UnreadCountResponse": {
             "type": "object",
-            "description": "Standard message response format",
             "properties": {
-                "message": {
-                    "description": "Informational message about the operation result",
-                    "type": "string",
-                    "example": "Book deleted successfully"
+                "unread": {
+                    "description": "Number of unread notifications\nexample: 3",
+                    "type": "integer"
                 }
             }
         },
         "handlers.UpdateBookRequest": {
             "type": "object",
-            "description": "Request model for updating an existing book",
             "required": [
-                "title",
                 "author",
-                "year",
-                "isbn"
+                "isbn",
+                "title",
+                "year"
             ],
             "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "branch_id": {
+                    "description": "Moves the book to another branch; left out, the book keeps its branch",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "isbn": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "description": "Custom fields as string, number or boolean values; tenants can restrict them with metadata fields",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "publisher_id": {
+                    "type": "string"
+                },
+                "series_id": {
+                    "type": "string"
+                },
+                "series_position": {
+                    "type": "integer"
+                },
                 "title": {
-                    "type": "string",
-                    "example": "To Kill a Mockingbird (Updated Edition)",
-                    "description": "Updated title of the book"
+                    "type": "string"
                 },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.UpsertBookRequest": {
+            "type": "object",
+            "properties": {
                 "author": {
-                    "type": "string",
-                    "example": "Harper Lee",
-                    "description": "Updated author of the book"
+                    "type": "string"
+                },
+                "base_updated_at": {
+                    "description": "The updated_at of the book when the client last synced it; the book is checked for\nconflicting changes on the server since then",
+                    "type": "string"
+                },
+                "changed_fields": {
+                    "description": "Fields the client changed since then, whose values conflict_policy=merge takes",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "isbn": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "publisher_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "draft or active (the default)",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
                 },
                 "year": {
-                    "type": "integer",
-                    "example": 1960,
-                    "description": "Updated publication year of the book"
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.ValidationRuleRequest": {
+            "type": "object",
+            "required": [
+                "kind",
+                "name"
+            ],
+            "properties": {
+                "category": {
+                    "description": "Limits the rule to books whose category metadata equals it\nexample: comics",
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Defaults to true",
+                    "type": "boolean"
+                },
+                "kind": {
+                    "description": "isbn_prefix, required_metadata or year_range\nexample: isbn_prefix",
+                    "type": "string"
+                },
+                "max_year": {
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "Replaces the default error message",
+                    "type": "string"
+                },
+                "metadata_key": {
+                    "description": "Metadata key that must be set, for required_metadata rules",
+                    "type": "string"
+                },
+                "min_year": {
+                    "description": "Inclusive year bounds, for year_range rules",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "example: Publisher ISBN range",
+                    "type": "string"
+                },
+                "pattern": {
+                    "description": "Regular expression the start of the ISBN must match, for isbn_prefix rules\nexample: 97[89]",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ValidationRuleTestRequest": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
                 },
                 "isbn": {
-                    "type": "string",
-                    "example": "978-0446310789",
-                    "description": "Updated International Standard Book Number (10-13 characters)"
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "title": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.ValidationRuleTestResponse": {
+            "type": "object",
+            "properties": {
+                "valid": {
+                    "type": "boolean"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.RuleViolation"
+                    }
+                }
+            }
+        },
+        "handlers.WorkResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "editions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BookResponse"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "middleware.BruteForceStats": {
+            "type": "object",
+            "properties": {
+                "ban_seconds": {
+                    "description": "BanSeconds is the length of a first ban, doubled with each strike up to MaxBanSeconds",
+                    "type": "integer"
+                },
+                "bans": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ratelimit.Ban"
+                    }
+                },
+                "max_ban_seconds": {
+                    "type": "integer"
+                },
+                "max_failures": {
+                    "type": "integer"
+                },
+                "window_seconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "middleware.URLGuardStats": {
+            "type": "object",
+            "properties": {
+                "ip_limit": {
+                    "description": "IPLimit is the number of requests an IP may make per window, zero when unlimited",
+                    "type": "integer"
+                },
+                "rejected": {
+                    "description": "Rejected counts the rejected requests by error code",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "rejected_total": {
+                    "type": "integer"
+                },
+                "token_required": {
+                    "type": "boolean"
+                },
+                "window_seconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ratelimit.Ban": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "strikes": {
+                    "description": "Strikes is the number of times the key was banned in a row, which sets the length of its ban",
+                    "type": "integer"
+                },
+                "until": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.BookImportError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "index": {
+                    "description": "Index is the position of the book in the import",
+                    "type": "integer"
+                },
+                "isbn": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.BookNeighbors": {
+            "type": "object",
+            "properties": {
+                "next_id": {
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Position is where the book is listed, from 1, out of Total books",
+                    "type": "integer"
+                },
+                "previous_id": {
+                    "description": "PreviousID and NextID are the books listed right before and after, null at either end",
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "usecase.FieldConflict": {
+            "type": "object",
+            "properties": {
+                "client": {},
+                "field": {
+                    "type": "string"
+                },
+                "kept": {
+                    "description": "Kept is the side whose value was kept, server or client",
+                    "type": "string"
+                },
+                "server": {}
+            }
+        },
+        "usecase.Holding": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "description": "Available tells whether the book can be borrowed, like the available field of books",
+                    "type": "boolean"
+                },
+                "book_id": {
+                    "type": "string"
+                },
+                "branch_id": {
+                    "description": "BranchID is the branch holding the book, absent for books of the whole library",
+                    "type": "string"
+                },
+                "due_back": {
+                    "description": "DueBack is when the first copy on loan is due back",
+                    "type": "string"
+                },
+                "holds": {
+                    "type": "integer"
+                },
+                "loans": {
+                    "description": "Loans counts the copies out on loan and Holds the members waiting for one",
+                    "type": "integer"
+                }
+            }
+        },
+        "usecase.RuleViolation": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "string"
+                },
+                "rule_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.ScanBatchResult": {
+            "type": "object",
+            "properties": {
+                "matched": {
+                    "description": "Matched counts the barcodes of the batch that belong to a catalog book",
+                    "type": "integer"
+                },
+                "recorded": {
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "Total is the number of scans of the session so far",
+                    "type": "integer"
+                },
+                "unmatched": {
+                    "description": "Unmatched lists the barcodes of the batch that belong to no catalog book",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "usecase.URLCacheStats": {
+            "type": "object",
+            "properties": {
+                "backend": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "hit_ratio": {
+                    "type": "number"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "misses": {
+                    "type": "integer"
+                },
+                "ttl_seconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "usecase.URLProfile": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         }
@@ -0,0 +1,122 @@
+// Package changelog lists the changes of every API version, from the changelog.json kept in the repository
+package changelog
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed changelog.json
+var changelogJSON []byte
+
+// Types of changes, as in Keep a Changelog
+const (
+	Added      = "added"
+	Changed    = "changed"
+	Deprecated = "deprecated"
+	Removed    = "removed"
+	Fixed      = "fixed"
+	Security   = "security"
+)
+
+// Change is a change of the API
+type Change struct {
+	Type    string `json:"type"`
+	Summary string `json:"summary"`
+	// Routes are the routes affected, as METHOD /path under the API prefix
+	Routes []string `json:"routes,omitempty"`
+}
+
+// Version is a released version of the API and its changes since the previous one
+type Version struct {
+	Version string   `json:"version"`
+	Date    string   `json:"date"`
+	Changes []Change `json:"changes"`
+}
+
+// Load returns the versions of the embedded changelog, newest first
+func Load() ([]Version, error) {
+	return Parse(changelogJSON)
+}
+
+// Parse reads a changelog and checks that its versions are ordered newest first, dated, and only
+// hold known types of changes
+func Parse(data []byte) ([]Version, error) {
+	var versions []Version
+	if err := json.Unmarshal(data, &versions); err != nil {
+		return nil, fmt.Errorf("invalid changelog: %w", err)
+	}
+	for i, v := range versions {
+		if _, err := parseVersion(v.Version); err != nil {
+			return nil, err
+		}
+		if _, err := time.Parse("2006-01-02", v.Date); err != nil {
+			return nil, fmt.Errorf("version %s has an invalid date %q", v.Version, v.Date)
+		}
+		if i > 0 && Compare(versions[i-1].Version, v.Version) <= 0 {
+			return nil, fmt.Errorf("version %s is listed after %s, versions must be newest first", v.Version, versions[i-1].Version)
+		}
+		for _, change := range v.Changes {
+			switch change.Type {
+			case Added, Changed, Deprecated, Removed, Fixed, Security:
+			default:
+				return nil, fmt.Errorf("version %s has a change of unknown type %q", v.Version, change.Type)
+			}
+		}
+	}
+	return versions, nil
+}
+
+// Since returns the versions newer than a version, newest first, or every version when since is
+// empty. It reports false when since is not a version of the changelog.
+func Since(versions []Version, since string) ([]Version, bool) {
+	if since == "" {
+		return versions, true
+	}
+	for i, v := range versions {
+		if v.Version == since {
+			return versions[:i], true
+		}
+	}
+	return nil, false
+}
+
+// Compare orders two valid versions numerically, part by part: 1.10 is newer than 1.9
+func Compare(a, b string) int {
+	pa, _ := parseVersion(a)
+	pb, _ := parseVersion(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// parseVersion splits a version such as 1.2 into its numbers
+func parseVersion(version string) ([]int, error) {
+	parts := strings.Split(version, ".")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid version %q, expected numbers separated by dots", version)
+		}
+		numbers[i] = n
+	}
+	return numbers, nil
+}
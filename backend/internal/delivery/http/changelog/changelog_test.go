@@ -0,0 +1,65 @@
+package changelog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	versions, err := Load()
+	require.NoError(t, err)
+	require.NotEmpty(t, versions)
+	for _, v := range versions {
+		assert.NotEmpty(t, v.Changes, v.Version)
+		for _, change := range v.Changes {
+			assert.NotEmpty(t, change.Summary, v.Version)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{name: "valid", data: `[{"version":"1.10","date":"2026-10-16","changes":[{"type":"added","summary":"x"}]},{"version":"1.9","date":"2026-01-01","changes":[]}]`},
+		{name: "not json", data: `{`, err: "invalid changelog: unexpected end of JSON input"},
+		{name: "invalid version", data: `[{"version":"v1","date":"2026-10-16"}]`, err: `invalid version "v1", expected numbers separated by dots`},
+		{name: "invalid date", data: `[{"version":"1.0","date":"16/10/2026"}]`, err: `version 1.0 has an invalid date "16/10/2026"`},
+		{name: "oldest first", data: `[{"version":"1.0","date":"2026-10-15"},{"version":"1.1","date":"2026-10-16"}]`, err: "version 1.1 is listed after 1.0, versions must be newest first"},
+		{name: "unknown type", data: `[{"version":"1.0","date":"2026-10-15","changes":[{"type":"improved","summary":"x"}]}]`, err: `version 1.0 has a change of unknown type "improved"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestSince(t *testing.T) {
+	versions := []Version{{Version: "1.2"}, {Version: "1.1"}, {Version: "1.0"}}
+
+	newer, ok := Since(versions, "1.0")
+	assert.True(t, ok)
+	assert.Equal(t, versions[:2], newer)
+
+	newer, ok = Since(versions, "1.2")
+	assert.True(t, ok)
+	assert.Empty(t, newer)
+
+	newer, ok = Since(versions, "")
+	assert.True(t, ok)
+	assert.Equal(t, versions, newer)
+
+	_, ok = Since(versions, "0.9")
+	assert.False(t, ok)
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessTokenHandler handles HTTP requests about the personal access tokens of the caller
+type AccessTokenHandler struct {
+	tokenUseCase *usecase.AccessTokenUseCase
+}
+
+// NewAccessTokenHandler creates a new access token handler
+func NewAccessTokenHandler(tokenUseCase *usecase.AccessTokenUseCase) *AccessTokenHandler {
+	return &AccessTokenHandler{
+		tokenUseCase: tokenUseCase,
+	}
+}
+
+// CreateAccessTokenRequest represents the request body for creating a personal access token
+type CreateAccessTokenRequest struct {
+	Name string `json:"name" binding:"required" example:"Catalog sync script"`
+	// Scopes are books:read, books:write, url:process or admin:*
+	Scopes []string `json:"scopes" binding:"required" example:"books:read,books:write"`
+	// ExpiresInDays is the lifetime of the token; ACCESS_TOKEN_LIFETIME when omitted
+	ExpiresInDays int `json:"expires_in_days,omitempty" example:"90"`
+}
+
+// AccessTokenCreatedResponse is a new access token with the token itself, shown only once
+type AccessTokenCreatedResponse struct {
+	entities.AccessToken
+	Token string `json:"token" example:"lms_pat_3f2a9c4be1d84e0f9a6b2c7d5e8f1a3b"`
+}
+
+// GetAccessTokens handles GET /api/me/tokens
+// @Summary List my access tokens
+// @Description List the caller's personal access tokens, newest first, revoked and expired ones included. The tokens themselves are not shown, only their hint.
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {array} entities.AccessToken
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /me/tokens [get]
+func (h *AccessTokenHandler) GetAccessTokens(c *gin.Context) {
+	userID := callerID(c)
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	tokens, err := h.tokenUseCase.List(userID)
+	if err != nil {
+		writeAccessTokenError(c, err, http.StatusInternalServerError)
+		return
+	}
+	if tokens == nil {
+		tokens = []entities.AccessToken{}
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// CreateAccessToken handles POST /api/me/tokens
+// @Summary Create an access token
+// @Description Issue a personal access token to the caller, sent as "Authorization: Bearer <token>" to sign requests in as the caller. The token only reaches the routes of its scopes: books:read and books:write for books and their copies, url:process for the URL processor, and admin:*, which only admins can grant, for every route. It expires after expires_in_days, at most ACCESS_TOKEN_MAX_LIFETIME. The token is shown in this response only.
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "User ID"
+// @Param token body CreateAccessTokenRequest true "Token"
+// @Success 201 {object} handlers.AccessTokenCreatedResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 403 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /me/tokens [post]
+func (h *AccessTokenHandler) CreateAccessToken(c *gin.Context) {
+	var req CreateAccessTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	token, secret, err := h.tokenUseCase.Create(callerID(c), usecase.AccessTokenRequest{
+		Name:          req.Name,
+		Scopes:        req.Scopes,
+		ExpiresInDays: req.ExpiresInDays,
+	})
+	if err != nil {
+		writeAccessTokenError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusCreated, AccessTokenCreatedResponse{AccessToken: *token, Token: secret})
+}
+
+// RevokeAccessToken handles DELETE /api/me/tokens/:id
+// @Summary Revoke an access token
+// @Description Revoke one of the caller's access tokens for good; requests presenting it are refused from then on
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "User ID"
+// @Param id path string true "Token ID"
+// @Success 200 {object} entities.AccessToken
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /me/tokens/{id} [delete]
+func (h *AccessTokenHandler) RevokeAccessToken(c *gin.Context) {
+	userID := callerID(c)
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	token, err := h.tokenUseCase.Revoke(userID, c.Param("id"))
+	if err != nil {
+		writeAccessTokenError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+// writeAccessTokenError writes a failed access token request; defined errors keep their status
+func writeAccessTokenError(c *gin.Context, err error, status int) {
+	if e, ok := domainerr.Lookup(err); ok {
+		c.Error(err)
+		c.JSON(e.Status, gin.H{"error": e.Error()})
+		return
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
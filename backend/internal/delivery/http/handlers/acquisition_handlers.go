@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AcquisitionHandler handles HTTP requests for the purchasing workflow
+type AcquisitionHandler struct {
+	acquisitionUseCase *usecase.AcquisitionUseCase
+}
+
+// NewAcquisitionHandler creates a new acquisition handler
+func NewAcquisitionHandler(acquisitionUseCase *usecase.AcquisitionUseCase) *AcquisitionHandler {
+	return &AcquisitionHandler{
+		acquisitionUseCase: acquisitionUseCase,
+	}
+}
+
+// SuggestionRequest represents the request body for suggesting a book to acquire
+type SuggestionRequest struct {
+	Title  string `json:"title" binding:"required"`
+	Author string `json:"author" binding:"required"`
+	Year   int    `json:"year"`
+	ISBN   string `json:"isbn"`
+	// Why the member would like the library to have the book
+	Note string `json:"note"`
+}
+
+// OrderAcquisitionRequest represents the request body for ordering an approved acquisition
+type OrderAcquisitionRequest struct {
+	Vendor string `json:"vendor" binding:"required"`
+	// Completes or corrects the ISBN of the suggestion
+	ISBN string `json:"isbn"`
+	// Completes or corrects the year of the suggestion
+	Year int `json:"year"`
+}
+
+// AcquisitionReasonRequest represents the request body for rejecting or cancelling an acquisition
+type AcquisitionReasonRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// GetAcquisitions handles GET /api/acquisitions
+// @Summary List acquisitions
+// @Description Retrieve the acquisitions queue, oldest first
+// @Tags acquisitions
+// @Accept json
+// @Produce json
+// @Param status query string false "Only return acquisitions with this status (suggested, approved, rejected, ordered, received, cancelled)"
+// @Success 200 {array} entities.Acquisition
+// @Failure 400 {object} handlers.ErrorResponse
+// @Router /acquisitions [get]
+func (h *AcquisitionHandler) GetAcquisitions(c *gin.Context) {
+	acquisitions, err := h.acquisitionUseCase.ListAcquisitions(c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, acquisitions)
+}
+
+// SuggestAcquisition handles POST /api/acquisitions/suggestions
+// @Summary Suggest a book
+// @Description Submit a suggestion for the library to acquire a book
+// @Tags acquisitions
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller ID"
+// @Param suggestion body SuggestionRequest true "Suggested book"
+// @Success 201 {object} entities.Acquisition
+// @Failure 400 {object} handlers.ErrorResponse
+// @Router /acquisitions/suggestions [post]
+func (h *AcquisitionHandler) SuggestAcquisition(c *gin.Context) {
+	memberID := callerID(c)
+	if memberID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	var req SuggestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	acquisition := &entities.Acquisition{
+		Title:  req.Title,
+		Author: req.Author,
+		Year:   req.Year,
+		ISBN:   req.ISBN,
+		Note:   req.Note,
+	}
+
+	if err := h.acquisitionUseCase.Suggest(memberID, acquisition); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, acquisition)
+}
+
+// GetAcquisition handles GET /api/acquisitions/:id
+// @Summary Get an acquisition
+// @Description Retrieve an acquisition and its order status
+// @Tags acquisitions
+// @Accept json
+// @Produce json
+// @Param id path string true "Acquisition ID"
+// @Success 200 {object} entities.Acquisition
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /acquisitions/{id} [get]
+func (h *AcquisitionHandler) GetAcquisition(c *gin.Context) {
+	acquisition, err := h.acquisitionUseCase.GetAcquisition(c.Param("id"))
+	if err != nil {
+		if err.Error() == "acquisition not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, acquisition)
+}
+
+// ApproveAcquisition handles POST /api/acquisitions/:id/approve
+// @Summary Approve a suggestion
+// @Description Accept a suggestion for ordering
+// @Tags acquisitions
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Librarian ID"
+// @Param id path string true "Acquisition ID"
+// @Success 200 {object} entities.Acquisition
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /acquisitions/{id}/approve [post]
+func (h *AcquisitionHandler) ApproveAcquisition(c *gin.Context) {
+	h.transition(c, func(librarianID, id string) (*entities.Acquisition, error) {
+		return h.acquisitionUseCase.Approve(librarianID, id)
+	})
+}
+
+// RejectAcquisition handles POST /api/acquisitions/:id/reject
+// @Summary Reject a suggestion
+// @Description Turn a suggestion down with a reason for the member
+// @Tags acquisitions
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Librarian ID"
+// @Param id path string true "Acquisition ID"
+// @Param reason body AcquisitionReasonRequest true "Rejection reason"
+// @Success 200 {object} entities.Acquisition
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /acquisitions/{id}/reject [post]
+func (h *AcquisitionHandler) RejectAcquisition(c *gin.Context) {
+	var req AcquisitionReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.transition(c, func(librarianID, id string) (*entities.Acquisition, error) {
+		return h.acquisitionUseCase.Reject(librarianID, id, req.Reason)
+	})
+}
+
+// OrderAcquisition handles POST /api/acquisitions/:id/order
+// @Summary Order an acquisition
+// @Description Record that an approved acquisition was ordered from a vendor
+// @Tags acquisitions
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Librarian ID"
+// @Param id path string true "Acquisition ID"
+// @Param order body OrderAcquisitionRequest true "Order details"
+// @Success 200 {object} entities.Acquisition
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /acquisitions/{id}/order [post]
+func (h *AcquisitionHandler) OrderAcquisition(c *gin.Context) {
+	var req OrderAcquisitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.transition(c, func(librarianID, id string) (*entities.Acquisition, error) {
+		return h.acquisitionUseCase.Order(librarianID, id, usecase.AcquisitionOrder{
+			Vendor: req.Vendor,
+			ISBN:   req.ISBN,
+			Year:   req.Year,
+		})
+	})
+}
+
+// ReceiveAcquisition handles POST /api/acquisitions/:id/receive
+// @Summary Receive an order
+// @Description Record the arrival of an ordered book and add it to the catalog
+// @Tags acquisitions
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Librarian ID"
+// @Param id path string true "Acquisition ID"
+// @Success 200 {object} entities.Acquisition
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /acquisitions/{id}/receive [post]
+func (h *AcquisitionHandler) ReceiveAcquisition(c *gin.Context) {
+	h.transition(c, func(librarianID, id string) (*entities.Acquisition, error) {
+		return h.acquisitionUseCase.Receive(librarianID, id)
+	})
+}
+
+// CancelAcquisition handles POST /api/acquisitions/:id/cancel
+// @Summary Cancel an acquisition
+// @Description Stop an approved or ordered acquisition
+// @Tags acquisitions
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Librarian ID"
+// @Param id path string true "Acquisition ID"
+// @Param reason body AcquisitionReasonRequest true "Cancellation reason"
+// @Success 200 {object} entities.Acquisition
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /acquisitions/{id}/cancel [post]
+func (h *AcquisitionHandler) CancelAcquisition(c *gin.Context) {
+	var req AcquisitionReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.transition(c, func(librarianID, id string) (*entities.Acquisition, error) {
+		return h.acquisitionUseCase.Cancel(librarianID, id, req.Reason)
+	})
+}
+
+// transition runs a status change on behalf of the calling librarian and writes the updated acquisition
+func (h *AcquisitionHandler) transition(c *gin.Context, move func(librarianID, id string) (*entities.Acquisition, error)) {
+	librarianID := callerID(c)
+	if librarianID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	acquisition, err := move(librarianID, c.Param("id"))
+	if err != nil {
+		if err.Error() == "acquisition not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, acquisition)
+}
@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed templates/admin/*.html
+var adminTemplates embed.FS
+
+// adminPages are the pages of the admin UI, each rendered within the layout
+var adminPages = []string{"books", "book_form", "trash", "migrations", "error"}
+
+// adminNotices are the confirmations shown after the redirect that follows a change
+var adminNotices = map[string]string{
+	"created":  "Book created.",
+	"updated":  "Book saved.",
+	"deleted":  "Book moved to the trash.",
+	"restored": "Book restored.",
+	"purged":   "Book deleted permanently.",
+}
+
+// MigrationLister lists the schema migrations and whether they have been applied
+type MigrationLister interface {
+	Migrations() ([]entities.Migration, error)
+}
+
+// adminPage is the data the admin pages are rendered with
+type adminPage struct {
+	Title      string
+	Admin      *entities.User
+	Notice     string
+	Error      string
+	Query      string
+	Books      []entities.Book
+	Form       adminBookForm
+	Migrations []entities.Migration
+}
+
+// adminBookForm holds the fields of the book form as entered; ID is empty for new books
+type adminBookForm struct {
+	ID     string
+	Title  string
+	Author string
+	Year   string
+	ISBN   string
+}
+
+// AdminUIHandler serves the server-rendered admin pages, for deployments without the frontend
+type AdminUIHandler struct {
+	bookUseCase *usecase.BookUseCase
+	migrations  MigrationLister
+	pages       map[string]*template.Template
+}
+
+// NewAdminUIHandler creates a new admin UI handler
+func NewAdminUIHandler(bookUseCase *usecase.BookUseCase, migrations MigrationLister) *AdminUIHandler {
+	pages := make(map[string]*template.Template, len(adminPages))
+	for _, page := range adminPages {
+		pages[page] = template.Must(template.ParseFS(adminTemplates, "templates/admin/layout.html", "templates/admin/"+page+".html"))
+	}
+	return &AdminUIHandler{
+		bookUseCase: bookUseCase,
+		migrations:  migrations,
+		pages:       pages,
+	}
+}
+
+// Home handles GET /admin
+func (h *AdminUIHandler) Home(c *gin.Context) {
+	c.Redirect(http.StatusSeeOther, "/admin/books")
+}
+
+// ListBooks handles GET /admin/books, searching by title with the q parameter
+func (h *AdminUIHandler) ListBooks(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	var books []entities.Book
+	var err error
+	if query != "" {
+		books, err = h.bookUseCase.SearchBooksByTitle(query)
+	} else {
+		books, err = h.bookUseCase.GetAllBooks()
+	}
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	h.render(c, http.StatusOK, "books", adminPage{Title: "Books", Query: query, Books: books})
+}
+
+// NewBook handles GET /admin/books/new
+func (h *AdminUIHandler) NewBook(c *gin.Context) {
+	h.render(c, http.StatusOK, "book_form", adminPage{Title: "New book"})
+}
+
+// CreateBook handles POST /admin/books
+func (h *AdminUIHandler) CreateBook(c *gin.Context) {
+	form := bookForm(c)
+	book := &entities.Book{}
+	err := form.apply(book)
+	if err == nil {
+		err = h.bookUseCase.CreateBook(book)
+	}
+	if err != nil {
+		h.render(c, http.StatusBadRequest, "book_form", adminPage{Title: "New book", Error: err.Error(), Form: form})
+		return
+	}
+	c.Redirect(http.StatusSeeOther, "/admin/books?notice=created")
+}
+
+// EditBook handles GET /admin/books/:id/edit
+func (h *AdminUIHandler) EditBook(c *gin.Context) {
+	book, ok := h.book(c)
+	if !ok {
+		return
+	}
+	form := adminBookForm{ID: book.ID, Title: book.Title, Author: book.Author, Year: strconv.Itoa(book.Year), ISBN: book.ISBN}
+	h.render(c, http.StatusOK, "book_form", adminPage{Title: "Edit " + book.Title, Form: form})
+}
+
+// UpdateBook handles POST /admin/books/:id. The form only has the main fields, so the others
+// are kept as they are.
+func (h *AdminUIHandler) UpdateBook(c *gin.Context) {
+	book, ok := h.book(c)
+	if !ok {
+		return
+	}
+	form := bookForm(c)
+	form.ID = book.ID
+	err := form.apply(book)
+	if err == nil {
+		err = h.bookUseCase.UpdateBook(book.ID, book)
+	}
+	if err != nil {
+		h.render(c, http.StatusBadRequest, "book_form", adminPage{Title: "Edit " + form.Title, Error: err.Error(), Form: form})
+		return
+	}
+	c.Redirect(http.StatusSeeOther, "/admin/books?notice=updated")
+}
+
+// DeleteBook handles POST /admin/books/:id/delete, moving the book to the trash
+func (h *AdminUIHandler) DeleteBook(c *gin.Context) {
+	if err := h.bookUseCase.DeleteBook(c.Param("id")); err != nil {
+		h.fail(c, err)
+		return
+	}
+	c.Redirect(http.StatusSeeOther, "/admin/books?notice=deleted")
+}
+
+// Trash handles GET /admin/trash
+func (h *AdminUIHandler) Trash(c *gin.Context) {
+	books, err := h.bookUseCase.GetDeletedBooks()
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	h.render(c, http.StatusOK, "trash", adminPage{Title: "Trash", Books: books})
+}
+
+// RestoreBook handles POST /admin/trash/:id/restore
+func (h *AdminUIHandler) RestoreBook(c *gin.Context) {
+	if err := h.bookUseCase.RestoreBook(c.Param("id")); err != nil {
+		h.fail(c, err)
+		return
+	}
+	c.Redirect(http.StatusSeeOther, "/admin/trash?notice=restored")
+}
+
+// PurgeBook handles POST /admin/trash/:id/purge, deleting the book permanently
+func (h *AdminUIHandler) PurgeBook(c *gin.Context) {
+	if err := h.bookUseCase.HardDeleteBook(c.Param("id")); err != nil {
+		h.fail(c, err)
+		return
+	}
+	c.Redirect(http.StatusSeeOther, "/admin/trash?notice=purged")
+}
+
+// Migrations handles GET /admin/migrations
+func (h *AdminUIHandler) Migrations(c *gin.Context) {
+	migrations, err := h.migrations.Migrations()
+	if err != nil {
+		h.fail(c, err)
+		return
+	}
+	h.render(c, http.StatusOK, "migrations", adminPage{Title: "Migrations", Migrations: migrations})
+}
+
+// book returns the book of the :id parameter, rendering an error page when there is none
+func (h *AdminUIHandler) book(c *gin.Context) (*entities.Book, bool) {
+	book, err := h.bookUseCase.GetBook(c.Param("id"))
+	if err == nil && book == nil {
+		err = domainerr.ErrBookNotFound
+	}
+	if err != nil {
+		h.fail(c, err)
+		return nil, false
+	}
+	return book, true
+}
+
+// fail renders an error page; domain errors keep their status, others are logged and hidden
+func (h *AdminUIHandler) fail(c *gin.Context, err error) {
+	var domainErr *domainerr.Error
+	if errors.As(err, &domainErr) {
+		h.render(c, domainErr.Status, "error", adminPage{Title: "Something went wrong", Error: domainErr.Error()})
+		return
+	}
+	log.Printf("Admin page %s failed: %v", c.Request.URL.Path, err)
+	h.render(c, http.StatusInternalServerError, "error", adminPage{Title: "Something went wrong", Error: "The request failed, try again later."})
+}
+
+// render writes a page with the signed in admin and the notice of the request
+func (h *AdminUIHandler) render(c *gin.Context, status int, page string, data adminPage) {
+	if user, ok := c.Get(middleware.AdminUserKey); ok {
+		data.Admin, _ = user.(*entities.User)
+	}
+	data.Notice = adminNotices[c.Query("notice")]
+
+	var body bytes.Buffer
+	if err := h.pages[page].ExecuteTemplate(&body, "layout", data); err != nil {
+		log.Printf("Failed to render admin page %s: %v", page, err)
+		c.String(http.StatusInternalServerError, "failed to render the page")
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Data(status, "text/html; charset=utf-8", body.Bytes())
+}
+
+// bookForm reads the book form of a request
+func bookForm(c *gin.Context) adminBookForm {
+	return adminBookForm{
+		Title:  strings.TrimSpace(c.PostForm("title")),
+		Author: strings.TrimSpace(c.PostForm("author")),
+		Year:   strings.TrimSpace(c.PostForm("year")),
+		ISBN:   strings.TrimSpace(c.PostForm("isbn")),
+	}
+}
+
+// apply sets the fields of the form on a book
+func (f adminBookForm) apply(book *entities.Book) error {
+	year, err := strconv.Atoi(f.Year)
+	if err != nil {
+		return errors.New("year must be a number")
+	}
+	book.Title = f.Title
+	book.Author = f.Author
+	book.Year = year
+	book.ISBN = f.ISBN
+	return nil
+}
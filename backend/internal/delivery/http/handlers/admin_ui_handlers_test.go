@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// stubUserRepository finds the users it holds by email
+type stubUserRepository map[string]*entities.User
+
+func (r stubUserRepository) FindByEmail(email string) (*entities.User, error) {
+	return r[email], nil
+}
+
+func (r stubUserRepository) GetByID(id string) (*entities.User, error) {
+	for _, user := range r {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r stubUserRepository) Update(user *entities.User) error {
+	return nil
+}
+
+func (r stubUserRepository) ListDeactivatedBefore(at time.Time) ([]entities.User, error) {
+	var users []entities.User
+	for _, user := range r {
+		if user.DeactivatedAt != nil && user.AnonymizedAt == nil && user.DeactivatedAt.Before(at) {
+			users = append(users, *user)
+		}
+	}
+	return users, nil
+}
+
+// stubMigrationLister lists fixed migrations
+type stubMigrationLister []entities.Migration
+
+func (l stubMigrationLister) Migrations() ([]entities.Migration, error) {
+	return l, nil
+}
+
+func TestAdminUIHandler(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse battery staple"), bcrypt.MinCost)
+	require.NoError(t, err)
+	users := stubUserRepository{"admin@example.com": {ID: "1", Email: "admin@example.com", Role: entities.UserRoleAdmin, PasswordHash: string(hash)}}
+	handler := NewAdminUIHandler(usecase.NewBookUseCase(newMemoryBookRepository()), stubMigrationLister{
+		{ID: "20241201000000_create_books_table", Applied: true},
+		{ID: "20261016000005_create_validation_rules_table"},
+	})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	admin := router.Group("/admin", middleware.AdminAuth(usecase.NewAdminAuthUseCase(users)))
+	admin.GET("/books", handler.ListBooks)
+	admin.POST("/books", handler.CreateBook)
+	admin.POST("/books/:id/delete", handler.DeleteBook)
+	admin.GET("/trash", handler.Trash)
+	admin.GET("/migrations", handler.Migrations)
+
+	request := func(method, target string, form url.Values, password string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+		req.Host = "library.example.com"
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if method == http.MethodPost {
+			req.Header.Set("Origin", "https://library.example.com")
+		}
+		if password != "" {
+			req.SetBasicAuth("admin@example.com", password)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	const password = "correct horse battery staple"
+
+	w := request(http.MethodGet, "/admin/books", nil, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Header().Get("WWW-Authenticate"), "Basic")
+	assert.Equal(t, http.StatusUnauthorized, request(http.MethodGet, "/admin/books", nil, "wrong password").Code)
+
+	form := url.Values{"title": {"The Great Gatsby"}, "author": {"F. Scott Fitzgerald"}, "year": {"1925"}, "isbn": {"9780743273565"}}
+	w = request(http.MethodPost, "/admin/books", form, password)
+	require.Equal(t, http.StatusSeeOther, w.Code, w.Body.String())
+	assert.Equal(t, "/admin/books?notice=created", w.Header().Get("Location"))
+
+	w = request(http.MethodGet, "/admin/books?notice=created", nil, password)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "Book created.")
+	assert.Contains(t, w.Body.String(), "The Great Gatsby")
+
+	// Invalid books render the form again with the error and what was entered
+	form.Set("year", "nineteen")
+	w = request(http.MethodPost, "/admin/books", form, password)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "year must be a number")
+	assert.Contains(t, w.Body.String(), `value="nineteen"`)
+
+	// Forms posted from other sites are refused
+	req := httptest.NewRequest(http.MethodPost, "/admin/books", strings.NewReader(form.Encode()))
+	req.Host = "library.example.com"
+	req.Header.Set("Origin", "https://evil.example.com")
+	req.SetBasicAuth("admin@example.com", password)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	books, _ := handler.bookUseCase.GetAllBooks()
+	require.Len(t, books, 1)
+	w = request(http.MethodPost, "/admin/books/"+books[0].ID+"/delete", nil, password)
+	assert.Equal(t, http.StatusSeeOther, w.Code)
+	w = request(http.MethodGet, "/admin/trash", nil, password)
+	assert.Contains(t, w.Body.String(), "The Great Gatsby")
+	w = request(http.MethodPost, "/admin/books/missing/delete", nil, password)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = request(http.MethodGet, "/admin/migrations", nil, password)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "<strong>Pending</strong>")
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsHandler handles HTTP requests about how the API is used
+type AnalyticsHandler struct {
+	analyticsUseCase *usecase.AnalyticsUseCase
+}
+
+// NewAnalyticsHandler creates a new analytics handler
+func NewAnalyticsHandler(analyticsUseCase *usecase.AnalyticsUseCase) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsUseCase: analyticsUseCase,
+	}
+}
+
+// GetAnalytics handles GET /api/admin/analytics
+// @Summary Get usage analytics
+// @Description Retrieve how often each endpoint was called and each optional feature was used over the last days, most used first. Counts are anonymous and recorded hourly; recent requests may take a minute to show.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param days query int false "Number of days, 1 to 365" default(30)
+// @Success 200 {object} entities.AnalyticsReport
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/analytics [get]
+func (h *AnalyticsHandler) GetAnalytics(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid days"})
+		return
+	}
+
+	report, err := h.analyticsUseCase.Report(days)
+	if err != nil {
+		if err.Error() == "days must be between 1 and 365" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/webdav"
+)
+
+// artifactMethods are the WebDAV methods allowed on artifacts, which are read-only
+const artifactMethods = "OPTIONS, GET, HEAD, PROPFIND"
+
+// ArtifactHandler serves storage areas such as exports and backups read-only over WebDAV, so that
+// operations teams can pull them with standard tools such as rclone, cadaver or a file manager
+type ArtifactHandler struct {
+	dav *webdav.Handler
+}
+
+// NewArtifactHandler creates a new artifact handler serving fs under prefix
+func NewArtifactHandler(prefix string, fs webdav.FileSystem) *ArtifactHandler {
+	return &ArtifactHandler{
+		dav: &webdav.Handler{
+			Prefix:     prefix,
+			FileSystem: fs,
+			LockSystem: webdav.NewMemLS(),
+		},
+	}
+}
+
+// ServeDAV handles the WebDAV requests under the prefix, refusing those that would write
+func (h *ArtifactHandler) ServeDAV(c *gin.Context) {
+	switch c.Request.Method {
+	case http.MethodOptions:
+		// Class 1 only: without writes there is nothing to lock
+		c.Header("DAV", "1")
+		c.Header("Allow", artifactMethods)
+		c.Status(http.StatusOK)
+	case http.MethodGet, http.MethodHead, "PROPFIND":
+		h.dav.ServeHTTP(c.Writer, c.Request)
+	default:
+		c.Header("Allow", artifactMethods)
+		c.JSON(http.StatusMethodNotAllowed, gin.H{"error": "artifacts are read-only"})
+	}
+}
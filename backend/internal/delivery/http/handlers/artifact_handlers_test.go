@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"library-management-system/internal/infrastructure/davfs"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactHandler_ServeDAV(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "books.csv"), []byte("title\n"), 0o644))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewArtifactHandler("/dav", davfs.New(map[string]string{"exports": dir}))
+	router.Any("/dav/*path", handler.ServeDAV)
+	router.Handle("PROPFIND", "/dav/*path", handler.ServeDAV)
+	router.Handle("MKCOL", "/dav/*path", handler.ServeDAV)
+
+	for _, tc := range []struct {
+		method string
+		path   string
+		status int
+	}{
+		{http.MethodOptions, "/dav/", http.StatusOK},
+		{http.MethodGet, "/dav/exports/books.csv", http.StatusOK},
+		{"PROPFIND", "/dav/exports/", http.StatusMultiStatus},
+		{http.MethodPut, "/dav/exports/books.csv", http.StatusMethodNotAllowed},
+		{http.MethodDelete, "/dav/exports/books.csv", http.StatusMethodNotAllowed},
+		{"MKCOL", "/dav/exports/new/", http.StatusMethodNotAllowed},
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, strings.NewReader("")))
+		assert.Equal(t, tc.status, w.Code, "%s %s", tc.method, tc.path)
+		if tc.status == http.StatusMethodNotAllowed || tc.method == http.MethodOptions {
+			assert.Equal(t, artifactMethods, w.Header().Get("Allow"))
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "books.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "title\n", string(data))
+}
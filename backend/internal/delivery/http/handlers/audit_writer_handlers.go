@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditBuffer is a write-behind buffer of audit entries
+type AuditBuffer interface {
+	Stats() entities.AuditWriterStats
+}
+
+// AuditWriterHandler handles HTTP requests about the write-behind buffer of audit entries
+type AuditWriterHandler struct {
+	buffer AuditBuffer
+}
+
+// NewAuditWriterHandler creates a new audit writer handler; buffer is nil when audit entries are
+// written during requests
+func NewAuditWriterHandler(buffer AuditBuffer) *AuditWriterHandler {
+	return &AuditWriterHandler{
+		buffer: buffer,
+	}
+}
+
+// GetAuditWriter handles GET /api/admin/audit-writer
+// @Summary Get the audit writer
+// @Description Report how many audit entries wait to be written, how many were written and how many were dropped because the buffer was full or the database kept failing, since the server started
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} entities.AuditWriterStats
+// @Router /admin/audit-writer [get]
+func (h *AuditWriterHandler) GetAuditWriter(c *gin.Context) {
+	if h.buffer == nil {
+		c.JSON(http.StatusOK, entities.AuditWriterStats{})
+		return
+	}
+	c.JSON(http.StatusOK, h.buffer.Stats())
+}
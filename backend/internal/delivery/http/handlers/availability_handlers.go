@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AvailabilityPoll is the response of an availability long poll
+type AvailabilityPoll struct {
+	usecase.BookAvailability
+	// Changed is false when the poll timed out without a change
+	Changed bool `json:"changed"`
+}
+
+// AvailabilityHandler handles HTTP requests for book availability
+type AvailabilityHandler struct {
+	availabilityUseCase *usecase.AvailabilityUseCase
+	maxTimeout          time.Duration
+}
+
+// NewAvailabilityHandler creates a new availability handler holding polls for at most maxTimeout
+func NewAvailabilityHandler(availabilityUseCase *usecase.AvailabilityUseCase, maxTimeout time.Duration) *AvailabilityHandler {
+	return &AvailabilityHandler{
+		availabilityUseCase: availabilityUseCase,
+		maxTimeout:          maxTimeout,
+	}
+}
+
+// PollAvailability handles GET /api/books/:id/availability/poll
+// @Summary Wait for a book's availability to change
+// @Description Long poll for clients that cannot use streaming: the request is held until the availability differs from the one the client knows, or the timeout elapses
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param available query bool false "Availability known to the client; without it the poll waits for the next change"
+// @Param timeout query int false "Seconds to wait, capped by the server"
+// @Success 200 {object} handlers.AvailabilityPoll
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/{id}/availability/poll [get]
+func (h *AvailabilityHandler) PollAvailability(c *gin.Context) {
+	var known *bool
+	if value := c.Query("available"); value != "" {
+		available, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid available"})
+			return
+		}
+		known = &available
+	}
+
+	timeout := h.maxTimeout
+	if value := c.Query("timeout"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid timeout"})
+			return
+		}
+		if requested := time.Duration(seconds) * time.Second; requested < timeout {
+			timeout = requested
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	defer cancel()
+	availability, changed, err := h.availabilityUseCase.WaitForChange(ctx, c.Param("id"), known)
+	if err != nil {
+		if err.Error() == "book not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, AvailabilityPoll{BookAvailability: *availability, Changed: changed})
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"library-management-system/internal/domain/domainerr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Overall statuses of a batch
+const (
+	BatchSucceeded = "succeeded"
+	BatchPartial   = "partial"
+	BatchFailed    = "failed"
+)
+
+// BatchResponse is the multi-status summary batch endpoints answer with, alongside the outcome of
+// each item. The response is 200 when every item succeeded and 207 Multi-Status otherwise.
+type BatchResponse struct {
+	// succeeded when every item did, failed when none did, partial otherwise. Atomic batches are
+	// succeeded or failed, as nothing is written when an item fails.
+	Status    string `json:"status" example:"partial"`
+	Atomic    bool   `json:"atomic"`
+	Total     int    `json:"total"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+}
+
+// BatchItem is the outcome of an item of a batch
+type BatchItem struct {
+	// Index is the position of the item in the batch
+	Index int `json:"index"`
+	// Status is the HTTP status the item would have had on its own; 424 for items of an atomic
+	// batch left unwritten because others failed
+	Status int `json:"status" example:"201"`
+	// Code is the error code of failed items, when it is in the error catalog
+	Code  string `json:"code,omitempty" example:"duplicate_isbn"`
+	Error string `json:"error,omitempty"`
+}
+
+// failed reports whether the item failed
+func (i BatchItem) failed() bool {
+	return i.Status >= http.StatusBadRequest
+}
+
+// batchItemError describes an item that failed with an error message, taking its status and code
+// from the error catalog, else the given status
+func batchItemError(index int, message string, status int) BatchItem {
+	item := BatchItem{Index: index, Status: status, Error: message}
+	if e, ok := domainerr.ByMessage(message); ok {
+		item.Status, item.Code = e.Status, e.Code
+	}
+	return item
+}
+
+// batchItemAborted describes a valid item left unwritten because others of its atomic batch failed
+func batchItemAborted(index int) BatchItem {
+	return batchItemError(index, domainerr.ErrBatchAborted.Error(), http.StatusFailedDependency)
+}
+
+// newBatchResponse sums up the outcome of the items of a batch
+func newBatchResponse(atomic bool, items []BatchItem) BatchResponse {
+	response := BatchResponse{Atomic: atomic, Total: len(items)}
+	for _, item := range items {
+		if item.failed() {
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+	}
+	switch {
+	case response.Failed == 0:
+		response.Status = BatchSucceeded
+	case response.Succeeded == 0:
+		response.Status = BatchFailed
+	default:
+		response.Status = BatchPartial
+	}
+	return response
+}
+
+// httpStatus is the status of the response of a batch
+func (r BatchResponse) httpStatus() int {
+	if r.Failed > 0 {
+		return http.StatusMultiStatus
+	}
+	return http.StatusOK
+}
+
+// atomicBatch reports whether the request asks with the atomic query parameter for its batch to
+// be written all or nothing
+func atomicBatch(c *gin.Context) (bool, error) {
+	value := c.Query("atomic")
+	if value == "" {
+		return false, nil
+	}
+	atomic, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errors.New("atomic must be true or false")
+	}
+	return atomic, nil
+}
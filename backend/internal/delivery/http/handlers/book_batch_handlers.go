@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBookIDBatch bounds the books of a batch deletion or restoration
+const maxBookIDBatch = 1000
+
+// BookIDBatchRequest represents the request body for deleting or restoring books by ID
+type BookIDBatchRequest struct {
+	IDs []string `json:"ids" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// BookBatchChangeResponse reports what a batch deletion or restoration did with one of its
+// books: 200 when changed, the status of its error when rejected, and 424 when left unchanged by
+// an atomic batch
+type BookBatchChangeResponse struct {
+	BatchItem
+	ID string `json:"id"`
+	// deleted, restored, rejected or aborted
+	Result string `json:"result"`
+}
+
+// BookBatchChangeBatchResponse is the multi-status result of a batch deletion or restoration
+type BookBatchChangeBatchResponse struct {
+	BatchResponse
+	Items []BookBatchChangeResponse `json:"items"`
+}
+
+// BatchDeleteBooks handles POST /api/books/batch-delete
+// @Summary Delete books by ID
+// @Description Soft delete up to 1000 books at once, such as those of a bad import. Returns 200 when every book was deleted, else 207 Multi-Status with the status of each: missing and already deleted books get 404, books of another branch than the librarian's 403, and books out on loan 409 book_on_loan. The others are deleted in one transaction, or with atomic=true none is unless all can be. Each deletion is audited.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param ids body BookIDBatchRequest true "IDs of the books"
+// @Param atomic query bool false "Delete none of the books unless every one can be"
+// @Success 200 {object} handlers.BookBatchChangeBatchResponse
+// @Success 207 {object} handlers.BookBatchChangeBatchResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/batch-delete [post]
+func (h *BookHandler) BatchDeleteBooks(c *gin.Context) {
+	h.changeBooks(c, h.bookUseCase.As(caller(c)).DeleteBooks)
+}
+
+// BatchRestoreBooks handles POST /api/books/batch-restore
+// @Summary Restore books by ID
+// @Description Restore up to 1000 soft-deleted books at once. Returns 200 when every book was restored, else 207 Multi-Status with the status of each: missing books get 404, books that are not deleted 409 book_not_deleted, and books of another branch than the librarian's 403. The others are restored in one transaction, or with atomic=true none is unless all can be. Each restoration is audited.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param ids body BookIDBatchRequest true "IDs of the books"
+// @Param atomic query bool false "Restore none of the books unless every one can be"
+// @Success 200 {object} handlers.BookBatchChangeBatchResponse
+// @Success 207 {object} handlers.BookBatchChangeBatchResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/batch-restore [post]
+func (h *BookHandler) BatchRestoreBooks(c *gin.Context) {
+	h.changeBooks(c, h.bookUseCase.As(caller(c)).RestoreBooks)
+}
+
+// changeBooks answers a batch deletion or restoration made by change
+func (h *BookHandler) changeBooks(c *gin.Context, change func(ids []string, atomic bool) ([]usecase.BookBatchResult, error)) {
+	atomic, err := atomicBatch(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req BookIDBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(req.IDs) == 0 || len(req.IDs) > maxBookIDBatch {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("between 1 and %d book IDs are required", maxBookIDBatch)})
+		return
+	}
+
+	results, err := change(req.IDs, atomic)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	responses := make([]BookBatchChangeResponse, len(results))
+	items := make([]BatchItem, len(results))
+	for i, result := range results {
+		switch result.Result {
+		case usecase.BookBatchRejected:
+			items[i] = batchItemError(result.Index, result.Error, http.StatusBadRequest)
+		case usecase.BookBatchAborted:
+			items[i] = batchItemAborted(result.Index)
+		default:
+			items[i] = BatchItem{Index: result.Index, Status: http.StatusOK}
+		}
+		responses[i] = BookBatchChangeResponse{BatchItem: items[i], ID: result.ID, Result: result.Result}
+	}
+	response := BookBatchChangeBatchResponse{BatchResponse: newBatchResponse(atomic, items), Items: responses}
+	c.JSON(response.httpStatus(), response)
+}
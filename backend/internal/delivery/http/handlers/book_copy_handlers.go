@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AddBookCopyRequest represents the request body for adding a copy of a book
+type AddBookCopyRequest struct {
+	// BranchID is the branch holding the copy; by default the branch of the librarian, else of the book
+	BranchID *string `json:"branch_id"`
+}
+
+// BookCopyLookupResponse is a copy found by its accession number, with its book
+type BookCopyLookupResponse struct {
+	Copy entities.BookCopy `json:"copy"`
+	Book BookResponse      `json:"book"`
+}
+
+// GetBookCopies handles GET /api/books/:id/copies
+// @Summary List the copies of a book
+// @Description Retrieve the physical copies of a book by accession number
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Success 200 {array} entities.BookCopy
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/{id}/copies [get]
+func (h *BookHandler) GetBookCopies(c *gin.Context) {
+	copies, err := h.bookUseCase.ListCopies(c.Param("id"))
+	if err != nil {
+		writeCopyError(c, err, http.StatusInternalServerError)
+		return
+	}
+	if copies == nil {
+		copies = []entities.BookCopy{}
+	}
+
+	c.JSON(http.StatusOK, copies)
+}
+
+// AddBookCopy handles POST /api/books/:id/copies
+// @Summary Add a copy of a book
+// @Description Add a physical copy of a book, numbered with the next accession number of its branch: the branch's accession prefix, else ACCESSION_PREFIX, followed by a number padded to ACCESSION_DIGITS. Librarians of a branch can only add copies to theirs.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param copy body AddBookCopyRequest false "Branch of the copy"
+// @Success 201 {object} entities.BookCopy
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 403 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /books/{id}/copies [post]
+func (h *BookHandler) AddBookCopy(c *gin.Context) {
+	var req AddBookCopyRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	bookCopy := &entities.BookCopy{BranchID: req.BranchID}
+	if err := h.bookUseCase.As(caller(c)).AddCopy(c.Param("id"), bookCopy); err != nil {
+		writeCopyError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusCreated, bookCopy)
+}
+
+// GetCopy handles GET /api/copies/:accessionNumber
+// @Summary Look a copy up by accession number
+// @Description Retrieve the copy with an accession number, e.g. scanned from its label, and its book
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param accessionNumber path string true "Accession number"
+// @Success 200 {object} handlers.BookCopyLookupResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /copies/{accessionNumber} [get]
+func (h *BookHandler) GetCopy(c *gin.Context) {
+	bookCopy, book, err := h.bookUseCase.FindCopyByAccessionNumber(c.Param("accessionNumber"))
+	if err != nil {
+		writeCopyError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, BookCopyLookupResponse{Copy: *bookCopy, Book: newBookResponse(*book, bookView{})})
+}
+
+// writeCopyError writes a failed copy request; defined errors keep their status
+func writeCopyError(c *gin.Context, err error, status int) {
+	if e, ok := domainerr.Lookup(err); ok {
+		c.Error(err)
+		c.JSON(e.Status, gin.H{"error": e.Error()})
+		return
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
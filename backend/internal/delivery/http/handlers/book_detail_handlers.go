@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BookDetailResponse is a book with everything its page shows about it
+// swagger:model BookDetailResponse
+type BookDetailResponse struct {
+	Book BookResponse `json:"book"`
+	// Authors credited on the book, split from its author
+	// example: ["Terry Pratchett","Neil Gaiman"]
+	Authors []string `json:"authors"`
+	// Category of the book from its metadata, empty when it has none
+	// example: ["Fantasy"]
+	Categories []string `json:"categories"`
+	// Publisher of the book, null when it names none
+	Publisher *entities.Publisher `json:"publisher"`
+	// Series of the book, null when it is not part of one
+	Series       *entities.Series          `json:"series"`
+	Identifiers  []entities.BookIdentifier `json:"identifiers"`
+	Copies       []entities.BookCopy       `json:"copies"`
+	Availability usecase.Holding           `json:"availability"`
+}
+
+// BookDetailHandler handles HTTP requests for the detail of books
+type BookDetailHandler struct {
+	bookDetailUseCase *usecase.BookDetailUseCase
+}
+
+// NewBookDetailHandler creates a new book detail handler
+func NewBookDetailHandler(bookDetailUseCase *usecase.BookDetailUseCase) *BookDetailHandler {
+	return &BookDetailHandler{
+		bookDetailUseCase: bookDetailUseCase,
+	}
+}
+
+// GetBookDetail handles GET /api/books/:id/full
+// @Summary Get the detail of a book
+// @Description Return a book with its authors, categories, publisher, series, identifiers, copies and availability in one response, which a book page would otherwise load with several requests
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param tz query string false "Timezone (IANA name or UTC offset) to render the timestamps of the book in"
+// @Success 200 {object} handlers.BookDetailResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/{id}/full [get]
+func (h *BookDetailHandler) GetBookDetail(c *gin.Context) {
+	location, err := requestLocation(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	detail, err := h.bookDetailUseCase.GetBookDetail(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if detail == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "book not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, BookDetailResponse{
+		Book:         newBookResponse(detail.Book, bookView{location: location}),
+		Authors:      detail.Authors,
+		Categories:   detail.Categories,
+		Publisher:    detail.Publisher,
+		Series:       detail.Series,
+		Identifiers:  detail.Identifiers,
+		Copies:       detail.Copies,
+		Availability: detail.Availability,
+	})
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SaveBookDraft handles PUT /api/books/:id/draft
+// @Summary Save a book draft
+// @Description Store proposed changes to a book without touching the live record, replacing the book's previous draft. Drafts are validated like updates.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param draft body UpdateBookRequest true "Proposed book information"
+// @Success 200 {object} entities.BookDraft
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 403 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
+// @Router /books/{id}/draft [put]
+func (h *BookHandler) SaveBookDraft(c *gin.Context) {
+	var req UpdateBookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.validateMetadata(c, req.Metadata); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	draft, err := h.bookUseCase.As(caller(c)).SaveDraft(c.Param("id"), &entities.Book{
+		Title:          req.Title,
+		Author:         req.Author,
+		Year:           req.Year,
+		ISBN:           req.ISBN,
+		Description:    req.Description,
+		PublisherID:    req.PublisherID,
+		SeriesID:       req.SeriesID,
+		SeriesPosition: req.SeriesPosition,
+		Metadata:       req.Metadata,
+	})
+	if err != nil {
+		c.JSON(draftErrorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
+
+// GetBookDraft handles GET /api/books/:id/draft
+// @Summary Get a book draft
+// @Description Retrieve the draft of a book with its changes to the live record, each as from and to values
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Success 200 {object} entities.BookDraft
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/{id}/draft [get]
+func (h *BookHandler) GetBookDraft(c *gin.Context) {
+	draft, err := h.bookUseCase.GetDraft(c.Param("id"))
+	if err != nil {
+		c.JSON(draftErrorStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
+
+// PublishBookDraft handles POST /api/books/:id/draft/publish
+// @Summary Publish a book draft
+// @Description Apply the draft of a book to the live record and delete the draft, atomically. Drafts of books updated since the draft was saved are refused with 409.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Success 200 {object} handlers.BookResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 403 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
+// @Router /books/{id}/draft/publish [post]
+func (h *BookHandler) PublishBookDraft(c *gin.Context) {
+	book, err := h.bookUseCase.As(caller(c)).PublishDraft(c.Param("id"))
+	if err != nil {
+		c.JSON(draftErrorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	view := h.view(c, nil)
+	writeBook(c, http.StatusOK, view, newBookResponse(*book, view))
+}
+
+// DiscardBookDraft handles DELETE /api/books/:id/draft
+// @Summary Discard a book draft
+// @Description Delete the draft of a book, leaving the live record as it is
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Success 200 {object} handlers.MessageResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 403 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /books/{id}/draft [delete]
+func (h *BookHandler) DiscardBookDraft(c *gin.Context) {
+	if err := h.bookUseCase.As(caller(c)).DiscardDraft(c.Param("id")); err != nil {
+		c.JSON(draftErrorStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "book draft discarded successfully"})
+}
+
+// draftErrorStatus maps the errors of draft operations to their status, fallback for the others
+func draftErrorStatus(err error, fallback int) int {
+	switch err.Error() {
+	case "book not found", "book draft not found":
+		return http.StatusNotFound
+	case "book changed since the draft was saved", "book is archived", "book with this ISBN was deleted":
+		return http.StatusConflict
+	case "book belongs to another branch":
+		return http.StatusForbidden
+	default:
+		return fallback
+	}
+}
@@ -1,8 +1,20 @@
 package handlers
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
+	"strings"
+	"time"
 
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/delivery/http/urlbuilder"
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
 	"library-management-system/internal/domain/entities"
 	"library-management-system/internal/usecase"
 
@@ -12,47 +24,236 @@ import (
 // BookHandler handles HTTP requests for books
 type BookHandler struct {
 	bookUseCase *usecase.BookUseCase
+	clock       clock.Clock
+	// links builds the canonical and hypermedia links of books; canonical links are omitted
+	// without a public base URL
+	links *urlbuilder.Builder
+	// metadata checks book metadata against the fields of the calling tenant when set
+	metadata *usecase.MetadataUseCase
+	// views counts the books read one at a time, for ranking search results by popularity
+	views ViewRecorder
+	// importProfiles reads CSV imports when set
+	importProfiles *usecase.ImportProfileUseCase
+	// importRuns keeps the history of imports and catches files imported twice when set
+	importRuns *usecase.ImportRunUseCase
+	// scans checks imported files for malware when set
+	scans *usecase.UploadScanUseCase
 }
 
+// ViewRecorder counts the views of books, once per visitor in a while
+type ViewRecorder interface {
+	RecordView(bookID, visitor string) bool
+}
+
+// sortPopularity orders search results by popularity, most popular first
+const sortPopularity = "popularity"
+
 // NewBookHandler creates a new book handler
 func NewBookHandler(bookUseCase *usecase.BookUseCase) *BookHandler {
 	return &BookHandler{
 		bookUseCase: bookUseCase,
+		clock:       clock.System{},
+	}
+}
+
+// SetClock replaces the clock used to compute derived response fields
+func (h *BookHandler) SetClock(c clock.Clock) {
+	h.clock = c
+}
+
+// SetLinks sets how the links of books are built, under the API prefix: the HAL links of books
+// and, when links are absolute, the Link rel="canonical" header of book detail responses
+// pointing at the slug URL of the book.
+func (h *BookHandler) SetLinks(links *urlbuilder.Builder) {
+	h.links = links
+}
+
+// SetMetadataUseCase enables checking the metadata of books against the fields the calling tenant
+// defined
+func (h *BookHandler) SetMetadataUseCase(metadataUseCase *usecase.MetadataUseCase) {
+	h.metadata = metadataUseCase
+}
+
+// SetViewRecorder enables counting the views of books read by ID or slug
+func (h *BookHandler) SetViewRecorder(views ViewRecorder) {
+	h.views = views
+}
+
+// SetImportProfileUseCase enables CSV imports, read with the import profiles of the calling tenant
+func (h *BookHandler) SetImportProfileUseCase(importProfileUseCase *usecase.ImportProfileUseCase) {
+	h.importProfiles = importProfileUseCase
+}
+
+// SetImportRunUseCase enables recording each import in the import history, and rejecting or
+// warning about files imported again
+func (h *BookHandler) SetImportRunUseCase(importRunUseCase *usecase.ImportRunUseCase) {
+	h.importRuns = importRunUseCase
+}
+
+// SetUploadScanUseCase enables scanning imported files for malware before reading them
+func (h *BookHandler) SetUploadScanUseCase(scans *usecase.UploadScanUseCase) {
+	h.scans = scans
+}
+
+// validateMetadata checks the metadata of a book against the fields of the calling tenant
+func (h *BookHandler) validateMetadata(c *gin.Context, metadata map[string]interface{}) error {
+	if h.metadata == nil {
+		return nil
+	}
+	return h.metadata.Validate(c.GetHeader(middleware.TenantHeader), metadata)
+}
+
+// metadataQueryPrefix prefixes the search parameters filtering on metadata, e.g. meta.shelf_code=A12
+const metadataQueryPrefix = "meta."
+
+// metadataFilters returns the metadata filters of a search request by key
+func metadataFilters(c *gin.Context) map[string]string {
+	filters := make(map[string]string)
+	for name, values := range c.Request.URL.Query() {
+		if key := strings.TrimPrefix(name, metadataQueryPrefix); key != name && key != "" && len(values) > 0 {
+			filters[key] = values[0]
+		}
+	}
+	return filters
+}
+
+// ExpensiveBookSearch reports whether a book search request has a title or author term that is
+// slow to search, so it can be throttled
+func ExpensiveBookSearch(c *gin.Context) bool {
+	return usecase.IsExpensiveSearchTerm(c.Query("title")) || usecase.IsExpensiveSearchTerm(c.Query("author"))
+}
+
+// largeBodyBytes is the body size from which bulk book writes count as expensive
+const largeBodyBytes = 256 << 10
+
+// LargeBookBatch reports whether a bulk book write, such as an import, has a body large enough to
+// be throttled as an expensive operation; bodies of unknown length are assumed large
+func LargeBookBatch(c *gin.Context) bool {
+	return c.Request.ContentLength < 0 || c.Request.ContentLength >= largeBodyBytes
+}
+
+// recordView counts a view of a book when views are counted
+func (h *BookHandler) recordView(c *gin.Context, book *entities.Book) {
+	if h.views != nil {
+		h.views.RecordView(book.ID, viewVisitor(c))
 	}
 }
 
+// viewVisitor returns who a book view is deduplicated by: the caller, else the X-Session-ID the
+// client keeps, else its IP
+func viewVisitor(c *gin.Context) string {
+	if caller := callerID(c); caller != "" {
+		return "user:" + caller
+	}
+	if session := c.GetHeader("X-Session-ID"); session != "" {
+		return "session:" + session
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// setCanonicalLink adds the canonical Link header of a book, so that requests by ID and by slug,
+// in every API version, name the same resource
+func (h *BookHandler) setCanonicalLink(c *gin.Context, book *entities.Book) {
+	if !h.links.Absolute() || book.Slug == "" {
+		return
+	}
+	c.Header("Link", "<"+h.links.URL("/books/by-slug/"+book.Slug)+">; rel=\"canonical\"")
+}
+
+// view builds the response view requested by the client
+func (h *BookHandler) view(c *gin.Context, location *time.Location) bookView {
+	view := bookView{location: location}
+	if includes(c, includeComputed) {
+		view.computedAt = h.clock.Now()
+	}
+	c.Header("Vary", "Accept")
+	if strings.Contains(c.GetHeader("Accept"), HALMediaType) {
+		view.links = h.links
+	}
+	return view
+}
+
+// trashView builds the response view of the trash endpoint, which exposes deletion times
+func (h *BookHandler) trashView(c *gin.Context, location *time.Location) bookView {
+	view := h.view(c, location)
+	view.trash = true
+	return view
+}
+
+// collapseByWork reports whether the client asked for one result per work
+func collapseByWork(c *gin.Context) bool {
+	return c.Query("collapse") == "work"
+}
+
 // CreateBookRequest represents the request body for creating a book
 type CreateBookRequest struct {
-	Title  string `json:"title" binding:"required"`
-	Author string `json:"author" binding:"required"`
-	Year   int    `json:"year" binding:"required"`
-	ISBN   string `json:"isbn" binding:"required"`
+	Title          string  `json:"title" binding:"required"`
+	Author         string  `json:"author" binding:"required"`
+	Year           int     `json:"year" binding:"required"`
+	ISBN           string  `json:"isbn" binding:"required"`
+	Description    string  `json:"description"`
+	PublisherID    *string `json:"publisher_id"`
+	SeriesID       *string `json:"series_id"`
+	SeriesPosition *int    `json:"series_position"`
+	// Branch the book belongs to; librarians of a branch may leave it out to use theirs
+	BranchID *string `json:"branch_id"`
+	// Hides the book from public listings until this time, RFC 3339; a past time publishes right away
+	PublishAt *time.Time `json:"publish_at"`
+	// draft or active (the default)
+	Status string `json:"status"`
+	// Custom fields as string, number or boolean values; tenants can restrict them with metadata fields
+	Metadata map[string]interface{} `json:"metadata"`
 }
 
 // UpdateBookRequest represents the request body for updating a book
 type UpdateBookRequest struct {
-	Title  string `json:"title" binding:"required"`
-	Author string `json:"author" binding:"required"`
-	Year   int    `json:"year" binding:"required"`
-	ISBN   string `json:"isbn" binding:"required"`
+	Title          string  `json:"title" binding:"required"`
+	Author         string  `json:"author" binding:"required"`
+	Year           int     `json:"year" binding:"required"`
+	ISBN           string  `json:"isbn" binding:"required"`
+	Description    string  `json:"description"`
+	PublisherID    *string `json:"publisher_id"`
+	SeriesID       *string `json:"series_id"`
+	SeriesPosition *int    `json:"series_position"`
+	// Moves the book to another branch; left out, the book keeps its branch
+	BranchID *string `json:"branch_id"`
+	// Custom fields as string, number or boolean values; tenants can restrict them with metadata fields
+	Metadata map[string]interface{} `json:"metadata"`
 }
 
 // GetBooks handles GET /api/books
 // @Summary Get all books
-// @Description Retrieve all books from the library
+// @Description Retrieve all books from the library, except those scheduled for later publication
 // @Tags books
 // @Accept json
 // @Produce json
-// @Success 200 {array} entities.Book
+// @Produce application/hal+json
+// @Param tz query string false "Timezone (IANA name or UTC offset) to render timestamps in"
+// @Param include query string false "Optional field groups, e.g. computed"
+// @Param collapse query string false "Set to work to return one edition per work"
+// @Param Accept header string false "application/hal+json adds hypermedia links to the books"
+// @Success 200 {array} handlers.BookResponse
+// @Failure 400 {object} handlers.ErrorResponse
 // @Router /books [get]
 func (h *BookHandler) GetBooks(c *gin.Context) {
+	location, err := requestLocation(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	books, err := h.bookUseCase.GetAllBooks()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, books)
+	view := h.view(c, location)
+	if collapseByWork(c) {
+		books, view.editionCounts = usecase.CollapseByWork(books)
+	}
+
+	writeBooks(c, http.StatusOK, view, newBookResponses(books, view))
 }
 
 // CreateBook handles POST /api/books
@@ -61,9 +262,14 @@ func (h *BookHandler) GetBooks(c *gin.Context) {
 // @Tags books
 // @Accept json
 // @Produce json
+// @Produce application/hal+json
 // @Param book body CreateBookRequest true "Book information"
-// @Success 201 {object} entities.Book
+// @Param include query string false "Optional field groups, e.g. computed"
+// @Param Accept header string false "application/hal+json adds hypermedia links to the books"
+// @Success 201 {object} handlers.BookResponse
 // @Failure 400 {object} handlers.ErrorResponse
+// @Failure 403 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
 // @Failure 500 {object} handlers.ErrorResponse
 // @Router /books [post]
 func (h *BookHandler) CreateBook(c *gin.Context) {
@@ -74,18 +280,489 @@ func (h *BookHandler) CreateBook(c *gin.Context) {
 	}
 
 	book := &entities.Book{
-		Title:  req.Title,
-		Author: req.Author,
-		Year:   req.Year,
-		ISBN:   req.ISBN,
+		Title:          req.Title,
+		Author:         req.Author,
+		Year:           req.Year,
+		ISBN:           req.ISBN,
+		Description:    req.Description,
+		PublisherID:    req.PublisherID,
+		SeriesID:       req.SeriesID,
+		SeriesPosition: req.SeriesPosition,
+		BranchID:       req.BranchID,
+		PublishAt:      req.PublishAt,
+		Status:         req.Status,
+		Metadata:       req.Metadata,
+	}
+
+	if err := h.validateMetadata(c, book.Metadata); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.bookUseCase.As(caller(c)).CreateBook(book); err != nil {
+		if h.deletedISBNConflict(c, err, book.ISBN) {
+			return
+		}
+		c.JSON(denialStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+		return
+	}
+
+	view := h.view(c, nil)
+	writeBook(c, http.StatusCreated, view, newBookResponse(*book, view))
+}
+
+// deletedISBNConflict answers a book written with the ISBN of a deleted book with 409 and the ID
+// of the deleted book, which can be restored instead. It reports whether err was such a conflict.
+func (h *BookHandler) deletedISBNConflict(c *gin.Context, err error, isbn string) bool {
+	if !errors.Is(err, domainerr.ErrISBNOfDeletedBook) {
+		return false
+	}
+	body := gin.H{"error": err.Error()}
+	if deleted, _ := h.bookUseCase.DeletedBookWithISBN(isbn); deleted != nil {
+		body["deleted_book_id"] = deleted.ID
+	}
+	c.JSON(http.StatusConflict, body)
+	return true
+}
+
+// ImportBooksRequest represents the request body for importing books in bulk
+type ImportBooksRequest struct {
+	// skip (the default) keeps the existing book when an ISBN is taken, upsert overwrites it
+	OnConflict string              `json:"on_conflict" binding:"omitempty,oneof=skip upsert" example:"skip"`
+	Books      []ImportBookRequest `json:"books" binding:"required,min=1,max=5000"`
+}
+
+// ImportBookRequest represents a book of a bulk import or upsert; books are placed in series one by one
+type ImportBookRequest struct {
+	Title       string  `json:"title"`
+	Author      string  `json:"author"`
+	Year        int     `json:"year"`
+	ISBN        string  `json:"isbn"`
+	PublisherID *string `json:"publisher_id"`
+	// draft or active (the default)
+	Status   string                 `json:"status"`
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+// book builds the book of the request
+func (r ImportBookRequest) book() entities.Book {
+	return entities.Book{
+		Title:       r.Title,
+		Author:      r.Author,
+		Year:        r.Year,
+		ISBN:        r.ISBN,
+		PublisherID: r.PublisherID,
+		Status:      r.Status,
+		Metadata:    r.Metadata,
+	}
+}
+
+// ImportBooks handles POST /api/books/import
+// @Summary Import books in bulk
+// @Description Create up to 5000 books at once with batch inserts. Invalid books are rejected one by one and the others imported; books whose ISBN is taken are skipped, or overwritten with on_conflict=upsert. A text/csv body is read with the import profile of the tenant named by profile, or with field names as headers. A file imported again within the duplicate window is rejected with 409, or let through with a Warning header. With a malware scanner configured, files it finds malware in or fails to check may be rejected with 422 or 503. With Accept: application/x-ndjson the progress is streamed after each chunk written, ending with the result. The result is 200 when no book was rejected, else 207 Multi-Status, with the status of each book; with atomic=true no book is imported unless all are valid.
+// @Tags books
+// @Accept json
+// @Accept text/csv
+// @Produce json
+// @Produce application/x-ndjson
+// @Param books body ImportBooksRequest true "Books to import"
+// @Param X-Tenant-ID header string false "Tenant ID, required with a profile"
+// @Param profile query string false "Import profile of a CSV import"
+// @Param on_conflict query string false "skip or upsert, for a CSV import"
+// @Param atomic query bool false "Import none of the books unless all are valid"
+// @Success 200 {object} handlers.BookImportResponse
+// @Success 207 {object} handlers.BookImportResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
+// @Failure 422 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Failure 503 {object} handlers.ErrorResponse
+// @Router /books/import [post]
+func (h *BookHandler) ImportBooks(c *gin.Context) {
+	run, err := h.startImportRun(c)
+	if e, ok := domainerr.Lookup(err); ok {
+		// The file was refused by the malware scan
+		h.finishImportRun(run, nil, err)
+		c.Error(err)
+		c.JSON(e.Status, gin.H{"error": e.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	if err := h.bookUseCase.CreateBook(book); err != nil {
+	var books []entities.Book
+	var onConflict string
+	if run.Format == entities.ImportFormatCSV {
+		books, onConflict, err = h.readCSVImport(c)
+	} else {
+		books, onConflict, err = h.readJSONImport(c)
+	}
+	if err != nil {
+		h.finishImportRun(run, nil, err)
+		status := http.StatusBadRequest
+		if errors.Is(err, domainerr.ErrImportProfileNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if h.importRuns != nil {
+		previous, err := h.importRuns.CheckDuplicate(run.TenantID, run.FileHash)
+		if errors.Is(err, domainerr.ErrDuplicateImport) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "duplicate_of": previous.ID})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if previous != nil {
+			run.DuplicateOf = &previous.ID
+			c.Header(middleware.WarningHeader, fmt.Sprintf("299 - %q", "identical file was already imported by run "+previous.ID))
+		}
+	}
+
+	atomic, err := atomicBatch(c)
+	if err != nil {
+		h.finishImportRun(run, nil, err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, book)
+	if strings.Contains(c.GetHeader("Accept"), NDJSONMediaType) {
+		h.streamImport(c, run, books, onConflict, atomic)
+		return
+	}
+
+	result, err := h.bookUseCase.As(caller(c)).ImportBooks(books, onConflict, atomic)
+	h.finishImportRun(run, result, err)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	response := newBookImportResponse(result, atomic)
+	c.JSON(response.httpStatus(), response)
+}
+
+// BookImportResponse is the multi-status result of an import, with the outcome of each book
+type BookImportResponse struct {
+	BatchResponse
+	*usecase.BookImportResult
+	// Books imported or skipped are 200, rejected books 400 or the status of their error, and
+	// valid books of an aborted atomic import 424
+	Items []BatchItem `json:"items"`
+}
+
+// newBookImportResponse describes the outcome of each book of an import
+func newBookImportResponse(result *usecase.BookImportResult, atomic bool) BookImportResponse {
+	items := make([]BatchItem, result.Received)
+	for i := range items {
+		items[i] = BatchItem{Index: i, Status: http.StatusOK}
+		if result.Aborted {
+			items[i] = batchItemAborted(i)
+		}
+	}
+	for _, rejected := range result.Rejected {
+		items[rejected.Index] = batchItemError(rejected.Index, rejected.Error, http.StatusBadRequest)
+	}
+	return BookImportResponse{BatchResponse: newBatchResponse(atomic, items), BookImportResult: result, Items: items}
+}
+
+// NDJSONMediaType in the Accept header of an import streams its progress as newline-delimited
+// JSON ImportEvents instead of answering once it is done
+const NDJSONMediaType = "application/x-ndjson"
+
+// Types of ImportEvent
+const (
+	ImportEventProgress = "progress"
+	ImportEventResult   = "result"
+	ImportEventError    = "error"
+)
+
+// ImportEvent is a line of the progress stream of an import: progress once the books are
+// validated and after each chunk written, then the result, or the error that stopped the import
+type ImportEvent struct {
+	// progress, result or error
+	Type string `json:"type"`
+	*usecase.ImportProgress
+	Result *BookImportResponse `json:"result,omitempty"`
+	Error  string              `json:"error,omitempty"`
+}
+
+// streamImport imports books while streaming their progress. The status is sent before the
+// import starts, so an import failing partway ends the stream with an error event under 200.
+func (h *BookHandler) streamImport(c *gin.Context, run *entities.ImportRun, books []entities.Book, onConflict string, atomic bool) {
+	c.Header("Content-Type", NDJSONMediaType)
+	c.Status(http.StatusOK)
+	encoder := json.NewEncoder(c.Writer)
+	send := func(event ImportEvent) {
+		if err := encoder.Encode(event); err == nil {
+			c.Writer.Flush()
+		}
+	}
+
+	result, err := h.bookUseCase.As(caller(c)).ImportBooksWithProgress(books, onConflict, atomic, func(progress usecase.ImportProgress) {
+		send(ImportEvent{Type: ImportEventProgress, ImportProgress: &progress})
+	})
+	h.finishImportRun(run, result, err)
+	if err != nil {
+		send(ImportEvent{Type: ImportEventError, Error: err.Error()})
+		return
+	}
+	response := newBookImportResponse(result, atomic)
+	send(ImportEvent{Type: ImportEventResult, Result: &response})
+}
+
+// readJSONImport reads the books of a JSON import
+func (h *BookHandler) readJSONImport(c *gin.Context) ([]entities.Book, string, error) {
+	var req ImportBooksRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, "", err
+	}
+
+	books := make([]entities.Book, len(req.Books))
+	for i, book := range req.Books {
+		if err := h.validateMetadata(c, book.Metadata); err != nil {
+			return nil, "", fmt.Errorf("books[%d]: %w", i, err)
+		}
+		books[i] = book.book()
+	}
+	return books, req.OnConflict, nil
+}
+
+// readCSVImport reads the books of a CSV import with the import profile named by the profile
+// query parameter
+func (h *BookHandler) readCSVImport(c *gin.Context) ([]entities.Book, string, error) {
+	onConflict := c.Query("on_conflict")
+	if onConflict != "" && onConflict != "skip" && onConflict != "upsert" {
+		return nil, "", errors.New("on_conflict must be skip or upsert")
+	}
+
+	books, err := h.importProfiles.ReadBooks(c.GetHeader(middleware.TenantHeader), c.Query("profile"), c.Request.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	for i, book := range books {
+		if err := h.validateMetadata(c, book.Metadata); err != nil {
+			return nil, "", fmt.Errorf("row %d: %w", i+2, err)
+		}
+	}
+	return books, onConflict, nil
+}
+
+// startImportRun describes the import of the request. With import history kept or files
+// scanned, it reads the body to hash and scan it, and puts it back for the import to read. A file
+// refused by the scan is returned with its run and the error to record on it.
+func (h *BookHandler) startImportRun(c *gin.Context) (*entities.ImportRun, error) {
+	run := &entities.ImportRun{
+		TenantID: c.GetHeader(middleware.TenantHeader),
+		Format:   entities.ImportFormatJSON,
+	}
+	if c.ContentType() == "text/csv" && h.importProfiles != nil {
+		run.Format = entities.ImportFormatCSV
+		run.Profile = c.Query("profile")
+	}
+	if h.importRuns == nil && h.scans == nil {
+		return run, nil
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	run.FileHash = usecase.FileHash(body)
+	if h.scans != nil {
+		if run.Scan, err = h.scans.Scan("import file "+run.FileHash, body); err != nil {
+			return run, err
+		}
+	}
+	return run, nil
+}
+
+// finishImportRun records the import run with its result, or the error that failed it, and
+// gives the result its run ID. The import is not undone when the run cannot be recorded.
+func (h *BookHandler) finishImportRun(run *entities.ImportRun, result *usecase.BookImportResult, err error) {
+	if h.importRuns == nil {
+		return
+	}
+
+	if err != nil {
+		run.Status = entities.ImportRunFailed
+		run.Error = err.Error()
+	} else {
+		run.Status = entities.ImportRunCompleted
+		run.Received, run.Imported, run.Skipped = result.Received, result.Imported, result.Skipped
+		run.Rejected = len(result.Rejected)
+		for _, rejected := range result.Rejected {
+			run.Errors = append(run.Errors, entities.ImportRunError{Index: rejected.Index, ISBN: rejected.ISBN, Error: rejected.Error})
+		}
+	}
+	if err := h.importRuns.Record(run); err != nil {
+		log.Printf("Failed to record import run of file %s: %v", run.FileHash, err)
+		return
+	}
+	if result != nil {
+		result.RunID = run.ID
+		if run.DuplicateOf != nil {
+			result.DuplicateOf = *run.DuplicateOf
+		}
+	}
+}
+
+// maxUpsertBatch bounds the books of a batch upsert
+const maxUpsertBatch = 1000
+
+// UpsertBookRequest represents a book to upsert, with the version of it a sync client last saw
+type UpsertBookRequest struct {
+	ImportBookRequest
+	// The updated_at of the book when the client last synced it; the book is checked for
+	// conflicting changes on the server since then
+	BaseUpdatedAt *time.Time `json:"base_updated_at"`
+	// Fields the client changed since then, whose values conflict_policy=merge takes
+	ChangedFields []string `json:"changed_fields"`
+}
+
+// BookUpsertResponse reports what a batch upsert did with one of its books: 201 when created,
+// 200 when updated, 409 on a conflict, 400 or the status of its error when rejected, and 424 when
+// left unwritten by an atomic upsert
+type BookUpsertResponse struct {
+	BatchItem
+	ISBN string `json:"isbn"`
+	// created, updated, conflict, rejected or aborted
+	Result string        `json:"result"`
+	Book   *BookResponse `json:"book,omitempty"`
+	// Fields where the client and a book changed on the server since base_updated_at differ
+	Conflicts []usecase.FieldConflict `json:"conflicts,omitempty"`
+}
+
+// BookUpsertBatchResponse is the multi-status result of a batch upsert
+type BookUpsertBatchResponse struct {
+	BatchResponse
+	Items []BookUpsertResponse `json:"items"`
+}
+
+// upsertItem describes the outcome of a book of a batch upsert
+func upsertItem(result usecase.BookUpsertResult) BatchItem {
+	switch result.Result {
+	case usecase.BookUpsertCreated:
+		return BatchItem{Index: result.Index, Status: http.StatusCreated}
+	case usecase.BookUpsertUpdated:
+		return BatchItem{Index: result.Index, Status: http.StatusOK}
+	case usecase.BookUpsertConflict:
+		return batchItemError(result.Index, domainerr.ErrUpsertConflict.Error(), http.StatusConflict)
+	case usecase.BookUpsertAborted:
+		return batchItemAborted(result.Index)
+	default:
+		return batchItemError(result.Index, result.Error, http.StatusBadRequest)
+	}
+}
+
+// UpsertBooks handles PUT /api/books/upsert
+// @Summary Create or update books by ISBN
+// @Description Create a book, or overwrite the title, author, year, publisher and metadata of the book having its ISBN, for sync clients that do not know book IDs. A single book returns 201 when created and 200 when updated. An array of up to 1000 books returns 200 when every book was written, else 207 Multi-Status, with the status of each; invalid books are rejected one by one and the others written all or nothing, or with atomic=true none is written unless all can be. Books with a base_updated_at that changed on the server since then are resolved with conflict_policy.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param book body UpsertBookRequest true "Book, or an array of books"
+// @Param conflict_policy query string false "client-wins (default), server-wins or merge"
+// @Param atomic query bool false "Write none of a batch unless every book can be written"
+// @Success 200 {object} handlers.BookUpsertBatchResponse
+// @Success 207 {object} handlers.BookUpsertBatchResponse
+// @Success 201 {object} handlers.BookResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/upsert [put]
+func (h *BookHandler) UpsertBooks(c *gin.Context) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	batch := bytes.HasPrefix(bytes.TrimSpace(body), []byte("["))
+
+	policy := c.Query("conflict_policy")
+	switch policy {
+	case "", usecase.ConflictClientWins, usecase.ConflictServerWins, usecase.ConflictMerge:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "conflict_policy must be client-wins, server-wins or merge"})
+		return
+	}
+	atomic, err := atomicBatch(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var requests []UpsertBookRequest
+	if batch {
+		err = json.Unmarshal(body, &requests)
+	} else {
+		requests = make([]UpsertBookRequest, 1)
+		err = json.Unmarshal(body, &requests[0])
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(requests) == 0 || len(requests) > maxUpsertBatch {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("between 1 and %d books are required", maxUpsertBatch)})
+		return
+	}
+
+	upserts := make([]usecase.BookUpsert, len(requests))
+	for i, request := range requests {
+		if err := h.validateMetadata(c, request.Metadata); err != nil {
+			if batch {
+				err = fmt.Errorf("books[%d]: %w", i, err)
+			}
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		upserts[i] = usecase.BookUpsert{Book: request.book(), BaseUpdatedAt: request.BaseUpdatedAt, ChangedFields: request.ChangedFields}
+	}
+
+	results, err := h.bookUseCase.As(caller(c)).UpsertBooks(upserts, policy, atomic)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !batch {
+		result := results[0]
+		switch {
+		case result.Error == domainerr.ErrISBNOfDeletedBook.Error():
+			h.deletedISBNConflict(c, domainerr.ErrISBNOfDeletedBook, result.ISBN)
+		case result.Result == usecase.BookUpsertRejected:
+			c.JSON(http.StatusBadRequest, gin.H{"error": result.Error})
+		case result.Result == usecase.BookUpsertConflict:
+			c.JSON(http.StatusConflict, gin.H{"error": domainerr.ErrUpsertConflict.Error(), "conflicts": result.Conflicts})
+		case result.Result == usecase.BookUpsertCreated:
+			view := h.view(c, nil)
+			writeBook(c, http.StatusCreated, view, newBookResponse(*result.Book, view))
+		default:
+			view := h.view(c, nil)
+			writeBook(c, http.StatusOK, view, newBookResponse(*result.Book, view))
+		}
+		return
+	}
+
+	responses := make([]BookUpsertResponse, len(results))
+	items := make([]BatchItem, len(results))
+	for i, result := range results {
+		items[i] = upsertItem(result)
+		responses[i] = BookUpsertResponse{BatchItem: items[i], ISBN: result.ISBN, Result: result.Result, Conflicts: result.Conflicts}
+		if result.Book != nil {
+			book := newBookResponse(*result.Book, bookView{})
+			responses[i].Book = &book
+		}
+	}
+	response := BookUpsertBatchResponse{BatchResponse: newBatchResponse(atomic, items), Items: responses}
+	c.JSON(response.httpStatus(), response)
 }
 
 // GetBook handles GET /api/books/:id
@@ -94,8 +771,15 @@ func (h *BookHandler) CreateBook(c *gin.Context) {
 // @Tags books
 // @Accept json
 // @Produce json
+// @Produce application/hal+json
 // @Param id path string true "Book ID"
-// @Success 200 {object} entities.Book
+// @Param tz query string false "Timezone (IANA name or UTC offset) to render timestamps in"
+// @Param include query string false "Optional field groups, e.g. computed"
+// @Param format query string false "json (default), or dc for a Dublin Core record, in XML unless Accept asks for application/json"
+// @Param Accept header string false "application/hal+json adds hypermedia links to the books"
+// @Success 200 {object} handlers.BookResponse
+// @Header 200 {string} Link "Canonical URL of the book, by slug; absent without PUBLIC_BASE_URL"
+// @Failure 400 {object} handlers.ErrorResponse
 // @Failure 404 {object} handlers.ErrorResponse
 // @Failure 500 {object} handlers.ErrorResponse
 // @Router /books/{id} [get]
@@ -106,6 +790,120 @@ func (h *BookHandler) GetBook(c *gin.Context) {
 		return
 	}
 
+	dc, err := dublinCoreRequested(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	location, err := requestLocation(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	book, err := h.bookUseCase.GetBook(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if book == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "book not found"})
+		return
+	}
+
+	h.recordView(c, book)
+	h.setCanonicalLink(c, book)
+	if dc {
+		record, err := h.bookUseCase.DublinCore(book)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		writeDublinCore(c, record)
+		return
+	}
+	view := h.view(c, location)
+	writeBook(c, http.StatusOK, view, newBookResponse(*book, view))
+}
+
+// dublinCoreRequested reports whether the client asked for a Dublin Core record with format=dc
+// rather than the JSON representation of a book
+func dublinCoreRequested(c *gin.Context) (bool, error) {
+	switch c.Query("format") {
+	case "", "json":
+		return false, nil
+	case "dc":
+		return true, nil
+	}
+	return false, errors.New("format must be json or dc")
+}
+
+// writeDublinCore writes a Dublin Core record as oai_dc XML, or as JSON when the client accepts it
+func writeDublinCore(c *gin.Context, record *entities.DublinCore) {
+	c.Header("Vary", "Accept")
+	if strings.Contains(c.GetHeader("Accept"), "application/json") {
+		c.JSON(http.StatusOK, record)
+		return
+	}
+	writeXML(c, "application/xml; charset=utf-8", record)
+}
+
+// BookSlugResponse represents the slug of a book
+// swagger:model BookSlugResponse
+type BookSlugResponse struct {
+	// example: 550e8400-e29b-41d4-a716-446655440000
+	BookID string `json:"book_id"`
+	// example: the-great-gatsby
+	Slug string `json:"slug"`
+}
+
+// RecordBookView handles POST /api/books/:id/view
+// @Summary Count a view of a book
+// @Description Count a view of a book page for its popularity, for clients that show a book without reading it through the API. A visitor's views of a book are counted once per POPULARITY_VIEW_DEDUP_WINDOW; visitors are told apart by X-User-ID, else X-Session-ID, else IP, and are not stored.
+// @Tags books
+// @Param id path string true "Book ID"
+// @Param X-Session-ID header string false "Browser session the view belongs to"
+// @Success 204 "View recorded"
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/{id}/view [post]
+func (h *BookHandler) RecordBookView(c *gin.Context) {
+	book, err := h.bookUseCase.GetBook(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if book == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "book not found"})
+		return
+	}
+
+	h.recordView(c, book)
+	c.Status(http.StatusNoContent)
+}
+
+// GetBookSlug handles GET /api/books/:id/slug
+// @Summary Get the slug of a book
+// @Description Retrieve the slug naming a book in page URLs. Slugs are derived from the title: lowercase, transliterated to ASCII and made unique with a numeric suffix.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Success 200 {object} handlers.BookSlugResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Deprecated
+// @Router /books/{id}/slug [get]
+func (h *BookHandler) GetBookSlug(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "book ID is required"})
+		return
+	}
+
 	book, err := h.bookUseCase.GetBook(id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -117,7 +915,94 @@ func (h *BookHandler) GetBook(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, book)
+	c.JSON(http.StatusOK, BookSlugResponse{BookID: book.ID, Slug: book.Slug})
+}
+
+// GetBookNeighbors handles GET /api/books/:id/neighbors
+// @Summary Get the books around a book in a listing
+// @Description Return the IDs of the books listed right before and after a book, and its position, so detail pages can link to the previous and next book without loading the listing. The search parameters, sort and collapse are those of GET /books/search; without search parameters the books are ordered like GET /books.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param title query string false "Search by title"
+// @Param author query string false "Search by author"
+// @Param year query int false "Search by year"
+// @Param publisher query string false "Search by publisher ID"
+// @Param identifier query string false "Search by ISBN or any other identifier"
+// @Param status query string false "Only list books with this status (draft, active, archived)"
+// @Param meta.{key} query string false "Only list books with this metadata value"
+// @Param collapse query string false "Set to work to list one edition per work"
+// @Param sort query string false "Set to popularity to order by recent views and loans, most popular first"
+// @Success 200 {object} usecase.BookNeighbors
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 429 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/{id}/neighbors [get]
+func (h *BookHandler) GetBookNeighbors(c *gin.Context) {
+	search, err := newBookSearch(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	books, err := h.findBooks(search)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if collapseByWork(c) {
+		books, _ = usecase.CollapseByWork(books)
+	}
+
+	neighbors, listed := usecase.Neighbors(books, c.Param("id"))
+	if !listed {
+		c.JSON(http.StatusNotFound, gin.H{"error": "book not found in the listing"})
+		return
+	}
+	c.JSON(http.StatusOK, neighbors)
+}
+
+// GetBookBySlug handles GET /api/books/by-slug/:slug
+// @Summary Get a book by slug
+// @Description Retrieve a book by the slug of its page URL; deleted books are not found
+// @Tags books
+// @Accept json
+// @Produce json
+// @Produce application/hal+json
+// @Param slug path string true "Book slug"
+// @Param tz query string false "Timezone (IANA name or UTC offset) to render timestamps in"
+// @Param include query string false "Optional field groups, e.g. computed"
+// @Param Accept header string false "application/hal+json adds hypermedia links to the books"
+// @Success 200 {object} handlers.BookResponse
+// @Header 200 {string} Link "Canonical URL of the book, by slug; absent without PUBLIC_BASE_URL"
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/by-slug/{slug} [get]
+func (h *BookHandler) GetBookBySlug(c *gin.Context) {
+	location, err := requestLocation(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	book, err := h.bookUseCase.GetBookBySlug(c.Param("slug"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if book == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "book not found"})
+		return
+	}
+
+	h.recordView(c, book)
+	h.setCanonicalLink(c, book)
+	view := h.view(c, location)
+	writeBook(c, http.StatusOK, view, newBookResponse(*book, view))
 }
 
 // UpdateBook handles PUT /api/books/:id
@@ -126,11 +1011,16 @@ func (h *BookHandler) GetBook(c *gin.Context) {
 // @Tags books
 // @Accept json
 // @Produce json
+// @Produce application/hal+json
 // @Param id path string true "Book ID"
 // @Param book body UpdateBookRequest true "Updated book information"
-// @Success 200 {object} entities.Book
+// @Param include query string false "Optional field groups, e.g. computed"
+// @Param Accept header string false "application/hal+json adds hypermedia links to the books"
+// @Success 200 {object} handlers.BookResponse
 // @Failure 400 {object} handlers.ErrorResponse
+// @Failure 403 {object} handlers.ErrorResponse
 // @Failure 404 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
 // @Failure 500 {object} handlers.ErrorResponse
 // @Router /books/{id} [put]
 func (h *BookHandler) UpdateBook(c *gin.Context) {
@@ -147,25 +1037,43 @@ func (h *BookHandler) UpdateBook(c *gin.Context) {
 	}
 
 	book := &entities.Book{
-		Title:  req.Title,
-		Author: req.Author,
-		Year:   req.Year,
-		ISBN:   req.ISBN,
+		Title:          req.Title,
+		Author:         req.Author,
+		Year:           req.Year,
+		ISBN:           req.ISBN,
+		Description:    req.Description,
+		PublisherID:    req.PublisherID,
+		SeriesID:       req.SeriesID,
+		SeriesPosition: req.SeriesPosition,
+		BranchID:       req.BranchID,
+		Metadata:       req.Metadata,
 	}
 
-	if err := h.bookUseCase.UpdateBook(id, book); err != nil {
+	if err := h.validateMetadata(c, book.Metadata); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if err := h.bookUseCase.As(caller(c)).UpdateBook(id, book); err != nil {
+		if h.deletedISBNConflict(c, err, book.ISBN) {
+			return
+		}
+		status := denialStatus(err, http.StatusBadRequest)
+		if errors.Is(err, domainerr.ErrBookArchived) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
 
 	// Get the updated book to return with proper timestamps
 	updatedBook, err := h.bookUseCase.GetBook(id)
-	if err != nil {
+	if err != nil || updatedBook == nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve updated book"})
 		return
 	}
 
-	c.JSON(http.StatusOK, updatedBook)
+	view := h.view(c, nil)
+	writeBook(c, http.StatusOK, view, newBookResponse(*updatedBook, view))
 }
 
 // DeleteBook handles DELETE /api/books/:id
@@ -177,7 +1085,9 @@ func (h *BookHandler) UpdateBook(c *gin.Context) {
 // @Param id path string true "Book ID"
 // @Success 200 {object} handlers.MessageResponse
 // @Failure 400 {object} handlers.ErrorResponse
+// @Failure 403 {object} handlers.ErrorResponse
 // @Failure 404 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
 // @Failure 500 {object} handlers.ErrorResponse
 // @Router /books/{id} [delete]
 func (h *BookHandler) DeleteBook(c *gin.Context) {
@@ -187,8 +1097,8 @@ func (h *BookHandler) DeleteBook(c *gin.Context) {
 		return
 	}
 
-	if err := h.bookUseCase.DeleteBook(id); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.bookUseCase.As(caller(c)).DeleteBook(id); err != nil {
+		c.JSON(deletionStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -197,43 +1107,131 @@ func (h *BookHandler) DeleteBook(c *gin.Context) {
 
 // SearchBooks handles GET /api/books/search
 // @Summary Search books
-// @Description Search books by title, author, or year
+// @Description Search books by title, author, year, publisher (including its imprints), identifier, status or metadata. Drafts are only returned with status=draft. Metadata is filtered with meta.{key}={value} parameters. Title or author terms starting with a wildcard, with several wildcards or longer than 64 characters are expensive: each caller runs a few at once, and more wait briefly or get 429.
 // @Tags books
 // @Accept json
 // @Produce json
+// @Produce application/hal+json
 // @Param title query string false "Search by title"
 // @Param author query string false "Search by author"
 // @Param year query int false "Search by year"
-// @Success 200 {array} entities.Book
+// @Param publisher query string false "Search by publisher ID"
+// @Param identifier query string false "Search by ISBN or any other identifier, e.g. an ISSN or DOI, written in any form"
+// @Param status query string false "Only return books with this status (draft, active, archived)"
+// @Param meta.{key} query string false "Only return books with this metadata value, e.g. meta.shelf_code=A12"
+// @Param tz query string false "Timezone (IANA name or UTC offset) to render timestamps in"
+// @Param include query string false "Optional field groups, e.g. computed"
+// @Param collapse query string false "Set to work to return one edition per work"
+// @Param sort query string false "Set to popularity to order by recent views and loans, most popular first"
+// @Param Accept header string false "application/hal+json adds hypermedia links to the books"
+// @Success 200 {array} handlers.BookResponse
 // @Failure 400 {object} handlers.ErrorResponse
+// @Failure 429 {object} handlers.ErrorResponse
 // @Failure 500 {object} handlers.ErrorResponse
 // @Router /books/search [get]
 func (h *BookHandler) SearchBooks(c *gin.Context) {
-	title := c.Query("title")
-	author := c.Query("author")
-	yearStr := c.Query("year")
+	location, err := requestLocation(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	search, err := newBookSearch(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if search.empty() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "at least one search parameter is required"})
+		return
+	}
+
+	books, err := h.findBooks(search)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	view := h.view(c, location)
+	if collapseByWork(c) {
+		books, view.editionCounts = usecase.CollapseByWork(books)
+	}
 
+	writeBooks(c, http.StatusOK, view, newBookResponses(books, view))
+}
+
+// bookSearch holds the search parameters of a request, as GET /books/search takes them
+type bookSearch struct {
+	title      string
+	author     string
+	year       string
+	publisher  string
+	identifier string
+	status     string
+	metadata   map[string]string
+	sort       string
+}
+
+// newBookSearch reads the search parameters of a request, checking its status and sort
+func newBookSearch(c *gin.Context) (bookSearch, error) {
+	search := bookSearch{
+		title:      c.Query("title"),
+		author:     c.Query("author"),
+		year:       c.Query("year"),
+		publisher:  c.Query("publisher"),
+		identifier: c.Query("identifier"),
+		status:     c.Query("status"),
+		metadata:   metadataFilters(c),
+		sort:       c.Query("sort"),
+	}
+	if search.status != "" && !entities.IsBookStatus(search.status) {
+		return search, fmt.Errorf("unknown book status %q", search.status)
+	}
+	if search.sort != "" && search.sort != sortPopularity {
+		return search, fmt.Errorf("unknown sort %q, expected %s", search.sort, sortPopularity)
+	}
+	return search, nil
+}
+
+// empty reports whether the search has no parameter to search by; sort does not search
+func (s bookSearch) empty() bool {
+	return s.title == "" && s.author == "" && s.year == "" && s.publisher == "" && s.identifier == "" && s.status == "" && len(s.metadata) == 0
+}
+
+// findBooks returns the books of a search in the order they are listed: the results of
+// GET /books/search, or the books of GET /books when the search is empty
+func (h *BookHandler) findBooks(search bookSearch) ([]entities.Book, error) {
 	var books []entities.Book
 	var err error
 
 	switch {
-	case title != "":
-		books, err = h.bookUseCase.SearchBooksByTitle(title)
-	case author != "":
-		books, err = h.bookUseCase.SearchBooksByAuthor(author)
-	case yearStr != "":
-		books, err = h.bookUseCase.SearchBooksByYear(yearStr)
+	case search.title != "":
+		books, err = h.bookUseCase.SearchBooksByTitle(search.title)
+	case search.author != "":
+		books, err = h.bookUseCase.SearchBooksByAuthor(search.author)
+	case search.year != "":
+		books, err = h.bookUseCase.SearchBooksByYear(search.year)
+	case search.publisher != "":
+		books, err = h.bookUseCase.SearchBooksByPublisher(search.publisher)
+	case search.identifier != "":
+		books, err = h.bookUseCase.SearchBooksByIdentifier(search.identifier)
+	case search.status != "":
+		books, err = h.bookUseCase.SearchBooksByStatus(search.status)
+	case len(search.metadata) > 0:
+		books, err = h.bookUseCase.SearchBooksByMetadata(search.metadata)
 	default:
-		c.JSON(http.StatusBadRequest, gin.H{"error": "at least one search parameter is required"})
-		return
+		books, err = h.bookUseCase.GetAllBooks()
 	}
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+		return nil, err
 	}
-
-	c.JSON(http.StatusOK, books)
+	// Filtering copies the books, so sorting never reorders those the use case cached
+	books = usecase.FilterByMetadata(usecase.FilterByStatus(books, search.status), search.metadata)
+	if search.sort == sortPopularity {
+		// Sorted before collapsing, so each work is represented by its most popular edition
+		usecase.SortByPopularity(books)
+	}
+	return books, nil
 }
 
 // GetDeletedBooks handles GET /api/books/deleted
@@ -242,17 +1240,102 @@ func (h *BookHandler) SearchBooks(c *gin.Context) {
 // @Tags books
 // @Accept json
 // @Produce json
-// @Success 200 {array} entities.Book
+// @Produce application/hal+json
+// @Param tz query string false "Timezone (IANA name or UTC offset) to render timestamps in"
+// @Param include query string false "Optional field groups, e.g. computed"
+// @Param Accept header string false "application/hal+json adds hypermedia links to the books"
+// @Success 200 {array} handlers.BookResponse
+// @Failure 400 {object} handlers.ErrorResponse
 // @Failure 500 {object} handlers.ErrorResponse
 // @Router /books/deleted [get]
 func (h *BookHandler) GetDeletedBooks(c *gin.Context) {
+	location, err := requestLocation(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	books, err := h.bookUseCase.GetDeletedBooks()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, books)
+	view := h.trashView(c, location)
+	writeBooks(c, http.StatusOK, view, newBookResponses(books, view))
+}
+
+// GetScheduledBooks handles GET /api/books/scheduled
+// @Summary Get scheduled books
+// @Description Retrieve the books waiting for their publication, soonest first
+// @Tags books
+// @Accept json
+// @Produce json
+// @Produce application/hal+json
+// @Param tz query string false "Timezone (IANA name or UTC offset) to render timestamps in"
+// @Param include query string false "Optional field groups, e.g. computed"
+// @Param Accept header string false "application/hal+json adds hypermedia links to the books"
+// @Success 200 {array} handlers.BookResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/scheduled [get]
+func (h *BookHandler) GetScheduledBooks(c *gin.Context) {
+	location, err := requestLocation(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	books, err := h.bookUseCase.GetScheduledBooks()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	view := h.view(c, location)
+	writeBooks(c, http.StatusOK, view, newBookResponses(books, view))
+}
+
+// ChangeBookStatusRequest represents the request body for changing the status of a book
+type ChangeBookStatusRequest struct {
+	// draft, active or archived
+	Status string `json:"status" binding:"required"`
+}
+
+// ChangeBookStatus handles PUT /api/books/:id/status
+// @Summary Change the status of a book
+// @Description Move a book to another status. Drafts can be made active, active books archived and archived books active again.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Produce application/hal+json
+// @Param id path string true "Book ID"
+// @Param status body ChangeBookStatusRequest true "New status"
+// @Param Accept header string false "application/hal+json adds hypermedia links to the books"
+// @Success 200 {object} handlers.BookResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 403 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /books/{id}/status [put]
+func (h *BookHandler) ChangeBookStatus(c *gin.Context) {
+	var req ChangeBookStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	book, err := h.bookUseCase.As(caller(c)).ChangeBookStatus(c.Param("id"), req.Status)
+	if err != nil {
+		status := denialStatus(err, http.StatusBadRequest)
+		if errors.Is(err, domainerr.ErrBookNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	view := h.view(c, nil)
+	writeBook(c, http.StatusOK, view, newBookResponse(*book, view))
 }
 
 // RestoreBook handles POST /api/books/:id/restore
@@ -264,6 +1347,7 @@ func (h *BookHandler) GetDeletedBooks(c *gin.Context) {
 // @Param id path string true "Book ID"
 // @Success 200 {object} handlers.MessageResponse
 // @Failure 400 {object} handlers.ErrorResponse
+// @Failure 403 {object} handlers.ErrorResponse
 // @Failure 500 {object} handlers.ErrorResponse
 // @Router /books/{id}/restore [post]
 func (h *BookHandler) RestoreBook(c *gin.Context) {
@@ -273,8 +1357,8 @@ func (h *BookHandler) RestoreBook(c *gin.Context) {
 		return
 	}
 
-	if err := h.bookUseCase.RestoreBook(id); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.bookUseCase.As(caller(c)).RestoreBook(id); err != nil {
+		c.JSON(denialStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -290,7 +1374,9 @@ func (h *BookHandler) RestoreBook(c *gin.Context) {
 // @Param id path string true "Book ID"
 // @Success 200 {object} handlers.MessageResponse
 // @Failure 400 {object} handlers.ErrorResponse
+// @Failure 403 {object} handlers.ErrorResponse
 // @Failure 404 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
 // @Failure 500 {object} handlers.ErrorResponse
 // @Router /books/{id}/permanent [delete]
 func (h *BookHandler) HardDeleteBook(c *gin.Context) {
@@ -300,8 +1386,8 @@ func (h *BookHandler) HardDeleteBook(c *gin.Context) {
 		return
 	}
 
-	if err := h.bookUseCase.HardDeleteBook(id); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := h.bookUseCase.As(caller(c)).HardDeleteBook(id); err != nil {
+		c.JSON(deletionStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -8,11 +8,14 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"library-management-system/internal/delivery/http/urlbuilder"
 	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // BookUseCaseInterface defines the interface for book use case
@@ -732,3 +735,216 @@ func TestBookHandler_HardDeleteBook(t *testing.T) {
 		})
 	}
 }
+
+func TestBookHandler_CanonicalLink(t *testing.T) {
+	bookRepo := newMemoryBookRepository()
+	book := &entities.Book{Title: "Cien años de soledad", Author: "Gabriel García Márquez", Year: 1967, ISBN: "9780307474728"}
+	bookUseCase := usecase.NewBookUseCase(bookRepo)
+	assert.NoError(t, bookUseCase.CreateBook(book))
+
+	handler := NewBookHandler(bookUseCase)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/books/by-slug/:slug", handler.GetBookBySlug)
+	router.GET("/api/books/:id", handler.GetBook)
+
+	// Without a public base URL there is no canonical link
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/books/"+book.ID, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Link"))
+
+	links, err := urlbuilder.New("https://Library.Example.com/")
+	require.NoError(t, err)
+	handler.SetLinks(links.Under("/api"))
+	for _, path := range []string{"/api/books/" + book.ID, "/api/books/by-slug/cien-anos-de-soledad"} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusOK, w.Code, path)
+		assert.Equal(t, `<https://library.example.com/api/books/by-slug/cien-anos-de-soledad>; rel="canonical"`, w.Header().Get("Link"), path)
+	}
+
+	// Missing books have no canonical form
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/books/by-slug/missing", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("Link"))
+}
+
+func TestBookHandler_HAL(t *testing.T) {
+	bookUseCase := usecase.NewBookUseCase(newMemoryBookRepository())
+	book := &entities.Book{Title: "Beloved", Author: "Toni Morrison", Year: 1987, ISBN: "9781400033416"}
+	require.NoError(t, bookUseCase.CreateBook(book))
+
+	links, err := urlbuilder.New("https://library.example.com")
+	require.NoError(t, err)
+	handler := NewBookHandler(bookUseCase)
+	handler.SetLinks(links.Under("/api"))
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/books/:id", handler.GetBook)
+
+	// Plain JSON unless HAL is asked for
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/books/"+book.ID, nil))
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.NotContains(t, w.Body.String(), "_links")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/books/"+book.ID, nil)
+	req.Header.Set("Accept", HALMediaType)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, HALMediaType, w.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+
+	var response BookResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, HALLink{Href: "https://library.example.com/api/books/" + book.ID}, response.Links["self"])
+	assert.Equal(t, HALLink{Href: "https://library.example.com/api/books/" + book.ID, Method: http.MethodPut}, response.Links["update"])
+}
+
+func TestBookHandler_DublinCore(t *testing.T) {
+	bookUseCase := usecase.NewBookUseCase(newMemoryBookRepository())
+	book := &entities.Book{Title: "Beloved", Author: "Toni Morrison", Year: 1987, ISBN: "9781400033416"}
+	require.NoError(t, bookUseCase.CreateBook(book))
+
+	handler := NewBookHandler(bookUseCase)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/books/:id", handler.GetBook)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/books/"+book.ID+"?format=dc", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `<dc:title>Beloved</dc:title><dc:creator>Toni Morrison</dc:creator><dc:date>1987</dc:date>`)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/books/"+book.ID+"?format=dc", nil)
+	req.Header.Set("Accept", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var record entities.DublinCore
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &record))
+	assert.Equal(t, []string{"urn:isbn:9781400033416", "urn:uuid:" + book.ID}, record.Identifier)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/books/"+book.ID+"?format=marc", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error": "format must be json or dc"}`, w.Body.String())
+}
+
+// recordedViews collects the views a BookHandler records
+type recordedViews struct {
+	visitors []string
+}
+
+func (r *recordedViews) RecordView(bookID, visitor string) bool {
+	r.visitors = append(r.visitors, bookID+" "+visitor)
+	return true
+}
+
+func TestBookHandler_RecordBookView(t *testing.T) {
+	bookUseCase := usecase.NewBookUseCase(newMemoryBookRepository())
+	book := &entities.Book{Title: "Beloved", Author: "Toni Morrison", Year: 1987, ISBN: "9781400033416"}
+	require.NoError(t, bookUseCase.CreateBook(book))
+
+	views := &recordedViews{}
+	handler := NewBookHandler(bookUseCase)
+	handler.SetViewRecorder(views)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/books/:id", handler.GetBook)
+	router.POST("/api/books/:id/view", handler.RecordBookView)
+
+	// Views are deduplicated by the caller, else the session, else the IP
+	for _, headers := range []map[string]string{
+		{"X-User-ID": "member-1", "X-Session-ID": "session-1"},
+		{"X-Session-ID": "session-1"},
+		{},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/books/"+book.ID+"/view", nil)
+		req.RemoteAddr = "192.0.2.10:4321"
+		for key, value := range headers {
+			req.Header.Set(key, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Empty(t, w.Body.String())
+	}
+
+	// Reading the book counts as a view too
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/books/"+book.ID, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/books/00000000-0000-0000-0000-000000000999/view", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	assert.Equal(t, []string{
+		book.ID + " user:member-1",
+		book.ID + " session:session-1",
+		book.ID + " ip:192.0.2.10",
+		book.ID + " ip:192.0.2.1",
+	}, views.visitors)
+}
+
+func TestBookHandler_ImportBooksProgress(t *testing.T) {
+	bookUseCase := usecase.NewBookUseCase(newMemoryBookRepository())
+	bookUseCase.SetImportChunkSize(2)
+	handler := NewBookHandler(bookUseCase)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/books/import", handler.ImportBooks)
+
+	body := `{"books":[
+		{"title":"Mort","author":"Terry Pratchett","year":1987,"isbn":"9780062225719"},
+		{"title":"Sourcery","author":"Terry Pratchett","year":1988,"isbn":"9780062225672"},
+		{"title":"","author":"Terry Pratchett","year":1988,"isbn":"9780062225689"},
+		{"title":"Wyrd Sisters","author":"Terry Pratchett","year":1988,"isbn":"9780062225733"},
+		{"title":"Pyramids","author":"Terry Pratchett","year":1989,"isbn":"9780062225184"}
+	]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/books/import", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", NDJSONMediaType)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, NDJSONMediaType, w.Header().Get("Content-Type"))
+	assert.True(t, w.Flushed)
+
+	var events []ImportEvent
+	decoder := json.NewDecoder(w.Body)
+	for decoder.More() {
+		var event ImportEvent
+		require.NoError(t, decoder.Decode(&event))
+		events = append(events, event)
+	}
+	require.Len(t, events, 4)
+	// The rejected book counts as processed once the books are validated, then each chunk of two
+	for i, expected := range []usecase.ImportProgress{
+		{Processed: 1, Total: 5},
+		{Processed: 3, Total: 5, Imported: 2},
+		{Processed: 5, Total: 5, Imported: 4},
+	} {
+		assert.Equal(t, ImportEventProgress, events[i].Type)
+		require.NotNil(t, events[i].ImportProgress)
+		assert.Equal(t, expected, *events[i].ImportProgress)
+	}
+	assert.Equal(t, ImportEventResult, events[3].Type)
+	require.NotNil(t, events[3].Result)
+	assert.Equal(t, int64(4), events[3].Result.Imported)
+	assert.Len(t, events[3].Result.Rejected, 1)
+
+	// Errors found before the import starts keep their status
+	req = httptest.NewRequest(http.MethodPost, "/api/books/import", bytes.NewBufferString(`{"books":[]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", NDJSONMediaType)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
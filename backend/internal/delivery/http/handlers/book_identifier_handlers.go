@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AddBookIdentifierRequest represents the request body for giving a book an identifier
+type AddBookIdentifierRequest struct {
+	// issn, asin, doi or internal
+	Type  string `json:"type" binding:"required" example:"doi"`
+	Value string `json:"value" binding:"required" example:"https://doi.org/10.1000/182"`
+}
+
+// GetBookIdentifiers handles GET /api/books/:id/identifiers
+// @Summary List the identifiers of a book
+// @Description Retrieve the identifiers of a book besides its ISBN, such as ISSNs, ASINs, DOIs and internal codes, by type and value
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Success 200 {array} entities.BookIdentifier
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/{id}/identifiers [get]
+func (h *BookHandler) GetBookIdentifiers(c *gin.Context) {
+	identifiers, err := h.bookUseCase.ListIdentifiers(c.Param("id"))
+	if err != nil {
+		writeIdentifierError(c, err, http.StatusInternalServerError)
+		return
+	}
+	if identifiers == nil {
+		identifiers = []entities.BookIdentifier{}
+	}
+
+	c.JSON(http.StatusOK, identifiers)
+}
+
+// AddBookIdentifier handles POST /api/books/:id/identifiers
+// @Summary Add an identifier to a book
+// @Description Give a book an ISSN, ASIN, DOI or internal code. Values are stored normalized: ISSNs as 1234-567X, ASINs upper case, DOIs lower case without their doi.org link or doi: prefix. A value of a type identifies one book.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param identifier body AddBookIdentifierRequest true "Identifier"
+// @Success 201 {object} entities.BookIdentifier
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 403 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /books/{id}/identifiers [post]
+func (h *BookHandler) AddBookIdentifier(c *gin.Context) {
+	var req AddBookIdentifierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	identifier := &entities.BookIdentifier{Type: req.Type, Value: req.Value}
+	if err := h.bookUseCase.As(caller(c)).AddIdentifier(c.Param("id"), identifier); err != nil {
+		writeIdentifierError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusCreated, identifier)
+}
+
+// RemoveBookIdentifier handles DELETE /api/books/:id/identifiers/:identifierId
+// @Summary Remove an identifier from a book
+// @Description Remove an identifier from a book, freeing its value for other books
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param identifierId path string true "Identifier ID"
+// @Success 200 {object} handlers.MessageResponse
+// @Failure 403 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/{id}/identifiers/{identifierId} [delete]
+func (h *BookHandler) RemoveBookIdentifier(c *gin.Context) {
+	if err := h.bookUseCase.As(caller(c)).RemoveIdentifier(c.Param("id"), c.Param("identifierId")); err != nil {
+		writeIdentifierError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "identifier removed successfully"})
+}
+
+// LookupBook handles GET /api/books/lookup
+// @Summary Look a book up by identifier
+// @Description Retrieve the book having an identifier of a type, written in any form, or its ISBN with type=isbn. Deleted books are not found.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Produce application/hal+json
+// @Param type query string true "isbn, issn, asin, doi or internal"
+// @Param value query string true "Identifier, e.g. 10.1000/182"
+// @Param tz query string false "Timezone (IANA name or UTC offset) to render timestamps in"
+// @Param include query string false "Optional field groups, e.g. computed"
+// @Param Accept header string false "application/hal+json adds hypermedia links to the books"
+// @Success 200 {object} handlers.BookResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /books/lookup [get]
+func (h *BookHandler) LookupBook(c *gin.Context) {
+	location, err := requestLocation(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	book, err := h.bookUseCase.FindBookByIdentifier(c.Query("type"), c.Query("value"))
+	if err != nil {
+		writeIdentifierError(c, err, http.StatusBadRequest)
+		return
+	}
+	if book == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": domainerr.ErrBookNotFound.Error()})
+		return
+	}
+
+	h.setCanonicalLink(c, book)
+	view := h.view(c, location)
+	writeBook(c, http.StatusOK, view, newBookResponse(*book, view))
+}
+
+// writeIdentifierError writes a failed identifier request; defined errors keep their status
+func writeIdentifierError(c *gin.Context, err error, status int) {
+	if e, ok := domainerr.Lookup(err); ok {
+		c.Error(err)
+		c.JSON(e.Status, gin.H{"error": e.Error()})
+		return
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
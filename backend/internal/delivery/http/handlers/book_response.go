@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"library-management-system/internal/delivery/http/urlbuilder"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// includeQueryParam lists optional field groups, e.g. include=computed
+const includeQueryParam = "include"
+
+// Optional field groups of book responses
+const (
+	includeComputed = "computed"
+)
+
+// HALMediaType in the Accept header adds hypermedia links to book responses, per the HAL
+// convention (application/hal+json)
+const HALMediaType = "application/hal+json"
+
+// BookResponse is the serialized form of a book. Handlers never return entities.Book directly,
+// so storage fields cannot leak into the API by accident.
+// swagger:model BookResponse
+type BookResponse struct {
+	// example: 550e8400-e29b-41d4-a716-446655440000
+	ID string `json:"id"`
+	// example: The Great Gatsby
+	Title string `json:"title"`
+	// example: F. Scott Fitzgerald
+	Author string `json:"author"`
+	// example: 1925
+	Year int `json:"year"`
+	// example: 9780743273565
+	ISBN string `json:"isbn"`
+	// Names the book in page URLs, see GET /api/books/by-slug/{slug}
+	// example: the-great-gatsby
+	Slug string `json:"slug"`
+	// Only present when the book has a description
+	Description string `json:"description,omitempty"`
+	// Only present when the book is linked to a publisher
+	// example: 7c9e6679-7425-40de-944b-e07fc1f90ae7
+	PublisherID *string `json:"publisher_id,omitempty"`
+	// Only present when the book belongs to a series
+	// example: 1b4e28ba-2fa1-11d2-883f-0016d3cca427
+	SeriesID *string `json:"series_id,omitempty"`
+	// example: 1
+	SeriesPosition *int `json:"series_position,omitempty"`
+	// Only present when the book is an edition of a work
+	// example: 9b2e8f4c-6d1a-4f3b-8c7e-2a5d9e0f1b3c
+	WorkID *string `json:"work_id,omitempty"`
+	// Only present when the book belongs to a branch rather than the whole library
+	// example: 0f8fad5b-d9cb-469f-a165-70867728950e
+	BranchID *string `json:"branch_id,omitempty"`
+	// Number of editions of the work in the results, only present with collapse=work
+	// example: 3
+	EditionCount *int `json:"edition_count,omitempty"`
+	// Custom fields of the library, only present when the book has some
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// Dominant colour of the cover, for placeholder backgrounds while it loads; only present
+	// when the book has a JPEG, PNG or GIF cover
+	// example: #1e3a8a
+	CoverColor string `json:"cover_color,omitempty"`
+	// Most common colours of the cover, the dominant one first
+	// example: ["#1e3a8a","#f59e0b","#f8fafc"]
+	CoverPalette []string `json:"cover_palette,omitempty"`
+	// draft, active or archived; drafts are hidden from public listings
+	// example: active
+	Status string `json:"status"`
+	// Whether the book is on the shelf; deleted and archived books are never available
+	// example: true
+	Available bool      `json:"available"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Only present on the trash endpoint
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Only present while the book is scheduled; it is hidden from public listings until then
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+	// Only present when a timezone was requested
+	DateMetadata *DateMetadata `json:"date_metadata,omitempty"`
+	// Links to the book and what can be done with it, only present with Accept: application/hal+json.
+	// Books have self, update, delete and timeline links; deleted books restore and purge links.
+	Links map[string]HALLink `json:"_links,omitempty"`
+	// Years since publication, only present with include=computed
+	// example: 99
+	AgeYears *int `json:"age_years,omitempty"`
+	// Whole days since the book was added, only present with include=computed
+	// example: 12
+	DaysInCatalog *int `json:"days_in_catalog,omitempty"`
+}
+
+// HALLink is a hypermedia link of a HAL resource
+// swagger:model HALLink
+type HALLink struct {
+	// example: /api/books/550e8400-e29b-41d4-a716-446655440000
+	Href string `json:"href"`
+	// HTTP method to follow the link with, a hint for links other than GET
+	// example: PUT
+	Method string `json:"method,omitempty"`
+}
+
+// BookCollectionHAL is a list of books in HAL form
+// swagger:model BookCollectionHAL
+type BookCollectionHAL struct {
+	// example: 2
+	Count    int `json:"count"`
+	Embedded struct {
+		Books []BookResponse `json:"books"`
+	} `json:"_embedded"`
+}
+
+// bookView selects which optional fields a book response carries
+type bookView struct {
+	// location converts timestamps and adds date metadata when set
+	location *time.Location
+	// trash exposes deleted_at
+	trash bool
+	// computedAt is the time derived fields are computed at; they are omitted when zero
+	computedAt time.Time
+	// editionCounts holds the editions per work of results collapsed by work
+	editionCounts map[string]int
+	// links builds the hypermedia links of HAL responses; they are omitted when nil
+	links *urlbuilder.Builder
+}
+
+// newBookResponse maps a book to its response; this is the only place the mapping happens
+func newBookResponse(book entities.Book, view bookView) BookResponse {
+	response := BookResponse{
+		ID:          book.ID,
+		Title:       book.Title,
+		Author:      book.Author,
+		Year:        book.Year,
+		ISBN:        book.ISBN,
+		Slug:        book.Slug,
+		Description: book.Description,
+		Status:      book.Status,
+		Available:   book.DeletedAt == nil && book.Status != entities.BookStatusArchived,
+		CreatedAt:   book.CreatedAt,
+		UpdatedAt:   book.UpdatedAt,
+	}
+	if book.PublisherID != nil {
+		publisherID := *book.PublisherID
+		response.PublisherID = &publisherID
+	}
+	if book.SeriesID != nil {
+		seriesID := *book.SeriesID
+		response.SeriesID = &seriesID
+	}
+	if book.SeriesPosition != nil {
+		seriesPosition := *book.SeriesPosition
+		response.SeriesPosition = &seriesPosition
+	}
+	if book.WorkID != nil {
+		workID := *book.WorkID
+		response.WorkID = &workID
+		if count, ok := view.editionCounts[workID]; ok {
+			response.EditionCount = &count
+		}
+	}
+	if book.BranchID != nil {
+		branchID := *book.BranchID
+		response.BranchID = &branchID
+	}
+	if len(book.Metadata) > 0 {
+		response.Metadata = make(map[string]interface{}, len(book.Metadata))
+		for key, value := range book.Metadata {
+			response.Metadata[key] = value
+		}
+	}
+	if len(book.CoverPalette) > 0 {
+		response.CoverColor = book.CoverPalette[0]
+		response.CoverPalette = append([]string(nil), book.CoverPalette...)
+	}
+	if view.trash && book.DeletedAt != nil {
+		deletedAt := *book.DeletedAt
+		response.DeletedAt = &deletedAt
+	}
+	if book.PublishAt != nil {
+		publishAt := *book.PublishAt
+		response.PublishAt = &publishAt
+	}
+
+	if view.location != nil {
+		response.CreatedAt = response.CreatedAt.In(view.location)
+		response.UpdatedAt = response.UpdatedAt.In(view.location)
+		if response.DeletedAt != nil {
+			deletedAt := response.DeletedAt.In(view.location)
+			response.DeletedAt = &deletedAt
+		}
+		if response.PublishAt != nil {
+			publishAt := response.PublishAt.In(view.location)
+			response.PublishAt = &publishAt
+		}
+		response.DateMetadata = newDateMetadata(response.CreatedAt, response.UpdatedAt, view.location)
+	}
+
+	if view.links != nil {
+		response.Links = bookLinks(book, view.links)
+	}
+
+	if !view.computedAt.IsZero() {
+		ageYears := nonNegative(view.computedAt.Year() - book.Year)
+		daysInCatalog := nonNegative(int(view.computedAt.Sub(book.CreatedAt).Hours() / 24))
+		response.AgeYears = &ageYears
+		response.DaysInCatalog = &daysInCatalog
+	}
+
+	return response
+}
+
+// newBookResponses maps a list of books, always returning a non-nil slice
+func newBookResponses(books []entities.Book, view bookView) []BookResponse {
+	responses := make([]BookResponse, len(books))
+	for i, book := range books {
+		responses[i] = newBookResponse(book, view)
+	}
+	return responses
+}
+
+// bookLinks returns the HAL links of a book, built under the API prefix
+func bookLinks(book entities.Book, links *urlbuilder.Builder) map[string]HALLink {
+	self := links.URL("/books/" + book.ID)
+	if book.DeletedAt != nil {
+		return map[string]HALLink{
+			"restore": {Href: self + "/restore", Method: http.MethodPost},
+			"purge":   {Href: self + "/permanent", Method: http.MethodDelete},
+		}
+	}
+	return map[string]HALLink{
+		"self":     {Href: self},
+		"update":   {Href: self, Method: http.MethodPut},
+		"delete":   {Href: self, Method: http.MethodDelete},
+		"timeline": {Href: self + "/timeline"},
+	}
+}
+
+// writeBook writes a book response, as HAL when the view has links
+func writeBook(c *gin.Context, status int, view bookView, response BookResponse) {
+	if view.links != nil {
+		c.Header("Content-Type", HALMediaType)
+	}
+	c.JSON(status, response)
+}
+
+// writeBooks writes a list of books: an array, or a HAL collection embedding the books when the
+// view has links
+func writeBooks(c *gin.Context, status int, view bookView, responses []BookResponse) {
+	if view.links == nil {
+		c.JSON(status, responses)
+		return
+	}
+	collection := BookCollectionHAL{Count: len(responses)}
+	collection.Embedded.Books = responses
+	c.Header("Content-Type", HALMediaType)
+	c.JSON(status, collection)
+}
+
+// includes reports whether the include query parameter lists the field group
+func includes(c *gin.Context, group string) bool {
+	for _, value := range strings.Split(c.Query(includeQueryParam), ",") {
+		if strings.TrimSpace(value) == group {
+			return true
+		}
+	}
+	return false
+}
+
+func nonNegative(n int) int {
+	if n < 0 {
+		return 0
+	}
+	return n
+}
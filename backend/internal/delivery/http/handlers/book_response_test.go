@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBookResponse_HidesDeletedAtOutsideTrash(t *testing.T) {
+	deletedAt := time.Date(2024, 1, 16, 9, 0, 0, 0, time.UTC)
+	book := entities.Book{ID: "test-id", Title: "Test Book", DeletedAt: &deletedAt}
+
+	response := newBookResponse(book, bookView{})
+	assert.Nil(t, response.DeletedAt)
+	assert.False(t, response.Available)
+
+	data, err := json.Marshal(response)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "deleted_at")
+	assert.NotContains(t, string(data), "date_metadata")
+
+	trashed := newBookResponse(book, bookView{trash: true})
+	assert.Equal(t, &deletedAt, trashed.DeletedAt)
+
+	available := newBookResponse(entities.Book{ID: "other-id"}, bookView{trash: true})
+	assert.True(t, available.Available)
+	assert.Nil(t, available.DeletedAt)
+}
+
+func TestNewBookResponse_CoverColors(t *testing.T) {
+	response := newBookResponse(entities.Book{ID: "test-id", CoverPalette: []string{"#1e3a8a", "#f59e0b"}}, bookView{})
+	assert.Equal(t, "#1e3a8a", response.CoverColor)
+	assert.Equal(t, []string{"#1e3a8a", "#f59e0b"}, response.CoverPalette)
+
+	data, err := json.Marshal(newBookResponse(entities.Book{ID: "other-id"}, bookView{}))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "cover_color")
+	assert.NotContains(t, string(data), "cover_palette")
+}
+
+func TestNewBookResponses_Localized(t *testing.T) {
+	deletedAt := time.Date(2024, 1, 16, 9, 0, 0, 0, time.UTC)
+	books := []entities.Book{{
+		ID:        "test-id",
+		Title:     "Test Book",
+		CreatedAt: time.Date(2023, 12, 31, 20, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		DeletedAt: &deletedAt,
+	}}
+
+	location, _ := parseLocation("+07:00")
+	responses := newBookResponses(books, bookView{location: location, trash: true})
+
+	// 2023-12-31 20:00 UTC is already 2024-01-01 in UTC+7, which falls in ISO week 1 of 2024
+	assert.Equal(t, "2024-W01", responses[0].DateMetadata.CreatedAtISOWeek)
+	assert.Equal(t, "2024-W03", responses[0].DateMetadata.UpdatedAtISOWeek)
+	assert.Equal(t, "UTC+07:00", responses[0].DateMetadata.Timezone)
+
+	data, err := json.Marshal(responses[0])
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"created_at":"2024-01-01T03:00:00+07:00"`)
+	assert.Contains(t, string(data), `"deleted_at":"2024-01-16T16:00:00+07:00"`)
+	assert.Contains(t, string(data), `"title":"Test Book"`)
+
+	assert.NotNil(t, newBookResponses(nil, bookView{}))
+}
+
+func TestNewBookResponse_ComputedFields(t *testing.T) {
+	book := entities.Book{
+		ID:        "test-id",
+		Year:      1925,
+		CreatedAt: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+	}
+
+	response := newBookResponse(book, bookView{})
+	assert.Nil(t, response.AgeYears)
+	assert.Nil(t, response.DaysInCatalog)
+
+	response = newBookResponse(book, bookView{computedAt: time.Date(2024, 2, 14, 10, 29, 0, 0, time.UTC)})
+	assert.Equal(t, 99, *response.AgeYears)
+	assert.Equal(t, 29, *response.DaysInCatalog)
+
+	// A clock behind the record never yields negative values
+	response = newBookResponse(book, bookView{computedAt: time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)})
+	assert.Equal(t, 0, *response.AgeYears)
+	assert.Equal(t, 0, *response.DaysInCatalog)
+}
+
+func TestIncludes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		query    string
+		expected bool
+	}{
+		{"", false},
+		{"include=computed", true},
+		{"include=history,%20computed", true},
+		{"include=computedx", false},
+	}
+
+	for _, tt := range tests {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/api/books?"+tt.query, nil)
+		assert.Equal(t, tt.expected, includes(c, includeComputed), tt.query)
+	}
+}
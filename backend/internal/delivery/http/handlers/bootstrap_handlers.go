@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BootstrapResponse is everything a frontend needs to render its first screen
+// swagger:model BootstrapResponse
+type BootstrapResponse struct {
+	// Public configuration, the feature flags under features included
+	Config PublicConfig `json:"config"`
+	// The caller, null when the request is anonymous or its user does not exist
+	User *entities.User `json:"user"`
+	// Unread notifications of the caller, null when there is no user
+	// example: 3
+	UnreadNotifications *int64 `json:"unread_notifications"`
+}
+
+// BootstrapHandler handles the request frontends start with, answering in one response what they
+// would otherwise ask for in several
+type BootstrapHandler struct {
+	config              PublicConfig
+	memberUseCase       *usecase.MemberUseCase
+	notificationUseCase *usecase.NotificationUseCase
+}
+
+// NewBootstrapHandler creates a new bootstrap handler serving the public config of configHandler
+func NewBootstrapHandler(configHandler *ConfigHandler, memberUseCase *usecase.MemberUseCase, notificationUseCase *usecase.NotificationUseCase) *BootstrapHandler {
+	return &BootstrapHandler{
+		config:              configHandler.config,
+		memberUseCase:       memberUseCase,
+		notificationUseCase: notificationUseCase,
+	}
+}
+
+// GetBootstrap handles GET /api/bootstrap
+// @Summary Bootstrap a frontend
+// @Description Return in one response the public configuration with its feature flags, the caller and their unread notification count, which a frontend would otherwise load with GET /config/public, GET /me/profile and GET /notifications/unread-count. Anonymous callers get a null user and count.
+// @Tags config
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string false "User ID"
+// @Success 200 {object} handlers.BootstrapResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /bootstrap [get]
+func (h *BootstrapHandler) GetBootstrap(c *gin.Context) {
+	response := BootstrapResponse{Config: h.config}
+
+	if userID := callerID(c); userID != "" {
+		user, err := h.memberUseCase.GetProfile(userID)
+		if err != nil && !errors.Is(err, domainerr.ErrMemberNotFound) {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if user != nil {
+			unread, err := h.notificationUseCase.UnreadCount(user.ID)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			response.User = user
+			response.UnreadNotifications = &unread
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BranchHandler handles HTTP requests for the branches of the library
+type BranchHandler struct {
+	branchUseCase *usecase.BranchUseCase
+}
+
+// NewBranchHandler creates a new branch handler
+func NewBranchHandler(branchUseCase *usecase.BranchUseCase) *BranchHandler {
+	return &BranchHandler{
+		branchUseCase: branchUseCase,
+	}
+}
+
+// CreateBranchRequest represents the request body for creating a branch
+type CreateBranchRequest struct {
+	Name string `json:"name" binding:"required"`
+	// AccessionPrefix gives the copies of the branch accession numbers of their own, e.g. HB-
+	AccessionPrefix string `json:"accession_prefix" example:"HB-"`
+}
+
+// GetBranches handles GET /api/admin/branches
+// @Summary List branches
+// @Description Retrieve the branches of the library ordered by name
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {array} entities.Branch
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/branches [get]
+func (h *BranchHandler) GetBranches(c *gin.Context) {
+	branches, err := h.branchUseCase.ListBranches()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, branches)
+}
+
+// CreateBranch handles POST /api/admin/branches
+// @Summary Create a branch
+// @Description Create a branch; books and copies may then be given to it, and librarians sending its ID in X-User-Branch can only change its books. Copies of branches with an accession prefix are numbered in a sequence of their own.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param branch body CreateBranchRequest true "Branch information"
+// @Success 201 {object} entities.Branch
+// @Failure 400 {object} handlers.ErrorResponse
+// @Router /admin/branches [post]
+func (h *BranchHandler) CreateBranch(c *gin.Context) {
+	var req CreateBranchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	branch := &entities.Branch{Name: req.Name, AccessionPrefix: req.AccessionPrefix}
+	if err := h.branchUseCase.CreateBranch(branch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, branch)
+}
+
+// GetBranch handles GET /api/admin/branches/:id
+// @Summary Get a branch by ID
+// @Description Retrieve a specific branch by its ID
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Branch ID"
+// @Success 200 {object} entities.Branch
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/branches/{id} [get]
+func (h *BranchHandler) GetBranch(c *gin.Context) {
+	branch, err := h.branchUseCase.GetBranch(c.Param("id"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domainerr.ErrBranchNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, branch)
+}
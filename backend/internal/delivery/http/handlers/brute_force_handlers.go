@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/domain/domainerr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BruteForceHandler handles HTTP requests about the bans of clients that fail to sign in
+type BruteForceHandler struct {
+	guard *middleware.BruteForceGuard
+}
+
+// NewBruteForceHandler creates a new brute-force handler
+func NewBruteForceHandler(guard *middleware.BruteForceGuard) *BruteForceHandler {
+	return &BruteForceHandler{
+		guard: guard,
+	}
+}
+
+// GetSignInBans handles GET /api/admin/sign-in-bans
+// @Summary List sign-in bans
+// @Description Report the lockout policy of sign-ins, and the client IPs (ip:...) and accounts (account:...) banned now after failing too often, the longest banned first
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} middleware.BruteForceStats
+// @Router /admin/sign-in-bans [get]
+func (h *BruteForceHandler) GetSignInBans(c *gin.Context) {
+	c.JSON(http.StatusOK, h.guard.Stats())
+}
+
+// LiftSignInBan handles DELETE /api/admin/sign-in-bans/:key
+// @Summary Lift a sign-in ban
+// @Description Unblock a client IP or account before its ban ends, forgetting its failures and strikes so its next ban is a first one again
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param key path string true "Banned key, e.g. ip:203.0.113.9 or account:admin@example.com"
+// @Success 200 {object} handlers.MessageResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /admin/sign-in-bans/{key} [delete]
+func (h *BruteForceHandler) LiftSignInBan(c *gin.Context) {
+	if !h.guard.Unban(c.Param("key")) {
+		c.JSON(http.StatusNotFound, gin.H{"error": domainerr.ErrBanNotFound.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "ban lifted successfully"})
+}
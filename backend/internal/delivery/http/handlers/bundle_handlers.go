@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BundleHandler handles HTTP requests for book bundle exports
+type BundleHandler struct {
+	bundleUseCase *usecase.BundleUseCase
+	// sortedJSON sorts the keys of bundles unless the request says otherwise
+	sortedJSON bool
+}
+
+// NewBundleHandler creates a new bundle handler
+func NewBundleHandler(bundleUseCase *usecase.BundleUseCase) *BundleHandler {
+	return &BundleHandler{
+		bundleUseCase: bundleUseCase,
+	}
+}
+
+// SetSortedJSON sets whether bundles are written with sorted keys by default
+func (h *BundleHandler) SetSortedJSON(sorted bool) {
+	h.sortedJSON = sorted
+}
+
+// GetBundle handles GET /api/books/:id/bundle
+// @Summary Export a book bundle
+// @Description Download a zip archive with the book record, its history, its cover image if it has one and a manifest. sorted=true sorts the keys of its JSON documents, so that bundles can be diffed. format=dc downloads only a Dublin Core record of the book instead, in XML unless Accept asks for application/json.
+// @Tags books
+// @Produce application/zip
+// @Produce xml
+// @Param id path string true "Book ID"
+// @Param sorted query bool false "Sort the keys of the JSON documents"
+// @Param format query string false "zip (default) or dc"
+// @Success 200 {file} file
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/{id}/bundle [get]
+func (h *BundleHandler) GetBundle(c *gin.Context) {
+	sorted, err := sortedKeys(c, h.sortedJSON)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sorted"})
+		return
+	}
+	format := c.DefaultQuery("format", "zip")
+	if format != "zip" && format != "dc" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be zip or dc"})
+		return
+	}
+
+	book, err := h.bundleUseCase.GetBook(c.Param("id"))
+	if err != nil {
+		if err.Error() == "book not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if format == "dc" {
+		record, err := h.bundleUseCase.DublinCore(book)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		extension := "xml"
+		if strings.Contains(c.GetHeader("Accept"), "application/json") {
+			extension = "json"
+		}
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="book-%s.dc.%s"`, book.ID, extension))
+		writeDublinCore(c, record)
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="book-%s.zip"`, book.ID))
+	c.Status(http.StatusOK)
+
+	if err := h.bundleUseCase.WriteBundle(book, c.Writer, sorted); err != nil {
+		// Headers are already sent, so the client only sees a truncated archive
+		log.Printf("Failed to write bundle for book %s: %v", book.ID, err)
+	}
+}
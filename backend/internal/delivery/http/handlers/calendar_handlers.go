@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CalendarHandler handles HTTP requests for the business hours and closed days of the library
+type CalendarHandler struct {
+	calendarUseCase *usecase.CalendarUseCase
+}
+
+// NewCalendarHandler creates a new calendar handler
+func NewCalendarHandler(calendarUseCase *usecase.CalendarUseCase) *CalendarHandler {
+	return &CalendarHandler{
+		calendarUseCase: calendarUseCase,
+	}
+}
+
+// BusinessHoursRequest represents the hours of a day of the week
+type BusinessHoursRequest struct {
+	// example: monday
+	Weekday string `json:"weekday" binding:"required"`
+	// example: 09:00
+	Opens string `json:"opens" binding:"required"`
+	// example: 17:00
+	Closes string `json:"closes" binding:"required"`
+}
+
+// SetBusinessHoursRequest represents the request body for replacing business hours
+type SetBusinessHoursRequest struct {
+	Hours []BusinessHoursRequest `json:"hours"`
+}
+
+// CreateClosedDayRequest represents the request body for closing on a day
+type CreateClosedDayRequest struct {
+	// BranchID is the branch that closes; without it the whole library does
+	BranchID *string `json:"branch_id"`
+	// example: 2026-12-25
+	Date string `json:"date" binding:"required"`
+	// example: Christmas Day
+	Name   string `json:"name"`
+	Yearly bool   `json:"yearly"`
+}
+
+// GetCalendar handles GET /api/admin/calendar
+// @Summary Get the calendar of the library or a branch
+// @Description Retrieve the business hours and closed days due dates follow: those of the library, or with branch_id those of a branch. Branches without hours of their own keep the hours of the library, and close on its closed days as well as their own.
+// @Tags admin
+// @Produce json
+// @Param branch_id query string false "Branch ID; without it the calendar of the whole library"
+// @Success 200 {object} entities.Calendar
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/calendar [get]
+func (h *CalendarHandler) GetCalendar(c *gin.Context) {
+	calendar, err := h.calendarUseCase.Calendar(c.Query("branch_id"))
+	if err != nil {
+		h.calendarError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+// SetBusinessHours handles PUT /api/admin/calendar/hours
+// @Summary Replace business hours
+// @Description Replace the weekly business hours of the library, or with branch_id of a branch. Weekdays without hours are closed, and due dates falling on them move to the next open day. No hours at all leave a branch with the hours of the library, and the library open every day.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param branch_id query string false "Branch ID; without it the hours of the whole library"
+// @Param hours body SetBusinessHoursRequest true "Hours by weekday"
+// @Success 200 {object} entities.Calendar
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /admin/calendar/hours [put]
+func (h *CalendarHandler) SetBusinessHours(c *gin.Context) {
+	var req SetBusinessHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hours := make([]entities.BusinessHours, len(req.Hours))
+	for i, day := range req.Hours {
+		hours[i] = entities.BusinessHours{Weekday: day.Weekday, Opens: day.Opens, Closes: day.Closes}
+	}
+	calendar, err := h.calendarUseCase.SetHours(c.Query("branch_id"), hours)
+	if err != nil {
+		h.calendarError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+// CreateClosedDay handles POST /api/admin/calendar/closed-days
+// @Summary Close on a day
+// @Description Close the library, or with branch_id a branch, on a date whatever its hours say. Yearly closed days are holidays falling on the same month and day every year from their date on. Due dates falling on closed days move to the next open day.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param day body CreateClosedDayRequest true "Closed day"
+// @Success 201 {object} entities.ClosedDay
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /admin/calendar/closed-days [post]
+func (h *CalendarHandler) CreateClosedDay(c *gin.Context) {
+	var req CreateClosedDayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	day := &entities.ClosedDay{BranchID: req.BranchID, Date: req.Date, Name: req.Name, Yearly: req.Yearly}
+	if err := h.calendarUseCase.AddClosedDay(day); err != nil {
+		h.calendarError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusCreated, day)
+}
+
+// DeleteClosedDay handles DELETE /api/admin/calendar/closed-days/:id
+// @Summary Open again on a closed day
+// @Description Delete a closed day. Due dates already moved off it are kept.
+// @Tags admin
+// @Param id path string true "Closed day ID"
+// @Success 204
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/calendar/closed-days/{id} [delete]
+func (h *CalendarHandler) DeleteClosedDay(c *gin.Context) {
+	if err := h.calendarUseCase.DeleteClosedDay(c.Param("id")); err != nil {
+		h.calendarError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// calendarError writes a calendar error: 404 for missing branches and closed days, and the given
+// status for anything else
+func (h *CalendarHandler) calendarError(c *gin.Context, err error, status int) {
+	if errors.Is(err, domainerr.ErrBranchNotFound) || errors.Is(err, domainerr.ErrClosedDayNotFound) {
+		status = http.StatusNotFound
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
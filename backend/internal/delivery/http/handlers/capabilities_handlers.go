@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Capabilities tells which optional subsystems the deployment runs, as they were wired when the
+// server started, so clients and SDKs can adapt instead of probing routes
+// swagger:model Capabilities
+type Capabilities struct {
+	Auth AuthCapabilities `json:"auth"`
+	// Members borrow, renew and return books
+	Loans bool `json:"loans"`
+	// Members place holds on books out on loan, kept for them once returned
+	Reservations bool `json:"reservations"`
+	// Events are delivered to the webhook channel
+	Webhooks bool `json:"webhooks"`
+	// Channels events are delivered through
+	// example: ["email","webhook"]
+	Notifications []string            `json:"notifications"`
+	Search        SearchCapabilities  `json:"search"`
+	Storage       StorageCapabilities `json:"storage"`
+	// Cache of processed URLs: memory, redis or none
+	// example: memory
+	URLCache string `json:"url_cache"`
+	// Where instances keep the state they share, such as rate limits and events: memory (each
+	// instance on its own) or redis
+	// example: memory
+	State string `json:"state"`
+	// Books are enriched from Open Library by admin jobs
+	Enrichment bool `json:"enrichment"`
+	// The server is a sandbox, whose data is reset nightly
+	Sandbox bool `json:"sandbox"`
+}
+
+// AuthCapabilities tells how requests are signed in
+// swagger:model AuthCapabilities
+type AuthCapabilities struct {
+	// Ways callers are identified: identity_headers (X-User-ID and X-User-Role set by a trusted
+	// gateway), sessions and access_tokens (bearer tokens)
+	// example: ["identity_headers","sessions","access_tokens"]
+	Modes []string `json:"modes"`
+	// Admins sign in to the HTML admin pages with their email and password
+	AdminUI bool `json:"admin_ui"`
+	// Admin routes only accept requests from allowed networks
+	AdminNetworksRestricted bool `json:"admin_networks_restricted"`
+}
+
+// SearchCapabilities tells how book searches are served
+// swagger:model SearchCapabilities
+type SearchCapabilities struct {
+	// example: postgres
+	Backend string `json:"backend"`
+	// Listings and searches are served from a cache, refreshed in the background once stale
+	Cached bool `json:"cached"`
+	// Expensive searches are limited per caller and may get 429
+	Throttled bool `json:"throttled"`
+}
+
+// StorageCapabilities tells where files are kept
+// swagger:model StorageCapabilities
+type StorageCapabilities struct {
+	// Where cover images are stored
+	// example: filesystem
+	Covers string `json:"covers"`
+	// Covers can be set from a URL the server fetches
+	CoverFetch bool `json:"cover_fetch"`
+	// Storage areas are served read-only over WebDAV
+	WebDAV bool `json:"webdav"`
+}
+
+// CapabilitiesHandler handles HTTP requests about the subsystems of the deployment
+type CapabilitiesHandler struct {
+	capabilities Capabilities
+}
+
+// NewCapabilitiesHandler creates a new capabilities handler reporting capabilities
+func NewCapabilitiesHandler(capabilities Capabilities) *CapabilitiesHandler {
+	if capabilities.Notifications == nil {
+		capabilities.Notifications = []string{}
+	}
+	return &CapabilitiesHandler{
+		capabilities: capabilities,
+	}
+}
+
+// GetCapabilities handles GET /api/capabilities
+// @Summary Get the capabilities of the deployment
+// @Description Report which optional subsystems this deployment runs, as wired at startup: how callers sign in, loans and reservations, notification channels and webhooks, the search backend, cover storage, the URL cache, shared state, enrichment and sandbox mode
+// @Tags config
+// @Accept json
+// @Produce json
+// @Success 200 {object} handlers.Capabilities
+// @Router /capabilities [get]
+func (h *CapabilitiesHandler) GetCapabilities(c *gin.Context) {
+	c.JSON(http.StatusOK, h.capabilities)
+}
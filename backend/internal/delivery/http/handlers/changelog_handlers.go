@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"library-management-system/internal/delivery/http/changelog"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChangelogResponse lists the changes of the API versions
+// swagger:model ChangelogResponse
+type ChangelogResponse struct {
+	// Version of the API this server runs
+	// example: 1.1
+	Current string `json:"current"`
+	// Versions newest first, with their changes since the previous version
+	Versions []changelog.Version `json:"versions"`
+}
+
+// ChangelogHandler handles HTTP requests about the changes of the API
+type ChangelogHandler struct {
+	versions []changelog.Version
+	current  string
+}
+
+// NewChangelogHandler creates a new changelog handler serving versions, of which the server runs current
+func NewChangelogHandler(versions []changelog.Version, current string) *ChangelogHandler {
+	return &ChangelogHandler{
+		versions: versions,
+		current:  current,
+	}
+}
+
+// GetChangelog handles GET /api/changelog
+// @Summary Get the API changelog
+// @Description Retrieve the changes of every API version, newest first, so integrators can check what changed between the versions they run against. With since, only the versions after it are listed.
+// @Tags changelog
+// @Accept json
+// @Produce json
+// @Param since query string false "Only list the versions after this one, e.g. 1.0"
+// @Success 200 {object} handlers.ChangelogResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Router /changelog [get]
+func (h *ChangelogHandler) GetChangelog(c *gin.Context) {
+	versions, ok := changelog.Since(h.versions, c.Query("since"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown version %q", c.Query("since"))})
+		return
+	}
+	if versions == nil {
+		versions = []changelog.Version{}
+	}
+
+	c.JSON(http.StatusOK, ChangelogResponse{Current: h.current, Versions: versions})
+}
@@ -0,0 +1,436 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CollectionHandler handles HTTP requests for reading lists
+type CollectionHandler struct {
+	collectionUseCase *usecase.CollectionUseCase
+}
+
+// NewCollectionHandler creates a new collection handler
+func NewCollectionHandler(collectionUseCase *usecase.CollectionUseCase) *CollectionHandler {
+	return &CollectionHandler{
+		collectionUseCase: collectionUseCase,
+	}
+}
+
+// CollectionRequest represents the request body for creating or updating a collection
+type CollectionRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+}
+
+// AddCollectionBookRequest represents the request body for adding a book to a collection
+type AddCollectionBookRequest struct {
+	BookID string `json:"book_id" binding:"required"`
+	// 1-based position to insert the book at; the book is appended when omitted
+	Position int `json:"position"`
+}
+
+// ReorderCollectionRequest represents the request body for reordering the books of a collection
+type ReorderCollectionRequest struct {
+	BookIDs []string `json:"book_ids" binding:"required"`
+}
+
+// CollectionResponse represents a collection with its books in order
+// swagger:model CollectionResponse
+type CollectionResponse struct {
+	entities.Collection
+	Books []BookResponse `json:"books"`
+}
+
+// SharedCollectionResponse is the read-only public view of a shared collection; it does not reveal the owner
+// swagger:model SharedCollectionResponse
+type SharedCollectionResponse struct {
+	// example: Summer reading
+	Title string `json:"title"`
+	// example: Books to take to the beach
+	Description string         `json:"description"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	Books       []BookResponse `json:"books"`
+}
+
+// GetCollections handles GET /api/collections
+// @Summary List collections
+// @Description Retrieve the caller's collections, newest first
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller ID"
+// @Success 200 {array} entities.Collection
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /collections [get]
+func (h *CollectionHandler) GetCollections(c *gin.Context) {
+	ownerID := callerID(c)
+	if ownerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	collections, err := h.collectionUseCase.ListCollections(ownerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, collections)
+}
+
+// CreateCollection handles POST /api/collections
+// @Summary Create a collection
+// @Description Create an empty collection owned by the caller
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller ID"
+// @Param collection body CollectionRequest true "Collection information"
+// @Success 201 {object} handlers.CollectionResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Router /collections [post]
+func (h *CollectionHandler) CreateCollection(c *gin.Context) {
+	ownerID := callerID(c)
+	if ownerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	var req CollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	collection := &entities.Collection{
+		Title:       req.Title,
+		Description: req.Description,
+	}
+
+	if err := h.collectionUseCase.CreateCollection(ownerID, collection); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, CollectionResponse{Collection: *collection, Books: []BookResponse{}})
+}
+
+// GetCollection handles GET /api/collections/:id
+// @Summary Get a collection
+// @Description Retrieve one of the caller's collections with its books in order
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller ID"
+// @Param id path string true "Collection ID"
+// @Success 200 {object} handlers.CollectionResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /collections/{id} [get]
+func (h *CollectionHandler) GetCollection(c *gin.Context) {
+	ownerID := callerID(c)
+	if ownerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	h.respondWithCollection(c, ownerID, c.Param("id"))
+}
+
+// UpdateCollection handles PUT /api/collections/:id
+// @Summary Update a collection
+// @Description Change the title and description of one of the caller's collections
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller ID"
+// @Param id path string true "Collection ID"
+// @Param collection body CollectionRequest true "Collection information"
+// @Success 200 {object} handlers.CollectionResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /collections/{id} [put]
+func (h *CollectionHandler) UpdateCollection(c *gin.Context) {
+	ownerID := callerID(c)
+	if ownerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	var req CollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	changes := &entities.Collection{
+		Title:       req.Title,
+		Description: req.Description,
+	}
+
+	if err := h.collectionUseCase.UpdateCollection(ownerID, c.Param("id"), changes); err != nil {
+		if err.Error() == "collection not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondWithCollection(c, ownerID, c.Param("id"))
+}
+
+// DeleteCollection handles DELETE /api/collections/:id
+// @Summary Delete a collection
+// @Description Delete one of the caller's collections; its books stay in the catalog
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller ID"
+// @Param id path string true "Collection ID"
+// @Success 200 {object} handlers.MessageResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /collections/{id} [delete]
+func (h *CollectionHandler) DeleteCollection(c *gin.Context) {
+	ownerID := callerID(c)
+	if ownerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	if err := h.collectionUseCase.DeleteCollection(ownerID, c.Param("id")); err != nil {
+		if err.Error() == "collection not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Collection deleted successfully"})
+}
+
+// AddBook handles POST /api/collections/:id/books
+// @Summary Add a book to a collection
+// @Description Insert a book at a position of one of the caller's collections, or append it
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller ID"
+// @Param id path string true "Collection ID"
+// @Param book body AddCollectionBookRequest true "Book to add"
+// @Success 200 {object} handlers.CollectionResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /collections/{id}/books [post]
+func (h *CollectionHandler) AddBook(c *gin.Context) {
+	ownerID := callerID(c)
+	if ownerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	var req AddCollectionBookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.collectionUseCase.AddBook(ownerID, c.Param("id"), req.BookID, req.Position); err != nil {
+		if err.Error() == "collection not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondWithCollection(c, ownerID, c.Param("id"))
+}
+
+// ReorderBooks handles PUT /api/collections/:id/books
+// @Summary Reorder the books of a collection
+// @Description Put the books of one of the caller's collections in the given order
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller ID"
+// @Param id path string true "Collection ID"
+// @Param order body ReorderCollectionRequest true "Every book of the collection in its new order"
+// @Success 200 {object} handlers.CollectionResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /collections/{id}/books [put]
+func (h *CollectionHandler) ReorderBooks(c *gin.Context) {
+	ownerID := callerID(c)
+	if ownerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	var req ReorderCollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.collectionUseCase.ReorderBooks(ownerID, c.Param("id"), req.BookIDs); err != nil {
+		if err.Error() == "collection not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondWithCollection(c, ownerID, c.Param("id"))
+}
+
+// RemoveBook handles DELETE /api/collections/:id/books/:bookId
+// @Summary Remove a book from a collection
+// @Description Remove a book from one of the caller's collections
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller ID"
+// @Param id path string true "Collection ID"
+// @Param bookId path string true "Book ID"
+// @Success 200 {object} handlers.CollectionResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /collections/{id}/books/{bookId} [delete]
+func (h *CollectionHandler) RemoveBook(c *gin.Context) {
+	ownerID := callerID(c)
+	if ownerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	if err := h.collectionUseCase.RemoveBook(ownerID, c.Param("id"), c.Param("bookId")); err != nil {
+		if err.Error() == "collection not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondWithCollection(c, ownerID, c.Param("id"))
+}
+
+// ShareCollection handles POST /api/collections/:id/share
+// @Summary Share a collection
+// @Description Give one of the caller's collections a share token exposing a read-only view at /shared/collections/{token}
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller ID"
+// @Param id path string true "Collection ID"
+// @Success 200 {object} entities.Collection
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /collections/{id}/share [post]
+func (h *CollectionHandler) ShareCollection(c *gin.Context) {
+	ownerID := callerID(c)
+	if ownerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	collection, err := h.collectionUseCase.Share(ownerID, c.Param("id"))
+	if err != nil {
+		if err.Error() == "collection not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, collection)
+}
+
+// UnshareCollection handles DELETE /api/collections/:id/share
+// @Summary Stop sharing a collection
+// @Description Revoke the share token of one of the caller's collections
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller ID"
+// @Param id path string true "Collection ID"
+// @Success 200 {object} entities.Collection
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /collections/{id}/share [delete]
+func (h *CollectionHandler) UnshareCollection(c *gin.Context) {
+	ownerID := callerID(c)
+	if ownerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	collection, err := h.collectionUseCase.Unshare(ownerID, c.Param("id"))
+	if err != nil {
+		if err.Error() == "collection not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, collection)
+}
+
+// GetSharedCollection handles GET /api/shared/collections/:token
+// @Summary View a shared collection
+// @Description Retrieve the read-only view of a collection through its share token; no caller ID is needed
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param token path string true "Share token"
+// @Success 200 {object} handlers.SharedCollectionResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /shared/collections/{token} [get]
+func (h *CollectionHandler) GetSharedCollection(c *gin.Context) {
+	collection, books, err := h.collectionUseCase.GetSharedCollection(c.Param("token"))
+	if err != nil {
+		if err.Error() == "collection not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, SharedCollectionResponse{
+		Title:       collection.Title,
+		Description: collection.Description,
+		UpdatedAt:   collection.UpdatedAt,
+		Books:       newBookResponses(books, bookView{}),
+	})
+}
+
+// respondWithCollection writes a collection of the caller and its books
+func (h *CollectionHandler) respondWithCollection(c *gin.Context, ownerID, id string) {
+	collection, books, err := h.collectionUseCase.GetCollection(ownerID, id)
+	if err != nil {
+		if err.Error() == "collection not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, CollectionResponse{Collection: *collection, Books: newBookResponses(books, bookView{})})
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// supportedLocales are the locales messages are written in; the API is only written in English
+var supportedLocales = []string{"en"}
+
+// PublicConfig holds the settings clients need to know, none of them secret
+// swagger:model PublicConfig
+type PublicConfig struct {
+	// example: v1
+	APIVersion string `json:"api_version"`
+	// example: /api
+	APIPrefix string         `json:"api_prefix"`
+	Features  PublicFeatures `json:"features"`
+	// Page sizes of the paginated listings, by listing
+	Pagination map[string]PageSizes `json:"pagination"`
+	// Locales the API writes messages in
+	// example: ["en"]
+	Locales []string `json:"locales"`
+	// Longest a long poll is held open, in seconds
+	// example: 30
+	LongPollTimeoutSeconds int              `json:"long_poll_timeout_seconds"`
+	Auth                   PublicAuthPolicy `json:"auth"`
+}
+
+// PublicAuthPolicy is what passwords must look like and how long tokens and sessions last, so
+// forms can be validated before they are sent
+// swagger:model PublicAuthPolicy
+type PublicAuthPolicy struct {
+	Password entities.PasswordPolicy `json:"password"`
+	// Default and longest lifetime of personal access tokens, in seconds; zero lets them last forever
+	// example: 7776000
+	AccessTokenLifetimeSeconds int `json:"access_token_lifetime_seconds"`
+	// example: 31536000
+	AccessTokenMaxLifetimeSeconds int `json:"access_token_max_lifetime_seconds"`
+	// example: 2592000
+	SessionLifetimeSeconds int `json:"session_lifetime_seconds"`
+	// Active sessions a user may have; signing in past it signs out the sessions seen least recently.
+	// Zero leaves them unlimited.
+	// example: 10
+	MaxSessionsPerUser int `json:"max_sessions_per_user"`
+}
+
+// PublicFeatures tells which optional features the server has turned on
+// swagger:model PublicFeatures
+type PublicFeatures struct {
+	// The v2 response format is served under /v2 and through the Accept header
+	APIV2 bool `json:"api_v2"`
+	// Requests are metered against a monthly quota, per signed-in user or else per client IP
+	Quota bool `json:"quota"`
+	// The anonymous URL processor requires an X-URL-Token: none, token or captcha
+	// example: none
+	URLTokenMode string `json:"url_token_mode"`
+	Swagger      bool   `json:"swagger"`
+	AdminUI      bool   `json:"admin_ui"`
+	// The server is a sandbox, whose data is reset nightly
+	Sandbox bool `json:"sandbox"`
+}
+
+// PageSizes are the default and largest page size of a listing
+// swagger:model PageSizes
+type PageSizes struct {
+	// example: 20
+	Default int `json:"default"`
+	// example: 100
+	Max int `json:"max"`
+}
+
+// ConfigHandler handles HTTP requests about the configuration of the server
+type ConfigHandler struct {
+	config PublicConfig
+}
+
+// NewConfigHandler creates a new config handler exposing config; the supported locales are filled in
+func NewConfigHandler(config PublicConfig) *ConfigHandler {
+	config.Locales = supportedLocales
+	return &ConfigHandler{
+		config: config,
+	}
+}
+
+// GetPublicConfig handles GET /api/config/public
+// @Summary Get the public configuration
+// @Description Retrieve the settings clients need, such as the API version, enabled features, page sizes, supported locales and the password, token and session policy, so they do not duplicate the server's configuration
+// @Tags config
+// @Accept json
+// @Produce json
+// @Success 200 {object} handlers.PublicConfig
+// @Router /config/public [get]
+func (h *ConfigHandler) GetPublicConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, h.config)
+}
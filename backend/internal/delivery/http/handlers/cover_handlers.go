@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CoverURLRequest sets a cover from the URL of its image instead of uploading it
+type CoverURLRequest struct {
+	URL string `json:"url" binding:"required" example:"https://covers.openlibrary.org/b/id/8432047-L.jpg"`
+}
+
+// CoverHandler handles HTTP requests for the cover images of books
+type CoverHandler struct {
+	coverUseCase *usecase.CoverUseCase
+}
+
+// NewCoverHandler creates a new cover handler
+func NewCoverHandler(coverUseCase *usecase.CoverUseCase) *CoverHandler {
+	return &CoverHandler{
+		coverUseCase: coverUseCase,
+	}
+}
+
+// UploadCover handles PUT /api/books/:id/cover
+// @Summary Upload a book cover
+// @Description Set the cover image of a book, replacing its current one. Send the image as the request body, or as the file field of a multipart form, or send a JSON body with the url of the image for the server to download it, within the same size limit, accepting image content types only and refusing private addresses. The format is read from the magic bytes, and a Content-Type naming another image format is refused. Dimensions are checked from the header before the image is decoded, and metadata such as EXIF is stripped before storage. With a malware scanner configured, the scan result is recorded on the image, and images it finds malware in or fails to check may be rejected. Images are stored once per content hash, so books uploading identical artwork share it; references tells how many books use the image.
+// @Tags books
+// @Accept image/jpeg,image/png,image/gif,image/webp,multipart/form-data,json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param file formData file false "Cover image"
+// @Param request body handlers.CoverURLRequest false "URL of the cover image"
+// @Success 200 {object} entities.BookCover
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 403 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 413 {object} handlers.ErrorResponse
+// @Failure 415 {object} handlers.ErrorResponse
+// @Failure 422 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Failure 503 {object} handlers.ErrorResponse
+// @Router /books/{id}/cover [put]
+func (h *CoverHandler) UploadCover(c *gin.Context) {
+	body := io.Reader(c.Request.Body)
+	declaredType := c.ContentType()
+	if declaredType == "application/json" {
+		h.fetchCover(c)
+		return
+	}
+	if strings.HasPrefix(declaredType, "multipart/form-data") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cover file is required"})
+			return
+		}
+		upload, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		defer upload.Close()
+		body = upload
+		declaredType = file.Header.Get("Content-Type")
+	}
+	// One byte over the limit is enough for the use case to refuse it
+	data, err := io.ReadAll(io.LimitReader(body, h.coverUseCase.MaxBytes()+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cover, err := h.coverUseCase.As(caller(c)).SetCover(c.Param("id"), data, declaredType)
+	if err != nil {
+		writeCoverError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cover)
+}
+
+// fetchCover sets the cover of a book from the URL of its image
+func (h *CoverHandler) fetchCover(c *gin.Context) {
+	var req CoverURLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cover, err := h.coverUseCase.As(caller(c)).SetCoverFromURL(c.Request.Context(), c.Param("id"), req.URL)
+	if err != nil {
+		writeCoverError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cover)
+}
+
+// GetCover handles GET /api/books/:id/cover
+// @Summary Get a book cover
+// @Description Download the cover image of a book. Its ETag is the content hash of the image, so clients can revalidate with If-None-Match.
+// @Tags books
+// @Produce image/jpeg,image/png,image/gif,image/webp
+// @Param id path string true "Book ID"
+// @Success 200 {file} file
+// @Success 304
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/{id}/cover [get]
+func (h *CoverHandler) GetCover(c *gin.Context) {
+	cover, err := h.coverUseCase.GetCover(c.Param("id"))
+	if err != nil {
+		writeCoverError(c, err)
+		return
+	}
+
+	etag := `"` + cover.Hash + `"`
+	c.Header("ETag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	_, data, err := h.coverUseCase.OpenCover(c.Param("id"))
+	if err != nil {
+		writeCoverError(c, err)
+		return
+	}
+	c.Data(http.StatusOK, cover.ContentType, data)
+}
+
+// DeleteCover handles DELETE /api/books/:id/cover
+// @Summary Delete a book cover
+// @Description Remove the cover of a book. Its image is deleted unless other books use it too.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Success 200 {object} handlers.MessageResponse
+// @Failure 403 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/{id}/cover [delete]
+func (h *CoverHandler) DeleteCover(c *gin.Context) {
+	if err := h.coverUseCase.As(caller(c)).DeleteCover(c.Param("id")); err != nil {
+		writeCoverError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "cover deleted successfully"})
+}
+
+// GetCoverDuplicateReport handles GET /api/admin/reports/cover-duplicates
+// @Summary Duplicate cover report
+// @Description List the groups of unrelated books whose covers are the same image, or look alike by their perceptual hashes, likely artwork attached to the wrong book. Books all of one work or one series are expected to share artwork and left out. WebP covers, and covers stored before perceptual hashes were computed, are only compared by content.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param max_distance query int false "Number of bits of the 64 of perceptual hashes images looking alike may differ by, 0 to 16" default(6)
+// @Success 200 {object} entities.CoverDuplicateReport
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/reports/cover-duplicates [get]
+func (h *CoverHandler) GetCoverDuplicateReport(c *gin.Context) {
+	maxDistance, err := strconv.Atoi(c.DefaultQuery("max_distance", strconv.Itoa(usecase.DefaultCoverDuplicateDistance)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid max_distance"})
+		return
+	}
+
+	report, err := h.coverUseCase.DuplicateReport(maxDistance)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrInvalidCoverDistance) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// writeCoverError writes a failed cover request; defined errors keep their status
+func writeCoverError(c *gin.Context, err error) {
+	if e, ok := domainerr.Lookup(err); ok {
+		c.Error(err)
+		c.JSON(e.Status, gin.H{"error": e.Error()})
+		return
+	}
+	if err.Error() == "book ID is required" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
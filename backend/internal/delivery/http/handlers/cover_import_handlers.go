@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"library-management-system/internal/delivery/http/urlbuilder"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CoverImportHandler handles HTTP requests for bulk cover imports
+type CoverImportHandler struct {
+	coverImportUseCase *usecase.CoverImportUseCase
+	links              *urlbuilder.Builder
+}
+
+// NewCoverImportHandler creates a new cover import handler
+func NewCoverImportHandler(coverImportUseCase *usecase.CoverImportUseCase) *CoverImportHandler {
+	return &CoverImportHandler{
+		coverImportUseCase: coverImportUseCase,
+	}
+}
+
+// SetLinks makes Location headers absolute URLs built by links
+func (h *CoverImportHandler) SetLinks(links *urlbuilder.Builder) {
+	h.links = links
+}
+
+// ImportCovers handles POST /api/books/covers/import
+// @Summary Import book covers from a ZIP archive
+// @Description Start a job setting the covers of books from a ZIP archive of images, each named by the ISBN of its book, in any folder, such as covers/9780141439518.jpg. Send the archive as the request body, or as the file field of a multipart form. Each image is checked and stored like an upload to PUT /books/{id}/cover, its extension standing for its content type, and the job reports on each. Directories, __MACOSX/ and hidden files are skipped.
+// @Tags books
+// @Accept application/zip,multipart/form-data
+// @Produce json
+// @Param file formData file false "ZIP archive of cover images"
+// @Success 202 {object} entities.CoverImportJob
+// @Header 202 {string} Location "URL of the job"
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 413 {object} handlers.ErrorResponse
+// @Failure 503 {object} handlers.ErrorResponse
+// @Router /books/covers/import [post]
+func (h *CoverImportHandler) ImportCovers(c *gin.Context) {
+	body := io.Reader(c.Request.Body)
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cover archive file is required"})
+			return
+		}
+		upload, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		defer upload.Close()
+		body = upload
+	}
+	// One byte over the limit is enough for the use case to refuse it
+	archive, err := io.ReadAll(io.LimitReader(body, h.coverImportUseCase.MaxBytes()+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, err := h.coverImportUseCase.Submit(c.Request.Context(), &usecase.CoverImportRequest{
+		Archive: archive,
+		Actor:   caller(c),
+	})
+	if err != nil {
+		writeCoverError(c, err)
+		return
+	}
+
+	status := http.StatusAccepted
+	if job.Status == entities.CoverImportCompleted || job.Status == entities.CoverImportFailed {
+		status = http.StatusOK
+	}
+	c.Header("Location", h.links.URL(c.FullPath()+"/"+job.ID))
+	c.JSON(status, job)
+}
+
+// GetCoverImport handles GET /api/books/covers/import/:id
+// @Summary Get a cover import
+// @Description Retrieve the status of a cover import, with the outcome of each image imported so far
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Cover import job ID"
+// @Success 200 {object} entities.CoverImportJob
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/covers/import/{id} [get]
+func (h *CoverImportHandler) GetCoverImport(c *gin.Context) {
+	job, err := h.coverImportUseCase.GetJob(c.Param("id"))
+	if err != nil {
+		writeCoverError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
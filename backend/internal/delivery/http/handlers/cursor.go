@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/domainerr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NextCursorHeader carries the cursor of the next page of a listing paginated by cursor, to send
+// back as the cursor query parameter; it is absent on the last page
+const NextCursorHeader = "X-Next-Cursor"
+
+// setNextCursor tells the cursor of the next page, if any
+func setNextCursor(c *gin.Context, next string) {
+	if next != "" {
+		c.Header(NextCursorHeader, next)
+	}
+}
+
+// listingError writes the error of a listing: 400 with its code for a cursor that is invalid or
+// of another query, 500 for anything else
+func listingError(c *gin.Context, err error) {
+	if e, ok := domainerr.Lookup(err); ok {
+		c.Error(err)
+		c.JSON(e.Status, gin.H{"error": e.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
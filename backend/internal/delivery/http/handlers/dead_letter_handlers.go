@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeadLetterHandler handles HTTP requests about background jobs that failed all their attempts
+type DeadLetterHandler struct {
+	deadLetterUseCase *usecase.DeadLetterUseCase
+}
+
+// NewDeadLetterHandler creates a new dead letter handler
+func NewDeadLetterHandler(deadLetterUseCase *usecase.DeadLetterUseCase) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		deadLetterUseCase: deadLetterUseCase,
+	}
+}
+
+// GetDeadLetters handles GET /api/admin/dead-letters
+// @Summary List dead letters
+// @Description Retrieve the latest background jobs that failed all their attempts, newest first. When there are more, X-Next-Cursor holds the cursor of the next page, which only continues the listing of the same queue.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param queue query string false "Worker pool, e.g. notifications"
+// @Param limit query int false "Number of dead letters" default(50)
+// @Param cursor query string false "Cursor of the page, from X-Next-Cursor"
+// @Success 200 {array} entities.DeadLetter
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page; absent on the last page"
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/dead-letters [get]
+func (h *DeadLetterHandler) GetDeadLetters(c *gin.Context) {
+	limit, err := middleware.PageSize(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return
+	}
+
+	deadLetters, next, err := h.deadLetterUseCase.ListDeadLetters(c.Query("queue"), limit, c.Query("cursor"))
+	if err != nil {
+		listingError(c, err)
+		return
+	}
+	if deadLetters == nil {
+		deadLetters = []entities.DeadLetter{}
+	}
+	setNextCursor(c, next)
+
+	c.JSON(http.StatusOK, deadLetters)
+}
+
+// GetDeadLetter handles GET /api/admin/dead-letters/:id
+// @Summary Get a dead letter
+// @Description Retrieve a failed background job with its payload and last error
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Dead letter ID"
+// @Success 200 {object} entities.DeadLetter
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/dead-letters/{id} [get]
+func (h *DeadLetterHandler) GetDeadLetter(c *gin.Context) {
+	deadLetter, err := h.deadLetterUseCase.GetDeadLetter(c.Param("id"))
+	if err != nil {
+		if err.Error() == "dead letter not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, deadLetter)
+}
+
+// RequeueDeadLetter handles POST /api/admin/dead-letters/:id/requeue
+// @Summary Requeue a dead letter
+// @Description Run a failed job again with its original number of attempts; it is removed from the dead letters and comes back if it fails again
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Dead letter ID"
+// @Success 200 {object} handlers.MessageResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 503 {object} handlers.ErrorResponse
+// @Router /admin/dead-letters/{id}/requeue [post]
+func (h *DeadLetterHandler) RequeueDeadLetter(c *gin.Context) {
+	if err := h.deadLetterUseCase.RequeueDeadLetter(c.Param("id")); err != nil {
+		switch err.Error() {
+		case "dead letter not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "job queue is full":
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "dead letter requeued successfully"})
+}
+
+// DiscardDeadLetter handles DELETE /api/admin/dead-letters/:id
+// @Summary Discard a dead letter
+// @Description Delete a failed job for good
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Dead letter ID"
+// @Success 200 {object} handlers.MessageResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/dead-letters/{id} [delete]
+func (h *DeadLetterHandler) DiscardDeadLetter(c *gin.Context) {
+	if err := h.deadLetterUseCase.DiscardDeadLetter(c.Param("id")); err != nil {
+		if err.Error() == "dead letter not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "dead letter discarded successfully"})
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"library-management-system/internal/domain/document"
+	"library-management-system/internal/domain/sortedjson"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeDocument renders a report as a file attachment named after the report
+func writeDocument(c *gin.Context, renderer document.Renderer, doc document.Document, name string) {
+	if renderer == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "pdf output is not available"})
+		return
+	}
+
+	// Render into memory first so a failure can still be reported as JSON
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, doc); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, renderer.Extension()))
+	c.Data(http.StatusOK, renderer.ContentType(), buf.Bytes())
+}
+
+// sortedKeys reports whether an export is written with the keys of its JSON objects sorted: as the
+// sorted query parameter asks, or else byDefault
+func sortedKeys(c *gin.Context, byDefault bool) (bool, error) {
+	value := c.Query("sorted")
+	if value == "" {
+		return byDefault, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// writeSortedJSON writes a JSON response with the keys of every object sorted, so that exports of
+// the same data are identical byte for byte
+func writeSortedJSON(c *gin.Context, status int, v interface{}) {
+	data, err := sortedjson.Marshal(v, "")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Data(status, "application/json; charset=utf-8", data)
+}
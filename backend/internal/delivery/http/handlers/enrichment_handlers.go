@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"library-management-system/internal/delivery/http/urlbuilder"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EnrichmentRequest is the optional JSON body of enrichment jobs
+type EnrichmentRequest struct {
+	// Apply updates the books right away; by default their changes are saved as drafts for review
+	Apply bool `json:"apply"`
+	// Limit bounds the books looked up, ENRICH_MAX_BOOKS by default
+	Limit int `json:"limit" example:"100"`
+}
+
+// EnrichmentHandler handles HTTP requests for bulk metadata enrichment
+type EnrichmentHandler struct {
+	enrichmentUseCase *usecase.EnrichmentUseCase
+	links             *urlbuilder.Builder
+}
+
+// NewEnrichmentHandler creates a new enrichment handler
+func NewEnrichmentHandler(enrichmentUseCase *usecase.EnrichmentUseCase) *EnrichmentHandler {
+	return &EnrichmentHandler{
+		enrichmentUseCase: enrichmentUseCase,
+	}
+}
+
+// SetLinks makes Location headers absolute URLs built by links
+func (h *EnrichmentHandler) SetLinks(links *urlbuilder.Builder) {
+	h.links = links
+}
+
+// StartEnrichment handles POST /api/admin/enrich
+// @Summary Enrich the metadata of books
+// @Description Start a job looking up the books missing a description, publisher or cover with the metadata provider, at a limited rate. Their changes are saved as drafts for review, and covers proposed on the job, unless apply is set. Books with a draft already are left alone. The caller, by X-User-ID, is notified of the summary when the job completes.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body handlers.EnrichmentRequest false "Enrichment options"
+// @Success 202 {object} entities.EnrichmentJob
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 503 {object} handlers.ErrorResponse
+// @Router /admin/enrich [post]
+func (h *EnrichmentHandler) StartEnrichment(c *gin.Context) {
+	var req EnrichmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, err := h.enrichmentUseCase.Submit(c.Request.Context(), &usecase.EnrichmentRequest{
+		Apply:       req.Apply,
+		Limit:       req.Limit,
+		RequestedBy: caller(c).UserID,
+	})
+	if err != nil {
+		if errors.Is(err, domainerr.ErrQueueFull) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := http.StatusAccepted
+	if job.Status == entities.EnrichmentJobCompleted || job.Status == entities.EnrichmentJobFailed {
+		status = http.StatusOK
+	}
+	c.Header("Location", h.links.URL(c.FullPath()+"/"+job.ID))
+	c.JSON(status, job)
+}
+
+// GetEnrichmentJob handles GET /api/admin/enrich/:id
+// @Summary Get an enrichment job
+// @Description Retrieve the status of an enrichment job, with its summary and the books it updated once it completes
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Enrichment job ID"
+// @Success 200 {object} entities.EnrichmentJob
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/enrich/{id} [get]
+func (h *EnrichmentHandler) GetEnrichmentJob(c *gin.Context) {
+	job, err := h.enrichmentUseCase.GetJob(c.Param("id"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domainerr.ErrEnrichmentJobNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
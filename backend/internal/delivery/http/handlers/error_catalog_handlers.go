@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/domainerr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorCode describes an error the API can return
+// swagger:model ErrorCode
+type ErrorCode struct {
+	// Stable machine-readable code
+	// example: book_not_found
+	Code string `json:"code"`
+	// HTTP status the error is usually returned with
+	// example: 404
+	Status int `json:"status"`
+	// Message returned in the "error" field of the response
+	// example: book not found
+	Message string `json:"message"`
+	// What the error means
+	Description string `json:"description"`
+	// Link to the documentation of the error
+	Docs string `json:"docs"`
+}
+
+// ErrorCatalogHandler handles HTTP requests about the error catalog
+type ErrorCatalogHandler struct {
+	docsURL string
+}
+
+// NewErrorCatalogHandler creates a new error catalog handler; each error links to docsURL#code
+func NewErrorCatalogHandler(docsURL string) *ErrorCatalogHandler {
+	return &ErrorCatalogHandler{
+		docsURL: docsURL,
+	}
+}
+
+// GetErrors handles GET /api/errors
+// @Summary List error codes
+// @Description Retrieve the catalog of domain errors so clients can map error responses to stable codes
+// @Tags errors
+// @Accept json
+// @Produce json
+// @Success 200 {array} handlers.ErrorCode
+// @Router /errors [get]
+func (h *ErrorCatalogHandler) GetErrors(c *gin.Context) {
+	catalog := domainerr.Catalog()
+	codes := make([]ErrorCode, 0, len(catalog))
+	for _, e := range catalog {
+		codes = append(codes, ErrorCode{
+			Code:        e.Code,
+			Status:      e.Status,
+			Message:     e.Message,
+			Description: e.Description,
+			Docs:        h.docsURL + "#" + e.Code,
+		})
+	}
+
+	c.JSON(http.StatusOK, codes)
+}
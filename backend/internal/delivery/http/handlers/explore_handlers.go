@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExploreHandler handles HTTP requests for the ad-hoc reports of the query explorer
+type ExploreHandler struct {
+	exploreUseCase *usecase.ExploreUseCase
+}
+
+// NewExploreHandler creates a new query explorer handler
+func NewExploreHandler(exploreUseCase *usecase.ExploreUseCase) *ExploreHandler {
+	return &ExploreHandler{
+		exploreUseCase: exploreUseCase,
+	}
+}
+
+// GetExploreEntities handles GET /api/admin/reports/explore
+// @Summary List what the query explorer can report on
+// @Description List the entities and fields queries to POST /api/admin/reports/explore can filter, group and aggregate on, with the types of the fields
+// @Tags reports
+// @Produce json
+// @Success 200 {array} entities.ExploreEntity
+// @Router /admin/reports/explore [get]
+func (h *ExploreHandler) GetExploreEntities(c *gin.Context) {
+	c.JSON(http.StatusOK, h.exploreUseCase.Entities())
+}
+
+// Explore handles POST /api/admin/reports/explore
+// @Summary Run an ad-hoc report
+// @Description Count, sum, average or find the bounds of the fields of books, loans or acquisitions, filtered and grouped by other fields. Only the entities and fields of GET /api/admin/reports/explore can be named; eq, ne and in filter every field, gt, gte, lt and lte integer and time fields, and times are RFC 3339. Groups come in ascending order, at most limit of them.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param query body entities.ExploreQuery true "Query"
+// @Success 200 {object} entities.ExploreResult
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 429 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/reports/explore [post]
+func (h *ExploreHandler) Explore(c *gin.Context) {
+	var query entities.ExploreQuery
+	if err := c.ShouldBindJSON(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.exploreUseCase.Validate(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.exploreUseCase.Explore(&query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,372 @@
+package handlers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"library-management-system/internal/delivery/http/urlbuilder"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Feed formats
+const (
+	jsonFeedVersion     = "https://jsonfeed.org/version/1.1"
+	atomNamespace       = "http://www.w3.org/2005/Atom"
+	dublinCoreNamespace = "http://purl.org/dc/elements/1.1/"
+)
+
+// JSONFeed is a feed in the JSON Feed format (https://jsonfeed.org/version/1.1)
+type JSONFeed struct {
+	Version     string         `json:"version" example:"https://jsonfeed.org/version/1.1"`
+	Title       string         `json:"title" example:"New arrivals"`
+	HomePageURL string         `json:"home_page_url" example:"https://library.example.com/"`
+	FeedURL     string         `json:"feed_url" example:"https://library.example.com/api/feeds/catalog.json"`
+	Items       []JSONFeedItem `json:"items"`
+}
+
+// JSONFeedItem is a book of a JSON feed
+type JSONFeedItem struct {
+	ID string `json:"id" example:"urn:uuid:3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21"`
+	// URL is the page of the book on the library site
+	URL         string `json:"url" example:"https://library.example.com/books/3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21"`
+	Title       string `json:"title" example:"Mort"`
+	ContentText string `json:"content_text" example:"Death takes an apprentice."`
+	// Image is the cover of the book, left out when it has none
+	Image         string           `json:"image,omitempty" example:"https://library.example.com/api/books/3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21/cover"`
+	DatePublished time.Time        `json:"date_published"`
+	DateModified  time.Time        `json:"date_modified"`
+	Authors       []JSONFeedAuthor `json:"authors"`
+	// Book is the book as the API lists it, an extension of the format
+	Book BookResponse `json:"_book"`
+}
+
+// JSONFeedAuthor is an author of a JSON feed item
+type JSONFeedAuthor struct {
+	Name string `json:"name" example:"Terry Pratchett"`
+}
+
+// rssFeed is a feed in the RSS 2.0 format (https://www.rssboard.org/rss-specification)
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	DC      string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string `xml:"title"`
+	Link          string `xml:"link"`
+	Description   string `xml:"description"`
+	LastBuildDate string `xml:"lastBuildDate"`
+	// Self links the feed to itself, which RSS has no element of its own for
+	Self  atomLink  `xml:"atom:link"`
+	Items []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	Description string        `xml:"description"`
+	Author      string        `xml:"dc:creator,omitempty"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate"`
+	Enclosure   *rssEnclosure `xml:"enclosure"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// atomFeed is a feed in the Atom format (RFC 4287), which OPDS 1.2 catalogs extend
+type atomFeed struct {
+	XMLName xml.Name `xml:"feed"`
+	XMLNS   string   `xml:"xmlns,attr"`
+	// The namespaces of the extensions used by OPDS catalogs
+	DC         string     `xml:"xmlns:dc,attr,omitempty"`
+	OPDS       string     `xml:"xmlns:opds,attr,omitempty"`
+	OpenSearch string     `xml:"xmlns:opensearch,attr,omitempty"`
+	ID         string     `xml:"id"`
+	Title      string     `xml:"title"`
+	Updated    string     `xml:"updated"`
+	Links      []atomLink `xml:"link"`
+	*atomPaging
+	Entries []atomEntry `xml:"entry"`
+}
+
+// atomPaging tells OpenSearch clients which page of how many results a feed is
+type atomPaging struct {
+	TotalResults int `xml:"opensearch:totalResults"`
+	ItemsPerPage int `xml:"opensearch:itemsPerPage"`
+	StartIndex   int `xml:"opensearch:startIndex"`
+}
+
+type atomEntry struct {
+	ID         string       `xml:"id"`
+	Title      string       `xml:"title"`
+	Updated    string       `xml:"updated"`
+	Published  string       `xml:"published,omitempty"`
+	Authors    []atomPerson `xml:"author"`
+	Identifier string       `xml:"dc:identifier,omitempty"`
+	Issued     string       `xml:"dc:issued,omitempty"`
+	Summary    string       `xml:"summary,omitempty"`
+	Content    *atomContent `xml:"content"`
+	Links      []atomLink   `xml:"link"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomLink struct {
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Href   string `xml:"href,attr"`
+	Length int64  `xml:"length,attr,omitempty"`
+}
+
+// FeedHandler handles HTTP requests for the feeds of the catalog other sites embed
+type FeedHandler struct {
+	feedUseCase *usecase.FeedUseCase
+	title       string
+	// site links to the pages of the library site, api to the API
+	site *urlbuilder.Builder
+	api  *urlbuilder.Builder
+}
+
+// NewFeedHandler creates a new feed handler for feeds named title
+func NewFeedHandler(feedUseCase *usecase.FeedUseCase, title string) *FeedHandler {
+	return &FeedHandler{
+		feedUseCase: feedUseCase,
+		title:       title,
+	}
+}
+
+// SetLinks makes the links of feeds absolute URLs, to pages built by site and to covers and
+// feeds built by api
+func (h *FeedHandler) SetLinks(site, api *urlbuilder.Builder) {
+	h.site = site
+	h.api = api
+}
+
+// GetCatalogJSON handles GET /api/feeds/catalog.json
+// @Summary Get the new arrivals as JSON Feed
+// @Description Retrieve the books most recently added to the catalog, newest first, as a JSON Feed for other sites to embed. Each item links to the page of the book and to its cover, and carries the book as the API lists it under _book. Any origin may read the feed. Last-Modified is when the latest book of the feed changed, so clients can revalidate with If-Modified-Since.
+// @Tags feeds
+// @Produce json
+// @Param limit query int false "Number of books, FEEDS_ITEMS by default and at most FEEDS_MAX_ITEMS"
+// @Success 200 {object} handlers.JSONFeed
+// @Success 304
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /feeds/catalog.json [get]
+func (h *FeedHandler) GetCatalogJSON(c *gin.Context) {
+	feed, ok := h.newArrivals(c)
+	if !ok {
+		return
+	}
+
+	response := JSONFeed{
+		Version:     jsonFeedVersion,
+		Title:       h.title,
+		HomePageURL: h.site.URL("/"),
+		FeedURL:     h.api.URL("/feeds/catalog.json"),
+		Items:       make([]JSONFeedItem, len(feed.Items)),
+	}
+	for i, item := range feed.Items {
+		book := item.Book
+		response.Items[i] = JSONFeedItem{
+			ID:            bookURN(book),
+			URL:           h.bookPage(book),
+			Title:         book.Title,
+			ContentText:   feedSummary(book),
+			DatePublished: book.CreatedAt.UTC(),
+			DateModified:  book.UpdatedAt.UTC(),
+			Authors:       []JSONFeedAuthor{{Name: book.Author}},
+			Book:          newBookResponse(book, bookView{}),
+		}
+		if item.Cover != nil {
+			response.Items[i].Image = h.coverURL(book)
+		}
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Data(http.StatusOK, "application/feed+json; charset=utf-8", body)
+}
+
+// GetCatalogRSS handles GET /api/feeds/catalog.rss
+// @Summary Get the new arrivals as RSS
+// @Description Retrieve the books most recently added to the catalog, newest first, as an RSS 2.0 feed. Covers are enclosures of their items. Any origin may read the feed, which clients can revalidate with If-Modified-Since.
+// @Tags feeds
+// @Produce xml
+// @Param limit query int false "Number of books, FEEDS_ITEMS by default and at most FEEDS_MAX_ITEMS"
+// @Success 200 {file} file
+// @Success 304
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /feeds/catalog.rss [get]
+func (h *FeedHandler) GetCatalogRSS(c *gin.Context) {
+	feed, ok := h.newArrivals(c)
+	if !ok {
+		return
+	}
+
+	channel := rssChannel{
+		Title:         h.title,
+		Link:          h.site.URL("/"),
+		Description:   h.title,
+		LastBuildDate: feed.Updated.Format(time.RFC1123Z),
+		Self:          atomLink{Rel: "self", Type: "application/rss+xml", Href: h.api.URL("/feeds/catalog.rss")},
+		Items:         make([]rssItem, len(feed.Items)),
+	}
+	for i, item := range feed.Items {
+		book := item.Book
+		channel.Items[i] = rssItem{
+			Title:       book.Title,
+			Link:        h.bookPage(book),
+			Description: feedSummary(book),
+			Author:      book.Author,
+			GUID:        rssGUID{Value: bookURN(book)},
+			PubDate:     book.CreatedAt.UTC().Format(time.RFC1123Z),
+		}
+		if item.Cover != nil {
+			channel.Items[i].Enclosure = &rssEnclosure{URL: h.coverURL(book), Length: item.Cover.Size, Type: item.Cover.ContentType}
+		}
+	}
+	writeXML(c, "application/rss+xml; charset=utf-8", rssFeed{Version: "2.0", Atom: atomNamespace, DC: dublinCoreNamespace, Channel: channel})
+}
+
+// GetCatalogAtom handles GET /api/feeds/catalog.atom
+// @Summary Get the new arrivals as Atom
+// @Description Retrieve the books most recently added to the catalog, newest first, as an Atom feed. Covers are enclosure links of their entries. Any origin may read the feed, which clients can revalidate with If-Modified-Since.
+// @Tags feeds
+// @Produce xml
+// @Param limit query int false "Number of books, FEEDS_ITEMS by default and at most FEEDS_MAX_ITEMS"
+// @Success 200 {file} file
+// @Success 304
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /feeds/catalog.atom [get]
+func (h *FeedHandler) GetCatalogAtom(c *gin.Context) {
+	feed, ok := h.newArrivals(c)
+	if !ok {
+		return
+	}
+
+	self := h.api.URL("/feeds/catalog.atom")
+	response := atomFeed{
+		XMLNS:   atomNamespace,
+		ID:      self,
+		Title:   h.title,
+		Updated: feed.Updated.Format(time.RFC3339),
+		Links: []atomLink{
+			{Rel: "self", Type: "application/atom+xml", Href: self},
+			{Rel: "alternate", Type: "text/html", Href: h.site.URL("/")},
+		},
+		Entries: make([]atomEntry, len(feed.Items)),
+	}
+	for i, item := range feed.Items {
+		book := item.Book
+		entry := atomEntry{
+			ID:        bookURN(book),
+			Title:     book.Title,
+			Updated:   book.UpdatedAt.UTC().Format(time.RFC3339),
+			Published: book.CreatedAt.UTC().Format(time.RFC3339),
+			Authors:   []atomPerson{{Name: book.Author}},
+			Summary:   feedSummary(book),
+			Links:     []atomLink{{Rel: "alternate", Type: "text/html", Href: h.bookPage(book)}},
+		}
+		if item.Cover != nil {
+			entry.Links = append(entry.Links, atomLink{Rel: "enclosure", Type: item.Cover.ContentType, Href: h.coverURL(book), Length: item.Cover.Size})
+		}
+		response.Entries[i] = entry
+	}
+	writeXML(c, "application/atom+xml; charset=utf-8", response)
+}
+
+// newArrivals looks up the books of a feed and answers conditional requests, writing the error
+// or Not Modified response when there is nothing more to write
+func (h *FeedHandler) newArrivals(c *gin.Context) (*usecase.Feed, bool) {
+	// Feeds are public, so sites embedding them are not limited to CORS_ALLOWED_ORIGINS
+	c.Header("Access-Control-Allow-Origin", "*")
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return nil, false
+	}
+	if limit, err = h.feedUseCase.Limit(limit); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	feed, err := h.feedUseCase.NewArrivals(limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	updated := feed.Updated.Truncate(time.Second)
+	c.Header("Last-Modified", updated.Format(http.TimeFormat))
+	if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil && !updated.After(since) {
+		c.Status(http.StatusNotModified)
+		return nil, false
+	}
+	return feed, true
+}
+
+// writeXML writes an XML document, such as a feed
+func writeXML(c *gin.Context, contentType string, document interface{}) {
+	body, err := xml.Marshal(document)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Data(http.StatusOK, contentType, append([]byte(xml.Header), body...))
+}
+
+// bookPage returns the link to the page of a book on the library site
+func (h *FeedHandler) bookPage(book entities.Book) string {
+	return h.site.URL("/books/" + book.ID)
+}
+
+// coverURL returns the link to the cover image of a book
+func (h *FeedHandler) coverURL(book entities.Book) string {
+	return h.api.URL("/books/" + book.ID + "/cover")
+}
+
+// bookURN is the permanent ID of a book in feeds, which outlives its title and links
+func bookURN(book entities.Book) string {
+	return "urn:uuid:" + book.ID
+}
+
+// feedSummary describes a book in a feed, by its description when it has one
+func feedSummary(book entities.Book) string {
+	if book.Description != "" {
+		return book.Description
+	}
+	return fmt.Sprintf("%s by %s (%d)", book.Title, book.Author, book.Year)
+}
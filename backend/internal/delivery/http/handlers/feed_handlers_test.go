@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"library-management-system/internal/delivery/http/urlbuilder"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// feedCovers serves the covers of feed tests by book ID
+type feedCovers map[string]*entities.BookCover
+
+func (c feedCovers) Attach(bookID, hash, perceptualHash, contentType string, data []byte, scan *entities.ScanResult) (*entities.BookCover, error) {
+	return nil, nil
+}
+
+func (c feedCovers) Detach(bookID string) (bool, error) {
+	return false, nil
+}
+
+func (c feedCovers) GetByBookID(bookID string) (*entities.BookCover, error) {
+	return c[bookID], nil
+}
+
+func (c feedCovers) ListAttached() ([]entities.BookCover, error) {
+	return nil, nil
+}
+
+func (c feedCovers) Open(hash string) ([]byte, error) {
+	return nil, nil
+}
+
+func newFeedRouter(t *testing.T) *gin.Engine {
+	repo := newMemoryBookRepository()
+	added := time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC)
+	repo.books["3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21"] = entities.Book{
+		ID: "3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21", Title: "Mort", Author: "Terry Pratchett", Year: 1987,
+		Description: "Death takes an apprentice.", Status: entities.BookStatusActive, CreatedAt: added, UpdatedAt: added,
+	}
+	repo.books["9b1d2c3e-0000-4000-8000-000000000002"] = entities.Book{
+		ID: "9b1d2c3e-0000-4000-8000-000000000002", Title: "Eric", Author: "Terry Pratchett", Year: 1990,
+		Status: entities.BookStatusActive, CreatedAt: added.Add(-time.Hour), UpdatedAt: added.Add(-time.Hour),
+	}
+	covers := feedCovers{"3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21": {ContentType: "image/jpeg", Size: 2048}}
+
+	handler := NewFeedHandler(usecase.NewFeedUseCase(usecase.NewBookUseCase(repo), covers, 20, 50), "New arrivals")
+	site, err := urlbuilder.New("https://library.example.com")
+	require.NoError(t, err)
+	handler.SetLinks(site, site.Under("/api"))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/feeds/catalog.json", handler.GetCatalogJSON)
+	router.GET("/api/feeds/catalog.rss", handler.GetCatalogRSS)
+	router.GET("/api/feeds/catalog.atom", handler.GetCatalogAtom)
+	return router
+}
+
+func TestFeedHandler_JSON(t *testing.T) {
+	router := newFeedRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/feeds/catalog.json", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/feed+json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Wed, 14 Oct 2026 09:30:00 GMT", w.Header().Get("Last-Modified"))
+
+	var feed JSONFeed
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &feed))
+	assert.Equal(t, "https://library.example.com/api/feeds/catalog.json", feed.FeedURL)
+	require.Len(t, feed.Items, 2)
+	assert.Equal(t, "urn:uuid:3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21", feed.Items[0].ID)
+	assert.Equal(t, "https://library.example.com/books/3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21", feed.Items[0].URL)
+	assert.Equal(t, "https://library.example.com/api/books/3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21/cover", feed.Items[0].Image)
+	assert.Equal(t, "Death takes an apprentice.", feed.Items[0].ContentText)
+	assert.Empty(t, feed.Items[1].Image)
+	assert.Equal(t, "Eric by Terry Pratchett (1990)", feed.Items[1].ContentText)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/feeds/catalog.json", nil)
+	req.Header.Set("If-Modified-Since", "Wed, 14 Oct 2026 09:30:00 GMT")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/feeds/catalog.json?limit=51", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error": "limit must be at most 50"}`, w.Body.String())
+}
+
+func TestFeedHandler_XML(t *testing.T) {
+	router := newFeedRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/feeds/catalog.rss?limit=1", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/rss+xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:dc="http://purl.org/dc/elements/1.1/"><channel><title>New arrivals</title><link>https://library.example.com/</link><description>New arrivals</description><lastBuildDate>Wed, 14 Oct 2026 09:30:00 +0000</lastBuildDate><atom:link rel="self" type="application/rss+xml" href="https://library.example.com/api/feeds/catalog.rss"></atom:link><item><title>Mort</title><link>https://library.example.com/books/3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21</link><description>Death takes an apprentice.</description><dc:creator>Terry Pratchett</dc:creator><guid isPermaLink="false">urn:uuid:3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21</guid><pubDate>Wed, 14 Oct 2026 09:30:00 +0000</pubDate><enclosure url="https://library.example.com/api/books/3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21/cover" length="2048" type="image/jpeg"></enclosure></item></channel></rss>`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/feeds/catalog.atom?limit=1", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/atom+xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom"><id>https://library.example.com/api/feeds/catalog.atom</id><title>New arrivals</title><updated>2026-10-14T09:30:00Z</updated><link rel="self" type="application/atom+xml" href="https://library.example.com/api/feeds/catalog.atom"></link><link rel="alternate" type="text/html" href="https://library.example.com/"></link><entry><id>urn:uuid:3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21</id><title>Mort</title><updated>2026-10-14T09:30:00Z</updated><published>2026-10-14T09:30:00Z</published><author><name>Terry Pratchett</name></author><summary>Death takes an apprentice.</summary><link rel="alternate" type="text/html" href="https://library.example.com/books/3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21"></link><link rel="enclosure" type="image/jpeg" href="https://library.example.com/api/books/3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21/cover" length="2048"></link></entry></feed>`, w.Body.String())
+}
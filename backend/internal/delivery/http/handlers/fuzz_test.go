@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// stubBookRepository is an empty catalog that accepts every write
+type stubBookRepository struct{}
+
+func (stubBookRepository) Create(book *entities.Book) error          { return nil }
+func (stubBookRepository) GetByID(id string) (*entities.Book, error) { return nil, nil }
+func (stubBookRepository) GetByIDInBranch(id, branchID string) (*entities.Book, error) {
+	return nil, nil
+}
+func (stubBookRepository) GetAll() ([]entities.Book, error)                       { return nil, nil }
+func (stubBookRepository) Update(book *entities.Book) error                       { return nil }
+func (stubBookRepository) Delete(id string) error                                 { return nil }
+func (stubBookRepository) HardDelete(id string) error                             { return nil }
+func (stubBookRepository) FindByTitle(title string) ([]entities.Book, error)      { return nil, nil }
+func (stubBookRepository) FindByAuthor(author string) ([]entities.Book, error)    { return nil, nil }
+func (stubBookRepository) FindByYear(year int) ([]entities.Book, error)           { return nil, nil }
+func (stubBookRepository) FindByISBN(isbn string) (*entities.Book, error)         { return nil, nil }
+func (stubBookRepository) FindBySlug(slug string) (*entities.Book, error)         { return nil, nil }
+func (stubBookRepository) FindByPublishers(ids []string) ([]entities.Book, error) { return nil, nil }
+func (stubBookRepository) FindBySeries(id string) ([]entities.Book, error)        { return nil, nil }
+func (stubBookRepository) FindByWork(id string) ([]entities.Book, error)          { return nil, nil }
+func (stubBookRepository) SetWork(ids []string, workID *string) error             { return nil }
+func (stubBookRepository) GetDeletedBooks() ([]entities.Book, error)              { return nil, nil }
+func (stubBookRepository) Restore(id string) error                                { return nil }
+func (stubBookRepository) RestoreBatch(ids []string) error                        { return nil }
+func (stubBookRepository) DeleteBatch(ids []string) error                         { return nil }
+func (stubBookRepository) FindScheduled() ([]entities.Book, error)                { return nil, nil }
+func (stubBookRepository) MarkPublished(id string) error                          { return nil }
+func (stubBookRepository) SetCoverPalette(id string, palette []string) error      { return nil }
+func (stubBookRepository) Upsert(books []entities.Book, versions []*time.Time) ([]bool, error) {
+	return make([]bool, len(books)), nil
+}
+func (stubBookRepository) CreateBatch(books []entities.Book, chunkSize int, onConflict repositories.BatchConflict) (int64, error) {
+	return 0, nil
+}
+func (stubBookRepository) FindByMetadata(filters map[string]string) ([]entities.Book, error) {
+	return nil, nil
+}
+func (stubBookRepository) FindByIDsWithDeleted(ids []string) ([]entities.Book, error) {
+	return nil, nil
+}
+
+// fuzzRouter mounts the real handlers without the recovery middleware so panics fail the fuzz target
+func fuzzRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	bookHandler := NewBookHandler(usecase.NewBookUseCase(stubBookRepository{}))
+	urlHandler := NewURLHandler(usecase.NewURLUseCase(newMemoryURLRepository(nil)))
+
+	router.POST("/api/books", bookHandler.CreateBook)
+	router.PUT("/api/books/:id", bookHandler.UpdateBook)
+	router.POST("/api/url/process", urlHandler.ProcessURL)
+	return router
+}
+
+func FuzzCreateBookRequest(f *testing.F) {
+	seeds := []string{
+		`{"title":"Test Book","author":"Test Author","year":2024,"isbn":"1234567890"}`,
+		`{"title":"","author":"","year":0,"isbn":""}`,
+		`{"title":"Test","author":"Author","year":-1,"isbn":"12345678901234567890"}`,
+		`{"title":123,"author":null,"year":"2024","isbn":[]}`,
+		`{"year":99999999999999999999}`,
+		`{"title":"\u0000\ud800","author":"A","year":2024,"isbn":"123456789X"}`,
+		`{`,
+		`[]`,
+		`null`,
+		``,
+	}
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+	}
+
+	router := fuzzRouter()
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		for _, req := range []*http.Request{
+			httptest.NewRequest(http.MethodPost, "/api/books", bytes.NewReader(body)),
+			httptest.NewRequest(http.MethodPut, "/api/books/test-id", bytes.NewReader(body)),
+		} {
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code >= http.StatusInternalServerError {
+				t.Fatalf("%s %s returned %d for body %q", req.Method, req.URL.Path, w.Code, body)
+			}
+		}
+	})
+}
+
+func FuzzURLRequest(f *testing.F) {
+	seeds := []string{
+		`{"url":"https://BYFOOD.com/food-EXPeriences?query=abc/","operation":"all"}`,
+		`{"url":"https://example.com","operation":"canonical"}`,
+		`{"url":"https://example.com/path/","operation":"redirection"}`,
+		`{"url":"://missing-scheme","operation":"all"}`,
+		`{"url":"%zz","operation":"canonical"}`,
+		`{"url":"http://[::1]:namedport","operation":"all"}`,
+		`{"url":"","operation":""}`,
+		`{"url":42,"operation":true}`,
+		`{`,
+		``,
+	}
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+	}
+
+	router := fuzzRouter()
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		req := httptest.NewRequest(http.MethodPost, "/api/url/process", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK && w.Code != http.StatusBadRequest {
+			t.Fatalf("unexpected status %d for body %q", w.Code, body)
+		}
+	})
+}
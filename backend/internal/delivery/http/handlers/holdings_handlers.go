@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HoldingsHandler handles HTTP requests for the holdings of ISBNs
+type HoldingsHandler struct {
+	holdingsUseCase *usecase.HoldingsUseCase
+}
+
+// NewHoldingsHandler creates a new holdings handler
+func NewHoldingsHandler(holdingsUseCase *usecase.HoldingsUseCase) *HoldingsHandler {
+	return &HoldingsHandler{
+		holdingsUseCase: holdingsUseCase,
+	}
+}
+
+// GetAvailability handles GET /api/availability
+// @Summary Look up the availability of ISBNs
+// @Description Batch lookup for discovery services polling the library: the holding of each ISBN, keyed by the normalized ISBN, or null when the library does not hold it. Its ETag is a hash of the response, so pollers can revalidate with If-None-Match, and any origin may read it.
+// @Tags availability
+// @Produce json
+// @Param isbns query string true "ISBNs separated by commas; the parameter may repeat"
+// @Param If-None-Match header string false "ETag of the holdings the client has"
+// @Success 200 {object} map[string]usecase.Holding
+// @Success 304 "The holdings have not changed"
+// @Header 200 {string} ETag "Hash of the holdings"
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /availability [get]
+func (h *HoldingsHandler) GetAvailability(c *gin.Context) {
+	c.Header("Access-Control-Allow-Origin", "*")
+
+	isbns, err := h.holdingsUseCase.ISBNs(c.QueryArray("isbns"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	holdings, err := h.holdingsUseCase.Holdings(isbns)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Maps are encoded with sorted keys, so the same holdings always hash to the same ETag
+	body, err := json.Marshal(holdings)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Header("ETag", etag)
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// etagMatches reports whether an If-None-Match header names the ETag, comparing weakly as
+// RFC 9110 asks of If-None-Match
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHoldingsHandler_GetAvailability(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	books := newMemoryBookRepository()
+	books.books["book-6"] = entities.Book{ID: "book-6", Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "9780062225719", Status: entities.BookStatusActive}
+	useCase := usecase.NewHoldingsUseCase(usecase.NewBookUseCase(books), newMemoryLoanRepository(now), newMemoryHoldRepository(now), 10)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/availability", NewHoldingsHandler(useCase).GetAvailability)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/availability?isbns=978-0-06-222571-9,9780552152938", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.JSONEq(t, `{
+		"9780062225719": {"book_id": "book-6", "available": true, "loans": 1, "holds": 2, "due_back": "2026-10-22T12:00:00Z"},
+		"9780552152938": null
+	}`, w.Body.String())
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	// Pollers revalidate with the ETag, which does not depend on the order of the ISBNs
+	req := httptest.NewRequest(http.MethodGet, "/api/availability?isbns=9780552152938&isbns=9780062225719", nil)
+	req.Header.Set("If-None-Match", `"other", W/`+etag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/availability", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error": "isbns is required"}`, w.Body.String())
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// callerIDHeader carries the identity of the caller until authentication is in place
+const callerIDHeader = "X-User-ID"
+
+// callerID returns the identity of the user making the request
+func callerID(c *gin.Context) string {
+	return c.GetHeader(callerIDHeader)
+}
+
+// caller returns the user making the request as the actor of the changes it makes
+func caller(c *gin.Context) entities.Actor {
+	actor := entities.Actor{
+		UserID:   callerID(c),
+		Role:     c.GetHeader(middleware.RoleHeader),
+		BranchID: c.GetHeader(middleware.BranchHeader),
+		TenantID: c.GetHeader(middleware.TenantHeader),
+	}
+	// The impersonator comes from the session only, never from a header the client could forge
+	if session, ok := c.Get(middleware.SessionKey); ok && session.(*entities.Session).Impersonated() {
+		actor.ImpersonatorID = *session.(*entities.Session).ImpersonatorID
+	}
+	return actor
+}
+
+// deletionStatus returns 409 for books that cannot be deleted while out on loan, and the status
+// of denialStatus otherwise
+func deletionStatus(err error) int {
+	if errors.Is(err, domainerr.ErrBookOnLoan) {
+		return http.StatusConflict
+	}
+	return denialStatus(err, http.StatusBadRequest)
+}
+
+// denialStatus returns 403 for changes denied to the librarians of another branch, and status for
+// any other error
+func denialStatus(err error, status int) int {
+	if errors.Is(err, domainerr.ErrBranchAccessDenied) {
+		return http.StatusForbidden
+	}
+	return status
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultImpersonationReportDays is how many days the impersonation report covers by default
+const defaultImpersonationReportDays = 30
+
+// ImpersonateRequest is why an admin impersonates a member
+type ImpersonateRequest struct {
+	Reason string `json:"reason" binding:"required" example:"Reproduce ticket 4821: renewals refused"`
+}
+
+// ImpersonateMember handles POST /api/admin/members/:id/impersonate
+// @Summary Impersonate a member
+// @Description Start a session for the calling admin to act as a member, such as to reproduce an issue they report. The session token, shown in this response only, is sent as "Authorization: Bearer <token>" until the session expires after IMPERSONATION_LIFETIME or is ended. Every request changing anything made with it is audited under the member, flagged with the admin, and it cannot create or revoke the member's access tokens or sessions, or deactivate their account.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Admin user ID"
+// @Param id path string true "Member ID"
+// @Param impersonation body ImpersonateRequest true "Reason"
+// @Success 201 {object} handlers.SessionCreatedResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 403 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /admin/members/{id}/impersonate [post]
+func (h *SessionHandler) ImpersonateMember(c *gin.Context) {
+	var req ImpersonateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, secret, err := h.sessionUseCase.Impersonate(callerID(c), c.Param("id"), req.Reason, usecase.SessionClient{
+		Device: c.Request.UserAgent(),
+		IP:     c.ClientIP(),
+	})
+	if err != nil {
+		writeAccessTokenError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusCreated, SessionCreatedResponse{Session: *session, Token: secret})
+}
+
+// EndImpersonation handles DELETE /api/admin/impersonations/:id
+// @Summary End an impersonation
+// @Description Revoke an active impersonation session before it expires; requests presenting its token are refused from then on
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Admin user ID"
+// @Param id path string true "Impersonation session ID"
+// @Success 200 {object} entities.Session
+// @Failure 403 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/impersonations/{id} [delete]
+func (h *SessionHandler) EndImpersonation(c *gin.Context) {
+	session, err := h.sessionUseCase.EndImpersonation(callerID(c), c.Param("id"))
+	if err != nil {
+		writeAccessTokenError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// GetImpersonationReport handles GET /api/admin/reports/impersonations
+// @Summary Impersonation report
+// @Description List the impersonations admins started in the last days, newest first, with the admin, member, reason and expiry of each and its audit entries: its start, every request changing anything made with it, and its end
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param days query int false "Days covered, 1 to 365" default(30)
+// @Success 200 {array} entities.Impersonation
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/reports/impersonations [get]
+func (h *SessionHandler) GetImpersonationReport(c *gin.Context) {
+	days := defaultImpersonationReportDays
+	if raw := c.Query("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "days must be a number"})
+			return
+		}
+		days = parsed
+	}
+
+	report, err := h.sessionUseCase.ImpersonationReport(days)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrInvalidReportDays) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
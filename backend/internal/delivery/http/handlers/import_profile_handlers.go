@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportProfileHandler handles HTTP requests for the CSV import profiles of the calling tenant
+type ImportProfileHandler struct {
+	importProfileUseCase *usecase.ImportProfileUseCase
+}
+
+// NewImportProfileHandler creates a new import profile handler
+func NewImportProfileHandler(importProfileUseCase *usecase.ImportProfileUseCase) *ImportProfileHandler {
+	return &ImportProfileHandler{
+		importProfileUseCase: importProfileUseCase,
+	}
+}
+
+// SaveImportProfileRequest represents the request body for saving an import profile
+type SaveImportProfileRequest struct {
+	// CSV header of each imported column mapped to title, author, year, isbn, publisher_id,
+	// status or metadata.{key}
+	Columns map[string]string `json:"columns" binding:"required"`
+	// Go time layout of the year column when it holds dates, e.g. 02/01/2006
+	DateFormat string `json:"date_format" example:"02/01/2006"`
+	// Column separator, a comma by default
+	Delimiter string `json:"delimiter" example:";"`
+}
+
+// GetImportProfiles handles GET /api/import-profiles
+// @Summary Get import profiles
+// @Description Retrieve the CSV import profiles the tenant saved, ordered by name
+// @Tags import-profiles
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Success 200 {array} entities.ImportProfile
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /import-profiles [get]
+func (h *ImportProfileHandler) GetImportProfiles(c *gin.Context) {
+	tenantID := c.GetHeader(middleware.TenantHeader)
+	if tenantID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "tenant ID is required"})
+		return
+	}
+
+	profiles, err := h.importProfileUseCase.ListProfiles(tenantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, profiles)
+}
+
+// GetImportProfile handles GET /api/import-profiles/:name
+// @Summary Get an import profile
+// @Description Retrieve a CSV import profile of the tenant by name
+// @Tags import-profiles
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param name path string true "Profile name"
+// @Success 200 {object} entities.ImportProfile
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /import-profiles/{name} [get]
+func (h *ImportProfileHandler) GetImportProfile(c *gin.Context) {
+	profile, err := h.importProfileUseCase.GetProfile(c.GetHeader(middleware.TenantHeader), c.Param("name"))
+	if err != nil {
+		c.JSON(importProfileErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// SaveImportProfile handles PUT /api/import-profiles/:name
+// @Summary Save an import profile
+// @Description Create or replace a named CSV column mapping of the tenant, selected with POST /books/import?profile={name}
+// @Tags import-profiles
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param name path string true "Profile name, e.g. publisher-feed"
+// @Param profile body SaveImportProfileRequest true "Column mapping"
+// @Success 200 {object} entities.ImportProfile
+// @Failure 400 {object} handlers.ErrorResponse
+// @Router /import-profiles/{name} [put]
+func (h *ImportProfileHandler) SaveImportProfile(c *gin.Context) {
+	var req SaveImportProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile := &entities.ImportProfile{
+		TenantID:   c.GetHeader(middleware.TenantHeader),
+		Name:       c.Param("name"),
+		Columns:    req.Columns,
+		DateFormat: req.DateFormat,
+		Delimiter:  req.Delimiter,
+	}
+
+	if err := h.importProfileUseCase.SaveProfile(profile); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// DeleteImportProfile handles DELETE /api/import-profiles/:name
+// @Summary Delete an import profile
+// @Description Remove a CSV import profile of the tenant
+// @Tags import-profiles
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param name path string true "Profile name"
+// @Success 200 {object} handlers.MessageResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /import-profiles/{name} [delete]
+func (h *ImportProfileHandler) DeleteImportProfile(c *gin.Context) {
+	if err := h.importProfileUseCase.DeleteProfile(c.GetHeader(middleware.TenantHeader), c.Param("name")); err != nil {
+		c.JSON(importProfileErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "import profile deleted successfully"})
+}
+
+// importProfileErrorStatus returns the status of an error finding or deleting an import profile
+func importProfileErrorStatus(err error) int {
+	if errors.Is(err, domainerr.ErrImportProfileNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
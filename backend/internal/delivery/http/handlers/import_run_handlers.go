@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportRunHandler handles HTTP requests about the history of book imports
+type ImportRunHandler struct {
+	importRunUseCase *usecase.ImportRunUseCase
+}
+
+// NewImportRunHandler creates a new import run handler
+func NewImportRunHandler(importRunUseCase *usecase.ImportRunUseCase) *ImportRunHandler {
+	return &ImportRunHandler{
+		importRunUseCase: importRunUseCase,
+	}
+}
+
+// GetImportRuns handles GET /api/imports
+// @Summary List import runs
+// @Description Retrieve the latest book imports with the hash of their file and what they did, newest first. With a tenant, only its imports are listed. When there are more runs, X-Next-Cursor holds the cursor of the next page, which only continues the listing of the same tenant.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string false "Tenant ID"
+// @Param limit query int false "Number of runs" default(50)
+// @Param cursor query string false "Cursor of the page, from X-Next-Cursor"
+// @Success 200 {array} entities.ImportRun
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page; absent on the last page"
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /imports [get]
+func (h *ImportRunHandler) GetImportRuns(c *gin.Context) {
+	limit, err := middleware.PageSize(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return
+	}
+
+	runs, next, err := h.importRunUseCase.ListRuns(c.GetHeader(middleware.TenantHeader), limit, c.Query("cursor"))
+	if err != nil {
+		listingError(c, err)
+		return
+	}
+	if runs == nil {
+		runs = []entities.ImportRun{}
+	}
+	setNextCursor(c, next)
+
+	c.JSON(http.StatusOK, runs)
+}
+
+// GetImportRun handles GET /api/imports/:id
+// @Summary Get an import run
+// @Description Retrieve a book import with the books it rejected, or the error that failed it
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Import run ID"
+// @Success 200 {object} entities.ImportRun
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /imports/{id} [get]
+func (h *ImportRunHandler) GetImportRun(c *gin.Context) {
+	run, err := h.importRunUseCase.GetRun(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, domainerr.ErrImportRunNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
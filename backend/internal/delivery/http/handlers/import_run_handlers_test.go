@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/pagetoken"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportRunHandler_GetImportRunsByCursor(t *testing.T) {
+	at := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	repo := newMemoryImportRunRepository()
+	for i := 1; i <= 5; i++ {
+		tenant := "tenant-1"
+		if i == 3 {
+			tenant = "tenant-2"
+		}
+		require.NoError(t, repo.Create(&entities.ImportRun{TenantID: tenant, CreatedAt: at}))
+	}
+	useCase := usecase.NewImportRunUseCase(repo, 0, usecase.DuplicateImportReject)
+	useCase.SetCursorSigner(pagetoken.NewSigner("secret"))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/imports", NewImportRunHandler(useCase).GetImportRuns)
+	list := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(middleware.TenantHeader, "tenant-1")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Runs of the same time are paged through by ID, without those of other tenants
+	var ids []string
+	path := "/api/imports?limit=2"
+	for pages := 0; path != ""; pages++ {
+		require.Less(t, pages, 3)
+		w := list(path)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var runs []entities.ImportRun
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &runs))
+		for _, run := range runs {
+			ids = append(ids, run.ID)
+		}
+		path = ""
+		if next := w.Header().Get(NextCursorHeader); next != "" {
+			path = "/api/imports?limit=2&cursor=" + next
+		}
+	}
+	run := func(n int) string { return fmt.Sprintf("00000000-0000-0000-0000-%012d", 9000+n) }
+	assert.Equal(t, []string{run(5), run(4), run(2), run(1)}, ids)
+
+	next := list("/api/imports?limit=2").Header().Get(NextCursorHeader)
+	req := httptest.NewRequest(http.MethodGet, "/api/imports?cursor="+next, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error": "cursor belongs to another listing or query"}`, w.Body.String(), "the cursor of tenant-1 without its tenant")
+}
@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"library-management-system/internal/domain/document"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InventoryHandler handles HTTP requests for shelf audits
+type InventoryHandler struct {
+	inventoryUseCase *usecase.InventoryUseCase
+	renderer         document.Renderer
+	// sortedJSON sorts the keys of format=json reports unless the request says otherwise
+	sortedJSON bool
+}
+
+// NewInventoryHandler creates a new inventory handler
+func NewInventoryHandler(inventoryUseCase *usecase.InventoryUseCase) *InventoryHandler {
+	return &InventoryHandler{
+		inventoryUseCase: inventoryUseCase,
+	}
+}
+
+// SetRenderer sets the renderer of format=pdf downloads
+func (h *InventoryHandler) SetRenderer(renderer document.Renderer) {
+	h.renderer = renderer
+}
+
+// SetSortedJSON sets whether format=json reports are written with sorted keys by default
+func (h *InventoryHandler) SetSortedJSON(sorted bool) {
+	h.sortedJSON = sorted
+}
+
+// OpenInventorySessionRequest represents the request body for opening an inventory session
+type OpenInventorySessionRequest struct {
+	// example: Spring 2024 audit
+	Name string `json:"name" binding:"required"`
+}
+
+// ScanRequest represents a batch of barcodes scanned at one location
+type ScanRequest struct {
+	// Shelf or room the barcodes were scanned at
+	// example: Fiction A-F
+	Location string `json:"location"`
+	// ISBNs read from the books, hyphens are ignored
+	Barcodes []string `json:"barcodes" binding:"required"`
+}
+
+// GetInventorySessions handles GET /api/inventory/sessions
+// @Summary List inventory sessions
+// @Description Retrieve all inventory sessions, newest first
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Success 200 {array} entities.InventorySession
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /inventory/sessions [get]
+func (h *InventoryHandler) GetInventorySessions(c *gin.Context) {
+	sessions, err := h.inventoryUseCase.ListSessions()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if sessions == nil {
+		sessions = []entities.InventorySession{}
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// OpenInventorySession handles POST /api/inventory/sessions
+// @Summary Open an inventory session
+// @Description Start a shelf audit; scans can be sent over several visits until the session is closed
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Librarian ID"
+// @Param session body OpenInventorySessionRequest true "Session details"
+// @Success 201 {object} entities.InventorySession
+// @Failure 400 {object} handlers.ErrorResponse
+// @Router /inventory/sessions [post]
+func (h *InventoryHandler) OpenInventorySession(c *gin.Context) {
+	librarianID := callerID(c)
+	if librarianID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	var req OpenInventorySessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session := &entities.InventorySession{Name: req.Name}
+	if err := h.inventoryUseCase.OpenSession(librarianID, session); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
+
+// GetInventorySession handles GET /api/inventory/sessions/:id
+// @Summary Get an inventory session
+// @Description Retrieve an inventory session and its status
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} entities.InventorySession
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /inventory/sessions/{id} [get]
+func (h *InventoryHandler) GetInventorySession(c *gin.Context) {
+	session, err := h.inventoryUseCase.GetSession(c.Param("id"))
+	if err != nil {
+		if err.Error() == "inventory session not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// RecordScans handles POST /api/inventory/sessions/:id/scans
+// @Summary Record scans
+// @Description Add a batch of barcodes scanned at a location to an open session
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Param scans body ScanRequest true "Scanned barcodes"
+// @Success 200 {object} usecase.ScanBatchResult
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /inventory/sessions/{id}/scans [post]
+func (h *InventoryHandler) RecordScans(c *gin.Context) {
+	var req ScanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.inventoryUseCase.RecordScans(c.Param("id"), req.Location, req.Barcodes)
+	if err != nil {
+		if err.Error() == "inventory session not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetInventoryReport handles GET /api/inventory/sessions/:id/report
+// @Summary Get a reconciliation report
+// @Description Retrieve the stored report of a closed session, or a preview of an open one. format=pdf returns a printable report.
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Produce application/pdf
+// @Param id path string true "Session ID"
+// @Param format query string false "json (default) or pdf"
+// @Param sorted query bool false "Sort the keys of the JSON report"
+// @Param tz query string false "Timezone (IANA name or UTC offset) to render timestamps in, UTC by default"
+// @Success 200 {object} entities.InventoryReport
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /inventory/sessions/{id}/report [get]
+func (h *InventoryHandler) GetInventoryReport(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "pdf" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or pdf"})
+		return
+	}
+	sorted, err := sortedKeys(c, h.sortedJSON)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sorted"})
+		return
+	}
+	location, err := requestLocation(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.inventoryUseCase.GetReport(c.Param("id"))
+	if err != nil {
+		if err.Error() == "inventory session not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if format == "pdf" {
+		writeDocument(c, h.renderer, inventoryDocument(report, exportLocation(location)), "inventory-report")
+		return
+	}
+	localized := *report
+	if location != nil {
+		localized.GeneratedAt = report.GeneratedAt.In(location)
+	}
+	localized.GeneratedAtISOWeek = isoWeek(report.GeneratedAt.In(exportLocation(location)))
+	report = &localized
+	if sorted {
+		writeSortedJSON(c, http.StatusOK, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// CloseInventorySession handles POST /api/inventory/sessions/:id/close
+// @Summary Close an inventory session
+// @Description Stop accepting scans and store the reconciliation report of missing, misplaced and unexpected books
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} entities.InventoryReport
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /inventory/sessions/{id}/close [post]
+func (h *InventoryHandler) CloseInventorySession(c *gin.Context) {
+	report, err := h.inventoryUseCase.CloseSession(c.Param("id"))
+	if err != nil {
+		if err.Error() == "inventory session not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// inventoryDocument lays a reconciliation report out for printing, with its date in location
+func inventoryDocument(report *entities.InventoryReport, location *time.Location) document.Document {
+	status := "Final report"
+	if !report.Final {
+		status = "Preview, the session is still open"
+	}
+
+	items := func(heading string, columns []string, list []entities.InventoryItem, row func(entities.InventoryItem) []string) document.Section {
+		section := document.Section{Heading: fmt.Sprintf("%s (%d)", heading, len(list)), Columns: columns}
+		for _, item := range list {
+			section.Rows = append(section.Rows, row(item))
+		}
+		if len(list) == 0 {
+			section.Lines = []string{"None"}
+		}
+		return section
+	}
+
+	return document.Document{
+		Title:    "Inventory report",
+		Subtitle: fmt.Sprintf("%s, generated %s (%s)", status, report.GeneratedAt.In(location).Format("2006-01-02 15:04 MST"), isoWeek(report.GeneratedAt.In(location))),
+		Sections: []document.Section{
+			{Lines: []string{fmt.Sprintf("Scanned: %d  Found: %d", report.Scanned, report.Found)}},
+			items("Missing", []string{"Title", "Barcode", "Expected at"}, report.Missing, func(item entities.InventoryItem) []string {
+				return []string{item.Title, item.Barcode, item.ExpectedLocation}
+			}),
+			items("Misplaced", []string{"Title", "Barcode", "Expected at", "Found at"}, report.Misplaced, func(item entities.InventoryItem) []string {
+				return []string{item.Title, item.Barcode, item.ExpectedLocation, item.FoundLocation}
+			}),
+			items("Unexpected", []string{"Barcode", "Found at"}, report.Unexpected, func(item entities.InventoryItem) []string {
+				return []string{item.Barcode, item.FoundLocation}
+			}),
+		},
+	}
+}
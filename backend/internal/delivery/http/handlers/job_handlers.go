@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JobLister reports the state of the recurring background jobs
+type JobLister interface {
+	Jobs() []entities.ScheduledJob
+}
+
+// JobHandler handles HTTP requests about recurring background jobs
+type JobHandler struct {
+	jobs JobLister
+}
+
+// NewJobHandler creates a new job handler
+func NewJobHandler(jobs JobLister) *JobHandler {
+	return &JobHandler{
+		jobs: jobs,
+	}
+}
+
+// GetJobs handles GET /api/admin/jobs
+// @Summary List scheduled jobs
+// @Description Retrieve the recurring background jobs with their schedule, next run and last run status
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {array} entities.ScheduledJob
+// @Router /admin/jobs [get]
+func (h *JobHandler) GetJobs(c *gin.Context) {
+	c.JSON(http.StatusOK, h.jobs.Jobs())
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LoanHandler handles HTTP requests of staff about loans
+type LoanHandler struct {
+	loanUseCase *usecase.LoanUseCase
+}
+
+// NewLoanHandler creates a new loan handler
+func NewLoanHandler(loanUseCase *usecase.LoanUseCase) *LoanHandler {
+	return &LoanHandler{
+		loanUseCase: loanUseCase,
+	}
+}
+
+// CheckoutRequest represents the request body for lending a book to a member
+type CheckoutRequest struct {
+	MemberID string `json:"member_id" binding:"required"`
+	BookID   string `json:"book_id" binding:"required"`
+	// OverrideReason lends the book past the loan limit and holds of other members
+	OverrideReason string `json:"override_reason,omitempty"`
+}
+
+// RenewRequest represents the optional request body for renewing a loan
+type RenewRequest struct {
+	// OverrideReason renews the loan past the renewal limit and holds of other members
+	OverrideReason string `json:"override_reason,omitempty"`
+}
+
+// CheckoutLoan handles POST /api/loans
+// @Summary Lend a book
+// @Description Lend a book to a member for the loan period of the tenant. Checkout is denied with 409 once the member reaches the loan limit or while other members hold the book, unless staff give an override_reason; overrides are recorded in the audit trail.
+// @Tags loans
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string false "Tenant whose circulation policies apply"
+// @Param loan body CheckoutRequest true "Member, book and optional override reason"
+// @Success 201 {object} entities.Loan
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 403 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /loans [post]
+func (h *LoanHandler) CheckoutLoan(c *gin.Context) {
+	var req CheckoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	override := usecase.Override{Staff: caller(c), Reason: req.OverrideReason}
+	loan, err := h.loanUseCase.Checkout(c.GetHeader(middleware.TenantHeader), req.MemberID, req.BookID, override)
+	if err != nil {
+		h.loanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, loan)
+}
+
+// RenewLoan handles POST /api/loans/:id/renew
+// @Summary Renew a loan
+// @Description Push the due date of a loan back by the loan period of the tenant and notify the member. Renewal is denied with 409 past the renewal limit, while other members hold the book, or once the loan is returned; staff may renew past the first two with an override_reason, which is recorded in the audit trail.
+// @Tags loans
+// @Accept json
+// @Produce json
+// @Param id path string true "Loan ID"
+// @Param renewal body RenewRequest false "Optional override reason"
+// @Success 200 {object} entities.Loan
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 403 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /loans/{id}/renew [post]
+func (h *LoanHandler) RenewLoan(c *gin.Context) {
+	var req RenewRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	loan, err := h.loanUseCase.Renew(c.Param("id"), usecase.Override{Staff: caller(c), Reason: req.OverrideReason})
+	if err != nil {
+		h.loanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, loan)
+}
+
+// ReturnLoan handles POST /api/loans/:id/return
+// @Summary Return a loan
+// @Description Record that a lent book came back. The copy is kept for the next member waiting for the book, whose hold becomes ready, and the member is notified. A loan already returned is refused with 409.
+// @Tags loans
+// @Accept json
+// @Produce json
+// @Param id path string true "Loan ID"
+// @Success 200 {object} entities.Loan
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /loans/{id}/return [post]
+func (h *LoanHandler) ReturnLoan(c *gin.Context) {
+	loan, err := h.loanUseCase.Return(c.Param("id"))
+	if err != nil {
+		h.loanError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, loan)
+}
+
+// GetOverrideReport handles GET /api/admin/reports/overrides
+// @Summary Weekly policy override report
+// @Description Summarize the circulation policy overrides of an ISO week by block, by shift and by member of staff, with the reason of each
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param week query string false "ISO week, e.g. 2024-W03; the current week by default"
+// @Success 200 {object} entities.OverrideReport
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/reports/overrides [get]
+func (h *LoanHandler) GetOverrideReport(c *gin.Context) {
+	report, err := h.loanUseCase.OverrideReport(c.Query("week"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrInvalidWeek) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// loanError answers the error of a checkout, renewal or return
+func (h *LoanHandler) loanError(c *gin.Context, err error) {
+	if e, ok := domainerr.Lookup(err); ok {
+		c.Error(err)
+		c.JSON(e.Status, gin.H{"error": e.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Request parameters selecting the timezone timestamps are rendered in
+const (
+	timezoneQueryParam = "tz"
+	timezoneHeader     = "X-Timezone"
+)
+
+// utcOffsetPattern matches fixed offsets such as +07:00, -0530 or +9. A leading space stands for
+// the plus sign, which query strings decode as a space when clients leave ?tz=+07:00 unescaped.
+var utcOffsetPattern = regexp.MustCompile(`^([+ -])(\d{1,2}):?(\d{2})?$`)
+
+// DateMetadata carries server-computed calendar information for localized responses
+// swagger:model DateMetadata
+type DateMetadata struct {
+	// Timezone the timestamps are expressed in
+	// example: Asia/Tokyo
+	Timezone string `json:"timezone"`
+	// ISO 8601 week of the creation date
+	// example: 2024-W03
+	CreatedAtISOWeek string `json:"created_at_iso_week"`
+	// ISO 8601 week of the last update
+	// example: 2024-W03
+	UpdatedAtISOWeek string `json:"updated_at_iso_week"`
+}
+
+// requestLocation returns the timezone requested via the tz query parameter or X-Timezone header.
+// It returns nil when the client did not ask for localization.
+func requestLocation(c *gin.Context) (*time.Location, error) {
+	name := c.Query(timezoneQueryParam)
+	if name == "" {
+		name = c.GetHeader(timezoneHeader)
+	}
+	if name == "" {
+		return nil, nil
+	}
+	return parseLocation(name)
+}
+
+// exportLocation returns the location exports render timestamps in, UTC unless the client asked
+// for another
+func exportLocation(location *time.Location) *time.Location {
+	if location == nil {
+		return time.UTC
+	}
+	return location
+}
+
+// parseLocation accepts IANA timezone names (Asia/Tokyo, UTC) and fixed UTC offsets (+07:00)
+func parseLocation(name string) (*time.Location, error) {
+	if match := utcOffsetPattern.FindStringSubmatch(name); match != nil {
+		hours, _ := strconv.Atoi(match[2])
+		minutes := 0
+		if match[3] != "" {
+			minutes, _ = strconv.Atoi(match[3])
+		}
+		if hours > 14 || minutes > 59 {
+			return nil, errors.New("invalid timezone offset")
+		}
+		sign := match[1]
+		if sign == " " {
+			sign = "+"
+		}
+		offset := hours*3600 + minutes*60
+		if sign == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(fmt.Sprintf("UTC%s%02d:%02d", sign, hours, minutes), offset), nil
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.New("invalid timezone")
+	}
+	return location, nil
+}
+
+// isoWeek formats the ISO 8601 week of a timestamp, e.g. 2024-W03
+func isoWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// newDateMetadata computes the calendar information of timestamps already expressed in the location
+func newDateMetadata(createdAt, updatedAt time.Time, location *time.Location) *DateMetadata {
+	return &DateMetadata{
+		Timezone:         location.String(),
+		CreatedAtISOWeek: isoWeek(createdAt),
+		UpdatedAtISOWeek: isoWeek(updatedAt),
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		expectedOffset int
+		expectedError  string
+	}{
+		{name: "IANA name", input: "Asia/Tokyo", expectedOffset: 9 * 3600},
+		{name: "UTC", input: "UTC", expectedOffset: 0},
+		{name: "positive offset with colon", input: "+07:00", expectedOffset: 7 * 3600},
+		{name: "negative offset without colon", input: "-0530", expectedOffset: -(5*3600 + 30*60)},
+		{name: "hours only", input: "+9", expectedOffset: 9 * 3600},
+		{name: "plus decoded as a space", input: " 07:00", expectedOffset: 7 * 3600},
+		{name: "out of range offset", input: "+15:00", expectedError: "invalid timezone offset"},
+		{name: "unknown name", input: "Mars/Olympus", expectedError: "invalid timezone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, err := parseLocation(tt.input)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			// January avoids daylight saving differences
+			_, offset := time.Date(2024, 1, 15, 0, 0, 0, 0, location).Zone()
+			assert.Equal(t, tt.expectedOffset, offset)
+		})
+	}
+}
+
+func TestRequestLocation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/api/books?tz=Europe/Paris", nil)
+	c.Request.Header.Set(timezoneHeader, "Asia/Tokyo")
+	location, err := requestLocation(c)
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Paris", location.String(), "query parameter takes precedence over header")
+
+	c, _ = gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/api/books?tz=+07:00", nil)
+	location, err = requestLocation(c)
+	assert.NoError(t, err)
+	assert.Equal(t, "UTC+07:00", location.String(), "an unescaped plus sign decodes as a space")
+
+	c, _ = gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/api/books", nil)
+	location, err = requestLocation(c)
+	assert.NoError(t, err)
+	assert.Nil(t, location)
+}
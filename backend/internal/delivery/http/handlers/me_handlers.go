@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MeHandler handles HTTP requests about the calling API client
+type MeHandler struct {
+	usageUseCase *usecase.UsageUseCase
+}
+
+// NewMeHandler creates a new me handler
+func NewMeHandler(usageUseCase *usecase.UsageUseCase) *MeHandler {
+	return &MeHandler{
+		usageUseCase: usageUseCase,
+	}
+}
+
+// GetUsage handles GET /api/me/usage
+// @Summary Get API usage
+// @Description Report the caller's request consumption against its monthly quota. Requests signed in with an access token are metered against the token, those signed in with a session against the tenant of their user, others against their client IP.
+// @Tags me
+// @Accept json
+// @Produce json
+// @Success 200 {object} entities.Usage
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /me/usage [get]
+func (h *MeHandler) GetUsage(c *gin.Context) {
+	usage, err := h.usageUseCase.GetUsage(middleware.QuotaSubject(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
@@ -0,0 +1,334 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MemberHandler handles the self-service requests of a member about their own account, loans
+// and fines, and the deactivation, reactivation and erasure of accounts by admins
+type MemberHandler struct {
+	loanUseCase   *usecase.LoanUseCase
+	memberUseCase *usecase.MemberUseCase
+}
+
+// NewMemberHandler creates a new member handler
+func NewMemberHandler(loanUseCase *usecase.LoanUseCase, memberUseCase *usecase.MemberUseCase) *MemberHandler {
+	return &MemberHandler{
+		loanUseCase:   loanUseCase,
+		memberUseCase: memberUseCase,
+	}
+}
+
+// ContactRequest represents the contact details a member changes; omitted fields are kept
+type ContactRequest struct {
+	Name  *string `json:"name,omitempty" example:"Ada Lovelace"`
+	Email *string `json:"email,omitempty" example:"ada@example.com"`
+	// Phone is cleared when empty
+	Phone *string `json:"phone,omitempty" example:"+44 20 7946 0958"`
+}
+
+// GetProfile handles GET /api/me/profile
+// @Summary Get my profile
+// @Description Retrieve the caller's account and contact details
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Member ID"
+// @Success 200 {object} entities.User
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /me/profile [get]
+func (h *MemberHandler) GetProfile(c *gin.Context) {
+	memberID := callerID(c)
+	if memberID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	user, err := h.memberUseCase.GetProfile(memberID)
+	if err != nil {
+		writeMemberError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateContact handles PATCH /api/me/contact
+// @Summary Update my contact details
+// @Description Change the caller's name, email or phone; omitted fields are kept
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Member ID"
+// @Param contact body ContactRequest true "Contact details"
+// @Success 200 {object} entities.User
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /me/contact [patch]
+func (h *MemberHandler) UpdateContact(c *gin.Context) {
+	memberID := callerID(c)
+	if memberID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	var req ContactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.memberUseCase.UpdateContact(memberID, usecase.ContactUpdate{
+		Name:  req.Name,
+		Email: req.Email,
+		Phone: req.Phone,
+	})
+	if err != nil {
+		writeMemberError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// GetLoans handles GET /api/me/loans
+// @Summary List my loans
+// @Description Retrieve the books the caller has out on loan, soonest due first. With from or to, retrieve every loan the caller borrowed between those UTC dates instead, returned or not, most recently borrowed first, including loans moved to the history.
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Member ID"
+// @Param from query string false "First day of the history, e.g. 2024-01-01; the first loan by default"
+// @Param to query string false "Last day of the history, e.g. 2024-12-31; today by default"
+// @Success 200 {array} entities.Loan
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /me/loans [get]
+func (h *MemberHandler) GetLoans(c *gin.Context) {
+	memberID := callerID(c)
+	if memberID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	if from, to := c.Query("from"), c.Query("to"); from != "" || to != "" {
+		loans, err := h.loanUseCase.MemberLoanHistory(memberID, from, to)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, usecase.ErrInvalidDateRange) {
+				status = http.StatusBadRequest
+			}
+			writeMemberError(c, err, status)
+			return
+		}
+		c.JSON(http.StatusOK, loans)
+		return
+	}
+
+	loans, err := h.loanUseCase.MemberLoans(memberID)
+	if err != nil {
+		writeMemberError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, loans)
+}
+
+// RenewLoan handles POST /api/me/loans/:id/renew
+// @Summary Renew a loan
+// @Description Push the due date of one of the caller's loans back by the loan period. Loans cannot be renewed past the renewal limit of the tenant, nor while other members hold the book.
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Member ID"
+// @Param id path string true "Loan ID"
+// @Success 200 {object} entities.Loan
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /me/loans/{id}/renew [post]
+func (h *MemberHandler) RenewLoan(c *gin.Context) {
+	memberID := callerID(c)
+	if memberID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	loan, err := h.loanUseCase.RenewMemberLoan(memberID, c.Param("id"))
+	if err != nil {
+		writeMemberError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, loan)
+}
+
+// GetHolds handles GET /api/me/holds
+// @Summary List my holds
+// @Description Retrieve the caller's waiting and ready holds with their place in the queue and when a copy should come back for them, estimated from the due dates of the current loans of the book
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Member ID"
+// @Success 200 {array} entities.HoldPosition
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /me/holds [get]
+func (h *MemberHandler) GetHolds(c *gin.Context) {
+	memberID := callerID(c)
+	if memberID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	holds, err := h.loanUseCase.MemberHolds(memberID)
+	if err != nil {
+		writeMemberError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, holds)
+}
+
+// GetFines handles GET /api/me/fines
+// @Summary List my fines
+// @Description Retrieve the fines charged to the caller, newest first, with the unpaid total
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Member ID"
+// @Success 200 {object} entities.MemberFines
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /me/fines [get]
+func (h *MemberHandler) GetFines(c *gin.Context) {
+	memberID := callerID(c)
+	if memberID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	fines, err := h.loanUseCase.MemberFines(memberID)
+	if err != nil {
+		writeMemberError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, fines)
+}
+
+// DeactivateAccount handles POST /api/me/deactivate
+// @Summary Deactivate my account
+// @Description Deactivate the caller's account: they can no longer sign in or borrow, their loans and fines are kept, and the account is anonymized after MEMBER_RETENTION_DAYS unless an admin reactivates it first
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Member ID"
+// @Success 200 {object} entities.User
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /me/deactivate [post]
+func (h *MemberHandler) DeactivateAccount(c *gin.Context) {
+	memberID := callerID(c)
+	if memberID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	user, err := h.memberUseCase.Deactivate(caller(c), memberID)
+	if err != nil {
+		writeMemberError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// DeactivateMember handles POST /api/admin/members/:id/deactivate
+// @Summary Deactivate a member
+// @Description Deactivate the account of a member: they can no longer sign in or borrow, their loans and fines are kept, and the account is anonymized after MEMBER_RETENTION_DAYS unless it is reactivated first. Deactivating it again changes nothing.
+// @Tags members
+// @Accept json
+// @Produce json
+// @Param id path string true "Member ID"
+// @Success 200 {object} entities.User
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/members/{id}/deactivate [post]
+func (h *MemberHandler) DeactivateMember(c *gin.Context) {
+	user, err := h.memberUseCase.Deactivate(caller(c), c.Param("id"))
+	if err != nil {
+		writeMemberError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// ReactivateMember handles POST /api/admin/members/:id/reactivate
+// @Summary Reactivate a member
+// @Description Reactivate a deactivated account, so its member can sign in and borrow again. Anonymized accounts cannot be reactivated.
+// @Tags members
+// @Accept json
+// @Produce json
+// @Param id path string true "Member ID"
+// @Success 200 {object} entities.User
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/members/{id}/reactivate [post]
+func (h *MemberHandler) ReactivateMember(c *gin.Context) {
+	user, err := h.memberUseCase.Reactivate(caller(c), c.Param("id"))
+	if err != nil {
+		writeMemberError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// EraseMember handles POST /api/admin/members/:id/erase
+// @Summary Erase a member
+// @Description Anonymize the account of a member right away on their request to be forgotten, rather than after the retention window; their loans and fines are kept under the anonymized account. Members with books on loan are refused until they return them.
+// @Tags members
+// @Accept json
+// @Produce json
+// @Param id path string true "Member ID"
+// @Success 200 {object} entities.User
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/members/{id}/erase [post]
+func (h *MemberHandler) EraseMember(c *gin.Context) {
+	user, err := h.memberUseCase.Erase(caller(c), c.Param("id"))
+	if err != nil {
+		writeMemberError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// writeMemberError writes a failed member request; defined errors keep their status and the
+// others get the fallback status
+func writeMemberError(c *gin.Context, err error, fallback int) {
+	if e, ok := domainerr.Lookup(err); ok {
+		c.Error(err)
+		c.JSON(e.Status, gin.H{"error": e.Error()})
+		return
+	}
+	c.JSON(fallback, gin.H{"error": err.Error()})
+}
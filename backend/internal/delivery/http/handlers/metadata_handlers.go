@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetadataHandler handles HTTP requests for the metadata fields of the calling tenant
+type MetadataHandler struct {
+	metadataUseCase *usecase.MetadataUseCase
+}
+
+// NewMetadataHandler creates a new metadata handler
+func NewMetadataHandler(metadataUseCase *usecase.MetadataUseCase) *MetadataHandler {
+	return &MetadataHandler{
+		metadataUseCase: metadataUseCase,
+	}
+}
+
+// SaveMetadataFieldRequest represents the request body for defining a metadata field
+type SaveMetadataFieldRequest struct {
+	// string, number or boolean
+	Type     string `json:"type" binding:"required"`
+	Label    string `json:"label"`
+	Required bool   `json:"required"`
+}
+
+// GetMetadataFields handles GET /api/metadata-fields
+// @Summary Get metadata fields
+// @Description Retrieve the custom metadata fields the tenant defined for its books, ordered by key
+// @Tags metadata
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Success 200 {array} entities.MetadataField
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /metadata-fields [get]
+func (h *MetadataHandler) GetMetadataFields(c *gin.Context) {
+	tenantID := c.GetHeader(middleware.TenantHeader)
+	if tenantID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "tenant ID is required"})
+		return
+	}
+
+	fields, err := h.metadataUseCase.ListFields(tenantID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, fields)
+}
+
+// SaveMetadataField handles PUT /api/metadata-fields/:key
+// @Summary Define a metadata field
+// @Description Create or replace a custom metadata field of the tenant. Once a tenant has fields, books saved for it may only carry those keys.
+// @Tags metadata
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param key path string true "Metadata key, e.g. shelf_code"
+// @Param field body SaveMetadataFieldRequest true "Field definition"
+// @Success 200 {object} entities.MetadataField
+// @Failure 400 {object} handlers.ErrorResponse
+// @Router /metadata-fields/{key} [put]
+func (h *MetadataHandler) SaveMetadataField(c *gin.Context) {
+	var req SaveMetadataFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	field := &entities.MetadataField{
+		TenantID: c.GetHeader(middleware.TenantHeader),
+		Key:      c.Param("key"),
+		Label:    req.Label,
+		Type:     req.Type,
+		Required: req.Required,
+	}
+
+	if err := h.metadataUseCase.SaveField(field); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, field)
+}
+
+// DeleteMetadataField handles DELETE /api/metadata-fields/:key
+// @Summary Delete a metadata field
+// @Description Remove a custom metadata field of the tenant. Books keep the values they have.
+// @Tags metadata
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string true "Tenant ID"
+// @Param key path string true "Metadata key"
+// @Success 200 {object} handlers.MessageResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /metadata-fields/{key} [delete]
+func (h *MetadataHandler) DeleteMetadataField(c *gin.Context) {
+	if err := h.metadataUseCase.DeleteField(c.GetHeader(middleware.TenantHeader), c.Param("key")); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, domainerr.ErrMetadataFieldNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "metadata field deleted successfully"})
+}
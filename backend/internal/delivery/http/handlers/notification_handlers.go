@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandler handles HTTP requests for in-app notifications
+type NotificationHandler struct {
+	notificationUseCase *usecase.NotificationUseCase
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(notificationUseCase *usecase.NotificationUseCase) *NotificationHandler {
+	return &NotificationHandler{
+		notificationUseCase: notificationUseCase,
+	}
+}
+
+// UnreadCountResponse represents the unread notification count payload
+// swagger:model UnreadCountResponse
+type UnreadCountResponse struct {
+	// Number of unread notifications
+	// example: 3
+	Unread int64 `json:"unread"`
+}
+
+// GetNotifications handles GET /api/notifications
+// @Summary List notifications
+// @Description Retrieve the caller's notifications, newest first
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller ID"
+// @Param unread query bool false "Only return unread notifications"
+// @Success 200 {array} entities.Notification
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /notifications [get]
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	recipientID := callerID(c)
+	if recipientID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	notifications, err := h.notificationUseCase.ListNotifications(recipientID, c.Query("unread") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+// GetUnreadCount handles GET /api/notifications/unread-count
+// @Summary Count unread notifications
+// @Description Retrieve the number of unread notifications of the caller
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller ID"
+// @Success 200 {object} handlers.UnreadCountResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /notifications/unread-count [get]
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	recipientID := callerID(c)
+	if recipientID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	count, err := h.notificationUseCase.UnreadCount(recipientID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, UnreadCountResponse{Unread: count})
+}
+
+// MarkAsRead handles POST /api/notifications/:id/read
+// @Summary Mark a notification as read
+// @Description Mark one of the caller's notifications as read
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller ID"
+// @Param id path string true "Notification ID"
+// @Success 200 {object} handlers.MessageResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /notifications/{id}/read [post]
+func (h *NotificationHandler) MarkAsRead(c *gin.Context) {
+	recipientID := callerID(c)
+	if recipientID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	if err := h.notificationUseCase.MarkAsRead(recipientID, c.Param("id")); err != nil {
+		if err.Error() == "notification not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "notification marked as read"})
+}
+
+// MarkAllAsRead handles POST /api/notifications/read-all
+// @Summary Mark all notifications as read
+// @Description Mark every notification of the caller as read
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Caller ID"
+// @Success 200 {object} handlers.MessageResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /notifications/read-all [post]
+func (h *NotificationHandler) MarkAllAsRead(c *gin.Context) {
+	recipientID := callerID(c)
+	if recipientID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	if err := h.notificationUseCase.MarkAllAsRead(recipientID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "all notifications marked as read"})
+}
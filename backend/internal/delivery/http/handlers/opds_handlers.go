@@ -0,0 +1,530 @@
+package handlers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"library-management-system/internal/delivery/http/urlbuilder"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OPDS namespaces, media types and link relations
+const (
+	opdsNamespace       = "http://opds-spec.org/2010/catalog"
+	openSearchNamespace = "http://a9.com/-/spec/opensearch/1.1/"
+	opdsNavigationType  = "application/atom+xml;profile=opds-catalog;kind=navigation"
+	opdsAcquisitionType = "application/atom+xml;profile=opds-catalog;kind=acquisition"
+	opdsJSONType        = "application/opds+json"
+	openSearchType      = "application/opensearchdescription+xml"
+	// opdsBorrow links books to their page, where members borrow or hold the physical copies the
+	// library lends; there are no files to download
+	opdsBorrow    = "http://opds-spec.org/acquisition/borrow"
+	opdsImage     = "http://opds-spec.org/image"
+	opdsThumbnail = "http://opds-spec.org/image/thumbnail"
+	opdsSortNew   = "http://opds-spec.org/sort/new"
+)
+
+// OPDSFeed is a catalog page in the OPDS 2.0 format (https://drafts.opds.io/opds-2.0)
+type OPDSFeed struct {
+	Metadata     OPDSMetadata      `json:"metadata"`
+	Links        []OPDSLink        `json:"links"`
+	Navigation   []OPDSLink        `json:"navigation,omitempty"`
+	Publications []OPDSPublication `json:"publications,omitempty"`
+}
+
+// OPDSMetadata describes a catalog page, and which page of how many items it is
+type OPDSMetadata struct {
+	Title         string    `json:"title" example:"Library catalog"`
+	Modified      time.Time `json:"modified"`
+	NumberOfItems *int      `json:"numberOfItems,omitempty" example:"120"`
+	ItemsPerPage  int       `json:"itemsPerPage,omitempty" example:"50"`
+	CurrentPage   int       `json:"currentPage,omitempty" example:"1"`
+}
+
+// OPDSLink is a link of an OPDS 2.0 catalog
+type OPDSLink struct {
+	Rel   string `json:"rel,omitempty" example:"self"`
+	Href  string `json:"href" example:"https://library.example.com/opds"`
+	Type  string `json:"type,omitempty" example:"application/opds+json"`
+	Title string `json:"title,omitempty"`
+	// Templated marks hrefs that are URI templates, such as the search link
+	Templated bool `json:"templated,omitempty"`
+}
+
+// OPDSPublication is a book of an OPDS 2.0 catalog
+type OPDSPublication struct {
+	Metadata OPDSPublicationMetadata `json:"metadata"`
+	Links    []OPDSLink              `json:"links"`
+	Images   []OPDSLink              `json:"images,omitempty"`
+}
+
+// OPDSPublicationMetadata describes a book of an OPDS 2.0 catalog
+type OPDSPublicationMetadata struct {
+	Type        string    `json:"@type" example:"http://schema.org/Book"`
+	Identifier  string    `json:"identifier" example:"urn:isbn:9780062225719"`
+	Title       string    `json:"title" example:"Mort"`
+	Author      string    `json:"author" example:"Terry Pratchett"`
+	Published   string    `json:"published,omitempty" example:"1987"`
+	Description string    `json:"description,omitempty"`
+	Modified    time.Time `json:"modified"`
+}
+
+// openSearchDescription tells OPDS 1.2 readers how to search the catalog
+// (https://github.com/dewitt/opensearch)
+type openSearchDescription struct {
+	XMLName     xml.Name        `xml:"OpenSearchDescription"`
+	XMLNS       string          `xml:"xmlns,attr"`
+	ShortName   string          `xml:"ShortName"`
+	Description string          `xml:"Description"`
+	URL         openSearchURL   `xml:"Url"`
+	Query       openSearchQuery `xml:"Query"`
+}
+
+type openSearchURL struct {
+	Type     string `xml:"type,attr"`
+	Template string `xml:"template,attr"`
+}
+
+type openSearchQuery struct {
+	Role        string `xml:"role,attr"`
+	SearchTerms string `xml:"searchTerms,attr"`
+}
+
+// opdsCatalog is a catalog page, written as OPDS 1.2 or 2.0 depending on the request
+type opdsCatalog struct {
+	// path is the path of the page under the prefix, with its query
+	path    string
+	title   string
+	updated time.Time
+	// sections are the subsections a navigation page leads to
+	sections []opdsSection
+	// feed holds the books of an acquisition page
+	feed *usecase.Feed
+	// page and pageSize place the page among total items, zero for unpaginated pages
+	page     int
+	pageSize int
+	total    int
+}
+
+// opdsSection is an entry of a navigation page
+type opdsSection struct {
+	title   string
+	summary string
+	path    string
+	rel     string
+	// acquisition marks sections listing books rather than further sections
+	acquisition bool
+}
+
+// OPDSHandler serves the catalog to e-reader apps as OPDS 1.2 (Atom) and, to those asking for
+// application/opds+json, OPDS 2.0
+type OPDSHandler struct {
+	feedUseCase *usecase.FeedUseCase
+	prefix      string
+	title       string
+	pageSize    int
+	// site links to the pages of the library site and the catalog, api to covers
+	site *urlbuilder.Builder
+	api  *urlbuilder.Builder
+}
+
+// NewOPDSHandler creates a new OPDS handler for a catalog named title served under prefix, with
+// pages of pageSize books
+func NewOPDSHandler(feedUseCase *usecase.FeedUseCase, prefix, title string, pageSize int) *OPDSHandler {
+	return &OPDSHandler{
+		feedUseCase: feedUseCase,
+		prefix:      prefix,
+		title:       title,
+		pageSize:    pageSize,
+	}
+}
+
+// SetLinks makes the links of the catalog absolute URLs, to pages built by site and to covers
+// built by api
+func (h *OPDSHandler) SetLinks(site, api *urlbuilder.Builder) {
+	h.site = site
+	h.api = api
+}
+
+// GetRoot handles GET /opds, the start of the catalog, leading to the new arrivals, every book and
+// the authors
+func (h *OPDSHandler) GetRoot(c *gin.Context) {
+	updated, err := h.feedUseCase.Updated()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.write(c, &opdsCatalog{
+		path:    "",
+		title:   h.title,
+		updated: updated,
+		sections: []opdsSection{
+			{title: "New arrivals", summary: "The books most recently added to the library", path: "/new", rel: opdsSortNew, acquisition: true},
+			{title: "All books", summary: "Every book of the library, by title", path: "/books", rel: "subsection", acquisition: true},
+			{title: "Authors", summary: "The books of the library, by author", path: "/authors", rel: "subsection"},
+		},
+	})
+}
+
+// GetNewArrivals handles GET /opds/new
+func (h *OPDSHandler) GetNewArrivals(c *gin.Context) {
+	limit, err := h.feedUseCase.Limit(0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	feed, err := h.feedUseCase.NewArrivals(limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.write(c, &opdsCatalog{path: "/new", title: "New arrivals", updated: feed.Updated, feed: feed})
+}
+
+// GetBooks handles GET /opds/books, a page of every book by title
+func (h *OPDSHandler) GetBooks(c *gin.Context) {
+	page, ok := opdsPage(c)
+	if !ok {
+		return
+	}
+	feed, err := h.feedUseCase.Catalog(page, h.pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.writePage(c, "/books", "All books", feed, page)
+}
+
+// GetAuthors handles GET /opds/authors, a page of the authors by name
+func (h *OPDSHandler) GetAuthors(c *gin.Context) {
+	page, ok := opdsPage(c)
+	if !ok {
+		return
+	}
+	authors, total, err := h.feedUseCase.Authors(page, h.pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	updated, err := h.feedUseCase.Updated()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	catalog := &opdsCatalog{
+		path:     withPage("/authors", page),
+		title:    "Authors",
+		updated:  updated,
+		sections: make([]opdsSection, len(authors)),
+		page:     page,
+		pageSize: h.pageSize,
+		total:    total,
+	}
+	for i, author := range authors {
+		catalog.sections[i] = opdsSection{
+			title:       author.Name,
+			summary:     strconv.Itoa(author.Books) + " book(s)",
+			path:        authorPath(author.Name),
+			rel:         "subsection",
+			acquisition: true,
+		}
+	}
+	h.write(c, catalog)
+}
+
+// GetAuthorBooks handles GET /opds/author?name=, a page of the books of an author by title
+func (h *OPDSHandler) GetAuthorBooks(c *gin.Context) {
+	page, ok := opdsPage(c)
+	if !ok {
+		return
+	}
+	name := c.Query("name")
+	if strings.TrimSpace(name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "author name is required"})
+		return
+	}
+	feed, err := h.feedUseCase.ByAuthor(name, page, h.pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.writePage(c, authorPath(name), name, feed, page)
+}
+
+// Search handles GET /opds/search, a page of the books whose title or author contains q (OPDS 1.2)
+// or query (OPDS 2.0)
+func (h *OPDSHandler) Search(c *gin.Context) {
+	page, ok := opdsPage(c)
+	if !ok {
+		return
+	}
+	query := c.Query("q")
+	if query == "" {
+		query = c.Query("query")
+	}
+	if strings.TrimSpace(query) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "search query is required"})
+		return
+	}
+	feed, err := h.feedUseCase.Search(query, page, h.pageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.writePage(c, opdsPath("/search", url.Values{"q": {query}}), "Search results for "+query, feed, page)
+}
+
+// GetOpenSearch handles GET /opds/opensearch.xml, describing the search of OPDS 1.2 catalogs
+func (h *OPDSHandler) GetOpenSearch(c *gin.Context) {
+	writeXML(c, openSearchType+"; charset=utf-8", openSearchDescription{
+		XMLNS:       openSearchNamespace,
+		ShortName:   h.title,
+		Description: "Search the books of " + h.title + " by title or author",
+		URL:         openSearchURL{Type: opdsAcquisitionType, Template: h.url("/search") + "?q={searchTerms}"},
+		Query:       openSearchQuery{Role: "example", SearchTerms: "pratchett"},
+	})
+}
+
+// writePage writes an acquisition page of books; path is the page without its page number
+func (h *OPDSHandler) writePage(c *gin.Context, path, title string, feed *usecase.Feed, page int) {
+	h.write(c, &opdsCatalog{
+		path:     withPage(path, page),
+		title:    title,
+		updated:  feed.Updated,
+		feed:     feed,
+		page:     page,
+		pageSize: h.pageSize,
+		total:    feed.Total,
+	})
+}
+
+// write writes a catalog page as OPDS 2.0 to clients accepting it, and as OPDS 1.2 otherwise
+func (h *OPDSHandler) write(c *gin.Context, catalog *opdsCatalog) {
+	if strings.Contains(c.GetHeader("Accept"), opdsJSONType) {
+		body, err := json.Marshal(h.opds2(catalog))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.Data(http.StatusOK, opdsJSONType, body)
+		return
+	}
+
+	kind := opdsNavigationType
+	if catalog.feed != nil {
+		kind = opdsAcquisitionType
+	}
+	writeXML(c, kind, h.opds1(catalog, kind))
+}
+
+// opds1 renders a catalog page as an OPDS 1.2 Atom feed
+func (h *OPDSHandler) opds1(catalog *opdsCatalog, kind string) atomFeed {
+	self := h.url(catalog.path)
+	feed := atomFeed{
+		XMLNS:      atomNamespace,
+		DC:         dublinCoreNamespace,
+		OPDS:       opdsNamespace,
+		OpenSearch: openSearchNamespace,
+		ID:         self,
+		Title:      catalog.title,
+		Updated:    catalog.updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Rel: "self", Type: kind, Href: self},
+			{Rel: "start", Type: opdsNavigationType, Href: h.url("")},
+			{Rel: "search", Type: openSearchType, Href: h.url("/opensearch.xml")},
+		},
+	}
+	for _, link := range h.pageLinks(catalog) {
+		feed.Links = append(feed.Links, atomLink{Rel: link.Rel, Type: kind, Href: link.Href})
+	}
+	if catalog.pageSize > 0 {
+		feed.atomPaging = &atomPaging{
+			TotalResults: catalog.total,
+			ItemsPerPage: catalog.pageSize,
+			StartIndex:   (catalog.page-1)*catalog.pageSize + 1,
+		}
+	}
+
+	for _, section := range catalog.sections {
+		sectionType := opdsNavigationType
+		if section.acquisition {
+			sectionType = opdsAcquisitionType
+		}
+		href := h.url(section.path)
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:      href,
+			Title:   section.title,
+			Updated: feed.Updated,
+			Content: &atomContent{Type: "text", Value: section.summary},
+			Links:   []atomLink{{Rel: section.rel, Type: sectionType, Href: href}},
+		})
+	}
+	if catalog.feed == nil {
+		return feed
+	}
+	for _, item := range catalog.feed.Items {
+		book := item.Book
+		entry := atomEntry{
+			ID:      bookURN(book),
+			Title:   book.Title,
+			Updated: book.UpdatedAt.UTC().Format(time.RFC3339),
+			Authors: []atomPerson{{Name: book.Author, URI: h.url(authorPath(book.Author))}},
+			Summary: book.Description,
+			Links: []atomLink{
+				{Rel: "alternate", Type: "text/html", Href: h.bookPage(book.ID)},
+				{Rel: opdsBorrow, Type: "text/html", Href: h.bookPage(book.ID)},
+			},
+		}
+		if book.ISBN != "" {
+			entry.Identifier = "urn:isbn:" + book.ISBN
+		}
+		if book.Year > 0 {
+			entry.Issued = strconv.Itoa(book.Year)
+		}
+		if item.Cover != nil {
+			cover := h.api.URL("/books/" + book.ID + "/cover")
+			entry.Links = append(entry.Links,
+				atomLink{Rel: opdsImage, Type: item.Cover.ContentType, Href: cover},
+				atomLink{Rel: opdsThumbnail, Type: item.Cover.ContentType, Href: cover})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	return feed
+}
+
+// opds2 renders a catalog page as an OPDS 2.0 feed
+func (h *OPDSHandler) opds2(catalog *opdsCatalog) OPDSFeed {
+	feed := OPDSFeed{
+		Metadata: OPDSMetadata{Title: catalog.title, Modified: catalog.updated.UTC()},
+		Links: []OPDSLink{
+			{Rel: "self", Type: opdsJSONType, Href: h.url(catalog.path)},
+			{Rel: "start", Type: opdsJSONType, Href: h.url("")},
+			{Rel: "search", Type: opdsJSONType, Href: h.url("/search") + "{?query}", Templated: true},
+		},
+	}
+	for _, link := range h.pageLinks(catalog) {
+		feed.Links = append(feed.Links, OPDSLink{Rel: link.Rel, Type: opdsJSONType, Href: link.Href})
+	}
+	if catalog.pageSize > 0 {
+		total := catalog.total
+		feed.Metadata.NumberOfItems = &total
+		feed.Metadata.ItemsPerPage = catalog.pageSize
+		feed.Metadata.CurrentPage = catalog.page
+	}
+
+	for _, section := range catalog.sections {
+		feed.Navigation = append(feed.Navigation, OPDSLink{Rel: section.rel, Type: opdsJSONType, Href: h.url(section.path), Title: section.title})
+	}
+	if catalog.feed == nil {
+		return feed
+	}
+	feed.Publications = make([]OPDSPublication, len(catalog.feed.Items))
+	for i, item := range catalog.feed.Items {
+		book := item.Book
+		publication := OPDSPublication{
+			Metadata: OPDSPublicationMetadata{
+				Type:        "http://schema.org/Book",
+				Identifier:  bookURN(book),
+				Title:       book.Title,
+				Author:      book.Author,
+				Description: book.Description,
+				Modified:    book.UpdatedAt.UTC(),
+			},
+			Links: []OPDSLink{
+				{Rel: "self", Type: "text/html", Href: h.bookPage(book.ID)},
+				{Rel: opdsBorrow, Type: "text/html", Href: h.bookPage(book.ID)},
+			},
+		}
+		if book.ISBN != "" {
+			publication.Metadata.Identifier = "urn:isbn:" + book.ISBN
+		}
+		if book.Year > 0 {
+			publication.Metadata.Published = strconv.Itoa(book.Year)
+		}
+		if item.Cover != nil {
+			publication.Images = []OPDSLink{{Href: h.api.URL("/books/" + book.ID + "/cover"), Type: item.Cover.ContentType}}
+		}
+		feed.Publications[i] = publication
+	}
+	return feed
+}
+
+// pageLinks returns the first, previous, next and last links of a paginated page
+func (h *OPDSHandler) pageLinks(catalog *opdsCatalog) []OPDSLink {
+	if catalog.pageSize == 0 {
+		return nil
+	}
+	last := (catalog.total + catalog.pageSize - 1) / catalog.pageSize
+	if last < 1 {
+		last = 1
+	}
+	at := func(page int) string {
+		return h.url(withPage(catalog.path, page))
+	}
+
+	links := []OPDSLink{{Rel: "first", Href: at(1)}}
+	if catalog.page > 1 {
+		links = append(links, OPDSLink{Rel: "previous", Href: at(catalog.page - 1)})
+	}
+	if catalog.page < last {
+		links = append(links, OPDSLink{Rel: "next", Href: at(catalog.page + 1)})
+	}
+	return append(links, OPDSLink{Rel: "last", Href: at(last)})
+}
+
+// url returns the link to a path of the catalog
+func (h *OPDSHandler) url(path string) string {
+	return h.site.URL(h.prefix + path)
+}
+
+// bookPage returns the link to the page of a book on the library site
+func (h *OPDSHandler) bookPage(bookID string) string {
+	return h.site.URL("/books/" + bookID)
+}
+
+// opdsPage reads the page number of a request, 1 by default, writing the error of invalid ones
+func opdsPage(c *gin.Context) (int, bool) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page"})
+		return 0, false
+	}
+	return page, true
+}
+
+// authorPath returns the path of the books of an author
+func authorPath(name string) string {
+	return opdsPath("/author", url.Values{"name": {name}})
+}
+
+// opdsPath joins a path and its query
+func opdsPath(path string, query url.Values) string {
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
+// withPage sets the page number of a path with a query
+func withPage(path string, page int) string {
+	parsed, err := url.Parse(path)
+	if err != nil {
+		return path
+	}
+	query := parsed.Query()
+	query.Set("page", strconv.Itoa(page))
+	return opdsPath(parsed.Path, query)
+}
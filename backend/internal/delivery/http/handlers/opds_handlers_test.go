@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"library-management-system/internal/delivery/http/urlbuilder"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOPDSRouter(t *testing.T) *gin.Engine {
+	repo := newMemoryBookRepository()
+	added := time.Date(2026, 10, 14, 9, 30, 0, 0, time.UTC)
+	for i, book := range []entities.Book{
+		{ID: "b1", Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "9780062225719"},
+		{ID: "b2", Title: "Eric", Author: "Terry Pratchett", Year: 1990},
+		{ID: "b3", Title: "Good Omens", Author: "Neil Gaiman", Year: 1990},
+	} {
+		book.Status = entities.BookStatusActive
+		book.CreatedAt = added.Add(time.Duration(i) * time.Hour)
+		book.UpdatedAt = book.CreatedAt
+		repo.books[book.ID] = book
+	}
+	covers := feedCovers{"b1": {ContentType: "image/jpeg", Size: 2048}}
+
+	handler := NewOPDSHandler(usecase.NewFeedUseCase(usecase.NewBookUseCase(repo), covers, 20, 50), "/opds", "Library catalog", 2)
+	site, err := urlbuilder.New("https://library.example.com")
+	require.NoError(t, err)
+	handler.SetLinks(site, site.Under("/api"))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/opds", handler.GetRoot)
+	router.GET("/opds/books", handler.GetBooks)
+	router.GET("/opds/author", handler.GetAuthorBooks)
+	router.GET("/opds/search", handler.Search)
+	router.GET("/opds/opensearch.xml", handler.GetOpenSearch)
+	return router
+}
+
+// opdsLinks returns the hrefs of the links of an Atom feed by rel
+func opdsLinks(t *testing.T, body []byte) (atomFeed, map[string]string) {
+	var feed atomFeed
+	require.NoError(t, xml.Unmarshal(body, &feed))
+	links := make(map[string]string)
+	for _, link := range feed.Links {
+		links[link.Rel] = link.Href
+	}
+	return feed, links
+}
+
+func TestOPDSHandler_Navigation(t *testing.T) {
+	router := newOPDSRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/opds", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, opdsNavigationType, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `<feed xmlns="http://www.w3.org/2005/Atom" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:opds="http://opds-spec.org/2010/catalog"`)
+	feed, links := opdsLinks(t, w.Body.Bytes())
+	assert.Equal(t, "https://library.example.com/opds", links["start"])
+	assert.Equal(t, "https://library.example.com/opds/opensearch.xml", links["search"])
+	require.Len(t, feed.Entries, 3)
+	assert.Equal(t, "https://library.example.com/opds/new", feed.Entries[0].Links[0].Href)
+	assert.Equal(t, opdsSortNew, feed.Entries[0].Links[0].Rel)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/opds/opensearch.xml", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `template="https://library.example.com/opds/search?q={searchTerms}"`)
+}
+
+func TestOPDSHandler_Acquisition(t *testing.T) {
+	router := newOPDSRouter(t)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/opds/books?page=2", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, opdsAcquisitionType, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `<opensearch:totalResults>3</opensearch:totalResults><opensearch:itemsPerPage>2</opensearch:itemsPerPage><opensearch:startIndex>3</opensearch:startIndex>`)
+	feed, links := opdsLinks(t, w.Body.Bytes())
+	assert.Equal(t, "https://library.example.com/opds/books?page=1", links["previous"])
+	assert.Empty(t, links["next"])
+	require.Len(t, feed.Entries, 1)
+	assert.Contains(t, w.Body.String(), `<author><name>Terry Pratchett</name><uri>https://library.example.com/opds/author?name=Terry+Pratchett</uri></author><dc:identifier>urn:isbn:9780062225719</dc:identifier><dc:issued>1987</dc:issued>`)
+	assert.Contains(t, w.Body.String(), `<link rel="http://opds-spec.org/acquisition/borrow" type="text/html" href="https://library.example.com/books/b1"></link><link rel="http://opds-spec.org/image" type="image/jpeg" href="https://library.example.com/api/books/b1/cover"></link>`)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/opds/author?name=terry+pratchett", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	feed, links = opdsLinks(t, w.Body.Bytes())
+	assert.Len(t, feed.Entries, 2)
+	assert.Empty(t, links["next"])
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/opds/search", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestOPDSHandler_OPDS2(t *testing.T) {
+	router := newOPDSRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/opds/search?query=pratchett", nil)
+	req.Header.Set("Accept", "application/opds+json, application/atom+xml;q=0.9")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, opdsJSONType, w.Header().Get("Content-Type"))
+
+	var feed OPDSFeed
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &feed))
+	assert.Equal(t, 2, *feed.Metadata.NumberOfItems)
+	assert.Equal(t, OPDSLink{Rel: "search", Type: opdsJSONType, Href: "https://library.example.com/opds/search{?query}", Templated: true}, feed.Links[2])
+	require.Len(t, feed.Publications, 2)
+	assert.Equal(t, "Eric", feed.Publications[0].Metadata.Title)
+	assert.Equal(t, "urn:uuid:b2", feed.Publications[0].Metadata.Identifier)
+	assert.Equal(t, "urn:isbn:9780062225719", feed.Publications[1].Metadata.Identifier)
+	assert.Equal(t, []OPDSLink{{Href: "https://library.example.com/api/books/b1/cover", Type: "image/jpeg"}}, feed.Publications[1].Images)
+}
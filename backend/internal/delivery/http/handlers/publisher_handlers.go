@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PublisherHandler handles HTTP requests for publishers and imprints
+type PublisherHandler struct {
+	publisherUseCase *usecase.PublisherUseCase
+}
+
+// NewPublisherHandler creates a new publisher handler
+func NewPublisherHandler(publisherUseCase *usecase.PublisherUseCase) *PublisherHandler {
+	return &PublisherHandler{
+		publisherUseCase: publisherUseCase,
+	}
+}
+
+// PublisherRequest represents the request body for creating or updating a publisher
+type PublisherRequest struct {
+	Name string `json:"name" binding:"required"`
+	// Parent publisher ID; set it to make the publisher an imprint
+	ParentID *string `json:"parent_id"`
+}
+
+// GetPublishers handles GET /api/publishers
+// @Summary Get all publishers
+// @Description Retrieve all publishers and imprints ordered by name
+// @Tags publishers
+// @Accept json
+// @Produce json
+// @Success 200 {array} entities.Publisher
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /publishers [get]
+func (h *PublisherHandler) GetPublishers(c *gin.Context) {
+	publishers, err := h.publisherUseCase.ListPublishers()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, publishers)
+}
+
+// CreatePublisher handles POST /api/publishers
+// @Summary Create a publisher
+// @Description Create a publisher, or an imprint of an existing publisher when parent_id is set
+// @Tags publishers
+// @Accept json
+// @Produce json
+// @Param publisher body PublisherRequest true "Publisher information"
+// @Success 201 {object} entities.Publisher
+// @Failure 400 {object} handlers.ErrorResponse
+// @Router /publishers [post]
+func (h *PublisherHandler) CreatePublisher(c *gin.Context) {
+	var req PublisherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	publisher := &entities.Publisher{
+		Name:     req.Name,
+		ParentID: req.ParentID,
+	}
+
+	if err := h.publisherUseCase.CreatePublisher(publisher); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, publisher)
+}
+
+// GetPublisher handles GET /api/publishers/:id
+// @Summary Get a publisher by ID
+// @Description Retrieve a specific publisher by its ID
+// @Tags publishers
+// @Accept json
+// @Produce json
+// @Param id path string true "Publisher ID"
+// @Success 200 {object} entities.Publisher
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /publishers/{id} [get]
+func (h *PublisherHandler) GetPublisher(c *gin.Context) {
+	publisher, err := h.publisherUseCase.GetPublisher(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if publisher == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "publisher not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, publisher)
+}
+
+// GetImprints handles GET /api/publishers/:id/imprints
+// @Summary List imprints
+// @Description Retrieve the direct imprints of a publisher
+// @Tags publishers
+// @Accept json
+// @Produce json
+// @Param id path string true "Publisher ID"
+// @Success 200 {array} entities.Publisher
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /publishers/{id}/imprints [get]
+func (h *PublisherHandler) GetImprints(c *gin.Context) {
+	imprints, err := h.publisherUseCase.ListImprints(c.Param("id"))
+	if err != nil {
+		if err.Error() == "publisher not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, imprints)
+}
+
+// UpdatePublisher handles PUT /api/publishers/:id
+// @Summary Update a publisher
+// @Description Rename a publisher or move it under another parent; omit parent_id to make it top-level
+// @Tags publishers
+// @Accept json
+// @Produce json
+// @Param id path string true "Publisher ID"
+// @Param publisher body PublisherRequest true "Updated publisher information"
+// @Success 200 {object} entities.Publisher
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /publishers/{id} [put]
+func (h *PublisherHandler) UpdatePublisher(c *gin.Context) {
+	var req PublisherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	publisher := &entities.Publisher{
+		Name:     req.Name,
+		ParentID: req.ParentID,
+	}
+
+	if err := h.publisherUseCase.UpdatePublisher(c.Param("id"), publisher); err != nil {
+		if err.Error() == "publisher not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, publisher)
+}
+
+// DeletePublisher handles DELETE /api/publishers/:id
+// @Summary Delete a publisher
+// @Description Delete a publisher that has no imprints and no books
+// @Tags publishers
+// @Accept json
+// @Produce json
+// @Param id path string true "Publisher ID"
+// @Success 200 {object} handlers.MessageResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /publishers/{id} [delete]
+func (h *PublisherHandler) DeletePublisher(c *gin.Context) {
+	if err := h.publisherUseCase.DeletePublisher(c.Param("id")); err != nil {
+		if err.Error() == "publisher not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "publisher deleted successfully"})
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DatabaseMonitor reports the state of the database connection
+type DatabaseMonitor interface {
+	Health() entities.DatabaseHealth
+}
+
+// ReadinessResponse reports whether the server can take traffic
+type ReadinessResponse struct {
+	// ready, read_only, unavailable or draining
+	Status   string                  `json:"status"`
+	Database entities.DatabaseHealth `json:"database"`
+}
+
+// ReadinessHandler handles the readiness probe of the server
+type ReadinessHandler struct {
+	database DatabaseMonitor
+	draining atomic.Bool
+}
+
+// NewReadinessHandler creates a new readiness handler
+func NewReadinessHandler(database DatabaseMonitor) *ReadinessHandler {
+	return &ReadinessHandler{
+		database: database,
+	}
+}
+
+// Drain makes the server report itself unready from now on, so load balancers stop sending it
+// new requests before it shuts down
+func (h *ReadinessHandler) Drain() {
+	h.draining.Store(true)
+}
+
+// GetReadiness handles GET /readyz
+// @Summary Check readiness
+// @Description Report whether the server can take traffic: 200 while the database connection is up, and with status read_only while the database refuses writes but still serves reads, 503 while it is down or the server is shutting down, along with the connection state as last checked
+// @Tags health
+// @Produce json
+// @Success 200 {object} handlers.ReadinessResponse
+// @Failure 503 {object} handlers.ReadinessResponse
+// @Router /readyz [get]
+func (h *ReadinessHandler) GetReadiness(c *gin.Context) {
+	health := h.database.Health()
+	if h.draining.Load() {
+		c.JSON(http.StatusServiceUnavailable, ReadinessResponse{Status: "draining", Database: health})
+		return
+	}
+	if health.State == entities.DatabaseReadOnly {
+		c.JSON(http.StatusOK, ReadinessResponse{Status: "read_only", Database: health})
+		return
+	}
+	if health.State != entities.DatabaseUp {
+		c.JSON(http.StatusServiceUnavailable, ReadinessResponse{Status: "unavailable", Database: health})
+		return
+	}
+	c.JSON(http.StatusOK, ReadinessResponse{Status: "ready", Database: health})
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RelatedBookResponse is a book related to another, with the signals relating them
+type RelatedBookResponse struct {
+	Rank  int   `json:"rank" example:"1"`
+	Score int64 `json:"score" example:"8"`
+	// Reasons lists the signals relating the books: same_author, same_series, shared_category
+	// and co_borrowed
+	Reasons []string `json:"reasons" example:"same_author,same_series"`
+	// CoBorrowers counts the members who borrowed both books
+	CoBorrowers int64        `json:"co_borrowers" example:"0"`
+	Book        BookResponse `json:"book"`
+}
+
+// RelatedBooksResponse lists the books related to a book by rank
+type RelatedBooksResponse struct {
+	BookID string `json:"book_id"`
+	// RefreshedAt is when the ranking was computed, nil when the book has no related books
+	RefreshedAt *time.Time            `json:"refreshed_at"`
+	Related     []RelatedBookResponse `json:"related"`
+}
+
+// RelatedBookHandler handles HTTP requests for related books
+type RelatedBookHandler struct {
+	relatedBookUseCase *usecase.RelatedBookUseCase
+}
+
+// NewRelatedBookHandler creates a new related book handler
+func NewRelatedBookHandler(relatedBookUseCase *usecase.RelatedBookUseCase) *RelatedBookHandler {
+	return &RelatedBookHandler{
+		relatedBookUseCase: relatedBookUseCase,
+	}
+}
+
+// GetRelatedBooks handles GET /api/books/:id/related
+// @Summary Get related books
+// @Description Retrieve the books related to a book, ranked by score. Each signal adds its weight to the score: the same author, the same series, the same category metadata, and every member who borrowed both books. The ranking is recomputed by the stats_refresh job rather than live; refreshed_at tells when, also sent as Last-Modified, and books deleted or unlisted since are left out.
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Success 200 {object} handlers.RelatedBooksResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/{id}/related [get]
+func (h *RelatedBookHandler) GetRelatedBooks(c *gin.Context) {
+	related, err := h.relatedBookUseCase.Related(c.Param("id"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domainerr.ErrBookNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := RelatedBooksResponse{BookID: c.Param("id"), Related: make([]RelatedBookResponse, len(related))}
+	for i, book := range related {
+		response.Related[i] = RelatedBookResponse{
+			Rank:        book.Rank,
+			Score:       book.Score,
+			Reasons:     book.Reasons(),
+			CoBorrowers: book.CoBorrowers,
+			Book:        newBookResponse(*book.Book, bookView{}),
+		}
+		if response.RefreshedAt == nil {
+			refreshedAt := book.RefreshedAt
+			response.RefreshedAt = &refreshedAt
+		}
+	}
+	if response.RefreshedAt != nil {
+		c.Header("Last-Modified", response.RefreshedAt.UTC().Format(http.TimeFormat))
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/domain/document"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportHandler handles HTTP requests for librarian reports
+type ReportHandler struct {
+	reportUseCase *usecase.ReportUseCase
+	renderer      document.Renderer
+	// sortedJSON sorts the keys of format=json reports unless the request says otherwise
+	sortedJSON bool
+}
+
+// NewReportHandler creates a new report handler
+func NewReportHandler(reportUseCase *usecase.ReportUseCase) *ReportHandler {
+	return &ReportHandler{
+		reportUseCase: reportUseCase,
+	}
+}
+
+// SetRenderer sets the renderer of format=pdf downloads
+func (h *ReportHandler) SetRenderer(renderer document.Renderer) {
+	h.renderer = renderer
+}
+
+// SetSortedJSON sets whether format=json reports are written with sorted keys by default
+func (h *ReportHandler) SetSortedJSON(sorted bool) {
+	h.sortedJSON = sorted
+}
+
+// weedingCSVHeader lists the columns of the weeding report export
+var weedingCSVHeader = []string{"book_id", "title", "author", "year", "isbn", "copies", "last_loaned_at", "last_activity_at", "last_loaned_at_iso_week", "last_activity_at_iso_week"}
+
+// GetWeedingReport handles GET /api/admin/reports/weeding
+// @Summary Weeding report
+// @Description List books never loaned or not loaned in the last N years, stalest first, to help retire stale stock. format=csv and format=pdf export every row.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Produce application/pdf
+// @Param years query int false "Years without a loan" default(5)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(50)
+// @Param format query string false "json (default), csv or pdf"
+// @Param sorted query bool false "Sort the keys of the JSON report"
+// @Param tz query string false "Timezone (IANA name or UTC offset) to render timestamps in, UTC by default"
+// @Success 200 {object} entities.WeedingReport
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/reports/weeding [get]
+func (h *ReportHandler) GetWeedingReport(c *gin.Context) {
+	years, err := strconv.Atoi(c.DefaultQuery("years", "0"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid years"})
+		return
+	}
+	location, err := requestLocation(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+	case "csv":
+		h.writeWeedingCSV(c, years, exportLocation(location))
+		return
+	case "pdf":
+		h.writeWeedingPDF(c, years, exportLocation(location))
+		return
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json, csv or pdf"})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page"})
+		return
+	}
+	pageSize, err := middleware.PageSize(c, "page_size")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page_size"})
+		return
+	}
+	sorted, err := sortedKeys(c, h.sortedJSON)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sorted"})
+		return
+	}
+
+	report, err := h.reportUseCase.WeedingReport(years, page, pageSize)
+	if err != nil {
+		if err.Error() == "years must be at least 1" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	localizeWeedingReport(report, location)
+
+	if sorted {
+		writeSortedJSON(c, http.StatusOK, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// writeWeedingCSV exports every weeding candidate as a CSV attachment, with timestamps in location
+func (h *ReportHandler) writeWeedingCSV(c *gin.Context, years int, location *time.Location) {
+	report, err := h.reportUseCase.WeedingCandidates(years)
+	if err != nil {
+		if err.Error() == "years must be at least 1" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="weeding-report.csv"`)
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	rows := append([][]string{weedingCSVHeader}, weedingCSVRows(report.Items, location)...)
+	if err := writer.WriteAll(rows); err != nil {
+		// Headers are already sent, so the client only sees a truncated file
+		log.Printf("Failed to write weeding report: %v", err)
+	}
+}
+
+// writeWeedingPDF exports every weeding candidate as a printable document, with dates in location
+func (h *ReportHandler) writeWeedingPDF(c *gin.Context, years int, location *time.Location) {
+	report, err := h.reportUseCase.WeedingCandidates(years)
+	if err != nil {
+		if err.Error() == "years must be at least 1" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	rows := make([][]string, len(report.Items))
+	for i, candidate := range report.Items {
+		lastLoanedAt, week := "never", ""
+		if candidate.LastLoanedAt != nil {
+			lastLoanedAt = candidate.LastLoanedAt.In(location).Format("2006-01-02")
+			week = isoWeek(candidate.LastLoanedAt.In(location))
+		}
+		rows[i] = []string{candidate.Title, candidate.Author, candidate.ISBN, strconv.Itoa(candidate.Copies), lastLoanedAt, week}
+	}
+
+	writeDocument(c, h.renderer, document.Document{
+		Title:    "Weeding report",
+		Subtitle: fmt.Sprintf("Not loaned in %d years (since %s, %s), %d books", report.Years, report.Cutoff.In(location).Format("2006-01-02"), isoWeek(report.Cutoff.In(location)), report.Total),
+		Sections: []document.Section{{
+			Columns: []string{"Title", "Author", "ISBN", "Copies", "Last loan", "Week"},
+			Rows:    rows,
+		}},
+	}, "weeding-report")
+}
+
+// weedingCSVRows formats weeding candidates as CSV records, with timestamps and their ISO weeks
+// in location
+func weedingCSVRows(candidates []entities.WeedingCandidate, location *time.Location) [][]string {
+	rows := make([][]string, len(candidates))
+	for i, candidate := range candidates {
+		lastLoanedAt, lastLoanedWeek := "", ""
+		if candidate.LastLoanedAt != nil {
+			lastLoanedAt = candidate.LastLoanedAt.In(location).Format(time.RFC3339)
+			lastLoanedWeek = isoWeek(candidate.LastLoanedAt.In(location))
+		}
+		rows[i] = []string{
+			candidate.BookID,
+			candidate.Title,
+			candidate.Author,
+			strconv.Itoa(candidate.Year),
+			candidate.ISBN,
+			strconv.Itoa(candidate.Copies),
+			lastLoanedAt,
+			candidate.LastActivityAt.In(location).Format(time.RFC3339),
+			lastLoanedWeek,
+			isoWeek(candidate.LastActivityAt.In(location)),
+		}
+	}
+	return rows
+}
+
+// localizeWeedingReport expresses the timestamps of a weeding report in location when the client
+// asked for one, and adds their ISO weeks in it, in UTC by default
+func localizeWeedingReport(report *entities.WeedingReport, location *time.Location) {
+	weeks := exportLocation(location)
+	if location != nil {
+		report.Cutoff = report.Cutoff.In(location)
+	}
+	report.CutoffISOWeek = isoWeek(report.Cutoff.In(weeks))
+	for i := range report.Items {
+		candidate := &report.Items[i]
+		if candidate.LastLoanedAt != nil {
+			if location != nil {
+				lastLoanedAt := candidate.LastLoanedAt.In(location)
+				candidate.LastLoanedAt = &lastLoanedAt
+			}
+			candidate.LastLoanedAtISOWeek = isoWeek(candidate.LastLoanedAt.In(weeks))
+		}
+		if location != nil {
+			candidate.LastActivityAt = candidate.LastActivityAt.In(location)
+		}
+		candidate.LastActivityAtISOWeek = isoWeek(candidate.LastActivityAt.In(weeks))
+	}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/infrastructure/pdf"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportHandler_GetWeedingReportCSV(t *testing.T) {
+	added := time.Date(2015, time.March, 1, 9, 0, 0, 0, time.UTC)
+	entities.SetClock(clock.NewFixed(added))
+	t.Cleanup(func() { entities.SetClock(clock.System{}) })
+
+	bookRepo := newMemoryBookRepository()
+	require.NoError(t, bookRepo.Create(&entities.Book{ID: "book-1", Title: "Moby-Dick; or, The Whale", Author: "Herman Melville", Year: 1851, ISBN: "9780142437247"}))
+
+	reportUseCase := usecase.NewReportUseCase(bookRepo, nil)
+	reportUseCase.SetClock(clock.NewFixed(added.AddDate(10, 0, 0)))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/admin/reports/weeding", NewReportHandler(reportUseCase).GetWeedingReport)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/reports/weeding?format=csv", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="weeding-report.csv"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t,
+		"book_id,title,author,year,isbn,copies,last_loaned_at,last_activity_at,last_loaned_at_iso_week,last_activity_at_iso_week\n"+
+			"book-1,\"Moby-Dick; or, The Whale\",Herman Melville,1851,9780142437247,1,,2015-03-01T09:00:00Z,,2015-W09\n",
+		w.Body.String())
+}
+
+func TestReportHandler_GetWeedingReportCSVInTimezone(t *testing.T) {
+	added := time.Date(2015, time.March, 1, 20, 0, 0, 0, time.UTC)
+	entities.SetClock(clock.NewFixed(added))
+	t.Cleanup(func() { entities.SetClock(clock.System{}) })
+
+	bookRepo := newMemoryBookRepository()
+	require.NoError(t, bookRepo.Create(&entities.Book{ID: "book-1", Title: "Moby-Dick", Author: "Herman Melville", Year: 1851, ISBN: "9780142437247"}))
+
+	reportUseCase := usecase.NewReportUseCase(bookRepo, nil)
+	reportUseCase.SetClock(clock.NewFixed(added.AddDate(10, 0, 0)))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/admin/reports/weeding", NewReportHandler(reportUseCase).GetWeedingReport)
+
+	// The plus sign of an unescaped offset arrives as a space
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/reports/weeding?format=csv&tz=+07:00", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), ",2015-03-02T03:00:00+07:00,,2015-W10\n")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/reports/weeding?format=csv&tz=Mars/Olympus", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"invalid timezone"}`, w.Body.String())
+}
+
+func TestReportHandler_GetWeedingReportPDF(t *testing.T) {
+	added := time.Date(2015, time.March, 1, 9, 0, 0, 0, time.UTC)
+	entities.SetClock(clock.NewFixed(added))
+	t.Cleanup(func() { entities.SetClock(clock.System{}) })
+
+	bookRepo := newMemoryBookRepository()
+	require.NoError(t, bookRepo.Create(&entities.Book{ID: "book-1", Title: "Moby-Dick", Author: "Herman Melville", Year: 1851, ISBN: "9780142437247"}))
+
+	reportUseCase := usecase.NewReportUseCase(bookRepo, nil)
+	reportUseCase.SetClock(clock.NewFixed(added.AddDate(10, 0, 0)))
+	handler := NewReportHandler(reportUseCase)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/admin/reports/weeding", handler.GetWeedingReport)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/reports/weeding?format=pdf", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code, "no renderer configured")
+
+	handler.SetRenderer(pdf.NewRenderer(pdf.A4))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/reports/weeding?format=pdf", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="weeding-report.pdf"`, w.Header().Get("Content-Disposition"))
+	assert.True(t, strings.HasPrefix(w.Body.String(), "%PDF-"))
+	assert.Contains(t, w.Body.String(), "Moby-Dick")
+}
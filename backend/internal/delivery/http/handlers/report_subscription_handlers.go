@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportSubscriptionHandler handles HTTP requests for scheduled report deliveries
+type ReportSubscriptionHandler struct {
+	subscriptionUseCase *usecase.ReportSubscriptionUseCase
+}
+
+// NewReportSubscriptionHandler creates a new report subscription handler
+func NewReportSubscriptionHandler(subscriptionUseCase *usecase.ReportSubscriptionUseCase) *ReportSubscriptionHandler {
+	return &ReportSubscriptionHandler{
+		subscriptionUseCase: subscriptionUseCase,
+	}
+}
+
+// ReportSubscriptionRequest represents the request body for creating or replacing a report subscription
+type ReportSubscriptionRequest struct {
+	// weekly_stats or weeding
+	// example: weekly_stats
+	Report string `json:"report" binding:"required"`
+	// email or webhook
+	// example: webhook
+	Channel string `json:"channel" binding:"required"`
+	// Email address or webhook URL
+	// example: https://example.com/hooks/reports
+	Target string `json:"target" binding:"required"`
+	// Cron expression evaluated in the timezone of the tenant
+	// example: 0 8 * * mon
+	Schedule string `json:"schedule" binding:"required"`
+	// Defaults to true
+	Enabled *bool `json:"enabled"`
+}
+
+// subscription maps the request to a subscription entity
+func (r ReportSubscriptionRequest) subscription() *entities.ReportSubscription {
+	enabled := true
+	if r.Enabled != nil {
+		enabled = *r.Enabled
+	}
+	return &entities.ReportSubscription{
+		Report:   r.Report,
+		Channel:  r.Channel,
+		Target:   r.Target,
+		Schedule: r.Schedule,
+		Enabled:  enabled,
+	}
+}
+
+// GetReportSubscriptions handles GET /api/admin/report-subscriptions
+// @Summary List report subscriptions
+// @Description Retrieve all scheduled report deliveries
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Success 200 {array} entities.ReportSubscription
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/report-subscriptions [get]
+func (h *ReportSubscriptionHandler) GetReportSubscriptions(c *gin.Context) {
+	subscriptions, err := h.subscriptionUseCase.ListSubscriptions()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if subscriptions == nil {
+		subscriptions = []entities.ReportSubscription{}
+	}
+
+	c.JSON(http.StatusOK, subscriptions)
+}
+
+// CreateReportSubscription handles POST /api/admin/report-subscriptions
+// @Summary Subscribe to a report
+// @Description Deliver a report by email or webhook on a cron schedule, evaluated in the timezone of the tenant sending X-Tenant-ID, or in the default CIRCULATION_TIMEZONE without it
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "Admin ID"
+// @Param X-Tenant-ID header string false "Tenant whose timezone the schedule is in"
+// @Param subscription body ReportSubscriptionRequest true "Subscription"
+// @Success 201 {object} entities.ReportSubscription
+// @Failure 400 {object} handlers.ErrorResponse
+// @Router /admin/report-subscriptions [post]
+func (h *ReportSubscriptionHandler) CreateReportSubscription(c *gin.Context) {
+	adminID := callerID(c)
+	if adminID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	var req ReportSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	subscription := req.subscription()
+	if tenantID := c.GetHeader(middleware.TenantHeader); tenantID != "" {
+		subscription.TenantID = &tenantID
+	}
+	if err := h.subscriptionUseCase.CreateSubscription(adminID, subscription); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, subscription)
+}
+
+// GetReportSubscription handles GET /api/admin/report-subscriptions/:id
+// @Summary Get a report subscription
+// @Description Retrieve a scheduled report delivery with its next and last run
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} entities.ReportSubscription
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/report-subscriptions/{id} [get]
+func (h *ReportSubscriptionHandler) GetReportSubscription(c *gin.Context) {
+	subscription, err := h.subscriptionUseCase.GetSubscription(c.Param("id"))
+	if err != nil {
+		if err.Error() == "subscription not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+// UpdateReportSubscription handles PUT /api/admin/report-subscriptions/:id
+// @Summary Update a report subscription
+// @Description Replace the report, delivery and schedule of a subscription; the next run is recomputed
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Param subscription body ReportSubscriptionRequest true "Subscription"
+// @Success 200 {object} entities.ReportSubscription
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /admin/report-subscriptions/{id} [put]
+func (h *ReportSubscriptionHandler) UpdateReportSubscription(c *gin.Context) {
+	var req ReportSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	subscription, err := h.subscriptionUseCase.UpdateSubscription(c.Param("id"), req.subscription())
+	if err != nil {
+		if err.Error() == "subscription not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+// DeleteReportSubscription handles DELETE /api/admin/report-subscriptions/:id
+// @Summary Delete a report subscription
+// @Description Stop a scheduled report delivery and drop its run history
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} handlers.MessageResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/report-subscriptions/{id} [delete]
+func (h *ReportSubscriptionHandler) DeleteReportSubscription(c *gin.Context) {
+	if err := h.subscriptionUseCase.DeleteSubscription(c.Param("id")); err != nil {
+		if err.Error() == "subscription not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "subscription deleted successfully"})
+}
+
+// GetReportSubscriptionRuns handles GET /api/admin/report-subscriptions/:id/runs
+// @Summary Report subscription history
+// @Description Retrieve the latest deliveries of a subscription, newest first
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Param limit query int false "Number of runs" default(20)
+// @Success 200 {array} entities.ReportSubscriptionRun
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /admin/report-subscriptions/{id}/runs [get]
+func (h *ReportSubscriptionHandler) GetReportSubscriptionRuns(c *gin.Context) {
+	limit, err := middleware.PageSize(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return
+	}
+
+	runs, err := h.subscriptionUseCase.ListRuns(c.Param("id"), limit)
+	if err != nil {
+		if err.Error() == "subscription not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if runs == nil {
+		runs = []entities.ReportSubscriptionRun{}
+	}
+
+	c.JSON(http.StatusOK, runs)
+}
+
+// RunReportSubscription handles POST /api/admin/report-subscriptions/:id/run
+// @Summary Deliver a report now
+// @Description Deliver a subscription immediately, e.g. to test its target; the schedule is unchanged
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} entities.ReportSubscriptionRun
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/report-subscriptions/{id}/run [post]
+func (h *ReportSubscriptionHandler) RunReportSubscription(c *gin.Context) {
+	run, err := h.subscriptionUseCase.RunNow(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err.Error() == "subscription not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
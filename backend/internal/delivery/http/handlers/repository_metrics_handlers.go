@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OperationMetrics is a registry of the calls of operations
+type OperationMetrics interface {
+	Snapshot() []entities.OperationStats
+}
+
+// RepositoryMetricsHandler handles HTTP requests about the calls made to the repositories
+type RepositoryMetricsHandler struct {
+	metrics OperationMetrics
+}
+
+// NewRepositoryMetricsHandler creates a new repository metrics handler
+func NewRepositoryMetricsHandler(metrics OperationMetrics) *RepositoryMetricsHandler {
+	return &RepositoryMetricsHandler{
+		metrics: metrics,
+	}
+}
+
+// GetRepositoryMetrics handles GET /api/admin/repository-metrics
+// @Summary Get repository metrics
+// @Description Report how many times each repository method was called on this instance since the server started, how many calls returned an error, and how long they took. The statements of every repository are reported by table and operation, as sql.<table>.<operation>, and the calls of the book repository by method, as books.<Method>.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {array} entities.OperationStats
+// @Router /admin/repository-metrics [get]
+func (h *RepositoryMetricsHandler) GetRepositoryMetrics(c *gin.Context) {
+	c.JSON(http.StatusOK, h.metrics.Snapshot())
+}
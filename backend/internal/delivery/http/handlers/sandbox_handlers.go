@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SandboxHandler handles HTTP requests about the dataset of a sandbox deployment
+type SandboxHandler struct {
+	sandboxUseCase *usecase.SandboxUseCase
+}
+
+// NewSandboxHandler creates a new sandbox handler
+func NewSandboxHandler(sandboxUseCase *usecase.SandboxUseCase) *SandboxHandler {
+	return &SandboxHandler{
+		sandboxUseCase: sandboxUseCase,
+	}
+}
+
+// SaveBaseline handles POST /api/admin/sandbox/baseline
+// @Summary Save the sandbox baseline
+// @Description Make the current data of a sandbox deployment the dataset the nightly sandbox_reset job restores. Only served when SANDBOX_ENABLED is set.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} entities.SandboxBaseline
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/sandbox/baseline [post]
+func (h *SandboxHandler) SaveBaseline(c *gin.Context) {
+	baseline, err := h.sandboxUseCase.SaveBaseline()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, baseline)
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bookSearchFilters maps the book fields GET /books/search filters on to their query parameters
+var bookSearchFilters = map[string]string{
+	"title":        "title",
+	"author":       "author",
+	"year":         "year",
+	"publisher_id": "publisher",
+	"status":       "status",
+}
+
+// SchemaHandler handles HTTP requests for the field-level schemas of entities
+type SchemaHandler struct {
+	schemaUseCase *usecase.SchemaUseCase
+}
+
+// NewSchemaHandler creates a new schema handler
+func NewSchemaHandler(schemaUseCase *usecase.SchemaUseCase) *SchemaHandler {
+	return &SchemaHandler{
+		schemaUseCase: schemaUseCase,
+	}
+}
+
+// GetBookSchema handles GET /api/schema/books
+// @Summary Get the schema of books
+// @Description Describe the fields of books so forms and filters can be built without hardcoding them: their types, whether they are required, read-only or unique, the bounds of the built-in validation, the enabled validation rules checking them, and the search parameters filtering on them. With a tenant, the metadata fields it defined are described too.
+// @Tags schema
+// @Produce json
+// @Param X-Tenant-ID header string false "Tenant ID, to describe its metadata fields"
+// @Success 200 {object} entities.EntitySchema
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /schema/books [get]
+func (h *SchemaHandler) GetBookSchema(c *gin.Context) {
+	schema, err := h.schemaUseCase.BookSchema(c.GetHeader(middleware.TenantHeader))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for i := range schema.Fields {
+		setFilter(&schema.Fields[i], bookSearchFilters[schema.Fields[i].Name])
+	}
+	for i := range schema.Metadata {
+		setFilter(&schema.Metadata[i], metadataQueryPrefix+schema.Metadata[i].Name)
+	}
+	schema.Sorts = append(schema.Sorts, sortPopularity)
+
+	c.JSON(http.StatusOK, schema)
+}
+
+// setFilter marks a field as filtered on by a search query parameter, if any
+func setFilter(field *entities.FieldSchema, param string) {
+	field.Filter = param
+	field.Filterable = param != ""
+}
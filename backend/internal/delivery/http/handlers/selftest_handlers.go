@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SelfTestHandler handles HTTP requests running the self-test of the server
+type SelfTestHandler struct {
+	selfTestUseCase *usecase.SelfTestUseCase
+}
+
+// NewSelfTestHandler creates a new self-test handler
+func NewSelfTestHandler(selfTestUseCase *usecase.SelfTestUseCase) *SelfTestHandler {
+	return &SelfTestHandler{
+		selfTestUseCase: selfTestUseCase,
+	}
+}
+
+// GetSelfTest handles GET /api/admin/selftest
+// @Summary Run the self-test
+// @Description Check the database connection, the migrations applied, the cover storage, the shared cache and the email, webhook and Slack servers, reporting each check as pass, fail or skip (not used by the deployment) with its detail. Answers 503 when any check fails, for post-deploy verification.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} entities.SelfTestReport
+// @Failure 503 {object} entities.SelfTestReport
+// @Router /admin/selftest [get]
+func (h *SelfTestHandler) GetSelfTest(c *gin.Context) {
+	report := h.selfTestUseCase.Run(c.Request.Context())
+	status := http.StatusOK
+	if !report.Passed {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SeriesHandler handles HTTP requests for book series
+type SeriesHandler struct {
+	seriesUseCase *usecase.SeriesUseCase
+}
+
+// NewSeriesHandler creates a new series handler
+func NewSeriesHandler(seriesUseCase *usecase.SeriesUseCase) *SeriesHandler {
+	return &SeriesHandler{
+		seriesUseCase: seriesUseCase,
+	}
+}
+
+// CreateSeriesRequest represents the request body for creating a series
+type CreateSeriesRequest struct {
+	Name string `json:"name" binding:"required"`
+	// How books are listed: position (default) or publication
+	Ordering string `json:"ordering"`
+}
+
+// GetAllSeries handles GET /api/series
+// @Summary Get all series
+// @Description Retrieve all book series ordered by name
+// @Tags series
+// @Accept json
+// @Produce json
+// @Success 200 {array} entities.Series
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /series [get]
+func (h *SeriesHandler) GetAllSeries(c *gin.Context) {
+	series, err := h.seriesUseCase.ListSeries()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// CreateSeries handles POST /api/series
+// @Summary Create a series
+// @Description Create a book series
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param series body CreateSeriesRequest true "Series information"
+// @Success 201 {object} entities.Series
+// @Failure 400 {object} handlers.ErrorResponse
+// @Router /series [post]
+func (h *SeriesHandler) CreateSeries(c *gin.Context) {
+	var req CreateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	series := &entities.Series{
+		Name:     req.Name,
+		Ordering: req.Ordering,
+	}
+
+	if err := h.seriesUseCase.CreateSeries(series); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, series)
+}
+
+// GetSeries handles GET /api/series/:id
+// @Summary Get a series by ID
+// @Description Retrieve a specific series by its ID
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path string true "Series ID"
+// @Success 200 {object} entities.Series
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /series/{id} [get]
+func (h *SeriesHandler) GetSeries(c *gin.Context) {
+	series, err := h.seriesUseCase.GetSeries(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if series == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "series not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// GetSeriesBooks handles GET /api/series/:id/books
+// @Summary List the books of a series
+// @Description Retrieve the books of a series in the series' ordering
+// @Tags series
+// @Accept json
+// @Produce json
+// @Param id path string true "Series ID"
+// @Success 200 {array} handlers.BookResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /series/{id}/books [get]
+func (h *SeriesHandler) GetSeriesBooks(c *gin.Context) {
+	books, err := h.seriesUseCase.ListBooks(c.Param("id"))
+	if err != nil {
+		if err.Error() == "series not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, newBookResponses(books, bookView{}))
+}
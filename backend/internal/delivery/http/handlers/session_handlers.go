@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionHandler handles HTTP requests signing users in and about the sessions of the caller
+type SessionHandler struct {
+	sessionUseCase *usecase.SessionUseCase
+}
+
+// NewSessionHandler creates a new session handler
+func NewSessionHandler(sessionUseCase *usecase.SessionUseCase) *SessionHandler {
+	return &SessionHandler{
+		sessionUseCase: sessionUseCase,
+	}
+}
+
+// SessionCreatedResponse is a new session with its token, shown only once
+type SessionCreatedResponse struct {
+	entities.Session
+	Token string `json:"token" example:"lms_sess_9c4be1d84e0f9a6b2c7d5e8f1a3b3f2a6d1e0c9b8a7f4e3d2c1b0a9f8e7d6c5b"`
+}
+
+// SessionResponse is an active session of the caller
+type SessionResponse struct {
+	entities.Session
+	// Current marks the session the request was signed in with
+	Current bool `json:"current"`
+}
+
+// RevokedSessionsResponse tells how many sessions were signed out
+type RevokedSessionsResponse struct {
+	Revoked int64 `json:"revoked" example:"2"`
+}
+
+// SignIn handles POST /api/auth/sessions
+// @Summary Sign in
+// @Description Sign in with the email and password of an active user, sent as HTTP Basic credentials, and start a session for the device, named by its User-Agent. The session token, shown in this response only, is sent as "Authorization: Bearer <token>" to sign requests in as the user until the session expires after SESSION_LIFETIME or is revoked.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Basic credentials: email and password"
+// @Success 201 {object} handlers.SessionCreatedResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 401 {object} handlers.ErrorResponse
+// @Failure 429 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /auth/sessions [post]
+func (h *SessionHandler) SignIn(c *gin.Context) {
+	email, password, ok := c.Request.BasicAuth()
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "email and password are required as HTTP Basic credentials"})
+		return
+	}
+
+	session, secret, err := h.sessionUseCase.SignIn(email, password, usecase.SessionClient{
+		Device: c.Request.UserAgent(),
+		IP:     c.ClientIP(),
+	})
+	if err != nil {
+		writeAccessTokenError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusCreated, SessionCreatedResponse{Session: *session, Token: secret})
+}
+
+// GetSessions handles GET /api/me/sessions
+// @Summary List my sessions
+// @Description List the caller's active sessions, last seen first, with the device and IP each was last seen from. The session the request was signed in with is marked current.
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {array} handlers.SessionResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /me/sessions [get]
+func (h *SessionHandler) GetSessions(c *gin.Context) {
+	userID := callerID(c)
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	sessions, err := h.sessionUseCase.List(userID)
+	if err != nil {
+		writeAccessTokenError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	current := currentSessionID(c)
+	responses := make([]SessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = SessionResponse{Session: session, Current: session.ID == current}
+	}
+
+	c.JSON(http.StatusOK, responses)
+}
+
+// RevokeSession handles DELETE /api/me/sessions/:id
+// @Summary Revoke a session
+// @Description Sign out one of the caller's active sessions, such as a lost device, or the current one; requests presenting its token are refused from then on
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "User ID"
+// @Param id path string true "Session ID"
+// @Success 200 {object} entities.Session
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /me/sessions/{id} [delete]
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	userID := callerID(c)
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	session, err := h.sessionUseCase.Revoke(userID, c.Param("id"))
+	if err != nil {
+		writeAccessTokenError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// RevokeOtherSessions handles DELETE /api/me/sessions
+// @Summary Revoke my other sessions
+// @Description Sign out every active session of the caller but the one the request was signed in with; requests not signed in with a session sign out all of them
+// @Tags me
+// @Accept json
+// @Produce json
+// @Param X-User-ID header string true "User ID"
+// @Success 200 {object} handlers.RevokedSessionsResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /me/sessions [delete]
+func (h *SessionHandler) RevokeOtherSessions(c *gin.Context) {
+	userID := callerID(c)
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "caller ID is required"})
+		return
+	}
+
+	revoked, err := h.sessionUseCase.RevokeOthers(userID, currentSessionID(c))
+	if err != nil {
+		writeAccessTokenError(c, err, http.StatusInternalServerError)
+		return
+	}
+
+	c.JSON(http.StatusOK, RevokedSessionsResponse{Revoked: revoked})
+}
+
+// currentSessionID returns the ID of the session the request was signed in with, empty when it
+// was not signed in with one
+func currentSessionID(c *gin.Context) string {
+	if session, ok := c.Get(middleware.SessionKey); ok {
+		return session.(*entities.Session).ID
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupTokenHeader carries the token that guards deployment bootstrap
+const setupTokenHeader = "X-Setup-Token"
+
+// SetupHandler handles HTTP requests that provision a fresh deployment
+type SetupHandler struct {
+	setupUseCase *usecase.SetupUseCase
+}
+
+// NewSetupHandler creates a new setup handler
+func NewSetupHandler(setupUseCase *usecase.SetupUseCase) *SetupHandler {
+	return &SetupHandler{
+		setupUseCase: setupUseCase,
+	}
+}
+
+// BootstrapRequest represents the request body for bootstrapping a deployment
+type BootstrapRequest struct {
+	Tenant struct {
+		Name string `json:"name" binding:"required"`
+		// Derived from the name when empty
+		Slug string `json:"slug"`
+	} `json:"tenant" binding:"required"`
+	Admin struct {
+		Email    string `json:"email" binding:"required"`
+		Name     string `json:"name"`
+		Password string `json:"password" binding:"required"`
+	} `json:"admin" binding:"required"`
+}
+
+// SetupStatus reports whether a deployment can and still needs to be bootstrapped
+type SetupStatus struct {
+	// Whether a setup token is configured
+	Enabled bool `json:"enabled"`
+	// Whether the deployment already has users
+	Bootstrapped bool `json:"bootstrapped"`
+}
+
+// GetSetupStatus handles GET /api/setup/status
+// @Summary Get setup status
+// @Description Report whether the deployment has been bootstrapped, so provisioning tools can skip the bootstrap call
+// @Tags setup
+// @Accept json
+// @Produce json
+// @Success 200 {object} handlers.SetupStatus
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /setup/status [get]
+func (h *SetupHandler) GetSetupStatus(c *gin.Context) {
+	bootstrapped, err := h.setupUseCase.IsBootstrapped()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, SetupStatus{Enabled: h.setupUseCase.Enabled(), Bootstrapped: bootstrapped})
+}
+
+// Bootstrap handles POST /api/setup/bootstrap
+// @Summary Bootstrap the deployment
+// @Description Create the first tenant, its admin user and the default policies. Requires the configured setup token and only succeeds once.
+// @Tags setup
+// @Accept json
+// @Produce json
+// @Param X-Setup-Token header string true "Setup token"
+// @Param request body handlers.BootstrapRequest true "First tenant and admin"
+// @Success 201 {object} entities.Bootstrap
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 401 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
+// @Router /setup/bootstrap [post]
+func (h *SetupHandler) Bootstrap(c *gin.Context) {
+	var req BootstrapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bootstrap, err := h.setupUseCase.Bootstrap(c.GetHeader(setupTokenHeader), usecase.BootstrapInput{
+		TenantName:    req.Tenant.Name,
+		TenantSlug:    req.Tenant.Slug,
+		AdminEmail:    req.Admin.Email,
+		AdminName:     req.Admin.Name,
+		AdminPassword: req.Admin.Password,
+	})
+	if err != nil {
+		if e, ok := domainerr.Lookup(err); ok {
+			c.Error(err)
+			c.JSON(e.Status, gin.H{"error": e.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, bootstrap)
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"library-management-system/internal/delivery/http/urlbuilder"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SitemapSubmission is the JSON body of sitemap submissions by URL
+type SitemapSubmission struct {
+	URL       string `json:"url" example:"https://www.byfood.com/sitemap.xml"`
+	Operation string `json:"operation,omitempty"`
+	Profile   string `json:"profile,omitempty" example:"seo"`
+}
+
+// SitemapHandler handles HTTP requests for sitemap canonicalization
+type SitemapHandler struct {
+	sitemapUseCase *usecase.SitemapUseCase
+	links          *urlbuilder.Builder
+}
+
+// NewSitemapHandler creates a new sitemap handler
+func NewSitemapHandler(sitemapUseCase *usecase.SitemapUseCase) *SitemapHandler {
+	return &SitemapHandler{
+		sitemapUseCase: sitemapUseCase,
+	}
+}
+
+// SetLinks makes Location headers absolute URLs built by links
+func (h *SitemapHandler) SetLinks(links *urlbuilder.Builder) {
+	h.links = links
+}
+
+// SubmitSitemap handles POST /api/url/sitemap
+// @Summary Canonicalize a sitemap
+// @Description Start a job processing every entry of a sitemap (or sitemap index, optionally gzipped) with an operation or profile, the seo profile by default. Send JSON with the sitemap URL, or a multipart form with the sitemap as file. The rewritten sitemap is downloaded from the job once it completes.
+// @Tags url
+// @Accept json,mpfd
+// @Produce json
+// @Param request body handlers.SitemapSubmission false "Sitemap to fetch"
+// @Param file formData file false "Sitemap to upload"
+// @Param operation formData string false "Operation applied to every entry"
+// @Param profile formData string false "Profile applied to every entry"
+// @Success 202 {object} entities.SitemapJob
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 503 {object} handlers.ErrorResponse
+// @Router /url/sitemap [post]
+func (h *SitemapHandler) SubmitSitemap(c *gin.Context) {
+	var request usecase.SitemapRequest
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "sitemap file is required"})
+			return
+		}
+		upload, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		defer upload.Close()
+		// One byte over the limit is enough for the use case to refuse it
+		if request.Upload, err = io.ReadAll(io.LimitReader(upload, h.sitemapUseCase.MaxBytes()+1)); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		request.Operation = c.PostForm("operation")
+		request.Profile = c.PostForm("profile")
+	} else {
+		var req SitemapSubmission
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		request = usecase.SitemapRequest{URL: req.URL, Operation: req.Operation, Profile: req.Profile}
+	}
+
+	job, err := h.sitemapUseCase.Submit(c.Request.Context(), &request)
+	if err != nil {
+		if errors.Is(err, domainerr.ErrQueueFull) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := http.StatusAccepted
+	if job.Status == entities.SitemapJobCompleted || job.Status == entities.SitemapJobFailed {
+		status = http.StatusOK
+	}
+	c.Header("Location", h.links.URL(c.FullPath()+"/"+job.ID))
+	c.JSON(status, job)
+}
+
+// GetSitemapJob handles GET /api/url/sitemap/:id
+// @Summary Get a sitemap job
+// @Description Retrieve the status of a sitemap job, with its entry counts and failed entries once it completes
+// @Tags url
+// @Accept json
+// @Produce json
+// @Param id path string true "Sitemap job ID"
+// @Success 200 {object} entities.SitemapJob
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /url/sitemap/{id} [get]
+func (h *SitemapHandler) GetSitemapJob(c *gin.Context) {
+	job, err := h.sitemapUseCase.GetJob(c.Param("id"))
+	if err != nil {
+		if err.Error() == "sitemap job not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// DownloadSitemap handles GET /api/url/sitemap/:id/sitemap.xml
+// @Summary Download a rewritten sitemap
+// @Description Download the sitemap of a completed job, with its entries processed and everything else unchanged
+// @Tags url
+// @Produce xml
+// @Param id path string true "Sitemap job ID"
+// @Success 200 {file} file
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 409 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /url/sitemap/{id}/sitemap.xml [get]
+func (h *SitemapHandler) DownloadSitemap(c *gin.Context) {
+	sitemap, err := h.sitemapUseCase.GetArtifact(c.Param("id"))
+	if err != nil {
+		switch err.Error() {
+		case "sitemap job not found":
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case "sitemap is not ready":
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="sitemap.xml"`)
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", sitemap)
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/repository"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSitemapHandler_UploadAndDownload(t *testing.T) {
+	sitemapUseCase := usecase.NewSitemapUseCase(repository.NewInMemorySitemapJobRepository(10), usecase.NewURLUseCase(newMemoryURLRepository(nil)), 1024, 100)
+	handler := NewSitemapHandler(sitemapUseCase)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/url/sitemap", handler.SubmitSitemap)
+	router.GET("/api/url/sitemap/:id/sitemap.xml", handler.DownloadSitemap)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("file", "sitemap.xml")
+	require.NoError(t, err)
+	file.Write([]byte(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc>https://BYFOOD.com/Tours/?page=2</loc><lastmod>2024-05-01</lastmod></url></urlset>`))
+	require.NoError(t, form.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/url/sitemap", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var job entities.SitemapJob
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &job))
+	assert.Equal(t, "/api/url/sitemap/"+job.ID, w.Header().Get("Location"))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/url/sitemap/"+job.ID+"/sitemap.xml", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="sitemap.xml"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc>https://www.byfood.com/tours</loc><lastmod>2024-05-01</lastmod></url></urlset>`, w.Body.String())
+
+	// Uploads over the size limit are refused
+	body.Reset()
+	form = multipart.NewWriter(&body)
+	file, _ = form.CreateFormFile("file", "sitemap.xml")
+	file.Write(bytes.Repeat([]byte("x"), 2048))
+	form.Close()
+	req = httptest.NewRequest(http.MethodPost, "/api/url/sitemap", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{"error":"sitemap is larger than 1024 bytes"}`, w.Body.String())
+}
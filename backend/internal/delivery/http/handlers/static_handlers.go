@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StaticHandler serves a static frontend build, such as a Next.js export, for the paths no route
+// matched. Pages are looked up as files, as .html files and as directories with an index.html;
+// other paths without a file extension get the root index.html, so client-side routing works.
+type StaticHandler struct {
+	files fs.FS
+	// reserved are the path prefixes of the backend, which the frontend never answers
+	reserved []string
+}
+
+// NewStaticHandler creates a new static handler serving files, leaving the paths under the
+// reserved prefixes to the backend's 404
+func NewStaticHandler(files fs.FS, reserved ...string) *StaticHandler {
+	return &StaticHandler{
+		files:    files,
+		reserved: reserved,
+	}
+}
+
+// Serve handles the requests no route matched
+func (h *StaticHandler) Serve(c *gin.Context) {
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return
+	}
+	urlPath := path.Clean("/" + c.Request.URL.Path)
+	for _, prefix := range h.reserved {
+		if urlPath == prefix || strings.HasPrefix(urlPath, strings.TrimSuffix(prefix, "/")+"/") {
+			return
+		}
+	}
+
+	name := strings.TrimPrefix(urlPath, "/")
+	candidates := []string{name, name + ".html", path.Join(name, "index.html")}
+	if !strings.Contains(path.Base(name), ".") {
+		candidates = append(candidates, "index.html")
+	}
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		info, err := fs.Stat(h.files, candidate)
+		if err != nil || info.IsDir() {
+			continue
+		}
+		// Next.js fingerprints its assets, so only they can be cached for good
+		if strings.HasPrefix(candidate, "_next/static/") {
+			c.Header("Cache-Control", "public, max-age=31536000, immutable")
+		} else {
+			c.Header("Cache-Control", "no-cache")
+		}
+		c.Status(http.StatusOK)
+		http.ServeFileFS(c.Writer, c.Request, h.files, candidate)
+		return
+	}
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticHandler_Serve(t *testing.T) {
+	files := fstest.MapFS{
+		"index.html":              {Data: []byte("home")},
+		"books.html":              {Data: []byte("books")},
+		"about/index.html":        {Data: []byte("about")},
+		"_next/static/app-123.js": {Data: []byte("app")},
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/books", func(c *gin.Context) { c.JSON(http.StatusOK, []string{}) })
+	router.NoRoute(NewStaticHandler(files, "/api", "/readyz").Serve)
+
+	for _, tc := range []struct {
+		path   string
+		status int
+		body   string
+		cache  string
+	}{
+		{"/", http.StatusOK, "home", "no-cache"},
+		{"/books", http.StatusOK, "books", "no-cache"},
+		{"/about/", http.StatusOK, "about", "no-cache"},
+		{"/_next/static/app-123.js", http.StatusOK, "app", "public, max-age=31536000, immutable"},
+		// Client-side routes get the app, missing assets do not
+		{"/books/42", http.StatusOK, "home", "no-cache"},
+		{"/_next/static/missing.js", http.StatusNotFound, "404 page not found", ""},
+		// Backend paths keep their own 404
+		{"/api/missing", http.StatusNotFound, "404 page not found", ""},
+		{"/readyz/missing", http.StatusNotFound, "404 page not found", ""},
+		{"/api/books", http.StatusOK, "[]", ""},
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		assert.Equal(t, tc.status, w.Code, tc.path)
+		assert.Equal(t, tc.body, w.Body.String(), tc.path)
+		assert.Equal(t, tc.cache, w.Header().Get("Cache-Control"), tc.path)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/books", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code, "only pages are read")
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatsHandler handles HTTP requests for the catalog and circulation stats
+type StatsHandler struct {
+	statsUseCase *usecase.StatsUseCase
+}
+
+// NewStatsHandler creates a new stats handler
+func NewStatsHandler(statsUseCase *usecase.StatsUseCase) *StatsHandler {
+	return &StatsHandler{
+		statsUseCase: statsUseCase,
+	}
+}
+
+// GetStats handles GET /api/stats
+// @Summary Get library stats
+// @Description Retrieve the books per publication year, the loans per month and the most borrowed authors. They are read from tables the stats_refresh job recomputes, not live: refreshed_at tells when the least recently refreshed table was refreshed, also sent as Last-Modified, and stale is set when that is longer ago than STATS_MAX_AGE or a table was never refreshed.
+// @Tags stats
+// @Accept json
+// @Produce json
+// @Success 200 {object} entities.LibraryStats
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /stats [get]
+func (h *StatsHandler) GetStats(c *gin.Context) {
+	stats, err := h.statsUseCase.Stats()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if stats.RefreshedAt != nil {
+		c.Header("Last-Modified", stats.RefreshedAt.UTC().Format(http.TimeFormat))
+	}
+	c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StorageHandler handles HTTP requests about the disk usage of the library
+type StorageHandler struct {
+	storageUseCase *usecase.StorageUseCase
+}
+
+// NewStorageHandler creates a new storage handler
+func NewStorageHandler(storageUseCase *usecase.StorageUseCase) *StorageHandler {
+	return &StorageHandler{
+		storageUseCase: storageUseCase,
+	}
+}
+
+// GetStorage handles GET /api/admin/storage
+// @Summary Get storage usage
+// @Description Measure the disk space used by the database and the configured directories against their soft quotas. An area that cannot be measured reports why in error.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} entities.StorageReport
+// @Router /admin/storage [get]
+func (h *StorageHandler) GetStorage(c *gin.Context) {
+	c.JSON(http.StatusOK, h.storageUseCase.Report(c.Request.Context()))
+}
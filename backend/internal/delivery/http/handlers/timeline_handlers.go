@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimelineHandler handles HTTP requests for book activity streams
+type TimelineHandler struct {
+	timelineUseCase *usecase.TimelineUseCase
+}
+
+// NewTimelineHandler creates a new timeline handler
+func NewTimelineHandler(timelineUseCase *usecase.TimelineUseCase) *TimelineHandler {
+	return &TimelineHandler{
+		timelineUseCase: timelineUseCase,
+	}
+}
+
+// GetTimeline handles GET /api/books/:id/timeline
+// @Summary Get a book's timeline
+// @Description Retrieve the book's activity stream (audit history and loans) newest first
+// @Tags books
+// @Accept json
+// @Produce json
+// @Param id path string true "Book ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(20)
+// @Success 200 {object} entities.TimelinePage
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /books/{id}/timeline [get]
+func (h *TimelineHandler) GetTimeline(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page"})
+		return
+	}
+	pageSize, err := middleware.PageSize(c, "page_size")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page_size"})
+		return
+	}
+
+	timeline, err := h.timelineUseCase.GetTimeline(c.Param("id"), page, pageSize)
+	if err != nil {
+		if err.Error() == "book not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, timeline)
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimezoneHandler handles HTTP requests for the timezones of tenants and branches
+type TimezoneHandler struct {
+	timezoneUseCase *usecase.TimezoneUseCase
+}
+
+// NewTimezoneHandler creates a new timezone handler
+func NewTimezoneHandler(timezoneUseCase *usecase.TimezoneUseCase) *TimezoneHandler {
+	return &TimezoneHandler{
+		timezoneUseCase: timezoneUseCase,
+	}
+}
+
+// SetTimezoneRequest represents the request body for setting a timezone
+type SetTimezoneRequest struct {
+	// IANA name of the timezone; empty to clear it
+	// example: Asia/Tokyo
+	Timezone string `json:"timezone"`
+}
+
+// GetTimezone handles GET /api/admin/timezone
+// @Summary Get the timezone of a tenant or a branch
+// @Description Retrieve the timezone due dates, stats and report schedules are worked out in: that of the branch with branch_id, else that of the tenant, else the default CIRCULATION_TIMEZONE, with where it comes from.
+// @Tags admin
+// @Produce json
+// @Param X-Tenant-ID header string false "Tenant ID; required without branch_id"
+// @Param branch_id query string false "Branch ID"
+// @Success 200 {object} entities.Timezone
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /admin/timezone [get]
+func (h *TimezoneHandler) GetTimezone(c *gin.Context) {
+	timezone, err := h.timezoneUseCase.Timezone(c.GetHeader(middleware.TenantHeader), c.Query("branch_id"))
+	if err != nil {
+		h.timezoneError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, timezone)
+}
+
+// SetTimezone handles PUT /api/admin/timezone
+// @Summary Set the timezone of a tenant or a branch
+// @Description Set the timezone of the tenant, or with branch_id of a branch, by its IANA name. An empty timezone leaves a branch in the timezone of the tenant, and the tenant in the default one. Loans already made keep their due dates.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Tenant-ID header string false "Tenant ID; required without branch_id"
+// @Param branch_id query string false "Branch ID"
+// @Param timezone body SetTimezoneRequest true "Timezone"
+// @Success 200 {object} entities.Timezone
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /admin/timezone [put]
+func (h *TimezoneHandler) SetTimezone(c *gin.Context) {
+	var req SetTimezoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	timezone, err := h.timezoneUseCase.SetTimezone(c.GetHeader(middleware.TenantHeader), c.Query("branch_id"), req.Timezone)
+	if err != nil {
+		h.timezoneError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, timezone)
+}
+
+// timezoneError writes a timezone error: 404 for missing tenants and branches, 400 for anything else
+func (h *TimezoneHandler) timezoneError(c *gin.Context, err error) {
+	status := http.StatusBadRequest
+	if errors.Is(err, domainerr.ErrTenantNotFound) || errors.Is(err, domainerr.ErrBranchNotFound) {
+		status = http.StatusNotFound
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/delivery/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// URLGuardHandler handles HTTP requests about the abuse protection of the URL processor
+type URLGuardHandler struct {
+	guard *middleware.URLGuard
+}
+
+// NewURLGuardHandler creates a new URL guard handler
+func NewURLGuardHandler(guard *middleware.URLGuard) *URLGuardHandler {
+	return &URLGuardHandler{
+		guard: guard,
+	}
+}
+
+// GetURLGuard handles GET /api/admin/url-guard
+// @Summary Get the URL processor's abuse protection
+// @Description Report the per-IP rate limit and token requirement of /url endpoints, and how many requests they rejected by error code since the server started
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} middleware.URLGuardStats
+// @Router /admin/url-guard [get]
+func (h *URLGuardHandler) GetURLGuard(c *gin.Context) {
+	c.JSON(http.StatusOK, h.guard.Stats())
+}
@@ -1,8 +1,14 @@
 package handlers
 
 import (
+	"encoding/csv"
+	"errors"
+	"log"
 	"net/http"
+	"strconv"
+	"time"
 
+	"library-management-system/internal/delivery/http/middleware"
 	"library-management-system/internal/domain/entities"
 	"library-management-system/internal/usecase"
 
@@ -23,12 +29,13 @@ func NewURLHandler(urlUseCase *usecase.URLUseCase) *URLHandler {
 
 // ProcessURL handles POST /api/url/process
 // @Summary Process URL
-// @Description Process a URL according to the specified operation (canonical, redirection, or all)
+// @Description Process a URL according to the specified operation (canonical, redirection, all, strip_tracking, strip_fragment, https or sort_query), or the operations of a profile, or else the default operation of the server when it has one. URLs without a scheme are taken for https ones, or rejected in strict mode. options tune canonical processing, with default ports, duplicate slashes, dot-segments and the root path, and how lowercasing treats percent-encoded characters of paths
 // @Tags url
 // @Accept json
 // @Produce json
 // @Param request body entities.URLRequest true "URL processing request"
 // @Success 200 {object} entities.URLResponse
+// @Header 200 {string} X-Cache "HIT when the result came from the cache, MISS otherwise; absent without a cache"
 // @Failure 400 {object} handlers.ErrorResponse
 // @Failure 500 {object} handlers.ErrorResponse
 // @Router /url/process [post]
@@ -45,5 +52,214 @@ func (h *URLHandler) ProcessURL(c *gin.Context) {
 		return
 	}
 
+	if h.urlUseCase.CacheStats().Enabled {
+		if response.Cached {
+			c.Header("X-Cache", "HIT")
+		} else {
+			c.Header("X-Cache", "MISS")
+		}
+	}
+
 	c.JSON(http.StatusOK, response)
 }
+
+// ProcessURLsRequest represents the request body for processing URLs in a batch
+type ProcessURLsRequest struct {
+	Requests []entities.URLRequest `json:"requests" binding:"required,min=1,max=100"`
+}
+
+// URLBatchItem reports what became of a URL of a batch: 200 with the processed URL, 400 when it
+// failed, and 424 when dropped by an atomic batch
+type URLBatchItem struct {
+	BatchItem
+	ProcessedURL string `json:"processed_url,omitempty"`
+	Cached       bool   `json:"cached,omitempty"`
+}
+
+// URLBatchResponse is the multi-status result of a batch of URLs
+type URLBatchResponse struct {
+	BatchResponse
+	Items []URLBatchItem `json:"items"`
+}
+
+// ProcessURLs handles POST /api/url/batch
+// @Summary Process URLs in a batch
+// @Description Process up to 100 URLs as /url/process does, each with its own operation, profile and options. Returns 200 when every URL was processed, else 207 Multi-Status with the status of each; with atomic=true a URL failing drops the others, which are neither returned nor recorded in the history. The batch counts once against the rate limit.
+// @Tags url
+// @Accept json
+// @Produce json
+// @Param request body ProcessURLsRequest true "URL processing requests"
+// @Param atomic query bool false "Return none of the URLs unless all are processed"
+// @Success 200 {object} handlers.URLBatchResponse
+// @Success 207 {object} handlers.URLBatchResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Router /url/batch [post]
+func (h *URLHandler) ProcessURLs(c *gin.Context) {
+	var req ProcessURLsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	atomic, err := atomicBatch(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	results := h.urlUseCase.ProcessURLs(req.Requests, atomic)
+	responses := make([]URLBatchItem, len(results))
+	items := make([]BatchItem, len(results))
+	for i, result := range results {
+		switch {
+		case result.Err != nil:
+			items[i] = batchItemError(result.Index, result.Err.Error(), http.StatusBadRequest)
+		case result.Aborted:
+			items[i] = batchItemAborted(result.Index)
+		default:
+			items[i] = BatchItem{Index: result.Index, Status: http.StatusOK}
+			responses[i].ProcessedURL, responses[i].Cached = result.Response.ProcessedURL, result.Response.Cached
+		}
+		responses[i].BatchItem = items[i]
+	}
+
+	response := URLBatchResponse{BatchResponse: newBatchResponse(atomic, items), Items: responses}
+	c.JSON(response.httpStatus(), response)
+}
+
+// ListProfiles handles GET /api/url/profiles
+// @Summary List URL profiles
+// @Description List the named bundles of operations that can be given as profile to URL processing
+// @Tags url
+// @Accept json
+// @Produce json
+// @Success 200 {array} usecase.URLProfile
+// @Router /url/profiles [get]
+func (h *URLHandler) ListProfiles(c *gin.Context) {
+	c.JSON(http.StatusOK, h.urlUseCase.ListProfiles())
+}
+
+// GetCacheStats handles GET /api/admin/url-cache
+// @Summary Get the URL cache statistics
+// @Description Report the backend and TTL of the cache of processed URLs, and its hits, misses and errors since the server started
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} usecase.URLCacheStats
+// @Router /admin/url-cache [get]
+func (h *URLHandler) GetCacheStats(c *gin.Context) {
+	c.JSON(http.StatusOK, h.urlUseCase.CacheStats())
+}
+
+// urlHistoryCSVHeader lists the columns of the URL history export
+var urlHistoryCSVHeader = []string{"id", "created_at", "url", "host", "operations", "profile", "processed_url", "cached"}
+
+// GetURLHistory handles GET /api/admin/url-history
+// @Summary List processed URLs
+// @Description Retrieve the latest URLs processed, newest first, with the operations applied and whether the result came from the cache, filtered by domain, including subdomains, by operation and by UTC dates. When there are more, X-Next-Cursor holds the cursor of the next page, which only continues the listing of the same filters. format=csv exports every matching URL.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Param domain query string false "Host of the URLs, e.g. byfood.com, matching its subdomains too"
+// @Param operation query string false "Operation applied, alone or within a profile, e.g. canonical"
+// @Param from query string false "First day, e.g. 2024-01-01"
+// @Param to query string false "Last day, e.g. 2024-01-31; today by default"
+// @Param limit query int false "Number of processed URLs" default(50)
+// @Param cursor query string false "Cursor of the page, from X-Next-Cursor"
+// @Param format query string false "json (default) or csv"
+// @Success 200 {array} entities.URLResult
+// @Header 200 {string} X-Next-Cursor "Cursor of the next page; absent on the last page"
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/url-history [get]
+func (h *URLHandler) GetURLHistory(c *gin.Context) {
+	query := usecase.URLHistoryQuery{
+		Domain:    c.Query("domain"),
+		Operation: c.Query("operation"),
+		From:      c.Query("from"),
+		To:        c.Query("to"),
+	}
+
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+	case "csv":
+		h.writeURLHistoryCSV(c, query)
+		return
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or csv"})
+		return
+	}
+
+	limit, err := middleware.PageSize(c, "limit")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+		return
+	}
+
+	results, next, err := h.urlUseCase.History(query, limit, c.Query("cursor"))
+	if err != nil {
+		urlHistoryError(c, err)
+		return
+	}
+	if results == nil {
+		results = []entities.URLResult{}
+	}
+	setNextCursor(c, next)
+
+	c.JSON(http.StatusOK, results)
+}
+
+// writeURLHistoryCSV exports every processed URL matching a query as a CSV attachment, written
+// as it is read
+func (h *URLHandler) writeURLHistoryCSV(c *gin.Context, query usecase.URLHistoryQuery) {
+	if err := h.urlUseCase.CheckHistoryQuery(query); err != nil {
+		urlHistoryError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="url-history.csv"`)
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	err := writer.Write(urlHistoryCSVHeader)
+	if err == nil {
+		err = h.urlUseCase.ExportHistory(query, func(results []entities.URLResult) error {
+			for _, result := range results {
+				row := []string{
+					result.ID,
+					result.CreatedAt.UTC().Format(time.RFC3339),
+					result.URL,
+					result.Host,
+					result.Operations,
+					result.Profile,
+					result.ProcessedURL,
+					strconv.FormatBool(result.Cached),
+				}
+				if err := writer.Write(row); err != nil {
+					return err
+				}
+			}
+			writer.Flush()
+			return writer.Error()
+		})
+	}
+	writer.Flush()
+	if err == nil {
+		err = writer.Error()
+	}
+	if err != nil {
+		// Headers are already sent, so the client only sees a truncated file
+		log.Printf("Failed to write URL history: %v", err)
+	}
+}
+
+// urlHistoryError writes the error of a history listing: 400 for an unknown operation or invalid
+// dates, and as listings do otherwise
+func urlHistoryError(c *gin.Context, err error) {
+	if errors.Is(err, usecase.ErrUnknownOperation) || errors.Is(err, usecase.ErrInvalidDateRange) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	listingError(c, err)
+}
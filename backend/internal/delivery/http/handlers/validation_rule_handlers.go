@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"library-management-system/internal/delivery/http/middleware"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ValidationRuleHandler handles HTTP requests for the validation rules admins add for books
+type ValidationRuleHandler struct {
+	ruleUseCase *usecase.ValidationRuleUseCase
+}
+
+// NewValidationRuleHandler creates a new validation rule handler
+func NewValidationRuleHandler(ruleUseCase *usecase.ValidationRuleUseCase) *ValidationRuleHandler {
+	return &ValidationRuleHandler{
+		ruleUseCase: ruleUseCase,
+	}
+}
+
+// ValidationRuleRequest represents the request body for creating or replacing a validation rule
+type ValidationRuleRequest struct {
+	// example: Publisher ISBN range
+	Name string `json:"name" binding:"required"`
+	// isbn_prefix, required_metadata or year_range
+	// example: isbn_prefix
+	Kind string `json:"kind" binding:"required"`
+	// Limits the rule to books whose category metadata equals it
+	// example: comics
+	Category string `json:"category"`
+	// Regular expression the start of the ISBN must match, for isbn_prefix rules
+	// example: 97[89]
+	Pattern string `json:"pattern"`
+	// Metadata key that must be set, for required_metadata rules
+	MetadataKey string `json:"metadata_key"`
+	// Inclusive year bounds, for year_range rules
+	MinYear *int `json:"min_year"`
+	MaxYear *int `json:"max_year"`
+	// Replaces the default error message
+	Message string `json:"message"`
+	// Defaults to true
+	Enabled *bool `json:"enabled"`
+}
+
+// rule maps the request to a validation rule entity
+func (r ValidationRuleRequest) rule() *entities.ValidationRule {
+	enabled := true
+	if r.Enabled != nil {
+		enabled = *r.Enabled
+	}
+	return &entities.ValidationRule{
+		Name:        r.Name,
+		Kind:        r.Kind,
+		Category:    r.Category,
+		Pattern:     r.Pattern,
+		MetadataKey: r.MetadataKey,
+		MinYear:     r.MinYear,
+		MaxYear:     r.MaxYear,
+		Message:     r.Message,
+		Enabled:     enabled,
+	}
+}
+
+// ValidationRuleTestRequest represents a book payload to test against the validation rules;
+// unlike a book creation, every field is optional
+type ValidationRuleTestRequest struct {
+	Title    string                 `json:"title"`
+	Author   string                 `json:"author"`
+	Year     int                    `json:"year"`
+	ISBN     string                 `json:"isbn"`
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+// ValidationRuleTestResponse lists the validation rules a payload fails
+type ValidationRuleTestResponse struct {
+	Valid      bool                    `json:"valid"`
+	Violations []usecase.RuleViolation `json:"violations"`
+}
+
+// GetValidationRules handles GET /api/admin/validation-rules
+// @Summary List validation rules
+// @Description Retrieve all validation rules, oldest first
+// @Tags validation
+// @Accept json
+// @Produce json
+// @Success 200 {array} entities.ValidationRule
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/validation-rules [get]
+func (h *ValidationRuleHandler) GetValidationRules(c *gin.Context) {
+	rules, err := h.ruleUseCase.ListRules()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if rules == nil {
+		rules = []entities.ValidationRule{}
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// CreateValidationRule handles POST /api/admin/validation-rules
+// @Summary Add a validation rule
+// @Description Add a check that books must pass when they are created or updated
+// @Tags validation
+// @Accept json
+// @Produce json
+// @Param rule body ValidationRuleRequest true "Validation rule"
+// @Success 201 {object} entities.ValidationRule
+// @Failure 400 {object} handlers.ErrorResponse
+// @Router /admin/validation-rules [post]
+func (h *ValidationRuleHandler) CreateValidationRule(c *gin.Context) {
+	var req ValidationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule := req.rule()
+	if err := h.ruleUseCase.CreateRule(rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// GetValidationRule handles GET /api/admin/validation-rules/:id
+// @Summary Get a validation rule
+// @Description Retrieve a validation rule by ID
+// @Tags validation
+// @Accept json
+// @Produce json
+// @Param id path string true "Validation rule ID"
+// @Success 200 {object} entities.ValidationRule
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/validation-rules/{id} [get]
+func (h *ValidationRuleHandler) GetValidationRule(c *gin.Context) {
+	rule, err := h.ruleUseCase.GetRule(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, domainerr.ErrValidationRuleNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// UpdateValidationRule handles PUT /api/admin/validation-rules/:id
+// @Summary Update a validation rule
+// @Description Replace the check of a validation rule, or enable or disable it
+// @Tags validation
+// @Accept json
+// @Produce json
+// @Param id path string true "Validation rule ID"
+// @Param rule body ValidationRuleRequest true "Validation rule"
+// @Success 200 {object} entities.ValidationRule
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /admin/validation-rules/{id} [put]
+func (h *ValidationRuleHandler) UpdateValidationRule(c *gin.Context) {
+	var req ValidationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.ruleUseCase.UpdateRule(c.Param("id"), req.rule())
+	if err != nil {
+		if errors.Is(err, domainerr.ErrValidationRuleNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeleteValidationRule handles DELETE /api/admin/validation-rules/:id
+// @Summary Delete a validation rule
+// @Description Remove a validation rule; books already saved are not revalidated
+// @Tags validation
+// @Accept json
+// @Produce json
+// @Param id path string true "Validation rule ID"
+// @Success 200 {object} handlers.MessageResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/validation-rules/{id} [delete]
+func (h *ValidationRuleHandler) DeleteValidationRule(c *gin.Context) {
+	if err := h.ruleUseCase.DeleteRule(c.Param("id")); err != nil {
+		if errors.Is(err, domainerr.ErrValidationRuleNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "validation rule deleted successfully"})
+}
+
+// TestValidationRules handles POST /api/admin/validation-rules/test
+// @Summary Test a book against the validation rules
+// @Description Evaluate the enabled validation rules against a book payload without saving it, listing every rule it fails. The built-in checks of books are not included unless profile=true, which reports the first check of the validation profile of the tenant the book fails as a violation of validation_profile.
+// @Tags validation
+// @Accept json
+// @Produce json
+// @Param book body ValidationRuleTestRequest true "Book payload"
+// @Param profile query bool false "Include the built-in checks of the validation profile"
+// @Param X-Tenant-ID header string false "Tenant whose validation profile is checked"
+// @Success 200 {object} ValidationRuleTestResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /admin/validation-rules/test [post]
+func (h *ValidationRuleHandler) TestValidationRules(c *gin.Context) {
+	var req ValidationRuleTestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	book := &entities.Book{
+		Title:    req.Title,
+		Author:   req.Author,
+		Year:     req.Year,
+		ISBN:     req.ISBN,
+		Metadata: req.Metadata,
+	}
+	var violations []usecase.RuleViolation
+	var err error
+	if c.Query("profile") == "true" {
+		violations, err = h.ruleUseCase.TestBookWithProfile(c.GetHeader(middleware.TenantHeader), book)
+	} else {
+		violations, err = h.ruleUseCase.TestBook(book)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, ValidationRuleTestResponse{Valid: len(violations) == 0, Violations: violations})
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WorkHandler handles HTTP requests for works and their editions
+type WorkHandler struct {
+	workUseCase *usecase.WorkUseCase
+}
+
+// NewWorkHandler creates a new work handler
+func NewWorkHandler(workUseCase *usecase.WorkUseCase) *WorkHandler {
+	return &WorkHandler{
+		workUseCase: workUseCase,
+	}
+}
+
+// CreateWorkRequest represents the request body for creating a work
+type CreateWorkRequest struct {
+	// Defaults to the title of the first edition
+	Title string `json:"title"`
+	// Defaults to the author of the first edition
+	Author  string   `json:"author"`
+	BookIDs []string `json:"book_ids"`
+}
+
+// GroupEditionsRequest represents the request body for adding editions to a work
+type GroupEditionsRequest struct {
+	BookIDs []string `json:"book_ids" binding:"required"`
+}
+
+// WorkResponse represents a work with its editions
+// swagger:model WorkResponse
+type WorkResponse struct {
+	entities.Work
+	Editions []BookResponse `json:"editions"`
+}
+
+// GetWorks handles GET /api/works
+// @Summary Get all works
+// @Description Retrieve all works ordered by title
+// @Tags works
+// @Accept json
+// @Produce json
+// @Success 200 {array} entities.Work
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /works [get]
+func (h *WorkHandler) GetWorks(c *gin.Context) {
+	works, err := h.workUseCase.ListWorks()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, works)
+}
+
+// CreateWork handles POST /api/works
+// @Summary Create a work
+// @Description Create a work, grouping the given books under it as editions
+// @Tags works
+// @Accept json
+// @Produce json
+// @Param work body CreateWorkRequest true "Work information"
+// @Success 201 {object} handlers.WorkResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Router /works [post]
+func (h *WorkHandler) CreateWork(c *gin.Context) {
+	var req CreateWorkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	work := &entities.Work{
+		Title:  req.Title,
+		Author: req.Author,
+	}
+
+	if err := h.workUseCase.CreateWork(work, req.BookIDs); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondWithWork(c, http.StatusCreated, work.ID)
+}
+
+// GetWork handles GET /api/works/:id
+// @Summary Get a work by ID
+// @Description Retrieve a work with its editions, oldest first
+// @Tags works
+// @Accept json
+// @Produce json
+// @Param id path string true "Work ID"
+// @Success 200 {object} handlers.WorkResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Failure 500 {object} handlers.ErrorResponse
+// @Router /works/{id} [get]
+func (h *WorkHandler) GetWork(c *gin.Context) {
+	h.respondWithWork(c, http.StatusOK, c.Param("id"))
+}
+
+// GroupEditions handles POST /api/works/:id/editions
+// @Summary Group editions
+// @Description Add books to a work as editions; books of another work are moved over
+// @Tags works
+// @Accept json
+// @Produce json
+// @Param id path string true "Work ID"
+// @Param editions body GroupEditionsRequest true "Books to group"
+// @Success 200 {object} handlers.WorkResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /works/{id}/editions [post]
+func (h *WorkHandler) GroupEditions(c *gin.Context) {
+	var req GroupEditionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.workUseCase.GroupEditions(c.Param("id"), req.BookIDs); err != nil {
+		if err.Error() == "work not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondWithWork(c, http.StatusOK, c.Param("id"))
+}
+
+// UngroupEdition handles DELETE /api/works/:id/editions/:bookId
+// @Summary Ungroup an edition
+// @Description Remove a book from a work
+// @Tags works
+// @Accept json
+// @Produce json
+// @Param id path string true "Work ID"
+// @Param bookId path string true "Book ID"
+// @Success 200 {object} handlers.WorkResponse
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /works/{id}/editions/{bookId} [delete]
+func (h *WorkHandler) UngroupEdition(c *gin.Context) {
+	if err := h.workUseCase.UngroupEdition(c.Param("id"), c.Param("bookId")); err != nil {
+		if err.Error() == "work not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondWithWork(c, http.StatusOK, c.Param("id"))
+}
+
+// respondWithWork writes a work and its editions
+func (h *WorkHandler) respondWithWork(c *gin.Context, status int, id string) {
+	work, editions, err := h.workUseCase.GetWork(id)
+	if err != nil {
+		if err.Error() == "work not found" {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, WorkResponse{Work: *work, Editions: newBookResponses(editions, bookView{})})
+}
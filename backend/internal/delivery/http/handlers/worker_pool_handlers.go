@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WorkerPool is a pool of background workers that can be resized while it runs
+type WorkerPool interface {
+	Workers() int
+	Queued() int
+	Capacity() int
+	Rejected() int64
+	Priorities() []entities.JobPriorityStats
+	Resize(workers int) error
+}
+
+// namedWorkerPool is a worker pool exposed under a name
+type namedWorkerPool struct {
+	name        string
+	description string
+	pool        WorkerPool
+}
+
+// state reports the current size, backlog and rejections of the pool, overall and by priority
+func (p namedWorkerPool) state() entities.WorkerPool {
+	return entities.WorkerPool{
+		Name:        p.name,
+		Description: p.description,
+		Workers:     p.pool.Workers(),
+		Queued:      p.pool.Queued(),
+		Capacity:    p.pool.Capacity(),
+		Rejected:    p.pool.Rejected(),
+		Priorities:  p.pool.Priorities(),
+	}
+}
+
+// WorkerPoolHandler handles HTTP requests to inspect and resize the background worker pools
+type WorkerPoolHandler struct {
+	pools []namedWorkerPool
+}
+
+// NewWorkerPoolHandler creates a new worker pool handler
+func NewWorkerPoolHandler() *WorkerPoolHandler {
+	return &WorkerPoolHandler{}
+}
+
+// AddPool exposes a worker pool under a name
+func (h *WorkerPoolHandler) AddPool(name, description string, pool WorkerPool) {
+	h.pools = append(h.pools, namedWorkerPool{name: name, description: description, pool: pool})
+}
+
+// ResizeWorkerPoolRequest represents the request body for resizing a worker pool
+type ResizeWorkerPoolRequest struct {
+	// example: 8
+	Workers int `json:"workers" binding:"required,min=1,max=256"`
+}
+
+// GetWorkerPools handles GET /api/admin/worker-pools
+// @Summary List worker pools
+// @Description Retrieve the background worker pools with their size and backlog, overall and by job priority
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {array} entities.WorkerPool
+// @Router /admin/worker-pools [get]
+func (h *WorkerPoolHandler) GetWorkerPools(c *gin.Context) {
+	pools := make([]entities.WorkerPool, 0, len(h.pools))
+	for _, pool := range h.pools {
+		pools = append(pools, pool.state())
+	}
+
+	c.JSON(http.StatusOK, pools)
+}
+
+// ResizeWorkerPool handles PUT /api/admin/worker-pools/:name
+// @Summary Resize a worker pool
+// @Description Change the number of workers of a pool without a restart; no job is dropped and the size goes back to the configured one on restart
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param name path string true "Pool name"
+// @Param pool body ResizeWorkerPoolRequest true "New size"
+// @Success 200 {object} entities.WorkerPool
+// @Failure 400 {object} handlers.ErrorResponse
+// @Failure 404 {object} handlers.ErrorResponse
+// @Router /admin/worker-pools/{name} [put]
+func (h *WorkerPoolHandler) ResizeWorkerPool(c *gin.Context) {
+	var req ResizeWorkerPoolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	for _, pool := range h.pools {
+		if pool.name != c.Param("name") {
+			continue
+		}
+		if err := pool.pool.Resize(req.Workers); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, pool.state())
+		return
+	}
+
+	c.JSON(http.StatusNotFound, gin.H{"error": "worker pool not found"})
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessTokenKey is the context key of the access token a request signed in with
+const AccessTokenKey = "access_token"
+
+// UserKey is the context key of the user a request signed in as, with a session or access token
+const UserKey = "user"
+
+// bearerPrefix starts the Authorization header of requests signing in with an access token
+const bearerPrefix = "Bearer "
+
+// RequiredScope returns the scope an access token needs for a request to a route under the API
+// base path: books:read or books:write for books and their copies, by method, url:process for the
+// URL processor, and admin:* for any other route
+func RequiredScope(basePath, method, route string) string {
+	route = strings.TrimPrefix(route, basePath)
+	switch {
+	case hasRoutePrefix(route, "/books"), hasRoutePrefix(route, "/copies"):
+		if method == http.MethodGet || method == http.MethodHead {
+			return entities.ScopeBooksRead
+		}
+		return entities.ScopeBooksWrite
+	case hasRoutePrefix(route, "/url"):
+		return entities.ScopeURLProcess
+	}
+	return entities.ScopeAdmin
+}
+
+// hasRoutePrefix reports whether a route is prefix or a route under it
+func hasRoutePrefix(route, prefix string) bool {
+	return route == prefix || strings.HasPrefix(route, prefix+"/")
+}
+
+// AccessTokens signs in the requests presenting a personal access token as a bearer token and
+// lets them reach the routes of its scopes only. The user of the token replaces the identity
+// headers the request sent, its branch included, so handlers see it as the caller. Requests without a bearer token,
+// or already signed in with a session, pass through untouched.
+func AccessTokens(tokenUseCase *usecase.AccessTokenUseCase, basePath string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authorization := c.GetHeader("Authorization")
+		if _, signedIn := c.Get(SessionKey); signedIn || !strings.HasPrefix(authorization, bearerPrefix) {
+			c.Next()
+			return
+		}
+
+		token, user, err := tokenUseCase.Authenticate(strings.TrimSpace(strings.TrimPrefix(authorization, bearerPrefix)))
+		if errors.Is(err, domainerr.ErrInvalidAccessToken) {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			c.Error(domainerr.ErrInvalidAccessToken)
+			c.AbortWithStatusJSON(domainerr.ErrInvalidAccessToken.Status, gin.H{"error": domainerr.ErrInvalidAccessToken.Error()})
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to authenticate access token: %v", err)
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "access tokens cannot be checked, retry later"})
+			return
+		}
+
+		scope := RequiredScope(basePath, c.Request.Method, c.FullPath())
+		if !token.Allows(scope) {
+			c.Header("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+			c.Error(domainerr.ErrInsufficientScope)
+			c.AbortWithStatusJSON(domainerr.ErrInsufficientScope.Status, gin.H{"error": domainerr.ErrInsufficientScope.Error()})
+			return
+		}
+
+		setIdentity(c, user)
+		c.Set(AccessTokenKey, token)
+		c.Next()
+	}
+}
+
+// setIdentity replaces the identity headers of a request with those of the user it signed in as,
+// so a client cannot claim another role, tenant or branch. The branch header is removed for users
+// working for no single branch.
+func setIdentity(c *gin.Context, user *entities.User) {
+	c.Request.Header.Set(UserIDHeader, user.ID)
+	c.Request.Header.Set(RoleHeader, user.Role)
+	c.Request.Header.Set(TenantHeader, user.TenantID)
+	c.Set(UserKey, user)
+	if user.BranchID != nil {
+		c.Request.Header.Set(BranchHeader, *user.BranchID)
+	} else {
+		c.Request.Header.Del(BranchHeader)
+	}
+}
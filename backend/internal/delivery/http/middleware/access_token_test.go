@@ -0,0 +1,123 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredScope(t *testing.T) {
+	tests := []struct {
+		method, route string
+		expected      string
+	}{
+		{method: http.MethodGet, route: "/api/books/:id", expected: "books:read"},
+		{method: http.MethodHead, route: "/api/books", expected: "books:read"},
+		{method: http.MethodPost, route: "/api/books", expected: "books:write"},
+		{method: http.MethodDelete, route: "/api/books/:id/copies/:copyId", expected: "books:write"},
+		{method: http.MethodGet, route: "/api/copies/:accessionNumber", expected: "books:read"},
+		{method: http.MethodPost, route: "/api/url/process", expected: "url:process"},
+		{method: http.MethodGet, route: "/api/admin/storage", expected: "admin:*"},
+		{method: http.MethodGet, route: "/api/bookshelves", expected: "admin:*"},
+		{method: http.MethodGet, route: "/api/me/tokens", expected: "admin:*"},
+		{method: http.MethodGet, route: "", expected: "admin:*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.route, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RequiredScope("/api", tt.method, tt.route))
+		})
+	}
+}
+
+// stubUsers looks users up by ID
+type stubUsers map[string]*entities.User
+
+func (u stubUsers) FindByEmail(email string) (*entities.User, error) { return nil, nil }
+func (u stubUsers) GetByID(id string) (*entities.User, error)        { return u[id], nil }
+func (u stubUsers) Update(user *entities.User) error                 { return nil }
+func (u stubUsers) ListDeactivatedBefore(at time.Time) ([]entities.User, error) {
+	return nil, nil
+}
+
+// stubAccessTokens keeps access tokens in memory
+type stubAccessTokens struct {
+	tokens []*entities.AccessToken
+}
+
+func (r *stubAccessTokens) Create(token *entities.AccessToken) error {
+	token.ID = "token-" + token.Name
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *stubAccessTokens) GetByID(id string) (*entities.AccessToken, error) {
+	for _, token := range r.tokens {
+		if token.ID == id {
+			return token, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *stubAccessTokens) FindByHash(hash string) (*entities.AccessToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == hash {
+			return token, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *stubAccessTokens) ListByUser(userID string) ([]entities.AccessToken, error) {
+	return nil, nil
+}
+
+func (r *stubAccessTokens) Update(token *entities.AccessToken) error {
+	return nil
+}
+
+func TestAccessTokens_BranchComesFromTheUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	branch := "branch-1"
+	users := stubUsers{
+		"librarian-1": {ID: "librarian-1", TenantID: "tenant-1", Role: entities.UserRoleLibrarian, BranchID: &branch},
+		"librarian-2": {ID: "librarian-2", TenantID: "tenant-1", Role: entities.UserRoleLibrarian},
+	}
+	tokenUseCase := usecase.NewAccessTokenUseCase(&stubAccessTokens{}, users, 24*time.Hour, 0)
+	_, branchSecret, err := tokenUseCase.Create("librarian-1", usecase.AccessTokenRequest{Name: "branch", Scopes: []string{entities.ScopeBooksWrite}})
+	require.NoError(t, err)
+	_, librarySecret, err := tokenUseCase.Create("librarian-2", usecase.AccessTokenRequest{Name: "library", Scopes: []string{entities.ScopeBooksWrite}})
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(AccessTokens(tokenUseCase, "/api"))
+	router.PUT("/api/books/:id", func(c *gin.Context) {
+		c.String(http.StatusOK, "%s|%s|%s", c.GetHeader(RoleHeader), c.GetHeader(TenantHeader), c.GetHeader(BranchHeader))
+	})
+	put := func(secret string, headers map[string]string) string {
+		req := httptest.NewRequest(http.MethodPut, "/api/books/book-1", nil)
+		req.Header.Set("Authorization", "Bearer "+secret)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		return w.Body.String()
+	}
+
+	// Leaving the branch out, or naming another, does not lift the restriction to the user's branch
+	assert.Equal(t, "librarian|tenant-1|branch-1", put(branchSecret, nil))
+	assert.Equal(t, "librarian|tenant-1|branch-1", put(branchSecret, map[string]string{BranchHeader: "branch-2", RoleHeader: entities.UserRoleAdmin}))
+	// Nor can staff of the whole library claim a branch
+	assert.Equal(t, "librarian|tenant-1|", put(librarySecret, map[string]string{BranchHeader: "branch-2"}))
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminUserKey is the context key of the admin signed in to an admin page
+const AdminUserKey = "admin_user"
+
+// AdminAuth lets through the requests signed in as an admin user with HTTP Basic credentials,
+// their email and password, and asks browsers for them otherwise. Browsers send the credentials
+// with requests from other sites too, so forms are only accepted from the pages' own origin.
+func AdminAuth(authUseCase *usecase.AdminAuthUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		email, password, ok := c.Request.BasicAuth()
+		if !ok {
+			challenge(c)
+			return
+		}
+		user, err := authUseCase.Authenticate(email, password)
+		if errors.Is(err, domainerr.ErrInvalidCredentials) {
+			challenge(c)
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to authenticate admin %s: %v", email, err)
+			c.AbortWithStatus(http.StatusServiceUnavailable)
+			return
+		}
+
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead && !sameOrigin(c.Request) {
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		c.Set(AdminUserKey, user)
+		c.Next()
+	}
+}
+
+// challenge asks the browser for admin credentials
+func challenge(c *gin.Context) {
+	c.Header("WWW-Authenticate", `Basic realm="Library admin", charset="UTF-8"`)
+	c.AbortWithStatus(http.StatusUnauthorized)
+}
+
+// sameOrigin reports whether a request comes from a page of the host it is sent to, according to
+// its Origin header, or its Referer when browsers leave Origin out
+func sameOrigin(r *http.Request) bool {
+	source := r.Header.Get("Origin")
+	if source == "" {
+		source = r.Referer()
+	}
+	u, err := url.Parse(source)
+	return err == nil && source != "" && u.Host == r.Host
+}
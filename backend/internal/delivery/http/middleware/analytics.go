@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"strings"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsRecorder counts anonymous usage events
+type AnalyticsRecorder interface {
+	Record(kind, name string)
+}
+
+// analyticsFeature is an optional feature of the API and how to tell a request uses it
+type analyticsFeature struct {
+	name string
+	used func(c *gin.Context) bool
+}
+
+// analyticsFeatures are the features whose adoption is recorded
+var analyticsFeatures = []analyticsFeature{
+	{"hal", func(c *gin.Context) bool { return strings.Contains(c.GetHeader("Accept"), "application/hal+json") }},
+	{"problem_details", func(c *gin.Context) bool { return strings.Contains(c.GetHeader("Accept"), ProblemMediaType) }},
+	{"api_key", func(c *gin.Context) bool { return c.GetHeader(APIKeyHeader) != "" }},
+	{"tenant", func(c *gin.Context) bool { return c.GetHeader(TenantHeader) != "" }},
+	{"timezone", func(c *gin.Context) bool { return c.Query("tz") != "" || c.GetHeader("X-Timezone") != "" }},
+	{"computed_fields", func(c *gin.Context) bool { return strings.Contains(c.Query("include"), "computed") }},
+	{"collapse_work", func(c *gin.Context) bool { return c.Query("collapse") == "work" }},
+	{"sort_popularity", func(c *gin.Context) bool { return c.Query("sort") == "popularity" }},
+	{"metadata_search", func(c *gin.Context) bool {
+		for key := range c.Request.URL.Query() {
+			if strings.HasPrefix(key, "meta.") {
+				return true
+			}
+		}
+		return false
+	}},
+}
+
+// Analytics records the endpoint each request was routed to, by method and route rather than
+// path so that no IDs are kept, and the optional features it used. Nothing about the caller is
+// recorded. Requests matching no route are not counted.
+func Analytics(recorder AnalyticsRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			return
+		}
+		recorder.Record(entities.AnalyticsEndpoint, c.Request.Method+" "+route)
+		for _, feature := range analyticsFeatures {
+			if feature.used(c) {
+				recorder.Record(entities.AnalyticsFeature, feature.name)
+			}
+		}
+	}
+}
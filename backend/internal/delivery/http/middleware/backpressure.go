@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"library-management-system/internal/domain/domainerr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// backpressureRetryAfter is the delay, in seconds, suggested to clients turned away by Backpressure
+const backpressureRetryAfter = 5
+
+// QueueDepth reports how full a background job queue is
+type QueueDepth interface {
+	Queued() int
+	Capacity() int
+}
+
+// Backpressure rejects write requests with 503 while any queue is at least percent full, so bursts
+// of work are pushed back to clients instead of piling up in memory. Reads are always served, and
+// so are the routes under the exempt prefixes, which lets operators resize pools to recover.
+func Backpressure(percent int, queues []QueueDepth, exempt ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		for _, prefix := range exempt {
+			if strings.HasPrefix(c.FullPath(), prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		for _, queue := range queues {
+			if capacity := queue.Capacity(); capacity > 0 && queue.Queued()*100 >= capacity*percent {
+				c.Header("Retry-After", strconv.Itoa(backpressureRetryAfter))
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": domainerr.ErrServerBusy.Error()})
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
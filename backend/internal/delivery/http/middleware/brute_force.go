@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/infrastructure/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FailureLockout bans keys that fail too often, such as ratelimit.Lockout in memory or
+// ratelimit.RedisLockout shared by every instance
+type FailureLockout interface {
+	Policy() ratelimit.LockoutPolicy
+	Banned(key string) (ratelimit.Ban, bool)
+	Fail(key string) (ratelimit.Ban, bool)
+	Succeed(key string)
+	Bans() []ratelimit.Ban
+	Unban(key string) bool
+}
+
+// Credentials returns the account a request signs in to, empty when its credentials name none,
+// and whether it presents credentials at all
+type Credentials func(c *gin.Context) (account string, presented bool)
+
+// BasicAuthCredentials finds the account of HTTP Basic credentials
+func BasicAuthCredentials(c *gin.Context) (string, bool) {
+	account, _, ok := c.Request.BasicAuth()
+	return account, ok
+}
+
+// HeaderCredentials finds credentials naming no account, such as a token, in a header
+func HeaderCredentials(header string) Credentials {
+	return func(c *gin.Context) (string, bool) {
+		return "", c.GetHeader(header) != ""
+	}
+}
+
+// BruteForceStats describes the lockout of a BruteForceGuard and the keys it bans
+type BruteForceStats struct {
+	MaxFailures   int `json:"max_failures"`
+	WindowSeconds int `json:"window_seconds"`
+	// BanSeconds is the length of a first ban, doubled with each strike up to MaxBanSeconds
+	BanSeconds    int             `json:"ban_seconds"`
+	MaxBanSeconds int             `json:"max_ban_seconds"`
+	Bans          []ratelimit.Ban `json:"bans"`
+}
+
+// BruteForceGuard counts the failed sign-ins of each client IP and account, and bans those that
+// fail too often with 429 and Retry-After, for longer each time. A request fails when it presents
+// credentials and gets 401; a successful one resets the failures of its IP and account.
+type BruteForceGuard struct {
+	lockout FailureLockout
+}
+
+// NewBruteForceGuard creates a guard banning keys with a lockout
+func NewBruteForceGuard(lockout FailureLockout) *BruteForceGuard {
+	return &BruteForceGuard{
+		lockout: lockout,
+	}
+}
+
+// Handler returns the middleware guarding the sign-ins whose credentials are found by credentials
+func (g *BruteForceGuard) Handler(credentials Credentials) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if g.lockout.Policy().MaxFailures <= 0 {
+			c.Next()
+			return
+		}
+
+		account, presented := credentials(c)
+		keys := []string{"ip:" + c.ClientIP()}
+		if account = strings.ToLower(strings.TrimSpace(account)); account != "" {
+			keys = append(keys, "account:"+account)
+		}
+
+		for _, key := range keys {
+			if ban, banned := g.lockout.Banned(key); banned {
+				retryAfter := int(ban.Until.Sub(timeNow()).Seconds()) + 1
+				c.Header("Retry-After", strconv.Itoa(retryAfter))
+				c.Error(domainerr.ErrSignInLockedOut)
+				c.AbortWithStatusJSON(domainerr.ErrSignInLockedOut.Status, gin.H{"error": domainerr.ErrSignInLockedOut.Error()})
+				return
+			}
+		}
+
+		c.Next()
+
+		if !presented {
+			return
+		}
+		switch status := c.Writer.Status(); {
+		case status == http.StatusUnauthorized:
+			for _, key := range keys {
+				g.lockout.Fail(key)
+			}
+		case status < http.StatusBadRequest:
+			for _, key := range keys {
+				g.lockout.Succeed(key)
+			}
+		}
+	}
+}
+
+// Stats returns the lockout policy and the keys banned now
+func (g *BruteForceGuard) Stats() BruteForceStats {
+	policy := g.lockout.Policy()
+	return BruteForceStats{
+		MaxFailures:   policy.MaxFailures,
+		WindowSeconds: int(policy.Window / time.Second),
+		BanSeconds:    int(policy.BanDuration / time.Second),
+		MaxBanSeconds: int(policy.MaxBanDuration / time.Second),
+		Bans:          g.lockout.Bans(),
+	}
+}
+
+// Unban lifts the ban of a key, such as ip:203.0.113.9 or account:admin@example.com, reporting
+// whether it was banned
+func (g *BruteForceGuard) Unban(key string) bool {
+	return g.lockout.Unban(key)
+}
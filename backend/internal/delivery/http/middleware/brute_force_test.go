@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/infrastructure/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBruteForceGuard(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	now := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+	lockout := ratelimit.NewLockout(ratelimit.LockoutPolicy{MaxFailures: 2, Window: time.Minute, BanDuration: time.Minute, MaxBanDuration: time.Hour})
+	lockout.SetClock(clock.NewFixed(now))
+	guard := NewBruteForceGuard(lockout)
+
+	router := gin.New()
+	router.GET("/admin", guard.Handler(BasicAuthCredentials), func(c *gin.Context) {
+		if _, password, _ := c.Request.BasicAuth(); password != "secret" {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	signIn := func(remoteAddr, email, password string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+		req.RemoteAddr = remoteAddr
+		if email != "" {
+			req.SetBasicAuth(email, password)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Browsers asking for credentials are not failing to sign in
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusUnauthorized, signIn("203.0.113.9:4000", "", "").Code)
+	}
+	assert.Empty(t, guard.Stats().Bans)
+
+	assert.Equal(t, http.StatusUnauthorized, signIn("203.0.113.9:4000", "Admin@example.com", "guess").Code)
+	assert.Equal(t, http.StatusUnauthorized, signIn("203.0.113.9:4000", "admin@example.com", "guess").Code)
+	w := signIn("203.0.113.9:4000", "admin@example.com", "secret")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "61", w.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"error":"too many failed sign-ins, retry later"}`, w.Body.String())
+
+	// The account is banned from other IPs too
+	assert.Equal(t, http.StatusTooManyRequests, signIn("198.51.100.1:4000", "admin@example.com", "secret").Code)
+	stats := guard.Stats()
+	assert.Equal(t, 2, stats.MaxFailures)
+	assert.Len(t, stats.Bans, 2)
+
+	assert.True(t, guard.Unban("account:admin@example.com"))
+	assert.Equal(t, http.StatusOK, signIn("198.51.100.1:4000", "admin@example.com", "secret").Code)
+	assert.Equal(t, http.StatusTooManyRequests, signIn("203.0.113.9:4000", "ada@example.com", "secret").Code)
+}
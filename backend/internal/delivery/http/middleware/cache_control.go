@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CachePolicy is how long shared caches, such as CDNs, may keep the responses of a route
+type CachePolicy struct {
+	// MaxAge is how long responses stay fresh; zero keeps them out of caches altogether
+	MaxAge time.Duration
+	// StaleWhileRevalidate is how long caches may keep serving responses past MaxAge while they
+	// revalidate them in the background
+	StaleWhileRevalidate time.Duration
+	// Vary lists the request headers responses differ by, so caches keep a copy for each
+	Vary []string
+}
+
+// header returns the Cache-Control header value of the policy
+func (p CachePolicy) header() string {
+	if p.MaxAge <= 0 {
+		return "no-store"
+	}
+	if p.StaleWhileRevalidate > 0 {
+		return fmt.Sprintf("public, max-age=%d, stale-while-revalidate=%d", int(p.MaxAge.Seconds()), int(p.StaleWhileRevalidate.Seconds()))
+	}
+	return fmt.Sprintf("public, max-age=%d", int(p.MaxAge.Seconds()))
+}
+
+// CacheRule is the cache policy of a route
+type CacheRule struct {
+	// Method and Route name the route as mounted on an API group, e.g. GET /books
+	Method string
+	Route  string
+	Policy CachePolicy
+}
+
+// CacheControl sets the Cache-Control header of the routes of the group mounted at base from
+// their rules. Successful responses of the routes with a rule are cached as it says, and any other
+// response, including every error and every response to a route without a rule, gets no-store so
+// that CDNs never keep what they should not. Handlers setting Cache-Control themselves override
+// it.
+func CacheControl(base string, rules []CacheRule) gin.HandlerFunc {
+	byRoute := make(map[string]CachePolicy, len(rules))
+	for _, r := range rules {
+		byRoute[r.Method+" "+base+r.Route] = r.Policy
+	}
+
+	return func(c *gin.Context) {
+		w := &cacheControlWriter{ResponseWriter: c.Writer, policy: byRoute[c.Request.Method+" "+c.FullPath()]}
+		c.Writer = w
+		c.Next()
+		// Responses without a body, such as 304 Not Modified, are never written through w
+		w.apply()
+	}
+}
+
+// cacheControlWriter sets the Cache-Control header just before the response is written, when its
+// status is known
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	policy  CachePolicy
+	applied bool
+}
+
+// apply sets the headers of the policy, or no-store when the response is not to be cached
+func (w *cacheControlWriter) apply() {
+	if w.applied || w.ResponseWriter.Written() {
+		return
+	}
+	w.applied = true
+	header := w.ResponseWriter.Header()
+	if header.Get("Cache-Control") != "" {
+		return
+	}
+	status := w.ResponseWriter.Status()
+	if status >= http.StatusMultipleChoices && status != http.StatusNotModified {
+		header.Set("Cache-Control", "no-store")
+		return
+	}
+	header.Set("Cache-Control", w.policy.header())
+	if w.policy.MaxAge > 0 {
+		for _, name := range w.policy.Vary {
+			header.Add("Vary", name)
+		}
+	}
+}
+
+func (w *cacheControlWriter) WriteHeaderNow() {
+	w.apply()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *cacheControlWriter) Write(data []byte) (int, error) {
+	w.apply()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheControlWriter) WriteString(s string) (int, error) {
+	w.apply()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *cacheControlWriter) Flush() {
+	w.apply()
+	w.ResponseWriter.Flush()
+}
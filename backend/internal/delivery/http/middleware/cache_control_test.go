@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheControl(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api")
+	api.Use(CacheControl(api.BasePath(), []CacheRule{
+		{Method: http.MethodGet, Route: "/books", Policy: CachePolicy{MaxAge: time.Minute, Vary: []string{"Accept", TenantHeader}}},
+		{Method: http.MethodGet, Route: "/books/:id/cover", Policy: CachePolicy{MaxAge: 24 * time.Hour}},
+		{Method: http.MethodGet, Route: "/availability", Policy: CachePolicy{MaxAge: time.Minute, StaleWhileRevalidate: 5 * time.Minute}},
+	}))
+	api.GET("/books", func(c *gin.Context) {
+		if c.Query("page") == "x" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{})
+	})
+	api.POST("/books", func(c *gin.Context) { c.JSON(http.StatusCreated, gin.H{}) })
+	api.GET("/books/:id", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })
+	api.GET("/books/:id/cover", func(c *gin.Context) {
+		c.Header("ETag", `"abc"`)
+		if c.GetHeader("If-None-Match") == `"abc"` {
+			c.Status(http.StatusNotModified)
+			return
+		}
+		c.Data(http.StatusOK, "image/png", []byte("png"))
+	})
+	api.GET("/availability", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })
+	api.GET("/config", func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	serve := func(method, path string, headers ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		for i := 0; i < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve(http.MethodGet, "/api/books")
+	assert.Equal(t, "public, max-age=60", w.Header().Get("Cache-Control"))
+	assert.Equal(t, []string{"Accept", TenantHeader}, w.Header().Values("Vary"))
+
+	// Errors, mutations and routes without a rule are never cached
+	for _, w := range []*httptest.ResponseRecorder{
+		serve(http.MethodGet, "/api/books?page=x"),
+		serve(http.MethodPost, "/api/books"),
+		serve(http.MethodGet, "/api/books/1"),
+	} {
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		assert.Empty(t, w.Header().Get("Vary"))
+	}
+
+	w = serve(http.MethodGet, "/api/books/1/cover")
+	assert.Equal(t, "public, max-age=86400", w.Header().Get("Cache-Control"))
+	w = serve(http.MethodGet, "/api/books/1/cover", "If-None-Match", `"abc"`)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Equal(t, "public, max-age=86400", w.Header().Get("Cache-Control"))
+
+	w = serve(http.MethodGet, "/api/availability")
+	assert.Equal(t, "public, max-age=60, stale-while-revalidate=300", w.Header().Get("Cache-Control"))
+
+	// Handlers may decide for themselves
+	w = serve(http.MethodGet, "/api/config")
+	assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+}
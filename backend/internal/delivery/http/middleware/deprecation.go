@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Headers announcing deprecations
+const (
+	DeprecationHeader = "Deprecation"
+	SunsetHeader      = "Sunset"
+	WarningHeader     = "Warning"
+)
+
+// Deprecation announces that a route, or some fields of its responses, will change or be removed
+type Deprecation struct {
+	// Method and Route name the route as mounted on an API group, e.g. GET /books/:id/slug
+	Method string `json:"method"`
+	Route  string `json:"route"`
+	// Fields are the deprecated fields of the responses; the whole route is deprecated without any
+	Fields []string `json:"fields,omitempty"`
+	// Since is when the deprecation was announced
+	Since time.Time `json:"since"`
+	// Sunset is when the route or fields stop working, nil until it is planned
+	Sunset *time.Time `json:"sunset,omitempty"`
+	// Message tells clients what to use instead
+	Message string `json:"message"`
+}
+
+// warning returns the Warning header value of the deprecation, with the miscellaneous persistent
+// warning code
+func (d Deprecation) warning() string {
+	var text string
+	switch len(d.Fields) {
+	case 0:
+		text = d.Method + " " + d.Route + " is deprecated"
+	case 1:
+		text = "Field " + d.Fields[0] + " of " + d.Method + " " + d.Route + " is deprecated"
+	default:
+		text = "Fields " + strings.Join(d.Fields, ", ") + " of " + d.Method + " " + d.Route + " are deprecated"
+	}
+	if d.Sunset != nil {
+		text += " and will be removed on " + d.Sunset.UTC().Format("2006-01-02")
+	}
+	if d.Message != "" {
+		text += ": " + d.Message
+	}
+	return fmt.Sprintf("299 - %q", text)
+}
+
+// Deprecations warns the clients of the deprecated routes of the group mounted at base. Deprecated
+// routes get a Deprecation header with the time of the announcement and, once planned, a Sunset
+// header with the time they stop working. Routes with deprecated fields only get a Warning, as
+// deprecated routes also do.
+func Deprecations(base string, deprecations []Deprecation) gin.HandlerFunc {
+	byRoute := make(map[string]Deprecation, len(deprecations))
+	for _, d := range deprecations {
+		byRoute[d.Method+" "+base+d.Route] = d
+	}
+
+	return func(c *gin.Context) {
+		if d, ok := byRoute[c.Request.Method+" "+c.FullPath()]; ok {
+			header := c.Writer.Header()
+			if len(d.Fields) == 0 {
+				header.Set(DeprecationHeader, fmt.Sprintf("@%d", d.Since.Unix()))
+				if d.Sunset != nil {
+					header.Set(SunsetHeader, d.Sunset.UTC().Format(http.TimeFormat))
+				}
+			}
+			header.Add(WarningHeader, d.warning())
+		}
+		c.Next()
+	}
+}
+
+// AnnotateOpenAPI marks the deprecated operations of an OpenAPI (Swagger 2.0) document and lists
+// every deprecation in its x-deprecations section. Operations get deprecated when the whole route
+// is, and an x-deprecation with the details either way; routes the document lacks are only listed.
+func AnnotateOpenAPI(doc []byte, deprecations []Deprecation) ([]byte, error) {
+	var spec map[string]interface{}
+	if err := json.Unmarshal(doc, &spec); err != nil {
+		return nil, err
+	}
+
+	paths, _ := spec["paths"].(map[string]interface{})
+	for _, d := range deprecations {
+		item, _ := paths[openAPIPath(d.Route)].(map[string]interface{})
+		operation, ok := item[strings.ToLower(d.Method)].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if len(d.Fields) == 0 {
+			operation["deprecated"] = true
+		}
+		operation["x-deprecation"] = d
+	}
+	if deprecations == nil {
+		deprecations = []Deprecation{}
+	}
+	spec["x-deprecations"] = deprecations
+	return json.MarshalIndent(spec, "", "    ")
+}
+
+// openAPIPath turns a gin route into an OpenAPI path, e.g. /books/:id into /books/{id}
+func openAPIPath(route string) string {
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDeprecations() []Deprecation {
+	sunset := time.Date(2027, 4, 16, 0, 0, 0, 0, time.UTC)
+	return []Deprecation{
+		{Method: http.MethodGet, Route: "/books/:id/slug", Since: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), Sunset: &sunset, Message: "read the slug of GET /books/{id} instead"},
+		{Method: http.MethodGet, Route: "/books/:id", Fields: []string{"available"}, Since: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), Message: "use status"},
+	}
+}
+
+func TestDeprecations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api")
+	api.Use(Deprecations(api.BasePath(), testDeprecations()))
+	ok := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) }
+	api.GET("/books/:id/slug", ok)
+	api.GET("/books/:id", ok)
+	api.PUT("/books/:id", ok)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/books/1/slug", nil))
+	assert.Equal(t, "@1792108800", w.Header().Get(DeprecationHeader))
+	assert.Equal(t, "Fri, 16 Apr 2027 00:00:00 GMT", w.Header().Get(SunsetHeader))
+	assert.Equal(t, `299 - "GET /books/:id/slug is deprecated and will be removed on 2027-04-16: read the slug of GET /books/{id} instead"`, w.Header().Get(WarningHeader))
+
+	// Deprecated fields only warn
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/books/1", nil))
+	assert.Empty(t, w.Header().Get(DeprecationHeader))
+	assert.Empty(t, w.Header().Get(SunsetHeader))
+	assert.Equal(t, `299 - "Field available of GET /books/:id is deprecated: use status"`, w.Header().Get(WarningHeader))
+
+	// Other methods of a route are not deprecated
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/books/1", nil))
+	assert.Empty(t, w.Header().Get(WarningHeader))
+}
+
+func TestAnnotateOpenAPI(t *testing.T) {
+	doc := []byte(`{"swagger":"2.0","paths":{"/books/{id}":{"get":{"summary":"Get a book"}},"/books/{id}/slug":{"get":{"summary":"Get the slug of a book"}}}}`)
+
+	annotated, err := AnnotateOpenAPI(doc, testDeprecations())
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Deprecated  bool         `json:"deprecated"`
+			Deprecation *Deprecation `json:"x-deprecation"`
+		} `json:"paths"`
+		Deprecations []Deprecation `json:"x-deprecations"`
+	}
+	require.NoError(t, json.Unmarshal(annotated, &spec))
+	assert.True(t, spec.Paths["/books/{id}/slug"]["get"].Deprecated)
+	assert.Equal(t, "2027-04-16T00:00:00Z", spec.Paths["/books/{id}/slug"]["get"].Deprecation.Sunset.Format(time.RFC3339))
+	assert.False(t, spec.Paths["/books/{id}"]["get"].Deprecated)
+	assert.Equal(t, []string{"available"}, spec.Paths["/books/{id}"]["get"].Deprecation.Fields)
+	assert.Len(t, spec.Deprecations, 2)
+
+	_, err = AnnotateOpenAPI([]byte("not json"), nil)
+	assert.Error(t, err)
+}
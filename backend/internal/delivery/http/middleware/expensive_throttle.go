@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"strconv"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/infrastructure/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// expensiveRetryAfter is the delay, in seconds, suggested to clients turned away by ExpensiveThrottle
+const expensiveRetryAfter = 10
+
+// expensiveKey is the single lane of the limiter shared by every expensive operation
+const expensiveKey = "expensive"
+
+// ExpensiveThrottle limits how many expensive operations, such as large imports and reports, the
+// server runs at once, so they cannot slow interactive requests down. Requests the expensive
+// function picks out, or all of them when it is nil, take a slot shared by every caller, waiting
+// in a queue when all are taken. When the queue is full or the wait runs out they get 429 with
+// Retry-After, along with how many operations are running and queued and the position the request
+// would have taken in the queue.
+func ExpensiveThrottle(limiter *ratelimit.ConcurrencyLimiter, expensive func(c *gin.Context) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if expensive != nil && !expensive(c) {
+			c.Next()
+			return
+		}
+
+		release, ok := limiter.Acquire(c.Request.Context(), expensiveKey)
+		if !ok {
+			running, waiting := limiter.Load(expensiveKey)
+			c.Header("Retry-After", strconv.Itoa(expensiveRetryAfter))
+			c.Error(domainerr.ErrOperationsBusy)
+			c.AbortWithStatusJSON(domainerr.ErrOperationsBusy.Status, gin.H{
+				"error":          domainerr.ErrOperationsBusy.Error(),
+				"running":        running,
+				"limit":          limiter.Limit(),
+				"queued":         waiting,
+				"queue_position": waiting + 1,
+			})
+			return
+		}
+		defer release()
+
+		c.Next()
+	}
+}
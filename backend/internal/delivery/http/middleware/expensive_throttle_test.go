@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"library-management-system/internal/infrastructure/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpensiveThrottle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := ratelimit.NewConcurrencyLimiter(1, 0, time.Millisecond)
+	large := func(c *gin.Context) bool { return c.Query("large") == "true" }
+	router := gin.New()
+	router.POST("/import", ExpensiveThrottle(limiter, large), func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) })
+
+	release, ok := limiter.Acquire(context.Background(), expensiveKey)
+	require.True(t, ok)
+	defer release()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/import?large=true", nil))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "10", w.Header().Get("Retry-After"))
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "too many expensive operations running, retry later", body["error"])
+	assert.Equal(t, float64(1), body["running"])
+	assert.Equal(t, float64(1), body["limit"])
+	assert.Equal(t, float64(0), body["queued"])
+	assert.Equal(t, float64(1), body["queue_position"])
+
+	// Cheap requests are never held back
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/import", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
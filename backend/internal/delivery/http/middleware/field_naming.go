@@ -0,0 +1,140 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FieldNaming is how the fields of v2 responses are named
+type FieldNaming string
+
+// Field namings of v2 responses
+const (
+	// SnakeCase keeps the names of v1, such as page_size
+	SnakeCase FieldNaming = "snake_case"
+	// CamelCase joins the words of names, capitalizing all but the first, such as pageSize
+	CamelCase FieldNaming = "camelCase"
+)
+
+// FieldNamingParameter is the parameter of the Accept header selecting the field naming of a
+// response, as in application/vnd.library.v2+json; naming=camelCase
+const FieldNamingParameter = "naming"
+
+// ParseFieldNaming parses the name of a field naming
+func ParseFieldNaming(value string) (FieldNaming, error) {
+	switch naming := FieldNaming(strings.TrimSpace(value)); naming {
+	case SnakeCase, CamelCase:
+		return naming, nil
+	}
+	return "", fmt.Errorf("unknown field naming %q, expected %s or %s", value, SnakeCase, CamelCase)
+}
+
+// FieldNamingPolicy picks the field naming of v2 responses: the naming parameter of the Accept
+// header first, then the naming of the caller's tenant, then Default
+type FieldNamingPolicy struct {
+	Default FieldNaming
+	// Tenants maps tenant IDs to namings of their own
+	Tenants map[string]FieldNaming
+}
+
+// naming returns the field naming of the response to a request
+func (p FieldNamingPolicy) naming(c *gin.Context) FieldNaming {
+	for _, accepted := range strings.Split(c.GetHeader("Accept"), ",") {
+		if _, params, err := mime.ParseMediaType(accepted); err == nil {
+			if naming, err := ParseFieldNaming(params[FieldNamingParameter]); err == nil {
+				return naming
+			}
+		}
+	}
+	if naming, ok := p.Tenants[c.GetHeader(TenantHeader)]; ok {
+		return naming
+	}
+	if p.Default == "" {
+		return SnakeCase
+	}
+	return p.Default
+}
+
+// freeformFields are the fields whose values are objects keyed by data, such as the metadata of
+// books, rather than by field names; their keys are left as they are
+var freeformFields = map[string]bool{
+	"_links":     true,
+	"by_block":   true,
+	"by_shift":   true,
+	"changes":    true,
+	"columns":    true,
+	"details":    true,
+	"metadata":   true,
+	"pagination": true,
+}
+
+// renameFields renames the fields of the objects of a JSON document, keeping their order. Values
+// of freeform fields are copied as they are.
+func renameFields(data []byte, rename func(string) string) []byte {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return data
+	}
+	switch trimmed[0] {
+	case '{':
+		fields, ok := objectFields(trimmed)
+		if !ok {
+			return data
+		}
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		for i, field := range fields {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(rename(field.key))
+			buf.Write(key)
+			buf.WriteByte(':')
+			if freeformFields[field.key] {
+				buf.Write(field.value)
+			} else {
+				buf.Write(renameFields(field.value, rename))
+			}
+		}
+		buf.WriteByte('}')
+		return buf.Bytes()
+	case '[':
+		var items []json.RawMessage
+		if json.Unmarshal(trimmed, &items) != nil {
+			return data
+		}
+		var buf bytes.Buffer
+		buf.WriteByte('[')
+		for i, item := range items {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(renameFields(item, rename))
+		}
+		buf.WriteByte(']')
+		return buf.Bytes()
+	}
+	return data
+}
+
+// camelCase turns a snake_case name into camelCase. Leading underscores, as in _links, are kept.
+func camelCase(name string) string {
+	trimmed := strings.TrimLeft(name, "_")
+	if !strings.Contains(trimmed, "_") {
+		return name
+	}
+	var b strings.Builder
+	b.WriteString(name[:len(name)-len(trimmed)])
+	for i, word := range strings.Split(trimmed, "_") {
+		if i > 0 && word != "" {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func fieldNamingRouter(naming FieldNamingPolicy) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(APIV2("https://docs.example.com", true, naming))
+	router.GET("/timeline", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"items":     []gin.H{{"occurred_at": "2024-01-15T10:30:00Z", "metadata": gin.H{"shelf_mark": "A1"}}},
+			"page":      1,
+			"page_size": 20,
+			"total":     1,
+		})
+	})
+	router.GET("/missing", func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "book not found"})
+	})
+	return router
+}
+
+func fieldNamingGet(router *gin.Engine, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAPIV2_FieldNaming(t *testing.T) {
+	router := fieldNamingRouter(FieldNamingPolicy{Default: SnakeCase, Tenants: map[string]FieldNaming{"tenant-1": CamelCase}})
+
+	w := fieldNamingGet(router, "/timeline", nil)
+	assert.Equal(t, `{"data":[{"metadata":{"shelf_mark":"A1"},"occurred_at":"2024-01-15T10:30:00Z"}],"meta":{"page":1,"page_size":20,"total":1,"total_pages":1}}`, w.Body.String())
+	assert.Equal(t, "Accept, "+TenantHeader, w.Header().Get("Vary"))
+
+	// Tenants get their naming; the keys of metadata are data and stay as they are
+	w = fieldNamingGet(router, "/timeline", map[string]string{TenantHeader: "tenant-1"})
+	assert.Equal(t, `{"data":[{"metadata":{"shelf_mark":"A1"},"occurredAt":"2024-01-15T10:30:00Z"}],"meta":{"page":1,"pageSize":20,"total":1,"totalPages":1}}`, w.Body.String())
+
+	// The Accept header comes first, and ignores unknown namings
+	w = fieldNamingGet(router, "/timeline", map[string]string{TenantHeader: "tenant-1", "Accept": V2MediaType + "; naming=snake_case"})
+	assert.Contains(t, w.Body.String(), `"page_size":20`)
+	w = fieldNamingGet(router, "/timeline", map[string]string{"Accept": "application/json; naming=camelCase"})
+	assert.Contains(t, w.Body.String(), `"pageSize":20`)
+	w = fieldNamingGet(router, "/timeline", map[string]string{"Accept": "application/json; naming=kebab-case"})
+	assert.Contains(t, w.Body.String(), `"page_size":20`)
+
+	// Problems are renamed too
+	w = fieldNamingGet(router, "/missing", map[string]string{TenantHeader: "tenant-1"})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, ProblemMediaType, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"detail":"book not found"`)
+}
+
+func TestCamelCase(t *testing.T) {
+	assert.Equal(t, "pageSize", camelCase("page_size"))
+	assert.Equal(t, "coverPalette", camelCase("cover_palette"))
+	assert.Equal(t, "id", camelCase("id"))
+	assert.Equal(t, "_links", camelCase("_links"))
+	assert.Equal(t, "_embeddedBooks", camelCase("_embedded_books"))
+}
+
+func TestParseFieldNaming(t *testing.T) {
+	naming, err := ParseFieldNaming(" camelCase ")
+	assert.NoError(t, err)
+	assert.Equal(t, CamelCase, naming)
+	_, err = ParseFieldNaming("CamelCase")
+	assert.Error(t, err)
+}
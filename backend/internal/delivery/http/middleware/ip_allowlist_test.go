@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingAudit keeps the audit entries created, in memory
+type recordingAudit struct {
+	entries []entities.AuditEntry
+}
+
+func (a *recordingAudit) Create(entry *entities.AuditEntry) error {
+	a.entries = append(a.entries, *entry)
+	return nil
+}
+
+func (a *recordingAudit) CreateBatch(entries []entities.AuditEntry) error {
+	a.entries = append(a.entries, entries...)
+	return nil
+}
+
+func (a *recordingAudit) ListByEntity(entityType, entityID string) ([]entities.AuditEntry, error) {
+	return nil, nil
+}
+
+func (a *recordingAudit) ListByAction(action string, from, to time.Time) ([]entities.AuditEntry, error) {
+	return nil, nil
+}
+
+func TestNewIPAllowlist(t *testing.T) {
+	allowlist, err := NewIPAllowlist([]string{"10.0.0.0/8", " 192.168.1.7 ", "2001:db8::/32", ""}, nil)
+	require.NoError(t, err)
+	assert.True(t, allowlist.Enabled())
+	assert.True(t, allowlist.Allows("10.1.2.3"))
+	assert.True(t, allowlist.Allows("192.168.1.7"))
+	assert.False(t, allowlist.Allows("192.168.1.8"))
+	assert.True(t, allowlist.Allows("2001:db8::1"))
+	assert.False(t, allowlist.Allows("not an ip"))
+
+	_, err = NewIPAllowlist([]string{"10.0.0.0/33"}, nil)
+	assert.Error(t, err)
+	_, err = NewIPAllowlist([]string{"localhost"}, nil)
+	assert.Error(t, err)
+
+	allowlist, err = NewIPAllowlist(nil, nil)
+	require.NoError(t, err)
+	assert.False(t, allowlist.Enabled())
+}
+
+func TestIPAllowlist_Handler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	audit := &recordingAudit{}
+	allowlist, err := NewIPAllowlist([]string{"10.0.0.0/8"}, audit)
+	require.NoError(t, err)
+
+	router := gin.New()
+	api := router.Group("/api")
+	api.Use(allowlist.Handler(api.BasePath() + "/admin"))
+	ok := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) }
+	api.GET("/books", ok)
+	api.DELETE("/books/:id", ok)
+	api.GET("/admin/jobs", ok)
+
+	request := func(method, path, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Other routes are open to anyone
+	assert.Equal(t, http.StatusOK, request(http.MethodGet, "/api/books", "203.0.113.9:4000").Code)
+	assert.Equal(t, http.StatusOK, request(http.MethodGet, "/api/admin/jobs", "10.0.0.5:4000").Code)
+	assert.Equal(t, http.StatusOK, request(http.MethodDelete, "/api/books/1", "10.0.0.5:4000").Code)
+	assert.Empty(t, audit.entries)
+
+	w := request(http.MethodGet, "/api/admin/jobs", "203.0.113.9:4000")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.JSONEq(t, `{"error":"client IP is not allowed"}`, w.Body.String())
+	assert.Equal(t, http.StatusForbidden, request(http.MethodDelete, "/api/books/1", "203.0.113.9:4000").Code)
+
+	require.Len(t, audit.entries, 2)
+	assert.Equal(t, entities.AuditEntityRoute, audit.entries[0].EntityType)
+	assert.Equal(t, "GET /api/admin/jobs", audit.entries[0].EntityID)
+	assert.Equal(t, entities.AuditActionIPRejected, audit.entries[0].Action)
+	assert.Equal(t, "203.0.113.9", audit.entries[0].Actor)
+	assert.Equal(t, "DELETE /api/books/:id", audit.entries[1].EntityID)
+	assert.Contains(t, audit.entries[1].Changes, `"path":"/api/books/1"`)
+}
@@ -0,0 +1,7 @@
+// Package middleware contains the HTTP middleware shared by the API routes
+package middleware
+
+import "time"
+
+// timeNow is the clock used by middleware, overridable in tests
+var timeNow = time.Now
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"library-management-system/internal/domain/domainerr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// pageSizeKey is the context key Pagination stores the resolved page size under
+const pageSizeKey = "page_size"
+
+// PageSizes are the page size a listing defaults to and the largest one it serves
+type PageSizes struct {
+	Default int
+	Max     int
+}
+
+// PageLimits are the page sizes of the paginated listings: Listings, by listing, and Default for
+// the others
+type PageLimits struct {
+	Default  PageSizes
+	Listings map[string]PageSizes
+}
+
+// For returns the page sizes of a listing
+func (l PageLimits) For(listing string) PageSizes {
+	if sizes, ok := l.Listings[listing]; ok {
+		return sizes
+	}
+	return l.Default
+}
+
+// PaginatedRoute is a route serving a listing one page at a time
+type PaginatedRoute struct {
+	// Method and Route name the route as mounted on an API group, e.g. GET /imports
+	Method string
+	Route  string
+	// Param is the query parameter of the page size, e.g. page_size or limit
+	Param string
+	// Listing names the page sizes of the route in PageLimits
+	Listing string
+}
+
+// Pagination enforces the page sizes of the paginated routes of the group mounted at base, so that
+// no client pulls a whole table by asking for a huge page. Requests without a page size, or with
+// one below 1, get the default of their listing, and requests for more than its max are rejected
+// with 400 and the max. Handlers read the page size with PageSize.
+func Pagination(base string, limits PageLimits, routes []PaginatedRoute) gin.HandlerFunc {
+	byRoute := make(map[string]PaginatedRoute, len(routes))
+	for _, r := range routes {
+		byRoute[r.Method+" "+base+r.Route] = r
+	}
+
+	return func(c *gin.Context) {
+		route, ok := byRoute[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			c.Next()
+			return
+		}
+
+		sizes := limits.For(route.Listing)
+		size := sizes.Default
+		if value := c.Query(route.Param); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid " + route.Param})
+				return
+			}
+			if n > sizes.Max {
+				c.AbortWithStatusJSON(domainerr.ErrPageSizeTooLarge.Status, gin.H{
+					"error":         domainerr.ErrPageSizeTooLarge.Error(),
+					"max_page_size": sizes.Max,
+				})
+				return
+			}
+			if n >= 1 {
+				size = n
+			}
+		}
+		c.Set(pageSizeKey, size)
+		c.Next()
+	}
+}
+
+// PageSize returns the page size of a paginated request: the one Pagination resolved, or else the
+// param query parameter, zero when it is missing so that the use case default applies
+func PageSize(c *gin.Context, param string) (int, error) {
+	if size, ok := c.Get(pageSizeKey); ok {
+		return size.(int), nil
+	}
+	return strconv.Atoi(c.DefaultQuery(param, "0"))
+}
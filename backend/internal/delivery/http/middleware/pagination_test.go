@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api")
+	limits := PageLimits{
+		Default:  PageSizes{Default: 50, Max: 200},
+		Listings: map[string]PageSizes{"timeline": {Default: 20, Max: 100}},
+	}
+	api.Use(Pagination(api.BasePath(), limits, []PaginatedRoute{
+		{Method: http.MethodGet, Route: "/books/:id/timeline", Param: "page_size", Listing: "timeline"},
+		{Method: http.MethodGet, Route: "/imports", Param: "limit", Listing: "imports"},
+	}))
+	handler := func(param string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			size, err := PageSize(c, param)
+			assert.NoError(t, err)
+			c.JSON(http.StatusOK, gin.H{"size": size})
+		}
+	}
+	api.GET("/books/:id/timeline", handler("page_size"))
+	api.GET("/imports", handler("limit"))
+	api.GET("/books", handler("page_size"))
+
+	for _, tc := range []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/api/books/1/timeline", http.StatusOK, `{"size":20}`},
+		{"/api/books/1/timeline?page_size=100", http.StatusOK, `{"size":100}`},
+		{"/api/books/1/timeline?page_size=0", http.StatusOK, `{"size":20}`},
+		{"/api/books/1/timeline?page_size=101", http.StatusBadRequest, `{"error":"page size is too large","max_page_size":100}`},
+		{"/api/books/1/timeline?page_size=all", http.StatusBadRequest, `{"error":"invalid page_size"}`},
+		{"/api/imports", http.StatusOK, `{"size":50}`},
+		{"/api/imports?limit=200", http.StatusOK, `{"size":200}`},
+		{"/api/imports?limit=100000", http.StatusBadRequest, `{"error":"page size is too large","max_page_size":200}`},
+		// Routes that are not paginated are left alone
+		{"/api/books?page_size=100000", http.StatusOK, `{"size":100000}`},
+	} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		assert.Equal(t, tc.status, w.Code, tc.path)
+		assert.JSONEq(t, tc.body, w.Body.String(), tc.path)
+	}
+}
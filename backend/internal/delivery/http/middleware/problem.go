@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"library-management-system/internal/domain/domainerr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemMediaType is the content type of RFC 7807 error responses
+const ProblemMediaType = "application/problem+json"
+
+// Problem is an RFC 7807 error response
+type Problem struct {
+	// Type links to the documentation of the error code, or is about:blank for errors outside the catalog
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Code     string `json:"code,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// NewProblem builds the problem of an error, linking errors of the domain catalog to docsURL#code.
+// Errors that lost their type on the way to the response are matched by message.
+func NewProblem(status int, err error, docsURL, instance string) Problem {
+	problem := Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   err.Error(),
+		Instance: instance,
+	}
+	e, ok := domainerr.Lookup(err)
+	if !ok {
+		e, ok = domainerr.ByMessage(err.Error())
+	}
+	if ok {
+		problem.Type = docsURL + "#" + e.Code
+		problem.Code = e.Code
+	}
+	return problem
+}
+
+// ProblemDetails turns the error responses of requests accepting ProblemMediaType into
+// problem+json, so v1 clients can opt in to the error format of v2
+func ProblemDetails(docsURL string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Vary", "Accept")
+		if !strings.Contains(c.GetHeader("Accept"), ProblemMediaType) {
+			c.Next()
+			return
+		}
+
+		rewriteResponse(c, func(status int, body []byte) (interface{}, string) {
+			if status < http.StatusBadRequest {
+				return nil, ""
+			}
+			return problemOf(c, status, body, docsURL), ProblemMediaType
+		})
+	}
+}
+
+// problemOf converts an error response body. The error a handler attached with c.Error is used
+// when it is a domain error, the "error" field of the body otherwise.
+func problemOf(c *gin.Context, status int, body []byte, docsURL string) Problem {
+	for i := len(c.Errors) - 1; i >= 0; i-- {
+		if _, ok := domainerr.Lookup(c.Errors[i].Err); ok {
+			return NewProblem(status, c.Errors[i].Err, docsURL, c.Request.URL.Path)
+		}
+	}
+
+	var response struct {
+		Error string `json:"error"`
+	}
+	json.Unmarshal(body, &response)
+	if response.Error == "" {
+		response.Error = http.StatusText(status)
+	}
+	return NewProblem(status, errors.New(response.Error), docsURL, c.Request.URL.Path)
+}
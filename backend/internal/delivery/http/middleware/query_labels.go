@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"library-management-system/internal/infrastructure/database"
+	"library-management-system/internal/infrastructure/tracing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueryLabels labels the SQL log lines of the queries a request runs with its trace ID and route,
+// so a slow endpoint can be matched to its queries. It must run after Tracing.
+func QueryLabels() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		labels := database.QueryLabels{Route: c.Request.Method + " " + route}
+		if span, ok := tracing.FromContext(c.Request.Context()); ok {
+			labels.RequestID = span.TraceID
+		}
+
+		c.Request = c.Request.WithContext(database.ContextWithQueryLabels(c.Request.Context(), labels))
+		defer database.BindQueryLabels(labels)()
+		c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Headers naming the API key and the tenant of a request
+const (
+	APIKeyHeader = "X-API-Key"
+	TenantHeader = "X-Tenant-ID"
+)
+
+// QuotaSubject returns the subject a request is metered against: the access token it signed in
+// with, the API key the server issued and verified, else the tenant of the user its session
+// signed in, else its client IP. Headers callers are free to change, such as X-API-Key and
+// X-Tenant-ID, are not trusted, so rotating them does not reset the count.
+func QuotaSubject(c *gin.Context) string {
+	if token, ok := c.Get(AccessTokenKey); ok {
+		return "key:" + token.(*entities.AccessToken).ID
+	}
+	if value, ok := c.Get(UserKey); ok {
+		user := value.(*entities.User)
+		if user.TenantID != "" {
+			return "tenant:" + user.TenantID
+		}
+		return "user:" + user.ID
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// Quota counts requests per subject and rejects them with 429 once the monthly quota is used up
+func Quota(usageUseCase *usecase.UsageUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subject := QuotaSubject(c)
+		usage, err := usageUseCase.RecordRequest(subject)
+		if err != nil {
+			// Metering problems must not take the API down
+			log.Printf("Failed to record usage for %s: %v", subject, err)
+			c.Next()
+			return
+		}
+
+		if usage.Limit > 0 {
+			c.Header("X-Quota-Limit", strconv.FormatInt(usage.Limit, 10))
+			c.Header("X-Quota-Remaining", strconv.FormatInt(usage.Remaining, 10))
+			c.Header("X-Quota-Reset", usage.ResetsAt.Format(http.TimeFormat))
+		}
+
+		if usage.Exceeded() {
+			c.Header("Retry-After", strconv.Itoa(int(usage.ResetsAt.Sub(timeNow()).Seconds())))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": domainerr.ErrQuotaExceeded.Error()})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/repository"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuota(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	usageUseCase := usecase.NewUsageUseCase(repository.NewInMemoryUsageRepository(), 2, nil)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		// Stands in for the session and access token middleware signing the request in
+		switch c.GetHeader("Authorization") {
+		case "Bearer lms_pat_member-1":
+			c.Set(AccessTokenKey, &entities.AccessToken{ID: "token-1", UserID: "member-1"})
+			c.Set(UserKey, &entities.User{ID: "member-1", TenantID: "tenant-1"})
+		case "Bearer lms_sess_member-2":
+			c.Set(UserKey, &entities.User{ID: "member-2", TenantID: "tenant-2"})
+		case "Bearer lms_sess_member-3":
+			c.Set(UserKey, &entities.User{ID: "member-3", TenantID: "tenant-2"})
+		}
+		c.Next()
+	})
+	router.Use(Quota(usageUseCase))
+	router.GET("/books", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	get := func(remoteAddr string, headers map[string]string) int {
+		req := httptest.NewRequest(http.MethodGet, "/books", nil)
+		req.RemoteAddr = remoteAddr
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Rotating the API key and tenant headers does not reset the count of an access token, even
+	// from another IP
+	signedIn := "Bearer lms_pat_member-1"
+	assert.Equal(t, http.StatusOK, get("203.0.113.9:4000", map[string]string{"Authorization": signedIn, APIKeyHeader: "key-1"}))
+	assert.Equal(t, http.StatusOK, get("203.0.113.9:4000", map[string]string{"Authorization": signedIn, APIKeyHeader: "key-2", TenantHeader: "tenant-2"}))
+	assert.Equal(t, http.StatusTooManyRequests, get("198.51.100.1:4000", map[string]string{"Authorization": signedIn, APIKeyHeader: "key-3"}))
+
+	// Users signed in with a session share the quota of their tenant, whatever tenant they claim
+	assert.Equal(t, http.StatusOK, get("203.0.113.10:4000", map[string]string{"Authorization": "Bearer lms_sess_member-2", TenantHeader: "tenant-9"}))
+	assert.Equal(t, http.StatusOK, get("203.0.113.11:4000", map[string]string{"Authorization": "Bearer lms_sess_member-3", TenantHeader: "tenant-8"}))
+	assert.Equal(t, http.StatusTooManyRequests, get("203.0.113.10:4000", map[string]string{"Authorization": "Bearer lms_sess_member-2"}))
+	usage, err := usageUseCase.GetUsage("tenant:tenant-2")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), usage.Used)
+
+	// Nor does it for callers who are not signed in, who are counted by IP
+	assert.Equal(t, http.StatusOK, get("192.0.2.7:4000", map[string]string{APIKeyHeader: "key-1"}))
+	assert.Equal(t, http.StatusOK, get("192.0.2.7:4000", map[string]string{APIKeyHeader: "key-2"}))
+	assert.Equal(t, http.StatusTooManyRequests, get("192.0.2.7:4000", map[string]string{TenantHeader: "tenant-3"}))
+	assert.Equal(t, http.StatusOK, get("192.0.2.8:4000", nil))
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"library-management-system/internal/domain/domainerr"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readOnlyRetryAfter is the delay, in seconds, suggested to clients turned away by ReadOnly
+const readOnlyRetryAfter = 30
+
+// WriteStatus reports whether the database refuses writes
+type WriteStatus interface {
+	ReadOnly() bool
+}
+
+// ReadOnly rejects write requests with 503 while the database refuses writes, so they fail fast
+// with a code clients can act on instead of an opaque error from the database. Reads are always
+// served, and so are the routes under the exempt prefixes, which do not write to the database.
+func ReadOnly(database WriteStatus, exempt ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		for _, prefix := range exempt {
+			if strings.HasPrefix(c.FullPath(), prefix) {
+				c.Next()
+				return
+			}
+		}
+
+		if database.ReadOnly() {
+			c.Header("Retry-After", strconv.Itoa(readOnlyRetryAfter))
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": domainerr.ErrDatabaseReadOnly.Error()})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedWriteStatus bool
+
+func (s fixedWriteStatus) ReadOnly() bool { return bool(s) }
+
+func TestReadOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	api := router.Group("/api")
+	api.Use(ReadOnly(fixedWriteStatus(true), api.BasePath()+"/url"))
+	ok := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) }
+	api.GET("/books", ok)
+	api.POST("/books", ok)
+	api.POST("/url/process", ok)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/books", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/books", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "database is read-only, retry later", body["error"])
+
+	// Exempt routes do not write to the database
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/url/process", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
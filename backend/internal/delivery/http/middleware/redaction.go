@@ -0,0 +1,372 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Identity headers of the caller, until authentication is in place
+const (
+	UserIDHeader = "X-User-ID"
+	// RoleHeader carries the role claim of the caller: admin, librarian or member
+	RoleHeader = "X-User-Role"
+	// BranchHeader carries the branch of staff working for a single branch, trusted like the
+	// role claim
+	BranchHeader = "X-User-Branch"
+)
+
+// Values of the redact struct tag. Fields tagged owner hold the ID of the member an object
+// belongs to; any other value marks a sensitive field, naming what it reveals.
+const redactOwner = "owner"
+
+// redactedType is how Redaction recognizes the JSON objects of an entity and what it removes
+type redactedType struct {
+	// signature are the keys every object of the entity has: those of its fields not omitempty
+	signature []string
+	// owner is the key of the owner field, empty when the entity has none
+	owner string
+	// sensitive are the keys of the fields removed
+	sensitive []string
+}
+
+// Redaction removes the fields the given entities tag as sensitive, such as the contact details
+// of members and the amounts of fines, from the JSON responses of callers without the admin role.
+// Callers still see the fields of what they own: objects whose owner field is their X-User-ID, or
+// without an owner field, whose nested objects are all theirs. Objects are recognized by the keys
+// of their entity, wherever they appear in a response, so handlers need no redaction of their
+// own. Other responses, such as CSV downloads and streams, pass through untouched.
+func Redaction(types ...interface{}) gin.HandlerFunc {
+	redacted := make([]redactedType, 0, len(types))
+	var needles [][]byte
+	for _, t := range types {
+		rt := redactedTypeOf(reflect.TypeOf(t))
+		redacted = append(redacted, rt)
+		for _, key := range rt.sensitive {
+			needles = append(needles, []byte(`"`+key+`"`))
+		}
+	}
+
+	return func(c *gin.Context) {
+		if c.GetHeader(RoleHeader) == entities.UserRoleAdmin {
+			c.Next()
+			return
+		}
+
+		writer := &redactingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		defer func() { c.Writer = writer.ResponseWriter }()
+
+		c.Next()
+
+		// Responses without a body are decided on here, by the content type they set
+		writer.decide()
+		if !writer.buffering {
+			return
+		}
+		body := writer.body.Bytes()
+		if containsAny(body, needles) {
+			r := &redactor{types: redacted, caller: c.GetHeader(UserIDHeader)}
+			if out, changed, _ := r.value(body); changed {
+				body = out
+			}
+		}
+		writer.ResponseWriter.WriteHeader(writer.Status())
+		writer.ResponseWriter.Write(body)
+	}
+}
+
+// redactedTypeOf reads the JSON keys and redact tags of the fields of an entity
+func redactedTypeOf(t reflect.Type) redactedType {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var rt redactedType
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if !strings.Contains(options, "omitempty") {
+			rt.signature = append(rt.signature, name)
+		}
+		switch tag := field.Tag.Get("redact"); tag {
+		case "":
+		case redactOwner:
+			rt.owner = name
+		default:
+			rt.sensitive = append(rt.sensitive, name)
+		}
+	}
+	return rt
+}
+
+// redactor rewrites the JSON values of a response for one caller
+type redactor struct {
+	types  []redactedType
+	caller string
+}
+
+// jsonField is a member of a JSON object, in the order it came in
+type jsonField struct {
+	key   string
+	value json.RawMessage
+}
+
+// value redacts a JSON value, reporting whether it changed and whether it holds objects owned by
+// someone other than the caller
+func (r *redactor) value(data []byte) (out []byte, changed, foreign bool) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return data, false, false
+	}
+	switch trimmed[0] {
+	case '{':
+		return r.object(trimmed)
+	case '[':
+		return r.array(trimmed)
+	}
+	return data, false, false
+}
+
+// object redacts a JSON object and the values in it, keeping the order of its keys
+func (r *redactor) object(data []byte) ([]byte, bool, bool) {
+	fields, ok := objectFields(data)
+	if !ok {
+		return data, false, false
+	}
+
+	changed, foreign := false, false
+	keys := make(map[string]json.RawMessage, len(fields))
+	for i, field := range fields {
+		out, fieldChanged, fieldForeign := r.value(field.value)
+		if fieldChanged {
+			fields[i].value = out
+			changed = true
+		}
+		foreign = foreign || fieldForeign
+		keys[field.key] = fields[i].value
+	}
+
+	for _, t := range r.types {
+		if !matches(keys, t.signature) {
+			continue
+		}
+		owned := !foreign
+		if t.owner != "" {
+			var owner string
+			owned = json.Unmarshal(keys[t.owner], &owner) == nil && owner != "" && owner == r.caller
+			foreign = foreign || !owned
+		}
+		if owned {
+			continue
+		}
+		kept := fields[:0]
+		for _, field := range fields {
+			if containsString(t.sensitive, field.key) {
+				changed = true
+				continue
+			}
+			kept = append(kept, field)
+		}
+		fields = kept
+	}
+
+	if !changed {
+		return data, false, foreign
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(field.key)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(field.value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), true, foreign
+}
+
+// array redacts the values of a JSON array
+func (r *redactor) array(data []byte) ([]byte, bool, bool) {
+	var items []json.RawMessage
+	if json.Unmarshal(data, &items) != nil {
+		return data, false, false
+	}
+	changed, foreign := false, false
+	for i, item := range items {
+		out, itemChanged, itemForeign := r.value(item)
+		if itemChanged {
+			items[i] = out
+			changed = true
+		}
+		foreign = foreign || itemForeign
+	}
+	if !changed {
+		return data, false, foreign
+	}
+	out, err := json.Marshal(items)
+	if err != nil {
+		return data, false, foreign
+	}
+	return out, true, foreign
+}
+
+// objectFields splits a JSON object into its members, in order
+func objectFields(data []byte) ([]jsonField, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, false
+	}
+	var fields []jsonField
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, false
+		}
+		key, ok := token.(string)
+		if !ok {
+			return nil, false
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, false
+		}
+		fields = append(fields, jsonField{key: key, value: value})
+	}
+	return fields, true
+}
+
+// matches reports whether an object has every key of a signature
+func matches(keys map[string]json.RawMessage, signature []string) bool {
+	if len(signature) == 0 {
+		return false
+	}
+	for _, key := range signature {
+		if _, ok := keys[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// containsAny reports whether data contains any of the needles
+func containsAny(data []byte, needles [][]byte) bool {
+	for _, needle := range needles {
+		if bytes.Contains(data, needle) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsString reports whether a slice contains a string
+func containsString(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// redactingWriter holds JSON responses back so Redaction can rewrite them, and lets any other
+// response through as it is written, so downloads and streams are not delayed
+type redactingWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+	// decided is set once the content type is known; buffering is set if it is JSON
+	decided   bool
+	buffering bool
+}
+
+// decide picks, on the first write, whether the response is held back
+func (w *redactingWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+	w.buffering = strings.HasPrefix(w.ResponseWriter.Header().Get("Content-Type"), "application/json")
+	if !w.buffering {
+		if w.status != 0 {
+			w.ResponseWriter.WriteHeader(w.status)
+		}
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *redactingWriter) WriteHeader(code int) {
+	if code <= 0 {
+		return
+	}
+	if w.decided && !w.buffering {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.status = code
+}
+
+func (w *redactingWriter) WriteHeaderNow() {
+	w.decide()
+}
+
+func (w *redactingWriter) Flush() {
+	w.decide()
+	if !w.buffering {
+		w.ResponseWriter.Flush()
+	}
+}
+
+func (w *redactingWriter) Write(data []byte) (int, error) {
+	w.decide()
+	if !w.buffering {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.body.Write(data)
+}
+
+func (w *redactingWriter) WriteString(s string) (int, error) {
+	w.decide()
+	if !w.buffering {
+		return w.ResponseWriter.WriteString(s)
+	}
+	return w.body.WriteString(s)
+}
+
+func (w *redactingWriter) Status() int {
+	if w.decided && !w.buffering {
+		return w.ResponseWriter.Status()
+	}
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *redactingWriter) Size() int {
+	if w.decided && !w.buffering {
+		return w.ResponseWriter.Size()
+	}
+	return w.body.Len()
+}
+
+func (w *redactingWriter) Written() bool {
+	if w.decided && !w.buffering {
+		return w.ResponseWriter.Written()
+	}
+	return w.status != 0 || w.body.Len() > 0
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func redactionRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Redaction(entities.User{}, entities.Fine{}, entities.MemberFines{}))
+	router.GET("/users/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, entities.User{ID: c.Param("id"), TenantID: "tenant-1", Email: "ada@example.com", Name: "Ada", Phone: "+44 20 7946 0958", Role: entities.UserRoleMember})
+	})
+	router.GET("/fines", func(c *gin.Context) {
+		c.JSON(http.StatusOK, entities.MemberFines{
+			Items:            []entities.Fine{{ID: "fine-1", TenantID: "tenant-1", UserID: "member-1", AmountCents: 150, Reason: "Late return"}},
+			OutstandingCents: 150,
+		})
+	})
+	router.GET("/export", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/csv", []byte("email\nada@example.com\n"))
+	})
+	return router
+}
+
+func redactionGet(router *gin.Engine, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRedaction_OtherMember(t *testing.T) {
+	w := redactionGet(redactionRouter(), "/users/member-1", map[string]string{UserIDHeader: "member-2", RoleHeader: entities.UserRoleLibrarian})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	// The fields left keep their order
+	assert.Equal(t, `{"id":"member-1","tenant_id":"tenant-1","name":"Ada","role":"member","created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}`, w.Body.String())
+}
+
+func TestRedaction_OwnerAndAdmin(t *testing.T) {
+	router := redactionRouter()
+
+	w := redactionGet(router, "/users/member-1", map[string]string{UserIDHeader: "member-1"})
+	assert.Contains(t, w.Body.String(), `"email":"ada@example.com"`)
+	assert.Contains(t, w.Body.String(), `"phone":"+44 20 7946 0958"`)
+
+	w = redactionGet(router, "/users/member-1", map[string]string{UserIDHeader: "admin-1", RoleHeader: entities.UserRoleAdmin})
+	assert.Contains(t, w.Body.String(), `"email":"ada@example.com"`)
+}
+
+func TestRedaction_NestedObjects(t *testing.T) {
+	router := redactionRouter()
+
+	// The list belongs to the member its fines belong to
+	w := redactionGet(router, "/fines", map[string]string{UserIDHeader: "member-1"})
+	assert.Contains(t, w.Body.String(), `"amount_cents":150`)
+	assert.Contains(t, w.Body.String(), `"outstanding_cents":150`)
+
+	w = redactionGet(router, "/fines", nil)
+	assert.NotContains(t, w.Body.String(), "amount_cents")
+	assert.NotContains(t, w.Body.String(), "outstanding_cents")
+	assert.Contains(t, w.Body.String(), `"reason":"Late return"`)
+}
+
+func TestRedaction_NonJSONPassesThrough(t *testing.T) {
+	w := redactionGet(redactionRouter(), "/export", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "email\nada@example.com\n", w.Body.String())
+}
@@ -0,0 +1,15 @@
+package middleware
+
+import "github.com/gin-gonic/gin"
+
+// SandboxHeader marks the responses of a sandbox deployment, whose data is reset nightly
+const SandboxHeader = "X-Sandbox"
+
+// Sandbox marks every response as coming from a sandbox deployment, so integrators can tell it
+// apart from production
+func Sandbox() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header(SandboxHeader, "true")
+		c.Next()
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"strconv"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/infrastructure/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// searchThrottleRetryAfter is the delay, in seconds, suggested to clients turned away by SearchThrottle
+const searchThrottleRetryAfter = 2
+
+// SearchThrottle limits how many expensive searches each caller runs at once, so one client's
+// query storm cannot starve the API. Searches the expensive function picks out take a slot of
+// their caller, waiting in a short queue when all are taken, and get 429 with Retry-After when
+// the queue is full or the wait runs out. Other searches are never held back. Callers are told
+// apart by QuotaSubject, never by headers they could change to get a fresh slot.
+func SearchThrottle(limiter *ratelimit.ConcurrencyLimiter, expensive func(c *gin.Context) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !expensive(c) {
+			c.Next()
+			return
+		}
+
+		release, ok := limiter.Acquire(c.Request.Context(), QuotaSubject(c))
+		if !ok {
+			c.Header("Retry-After", strconv.Itoa(searchThrottleRetryAfter))
+			c.Error(domainerr.ErrSearchBusy)
+			c.AbortWithStatusJSON(domainerr.ErrSearchBusy.Status, gin.H{"error": domainerr.ErrSearchBusy.Error()})
+			return
+		}
+		defer release()
+
+		c.Next()
+	}
+}
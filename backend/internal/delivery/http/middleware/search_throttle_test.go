@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/infrastructure/ratelimit"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchThrottle(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := ratelimit.NewConcurrencyLimiter(1, 0, time.Millisecond)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		// Stands in for the session middleware signing the request in
+		if c.GetHeader("Authorization") == "Bearer lms_sess_member-1" {
+			c.Set(UserKey, &entities.User{ID: "member-1", TenantID: "tenant-1"})
+		}
+		c.Next()
+	})
+	router.GET("/books/search", SearchThrottle(limiter, func(c *gin.Context) bool { return true }), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	search := func(headers map[string]string) int {
+		req := httptest.NewRequest(http.MethodGet, "/books/search?title=%25a%25", nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// The slot of the client's IP is taken
+	release, ok := limiter.Acquire(context.Background(), "ip:192.0.2.1")
+	require.True(t, ok)
+	defer release()
+
+	// Claiming another user in a header does not get a fresh slot
+	assert.Equal(t, http.StatusTooManyRequests, search(nil))
+	assert.Equal(t, http.StatusTooManyRequests, search(map[string]string{UserIDHeader: "someone-else"}))
+	// Signing in does
+	assert.Equal(t, http.StatusOK, search(map[string]string{"Authorization": "Bearer lms_sess_member-1"}))
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionKey is the context key of the session a request signed in with
+const SessionKey = "session"
+
+// Sessions signs in the requests presenting a session token as a bearer token. Sessions reach
+// every route their user can, and their user replaces the identity headers the request sent, its
+// branch included, as with access tokens. Requests with any other bearer token, or none, pass through untouched.
+// Requests changing anything with a session an admin started to impersonate its user are audited.
+func Sessions(sessionUseCase *usecase.SessionUseCase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret := strings.TrimSpace(strings.TrimPrefix(c.GetHeader("Authorization"), bearerPrefix))
+		if !strings.HasPrefix(secret, entities.SessionTokenPrefix) {
+			c.Next()
+			return
+		}
+
+		session, user, err := sessionUseCase.Authenticate(secret, c.ClientIP())
+		if errors.Is(err, domainerr.ErrInvalidSession) {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			c.Error(domainerr.ErrInvalidSession)
+			c.AbortWithStatusJSON(domainerr.ErrInvalidSession.Status, gin.H{"error": domainerr.ErrInvalidSession.Error()})
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to authenticate session: %v", err)
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "sessions cannot be checked, retry later"})
+			return
+		}
+
+		setIdentity(c, user)
+		c.Set(SessionKey, session)
+		c.Next()
+
+		if !session.Impersonated() {
+			return
+		}
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			sessionUseCase.RecordImpersonatedRequest(session, c.Request.Method, c.FullPath(), c.Writer.Status())
+		}
+	}
+}
+
+// NotImpersonated refuses the requests signed in with a session an admin started to impersonate
+// its user, keeping admins from handing themselves credentials that outlive the impersonation
+func NotImpersonated() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if session, ok := c.Get(SessionKey); ok && session.(*entities.Session).Impersonated() {
+			c.Error(domainerr.ErrForbiddenWhileImpersonating)
+			c.AbortWithStatusJSON(domainerr.ErrForbiddenWhileImpersonating.Status, gin.H{"error": domainerr.ErrForbiddenWhileImpersonating.Error()})
+			return
+		}
+		c.Next()
+	}
+}
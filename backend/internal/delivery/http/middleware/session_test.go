@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSessions keeps sessions in memory
+type stubSessions []*entities.Session
+
+func (r *stubSessions) Create(session *entities.Session) error {
+	*r = append(*r, session)
+	return nil
+}
+
+func (r *stubSessions) GetByID(id string) (*entities.Session, error) {
+	for _, session := range *r {
+		if session.ID == id {
+			return session, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *stubSessions) FindByHash(hash string) (*entities.Session, error) {
+	for _, session := range *r {
+		if session.TokenHash == hash {
+			return session, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *stubSessions) ListActiveByUser(userID string, at time.Time) ([]entities.Session, error) {
+	return nil, nil
+}
+
+func (r *stubSessions) Update(session *entities.Session) error {
+	return nil
+}
+
+func (r *stubSessions) RevokeOthers(userID, keepID string, at time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *stubSessions) ListImpersonations(since time.Time) ([]entities.Session, error) {
+	return nil, nil
+}
+
+func TestSessions_BranchComesFromTheUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	branch := "branch-1"
+	users := stubUsers{
+		"librarian-1": {ID: "librarian-1", TenantID: "tenant-1", Role: entities.UserRoleLibrarian, BranchID: &branch},
+		"librarian-2": {ID: "librarian-2", TenantID: "tenant-1", Role: entities.UserRoleLibrarian},
+	}
+	branchSecret, librarySecret := entities.NewSessionSecret(), entities.NewSessionSecret()
+	sessions := stubSessions{
+		{ID: "session-1", UserID: "librarian-1", TokenHash: entities.HashAccessToken(branchSecret), ExpiresAt: time.Now().Add(time.Hour)},
+		{ID: "session-2", UserID: "librarian-2", TokenHash: entities.HashAccessToken(librarySecret), ExpiresAt: time.Now().Add(time.Hour)},
+	}
+
+	router := gin.New()
+	router.Use(Sessions(usecase.NewSessionUseCase(&sessions, users, 24*time.Hour)))
+	router.PUT("/api/books/:id", func(c *gin.Context) {
+		c.String(http.StatusOK, "%s|%s|%s", c.GetHeader(RoleHeader), c.GetHeader(TenantHeader), c.GetHeader(BranchHeader))
+	})
+	put := func(secret string, headers map[string]string) string {
+		req := httptest.NewRequest(http.MethodPut, "/api/books/book-1", nil)
+		req.Header.Set("Authorization", "Bearer "+secret)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		return w.Body.String()
+	}
+
+	// Leaving the branch out, or naming another, does not lift the restriction to the user's branch
+	assert.Equal(t, "librarian|tenant-1|branch-1", put(branchSecret, nil))
+	assert.Equal(t, "librarian|tenant-1|branch-1", put(branchSecret, map[string]string{BranchHeader: "branch-2"}))
+	// Nor can staff of the whole library claim a branch
+	assert.Equal(t, "librarian|tenant-1|", put(librarySecret, map[string]string{BranchHeader: "branch-2"}))
+}
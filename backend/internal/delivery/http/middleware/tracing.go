@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"library-management-system/internal/infrastructure/tracing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TraceIDKey is the gin context key holding the trace ID of a request
+const TraceIDKey = "trace_id"
+
+// Tracing continues the trace of a request's traceparent header, or starts one, as a span of its
+// own carried by the request context so outgoing calls propagate it. The response returns the
+// span in traceparent and its trace ID in X-Trace-ID.
+func Tracing() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		span, ok := tracing.FromHeader(c.Request.Header)
+		if ok {
+			span = span.Child()
+		} else {
+			span = tracing.New()
+		}
+
+		c.Request = c.Request.WithContext(tracing.ContextWith(c.Request.Context(), span))
+		c.Set(TraceIDKey, span.TraceID)
+		c.Header(tracing.HeaderTraceparent, span.Traceparent())
+		c.Header(tracing.HeaderTraceID, span.TraceID)
+		c.Next()
+	}
+}
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/infrastructure/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// URLTokenHeader carries the access token or captcha response URLGuard may require
+const URLTokenHeader = "X-URL-Token"
+
+// TokenVerifier checks the token of a request, given the client IP
+type TokenVerifier interface {
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
+
+// RateLimiter limits the number of events per key in time windows, such as ratelimit.Limiter in
+// memory or ratelimit.RedisLimiter shared by every instance
+type RateLimiter interface {
+	Allow(key string) ratelimit.Decision
+	Limit() int
+	Window() time.Duration
+}
+
+// URLGuardStats describes the protection of a URLGuard and the requests it turned away
+type URLGuardStats struct {
+	// IPLimit is the number of requests an IP may make per window, zero when unlimited
+	IPLimit       int   `json:"ip_limit"`
+	WindowSeconds int   `json:"window_seconds"`
+	TokenRequired bool  `json:"token_required"`
+	RejectedTotal int64 `json:"rejected_total"`
+	// Rejected counts the rejected requests by error code
+	Rejected map[string]int64 `json:"rejected"`
+}
+
+// URLGuard protects the anonymous URL processor from abuse with a per-IP rate limit and an
+// optional token or captcha requirement, counting the requests it rejects
+type URLGuard struct {
+	limiter  RateLimiter
+	verifier TokenVerifier
+
+	mu       sync.Mutex
+	rejected map[string]int64
+}
+
+// NewURLGuard creates a guard; a nil limiter disables the rate limit and a nil verifier the
+// token requirement
+func NewURLGuard(limiter RateLimiter, verifier TokenVerifier) *URLGuard {
+	return &URLGuard{
+		limiter:  limiter,
+		verifier: verifier,
+		rejected: make(map[string]int64),
+	}
+}
+
+// Handler returns the middleware enforcing the guard. The rate limit is checked first, so
+// clients cannot make the service verify captcha responses faster than they may call it.
+func (g *URLGuard) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if g.limiter != nil {
+			decision := g.limiter.Allow(c.ClientIP())
+			c.Header("X-RateLimit-Limit", strconv.Itoa(decision.Limit))
+			c.Header("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+			if !decision.Allowed {
+				retryAfter := int(decision.ResetsAt.Sub(timeNow()).Seconds()) + 1
+				c.Header("Retry-After", strconv.Itoa(retryAfter))
+				g.reject(c, domainerr.ErrRateLimited)
+				return
+			}
+		}
+
+		if g.verifier != nil {
+			token := c.GetHeader(URLTokenHeader)
+			if token == "" {
+				g.reject(c, domainerr.ErrURLTokenRequired)
+				return
+			}
+			ok, err := g.verifier.Verify(c.Request.Context(), token, c.ClientIP())
+			if err != nil {
+				log.Printf("Failed to verify URL token: %v", err)
+				g.reject(c, domainerr.ErrTokenCheckUnavailable)
+				return
+			}
+			if !ok {
+				g.reject(c, domainerr.ErrInvalidURLToken)
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// Stats returns the protection settings and rejection counts of the guard
+func (g *URLGuard) Stats() URLGuardStats {
+	stats := URLGuardStats{TokenRequired: g.verifier != nil, Rejected: make(map[string]int64)}
+	if g.limiter != nil {
+		stats.IPLimit = g.limiter.Limit()
+		stats.WindowSeconds = int(g.limiter.Window().Seconds())
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for code, count := range g.rejected {
+		stats.Rejected[code] = count
+		stats.RejectedTotal += count
+	}
+	return stats
+}
+
+// reject aborts a request with a domain error and counts it
+func (g *URLGuard) reject(c *gin.Context, err *domainerr.Error) {
+	g.mu.Lock()
+	g.rejected[err.Code]++
+	g.mu.Unlock()
+
+	c.Error(err)
+	c.AbortWithStatusJSON(err.Status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Media type of v2 responses
+const (
+	// V2MediaType in the Accept header selects v2 responses on the unversioned prefix
+	V2MediaType = "application/vnd.library.v2+json"
+)
+
+// APIVersionHeader tells clients which response format they got
+const APIVersionHeader = "API-Version"
+
+// Envelope is the body of successful v2 responses
+type Envelope struct {
+	Data json.RawMessage `json:"data"`
+	Meta *Meta           `json:"meta,omitempty"`
+}
+
+// Meta describes the collection in an envelope: its size, or its position for paginated results
+type Meta struct {
+	Count      *int `json:"count,omitempty"`
+	Page       *int `json:"page,omitempty"`
+	PageSize   *int `json:"page_size,omitempty"`
+	Total      *int `json:"total,omitempty"`
+	TotalPages *int `json:"total_pages,omitempty"`
+}
+
+// APIV2 serves v2 responses: successful JSON bodies are wrapped in an Envelope with pagination
+// meta, and errors become problem+json. Handlers keep producing v1 bodies, which this rewrites,
+// so both versions share the same handlers and use cases. With always set every request gets v2,
+// as on the /v2 prefix; otherwise only requests accepting V2MediaType do. Non-JSON responses such
+// as CSV or PDF downloads pass through untouched. Fields are named as naming picks for the request,
+// the envelope and problem documents included.
+func APIV2(docsURL string, always bool, naming FieldNamingPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !always {
+			c.Header("Vary", "Accept")
+			if !strings.Contains(c.GetHeader("Accept"), V2MediaType) {
+				c.Next()
+				return
+			}
+		}
+		// The naming of fields follows the Accept header, and the tenant when tenants have their own
+		if len(naming.Tenants) > 0 {
+			c.Header("Vary", "Accept, "+TenantHeader)
+		} else {
+			c.Header("Vary", "Accept")
+		}
+
+		c.Header(APIVersionHeader, "2")
+		fieldNaming := naming.naming(c)
+		rewriteResponse(c, func(status int, body []byte) (interface{}, string) {
+			var out interface{}
+			contentType := ""
+			if status >= http.StatusBadRequest {
+				out, contentType = problemOf(c, status, body, docsURL), ProblemMediaType
+			} else {
+				out = envelope(body)
+			}
+			if fieldNaming == CamelCase {
+				if data, err := json.Marshal(out); err == nil {
+					out = json.RawMessage(renameFields(data, camelCase))
+				}
+			}
+			return out, contentType
+		})
+	}
+}
+
+// rewriteResponse runs the handlers with a buffered writer, then writes what rewrite makes of a
+// JSON body instead, with its content type if not empty. Empty and non-JSON bodies, such as CSV or
+// PDF downloads, and bodies rewrite returns nil for are written unchanged.
+func rewriteResponse(c *gin.Context, rewrite func(status int, body []byte) (interface{}, string)) {
+	original := c.Writer
+	buffered := &bufferedWriter{ResponseWriter: original}
+	c.Writer = buffered
+	defer func() { c.Writer = original }()
+
+	c.Next()
+
+	status := buffered.Status()
+	body := buffered.body.Bytes()
+	if len(body) > 0 && strings.HasPrefix(original.Header().Get("Content-Type"), "application/json") {
+		if out, contentType := rewrite(status, body); out != nil {
+			if data, err := json.Marshal(out); err == nil {
+				if contentType != "" {
+					original.Header().Set("Content-Type", contentType)
+				}
+				body = data
+			}
+		}
+	}
+	original.WriteHeader(status)
+	original.Write(body)
+}
+
+// envelope wraps a v1 body: arrays get their count, pages their items and pagination meta
+func envelope(body []byte) Envelope {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return Envelope{Data: json.RawMessage("null")}
+	}
+
+	switch trimmed[0] {
+	case '[':
+		var items []json.RawMessage
+		if json.Unmarshal(trimmed, &items) == nil {
+			count := len(items)
+			return Envelope{Data: trimmed, Meta: &Meta{Count: &count}}
+		}
+	case '{':
+		var page struct {
+			Items    json.RawMessage `json:"items"`
+			Page     *int            `json:"page"`
+			PageSize *int            `json:"page_size"`
+			Total    *int            `json:"total"`
+		}
+		if json.Unmarshal(trimmed, &page) == nil && page.Page != nil && page.PageSize != nil && page.Total != nil &&
+			len(bytes.TrimSpace(page.Items)) > 0 && bytes.TrimSpace(page.Items)[0] == '[' {
+			totalPages := 0
+			if *page.PageSize > 0 {
+				totalPages = (*page.Total + *page.PageSize - 1) / *page.PageSize
+			}
+			return Envelope{Data: page.Items, Meta: &Meta{Page: page.Page, PageSize: page.PageSize, Total: page.Total, TotalPages: &totalPages}}
+		}
+	}
+	return Envelope{Data: trimmed}
+}
+
+// bufferedWriter holds a response back so it can be rewritten once the handler is done
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body   bytes.Buffer
+	status int
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+// Flush holds streamed responses back too; they are written at once when the handler is done
+func (w *bufferedWriter) Flush() {}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.status != 0 || w.body.Len() > 0
+}
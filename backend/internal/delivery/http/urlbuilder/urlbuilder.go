@@ -0,0 +1,52 @@
+// Package urlbuilder makes the absolute links the API returns from the configured public base
+// URL of the service. Hosts are never taken from request headers, which clients and proxies
+// control.
+package urlbuilder
+
+import (
+	"strings"
+
+	"library-management-system/internal/domain/urlnorm"
+)
+
+// Builder builds links on a base URL. Without a base URL, or on a nil Builder, links stay
+// relative to the host the request was made to.
+type Builder struct {
+	// base is the prefix of links, an absolute URL or, for relative links, a path or nothing
+	base     string
+	absolute bool
+}
+
+// New creates a builder for baseURL, e.g. https://library.example.com; an empty baseURL gives
+// relative links
+func New(baseURL string) (*Builder, error) {
+	if baseURL == "" {
+		return &Builder{}, nil
+	}
+	base, err := urlnorm.BaseURL(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	return &Builder{base: base, absolute: true}, nil
+}
+
+// Absolute reports whether links are absolute, i.e. a base URL is configured
+func (b *Builder) Absolute() bool {
+	return b != nil && b.absolute
+}
+
+// Under returns a builder for links under path, e.g. the API prefix
+func (b *Builder) Under(path string) *Builder {
+	return &Builder{base: b.URL(path), absolute: b.Absolute()}
+}
+
+// URL returns the link to path, which starts with a slash
+func (b *Builder) URL(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if b == nil {
+		return path
+	}
+	return b.base + path
+}
@@ -0,0 +1,31 @@
+package urlbuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder(t *testing.T) {
+	links, err := New("https://Library.Example.com/")
+	require.NoError(t, err)
+	assert.True(t, links.Absolute())
+	assert.Equal(t, "https://library.example.com/api/url/sitemap/1", links.URL("/api/url/sitemap/1"))
+	assert.Equal(t, "https://library.example.com/api/books/by-slug/dune", links.Under("/api").URL("books/by-slug/dune"))
+
+	_, err = New("library.example.com")
+	assert.Error(t, err)
+}
+
+func TestBuilder_Relative(t *testing.T) {
+	links, err := New("")
+	require.NoError(t, err)
+	assert.False(t, links.Absolute())
+	assert.Equal(t, "/api/books/by-slug/dune", links.Under("/api").URL("/books/by-slug/dune"))
+	assert.False(t, links.Under("/api").Absolute(), "relative builders stay relative")
+
+	var none *Builder
+	assert.False(t, none.Absolute())
+	assert.Equal(t, "/api/url/sitemap/1", none.URL("/api/url/sitemap/1"))
+}
@@ -0,0 +1,56 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_Next(t *testing.T) {
+	// Wednesday
+	from := time.Date(2024, time.January, 17, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{expr: "* * * * *", expected: time.Date(2024, time.January, 17, 10, 31, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", expected: time.Date(2024, time.January, 17, 10, 45, 0, 0, time.UTC)},
+		{expr: "0 8 * * mon", expected: time.Date(2024, time.January, 22, 8, 0, 0, 0, time.UTC)},
+		{expr: "0 8 * * 1-5", expected: time.Date(2024, time.January, 18, 8, 0, 0, 0, time.UTC)},
+		{expr: "30 10 17 1 *", expected: time.Date(2025, time.January, 17, 10, 30, 0, 0, time.UTC)},
+		{expr: "0 0 1,15 * *", expected: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 feb *", expected: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 9 * * 7", expected: time.Date(2024, time.January, 21, 9, 0, 0, 0, time.UTC)},
+		{expr: "@daily", expected: time.Date(2024, time.January, 18, 0, 0, 0, 0, time.UTC)},
+		// Restricted day of month and day of week match either
+		{expr: "0 0 20 * mon", expected: time.Date(2024, time.January, 20, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 30 2 *", expected: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, schedule.Next(from))
+		})
+	}
+}
+
+func TestSchedule_NextKeepsLocation(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	schedule, err := Parse("0 8 * * *")
+	require.NoError(t, err)
+
+	next := schedule.Next(time.Date(2024, time.January, 17, 9, 0, 0, 0, jakarta))
+	assert.Equal(t, time.Date(2024, time.January, 18, 8, 0, 0, 0, jakarta), next)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "* * * 13 *", "*/0 * * * *", "5-1 * * * *", "0 0 * * funday"} {
+		_, err := Parse(expr)
+		assert.Error(t, err, expr)
+	}
+}
@@ -0,0 +1,27 @@
+package document
+
+import "io"
+
+// Document is a printable report: a title followed by sections of tabular rows or free lines
+type Document struct {
+	Title    string
+	Subtitle string
+	Sections []Section
+}
+
+// Section is a block of a document. Rows are rendered under Columns; Lines are rendered as is
+type Section struct {
+	Heading string
+	Columns []string
+	Rows    [][]string
+	Lines   []string
+}
+
+// Renderer turns documents into a downloadable format
+type Renderer interface {
+	// ContentType is the MIME type of the rendered output
+	ContentType() string
+	// Extension is the file extension of the rendered output, without the dot
+	Extension() string
+	Render(w io.Writer, doc Document) error
+}
@@ -0,0 +1,213 @@
+package domainerr
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+)
+
+// Error is a domain error with a stable, machine-readable code. Its message is what the API
+// returns in the "error" field, so clients can map a response back to its code.
+type Error struct {
+	Code        string
+	Status      int
+	Message     string
+	Description string
+}
+
+// Error returns the message of the error
+func (e *Error) Error() string {
+	return e.Message
+}
+
+var (
+	catalog   = map[string]*Error{}
+	byMessage = map[string]*Error{}
+)
+
+// define registers an error in the catalog; codes and messages must be unique
+func define(code string, status int, message, description string) *Error {
+	if _, ok := catalog[code]; ok {
+		panic("domainerr: duplicate error code " + code)
+	}
+	if _, ok := byMessage[message]; ok {
+		panic("domainerr: duplicate error message " + message)
+	}
+	e := &Error{Code: code, Status: status, Message: message, Description: description}
+	catalog[code] = e
+	byMessage[message] = e
+	return e
+}
+
+// Catalog returns every defined error sorted by code
+func Catalog() []*Error {
+	list := make([]*Error, 0, len(catalog))
+	for _, e := range catalog {
+		list = append(list, e)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Code < list[j].Code
+	})
+	return list
+}
+
+// Lookup returns the defined error wrapped in err, if any
+func Lookup(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// ByMessage returns the defined error with the given message, for responses that only carry
+// the message of an error
+func ByMessage(message string) (*Error, bool) {
+	e, ok := byMessage[message]
+	return e, ok
+}
+
+// Resources that were not found
+var (
+	ErrBookNotFound             = define("book_not_found", http.StatusNotFound, "book not found", "The book does not exist or has been deleted.")
+	ErrPublisherNotFound        = define("publisher_not_found", http.StatusNotFound, "publisher not found", "The publisher does not exist.")
+	ErrParentPublisherNotFound  = define("parent_publisher_not_found", http.StatusBadRequest, "parent publisher not found", "The parent publisher given for an imprint does not exist.")
+	ErrSeriesNotFound           = define("series_not_found", http.StatusNotFound, "series not found", "The series does not exist.")
+	ErrWorkNotFound             = define("work_not_found", http.StatusNotFound, "work not found", "The work does not exist.")
+	ErrCollectionNotFound       = define("collection_not_found", http.StatusNotFound, "collection not found", "The collection does not exist, or the share link has been revoked.")
+	ErrAcquisitionNotFound      = define("acquisition_not_found", http.StatusNotFound, "acquisition not found", "The acquisition request does not exist.")
+	ErrInventorySessionNotFound = define("inventory_session_not_found", http.StatusNotFound, "inventory session not found", "The inventory session does not exist.")
+	ErrNotificationNotFound     = define("notification_not_found", http.StatusNotFound, "notification not found", "The notification does not exist.")
+	ErrSubscriptionNotFound     = define("subscription_not_found", http.StatusNotFound, "subscription not found", "The report subscription does not exist.")
+	ErrDeadLetterNotFound       = define("dead_letter_not_found", http.StatusNotFound, "dead letter not found", "The dead letter does not exist or has already been requeued or discarded.")
+	ErrBookDraftNotFound        = define("book_draft_not_found", http.StatusNotFound, "book draft not found", "The book has no draft; save one with PUT /api/books/{id}/draft.")
+	ErrMetadataFieldNotFound    = define("metadata_field_not_found", http.StatusNotFound, "metadata field not found", "The tenant has not defined this metadata field.")
+	ErrValidationRuleNotFound   = define("validation_rule_not_found", http.StatusNotFound, "validation rule not found", "The validation rule does not exist.")
+	ErrImportRunNotFound        = define("import_run_not_found", http.StatusNotFound, "import run not found", "The import run does not exist.")
+	ErrImportProfileNotFound    = define("import_profile_not_found", http.StatusNotFound, "import profile not found", "The tenant has not saved an import profile with this name.")
+	ErrSitemapJobNotFound       = define("sitemap_job_not_found", http.StatusNotFound, "sitemap job not found", "The sitemap job does not exist, or is old enough to have been dropped with its artifact.")
+	ErrMemberNotFound           = define("member_not_found", http.StatusNotFound, "member not found", "The X-User-ID header does not belong to a user.")
+	ErrLoanNotFound             = define("loan_not_found", http.StatusNotFound, "loan not found", "The loan does not exist or belongs to another member.")
+	ErrCoverNotFound            = define("cover_not_found", http.StatusNotFound, "cover not found", "The book has no cover; upload one with PUT /api/books/{id}/cover.")
+	ErrBranchNotFound           = define("branch_not_found", http.StatusNotFound, "branch not found", "The branch does not exist.")
+	ErrEnrichmentJobNotFound    = define("enrichment_job_not_found", http.StatusNotFound, "enrichment job not found", "The enrichment job does not exist, or is old enough to have been dropped; its drafts and updates are kept.")
+	ErrClosedDayNotFound        = define("closed_day_not_found", http.StatusNotFound, "closed day not found", "The closed day does not exist, or has already been deleted.")
+	ErrTenantNotFound           = define("tenant_not_found", http.StatusNotFound, "tenant not found", "The X-Tenant-ID header does not belong to a tenant.")
+	ErrIdentifierNotFound       = define("identifier_not_found", http.StatusNotFound, "identifier not found", "The book has no identifier with this ID.")
+	ErrCopyNotFound             = define("copy_not_found", http.StatusNotFound, "copy not found", "No copy of a book has this accession number.")
+	ErrAccessTokenNotFound      = define("access_token_not_found", http.StatusNotFound, "access token not found", "The caller has no access token with this ID.")
+	ErrSessionNotFound          = define("session_not_found", http.StatusNotFound, "session not found", "The caller has no active session with this ID; it may have expired or been revoked.")
+	ErrImpersonationNotFound    = define("impersonation_not_found", http.StatusNotFound, "impersonation not found", "No active impersonation has this ID; it may have expired or been ended.")
+	ErrCoverImportNotFound      = define("cover_import_not_found", http.StatusNotFound, "cover import not found", "The cover import does not exist, or is old enough to have been dropped; the covers it set are kept.")
+)
+
+// Rejected uploads
+var (
+	ErrCoverTooLarge           = define("cover_too_large", http.StatusRequestEntityTooLarge, "cover image is too large", "The cover image is larger than COVERS_MAX_BYTES.")
+	ErrUnsupportedCoverType    = define("unsupported_cover_type", http.StatusUnsupportedMediaType, "cover must be a JPEG, PNG, GIF or WebP image", "The uploaded file is not a JPEG, PNG, GIF or WebP image, judging by its magic bytes.")
+	ErrCoverTypeMismatch       = define("cover_type_mismatch", http.StatusUnsupportedMediaType, "cover content does not match its content type", "The Content-Type sent names another image format than the magic bytes of the cover.")
+	ErrMalformedCover          = define("malformed_cover", http.StatusUnprocessableEntity, "cover is not a valid image", "The cover starts like an image but is not a valid one, such as a truncated file or another file behind an image signature.")
+	ErrCoverDimensionsTooLarge = define("cover_dimensions_too_large", http.StatusUnprocessableEntity, "cover image dimensions are too large", "The cover is wider or taller than COVERS_MAX_DIMENSION, or has more pixels than COVERS_MAX_PIXELS, as read from its header before it is decoded.")
+	ErrFileInfected            = define("file_infected", http.StatusUnprocessableEntity, "file contains malware", "The malware scanner found malware in the uploaded file; the upload is recorded as infected.")
+	ErrScanUnavailable         = define("scan_unavailable", http.StatusServiceUnavailable, "file could not be scanned for malware, retry later", "The malware scanner failed or timed out, and files that cannot be scanned are rejected by UPLOAD_SCAN_ON_ERROR.")
+	ErrInvalidCoverURL         = define("invalid_cover_url", http.StatusBadRequest, "cover url must be an http or https URL", "The url sent to PUT /api/books/{id}/cover is not an absolute http or https URL.")
+	ErrCoverFetchDisabled      = define("cover_fetch_disabled", http.StatusBadRequest, "fetching covers by URL is disabled", "COVERS_FETCH_ENABLED is off, so covers cannot be set from a url; upload the image instead.")
+	ErrCoverFetchFailed        = define("cover_fetch_failed", http.StatusUnprocessableEntity, "cover could not be downloaded", "The cover url could not be reached in time, answered with an error status or with a content type other than a JPEG, PNG, GIF or WebP image, sent more than COVERS_MAX_BYTES, or resolves to a private address.")
+	ErrInvalidCoverArchive     = define("invalid_cover_archive", http.StatusBadRequest, "cover archive must be a ZIP file of images", "The body of POST /api/books/covers/import is not a ZIP archive, or holds no file besides directories and hidden files.")
+	ErrCoverArchiveTooLarge    = define("cover_archive_too_large", http.StatusRequestEntityTooLarge, "cover archive is too large", "The ZIP archive is larger than COVERS_IMPORT_MAX_BYTES, or holds more images than COVERS_IMPORT_MAX_FILES.")
+	ErrNoISBNInFileName        = define("no_isbn_in_file_name", http.StatusBadRequest, "file name is not an ISBN", "Images of a cover import are named by the ISBN of their book, such as 9780141439518.jpg, and this one is not.")
+)
+
+// Conflicts with existing data
+var (
+	ErrDuplicateISBN          = define("duplicate_isbn", http.StatusBadRequest, "book with this ISBN already exists", "Another book in the catalog already has this ISBN.")
+	ErrISBNOfDeletedBook      = define("isbn_of_deleted_book", http.StatusConflict, "book with this ISBN was deleted", "A deleted book keeps its ISBN until it is purged; restore it with POST /api/books/{id}/restore, deleted_book_id giving its ID, or purge it with DELETE /api/books/{id}/permanent.")
+	ErrDuplicateIdentifier    = define("duplicate_identifier", http.StatusBadRequest, "book with this identifier already exists", "Another book already has an identifier of this type and value; deleted books keep theirs until they are purged.")
+	ErrISBNInCatalog          = define("isbn_in_catalog", http.StatusBadRequest, "book with this ISBN is already in the catalog", "An acquisition was suggested for a book the library already has.")
+	ErrDuplicatePublisherName = define("duplicate_publisher_name", http.StatusBadRequest, "publisher with this name already exists", "Another publisher already has this name.")
+	ErrDuplicateBranchName    = define("duplicate_branch_name", http.StatusBadRequest, "branch with this name already exists", "Another branch already has this name.")
+	ErrDuplicateSeriesName    = define("duplicate_series_name", http.StatusBadRequest, "series with this name already exists", "Another series already has this name.")
+	ErrSeriesPositionTaken    = define("series_position_taken", http.StatusBadRequest, "series position is already taken", "Another book already has this position in the series.")
+	ErrBookInCollection       = define("book_in_collection", http.StatusBadRequest, "book is already in the collection", "The book has already been added to the collection.")
+	ErrStaleBookDraft         = define("stale_book_draft", http.StatusConflict, "book changed since the draft was saved", "The book was updated after its draft was saved; save the draft again on top of the current book before publishing it.")
+	ErrBookArchived           = define("book_archived", http.StatusConflict, "book is archived", "Archived books cannot be edited; make the book active again first.")
+	ErrDuplicateEmail         = define("duplicate_email", http.StatusConflict, "user with this email already exists", "Another user already signs in with this email, in the same or another case.")
+	ErrSitemapNotReady        = define("sitemap_not_ready", http.StatusConflict, "sitemap is not ready", "The sitemap job has not completed, so there is no rewritten sitemap to download yet.")
+	ErrUpsertConflict         = define("upsert_conflict", http.StatusConflict, "book changed on the server since base_updated_at", "The book was updated on the server after the base_updated_at of the upsert and conflict_policy=server-wins kept it as it is, or it was updated again while the upsert was being written; the conflicts list the fields that differ.")
+	ErrDuplicateImport        = define("duplicate_import", http.StatusConflict, "identical file was already imported", "The same file was imported within the import duplicate window; the earlier run is in GET /api/imports/{id}.")
+	ErrBookNotDeleted         = define("book_not_deleted", http.StatusConflict, "book is not deleted", "Only deleted books can be restored by POST /api/books/batch-restore; the book is in the catalog.")
+	ErrMemberAnonymized       = define("member_anonymized", http.StatusConflict, "member account has been anonymized", "The personal details of the deactivated account were erased after MEMBER_RETENTION_DAYS or on request, so it cannot be reactivated.")
+)
+
+// Batches
+var (
+	ErrBatchAborted = define("batch_aborted", http.StatusFailedDependency, "not written as other items of the atomic batch failed", "The item was valid, but the batch was sent with atomic=true and other items failed, so none of it was written.")
+)
+
+// Circulation rules
+var (
+	ErrLoanReturned        = define("loan_returned", http.StatusConflict, "loan has already been returned", "Returned loans cannot be renewed or returned again.")
+	ErrRenewalLimitReached = define("renewal_limit_reached", http.StatusConflict, "loan has reached its renewal limit", "The loan was renewed as many times as the max_renewals policy of the tenant allows; return the book by its due date.")
+	ErrLoanOnHold          = define("loan_on_hold", http.StatusConflict, "book is on hold for another member", "Other members are waiting for the book, so the loan cannot be renewed; return the book by its due date.")
+	ErrLoanLimitReached    = define("loan_limit_reached", http.StatusConflict, "member has reached their loan limit", "The member has as many active loans as the max_active_loans policy of the tenant allows; staff can lend past it with an override_reason.")
+	ErrBookOnHold          = define("book_on_hold", http.StatusConflict, "book is on hold for other members", "Other members are waiting for the book, so it is kept for them; staff can lend it anyway with an override_reason.")
+	ErrBookOnLoan          = define("book_on_loan", http.StatusConflict, "book has active loans", "The book is out on loan, so it cannot be deleted until every loan of it is returned.")
+	ErrMemberDeactivated   = define("member_deactivated", http.StatusForbidden, "member account is deactivated", "The member's account is deactivated, so they cannot borrow or renew until an admin reactivates it; their loans and fines are kept.")
+	ErrMemberHasLoans      = define("member_has_loans", http.StatusConflict, "member has active loans", "The member still has books on loan, so their account cannot be erased until every loan is returned.")
+	ErrOverrideNotAllowed  = define("override_not_allowed", http.StatusForbidden, "only staff can override circulation policies", "An override_reason was sent by a caller other than a librarian or admin, by X-User-Role, or without the X-User-ID the override is recorded under.")
+)
+
+// Capacity limits
+var (
+	ErrQuotaExceeded    = define("quota_exceeded", http.StatusTooManyRequests, "monthly quota exceeded", "The caller has used up its monthly request quota.")
+	ErrServerBusy       = define("server_busy", http.StatusServiceUnavailable, "server is busy, retry later", "Background work is backed up; retry the write after the Retry-After delay.")
+	ErrQueueFull        = define("queue_full", http.StatusServiceUnavailable, "job queue is full", "The background job could not be queued; retry later.")
+	ErrSearchBusy       = define("search_busy", http.StatusTooManyRequests, "too many expensive searches, retry later", "The caller already has as many expensive searches running and waiting as allowed; retry after the Retry-After delay.")
+	ErrOperationsBusy   = define("operations_busy", http.StatusTooManyRequests, "too many expensive operations running, retry later", "The server already runs and queues as many large imports and reports as allowed; the response tells how many are running and queued, retry after the Retry-After delay.")
+	ErrDatabaseReadOnly = define("database_read_only", http.StatusServiceUnavailable, "database is read-only, retry later", "The database is refusing writes, e.g. after a replica failover or while its disk is full; reads are still served, retry the write after the Retry-After delay.")
+	ErrPageSizeTooLarge = define("page_size_too_large", http.StatusBadRequest, "page size is too large", "The listing does not serve pages that large; the response tells the largest page size, and GET /api/config/public lists those of every listing.")
+)
+
+// Pagination cursors
+var (
+	ErrInvalidCursor  = define("invalid_cursor", http.StatusBadRequest, "invalid cursor", "The cursor is malformed, was altered, or was not issued by this deployment; start the listing again without cursor.")
+	ErrCursorMismatch = define("cursor_mismatch", http.StatusBadRequest, "cursor belongs to another listing or query", "The cursor was issued for another listing, or for the same listing with other filters; send it with the query it came from, or start again without cursor.")
+)
+
+// Abuse protection of anonymous endpoints
+var (
+	ErrRateLimited           = define("rate_limited", http.StatusTooManyRequests, "too many requests, slow down", "The client IP made too many requests to the endpoint; retry after the Retry-After delay.")
+	ErrURLTokenRequired      = define("url_token_required", http.StatusUnauthorized, "url token required", "URL processing requires an access token or captcha response in the X-URL-Token header.")
+	ErrInvalidURLToken       = define("invalid_url_token", http.StatusUnauthorized, "invalid url token", "The X-URL-Token header is not an accepted access token or a valid captcha response.")
+	ErrTokenCheckUnavailable = define("token_check_unavailable", http.StatusServiceUnavailable, "token verification is unavailable", "The captcha provider could not be reached to verify the X-URL-Token header; retry later.")
+)
+
+// Deployment setup
+var (
+	ErrBootstrapDisabled   = define("bootstrap_disabled", http.StatusNotFound, "bootstrap is disabled", "No setup token is configured, so the deployment cannot be bootstrapped over the API.")
+	ErrInvalidSetupToken   = define("invalid_setup_token", http.StatusUnauthorized, "invalid setup token", "The X-Setup-Token header does not match the configured setup token.")
+	ErrAlreadyBootstrapped = define("already_bootstrapped", http.StatusConflict, "deployment is already bootstrapped", "The deployment already has users; bootstrap only runs once.")
+)
+
+// Admin access
+var (
+	ErrInvalidCredentials = define("invalid_credentials", http.StatusUnauthorized, "invalid credentials", "The email and password do not belong to an active user, or on admin routes to an active admin.")
+	ErrIPNotAllowed       = define("ip_not_allowed", http.StatusForbidden, "client IP is not allowed", "Admin routes and deletions only accept requests from the networks in ADMIN_ALLOWED_CIDRS.")
+	ErrSignInLockedOut    = define("sign_in_locked_out", http.StatusTooManyRequests, "too many failed sign-ins, retry later", "The client IP or account failed to sign in too often and is banned for a while, longer after each ban; retry after the Retry-After delay or ask an admin to lift the ban.")
+	ErrBanNotFound        = define("ban_not_found", http.StatusNotFound, "ban not found", "The key is not banned, or its ban has already ended.")
+)
+
+// Personal access tokens and sessions
+var (
+	ErrInvalidAccessToken          = define("invalid_access_token", http.StatusUnauthorized, "invalid access token", "The bearer token in the Authorization header is unknown, expired or revoked, or its user no longer exists or is deactivated.")
+	ErrInsufficientScope           = define("insufficient_scope", http.StatusForbidden, "access token lacks the scope of this request", "The access token was not granted the scope the route needs, named in the WWW-Authenticate header: books:read or books:write for books and copies, url:process for the URL processor, admin:* for any other route.")
+	ErrScopeNotAllowed             = define("scope_not_allowed", http.StatusForbidden, "only admins can grant the admin:* scope", "A user other than an admin asked for a token with the admin:* scope, which reaches every route.")
+	ErrImpersonationNotAllowed     = define("impersonation_not_allowed", http.StatusForbidden, "only admins can impersonate, and only members", "The caller is not an active admin, or the user to impersonate is staff.")
+	ErrForbiddenWhileImpersonating = define("forbidden_while_impersonating", http.StatusForbidden, "not allowed while impersonating", "Impersonation sessions cannot create or revoke the access tokens or sessions of the member they act as, or deactivate their account.")
+	ErrInvalidSession              = define("invalid_session", http.StatusUnauthorized, "invalid session", "The session token in the Authorization header is unknown, expired or revoked, or its user no longer exists or is deactivated; sign in again with POST /api/auth/sessions.")
+)
+
+// Branch access
+var (
+	ErrBranchAccessDenied = define("branch_access_denied", http.StatusForbidden, "book belongs to another branch", "Librarians of a branch, named by the X-User-Branch header, can only change the books of their branch; books of other branches and of the whole library are left to their staff and to admins.")
+)
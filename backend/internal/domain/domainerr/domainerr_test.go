@@ -0,0 +1,52 @@
+package domainerr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog_SortedByCode(t *testing.T) {
+	codes := []string{}
+	for _, e := range Catalog() {
+		codes = append(codes, e.Code)
+		assert.NotEmpty(t, e.Message, e.Code)
+		assert.NotEmpty(t, e.Description, e.Code)
+		assert.NotZero(t, e.Status, e.Code)
+	}
+	assert.IsIncreasing(t, codes)
+	assert.Contains(t, codes, "book_not_found")
+}
+
+func TestLookup(t *testing.T) {
+	e, ok := Lookup(fmt.Errorf("get book: %w", ErrBookNotFound))
+	assert.True(t, ok)
+	assert.Equal(t, "book_not_found", e.Code)
+	assert.EqualError(t, ErrBookNotFound, "book not found")
+
+	_, ok = Lookup(errors.New("book not found"))
+	assert.False(t, ok)
+}
+
+func TestByMessage(t *testing.T) {
+	e, ok := ByMessage("book not found")
+	assert.True(t, ok)
+	assert.Same(t, ErrBookNotFound, e)
+
+	_, ok = ByMessage("internal server error")
+	assert.False(t, ok)
+}
+
+func TestDefine_RejectsDuplicateCodes(t *testing.T) {
+	assert.Panics(t, func() {
+		define("book_not_found", 404, "book not found", "duplicate")
+	})
+}
+
+func TestDefine_RejectsDuplicateMessages(t *testing.T) {
+	assert.Panics(t, func() {
+		define("missing_book", 404, "book not found", "duplicate")
+	})
+}
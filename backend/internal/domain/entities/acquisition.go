@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Acquisition statuses, from a member's suggestion to the book arriving in the catalog
+const (
+	AcquisitionSuggested = "suggested"
+	AcquisitionApproved  = "approved"
+	AcquisitionRejected  = "rejected"
+	AcquisitionOrdered   = "ordered"
+	AcquisitionReceived  = "received"
+	AcquisitionCancelled = "cancelled"
+)
+
+// acquisitionTransitions lists the statuses an acquisition can move to from each status
+var acquisitionTransitions = map[string][]string{
+	AcquisitionSuggested: {AcquisitionApproved, AcquisitionRejected},
+	AcquisitionApproved:  {AcquisitionOrdered, AcquisitionCancelled},
+	AcquisitionOrdered:   {AcquisitionReceived, AcquisitionCancelled},
+}
+
+// Acquisition represents a book going through the purchasing workflow
+type Acquisition struct {
+	ID     string `json:"id" gorm:"primaryKey;type:uuid"`
+	Title  string `json:"title" gorm:"not null"`
+	Author string `json:"author" gorm:"not null"`
+	Year   int    `json:"year,omitempty"`
+	ISBN   string `json:"isbn,omitempty"`
+	Note   string `json:"note,omitempty"`
+	Status string `json:"status" gorm:"not null;index"`
+	// SuggestedBy is the member who submitted the suggestion
+	SuggestedBy string `json:"suggested_by" gorm:"not null;index"`
+	// ReviewedBy is the librarian who last moved the acquisition along
+	ReviewedBy string `json:"reviewed_by,omitempty"`
+	Vendor     string `json:"vendor,omitempty"`
+	// Reason explains a rejection or cancellation
+	Reason string `json:"reason,omitempty"`
+	// BookID is the catalog book the acquisition was received as
+	BookID     *string    `json:"book_id,omitempty" gorm:"type:uuid"`
+	OrderedAt  *time.Time `json:"ordered_at,omitempty"`
+	ReceivedAt *time.Time `json:"received_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is called before creating a new acquisition
+func (a *Acquisition) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the Acquisition entity
+func (Acquisition) TableName() string {
+	return "acquisitions"
+}
+
+// CanMoveTo reports whether the acquisition can move from its current status to the given one
+func (a *Acquisition) CanMoveTo(status string) bool {
+	for _, next := range acquisitionTransitions[a.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAcquisitionStatus reports whether the status is a known acquisition status
+func IsAcquisitionStatus(status string) bool {
+	switch status {
+	case AcquisitionSuggested, AcquisitionApproved, AcquisitionRejected,
+		AcquisitionOrdered, AcquisitionReceived, AcquisitionCancelled:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package entities
+
+import "time"
+
+// Kinds of analytics events
+const (
+	// AnalyticsEndpoint events count the requests routed to an endpoint, named by its method and route
+	AnalyticsEndpoint = "endpoint"
+	// AnalyticsFeature events count the requests using an optional feature of the API
+	AnalyticsFeature = "feature"
+)
+
+// AnalyticsEvent counts how often an endpoint or feature was used in an hour. Events are
+// anonymous: they name routes and features, never the callers or the values they sent.
+type AnalyticsEvent struct {
+	Hour  time.Time `json:"hour" gorm:"primaryKey"`
+	Kind  string    `json:"kind" gorm:"primaryKey;size:20"`
+	Name  string    `json:"name" gorm:"primaryKey;size:255"`
+	Count int64     `json:"count" gorm:"not null;default:0"`
+}
+
+// TableName returns the table name for the AnalyticsEvent entity
+func (AnalyticsEvent) TableName() string {
+	return "analytics_events"
+}
+
+// AnalyticsCount is how often an endpoint or feature was used over a report
+type AnalyticsCount struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// AnalyticsReport summarizes the use of the API since a time, most used first
+type AnalyticsReport struct {
+	// Enabled is false when ANALYTICS_ENABLED opted out; events recorded before are still reported
+	Enabled   bool             `json:"enabled"`
+	Since     time.Time        `json:"since"`
+	Requests  int64            `json:"requests"`
+	Endpoints []AnalyticsCount `json:"endpoints"`
+	Features  []AnalyticsCount `json:"features"`
+}
@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Audit actions recorded for books
+const (
+	AuditActionCreated       = "created"
+	AuditActionUpdated       = "updated"
+	AuditActionDeleted       = "deleted"
+	AuditActionRestored      = "restored"
+	AuditActionHardDeleted   = "hard_deleted"
+	AuditActionPublished     = "published"
+	AuditActionStatusChanged = "status_changed"
+)
+
+// Audit entries of requests the admin IP allowlist turned away, one entity per route
+const (
+	AuditEntityRoute      = "route"
+	AuditActionIPRejected = "ip_rejected"
+)
+
+// Audit entries of circulation policies staff overrode, one entity per loan
+const (
+	AuditEntityLoan             = "loan"
+	AuditActionPolicyOverridden = "policy_overridden"
+)
+
+// Audit entries of the deactivation, reactivation and anonymization of accounts, one entity per user
+const (
+	AuditEntityUser        = "user"
+	AuditActionDeactivated = "deactivated"
+	AuditActionReactivated = "reactivated"
+	AuditActionAnonymized  = "anonymized"
+)
+
+// Audit entries of admins impersonating members, one entity per impersonation session
+const (
+	AuditEntitySession              = "session"
+	AuditActionImpersonationStarted = "impersonation_started"
+	AuditActionImpersonationEnded   = "impersonation_ended"
+	AuditActionImpersonatedRequest  = "impersonated_request"
+)
+
+// AuditEntry records a change made to an entity
+type AuditEntry struct {
+	ID         string    `json:"id" gorm:"primaryKey;type:uuid"`
+	EntityType string    `json:"entity_type" gorm:"not null;index:idx_audit_entries_entity"`
+	EntityID   string    `json:"entity_id" gorm:"not null;index:idx_audit_entries_entity"`
+	Action     string    `json:"action" gorm:"not null"`
+	Actor      string    `json:"actor,omitempty"`
+	Changes    string    `json:"changes,omitempty" gorm:"type:text"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime;index"`
+	// ImpersonatorID flags the changes an admin made while impersonating the actor
+	ImpersonatorID string `json:"impersonator_id,omitempty" gorm:"size:64;index"`
+}
+
+// BeforeCreate is called before creating a new audit entry
+func (a *AuditEntry) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the AuditEntry entity
+func (AuditEntry) TableName() string {
+	return "audit_entries"
+}
+
+// AuditWriterStats is the state of the write-behind buffer of audit entries
+type AuditWriterStats struct {
+	// Enabled is false when audit entries are written during requests
+	Enabled  bool  `json:"enabled"`
+	Buffered int   `json:"buffered"`
+	Capacity int   `json:"capacity"`
+	Written  int64 `json:"written"`
+	// DroppedFull is the number of entries dropped because the buffer was full
+	DroppedFull int64 `json:"dropped_full"`
+	// DroppedFailed is the number of entries dropped after every attempt to write their batch failed
+	DroppedFailed int64 `json:"dropped_failed"`
+	// FailedWrites is the number of batch writes that failed, retried or not
+	FailedWrites int64 `json:"failed_writes"`
+}
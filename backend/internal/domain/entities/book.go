@@ -1,32 +1,133 @@
 package entities
 
 import (
+	"strings"
 	"time"
 
-	"github.com/google/uuid"
+	"library-management-system/internal/domain/urlnorm"
+
 	"gorm.io/gorm"
 )
 
-// Book represents a book entity
+// maxSlugLength bounds the slugs derived from book titles
+const maxSlugLength = 80
+
+// Book statuses. Drafts are being prepared and are hidden from public listings; archived books
+// stay listed but are off the shelf and can no longer be edited.
+const (
+	BookStatusDraft    = "draft"
+	BookStatusActive   = "active"
+	BookStatusArchived = "archived"
+)
+
+// bookStatusTransitions lists the statuses a book can move to from each status
+var bookStatusTransitions = map[string][]string{
+	BookStatusDraft:    {BookStatusActive},
+	BookStatusActive:   {BookStatusArchived},
+	BookStatusArchived: {BookStatusActive},
+}
+
+// Book represents a book entity. Fields tagged schema:"read_only" are set by the server, which
+// the schema of books tells clients along with what GORM keys and timestamps imply.
 type Book struct {
-	ID        string     `json:"id" gorm:"primaryKey;type:uuid"`
-	Title     string     `json:"title" gorm:"not null;index"`
-	Author    string     `json:"author" gorm:"not null;index"`
-	Year      int        `json:"year" gorm:"not null;index"`
-	ISBN      string     `json:"isbn" gorm:"uniqueIndex;not null"`
-	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime;index"`
-	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"index"`
+	ID     string `json:"id" gorm:"primaryKey;type:uuid"`
+	Title  string `json:"title" gorm:"not null;index"`
+	Author string `json:"author" gorm:"not null;index"`
+	Year   int    `json:"year" gorm:"not null;index"`
+	ISBN   string `json:"isbn" gorm:"uniqueIndex;not null"`
+	// Slug names the book in page URLs; it is derived from the title and unique
+	Slug           string  `json:"slug" gorm:"size:255;uniqueIndex" schema:"read_only"`
+	Description    string  `json:"description,omitempty" gorm:"type:text"`
+	PublisherID    *string `json:"publisher_id,omitempty" gorm:"type:uuid;index"`
+	SeriesID       *string `json:"series_id,omitempty" gorm:"type:uuid;uniqueIndex:idx_books_series_position"`
+	SeriesPosition *int    `json:"series_position,omitempty" gorm:"uniqueIndex:idx_books_series_position"`
+	WorkID         *string `json:"work_id,omitempty" gorm:"type:uuid;index" schema:"read_only"`
+	Status         string  `json:"status" gorm:"size:20;not null;default:active;index"`
+	// BranchID is the branch the book belongs to, nil for books of the whole library
+	BranchID *string `json:"branch_id,omitempty" gorm:"type:uuid;index"`
+	// Metadata holds the custom fields of the library as string, number or boolean values
+	Metadata map[string]interface{} `json:"metadata,omitempty" gorm:"serializer:json;type:jsonb"`
+	// CoverPalette holds the most common colours of the cover, the dominant one first, as #rrggbb
+	// strings; it is set along with the cover, and empty for WebP covers
+	CoverPalette []string `json:"cover_palette,omitempty" gorm:"serializer:json;type:jsonb" schema:"read_only"`
+	// PublishAt schedules a new book: it is hidden from public listings until then, and cleared
+	// once the book goes live
+	PublishAt *time.Time `json:"publish_at,omitempty" gorm:"index"`
+	// Popularity ranks books by their recent views and loans; it is refreshed daily from the
+	// book stats and only used to order search results
+	Popularity int64      `json:"-" gorm:"not null;default:0;index"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime;index"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty" gorm:"index" schema:"read_only"`
 }
 
 // BeforeCreate is called before creating a new book
 func (b *Book) BeforeCreate(tx *gorm.DB) error {
 	if b.ID == "" {
-		b.ID = uuid.New().String()
+		b.ID = newID()
 	}
 	return nil
 }
 
+// SlugBase returns the slug the title of the book leads to, before it is made unique
+func (b *Book) SlugBase() string {
+	base := urlnorm.Slugify(b.Title, maxSlugLength)
+	if base == "" {
+		// Titles in non-Latin scripts have nothing to transliterate
+		return "book"
+	}
+	return base
+}
+
+// Scheduled reports whether the book is waiting for its publication
+func (b *Book) Scheduled() bool {
+	return b.PublishAt != nil
+}
+
+// Listed reports whether the book shows in public listings: it is neither scheduled nor a draft
+func (b *Book) Listed() bool {
+	return !b.Scheduled() && b.Status != BookStatusDraft
+}
+
+// MetadataMatches reports whether the book has each metadata key with the value, compared as text
+func (b *Book) MetadataMatches(filters map[string]string) bool {
+	for key, value := range filters {
+		actual, ok := b.Metadata[key]
+		if !ok || MetadataText(actual) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// CanMoveTo reports whether the book can move from its current status to the given one
+func (b *Book) CanMoveTo(status string) bool {
+	for _, next := range bookStatusTransitions[b.Status] {
+		if next == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBookStatus reports whether the status is a known book status
+func IsBookStatus(status string) bool {
+	switch status {
+	case BookStatusDraft, BookStatusActive, BookStatusArchived:
+		return true
+	}
+	return false
+}
+
+// isbnSeparators are dropped from ISBNs by NormalizeISBN
+var isbnSeparators = strings.NewReplacer("-", "", " ", "")
+
+// NormalizeISBN returns the form ISBNs are stored in: without hyphens or spaces, and with the X
+// check digit of ISBN-10s upper case, so that one ISBN written two ways is a duplicate
+func NormalizeISBN(isbn string) string {
+	return strings.ToUpper(isbnSeparators.Replace(strings.TrimSpace(isbn)))
+}
+
 // TableName returns the table name for the Book entity
 func (Book) TableName() string {
 	return "books"
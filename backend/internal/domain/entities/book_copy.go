@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BookCopy is a physical copy of a book, shelved at a branch or the library as a whole and known
+// by its accession number
+type BookCopy struct {
+	ID     string `json:"id" gorm:"primaryKey;type:uuid"`
+	BookID string `json:"book_id" gorm:"type:uuid;not null;index"`
+	// BranchID is the branch holding the copy, empty for copies of the whole library
+	BranchID *string `json:"branch_id,omitempty" gorm:"type:uuid;index"`
+	// AccessionNumber is the prefix of the branch or library followed by the next number of its
+	// sequence, e.g. ACC-000042
+	AccessionNumber string    `json:"accession_number" gorm:"size:40;not null;uniqueIndex" example:"ACC-000042"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// BeforeCreate is called before creating a new book copy
+func (c *BookCopy) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the BookCopy entity
+func (BookCopy) TableName() string {
+	return "book_copies"
+}
+
+// AccessionCounter is the last accession number given with a prefix. Numbers are taken by
+// incrementing it in the database, so concurrent copies never share one; a number taken for a
+// copy that then fails to be created is skipped rather than given again.
+type AccessionCounter struct {
+	Prefix string `gorm:"primaryKey;size:20"`
+	Value  int64  `gorm:"not null"`
+}
+
+// TableName returns the table name for the AccessionCounter entity
+func (AccessionCounter) TableName() string {
+	return "accession_counters"
+}
+
+// FormatAccessionNumber writes the accession number n of a prefix, its number padded with zeros
+// to digits digits
+func FormatAccessionNumber(prefix string, n int64, digits int) string {
+	return fmt.Sprintf("%s%0*d", prefix, digits, n)
+}
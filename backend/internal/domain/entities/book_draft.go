@@ -0,0 +1,46 @@
+package entities
+
+import "time"
+
+// BookDraft holds proposed changes to a book, kept apart from the live record until they are
+// published, e.g. while enriched metadata is reviewed. A book has at most one draft.
+type BookDraft struct {
+	BookID         string                 `json:"book_id" gorm:"primaryKey;type:uuid"`
+	Title          string                 `json:"title" gorm:"not null"`
+	Author         string                 `json:"author" gorm:"not null"`
+	Year           int                    `json:"year" gorm:"not null"`
+	ISBN           string                 `json:"isbn" gorm:"not null"`
+	Description    string                 `json:"description,omitempty" gorm:"type:text"`
+	PublisherID    *string                `json:"publisher_id,omitempty" gorm:"type:uuid"`
+	SeriesID       *string                `json:"series_id,omitempty" gorm:"type:uuid"`
+	SeriesPosition *int                   `json:"series_position,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty" gorm:"serializer:json;type:jsonb"`
+	// BaseUpdatedAt is when the book had last been updated as the draft was saved; drafts of
+	// books that changed since cannot be published
+	BaseUpdatedAt time.Time `json:"base_updated_at"`
+	// Changes is the difference with the live book, filled in when the draft is read
+	Changes   map[string]interface{} `json:"changes" gorm:"-"`
+	CreatedAt time.Time              `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time              `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Book returns the proposed book fields of the draft
+func (d *BookDraft) Book() *Book {
+	return &Book{
+		ID:             d.BookID,
+		Title:          d.Title,
+		Author:         d.Author,
+		Year:           d.Year,
+		ISBN:           d.ISBN,
+		Description:    d.Description,
+		PublisherID:    d.PublisherID,
+		SeriesID:       d.SeriesID,
+		SeriesPosition: d.SeriesPosition,
+		Metadata:       d.Metadata,
+	}
+}
+
+// TableName returns the table name for the BookDraft entity
+func (BookDraft) TableName() string {
+	return "book_drafts"
+}
@@ -0,0 +1,124 @@
+package entities
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Types of the identifiers books can have besides their ISBN, for catalog items such as serials,
+// articles and e-books that lack one
+const (
+	IdentifierISSN = "issn"
+	IdentifierASIN = "asin"
+	IdentifierDOI  = "doi"
+	// IdentifierInternal is a code the library gives items, compared as written
+	IdentifierInternal = "internal"
+)
+
+// IdentifierISBN names the ISBN of books where identifiers are looked up by type; it is a field of
+// the book rather than an identifier
+const IdentifierISBN = "isbn"
+
+// IdentifierTypes lists the types of identifiers in the order they are searched
+var IdentifierTypes = []string{IdentifierISSN, IdentifierASIN, IdentifierDOI, IdentifierInternal}
+
+// maxInternalIdentifierLength bounds the internal codes of the library
+const maxInternalIdentifierLength = 64
+
+// doiPrefixes are dropped from DOIs, which are often written as links
+var doiPrefixes = []string{"https://doi.org/", "http://doi.org/", "https://dx.doi.org/", "http://dx.doi.org/", "doi:"}
+
+// BookIdentifier is an identifier of a book other than its ISBN. A value of a type identifies one
+// book; deleted books keep their identifiers until they are purged.
+type BookIdentifier struct {
+	ID     string `json:"id" gorm:"primaryKey;type:uuid"`
+	BookID string `json:"book_id" gorm:"type:uuid;not null;index"`
+	Type   string `json:"type" gorm:"size:20;not null;uniqueIndex:idx_book_identifiers_type_value" example:"doi"`
+	// Value is stored normalized, see NormalizeIdentifier
+	Value     string    `json:"value" gorm:"size:255;not null;uniqueIndex:idx_book_identifiers_type_value" example:"10.1000/182"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// BeforeCreate is called before creating a new book identifier
+func (i *BookIdentifier) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == "" {
+		i.ID = newID()
+	}
+	return nil
+}
+
+// IsIdentifierType reports whether kind is a type of book identifier
+func IsIdentifierType(kind string) bool {
+	for _, t := range IdentifierTypes {
+		if kind == t {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeIdentifier checks an identifier of a known type and returns the form it is stored in,
+// so that one identifier written two ways is a duplicate: ISSNs as 1234-567X, ASINs upper case,
+// DOIs lower case without their doi.org link or doi: prefix, and internal codes trimmed
+func NormalizeIdentifier(kind, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch kind {
+	case IdentifierISSN:
+		issn := strings.ToUpper(isbnSeparators.Replace(value))
+		if len(issn) != 8 || !isDigits(issn[:7]) || !(isDigits(issn[7:]) || issn[7] == 'X') {
+			return "", errors.New("ISSN must be 8 digits, the last of which may be X")
+		}
+		return issn[:4] + "-" + issn[4:], nil
+	case IdentifierASIN:
+		asin := strings.ToUpper(value)
+		if len(asin) != 10 || !isAlphanumeric(asin) {
+			return "", errors.New("ASIN must be 10 letters or digits")
+		}
+		return asin, nil
+	case IdentifierDOI:
+		doi := value
+		for _, prefix := range doiPrefixes {
+			if len(doi) >= len(prefix) && strings.EqualFold(doi[:len(prefix)], prefix) {
+				doi = doi[len(prefix):]
+				break
+			}
+		}
+		// DOIs are case-insensitive
+		doi = strings.ToLower(doi)
+		prefix, suffix, ok := strings.Cut(doi, "/")
+		if !ok || !strings.HasPrefix(prefix, "10.") || suffix == "" || len(doi) > 255 {
+			return "", errors.New("DOI must look like 10.1000/182")
+		}
+		return doi, nil
+	case IdentifierInternal:
+		if value == "" || len(value) > maxInternalIdentifierLength {
+			return "", errors.New("internal code must be between 1 and 64 characters")
+		}
+		return value, nil
+	default:
+		return "", errors.New("unknown identifier type")
+	}
+}
+
+// isDigits reports whether s only has ASCII digits
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isAlphanumeric reports whether s only has ASCII letters and digits
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeIdentifier(t *testing.T) {
+	tests := []struct {
+		kind, value string
+		expected    string
+		err         string
+	}{
+		{kind: IdentifierISSN, value: "0317 847x", expected: "0317-847X"},
+		{kind: IdentifierISSN, value: "0317-8471", expected: "0317-8471"},
+		{kind: IdentifierISSN, value: "0317-84", err: "ISSN must be 8 digits, the last of which may be X"},
+		{kind: IdentifierISSN, value: "X317-8471", err: "ISSN must be 8 digits, the last of which may be X"},
+		{kind: IdentifierASIN, value: "b000fc1pji", expected: "B000FC1PJI"},
+		{kind: IdentifierASIN, value: "B000-FC1PJ", err: "ASIN must be 10 letters or digits"},
+		{kind: IdentifierDOI, value: "https://doi.org/10.1000/ABC.182", expected: "10.1000/abc.182"},
+		{kind: IdentifierDOI, value: "DOI:10.1000/182", expected: "10.1000/182"},
+		{kind: IdentifierDOI, value: "10.1000", err: "DOI must look like 10.1000/182"},
+		{kind: IdentifierDOI, value: "11.1000/182", err: "DOI must look like 10.1000/182"},
+		{kind: IdentifierInternal, value: " Ref-007 ", expected: "Ref-007"},
+		{kind: IdentifierInternal, value: "  ", err: "internal code must be between 1 and 64 characters"},
+		{kind: IdentifierISBN, value: "9780306406157", err: "unknown identifier type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind+" "+tt.value, func(t *testing.T) {
+			value, err := NormalizeIdentifier(tt.kind, tt.value)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package entities
+
+import "time"
+
+// BookDailyStats counts how often a book was viewed and loaned on a day
+type BookDailyStats struct {
+	BookID    string    `json:"book_id" gorm:"primaryKey;type:uuid"`
+	Day       time.Time `json:"day" gorm:"primaryKey;type:date"`
+	Views     int64     `json:"views" gorm:"not null;default:0"`
+	Loans     int64     `json:"loans" gorm:"not null;default:0"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for the BookDailyStats entity
+func (BookDailyStats) TableName() string {
+	return "book_daily_stats"
+}
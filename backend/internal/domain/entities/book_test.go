@@ -208,3 +208,9 @@ func TestBook_SoftDelete(t *testing.T) {
 	assert.NotNil(t, book.DeletedAt)
 	assert.Equal(t, deletedAt, *book.DeletedAt)
 }
+
+func TestNormalizeISBN(t *testing.T) {
+	assert.Equal(t, "9780306406157", NormalizeISBN("978-0-306-40615-7"))
+	assert.Equal(t, "080442957X", NormalizeISBN(" 0 8044 2957 x"))
+	assert.Equal(t, "9780306406157", NormalizeISBN("9780306406157"))
+}
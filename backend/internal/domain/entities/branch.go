@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Branch is a location of the library. Books may belong to one, and the librarians of a branch
+// can only change its books.
+type Branch struct {
+	ID   string `json:"id" gorm:"primaryKey;type:uuid"`
+	Name string `json:"name" gorm:"not null;uniqueIndex"`
+	// Timezone is the IANA timezone of the branch, empty when it is in the timezone of its tenant
+	Timezone string `json:"timezone,omitempty" gorm:"size:64"`
+	// AccessionPrefix starts the accession numbers of the branch's copies, which then have a
+	// sequence of their own; empty when they share the library's
+	AccessionPrefix string    `json:"accession_prefix,omitempty" gorm:"size:20" example:"HB-"`
+	CreatedAt       time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is called before creating a new branch
+func (b *Branch) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == "" {
+		b.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the Branch entity
+func (Branch) TableName() string {
+	return "branches"
+}
+
+// Actor is the caller a change is made for, as far as record-level access and validation go. The
+// zero actor, used by background jobs, is not restricted.
+type Actor struct {
+	UserID string
+	// Role is admin, librarian or member
+	Role string
+	// BranchID is the branch of staff working for a single branch
+	BranchID string
+	// TenantID is the tenant whose validation profile the changes are checked with
+	TenantID string
+	// ImpersonatorID is the admin acting as the user, empty unless they impersonate them
+	ImpersonatorID string
+}
+
+// BranchScoped reports whether the actor may only change the records of their branch: the
+// librarians of a branch. Admins and librarians of the whole library are not restricted.
+func (a Actor) BranchScoped() bool {
+	return a.Role == UserRoleLibrarian && a.BranchID != ""
+}
+
+// InBranch reports whether a record of the branch, nil for library-wide records, may be changed
+// by the actor
+func (a Actor) InBranch(branchID *string) bool {
+	return !a.BranchScoped() || (branchID != nil && *branchID == a.BranchID)
+}
@@ -0,0 +1,137 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Weekdays names the days of the week business hours are kept for, indexed by time.Weekday
+var Weekdays = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+// ClosedDayLayout is the layout of the dates of closed days
+const ClosedDayLayout = "2006-01-02"
+
+// BusinessHours are the hours a branch, or the whole library, opens on a day of the week
+type BusinessHours struct {
+	ID string `json:"id" gorm:"primaryKey;type:uuid"`
+	// BranchID is the branch the hours are of, nil for the hours of the whole library
+	BranchID *string `json:"branch_id,omitempty" gorm:"type:uuid;index"`
+	// Weekday is the lowercase English name of the day, e.g. monday
+	Weekday string `json:"weekday" gorm:"size:9;not null"`
+	// Opens and Closes are HH:MM
+	Opens     string    `json:"opens" gorm:"size:5;not null"`
+	Closes    string    `json:"closes" gorm:"size:5;not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is called before creating new business hours
+func (h *BusinessHours) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == "" {
+		h.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the BusinessHours entity
+func (BusinessHours) TableName() string {
+	return "business_hours"
+}
+
+// Validate checks the weekday and that the hours are HH:MM, opening before closing
+func (h *BusinessHours) Validate() error {
+	h.Weekday = strings.ToLower(strings.TrimSpace(h.Weekday))
+	if weekday(h.Weekday) < 0 {
+		return fmt.Errorf("weekday must be one of %s", strings.Join(Weekdays, ", "))
+	}
+	opens, err := time.Parse("15:04", h.Opens)
+	if err != nil {
+		return fmt.Errorf("opens must be HH:MM, got %q", h.Opens)
+	}
+	closes, err := time.Parse("15:04", h.Closes)
+	if err != nil {
+		return fmt.Errorf("closes must be HH:MM, got %q", h.Closes)
+	}
+	if !opens.Before(closes) {
+		return fmt.Errorf("%s must open before it closes", h.Weekday)
+	}
+	return nil
+}
+
+// ClosedDay is a day a branch, or the whole library, stays closed on whatever its hours say
+type ClosedDay struct {
+	ID string `json:"id" gorm:"primaryKey;type:uuid"`
+	// BranchID is the branch that closes, nil when the whole library does
+	BranchID *string `json:"branch_id,omitempty" gorm:"type:uuid;index"`
+	// Date is YYYY-MM-DD
+	Date string `json:"date" gorm:"size:10;not null;index"`
+	Name string `json:"name"`
+	// Yearly closed days are holidays falling on the same month and day every year; the year of
+	// their date is when they were first observed
+	Yearly    bool      `json:"yearly" gorm:"not null;default:false"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// BeforeCreate is called before creating a new closed day
+func (d *ClosedDay) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the ClosedDay entity
+func (ClosedDay) TableName() string {
+	return "closed_days"
+}
+
+// Calendar is when a branch, or the whole library, is open: on the weekdays it has hours for,
+// but its closed days. A calendar without any hours is open every day but its closed days.
+type Calendar struct {
+	BranchID   *string         `json:"branch_id,omitempty"`
+	Hours      []BusinessHours `json:"hours"`
+	ClosedDays []ClosedDay     `json:"closed_days"`
+}
+
+// OpenOn reports whether the calendar is open on the day of t, in the location of t
+func (c *Calendar) OpenOn(t time.Time) bool {
+	date := t.Format(ClosedDayLayout)
+	for _, day := range c.ClosedDays {
+		if day.Date == date || (day.Yearly && day.Date[4:] == date[4:] && day.Date <= date) {
+			return false
+		}
+	}
+	if len(c.Hours) == 0 {
+		return true
+	}
+	for _, hours := range c.Hours {
+		if weekday(hours.Weekday) == int(t.Weekday()) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextOpenDay moves t forward by whole days to the first day the calendar is open, leaving it as
+// is when it already falls on one. A calendar closed for the whole year ahead leaves t as is too.
+func (c *Calendar) NextOpenDay(t time.Time) time.Time {
+	for days := 0; days <= 366; days++ {
+		if day := t.AddDate(0, 0, days); c.OpenOn(day) {
+			return day
+		}
+	}
+	return t
+}
+
+// weekday returns the time.Weekday of a day name, -1 for unknown names
+func weekday(name string) int {
+	for i, day := range Weekdays {
+		if day == name {
+			return i
+		}
+	}
+	return -1
+}
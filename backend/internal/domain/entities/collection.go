@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Collection represents a user's ordered reading list
+type Collection struct {
+	ID          string `json:"id" gorm:"primaryKey;type:uuid"`
+	OwnerID     string `json:"owner_id" gorm:"not null;index"`
+	Title       string `json:"title" gorm:"not null"`
+	Description string `json:"description"`
+	// ShareToken exposes a read-only view of the collection while it is set
+	ShareToken *string   `json:"share_token,omitempty" gorm:"uniqueIndex"`
+	CreatedAt  time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// CollectionBook places a book at a position of a collection
+type CollectionBook struct {
+	CollectionID string `gorm:"primaryKey;type:uuid"`
+	BookID       string `gorm:"primaryKey;type:uuid"`
+	Position     int    `gorm:"not null"`
+}
+
+// BeforeCreate is called before creating a new collection
+func (c *Collection) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the Collection entity
+func (Collection) TableName() string {
+	return "collections"
+}
+
+// TableName returns the table name for the CollectionBook entity
+func (CollectionBook) TableName() string {
+	return "collection_books"
+}
+
+// IsShared reports whether the collection can be viewed through its share token
+func (c *Collection) IsShared() bool {
+	return c.ShareToken != nil
+}
+
+// NewShareToken returns a new unguessable share token
+func NewShareToken() string {
+	return strings.ReplaceAll(newID(), "-", "")
+}
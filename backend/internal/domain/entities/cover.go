@@ -0,0 +1,82 @@
+package entities
+
+import "time"
+
+// CoverObject is a stored cover image, keyed by the SHA-256 of its content so that books with
+// identical covers, such as the volumes of a series, share one copy
+type CoverObject struct {
+	Hash        string `json:"hash" gorm:"primaryKey;size:64"`
+	ContentType string `json:"content_type" gorm:"not null"`
+	Size        int64  `json:"size" gorm:"not null"`
+	// RefCount is the number of books using the image, which is deleted when it drops to zero
+	RefCount int `json:"ref_count" gorm:"not null"`
+	// PerceptualHash is the difference hash of the image, close for images that look alike; it is
+	// empty for WebP images and those stored before hashes were computed
+	PerceptualHash string `json:"perceptual_hash,omitempty" gorm:"size:16;index"`
+	// Scan is the malware scan of the latest upload of the image
+	Scan      *ScanResult `json:"scan,omitempty" gorm:"serializer:json;type:jsonb"`
+	CreatedAt time.Time   `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// TableName returns the table name for the CoverObject entity
+func (CoverObject) TableName() string {
+	return "cover_objects"
+}
+
+// BookCover points a book at its cover image
+type BookCover struct {
+	BookID    string    `json:"book_id" gorm:"primaryKey;type:uuid"`
+	Hash      string    `json:"hash" gorm:"not null;size:64;index"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	// ContentType and Size describe the image, which References books share
+	ContentType    string `json:"content_type" gorm:"-"`
+	Size           int64  `json:"size" gorm:"-"`
+	References     int    `json:"references" gorm:"-"`
+	PerceptualHash string `json:"perceptual_hash,omitempty" gorm:"-"`
+	// Scan is the malware scan of the image
+	Scan *ScanResult `json:"scan,omitempty" gorm:"-"`
+}
+
+// TableName returns the table name for the BookCover entity
+func (BookCover) TableName() string {
+	return "book_covers"
+}
+
+// Kinds of groups of the cover duplicate report
+const (
+	// CoverDuplicateIdentical groups books sharing the very same image
+	CoverDuplicateIdentical = "identical"
+	// CoverDuplicateSimilar groups books whose images look alike by their perceptual hashes
+	CoverDuplicateSimilar = "similar"
+)
+
+// CoverDuplicateReport lists the groups of unrelated books whose covers are the same or look
+// alike, likely artwork attached to the wrong book. Books of one work or one series are expected
+// to share artwork and are not reported.
+type CoverDuplicateReport struct {
+	// MaxDistance is the number of bits perceptual hashes may differ by for images to look alike
+	MaxDistance int `json:"max_distance" example:"6"`
+	// Covers counts the books with a cover compared, and Unhashed those compared by content only
+	Covers      int                   `json:"covers" example:"1250"`
+	Unhashed    int                   `json:"unhashed" example:"40"`
+	Groups      []CoverDuplicateGroup `json:"groups"`
+	GeneratedAt time.Time             `json:"generated_at"`
+}
+
+// CoverDuplicateGroup is a group of unrelated books with the same or similar covers
+type CoverDuplicateGroup struct {
+	Kind string `json:"kind" example:"similar"`
+	// Distance is the largest number of bits the perceptual hashes of the group differ by
+	Distance int                  `json:"distance" example:"3"`
+	Books    []CoverDuplicateBook `json:"books"`
+}
+
+// CoverDuplicateBook is a book of a cover duplicate group, with the image of its cover
+type CoverDuplicateBook struct {
+	BookID         string `json:"book_id"`
+	Title          string `json:"title"`
+	Author         string `json:"author"`
+	ISBN           string `json:"isbn"`
+	Hash           string `json:"hash"`
+	PerceptualHash string `json:"perceptual_hash,omitempty" example:"c4d2e8f0b1a39587"`
+}
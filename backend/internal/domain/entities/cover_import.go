@@ -0,0 +1,53 @@
+package entities
+
+import "time"
+
+// Cover import job statuses
+const (
+	CoverImportQueued    = "queued"
+	CoverImportRunning   = "running"
+	CoverImportCompleted = "completed"
+	CoverImportFailed    = "failed"
+)
+
+// Outcomes of the files of a cover import
+const (
+	CoverImportFileImported = "imported"
+	CoverImportFileFailed   = "failed"
+)
+
+// CoverImportJob sets the covers of books from a ZIP archive of images named by ISBN
+type CoverImportJob struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	// Files counts the images in the archive, Imported those set as covers and Failed the others
+	Files    int `json:"files"`
+	Imported int `json:"imported"`
+	Failed   int `json:"failed"`
+	// Results reports on each image, in the order of the archive, once the job has run
+	Results     []CoverImportFile `json:"results"`
+	RequestedBy string            `json:"requested_by,omitempty"`
+	Error       string            `json:"error,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	CompletedAt *time.Time        `json:"completed_at,omitempty"`
+}
+
+// CoverImportFile is the outcome of an image of a cover import
+type CoverImportFile struct {
+	// File is the path of the image in the archive
+	File string `json:"file" example:"covers/978-0-14-143951-8.jpg"`
+	// ISBN is read from the file name, normalized
+	ISBN   string `json:"isbn" example:"9780141439518"`
+	BookID string `json:"book_id,omitempty"`
+	Status string `json:"status" example:"imported"`
+	// Code is the error code of failed images, when it is in the error catalog
+	Code  string `json:"code,omitempty" example:"malformed_cover"`
+	Error string `json:"error,omitempty"`
+}
+
+// EnsureID assigns an ID to a new job; cover import jobs are kept in memory, not stored with GORM
+func (j *CoverImportJob) EnsureID() {
+	if j.ID == "" {
+		j.ID = newID()
+	}
+}
@@ -0,0 +1,25 @@
+package entities
+
+import "time"
+
+// Database connection states
+const (
+	DatabaseUp   = "up"
+	DatabaseDown = "down"
+	// DatabaseReadOnly is a reachable database refusing writes, such as a replica after a
+	// failover or a primary whose disk is full
+	DatabaseReadOnly = "read_only"
+)
+
+// DatabaseHealth is the state of the database connection as last checked
+type DatabaseHealth struct {
+	State string `json:"state"`
+	// Since is when the connection entered its state
+	Since     time.Time `json:"since"`
+	CheckedAt time.Time `json:"checked_at"`
+	LastError string    `json:"last_error,omitempty"`
+	// Failures is the number of checks in a row that failed
+	Failures int `json:"failures"`
+	// Reconnects is the number of times the connection came back after it was lost
+	Reconnects int `json:"reconnects"`
+}
@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DeadLetter is a background job that failed all its attempts, kept so it can be inspected,
+// requeued or discarded
+type DeadLetter struct {
+	ID string `json:"id" gorm:"primaryKey;type:uuid"`
+	// Queue is the worker pool the job ran on, e.g. notifications
+	Queue string `json:"queue" gorm:"not null;size:50;index"`
+	// Kind selects the handler that runs the job from its payload
+	Kind string `json:"kind" gorm:"not null;size:100;index"`
+	Name string `json:"name" gorm:"not null;size:255"`
+	// Payload is the JSON input of the job
+	Payload     string    `json:"payload" gorm:"type:text"`
+	Error       string    `json:"error" gorm:"type:text"`
+	Attempts    int       `json:"attempts"`
+	MaxAttempts int       `json:"max_attempts"`
+	FailedAt    time.Time `json:"failed_at" gorm:"not null;index"`
+}
+
+// BeforeCreate is called before creating a new dead letter
+func (d *DeadLetter) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the DeadLetter entity
+func (DeadLetter) TableName() string {
+	return "dead_letters"
+}
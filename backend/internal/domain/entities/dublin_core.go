@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"encoding/xml"
+	"strconv"
+)
+
+// Dublin Core namespaces, as used by OAI-PMH (https://www.openarchives.org/OAI/2.0/oai_dc.xsd)
+const (
+	DublinCoreNamespace    = "http://purl.org/dc/elements/1.1/"
+	OAIDublinCoreNamespace = "http://www.openarchives.org/OAI/2.0/oai_dc/"
+)
+
+// LanguageMetadataKey is the metadata key holding the language of a book, e.g. en
+const LanguageMetadataKey = "language"
+
+// DublinCore is a book described with the fifteen elements of Simple Dublin Core, a lighter
+// interchange format than MARC that most catalogs and repositories read. Every element may repeat,
+// and elements a book has no value for are left out.
+type DublinCore struct {
+	XMLName xml.Name `json:"-" xml:"oai_dc:dc"`
+	OAIDC   string   `json:"-" xml:"xmlns:oai_dc,attr"`
+	DC      string   `json:"-" xml:"xmlns:dc,attr"`
+
+	Title       []string `json:"title,omitempty" xml:"dc:title"`
+	Creator     []string `json:"creator,omitempty" xml:"dc:creator"`
+	Subject     []string `json:"subject,omitempty" xml:"dc:subject"`
+	Description []string `json:"description,omitempty" xml:"dc:description"`
+	Publisher   []string `json:"publisher,omitempty" xml:"dc:publisher"`
+	Date        []string `json:"date,omitempty" xml:"dc:date"`
+	Type        []string `json:"type,omitempty" xml:"dc:type"`
+	Identifier  []string `json:"identifier,omitempty" xml:"dc:identifier"`
+	Language    []string `json:"language,omitempty" xml:"dc:language"`
+}
+
+// NewDublinCore describes a book in Dublin Core, with the name of its publisher if it has one.
+// The category metadata is the subject and the language metadata the language; books are
+// identified by ISBN and by ID.
+func NewDublinCore(book *Book, publisher string) *DublinCore {
+	record := &DublinCore{
+		OAIDC:   OAIDublinCoreNamespace,
+		DC:      DublinCoreNamespace,
+		Title:   []string{book.Title},
+		Creator: []string{book.Author},
+		// Text is the DCMI type of books (https://www.dublincore.org/specifications/dublin-core/dcmi-type-vocabulary/)
+		Type: []string{"Text"},
+	}
+	if category := MetadataText(book.Metadata[CategoryMetadataKey]); category != "" {
+		record.Subject = []string{category}
+	}
+	if book.Description != "" {
+		record.Description = []string{book.Description}
+	}
+	if publisher != "" {
+		record.Publisher = []string{publisher}
+	}
+	if book.Year > 0 {
+		record.Date = []string{strconv.Itoa(book.Year)}
+	}
+	if book.ISBN != "" {
+		record.Identifier = append(record.Identifier, "urn:isbn:"+book.ISBN)
+	}
+	record.Identifier = append(record.Identifier, "urn:uuid:"+book.ID)
+	if language := MetadataText(book.Metadata[LanguageMetadataKey]); language != "" {
+		record.Language = []string{language}
+	}
+	return record
+}
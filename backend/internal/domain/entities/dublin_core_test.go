@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDublinCore(t *testing.T) {
+	book := &Book{
+		ID: "3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21", Title: "Mort", Author: "Terry Pratchett", Year: 1987,
+		ISBN: "9780062225719", Description: "Death takes an apprentice.",
+		Metadata: map[string]interface{}{CategoryMetadataKey: "Fantasy", LanguageMetadataKey: "en"},
+	}
+
+	record := NewDublinCore(book, "Victor Gollancz")
+	body, err := xml.Marshal(record)
+	require.NoError(t, err)
+	assert.Equal(t, `<oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/" xmlns:dc="http://purl.org/dc/elements/1.1/">`+
+		`<dc:title>Mort</dc:title><dc:creator>Terry Pratchett</dc:creator><dc:subject>Fantasy</dc:subject>`+
+		`<dc:description>Death takes an apprentice.</dc:description><dc:publisher>Victor Gollancz</dc:publisher>`+
+		`<dc:date>1987</dc:date><dc:type>Text</dc:type><dc:identifier>urn:isbn:9780062225719</dc:identifier>`+
+		`<dc:identifier>urn:uuid:3f0c5b8e-2f6a-4d4e-9d59-1c8f0e6b7a21</dc:identifier><dc:language>en</dc:language></oai_dc:dc>`, string(body))
+
+	// Elements without a value are left out
+	record = NewDublinCore(&Book{ID: "b2", Title: "Eric", Author: "Terry Pratchett"}, "")
+	assert.Nil(t, record.Subject)
+	assert.Nil(t, record.Publisher)
+	assert.Nil(t, record.Date)
+	assert.Equal(t, []string{"urn:uuid:b2"}, record.Identifier)
+}
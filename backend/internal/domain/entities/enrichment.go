@@ -0,0 +1,67 @@
+package entities
+
+import "time"
+
+// Enrichment job statuses
+const (
+	EnrichmentJobQueued    = "queued"
+	EnrichmentJobRunning   = "running"
+	EnrichmentJobCompleted = "completed"
+	EnrichmentJobFailed    = "failed"
+)
+
+// BookMetadata is what a metadata provider knows of a book
+type BookMetadata struct {
+	Description string
+	Publisher   string
+	// CoverURL is where the cover image can be downloaded, empty without one
+	CoverURL string
+}
+
+// EnrichmentJob fills in the description, publisher and cover of the books missing them from a
+// metadata provider, saving the changes as drafts for review or applying them right away
+type EnrichmentJob struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	// Apply updates the books; otherwise their changes are saved as drafts and covers only proposed
+	Apply bool `json:"apply"`
+	// RequestedBy is the user notified of the summary when the job completes
+	RequestedBy string `json:"requested_by,omitempty"`
+	// Candidates counts the books missing a description, publisher or cover that were looked up
+	Candidates int `json:"candidates"`
+	// NotFound counts the books the provider does not know, Unchanged those it knew nothing new of
+	NotFound  int `json:"not_found"`
+	Unchanged int `json:"unchanged"`
+	Drafted   int `json:"drafted"`
+	Applied   int `json:"applied"`
+	// Skipped counts the books left alone because they already have a draft
+	Skipped int `json:"skipped"`
+	// Updates lists the books given new fields, up to a limit
+	Updates []EnrichmentUpdate `json:"updates,omitempty"`
+	// Failures lists the books that could not be enriched, up to a limit
+	Failures    []EnrichmentFailure `json:"failures,omitempty"`
+	Error       string              `json:"error,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+	CompletedAt *time.Time          `json:"completed_at,omitempty"`
+}
+
+// EnrichmentUpdate is a book given new fields by an enrichment job
+type EnrichmentUpdate struct {
+	BookID string   `json:"book_id"`
+	Fields []string `json:"fields"`
+	// CoverURL is the cover proposed for a book whose changes were drafted, drafts having no cover
+	CoverURL string `json:"cover_url,omitempty"`
+}
+
+// EnrichmentFailure is a book an enrichment job could not enrich
+type EnrichmentFailure struct {
+	BookID string `json:"book_id"`
+	Error  string `json:"error"`
+}
+
+// EnsureID assigns an ID to a new job; enrichment jobs are kept in memory, not stored with GORM
+func (j *EnrichmentJob) EnsureID() {
+	if j.ID == "" {
+		j.ID = newID()
+	}
+}
@@ -0,0 +1,58 @@
+package entities
+
+// Field types of entity schemas, as in JSON Schema
+const (
+	FieldTypeString  = "string"
+	FieldTypeInteger = "integer"
+	FieldTypeNumber  = "number"
+	FieldTypeBoolean = "boolean"
+	FieldTypeObject  = "object"
+	FieldTypeArray   = "array"
+)
+
+// EntitySchema describes the fields of an entity, so clients can build forms and filters without
+// hardcoding them
+type EntitySchema struct {
+	Entity string        `json:"entity"`
+	Fields []FieldSchema `json:"fields"`
+	// Metadata are the custom fields the tenant defined for the metadata object
+	Metadata []FieldSchema `json:"metadata"`
+	// Sorts are the orders search results can be put in with the sort parameter
+	Sorts []string `json:"sorts"`
+}
+
+// FieldSchema describes a field of an entity: its type, the constraints the built-in validation
+// and the enabled validation rules put on it, and whether it can be filtered and sorted on
+type FieldSchema struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Format refines the type, e.g. uuid or date-time
+	Format      string `json:"format,omitempty"`
+	Description string `json:"description,omitempty"`
+	Nullable    bool   `json:"nullable"`
+	Required    bool   `json:"required"`
+	// ReadOnly fields are set by the server and ignored when sent
+	ReadOnly  bool     `json:"read_only"`
+	Unique    bool     `json:"unique,omitempty"`
+	Minimum   *int     `json:"minimum,omitempty"`
+	Maximum   *int     `json:"maximum,omitempty"`
+	MinLength *int     `json:"min_length,omitempty"`
+	MaxLength *int     `json:"max_length,omitempty"`
+	Enum      []string `json:"enum,omitempty"`
+	// Rules are the enabled validation rules checking the field, some only in a category
+	Rules      []FieldRule `json:"rules,omitempty"`
+	Filterable bool        `json:"filterable"`
+	// Filter is the query parameter of searches filtering on the field
+	Filter   string `json:"filter,omitempty"`
+	Sortable bool   `json:"sortable"`
+}
+
+// FieldRule is a validation rule checking a field
+type FieldRule struct {
+	RuleID   string `json:"rule_id"`
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	Category string `json:"category,omitempty"`
+	// Message is the error books failing the rule get
+	Message string `json:"message"`
+}
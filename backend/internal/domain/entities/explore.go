@@ -0,0 +1,141 @@
+package entities
+
+// Types of the fields the query explorer exposes
+const (
+	ExploreString  = "string"
+	ExploreInteger = "integer"
+	ExploreBoolean = "boolean"
+	ExploreTime    = "time"
+)
+
+// Filter operators of the query explorer
+const (
+	ExploreEq  = "eq"
+	ExploreNe  = "ne"
+	ExploreIn  = "in"
+	ExploreGt  = "gt"
+	ExploreGte = "gte"
+	ExploreLt  = "lt"
+	ExploreLte = "lte"
+)
+
+// Aggregate functions of the query explorer
+const (
+	ExploreCount         = "count"
+	ExploreCountDistinct = "count_distinct"
+	ExploreSum           = "sum"
+	ExploreAvg           = "avg"
+	ExploreMin           = "min"
+	ExploreMax           = "max"
+)
+
+// ExploreField is a field of an entity the query explorer can filter, group or aggregate on
+type ExploreField struct {
+	Name string `json:"name"`
+	// Type is string, integer, boolean or time; month fields are strings formatted as 2006-01
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+}
+
+// ExploreEntity is an entity the query explorer can report on
+type ExploreEntity struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Fields      []ExploreField `json:"fields"`
+}
+
+// Field returns the field of the entity with a name, or nil
+func (e *ExploreEntity) Field(name string) *ExploreField {
+	for i := range e.Fields {
+		if e.Fields[i].Name == name {
+			return &e.Fields[i]
+		}
+	}
+	return nil
+}
+
+// ExploreEntities lists the entities and fields the query explorer accepts; the repository maps
+// each of them to its SQL, so a query only ever names entries of this list
+var ExploreEntities = []ExploreEntity{
+	{
+		Name:        "books",
+		Description: "Books of the catalog, excluding deleted ones",
+		Fields: []ExploreField{
+			{Name: "author", Type: ExploreString},
+			{Name: "year", Type: ExploreInteger},
+			{Name: "status", Type: ExploreString},
+			{Name: "publisher_id", Type: ExploreString},
+			{Name: "series_id", Type: ExploreString},
+			{Name: "work_id", Type: ExploreString},
+			{Name: "created_at", Type: ExploreTime},
+			{Name: "created_month", Type: ExploreString, Description: "Month the book was added"},
+		},
+	},
+	{
+		Name:        "loans",
+		Description: "Loans of every tenant, returned or not",
+		Fields: []ExploreField{
+			{Name: "tenant_id", Type: ExploreString},
+			{Name: "user_id", Type: ExploreString},
+			{Name: "book_id", Type: ExploreString},
+			{Name: "borrowed_at", Type: ExploreTime},
+			{Name: "borrowed_month", Type: ExploreString, Description: "Month the book was borrowed"},
+			{Name: "due_at", Type: ExploreTime},
+			{Name: "returned_at", Type: ExploreTime},
+			{Name: "returned", Type: ExploreBoolean, Description: "Whether the book was returned"},
+			{Name: "renewals", Type: ExploreInteger},
+		},
+	},
+	{
+		Name:        "acquisitions",
+		Description: "Acquisitions in every status of the purchasing workflow",
+		Fields: []ExploreField{
+			{Name: "status", Type: ExploreString},
+			{Name: "vendor", Type: ExploreString},
+			{Name: "suggested_by", Type: ExploreString},
+			{Name: "year", Type: ExploreInteger},
+			{Name: "created_at", Type: ExploreTime},
+			{Name: "created_month", Type: ExploreString, Description: "Month the acquisition was suggested"},
+			{Name: "received_at", Type: ExploreTime},
+		},
+	},
+}
+
+// ExploreFilter restricts the rows of a query explorer query; the value of an in filter is a list
+type ExploreFilter struct {
+	Field string      `json:"field"`
+	Op    string      `json:"op"`
+	Value interface{} `json:"value"`
+}
+
+// ExploreAggregate computes a value over the rows of each group; count takes no field
+type ExploreAggregate struct {
+	Func  string `json:"func"`
+	Field string `json:"field,omitempty"`
+}
+
+// Column returns the name of the result column of the aggregate
+func (a ExploreAggregate) Column() string {
+	if a.Field == "" {
+		return a.Func
+	}
+	return a.Func + "_" + a.Field
+}
+
+// ExploreQuery is an ad-hoc report over the fields of one entity of ExploreEntities
+type ExploreQuery struct {
+	Entity     string             `json:"entity"`
+	Filters    []ExploreFilter    `json:"filters"`
+	GroupBy    []string           `json:"group_by"`
+	Aggregates []ExploreAggregate `json:"aggregates"`
+	Limit      int                `json:"limit"`
+}
+
+// ExploreResult holds the rows of a query explorer query, groups in ascending order
+type ExploreResult struct {
+	// Columns are the group-by fields followed by the aggregates
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+	// Truncated is set when more groups than the limit matched
+	Truncated bool `json:"truncated"`
+}
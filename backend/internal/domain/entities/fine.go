@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Fine is an amount a member owes the library, e.g. for returning a loan late. The amount is left
+// out of the responses of callers other than admins and the member.
+type Fine struct {
+	ID       string `json:"id" gorm:"primaryKey;type:uuid"`
+	TenantID string `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	UserID   string `json:"user_id" gorm:"type:uuid;not null;index" redact:"owner"`
+	// LoanID is the loan the fine was charged for, if any
+	LoanID *string `json:"loan_id,omitempty" gorm:"type:uuid"`
+	// AmountCents is the amount in the smallest unit of the library's currency
+	AmountCents int64      `json:"amount_cents" gorm:"not null" redact:"fine"`
+	Reason      string     `json:"reason" gorm:"not null"`
+	PaidAt      *time.Time `json:"paid_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is called before creating a new fine
+func (f *Fine) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == "" {
+		f.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the Fine entity
+func (Fine) TableName() string {
+	return "fines"
+}
+
+// MemberFines lists a member's fines, newest first, with what is left to pay. Like the amounts of
+// the fines, what is left is only shown to admins and the member.
+type MemberFines struct {
+	Items            []Fine `json:"items"`
+	OutstandingCents int64  `json:"outstanding_cents" redact:"fine"`
+}
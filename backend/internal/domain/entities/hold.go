@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Hold statuses; a waiting or ready hold is still pending
+const (
+	HoldWaiting   = "waiting"
+	HoldReady     = "ready"
+	HoldFulfilled = "fulfilled"
+	HoldCancelled = "cancelled"
+	HoldExpired   = "expired"
+)
+
+// Hold is a member's place in the queue for a book that is out on loan
+type Hold struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:uuid"`
+	TenantID  string    `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	UserID    string    `json:"user_id" gorm:"type:uuid;not null;index"`
+	BookID    string    `json:"book_id" gorm:"type:uuid;not null;index"`
+	Status    string    `json:"status" gorm:"not null;index"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is called before creating a new hold
+func (h *Hold) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == "" {
+		h.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the Hold entity
+func (Hold) TableName() string {
+	return "holds"
+}
+
+// HoldPosition is a pending hold with its place in the queue for the book
+type HoldPosition struct {
+	Hold
+	// QueuePosition is 1 for the next member to get a copy back, and 0 once the hold is ready
+	QueuePosition int `json:"queue_position"`
+	// EstimatedAvailableAt is when a copy should come back for the hold, going by the due dates of
+	// the loans of the book. It is empty for ready holds, and when no copy is out on loan.
+	EstimatedAvailableAt *time.Time `json:"estimated_available_at,omitempty"`
+}
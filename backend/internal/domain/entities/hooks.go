@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/idgen"
+)
+
+// GORM hooks cannot receive dependencies, so the generators they use are configured here
+var (
+	idGenerator idgen.Generator = idgen.UUID{}
+	entityClock clock.Clock     = clock.System{}
+)
+
+// SetIDGenerator replaces the generator used to assign IDs to new entities
+func SetIDGenerator(generator idgen.Generator) {
+	idGenerator = generator
+}
+
+// SetClock replaces the clock used for entity timestamps
+func SetClock(c clock.Clock) {
+	entityClock = c
+}
+
+// Now returns the current time of the entity clock; it is also used as GORM's NowFunc
+func Now() time.Time {
+	return entityClock.Now()
+}
+
+// newID returns a new entity ID
+func newID() string {
+	return idGenerator.NewID()
+}
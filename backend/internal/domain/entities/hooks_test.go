@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/idgen"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetIDGenerator(t *testing.T) {
+	SetIDGenerator(idgen.NewSequence())
+	defer SetIDGenerator(idgen.UUID{})
+
+	book := &Book{}
+	notification := &Notification{}
+	assert.NoError(t, book.BeforeCreate(nil))
+	assert.NoError(t, notification.BeforeCreate(nil))
+
+	assert.Equal(t, "00000000-0000-0000-0000-000000000001", book.ID)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000002", notification.ID)
+}
+
+func TestSetClock(t *testing.T) {
+	frozen := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	fixed := clock.NewFixed(frozen)
+	SetClock(fixed)
+	defer SetClock(clock.System{})
+
+	assert.Equal(t, frozen, Now())
+
+	fixed.Advance(time.Hour)
+	assert.Equal(t, frozen.Add(time.Hour), Now())
+}
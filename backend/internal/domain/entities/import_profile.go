@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"strings"
+	"time"
+)
+
+// Book fields the columns of a CSV import can be mapped to. Columns can also be mapped to a
+// metadata key with ImportMetadataPrefix, e.g. metadata.shelf_code.
+const (
+	ImportFieldTitle       = "title"
+	ImportFieldAuthor      = "author"
+	ImportFieldYear        = "year"
+	ImportFieldISBN        = "isbn"
+	ImportFieldPublisherID = "publisher_id"
+	ImportFieldStatus      = "status"
+)
+
+// ImportMetadataPrefix prefixes the import fields that set a metadata key of the book
+const ImportMetadataPrefix = "metadata."
+
+// ImportProfile is a named column mapping of a tenant for CSV imports of books, so that a
+// recurring feed is set up once and selected by name on each import
+type ImportProfile struct {
+	TenantID string `json:"tenant_id" gorm:"primaryKey"`
+	Name     string `json:"name" gorm:"primaryKey"`
+	// Columns maps the CSV header of each imported column to a book field; other columns are ignored
+	Columns map[string]string `json:"columns" gorm:"serializer:json;type:jsonb;not null"`
+	// DateFormat is the Go time layout of the year column, e.g. 02/01/2006, when the feed has
+	// dates rather than years; empty reads plain years
+	DateFormat string `json:"date_format,omitempty"`
+	// Delimiter separates the columns, a comma when empty
+	Delimiter string    `json:"delimiter,omitempty"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for the ImportProfile entity
+func (ImportProfile) TableName() string {
+	return "import_profiles"
+}
+
+// IsImportField reports whether a CSV column can be mapped to the field
+func IsImportField(field string) bool {
+	switch field {
+	case ImportFieldTitle, ImportFieldAuthor, ImportFieldYear, ImportFieldISBN, ImportFieldPublisherID, ImportFieldStatus:
+		return true
+	}
+	return strings.HasPrefix(field, ImportMetadataPrefix) && len(field) > len(ImportMetadataPrefix)
+}
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Import run statuses
+const (
+	ImportRunCompleted = "completed"
+	ImportRunFailed    = "failed"
+)
+
+// Import file formats
+const (
+	ImportFormatJSON = "json"
+	ImportFormatCSV  = "csv"
+)
+
+// ImportRun records a bulk import of books: the hash of the file sent, so that a file imported
+// again can be recognized, and what the import did with it
+type ImportRun struct {
+	ID string `json:"id" gorm:"primaryKey;type:uuid"`
+	// TenantID is the tenant the file was imported for, empty without one
+	TenantID string `json:"tenant_id,omitempty" gorm:"size:100;index:idx_import_runs_tenant_hash"`
+	// FileHash is the hex SHA-256 of the request body
+	FileHash string `json:"file_hash" gorm:"not null;size:64;index:idx_import_runs_tenant_hash"`
+	Format   string `json:"format" gorm:"not null;size:10"`
+	// Profile is the import profile a CSV file was read with
+	Profile string `json:"profile,omitempty" gorm:"size:100"`
+	Status  string `json:"status" gorm:"not null;size:20"`
+	// Received, Imported, Skipped and Rejected count the books of a completed run
+	Received int   `json:"received"`
+	Imported int64 `json:"imported"`
+	Skipped  int64 `json:"skipped"`
+	Rejected int   `json:"rejected"`
+	// Errors lists the books a completed run rejected
+	Errors []ImportRunError `json:"errors" gorm:"serializer:json;type:jsonb"`
+	// Error is why a failed run imported nothing, or stopped partway
+	Error string `json:"error,omitempty" gorm:"type:text"`
+	// Scan is the malware scan of the file, when it was scanned
+	Scan *ScanResult `json:"scan,omitempty" gorm:"serializer:json;type:jsonb"`
+	// DuplicateOf is the earlier run of the same file, when a duplicate was let through with a warning
+	DuplicateOf *string   `json:"duplicate_of,omitempty" gorm:"type:uuid"`
+	CreatedAt   time.Time `json:"created_at" gorm:"not null;index"`
+}
+
+// ImportRunError explains why a book of an import run was rejected
+type ImportRunError struct {
+	// Index is the position of the book in the file, from 0
+	Index int    `json:"index"`
+	ISBN  string `json:"isbn,omitempty"`
+	Error string `json:"error"`
+}
+
+// BeforeCreate is called before creating a new import run
+func (r *ImportRun) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the ImportRun entity
+func (ImportRun) TableName() string {
+	return "import_runs"
+}
@@ -0,0 +1,96 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Inventory session statuses
+const (
+	InventorySessionOpen   = "open"
+	InventorySessionClosed = "closed"
+)
+
+// InventorySession is a shelf audit during which librarians scan the barcodes of the books they find
+type InventorySession struct {
+	ID       string     `json:"id" gorm:"primaryKey;type:uuid"`
+	Name     string     `json:"name" gorm:"not null"`
+	Status   string     `json:"status" gorm:"not null;index"`
+	OpenedBy string     `json:"opened_by" gorm:"not null"`
+	ClosedAt *time.Time `json:"closed_at,omitempty"`
+	// Report is the JSON reconciliation report stored when the session closes
+	Report    string    `json:"-" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;index"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// InventoryScan records a barcode scanned at a location during an inventory session
+type InventoryScan struct {
+	ID        string `json:"id" gorm:"primaryKey;type:uuid"`
+	SessionID string `json:"session_id" gorm:"type:uuid;not null;index"`
+	Barcode   string `json:"barcode" gorm:"not null"`
+	Location  string `json:"location"`
+	// BookID is the catalog book the barcode matched when it was scanned
+	BookID    *string   `json:"book_id,omitempty" gorm:"type:uuid"`
+	ScannedAt time.Time `json:"scanned_at" gorm:"autoCreateTime"`
+}
+
+// InventoryItem is a line of a reconciliation report
+type InventoryItem struct {
+	BookID           string `json:"book_id,omitempty"`
+	Title            string `json:"title,omitempty"`
+	Barcode          string `json:"barcode"`
+	ExpectedLocation string `json:"expected_location,omitempty"`
+	FoundLocation    string `json:"found_location,omitempty"`
+}
+
+// InventoryReport reconciles the scans of a session with the catalog
+type InventoryReport struct {
+	SessionID string `json:"session_id"`
+	// Final is false for previews of sessions that are still open
+	Final       bool      `json:"final"`
+	GeneratedAt time.Time `json:"generated_at"`
+	// GeneratedAtISOWeek is the ISO 8601 week of the report in the timezone it was asked in,
+	// filled in by the API and not stored
+	GeneratedAtISOWeek string `json:"generated_at_iso_week,omitempty"`
+	Scanned            int    `json:"scanned"`
+	Found              int    `json:"found"`
+	// Missing books are in the catalog but were not scanned
+	Missing []InventoryItem `json:"missing"`
+	// Misplaced books were found somewhere other than where the previous audit found them
+	Misplaced []InventoryItem `json:"misplaced"`
+	// Unexpected barcodes do not belong to any book of the catalog
+	Unexpected []InventoryItem `json:"unexpected"`
+}
+
+// BeforeCreate is called before creating a new inventory session
+func (s *InventorySession) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the InventorySession entity
+func (InventorySession) TableName() string {
+	return "inventory_sessions"
+}
+
+// IsOpen reports whether the session still accepts scans
+func (s *InventorySession) IsOpen() bool {
+	return s.Status == InventorySessionOpen
+}
+
+// BeforeCreate is called before creating a new inventory scan
+func (s *InventoryScan) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the InventoryScan entity
+func (InventoryScan) TableName() string {
+	return "inventory_scans"
+}
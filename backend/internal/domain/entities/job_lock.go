@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+// JobLock is held by the instance running a scheduled job, so replicas do not run it twice
+type JobLock struct {
+	JobName string `json:"job_name" gorm:"primaryKey;size:100"`
+	// Owner identifies the instance holding the lock
+	Owner      string    `json:"owner" gorm:"not null;size:255"`
+	AcquiredAt time.Time `json:"acquired_at" gorm:"not null"`
+	// ExpiresAt is when other instances may take the lock over
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
+}
+
+// TableName specifies the table name for the JobLock entity
+func (JobLock) TableName() string {
+	return "job_locks"
+}
@@ -0,0 +1,77 @@
+package entities
+
+import "time"
+
+// Names of the materialized stats tables, as their refreshes are recorded
+const (
+	StatsBooksPerYear  = "books_per_year"
+	StatsLoansPerMonth = "loans_per_month"
+	StatsTopAuthors    = "top_authors"
+)
+
+// StatsTables lists the materialized stats tables in the order they are refreshed
+var StatsTables = []string{StatsBooksPerYear, StatsLoansPerMonth, StatsTopAuthors}
+
+// BooksPerYear counts the listed books of the catalog published in a year
+type BooksPerYear struct {
+	Year  int   `json:"year" gorm:"primaryKey;autoIncrement:false"`
+	Books int64 `json:"books" gorm:"not null;default:0"`
+}
+
+// TableName returns the table name for the BooksPerYear entity
+func (BooksPerYear) TableName() string {
+	return "books_per_year"
+}
+
+// LoansPerMonth counts the loans made in a month, such as 2024-04, and those since returned
+type LoansPerMonth struct {
+	Month    string `json:"month" gorm:"primaryKey;size:7"`
+	Loans    int64  `json:"loans" gorm:"not null;default:0"`
+	Returned int64  `json:"returned" gorm:"not null;default:0"`
+}
+
+// TableName returns the table name for the LoansPerMonth entity
+func (LoansPerMonth) TableName() string {
+	return "loans_per_month"
+}
+
+// TopAuthor is one of the most borrowed authors, ranked from 1 by their loans, then by the books
+// of theirs the catalog lists
+type TopAuthor struct {
+	Rank   int    `json:"rank" gorm:"primaryKey;autoIncrement:false"`
+	Author string `json:"author" gorm:"not null"`
+	Books  int64  `json:"books" gorm:"not null;default:0"`
+	Loans  int64  `json:"loans" gorm:"not null;default:0"`
+}
+
+// TableName returns the table name for the TopAuthor entity
+func (TopAuthor) TableName() string {
+	return "top_authors"
+}
+
+// StatsRefresh records when a stats table was last refreshed and how many rows it got
+type StatsRefresh struct {
+	Name        string    `json:"name" gorm:"primaryKey;size:50"`
+	RefreshedAt time.Time `json:"refreshed_at" gorm:"not null"`
+	Rows        int64     `json:"rows" gorm:"not null;default:0"`
+}
+
+// TableName returns the table name for the StatsRefresh entity
+func (StatsRefresh) TableName() string {
+	return "stats_refreshes"
+}
+
+// LibraryStats are the catalog and circulation figures read from the stats tables, as of their
+// last refresh rather than live
+type LibraryStats struct {
+	// RefreshedAt is when the least recently refreshed table was refreshed, nil until every
+	// table has been refreshed once
+	RefreshedAt *time.Time `json:"refreshed_at"`
+	// Stale is set when a table was not refreshed within the maximum age of the stats
+	Stale         bool            `json:"stale"`
+	BooksPerYear  []BooksPerYear  `json:"books_per_year"`
+	LoansPerMonth []LoansPerMonth `json:"loans_per_month"`
+	TopAuthors    []TopAuthor     `json:"top_authors"`
+	// Refreshes tells when each table was last refreshed
+	Refreshes []StatsRefresh `json:"refreshes"`
+}
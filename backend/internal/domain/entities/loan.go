@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Loan is a copy of a book lent to a member; it is active until it is returned
+type Loan struct {
+	ID         string     `json:"id" gorm:"primaryKey;type:uuid"`
+	TenantID   string     `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	UserID     string     `json:"user_id" gorm:"type:uuid;not null;index"`
+	BookID     string     `json:"book_id" gorm:"type:uuid;not null;index"`
+	BorrowedAt time.Time  `json:"borrowed_at" gorm:"not null"`
+	DueAt      time.Time  `json:"due_at" gorm:"not null"`
+	ReturnedAt *time.Time `json:"returned_at,omitempty"`
+	// Renewals counts how many times the due date was pushed back
+	Renewals  int       `json:"renewals" gorm:"not null;default:0"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is called before creating a new loan
+func (l *Loan) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == "" {
+		l.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the Loan entity
+func (Loan) TableName() string {
+	return "loans"
+}
+
+// IsOverdue reports whether the loan is still out after its due date
+func (l *Loan) IsOverdue(now time.Time) bool {
+	return l.ReturnedAt == nil && now.After(l.DueAt)
+}
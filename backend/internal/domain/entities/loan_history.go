@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// ArchivedLoan is a loan returned long ago, moved out of the loans table by the loan archive job
+// so the table of current loans stays small
+type ArchivedLoan struct {
+	Loan
+	// ArchivedAt is when the loan was moved
+	ArchivedAt time.Time `json:"archived_at" gorm:"not null"`
+}
+
+// TableName returns the table name for the ArchivedLoan entity
+func (ArchivedLoan) TableName() string {
+	return "loans_history"
+}
@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"strconv"
+	"time"
+)
+
+// Metadata field types
+const (
+	MetadataTypeString  = "string"
+	MetadataTypeNumber  = "number"
+	MetadataTypeBoolean = "boolean"
+)
+
+// MetadataField defines a custom metadata key of a tenant's books. Books saved for a tenant with
+// field definitions may only carry those keys; tenants without definitions may store any key.
+type MetadataField struct {
+	TenantID string `json:"tenant_id" gorm:"primaryKey"`
+	Key      string `json:"key" gorm:"primaryKey"`
+	// Label is how the field is shown to librarians
+	Label string `json:"label,omitempty"`
+	Type  string `json:"type" gorm:"not null"`
+	// Required fields must be present on every book saved for the tenant
+	Required  bool      `json:"required"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for the MetadataField entity
+func (MetadataField) TableName() string {
+	return "metadata_fields"
+}
+
+// Accepts reports whether a metadata value has the type of the field
+func (f *MetadataField) Accepts(value interface{}) bool {
+	switch value.(type) {
+	case string:
+		return f.Type == MetadataTypeString
+	case float64:
+		return f.Type == MetadataTypeNumber
+	case bool:
+		return f.Type == MetadataTypeBoolean
+	}
+	return false
+}
+
+// IsMetadataType reports whether the type is a known metadata field type
+func IsMetadataType(fieldType string) bool {
+	switch fieldType {
+	case MetadataTypeString, MetadataTypeNumber, MetadataTypeBoolean:
+		return true
+	}
+	return false
+}
+
+// MetadataText returns the text form of a metadata value, the form search filters compare with
+func MetadataText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
@@ -0,0 +1,17 @@
+package entities
+
+// OperationStats is what was recorded of an operation, e.g. a repository method, since the
+// server started
+type OperationStats struct {
+	// Name is the operation, e.g. books.GetByID
+	Name   string `json:"name" example:"books.GetByID"`
+	Calls  int64  `json:"calls"`
+	Errors int64  `json:"errors"`
+	// ErrorRate is errors over calls
+	ErrorRate float64 `json:"error_rate"`
+	// TotalMs, AvgMs and MaxMs are the total, average and longest duration of the calls in
+	// milliseconds
+	TotalMs float64 `json:"total_ms"`
+	AvgMs   float64 `json:"avg_ms"`
+	MaxMs   float64 `json:"max_ms"`
+}
@@ -0,0 +1,7 @@
+package entities
+
+// Migration is a schema migration and whether it has been applied to the database
+type Migration struct {
+	ID      string `json:"id"`
+	Applied bool   `json:"applied"`
+}
@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Notification represents an in-app notification addressed to a single recipient
+type Notification struct {
+	ID          string     `json:"id" gorm:"primaryKey;type:uuid"`
+	RecipientID string     `json:"recipient_id" gorm:"not null;index"`
+	Type        string     `json:"type" gorm:"not null"`
+	Title       string     `json:"title" gorm:"not null"`
+	Message     string     `json:"message"`
+	BookID      *string    `json:"book_id,omitempty" gorm:"type:uuid"`
+	ReadAt      *time.Time `json:"read_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"autoCreateTime;index"`
+}
+
+// BeforeCreate is called before creating a new notification
+func (n *Notification) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == "" {
+		n.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the Notification entity
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// IsRead reports whether the notification has been marked as read
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ShiftOffHours names the time outside of every shift
+const ShiftOffHours = "off_hours"
+
+// Shift is a part of the day staff work at the desk, from Start to End in minutes after midnight.
+// A shift ending before it starts runs past midnight.
+type Shift struct {
+	Name  string
+	Start int
+	End   int
+}
+
+// ParseShift parses the span of a shift as HH:MM-HH:MM
+func ParseShift(name, span string) (Shift, error) {
+	from, to, ok := strings.Cut(span, "-")
+	if !ok {
+		return Shift{}, fmt.Errorf("shift %s must be HH:MM-HH:MM, got %q", name, span)
+	}
+	start, err := time.Parse("15:04", strings.TrimSpace(from))
+	if err != nil {
+		return Shift{}, fmt.Errorf("shift %s must be HH:MM-HH:MM, got %q", name, span)
+	}
+	end, err := time.Parse("15:04", strings.TrimSpace(to))
+	if err != nil {
+		return Shift{}, fmt.Errorf("shift %s must be HH:MM-HH:MM, got %q", name, span)
+	}
+	return Shift{Name: name, Start: start.Hour()*60 + start.Minute(), End: end.Hour()*60 + end.Minute()}, nil
+}
+
+// Contains reports whether a time of day, in minutes after midnight, is in the shift
+func (s Shift) Contains(minute int) bool {
+	if s.Start <= s.End {
+		return minute >= s.Start && minute < s.End
+	}
+	return minute >= s.Start || minute < s.End
+}
+
+// ShiftAt returns the name of the shift a time falls in, as told by its clock in its location,
+// or ShiftOffHours
+func ShiftAt(shifts []Shift, t time.Time) string {
+	minute := t.Hour()*60 + t.Minute()
+	for _, shift := range shifts {
+		if shift.Contains(minute) {
+			return shift.Name
+		}
+	}
+	return ShiftOffHours
+}
+
+// PolicyOverride is a circulation policy block staff lent or renewed past, as recorded in the
+// audit trail
+type PolicyOverride struct {
+	LoanID string `json:"loan_id"`
+	// Operation is checkout or renewal
+	Operation string `json:"operation"`
+	// Block is the code of the error the policy would have answered, e.g. loan_limit_reached
+	Block    string `json:"block"`
+	MemberID string `json:"member_id,omitempty"`
+	BookID   string `json:"book_id,omitempty"`
+	StaffID  string `json:"staff_id"`
+	Reason   string `json:"reason"`
+	// Shift is the shift of the staff at the time of the override
+	Shift        string    `json:"shift"`
+	OverriddenAt time.Time `json:"overridden_at"`
+}
+
+// StaffOverrides counts the overrides of a member of staff
+type StaffOverrides struct {
+	StaffID string `json:"staff_id"`
+	Count   int    `json:"count"`
+}
+
+// OverrideReport summarizes the policy overrides of a week, from Monday to Monday
+type OverrideReport struct {
+	// Week is the ISO week of the report, e.g. 2024-W03
+	Week  string    `json:"week"`
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+	Total int       `json:"total"`
+	// ByBlock and ByShift count the overrides by block and by shift
+	ByBlock map[string]int `json:"by_block"`
+	ByShift map[string]int `json:"by_shift"`
+	// ByStaff counts the overrides of each member of staff, the most first
+	ByStaff   []StaffOverrides `json:"by_staff"`
+	Overrides []PolicyOverride `json:"overrides"`
+}
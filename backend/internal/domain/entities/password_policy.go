@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MaxPasswordLength is the longest password bcrypt hashes in full; it ignores the bytes past it
+const MaxPasswordLength = 72
+
+// PasswordPolicy is what passwords must look like: long enough, and with the kinds of character it requires
+// swagger:model PasswordPolicy
+type PasswordPolicy struct {
+	// example: 12
+	MinLength        int  `json:"min_length"`
+	RequireUppercase bool `json:"require_uppercase"`
+	RequireLowercase bool `json:"require_lowercase"`
+	RequireDigit     bool `json:"require_digit"`
+	// Symbols are any character that is not a letter, a digit or a space
+	RequireSymbol bool `json:"require_symbol"`
+}
+
+// Check returns an error naming everything a password lacks under the policy, or nil when it complies
+func (p PasswordPolicy) Check(password string) error {
+	if len(password) > MaxPasswordLength {
+		return errors.New("password must be at most " + strconv.Itoa(MaxPasswordLength) + " bytes")
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsLetter(r) && !unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+
+	var missing []string
+	if len([]rune(password)) < p.MinLength {
+		missing = append(missing, "at least "+strconv.Itoa(p.MinLength)+" characters")
+	}
+	if p.RequireUppercase && !upper {
+		missing = append(missing, "an uppercase letter")
+	}
+	if p.RequireLowercase && !lower {
+		missing = append(missing, "a lowercase letter")
+	}
+	if p.RequireDigit && !digit {
+		missing = append(missing, "a digit")
+	}
+	if p.RequireSymbol && !symbol {
+		missing = append(missing, "a symbol")
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return errors.New("password must have " + strings.Join(missing, ", "))
+}
+
+// Validate reports whether the policy can be met by a password bcrypt hashes in full
+func (p PasswordPolicy) Validate() error {
+	if p.MinLength < 1 || p.MinLength > MaxPasswordLength {
+		return errors.New("minimum password length must be between 1 and " + strconv.Itoa(MaxPasswordLength))
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	strict := PasswordPolicy{MinLength: 10, RequireUppercase: true, RequireLowercase: true, RequireDigit: true, RequireSymbol: true}
+
+	tests := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		err      string
+	}{
+		{name: "long enough", policy: PasswordPolicy{MinLength: 12}, password: "correct horse"},
+		{name: "too short", policy: PasswordPolicy{MinLength: 12}, password: "short", err: "password must have at least 12 characters"},
+		{name: "counts characters, not bytes", policy: PasswordPolicy{MinLength: 6}, password: "ñandúé"},
+		{name: "complies with every class", policy: strict, password: "Tr0ub4dor&3"},
+		{name: "names every class missing", policy: strict, password: "correct horse", err: "password must have an uppercase letter, a digit, a symbol"},
+		{name: "spaces are not symbols", policy: PasswordPolicy{RequireSymbol: true}, password: "a b", err: "password must have a symbol"},
+		{name: "longer than bcrypt hashes", policy: PasswordPolicy{MinLength: 1}, password: strings.Repeat("a", 73), err: "password must be at most 72 bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.password)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package entities
+
+import "time"
+
+// Policy keys
+const (
+	PolicyLoanPeriodDays = "loan_period_days"
+	PolicyMaxActiveLoans = "max_active_loans"
+	PolicyMaxRenewals    = "max_renewals"
+	PolicyHoldExpiryDays = "hold_expiry_days"
+)
+
+// Policy is a circulation rule of a tenant, stored as text and read by the feature that enforces it
+type Policy struct {
+	TenantID  string    `json:"tenant_id" gorm:"primaryKey;type:uuid"`
+	Key       string    `json:"key" gorm:"primaryKey"`
+	Value     string    `json:"value" gorm:"not null"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TableName returns the table name for the Policy entity
+func (Policy) TableName() string {
+	return "policies"
+}
+
+// Bootstrap is what a fresh deployment is provisioned with: its first tenant, admin and policies
+type Bootstrap struct {
+	Tenant   Tenant   `json:"tenant"`
+	Admin    User     `json:"admin"`
+	Policies []Policy `json:"policies"`
+}
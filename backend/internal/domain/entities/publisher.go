@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Publisher represents a publishing house; a publisher with a parent is an imprint of that parent
+type Publisher struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:uuid"`
+	Name      string    `json:"name" gorm:"not null;uniqueIndex"`
+	ParentID  *string   `json:"parent_id,omitempty" gorm:"type:uuid;index"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is called before creating a new publisher
+func (p *Publisher) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == "" {
+		p.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the Publisher entity
+func (Publisher) TableName() string {
+	return "publishers"
+}
+
+// IsImprint reports whether the publisher belongs to a parent publisher
+func (p *Publisher) IsImprint() bool {
+	return p.ParentID != nil
+}
@@ -0,0 +1,60 @@
+package entities
+
+import "time"
+
+// Signals relating two books, as reasons of related books
+const (
+	RelatedSameAuthor     = "same_author"
+	RelatedSameSeries     = "same_series"
+	RelatedSharedCategory = "shared_category"
+	RelatedCoBorrowed     = "co_borrowed"
+)
+
+// RelatedBookWeights are the scores each signal adds; co-borrowing adds its weight once per member
+// who borrowed both books. A signal weighing zero is ignored.
+type RelatedBookWeights struct {
+	SameAuthor     int
+	SameSeries     int
+	SharedCategory int
+	CoBorrowed     int
+}
+
+// RelatedBook is a book related to another by the signals it shares with it, ranked from 1 by
+// its score among the books related to the other. The stats_refresh job recomputes them.
+type RelatedBook struct {
+	BookID         string `json:"-" gorm:"primaryKey;type:uuid"`
+	RelatedID      string `json:"-" gorm:"primaryKey;type:uuid"`
+	Rank           int    `json:"rank" gorm:"not null"`
+	Score          int64  `json:"score" gorm:"not null"`
+	SameAuthor     bool   `json:"-" gorm:"not null;default:false"`
+	SameSeries     bool   `json:"-" gorm:"not null;default:false"`
+	SharedCategory bool   `json:"-" gorm:"not null;default:false"`
+	// CoBorrowers counts the members who borrowed both books
+	CoBorrowers int64     `json:"co_borrowers" gorm:"not null;default:0"`
+	RefreshedAt time.Time `json:"-" gorm:"not null"`
+	// Book is the related book, loaded when the related books are read
+	Book *Book `json:"-" gorm:"-"`
+}
+
+// Reasons lists the signals relating the books
+func (r RelatedBook) Reasons() []string {
+	reasons := []string{}
+	if r.SameAuthor {
+		reasons = append(reasons, RelatedSameAuthor)
+	}
+	if r.SameSeries {
+		reasons = append(reasons, RelatedSameSeries)
+	}
+	if r.SharedCategory {
+		reasons = append(reasons, RelatedSharedCategory)
+	}
+	if r.CoBorrowers > 0 {
+		reasons = append(reasons, RelatedCoBorrowed)
+	}
+	return reasons
+}
+
+// TableName returns the table name for the RelatedBook entity
+func (RelatedBook) TableName() string {
+	return "related_books"
+}
@@ -0,0 +1,43 @@
+package entities
+
+import "time"
+
+// WeedingCandidate is a book that has not circulated recently enough to keep its shelf space
+type WeedingCandidate struct {
+	BookID string `json:"book_id"`
+	Title  string `json:"title"`
+	Author string `json:"author"`
+	Year   int    `json:"year"`
+	ISBN   string `json:"isbn"`
+	Copies int    `json:"copies"`
+	// LastLoanedAt is empty for books that were never loaned
+	LastLoanedAt *time.Time `json:"last_loaned_at,omitempty"`
+	// LastActivityAt is the latest loan or catalog change of the book
+	LastActivityAt time.Time `json:"last_activity_at"`
+	// ISO 8601 weeks of the last loan and activity, e.g. 2024-W03, in the timezone the report was
+	// asked in; filled in by the API for spreadsheet users
+	LastLoanedAtISOWeek   string `json:"last_loaned_at_iso_week,omitempty"`
+	LastActivityAtISOWeek string `json:"last_activity_at_iso_week,omitempty"`
+}
+
+// WeedingReport is one page of the books not loaned in the last Years years, stalest first
+type WeedingReport struct {
+	Years  int       `json:"years"`
+	Cutoff time.Time `json:"cutoff"`
+	// CutoffISOWeek is the ISO 8601 week of the cutoff, filled in by the API
+	CutoffISOWeek string             `json:"cutoff_iso_week,omitempty"`
+	Items         []WeedingCandidate `json:"items"`
+	Page          int                `json:"page"`
+	PageSize      int                `json:"page_size"`
+	Total         int                `json:"total"`
+}
+
+// CatalogStats summarizes catalog activity over a period
+type CatalogStats struct {
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+	TotalBooks int       `json:"total_books"`
+	Added      int       `json:"added"`
+	Updated    int       `json:"updated"`
+	Deleted    int       `json:"deleted"`
+}
@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Reports that can be subscribed to
+const (
+	ReportWeeklyStats = "weekly_stats"
+	ReportWeeding     = "weeding"
+)
+
+// Delivery channels of report subscriptions
+const (
+	ReportChannelEmail   = "email"
+	ReportChannelWebhook = "webhook"
+)
+
+// Outcomes of a report subscription run
+const (
+	ReportRunSucceeded = "succeeded"
+	ReportRunFailed    = "failed"
+)
+
+// IsSubscribableReport reports whether a report can be delivered on a schedule
+func IsSubscribableReport(report string) bool {
+	return report == ReportWeeklyStats || report == ReportWeeding
+}
+
+// ReportSubscription delivers a report to an email address or a webhook on a cron schedule
+type ReportSubscription struct {
+	ID      string `json:"id" gorm:"primaryKey;type:uuid"`
+	Report  string `json:"report" gorm:"not null"`
+	Channel string `json:"channel" gorm:"not null"`
+	// Target is the email address or the webhook URL the report is delivered to
+	Target string `json:"target" gorm:"not null"`
+	// Schedule is a five-field cron expression evaluated in the timezone of the tenant, e.g. "0 8 * * mon"
+	Schedule string `json:"schedule" gorm:"not null"`
+	// TenantID is the tenant whose timezone the schedule is in, nil for the default timezone
+	TenantID   *string    `json:"tenant_id,omitempty" gorm:"type:uuid"`
+	Enabled    bool       `json:"enabled" gorm:"not null"`
+	CreatedBy  string     `json:"created_by" gorm:"not null"`
+	NextRunAt  *time.Time `json:"next_run_at,omitempty" gorm:"index"`
+	LastRunAt  *time.Time `json:"last_run_at,omitempty"`
+	LastStatus string     `json:"last_status,omitempty"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// ReportSubscriptionRun records one delivery attempt of a subscription
+type ReportSubscriptionRun struct {
+	ID             string    `json:"id" gorm:"primaryKey;type:uuid"`
+	SubscriptionID string    `json:"subscription_id" gorm:"type:uuid;not null;index"`
+	Status         string    `json:"status" gorm:"not null"`
+	Error          string    `json:"error,omitempty"`
+	StartedAt      time.Time `json:"started_at" gorm:"not null;index"`
+	FinishedAt     time.Time `json:"finished_at" gorm:"not null"`
+}
+
+// BeforeCreate is called before creating a new report subscription
+func (s *ReportSubscription) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the ReportSubscription entity
+func (ReportSubscription) TableName() string {
+	return "report_subscriptions"
+}
+
+// BeforeCreate is called before creating a new report subscription run
+func (r *ReportSubscriptionRun) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the ReportSubscriptionRun entity
+func (ReportSubscriptionRun) TableName() string {
+	return "report_subscription_runs"
+}
@@ -0,0 +1,13 @@
+package entities
+
+import "time"
+
+// SandboxBaseline is the dataset a sandbox deployment is reset to
+// swagger:model SandboxBaseline
+type SandboxBaseline struct {
+	// Tables copied into the baseline
+	// example: 42
+	Tables int `json:"tables"`
+	// example: 2026-10-16T04:00:00Z
+	SavedAt time.Time `json:"saved_at"`
+}
@@ -0,0 +1,29 @@
+package entities
+
+import "time"
+
+// Scan statuses of uploaded files
+const (
+	// ScanClean is a file the scanner found nothing in
+	ScanClean = "clean"
+	// ScanInfected is a file the scanner found malware in, accepted only when infected files are
+	// flagged rather than rejected
+	ScanInfected = "infected"
+	// ScanFailed is a file the scanner could not check, accepted only when scan failures let files
+	// through
+	ScanFailed = "failed"
+	// ScanSkipped is a file uploaded without a scanner configured
+	ScanSkipped = "skipped"
+)
+
+// ScanResult records the malware scan of an uploaded file on its upload record
+type ScanResult struct {
+	Status string `json:"status"`
+	// Scanner names the scanner, none when scanning is off
+	Scanner string `json:"scanner"`
+	// Signature is the malware the scanner found in an infected file
+	Signature string `json:"signature,omitempty"`
+	// Error is why a failed scan failed
+	Error     string    `json:"error,omitempty"`
+	ScannedAt time.Time `json:"scanned_at"`
+}
@@ -0,0 +1,37 @@
+package entities
+
+import "time"
+
+// Outcomes of a scheduled job run
+const (
+	JobRunSucceeded = "succeeded"
+	JobRunFailed    = "failed"
+	// JobRunSkipped means the run was due while the previous one was still running
+	JobRunSkipped = "skipped"
+	// JobRunLocked means another instance held the lock of the job and ran it instead
+	JobRunLocked = "locked"
+)
+
+// ScheduledJob is the state of a recurring background job
+type ScheduledJob struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Schedule is a five-field cron expression evaluated in UTC
+	Schedule string `json:"schedule"`
+	Enabled  bool   `json:"enabled"`
+	// Priority is the priority the job runs with on the job queue
+	Priority  string     `json:"priority"`
+	Running   bool       `json:"running"`
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+	// LastDurationMs is how long the last run took, in milliseconds
+	LastDurationMs int64  `json:"last_duration_ms"`
+	LastStatus     string `json:"last_status,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	// RunCount is how many times this instance ran the job since it started
+	RunCount int64 `json:"run_count"`
+	// LockMissCount is how many runs were left to another instance holding the lock
+	LockMissCount int64 `json:"lock_miss_count"`
+	// LockErrorCount is how many runs failed because the lock could not be checked
+	LockErrorCount int64 `json:"lock_error_count"`
+}
@@ -0,0 +1,33 @@
+package entities
+
+import "time"
+
+// Outcomes of a self-test check
+const (
+	SelfTestPass = "pass"
+	SelfTestFail = "fail"
+	// SelfTestSkip is the outcome of checks of what the deployment does not use
+	SelfTestSkip = "skip"
+)
+
+// SelfTestReport is the outcome of the self-test of a deployment, passed when no check failed
+// swagger:model SelfTestReport
+type SelfTestReport struct {
+	Passed bool            `json:"passed"`
+	Checks []SelfTestCheck `json:"checks"`
+	RanAt  time.Time       `json:"ran_at"`
+}
+
+// SelfTestCheck is the outcome of one check of a self-test
+// swagger:model SelfTestCheck
+type SelfTestCheck struct {
+	// example: database
+	Name string `json:"name"`
+	// pass, fail or skip
+	// example: pass
+	Status string `json:"status"`
+	// What passed, why the check failed or why it was skipped
+	// example: connected
+	Detail     string `json:"detail,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Series orderings decide how the books of a series are listed
+const (
+	SeriesOrderingPosition    = "position"
+	SeriesOrderingPublication = "publication"
+)
+
+// Series represents a named sequence of books
+type Series struct {
+	ID   string `json:"id" gorm:"primaryKey;type:uuid"`
+	Name string `json:"name" gorm:"not null;uniqueIndex"`
+	// Ordering is either "position" (series_position) or "publication" (year)
+	Ordering  string    `json:"ordering" gorm:"not null;default:position"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is called before creating a new series
+func (s *Series) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the Series entity
+func (Series) TableName() string {
+	return "series"
+}
@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SessionTokenPrefix starts every session token, telling them apart from personal access tokens
+const SessionTokenPrefix = "lms_sess_"
+
+// Session is a device a user signed in on with their email and password. The device presents the
+// session token as a bearer token until the session expires or is revoked. Only a hash of the
+// token is stored, like those of access tokens.
+type Session struct {
+	ID     string `json:"id" gorm:"primaryKey;type:uuid"`
+	UserID string `json:"user_id" gorm:"type:uuid;not null;index"`
+	// TokenHash is the SHA-256 of the token and never leaves the server
+	TokenHash string `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	// Device is the User-Agent the session was signed in with
+	Device string `json:"device" gorm:"size:255" example:"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_5) Firefox/128.0"`
+	// IP is the client IP the session was last seen from
+	IP string `json:"ip" gorm:"size:45" example:"203.0.113.9"`
+	// LastSeenAt is updated at most once a minute
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	// ImpersonatorID is the admin who started the session to act as its user, nil for sessions the
+	// user signed in to themselves
+	ImpersonatorID *string `json:"impersonator_id,omitempty" gorm:"type:uuid;index"`
+	// ImpersonationReason is why the admin impersonates the user, such as the issue to reproduce
+	ImpersonationReason string `json:"impersonation_reason,omitempty" gorm:"size:255" example:"Reproduce ticket 4821: renewals refused"`
+}
+
+// BeforeCreate is called before creating a new session
+func (s *Session) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the Session entity
+func (Session) TableName() string {
+	return "sessions"
+}
+
+// Active reports whether the session signs requests in at a time: it is neither revoked nor expired
+func (s *Session) Active(at time.Time) bool {
+	return s.RevokedAt == nil && at.Before(s.ExpiresAt)
+}
+
+// Impersonated reports whether an admin started the session to act as its user
+func (s *Session) Impersonated() bool {
+	return s.ImpersonatorID != nil
+}
+
+// Impersonation is a session an admin started to act as a member, with the requests it changed
+// anything with
+// swagger:model Impersonation
+type Impersonation struct {
+	Session
+	// Actions are the audit entries of the changes made during the impersonation, oldest first
+	Actions []AuditEntry `json:"actions"`
+}
+
+// NewSessionSecret returns a new unguessable session token
+func NewSessionSecret() string {
+	return SessionTokenPrefix + strings.ReplaceAll(newID(), "-", "") + strings.ReplaceAll(newID(), "-", "")
+}
@@ -0,0 +1,45 @@
+package entities
+
+import "time"
+
+// Sitemap job statuses
+const (
+	SitemapJobQueued    = "queued"
+	SitemapJobRunning   = "running"
+	SitemapJobCompleted = "completed"
+	SitemapJobFailed    = "failed"
+)
+
+// SitemapJob is a batch canonicalization of the entries of a sitemap. The rewritten sitemap is
+// kept as the artifact of the job once it completes.
+type SitemapJob struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	// Source is the URL the sitemap was fetched from, or "upload"
+	Source    string `json:"source"`
+	Operation string `json:"operation,omitempty"`
+	Profile   string `json:"profile,omitempty"`
+	Entries   int    `json:"entries"`
+	// Rewritten counts the entries whose URL changed
+	Rewritten int `json:"rewritten"`
+	// Failures lists entries left unchanged because they could not be processed, up to a limit
+	Failures    []SitemapFailure `json:"failures,omitempty"`
+	Error       string           `json:"error,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+	Artifact    []byte           `json:"-"`
+}
+
+// SitemapFailure is a sitemap entry that could not be processed
+type SitemapFailure struct {
+	Loc   string `json:"loc"`
+	Error string `json:"error"`
+}
+
+// EnsureID assigns an ID to a new job; sitemap jobs are not stored with GORM, whose hooks do it
+// for other entities
+func (j *SitemapJob) EnsureID() {
+	if j.ID == "" {
+		j.ID = newID()
+	}
+}
@@ -0,0 +1,33 @@
+package entities
+
+import "time"
+
+// Storage usage levels
+const (
+	StorageOK = "ok"
+	// StorageWarning is reached at the warning percentage of the limit
+	StorageWarning = "warning"
+	// StorageExceeded is reached at the limit. Limits are soft: nothing is refused beyond them.
+	StorageExceeded = "exceeded"
+)
+
+// StorageArea is the disk space used by something the library stores, such as its database
+type StorageArea struct {
+	Name string `json:"name"`
+	// Path is the directory of the area, empty for the database
+	Path      string `json:"path,omitempty"`
+	UsedBytes int64  `json:"used_bytes"`
+	// LimitBytes is the soft quota of the area, zero without one
+	LimitBytes  int64   `json:"limit_bytes,omitempty"`
+	UsedPercent float64 `json:"used_percent,omitempty"`
+	Level       string  `json:"level"`
+	// Error is why the area could not be measured
+	Error string `json:"error,omitempty"`
+}
+
+// StorageReport is the disk usage of every storage area at a time
+type StorageReport struct {
+	CheckedAt   time.Time     `json:"checked_at"`
+	WarnPercent int           `json:"warn_percent"`
+	Areas       []StorageArea `json:"areas"`
+}
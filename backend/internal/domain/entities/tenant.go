@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Tenant is an organisation served by the deployment, e.g. a library network
+type Tenant struct {
+	ID   string `json:"id" gorm:"primaryKey;type:uuid"`
+	Slug string `json:"slug" gorm:"not null;uniqueIndex"`
+	Name string `json:"name" gorm:"not null"`
+	// Timezone is the IANA timezone due dates, stats and report schedules of the tenant are in,
+	// empty for the default timezone of the deployment
+	Timezone  string    `json:"timezone,omitempty" gorm:"size:64"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is called before creating a new tenant
+func (t *Tenant) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the Tenant entity
+func (Tenant) TableName() string {
+	return "tenants"
+}
@@ -0,0 +1,27 @@
+package entities
+
+import "time"
+
+// Timeline event types
+const (
+	TimelineTypeAudit = "audit"
+	TimelineTypeLoan  = "loan"
+)
+
+// TimelineEvent is a single entry of a book's activity stream
+type TimelineEvent struct {
+	Type       string            `json:"type"`
+	Action     string            `json:"action"`
+	Summary    string            `json:"summary"`
+	Details    map[string]string `json:"details,omitempty"`
+	OccurredAt time.Time         `json:"occurred_at"`
+}
+
+// TimelinePage is one page of a book's activity stream
+type TimelinePage struct {
+	BookID   string          `json:"book_id"`
+	Items    []TimelineEvent `json:"items"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"page_size"`
+	Total    int             `json:"total"`
+}
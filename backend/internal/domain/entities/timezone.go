@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// Where the timezone of a tenant or a branch comes from
+const (
+	TimezoneFromBranch  = "branch"
+	TimezoneFromTenant  = "tenant"
+	TimezoneFromDefault = "default"
+)
+
+// Timezone is the timezone a tenant, or one of its branches, works out its days in: that of the
+// branch, else that of the tenant, else the default timezone of the deployment
+type Timezone struct {
+	TenantID string  `json:"tenant_id,omitempty"`
+	BranchID *string `json:"branch_id,omitempty"`
+	// Name is the IANA name of the timezone, e.g. Asia/Tokyo
+	Name string `json:"timezone"`
+	// Source is branch, tenant or default
+	Source string `json:"source"`
+}
+
+// LoadTimezone loads a timezone by its IANA name. The local timezone of the server is rejected,
+// as it changes with wherever the server is deployed.
+func LoadTimezone(name string) (*time.Location, error) {
+	location, err := time.LoadLocation(name)
+	if err != nil || name == "" || name == "Local" {
+		return nil, fmt.Errorf("timezone must be an IANA name such as Asia/Tokyo, got %q", name)
+	}
+	return location, nil
+}
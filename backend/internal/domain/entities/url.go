@@ -1,14 +1,57 @@
 package entities
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
 // URLRequest represents the input for URL processing
 type URLRequest struct {
 	URL       string `json:"url"`
-	Operation string `json:"operation"`
+	Operation string `json:"operation,omitempty"`
+	// Profile names a bundle of operations to apply instead of a single operation
+	Profile string `json:"profile,omitempty"`
+	// Options tune canonical processing and lowercasing, by the canonical, redirection and all
+	// operations or within a profile
+	Options *URLOptions `json:"options,omitempty"`
+}
+
+// Root path handling of canonical URLs
+const (
+	RootPathSlash = "slash"
+	RootPathEmpty = "empty"
+)
+
+// Handling of the percent-encoded characters of paths by lowercasing operations
+const (
+	PathEncodingLowercase = "lowercase"
+	PathEncodingStrict    = "strict"
+	PathEncodingDecode    = "decode"
+)
+
+// URLOptions are the toggles of canonical processing and lowercasing; without them ports,
+// dot-segments and duplicate slashes are kept, the root path is "/" and percent-encodings are
+// lowercased with the rest of the URL
+type URLOptions struct {
+	// StripDefaultPort drops :80 from http URLs and :443 from https ones
+	StripDefaultPort bool `json:"strip_default_port,omitempty"`
+	// CollapseSlashes turns runs of slashes in the path into one
+	CollapseSlashes bool `json:"collapse_slashes,omitempty"`
+	// RemoveDotSegments resolves "." and ".." segments of the path
+	RemoveDotSegments bool `json:"remove_dot_segments,omitempty"`
+	// RootPath is slash to keep "/" for the root (default) or empty to drop it
+	RootPath string `json:"root_path,omitempty"`
+	// PathEncoding is lowercase to lowercase percent-encodings too (default), strict to keep them
+	// upper-case as RFC 3986 does, or decode to show the characters they stand for
+	PathEncoding string `json:"path_encoding,omitempty"`
 }
 
 // URLResponse represents the output for URL processing
 type URLResponse struct {
 	ProcessedURL string `json:"processed_url"`
+	// Cached is set when the result came from the cache
+	Cached bool `json:"-"`
 }
 
 // OperationType represents the type of URL processing operation
@@ -18,4 +61,41 @@ const (
 	OperationCanonical   OperationType = "canonical"
 	OperationRedirection OperationType = "redirection"
 	OperationAll         OperationType = "all"
+	// Operations used mostly through profiles
+	OperationStripTracking OperationType = "strip_tracking"
+	OperationStripFragment OperationType = "strip_fragment"
+	OperationHTTPS         OperationType = "https"
+	OperationSortQuery     OperationType = "sort_query"
 )
+
+// URLResult is a URL as it was processed, kept in the history of URL processing
+type URLResult struct {
+	ID string `json:"id" gorm:"primaryKey;type:uuid"`
+	// Key identifies the processing by the URL and the operations it resolved to; results are
+	// cached under it
+	Key string `json:"-" gorm:"size:64;not null;index"`
+	URL string `json:"url" gorm:"not null"`
+	// Host is the lower-case host of the URL, which the history is filtered by domain with
+	Host string `json:"host" gorm:"size:255;not null;default:'';index"`
+	// Operations are the operations applied in order, joined with commas
+	Operations string `json:"operations" gorm:"not null"`
+	// Profile is the profile the operations came from, if any
+	Profile      string `json:"profile,omitempty"`
+	ProcessedURL string `json:"processed_url" gorm:"not null"`
+	// Cached is set when the result was served from the cache
+	Cached    bool      `json:"cached" gorm:"not null;default:false"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime;index"`
+}
+
+// BeforeCreate is called before recording a new URL result
+func (r *URLResult) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the URLResult entity
+func (URLResult) TableName() string {
+	return "url_results"
+}
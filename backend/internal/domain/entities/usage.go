@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+// Usage represents the API consumption of a quota subject (API key or tenant) in a billing period
+type Usage struct {
+	Subject   string    `json:"subject"`
+	Period    string    `json:"period"`
+	Used      int64     `json:"used"`
+	Limit     int64     `json:"limit"`
+	Remaining int64     `json:"remaining"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
+// Exceeded reports whether the subject has used more than its quota allows
+func (u *Usage) Exceeded() bool {
+	return u.Limit > 0 && u.Used > u.Limit
+}
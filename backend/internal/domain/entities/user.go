@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// User roles
+const (
+	UserRoleAdmin     = "admin"
+	UserRoleLibrarian = "librarian"
+	UserRoleMember    = "member"
+)
+
+// User is a person who signs in to the library, staff or member. Fields tagged redact are left
+// out of the responses of callers other than admins and the user.
+type User struct {
+	ID       string `json:"id" gorm:"primaryKey;type:uuid" redact:"owner"`
+	TenantID string `json:"tenant_id" gorm:"type:uuid;not null;index"`
+	// Email is stored normalized and is unique whatever its case, see NormalizeEmail
+	Email string `json:"email" gorm:"not null;uniqueIndex" redact:"contact"`
+	Name  string `json:"name"`
+	// Phone is where the library reaches a member besides email
+	Phone string `json:"phone,omitempty" redact:"contact"`
+	Role  string `json:"role" gorm:"not null"`
+	// BranchID is the branch a librarian works for, nil for staff of the whole library. Requests
+	// signed in as the user carry it in place of any X-User-Branch header the client sent.
+	BranchID *string `json:"branch_id,omitempty" gorm:"type:uuid;index"`
+	// PasswordHash is a bcrypt hash and never leaves the server
+	PasswordHash string `json:"-" gorm:"not null"`
+	// DeactivatedAt is set while the account is deactivated: its user can neither sign in nor
+	// borrow, and their loans and fines are kept
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty" gorm:"index"`
+	// AnonymizedAt is set once the personal details of a deactivated account are erased for good
+	AnonymizedAt *time.Time `json:"anonymized_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is called before creating a new user
+func (u *User) BeforeCreate(tx *gorm.DB) error {
+	if u.ID == "" {
+		u.ID = newID()
+	}
+	return nil
+}
+
+// NormalizeEmail returns the form emails are stored and looked up in: trimmed and lower case, so
+// that one address written in two cases belongs to one user
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// TableName returns the table name for the User entity
+func (User) TableName() string {
+	return "users"
+}
+
+// anonymizedUserName replaces the names of anonymized users
+const anonymizedUserName = "Deleted member"
+
+// Active reports whether the user can sign in and borrow
+func (u *User) Active() bool {
+	return u.DeactivatedAt == nil
+}
+
+// Anonymize erases the personal details of a user for good, keeping the ID their loans and fines
+// refer to. The email is replaced by an address that cannot be delivered to, unique like emails
+// must be, and the password by none, so the account can no longer sign in.
+func (u *User) Anonymize(at time.Time) {
+	u.Name = anonymizedUserName
+	u.Email = "deleted-" + u.ID + "@anonymized.invalid"
+	u.Phone = ""
+	u.PasswordHash = ""
+	if u.DeactivatedAt == nil {
+		u.DeactivatedAt = &at
+	}
+	u.AnonymizedAt = &at
+}
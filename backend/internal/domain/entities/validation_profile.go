@@ -0,0 +1,141 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// ISBN checks of validation profiles
+const (
+	// ISBNCheckLength accepts ISBNs of 10 to 13 characters
+	ISBNCheckLength = "length"
+	// ISBNCheckChecksum accepts ISBN-10s and ISBN-13s with a valid check digit
+	ISBNCheckChecksum = "checksum"
+)
+
+// Bounds of ISBNs checked by length
+const (
+	MinISBNLength = 10
+	MaxISBNLength = 13
+)
+
+// ValidationProfile holds the thresholds of the built-in validation of books, which deployments
+// configure and tenants may override
+type ValidationProfile struct {
+	// MinYear and MaxYear bound the year of books, inclusive
+	MinYear int `json:"min_year" example:"1000"`
+	MaxYear int `json:"max_year" example:"2100"`
+	// ISBNCheck is length or checksum
+	ISBNCheck string `json:"isbn_check" example:"length"`
+	// MinTitleLength and MaxTitleLength bound the characters of titles; a MaxTitleLength of 0
+	// leaves titles unbounded
+	MinTitleLength int `json:"min_title_length" example:"1"`
+	MaxTitleLength int `json:"max_title_length" example:"500"`
+}
+
+// DefaultValidationProfile is the validation of books unless configured otherwise
+var DefaultValidationProfile = ValidationProfile{
+	MinYear:        1000,
+	MaxYear:        2100,
+	ISBNCheck:      ISBNCheckLength,
+	MinTitleLength: 1,
+}
+
+// Check reports whether the thresholds make sense: a year range and title bounds that books can
+// meet, and a known ISBN check
+func (p ValidationProfile) Check() error {
+	if p.MinYear > p.MaxYear {
+		return fmt.Errorf("min year %d is after max year %d", p.MinYear, p.MaxYear)
+	}
+	if p.ISBNCheck != ISBNCheckLength && p.ISBNCheck != ISBNCheckChecksum {
+		return fmt.Errorf("unknown ISBN check %q, expected length or checksum", p.ISBNCheck)
+	}
+	if p.MinTitleLength < 1 || (p.MaxTitleLength != 0 && p.MaxTitleLength < p.MinTitleLength) {
+		return fmt.Errorf("title lengths %d to %d leave no title valid", p.MinTitleLength, p.MaxTitleLength)
+	}
+	return nil
+}
+
+// CheckBook checks the title, year and normalized ISBN of a book against the thresholds,
+// returning the first they fail; books need an ISBN whatever the check
+func (p ValidationProfile) CheckBook(book *Book) error {
+	length := utf8.RuneCountInString(book.Title)
+	if length < p.MinTitleLength || (p.MaxTitleLength != 0 && length > p.MaxTitleLength) {
+		if p.MaxTitleLength == 0 {
+			return fmt.Errorf("book title must be at least %d characters", p.MinTitleLength)
+		}
+		return fmt.Errorf("book title must be between %d and %d characters", p.MinTitleLength, p.MaxTitleLength)
+	}
+	if book.Year < p.MinYear || book.Year > p.MaxYear {
+		return fmt.Errorf("book year must be between %d and %d", p.MinYear, p.MaxYear)
+	}
+	if book.ISBN == "" {
+		return errors.New("book ISBN is required")
+	}
+	if p.ISBNCheck == ISBNCheckChecksum {
+		if !ValidISBN(book.ISBN) {
+			return errors.New("book ISBN must be an ISBN-10 or ISBN-13 with a valid check digit")
+		}
+		return nil
+	}
+	if len(book.ISBN) < MinISBNLength || len(book.ISBN) > MaxISBNLength {
+		return fmt.Errorf("book ISBN must be between %d and %d characters", MinISBNLength, MaxISBNLength)
+	}
+	return nil
+}
+
+// ValidationProfiles holds the validation of books of the deployment and of the tenants
+// overriding it; the zero value validates every book with DefaultValidationProfile
+type ValidationProfiles struct {
+	Default ValidationProfile
+	// Tenants are complete profiles by tenant ID, the settings they do not override filled in
+	// from Default
+	Tenants map[string]ValidationProfile
+}
+
+// For returns the profile books of a tenant are validated with
+func (p ValidationProfiles) For(tenantID string) ValidationProfile {
+	if profile, ok := p.Tenants[tenantID]; ok && tenantID != "" {
+		return profile
+	}
+	if p.Default == (ValidationProfile{}) {
+		return DefaultValidationProfile
+	}
+	return p.Default
+}
+
+// ValidISBN reports whether a normalized ISBN is an ISBN-10 or ISBN-13 with a valid check digit
+func ValidISBN(isbn string) bool {
+	switch len(isbn) {
+	case 10:
+		sum := 0
+		for i := 0; i < 10; i++ {
+			var digit int
+			switch {
+			case isbn[i] >= '0' && isbn[i] <= '9':
+				digit = int(isbn[i] - '0')
+			case isbn[i] == 'X' && i == 9:
+				digit = 10
+			default:
+				return false
+			}
+			sum += digit * (10 - i)
+		}
+		return sum%11 == 0
+	case 13:
+		if !isDigits(isbn) {
+			return false
+		}
+		sum := 0
+		for i := 0; i < 13; i++ {
+			digit := int(isbn[i] - '0')
+			if i%2 == 1 {
+				digit *= 3
+			}
+			sum += digit
+		}
+		return sum%10 == 0
+	}
+	return false
+}
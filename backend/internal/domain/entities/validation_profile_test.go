@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationProfile_CheckBook(t *testing.T) {
+	strict := ValidationProfile{MinYear: 1450, MaxYear: 2100, ISBNCheck: ISBNCheckChecksum, MinTitleLength: 2, MaxTitleLength: 10}
+
+	tests := []struct {
+		name    string
+		profile ValidationProfile
+		book    Book
+		err     string
+	}{
+		{name: "default", profile: DefaultValidationProfile, book: Book{Title: "A", Year: 1000, ISBN: "1234567890"}},
+		{name: "default long ISBN", profile: DefaultValidationProfile, book: Book{Title: "A", Year: 2024, ISBN: "12345678901234"}, err: "book ISBN must be between 10 and 13 characters"},
+		{name: "missing ISBN", profile: DefaultValidationProfile, book: Book{Title: "A", Year: 2024}, err: "book ISBN is required"},
+		{name: "valid ISBN-13", profile: strict, book: Book{Title: "Hamlet", Year: 1603, ISBN: "9780306406157"}},
+		{name: "valid ISBN-10", profile: strict, book: Book{Title: "Hamlet", Year: 1603, ISBN: "080442957X"}},
+		{name: "invalid check digit", profile: strict, book: Book{Title: "Hamlet", Year: 1603, ISBN: "9780306406158"}, err: "book ISBN must be an ISBN-10 or ISBN-13 with a valid check digit"},
+		{name: "year before range", profile: strict, book: Book{Title: "Hamlet", Year: 1449, ISBN: "9780306406157"}, err: "book year must be between 1450 and 2100"},
+		{name: "title too short", profile: strict, book: Book{Title: "H", Year: 1603, ISBN: "9780306406157"}, err: "book title must be between 2 and 10 characters"},
+		{name: "title too long", profile: strict, book: Book{Title: "Hamlet, Prince", Year: 1603, ISBN: "9780306406157"}, err: "book title must be between 2 and 10 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.profile.CheckBook(&tt.book)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidationProfiles_For(t *testing.T) {
+	archive := ValidationProfile{MinYear: 1450, MaxYear: 2100, ISBNCheck: ISBNCheckChecksum, MinTitleLength: 1}
+	profiles := ValidationProfiles{Default: DefaultValidationProfile, Tenants: map[string]ValidationProfile{"archive": archive}}
+
+	assert.Equal(t, archive, profiles.For("archive"))
+	assert.Equal(t, DefaultValidationProfile, profiles.For("other"))
+	assert.Equal(t, DefaultValidationProfile, profiles.For(""))
+	assert.Equal(t, DefaultValidationProfile, ValidationProfiles{}.For("archive"))
+}
@@ -0,0 +1,69 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Kinds of validation rules
+const (
+	// ValidationRuleISBNPrefix requires the ISBN to start with a match of the rule's pattern
+	ValidationRuleISBNPrefix = "isbn_prefix"
+	// ValidationRuleRequiredMetadata requires a metadata key to be set
+	ValidationRuleRequiredMetadata = "required_metadata"
+	// ValidationRuleYearRange requires the year to be within the rule's bounds
+	ValidationRuleYearRange = "year_range"
+)
+
+// CategoryMetadataKey is the metadata key holding the category of a book, which validation
+// rules can be limited to
+const CategoryMetadataKey = "category"
+
+// IsValidationRuleKind reports whether kind is a known kind of validation rule
+func IsValidationRuleKind(kind string) bool {
+	return kind == ValidationRuleISBNPrefix || kind == ValidationRuleRequiredMetadata || kind == ValidationRuleYearRange
+}
+
+// ValidationRule is an extra check admins add to the validation of books
+type ValidationRule struct {
+	ID   string `json:"id" gorm:"primaryKey;type:uuid"`
+	Name string `json:"name" gorm:"not null"`
+	Kind string `json:"kind" gorm:"not null"`
+	// Category limits the rule to books whose category metadata equals it; empty applies the
+	// rule to every book
+	Category string `json:"category,omitempty"`
+	// Pattern is the regular expression the start of the ISBN must match, for isbn_prefix rules
+	Pattern string `json:"pattern,omitempty"`
+	// MetadataKey is the metadata key that must be set, for required_metadata rules
+	MetadataKey string `json:"metadata_key,omitempty"`
+	// MinYear and MaxYear bound the year, inclusive, for year_range rules; either may be open
+	MinYear *int `json:"min_year,omitempty"`
+	MaxYear *int `json:"max_year,omitempty"`
+	// Message replaces the default error message of the rule
+	Message   string    `json:"message,omitempty"`
+	Enabled   bool      `json:"enabled" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// AppliesTo reports whether the book is in the category the rule is limited to, if any
+func (r *ValidationRule) AppliesTo(book *Book) bool {
+	if r.Category == "" {
+		return true
+	}
+	return MetadataText(book.Metadata[CategoryMetadataKey]) == r.Category
+}
+
+// BeforeCreate is called before creating a new validation rule
+func (r *ValidationRule) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the ValidationRule entity
+func (ValidationRule) TableName() string {
+	return "validation_rules"
+}
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Work represents an abstract creative work; books linked to a work are its editions
+type Work struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:uuid"`
+	Title     string    `json:"title" gorm:"not null;index"`
+	Author    string    `json:"author" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// BeforeCreate is called before creating a new work
+func (w *Work) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == "" {
+		w.ID = newID()
+	}
+	return nil
+}
+
+// TableName returns the table name for the Work entity
+func (Work) TableName() string {
+	return "works"
+}
@@ -0,0 +1,33 @@
+package entities
+
+// WorkerPool is the state of a pool of background workers
+type WorkerPool struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Workers     int    `json:"workers"`
+	// Queued is the number of jobs waiting for a worker
+	Queued int `json:"queued"`
+	// Capacity is the number of jobs that can wait before producers block
+	Capacity int `json:"capacity"`
+	// Rejected is the number of jobs refused because the queue was full
+	Rejected int64 `json:"rejected"`
+	// Priorities break the jobs of the pool down by priority, highest first
+	Priorities []JobPriorityStats `json:"priorities"`
+}
+
+// JobPriorityStats counts the jobs of a priority class of a worker pool since it started
+type JobPriorityStats struct {
+	// Priority is interactive, scheduled or backfill
+	Priority string `json:"priority"`
+	// Weight is the share of the workers the priority gets while other priorities wait too
+	Weight   int   `json:"weight"`
+	Queued   int   `json:"queued"`
+	Enqueued int64 `json:"enqueued"`
+	Rejected int64 `json:"rejected"`
+	// Succeeded and Failed count the jobs done, failed ones after all their attempts
+	Succeeded int64 `json:"succeeded"`
+	Failed    int64 `json:"failed"`
+	// AvgWaitMs and MaxWaitMs are how long attempts waited for a worker, in milliseconds
+	AvgWaitMs int64 `json:"avg_wait_ms"`
+	MaxWaitMs int64 `json:"max_wait_ms"`
+}
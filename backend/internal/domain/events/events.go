@@ -0,0 +1,65 @@
+package events
+
+import "time"
+
+// EventType identifies a kind of domain event
+type EventType string
+
+const (
+	HoldAvailable         EventType = "hold.available"
+	LoanDueSoon           EventType = "loan.due_soon"
+	ChangeRequestApproved EventType = "change_request.approved"
+	// LoanRenewed carries the new due date in its "due_at" payload entry
+	LoanRenewed EventType = "loan.renewed"
+	// BookAvailabilityChanged carries the new availability in its "available" payload entry
+	BookAvailabilityChanged EventType = "book.availability_changed"
+	// StorageThresholdCrossed carries the storage area, its new level and its usage in its payload
+	StorageThresholdCrossed EventType = "storage.threshold_crossed"
+	// EnrichmentCompleted carries the enrichment job and its counts in its payload
+	EnrichmentCompleted EventType = "enrichment.completed"
+)
+
+// summaries holds a human-readable summary for each event type
+var summaries = map[EventType]string{
+	HoldAvailable:           "Your hold is ready for pickup",
+	LoanDueSoon:             "A loan is due soon",
+	ChangeRequestApproved:   "Your change request was approved",
+	LoanRenewed:             "Your loan was renewed",
+	BookAvailabilityChanged: "A book's availability changed",
+	StorageThresholdCrossed: "Storage is running out",
+	EnrichmentCompleted:     "Metadata enrichment finished",
+}
+
+// Summary returns a human-readable summary of the event type
+func (t EventType) Summary() string {
+	if summary, ok := summaries[t]; ok {
+		return summary
+	}
+	return string(t)
+}
+
+// Event represents something that happened in the domain that other parts of the system may react to
+type Event struct {
+	Type        EventType
+	RecipientID string
+	BookID      string
+	Payload     map[string]string
+	OccurredAt  time.Time
+}
+
+// Handler reacts to a published event
+type Handler func(event Event) error
+
+// Bus defines the interface for publishing and subscribing to domain events. Subscribed handlers
+// run once per event, on the instance that published it.
+type Bus interface {
+	Publish(event Event)
+	Subscribe(eventType EventType, handler Handler)
+}
+
+// Broadcaster is implemented by buses whose handlers can run on every instance, for subscribers
+// keeping state of their own in each instance, such as clients waiting on a change
+type Broadcaster interface {
+	// SubscribeBroadcast registers a handler run on each instance for the events published by any of them
+	SubscribeBroadcast(eventType EventType, handler Handler)
+}
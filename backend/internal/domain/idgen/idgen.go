@@ -0,0 +1,41 @@
+package idgen
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Generator produces identifiers for new records
+type Generator interface {
+	NewID() string
+}
+
+// UUID generates random version 4 UUIDs
+type UUID struct{}
+
+// NewID returns a new random UUID
+func (UUID) NewID() string {
+	return uuid.New().String()
+}
+
+// Sequence generates predictable UUID-shaped identifiers (…-000000000001, …-000000000002) for deterministic tests
+type Sequence struct {
+	mu   sync.Mutex
+	next uint64
+}
+
+// NewSequence creates a sequence starting at 1
+func NewSequence() *Sequence {
+	return &Sequence{next: 1}
+}
+
+// NewID returns the next identifier of the sequence
+func (s *Sequence) NewID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := fmt.Sprintf("00000000-0000-0000-0000-%012d", s.next)
+	s.next++
+	return id
+}
@@ -0,0 +1,126 @@
+package idgen
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Strategy names a way of generating IDs for new records
+type Strategy string
+
+// ID strategies
+const (
+	// StrategyUUIDv4 generates random UUIDs, which index in no particular order
+	StrategyUUIDv4 Strategy = "uuidv4"
+	// StrategyUUIDv7 generates UUIDs starting with the millisecond they were made, so they sort by
+	// creation time and new rows land at the end of primary key indexes
+	StrategyUUIDv7 Strategy = "uuidv7"
+	// StrategyULID generates ULIDs: a millisecond timestamp and 80 random bits, written in UUID form
+	// since keys are stored in uuid columns
+	StrategyULID Strategy = "ulid"
+)
+
+// New returns the generator of a strategy
+func New(strategy string) (Generator, error) {
+	switch Strategy(strings.ToLower(strings.TrimSpace(strategy))) {
+	case "", StrategyUUIDv4:
+		return UUID{}, nil
+	case StrategyUUIDv7:
+		return NewUUIDv7(), nil
+	case StrategyULID:
+		return NewULID(), nil
+	}
+	return nil, fmt.Errorf("unknown ID strategy %q, expected %s, %s or %s", strategy, StrategyUUIDv4, StrategyUUIDv7, StrategyULID)
+}
+
+// ordered generates 128-bit IDs starting with a 48-bit millisecond timestamp, followed by random
+// bits. IDs made within the same millisecond, or after the clock stepped back, keep increasing: the
+// low 62 bits of the previous ID are incremented rather than drawn again, and on their overflow
+// the timestamp moves on a millisecond.
+type ordered struct {
+	mu   sync.Mutex
+	now  func() time.Time
+	ms   uint64
+	last [16]byte
+	// stamp sets the bits the format reserves, such as the version and variant of UUIDs
+	stamp func(id *[16]byte)
+}
+
+// next returns the next ID
+func (g *ordered) next() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	id := g.last
+	const mask = 1<<62 - 1
+	low := binary.BigEndian.Uint64(id[8:])
+	if ms := uint64(g.now().UnixMilli()); ms > g.ms || low&mask == mask {
+		g.ms = max(ms, g.ms+1)
+		if _, err := rand.Read(id[6:]); err != nil {
+			panic(fmt.Sprintf("idgen: reading random bytes: %v", err))
+		}
+	} else {
+		binary.BigEndian.PutUint64(id[8:], low+1)
+	}
+	binary.BigEndian.PutUint16(id[0:], uint16(g.ms>>32))
+	binary.BigEndian.PutUint32(id[2:], uint32(g.ms))
+	if g.stamp != nil {
+		g.stamp(&id)
+	}
+	g.last = id
+	return format(id)
+}
+
+// format writes an ID in the 8-4-4-4-12 hex form of UUIDs
+func format(id [16]byte) string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], id[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], id[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], id[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], id[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], id[10:])
+	return string(buf[:])
+}
+
+// UUIDv7 generates version 7 UUIDs, which sort by the millisecond they were made
+type UUIDv7 struct {
+	ordered
+}
+
+// NewUUIDv7 creates a UUIDv7 generator reading the system clock
+func NewUUIDv7() *UUIDv7 {
+	return &UUIDv7{ordered{now: time.Now, stamp: func(id *[16]byte) {
+		id[6] = id[6]&0x0f | 0x70
+		id[8] = id[8]&0x3f | 0x80
+	}}}
+}
+
+// NewID returns a new version 7 UUID
+func (g *UUIDv7) NewID() string {
+	return g.next()
+}
+
+// ULID generates ULIDs in UUID form, which sort by the millisecond they were made. Unlike version 7
+// UUIDs they reserve no bits, leaving 80 random ones.
+type ULID struct {
+	ordered
+}
+
+// NewULID creates a ULID generator reading the system clock
+func NewULID() *ULID {
+	return &ULID{ordered{now: time.Now}}
+}
+
+// NewID returns a new ULID in UUID form
+func (g *ULID) NewID() string {
+	return g.next()
+}
@@ -0,0 +1,78 @@
+package idgen
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	for strategy, want := range map[string]any{"": UUID{}, "uuidv4": UUID{}, " UUIDv7 ": &UUIDv7{}, "ulid": &ULID{}} {
+		generator, err := New(strategy)
+		require.NoError(t, err, strategy)
+		assert.IsType(t, want, generator, strategy)
+	}
+	_, err := New("snowflake")
+	assert.Error(t, err)
+}
+
+func TestUUIDv7(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	g := NewUUIDv7()
+	g.now = func() time.Time { return now }
+
+	var ids []string
+	for i := 0; i < 1000; i++ {
+		ids = append(ids, g.NewID())
+		if i == 500 {
+			// IDs keep increasing when the clock steps back
+			now = now.Add(-time.Second)
+		}
+	}
+	now = now.Add(time.Hour)
+	ids = append(ids, g.NewID())
+
+	assert.True(t, sort.StringsAreSorted(ids))
+	for _, id := range ids {
+		parsed, err := uuid.Parse(id)
+		require.NoError(t, err)
+		assert.Equal(t, uuid.Version(7), parsed.Version())
+		assert.Equal(t, uuid.RFC4122, parsed.Variant())
+	}
+	assert.Equal(t, "018d0cab-c4", ids[0][:11])
+}
+
+func TestULID(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	g := NewULID()
+	g.now = func() time.Time { return now }
+
+	first := g.NewID()
+	second := g.NewID()
+	now = now.Add(time.Millisecond)
+	third := g.NewID()
+
+	assert.Less(t, first, second)
+	assert.Less(t, second, third)
+	assert.Equal(t, "018d0cab-c4", first[:11])
+	assert.Equal(t, "018d0cab-c4", third[:11])
+	_, err := uuid.Parse(third)
+	assert.NoError(t, err)
+}
+
+func TestOrderedOverflow(t *testing.T) {
+	g := NewULID()
+	g.now = func() time.Time { return time.UnixMilli(1000) }
+	g.NewID()
+	// With the low bits exhausted, the next ID borrows the next millisecond
+	for i := 8; i < 16; i++ {
+		g.last[i] = 0xff
+	}
+	g.last[8] = 0x3f
+	id := g.NewID()
+	assert.Equal(t, "00000000-03e9", id[:13])
+}
@@ -0,0 +1,59 @@
+package pagetoken
+
+import (
+	"strings"
+	"testing"
+
+	"library-management-system/internal/domain/domainerr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner(t *testing.T) {
+	signer := NewSigner("secret")
+	query := Query("tenant=acme")
+	cursor := Cursor{Listing: "imports", Query: query, Keys: []string{"2024-01-15T10:30:00Z", "run-2"}}
+	token := signer.Encode(cursor)
+
+	decoded, err := signer.Decode(token, "imports", query, 2)
+	require.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+
+	encoded, signature, _ := strings.Cut(token, ".")
+	forged := signer.Encode(Cursor{Listing: "imports", Query: query, Keys: []string{"2099-01-01T00:00:00Z", "run-9"}})
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	for _, tc := range []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{"empty", "", domainerr.ErrInvalidCursor},
+		{"not base64", "%%%." + signature, domainerr.ErrInvalidCursor},
+		{"without signature", encoded, domainerr.ErrInvalidCursor},
+		{"payload swapped", forgedPayload + "." + signature, domainerr.ErrInvalidCursor},
+		{"signed with another key", NewSigner("other").Encode(cursor), domainerr.ErrInvalidCursor},
+	} {
+		_, err := signer.Decode(tc.token, "imports", query, 2)
+		assert.ErrorIs(t, err, tc.err, tc.name)
+	}
+}
+
+func TestSigner_Mismatch(t *testing.T) {
+	signer := NewSigner("secret")
+	token := signer.Encode(Cursor{Listing: "imports", Query: Query("tenant=acme"), Keys: []string{"2024-01-15T10:30:00Z", "run-2"}})
+
+	_, err := signer.Decode(token, "dead_letters", Query("tenant=acme"), 2)
+	assert.ErrorIs(t, err, domainerr.ErrCursorMismatch, "another listing")
+	_, err = signer.Decode(token, "imports", Query("tenant=globex"), 2)
+	assert.ErrorIs(t, err, domainerr.ErrCursorMismatch, "another query")
+	_, err = signer.Decode(token, "imports", Query("tenant=acme"), 1)
+	assert.ErrorIs(t, err, domainerr.ErrCursorMismatch, "other sort keys")
+}
+
+func TestQuery(t *testing.T) {
+	assert.Equal(t, Query("tenant=acme", "sort=newest"), Query("tenant=acme", "sort=newest"))
+	assert.NotEqual(t, Query("tenant=acme", "sort=newest"), Query("sort=newest", "tenant=acme"))
+	assert.NotEqual(t, Query("tenant=acme\nsort=newest"), Query("tenant=acme", "sort=newest"), "params do not run together")
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// AccessTokenRepository defines the interface for personal access token data operations
+type AccessTokenRepository interface {
+	Create(token *entities.AccessToken) error
+	// GetByID returns a token, nil when there is none
+	GetByID(id string) (*entities.AccessToken, error)
+	// FindByHash returns the token with a hash, nil when there is none
+	FindByHash(hash string) (*entities.AccessToken, error)
+	// ListByUser lists the tokens of a user, newest first, revoked and expired ones included
+	ListByUser(userID string) ([]entities.AccessToken, error)
+	Update(token *entities.AccessToken) error
+}
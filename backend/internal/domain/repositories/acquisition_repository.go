@@ -0,0 +1,12 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// AcquisitionRepository defines the interface for acquisition data access
+type AcquisitionRepository interface {
+	Create(acquisition *entities.Acquisition) error
+	GetByID(id string) (*entities.Acquisition, error)
+	// List retrieves acquisitions oldest first, optionally only those with the given status
+	List(status string) ([]entities.Acquisition, error)
+	Update(acquisition *entities.Acquisition) error
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/entities"
+)
+
+// AnalyticsRepository defines the interface for the hourly counts of anonymous usage events
+type AnalyticsRepository interface {
+	// Add adds the counts of events to those already recorded for the same hour, kind and name
+	Add(events []entities.AnalyticsEvent) error
+	// Totals returns the counts of the events of a kind since a time, by name, most used first
+	Totals(kind string, since time.Time) ([]entities.AnalyticsCount, error)
+}
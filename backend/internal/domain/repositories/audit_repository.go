@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/entities"
+)
+
+// AuditRepository defines the interface for audit trail data access
+type AuditRepository interface {
+	Create(entry *entities.AuditEntry) error
+	// CreateBatch creates several audit entries at once, all or none
+	CreateBatch(entries []entities.AuditEntry) error
+	ListByEntity(entityType, entityID string) ([]entities.AuditEntry, error)
+	// ListByAction retrieves the entries of an action made from from until to, oldest first
+	ListByAction(action string, from, to time.Time) ([]entities.AuditEntry, error)
+}
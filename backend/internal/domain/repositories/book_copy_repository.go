@@ -0,0 +1,15 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// BookCopyRepository defines the interface for the physical copies of books
+type BookCopyRepository interface {
+	// NextAccessionNumber takes the next number of the sequence of a prefix, starting at 1. It is
+	// safe to call concurrently, and numbers are never given twice.
+	NextAccessionNumber(prefix string) (int64, error)
+	Create(bookCopy *entities.BookCopy) error
+	// ListByBook lists the copies of a book by accession number
+	ListByBook(bookID string) ([]entities.BookCopy, error)
+	// FindByAccessionNumber finds the copy with an accession number, nil when there is none
+	FindByAccessionNumber(number string) (*entities.BookCopy, error)
+}
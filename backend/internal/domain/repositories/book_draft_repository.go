@@ -0,0 +1,13 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// BookDraftRepository defines the interface for book draft data access
+type BookDraftRepository interface {
+	// Save creates or replaces the draft of a book
+	Save(draft *entities.BookDraft) error
+	GetByBookID(bookID string) (*entities.BookDraft, error)
+	Delete(bookID string) error
+	// Publish updates a book with its draft applied and deletes the draft, in one transaction
+	Publish(book *entities.Book) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// BookIdentifierRepository defines the interface for the identifiers of books besides their ISBN
+type BookIdentifierRepository interface {
+	Create(identifier *entities.BookIdentifier) error
+	// ListByBook lists the identifiers of a book by type and value
+	ListByBook(bookID string) ([]entities.BookIdentifier, error)
+	// FindByValue finds the identifier of a type with a normalized value, nil when no book has it
+	FindByValue(kind, value string) (*entities.BookIdentifier, error)
+	// Delete deletes an identifier of a book, reporting whether the book had it
+	Delete(bookID, id string) (bool, error)
+	// DeleteByBook deletes every identifier of a book
+	DeleteByBook(bookID string) error
+}
@@ -1,19 +1,79 @@
 package repositories
 
-import "library-management-system/internal/domain/entities"
+import (
+	"fmt"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+)
+
+// BatchConflict decides what a batch insert does with a book whose ISBN is already taken
+type BatchConflict string
+
+const (
+	// BatchConflictSkip keeps the existing book and leaves the new one out
+	BatchConflictSkip BatchConflict = "skip"
+	// BatchConflictUpsert overwrites the catalog fields of the existing book with the new one
+	BatchConflictUpsert BatchConflict = "upsert"
+)
+
+// StaleUpsertError is returned by Upsert, which writes nothing, when stored books changed after
+// the version they were checked at
+type StaleUpsertError struct {
+	// Stale are the positions of those books in the upsert
+	Stale []int
+}
+
+func (e *StaleUpsertError) Error() string {
+	return fmt.Sprintf("%d books changed since they were checked", len(e.Stale))
+}
 
 // BookRepository defines the interface for book data access
 type BookRepository interface {
 	Create(book *entities.Book) error
+	// CreateBatch inserts books in chunks of chunkSize, one transaction per chunk, and returns how
+	// many were inserted or, with BatchConflictUpsert, updated. Chunks written before a failing
+	// one are kept.
+	CreateBatch(books []entities.Book, chunkSize int, onConflict BatchConflict) (int64, error)
+	// Upsert inserts books or overwrites the catalog fields of the books having their ISBN, all
+	// or nothing. Each book is filled with its stored state, and the result tells which were created.
+	// A stored book is only overwritten when it was not updated after versions[i], if given;
+	// otherwise Upsert fails with a *StaleUpsertError.
+	Upsert(books []entities.Book, versions []*time.Time) ([]bool, error)
 	GetByID(id string) (*entities.Book, error)
+	// GetByIDInBranch retrieves a book by ID if it belongs to the branch; books of other branches
+	// and of the whole library are not found
+	GetByIDInBranch(id, branchID string) (*entities.Book, error)
 	GetAll() ([]entities.Book, error)
 	Update(book *entities.Book) error
 	Delete(id string) error
+	// DeleteBatch soft deletes the books with the IDs in one transaction, all or nothing; it fails
+	// when any of them is missing or already deleted
+	DeleteBatch(ids []string) error
 	HardDelete(id string) error
 	FindByTitle(title string) ([]entities.Book, error)
 	FindByAuthor(author string) ([]entities.Book, error)
 	FindByYear(year int) ([]entities.Book, error)
 	FindByISBN(isbn string) (*entities.Book, error)
+	// FindBySlug finds the book with a slug, deleted or not
+	FindBySlug(slug string) (*entities.Book, error)
+	FindByPublishers(publisherIDs []string) ([]entities.Book, error)
+	// FindByMetadata finds the books having each metadata key with the value, compared as text
+	FindByMetadata(filters map[string]string) ([]entities.Book, error)
+	FindBySeries(seriesID string) ([]entities.Book, error)
+	FindByWork(workID string) ([]entities.Book, error)
+	SetWork(bookIDs []string, workID *string) error
 	GetDeletedBooks() ([]entities.Book, error)
+	// FindScheduled finds the books waiting for their publication, soonest first
+	FindScheduled() ([]entities.Book, error)
+	// MarkPublished clears the publish_at of a scheduled book, making it live
+	MarkPublished(id string) error
+	// SetCoverPalette records the colours of the cover of a book, or clears them when nil
+	SetCoverPalette(id string, palette []string) error
 	Restore(id string) error
+	// RestoreBatch restores the soft-deleted books with the IDs in one transaction, all or
+	// nothing; it fails when any of them is missing or not deleted
+	RestoreBatch(ids []string) error
+	// FindByIDsWithDeleted finds the books with the IDs, deleted or not
+	FindByIDsWithDeleted(ids []string) ([]entities.Book, error)
 }
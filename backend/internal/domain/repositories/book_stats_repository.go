@@ -0,0 +1,13 @@
+package repositories
+
+import "time"
+
+// BookStatsRepository defines the interface for the daily view and loan counts of books
+type BookStatsRepository interface {
+	// AddViews adds view counts, by book ID, to the stats of the books on a day
+	AddViews(day time.Time, views map[string]int64) error
+	// RefreshPopularity recounts the daily loans of the books since a day, then sets the
+	// popularity of every book to its views plus loanWeight times its loans since that day.
+	// Stats from before the day are dropped.
+	RefreshPopularity(since time.Time, loanWeight int64) error
+}
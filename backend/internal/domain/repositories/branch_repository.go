@@ -0,0 +1,12 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// BranchRepository defines the interface for branch data access
+type BranchRepository interface {
+	Create(branch *entities.Branch) error
+	GetByID(id string) (*entities.Branch, error)
+	Update(branch *entities.Branch) error
+	GetAll() ([]entities.Branch, error)
+	FindByName(name string) (*entities.Branch, error)
+}
@@ -0,0 +1,8 @@
+package repositories
+
+// CacheVersion defines the interface for a version number shared by every instance, bumped to
+// invalidate what each of them cached
+type CacheVersion interface {
+	Get() (int64, error)
+	Bump() error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// CalendarRepository defines the interface for business hours and closed days data access
+type CalendarRepository interface {
+	// ListHours retrieves the business hours of the library and of every branch
+	ListHours() ([]entities.BusinessHours, error)
+	// ReplaceHours replaces the business hours of a branch, nil for the whole library, at once
+	ReplaceHours(branchID *string, hours []entities.BusinessHours) error
+	// ListClosedDays retrieves the closed days of the library and of every branch, by date
+	ListClosedDays() ([]entities.ClosedDay, error)
+	CreateClosedDay(day *entities.ClosedDay) error
+	// DeleteClosedDay deletes a closed day, reporting whether it existed
+	DeleteClosedDay(id string) (bool, error)
+}
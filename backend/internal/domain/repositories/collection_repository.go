@@ -0,0 +1,15 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// CollectionRepository defines the interface for collection data access
+type CollectionRepository interface {
+	Create(collection *entities.Collection) error
+	GetByID(id string) (*entities.Collection, error)
+	GetByShareToken(token string) (*entities.Collection, error)
+	ListByOwner(ownerID string) ([]entities.Collection, error)
+	Update(collection *entities.Collection) error
+	Delete(id string) error
+	ListBookIDs(collectionID string) ([]string, error)
+	SetBooks(collectionID string, bookIDs []string) error
+}
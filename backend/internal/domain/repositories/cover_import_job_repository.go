@@ -0,0 +1,10 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// CoverImportJobRepository defines the interface for cover import job data access
+type CoverImportJobRepository interface {
+	Create(job *entities.CoverImportJob) error
+	GetByID(id string) (*entities.CoverImportJob, error)
+	Update(job *entities.CoverImportJob) error
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// CoverRepository defines the interface for cover image data access. Images are stored once per
+// content hash however many books use them, and deleted along with the last book using them.
+type CoverRepository interface {
+	// Attach makes an image the cover of a book, storing it unless an image with its hash is
+	// stored already, and releases the previous cover of the book. The scan result of the upload,
+	// if any, replaces the one recorded on the image, and its perceptual hash fills in a missing one.
+	Attach(bookID, hash, perceptualHash, contentType string, data []byte, scan *entities.ScanResult) (*entities.BookCover, error)
+	// Detach removes the cover of a book and releases its image, reporting whether it had one
+	Detach(bookID string) (bool, error)
+	// GetByBookID returns the cover of a book with the details of its image, nil without one
+	GetByBookID(bookID string) (*entities.BookCover, error)
+	// ListAttached returns the covers of the books not deleted with the details of their images,
+	// ordered by book
+	ListAttached() ([]entities.BookCover, error)
+	// Open reads a stored image
+	Open(hash string) ([]byte, error)
+}
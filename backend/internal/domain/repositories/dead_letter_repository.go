@@ -0,0 +1,13 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// DeadLetterRepository defines the interface for failed background job data access
+type DeadLetterRepository interface {
+	Create(deadLetter *entities.DeadLetter) error
+	GetByID(id string) (*entities.DeadLetter, error)
+	// List returns the dead letters of a queue, or of every queue when queue is empty, newest
+	// first, starting after a position when it is not nil
+	List(queue string, after *PageAfter, limit int) ([]entities.DeadLetter, error)
+	Delete(id string) error
+}
@@ -0,0 +1,10 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// EnrichmentJobRepository defines the interface for metadata enrichment job data access
+type EnrichmentJobRepository interface {
+	Create(job *entities.EnrichmentJob) error
+	GetByID(id string) (*entities.EnrichmentJob, error)
+	Update(job *entities.EnrichmentJob) error
+}
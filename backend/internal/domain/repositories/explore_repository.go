@@ -0,0 +1,10 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// ExploreRepository defines the interface for the ad-hoc reports of the query explorer
+type ExploreRepository interface {
+	// Run runs a validated query, returning at most its limit of rows plus one so callers can
+	// tell whether the result was truncated
+	Run(query *entities.ExploreQuery) (*entities.ExploreResult, error)
+}
@@ -0,0 +1,9 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// FineRepository defines the interface for fine data access
+type FineRepository interface {
+	// ListByUser retrieves the fines charged to a user, newest first
+	ListByUser(userID string) ([]entities.Fine, error)
+}
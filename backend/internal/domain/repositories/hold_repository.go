@@ -0,0 +1,14 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// HoldRepository defines the interface for hold data access
+type HoldRepository interface {
+	// CountPending counts the waiting and ready holds on a book, leaving out those of one user
+	CountPending(bookID, exceptUserID string) (int64, error)
+	// ListPendingByUser retrieves the waiting and ready holds of a user, oldest first
+	ListPendingByUser(userID string) ([]entities.Hold, error)
+	// ListWaiting retrieves the waiting holds on a book in queue order, oldest first
+	ListWaiting(bookID string) ([]entities.Hold, error)
+	Update(hold *entities.Hold) error
+}
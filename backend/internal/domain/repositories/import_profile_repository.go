@@ -0,0 +1,15 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// ImportProfileRepository defines the interface for CSV import profile data access
+type ImportProfileRepository interface {
+	// Save creates or replaces a profile
+	Save(profile *entities.ImportProfile) error
+	// Get retrieves a profile of a tenant by name, nil when there is none
+	Get(tenantID, name string) (*entities.ImportProfile, error)
+	// ListByTenant lists the profiles of a tenant ordered by name
+	ListByTenant(tenantID string) ([]entities.ImportProfile, error)
+	// Delete removes a profile and reports whether it existed
+	Delete(tenantID, name string) (bool, error)
+}
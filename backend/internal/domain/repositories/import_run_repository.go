@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/entities"
+)
+
+// ImportRunRepository defines the interface for book import history data access
+type ImportRunRepository interface {
+	Create(run *entities.ImportRun) error
+	GetByID(id string) (*entities.ImportRun, error)
+	// List returns the latest runs of a tenant, or of every tenant when tenantID is empty, newest
+	// first, starting after a position when it is not nil
+	List(tenantID string, after *PageAfter, limit int) ([]entities.ImportRun, error)
+	// FindCompleted returns the latest completed run of a tenant with the file hash since a time,
+	// nil when there is none
+	FindCompleted(tenantID, fileHash string, since time.Time) (*entities.ImportRun, error)
+}
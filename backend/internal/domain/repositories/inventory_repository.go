@@ -0,0 +1,16 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// InventoryRepository defines the interface for inventory session data access
+type InventoryRepository interface {
+	CreateSession(session *entities.InventorySession) error
+	GetSession(id string) (*entities.InventorySession, error)
+	ListSessions() ([]entities.InventorySession, error)
+	UpdateSession(session *entities.InventorySession) error
+	// LastClosedSession returns the most recently closed session, or nil when none was closed yet
+	LastClosedSession() (*entities.InventorySession, error)
+	AddScans(scans []entities.InventoryScan) error
+	// ListScans retrieves the scans of a session in the order they were made
+	ListScans(sessionID string) ([]entities.InventoryScan, error)
+}
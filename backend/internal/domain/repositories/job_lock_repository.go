@@ -0,0 +1,12 @@
+package repositories
+
+import "time"
+
+// JobLockRepository defines the interface for scheduled job locks shared by all instances
+type JobLockRepository interface {
+	// Acquire takes the lock of a job for an owner until expiresAt. It succeeds when the lock is free,
+	// expired at now, or already held by the owner, and reports false when another owner holds it.
+	Acquire(jobName, owner string, now, expiresAt time.Time) (bool, error)
+	// Release moves the expiry of a lock held by the owner to until; other owners' locks are left alone
+	Release(jobName, owner string, until time.Time) error
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/entities"
+)
+
+// LoanRepository defines the interface for loan data access
+type LoanRepository interface {
+	Create(loan *entities.Loan) error
+	// GetByID retrieves a loan by ID, archived or not
+	GetByID(id string) (*entities.Loan, error)
+	// ListActiveByUser retrieves the loans a user has not returned yet, soonest due first
+	ListActiveByUser(userID string) ([]entities.Loan, error)
+	// ListActiveByBook retrieves the loans of a book not returned yet, soonest due first
+	ListActiveByBook(bookID string) ([]entities.Loan, error)
+	// ListByBook retrieves every loan of a book, archived or not, most recently borrowed first
+	ListByBook(bookID string) ([]entities.Loan, error)
+	// ListDueBetween retrieves the loans not returned yet that are due from a time until another,
+	// soonest due first
+	ListDueBetween(from, to time.Time) ([]entities.Loan, error)
+	// ListByUser retrieves the loans a user borrowed from a time until another, archived or not,
+	// most recently borrowed first
+	ListByUser(userID string, from, to time.Time) ([]entities.Loan, error)
+	// Archive moves up to limit loans returned before a time out of the current loans, into the
+	// history, and returns how many it moved
+	Archive(returnedBefore time.Time, limit int) (int64, error)
+	Update(loan *entities.Loan) error
+}
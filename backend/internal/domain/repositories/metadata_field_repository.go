@@ -0,0 +1,13 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// MetadataFieldRepository defines the interface for metadata field definition data access
+type MetadataFieldRepository interface {
+	// Save creates or replaces the definition of a field
+	Save(field *entities.MetadataField) error
+	// ListByTenant lists the field definitions of a tenant ordered by key
+	ListByTenant(tenantID string) ([]entities.MetadataField, error)
+	// Delete removes the definition of a field and reports whether it existed
+	Delete(tenantID, key string) (bool, error)
+}
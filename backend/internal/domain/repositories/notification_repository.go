@@ -0,0 +1,13 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// NotificationRepository defines the interface for notification data access
+type NotificationRepository interface {
+	Create(notification *entities.Notification) error
+	GetByID(id string) (*entities.Notification, error)
+	ListByRecipient(recipientID string, unreadOnly bool) ([]entities.Notification, error)
+	CountUnread(recipientID string) (int64, error)
+	MarkRead(id string) error
+	MarkAllRead(recipientID string) error
+}
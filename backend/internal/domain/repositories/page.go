@@ -0,0 +1,10 @@
+package repositories
+
+import "time"
+
+// PageAfter is the position a page of a listing sorted newest first starts after: the time and ID
+// of the last record of the previous page, the ID breaking ties between records of the same time
+type PageAfter struct {
+	Time time.Time
+	ID   string
+}
@@ -0,0 +1,9 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// PolicyRepository defines the interface for reading the circulation policies of tenants
+type PolicyRepository interface {
+	// Get returns a policy of a tenant, or nil when the tenant has not set it
+	Get(tenantID, key string) (*entities.Policy, error)
+}
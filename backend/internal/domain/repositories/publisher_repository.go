@@ -0,0 +1,14 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// PublisherRepository defines the interface for publisher data access
+type PublisherRepository interface {
+	Create(publisher *entities.Publisher) error
+	GetByID(id string) (*entities.Publisher, error)
+	GetAll() ([]entities.Publisher, error)
+	Update(publisher *entities.Publisher) error
+	Delete(id string) error
+	FindByName(name string) (*entities.Publisher, error)
+	ListImprints(parentID string) ([]entities.Publisher, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/entities"
+)
+
+// RelatedBookRepository defines the interface for the materialized related books
+type RelatedBookRepository interface {
+	// Refresh recomputes the related books of every listed book from the catalog and the loans in
+	// one transaction, keeping the limit best of each, and returns how many it kept
+	Refresh(at time.Time, weights entities.RelatedBookWeights, limit int) (int64, error)
+	// ListByBookID returns the related books of a book by rank
+	ListByBookID(bookID string) ([]entities.RelatedBook, error)
+}
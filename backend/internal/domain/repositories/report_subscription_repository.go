@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/entities"
+)
+
+// ReportSubscriptionRepository defines the interface for report subscription data access
+type ReportSubscriptionRepository interface {
+	Create(subscription *entities.ReportSubscription) error
+	GetByID(id string) (*entities.ReportSubscription, error)
+	List() ([]entities.ReportSubscription, error)
+	Update(subscription *entities.ReportSubscription) error
+	Delete(id string) error
+	// ListDue returns the enabled subscriptions whose next run is at or before now
+	ListDue(now time.Time) ([]entities.ReportSubscription, error)
+	AddRun(run *entities.ReportSubscriptionRun) error
+	// ListRuns returns the latest runs of a subscription, newest first
+	ListRuns(subscriptionID string, limit int) ([]entities.ReportSubscriptionRun, error)
+}
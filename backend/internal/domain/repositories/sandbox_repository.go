@@ -0,0 +1,14 @@
+package repositories
+
+// SandboxRepository defines the interface for the baseline dataset of a sandbox deployment, kept
+// apart from the tables the API writes to
+type SandboxRepository interface {
+	// HasBaseline reports whether a baseline has been saved
+	HasBaseline() (bool, error)
+	// SaveBaseline replaces the baseline with a copy of the data of every table, returning how many
+	// tables it copied
+	SaveBaseline() (int, error)
+	// Restore empties every table and refills it from the baseline in one transaction, returning
+	// how many tables it refilled
+	Restore() (int, error)
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// SeriesRepository defines the interface for series data access
+type SeriesRepository interface {
+	Create(series *entities.Series) error
+	GetByID(id string) (*entities.Series, error)
+	GetAll() ([]entities.Series, error)
+	FindByName(name string) (*entities.Series, error)
+}
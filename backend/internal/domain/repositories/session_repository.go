@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/entities"
+)
+
+// SessionRepository defines the interface for the server-side store of sign-in sessions
+type SessionRepository interface {
+	Create(session *entities.Session) error
+	// GetByID returns a session, nil when there is none
+	GetByID(id string) (*entities.Session, error)
+	// FindByHash returns the session with a token hash, nil when there is none
+	FindByHash(hash string) (*entities.Session, error)
+	// ListActiveByUser lists the sessions of a user neither revoked nor expired at a time, last
+	// seen first
+	ListActiveByUser(userID string, at time.Time) ([]entities.Session, error)
+	Update(session *entities.Session) error
+	// RevokeOthers revokes the active sessions of a user but one, which may be empty, and returns
+	// how many it revoked
+	RevokeOthers(userID, keepID string, at time.Time) (int64, error)
+	// ListImpersonations lists the sessions admins started to impersonate users since a time,
+	// newest first
+	ListImpersonations(since time.Time) ([]entities.Session, error)
+}
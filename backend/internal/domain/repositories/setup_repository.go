@@ -0,0 +1,12 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// SetupRepository defines the interface for provisioning a fresh deployment
+type SetupRepository interface {
+	// IsBootstrapped reports whether the deployment already has users
+	IsBootstrapped() (bool, error)
+	// Bootstrap creates the tenant, its admin and its policies in one transaction. It fails with
+	// domainerr.ErrAlreadyBootstrapped when the deployment already has users.
+	Bootstrap(bootstrap *entities.Bootstrap) error
+}
@@ -0,0 +1,10 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// SitemapJobRepository defines the interface for sitemap job data access
+type SitemapJobRepository interface {
+	Create(job *entities.SitemapJob) error
+	GetByID(id string) (*entities.SitemapJob, error)
+	Update(job *entities.SitemapJob) error
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/entities"
+)
+
+// StatsRepository defines the interface for the materialized stats tables
+type StatsRepository interface {
+	// Refresh recomputes every stats table from the books and loans in one transaction, keeping
+	// topAuthors authors, and records the refreshes at a time. Loans fall in the months of the
+	// timezone of the branch of their book or of their tenant, else of the named timezone.
+	Refresh(at time.Time, topAuthors int, timezone string) error
+	// BooksPerYear returns the books per year, oldest year first
+	BooksPerYear() ([]entities.BooksPerYear, error)
+	// LoansPerMonth returns the loans per month, oldest month first
+	LoansPerMonth() ([]entities.LoansPerMonth, error)
+	// TopAuthors returns the top authors by rank
+	TopAuthors() ([]entities.TopAuthor, error)
+	// Refreshes returns the last refresh of each table that has been refreshed
+	Refreshes() ([]entities.StatsRefresh, error)
+}
@@ -0,0 +1,9 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// TenantRepository defines the interface for tenant data access
+type TenantRepository interface {
+	GetByID(id string) (*entities.Tenant, error)
+	Update(tenant *entities.Tenant) error
+}
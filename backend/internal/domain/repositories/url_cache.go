@@ -0,0 +1,10 @@
+package repositories
+
+import "time"
+
+// URLCache defines the interface for storing processed URLs by the request that produced them
+type URLCache interface {
+	// Get returns the processed URL cached under a key, and whether there was one
+	Get(key string) (string, bool, error)
+	Set(key, processedURL string, ttl time.Duration) error
+}
@@ -1,8 +1,33 @@
 package repositories
 
-import "library-management-system/internal/domain/entities"
+import (
+	"time"
 
-// URLRepository defines the interface for URL processing
+	"library-management-system/internal/domain/entities"
+)
+
+// URLRepository defines the interface for keeping the results of URL processing: the history of
+// the URLs processed, and a cache of recent results by the key of their processing
 type URLRepository interface {
-	ProcessURL(request *entities.URLRequest) (*entities.URLResponse, error)
+	// SaveResult records a result in the history and, for a positive ttl, caches its processed
+	// URL under its key for that long
+	SaveResult(result *entities.URLResult, ttl time.Duration) error
+	// GetCached returns the processed URL cached under a key, and whether there was one
+	GetCached(key string) (string, bool, error)
+	// History returns the latest results matching a filter, newest first, after a position
+	History(filter URLHistoryFilter, after *PageAfter, limit int) ([]entities.URLResult, error)
+	// DeleteBefore deletes up to limit results recorded before a time, oldest first, and returns
+	// how many it deleted
+	DeleteBefore(before time.Time, limit int) (int64, error)
+}
+
+// URLHistoryFilter narrows the history of URL processing; empty fields match every result
+type URLHistoryFilter struct {
+	// Domain matches the URLs of a host and of its subdomains
+	Domain string
+	// Operation matches the results an operation was applied to
+	Operation string
+	// From and To match the results recorded from one time until before another
+	From time.Time
+	To   time.Time
 }
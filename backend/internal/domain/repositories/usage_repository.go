@@ -0,0 +1,7 @@
+package repositories
+
+// UsageRepository defines the interface for per-subject request counters
+type UsageRepository interface {
+	Increment(subject, period string) (int64, error)
+	Get(subject, period string) (int64, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/entities"
+)
+
+// UserRepository defines the interface for user data operations
+type UserRepository interface {
+	// FindByEmail returns the user with an email, or nil when there is none
+	FindByEmail(email string) (*entities.User, error)
+	GetByID(id string) (*entities.User, error)
+	Update(user *entities.User) error
+	// ListDeactivatedBefore lists the users deactivated before a time and not anonymized yet
+	ListDeactivatedBefore(at time.Time) ([]entities.User, error)
+}
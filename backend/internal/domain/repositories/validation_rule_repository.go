@@ -0,0 +1,14 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// ValidationRuleRepository defines the interface for validation rule data access
+type ValidationRuleRepository interface {
+	Create(rule *entities.ValidationRule) error
+	GetByID(id string) (*entities.ValidationRule, error)
+	List() ([]entities.ValidationRule, error)
+	// ListEnabled returns the rules books are validated against, oldest first
+	ListEnabled() ([]entities.ValidationRule, error)
+	Update(rule *entities.ValidationRule) error
+	Delete(id string) error
+}
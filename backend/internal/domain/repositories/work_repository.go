@@ -0,0 +1,10 @@
+package repositories
+
+import "library-management-system/internal/domain/entities"
+
+// WorkRepository defines the interface for work data access
+type WorkRepository interface {
+	Create(work *entities.Work) error
+	GetByID(id string) (*entities.Work, error)
+	GetAll() ([]entities.Work, error)
+}
@@ -0,0 +1,34 @@
+// Package sortedjson encodes JSON with the keys of every object sorted, so that exports of the same
+// data are identical byte for byte whatever the field order of the structs they were built from,
+// and snapshots taken in different environments can be diffed.
+package sortedjson
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Marshal encodes v with sorted keys, indented by indent unless it is empty. Numbers are kept as
+// written, not rounded through float64.
+func Marshal(v interface{}, indent string) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// Objects decode into maps, which encoding/json writes in key order
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", indent)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
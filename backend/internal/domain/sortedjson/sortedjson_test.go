@@ -0,0 +1,41 @@
+package sortedjson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type record struct {
+	Title    string            `json:"title"`
+	Author   string            `json:"author"`
+	ID       int64             `json:"id"`
+	Metadata map[string]string `json:"metadata"`
+	Copies   []copyRecord      `json:"copies"`
+}
+
+type copyRecord struct {
+	Shelf   string `json:"shelf"`
+	Barcode string `json:"barcode"`
+}
+
+func TestMarshal(t *testing.T) {
+	data, err := Marshal(record{
+		Title:    "Emma",
+		Author:   "Jane Austen",
+		ID:       9007199254740993,
+		Metadata: map[string]string{"b": "2", "a": "1"},
+		Copies:   []copyRecord{{Shelf: "A1", Barcode: "0002"}, {Shelf: "B4", Barcode: "0001"}},
+	}, "")
+	require.NoError(t, err)
+
+	// Keys are sorted at every level, arrays keep their order and large IDs are not rounded
+	assert.Equal(t, `{"author":"Jane Austen","copies":[{"barcode":"0002","shelf":"A1"},{"barcode":"0001","shelf":"B4"}],"id":9007199254740993,"metadata":{"a":"1","b":"2"},"title":"Emma"}`, string(data))
+}
+
+func TestMarshal_Indent(t *testing.T) {
+	data, err := Marshal(copyRecord{Shelf: "A1", Barcode: "0002"}, "  ")
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"barcode\": \"0002\",\n  \"shelf\": \"A1\"\n}", string(data))
+}
@@ -0,0 +1,296 @@
+// Package urlnorm holds the URL normalizations shared by the URL processor and the URLs the
+// service builds itself, such as the slugs of book pages
+package urlnorm
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// transliterations spells out the Latin letters that do not decompose into a base letter and accents
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'Æ': "ae", 'œ': "oe", 'Œ': "oe", 'ø': "o", 'Ø': "o",
+	'ł': "l", 'Ł': "l", 'đ': "d", 'Đ': "d", 'ð': "d", 'Ð': "d", 'þ': "th", 'Þ': "th", 'ı': "i",
+}
+
+// CanonicalPath removes the trailing slashes of a URL path, keeping "/" for the root
+func CanonicalPath(path string) string {
+	path = strings.TrimRight(path, "/")
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// CanonicalOptions tune canonical URLs beyond dropping the query and trailing slashes; the zero
+// value keeps ports, dot-segments and duplicate slashes, and "/" for the root
+type CanonicalOptions struct {
+	// StripDefaultPort drops :80 from http URLs and :443 from https ones
+	StripDefaultPort bool
+	// CollapseSlashes turns runs of slashes in the path into one
+	CollapseSlashes bool
+	// RemoveDotSegments resolves "." and ".." segments of the path
+	RemoveDotSegments bool
+	// EmptyRoot leaves the root path empty instead of "/"
+	EmptyRoot bool
+}
+
+// Canonicalize drops the query and the trailing slashes of a URL, and whatever else options ask for
+func Canonicalize(u *url.URL, options CanonicalOptions) {
+	u.RawQuery = ""
+	if options.StripDefaultPort {
+		u.Host = StripDefaultPort(u.Scheme, u.Host)
+	}
+	path := u.Path
+	if options.RemoveDotSegments {
+		path = RemoveDotSegments(path)
+	}
+	if options.CollapseSlashes {
+		path = CollapseSlashes(path)
+	}
+	path = CanonicalPath(path)
+	if options.EmptyRoot && path == "/" {
+		path = ""
+	}
+	u.Path = path
+}
+
+// StripDefaultPort removes the port of a host when it is the default port of the scheme
+func StripDefaultPort(scheme, host string) string {
+	var port string
+	switch strings.ToLower(scheme) {
+	case "http":
+		port = ":80"
+	case "https":
+		port = ":443"
+	default:
+		return host
+	}
+	// The port comes after the brackets of IPv6 addresses, so "[::443]" has none
+	return strings.TrimSuffix(host, port)
+}
+
+// CollapseSlashes turns runs of slashes into single ones
+func CollapseSlashes(path string) string {
+	for strings.Contains(path, "//") {
+		path = strings.ReplaceAll(path, "//", "/")
+	}
+	return path
+}
+
+// RemoveDotSegments resolves the "." and ".." segments of a path as RFC 3986 does; ".." never
+// climbs above the root
+func RemoveDotSegments(path string) string {
+	segments := strings.Split(path, "/")
+	absolute := strings.HasPrefix(path, "/")
+	resolved := make([]string, 0, len(segments))
+	for i, segment := range segments {
+		last := i == len(segments)-1
+		switch segment {
+		case ".":
+		case "..":
+			if len(resolved) > 1 || (len(resolved) == 1 && !absolute) {
+				resolved = resolved[:len(resolved)-1]
+			}
+		default:
+			resolved = append(resolved, segment)
+			continue
+		}
+		// A path ending in a dot-segment ends in a directory
+		if last {
+			resolved = append(resolved, "")
+		}
+	}
+	return strings.Join(resolved, "/")
+}
+
+// PathEncoding is how lowercasing a URL treats the percent-encoded characters of its path
+type PathEncoding int
+
+const (
+	// EncodingLowercased lowercases them along with the rest of the URL, hex digits included
+	EncodingLowercased PathEncoding = iota
+	// EncodingNormalized keeps them with upper-case hex digits, decoding unreserved characters,
+	// as RFC 3986 normalizes URLs
+	EncodingNormalized
+	// EncodingDecoded also decodes the characters beyond ASCII, for display. Reserved characters,
+	// spaces and invalid UTF-8 stay encoded.
+	EncodingDecoded
+)
+
+// Lowercase lowercases a URL, treating the percent-encoded characters of its path by encoding
+func Lowercase(u *url.URL, encoding PathEncoding) string {
+	if encoding == EncodingLowercased || u.Opaque != "" {
+		return strings.ToLower(u.String())
+	}
+
+	path := u.EscapedPath()
+	if u.Host != "" && path != "" && path[0] != '/' {
+		path = "/" + path
+	}
+	// The path goes between the authority and the query
+	authority := (&url.URL{Scheme: u.Scheme, User: u.User, Host: u.Host}).String()
+	rest := *u
+	rest.Path, rest.RawPath = "", ""
+	return strings.ToLower(authority) + lowercasePath(path, encoding) + strings.ToLower(strings.TrimPrefix(rest.String(), authority))
+}
+
+// lowercasePath lowercases an escaped path, decoding its escapes as encoding says
+func lowercasePath(path string, encoding PathEncoding) string {
+	var lowered strings.Builder
+	for i := 0; i < len(path); {
+		if !isEscape(path, i) {
+			_, size := utf8.DecodeRuneInString(path[i:])
+			lowered.WriteString(strings.ToLower(path[i : i+size]))
+			i += size
+			continue
+		}
+		// A run of escapes may spell out a character beyond ASCII
+		var run []byte
+		for ; isEscape(path, i); i += 3 {
+			run = append(run, unhex(path[i+1])<<4|unhex(path[i+2]))
+		}
+		writeEscapes(&lowered, run, encoding == EncodingDecoded)
+	}
+	return lowered.String()
+}
+
+// writeEscapes writes decoded escapes back: unreserved characters, and with decode printable
+// characters beyond ASCII, lowercased; anything else escaped with upper-case hex digits
+func writeEscapes(lowered *strings.Builder, run []byte, decode bool) {
+	for len(run) > 0 {
+		r, size := utf8.DecodeRune(run)
+		switch {
+		case r < utf8.RuneSelf && isUnreserved(byte(r)):
+			lowered.WriteString(strings.ToLower(string(r)))
+		case decode && r >= utf8.RuneSelf && r != utf8.RuneError && unicode.IsPrint(r) && !unicode.IsSpace(r):
+			lowered.WriteString(strings.ToLower(string(r)))
+		default:
+			for _, c := range run[:size] {
+				fmt.Fprintf(lowered, "%%%02X", c)
+			}
+		}
+		run = run[size:]
+	}
+}
+
+// isEscape reports whether a percent-encoded byte starts at i
+func isEscape(s string, i int) bool {
+	return i+2 < len(s) && s[i] == '%' && isHex(s[i+1]) && isHex(s[i+2])
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func unhex(c byte) byte {
+	switch {
+	case c <= '9':
+		return c - '0'
+	case c <= 'F':
+		return c - 'A' + 10
+	}
+	return c - 'a' + 10
+}
+
+// isUnreserved reports whether RFC 3986 lets a character appear unencoded anywhere in a URL
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+// BaseURL normalizes the absolute http(s) URL other URLs are built on: lowercase scheme and host,
+// no trailing slash, so that appending "/path" gives a canonical URL. Query and fragment are refused.
+func BaseURL(raw string) (string, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", errors.New("base URL must be an absolute http or https URL")
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" || parsed.User != nil {
+		return "", errors.New("base URL cannot have credentials, a query or a fragment")
+	}
+	parsed.Host = strings.ToLower(parsed.Host)
+	parsed.Path = strings.TrimSuffix(CanonicalPath(parsed.Path), "/")
+	parsed.RawPath = ""
+	return parsed.String(), nil
+}
+
+// Slugify turns text into a lowercase ASCII path segment of at most maxLength characters:
+// accented letters lose their accents, other characters that are not ASCII letters or digits
+// become single hyphens. It returns "" when nothing is left, e.g. for text in non-Latin scripts.
+func Slugify(text string, maxLength int) string {
+	var slug strings.Builder
+	hyphen := false
+	write := func(s string) {
+		if hyphen && slug.Len() > 0 {
+			slug.WriteByte('-')
+		}
+		hyphen = false
+		slug.WriteString(s)
+	}
+
+	for _, r := range norm.NFKD.String(text) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Accents left over by the decomposition
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			write(string(unicode.ToLower(r)))
+		case transliterations[r] != "":
+			write(transliterations[r])
+		case r == '\'' || r == '’':
+			// Apostrophes join words: "Ender's" becomes "enders"
+		default:
+			hyphen = true
+		}
+	}
+
+	result := slug.String()
+	if len(result) > maxLength {
+		result = result[:maxLength]
+		// Cut at a word boundary when there is one
+		if i := strings.LastIndexByte(result, '-'); i > 0 {
+			result = result[:i]
+		}
+		result = strings.TrimRight(result, "-")
+	}
+	return result
+}
+
+// UniqueSlug returns base, or base with the first free numeric suffix (base-2, base-3, ...)
+// when taken reports it is used
+func UniqueSlug(base string, taken func(slug string) (bool, error)) (string, error) {
+	slug := base
+	for n := 2; ; n++ {
+		used, err := taken(slug)
+		if err != nil {
+			return "", err
+		}
+		if !used {
+			return slug, nil
+		}
+		slug = base + "-" + strconv.Itoa(n)
+	}
+}
+
+// HasSlugBase reports whether slug is base or base with a numeric suffix, as UniqueSlug makes them
+func HasSlugBase(slug, base string) bool {
+	if slug == base {
+		return true
+	}
+	suffix, ok := strings.CutPrefix(slug, base+"-")
+	if !ok || suffix == "" {
+		return false
+	}
+	_, err := strconv.Atoi(suffix)
+	return err == nil
+}
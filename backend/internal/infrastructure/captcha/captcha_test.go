@@ -0,0 +1,57 @@
+package captcha
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokens_Verify(t *testing.T) {
+	verifier := NewTokens([]string{"partner-token", " ", ""})
+
+	ok, err := verifier.Verify(context.Background(), "partner-token", "")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	for _, token := range []string{"", " ", "other"} {
+		ok, _ := verifier.Verify(context.Background(), token, "")
+		assert.False(t, ok, token)
+	}
+}
+
+func TestSiteVerify_Verify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "site-secret", r.PostForm.Get("secret"))
+		assert.Equal(t, "203.0.113.7", r.PostForm.Get("remoteip"))
+		if r.PostForm.Get("response") == "solved" {
+			w.Write([]byte(`{"success":true}`))
+			return
+		}
+		w.Write([]byte(`{"success":false,"error-codes":["invalid-input-response"]}`))
+	}))
+	defer server.Close()
+	verifier := NewSiteVerify(server.URL, "site-secret")
+
+	ok, err := verifier.Verify(context.Background(), "solved", "203.0.113.7")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = verifier.Verify(context.Background(), "forged", "203.0.113.7")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestSiteVerify_ProviderFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := NewSiteVerify(server.URL, "site-secret").Verify(context.Background(), "solved", "")
+	assert.Error(t, err)
+}
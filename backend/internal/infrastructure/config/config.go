@@ -4,17 +4,56 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	API      APIConfig
-	CORS     CORSConfig
-	Logging  LoggingConfig
-	Swagger  SwaggerConfig
-	Security SecurityConfig
+	Server        ServerConfig
+	Database      DatabaseConfig
+	API           APIConfig
+	CORS          CORSConfig
+	Logging       LoggingConfig
+	Swagger       SwaggerConfig
+	Security      SecurityConfig
+	Jobs          JobsConfig
+	Notifications NotificationConfig
+	Quota         QuotaConfig
+	Reports       ReportsConfig
+	Scheduler     SchedulerConfig
+	Search        SearchConfig
+	Expensive     ExpensiveConfig
+	Popularity    PopularityConfig
+	Stats         StatsConfig
+	RelatedBooks  RelatedBooksConfig
+	Feeds         FeedsConfig
+	OPDS          OPDSConfig
+	Availability  AvailabilityConfig
+	QueryCache    QueryCacheConfig
+	ISBNCache     ISBNCacheConfig
+	HTTPCache     HTTPCacheConfig
+	Audit         AuditConfig
+	Analytics     AnalyticsConfig
+	Storage       StorageConfig
+	Import        ImportConfig
+	Artifacts     ArtifactsConfig
+	Covers        CoversConfig
+	UploadScan    UploadScanConfig
+	URLGuard      URLGuardConfig
+	SignIn        SignInConfig
+	URLProfiles   map[string][]string
+	URLDefaults   URLDefaultsConfig
+	URLCache      URLCacheConfig
+	URLSitemap    URLSitemapConfig
+	Redis         RedisConfig
+	State         StateConfig
+	Frontend      FrontendConfig
+	AdminUI       AdminUIConfig
+	Circulation   CirculationConfig
+	Enrichment    EnrichmentConfig
+	Accession     AccessionConfig
+	Validation    ValidationConfig
+	Sandbox       SandboxConfig
 }
 
 // ServerConfig holds server configuration
@@ -22,6 +61,15 @@ type ServerConfig struct {
 	Port        string
 	Host        string
 	Environment string
+	// ShutdownDelay is how long the server keeps serving, while reporting itself unready, after
+	// it is told to stop, so load balancers take it out of rotation first
+	ShutdownDelay time.Duration
+	// ShutdownTimeout is the grace period in-flight requests, background jobs and buffered work
+	// get to finish on shutdown
+	ShutdownTimeout time.Duration
+	// TrustedProxies are the networks of the proxies whose X-Forwarded-For headers tell the
+	// client IP; the default trusts any, so set it when client IPs are relied on
+	TrustedProxies []string
 }
 
 // DatabaseConfig holds database configuration
@@ -32,6 +80,19 @@ type DatabaseConfig struct {
 	Password string
 	Name     string
 	SSLMode  string
+	// WaitForDB is how long startup keeps trying to connect before giving up, waiting
+	// ConnectBackoff and doubling it between attempts; zero tries once
+	WaitForDB      time.Duration
+	ConnectBackoff time.Duration
+	// HealthInterval is how often the connection is checked once it is up
+	HealthInterval time.Duration
+	// ExplainEnabled explains the queries GORM builds and logs the sequential scans of tables of
+	// ExplainMinRows rows or more; it is ignored in production
+	ExplainEnabled bool
+	ExplainMinRows int
+	// IDStrategy generates the IDs of new records: uuidv4, random, or uuidv7 and ulid, which sort
+	// by creation time. Existing IDs stay valid whichever is picked.
+	IDStrategy string
 }
 
 // APIConfig holds API configuration
@@ -39,6 +100,35 @@ type APIConfig struct {
 	Version string
 	Prefix  string
 	Timeout string
+	// ErrorDocsURL is the page documenting error codes; GET /api/errors links to its #code anchors
+	ErrorDocsURL string
+	// V2Enabled serves the v2 response format on the /v2 prefix and through Accept negotiation
+	V2Enabled bool
+	// V2FieldNaming names the fields of v2 responses, snake_case or camelCase, unless the request
+	// asks for a naming in its Accept header or V2FieldNamingTenants, keyed by tenant, gives the
+	// tenant of the request a naming of its own
+	V2FieldNaming        string
+	V2FieldNamingTenants map[string]string
+	// LongPollTimeout is the longest a long poll is held open
+	LongPollTimeout time.Duration
+	// PublicBaseURL is where clients reach the service, e.g. https://library.example.com. The
+	// absolute links the API returns are built on it; without it links are relative, and the
+	// canonical links of books are omitted.
+	PublicBaseURL string
+	// DefaultPageSize and MaxPageSize are the page sizes of the paginated listings, unless
+	// PageSizes, keyed by listing, gives a listing sizes of its own
+	DefaultPageSize int
+	MaxPageSize     int
+	PageSizes       map[string]PageSizeConfig
+	// SortedJSONExports sorts the keys of bundles and JSON reports unless a request passes
+	// sorted=false, so that exports taken in different environments can be diffed
+	SortedJSONExports bool
+}
+
+// PageSizeConfig holds the page size a listing defaults to and the largest one it serves
+type PageSizeConfig struct {
+	Default int
+	Max     int
 }
 
 // CORSConfig holds CORS configuration
@@ -67,15 +157,455 @@ type SwaggerConfig struct {
 type SecurityConfig struct {
 	JWTSecret string
 	JWTExpiry string
+	// SetupToken guards the one-time bootstrap endpoint, which is disabled when it is empty
+	SetupToken string
+	// AdminAllowedCIDRs are the networks admin routes and deletions accept requests from, any
+	// when empty
+	AdminAllowedCIDRs []string
+	// CursorSecret signs the cursors of paginated listings; JWTSecret when empty. Instances behind
+	// a load balancer need the same one.
+	CursorSecret string
+	// AccessTokenLifetime is how long personal access tokens last unless their user asks otherwise,
+	// and AccessTokenMaxLifetime how long they may; zero lets them last forever
+	AccessTokenLifetime    time.Duration
+	AccessTokenMaxLifetime time.Duration
+	// SessionLifetime is how long the sessions users start by signing in last
+	SessionLifetime time.Duration
+	// ImpersonationLifetime is how long the sessions admins start to impersonate members last
+	ImpersonationLifetime time.Duration
+	// SessionMaxPerUser is how many active sessions a user may have, unlimited when zero
+	SessionMaxPerUser int
+	// PasswordMinLength and the PasswordRequire settings are the policy passwords are checked against
+	PasswordMinLength        int
+	PasswordRequireUppercase bool
+	PasswordRequireLowercase bool
+	PasswordRequireDigit     bool
+	PasswordRequireSymbol    bool
+}
+
+// JobsConfig holds background job queue configuration
+type JobsConfig struct {
+	Workers int
+	// QueueSize is the maximum number of jobs waiting in each pool; further jobs are refused
+	QueueSize    int
+	RetryBackoff time.Duration
+	// BackpressurePercent is how full a pool may get before write requests are refused with 503, zero disables it
+	BackpressurePercent int
+	// PriorityWeights are the shares of the workers of each job priority while several have jobs
+	// waiting, keyed by priority
+	PriorityWeights map[string]int64
+}
+
+// NotificationConfig holds outbound notification channel configuration
+type NotificationConfig struct {
+	EmailEnabled    bool
+	SMTPHost        string
+	SMTPPort        string
+	SMTPUsername    string
+	SMTPPassword    string
+	EmailFrom       string
+	WebhookEnabled  bool
+	WebhookURL      string
+	SlackEnabled    bool
+	SlackWebhookURL string
+	// Routes maps an event type to the channels it is delivered on
+	Routes      map[string][]string
+	MaxAttempts int
+	// Workers is the size of the pool delivering notifications, separate from the general job queue
+	Workers int
+}
+
+// QuotaConfig holds per user/client IP usage quota configuration
+type QuotaConfig struct {
+	Enabled bool
+	// MonthlyLimit is the default number of requests per month, zero means unlimited
+	MonthlyLimit int64
+	// Overrides maps a quota subject ("key:<access token id>", "tenant:<id>" or "ip:<address>") to
+	// its own monthly limit
+	Overrides map[string]int64
+}
+
+// ReportsConfig holds configuration for printable reports
+type ReportsConfig struct {
+	// PDFPageSize is the page format of PDF reports: a4, letter, label-4x6 or label-2x4
+	PDFPageSize string
+}
+
+// SchedulerConfig holds configuration for recurring background jobs
+type SchedulerConfig struct {
+	Enabled bool
+	// Jitter is the largest random delay added to each run
+	Jitter time.Duration
+	// DisabledJobs lists jobs that are registered but never run
+	DisabledJobs []string
+	// Schedules maps a job name to a cron expression replacing its default schedule
+	Schedules map[string]string
+	// TrashRetentionDays is how long deleted books stay in the trash before they are purged
+	TrashRetentionDays int
+	// MemberRetentionDays is how long deactivated accounts are kept before they are anonymized
+	MemberRetentionDays int
+	// LoanArchiveDays is how long returned loans stay among the current loans before they are
+	// moved to the loan history
+	LoanArchiveDays int
+	// LoanDueSoonDays is how many days before their due date members are reminded of a loan
+	LoanDueSoonDays int
+	// URLHistoryRetentionDays is how long processed URLs stay in the URL history
+	URLHistoryRetentionDays int
+	// LockEnabled makes replicas share job locks in the database, so each run happens on one instance only
+	LockEnabled bool
+	// LockTTL is how long a lock outlives an instance that died mid-run; it must exceed the longest run
+	LockTTL time.Duration
+	// InstanceID names this instance in job locks, defaults to the hostname and process ID
+	InstanceID string
+}
+
+// SearchConfig holds the throttling of expensive book searches
+type SearchConfig struct {
+	// ExpensiveConcurrency is how many expensive searches a caller may run at once, zero means unlimited
+	ExpensiveConcurrency int
+	// ExpensiveQueue is how many more of a caller's expensive searches may wait for a slot
+	ExpensiveQueue int
+	// ExpensiveWait is how long a search waits for a slot before it is turned away
+	ExpensiveWait time.Duration
+}
+
+// ExpensiveConfig holds the server-wide limit of expensive operations, such as large imports and
+// reports, so they cannot slow interactive requests down
+type ExpensiveConfig struct {
+	// Concurrency is how many expensive operations run at once, zero means unlimited
+	Concurrency int
+	// Queue is how many more may wait for a slot
+	Queue int
+	// Wait is how long an operation waits for a slot before it is turned away
+	Wait time.Duration
+}
+
+// PopularityConfig holds the ranking of books by their recent views and loans
+type PopularityConfig struct {
+	// WindowDays is how many days of views and loans count towards popularity
+	WindowDays int
+	// LoanWeight is how many views a loan is worth
+	LoanWeight int
+	// ViewFlushInterval is how often the views counted in memory are written to the database
+	ViewFlushInterval time.Duration
+	// ViewDedupWindow is how long further views of a book by the same visitor are not counted
+	ViewDedupWindow time.Duration
+}
+
+// StatsConfig holds the materialized stats tables the stats_refresh job recomputes
+type StatsConfig struct {
+	// TopAuthors is how many of the most borrowed authors are kept
+	TopAuthors int
+	// MaxAge is how old the stats may get before they are reported stale, zero never reports them stale
+	MaxAge time.Duration
+}
+
+// RelatedBooksConfig holds the ranking of related books, recomputed by the stats_refresh job
+type RelatedBooksConfig struct {
+	// Limit is how many related books are kept per book
+	Limit int
+	// The weights are the scores each signal adds, CoBorrowedWeight once per member who borrowed
+	// both books; zero ignores a signal
+	AuthorWeight     int
+	SeriesWeight     int
+	CategoryWeight   int
+	CoBorrowedWeight int
+}
+
+// FeedsConfig holds the feeds of new arrivals other sites embed
+type FeedsConfig struct {
+	// Title names the feeds
+	Title string
+	// Items is the length of feeds requested without a limit, MaxItems the most a limit may ask for
+	Items    int
+	MaxItems int
+	// MaxAge is the max-age of feeds, zero keeping them out of caches
+	MaxAge time.Duration
+}
+
+// OPDSConfig holds the OPDS catalog e-reader apps browse the library with
+type OPDSConfig struct {
+	// Enabled serves the catalog under /opds
+	Enabled bool
+	// Title names the catalog
+	Title string
+	// PageSize is how many books or authors a page of the catalog lists
+	PageSize int
+}
+
+// AvailabilityConfig holds the batch availability lookups discovery services poll
+type AvailabilityConfig struct {
+	// MaxISBNs is the most ISBNs a lookup may ask for
+	MaxISBNs int
+	// MaxAge is the max-age of lookups, zero keeping them out of caches, and Stale how long caches
+	// may serve them past it while revalidating by ETag
+	MaxAge time.Duration
+	Stale  time.Duration
+}
+
+// QueryCacheConfig holds the stale-while-revalidate cache of book listings and searches
+type QueryCacheConfig struct {
+	// TTL is how long a result is served as fresh, zero disables the cache
+	TTL time.Duration
+	// Stale is how long a result is served past its TTL while it is refreshed in the background
+	Stale      time.Duration
+	MaxEntries int
+}
+
+// ISBNCacheConfig holds the cache of book lookups by ISBN, which duplicate checks make
+type ISBNCacheConfig struct {
+	// TTL is how long a lookup is cached, bounding how long the writes of other instances take to
+	// show; zero disables the cache
+	TTL        time.Duration
+	MaxEntries int
+}
+
+// HTTPCacheConfig holds how long CDNs and other shared caches may keep API responses
+type HTTPCacheConfig struct {
+	// ListingMaxAge is the max-age of catalog listings, zero keeping them out of caches
+	ListingMaxAge time.Duration
+	// CoverMaxAge is the max-age of cover images, which are revalidated by ETag afterwards
+	CoverMaxAge time.Duration
+}
+
+// AuditConfig holds the write-behind buffer of audit entries
+type AuditConfig struct {
+	// Async buffers audit entries and writes them in the background instead of during requests
+	Async      bool
+	BufferSize int
+	BatchSize  int
+	// FlushInterval is how often a partial batch is written, and how long a failed write waits before its retry
+	FlushInterval time.Duration
+	// MaxAttempts is how many times a batch is written before it is dropped
+	MaxAttempts int
+}
+
+// AnalyticsConfig holds the anonymous usage analytics of the API
+type AnalyticsConfig struct {
+	// Enabled records which endpoints and features are used; false opts out of recording
+	Enabled bool
+	// FlushInterval is how often the events counted in memory are written to the database
+	FlushInterval time.Duration
+}
+
+// StorageConfig holds the soft quotas of the disk space the library uses
+type StorageConfig struct {
+	// Directories are the directories watched besides the database, by area name
+	Directories map[string]string
+	// LimitsMB are the soft quotas of the areas in MiB, by area name; the database is named database
+	LimitsMB map[string]int64
+	// WarnPercent is the share of its quota at which an area gets a warning
+	WarnPercent int
+}
+
+// ArtifactsConfig holds the read-only WebDAV access to storage areas, such as exports and backups
+type ArtifactsConfig struct {
+	// Areas are the areas of STORAGE_DIRECTORIES served under /dav, none turning access off
+	Areas []string
+	// Username and Password are the Basic credentials of the WebDAV clients
+	Username string
+	Password string
+}
+
+// CoversConfig holds the storage of book cover images
+type CoversConfig struct {
+	// Storage is where the images are kept; filesystem, under Dir, is the only driver
+	Storage string
+	// Dir is the directory the images are stored in, one file per content hash
+	Dir string
+	// MaxBytes is the size of the largest image accepted
+	MaxBytes int64
+	// MaxDimension is the largest width or height accepted, in pixels
+	MaxDimension int
+	// MaxPixels is the largest area accepted, checked from the image header before any decoding so
+	// that small files expanding to huge images are refused
+	MaxPixels int64
+	// FetchEnabled allows setting covers from a URL; FetchPrivate also allows private addresses
+	FetchEnabled bool
+	FetchPrivate bool
+	FetchTimeout time.Duration
+	// ImportMaxBytes bounds the ZIP archives of cover imports and ImportMaxFiles the images they
+	// hold; ImportJobsKept is how many imports are kept in memory with their reports
+	ImportMaxBytes int64
+	ImportMaxFiles int
+	ImportJobsKept int
+}
+
+// UploadScanConfig holds the malware scanning of uploaded covers and import files
+type UploadScanConfig struct {
+	// Scanner is none or clamav
+	Scanner string
+	// ClamAVAddress is the clamd address, host:port or the path of a unix socket
+	ClamAVAddress string
+	// Timeout bounds a scan, which fails beyond it
+	Timeout time.Duration
+	// OnInfected is reject, or accept to store infected files and only record the scan result
+	OnInfected string
+	// OnError is reject, or accept to store files the scanner fails to check
+	OnError string
+}
+
+// ImportConfig holds the bulk import of books
+type ImportConfig struct {
+	// ChunkSize is the number of books inserted per transaction
+	ChunkSize int
+	// DuplicateWindow is how long an imported file is remembered, zero turns duplicate detection off
+	DuplicateWindow time.Duration
+	// DuplicateAction is what happens to a file imported again within the window: "reject" or "warn"
+	DuplicateAction string
+}
+
+// URLGuardConfig holds the abuse protection of the anonymous URL processor
+type URLGuardConfig struct {
+	// IPLimit is the number of URL requests a client IP may make per IPWindow, zero means unlimited
+	IPLimit  int
+	IPWindow time.Duration
+	// TokenMode requires an X-URL-Token header: "none", "token" (one of Tokens) or "captcha"
+	// (a captcha response checked at CaptchaVerifyURL)
+	TokenMode        string
+	Tokens           []string
+	CaptchaVerifyURL string
+	CaptchaSecret    string
+}
+
+// SignInConfig holds the lockout of clients and accounts that fail to sign in
+type SignInConfig struct {
+	// MaxFailures failed sign-ins within FailureWindow ban the client IP and account; zero disables bans
+	MaxFailures   int
+	FailureWindow time.Duration
+	// BanDuration is the length of a first ban, doubled with each ban that follows, up to MaxBanDuration
+	BanDuration    time.Duration
+	MaxBanDuration time.Duration
+}
+
+// URLDefaultsConfig holds how lenient URL processing is with what requests leave out
+type URLDefaultsConfig struct {
+	// Operation is applied to requests with neither operation nor profile; empty requires one
+	Operation string
+	// Strict rejects URLs without a scheme instead of taking them for https ones
+	Strict bool
+}
+
+// URLCacheConfig holds the cache of processed URLs
+type URLCacheConfig struct {
+	// Backend is "memory" (per instance), "redis" (shared) or "none"
+	Backend string
+	TTL     time.Duration
+	// MaxEntries bounds the memory backend
+	MaxEntries int
+}
+
+// URLSitemapConfig holds the limits of sitemap canonicalization jobs
+type URLSitemapConfig struct {
+	// MaxBytes and MaxEntries default to the limits of the sitemap protocol
+	MaxBytes   int
+	MaxEntries int
+	// JobsKept is how many jobs, with their rewritten sitemaps, are kept in memory
+	JobsKept int
+	// FetchEnabled allows submitting sitemaps by URL; FetchPrivate also allows private addresses
+	FetchEnabled bool
+	FetchPrivate bool
+	FetchTimeout time.Duration
+}
+
+// RedisConfig holds the Redis connection used by shared caches and state
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+}
+
+// StateConfig selects where the state instances must agree on is kept: rate limits, usage
+// counters, query cache invalidations and events broadcast to waiting clients
+type StateConfig struct {
+	// Backend is "memory" (each instance on its own) or "redis" (shared by every instance)
+	Backend string
+}
+
+// FrontendConfig holds the frontend build embedded in binaries built with the embedfrontend tag
+type FrontendConfig struct {
+	// Enabled serves the embedded build under /, when the binary has one
+	Enabled bool
+}
+
+// AdminUIConfig holds the server-rendered admin pages
+type AdminUIConfig struct {
+	// Enabled serves the pages under /admin, to admin users signing in with their email and password
+	Enabled bool
+}
+
+// CirculationConfig holds the desk shifts policy overrides are reported by, and the timezone
+// circulation falls back to
+type CirculationConfig struct {
+	// Shifts maps a shift name to its span as HH:MM-HH:MM; overrides outside every shift are
+	// reported as off_hours
+	Shifts map[string]string
+	// ShiftTimezone is the IANA timezone the shifts and weekly override reports are in
+	ShiftTimezone string
+	// Timezone is the IANA timezone of tenants and branches without one of their own, which their
+	// due dates, closed days, monthly loan stats and report schedules are in
+	Timezone string
+}
+
+// EnrichmentConfig holds the metadata provider bulk enrichment looks books up with
+type EnrichmentConfig struct {
+	// Enabled allows admins to start enrichment jobs
+	Enabled bool
+	// ProviderURL is the Open Library instance books are looked up on, CoverURL the one serving
+	// their covers
+	ProviderURL string
+	CoverURL    string
+	Timeout     time.Duration
+	// RequestInterval is the least time between two lookups, so the provider's rate limits are kept
+	RequestInterval time.Duration
+	// MaxBooks bounds the books a job looks up, and JobsKept how many jobs are kept in memory
+	MaxBooks int
+	JobsKept int
+}
+
+// AccessionConfig holds how the accession numbers of copies are written
+type AccessionConfig struct {
+	// Prefix starts the numbers of copies of the library and of branches without a prefix of
+	// their own
+	Prefix string
+	// Digits is the length numbers are padded to with zeros
+	Digits int
+}
+
+// SandboxConfig holds the sandbox mode, for deployments integrators test against
+type SandboxConfig struct {
+	// Enabled marks the deployment as a sandbox, whose data the sandbox_reset job restores to its
+	// baseline nightly. Its database must be named for it, containing "sandbox", so a production
+	// database is never reset by mistake.
+	Enabled bool
+}
+
+// ValidationConfig holds the thresholds of the built-in validation of books
+type ValidationConfig struct {
+	// MinYear and MaxYear bound the year of books, inclusive
+	MinYear int
+	MaxYear int
+	// ISBNCheck is length, accepting ISBNs of 10 to 13 characters, or checksum, accepting
+	// ISBN-10s and ISBN-13s with a valid check digit
+	ISBNCheck string
+	// MinTitleLength and MaxTitleLength bound the characters of titles; 0 leaves them unbounded
+	MinTitleLength int
+	MaxTitleLength int
+	// Tenants overrides settings for tenants, keyed by tenant and then by setting, e.g.
+	// min_year or isbn_check
+	Tenants map[string]map[string]string
 }
 
 // Load loads configuration from environment variables
 func Load() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Port:        getEnv("BACKEND_PORT", "8080"),
-			Host:        getEnv("BACKEND_HOST", "localhost"),
-			Environment: getEnv("BACKEND_ENVIRONMENT", "development"),
+			Port:            getEnv("BACKEND_PORT", "8080"),
+			Host:            getEnv("BACKEND_HOST", "localhost"),
+			Environment:     getEnv("BACKEND_ENVIRONMENT", "development"),
+			ShutdownDelay:   getEnvDuration("BACKEND_SHUTDOWN_DELAY", 0),
+			ShutdownTimeout: getEnvDuration("BACKEND_SHUTDOWN_TIMEOUT", 15*time.Second),
+			TrustedProxies:  parseList(getEnv("BACKEND_TRUSTED_PROXIES", "0.0.0.0/0,::/0")),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -84,16 +614,33 @@ func Load() *Config {
 			Password: getEnv("DB_PASSWORD", "postgres"),
 			Name:     getEnv("DB_NAME", "library_management"),
 			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
+
+			WaitForDB:      getEnvSeconds("WAIT_FOR_DB", time.Minute),
+			ConnectBackoff: getEnvDuration("DB_CONNECT_BACKOFF", time.Second),
+			HealthInterval: getEnvDuration("DB_HEALTH_INTERVAL", 10*time.Second),
+			ExplainEnabled: getEnvBool("DB_EXPLAIN_ENABLED", false),
+			ExplainMinRows: getEnvInt("DB_EXPLAIN_MIN_ROWS", 10000),
+			IDStrategy:     getEnv("DB_ID_STRATEGY", "uuidv4"),
 		},
 		API: APIConfig{
-			Version: getEnv("API_VERSION", "v1"),
-			Prefix:  getEnv("API_PREFIX", "/api"),
-			Timeout: getEnv("API_TIMEOUT", "30s"),
+			Version:              getEnv("API_VERSION", "v1"),
+			Prefix:               getEnv("API_PREFIX", "/api"),
+			Timeout:              getEnv("API_TIMEOUT", "30s"),
+			ErrorDocsURL:         getEnv("ERROR_DOCS_URL", "https://github.com/hanifmaliki/byfood-assignment/blob/main/API_EXAMPLES.md"),
+			V2Enabled:            getEnvBool("API_V2_ENABLED", true),
+			V2FieldNaming:        getEnv("API_V2_FIELD_NAMING", "snake_case"),
+			V2FieldNamingTenants: parsePaths(getEnv("API_V2_FIELD_NAMING_TENANTS", "")),
+			LongPollTimeout:      getEnvDuration("LONG_POLL_TIMEOUT", 30*time.Second),
+			PublicBaseURL:        getEnv("PUBLIC_BASE_URL", ""),
+			DefaultPageSize:      getEnvInt("PAGE_SIZE_DEFAULT", 50),
+			MaxPageSize:          getEnvInt("PAGE_SIZE_MAX", 200),
+			PageSizes:            parsePageSizes(getEnv("PAGE_SIZES", "timeline=20/100,reports=50/500,report_runs=20/100")),
+			SortedJSONExports:    getEnvBool("SORTED_JSON_EXPORTS", false),
 		},
 		CORS: CORSConfig{
 			AllowedOrigins: strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:3000,http://127.0.0.1:3000,http://localhost:8080,http://127.0.0.1:8080"), ","),
 			AllowedMethods: strings.Split(getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,DELETE,OPTIONS"), ","),
-			AllowedHeaders: strings.Split(getEnv("CORS_ALLOWED_HEADERS", "Content-Type,Authorization"), ","),
+			AllowedHeaders: strings.Split(getEnv("CORS_ALLOWED_HEADERS", "Content-Type,Authorization,traceparent,tracestate,X-Session-ID"), ","),
 		},
 		Logging: LoggingConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
@@ -104,11 +651,246 @@ func Load() *Config {
 			Enabled:     getEnvBool("SWAGGER_ENABLED", true),
 			Title:       getEnv("SWAGGER_TITLE", "Library Management System API"),
 			Description: getEnv("SWAGGER_DESCRIPTION", "A RESTful API for managing books and URL processing"),
-			Version:     getEnv("SWAGGER_VERSION", "1.0"),
+			Version:     getEnv("SWAGGER_VERSION", "1.1"),
 		},
 		Security: SecurityConfig{
-			JWTSecret: getEnv("JWT_SECRET", "your-super-secret-jwt-key-change-this-in-production"),
-			JWTExpiry: getEnv("JWT_EXPIRY", "24h"),
+			JWTSecret:                getEnv("JWT_SECRET", "your-super-secret-jwt-key-change-this-in-production"),
+			JWTExpiry:                getEnv("JWT_EXPIRY", "24h"),
+			SetupToken:               getEnv("SETUP_TOKEN", ""),
+			AdminAllowedCIDRs:        parseList(getEnv("ADMIN_ALLOWED_CIDRS", "")),
+			CursorSecret:             getEnv("CURSOR_SECRET", ""),
+			AccessTokenLifetime:      getEnvDuration("ACCESS_TOKEN_LIFETIME", 90*24*time.Hour),
+			AccessTokenMaxLifetime:   getEnvDuration("ACCESS_TOKEN_MAX_LIFETIME", 365*24*time.Hour),
+			SessionLifetime:          getEnvDuration("SESSION_LIFETIME", 30*24*time.Hour),
+			ImpersonationLifetime:    getEnvDuration("IMPERSONATION_LIFETIME", 30*time.Minute),
+			SessionMaxPerUser:        getEnvInt("SESSION_MAX_PER_USER", 10),
+			PasswordMinLength:        getEnvInt("PASSWORD_MIN_LENGTH", 12),
+			PasswordRequireUppercase: getEnvBool("PASSWORD_REQUIRE_UPPERCASE", false),
+			PasswordRequireLowercase: getEnvBool("PASSWORD_REQUIRE_LOWERCASE", false),
+			PasswordRequireDigit:     getEnvBool("PASSWORD_REQUIRE_DIGIT", false),
+			PasswordRequireSymbol:    getEnvBool("PASSWORD_REQUIRE_SYMBOL", false),
+		},
+		Jobs: JobsConfig{
+			Workers:             getEnvInt("JOBS_WORKERS", 4),
+			QueueSize:           getEnvInt("JOBS_QUEUE_SIZE", 100),
+			RetryBackoff:        getEnvDuration("JOBS_RETRY_BACKOFF", 2*time.Second),
+			BackpressurePercent: getEnvInt("JOBS_BACKPRESSURE_PERCENT", 90),
+			PriorityWeights:     parseLimits(getEnv("JOBS_PRIORITY_WEIGHTS", "interactive=6,scheduled=3,backfill=1")),
+		},
+		Notifications: NotificationConfig{
+			EmailEnabled:    getEnvBool("NOTIFY_EMAIL_ENABLED", false),
+			SMTPHost:        getEnv("SMTP_HOST", "localhost"),
+			SMTPPort:        getEnv("SMTP_PORT", "25"),
+			SMTPUsername:    getEnv("SMTP_USERNAME", ""),
+			SMTPPassword:    getEnv("SMTP_PASSWORD", ""),
+			EmailFrom:       getEnv("NOTIFY_EMAIL_FROM", "library@localhost"),
+			WebhookEnabled:  getEnvBool("NOTIFY_WEBHOOK_ENABLED", false),
+			WebhookURL:      getEnv("NOTIFY_WEBHOOK_URL", ""),
+			SlackEnabled:    getEnvBool("NOTIFY_SLACK_ENABLED", false),
+			SlackWebhookURL: getEnv("NOTIFY_SLACK_WEBHOOK_URL", ""),
+			Routes:          parseRoutes(getEnv("NOTIFY_ROUTES", "hold.available=email,webhook,slack;loan.due_soon=email,webhook,slack;change_request.approved=email,webhook,slack;loan.renewed=email,webhook,slack;storage.threshold_crossed=webhook,slack")),
+			MaxAttempts:     getEnvInt("NOTIFY_MAX_ATTEMPTS", 3),
+			Workers:         getEnvInt("NOTIFY_WORKERS", 2),
+		},
+		Quota: QuotaConfig{
+			Enabled:      getEnvBool("QUOTA_ENABLED", false),
+			MonthlyLimit: int64(getEnvInt("QUOTA_MONTHLY_LIMIT", 100000)),
+			Overrides:    parseLimits(getEnv("QUOTA_OVERRIDES", "")),
+		},
+		Reports: ReportsConfig{
+			PDFPageSize: getEnv("REPORT_PDF_PAGE_SIZE", "a4"),
+		},
+		Scheduler: SchedulerConfig{
+			Enabled:                 getEnvBool("SCHEDULER_ENABLED", true),
+			Jitter:                  getEnvDuration("SCHEDULER_JITTER", 30*time.Second),
+			DisabledJobs:            strings.Split(getEnv("SCHEDULER_DISABLED_JOBS", "trash_purge"), ","),
+			Schedules:               parseSchedules(getEnv("SCHEDULER_SCHEDULES", "")),
+			TrashRetentionDays:      getEnvInt("TRASH_RETENTION_DAYS", 30),
+			MemberRetentionDays:     getEnvInt("MEMBER_RETENTION_DAYS", 365),
+			LoanArchiveDays:         getEnvInt("LOAN_ARCHIVE_DAYS", 365),
+			LoanDueSoonDays:         getEnvInt("LOAN_DUE_SOON_DAYS", 2),
+			URLHistoryRetentionDays: getEnvInt("URL_HISTORY_RETENTION_DAYS", 90),
+			LockEnabled:             getEnvBool("SCHEDULER_LOCK_ENABLED", true),
+			LockTTL:                 getEnvDuration("SCHEDULER_LOCK_TTL", 10*time.Minute),
+			InstanceID:              getEnv("SCHEDULER_INSTANCE_ID", ""),
+		},
+		Search: SearchConfig{
+			ExpensiveConcurrency: getEnvInt("SEARCH_EXPENSIVE_CONCURRENCY", 2),
+			ExpensiveQueue:       getEnvInt("SEARCH_EXPENSIVE_QUEUE", 4),
+			ExpensiveWait:        getEnvDuration("SEARCH_EXPENSIVE_WAIT", 10*time.Second),
+		},
+		Expensive: ExpensiveConfig{
+			Concurrency: getEnvInt("EXPENSIVE_CONCURRENCY", 4),
+			Queue:       getEnvInt("EXPENSIVE_QUEUE", 8),
+			Wait:        getEnvDuration("EXPENSIVE_WAIT", 30*time.Second),
+		},
+		Popularity: PopularityConfig{
+			WindowDays:        getEnvInt("POPULARITY_WINDOW_DAYS", 30),
+			LoanWeight:        getEnvInt("POPULARITY_LOAN_WEIGHT", 10),
+			ViewFlushInterval: getEnvDuration("POPULARITY_VIEW_FLUSH_INTERVAL", time.Minute),
+			ViewDedupWindow:   getEnvDuration("POPULARITY_VIEW_DEDUP_WINDOW", 30*time.Minute),
+		},
+		Stats: StatsConfig{
+			TopAuthors: getEnvInt("STATS_TOP_AUTHORS", 10),
+			MaxAge:     getEnvDuration("STATS_MAX_AGE", 30*time.Minute),
+		},
+		RelatedBooks: RelatedBooksConfig{
+			Limit:            getEnvInt("RELATED_BOOKS_LIMIT", 10),
+			AuthorWeight:     getEnvInt("RELATED_BOOKS_AUTHOR_WEIGHT", 3),
+			SeriesWeight:     getEnvInt("RELATED_BOOKS_SERIES_WEIGHT", 5),
+			CategoryWeight:   getEnvInt("RELATED_BOOKS_CATEGORY_WEIGHT", 2),
+			CoBorrowedWeight: getEnvInt("RELATED_BOOKS_CO_BORROWED_WEIGHT", 1),
+		},
+		Feeds: FeedsConfig{
+			Title:    getEnv("FEEDS_TITLE", "New arrivals"),
+			Items:    getEnvInt("FEEDS_ITEMS", 20),
+			MaxItems: getEnvInt("FEEDS_MAX_ITEMS", 50),
+			MaxAge:   getEnvDuration("FEEDS_MAX_AGE", 15*time.Minute),
+		},
+		OPDS: OPDSConfig{
+			Enabled:  getEnvBool("OPDS_ENABLED", true),
+			Title:    getEnv("OPDS_TITLE", "Library catalog"),
+			PageSize: getEnvInt("OPDS_PAGE_SIZE", 50),
+		},
+		Availability: AvailabilityConfig{
+			MaxISBNs: getEnvInt("AVAILABILITY_MAX_ISBNS", 100),
+			MaxAge:   getEnvDuration("AVAILABILITY_MAX_AGE", time.Minute),
+			Stale:    getEnvDuration("AVAILABILITY_STALE", 5*time.Minute),
+		},
+		QueryCache: QueryCacheConfig{
+			TTL:        getEnvDuration("QUERY_CACHE_TTL", 5*time.Second),
+			Stale:      getEnvDuration("QUERY_CACHE_STALE", 30*time.Second),
+			MaxEntries: getEnvInt("QUERY_CACHE_MAX_ENTRIES", 1000),
+		},
+		ISBNCache: ISBNCacheConfig{
+			TTL:        getEnvDuration("ISBN_CACHE_TTL", 10*time.Second),
+			MaxEntries: getEnvInt("ISBN_CACHE_MAX_ENTRIES", 10000),
+		},
+		HTTPCache: HTTPCacheConfig{
+			ListingMaxAge: getEnvDuration("HTTP_CACHE_LISTING_MAX_AGE", 30*time.Second),
+			CoverMaxAge:   getEnvDuration("HTTP_CACHE_COVER_MAX_AGE", 24*time.Hour),
+		},
+		Analytics: AnalyticsConfig{
+			Enabled:       getEnvBool("ANALYTICS_ENABLED", true),
+			FlushInterval: getEnvDuration("ANALYTICS_FLUSH_INTERVAL", time.Minute),
+		},
+		Storage: StorageConfig{
+			Directories: parsePaths(getEnv("STORAGE_DIRECTORIES", "")),
+			LimitsMB:    parseLimits(getEnv("STORAGE_LIMITS_MB", "")),
+			WarnPercent: getEnvInt("STORAGE_WARN_PERCENT", 80),
+		},
+		Audit: AuditConfig{
+			Async:         getEnvBool("AUDIT_ASYNC", true),
+			BufferSize:    getEnvInt("AUDIT_BUFFER_SIZE", 1000),
+			BatchSize:     getEnvInt("AUDIT_BATCH_SIZE", 100),
+			FlushInterval: getEnvDuration("AUDIT_FLUSH_INTERVAL", time.Second),
+			MaxAttempts:   getEnvInt("AUDIT_MAX_ATTEMPTS", 3),
+		},
+		Import: ImportConfig{
+			ChunkSize:       getEnvInt("IMPORT_CHUNK_SIZE", 500),
+			DuplicateWindow: getEnvDuration("IMPORT_DUPLICATE_WINDOW", 24*time.Hour),
+			DuplicateAction: getEnv("IMPORT_DUPLICATE_ACTION", "reject"),
+		},
+		Artifacts: ArtifactsConfig{
+			Areas:    parseList(getEnv("ARTIFACTS_AREAS", "")),
+			Username: getEnv("ARTIFACTS_USERNAME", "artifacts"),
+			Password: getEnv("ARTIFACTS_PASSWORD", ""),
+		},
+		Covers: CoversConfig{
+			Storage:        getEnv("COVERS_STORAGE", "filesystem"),
+			Dir:            getEnv("COVERS_DIR", "data/covers"),
+			MaxBytes:       int64(getEnvInt("COVERS_MAX_BYTES", 5<<20)),
+			MaxDimension:   getEnvInt("COVERS_MAX_DIMENSION", 6000),
+			MaxPixels:      int64(getEnvInt("COVERS_MAX_PIXELS", 24000000)),
+			FetchEnabled:   getEnvBool("COVERS_FETCH_ENABLED", true),
+			FetchPrivate:   getEnvBool("COVERS_FETCH_PRIVATE", false),
+			FetchTimeout:   getEnvDuration("COVERS_FETCH_TIMEOUT", 15*time.Second),
+			ImportMaxBytes: int64(getEnvInt("COVERS_IMPORT_MAX_BYTES", 200<<20)),
+			ImportMaxFiles: getEnvInt("COVERS_IMPORT_MAX_FILES", 1000),
+			ImportJobsKept: getEnvInt("COVERS_IMPORT_JOBS_KEPT", 20),
+		},
+		UploadScan: UploadScanConfig{
+			Scanner:       getEnv("UPLOAD_SCANNER", "none"),
+			ClamAVAddress: getEnv("CLAMAV_ADDRESS", "localhost:3310"),
+			Timeout:       getEnvDuration("UPLOAD_SCAN_TIMEOUT", 30*time.Second),
+			OnInfected:    getEnv("UPLOAD_SCAN_ON_INFECTED", "reject"),
+			OnError:       getEnv("UPLOAD_SCAN_ON_ERROR", "reject"),
+		},
+		URLGuard: URLGuardConfig{
+			IPLimit:          getEnvInt("URL_IP_LIMIT", 60),
+			IPWindow:         getEnvDuration("URL_IP_WINDOW", time.Minute),
+			TokenMode:        getEnv("URL_TOKEN_MODE", "none"),
+			Tokens:           strings.Split(getEnv("URL_TOKENS", ""), ","),
+			CaptchaVerifyURL: getEnv("URL_CAPTCHA_VERIFY_URL", "https://hcaptcha.com/siteverify"),
+			CaptchaSecret:    getEnv("URL_CAPTCHA_SECRET", ""),
+		},
+		SignIn: SignInConfig{
+			MaxFailures:    getEnvInt("SIGNIN_MAX_FAILURES", 5),
+			FailureWindow:  getEnvDuration("SIGNIN_FAILURE_WINDOW", 15*time.Minute),
+			BanDuration:    getEnvDuration("SIGNIN_BAN_DURATION", time.Minute),
+			MaxBanDuration: getEnvDuration("SIGNIN_MAX_BAN_DURATION", 24*time.Hour),
+		},
+		// URL processing profiles beyond the built-in ones, e.g. "share=strip_tracking,sort_query"
+		URLProfiles: parseRoutes(getEnv("URL_PROFILES", "")),
+		URLDefaults: URLDefaultsConfig{
+			Operation: getEnv("URL_DEFAULT_OPERATION", ""),
+			Strict:    getEnvBool("URL_STRICT", false),
+		},
+		URLCache: URLCacheConfig{
+			Backend:    getEnv("URL_CACHE_BACKEND", "memory"),
+			TTL:        getEnvDuration("URL_CACHE_TTL", time.Hour),
+			MaxEntries: getEnvInt("URL_CACHE_MAX_ENTRIES", 10000),
+		},
+		URLSitemap: URLSitemapConfig{
+			MaxBytes:     getEnvInt("URL_SITEMAP_MAX_BYTES", 50<<20),
+			MaxEntries:   getEnvInt("URL_SITEMAP_MAX_ENTRIES", 50000),
+			JobsKept:     getEnvInt("URL_SITEMAP_JOBS_KEPT", 50),
+			FetchEnabled: getEnvBool("URL_SITEMAP_FETCH_ENABLED", true),
+			FetchPrivate: getEnvBool("URL_SITEMAP_FETCH_PRIVATE", false),
+			FetchTimeout: getEnvDuration("URL_SITEMAP_FETCH_TIMEOUT", 30*time.Second),
+		},
+		Redis: RedisConfig{
+			Addr:     getEnv("REDIS_ADDR", "localhost:6379"),
+			Password: getEnv("REDIS_PASSWORD", ""),
+			DB:       getEnvInt("REDIS_DB", 0),
+		},
+		State: StateConfig{
+			Backend: getEnv("STATE_BACKEND", "memory"),
+		},
+		Frontend: FrontendConfig{
+			Enabled: getEnvBool("FRONTEND_ENABLED", true),
+		},
+		AdminUI: AdminUIConfig{
+			Enabled: getEnvBool("ADMIN_UI_ENABLED", true),
+		},
+		Circulation: CirculationConfig{
+			Shifts:        parseSchedules(getEnv("CIRCULATION_SHIFTS", "morning=08:00-13:00;afternoon=13:00-18:00;evening=18:00-22:00")),
+			ShiftTimezone: getEnv("CIRCULATION_SHIFT_TIMEZONE", "UTC"),
+			Timezone:      getEnv("CIRCULATION_TIMEZONE", "UTC"),
+		},
+		Enrichment: EnrichmentConfig{
+			Enabled:         getEnvBool("ENRICH_ENABLED", true),
+			ProviderURL:     getEnv("ENRICH_PROVIDER_URL", "https://openlibrary.org"),
+			CoverURL:        getEnv("ENRICH_COVER_URL", "https://covers.openlibrary.org"),
+			Timeout:         getEnvDuration("ENRICH_TIMEOUT", 10*time.Second),
+			RequestInterval: getEnvDuration("ENRICH_REQUEST_INTERVAL", time.Second),
+			MaxBooks:        getEnvInt("ENRICH_MAX_BOOKS", 500),
+			JobsKept:        getEnvInt("ENRICH_JOBS_KEPT", 20),
+		},
+		Accession: AccessionConfig{
+			Prefix: getEnv("ACCESSION_PREFIX", "ACC-"),
+			Digits: getEnvInt("ACCESSION_DIGITS", 6),
+		},
+		Validation: ValidationConfig{
+			MinYear:        getEnvInt("VALIDATION_MIN_YEAR", 1000),
+			MaxYear:        getEnvInt("VALIDATION_MAX_YEAR", 2100),
+			ISBNCheck:      getEnv("VALIDATION_ISBN_CHECK", "length"),
+			MinTitleLength: getEnvInt("VALIDATION_TITLE_MIN_LENGTH", 1),
+			MaxTitleLength: getEnvInt("VALIDATION_TITLE_MAX_LENGTH", 0),
+			Tenants:        parseOverrides(getEnv("VALIDATION_TENANTS", "")),
+		},
+		Sandbox: SandboxConfig{
+			Enabled: getEnvBool("SANDBOX_ENABLED", false),
 		},
 	}
 }
@@ -140,3 +922,147 @@ func getEnvInt(key string, fallback int) int {
 	}
 	return fallback
 }
+
+// getEnvDuration gets environment variable as duration with fallback
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if durationValue, err := time.ParseDuration(value); err == nil {
+			return durationValue
+		}
+	}
+	return fallback
+}
+
+// getEnvSeconds gets environment variable as a duration, or a number of seconds, with fallback
+func getEnvSeconds(key string, fallback time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return getEnvDuration(key, fallback)
+}
+
+// parseList parses "value,value" into a list, skipping blank entries
+func parseList(value string) []string {
+	var list []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			list = append(list, entry)
+		}
+	}
+	return list
+}
+
+// parseRoutes parses "name=value,value;name=value" into a table, such as notification routes
+// ("event=channel,channel") or URL profiles ("profile=operation,operation")
+func parseRoutes(value string) map[string][]string {
+	routes := make(map[string][]string)
+	for _, entry := range strings.Split(value, ";") {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			continue
+		}
+		var channels []string
+		for _, channel := range strings.Split(parts[1], ",") {
+			if channel = strings.TrimSpace(channel); channel != "" {
+				channels = append(channels, channel)
+			}
+		}
+		routes[strings.TrimSpace(parts[0])] = channels
+	}
+	return routes
+}
+
+// parseLimits parses "subject=limit,subject=limit" into a limit table, skipping malformed entries
+func parseLimits(value string) map[string]int64 {
+	limits := make(map[string]int64)
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		limit, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+		if err != nil {
+			continue
+		}
+		limits[strings.TrimSpace(parts[0])] = limit
+	}
+	return limits
+}
+
+// parsePageSizes parses "listing=default/max,listing=default/max" into a page size table,
+// skipping malformed entries
+func parsePageSizes(value string) map[string]PageSizeConfig {
+	sizes := make(map[string]PageSizeConfig)
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			continue
+		}
+		defaultSize, maxSize, ok := strings.Cut(parts[1], "/")
+		if !ok {
+			continue
+		}
+		d, err := strconv.Atoi(strings.TrimSpace(defaultSize))
+		if err != nil {
+			continue
+		}
+		m, err := strconv.Atoi(strings.TrimSpace(maxSize))
+		if err != nil {
+			continue
+		}
+		sizes[strings.TrimSpace(parts[0])] = PageSizeConfig{Default: d, Max: m}
+	}
+	return sizes
+}
+
+// parsePaths parses "name=path;name=path" into a path table, skipping malformed entries
+func parsePaths(value string) map[string]string {
+	paths := make(map[string]string)
+	for _, entry := range strings.Split(value, ";") {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			continue
+		}
+		paths[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return paths
+}
+
+// parseSchedules parses "job=cron expression;job=cron expression" into a schedule table
+func parseSchedules(value string) map[string]string {
+	schedules := make(map[string]string)
+	for _, entry := range strings.Split(value, ";") {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			continue
+		}
+		schedules[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return schedules
+}
+
+// parseOverrides parses "name=setting:value,setting:value;name=setting:value" into a table of
+// settings by name, such as the validation settings of tenants, skipping malformed entries
+func parseOverrides(value string) map[string]map[string]string {
+	overrides := make(map[string]map[string]string)
+	for _, entry := range strings.Split(value, ";") {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			continue
+		}
+		settings := make(map[string]string)
+		for _, setting := range strings.Split(parts[1], ",") {
+			key, val, ok := strings.Cut(setting, ":")
+			if !ok || strings.TrimSpace(key) == "" {
+				continue
+			}
+			settings[strings.TrimSpace(key)] = strings.TrimSpace(val)
+		}
+		if len(settings) > 0 {
+			overrides[strings.TrimSpace(parts[0])] = settings
+		}
+	}
+	return overrides
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -59,7 +60,7 @@ func TestLoad(t *testing.T) {
 
 	assert.Equal(t, []string{"http://localhost:3000", "http://localhost:3001"}, config.CORS.AllowedOrigins)
 	assert.Equal(t, []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}, config.CORS.AllowedMethods)
-	assert.Equal(t, []string{"Content-Type", "Authorization"}, config.CORS.AllowedHeaders)
+	assert.Equal(t, []string{"Content-Type", "Authorization", "traceparent", "tracestate", "X-Session-ID"}, config.CORS.AllowedHeaders)
 
 	assert.Equal(t, "info", config.Logging.Level)
 	assert.Equal(t, "json", config.Logging.Format)
@@ -68,10 +69,11 @@ func TestLoad(t *testing.T) {
 	assert.True(t, config.Swagger.Enabled)
 	assert.Equal(t, "Library Management System API", config.Swagger.Title)
 	assert.Equal(t, "A RESTful API for managing books and URL processing", config.Swagger.Description)
-	assert.Equal(t, "1.0", config.Swagger.Version)
+	assert.Equal(t, "1.1", config.Swagger.Version)
 
 	assert.Equal(t, "your-super-secret-jwt-key-change-this-in-production", config.Security.JWTSecret)
 	assert.Equal(t, "24h", config.Security.JWTExpiry)
+	assert.Empty(t, config.Security.CursorSecret)
 }
 
 func TestLoad_WithEnvironmentVariables(t *testing.T) {
@@ -281,3 +283,74 @@ func TestConfig_Validation(t *testing.T) {
 	assert.Greater(t, len(config.CORS.AllowedMethods), 0)
 	assert.Greater(t, len(config.CORS.AllowedHeaders), 0)
 }
+
+func TestGetEnvDuration(t *testing.T) {
+	defer os.Unsetenv("TEST_DURATION_VAR")
+
+	os.Setenv("TEST_DURATION_VAR", "5s")
+	assert.Equal(t, 5*time.Second, getEnvDuration("TEST_DURATION_VAR", time.Second))
+
+	os.Setenv("TEST_DURATION_VAR", "invalid")
+	assert.Equal(t, time.Second, getEnvDuration("TEST_DURATION_VAR", time.Second))
+
+	os.Unsetenv("TEST_DURATION_VAR")
+	assert.Equal(t, time.Minute, getEnvDuration("TEST_DURATION_VAR", time.Minute))
+}
+
+func TestGetEnvSeconds(t *testing.T) {
+	defer os.Unsetenv("TEST_SECONDS_VAR")
+
+	os.Setenv("TEST_SECONDS_VAR", "90")
+	assert.Equal(t, 90*time.Second, getEnvSeconds("TEST_SECONDS_VAR", time.Minute))
+
+	os.Setenv("TEST_SECONDS_VAR", "2m")
+	assert.Equal(t, 2*time.Minute, getEnvSeconds("TEST_SECONDS_VAR", time.Minute))
+
+	os.Setenv("TEST_SECONDS_VAR", "soon")
+	assert.Equal(t, time.Minute, getEnvSeconds("TEST_SECONDS_VAR", time.Minute))
+}
+
+func TestParseRoutes(t *testing.T) {
+	routes := parseRoutes("hold.available=email, slack;loan.due_soon=webhook;;invalid")
+
+	assert.Equal(t, map[string][]string{
+		"hold.available": {"email", "slack"},
+		"loan.due_soon":  {"webhook"},
+	}, routes)
+}
+
+func TestParseLimits(t *testing.T) {
+	limits := parseLimits("key:abc=500, tenant:acme=10000,broken,key:x=notanumber")
+
+	assert.Equal(t, map[string]int64{
+		"key:abc":     500,
+		"tenant:acme": 10000,
+	}, limits)
+}
+
+func TestParsePageSizes(t *testing.T) {
+	sizes := parsePageSizes("timeline=20/100, reports = 50/500,broken,imports=50,runs=x/10")
+
+	assert.Equal(t, map[string]PageSizeConfig{
+		"timeline": {Default: 20, Max: 100},
+		"reports":  {Default: 50, Max: 500},
+	}, sizes)
+}
+
+func TestParseSchedules(t *testing.T) {
+	schedules := parseSchedules("trash_purge=0 3 * * *; report_subscriptions = */5 * * * *;;broken;empty=")
+
+	assert.Equal(t, map[string]string{
+		"trash_purge":          "0 3 * * *",
+		"report_subscriptions": "*/5 * * * *",
+	}, schedules)
+}
+
+func TestParseOverrides(t *testing.T) {
+	overrides := parseOverrides("acme=min_year:1450, isbn_check:checksum; globex = max_title_length:200,broken;;empty=;=min_year:1")
+
+	assert.Equal(t, map[string]map[string]string{
+		"acme":   {"min_year": "1450", "isbn_check": "checksum"},
+		"globex": {"max_title_length": "200"},
+	}, overrides)
+}
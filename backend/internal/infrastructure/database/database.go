@@ -1,9 +1,13 @@
 package database
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"time"
 
+	"library-management-system/internal/domain/entities"
 	"library-management-system/internal/infrastructure/config"
 	"library-management-system/internal/infrastructure/database/migrations"
 
@@ -17,8 +21,25 @@ type Database struct {
 	DB *gorm.DB
 }
 
-// NewDatabase creates a new database connection
+// NewDatabase creates a new database connection and runs the migrations not applied yet
 func NewDatabase() (*Database, error) {
+	db, err := Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	// Run migrations
+	if err := runMigrations(db.DB); err != nil {
+		log.Printf("Failed to run migrations: %v", err)
+		return nil, err
+	}
+
+	log.Println("Database connected and migrated successfully")
+	return db, nil
+}
+
+// Connect creates a new database connection, leaving the migrations alone
+func Connect() (*Database, error) {
 	// Load configuration
 	cfg := config.Load()
 
@@ -38,7 +59,10 @@ func NewDatabase() (*Database, error) {
 	}
 
 	gormConfig := &gorm.Config{
-		Logger: logger.Default.LogMode(gormLogLevel),
+		// Label the SQL lines with the request their query runs for
+		Logger: NewQueryLogger(logger.Default.LogMode(gormLogLevel)),
+		// Share the entity clock so GORM-managed timestamps can be frozen in tests
+		NowFunc: entities.Now,
 	}
 
 	var db *gorm.DB
@@ -54,7 +78,7 @@ func NewDatabase() (*Database, error) {
 		cfg.Database.SSLMode,
 	)
 
-	db, err = gorm.Open(postgres.Open(dsn), gormConfig)
+	db, err = connect(dsn, gormConfig, cfg.Database.WaitForDB, cfg.Database.ConnectBackoff)
 	if err != nil {
 		log.Printf("Failed to connect to PostgreSQL database: %v", err)
 		return nil, err
@@ -62,16 +86,41 @@ func NewDatabase() (*Database, error) {
 	log.Printf("Connected to PostgreSQL database: %s:%s/%s",
 		cfg.Database.Host, cfg.Database.Port, cfg.Database.Name)
 
-	// Run migrations
-	if err := runMigrations(db); err != nil {
-		log.Printf("Failed to run migrations: %v", err)
-		return nil, err
+	if cfg.Database.ExplainEnabled {
+		if cfg.Server.Environment == "production" {
+			log.Println("DB_EXPLAIN_ENABLED is ignored in production")
+		} else if err := db.Use(NewPlanGuard(float64(cfg.Database.ExplainMinRows))); err != nil {
+			return nil, err
+		} else {
+			log.Printf("Explaining queries, sequential scans of tables of %d rows or more are logged", cfg.Database.ExplainMinRows)
+		}
 	}
-
-	log.Println("Database connected and migrated successfully")
 	return &Database{DB: db}, nil
 }
 
+// connect opens the database, retrying with a doubling backoff for up to wait while it is still
+// starting up
+func connect(dsn string, gormConfig *gorm.Config, wait, backoff time.Duration) (*gorm.DB, error) {
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	deadline := time.Now().Add(wait)
+	for attempt := 1; ; attempt++ {
+		db, err := gorm.Open(postgres.Open(dsn), gormConfig)
+		if err == nil {
+			return db, nil
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil, fmt.Errorf("database not ready after %d attempts in %s: %w", attempt, wait, err)
+		}
+		backoff = min(backoff, remaining)
+		log.Printf("Database not ready (attempt %d), retrying in %s: %v", attempt, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
 // runMigrations runs database migrations using gormigrate
 func runMigrations(db *gorm.DB) error {
 	migrationManager := migrations.NewMigrationManager(db)
@@ -83,6 +132,57 @@ func (d *Database) GetDB() *gorm.DB {
 	return d.DB
 }
 
+// Size returns the disk space used by the database, in bytes
+func (d *Database) Size(ctx context.Context) (int64, error) {
+	var size int64
+	err := d.DB.WithContext(ctx).Raw("SELECT pg_database_size(current_database())").Scan(&size).Error
+	return size, err
+}
+
+// PingContext checks the connection to the database
+func (d *Database) PingContext(ctx context.Context) error {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// CheckReadOnly reports whether the database refuses writes: a replica in recovery, such as
+// after a failover, or a server defaulting to read-only transactions
+func (d *Database) CheckReadOnly(ctx context.Context) (bool, error) {
+	var readOnly bool
+	err := d.DB.WithContext(ctx).
+		Raw("SELECT pg_is_in_recovery() OR current_setting('transaction_read_only') = 'on'").
+		Scan(&readOnly).Error
+	return readOnly, err
+}
+
+// readOnlyStates are the SQLSTATE codes of writes refused by a database that still serves reads
+var readOnlyStates = map[string]bool{
+	"25006": true, // read_only_sql_transaction
+	"53100": true, // disk_full
+}
+
+// OnWriteRefused calls refused with the error of every create, update or delete the database
+// refuses while still serving reads, so a read-only database is noticed before its next check
+func (d *Database) OnWriteRefused(refused func(error)) error {
+	callback := func(tx *gorm.DB) {
+		var pgErr interface{ SQLState() string }
+		if errors.As(tx.Error, &pgErr) && readOnlyStates[pgErr.SQLState()] {
+			refused(tx.Error)
+		}
+	}
+	callbacks := d.DB.Callback()
+	if err := callbacks.Create().After("gorm:create").Register("database:write_refused", callback); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("database:write_refused", callback); err != nil {
+		return err
+	}
+	return callbacks.Delete().After("gorm:delete").Register("database:write_refused", callback)
+}
+
 // RunMigrations runs migrations manually (for CLI commands)
 func (d *Database) RunMigrations() error {
 	migrationManager := migrations.NewMigrationManager(d.DB)
@@ -107,6 +207,12 @@ func (d *Database) MigrationStatus() error {
 	return migrationManager.Status()
 }
 
+// Migrations returns every migration with whether it has been applied
+func (d *Database) Migrations() ([]entities.Migration, error) {
+	migrationManager := migrations.NewMigrationManager(d.DB)
+	return migrationManager.List()
+}
+
 // GetAppliedMigrations returns all applied migrations
 func (d *Database) GetAppliedMigrations() ([]string, error) {
 	migrationManager := migrations.NewMigrationManager(d.DB)
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateNotificationsTable creates the notifications table
+func CreateNotificationsTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261015000000_create_notifications_table",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.Notification{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.Notification{})
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateAuditEntriesTable creates the audit_entries table
+func CreateAuditEntriesTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261015000001_create_audit_entries_table",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.AuditEntry{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.AuditEntry{})
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreatePublishersTable creates the publishers table and links books to their publisher
+func CreatePublishersTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261015000002_create_publishers_table",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&entities.Publisher{}); err != nil {
+				return err
+			}
+			if !tx.Migrator().HasColumn(&entities.Book{}, "PublisherID") {
+				if err := tx.Migrator().AddColumn(&entities.Book{}, "PublisherID"); err != nil {
+					return err
+				}
+			}
+			if !tx.Migrator().HasIndex(&entities.Book{}, "PublisherID") {
+				return tx.Migrator().CreateIndex(&entities.Book{}, "PublisherID")
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&entities.Book{}, "PublisherID") {
+				if err := tx.Migrator().DropColumn(&entities.Book{}, "PublisherID"); err != nil {
+					return err
+				}
+			}
+			return tx.Migrator().DropTable(&entities.Publisher{})
+		},
+	}
+}
@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// ExtractBookPublishers turns the free-text publisher column of books, where a deployment has one,
+// into publisher records and links every book to its publisher
+func ExtractBookPublishers() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261015000003_extract_book_publishers",
+		Migrate: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasColumn(&entities.Book{}, "publisher") {
+				return nil
+			}
+
+			var names []string
+			err := tx.Raw("SELECT DISTINCT TRIM(publisher) FROM books WHERE publisher IS NOT NULL AND TRIM(publisher) <> ''").
+				Scan(&names).Error
+			if err != nil {
+				return err
+			}
+
+			for _, name := range names {
+				publisher := entities.Publisher{Name: name}
+				if err := tx.Where("name = ?", name).FirstOrCreate(&publisher).Error; err != nil {
+					return err
+				}
+				err := tx.Exec("UPDATE books SET publisher_id = ? WHERE TRIM(publisher) = ? AND publisher_id IS NULL", publisher.ID, name).Error
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			// The free-text column is left untouched by Migrate, so unlinking the books is enough
+			if !tx.Migrator().HasColumn(&entities.Book{}, "publisher") {
+				return nil
+			}
+			return tx.Exec("UPDATE books SET publisher_id = NULL WHERE publisher IS NOT NULL").Error
+		},
+	}
+}
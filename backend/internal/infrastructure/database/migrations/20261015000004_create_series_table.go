@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateSeriesTable creates the series table and adds the series columns to books
+func CreateSeriesTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261015000004_create_series_table",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&entities.Series{}); err != nil {
+				return err
+			}
+			for _, column := range []string{"SeriesID", "SeriesPosition"} {
+				if !tx.Migrator().HasColumn(&entities.Book{}, column) {
+					if err := tx.Migrator().AddColumn(&entities.Book{}, column); err != nil {
+						return err
+					}
+				}
+			}
+			if !tx.Migrator().HasIndex(&entities.Book{}, "idx_books_series_position") {
+				return tx.Migrator().CreateIndex(&entities.Book{}, "idx_books_series_position")
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if tx.Migrator().HasIndex(&entities.Book{}, "idx_books_series_position") {
+				if err := tx.Migrator().DropIndex(&entities.Book{}, "idx_books_series_position"); err != nil {
+					return err
+				}
+			}
+			for _, column := range []string{"SeriesPosition", "SeriesID"} {
+				if tx.Migrator().HasColumn(&entities.Book{}, column) {
+					if err := tx.Migrator().DropColumn(&entities.Book{}, column); err != nil {
+						return err
+					}
+				}
+			}
+			return tx.Migrator().DropTable(&entities.Series{})
+		},
+	}
+}
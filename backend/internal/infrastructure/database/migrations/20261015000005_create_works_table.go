@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateWorksTable creates the works table and links books to the work they are an edition of
+func CreateWorksTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261015000005_create_works_table",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&entities.Work{}); err != nil {
+				return err
+			}
+			if !tx.Migrator().HasColumn(&entities.Book{}, "WorkID") {
+				if err := tx.Migrator().AddColumn(&entities.Book{}, "WorkID"); err != nil {
+					return err
+				}
+			}
+			if !tx.Migrator().HasIndex(&entities.Book{}, "WorkID") {
+				return tx.Migrator().CreateIndex(&entities.Book{}, "WorkID")
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&entities.Book{}, "WorkID") {
+				if err := tx.Migrator().DropColumn(&entities.Book{}, "WorkID"); err != nil {
+					return err
+				}
+			}
+			return tx.Migrator().DropTable(&entities.Work{})
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateCollectionsTables creates the collections table and its ordered book memberships
+func CreateCollectionsTables() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261015000006_create_collections_tables",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.Collection{}, &entities.CollectionBook{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.CollectionBook{}, &entities.Collection{})
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateAcquisitionsTable creates the acquisitions table of the purchasing workflow
+func CreateAcquisitionsTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261015000007_create_acquisitions_table",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.Acquisition{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.Acquisition{})
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateInventoryTables creates the inventory sessions and their scans
+func CreateInventoryTables() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261015000008_create_inventory_tables",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.InventorySession{}, &entities.InventoryScan{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.InventoryScan{}, &entities.InventorySession{})
+		},
+	}
+}
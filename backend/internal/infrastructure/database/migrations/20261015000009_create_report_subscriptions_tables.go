@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateReportSubscriptionsTables creates the report subscriptions and their run history
+func CreateReportSubscriptionsTables() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261015000009_create_report_subscriptions_tables",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.ReportSubscription{}, &entities.ReportSubscriptionRun{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.ReportSubscriptionRun{}, &entities.ReportSubscription{})
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateJobLocksTable creates the locks scheduled jobs take so replicas do not run them twice
+func CreateJobLocksTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261015000010_create_job_locks_table",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.JobLock{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.JobLock{})
+		},
+	}
+}
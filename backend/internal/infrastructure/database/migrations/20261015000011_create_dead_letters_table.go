@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateDeadLettersTable creates the table keeping background jobs that failed all their attempts
+func CreateDeadLettersTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261015000011_create_dead_letters_table",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.DeadLetter{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.DeadLetter{})
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateTenantsUsersPoliciesTables creates the tables a deployment is bootstrapped with
+func CreateTenantsUsersPoliciesTables() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261015000012_create_tenants_users_policies_tables",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.Tenant{}, &entities.User{}, &entities.Policy{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.Policy{}, &entities.User{}, &entities.Tenant{})
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/urlnorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// AddSlugToBooks adds the slug column to books, gives every existing book, deleted ones
+// included, the slug of its title, then makes slugs unique. Older books get the bare slugs.
+func AddSlugToBooks() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000000_add_slug_to_books",
+		Migrate: func(tx *gorm.DB) error {
+			migrator := tx.Migrator()
+			if !migrator.HasColumn(&entities.Book{}, "Slug") {
+				if err := migrator.AddColumn(&entities.Book{}, "Slug"); err != nil {
+					return err
+				}
+			}
+
+			var books []entities.Book
+			if err := tx.Unscoped().Select("id", "title", "slug").Order("created_at, id").Find(&books).Error; err != nil {
+				return err
+			}
+			taken := make(map[string]bool, len(books))
+			for _, book := range books {
+				taken[book.Slug] = book.Slug != ""
+			}
+			for _, book := range books {
+				if book.Slug != "" {
+					continue
+				}
+				slug, _ := urlnorm.UniqueSlug(book.SlugBase(), func(slug string) (bool, error) {
+					return taken[slug], nil
+				})
+				taken[slug] = true
+				if err := tx.Exec("UPDATE books SET slug = ? WHERE id = ?", slug, book.ID).Error; err != nil {
+					return err
+				}
+			}
+
+			if !migrator.HasIndex(&entities.Book{}, "Slug") {
+				return migrator.CreateIndex(&entities.Book{}, "Slug")
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			migrator := tx.Migrator()
+			if migrator.HasIndex(&entities.Book{}, "Slug") {
+				if err := migrator.DropIndex(&entities.Book{}, "Slug"); err != nil {
+					return err
+				}
+			}
+			return migrator.DropColumn(&entities.Book{}, "Slug")
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateBookDraftsTable creates the table keeping unpublished changes to books
+func CreateBookDraftsTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000001_create_book_drafts_table",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.BookDraft{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.BookDraft{})
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// AddPublishAtToBooks adds the publication schedule of books; existing books are live
+func AddPublishAtToBooks() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000002_add_publish_at_to_books",
+		Migrate: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasColumn(&entities.Book{}, "PublishAt") {
+				if err := tx.Migrator().AddColumn(&entities.Book{}, "PublishAt"); err != nil {
+					return err
+				}
+			}
+			if !tx.Migrator().HasIndex(&entities.Book{}, "PublishAt") {
+				return tx.Migrator().CreateIndex(&entities.Book{}, "PublishAt")
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&entities.Book{}, "PublishAt") {
+				return tx.Migrator().DropColumn(&entities.Book{}, "PublishAt")
+			}
+			return nil
+		},
+	}
+}
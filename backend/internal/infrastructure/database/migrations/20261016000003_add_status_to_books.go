@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// AddStatusToBooks adds the status of books; existing books become active
+func AddStatusToBooks() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000003_add_status_to_books",
+		Migrate: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasColumn(&entities.Book{}, "Status") {
+				if err := tx.Migrator().AddColumn(&entities.Book{}, "Status"); err != nil {
+					return err
+				}
+			}
+			if !tx.Migrator().HasIndex(&entities.Book{}, "Status") {
+				return tx.Migrator().CreateIndex(&entities.Book{}, "Status")
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&entities.Book{}, "Status") {
+				return tx.Migrator().DropColumn(&entities.Book{}, "Status")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// AddMetadataToBooks adds the custom metadata of books and drafts, and the table of the
+// metadata fields tenants define
+func AddMetadataToBooks() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000004_add_metadata_to_books",
+		Migrate: func(tx *gorm.DB) error {
+			for _, model := range []interface{}{&entities.Book{}, &entities.BookDraft{}} {
+				if !tx.Migrator().HasColumn(model, "Metadata") {
+					if err := tx.Migrator().AddColumn(model, "Metadata"); err != nil {
+						return err
+					}
+				}
+			}
+			return tx.AutoMigrate(&entities.MetadataField{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, model := range []interface{}{&entities.Book{}, &entities.BookDraft{}} {
+				if tx.Migrator().HasColumn(model, "Metadata") {
+					if err := tx.Migrator().DropColumn(model, "Metadata"); err != nil {
+						return err
+					}
+				}
+			}
+			return tx.Migrator().DropTable(&entities.MetadataField{})
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateValidationRulesTable creates the table of the validation rules admins add for books
+func CreateValidationRulesTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000005_create_validation_rules_table",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.ValidationRule{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.ValidationRule{})
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateCirculationTables creates the tables of loans, holds and fines, and adds the phone
+// members are reached on
+func CreateCirculationTables() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000006_create_circulation_tables",
+		Migrate: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasColumn(&entities.User{}, "Phone") {
+				if err := tx.Migrator().AddColumn(&entities.User{}, "Phone"); err != nil {
+					return err
+				}
+			}
+			return tx.AutoMigrate(&entities.Loan{}, &entities.Hold{}, &entities.Fine{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&entities.Fine{}, &entities.Hold{}, &entities.Loan{}); err != nil {
+				return err
+			}
+			if tx.Migrator().HasColumn(&entities.User{}, "Phone") {
+				return tx.Migrator().DropColumn(&entities.User{}, "Phone")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateBookDailyStatsTable creates the table of daily view and loan counts of books, and the
+// popularity column search results are ordered by
+func CreateBookDailyStatsTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000007_create_book_daily_stats_table",
+		Migrate: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasColumn(&entities.Book{}, "Popularity") {
+				if err := tx.Migrator().AddColumn(&entities.Book{}, "Popularity"); err != nil {
+					return err
+				}
+				if err := tx.Migrator().CreateIndex(&entities.Book{}, "Popularity"); err != nil {
+					return err
+				}
+			}
+			return tx.AutoMigrate(&entities.BookDailyStats{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&entities.BookDailyStats{}); err != nil {
+				return err
+			}
+			if tx.Migrator().HasColumn(&entities.Book{}, "Popularity") {
+				return tx.Migrator().DropColumn(&entities.Book{}, "Popularity")
+			}
+			return nil
+		},
+	}
+}
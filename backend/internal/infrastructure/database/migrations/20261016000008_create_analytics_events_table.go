@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateAnalyticsEventsTable creates the table of hourly counts of anonymous usage events
+func CreateAnalyticsEventsTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000008_create_analytics_events_table",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.AnalyticsEvent{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.AnalyticsEvent{})
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateImportProfilesTable creates the table of the column mappings tenants save for CSV imports
+func CreateImportProfilesTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000009_create_import_profiles_table",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.ImportProfile{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.ImportProfile{})
+		},
+	}
+}
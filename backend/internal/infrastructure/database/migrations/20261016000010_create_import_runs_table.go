@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateImportRunsTable creates the table of the history of book imports
+func CreateImportRunsTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000010_create_import_runs_table",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.ImportRun{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.ImportRun{})
+		},
+	}
+}
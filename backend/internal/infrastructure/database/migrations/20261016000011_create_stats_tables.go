@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateStatsTables creates the materialized stats tables the stats_refresh job fills, and the
+// table of their refresh times
+func CreateStatsTables() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000011_create_stats_tables",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.BooksPerYear{}, &entities.LoansPerMonth{}, &entities.TopAuthor{}, &entities.StatsRefresh{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.StatsRefresh{}, &entities.TopAuthor{}, &entities.LoansPerMonth{}, &entities.BooksPerYear{})
+		},
+	}
+}
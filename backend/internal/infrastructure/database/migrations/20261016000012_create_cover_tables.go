@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateCoverTables creates the tables of the cover images, stored once per content hash, and of
+// the books using them
+func CreateCoverTables() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000012_create_cover_tables",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.CoverObject{}, &entities.BookCover{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.BookCover{}, &entities.CoverObject{})
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// AddScanToUploads adds the malware scan results to cover images and import runs
+func AddScanToUploads() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000013_add_scan_to_uploads",
+		Migrate: func(tx *gorm.DB) error {
+			for _, model := range []interface{}{&entities.CoverObject{}, &entities.ImportRun{}} {
+				if !tx.Migrator().HasColumn(model, "Scan") {
+					if err := tx.Migrator().AddColumn(model, "Scan"); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, model := range []interface{}{&entities.ImportRun{}, &entities.CoverObject{}} {
+				if tx.Migrator().HasColumn(model, "Scan") {
+					if err := tx.Migrator().DropColumn(model, "Scan"); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateBranches creates the table of the library's branches and links books to the branch they
+// belong to
+func CreateBranches() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000014_create_branches",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&entities.Branch{}); err != nil {
+				return err
+			}
+			if !tx.Migrator().HasColumn(&entities.Book{}, "BranchID") {
+				if err := tx.Migrator().AddColumn(&entities.Book{}, "BranchID"); err != nil {
+					return err
+				}
+			}
+			if !tx.Migrator().HasIndex(&entities.Book{}, "BranchID") {
+				return tx.Migrator().CreateIndex(&entities.Book{}, "BranchID")
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&entities.Book{}, "BranchID") {
+				if err := tx.Migrator().DropColumn(&entities.Book{}, "BranchID"); err != nil {
+					return err
+				}
+			}
+			return tx.Migrator().DropTable(&entities.Branch{})
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// AddDescriptionToBooks adds the description of books and drafts
+func AddDescriptionToBooks() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000015_add_description_to_books",
+		Migrate: func(tx *gorm.DB) error {
+			for _, model := range []interface{}{&entities.Book{}, &entities.BookDraft{}} {
+				if !tx.Migrator().HasColumn(model, "Description") {
+					if err := tx.Migrator().AddColumn(model, "Description"); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, model := range []interface{}{&entities.Book{}, &entities.BookDraft{}} {
+				if tx.Migrator().HasColumn(model, "Description") {
+					if err := tx.Migrator().DropColumn(model, "Description"); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"log"
+	"strings"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// normalizedISBN is the SQL form of entities.NormalizeISBN
+const normalizedISBN = "UPPER(REPLACE(REPLACE(TRIM(isbn), '-', ''), ' ', ''))"
+
+// NormalizeBookISBNs rewrites the ISBNs of existing books, deleted ones included, in their
+// normalized form. Books whose ISBNs become the same once normalized keep them as they are and
+// are logged, for staff to tell apart before EnforceNormalizedISBNs runs.
+func NormalizeBookISBNs() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000016_normalize_book_isbns",
+		Migrate: func(tx *gorm.DB) error {
+			var books []entities.Book
+			if err := tx.Unscoped().Select("id", "isbn").Order("created_at, id").Find(&books).Error; err != nil {
+				return err
+			}
+			groups := make(map[string][]entities.Book, len(books))
+			for _, book := range books {
+				isbn := entities.NormalizeISBN(book.ISBN)
+				groups[isbn] = append(groups[isbn], book)
+			}
+
+			for _, book := range books {
+				isbn := entities.NormalizeISBN(book.ISBN)
+				group := groups[isbn]
+				if len(group) > 1 {
+					if group[0].ID == book.ID {
+						logDuplicateISBN(isbn, group)
+					}
+					continue
+				}
+				if isbn == book.ISBN {
+					continue
+				}
+				if err := tx.Exec("UPDATE books SET isbn = ? WHERE id = ?", isbn, book.ID).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			// The hyphens and spaces dropped from ISBNs are not kept, and the normalized ISBNs are
+			// as valid as the originals
+			return nil
+		},
+	}
+}
+
+// logDuplicateISBN reports the books sharing an ISBN once it is normalized
+func logDuplicateISBN(isbn string, books []entities.Book) {
+	entries := make([]string, len(books))
+	for i, book := range books {
+		entries[i] = book.ID + " (" + book.ISBN + ")"
+	}
+	log.Printf("⚠️  Books %s share the ISBN %s; their ISBNs are left as they are", strings.Join(entries, ", "), isbn)
+}
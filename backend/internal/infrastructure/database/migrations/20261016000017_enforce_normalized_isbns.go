@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// EnforceNormalizedISBNs makes normalized ISBNs unique, so that one ISBN cannot be stored twice
+// written two ways. It fails, changing nothing, while books still share a normalized ISBN; it
+// runs again with the next migration once they have been told apart or purged.
+func EnforceNormalizedISBNs() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000017_enforce_normalized_isbns",
+		Migrate: func(tx *gorm.DB) error {
+			var duplicates []string
+			err := tx.Raw("SELECT " + normalizedISBN + " FROM books GROUP BY " + normalizedISBN + " HAVING COUNT(*) > 1 ORDER BY 1").
+				Scan(&duplicates).Error
+			if err != nil {
+				return err
+			}
+			if len(duplicates) > 0 {
+				return fmt.Errorf("books share the ISBNs %s once normalized; change or purge all but one of each and migrate again",
+					strings.Join(duplicates, ", "))
+			}
+
+			return tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_books_isbn_normalized ON books ((" + normalizedISBN + "))").Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec("DROP INDEX IF EXISTS idx_books_isbn_normalized").Error
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateRelatedBooksTable creates the table of related books the stats_refresh job fills
+func CreateRelatedBooksTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000018_create_related_books_table",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.RelatedBook{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.RelatedBook{})
+		},
+	}
+}
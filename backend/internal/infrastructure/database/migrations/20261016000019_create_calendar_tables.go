@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateCalendarTables creates the tables of the business hours and closed days due dates follow
+func CreateCalendarTables() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000019_create_calendar_tables",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.BusinessHours{}, &entities.ClosedDay{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.ClosedDay{}, &entities.BusinessHours{})
+		},
+	}
+}
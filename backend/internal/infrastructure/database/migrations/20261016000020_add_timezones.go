@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// timezoneColumns are the columns the timezones of tenants and branches are kept and looked up in
+var timezoneColumns = []struct {
+	model interface{}
+	field string
+}{
+	{&entities.Tenant{}, "Timezone"},
+	{&entities.Branch{}, "Timezone"},
+	{&entities.ReportSubscription{}, "TenantID"},
+}
+
+// AddTimezones adds the timezones of tenants and branches, and the tenants of report
+// subscriptions whose schedules are in their timezone
+func AddTimezones() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000020_add_timezones",
+		Migrate: func(tx *gorm.DB) error {
+			for _, column := range timezoneColumns {
+				if !tx.Migrator().HasColumn(column.model, column.field) {
+					if err := tx.Migrator().AddColumn(column.model, column.field); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range timezoneColumns {
+				if tx.Migrator().HasColumn(column.model, column.field) {
+					if err := tx.Migrator().DropColumn(column.model, column.field); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateURLResultsTable creates the history of URL processing
+func CreateURLResultsTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000021_create_url_results_table",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.URLResult{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.URLResult{})
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateBookIdentifiersTable creates the identifiers of books besides their ISBN, unique per type
+func CreateBookIdentifiersTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000022_create_book_identifiers_table",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.BookIdentifier{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.BookIdentifier{})
+		},
+	}
+}
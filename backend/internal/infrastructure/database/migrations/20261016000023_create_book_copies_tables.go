@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateBookCopiesTables creates the copies of books with their accession numbers, the counters
+// the numbers are taken from, and the accession prefixes of branches
+func CreateBookCopiesTables() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000023_create_book_copies_tables",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&entities.BookCopy{}, &entities.AccessionCounter{}); err != nil {
+				return err
+			}
+			if tx.Migrator().HasColumn(&entities.Branch{}, "AccessionPrefix") {
+				return nil
+			}
+			return tx.Migrator().AddColumn(&entities.Branch{}, "AccessionPrefix")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&entities.Branch{}, "AccessionPrefix") {
+				if err := tx.Migrator().DropColumn(&entities.Branch{}, "AccessionPrefix"); err != nil {
+					return err
+				}
+			}
+			return tx.Migrator().DropTable(&entities.AccessionCounter{}, &entities.BookCopy{})
+		},
+	}
+}
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateAccessTokensTable creates the personal access tokens of users
+func CreateAccessTokensTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000024_create_access_tokens_table",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.AccessToken{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.AccessToken{})
+		},
+	}
+}
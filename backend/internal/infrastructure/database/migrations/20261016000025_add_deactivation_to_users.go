@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// deactivationFields are the columns of users telling when their account was deactivated and
+// anonymized
+var deactivationFields = []string{"DeactivatedAt", "AnonymizedAt"}
+
+// AddDeactivationToUsers adds the deactivation and anonymization of accounts to users, indexed
+// by deactivation for the anonymization job to find the accounts past their retention window
+func AddDeactivationToUsers() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000025_add_deactivation_to_users",
+		Migrate: func(tx *gorm.DB) error {
+			for _, field := range deactivationFields {
+				if !tx.Migrator().HasColumn(&entities.User{}, field) {
+					if err := tx.Migrator().AddColumn(&entities.User{}, field); err != nil {
+						return err
+					}
+				}
+			}
+			if tx.Migrator().HasIndex(&entities.User{}, "DeactivatedAt") {
+				return nil
+			}
+			return tx.Migrator().CreateIndex(&entities.User{}, "DeactivatedAt")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, field := range deactivationFields {
+				if tx.Migrator().HasColumn(&entities.User{}, field) {
+					if err := tx.Migrator().DropColumn(&entities.User{}, field); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// normalizedEmail is the SQL form of entities.NormalizeEmail
+const normalizedEmail = "LOWER(TRIM(email))"
+
+// EnforceCaseInsensitiveEmails stores the emails of existing users normalized and makes them
+// unique whatever their case, with an index on LOWER(email) that Postgres and SQLite both build.
+// It fails, changing nothing, while users share an email written in different cases; it runs
+// again with the next migration once all but one of them have changed theirs.
+func EnforceCaseInsensitiveEmails() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000026_enforce_case_insensitive_emails",
+		Migrate: func(tx *gorm.DB) error {
+			var duplicates []string
+			err := tx.Raw("SELECT " + normalizedEmail + " FROM users GROUP BY " + normalizedEmail + " HAVING COUNT(*) > 1 ORDER BY 1").
+				Scan(&duplicates).Error
+			if err != nil {
+				return err
+			}
+			if len(duplicates) > 0 {
+				return fmt.Errorf("users share the emails %s in different cases; change all but one of each and migrate again",
+					strings.Join(duplicates, ", "))
+			}
+
+			if err := tx.Exec("UPDATE users SET email = " + normalizedEmail + " WHERE email <> " + normalizedEmail).Error; err != nil {
+				return err
+			}
+			return tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_lower ON users ((LOWER(email)))").Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			// The original cases of emails are not kept, and the normalized emails sign in as well
+			return tx.Exec("DROP INDEX IF EXISTS idx_users_email_lower").Error
+		},
+	}
+}
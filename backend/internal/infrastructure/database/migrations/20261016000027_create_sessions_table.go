@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateSessionsTable creates the sign-in sessions of users
+func CreateSessionsTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000027_create_sessions_table",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&entities.Session{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.Session{})
+		},
+	}
+}
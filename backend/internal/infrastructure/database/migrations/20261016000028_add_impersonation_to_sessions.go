@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// impersonationColumns are the columns recording admins impersonating members, by the model they
+// are added to; the first of each is indexed for the impersonation report
+var impersonationColumns = []struct {
+	model  interface{}
+	fields []string
+}{
+	{model: &entities.Session{}, fields: []string{"ImpersonatorID", "ImpersonationReason"}},
+	{model: &entities.AuditEntry{}, fields: []string{"ImpersonatorID"}},
+}
+
+// AddImpersonationToSessions adds the admin impersonating the user to sessions, and flags the
+// audit entries of the changes made while impersonating
+func AddImpersonationToSessions() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000028_add_impersonation_to_sessions",
+		Migrate: func(tx *gorm.DB) error {
+			for _, columns := range impersonationColumns {
+				for _, field := range columns.fields {
+					if !tx.Migrator().HasColumn(columns.model, field) {
+						if err := tx.Migrator().AddColumn(columns.model, field); err != nil {
+							return err
+						}
+					}
+				}
+				if !tx.Migrator().HasIndex(columns.model, columns.fields[0]) {
+					if err := tx.Migrator().CreateIndex(columns.model, columns.fields[0]); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, columns := range impersonationColumns {
+				for _, field := range columns.fields {
+					if tx.Migrator().HasColumn(columns.model, field) {
+						if err := tx.Migrator().DropColumn(columns.model, field); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// AddCoverPaletteToBooks adds the colours of their cover to books; covers set before keep none
+// until they are set again
+func AddCoverPaletteToBooks() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000029_add_cover_palette_to_books",
+		Migrate: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasColumn(&entities.Book{}, "CoverPalette") {
+				return tx.Migrator().AddColumn(&entities.Book{}, "CoverPalette")
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&entities.Book{}, "CoverPalette") {
+				return tx.Migrator().DropColumn(&entities.Book{}, "CoverPalette")
+			}
+			return nil
+		},
+	}
+}
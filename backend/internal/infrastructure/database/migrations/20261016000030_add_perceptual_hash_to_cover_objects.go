@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// AddPerceptualHashToCoverObjects adds the perceptual hash of cover images, indexed for the
+// duplicate report; images stored before get one when uploaded again
+func AddPerceptualHashToCoverObjects() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000030_add_perceptual_hash_to_cover_objects",
+		Migrate: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasColumn(&entities.CoverObject{}, "PerceptualHash") {
+				if err := tx.Migrator().AddColumn(&entities.CoverObject{}, "PerceptualHash"); err != nil {
+					return err
+				}
+			}
+			if !tx.Migrator().HasIndex(&entities.CoverObject{}, "PerceptualHash") {
+				return tx.Migrator().CreateIndex(&entities.CoverObject{}, "PerceptualHash")
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&entities.CoverObject{}, "PerceptualHash") {
+				return tx.Migrator().DropColumn(&entities.CoverObject{}, "PerceptualHash")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// CreateLoansHistoryTable creates the table archived loans are moved to, indexed by when they were
+// borrowed for the listings reaching back to them
+func CreateLoansHistoryTable() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000031_create_loans_history_table",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&entities.ArchivedLoan{}); err != nil {
+				return err
+			}
+			return tx.Exec("CREATE INDEX IF NOT EXISTS idx_loans_history_borrowed_at ON loans_history (borrowed_at)").Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&entities.ArchivedLoan{})
+		},
+	}
+}
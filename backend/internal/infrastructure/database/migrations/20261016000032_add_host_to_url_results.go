@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// AddHostToURLResults adds the host of processed URLs, indexed for filtering the history by
+// domain, and fills it in for the URLs processed before
+func AddHostToURLResults() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000032_add_host_to_url_results",
+		Migrate: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasColumn(&entities.URLResult{}, "Host") {
+				if err := tx.Migrator().AddColumn(&entities.URLResult{}, "Host"); err != nil {
+					return err
+				}
+				err := tx.Exec(`UPDATE url_results
+					SET host = LOWER(COALESCE(substring(url from '^[A-Za-z][A-Za-z0-9+.-]*://(?:[^@/?#]*@)?([^:/?#]*)'), ''))`).Error
+				if err != nil {
+					return err
+				}
+			}
+			if !tx.Migrator().HasIndex(&entities.URLResult{}, "Host") {
+				return tx.Migrator().CreateIndex(&entities.URLResult{}, "Host")
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&entities.URLResult{}, "Host") {
+				return tx.Migrator().DropColumn(&entities.URLResult{}, "Host")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"library-management-system/internal/domain/entities"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// AddBranchToUsers adds the branch librarians work for to users, so requests signed in with a
+// session or access token take their branch from the account rather than from a header
+func AddBranchToUsers() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261016000033_add_branch_to_users",
+		Migrate: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasColumn(&entities.User{}, "BranchID") {
+				if err := tx.Migrator().AddColumn(&entities.User{}, "BranchID"); err != nil {
+					return err
+				}
+			}
+			if tx.Migrator().HasIndex(&entities.User{}, "BranchID") {
+				return nil
+			}
+			return tx.Migrator().CreateIndex(&entities.User{}, "BranchID")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if tx.Migrator().HasColumn(&entities.User{}, "BranchID") {
+				return tx.Migrator().DropColumn(&entities.User{}, "BranchID")
+			}
+			return nil
+		},
+	}
+}
@@ -3,6 +3,8 @@ package migrations
 import (
 	"log"
 
+	"library-management-system/internal/domain/entities"
+
 	"github.com/go-gormigrate/gormigrate/v2"
 	"gorm.io/gorm"
 )
@@ -20,6 +22,53 @@ func NewMigrationManager(db *gorm.DB) *MigrationManager {
 		CreateBooksTable(),
 		AddIndexesToBooks(),
 		AddSoftDeleteToBooks(),
+		CreateNotificationsTable(),
+		CreateAuditEntriesTable(),
+		CreatePublishersTable(),
+		ExtractBookPublishers(),
+		CreateSeriesTable(),
+		CreateWorksTable(),
+		CreateCollectionsTables(),
+		CreateAcquisitionsTable(),
+		CreateInventoryTables(),
+		CreateReportSubscriptionsTables(),
+		CreateJobLocksTable(),
+		CreateDeadLettersTable(),
+		CreateTenantsUsersPoliciesTables(),
+		AddSlugToBooks(),
+		CreateBookDraftsTable(),
+		AddPublishAtToBooks(),
+		AddStatusToBooks(),
+		AddMetadataToBooks(),
+		CreateValidationRulesTable(),
+		CreateCirculationTables(),
+		CreateBookDailyStatsTable(),
+		CreateAnalyticsEventsTable(),
+		CreateImportProfilesTable(),
+		CreateImportRunsTable(),
+		CreateStatsTables(),
+		CreateCoverTables(),
+		AddScanToUploads(),
+		CreateBranches(),
+		AddDescriptionToBooks(),
+		NormalizeBookISBNs(),
+		EnforceNormalizedISBNs(),
+		CreateRelatedBooksTable(),
+		CreateCalendarTables(),
+		AddTimezones(),
+		CreateURLResultsTable(),
+		CreateBookIdentifiersTable(),
+		CreateBookCopiesTables(),
+		CreateAccessTokensTable(),
+		AddDeactivationToUsers(),
+		EnforceCaseInsensitiveEmails(),
+		CreateSessionsTable(),
+		AddImpersonationToSessions(),
+		AddCoverPaletteToBooks(),
+		AddPerceptualHashToCoverObjects(),
+		CreateLoansHistoryTable(),
+		AddHostToURLResults(),
+		AddBranchToUsers(),
 	}
 
 	migrator := gormigrate.New(db, gormigrate.DefaultOptions, migrations)
@@ -79,7 +128,7 @@ func (m *MigrationManager) Status() error {
 		ID string `gorm:"column:id"`
 	}
 
-	if err := m.db.Table("schema_migrations").Find(&appliedMigrations).Error; err != nil {
+	if err := m.db.Table(gormigrate.DefaultOptions.TableName).Find(&appliedMigrations).Error; err != nil {
 		// If table doesn't exist, no migrations have been applied
 		log.Println("  No migrations have been applied yet")
 		return nil
@@ -103,6 +152,26 @@ func (m *MigrationManager) Status() error {
 	return nil
 }
 
+// List returns every migration, oldest first, with whether it has been applied
+func (m *MigrationManager) List() ([]entities.Migration, error) {
+	applied := make(map[string]bool)
+	if m.db.Migrator().HasTable(gormigrate.DefaultOptions.TableName) {
+		ids, err := m.GetAppliedMigrations()
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			applied[id] = true
+		}
+	}
+
+	list := make([]entities.Migration, len(m.migrations))
+	for i, migration := range m.migrations {
+		list[i] = entities.Migration{ID: migration.ID, Applied: applied[migration.ID]}
+	}
+	return list, nil
+}
+
 // GetMigrations returns all available migrations
 func (m *MigrationManager) GetMigrations() []*gormigrate.Migration {
 	return m.migrations
@@ -114,7 +183,7 @@ func (m *MigrationManager) GetAppliedMigrations() ([]string, error) {
 		ID string `gorm:"column:id"`
 	}
 
-	if err := m.db.Table("schema_migrations").Find(&appliedMigrations).Error; err != nil {
+	if err := m.db.Table(gormigrate.DefaultOptions.TableName).Find(&appliedMigrations).Error; err != nil {
 		return nil, err
 	}
 
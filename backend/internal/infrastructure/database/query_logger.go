@@ -0,0 +1,126 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"gorm.io/gorm/logger"
+)
+
+// QueryLabels name the HTTP request a query runs for in the SQL log
+type QueryLabels struct {
+	// RequestID is the trace ID the response returns in X-Trace-ID
+	RequestID string
+	// Route is the method and route pattern of the request, such as GET /api/books/:id
+	Route string
+}
+
+// String formats the labels as the prefix of a log line
+func (l QueryLabels) String() string {
+	return fmt.Sprintf("[request_id=%s route=%q] ", l.RequestID, l.Route)
+}
+
+type queryLabelsKey struct{}
+
+// ContextWithQueryLabels returns a copy of ctx carrying labels, for the queries run with it
+func ContextWithQueryLabels(ctx context.Context, labels QueryLabels) context.Context {
+	return context.WithValue(ctx, queryLabelsKey{}, labels)
+}
+
+// boundLabels holds the labels bound to goroutines, by goroutine ID
+var boundLabels sync.Map
+
+// BindQueryLabels labels the queries the calling goroutine runs until the returned function is
+// called. Repositories run their queries without a context, on the goroutine serving the request,
+// so this is how their log lines are labelled; goroutines the request starts are not.
+func BindQueryLabels(labels QueryLabels) (unbind func()) {
+	id := goroutineID()
+	boundLabels.Store(id, labels)
+	return func() { boundLabels.Delete(id) }
+}
+
+// queryLabels returns the labels of a query, from its context, or else from its goroutine
+func queryLabels(ctx context.Context) (QueryLabels, bool) {
+	if ctx != nil {
+		if labels, ok := ctx.Value(queryLabelsKey{}).(QueryLabels); ok {
+			return labels, true
+		}
+	}
+	if labels, ok := boundLabels.Load(goroutineID()); ok {
+		return labels.(QueryLabels), true
+	}
+	return QueryLabels{}, false
+}
+
+// goroutineID returns the ID of the calling goroutine, read from the header of its stack trace,
+// "goroutine 42 [running]:"
+func goroutineID() uint64 {
+	var buf [64]byte
+	header := buf[:runtime.Stack(buf[:], false)]
+	header = bytes.TrimPrefix(header, []byte("goroutine "))
+	if i := bytes.IndexByte(header, ' '); i >= 0 {
+		header = header[:i]
+	}
+	id, _ := strconv.ParseUint(string(header), 10, 64)
+	return id
+}
+
+// queryLogger prefixes the lines of a GORM logger with the labels of the request their query runs
+// for, so that the queries of a slow endpoint can be told apart from the rest
+type queryLogger struct {
+	logger.Interface
+}
+
+// NewQueryLogger wraps a GORM logger, labelling its lines
+func NewQueryLogger(inner logger.Interface) logger.Interface {
+	return &queryLogger{Interface: inner}
+}
+
+// LogMode returns the logger at level, still labelling its lines
+func (l *queryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return &queryLogger{Interface: l.Interface.LogMode(level)}
+}
+
+// Info logs a message, labelled
+func (l *queryLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	msg, data = labelled(ctx, msg, data)
+	l.Interface.Info(ctx, msg, data...)
+}
+
+// Warn logs a warning, labelled
+func (l *queryLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	msg, data = labelled(ctx, msg, data)
+	l.Interface.Warn(ctx, msg, data...)
+}
+
+// Error logs an error, labelled
+func (l *queryLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	msg, data = labelled(ctx, msg, data)
+	l.Interface.Error(ctx, msg, data...)
+}
+
+// Trace logs a query, labelled. The labels are only looked up for the queries the wrapped logger
+// logs, as it only renders those.
+func (l *queryLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	l.Interface.Trace(ctx, begin, func() (string, int64) {
+		sql, rows := fc()
+		if labels, ok := queryLabels(ctx); ok {
+			sql = labels.String() + sql
+		}
+		return sql, rows
+	}, err)
+}
+
+// labelled prefixes a message with the labels of ctx, if any, leaving its verbs alone
+func labelled(ctx context.Context, msg string, data []interface{}) (string, []interface{}) {
+	labels, ok := queryLabels(ctx)
+	if !ok {
+		return msg, data
+	}
+	return "%s" + msg, append([]interface{}{labels.String()}, data...)
+}
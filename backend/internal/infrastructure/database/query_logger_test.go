@@ -0,0 +1,49 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/logger"
+)
+
+func TestQueryLogger(t *testing.T) {
+	var out bytes.Buffer
+	queryLog := NewQueryLogger(logger.New(log.New(&out, "", 0), logger.Config{LogLevel: logger.Info, SlowThreshold: 200 * time.Millisecond}))
+	query := func() (string, int64) { return `SELECT * FROM "books"`, 2 }
+	labels := QueryLabels{RequestID: "4bf92f3577b34da6a3ce929d0e0e4736", Route: "GET /api/books/:id"}
+
+	// Queries run with a context carrying labels are labelled
+	queryLog.Trace(ContextWithQueryLabels(context.Background(), labels), time.Now(), query, nil)
+	assert.Contains(t, out.String(), `[request_id=4bf92f3577b34da6a3ce929d0e0e4736 route="GET /api/books/:id"] SELECT * FROM "books"`)
+
+	// Queries run without a context are labelled by the goroutine running them, while it is bound
+	out.Reset()
+	unbind := BindQueryLabels(labels)
+	queryLog.Trace(context.Background(), time.Now(), query, nil)
+	queryLog.Warn(context.Background(), "%d%% of the pool in use", 90)
+	assert.Contains(t, out.String(), `route="GET /api/books/:id"] SELECT`)
+	assert.Contains(t, out.String(), `route="GET /api/books/:id"] 90% of the pool in use`)
+
+	out.Reset()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		queryLog.Trace(context.Background(), time.Now(), query, nil)
+	}()
+	<-done
+	unbind()
+	queryLog.Trace(context.Background(), time.Now(), query, nil)
+	assert.NotContains(t, out.String(), "request_id")
+
+	// The level is kept, and the labels with it
+	out.Reset()
+	queryLog.LogMode(logger.Silent).Trace(ContextWithQueryLabels(context.Background(), labels), time.Now(), query, nil)
+	assert.Empty(t, out.String())
+	queryLog.LogMode(logger.Warn).Trace(ContextWithQueryLabels(context.Background(), labels), time.Now().Add(-time.Second), query, nil)
+	assert.Contains(t, out.String(), "request_id=")
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// queryStartKey is the statement setting the start of a statement is kept in
+const queryStartKey = "query_metrics:start"
+
+// Observer records the calls of named operations, such as a metrics registry
+type Observer interface {
+	Observe(name string, d time.Duration, err error)
+}
+
+// QueryMetrics is a GORM plugin recording the duration and outcome of every statement GORM runs,
+// so the queries of every repository are measured, not only those of decorated ones. Statements
+// are named sql.<table>.<operation>, e.g. sql.loans.query, or sql.raw and sql.row for raw SQL,
+// whose table GORM does not know. Rows not found are not errors.
+type QueryMetrics struct {
+	observer Observer
+}
+
+// NewQueryMetrics creates a plugin recording statements to observer
+func NewQueryMetrics(observer Observer) *QueryMetrics {
+	return &QueryMetrics{observer: observer}
+}
+
+// Name names the plugin
+func (m *QueryMetrics) Name() string {
+	return "query_metrics"
+}
+
+// Initialize times the statements of every kind of operation around the callback running its SQL
+func (m *QueryMetrics) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register("query_metrics:start", m.start),
+		callbacks.Create().After("gorm:create").Register("query_metrics:observe", m.observe("create")),
+		callbacks.Query().Before("gorm:query").Register("query_metrics:start", m.start),
+		callbacks.Query().After("gorm:query").Register("query_metrics:observe", m.observe("query")),
+		callbacks.Update().Before("gorm:update").Register("query_metrics:start", m.start),
+		callbacks.Update().After("gorm:update").Register("query_metrics:observe", m.observe("update")),
+		callbacks.Delete().Before("gorm:delete").Register("query_metrics:start", m.start),
+		callbacks.Delete().After("gorm:delete").Register("query_metrics:observe", m.observe("delete")),
+		callbacks.Row().Before("gorm:row").Register("query_metrics:start", m.start),
+		callbacks.Row().After("gorm:row").Register("query_metrics:observe", m.observe("row")),
+		callbacks.Raw().Before("gorm:raw").Register("query_metrics:start", m.start),
+		callbacks.Raw().After("gorm:raw").Register("query_metrics:observe", m.observe("raw")),
+	)
+}
+
+// start notes when a statement started
+func (m *QueryMetrics) start(db *gorm.DB) {
+	db.InstanceSet(queryStartKey, time.Now())
+}
+
+// observe returns the callback recording the statements of an operation once they ran
+func (m *QueryMetrics) observe(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+
+		name := "sql." + operation
+		if table := db.Statement.Table; table != "" {
+			name = "sql." + table + "." + operation
+		}
+		err := db.Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = nil
+		}
+		m.observer.Observe(name, time.Since(value.(time.Time)), err)
+	}
+}
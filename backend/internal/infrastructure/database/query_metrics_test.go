@@ -0,0 +1,36 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// recordingObserver keeps the names of the operations observed
+type recordingObserver []string
+
+func (o *recordingObserver) Observe(name string, d time.Duration, err error) {
+	*o = append(*o, name)
+}
+
+func TestQueryMetrics(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+	var observed recordingObserver
+	require.NoError(t, db.Use(NewQueryMetrics(&observed)))
+
+	// Statements of any repository are recorded, by table and operation
+	db.Find(&[]entities.Loan{})
+	db.Create(&entities.Hold{UserID: "member-1", BookID: "book-1", Status: entities.HoldWaiting})
+	db.Model(&entities.Hold{}).Where("id = ?", "hold-1").Update("status", entities.HoldReady)
+	db.Where("id = ?", "hold-1").Delete(&entities.Hold{})
+	db.Exec("DELETE FROM loans_history WHERE archived_at < now()")
+
+	assert.Equal(t, recordingObserver{"sql.loans.query", "sql.holds.create", "sql.holds.update", "sql.holds.delete", "sql.raw"}, observed)
+}
@@ -0,0 +1,160 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PlanNode is a node of a Postgres query plan, as EXPLAIN (FORMAT JSON) writes it
+type PlanNode struct {
+	NodeType     string     `json:"Node Type"`
+	RelationName string     `json:"Relation Name"`
+	PlanRows     float64    `json:"Plan Rows"`
+	Plans        []PlanNode `json:"Plans"`
+}
+
+// ParsePlan parses the output of EXPLAIN (FORMAT JSON) into the root node of its plan
+func ParsePlan(data []byte) (*PlanNode, error) {
+	var plans []struct {
+		Plan PlanNode `json:"Plan"`
+	}
+	if err := json.Unmarshal(data, &plans); err != nil {
+		return nil, err
+	}
+	if len(plans) == 0 {
+		return nil, fmt.Errorf("empty query plan")
+	}
+	return &plans[0].Plan, nil
+}
+
+// SeqScans returns the tables a plan reads in full, in the order it reads them
+func (n *PlanNode) SeqScans() []string {
+	var tables []string
+	if n.NodeType == "Seq Scan" || n.NodeType == "Parallel Seq Scan" {
+		tables = append(tables, n.RelationName)
+	}
+	for i := range n.Plans {
+		tables = append(tables, n.Plans[i].SeqScans()...)
+	}
+	return tables
+}
+
+// SeqScan is a table a query read in full
+type SeqScan struct {
+	Table string
+	// Rows is the estimated size of the table
+	Rows float64
+	SQL  string
+}
+
+// tableSizeTTL is how long the estimated size of a table is reused before it is read again
+const tableSizeTTL = time.Minute
+
+// PlanGuard is a GORM plugin explaining the queries GORM builds, such as those of searches and
+// listings, and reporting the tables of MinRows rows or more they scan sequentially: queries no
+// index serves, which get slower as the table grows. Each query text is explained once. It
+// doubles the queries made, so it is meant for development and tests, not production.
+type PlanGuard struct {
+	// MinRows is the estimated size from which sequential scans are reported; zero or less
+	// reports every one
+	MinRows float64
+	// Report is called with each sequential scan found
+	Report func(db *gorm.DB, scan SeqScan)
+
+	explained sync.Map
+	mu        sync.Mutex
+	sizes     map[string]tableSize
+}
+
+type tableSize struct {
+	rows   float64
+	readAt time.Time
+}
+
+// NewPlanGuard creates a plan guard logging the sequential scans of tables of minRows rows or
+// more as warnings of the GORM logger
+func NewPlanGuard(minRows float64) *PlanGuard {
+	return &PlanGuard{
+		MinRows: minRows,
+		Report: func(db *gorm.DB, scan SeqScan) {
+			db.Logger.Warn(db.Statement.Context, "sequential scan of %s (~%.0f rows), no index serves: %s", scan.Table, scan.Rows, scan.SQL)
+		},
+	}
+}
+
+// Name names the plugin
+func (g *PlanGuard) Name() string {
+	return "plan_guard"
+}
+
+// Initialize explains the queries once they ran
+func (g *PlanGuard) Initialize(db *gorm.DB) error {
+	return db.Callback().Query().After("gorm:query").Register("plan_guard:explain", g.explain)
+}
+
+// explain explains the query of a statement the first time it is seen
+func (g *PlanGuard) explain(db *gorm.DB) {
+	query := db.Statement.SQL.String()
+	if db.Error != nil || db.DryRun || !strings.HasPrefix(strings.ToUpper(strings.TrimSpace(query)), "SELECT") {
+		return
+	}
+	if _, seen := g.explained.LoadOrStore(query, true); seen {
+		return
+	}
+
+	plan, err := g.plan(db, query)
+	if err != nil {
+		db.Logger.Warn(db.Statement.Context, "explaining %s: %v", query, err)
+		return
+	}
+	for _, table := range plan.SeqScans() {
+		rows, err := g.tableRows(db, table)
+		if err != nil {
+			db.Logger.Warn(db.Statement.Context, "estimating the size of %s: %v", table, err)
+			continue
+		}
+		if g.MinRows <= 0 || rows >= g.MinRows {
+			g.Report(db, SeqScan{Table: table, Rows: rows, SQL: query})
+		}
+	}
+}
+
+// plan explains a query with the values it ran with, on the connection or transaction it ran on
+func (g *PlanGuard) plan(db *gorm.DB, query string) (*PlanNode, error) {
+	var data []byte
+	row := db.Statement.ConnPool.QueryRowContext(db.Statement.Context, "EXPLAIN (FORMAT JSON) "+query, db.Statement.Vars...)
+	if err := row.Scan(&data); err != nil {
+		return nil, err
+	}
+	return ParsePlan(data)
+}
+
+// tableRows returns the estimated number of rows of a table, from the statistics of Postgres.
+// Tables never analyzed count as empty.
+func (g *PlanGuard) tableRows(db *gorm.DB, table string) (float64, error) {
+	g.mu.Lock()
+	size, ok := g.sizes[table]
+	g.mu.Unlock()
+	if ok && time.Since(size.readAt) < tableSizeTTL {
+		return size.rows, nil
+	}
+
+	var rows float64
+	row := db.Statement.ConnPool.QueryRowContext(db.Statement.Context,
+		"SELECT GREATEST(COALESCE((SELECT reltuples FROM pg_class WHERE oid = to_regclass($1)), 0), 0)", table)
+	if err := row.Scan(&rows); err != nil {
+		return 0, err
+	}
+	g.mu.Lock()
+	if g.sizes == nil {
+		g.sizes = make(map[string]tableSize)
+	}
+	g.sizes[table] = tableSize{rows: rows, readAt: time.Now()}
+	g.mu.Unlock()
+	return rows, nil
+}
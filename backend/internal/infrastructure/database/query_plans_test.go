@@ -0,0 +1,25 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePlan(t *testing.T) {
+	plan, err := ParsePlan([]byte(`[{"Plan": {
+		"Node Type": "Nested Loop", "Plan Rows": 10,
+		"Plans": [
+			{"Node Type": "Seq Scan", "Relation Name": "books", "Plan Rows": 10},
+			{"Node Type": "Index Scan", "Relation Name": "publishers", "Plan Rows": 1},
+			{"Node Type": "Gather", "Plans": [{"Node Type": "Parallel Seq Scan", "Relation Name": "loans"}]}
+		]
+	}}]`))
+	require.NoError(t, err)
+	assert.Equal(t, "Nested Loop", plan.NodeType)
+	assert.Equal(t, []string{"books", "loans"}, plan.SeqScans())
+
+	_, err = ParsePlan([]byte(`[]`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,176 @@
+package database
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+)
+
+// Pinger checks a database connection, such as *sql.DB
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// ReadOnlyChecker tells whether a reachable database refuses writes
+type ReadOnlyChecker interface {
+	CheckReadOnly(ctx context.Context) (bool, error)
+}
+
+// Supervisor checks the database connection in the background and reports its state. While
+// the connection is down it is checked sooner, backing off from a second up to the interval;
+// the connection pool opens a new connection for the check, so the connection comes back as
+// soon as the database does.
+type Supervisor struct {
+	pinger   Pinger
+	interval time.Duration
+
+	mu     sync.RWMutex
+	health entities.DatabaseHealth
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+const (
+	// minRetry is how soon a lost connection is checked again
+	minRetry = time.Second
+	// checkTimeout bounds a check, so an unresponsive database counts as down
+	checkTimeout = 5 * time.Second
+)
+
+// NewSupervisor creates a supervisor checking the connection every interval, assumed up until
+// its first check
+func NewSupervisor(pinger Pinger, interval time.Duration) *Supervisor {
+	if interval < minRetry {
+		interval = minRetry
+	}
+	now := time.Now()
+	return &Supervisor{
+		pinger:   pinger,
+		interval: interval,
+		health:   entities.DatabaseHealth{State: entities.DatabaseUp, Since: now, CheckedAt: now},
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start checks the connection in the background until Stop is called
+func (s *Supervisor) Start() {
+	go s.run()
+}
+
+// Stop stops checking the connection
+func (s *Supervisor) Stop() {
+	close(s.stop)
+	<-s.done
+}
+
+// Health returns the state of the connection as last checked
+func (s *Supervisor) Health() entities.DatabaseHealth {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.health
+}
+
+// ReadOnly reports whether the database was last found refusing writes
+func (s *Supervisor) ReadOnly() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.health.State == entities.DatabaseReadOnly
+}
+
+// MarkReadOnly records that the database refused a write with err, until a check finds it
+// writable again
+func (s *Supervisor) MarkReadOnly(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.health.State != entities.DatabaseUp {
+		return
+	}
+	log.Printf("Database refused a write, serving reads only: %v", err)
+	s.health.State = entities.DatabaseReadOnly
+	s.health.Since = time.Now()
+	s.health.LastError = err.Error()
+}
+
+// Check checks the connection now and records its state. Pingers that are also ReadOnlyCheckers
+// are asked whether the database takes writes once it answers.
+func (s *Supervisor) Check(ctx context.Context) entities.DatabaseHealth {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	err := s.pinger.PingContext(ctx)
+	readOnly := false
+	if checker, ok := s.pinger.(ReadOnlyChecker); ok && err == nil {
+		readOnly, err = checker.CheckReadOnly(ctx)
+	}
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.health.CheckedAt = now
+	if err != nil {
+		if s.health.State != entities.DatabaseDown {
+			log.Printf("Lost the database connection: %v", err)
+			s.health.State = entities.DatabaseDown
+			s.health.Since = now
+		}
+		s.health.LastError = err.Error()
+		s.health.Failures++
+		return s.health
+	}
+
+	state := entities.DatabaseUp
+	if readOnly {
+		state = entities.DatabaseReadOnly
+	}
+	switch {
+	case s.health.State == entities.DatabaseDown:
+		log.Printf("Database connection is back after %s", now.Sub(s.health.Since).Round(time.Second))
+		s.health.Reconnects++
+	case s.health.State == state:
+	case readOnly:
+		log.Printf("Database is read-only, serving reads only")
+	default:
+		log.Printf("Database takes writes again after %s", now.Sub(s.health.Since).Round(time.Second))
+	}
+	if s.health.State != state {
+		s.health.State = state
+		s.health.Since = now
+	}
+	s.health.LastError = ""
+	s.health.Failures = 0
+	return s.health
+}
+
+// run checks the connection every interval, and with a growing backoff while it is down
+func (s *Supervisor) run() {
+	defer close(s.done)
+	wait := s.interval
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-time.After(wait):
+		}
+
+		health := s.Check(context.Background())
+		if health.State != entities.DatabaseDown {
+			wait = s.interval
+			continue
+		}
+		wait = retryAfter(health.Failures, s.interval)
+	}
+}
+
+// retryAfter returns how long to wait before checking a connection that failed failures checks
+// in a row: a second after the first, doubling up to the interval
+func retryAfter(failures int, interval time.Duration) time.Duration {
+	wait := minRetry
+	for i := 1; i < failures && wait < interval; i++ {
+		wait *= 2
+	}
+	return min(wait, interval)
+}
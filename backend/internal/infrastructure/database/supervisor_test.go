@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePinger fails while err is set
+type fakePinger struct {
+	mu  sync.Mutex
+	err error
+}
+
+func (p *fakePinger) PingContext(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+func (p *fakePinger) set(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+// fakeReplica is reachable and refuses writes while readOnly is set
+type fakeReplica struct {
+	fakePinger
+	readOnly bool
+}
+
+func (r *fakeReplica) CheckReadOnly(ctx context.Context) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.readOnly, r.err
+}
+
+func (r *fakeReplica) setReadOnly(readOnly bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.readOnly = readOnly
+}
+
+func TestSupervisor_TracksLostAndRestoredConnections(t *testing.T) {
+	pinger := &fakePinger{}
+	supervisor := NewSupervisor(pinger, time.Minute)
+	assert.Equal(t, entities.DatabaseUp, supervisor.Health().State)
+
+	pinger.set(errors.New("connection refused"))
+	supervisor.Check(context.Background())
+	health := supervisor.Check(context.Background())
+	assert.Equal(t, entities.DatabaseDown, health.State)
+	assert.Equal(t, "connection refused", health.LastError)
+	assert.Equal(t, 2, health.Failures)
+	lostAt := health.Since
+
+	pinger.set(nil)
+	health = supervisor.Check(context.Background())
+	assert.Equal(t, entities.DatabaseUp, health.State)
+	assert.Empty(t, health.LastError)
+	assert.Equal(t, 0, health.Failures)
+	assert.Equal(t, 1, health.Reconnects)
+	assert.False(t, health.Since.Before(lostAt))
+	assert.Equal(t, health, supervisor.Health())
+}
+
+func TestSupervisor_TracksReadOnlyDatabases(t *testing.T) {
+	replica := &fakeReplica{readOnly: true}
+	supervisor := NewSupervisor(replica, time.Minute)
+
+	health := supervisor.Check(context.Background())
+	assert.Equal(t, entities.DatabaseReadOnly, health.State)
+	assert.True(t, supervisor.ReadOnly())
+	assert.Equal(t, 0, health.Failures)
+
+	replica.setReadOnly(false)
+	health = supervisor.Check(context.Background())
+	assert.Equal(t, entities.DatabaseUp, health.State)
+	assert.False(t, supervisor.ReadOnly())
+	assert.Equal(t, 0, health.Reconnects)
+
+	supervisor.MarkReadOnly(errors.New("could not extend file: No space left on device"))
+	health = supervisor.Health()
+	assert.Equal(t, entities.DatabaseReadOnly, health.State)
+	assert.Equal(t, "could not extend file: No space left on device", health.LastError)
+
+	health = supervisor.Check(context.Background())
+	assert.Equal(t, entities.DatabaseUp, health.State)
+	assert.Empty(t, health.LastError)
+
+	replica.set(errors.New("connection refused"))
+	supervisor.Check(context.Background())
+	supervisor.MarkReadOnly(errors.New("cannot execute INSERT in a read-only transaction"))
+	assert.Equal(t, entities.DatabaseDown, supervisor.Health().State)
+}
+
+func TestSupervisor_ChecksInTheBackground(t *testing.T) {
+	pinger := &fakePinger{err: errors.New("connection refused")}
+	supervisor := NewSupervisor(pinger, time.Millisecond)
+	supervisor.Start()
+	defer supervisor.Stop()
+
+	assert.Eventually(t, func() bool {
+		return supervisor.Health().State == entities.DatabaseDown
+	}, 3*time.Second, 10*time.Millisecond)
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Second, retryAfter(1, 30*time.Second))
+	assert.Equal(t, 2*time.Second, retryAfter(2, 30*time.Second))
+	assert.Equal(t, 16*time.Second, retryAfter(5, 30*time.Second))
+	assert.Equal(t, 30*time.Second, retryAfter(6, 30*time.Second))
+	assert.Equal(t, 30*time.Second, retryAfter(1000, 30*time.Second))
+}
@@ -0,0 +1,237 @@
+// Package davfs serves directories read-only over WebDAV, each as a folder of a virtual root, so
+// that storage areas such as exports and backups can be pulled with standard WebDAV clients.
+package davfs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/net/webdav"
+)
+
+// FS is a read-only webdav.FileSystem over named directories. Anything that would write,
+// including opening a file for writing, fails with os.ErrPermission.
+type FS struct {
+	dirs  map[string]webdav.Dir
+	names []string
+}
+
+// New creates a file system serving each directory as the folder of its name
+func New(dirs map[string]string) *FS {
+	fs := &FS{dirs: make(map[string]webdav.Dir, len(dirs))}
+	for name, dir := range dirs {
+		fs.dirs[name] = webdav.Dir(dir)
+		fs.names = append(fs.names, name)
+	}
+	sort.Strings(fs.names)
+	return fs
+}
+
+// Mkdir is not allowed
+func (fs *FS) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	return os.ErrPermission
+}
+
+// RemoveAll is not allowed
+func (fs *FS) RemoveAll(ctx context.Context, name string) error {
+	return os.ErrPermission
+}
+
+// Rename is not allowed
+func (fs *FS) Rename(ctx context.Context, oldName, newName string) error {
+	return os.ErrPermission
+}
+
+// writeFlags are the flags of OpenFile that would change a file
+const writeFlags = os.O_WRONLY | os.O_RDWR | os.O_CREATE | os.O_TRUNC | os.O_APPEND
+
+// OpenFile opens a file or directory for reading
+func (fs *FS) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	if flag&writeFlags != 0 {
+		return nil, os.ErrPermission
+	}
+	dir, rest, err := fs.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		return &rootDir{fs: fs}, nil
+	}
+	file, err := fs.dirs[dir].OpenFile(ctx, rest, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	if rest == "/" {
+		return &areaDir{File: file, name: dir}, nil
+	}
+	return readOnlyFile{file}, nil
+}
+
+// Stat describes a file or directory
+func (fs *FS) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	dir, rest, err := fs.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		return fs.rootInfo(), nil
+	}
+	info, err := fs.dirs[dir].Stat(ctx, rest)
+	if err != nil {
+		return nil, err
+	}
+	if rest == "/" {
+		return namedInfo{FileInfo: info, name: dir}, nil
+	}
+	return info, nil
+}
+
+// resolve splits a path into the directory it is in and the path within it; the root has no
+// directory
+func (fs *FS) resolve(name string) (string, string, error) {
+	name = path.Clean("/" + name)
+	if name == "/" {
+		return "", "/", nil
+	}
+	dir, rest, _ := strings.Cut(strings.TrimPrefix(name, "/"), "/")
+	if _, ok := fs.dirs[dir]; !ok {
+		return "", "", os.ErrNotExist
+	}
+	return dir, "/" + rest, nil
+}
+
+// rootInfo describes the root, as modified when its most recently modified directory was
+func (fs *FS) rootInfo() os.FileInfo {
+	var modTime time.Time
+	for _, info := range fs.areas() {
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return dirInfo{name: "/", modTime: modTime}
+}
+
+// areas describes the directories served, leaving out those that do not exist
+func (fs *FS) areas() []os.FileInfo {
+	infos := make([]os.FileInfo, 0, len(fs.names))
+	for _, name := range fs.names {
+		info, err := fs.dirs[name].Stat(context.Background(), "/")
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		infos = append(infos, namedInfo{FileInfo: info, name: name})
+	}
+	return infos
+}
+
+// readOnlyFile is a file of a directory served, which cannot be written
+type readOnlyFile struct {
+	webdav.File
+}
+
+// Write is not allowed
+func (readOnlyFile) Write(p []byte) (int, error) {
+	return 0, os.ErrPermission
+}
+
+// areaDir is a directory served, named after its folder rather than its path on disk
+type areaDir struct {
+	webdav.File
+	name string
+}
+
+// Stat describes the directory under its folder name
+func (d *areaDir) Stat() (os.FileInfo, error) {
+	info, err := d.File.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return namedInfo{FileInfo: info, name: d.name}, nil
+}
+
+// Write is not allowed
+func (d *areaDir) Write(p []byte) (int, error) {
+	return 0, os.ErrPermission
+}
+
+// rootDir is the virtual root, listing the directories served
+type rootDir struct {
+	fs      *FS
+	entries []os.FileInfo
+	read    bool
+}
+
+// Close does nothing
+func (d *rootDir) Close() error {
+	return nil
+}
+
+// Read fails, the root being a directory
+func (d *rootDir) Read(p []byte) (int, error) {
+	return 0, errors.New("is a directory")
+}
+
+// Seek fails, the root being a directory
+func (d *rootDir) Seek(offset int64, whence int) (int64, error) {
+	return 0, errors.New("is a directory")
+}
+
+// Write is not allowed
+func (d *rootDir) Write(p []byte) (int, error) {
+	return 0, os.ErrPermission
+}
+
+// Readdir lists the directories served, count at a time as os.File.Readdir does
+func (d *rootDir) Readdir(count int) ([]os.FileInfo, error) {
+	if !d.read {
+		d.entries = d.fs.areas()
+		d.read = true
+	}
+	if count <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	n := min(count, len(d.entries))
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
+
+// Stat describes the root
+func (d *rootDir) Stat() (os.FileInfo, error) {
+	return d.fs.rootInfo(), nil
+}
+
+// namedInfo renames a directory after its folder
+type namedInfo struct {
+	os.FileInfo
+	name string
+}
+
+// Name returns the folder name
+func (i namedInfo) Name() string {
+	return i.name
+}
+
+// dirInfo describes the virtual root
+type dirInfo struct {
+	name    string
+	modTime time.Time
+}
+
+func (i dirInfo) Name() string       { return i.name }
+func (i dirInfo) Size() int64        { return 0 }
+func (i dirInfo) Mode() os.FileMode  { return os.ModeDir | 0o555 }
+func (i dirInfo) ModTime() time.Time { return i.modTime }
+func (i dirInfo) IsDir() bool        { return true }
+func (i dirInfo) Sys() interface{}   { return nil }
@@ -0,0 +1,81 @@
+package davfs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/webdav"
+)
+
+func newTestFS(t *testing.T) (*FS, string) {
+	root := t.TempDir()
+	for _, dir := range []string{"exports", "backups"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0o755))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(root, "exports", "books.csv"), []byte("title\nMoby-Dick\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "secret.txt"), []byte("secret"), 0o644))
+	return New(map[string]string{
+		"exports": filepath.Join(root, "exports"),
+		"backups": filepath.Join(root, "backups"),
+		"missing": filepath.Join(root, "missing"),
+	}), root
+}
+
+func TestFS_ListsAreasAtTheRoot(t *testing.T) {
+	fs, _ := newTestFS(t)
+	handler := &webdav.Handler{Prefix: "/dav", FileSystem: fs, LockSystem: webdav.NewMemLS()}
+
+	req := httptest.NewRequest("PROPFIND", "/dav/", nil)
+	req.Header.Set("Depth", "1")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	assert.Contains(t, w.Body.String(), "<D:href>/dav/exports/</D:href>")
+	assert.Contains(t, w.Body.String(), "<D:href>/dav/backups/</D:href>")
+	assert.NotContains(t, w.Body.String(), "missing")
+}
+
+func TestFS_ServesFiles(t *testing.T) {
+	fs, _ := newTestFS(t)
+	handler := &webdav.Handler{Prefix: "/dav", FileSystem: fs, LockSystem: webdav.NewMemLS()}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/dav/exports/books.csv", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "title\nMoby-Dick\n", w.Body.String())
+
+	// Paths cannot climb out of a directory served
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/dav/exports/../../secret.txt", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestFS_RefusesWrites(t *testing.T) {
+	fs, root := newTestFS(t)
+	ctx := context.Background()
+
+	_, err := fs.OpenFile(ctx, "/exports/new.csv", os.O_RDWR|os.O_CREATE, 0o644)
+	assert.ErrorIs(t, err, os.ErrPermission)
+	assert.ErrorIs(t, fs.Mkdir(ctx, "/exports/dir", 0o755), os.ErrPermission)
+	assert.ErrorIs(t, fs.RemoveAll(ctx, "/exports/books.csv"), os.ErrPermission)
+	assert.ErrorIs(t, fs.Rename(ctx, "/exports/books.csv", "/backups/books.csv"), os.ErrPermission)
+
+	file, err := fs.OpenFile(ctx, "/exports/books.csv", os.O_RDONLY, 0)
+	require.NoError(t, err)
+	defer file.Close()
+	_, err = io.Copy(file, strings.NewReader("overwritten"))
+	assert.ErrorIs(t, err, os.ErrPermission)
+
+	data, err := os.ReadFile(filepath.Join(root, "exports", "books.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "title\nMoby-Dick\n", string(data))
+}
@@ -0,0 +1,33 @@
+package diskusage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectorySize(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.jpg"), make([]byte, 1000), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "nested"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nested", "b.jpg"), make([]byte, 234), 0o644))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "a.jpg"), filepath.Join(dir, "link.jpg")))
+
+	size, err := DirectorySize(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1234), size)
+
+	// A directory that was never created uses no space
+	size, err = DirectorySize(context.Background(), filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Zero(t, size)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = DirectorySize(ctx, dir)
+	assert.ErrorIs(t, err, context.Canceled)
+}
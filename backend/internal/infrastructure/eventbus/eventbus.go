@@ -0,0 +1,60 @@
+package eventbus
+
+import (
+	"log"
+	"sync"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/events"
+)
+
+// InMemoryBus is a synchronous, in-process implementation of events.Bus
+type InMemoryBus struct {
+	mu       sync.RWMutex
+	handlers map[events.EventType][]events.Handler
+	clock    clock.Clock
+}
+
+// NewInMemoryBus creates a new in-memory event bus
+func NewInMemoryBus() *InMemoryBus {
+	return &InMemoryBus{
+		handlers: make(map[events.EventType][]events.Handler),
+		clock:    clock.System{},
+	}
+}
+
+// SetClock replaces the clock used to timestamp events published without a time
+func (b *InMemoryBus) SetClock(c clock.Clock) {
+	b.clock = c
+}
+
+// Subscribe registers a handler for the given event type
+func (b *InMemoryBus) Subscribe(eventType events.EventType, handler events.Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// SubscribeBroadcast registers a handler for the given event type; with a single instance it is
+// the same as Subscribe
+func (b *InMemoryBus) SubscribeBroadcast(eventType events.EventType, handler events.Handler) {
+	b.Subscribe(eventType, handler)
+}
+
+// Publish delivers the event to every handler subscribed to its type
+func (b *InMemoryBus) Publish(event events.Event) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = b.clock.Now()
+	}
+
+	b.mu.RLock()
+	handlers := append([]events.Handler(nil), b.handlers[event.Type]...)
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(event); err != nil {
+			// A failing subscriber must not prevent the others from running
+			log.Printf("Event handler for %s failed: %v", event.Type, err)
+		}
+	}
+}
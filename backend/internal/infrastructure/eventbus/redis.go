@@ -0,0 +1,116 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/events"
+	"library-management-system/internal/infrastructure/redis"
+)
+
+// redisChannel is the Redis channel events are broadcast on
+const redisChannel = "events"
+
+// redisRetry is how long a lost subscription waits before subscribing again
+const redisRetry = time.Second
+
+// redisMessage is an event as broadcast to the other instances
+type redisMessage struct {
+	// Origin is the instance that published the event, which already delivered it
+	Origin      string            `json:"origin"`
+	Type        string            `json:"type"`
+	RecipientID string            `json:"recipient_id,omitempty"`
+	BookID      string            `json:"book_id,omitempty"`
+	Payload     map[string]string `json:"payload,omitempty"`
+	OccurredAt  time.Time         `json:"occurred_at"`
+}
+
+// RedisBus is an events.Bus for several instances. Subscribe handlers run once per event, on the
+// instance that published it, as with InMemoryBus; SubscribeBroadcast handlers also run on every
+// other instance, which the event reaches through Redis pub/sub. Events broadcast while an
+// instance is disconnected from Redis are missed by it.
+type RedisBus struct {
+	local     *InMemoryBus
+	broadcast *InMemoryBus
+	client    *redis.Client
+	instance  string
+	sub       *redis.Subscription
+}
+
+// NewRedisBus creates a bus broadcasting through client, as instance, and starts listening to
+// the events of the other instances
+func NewRedisBus(client *redis.Client, instance string) *RedisBus {
+	b := &RedisBus{
+		local:     NewInMemoryBus(),
+		broadcast: NewInMemoryBus(),
+		client:    client,
+		instance:  instance,
+	}
+	b.sub = client.Subscribe(redisChannel, redisRetry, b.receive)
+	return b
+}
+
+// SetClock replaces the clock used to timestamp events published without a time
+func (b *RedisBus) SetClock(c clock.Clock) {
+	b.local.SetClock(c)
+}
+
+// Subscribe registers a handler run on this instance for the events it publishes
+func (b *RedisBus) Subscribe(eventType events.EventType, handler events.Handler) {
+	b.local.Subscribe(eventType, handler)
+}
+
+// SubscribeBroadcast registers a handler run for the events published by any instance
+func (b *RedisBus) SubscribeBroadcast(eventType events.EventType, handler events.Handler) {
+	b.broadcast.Subscribe(eventType, handler)
+}
+
+// Publish delivers the event to the handlers of this instance, then broadcasts it to the others
+func (b *RedisBus) Publish(event events.Event) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = b.local.clock.Now()
+	}
+	b.local.Publish(event)
+	b.broadcast.Publish(event)
+
+	message, err := json.Marshal(redisMessage{
+		Origin:      b.instance,
+		Type:        string(event.Type),
+		RecipientID: event.RecipientID,
+		BookID:      event.BookID,
+		Payload:     event.Payload,
+		OccurredAt:  event.OccurredAt,
+	})
+	if err == nil {
+		err = b.client.Publish(redisChannel, string(message))
+	}
+	if err != nil {
+		log.Printf("Failed to broadcast %s to other instances: %v", event.Type, err)
+	}
+}
+
+// Close stops listening to the events of the other instances
+func (b *RedisBus) Close() {
+	b.sub.Close()
+}
+
+// receive delivers an event broadcast by another instance to the broadcast handlers
+func (b *RedisBus) receive(message string) {
+	var m redisMessage
+	if err := json.Unmarshal([]byte(message), &m); err != nil {
+		log.Printf("Ignoring malformed event from Redis: %v", err)
+		return
+	}
+	if m.Origin == b.instance {
+		return
+	}
+	b.broadcast.Publish(events.Event{
+		Type:        events.EventType(m.Type),
+		RecipientID: m.RecipientID,
+		BookID:      m.BookID,
+		Payload:     m.Payload,
+		OccurredAt:  m.OccurredAt,
+	})
+}
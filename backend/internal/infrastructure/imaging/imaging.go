@@ -0,0 +1,464 @@
+// Package imaging checks uploaded images and strips their metadata without third-party decoders.
+// JPEG, PNG and GIF images are decoded with the standard library; WebP, which it cannot decode,
+// is checked by its container structure and the headers of its frames.
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+// Format is an image format recognised by its magic bytes
+type Format string
+
+// Supported formats
+const (
+	JPEG Format = "jpeg"
+	PNG  Format = "png"
+	GIF  Format = "gif"
+	WebP Format = "webp"
+)
+
+// Formats lists the supported formats
+var Formats = []Format{JPEG, PNG, GIF, WebP}
+
+// ContentType returns the media type of the format
+func (f Format) ContentType() string {
+	return "image/" + string(f)
+}
+
+// Info describes an image, as read from its header
+type Info struct {
+	Format Format
+	Width  int
+	Height int
+}
+
+// Pixels returns the area of the image
+func (i Info) Pixels() int64 {
+	return int64(i.Width) * int64(i.Height)
+}
+
+var (
+	// ErrUnknownFormat is returned for data that does not start with the magic bytes of a
+	// supported format
+	ErrUnknownFormat = errors.New("unknown image format")
+	// ErrMalformed is returned for data that starts like an image but is not a valid one, such as
+	// a truncated file or another file behind an image signature
+	ErrMalformed = errors.New("malformed image")
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// Detect recognises the format of an image by its magic bytes
+func Detect(data []byte) (Format, bool) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return JPEG, true
+	case bytes.HasPrefix(data, pngSignature):
+		return PNG, true
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return GIF, true
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return WebP, true
+	}
+	return "", false
+}
+
+// Inspect reads the format and dimensions of an image from its header, without decoding it, so
+// that the size of the decoded image can be checked before paying for it
+func Inspect(data []byte) (Info, error) {
+	format, ok := Detect(data)
+	if !ok {
+		return Info{}, ErrUnknownFormat
+	}
+
+	var config image.Config
+	var err error
+	switch format {
+	case JPEG:
+		config, err = jpeg.DecodeConfig(bytes.NewReader(data))
+	case PNG:
+		config, err = png.DecodeConfig(bytes.NewReader(data))
+	case GIF:
+		config, err = gif.DecodeConfig(bytes.NewReader(data))
+	case WebP:
+		config, err = webpConfig(data)
+	}
+	if err != nil {
+		return Info{}, malformed(err)
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return Info{}, malformed(errors.New("image has no pixels"))
+	}
+	return Info{Format: format, Width: config.Width, Height: config.Height}, nil
+}
+
+// Verify checks that an image inspected before is valid through to its end. JPEG, PNG and GIF
+// images are decoded, so their dimensions must have been checked first; WebP images are walked
+// chunk by chunk.
+func Verify(data []byte, info Info) error {
+	var err error
+	switch info.Format {
+	case JPEG:
+		_, err = jpeg.Decode(bytes.NewReader(data))
+	case PNG:
+		_, err = png.Decode(bytes.NewReader(data))
+	case GIF:
+		_, err = gif.Decode(bytes.NewReader(data))
+	case WebP:
+		err = verifyWebP(data)
+	default:
+		return ErrUnknownFormat
+	}
+	if err != nil {
+		return malformed(err)
+	}
+	return nil
+}
+
+// decode decodes an image for its colours, returning nil for WebP images, which the standard
+// library cannot decode
+func decode(data []byte, format Format) (image.Image, error) {
+	var img image.Image
+	var err error
+	switch format {
+	case JPEG:
+		img, err = jpeg.Decode(bytes.NewReader(data))
+	case PNG:
+		img, err = png.Decode(bytes.NewReader(data))
+	case GIF:
+		img, err = gif.Decode(bytes.NewReader(data))
+	case WebP:
+		return nil, nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, malformed(err)
+	}
+	return img, nil
+}
+
+// StripMetadata returns an image without its metadata (EXIF, XMP, IPTC, comments and text
+// chunks) nor anything appended after its end. The image data is copied as is rather than
+// re-encoded, so nothing is lost but a photo's EXIF orientation.
+func StripMetadata(data []byte, format Format) ([]byte, error) {
+	var out []byte
+	var err error
+	switch format {
+	case JPEG:
+		out, err = stripJPEG(data)
+	case PNG:
+		out, err = stripPNG(data)
+	case GIF:
+		out, err = stripGIF(data)
+	case WebP:
+		out, err = stripWebP(data)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, malformed(err)
+	}
+	return out, nil
+}
+
+func malformed(err error) error {
+	return fmt.Errorf("%w: %v", ErrMalformed, err)
+}
+
+var errTruncated = errors.New("unexpected end of image")
+
+// stripJPEG copies the segments of a JPEG image but its APP1 (EXIF, XMP), APP13 (IPTC) and
+// comment segments. ICC profiles (APP2) and Adobe colour transforms (APP14) are kept since
+// colours depend on them.
+func stripJPEG(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, 0xFF, 0xD8)
+	i := 2
+	for {
+		if i >= len(data) || data[i] != 0xFF {
+			return nil, errors.New("expected a JPEG marker")
+		}
+		// A marker may be preceded by any number of fill bytes
+		for i < len(data) && data[i] == 0xFF {
+			i++
+		}
+		if i >= len(data) {
+			return nil, errTruncated
+		}
+		marker := data[i]
+		i++
+		switch {
+		case marker == 0xD9:
+			return append(out, 0xFF, 0xD9), nil
+		case marker == 0x01, marker >= 0xD0 && marker <= 0xD7:
+			out = append(out, 0xFF, marker)
+			continue
+		}
+
+		if i+2 > len(data) {
+			return nil, errTruncated
+		}
+		length := int(binary.BigEndian.Uint16(data[i:]))
+		if length < 2 || i+length > len(data) {
+			return nil, errTruncated
+		}
+		if marker != 0xE1 && marker != 0xED && marker != 0xFE {
+			out = append(out, 0xFF, marker)
+			out = append(out, data[i:i+length]...)
+		}
+		i += length
+
+		if marker == 0xDA {
+			// Entropy-coded data runs to the next marker; 0xFF is followed by 0x00 when it is
+			// data, and restart markers belong to the data
+			start := i
+			for i+1 < len(data) && (data[i] != 0xFF || data[i+1] == 0x00 || data[i+1] >= 0xD0 && data[i+1] <= 0xD7) {
+				i++
+			}
+			if i+1 >= len(data) {
+				return nil, errTruncated
+			}
+			out = append(out, data[start:i]...)
+		}
+	}
+}
+
+// pngMetadata are the PNG chunks carrying metadata rather than pixels or colour information
+var pngMetadata = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+// stripPNG copies the chunks of a PNG image up to IEND but its metadata chunks
+func stripPNG(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	i := len(pngSignature)
+	for {
+		if i+12 > len(data) {
+			return nil, errTruncated
+		}
+		length := binary.BigEndian.Uint32(data[i:])
+		if length > uint32(len(data)-i-12) {
+			return nil, errTruncated
+		}
+		end := i + 12 + int(length)
+		kind := string(data[i+4 : i+8])
+		if !pngMetadata[kind] {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+		if kind == "IEND" {
+			return out, nil
+		}
+	}
+}
+
+// stripGIF copies the blocks of a GIF image up to its trailer but comments and application
+// extensions other than the looping of animations, which is where XMP metadata is kept
+func stripGIF(data []byte) ([]byte, error) {
+	// Header and logical screen descriptor, followed by the global colour table if any
+	i := 13
+	if len(data) < i {
+		return nil, errTruncated
+	}
+	if data[10]&0x80 != 0 {
+		i += 3 << (data[10]&0x07 + 1)
+	}
+	if i > len(data) {
+		return nil, errTruncated
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:i]...)
+	for {
+		if i >= len(data) {
+			return nil, errTruncated
+		}
+		switch data[i] {
+		case 0x3B:
+			return append(out, 0x3B), nil
+		case 0x21:
+			if i+2 > len(data) {
+				return nil, errTruncated
+			}
+			end, err := skipGIFSubBlocks(data, i+2)
+			if err != nil {
+				return nil, err
+			}
+			keep := true
+			switch data[i+1] {
+			case 0xFE:
+				keep = false
+			case 0xFF:
+				keep = end-i >= 14 && data[i+2] == 11 &&
+					(string(data[i+3:i+14]) == "NETSCAPE2.0" || string(data[i+3:i+14]) == "ANIMEXTS1.0")
+			}
+			if keep {
+				out = append(out, data[i:end]...)
+			}
+			i = end
+		case 0x2C:
+			// Image descriptor, local colour table, LZW minimum code size and image data
+			start := i
+			i += 10
+			if i > len(data) {
+				return nil, errTruncated
+			}
+			if data[i-1]&0x80 != 0 {
+				i += 3 << (data[i-1]&0x07 + 1)
+			}
+			end, err := skipGIFSubBlocks(data, i+1)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, data[start:end]...)
+			i = end
+		default:
+			return nil, fmt.Errorf("unknown GIF block 0x%02x", data[i])
+		}
+	}
+}
+
+// skipGIFSubBlocks returns the end of the sub-blocks starting at i
+func skipGIFSubBlocks(data []byte, i int) (int, error) {
+	for {
+		if i >= len(data) {
+			return 0, errTruncated
+		}
+		n := int(data[i])
+		i++
+		if n == 0 {
+			return i, nil
+		}
+		i += n
+	}
+}
+
+// riffChunk is a chunk of a WebP file
+type riffChunk struct {
+	id   string
+	data []byte
+}
+
+// webpChunks splits a WebP file into its chunks, ignoring anything after the RIFF container
+func webpChunks(data []byte) ([]riffChunk, error) {
+	if len(data) < 12 {
+		return nil, errTruncated
+	}
+	size := binary.LittleEndian.Uint32(data[4:8])
+	if size < 4 || uint64(size)+8 > uint64(len(data)) {
+		return nil, errTruncated
+	}
+	body := data[12 : 8+size]
+	var chunks []riffChunk
+	for len(body) > 0 {
+		if len(body) < 8 {
+			return nil, errTruncated
+		}
+		length := binary.LittleEndian.Uint32(body[4:8])
+		padded := uint64(length) + uint64(length&1)
+		if padded > uint64(len(body)-8) {
+			return nil, errTruncated
+		}
+		chunks = append(chunks, riffChunk{id: string(body[:4]), data: body[8 : 8+length]})
+		body = body[8+padded:]
+	}
+	if len(chunks) == 0 {
+		return nil, errors.New("WebP file has no chunks")
+	}
+	return chunks, nil
+}
+
+// webpConfig reads the dimensions of a WebP image from its first chunk: the canvas of extended
+// files, else the frame header of lossy or lossless ones
+func webpConfig(data []byte) (image.Config, error) {
+	chunks, err := webpChunks(data)
+	if err != nil {
+		return image.Config{}, err
+	}
+	first := chunks[0]
+	d := first.data
+	switch first.id {
+	case "VP8X":
+		if len(d) < 10 {
+			return image.Config{}, errTruncated
+		}
+		return image.Config{Width: 1 + int(uint24(d[4:7])), Height: 1 + int(uint24(d[7:10]))}, nil
+	case "VP8 ":
+		if len(d) < 10 || d[3] != 0x9D || d[4] != 0x01 || d[5] != 0x2A {
+			return image.Config{}, errors.New("invalid VP8 frame header")
+		}
+		return image.Config{
+			Width:  int(binary.LittleEndian.Uint16(d[6:8]) & 0x3FFF),
+			Height: int(binary.LittleEndian.Uint16(d[8:10]) & 0x3FFF),
+		}, nil
+	case "VP8L":
+		if len(d) < 5 || d[0] != 0x2F {
+			return image.Config{}, errors.New("invalid VP8L header")
+		}
+		bits := binary.LittleEndian.Uint32(d[1:5])
+		return image.Config{Width: 1 + int(bits&0x3FFF), Height: 1 + int(bits>>14&0x3FFF)}, nil
+	}
+	return image.Config{}, fmt.Errorf("unexpected first WebP chunk %q", first.id)
+}
+
+// verifyWebP checks that a WebP file holds image data
+func verifyWebP(data []byte) error {
+	chunks, err := webpChunks(data)
+	if err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		switch chunk.id {
+		case "VP8 ", "VP8L", "ANMF":
+			return nil
+		}
+	}
+	return errors.New("WebP file has no image data")
+}
+
+// stripWebP rebuilds a WebP file without its EXIF and XMP chunks, clearing their flags in the
+// extended header
+func stripWebP(data []byte) ([]byte, error) {
+	chunks, err := webpChunks(data)
+	if err != nil {
+		return nil, err
+	}
+	var body []byte
+	for _, chunk := range chunks {
+		if chunk.id == "EXIF" || chunk.id == "XMP " {
+			continue
+		}
+		payload := chunk.data
+		if chunk.id == "VP8X" && len(payload) > 0 {
+			payload = append([]byte{payload[0] &^ 0x0C}, payload[1:]...)
+		}
+		body = append(body, chunk.id...)
+		body = binary.LittleEndian.AppendUint32(body, uint32(len(payload)))
+		body = append(body, payload...)
+		if len(payload)%2 == 1 {
+			body = append(body, 0)
+		}
+	}
+	out := make([]byte, 0, 12+len(body))
+	out = append(out, "RIFF"...)
+	out = binary.LittleEndian.AppendUint32(out, uint32(4+len(body)))
+	out = append(out, "WEBP"...)
+	return append(out, body...), nil
+}
+
+func uint24(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+}
@@ -0,0 +1,241 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testImage() *image.Paletted {
+	img := image.NewPaletted(image.Rect(0, 0, 3, 2), color.Palette{color.White, color.Black})
+	img.SetColorIndex(1, 1, 1)
+	return img
+}
+
+func encodeJPEG(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, testImage(), nil))
+	return buf.Bytes()
+}
+
+func encodePNG(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, testImage()))
+	return buf.Bytes()
+}
+
+func encodeGIF(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, gif.Encode(&buf, testImage(), nil))
+	return buf.Bytes()
+}
+
+// pngChunk encodes a PNG chunk with its checksum
+func pngChunk(kind string, data []byte) []byte {
+	out := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+	out = append(out, kind...)
+	out = append(out, data...)
+	return binary.BigEndian.AppendUint32(out, crc32.ChecksumIEEE(append([]byte(kind), data...)))
+}
+
+// webpFile wraps chunks, given as id and data pairs, in a RIFF container
+func webpFile(chunks ...string) []byte {
+	var body []byte
+	for i := 0; i < len(chunks); i += 2 {
+		body = append(body, chunks[i]...)
+		body = binary.LittleEndian.AppendUint32(body, uint32(len(chunks[i+1])))
+		body = append(body, chunks[i+1]...)
+		if len(chunks[i+1])%2 == 1 {
+			body = append(body, 0)
+		}
+	}
+	out := append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(4+len(body)))...)
+	return append(append(out, "WEBP"...), body...)
+}
+
+// vp8l is the header of a lossless 3x2 WebP frame
+var vp8l = string([]byte{0x2F, 0x02, 0x40, 0x00, 0x00, 0x00})
+
+func TestInspect(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"jpeg": encodeJPEG(t),
+		"png":  encodePNG(t),
+		"gif":  encodeGIF(t),
+		"webp": webpFile("VP8L", vp8l),
+	} {
+		t.Run(name, func(t *testing.T) {
+			info, err := Inspect(data)
+			require.NoError(t, err)
+			assert.Equal(t, Info{Format: Format(name), Width: 3, Height: 2}, info)
+			assert.NoError(t, Verify(data, info))
+		})
+	}
+}
+
+func TestInspect_ReadsDimensionsWithoutDecoding(t *testing.T) {
+	// A header claiming 100000x100000 pixels, with no image data to decode
+	ihdr := binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint32(nil, 100000), 100000)
+	ihdr = append(ihdr, 8, 6, 0, 0, 0)
+	data := append(append([]byte{}, pngSignature...), pngChunk("IHDR", ihdr)...)
+
+	info, err := Inspect(data)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10000000000), info.Pixels())
+}
+
+func TestInspect_RejectsDisguisedFiles(t *testing.T) {
+	_, err := Inspect([]byte("<html><script>alert(1)</script></html>"))
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+
+	// A PNG signature in front of something else
+	_, err = Inspect(append(append([]byte{}, pngSignature...), "<?php system($_GET['c']); ?>"...))
+	assert.ErrorIs(t, err, ErrMalformed)
+
+	// A valid header with the image data cut off
+	data := encodePNG(t)
+	truncated := data[:len(data)-16]
+	info, err := Inspect(truncated)
+	require.NoError(t, err)
+	assert.ErrorIs(t, Verify(truncated, info), ErrMalformed)
+
+	// A WebP container with no frame
+	_, err = Inspect(webpFile("EXIF", "Exif\x00\x00"))
+	assert.ErrorIs(t, err, ErrMalformed)
+}
+
+func TestStripMetadata_JPEG(t *testing.T) {
+	data := encodeJPEG(t)
+	exif := []byte{0xFF, 0xE1, 0x00, 0x0F}
+	exif = append(exif, "Exif\x00\x00GPS:xyz"...)
+	comment := []byte{0xFF, 0xFE, 0x00, 0x07, 'h', 'e', 'l', 'l', 'o'}
+	tagged := append(append(append(append([]byte{}, data[:2]...), exif...), comment...), data[2:]...)
+	tagged = append(tagged, "PK\x03\x04appended archive"...)
+
+	stripped, err := StripMetadata(tagged, JPEG)
+	require.NoError(t, err)
+	assert.Equal(t, data, stripped)
+	assert.NotContains(t, string(stripped), "GPS")
+}
+
+func TestStripMetadata_PNG(t *testing.T) {
+	data := encodePNG(t)
+	// After the signature and IHDR
+	at := len(pngSignature) + 25
+	tagged := append(append([]byte{}, data[:at]...), pngChunk("tEXt", []byte("Author\x00Jane"))...)
+	tagged = append(tagged, pngChunk("eXIf", []byte("MM\x00\x2aGPS"))...)
+	tagged = append(append(tagged, data[at:]...), "trailing"...)
+
+	stripped, err := StripMetadata(tagged, PNG)
+	require.NoError(t, err)
+	assert.Equal(t, data, stripped)
+}
+
+func TestStripMetadata_GIF(t *testing.T) {
+	data := encodeGIF(t)
+	// After the header, screen descriptor and two-colour global table
+	at := 13 + 6
+	comment := []byte{0x21, 0xFE, 0x05, 'h', 'e', 'l', 'l', 'o', 0x00}
+	xmp := append([]byte{0x21, 0xFF, 0x0B}, "XMP DataXMP"...)
+	xmp = append(xmp, 0x03, 'x', 'm', 'p', 0x00)
+	tagged := append(append(append(append([]byte{}, data[:at]...), comment...), xmp...), data[at:]...)
+
+	stripped, err := StripMetadata(tagged, GIF)
+	require.NoError(t, err)
+	assert.Equal(t, data, stripped)
+}
+
+func TestStripMetadata_WebP(t *testing.T) {
+	canvas := string([]byte{0x0C, 0, 0, 0, 2, 0, 0, 1, 0, 0})
+	tagged := webpFile("VP8X", canvas, "VP8L", vp8l, "EXIF", "Exif\x00\x00GPS", "XMP ", "<x:xmpmeta/>")
+
+	stripped, err := StripMetadata(tagged, WebP)
+	require.NoError(t, err)
+	cleared := string([]byte{0x00, 0, 0, 0, 2, 0, 0, 1, 0, 0})
+	assert.Equal(t, webpFile("VP8X", cleared, "VP8L", vp8l), stripped)
+
+	info, err := Inspect(stripped)
+	require.NoError(t, err)
+	assert.Equal(t, Info{Format: WebP, Width: 3, Height: 2}, info)
+}
+
+func TestPalette(t *testing.T) {
+	for name, data := range map[string][]byte{"png": encodePNG(t), "gif": encodeGIF(t)} {
+		t.Run(name, func(t *testing.T) {
+			palette, err := Palette(data, Format(name), 5)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"#ffffff", "#000000"}, palette)
+		})
+	}
+
+	// Transparent pixels are left out, and the palette is cut to its size
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 1))
+	img.Set(0, 0, color.NRGBA{R: 0x1e, G: 0x3a, B: 0x8a, A: 0xFF})
+	img.Set(1, 0, color.NRGBA{R: 0x1e, G: 0x3a, B: 0x8a, A: 0xFF})
+	img.Set(2, 0, color.NRGBA{R: 0xf5, G: 0x9e, B: 0x0b, A: 0xFF})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	palette, err := Palette(buf.Bytes(), PNG, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"#1e3a8a"}, palette)
+
+	palette, err = Palette(webpFile("VP8L", vp8l), WebP, 5)
+	require.NoError(t, err)
+	assert.Nil(t, palette)
+	_, err = Palette(encodePNG(t)[:40], PNG, 5)
+	assert.ErrorIs(t, err, ErrMalformed)
+}
+
+// artwork draws a pattern of blobs at any size, mirrored when flipped
+func artwork(width, height int, flipped bool) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			u, v := float64(x)/float64(width), float64(y)/float64(height)
+			if flipped {
+				u = 1 - u
+			}
+			shade := uint8(255 * math.Abs(math.Sin(7*u*u+5*v)*math.Cos(3*v-4*u)))
+			img.Set(x, y, color.RGBA{R: shade, G: 255 - shade, B: uint8(255 * v), A: 0xFF})
+		}
+	}
+	return img
+}
+
+func TestPerceptualHash(t *testing.T) {
+	encode := func(img image.Image, format Format) []byte {
+		var buf bytes.Buffer
+		if format == JPEG {
+			require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 60}))
+		} else {
+			require.NoError(t, png.Encode(&buf, img))
+		}
+		return buf.Bytes()
+	}
+	original, err := PerceptualHash(encode(artwork(300, 450, false), PNG), PNG)
+	require.NoError(t, err)
+	assert.Len(t, original, 16)
+
+	// A smaller, recompressed copy hashes close to the original, other artwork far from it
+	thumbnail, err := PerceptualHash(encode(artwork(100, 150, false), JPEG), JPEG)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, HashDistance(original, thumbnail), 4)
+	other, err := PerceptualHash(encode(artwork(300, 450, true), PNG), PNG)
+	require.NoError(t, err)
+	assert.Greater(t, HashDistance(original, other), 16)
+
+	hash, err := PerceptualHash(webpFile("VP8L", vp8l), WebP)
+	require.NoError(t, err)
+	assert.Empty(t, hash)
+	assert.Equal(t, -1, HashDistance(original, ""))
+	assert.Equal(t, 0, HashDistance(original, original))
+}
@@ -0,0 +1,66 @@
+package imaging
+
+import (
+	"fmt"
+	"sort"
+)
+
+// paletteSamples bounds the pixels sampled along each side of an image, so that the palette of a
+// large cover costs about as much as that of a thumbnail
+const paletteSamples = 64
+
+// Palette returns up to size of the most common colours of an image as #rrggbb strings, the
+// dominant colour first. Colours are grouped by their 4 high bits per channel, each group standing
+// for the average of its pixels, and transparent pixels are left out. WebP images, which the
+// standard library cannot decode, have no palette.
+func Palette(data []byte, format Format, size int) ([]string, error) {
+	img, err := decode(data, format)
+	if err != nil || img == nil {
+		return nil, err
+	}
+
+	type bucket struct {
+		key           int
+		r, g, b, hits uint64
+	}
+	buckets := map[int]*bucket{}
+	bounds := img.Bounds()
+	stepX := max(1, bounds.Dx()/paletteSamples)
+	stepY := max(1, bounds.Dy()/paletteSamples)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += stepY {
+		for x := bounds.Min.X; x < bounds.Max.X; x += stepX {
+			r, g, b, a := img.At(x, y).RGBA()
+			if a < 0x8000 {
+				continue
+			}
+			// Colours come premultiplied by their alpha, on 16 bits
+			r, g, b = r*0xFFFF/a>>8, g*0xFFFF/a>>8, b*0xFFFF/a>>8
+			key := int(r>>4)<<8 | int(g>>4)<<4 | int(b>>4)
+			group := buckets[key]
+			if group == nil {
+				group = &bucket{key: key}
+				buckets[key] = group
+			}
+			group.r += uint64(r)
+			group.g += uint64(g)
+			group.b += uint64(b)
+			group.hits++
+		}
+	}
+
+	groups := make([]*bucket, 0, len(buckets))
+	for _, group := range buckets {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].hits != groups[j].hits {
+			return groups[i].hits > groups[j].hits
+		}
+		return groups[i].key < groups[j].key
+	})
+	palette := make([]string, 0, min(size, len(groups)))
+	for _, group := range groups[:min(size, len(groups))] {
+		palette = append(palette, fmt.Sprintf("#%02x%02x%02x", group.r/group.hits, group.g/group.hits, group.b/group.hits))
+	}
+	return palette, nil
+}
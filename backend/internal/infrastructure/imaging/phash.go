@@ -0,0 +1,68 @@
+package imaging
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+)
+
+// hashSamples bounds the pixels sampled along each side of a cell of a perceptual hash
+const hashSamples = 8
+
+// PerceptualHash returns the difference hash of an image as 16 hex digits. The image is shrunk to
+// 9x8 cells of average luminance, each bit telling whether a cell is brighter than the cell on its
+// right, so resized, recompressed or slightly retouched copies of an image hash within a few bits
+// of each other. WebP images, which the standard library cannot decode, have no hash.
+func PerceptualHash(data []byte, format Format) (string, error) {
+	img, err := decode(data, format)
+	if err != nil || img == nil {
+		return "", err
+	}
+
+	var cells [8][9]uint64
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	for row := 0; row < 8; row++ {
+		y0 := bounds.Min.Y + row*height/8
+		y1 := max(bounds.Min.Y+(row+1)*height/8, y0+1)
+		for col := 0; col < 9; col++ {
+			x0 := bounds.Min.X + col*width/9
+			x1 := max(bounds.Min.X+(col+1)*width/9, x0+1)
+			var sum, count uint64
+			for y := y0; y < y1; y += max(1, (y1-y0)/hashSamples) {
+				for x := x0; x < x1; x += max(1, (x1-x0)/hashSamples) {
+					r, g, b, _ := img.At(x, y).RGBA()
+					// Luminance, with the ITU-R BT.601 weights in thousandths
+					sum += (299*uint64(r) + 587*uint64(g) + 114*uint64(b)) / 1000
+					count++
+				}
+			}
+			cells[row][col] = sum / count
+		}
+	}
+
+	var hash uint64
+	for row := 0; row < 8; row++ {
+		for col := 0; col < 8; col++ {
+			hash <<= 1
+			if cells[row][col] > cells[row][col+1] {
+				hash |= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%016x", hash), nil
+}
+
+// HashDistance returns the number of bits two perceptual hashes differ by, from 0 for images
+// looking the same to 64, or -1 when either is not a perceptual hash
+func HashDistance(a, b string) int {
+	x, err := strconv.ParseUint(a, 16, 64)
+	if err != nil || len(a) != 16 {
+		return -1
+	}
+	y, err := strconv.ParseUint(b, 16, 64)
+	if err != nil || len(b) != 16 {
+		return -1
+	}
+	return bits.OnesCount64(x ^ y)
+}
@@ -0,0 +1,501 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/infrastructure/tracing"
+)
+
+// Errors returned by Enqueue
+var (
+	// ErrQueueClosed is returned when enqueuing on a stopped queue
+	ErrQueueClosed = errors.New("job queue is closed")
+	// ErrQueueFull is returned when the queue already holds as many jobs as it may
+	ErrQueueFull = domainerr.ErrQueueFull
+)
+
+// Priority classes jobs are scheduled by; a busy queue runs jobs of higher priorities more often,
+// in proportion to the weights of the priorities, without starving the lower ones
+type Priority string
+
+// Priorities, highest first
+const (
+	// PriorityInteractive is work someone is waiting for, such as a sitemap they submitted
+	PriorityInteractive Priority = "interactive"
+	// PriorityScheduled is recurring work, such as report deliveries
+	PriorityScheduled Priority = "scheduled"
+	// PriorityBackfill is bulk work that can wait, such as recomputations and replays
+	PriorityBackfill Priority = "backfill"
+)
+
+// priorities lists the priorities highest first, in the order of the lanes of a queue
+var priorities = []Priority{PriorityInteractive, PriorityScheduled, PriorityBackfill}
+
+// DefaultWeights are the shares of the workers each priority gets while all have jobs waiting
+var DefaultWeights = map[Priority]int{PriorityInteractive: 6, PriorityScheduled: 3, PriorityBackfill: 1}
+
+// lane returns the index of the lane of a priority, or -1 for unknown priorities
+func (p Priority) lane() int {
+	for i, priority := range priorities {
+		if p == priority {
+			return i
+		}
+	}
+	return -1
+}
+
+// Job is a unit of background work
+type Job struct {
+	Name string
+	// Priority defaults to PriorityInteractive
+	Priority Priority
+	// Kind names the Handler that runs the job from its Payload. Only jobs with a kind can be
+	// stored when they fail for good and requeued later; the others run Run.
+	Kind        string
+	Payload     []byte
+	Run         func(ctx context.Context) error
+	MaxAttempts int
+	// Trace is the trace the job continues, e.g. that of the request that queued it. Enqueue
+	// starts one when it is empty, so every attempt of a job shares a trace.
+	Trace    tracing.SpanContext
+	attempt  int
+	queuedAt time.Time
+}
+
+// Handler runs a job of a kind from its payload
+type Handler func(ctx context.Context, payload []byte) error
+
+// Failure is a job that failed all its attempts
+type Failure struct {
+	Kind        string
+	Name        string
+	Payload     []byte
+	Attempts    int
+	MaxAttempts int
+	Err         error
+}
+
+// Queue runs jobs on a pool of workers and retries failed jobs with exponential backoff. Waiting
+// jobs are kept in a lane per priority, which workers take turns on by weight.
+type Queue struct {
+	// slots holds a token per waiting job, up to the capacity of the queue; ready a signal per
+	// waiting job, which a worker takes before picking a job from the lanes
+	slots        chan struct{}
+	ready        chan struct{}
+	lanes        []chan *Job
+	retryBackoff time.Duration
+
+	// laneMu guards the weighted round robin over the lanes and their stats
+	laneMu  sync.Mutex
+	weights []int
+	credits []int
+	stats   []laneStats
+
+	// poolMu guards the pool size; shrink tells a worker to leave
+	poolMu  sync.Mutex
+	workers int
+	started bool
+	shrink  chan struct{}
+
+	mu       sync.RWMutex
+	closed   bool
+	stopping chan struct{}
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	pending  sync.WaitGroup
+	rejected int64
+
+	handlersMu sync.RWMutex
+	handlers   map[string]Handler
+	onFailure  func(Failure)
+}
+
+// laneStats counts the jobs of a lane
+type laneStats struct {
+	enqueued  int64
+	rejected  int64
+	succeeded int64
+	failed    int64
+	started   int64
+	waited    time.Duration
+	maxWait   time.Duration
+}
+
+// NewQueue creates a new job queue with the default weights
+func NewQueue(workers, size int, retryBackoff time.Duration) *Queue {
+	if workers < 1 {
+		workers = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	lanes := make([]chan *Job, len(priorities))
+	weights := make([]int, len(priorities))
+	for i, priority := range priorities {
+		lanes[i] = make(chan *Job, size)
+		weights[i] = DefaultWeights[priority]
+	}
+	return &Queue{
+		slots:        make(chan struct{}, size),
+		ready:        make(chan struct{}, size),
+		lanes:        lanes,
+		weights:      weights,
+		credits:      make([]int, len(priorities)),
+		stats:        make([]laneStats, len(priorities)),
+		workers:      workers,
+		retryBackoff: retryBackoff,
+		shrink:       make(chan struct{}),
+		handlers:     make(map[string]Handler),
+		stopping:     make(chan struct{}),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+// Start launches the worker pool
+func (q *Queue) Start() {
+	q.poolMu.Lock()
+	defer q.poolMu.Unlock()
+	if q.started {
+		return
+	}
+	q.started = true
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go q.work()
+	}
+}
+
+// SetWeights changes the shares of the workers the priorities get while jobs of several priorities
+// wait; priorities left out keep their weight
+func (q *Queue) SetWeights(weights map[Priority]int) error {
+	for priority, weight := range weights {
+		if priority.lane() < 0 {
+			return fmt.Errorf("unknown job priority %q", priority)
+		}
+		if weight < 1 {
+			return fmt.Errorf("weight of job priority %s must be at least 1", priority)
+		}
+	}
+
+	q.laneMu.Lock()
+	defer q.laneMu.Unlock()
+	for priority, weight := range weights {
+		q.weights[priority.lane()] = weight
+	}
+	return nil
+}
+
+// Handle registers the handler running the jobs of a kind
+func (q *Queue) Handle(kind string, handler Handler) {
+	q.handlersMu.Lock()
+	defer q.handlersMu.Unlock()
+	q.handlers[kind] = handler
+}
+
+// OnFailure registers a function called with every job of a kind that failed all its attempts,
+// or whose retry was abandoned because the queue stopped, typically to keep it as a dead letter.
+func (q *Queue) OnFailure(fn func(Failure)) {
+	q.handlersMu.Lock()
+	defer q.handlersMu.Unlock()
+	q.onFailure = fn
+}
+
+// Requeue enqueues a job of a kind again from its payload, e.g. a dead letter, as a backfill
+func (q *Queue) Requeue(kind, name string, payload []byte, maxAttempts int) error {
+	if q.handler(kind) == nil {
+		return fmt.Errorf("no handler for job kind %s", kind)
+	}
+	return q.Enqueue(Job{Name: name, Kind: kind, Payload: payload, MaxAttempts: maxAttempts, Priority: PriorityBackfill})
+}
+
+// handler returns the handler of a job kind, or nil
+func (q *Queue) handler(kind string) Handler {
+	q.handlersMu.RLock()
+	defer q.handlersMu.RUnlock()
+	return q.handlers[kind]
+}
+
+// Resize changes the number of workers without dropping jobs. New workers start right away;
+// when shrinking, the extra workers leave as soon as they are done with their current job.
+func (q *Queue) Resize(workers int) error {
+	if workers < 1 {
+		return errors.New("workers must be at least 1")
+	}
+
+	q.poolMu.Lock()
+	defer q.poolMu.Unlock()
+	q.mu.RLock()
+	closed := q.closed
+	q.mu.RUnlock()
+	if closed {
+		return ErrQueueClosed
+	}
+
+	for ; q.workers < workers; q.workers++ {
+		if q.started {
+			q.wg.Add(1)
+			go q.work()
+		}
+	}
+	for ; q.workers > workers; q.workers-- {
+		if !q.started {
+			continue
+		}
+		select {
+		case q.shrink <- struct{}{}:
+			// an idle worker left
+		default:
+			go q.retire()
+		}
+	}
+	return nil
+}
+
+// Workers returns the number of workers of the pool
+func (q *Queue) Workers() int {
+	q.poolMu.Lock()
+	defer q.poolMu.Unlock()
+	return q.workers
+}
+
+// Queued returns the number of jobs waiting for a worker
+func (q *Queue) Queued() int {
+	return len(q.slots)
+}
+
+// Capacity returns the maximum number of waiting jobs; beyond it Enqueue fails with ErrQueueFull
+func (q *Queue) Capacity() int {
+	return cap(q.slots)
+}
+
+// Priorities returns the weight, backlog and counts of every priority, highest first
+func (q *Queue) Priorities() []entities.JobPriorityStats {
+	q.laneMu.Lock()
+	defer q.laneMu.Unlock()
+	stats := make([]entities.JobPriorityStats, len(priorities))
+	for i, priority := range priorities {
+		lane := q.stats[i]
+		stats[i] = entities.JobPriorityStats{
+			Priority:  string(priority),
+			Weight:    q.weights[i],
+			Queued:    len(q.lanes[i]),
+			Enqueued:  lane.enqueued,
+			Rejected:  lane.rejected,
+			Succeeded: lane.succeeded,
+			Failed:    lane.failed,
+			MaxWaitMs: lane.maxWait.Milliseconds(),
+		}
+		if lane.started > 0 {
+			stats[i].AvgWaitMs = (lane.waited / time.Duration(lane.started)).Milliseconds()
+		}
+	}
+	return stats
+}
+
+// Rejected returns the number of jobs refused because the queue was full
+func (q *Queue) Rejected() int64 {
+	return atomic.LoadInt64(&q.rejected)
+}
+
+// Stop stops accepting jobs, waits for queued jobs to finish and shuts down the workers.
+// Retries that are still waiting for their backoff are abandoned.
+func (q *Queue) Stop() {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return
+	}
+	q.closed = true
+	close(q.stopping)
+	q.mu.Unlock()
+
+	q.pending.Wait()
+	close(q.ready)
+	q.wg.Wait()
+	q.cancel()
+}
+
+// Enqueue schedules a job for execution. It never blocks: when the queue is full the job is
+// refused with ErrQueueFull, so producers shed load instead of piling up behind the workers.
+func (q *Queue) Enqueue(job Job) error {
+	if job.MaxAttempts < 1 {
+		job.MaxAttempts = 1
+	}
+	if !job.Trace.Valid() {
+		job.Trace = tracing.New()
+	}
+	if job.Priority == "" {
+		job.Priority = PriorityInteractive
+	}
+	lane := job.Priority.lane()
+	if lane < 0 {
+		return fmt.Errorf("unknown job priority %q", job.Priority)
+	}
+	err := q.push(&job, false)
+	q.laneMu.Lock()
+	switch {
+	case err == nil:
+		q.stats[lane].enqueued++
+	case errors.Is(err, ErrQueueFull):
+		q.stats[lane].rejected++
+		atomic.AddInt64(&q.rejected, 1)
+	}
+	q.laneMu.Unlock()
+	return err
+}
+
+// push adds a job to the lane of its priority; retries wait for room rather than losing an
+// accepted job
+func (q *Queue) push(job *Job, wait bool) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	q.pending.Add(1)
+	if wait {
+		q.slots <- struct{}{}
+	} else {
+		select {
+		case q.slots <- struct{}{}:
+		default:
+			q.pending.Done()
+			return ErrQueueFull
+		}
+	}
+	// Lanes and ready have room for every slot, so these never block
+	job.queuedAt = time.Now()
+	q.lanes[job.Priority.lane()] <- job
+	q.ready <- struct{}{}
+	return nil
+}
+
+func (q *Queue) work() {
+	defer q.wg.Done()
+	for {
+		select {
+		case _, ok := <-q.ready:
+			if !ok {
+				return
+			}
+			q.run(q.next())
+			q.pending.Done()
+		case <-q.shrink:
+			return
+		}
+	}
+}
+
+// next takes the job to run from the lanes by smooth weighted round robin: every lane with jobs
+// earns its weight in credits, and the richest one pays the weights of all of them for its turn.
+// A worker only calls it after taking a ready signal, so some lane has a job.
+func (q *Queue) next() *Job {
+	q.laneMu.Lock()
+	defer q.laneMu.Unlock()
+	best, total := -1, 0
+	for i, lane := range q.lanes {
+		if len(lane) == 0 {
+			continue
+		}
+		q.credits[i] += q.weights[i]
+		total += q.weights[i]
+		if best < 0 || q.credits[i] > q.credits[best] {
+			best = i
+		}
+	}
+	q.credits[best] -= total
+	job := <-q.lanes[best]
+	<-q.slots
+
+	wait := time.Since(job.queuedAt)
+	stats := &q.stats[best]
+	stats.started++
+	stats.waited += wait
+	stats.maxWait = max(stats.maxWait, wait)
+	return job
+}
+
+// done counts a job that will not be attempted again
+func (q *Queue) done(job *Job, err error) {
+	q.laneMu.Lock()
+	defer q.laneMu.Unlock()
+	if err != nil {
+		q.stats[job.Priority.lane()].failed++
+		return
+	}
+	q.stats[job.Priority.lane()].succeeded++
+}
+
+// retire waits for a busy worker to leave; on stop every worker leaves anyway
+func (q *Queue) retire() {
+	select {
+	case q.shrink <- struct{}{}:
+	case <-q.stopping:
+	}
+}
+
+// fail reports a job that will not be attempted again to the failure handler
+func (q *Queue) fail(job *Job, err error) {
+	q.handlersMu.RLock()
+	onFailure := q.onFailure
+	q.handlersMu.RUnlock()
+	if onFailure != nil && job.Kind != "" {
+		onFailure(Failure{Kind: job.Kind, Name: job.Name, Payload: job.Payload, Attempts: job.attempt, MaxAttempts: job.MaxAttempts, Err: err})
+	}
+}
+
+// execute runs a job once, through the handler of its kind unless it has its own Run
+func (q *Queue) execute(job *Job) error {
+	ctx := tracing.ContextWith(q.ctx, job.Trace.Child())
+	if job.Run != nil {
+		return job.Run(ctx)
+	}
+	handler := q.handler(job.Kind)
+	if handler == nil {
+		return fmt.Errorf("no handler for job kind %s", job.Kind)
+	}
+	return handler(ctx, job.Payload)
+}
+
+func (q *Queue) run(job *Job) {
+	job.attempt++
+	err := q.execute(job)
+	if err == nil {
+		q.done(job, nil)
+		return
+	}
+
+	if job.attempt >= job.MaxAttempts {
+		log.Printf("Job %s failed after %d attempt(s) (trace %s): %v", job.Name, job.attempt, job.Trace.TraceID, err)
+		q.done(job, err)
+		q.fail(job, err)
+		return
+	}
+
+	delay := q.retryBackoff << (job.attempt - 1)
+	log.Printf("Job %s failed (attempt %d/%d), retrying in %s: %v", job.Name, job.attempt, job.MaxAttempts, delay, err)
+
+	q.pending.Add(1)
+	go func() {
+		defer q.pending.Done()
+		select {
+		case <-time.After(delay):
+		case <-q.stopping:
+			log.Printf("Job %s retry abandoned: queue is stopping", job.Name)
+			q.done(job, err)
+			q.fail(job, err)
+			return
+		}
+		if err := q.push(job, true); err != nil {
+			log.Printf("Job %s retry dropped: %v", job.Name, err)
+		}
+	}()
+}
@@ -0,0 +1,376 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"library-management-system/internal/infrastructure/tracing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueue_RunsJob(t *testing.T) {
+	queue := NewQueue(2, 10, time.Millisecond)
+	queue.Start()
+
+	var runs int32
+	err := queue.Enqueue(Job{
+		Name: "test",
+		Run: func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		},
+	})
+	assert.NoError(t, err)
+
+	queue.Stop()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+}
+
+func TestQueue_RunsJobsInTheirTrace(t *testing.T) {
+	queue := NewQueue(1, 10, time.Millisecond)
+	queue.Start()
+
+	parent, _ := tracing.Parse("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "")
+	var traces []string
+	var mu sync.Mutex
+	run := func(ctx context.Context) error {
+		span, ok := tracing.FromContext(ctx)
+		assert.True(t, ok)
+		mu.Lock()
+		traces = append(traces, span.TraceID)
+		mu.Unlock()
+		return nil
+	}
+	assert.NoError(t, queue.Enqueue(Job{Name: "traced", Run: run, Trace: parent}))
+	assert.NoError(t, queue.Enqueue(Job{Name: "untraced", Run: run}))
+
+	queue.Stop()
+	assert.Len(t, traces, 2)
+	assert.Equal(t, parent.TraceID, traces[0])
+	assert.NotEqual(t, parent.TraceID, traces[1], "jobs outside a trace start their own")
+}
+
+func TestQueue_RetriesFailedJob(t *testing.T) {
+	queue := NewQueue(1, 10, time.Millisecond)
+	queue.Start()
+
+	var runs int32
+	done := make(chan struct{})
+	err := queue.Enqueue(Job{
+		Name:        "flaky",
+		MaxAttempts: 3,
+		Run: func(ctx context.Context) error {
+			if atomic.AddInt32(&runs, 1) < 3 {
+				return errors.New("temporary failure")
+			}
+			close(done)
+			return nil
+		},
+	})
+	assert.NoError(t, err)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("job was not retried")
+	}
+
+	queue.Stop()
+	assert.Equal(t, int32(3), atomic.LoadInt32(&runs))
+}
+
+func TestQueue_StopsRetryingAfterMaxAttempts(t *testing.T) {
+	queue := NewQueue(1, 10, time.Millisecond)
+	queue.Start()
+
+	var runs int32
+	err := queue.Enqueue(Job{
+		Name:        "broken",
+		MaxAttempts: 2,
+		Run: func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			return errors.New("permanent failure")
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&runs) == 2 }, time.Second, time.Millisecond)
+	queue.Stop()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&runs))
+}
+
+func TestQueue_EnqueueAfterStop(t *testing.T) {
+	queue := NewQueue(1, 10, time.Millisecond)
+	queue.Start()
+	queue.Stop()
+
+	err := queue.Enqueue(Job{Name: "late", Run: func(ctx context.Context) error { return nil }})
+	assert.ErrorIs(t, err, ErrQueueClosed)
+}
+
+func TestQueue_ResizeGrowsPool(t *testing.T) {
+	queue := NewQueue(1, 10, time.Millisecond)
+	queue.Start()
+
+	var running int32
+	release := make(chan struct{})
+	blocking := Job{
+		Name: "blocking",
+		Run: func(ctx context.Context) error {
+			atomic.AddInt32(&running, 1)
+			<-release
+			return nil
+		},
+	}
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, queue.Enqueue(blocking))
+	}
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&running) == 1 }, time.Second, time.Millisecond)
+
+	assert.NoError(t, queue.Resize(3))
+	assert.Equal(t, 3, queue.Workers())
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&running) == 3 }, time.Second, time.Millisecond)
+
+	close(release)
+	queue.Stop()
+}
+
+func TestQueue_ResizeShrinksPool(t *testing.T) {
+	queue := NewQueue(3, 10, time.Millisecond)
+	queue.Start()
+	// let the workers go idle, so they leave as soon as the pool shrinks
+	time.Sleep(10 * time.Millisecond)
+	assert.NoError(t, queue.Resize(1))
+	assert.Equal(t, 1, queue.Workers())
+
+	var running int32
+	release := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, queue.Enqueue(Job{
+			Name: "blocking",
+			Run: func(ctx context.Context) error {
+				atomic.AddInt32(&running, 1)
+				<-release
+				return nil
+			},
+		}))
+	}
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&running) == 1 }, time.Second, time.Millisecond)
+	assert.Never(t, func() bool { return atomic.LoadInt32(&running) > 1 }, 50*time.Millisecond, time.Millisecond)
+	assert.Equal(t, 1, queue.Queued())
+
+	close(release)
+	queue.Stop()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&running))
+}
+
+func TestQueue_ResizeValidation(t *testing.T) {
+	queue := NewQueue(2, 10, time.Millisecond)
+	assert.EqualError(t, queue.Resize(0), "workers must be at least 1")
+	assert.NoError(t, queue.Resize(4))
+	assert.Equal(t, 4, queue.Workers())
+	assert.Equal(t, 10, queue.Capacity())
+
+	queue.Start()
+	queue.Stop()
+	assert.ErrorIs(t, queue.Resize(2), ErrQueueClosed)
+}
+
+func TestQueue_RejectsJobsWhenFull(t *testing.T) {
+	queue := NewQueue(1, 1, time.Millisecond)
+	queue.Start()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	assert.NoError(t, queue.Enqueue(Job{
+		Name: "running",
+		Run: func(ctx context.Context) error {
+			close(started)
+			<-release
+			return nil
+		},
+	}))
+	<-started
+
+	noop := Job{Name: "noop", Run: func(ctx context.Context) error { return nil }}
+	assert.NoError(t, queue.Enqueue(noop))
+	assert.ErrorIs(t, queue.Enqueue(noop), ErrQueueFull)
+	assert.Equal(t, 1, queue.Queued())
+	assert.Equal(t, int64(1), queue.Rejected())
+
+	close(release)
+	queue.Stop()
+}
+
+func TestQueue_RetriesWaitForRoom(t *testing.T) {
+	queue := NewQueue(1, 1, 20*time.Millisecond)
+	queue.Start()
+
+	var runs int32
+	failed := make(chan struct{})
+	assert.NoError(t, queue.Enqueue(Job{
+		Name:        "flaky",
+		MaxAttempts: 2,
+		Run: func(ctx context.Context) error {
+			if atomic.AddInt32(&runs, 1) == 1 {
+				close(failed)
+				return errors.New("temporary failure")
+			}
+			return nil
+		},
+	}))
+	<-failed
+
+	// Keep the worker busy and the queue full while the retry waits for its backoff
+	started := make(chan struct{})
+	release := make(chan struct{})
+	assert.NoError(t, queue.Enqueue(Job{Name: "blocking", Run: func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	}}))
+	<-started
+	assert.NoError(t, queue.Enqueue(Job{Name: "noop", Run: func(ctx context.Context) error { return nil }}))
+
+	time.Sleep(40 * time.Millisecond)
+	close(release)
+
+	queue.Stop()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&runs))
+	assert.Equal(t, int64(0), queue.Rejected())
+}
+
+func TestQueue_ReportsFailedJobsOfAKind(t *testing.T) {
+	queue := NewQueue(1, 10, time.Millisecond)
+	queue.Handle("greet", func(ctx context.Context, payload []byte) error {
+		return errors.New("cannot greet " + string(payload))
+	})
+
+	var failures []Failure
+	var mu sync.Mutex
+	queue.OnFailure(func(failure Failure) {
+		mu.Lock()
+		defer mu.Unlock()
+		failures = append(failures, failure)
+	})
+	queue.Start()
+
+	assert.NoError(t, queue.Enqueue(Job{Name: "greet:world", Kind: "greet", Payload: []byte("world"), MaxAttempts: 2}))
+	// Jobs without a kind cannot be requeued, so they are not reported
+	assert.NoError(t, queue.Enqueue(Job{Name: "closure", Run: func(ctx context.Context) error { return errors.New("boom") }}))
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(failures) == 1
+	}, time.Second, time.Millisecond)
+	queue.Stop()
+
+	assert.Equal(t, []Failure{{
+		Kind:        "greet",
+		Name:        "greet:world",
+		Payload:     []byte("world"),
+		Attempts:    2,
+		MaxAttempts: 2,
+		Err:         errors.New("cannot greet world"),
+	}}, failures)
+}
+
+func TestQueue_Requeue(t *testing.T) {
+	queue := NewQueue(1, 10, time.Millisecond)
+	var greeted []string
+	queue.Handle("greet", func(ctx context.Context, payload []byte) error {
+		greeted = append(greeted, string(payload))
+		return nil
+	})
+	queue.Start()
+
+	assert.NoError(t, queue.Requeue("greet", "greet:world", []byte("world"), 1))
+	assert.EqualError(t, queue.Requeue("unknown", "unknown", nil, 1), "no handler for job kind unknown")
+	queue.Stop()
+
+	assert.Equal(t, []string{"world"}, greeted)
+}
+
+func TestQueue_ReportsRetriesAbandonedOnStop(t *testing.T) {
+	queue := NewQueue(1, 10, time.Hour)
+	queue.Handle("greet", func(ctx context.Context, payload []byte) error {
+		return errors.New("unreachable")
+	})
+	failures := make(chan Failure, 1)
+	queue.OnFailure(func(failure Failure) { failures <- failure })
+	queue.Start()
+
+	var attempted int32
+	assert.NoError(t, queue.Enqueue(Job{Name: "greet:world", Kind: "greet", MaxAttempts: 3}))
+	assert.NoError(t, queue.Enqueue(Job{Name: "marker", Run: func(ctx context.Context) error {
+		atomic.AddInt32(&attempted, 1)
+		return nil
+	}}))
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&attempted) == 1 }, time.Second, time.Millisecond)
+	queue.Stop()
+
+	failure := <-failures
+	assert.Equal(t, "greet:world", failure.Name)
+	assert.Equal(t, 1, failure.Attempts)
+	assert.Equal(t, 3, failure.MaxAttempts)
+}
+
+func TestQueue_RunsPrioritiesByWeight(t *testing.T) {
+	queue := NewQueue(1, 10, time.Millisecond)
+	assert.NoError(t, queue.SetWeights(map[Priority]int{PriorityInteractive: 2, PriorityBackfill: 1}))
+	queue.Start()
+
+	// Hold the only worker while jobs of both priorities pile up
+	started := make(chan struct{})
+	release := make(chan struct{})
+	assert.NoError(t, queue.Enqueue(Job{Name: "blocking", Run: func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	}}))
+	<-started
+
+	var order []Priority
+	var mu sync.Mutex
+	for _, priority := range []Priority{PriorityBackfill, PriorityBackfill, PriorityBackfill, PriorityInteractive, PriorityInteractive, PriorityInteractive} {
+		priority := priority
+		assert.NoError(t, queue.Enqueue(Job{Name: string(priority), Priority: priority, Run: func(ctx context.Context) error {
+			mu.Lock()
+			order = append(order, priority)
+			mu.Unlock()
+			return nil
+		}}))
+	}
+	close(release)
+	queue.Stop()
+
+	assert.Equal(t, []Priority{
+		PriorityInteractive, PriorityBackfill, PriorityInteractive, PriorityInteractive, PriorityBackfill, PriorityBackfill,
+	}, order, "interactive jobs get two turns for every backfill one")
+
+	stats := queue.Priorities()
+	assert.Len(t, stats, 3)
+	assert.Equal(t, "interactive", stats[0].Priority)
+	assert.Equal(t, 2, stats[0].Weight)
+	assert.Equal(t, int64(4), stats[0].Enqueued)
+	assert.Equal(t, int64(4), stats[0].Succeeded)
+	assert.Equal(t, 3, stats[1].Weight, "priorities left out keep their weight")
+	assert.Equal(t, int64(0), stats[1].Enqueued)
+	assert.Equal(t, "backfill", stats[2].Priority)
+	assert.Equal(t, int64(3), stats[2].Succeeded)
+	assert.Equal(t, 0, stats[2].Queued)
+}
+
+func TestQueue_PriorityValidation(t *testing.T) {
+	queue := NewQueue(1, 10, time.Millisecond)
+	assert.EqualError(t, queue.SetWeights(map[Priority]int{"urgent": 1}), `unknown job priority "urgent"`)
+	assert.EqualError(t, queue.SetWeights(map[Priority]int{PriorityScheduled: 0}), "weight of job priority scheduled must be at least 1")
+	assert.EqualError(t, queue.Enqueue(Job{Name: "urgent", Priority: "urgent"}), `unknown job priority "urgent"`)
+	assert.Equal(t, 0, queue.Queued())
+}
@@ -0,0 +1,76 @@
+// Package metrics records the number of calls, errors and durations of named operations in
+// memory, per instance, for the admin API to report. Counts start over when the server restarts.
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+)
+
+// Registry holds the metrics of operations by name. It is safe for concurrent use.
+type Registry struct {
+	mu         sync.Mutex
+	operations map[string]*operation
+}
+
+// operation is what was recorded of an operation
+type operation struct {
+	calls  int64
+	errors int64
+	total  time.Duration
+	max    time.Duration
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{operations: make(map[string]*operation)}
+}
+
+// Observe records a call of an operation that took d and returned err
+func (r *Registry) Observe(name string, d time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	op, ok := r.operations[name]
+	if !ok {
+		op = &operation{}
+		r.operations[name] = op
+	}
+	op.calls++
+	if err != nil {
+		op.errors++
+	}
+	op.total += d
+	if d > op.max {
+		op.max = d
+	}
+}
+
+// Snapshot returns the metrics of every operation called so far, by name
+func (r *Registry) Snapshot() []entities.OperationStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make([]entities.OperationStats, 0, len(r.operations))
+	for name, op := range r.operations {
+		stats = append(stats, entities.OperationStats{
+			Name:      name,
+			Calls:     op.calls,
+			Errors:    op.errors,
+			ErrorRate: float64(op.errors) / float64(op.calls),
+			TotalMs:   milliseconds(op.total),
+			AvgMs:     milliseconds(op.total / time.Duration(op.calls)),
+			MaxMs:     milliseconds(op.max),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+// milliseconds converts a duration to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	assert.Empty(t, registry.Snapshot())
+
+	registry.Observe("books.GetByID", 10*time.Millisecond, nil)
+	registry.Observe("books.GetByID", 30*time.Millisecond, errors.New("record not found"))
+	registry.Observe("books.Create", 2*time.Millisecond, nil)
+	registry.Observe("books.GetByID", 20*time.Millisecond, nil)
+	registry.Observe("books.GetByID", 40*time.Millisecond, errors.New("database unavailable"))
+
+	stats := registry.Snapshot()
+	require.Len(t, stats, 2)
+	assert.Equal(t, "books.Create", stats[0].Name, "sorted by name")
+	assert.Equal(t, int64(1), stats[0].Calls)
+	assert.Zero(t, stats[0].ErrorRate)
+
+	getByID := stats[1]
+	assert.Equal(t, "books.GetByID", getByID.Name)
+	assert.Equal(t, int64(4), getByID.Calls)
+	assert.Equal(t, int64(2), getByID.Errors)
+	assert.Equal(t, 0.5, getByID.ErrorRate)
+	assert.Equal(t, 100.0, getByID.TotalMs)
+	assert.Equal(t, 25.0, getByID.AvgMs)
+	assert.Equal(t, 40.0, getByID.MaxMs)
+}
+
+func TestRegistry_Concurrent(t *testing.T) {
+	registry := NewRegistry()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				registry.Observe("books.GetAll", time.Millisecond, nil)
+				registry.Snapshot()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(800), registry.Snapshot()[0].Calls)
+}
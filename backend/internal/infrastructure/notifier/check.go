@@ -0,0 +1,68 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/url"
+)
+
+// Checker is a channel that can check it reaches its server without delivering anything
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// Check connects to the SMTP server and greets it, without signing in or sending mail
+func (n *EmailNotifier) Check(ctx context.Context) error {
+	addr := net.JoinHostPort(n.host, n.port)
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("smtp server at %s: %w", addr, err)
+	}
+	defer client.Close()
+	if err := client.Hello("localhost"); err != nil {
+		return fmt.Errorf("smtp server at %s: %w", addr, err)
+	}
+	return client.Quit()
+}
+
+// Check connects to the host of the webhook URL, without posting to it
+func (n *WebhookNotifier) Check(ctx context.Context) error {
+	return dialURL(ctx, n.url)
+}
+
+// Check connects to the host of the Slack webhook URL, without posting to it
+func (n *SlackNotifier) Check(ctx context.Context) error {
+	return dialURL(ctx, n.webhookURL)
+}
+
+// dialURL opens, then closes, a TCP connection to the host of an HTTP URL
+func dialURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
@@ -0,0 +1,158 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/events"
+	"library-management-system/internal/infrastructure/jobs"
+)
+
+// Channel names used in routing configuration
+const (
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+	ChannelSlack   = "slack"
+)
+
+// JobKindNotification is the job kind of notification deliveries
+const JobKindNotification = "notification"
+
+// delivery is the payload of a notification delivery job
+type delivery struct {
+	Channel string  `json:"channel"`
+	Message Message `json:"message"`
+}
+
+// Recipients looks up the users notifications are addressed to, such as the user repository
+type Recipients interface {
+	GetByID(id string) (*entities.User, error)
+}
+
+// Dispatcher fans domain events out to the notification channels routed for their type.
+// Each delivery runs as its own job so a failing channel is retried independently.
+type Dispatcher struct {
+	channels    map[string]Notifier
+	routes      map[events.EventType][]string
+	queue       *jobs.Queue
+	maxAttempts int
+	// recipients give emails the address of their recipient, as events only name them by ID
+	recipients Recipients
+}
+
+// NewDispatcher creates a new dispatcher over the enabled channels
+func NewDispatcher(channels []Notifier, routes map[string][]string, queue *jobs.Queue, maxAttempts int) *Dispatcher {
+	d := &Dispatcher{
+		channels:    make(map[string]Notifier),
+		routes:      make(map[events.EventType][]string),
+		queue:       queue,
+		maxAttempts: maxAttempts,
+	}
+	for _, channel := range channels {
+		d.channels[channel.Name()] = channel
+	}
+	for eventType, names := range routes {
+		d.routes[events.EventType(eventType)] = names
+	}
+	queue.Handle(JobKindNotification, d.deliver)
+	return d
+}
+
+// SetRecipients sets where the email addresses of recipients are looked up
+func (d *Dispatcher) SetRecipients(recipients Recipients) {
+	d.recipients = recipients
+}
+
+// Subscribe registers the dispatcher on the event bus for every routed event type
+func (d *Dispatcher) Subscribe(bus events.Bus) {
+	for eventType := range d.routes {
+		bus.Subscribe(eventType, d.HandleEvent)
+	}
+}
+
+// Channels returns the names of the enabled channels, sorted
+func (d *Dispatcher) Channels() []string {
+	names := make([]string, 0, len(d.channels))
+	for name := range d.channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HandleEvent enqueues one delivery job per enabled channel routed for the event
+func (d *Dispatcher) HandleEvent(event events.Event) error {
+	message := Message{
+		EventType:   string(event.Type),
+		RecipientID: event.RecipientID,
+		Email:       event.Payload["email"],
+		Subject:     event.Type.Summary(),
+		Body:        event.Payload["message"],
+		BookID:      event.BookID,
+		OccurredAt:  event.OccurredAt,
+	}
+
+	for _, name := range d.routes[event.Type] {
+		if _, ok := d.channels[name]; !ok {
+			// Routed to a disabled or unknown channel
+			continue
+		}
+
+		payload, err := json.Marshal(delivery{Channel: name, Message: message})
+		if err != nil {
+			return err
+		}
+		err = d.queue.Enqueue(jobs.Job{
+			Name:        "notify:" + name + ":" + message.EventType,
+			Kind:        JobKindNotification,
+			Payload:     payload,
+			MaxAttempts: d.maxAttempts,
+		})
+		if err != nil {
+			log.Printf("Failed to enqueue %s notification for %s: %v", name, event.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// deliver sends a notification delivery job over its channel
+func (d *Dispatcher) deliver(ctx context.Context, payload []byte) error {
+	var job delivery
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return fmt.Errorf("invalid notification payload: %w", err)
+	}
+	channel, ok := d.channels[job.Channel]
+	if !ok {
+		return fmt.Errorf("notification channel %s is not enabled", job.Channel)
+	}
+	if job.Channel == ChannelEmail && job.Message.Email == "" {
+		email, err := d.recipientEmail(job.Message.RecipientID)
+		if err != nil {
+			return err
+		}
+		job.Message.Email = email
+	}
+	return channel.Send(ctx, job.Message)
+}
+
+// recipientEmail looks up the current address of a recipient when the email is sent, so a
+// changed address is used and a failed lookup is retried like a failed send. Recipients who are
+// unknown or deactivated have none.
+func (d *Dispatcher) recipientEmail(recipientID string) (string, error) {
+	if d.recipients == nil || recipientID == "" {
+		return "", nil
+	}
+	user, err := d.recipients.GetByID(recipientID)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up recipient %s: %w", recipientID, err)
+	}
+	if user == nil || !user.Active() {
+		return "", nil
+	}
+	return user.Email, nil
+}
@@ -0,0 +1,106 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/events"
+	"library-management-system/internal/infrastructure/eventbus"
+	"library-management-system/internal/infrastructure/jobs"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingNotifier records the messages it is asked to send
+type recordingNotifier struct {
+	name     string
+	mu       sync.Mutex
+	messages []Message
+}
+
+func (n *recordingNotifier) Name() string {
+	return n.name
+}
+
+func (n *recordingNotifier) Send(ctx context.Context, message Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.messages = append(n.messages, message)
+	return nil
+}
+
+func (n *recordingNotifier) sent() []Message {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]Message(nil), n.messages...)
+}
+
+func TestDispatcher_RoutesEventsToEnabledChannels(t *testing.T) {
+	queue := jobs.NewQueue(1, 10, time.Millisecond)
+	queue.Start()
+
+	email := &recordingNotifier{name: ChannelEmail}
+	slack := &recordingNotifier{name: ChannelSlack}
+	routes := map[string][]string{
+		string(events.HoldAvailable): {ChannelEmail, ChannelWebhook},
+		string(events.LoanDueSoon):   {ChannelSlack},
+	}
+
+	bus := eventbus.NewInMemoryBus()
+	dispatcher := NewDispatcher([]Notifier{slack, email}, routes, queue, 1)
+	dispatcher.Subscribe(bus)
+	assert.Equal(t, []string{ChannelEmail, ChannelSlack}, dispatcher.Channels())
+
+	bus.Publish(events.Event{
+		Type:        events.HoldAvailable,
+		RecipientID: "member-1",
+		Payload:     map[string]string{"email": "member@example.com"},
+	})
+	queue.Stop()
+
+	// The webhook channel is routed but not enabled, Slack is enabled but not routed
+	assert.Len(t, email.sent(), 1)
+	assert.Equal(t, "member@example.com", email.sent()[0].Email)
+	assert.Equal(t, events.HoldAvailable.Summary(), email.sent()[0].Subject)
+	assert.Empty(t, slack.sent())
+}
+
+// flakyNotifier fails until it is told to recover
+type flakyNotifier struct {
+	recordingNotifier
+	down int32
+}
+
+func (n *flakyNotifier) Send(ctx context.Context, message Message) error {
+	if atomic.LoadInt32(&n.down) == 1 {
+		return errors.New("channel unavailable")
+	}
+	return n.recordingNotifier.Send(ctx, message)
+}
+
+func TestDispatcher_FailedDeliveriesCanBeRequeued(t *testing.T) {
+	queue := jobs.NewQueue(1, 10, time.Millisecond)
+	failures := make(chan jobs.Failure, 1)
+	queue.OnFailure(func(failure jobs.Failure) { failures <- failure })
+	queue.Start()
+	defer queue.Stop()
+
+	webhook := &flakyNotifier{recordingNotifier: recordingNotifier{name: ChannelWebhook}, down: 1}
+	dispatcher := NewDispatcher([]Notifier{webhook}, map[string][]string{string(events.HoldAvailable): {ChannelWebhook}}, queue, 2)
+	assert.NoError(t, dispatcher.HandleEvent(events.Event{Type: events.HoldAvailable, RecipientID: "member-1"}))
+
+	failure := <-failures
+	assert.Equal(t, JobKindNotification, failure.Kind)
+	assert.Equal(t, 2, failure.Attempts)
+	assert.EqualError(t, failure.Err, "channel unavailable")
+	assert.Empty(t, webhook.sent())
+
+	atomic.StoreInt32(&webhook.down, 0)
+	assert.NoError(t, queue.Requeue(failure.Kind, failure.Name, failure.Payload, 1))
+	assert.Eventually(t, func() bool { return len(webhook.sent()) == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, "member-1", webhook.sent()[0].RecipientID)
+}
@@ -0,0 +1,61 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// EmailNotifier delivers messages over SMTP
+type EmailNotifier struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+// NewEmailNotifier creates a new email notifier
+func NewEmailNotifier(host, port, username, password, from string) *EmailNotifier {
+	return &EmailNotifier{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+// Name returns the channel name
+func (n *EmailNotifier) Name() string {
+	return ChannelEmail
+}
+
+// Send sends the message to the recipient's email address
+func (n *EmailNotifier) Send(ctx context.Context, message Message) error {
+	if message.Email == "" {
+		return errors.New("recipient has no email address")
+	}
+
+	var auth smtp.Auth
+	if n.username != "" {
+		auth = smtp.PlainAuth("", n.username, n.password, n.host)
+	}
+
+	body := strings.Join([]string{
+		"From: " + n.from,
+		"To: " + message.Email,
+		"Subject: " + message.Subject,
+		"",
+		message.Body,
+	}, "\r\n")
+
+	addr := net.JoinHostPort(n.host, n.port)
+	if err := smtp.SendMail(addr, auth, n.from, []string{message.Email}, []byte(body)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package notifier
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/events"
+	"library-management-system/internal/infrastructure/eventbus"
+	"library-management-system/internal/infrastructure/jobs"
+	"library-management-system/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receivedEmail is an email accepted by the test SMTP server
+type receivedEmail struct {
+	to   []string
+	data string
+}
+
+// startSMTPServer accepts emails without authentication and hands them over on the channel
+func startSMTPServer(t *testing.T) (host, port string, received <-chan receivedEmail) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	emails := make(chan receivedEmail, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, emails)
+		}
+	}()
+	host, port, err = net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	return host, port, emails
+}
+
+// serveSMTP speaks just enough SMTP for net/smtp.SendMail
+func serveSMTP(conn net.Conn, emails chan<- receivedEmail) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP")
+	var email receivedEmail
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			email.to = append(email.to, strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>"))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			email.data = data.String()
+			emails <- email
+			email = receivedEmail{}
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+// stubAcquisitions keeps acquisitions by ID
+type stubAcquisitions map[string]*entities.Acquisition
+
+func (r stubAcquisitions) Create(acquisition *entities.Acquisition) error {
+	r[acquisition.ID] = acquisition
+	return nil
+}
+
+func (r stubAcquisitions) GetByID(id string) (*entities.Acquisition, error) {
+	return r[id], nil
+}
+
+func (r stubAcquisitions) List(status string) ([]entities.Acquisition, error) {
+	return nil, nil
+}
+
+func (r stubAcquisitions) Update(acquisition *entities.Acquisition) error {
+	r[acquisition.ID] = acquisition
+	return nil
+}
+
+// stubRecipients looks users up by ID
+type stubRecipients map[string]*entities.User
+
+func (r stubRecipients) GetByID(id string) (*entities.User, error) {
+	return r[id], nil
+}
+
+func TestDispatcher_EmailsTheRecipientOfAPublishedEvent(t *testing.T) {
+	host, port, received := startSMTPServer(t)
+	queue := jobs.NewQueue(1, 10, time.Millisecond)
+	queue.Start()
+	defer queue.Stop()
+
+	bus := eventbus.NewInMemoryBus()
+	dispatcher := NewDispatcher([]Notifier{NewEmailNotifier(host, port, "", "", "library@example.com")}, map[string][]string{
+		string(events.ChangeRequestApproved): {ChannelEmail},
+	}, queue, 1)
+	dispatcher.SetRecipients(stubRecipients{
+		"member-1": {ID: "member-1", Email: "ada@example.com", Role: entities.UserRoleMember},
+	})
+	dispatcher.Subscribe(bus)
+
+	// The event names its recipient by ID only, as every publisher does
+	acquisitions := stubAcquisitions{"acq-1": {ID: "acq-1", Status: entities.AcquisitionSuggested, SuggestedBy: "member-1", Title: "Mort", Author: "Terry Pratchett"}}
+	acquisitionUseCase := usecase.NewAcquisitionUseCase(acquisitions, nil, nil)
+	acquisitionUseCase.SetEventBus(bus)
+	_, err := acquisitionUseCase.Approve("librarian-1", "acq-1")
+	require.NoError(t, err)
+
+	select {
+	case email := <-received:
+		assert.Equal(t, []string{"ada@example.com"}, email.to)
+		assert.Contains(t, email.data, "To: ada@example.com\r\n")
+		assert.Contains(t, email.data, "Subject: "+events.ChangeRequestApproved.Summary()+"\r\n")
+		assert.Contains(t, email.data, `Your suggestion of "Mort" by Terry Pratchett will be ordered.`)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no email was delivered")
+	}
+}
@@ -0,0 +1,23 @@
+package notifier
+
+import (
+	"context"
+	"time"
+)
+
+// Message is a channel-agnostic notification
+type Message struct {
+	EventType   string    `json:"event_type"`
+	RecipientID string    `json:"recipient_id"`
+	Email       string    `json:"email,omitempty"`
+	Subject     string    `json:"subject"`
+	Body        string    `json:"body,omitempty"`
+	BookID      string    `json:"book_id,omitempty"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// Notifier delivers messages over a single channel
+type Notifier interface {
+	Name() string
+	Send(ctx context.Context, message Message) error
+}
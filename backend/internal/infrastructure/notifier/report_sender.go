@@ -0,0 +1,61 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"library-management-system/internal/usecase"
+)
+
+// ReportSender delivers scheduled reports by email or to the webhook URL of each subscription
+type ReportSender struct {
+	email  *EmailNotifier
+	client *http.Client
+}
+
+// NewReportSender creates a new report sender; email is nil when email delivery is disabled
+func NewReportSender(email *EmailNotifier) *ReportSender {
+	return &ReportSender{
+		email:  email,
+		client: newHTTPClient(),
+	}
+}
+
+// reportPayload is the JSON body posted to report webhooks
+type reportPayload struct {
+	Report      string      `json:"report"`
+	Subject     string      `json:"subject"`
+	Summary     string      `json:"summary"`
+	GeneratedAt time.Time   `json:"generated_at"`
+	Data        interface{} `json:"data"`
+}
+
+// SendReport delivers a report over the channel of a subscription
+func (s *ReportSender) SendReport(ctx context.Context, channel, target string, message usecase.ReportMessage) error {
+	switch channel {
+	case ChannelEmail:
+		if s.email == nil {
+			return errors.New("email delivery is disabled")
+		}
+		return s.email.Send(ctx, Message{
+			EventType:  "report." + message.Report,
+			Email:      target,
+			Subject:    message.Subject,
+			Body:       message.Body,
+			OccurredAt: message.GeneratedAt,
+		})
+	case ChannelWebhook:
+		return postJSON(ctx, s.client, target, reportPayload{
+			Report:      message.Report,
+			Subject:     message.Subject,
+			Summary:     message.Body,
+			GeneratedAt: message.GeneratedAt,
+			Data:        message.Data,
+		})
+	default:
+		return fmt.Errorf("unsupported report channel %q", channel)
+	}
+}
@@ -0,0 +1,43 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"library-management-system/internal/usecase"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportSender_PostsReportsToWebhooks(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	err := NewReportSender(nil).SendReport(context.Background(), ChannelWebhook, server.URL, usecase.ReportMessage{
+		Report:      "weekly_stats",
+		Subject:     "Weekly catalog stats",
+		Body:        "2 books added",
+		Data:        map[string]int{"added": 2},
+		GeneratedAt: time.Date(2024, time.January, 15, 8, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "weekly_stats", received["report"])
+	assert.Equal(t, "2 books added", received["summary"])
+	assert.Equal(t, "2024-01-15T08:00:00Z", received["generated_at"])
+	assert.Equal(t, map[string]interface{}{"added": float64(2)}, received["data"])
+}
+
+func TestReportSender_RejectsDisabledEmail(t *testing.T) {
+	err := NewReportSender(nil).SendReport(context.Background(), ChannelEmail, "admin@example.com", usecase.ReportMessage{})
+	assert.EqualError(t, err, "email delivery is disabled")
+}
@@ -0,0 +1,34 @@
+package notifier
+
+import (
+	"context"
+	"net/http"
+)
+
+// SlackNotifier delivers messages through a Slack incoming webhook
+type SlackNotifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+// NewSlackNotifier creates a new Slack notifier
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{
+		webhookURL: webhookURL,
+		client:     newHTTPClient(),
+	}
+}
+
+// Name returns the channel name
+func (n *SlackNotifier) Name() string {
+	return ChannelSlack
+}
+
+// Send posts the message to the Slack channel bound to the webhook
+func (n *SlackNotifier) Send(ctx context.Context, message Message) error {
+	text := "*" + message.Subject + "*"
+	if message.Body != "" {
+		text += "\n" + message.Body
+	}
+	return postJSON(ctx, n.client, n.webhookURL, map[string]string{"text": text})
+}
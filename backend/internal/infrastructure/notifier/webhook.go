@@ -0,0 +1,84 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"library-management-system/internal/infrastructure/tracing"
+)
+
+// WebhookNotifier delivers messages as JSON POST requests to a configured URL
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a new webhook notifier
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: newHTTPClient(),
+	}
+}
+
+// Name returns the channel name
+func (n *WebhookNotifier) Name() string {
+	return ChannelWebhook
+}
+
+// webhookPayload is the JSON body posted to webhook endpoints
+type webhookPayload struct {
+	Event       string    `json:"event"`
+	RecipientID string    `json:"recipient_id,omitempty"`
+	BookID      string    `json:"book_id,omitempty"`
+	Subject     string    `json:"subject"`
+	Body        string    `json:"body,omitempty"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// Send posts the message to the webhook URL
+func (n *WebhookNotifier) Send(ctx context.Context, message Message) error {
+	return postJSON(ctx, n.client, n.url, webhookPayload{
+		Event:       message.EventType,
+		RecipientID: message.RecipientID,
+		BookID:      message.BookID,
+		Subject:     message.Subject,
+		Body:        message.Body,
+		OccurredAt:  message.OccurredAt,
+	})
+}
+
+// newHTTPClient creates the client of outgoing notifications, which carries the trace of the
+// request or job sending them
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: 10 * time.Second, Transport: &tracing.Transport{}}
+}
+
+// postJSON posts a JSON document and treats any non-2xx status as a failure
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Package openlibrary looks up the metadata of books by ISBN with the Books API of Open Library
+// (https://openlibrary.org/dev/docs/api/books), to fill in what the catalog is missing.
+package openlibrary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/infrastructure/tracing"
+)
+
+// Client queries an Open Library instance
+type Client struct {
+	baseURL  string
+	coverURL string
+	client   *http.Client
+}
+
+// NewClient creates a client for the Books API at baseURL, e.g. https://openlibrary.org, linking
+// to the covers served at coverURL, e.g. https://covers.openlibrary.org
+func NewClient(baseURL, coverURL string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		coverURL: strings.TrimRight(coverURL, "/"),
+		client:   &http.Client{Timeout: timeout, Transport: &tracing.Transport{}},
+	}
+}
+
+// details is the part of a Books API record the catalog uses
+type details struct {
+	// Description is either a string or a {"type": "/type/text", "value": "..."} object
+	Description json.RawMessage `json:"description"`
+	Publishers  []string        `json:"publishers"`
+	Covers      []int64         `json:"covers"`
+}
+
+// Lookup returns what Open Library knows of the book with an ISBN, nil when it does not know it
+func (c *Client) Lookup(ctx context.Context, isbn string) (*entities.BookMetadata, error) {
+	key := "ISBN:" + isbn
+	query := url.Values{}
+	query.Set("bibkeys", key)
+	query.Set("format", "json")
+	query.Set("jscmd", "details")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/books?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from Open Library", resp.StatusCode)
+	}
+
+	var records map[string]struct {
+		Details details `json:"details"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&records); err != nil {
+		return nil, fmt.Errorf("invalid Open Library response: %w", err)
+	}
+	record, ok := records[key]
+	if !ok {
+		return nil, nil
+	}
+
+	metadata := &entities.BookMetadata{Description: description(record.Details.Description)}
+	if len(record.Details.Publishers) > 0 {
+		metadata.Publisher = strings.TrimSpace(record.Details.Publishers[0])
+	}
+	for _, cover := range record.Details.Covers {
+		// Open Library marks missing covers with -1
+		if cover > 0 {
+			metadata.CoverURL = fmt.Sprintf("%s/b/id/%d-L.jpg", c.coverURL, cover)
+			break
+		}
+	}
+	return metadata, nil
+}
+
+// description reads a description given as a string or a text object
+func description(raw json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return strings.TrimSpace(text)
+	}
+	var typed struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(raw, &typed); err == nil {
+		return strings.TrimSpace(typed.Value)
+	}
+	return ""
+}
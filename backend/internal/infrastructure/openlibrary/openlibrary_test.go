@@ -0,0 +1,61 @@
+package openlibrary
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Lookup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/books", r.URL.Path)
+		assert.Equal(t, "details", r.URL.Query().Get("jscmd"))
+		switch r.URL.Query().Get("bibkeys") {
+		case "ISBN:9780743273565":
+			w.Write([]byte(`{"ISBN:9780743273565": {"details": {
+				"description": {"type": "/type/text", "value": " A portrait of the Jazz Age. "},
+				"publishers": ["Scribner", "Simon & Schuster"],
+				"covers": [-1, 8432047]
+			}}}`))
+		case "ISBN:9780141439518":
+			w.Write([]byte(`{"ISBN:9780141439518": {"details": {"description": "A novel of manners."}}}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+	client := NewClient(server.URL+"/", "https://covers.example.org", time.Second)
+
+	metadata, err := client.Lookup(context.Background(), "9780743273565")
+	require.NoError(t, err)
+	assert.Equal(t, &entities.BookMetadata{
+		Description: "A portrait of the Jazz Age.",
+		Publisher:   "Scribner",
+		CoverURL:    "https://covers.example.org/b/id/8432047-L.jpg",
+	}, metadata)
+
+	metadata, err = client.Lookup(context.Background(), "9780141439518")
+	require.NoError(t, err)
+	assert.Equal(t, &entities.BookMetadata{Description: "A novel of manners."}, metadata)
+
+	metadata, err = client.Lookup(context.Background(), "9999999999")
+	require.NoError(t, err)
+	assert.Nil(t, metadata)
+}
+
+func TestClient_LookupFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL, server.URL, time.Second).Lookup(context.Background(), "9780743273565")
+	assert.EqualError(t, err, "unexpected status code 429 from Open Library")
+}
@@ -0,0 +1,302 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"library-management-system/internal/domain/document"
+)
+
+// PageSize is a page format in PDF points (1/72 inch)
+type PageSize struct {
+	Name   string
+	Width  float64
+	Height float64
+}
+
+// Supported page sizes; the label sizes fit common thermal label printers
+var (
+	A4       = PageSize{Name: "a4", Width: 595, Height: 842}
+	Letter   = PageSize{Name: "letter", Width: 612, Height: 792}
+	Label4x6 = PageSize{Name: "label-4x6", Width: 288, Height: 432}
+	Label2x4 = PageSize{Name: "label-2x4", Width: 144, Height: 288}
+)
+
+var pageSizes = []PageSize{A4, Letter, Label4x6, Label2x4}
+
+// PageSizeByName looks a page size up by its name, e.g. "a4" or "label-4x6"
+func PageSizeByName(name string) (PageSize, bool) {
+	for _, size := range pageSizes {
+		if strings.EqualFold(size.Name, strings.TrimSpace(name)) {
+			return size, true
+		}
+	}
+	return PageSize{}, false
+}
+
+// Renderer writes documents as PDF using the built-in Courier fonts, so no font has to be embedded
+// and columns line up on any page width. Tables that are too wide for the page, as on labels,
+// are printed as one "column: value" block per row instead.
+type Renderer struct {
+	size     PageSize
+	fontSize float64
+	margin   float64
+}
+
+// NewRenderer creates a PDF renderer for a page size
+func NewRenderer(size PageSize) *Renderer {
+	fontSize := 9.0
+	if size.Width < 300 {
+		fontSize = 7
+	}
+	margin := size.Width * 0.06
+	if margin > 36 {
+		margin = 36
+	}
+	return &Renderer{size: size, fontSize: fontSize, margin: margin}
+}
+
+// ContentType returns the MIME type of PDF files
+func (r *Renderer) ContentType() string {
+	return "application/pdf"
+}
+
+// Extension returns the PDF file extension
+func (r *Renderer) Extension() string {
+	return "pdf"
+}
+
+// line is a line of text on a page
+type line struct {
+	text string
+	bold bool
+}
+
+// Render lays the document out on pages and writes the PDF
+func (r *Renderer) Render(w io.Writer, doc document.Document) error {
+	return r.write(w, r.paginate(r.layout(doc)))
+}
+
+// columns is the number of Courier characters that fit on a line
+func (r *Renderer) columns() int {
+	// Courier glyphs are 600/1000 em wide
+	return int((r.size.Width - 2*r.margin) / (r.fontSize * 0.6))
+}
+
+// leading is the distance between two baselines
+func (r *Renderer) leading() float64 {
+	return r.fontSize * 1.25
+}
+
+// layout turns the document into wrapped lines
+func (r *Renderer) layout(doc document.Document) []line {
+	width := r.columns()
+	var lines []line
+	add := func(text string, bold bool) {
+		for _, wrapped := range wrap(text, width) {
+			lines = append(lines, line{text: wrapped, bold: bold})
+		}
+	}
+
+	add(doc.Title, true)
+	if doc.Subtitle != "" {
+		add(doc.Subtitle, false)
+	}
+
+	for _, section := range doc.Sections {
+		lines = append(lines, line{})
+		if section.Heading != "" {
+			add(section.Heading, true)
+		}
+		for _, text := range section.Lines {
+			add(text, false)
+		}
+		if len(section.Rows) == 0 {
+			continue
+		}
+
+		if table, ok := tableLines(section.Columns, section.Rows, width); ok {
+			for i, text := range table {
+				lines = append(lines, line{text: text, bold: i == 0})
+			}
+			continue
+		}
+		for i, row := range section.Rows {
+			if i > 0 {
+				lines = append(lines, line{})
+			}
+			for j, value := range row {
+				label := ""
+				if j < len(section.Columns) {
+					label = section.Columns[j] + ": "
+				}
+				add(label+value, false)
+			}
+		}
+	}
+	return lines
+}
+
+// paginate splits lines into pages
+func (r *Renderer) paginate(lines []line) [][]line {
+	perPage := int((r.size.Height - 2*r.margin) / r.leading())
+	if perPage < 1 {
+		perPage = 1
+	}
+
+	var pages [][]line
+	for len(lines) > perPage {
+		pages = append(pages, lines[:perPage])
+		lines = lines[perPage:]
+	}
+	return append(pages, lines)
+}
+
+// write serializes the pages as a PDF file
+func (r *Renderer) write(w io.Writer, pages [][]line) error {
+	// Objects 1-4 are the catalog, the page tree and the two fonts; each page adds a page and a content stream
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"", // page tree, filled in once the page objects are numbered
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>",
+	}
+
+	kids := make([]string, len(pages))
+	for i, page := range pages {
+		pageNumber := len(objects) + 1
+		kids[i] = fmt.Sprintf("%d 0 R", pageNumber)
+
+		content := r.content(page)
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+				number(r.size.Width), number(r.size.Height), pageNumber+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// content builds the content stream of a page
+func (r *Renderer) content(page []line) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "BT\n%s TL\n%s %s Td\n", number(r.leading()), number(r.margin), number(r.size.Height-r.margin-r.fontSize))
+	for _, l := range page {
+		font := "F1"
+		if l.bold {
+			font = "F2"
+		}
+		fmt.Fprintf(&b, "/%s %s Tf\n(%s) Tj T*\n", font, number(r.fontSize), escape(l.text))
+	}
+	b.WriteString("ET")
+	return b.String()
+}
+
+// tableLines aligns rows under their columns, or reports false when the table is wider than the page
+func tableLines(columns []string, rows [][]string, width int) ([]string, bool) {
+	widths := make([]int, len(columns))
+	for i, column := range columns {
+		widths[i] = len([]rune(column))
+	}
+	for _, row := range rows {
+		for i, value := range row {
+			if i < len(widths) && len([]rune(value)) > widths[i] {
+				widths[i] = len([]rune(value))
+			}
+		}
+	}
+
+	total := 0
+	for _, w := range widths {
+		total += w + 2
+	}
+	if len(columns) == 0 || total-2 > width {
+		return nil, false
+	}
+
+	format := func(values []string) string {
+		cells := make([]string, len(widths))
+		for i := range widths {
+			value := ""
+			if i < len(values) {
+				value = values[i]
+			}
+			cells[i] = value + strings.Repeat(" ", widths[i]-len([]rune(value)))
+		}
+		return strings.TrimRight(strings.Join(cells, "  "), " ")
+	}
+
+	lines := []string{format(columns)}
+	for _, row := range rows {
+		lines = append(lines, format(row))
+	}
+	return lines, true
+}
+
+// wrap breaks text into lines of at most width characters, preferring spaces
+func wrap(text string, width int) []string {
+	runes := []rune(text)
+	if len(runes) <= width || width < 1 {
+		return []string{text}
+	}
+
+	var lines []string
+	for len(runes) > width {
+		cut := width
+		for i := width; i > width/2; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		lines = append(lines, strings.TrimRight(string(runes[:cut]), " "))
+		runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
+	}
+	return append(lines, string(runes))
+}
+
+// escape encodes text as the body of a PDF string in WinAnsi; characters outside Latin-1 become '?'
+func escape(text string) string {
+	var b strings.Builder
+	for _, c := range text {
+		switch {
+		case c == '\\' || c == '(' || c == ')':
+			b.WriteByte('\\')
+			b.WriteRune(c)
+		case c < 0x20:
+			b.WriteByte(' ')
+		case c < 0x80:
+			b.WriteRune(c)
+		case c >= 0xA0 && c <= 0xFF:
+			fmt.Fprintf(&b, "\\%03o", c)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// number formats a coordinate without trailing zeros
+func number(f float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", f), "0"), ".")
+}
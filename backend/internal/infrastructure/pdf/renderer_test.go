@@ -0,0 +1,95 @@
+package pdf
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"library-management-system/internal/domain/document"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func render(t *testing.T, size PageSize, doc document.Document) string {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, NewRenderer(size).Render(&buf, doc))
+	return buf.String()
+}
+
+func TestRenderer_WritesValidStructure(t *testing.T) {
+	out := render(t, A4, document.Document{
+		Title: "Weeding report",
+		Sections: []document.Section{{
+			Columns: []string{"Title", "ISBN"},
+			Rows:    [][]string{{"Moby-Dick (Penguin)", "9780142437247"}},
+		}},
+	})
+
+	assert.True(t, strings.HasPrefix(out, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(out, "%%EOF\n"))
+	assert.Contains(t, out, "/MediaBox [0 0 595 842]")
+	assert.Contains(t, out, `(Moby-Dick \(Penguin\)  9780142437247) Tj`)
+
+	// Every xref entry must point at the start of its object
+	startxref := regexp.MustCompile(`startxref\n(\d+)`).FindStringSubmatch(out)
+	require.Len(t, startxref, 2)
+	offset, err := strconv.Atoi(startxref[1])
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(out[offset:], "xref\n"))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllStringSubmatch(out[offset:], -1)
+	require.NotEmpty(t, entries)
+	for i, entry := range entries {
+		objectOffset, err := strconv.Atoi(entry[1])
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(out[objectOffset:], strconv.Itoa(i+1)+" 0 obj"), "object %d", i+1)
+	}
+}
+
+func TestRenderer_StacksWideTablesOnLabels(t *testing.T) {
+	out := render(t, Label2x4, document.Document{
+		Title: "Inventory report",
+		Sections: []document.Section{{
+			Columns: []string{"Title", "Barcode", "Found at"},
+			Rows:    [][]string{{"The Colour of Magic", "9780062225689", "Returns cart"}},
+		}},
+	})
+
+	assert.Contains(t, out, "(Title: The Colour of Magic) Tj")
+	assert.Contains(t, out, "(Found at: Returns cart) Tj")
+}
+
+func TestRenderer_AddsPages(t *testing.T) {
+	rows := make([][]string, 200)
+	for i := range rows {
+		rows[i] = []string{strconv.Itoa(i)}
+	}
+
+	out := render(t, Label4x6, document.Document{Title: "Long", Sections: []document.Section{{Columns: []string{"Row"}, Rows: rows}}})
+
+	assert.Contains(t, out, "/Count 5")
+	assert.Contains(t, out, "(199) Tj")
+}
+
+func TestWrap(t *testing.T) {
+	assert.Equal(t, []string{"short"}, wrap("short", 10))
+	assert.Equal(t, []string{"the quick", "brown fox"}, wrap("the quick brown fox", 10))
+	assert.Equal(t, []string{"9780062225", "689"}, wrap("9780062225689", 10))
+}
+
+func TestEscape(t *testing.T) {
+	assert.Equal(t, `a\\b \(c\) caf\351 ?`, escape("a\\b (c) café 漢"))
+}
+
+func TestPageSizeByName(t *testing.T) {
+	size, ok := PageSizeByName(" Label-4x6 ")
+	assert.True(t, ok)
+	assert.Equal(t, Label4x6, size)
+
+	_, ok = PageSizeByName("a5")
+	assert.False(t, ok)
+}
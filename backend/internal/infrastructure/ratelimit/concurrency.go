@@ -0,0 +1,116 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ConcurrencyLimiter bounds how many operations a key, such as a user, may run at once. Operations
+// over the limit wait in a short per-key queue for a slot to free up; when the queue is full or the
+// wait runs out, they are turned away.
+type ConcurrencyLimiter struct {
+	limit int
+	queue int
+	wait  time.Duration
+
+	mu    sync.Mutex
+	lanes map[string]*lane
+}
+
+// lane holds the slots of a key and counts the operations waiting for one
+type lane struct {
+	slots   chan struct{}
+	waiting int
+}
+
+// NewConcurrencyLimiter creates a limiter running limit operations per key at once, with up to queue
+// more waiting at most wait for a slot
+func NewConcurrencyLimiter(limit, queue int, wait time.Duration) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{
+		limit: limit,
+		queue: queue,
+		wait:  wait,
+		lanes: make(map[string]*lane),
+	}
+}
+
+// Limit returns the number of operations a key may run at once
+func (l *ConcurrencyLimiter) Limit() int {
+	return l.limit
+}
+
+// Load returns how many operations of a key are running and how many wait for a slot
+func (l *ConcurrencyLimiter) Load(key string) (running, waiting int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ln, ok := l.lanes[key]
+	if !ok {
+		return 0, 0
+	}
+	return len(ln.slots), ln.waiting
+}
+
+// Acquire takes a slot for an operation of a key, waiting for one if the key is at its limit. It
+// returns the function releasing the slot, or false when the operation was turned away because the
+// queue was full, the wait ran out or ctx ended.
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context, key string) (func(), bool) {
+	l.mu.Lock()
+	ln, ok := l.lanes[key]
+	if !ok {
+		ln = &lane{slots: make(chan struct{}, l.limit)}
+		l.lanes[key] = ln
+	}
+	select {
+	case ln.slots <- struct{}{}:
+		l.mu.Unlock()
+		return l.releaser(key, ln), true
+	default:
+	}
+	if ln.waiting >= l.queue {
+		l.mu.Unlock()
+		return nil, false
+	}
+	ln.waiting++
+	l.mu.Unlock()
+
+	timer := time.NewTimer(l.wait)
+	defer timer.Stop()
+
+	acquired := false
+	select {
+	case ln.slots <- struct{}{}:
+		acquired = true
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	l.mu.Lock()
+	ln.waiting--
+	l.mu.Unlock()
+	if !acquired {
+		l.forget(key, ln)
+		return nil, false
+	}
+	return l.releaser(key, ln), true
+}
+
+// releaser returns the function giving a slot of a lane back, once
+func (l *ConcurrencyLimiter) releaser(key string, ln *lane) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-ln.slots
+			l.forget(key, ln)
+		})
+	}
+}
+
+// forget drops the lane of a key once nothing runs or waits in it
+func (l *ConcurrencyLimiter) forget(key string, ln *lane) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(ln.slots) == 0 && ln.waiting == 0 && l.lanes[key] == ln {
+		delete(l.lanes, key)
+	}
+}
@@ -0,0 +1,189 @@
+package ratelimit
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+)
+
+// Ban is a key turned away after failing too often
+type Ban struct {
+	Key string `json:"key"`
+	// Strikes is the number of times the key was banned in a row, which sets the length of its ban
+	Strikes int       `json:"strikes"`
+	Until   time.Time `json:"until"`
+}
+
+// LockoutPolicy sets when keys are banned and for how long
+type LockoutPolicy struct {
+	// MaxFailures failures within Window ban a key
+	MaxFailures int
+	Window      time.Duration
+	// BanDuration is the length of a first ban, doubled with each strike up to MaxBanDuration.
+	// Strikes are forgotten once a key goes MaxBanDuration without being banned again.
+	BanDuration    time.Duration
+	MaxBanDuration time.Duration
+}
+
+// banFor returns the length of the ban of a strike
+func (p LockoutPolicy) banFor(strikes int) time.Duration {
+	ban := p.BanDuration
+	for i := 1; i < strikes && ban < p.MaxBanDuration; i++ {
+		ban *= 2
+	}
+	if ban > p.MaxBanDuration {
+		ban = p.MaxBanDuration
+	}
+	return ban
+}
+
+// lockoutEntry counts the failures of a key and remembers its bans
+type lockoutEntry struct {
+	failures    int
+	windowStart time.Time
+	strikes     int
+	bannedUntil time.Time
+	// forgetAt is when the strikes of the key are forgotten
+	forgetAt time.Time
+}
+
+// Lockout bans keys, such as client IPs and accounts, that fail too often, for longer each time
+// they are banned again. Keys are tracked in memory, per instance.
+type Lockout struct {
+	policy LockoutPolicy
+	clock  clock.Clock
+
+	mu        sync.Mutex
+	entries   map[string]*lockoutEntry
+	lastSweep time.Time
+}
+
+// NewLockout creates a lockout with a policy
+func NewLockout(policy LockoutPolicy) *Lockout {
+	return &Lockout{
+		policy:  policy,
+		clock:   clock.System{},
+		entries: make(map[string]*lockoutEntry),
+	}
+}
+
+// SetClock replaces the clock failures and bans are timed with
+func (l *Lockout) SetClock(c clock.Clock) {
+	l.clock = c
+}
+
+// Policy returns when keys are banned and for how long
+func (l *Lockout) Policy() LockoutPolicy {
+	return l.policy
+}
+
+// Banned returns the ban of a key, if it is banned
+func (l *Lockout) Banned(key string) (Ban, bool) {
+	now := l.clock.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e, ok := l.entries[key]
+	if !ok || !now.Before(e.bannedUntil) {
+		return Ban{}, false
+	}
+	return Ban{Key: key, Strikes: e.strikes, Until: e.bannedUntil}, true
+}
+
+// Fail counts a failure of a key and returns its ban, if the key is banned now
+func (l *Lockout) Fail(key string) (Ban, bool) {
+	now := l.clock.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+
+	e, ok := l.entries[key]
+	if !ok {
+		e = &lockoutEntry{}
+		l.entries[key] = e
+	}
+	if now.Before(e.bannedUntil) {
+		return Ban{Key: key, Strikes: e.strikes, Until: e.bannedUntil}, true
+	}
+	if !now.Before(e.windowStart.Add(l.policy.Window)) {
+		e.failures, e.windowStart = 0, now
+	}
+	e.failures++
+	if e.failures < l.policy.MaxFailures {
+		return Ban{}, false
+	}
+
+	if !now.Before(e.forgetAt) {
+		e.strikes = 0
+	}
+	e.strikes++
+	e.failures = 0
+	e.bannedUntil = now.Add(l.policy.banFor(e.strikes))
+	e.forgetAt = e.bannedUntil.Add(l.policy.MaxBanDuration)
+	return Ban{Key: key, Strikes: e.strikes, Until: e.bannedUntil}, true
+}
+
+// Succeed forgets the failures of a key, but not its strikes
+func (l *Lockout) Succeed(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.entries[key]; ok {
+		e.failures = 0
+	}
+}
+
+// Bans returns the keys banned now, the longest banned first
+func (l *Lockout) Bans() []Ban {
+	now := l.clock.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	bans := []Ban{}
+	for key, e := range l.entries {
+		if now.Before(e.bannedUntil) {
+			bans = append(bans, Ban{Key: key, Strikes: e.strikes, Until: e.bannedUntil})
+		}
+	}
+	sortBans(bans)
+	return bans
+}
+
+// Unban lifts the ban of a key and forgets its failures and strikes, reporting whether it was banned
+func (l *Lockout) Unban(key string) bool {
+	now := l.clock.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e, ok := l.entries[key]
+	if !ok {
+		return false
+	}
+	delete(l.entries, key)
+	return now.Before(e.bannedUntil)
+}
+
+// sweep forgets the keys with no failures in their window and no strikes left, at most once per window
+func (l *Lockout) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.policy.Window {
+		return
+	}
+	l.lastSweep = now
+	for key, e := range l.entries {
+		if !now.Before(e.windowStart.Add(l.policy.Window)) && !now.Before(e.forgetAt) {
+			delete(l.entries, key)
+		}
+	}
+}
+
+// sortBans orders bans by when they end, the last first, then by key
+func sortBans(bans []Ban) {
+	sort.Slice(bans, func(i, j int) bool {
+		if !bans[i].Until.Equal(bans[j].Until) {
+			return bans[i].Until.After(bans[j].Until)
+		}
+		return bans[i].Key < bans[j].Key
+	})
+}
@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testLockoutPolicy = LockoutPolicy{MaxFailures: 3, Window: 10 * time.Minute, BanDuration: time.Minute, MaxBanDuration: 3 * time.Minute}
+
+func TestLockout_BansAfterMaxFailures(t *testing.T) {
+	now := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+	fixed := clock.NewFixed(now)
+	lockout := NewLockout(testLockoutPolicy)
+	lockout.SetClock(fixed)
+
+	_, banned := lockout.Fail("ip:203.0.113.7")
+	assert.False(t, banned)
+	lockout.Fail("ip:203.0.113.7")
+	ban, banned := lockout.Fail("ip:203.0.113.7")
+	assert.True(t, banned)
+	assert.Equal(t, Ban{Key: "ip:203.0.113.7", Strikes: 1, Until: now.Add(time.Minute)}, ban)
+
+	_, banned = lockout.Banned("ip:203.0.113.7")
+	assert.True(t, banned)
+	_, banned = lockout.Banned("ip:198.51.100.1")
+	assert.False(t, banned, "keys are banned separately")
+	assert.Equal(t, []Ban{ban}, lockout.Bans())
+
+	fixed.Advance(time.Minute)
+	_, banned = lockout.Banned("ip:203.0.113.7")
+	assert.False(t, banned, "bans end")
+	assert.Empty(t, lockout.Bans())
+}
+
+func TestLockout_EscalatesBans(t *testing.T) {
+	fixed := clock.NewFixed(time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC))
+	lockout := NewLockout(testLockoutPolicy)
+	lockout.SetClock(fixed)
+
+	banFor := func() time.Duration {
+		var ban Ban
+		for i := 0; i < testLockoutPolicy.MaxFailures; i++ {
+			ban, _ = lockout.Fail("account:ada@example.com")
+		}
+		length := ban.Until.Sub(fixed.Now())
+		fixed.Set(ban.Until)
+		return length
+	}
+	assert.Equal(t, time.Minute, banFor())
+	assert.Equal(t, 2*time.Minute, banFor())
+	assert.Equal(t, 3*time.Minute, banFor(), "bans stop growing at the maximum")
+
+	// Strikes are forgotten after going the maximum ban length without a ban
+	fixed.Advance(3 * time.Minute)
+	assert.Equal(t, time.Minute, banFor())
+}
+
+func TestLockout_SucceedAndUnban(t *testing.T) {
+	fixed := clock.NewFixed(time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC))
+	lockout := NewLockout(testLockoutPolicy)
+	lockout.SetClock(fixed)
+
+	lockout.Fail("ip:203.0.113.7")
+	lockout.Fail("ip:203.0.113.7")
+	lockout.Succeed("ip:203.0.113.7")
+	_, banned := lockout.Fail("ip:203.0.113.7")
+	assert.False(t, banned, "a success resets the failures")
+
+	lockout.Fail("ip:203.0.113.7")
+	lockout.Fail("ip:203.0.113.7")
+	assert.True(t, lockout.Unban("ip:203.0.113.7"))
+	_, banned = lockout.Banned("ip:203.0.113.7")
+	assert.False(t, banned)
+	assert.False(t, lockout.Unban("ip:203.0.113.7"), "only banned keys are unbanned")
+}
@@ -0,0 +1,99 @@
+// Package ratelimit limits how often, and how many times at once, a key such as a client IP may do something
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+)
+
+// Limiter allows a number of events per key in fixed time windows
+type Limiter struct {
+	limit  int
+	window time.Duration
+	clock  clock.Clock
+
+	mu        sync.Mutex
+	windows   map[string]*window
+	lastSweep time.Time
+}
+
+// window counts the events of a key since start
+type window struct {
+	start time.Time
+	count int
+}
+
+// Decision is the outcome of an event
+type Decision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	ResetsAt  time.Time
+}
+
+// NewLimiter creates a limiter allowing limit events per key in each window
+func NewLimiter(limit int, length time.Duration) *Limiter {
+	return &Limiter{
+		limit:   limit,
+		window:  length,
+		clock:   clock.System{},
+		windows: make(map[string]*window),
+	}
+}
+
+// SetClock replaces the clock that windows are measured with
+func (l *Limiter) SetClock(c clock.Clock) {
+	l.clock = c
+}
+
+// Limit returns the number of events allowed per window
+func (l *Limiter) Limit() int {
+	return l.limit
+}
+
+// Window returns the length of a window
+func (l *Limiter) Window() time.Duration {
+	return l.window
+}
+
+// Allow counts an event of a key and decides whether it is within the limit
+func (l *Limiter) Allow(key string) Decision {
+	now := l.clock.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+
+	w, ok := l.windows[key]
+	if !ok || !now.Before(w.start.Add(l.window)) {
+		w = &window{start: now}
+		l.windows[key] = w
+	}
+	w.count++
+
+	remaining := l.limit - w.count
+	if remaining < 0 {
+		remaining = 0
+	}
+	return Decision{
+		Allowed:   w.count <= l.limit,
+		Limit:     l.limit,
+		Remaining: remaining,
+		ResetsAt:  w.start.Add(l.window),
+	}
+}
+
+// sweep forgets the keys whose window ended, at most once per window
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+	l.lastSweep = now
+	for key, w := range l.windows {
+		if !now.Before(w.start.Add(l.window)) {
+			delete(l.windows, key)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_AllowsLimitPerWindow(t *testing.T) {
+	now := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+	fixed := clock.NewFixed(now)
+	limiter := NewLimiter(2, time.Minute)
+	limiter.SetClock(fixed)
+
+	first := limiter.Allow("203.0.113.7")
+	assert.True(t, first.Allowed)
+	assert.Equal(t, 1, first.Remaining)
+	assert.Equal(t, now.Add(time.Minute), first.ResetsAt)
+	assert.True(t, limiter.Allow("203.0.113.7").Allowed)
+
+	third := limiter.Allow("203.0.113.7")
+	assert.False(t, third.Allowed)
+	assert.Equal(t, 0, third.Remaining)
+	assert.True(t, limiter.Allow("198.51.100.1").Allowed, "keys are limited separately")
+
+	fixed.Advance(time.Minute)
+	assert.True(t, limiter.Allow("203.0.113.7").Allowed, "a new window starts once the last one ended")
+}
+
+func TestLimiter_ForgetsEndedWindows(t *testing.T) {
+	fixed := clock.NewFixed(time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC))
+	limiter := NewLimiter(1, time.Minute)
+	limiter.SetClock(fixed)
+
+	limiter.Allow("203.0.113.7")
+	fixed.Advance(2 * time.Minute)
+	limiter.Allow("198.51.100.1")
+	assert.Len(t, limiter.windows, 1)
+}
+
+func TestConcurrencyLimiter_QueuesOverTheLimit(t *testing.T) {
+	limiter := NewConcurrencyLimiter(1, 1, time.Second)
+
+	release, ok := limiter.Acquire(context.Background(), "user:analyst")
+	assert.True(t, ok)
+	other, ok := limiter.Acquire(context.Background(), "user:librarian")
+	assert.True(t, ok, "keys are limited separately")
+	other()
+
+	queued := make(chan bool)
+	go func() {
+		queuedRelease, ok := limiter.Acquire(context.Background(), "user:analyst")
+		if ok {
+			queuedRelease()
+		}
+		queued <- ok
+	}()
+	assert.Eventually(t, func() bool {
+		limiter.mu.Lock()
+		defer limiter.mu.Unlock()
+		return limiter.lanes["user:analyst"].waiting == 1
+	}, time.Second, time.Millisecond)
+
+	_, ok = limiter.Acquire(context.Background(), "user:analyst")
+	assert.False(t, ok, "the queue is full")
+	running, waiting := limiter.Load("user:analyst")
+	assert.Equal(t, 1, running)
+	assert.Equal(t, 1, waiting)
+
+	release()
+	assert.True(t, <-queued, "the queued operation runs once a slot frees up")
+	limiter.mu.Lock()
+	assert.Empty(t, limiter.lanes, "idle keys are forgotten")
+	limiter.mu.Unlock()
+}
+
+func TestConcurrencyLimiter_GivesUpAfterTheWait(t *testing.T) {
+	limiter := NewConcurrencyLimiter(1, 1, 10*time.Millisecond)
+
+	release, ok := limiter.Acquire(context.Background(), "user:analyst")
+	assert.True(t, ok)
+	defer release()
+
+	_, ok = limiter.Acquire(context.Background(), "user:analyst")
+	assert.False(t, ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	limiter.wait = time.Minute
+	_, ok = limiter.Acquire(ctx, "user:analyst")
+	assert.False(t, ok, "a cancelled request stops waiting")
+}
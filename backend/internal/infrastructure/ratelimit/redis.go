@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/infrastructure/redis"
+)
+
+// countInWindow counts an event in the window of a key, started by its first event, and returns
+// the count along with the milliseconds left in the window
+const countInWindow = `local count = redis.call('INCR', KEYS[1])
+if count == 1 then redis.call('PEXPIRE', KEYS[1], ARGV[1]) end
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl < 0 then
+  redis.call('PEXPIRE', KEYS[1], ARGV[1])
+  ttl = tonumber(ARGV[1])
+end
+return {count, ttl}`
+
+// RedisLimiter allows a number of events per key in fixed time windows counted in Redis, so the
+// limit holds across every instance sharing it
+type RedisLimiter struct {
+	client *redis.Client
+	prefix string
+	limit  int
+	window time.Duration
+	clock  clock.Clock
+}
+
+// NewRedisLimiter creates a limiter allowing limit events per key in each window, counted under
+// keys starting with prefix
+func NewRedisLimiter(client *redis.Client, prefix string, limit int, length time.Duration) *RedisLimiter {
+	return &RedisLimiter{
+		client: client,
+		prefix: prefix,
+		limit:  limit,
+		window: length,
+		clock:  clock.System{},
+	}
+}
+
+// SetClock replaces the clock the end of windows is computed with
+func (l *RedisLimiter) SetClock(c clock.Clock) {
+	l.clock = c
+}
+
+// Limit returns the number of events allowed per window
+func (l *RedisLimiter) Limit() int {
+	return l.limit
+}
+
+// Window returns the length of a window
+func (l *RedisLimiter) Window() time.Duration {
+	return l.window
+}
+
+// Allow counts an event of a key and decides whether it is within the limit. While Redis is
+// unreachable every event is allowed, so an outage of the limiter does not become one of the service.
+func (l *RedisLimiter) Allow(key string) Decision {
+	now := l.clock.Now()
+	count, ttl, err := l.count(key)
+	if err != nil {
+		log.Printf("Failed to count %s in Redis, allowing it: %v", key, err)
+		return Decision{Allowed: true, Limit: l.limit, Remaining: l.limit, ResetsAt: now.Add(l.window)}
+	}
+
+	remaining := l.limit - count
+	if remaining < 0 {
+		remaining = 0
+	}
+	return Decision{
+		Allowed:   count <= l.limit,
+		Limit:     l.limit,
+		Remaining: remaining,
+		ResetsAt:  now.Add(ttl),
+	}
+}
+
+// count counts an event of a key and returns the count and time left in its window
+func (l *RedisLimiter) count(key string) (int, time.Duration, error) {
+	reply, err := l.client.Eval(countInWindow, []string{l.prefix + key}, strconv.FormatInt(l.window.Milliseconds(), 10))
+	if err != nil {
+		return 0, 0, err
+	}
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rate limit reply %v", reply)
+	}
+	count, countOK := values[0].(int64)
+	ttl, ttlOK := values[1].(int64)
+	if !countOK || !ttlOK {
+		return 0, 0, fmt.Errorf("unexpected rate limit reply %v", reply)
+	}
+	return int(count), time.Duration(ttl) * time.Millisecond, nil
+}
@@ -0,0 +1,193 @@
+package ratelimit
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/infrastructure/redis"
+)
+
+// failInLockout counts a failure of a key, kept in a hash, and bans it once it reaches the
+// maximum, adding it to the sorted set of bans. It returns the strikes of the key and the end of
+// its ban, in milliseconds, zero when it is not banned.
+const failInLockout = `local now = tonumber(ARGV[1])
+local maxFailures, window = tonumber(ARGV[2]), tonumber(ARGV[3])
+local banDuration, maxBan = tonumber(ARGV[4]), tonumber(ARGV[5])
+local state = redis.call('HMGET', KEYS[1], 'failures', 'window_start', 'strikes', 'banned_until', 'forget_at')
+local failures = tonumber(state[1]) or 0
+local windowStart = tonumber(state[2]) or 0
+local strikes = tonumber(state[3]) or 0
+local bannedUntil = tonumber(state[4]) or 0
+local forgetAt = tonumber(state[5]) or 0
+if now < bannedUntil then return {strikes, bannedUntil} end
+if now >= windowStart + window then
+  failures = 0
+  windowStart = now
+end
+failures = failures + 1
+local banned = 0
+if failures >= maxFailures then
+  if now >= forgetAt then strikes = 0 end
+  strikes = strikes + 1
+  local ban = banDuration
+  for i = 2, strikes do
+    if ban >= maxBan then break end
+    ban = ban * 2
+  end
+  if ban > maxBan then ban = maxBan end
+  failures = 0
+  bannedUntil = now + ban
+  forgetAt = bannedUntil + maxBan
+  banned = bannedUntil
+  redis.call('ZADD', KEYS[2], bannedUntil, ARGV[6])
+end
+redis.call('HSET', KEYS[1], 'failures', failures, 'window_start', windowStart, 'strikes', strikes, 'banned_until', bannedUntil, 'forget_at', forgetAt)
+redis.call('PEXPIREAT', KEYS[1], math.max(windowStart + window, forgetAt))
+return {strikes, banned}`
+
+// unbanInLockout forgets a key and removes it from the sorted set of bans, returning the end of
+// its ban in milliseconds, zero when it was not banned
+const unbanInLockout = `local bannedUntil = tonumber(redis.call('HGET', KEYS[1], 'banned_until')) or 0
+redis.call('DEL', KEYS[1])
+redis.call('ZREM', KEYS[2], ARGV[1])
+return bannedUntil`
+
+// RedisLockout bans keys that fail too often like Lockout, counting failures in Redis so that a
+// ban holds across every instance sharing it
+type RedisLockout struct {
+	client *redis.Client
+	prefix string
+	policy LockoutPolicy
+	clock  clock.Clock
+}
+
+// NewRedisLockout creates a lockout with a policy, keeping its state under keys starting with prefix
+func NewRedisLockout(client *redis.Client, prefix string, policy LockoutPolicy) *RedisLockout {
+	return &RedisLockout{
+		client: client,
+		prefix: prefix,
+		policy: policy,
+		clock:  clock.System{},
+	}
+}
+
+// SetClock replaces the clock failures and bans are timed with
+func (l *RedisLockout) SetClock(c clock.Clock) {
+	l.clock = c
+}
+
+// Policy returns when keys are banned and for how long
+func (l *RedisLockout) Policy() LockoutPolicy {
+	return l.policy
+}
+
+// Banned returns the ban of a key, if it is banned. While Redis is unreachable no key is banned,
+// so an outage of the lockout does not lock everyone out.
+func (l *RedisLockout) Banned(key string) (Ban, bool) {
+	reply, err := l.client.Do("HMGET", l.prefix+key, "strikes", "banned_until")
+	if err != nil {
+		log.Printf("Failed to read the ban of %s from Redis, allowing it: %v", key, err)
+		return Ban{}, false
+	}
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 2 {
+		return Ban{}, false
+	}
+	strikes, _ := strconv.Atoi(replyString(values[0]))
+	until, _ := strconv.ParseInt(replyString(values[1]), 10, 64)
+	if l.clock.Now().UnixMilli() >= until {
+		return Ban{}, false
+	}
+	return Ban{Key: key, Strikes: strikes, Until: time.UnixMilli(until).UTC()}, true
+}
+
+// Fail counts a failure of a key and returns its ban, if the key is banned now
+func (l *RedisLockout) Fail(key string) (Ban, bool) {
+	reply, err := l.client.Eval(failInLockout, []string{l.prefix + key, l.bansKey()},
+		strconv.FormatInt(l.clock.Now().UnixMilli(), 10),
+		strconv.Itoa(l.policy.MaxFailures),
+		strconv.FormatInt(l.policy.Window.Milliseconds(), 10),
+		strconv.FormatInt(l.policy.BanDuration.Milliseconds(), 10),
+		strconv.FormatInt(l.policy.MaxBanDuration.Milliseconds(), 10),
+		key)
+	if err == nil {
+		var strikes, until int64
+		if strikes, until, err = replyPair(reply); err == nil {
+			if until == 0 {
+				return Ban{}, false
+			}
+			return Ban{Key: key, Strikes: int(strikes), Until: time.UnixMilli(until).UTC()}, true
+		}
+	}
+	log.Printf("Failed to count a failure of %s in Redis: %v", key, err)
+	return Ban{}, false
+}
+
+// Succeed forgets the failures of a key, but not its strikes
+func (l *RedisLockout) Succeed(key string) {
+	if _, err := l.client.Do("HDEL", l.prefix+key, "failures", "window_start"); err != nil {
+		log.Printf("Failed to reset the failures of %s in Redis: %v", key, err)
+	}
+}
+
+// Bans returns the keys banned now, the longest banned first
+func (l *RedisLockout) Bans() []Ban {
+	now := strconv.FormatInt(l.clock.Now().UnixMilli(), 10)
+	bans := []Ban{}
+	if _, err := l.client.Do("ZREMRANGEBYSCORE", l.bansKey(), "-inf", now); err != nil {
+		log.Printf("Failed to list the bans in Redis: %v", err)
+		return bans
+	}
+	reply, err := l.client.Do("ZRANGE", l.bansKey(), "0", "-1")
+	if err != nil {
+		log.Printf("Failed to list the bans in Redis: %v", err)
+		return bans
+	}
+	keys, _ := reply.([]interface{})
+	for _, key := range keys {
+		if ban, ok := l.Banned(replyString(key)); ok {
+			bans = append(bans, ban)
+		}
+	}
+	sortBans(bans)
+	return bans
+}
+
+// Unban lifts the ban of a key and forgets its failures and strikes, reporting whether it was banned
+func (l *RedisLockout) Unban(key string) bool {
+	reply, err := l.client.Eval(unbanInLockout, []string{l.prefix + key, l.bansKey()}, key)
+	if err != nil {
+		log.Printf("Failed to unban %s in Redis: %v", key, err)
+		return false
+	}
+	until, _ := reply.(int64)
+	return l.clock.Now().UnixMilli() < until
+}
+
+// bansKey is the sorted set of banned keys, scored by the end of their ban
+func (l *RedisLockout) bansKey() string {
+	return l.prefix + "bans"
+}
+
+// replyString returns a bulk string reply, or "" for nil
+func replyString(reply interface{}) string {
+	s, _ := reply.(string)
+	return s
+}
+
+// replyPair reads a reply of two integers
+func replyPair(reply interface{}) (int64, int64, error) {
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 2 {
+		return 0, 0, fmt.Errorf("unexpected lockout reply %v", reply)
+	}
+	first, firstOK := values[0].(int64)
+	second, secondOK := values[1].(int64)
+	if !firstOK || !secondOK {
+		return 0, 0, fmt.Errorf("unexpected lockout reply %v", reply)
+	}
+	return first, second, nil
+}
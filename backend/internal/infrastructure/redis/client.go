@@ -0,0 +1,327 @@
+// Package redis is a minimal Redis client speaking RESP over pooled TCP connections, covering the
+// commands the service needs
+package redis
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrNil is returned for keys that do not exist
+var ErrNil = errors.New("redis: nil")
+
+// Error is an error reply of the server
+type Error string
+
+func (e Error) Error() string {
+	return "redis: " + string(e)
+}
+
+// Options configures a client
+type Options struct {
+	Addr     string
+	Password string
+	DB       int
+	// Timeout bounds dialing and each command, 2s when zero
+	Timeout time.Duration
+	// PoolSize is the number of idle connections kept, 4 when zero
+	PoolSize int
+}
+
+// Client runs commands on a Redis server
+type Client struct {
+	opts Options
+	idle chan *conn
+}
+
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// NewClient creates a client; connections are opened on first use
+func NewClient(opts Options) *Client {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 2 * time.Second
+	}
+	if opts.PoolSize <= 0 {
+		opts.PoolSize = 4
+	}
+	return &Client{opts: opts, idle: make(chan *conn, opts.PoolSize)}
+}
+
+// Ping checks that the server is reachable
+func (c *Client) Ping() error {
+	_, err := c.Do("PING")
+	return err
+}
+
+// Get returns the value of a key, or ErrNil when it does not exist
+func (c *Client) Get(key string) (string, error) {
+	reply, err := c.Do("GET", key)
+	if err != nil {
+		return "", err
+	}
+	value, ok := reply.(string)
+	if !ok {
+		return "", ErrNil
+	}
+	return value, nil
+}
+
+// Set sets the value of a key expiring after ttl, or never when ttl is zero
+func (c *Client) Set(key, value string, ttl time.Duration) error {
+	args := []string{"SET", key, value}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	}
+	_, err := c.Do(args...)
+	return err
+}
+
+// Incr increments the integer value of a key, created at zero, and returns the new value
+func (c *Client) Incr(key string) (int64, error) {
+	reply, err := c.Do("INCR", key)
+	if err != nil {
+		return 0, err
+	}
+	value, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("redis: unexpected INCR reply %v", reply)
+	}
+	return value, nil
+}
+
+// Eval runs a Lua script on keys and args atomically and returns its reply
+func (c *Client) Eval(script string, keys []string, args ...string) (interface{}, error) {
+	command := append([]string{"EVAL", script, strconv.Itoa(len(keys))}, keys...)
+	return c.Do(append(command, args...)...)
+}
+
+// Publish sends a message to the subscribers of a channel
+func (c *Client) Publish(channel, message string) error {
+	_, err := c.Do("PUBLISH", channel, message)
+	return err
+}
+
+// Do runs a command and returns its reply: a string, an int64, nil, or a []interface{} of replies.
+// Error replies are returned as Error.
+func (c *Client) Do(args ...string) (interface{}, error) {
+	cn, err := c.get()
+	if err != nil {
+		return nil, err
+	}
+	reply, err := cn.do(c.opts.Timeout, args)
+	var replyErr Error
+	if err != nil && !errors.As(err, &replyErr) {
+		// The connection is in an unknown state after a network or protocol error
+		cn.Close()
+		return nil, err
+	}
+	c.put(cn)
+	return reply, err
+}
+
+// Close closes the idle connections
+func (c *Client) Close() error {
+	for {
+		select {
+		case cn := <-c.idle:
+			cn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+func (c *Client) get() (*conn, error) {
+	select {
+	case cn := <-c.idle:
+		return cn, nil
+	default:
+	}
+
+	return c.dial()
+}
+
+// dial opens a connection, authenticated and switched to the database of the client
+func (c *Client) dial() (*conn, error) {
+	netConn, err := net.DialTimeout("tcp", c.opts.Addr, c.opts.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	cn := &conn{Conn: netConn, reader: bufio.NewReader(netConn)}
+	if c.opts.Password != "" {
+		if _, err := cn.do(c.opts.Timeout, []string{"AUTH", c.opts.Password}); err != nil {
+			cn.Close()
+			return nil, err
+		}
+	}
+	if c.opts.DB != 0 {
+		if _, err := cn.do(c.opts.Timeout, []string{"SELECT", strconv.Itoa(c.opts.DB)}); err != nil {
+			cn.Close()
+			return nil, err
+		}
+	}
+	return cn, nil
+}
+
+func (c *Client) put(cn *conn) {
+	select {
+	case c.idle <- cn:
+	default:
+		cn.Close()
+	}
+}
+
+// Subscription delivers the messages of a channel until it is closed
+type Subscription struct {
+	mu     sync.Mutex
+	conn   *conn
+	closed bool
+	done   chan struct{}
+}
+
+// Subscribe calls handle with every message published to a channel, on a connection of its own.
+// Messages are handled one at a time. When the connection is lost it is opened again after
+// retryAfter, and the messages published in between are missed.
+func (c *Client) Subscribe(channel string, retryAfter time.Duration, handle func(message string)) *Subscription {
+	sub := &Subscription{done: make(chan struct{})}
+	go func() {
+		defer close(sub.done)
+		for {
+			err := c.listen(sub, channel, handle)
+			sub.mu.Lock()
+			closed := sub.closed
+			sub.mu.Unlock()
+			if closed {
+				return
+			}
+			log.Printf("Redis subscription to %s lost, retrying in %s: %v", channel, retryAfter, err)
+			time.Sleep(retryAfter)
+		}
+	}()
+	return sub
+}
+
+// listen subscribes to a channel and handles its messages until the connection fails
+func (c *Client) listen(sub *Subscription, channel string, handle func(message string)) error {
+	cn, err := c.dial()
+	if err != nil {
+		return err
+	}
+	sub.mu.Lock()
+	if sub.closed {
+		sub.mu.Unlock()
+		cn.Close()
+		return nil
+	}
+	sub.conn = cn
+	sub.mu.Unlock()
+	defer cn.Close()
+
+	if _, err := cn.do(c.opts.Timeout, []string{"SUBSCRIBE", channel}); err != nil {
+		return err
+	}
+	// Messages come whenever they are published, so reads wait for as long as it takes
+	if err := cn.SetDeadline(time.Time{}); err != nil {
+		return err
+	}
+	for {
+		reply, err := readReply(cn.reader)
+		if err != nil {
+			return err
+		}
+		push, ok := reply.([]interface{})
+		if !ok || len(push) != 3 || push[0] != "message" {
+			continue
+		}
+		if message, ok := push[2].(string); ok {
+			handle(message)
+		}
+	}
+}
+
+// Close stops the subscription and waits for the message being handled, if any
+func (s *Subscription) Close() {
+	s.mu.Lock()
+	s.closed = true
+	if s.conn != nil {
+		s.conn.Close()
+	}
+	s.mu.Unlock()
+	<-s.done
+}
+
+// do writes a command as a RESP array of bulk strings and reads its reply
+func (cn *conn) do(timeout time.Duration, args []string) (interface{}, error) {
+	if err := cn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+
+	buf := []byte("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		buf = append(buf, "$"+strconv.Itoa(len(arg))+"\r\n"+arg+"\r\n"...)
+	}
+	if _, err := cn.Write(buf); err != nil {
+		return nil, err
+	}
+	return readReply(cn.reader)
+}
+
+// readReply reads one RESP reply
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+	kind, payload := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return payload, nil
+	case '-':
+		return nil, Error(payload)
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		size, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed bulk length %q", payload)
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return string(data[:size]), nil
+	case '*':
+		count, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed array length %q", payload)
+		}
+		if count < 0 {
+			return nil, nil
+		}
+		replies := make([]interface{}, count)
+		for i := range replies {
+			if replies[i], err = readReply(r); err != nil {
+				return nil, err
+			}
+		}
+		return replies, nil
+	}
+	return nil, fmt.Errorf("redis: unknown reply type %q", kind)
+}
@@ -0,0 +1,208 @@
+package redis
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer answers a few commands from an in-memory map and records what it received
+type fakeServer struct {
+	listener net.Listener
+	mu       sync.Mutex
+	values   map[string]string
+	commands [][]string
+	// subscribers are the connections subscribed to each channel
+	subscribers map[string][]net.Conn
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeServer{listener: listener, values: map[string]string{}, subscribers: map[string][]net.Conn{}}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		reply, err := readReply(reader)
+		if err != nil {
+			return
+		}
+		var args []string
+		for _, arg := range reply.([]interface{}) {
+			args = append(args, arg.(string))
+		}
+
+		s.mu.Lock()
+		s.commands = append(s.commands, args)
+		var out string
+		switch strings.ToUpper(args[0]) {
+		case "PING":
+			out = "+PONG\r\n"
+		case "AUTH":
+			if args[1] == "secret" {
+				out = "+OK\r\n"
+			} else {
+				out = "-WRONGPASS invalid password\r\n"
+			}
+		case "SELECT":
+			out = "+OK\r\n"
+		case "GET":
+			if value, ok := s.values[args[1]]; ok {
+				out = "$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n"
+			} else {
+				out = "$-1\r\n"
+			}
+		case "SET":
+			s.values[args[1]] = args[2]
+			out = "+OK\r\n"
+		case "INCR":
+			count, _ := strconv.Atoi(s.values[args[1]])
+			s.values[args[1]] = strconv.Itoa(count + 1)
+			out = ":" + s.values[args[1]] + "\r\n"
+		case "SUBSCRIBE":
+			s.subscribers[args[1]] = append(s.subscribers[args[1]], conn)
+			out = "*3\r\n$9\r\nsubscribe\r\n$" + strconv.Itoa(len(args[1])) + "\r\n" + args[1] + "\r\n:1\r\n"
+		case "PUBLISH":
+			message := "*3\r\n$7\r\nmessage\r\n$" + strconv.Itoa(len(args[1])) + "\r\n" + args[1] + "\r\n$" + strconv.Itoa(len(args[2])) + "\r\n" + args[2] + "\r\n"
+			for _, subscriber := range s.subscribers[args[1]] {
+				subscriber.Write([]byte(message))
+			}
+			out = ":" + strconv.Itoa(len(s.subscribers[args[1]])) + "\r\n"
+		default:
+			out = "-ERR unknown command\r\n"
+		}
+		// Replies are written under the lock, so they do not interleave with published messages
+		conn.Write([]byte(out))
+		s.mu.Unlock()
+	}
+}
+
+func (s *fakeServer) received() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]string(nil), s.commands...)
+}
+
+func TestClient_GetSet(t *testing.T) {
+	server := newFakeServer(t)
+	client := NewClient(Options{Addr: server.listener.Addr().String(), Password: "secret", DB: 2})
+	defer client.Close()
+
+	require.NoError(t, client.Ping())
+
+	_, err := client.Get("missing")
+	assert.ErrorIs(t, err, ErrNil)
+
+	require.NoError(t, client.Set("greeting", "hello\r\nworld", time.Minute))
+	value, err := client.Get("greeting")
+	require.NoError(t, err)
+	assert.Equal(t, "hello\r\nworld", value)
+
+	// One pooled connection, authenticated and switched to the database once
+	assert.Equal(t, [][]string{
+		{"AUTH", "secret"},
+		{"SELECT", "2"},
+		{"PING"},
+		{"GET", "missing"},
+		{"SET", "greeting", "hello\r\nworld", "PX", "60000"},
+		{"GET", "greeting"},
+	}, server.received())
+}
+
+func TestClient_Errors(t *testing.T) {
+	server := newFakeServer(t)
+
+	client := NewClient(Options{Addr: server.listener.Addr().String(), Password: "wrong"})
+	err := client.Ping()
+	var replyErr Error
+	require.ErrorAs(t, err, &replyErr)
+	assert.Equal(t, "redis: WRONGPASS invalid password", err.Error())
+
+	client = NewClient(Options{Addr: server.listener.Addr().String()})
+	defer client.Close()
+	_, err = client.Do("FLUSHALL")
+	assert.EqualError(t, err, "redis: ERR unknown command")
+	// Error replies leave the connection usable
+	assert.NoError(t, client.Ping())
+
+	unreachable := NewClient(Options{Addr: "127.0.0.1:1", Timeout: 200 * time.Millisecond})
+	assert.Error(t, unreachable.Ping())
+}
+
+func TestClient_Incr(t *testing.T) {
+	server := newFakeServer(t)
+	client := NewClient(Options{Addr: server.listener.Addr().String()})
+	defer client.Close()
+
+	for want := int64(1); want <= 2; want++ {
+		count, err := client.Incr("counter")
+		require.NoError(t, err)
+		assert.Equal(t, want, count)
+	}
+}
+
+func TestClient_Subscribe(t *testing.T) {
+	server := newFakeServer(t)
+	client := NewClient(Options{Addr: server.listener.Addr().String()})
+	defer client.Close()
+
+	received := make(chan string, 100)
+	sub := client.Subscribe("events", time.Millisecond, func(message string) { received <- message })
+	defer sub.Close()
+
+	// Messages published before the subscription is in place are missed, so publish until one arrives
+	require.Eventually(t, func() bool {
+		require.NoError(t, client.Publish("events", "first"))
+		select {
+		case message := <-received:
+			return message == "first"
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, client.Publish("events", "hello\r\nworld"))
+	for {
+		select {
+		case message := <-received:
+			if message == "first" {
+				continue
+			}
+			assert.Equal(t, "hello\r\nworld", message)
+			return
+		case <-time.After(time.Second):
+			t.Fatal("the message was not delivered")
+		}
+	}
+}
+
+func TestReadReply(t *testing.T) {
+	reply, err := readReply(bufio.NewReader(strings.NewReader("*3\r\n:42\r\n$-1\r\n+OK\r\n")))
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{int64(42), nil, "OK"}, reply)
+
+	_, err = readReply(bufio.NewReader(strings.NewReader("?what\r\n")))
+	assert.Error(t, err)
+}
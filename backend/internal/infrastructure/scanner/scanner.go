@@ -0,0 +1,110 @@
+// Package scanner checks uploaded files for malware: with a ClamAV daemon, or not at all.
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// NoOp is the scanner used when scanning is off; it finds nothing in any file
+type NoOp struct{}
+
+// Name returns "none"
+func (NoOp) Name() string {
+	return "none"
+}
+
+// Scan finds nothing
+func (NoOp) Scan(ctx context.Context, data []byte) (string, error) {
+	return "", nil
+}
+
+// Disabled reports that files are not actually scanned, so that they are not recorded as clean
+func (NoOp) Disabled() bool {
+	return true
+}
+
+// clamAVChunkSize is the size of the chunks files are streamed to clamd in, below its default
+// StreamMaxLength
+const clamAVChunkSize = 64 << 10
+
+// ClamAV scans files with a clamd daemon, streaming them with the INSTREAM command so that the
+// daemon does not need access to the files
+type ClamAV struct {
+	network string
+	address string
+	dialer  net.Dialer
+}
+
+// NewClamAV creates a scanner for the clamd daemon at address, host:port or the path of a unix
+// socket
+func NewClamAV(address string) *ClamAV {
+	network := "tcp"
+	if strings.HasPrefix(address, "/") {
+		network = "unix"
+	}
+	return &ClamAV{network: network, address: address}
+}
+
+// Name returns "clamav"
+func (s *ClamAV) Name() string {
+	return "clamav"
+}
+
+// Scan sends a file to clamd and returns the name of the signature it matched, empty when the
+// file is clean. The context bounds the whole exchange.
+func (s *ClamAV) Scan(ctx context.Context, data []byte) (string, error) {
+	conn, err := s.dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else {
+		conn.SetDeadline(time.Now().Add(time.Minute))
+	}
+
+	// Requests prefixed with z are terminated by a null byte, and so are their replies
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return "", fmt.Errorf("failed to send file to clamd: %w", err)
+	}
+	for start := 0; start < len(data); start += clamAVChunkSize {
+		chunk := data[start:min(start+clamAVChunkSize, len(data))]
+		if _, err := conn.Write(binary.BigEndian.AppendUint32(nil, uint32(len(chunk)))); err != nil {
+			return "", fmt.Errorf("failed to send file to clamd: %w", err)
+		}
+		if _, err := conn.Write(chunk); err != nil {
+			return "", fmt.Errorf("failed to send file to clamd: %w", err)
+		}
+	}
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return "", fmt.Errorf("failed to send file to clamd: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil {
+		return "", fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	return parseClamAVReply(strings.TrimSuffix(reply, "\x00"))
+}
+
+// parseClamAVReply reads the reply to INSTREAM: "stream: OK", "stream: <signature> FOUND" or
+// "<reason> ERROR"
+func parseClamAVReply(reply string) (string, error) {
+	reply = strings.TrimSpace(reply)
+	switch {
+	case reply == "stream: OK":
+		return "", nil
+	case strings.HasPrefix(reply, "stream: ") && strings.HasSuffix(reply, " FOUND"):
+		return strings.TrimSuffix(strings.TrimPrefix(reply, "stream: "), " FOUND"), nil
+	case strings.HasSuffix(reply, " ERROR"):
+		return "", fmt.Errorf("clamd failed to scan the file: %s", strings.TrimSuffix(reply, " ERROR"))
+	}
+	return "", fmt.Errorf("unexpected clamd reply %q", reply)
+}
@@ -0,0 +1,93 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eicar is the standard antivirus test file
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// fakeClamd answers INSTREAM like clamd, finding the EICAR test file, and returns its address
+// and the files it received
+func fakeClamd(t *testing.T) (string, <-chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	received := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			reader := bufio.NewReader(conn)
+			command, err := reader.ReadString(0)
+			if err != nil || command != "zINSTREAM\x00" {
+				conn.Write([]byte("UNKNOWN COMMAND\x00"))
+				conn.Close()
+				continue
+			}
+			var file strings.Builder
+			for {
+				var size uint32
+				if err := binary.Read(reader, binary.BigEndian, &size); err != nil || size == 0 {
+					break
+				}
+				io.CopyN(&file, reader, int64(size))
+			}
+			received <- file.String()
+			if strings.Contains(file.String(), "EICAR-STANDARD-ANTIVIRUS-TEST-FILE") {
+				conn.Write([]byte("stream: Eicar-Test-Signature FOUND\x00"))
+			} else {
+				conn.Write([]byte("stream: OK\x00"))
+			}
+			conn.Close()
+		}
+	}()
+	return listener.Addr().String(), received
+}
+
+func TestClamAV_Scan(t *testing.T) {
+	address, received := fakeClamd(t)
+	scanner := NewClamAV(address)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Larger than a chunk, to be streamed in several
+	clean := strings.Repeat("a clean file ", 10000)
+	signature, err := scanner.Scan(ctx, []byte(clean))
+	require.NoError(t, err)
+	assert.Empty(t, signature)
+	assert.Equal(t, clean, <-received)
+
+	signature, err = scanner.Scan(ctx, []byte(eicar))
+	require.NoError(t, err)
+	assert.Equal(t, "Eicar-Test-Signature", signature)
+}
+
+func TestClamAV_ScanUnavailable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	_, err = NewClamAV(address).Scan(context.Background(), []byte("file"))
+	assert.ErrorContains(t, err, "failed to connect to clamd")
+}
+
+func TestParseClamAVReply(t *testing.T) {
+	_, err := parseClamAVReply("INSTREAM size limit exceeded. ERROR")
+	assert.ErrorContains(t, err, "INSTREAM size limit exceeded.")
+	_, err = parseClamAVReply("PONG")
+	assert.ErrorContains(t, err, "unexpected clamd reply")
+}
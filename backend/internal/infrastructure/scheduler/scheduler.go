@@ -0,0 +1,276 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/cron"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+	"library-management-system/internal/infrastructure/jobs"
+)
+
+// tickInterval is how often the scheduler looks for due jobs
+const tickInterval = time.Second
+
+// JobSpec declares a recurring job
+type JobSpec struct {
+	Name        string
+	Description string
+	// Schedule is a five-field cron expression evaluated in UTC
+	Schedule string
+	Enabled  bool
+	// Jitter delays each run by a random duration up to this value, so replicas do not fire together
+	Jitter time.Duration
+	// Priority the job runs with on the queue, PriorityScheduled by default
+	Priority jobs.Priority
+	Run      func(ctx context.Context) error
+}
+
+// entry is a registered job and its state
+type entry struct {
+	spec     JobSpec
+	schedule *cron.Schedule
+	state    entities.ScheduledJob
+}
+
+// Scheduler fires registered jobs on their cron schedules and runs them on the job queue
+type Scheduler struct {
+	queue *jobs.Queue
+	clock clock.Clock
+	// random returns a number in [0, n); replaced in tests
+	random func(n int64) int64
+
+	// locks, when set, keeps replicas from running the same job twice
+	locks   repositories.JobLockRepository
+	owner   string
+	lockTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*entry
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// New creates a scheduler running jobs on the given queue
+func New(queue *jobs.Queue) *Scheduler {
+	return &Scheduler{
+		queue:   queue,
+		clock:   clock.System{},
+		random:  rand.Int63n,
+		entries: make(map[string]*entry),
+	}
+}
+
+// SetClock replaces the clock schedules are evaluated with
+func (s *Scheduler) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// SetLocks makes every run take a lock shared by all instances first; a run whose lock is held by
+// another instance is left to it. A lock is kept until the next scheduled run, so replicas firing
+// the same run with a different jitter skip it, and expires after ttl if its owner dies mid-run.
+func (s *Scheduler) SetLocks(locks repositories.JobLockRepository, owner string, ttl time.Duration) {
+	s.locks = locks
+	s.owner = owner
+	s.lockTTL = ttl
+}
+
+// Register adds a job; disabled jobs are listed in the status but never run
+func (s *Scheduler) Register(spec JobSpec) error {
+	if spec.Name == "" {
+		return errors.New("job name is required")
+	}
+	schedule, err := cron.Parse(spec.Schedule)
+	if err != nil {
+		return fmt.Errorf("invalid schedule for job %s: %w", spec.Name, err)
+	}
+	if spec.Priority == "" {
+		spec.Priority = jobs.PriorityScheduled
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.entries[spec.Name]; exists {
+		return fmt.Errorf("job %s is already registered", spec.Name)
+	}
+
+	e := &entry{
+		spec:     spec,
+		schedule: schedule,
+		state: entities.ScheduledJob{
+			Name:        spec.Name,
+			Description: spec.Description,
+			Schedule:    spec.Schedule,
+			Enabled:     spec.Enabled,
+			Priority:    string(spec.Priority),
+		},
+	}
+	if spec.Enabled {
+		s.planNext(e, s.clock.Now().UTC())
+	}
+	s.entries[spec.Name] = e
+	return nil
+}
+
+// Start begins firing jobs in the background
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go func(stop, done chan struct{}) {
+		defer close(done)
+		ticker := time.NewTicker(tickInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.Tick()
+			case <-stop:
+				return
+			}
+		}
+	}(s.stop, s.done)
+}
+
+// Stop stops firing jobs; runs already on the queue are left to the queue
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop = nil
+	s.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// Tick enqueues every enabled job whose next run has come
+func (s *Scheduler) Tick() {
+	now := s.clock.Now().UTC()
+
+	s.mu.Lock()
+	var due []*entry
+	for _, e := range s.entries {
+		if !e.spec.Enabled || e.state.NextRunAt == nil || e.state.NextRunAt.After(now) {
+			continue
+		}
+		s.planNext(e, now)
+		if e.state.Running {
+			log.Printf("Scheduled job %s skipped: previous run still in progress", e.spec.Name)
+			e.state.LastStatus = entities.JobRunSkipped
+			continue
+		}
+		e.state.Running = true
+		due = append(due, e)
+	}
+	s.mu.Unlock()
+
+	for _, e := range due {
+		e := e
+		err := s.queue.Enqueue(jobs.Job{
+			Name:     "scheduled:" + e.spec.Name,
+			Priority: e.spec.Priority,
+			Run: func(ctx context.Context) error {
+				return s.run(ctx, e)
+			},
+		})
+		if err != nil {
+			log.Printf("Failed to enqueue scheduled job %s: %v", e.spec.Name, err)
+			s.mu.Lock()
+			e.state.Running = false
+			s.mu.Unlock()
+		}
+	}
+}
+
+// Jobs returns the state of every registered job, sorted by name
+func (s *Scheduler) Jobs() []entities.ScheduledJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states := make([]entities.ScheduledJob, 0, len(s.entries))
+	for _, e := range s.entries {
+		states = append(states, e.state)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Name < states[j].Name })
+	return states
+}
+
+// run takes the lock of a job, executes it and records its outcome
+func (s *Scheduler) run(ctx context.Context, e *entry) error {
+	startedAt := s.clock.Now().UTC()
+
+	if s.locks != nil {
+		acquired, err := s.locks.Acquire(e.spec.Name, s.owner, startedAt, startedAt.Add(s.lockTTL))
+		if err != nil || !acquired {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			e.state.Running = false
+			if err != nil {
+				e.state.LockErrorCount++
+				e.state.LastStatus = entities.JobRunFailed
+				e.state.LastError = "failed to acquire lock: " + err.Error()
+				return err
+			}
+			e.state.LockMissCount++
+			e.state.LastStatus = entities.JobRunLocked
+			return nil
+		}
+		defer s.release(e, startedAt)
+	}
+
+	err := e.spec.Run(ctx)
+	finishedAt := s.clock.Now().UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e.state.Running = false
+	e.state.RunCount++
+	e.state.LastRunAt = &startedAt
+	e.state.LastDurationMs = finishedAt.Sub(startedAt).Milliseconds()
+	e.state.LastStatus = entities.JobRunSucceeded
+	e.state.LastError = ""
+	if err != nil {
+		e.state.LastStatus = entities.JobRunFailed
+		e.state.LastError = err.Error()
+	}
+	return err
+}
+
+// release keeps the lock of a finished run until the next scheduled run, when it becomes free again
+func (s *Scheduler) release(e *entry, startedAt time.Time) {
+	until := e.schedule.Next(startedAt)
+	if now := s.clock.Now().UTC(); until.IsZero() || until.Before(now) {
+		until = now
+	}
+	if err := s.locks.Release(e.spec.Name, s.owner, until); err != nil {
+		log.Printf("Failed to release lock of scheduled job %s: %v", e.spec.Name, err)
+	}
+}
+
+// planNext sets the next run of a job after now, including its jitter
+func (s *Scheduler) planNext(e *entry, now time.Time) {
+	next := e.schedule.Next(now)
+	if next.IsZero() {
+		e.state.NextRunAt = nil
+		return
+	}
+	if e.spec.Jitter > 0 {
+		next = next.Add(time.Duration(s.random(int64(e.spec.Jitter))))
+	}
+	e.state.NextRunAt = &next
+}
@@ -0,0 +1,308 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+	"library-management-system/internal/infrastructure/jobs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+func newTestScheduler(t *testing.T) (*Scheduler, *jobs.Queue, *clock.Fixed) {
+	queue := jobs.NewQueue(1, 10, time.Millisecond)
+	queue.Start()
+	t.Cleanup(queue.Stop)
+
+	now := clock.NewFixed(start)
+	s := New(queue)
+	s.SetClock(now)
+	return s, queue, now
+}
+
+func TestScheduler_RunsDueJobs(t *testing.T) {
+	s, queue, now := newTestScheduler(t)
+
+	runs := make(chan struct{}, 10)
+	require.NoError(t, s.Register(JobSpec{
+		Name:     "hourly",
+		Schedule: "0 * * * *",
+		Enabled:  true,
+		Run: func(ctx context.Context) error {
+			runs <- struct{}{}
+			return nil
+		},
+	}))
+
+	job := s.Jobs()[0]
+	assert.Equal(t, time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC), *job.NextRunAt)
+
+	s.Tick()
+	assert.Len(t, runs, 0, "job ran before its schedule")
+
+	now.Set(time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC))
+	s.Tick()
+	queue.Stop()
+
+	assert.Len(t, runs, 1)
+	job = s.Jobs()[0]
+	assert.Equal(t, entities.JobRunSucceeded, job.LastStatus)
+	assert.Equal(t, time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC), *job.LastRunAt)
+	assert.Equal(t, time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), *job.NextRunAt)
+	assert.False(t, job.Running)
+}
+
+func TestScheduler_RecordsFailures(t *testing.T) {
+	s, queue, now := newTestScheduler(t)
+
+	require.NoError(t, s.Register(JobSpec{
+		Name:     "broken",
+		Schedule: "* * * * *",
+		Enabled:  true,
+		Run: func(ctx context.Context) error {
+			return errors.New("database unavailable")
+		},
+	}))
+
+	now.Advance(time.Minute)
+	s.Tick()
+	queue.Stop()
+
+	job := s.Jobs()[0]
+	assert.Equal(t, entities.JobRunFailed, job.LastStatus)
+	assert.Equal(t, "database unavailable", job.LastError)
+}
+
+func TestScheduler_SkipsOverlappingRuns(t *testing.T) {
+	s, queue, now := newTestScheduler(t)
+
+	release := make(chan struct{})
+	started := make(chan struct{}, 10)
+	require.NoError(t, s.Register(JobSpec{
+		Name:     "slow",
+		Schedule: "* * * * *",
+		Enabled:  true,
+		Run: func(ctx context.Context) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		},
+	}))
+
+	now.Advance(time.Minute)
+	s.Tick()
+	<-started
+
+	now.Advance(time.Minute)
+	s.Tick()
+	job := s.Jobs()[0]
+	assert.True(t, job.Running)
+	assert.Equal(t, entities.JobRunSkipped, job.LastStatus)
+
+	close(release)
+	queue.Stop()
+	assert.Len(t, started, 0, "overlapping run was started")
+	assert.Equal(t, entities.JobRunSucceeded, s.Jobs()[0].LastStatus)
+}
+
+func TestScheduler_DisabledJobsNeverRun(t *testing.T) {
+	s, queue, now := newTestScheduler(t)
+
+	ran := false
+	require.NoError(t, s.Register(JobSpec{
+		Name:     "off",
+		Schedule: "* * * * *",
+		Run: func(ctx context.Context) error {
+			ran = true
+			return nil
+		},
+	}))
+
+	now.Advance(time.Hour)
+	s.Tick()
+	queue.Stop()
+
+	assert.False(t, ran)
+	job := s.Jobs()[0]
+	assert.False(t, job.Enabled)
+	assert.Nil(t, job.NextRunAt)
+}
+
+func TestScheduler_AddsJitter(t *testing.T) {
+	s, _, _ := newTestScheduler(t)
+	s.random = func(n int64) int64 {
+		assert.Equal(t, int64(time.Minute), n)
+		return int64(15 * time.Second)
+	}
+
+	require.NoError(t, s.Register(JobSpec{
+		Name:     "jittered",
+		Schedule: "0 * * * *",
+		Enabled:  true,
+		Jitter:   time.Minute,
+		Run:      func(ctx context.Context) error { return nil },
+	}))
+
+	assert.Equal(t, time.Date(2024, 1, 15, 11, 0, 15, 0, time.UTC), *s.Jobs()[0].NextRunAt)
+}
+
+func TestScheduler_Register(t *testing.T) {
+	s, _, _ := newTestScheduler(t)
+	noop := func(ctx context.Context) error { return nil }
+
+	assert.EqualError(t, s.Register(JobSpec{Schedule: "* * * * *", Run: noop}), "job name is required")
+	assert.ErrorContains(t, s.Register(JobSpec{Name: "bad", Schedule: "every minute", Run: noop}), "invalid schedule for job bad")
+
+	require.NoError(t, s.Register(JobSpec{Name: "b", Schedule: "* * * * *", Run: noop}))
+	require.NoError(t, s.Register(JobSpec{Name: "a", Schedule: "@daily", Run: noop}))
+	assert.EqualError(t, s.Register(JobSpec{Name: "a", Schedule: "@daily", Run: noop}), "job a is already registered")
+
+	names := []string{}
+	for _, job := range s.Jobs() {
+		names = append(names, job.Name)
+	}
+	assert.Equal(t, []string{"a", "b"}, names)
+}
+
+// memoryJobLocks is a lock table shared by the schedulers of a test
+type memoryJobLocks struct {
+	mu    sync.Mutex
+	locks map[string]entities.JobLock
+	err   error
+}
+
+var _ repositories.JobLockRepository = (*memoryJobLocks)(nil)
+
+func newMemoryJobLocks() *memoryJobLocks {
+	return &memoryJobLocks{locks: make(map[string]entities.JobLock)}
+}
+
+func (m *memoryJobLocks) Acquire(jobName, owner string, now, expiresAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return false, m.err
+	}
+	if lock, ok := m.locks[jobName]; ok && lock.Owner != owner && lock.ExpiresAt.After(now) {
+		return false, nil
+	}
+	m.locks[jobName] = entities.JobLock{JobName: jobName, Owner: owner, AcquiredAt: now, ExpiresAt: expiresAt}
+	return true, nil
+}
+
+func (m *memoryJobLocks) Release(jobName, owner string, until time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if lock, ok := m.locks[jobName]; ok && lock.Owner == owner {
+		lock.ExpiresAt = until
+		m.locks[jobName] = lock
+	}
+	return nil
+}
+
+func TestScheduler_LockKeepsReplicasFromDoubleFiring(t *testing.T) {
+	locks := newMemoryJobLocks()
+	var mu sync.Mutex
+	runsBy := map[string]int{}
+
+	replica := func(owner string) (*Scheduler, *jobs.Queue, *clock.Fixed) {
+		s, queue, now := newTestScheduler(t)
+		s.SetLocks(locks, owner, 10*time.Minute)
+		require.NoError(t, s.Register(JobSpec{
+			Name:     "hourly",
+			Schedule: "0 * * * *",
+			Enabled:  true,
+			Run: func(ctx context.Context) error {
+				mu.Lock()
+				runsBy[owner]++
+				mu.Unlock()
+				return nil
+			},
+		}))
+		return s, queue, now
+	}
+	first, firstQueue, firstNow := replica("replica-1")
+	second, secondQueue, secondNow := replica("replica-2")
+
+	// The first replica fires on time, the second one a few seconds later because of its jitter
+	firstNow.Set(time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC))
+	first.Tick()
+	firstQueue.Stop()
+	secondNow.Set(time.Date(2024, 1, 15, 11, 0, 20, 0, time.UTC))
+	second.Tick()
+	secondQueue.Stop()
+
+	assert.Equal(t, map[string]int{"replica-1": 1}, runsBy)
+	assert.Equal(t, int64(1), first.Jobs()[0].RunCount)
+	job := second.Jobs()[0]
+	assert.Equal(t, entities.JobRunLocked, job.LastStatus)
+	assert.Equal(t, int64(1), job.LockMissCount)
+	assert.Equal(t, int64(0), job.RunCount)
+	assert.False(t, job.Running)
+
+	// The lock is kept until the next run only
+	assert.Equal(t, time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), locks.locks["hourly"].ExpiresAt)
+}
+
+func TestScheduler_ExpiredLockIsTakenOver(t *testing.T) {
+	locks := newMemoryJobLocks()
+	locks.locks["hourly"] = entities.JobLock{JobName: "hourly", Owner: "crashed", ExpiresAt: time.Date(2024, 1, 15, 10, 45, 0, 0, time.UTC)}
+
+	s, queue, now := newTestScheduler(t)
+	s.SetLocks(locks, "replica-1", 10*time.Minute)
+	ran := false
+	require.NoError(t, s.Register(JobSpec{
+		Name:     "hourly",
+		Schedule: "0 * * * *",
+		Enabled:  true,
+		Run: func(ctx context.Context) error {
+			ran = true
+			return nil
+		},
+	}))
+
+	now.Set(time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC))
+	s.Tick()
+	queue.Stop()
+
+	assert.True(t, ran)
+	assert.Equal(t, "replica-1", locks.locks["hourly"].Owner)
+}
+
+func TestScheduler_LockErrorsFailTheRun(t *testing.T) {
+	locks := newMemoryJobLocks()
+	locks.err = errors.New("connection refused")
+
+	s, queue, now := newTestScheduler(t)
+	s.SetLocks(locks, "replica-1", 10*time.Minute)
+	ran := false
+	require.NoError(t, s.Register(JobSpec{
+		Name:     "minutely",
+		Schedule: "* * * * *",
+		Enabled:  true,
+		Run: func(ctx context.Context) error {
+			ran = true
+			return nil
+		},
+	}))
+
+	now.Advance(time.Minute)
+	s.Tick()
+	queue.Stop()
+
+	assert.False(t, ran)
+	job := s.Jobs()[0]
+	assert.Equal(t, entities.JobRunFailed, job.LastStatus)
+	assert.Equal(t, "failed to acquire lock: connection refused", job.LastError)
+	assert.Equal(t, int64(1), job.LockErrorCount)
+	assert.False(t, job.Running)
+}
@@ -0,0 +1,146 @@
+// Package tracing propagates W3C trace context (traceparent and tracestate headers) through the
+// API and its outgoing HTTP calls. It only carries trace and span IDs; spans are not recorded or
+// exported, so traces started upstream stay connected across this service without a collector.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// Trace context headers
+const (
+	HeaderTraceparent = "traceparent"
+	HeaderTracestate  = "tracestate"
+	// HeaderTraceID returns the trace ID of a request to clients that do not parse traceparent
+	HeaderTraceID = "X-Trace-ID"
+)
+
+// flagSampled is the trace flag of traces started here, asking downstream services to record them
+const flagSampled = "01"
+
+// SpanContext identifies a span of a trace
+type SpanContext struct {
+	TraceID string
+	SpanID  string
+	Flags   string
+	// State is the vendor-specific tracestate, propagated unchanged
+	State string
+}
+
+// Valid reports whether the span context has a trace and span ID
+func (s SpanContext) Valid() bool {
+	return s.TraceID != "" && s.SpanID != ""
+}
+
+// Traceparent formats the span context as a version 00 traceparent header
+func (s SpanContext) Traceparent() string {
+	return "00-" + s.TraceID + "-" + s.SpanID + "-" + s.Flags
+}
+
+// Child returns a new span of the same trace
+func (s SpanContext) Child() SpanContext {
+	s.SpanID = randomHex(8)
+	return s
+}
+
+// New starts a trace
+func New() SpanContext {
+	return SpanContext{TraceID: randomHex(16), SpanID: randomHex(8), Flags: flagSampled}
+}
+
+// Parse reads the traceparent and tracestate headers. Versions above 00 are read as 00, as the
+// specification asks; malformed headers and all-zero IDs are rejected.
+func Parse(traceparent, tracestate string) (SpanContext, bool) {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 || !isHex(parts[0], 2) || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return SpanContext{}, false
+	}
+	traceID, spanID, flags := parts[1], parts[2], parts[3]
+	if !isHex(traceID, 32) || !isHex(spanID, 16) || !isHex(flags, 2) || isZero(traceID) || isZero(spanID) {
+		return SpanContext{}, false
+	}
+	return SpanContext{TraceID: traceID, SpanID: spanID, Flags: flags, State: strings.TrimSpace(tracestate)}, true
+}
+
+// FromHeader reads the trace context of a request
+func FromHeader(h http.Header) (SpanContext, bool) {
+	return Parse(h.Get(HeaderTraceparent), h.Get(HeaderTracestate))
+}
+
+type contextKey struct{}
+
+// ContextWith returns a context carrying the span; invalid spans leave the context unchanged
+func ContextWith(ctx context.Context, span SpanContext) context.Context {
+	if !span.Valid() {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, span)
+}
+
+// FromContext returns the span carried by a context
+func FromContext(ctx context.Context) (SpanContext, bool) {
+	span, ok := ctx.Value(contextKey{}).(SpanContext)
+	return span, ok
+}
+
+// Inject sets the trace context headers of an outgoing request to a child of the span in ctx
+func Inject(ctx context.Context, h http.Header) {
+	span, ok := FromContext(ctx)
+	if !ok {
+		return
+	}
+	child := span.Child()
+	h.Set(HeaderTraceparent, child.Traceparent())
+	if child.State != "" {
+		h.Set(HeaderTracestate, child.State)
+	}
+}
+
+// Transport injects the trace context of each request's context into its headers
+type Transport struct {
+	// Base performs the requests, http.DefaultTransport when nil
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if _, ok := FromContext(req.Context()); ok {
+		req = req.Clone(req.Context())
+		Inject(req.Context(), req.Header)
+	}
+	return base.RoundTrip(req)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	for {
+		rand.Read(b)
+		if id := hex.EncodeToString(b); !isZero(id) {
+			return id
+		}
+	}
+}
+
+func isHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, c := range s {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func isZero(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
@@ -0,0 +1,79 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestParse(t *testing.T) {
+	span, ok := Parse(traceparent, "congo=t61rcWkgMzE")
+	require.True(t, ok)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.TraceID)
+	assert.Equal(t, "00f067aa0ba902b7", span.SpanID)
+	assert.Equal(t, "01", span.Flags)
+	assert.Equal(t, "congo=t61rcWkgMzE", span.State)
+	assert.Equal(t, traceparent, span.Traceparent())
+
+	_, ok = Parse("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future", "")
+	assert.True(t, ok, "later versions are read as 00")
+
+	for _, invalid := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01",
+	} {
+		_, ok := Parse(invalid, "")
+		assert.False(t, ok, invalid)
+	}
+}
+
+func TestNewAndChild(t *testing.T) {
+	span := New()
+	require.True(t, span.Valid())
+	_, ok := Parse(span.Traceparent(), "")
+	assert.True(t, ok)
+
+	child := span.Child()
+	assert.Equal(t, span.TraceID, child.TraceID)
+	assert.NotEqual(t, span.SpanID, child.SpanID)
+}
+
+func TestTransport_InjectsChildSpan(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: &Transport{}}
+
+	span, _ := Parse(traceparent, "congo=t61rcWkgMzE")
+	req, _ := http.NewRequestWithContext(ContextWith(context.Background(), span), http.MethodGet, server.URL, nil)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	sent, ok := FromHeader(received)
+	require.True(t, ok)
+	assert.Equal(t, span.TraceID, sent.TraceID)
+	assert.NotEqual(t, span.SpanID, sent.SpanID)
+	assert.Equal(t, "congo=t61rcWkgMzE", sent.State)
+	assert.Empty(t, req.Header.Get(HeaderTraceparent), "the caller's request is not modified")
+
+	req, _ = http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, received.Get(HeaderTraceparent), "requests outside a trace carry no header")
+}
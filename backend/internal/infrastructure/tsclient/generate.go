@@ -0,0 +1,313 @@
+package tsclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Generate renders a typed TypeScript client for the operations of a spec. The client wraps an
+// axios instance so the frontend keeps its own base URL, timeout and interceptors. source names
+// the document in the header of the generated file.
+func Generate(spec *Spec, source string) []byte {
+	g := &generator{spec: spec, names: typeNames(spec.Definitions)}
+
+	fmt.Fprintf(&g.out, "// Code generated by cmd/genclient from %s. DO NOT EDIT.\n\n", source)
+	g.out.WriteString("import type { AxiosInstance } from 'axios';\n")
+
+	defs := make([]string, 0, len(spec.Definitions))
+	for def := range spec.Definitions {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return g.names[defs[i]] < g.names[defs[j]] })
+	for _, def := range defs {
+		g.writeDefinition(def, spec.Definitions[def])
+	}
+
+	g.out.WriteString("\nexport const createClient = (http: AxiosInstance) => ({\n")
+	used := map[string]int{}
+	for _, route := range spec.Routes() {
+		g.writeOperation(route, spec.Paths[route.Path][strings.ToLower(route.Method)], used)
+	}
+	g.out.WriteString("});\n\nexport type Client = ReturnType<typeof createClient>;\n")
+
+	return []byte(g.out.String())
+}
+
+type generator struct {
+	spec  *Spec
+	names map[string]string
+	out   strings.Builder
+}
+
+// typeNames maps definitions such as entities.Book to TypeScript names such as Book. Names that
+// would clash keep their package as a prefix, e.g. HandlersBook.
+func typeNames(defs map[string]*Schema) map[string]string {
+	short := map[string]int{}
+	for def := range defs {
+		short[shortName(def)]++
+	}
+	names := map[string]string{}
+	for def := range defs {
+		if short[shortName(def)] == 1 {
+			names[def] = shortName(def)
+		} else {
+			names[def] = pascal(def)
+		}
+	}
+	return names
+}
+
+func shortName(def string) string {
+	return pascal(def[strings.LastIndex(def, ".")+1:])
+}
+
+func (g *generator) writeDefinition(def string, schema *Schema) {
+	g.out.WriteString("\n")
+	writeDoc(&g.out, "", schema.Description)
+	if schema.Type == "object" || len(schema.Properties) > 0 {
+		fmt.Fprintf(&g.out, "export interface %s {\n", g.names[def])
+		for _, name := range sortedKeys(schema.Properties) {
+			prop := schema.Properties[name]
+			writeDoc(&g.out, "  ", prop.Description)
+			fmt.Fprintf(&g.out, "  %s%s: %s;\n", propertyName(name), optional(!contains(schema.Required, name)), g.tsType(prop))
+		}
+		g.out.WriteString("}\n")
+		return
+	}
+	fmt.Fprintf(&g.out, "export type %s = %s;\n", g.names[def], g.tsType(schema))
+}
+
+func (g *generator) writeOperation(route Route, op Operation, used map[string]int) {
+	name := operationName(route, op)
+	used[name]++
+	if used[name] > 1 {
+		name += strconv.Itoa(used[name])
+	}
+
+	args := []string{}
+	var body, query []Parameter
+	for _, param := range op.Parameters {
+		switch param.In {
+		case "path":
+			args = append(args, fmt.Sprintf("%s: %s", identifier(param.Name), g.parameterType(param)))
+		case "body":
+			body = append(body, param)
+		case "query":
+			query = append(query, param)
+		}
+	}
+	for _, param := range body {
+		args = append(args, fmt.Sprintf("%s%s: %s", identifier(param.Name), optional(!param.Required), g.tsType(param.Schema)))
+	}
+	if len(query) > 0 {
+		fields := []string{}
+		required := false
+		for _, param := range query {
+			fields = append(fields, fmt.Sprintf("%s%s: %s", propertyName(param.Name), optional(!param.Required), g.parameterType(param)))
+			required = required || param.Required
+		}
+		arg := "query: { " + strings.Join(fields, "; ") + " }"
+		if !required {
+			arg += " = {}"
+		}
+		args = append(args, arg)
+	}
+
+	config := []string{fmt.Sprintf("method: '%s'", strings.ToLower(route.Method)), "url: " + g.url(route.Path)}
+	if len(body) > 0 {
+		config = append(config, "data: "+identifier(body[0].Name))
+	}
+	if len(query) > 0 {
+		config = append(config, "params: query")
+	}
+
+	writeDoc(&g.out, "  ", op.Summary)
+	fmt.Fprintf(&g.out, "  %s: async (%s): Promise<%s> => {\n", name, strings.Join(args, ", "), g.responseType(op))
+	fmt.Fprintf(&g.out, "    const response = await http.request({ %s });\n", strings.Join(config, ", "))
+	g.out.WriteString("    return response.data;\n  },\n")
+}
+
+// url renders the path of an operation as a template literal with its path parameters encoded
+func (g *generator) url(path string) string {
+	var b strings.Builder
+	b.WriteString("`" + g.spec.BasePath)
+	for {
+		open := strings.Index(path, "{")
+		end := strings.Index(path, "}")
+		if open < 0 || end < open {
+			break
+		}
+		b.WriteString(path[:open])
+		fmt.Fprintf(&b, "${encodeURIComponent(String(%s))}", identifier(path[open+1:end]))
+		path = path[end+1:]
+	}
+	b.WriteString(path + "`")
+	return b.String()
+}
+
+// responseType is the type of the first documented success response
+func (g *generator) responseType(op Operation) string {
+	codes := sortedKeys(op.Responses)
+	for _, code := range codes {
+		if strings.HasPrefix(code, "2") {
+			if schema := op.Responses[code].Schema; schema != nil {
+				return g.tsType(schema)
+			}
+			return "void"
+		}
+	}
+	return "unknown"
+}
+
+func (g *generator) parameterType(param Parameter) string {
+	if param.Schema != nil {
+		return g.tsType(param.Schema)
+	}
+	return g.tsType(&Schema{Type: param.Type, Items: param.Items, Enum: param.Enum})
+}
+
+func (g *generator) tsType(schema *Schema) string {
+	if schema == nil {
+		return "unknown"
+	}
+	if schema.Ref != "" {
+		if name, ok := g.names[strings.TrimPrefix(schema.Ref, "#/definitions/")]; ok {
+			return name
+		}
+		return "unknown"
+	}
+	if len(schema.Enum) > 0 {
+		literals := make([]string, 0, len(schema.Enum))
+		for _, value := range schema.Enum {
+			literal, _ := json.Marshal(value)
+			literals = append(literals, string(literal))
+		}
+		return strings.Join(literals, " | ")
+	}
+
+	switch schema.Type {
+	case "string":
+		return "string"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "file":
+		return "Blob"
+	case "array":
+		item := g.tsType(schema.Items)
+		if strings.Contains(item, " ") {
+			item = "(" + item + ")"
+		}
+		return item + "[]"
+	case "object":
+		if len(schema.Properties) > 0 {
+			fields := []string{}
+			for _, name := range sortedKeys(schema.Properties) {
+				fields = append(fields, fmt.Sprintf("%s%s: %s", propertyName(name), optional(!contains(schema.Required, name)), g.tsType(schema.Properties[name])))
+			}
+			return "{ " + strings.Join(fields, "; ") + " }"
+		}
+		if schema.AdditionalProperties != nil {
+			return "Record<string, " + g.tsType(schema.AdditionalProperties) + ">"
+		}
+		return "Record<string, unknown>"
+	}
+	return "unknown"
+}
+
+// articles are left out of operation names
+var articles = map[string]bool{"a": true, "an": true, "the": true}
+
+// operationName derives a function name from the summary, e.g. "Get a book by ID" becomes getBookByID
+func operationName(route Route, op Operation) string {
+	words := []string{}
+	for _, word := range strings.FieldsFunc(op.Summary, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		if !articles[strings.ToLower(word)] {
+			words = append(words, word)
+		}
+	}
+	if len(words) == 0 {
+		words = append([]string{strings.ToLower(route.Method)}, strings.FieldsFunc(route.Path, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })...)
+	}
+	name := strings.ToLower(words[0])
+	for _, word := range words[1:] {
+		name += pascal(word)
+	}
+	if unicode.IsDigit(rune(name[0])) {
+		name = "op" + pascal(name)
+	}
+	return name
+}
+
+// pascal upper-cases the first letter of each word, dropping separators
+func pascal(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// identifier turns a parameter name such as book_id into a TypeScript identifier such as bookId
+func identifier(name string) string {
+	p := pascal(name)
+	if p == "" {
+		return "arg"
+	}
+	return strings.ToLower(p[:1]) + p[1:]
+}
+
+// propertyName quotes keys that are not valid identifiers
+func propertyName(name string) string {
+	for i, r := range name {
+		if !(unicode.IsLetter(r) || r == '_' || r == '$' || (i > 0 && unicode.IsDigit(r))) {
+			return strconv.Quote(name)
+		}
+	}
+	return name
+}
+
+func optional(ok bool) string {
+	if ok {
+		return "?"
+	}
+	return ""
+}
+
+func writeDoc(b *strings.Builder, indent, doc string) {
+	if doc = strings.TrimSpace(doc); doc != "" {
+		fmt.Fprintf(b, "%s/** %s */\n", indent, strings.ReplaceAll(doc, "*/", "*\\/"))
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package tsclient
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// routerAnnotation matches swag comments such as "// @Router /books/{id} [get]"
+var routerAnnotation = regexp.MustCompile(`^//\s*@Router\s+(\S+)\s+\[(\w+)\]`)
+
+// AnnotatedRoutes collects the routes documented with @Router in the Go files of the given
+// directories. Those annotations are what swag builds the spec from.
+func AnnotatedRoutes(dirs ...string) ([]Route, error) {
+	routes := []Route{}
+	for _, dir := range dirs {
+		files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if strings.HasSuffix(file, "_test.go") {
+				continue
+			}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			routes = append(routes, parseAnnotations(string(data))...)
+		}
+	}
+	sortRoutes(routes)
+	return routes, nil
+}
+
+func parseAnnotations(source string) []Route {
+	routes := []Route{}
+	for _, line := range strings.Split(source, "\n") {
+		if m := routerAnnotation.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			routes = append(routes, Route{Method: strings.ToUpper(m[2]), Path: m[1]})
+		}
+	}
+	return routes
+}
+
+// Drift lists the differences between the routes the code documents and the routes of the spec.
+// It is empty when the spec is up to date.
+func Drift(annotated, spec []Route) []string {
+	inSpec := map[Route]bool{}
+	for _, route := range spec {
+		inSpec[route] = true
+	}
+	inCode := map[Route]bool{}
+	for _, route := range annotated {
+		inCode[route] = true
+	}
+
+	problems := []string{}
+	for _, route := range annotated {
+		if !inSpec[route] {
+			problems = append(problems, fmt.Sprintf("%s is missing from the spec", route))
+		}
+	}
+	for _, route := range spec {
+		if !inCode[route] {
+			problems = append(problems, fmt.Sprintf("%s is in the spec but no handler documents it", route))
+		}
+	}
+	return problems
+}
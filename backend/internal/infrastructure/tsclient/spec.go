@@ -0,0 +1,116 @@
+package tsclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Spec is the subset of a Swagger 2.0 document the client is generated from
+type Spec struct {
+	BasePath    string                          `json:"basePath"`
+	Paths       map[string]map[string]Operation `json:"paths"`
+	Definitions map[string]*Schema              `json:"definitions"`
+}
+
+// Operation is one method of a path
+type Operation struct {
+	Summary    string              `json:"summary"`
+	Tags       []string            `json:"tags"`
+	Parameters []Parameter         `json:"parameters"`
+	Responses  map[string]Response `json:"responses"`
+}
+
+// Parameter is a path, query or body parameter of an operation
+type Parameter struct {
+	Name        string        `json:"name"`
+	In          string        `json:"in"`
+	Description string        `json:"description"`
+	Required    bool          `json:"required"`
+	Type        string        `json:"type"`
+	Items       *Schema       `json:"items"`
+	Enum        []interface{} `json:"enum"`
+	Schema      *Schema       `json:"schema"`
+}
+
+// Response is the documented response of an operation for a status code
+type Response struct {
+	Description string  `json:"description"`
+	Schema      *Schema `json:"schema"`
+}
+
+// Schema describes a JSON value
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Items                *Schema            `json:"items"`
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	Enum                 []interface{}      `json:"enum"`
+	AdditionalProperties *Schema            `json:"additionalProperties"`
+}
+
+// UnmarshalJSON also accepts the boolean schemas additionalProperties may be, such as the true
+// swag writes for map[string]interface{} fields, which read as a schema of any value
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); bytes.Equal(trimmed, []byte("true")) || bytes.Equal(trimmed, []byte("false")) {
+		*s = Schema{}
+		return nil
+	}
+	type plain Schema
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// Route is an HTTP method and a path relative to the base path, e.g. GET /books/{id}
+type Route struct {
+	Method string
+	Path   string
+}
+
+func (r Route) String() string {
+	return r.Method + " " + r.Path
+}
+
+// LoadSpec reads a Swagger 2.0 JSON document
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSpec(data)
+}
+
+// ParseSpec decodes a Swagger 2.0 JSON document
+func ParseSpec(data []byte) (*Spec, error) {
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	return &spec, nil
+}
+
+// Routes lists the routes of the spec sorted by path, then method
+func (s *Spec) Routes() []Route {
+	routes := []Route{}
+	for path, methods := range s.Paths {
+		for method := range methods {
+			routes = append(routes, Route{Method: strings.ToUpper(method), Path: path})
+		}
+	}
+	sortRoutes(routes)
+	return routes
+}
+
+func sortRoutes(routes []Route) {
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+}
@@ -0,0 +1,165 @@
+package tsclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `{
+  "basePath": "/api",
+  "paths": {
+    "/books/{id}": {
+      "get": {
+        "summary": "Get a book by ID",
+        "parameters": [{"name": "id", "in": "path", "required": true, "type": "string"}],
+        "responses": {"200": {"schema": {"$ref": "#/definitions/entities.Book"}}, "404": {"schema": {"$ref": "#/definitions/handlers.ErrorResponse"}}}
+      },
+      "delete": {
+        "parameters": [{"name": "id", "in": "path", "required": true, "type": "string"}],
+        "responses": {"204": {}}
+      }
+    },
+    "/books/search": {
+      "get": {
+        "summary": "Search books",
+        "parameters": [
+          {"name": "title", "in": "query", "type": "string"},
+          {"name": "status", "in": "query", "type": "string", "enum": ["active", "deleted"]}
+        ],
+        "responses": {"200": {"schema": {"type": "array", "items": {"$ref": "#/definitions/entities.Book"}}}}
+      }
+    },
+    "/books": {
+      "post": {
+        "summary": "Create a new book",
+        "parameters": [{"name": "book", "in": "body", "required": true, "schema": {"$ref": "#/definitions/handlers.CreateBookRequest"}}],
+        "responses": {"201": {"schema": {"$ref": "#/definitions/entities.Book"}}}
+      }
+    }
+  },
+  "definitions": {
+    "entities.Book": {
+      "type": "object",
+      "description": "A book",
+      "required": ["id"],
+      "properties": {
+        "id": {"type": "string"},
+        "year": {"type": "integer", "description": "Publication year"},
+        "tags": {"type": "array", "items": {"type": "string"}},
+        "extra": {"type": "object", "additionalProperties": {"type": "boolean"}}
+      }
+    },
+    "handlers.CreateBookRequest": {"type": "object", "required": ["title"], "properties": {"title": {"type": "string"}}},
+    "handlers.ErrorResponse": {"type": "object", "properties": {"error": {"type": "string"}}},
+    "handlers.Book": {"type": "object", "properties": {"title": {"type": "string"}}}
+  }
+}`
+
+const wantClient = "// Code generated by cmd/genclient from docs/swagger.json. DO NOT EDIT.\n" +
+	`
+import type { AxiosInstance } from 'axios';
+
+export interface CreateBookRequest {
+  title: string;
+}
+
+/** A book */
+export interface EntitiesBook {
+  extra?: Record<string, boolean>;
+  id: string;
+  tags?: string[];
+  /** Publication year */
+  year?: number;
+}
+
+export interface ErrorResponse {
+  error?: string;
+}
+
+export interface HandlersBook {
+  title?: string;
+}
+
+export const createClient = (http: AxiosInstance) => ({
+  /** Create a new book */
+  createNewBook: async (book: CreateBookRequest): Promise<EntitiesBook> => {
+    const response = await http.request({ method: 'post', url: ` + "`/api/books`" + `, data: book });
+    return response.data;
+  },
+  /** Search books */
+  searchBooks: async (query: { title?: string; status?: "active" | "deleted" } = {}): Promise<EntitiesBook[]> => {
+    const response = await http.request({ method: 'get', url: ` + "`/api/books/search`" + `, params: query });
+    return response.data;
+  },
+  deleteBooksId: async (id: string): Promise<void> => {
+    const response = await http.request({ method: 'delete', url: ` + "`/api/books/${encodeURIComponent(String(id))}`" + ` });
+    return response.data;
+  },
+  /** Get a book by ID */
+  getBookByID: async (id: string): Promise<EntitiesBook> => {
+    const response = await http.request({ method: 'get', url: ` + "`/api/books/${encodeURIComponent(String(id))}`" + ` });
+    return response.data;
+  },
+});
+
+export type Client = ReturnType<typeof createClient>;
+`
+
+func TestGenerate(t *testing.T) {
+	spec, err := ParseSpec([]byte(testSpec))
+	require.NoError(t, err)
+
+	assert.Equal(t, wantClient, string(Generate(spec, "docs/swagger.json")))
+}
+
+func TestParseSpec_BooleanAdditionalProperties(t *testing.T) {
+	spec, err := ParseSpec([]byte(`{
+  "basePath": "/api",
+  "paths": {},
+  "definitions": {
+    "handlers.BookResponse": {"type": "object", "properties": {"metadata": {"type": "object", "additionalProperties": true}}}
+  }
+}`))
+	require.NoError(t, err)
+
+	metadata := spec.Definitions["handlers.BookResponse"].Properties["metadata"]
+	require.NotNil(t, metadata.AdditionalProperties)
+	assert.Contains(t, string(Generate(spec, "docs/swagger.json")), "metadata?: Record<string, unknown>;")
+}
+
+func TestGenerate_IsDeterministic(t *testing.T) {
+	spec, err := ParseSpec([]byte(testSpec))
+	require.NoError(t, err)
+
+	first := Generate(spec, "docs/swagger.json")
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first, Generate(spec, "docs/swagger.json"))
+	}
+}
+
+func TestDrift(t *testing.T) {
+	spec, err := ParseSpec([]byte(testSpec))
+	require.NoError(t, err)
+
+	annotated := parseAnnotations(`
+// GetBook handles GET /api/books/:id
+// @Summary Get a book by ID
+// @Router /books/{id} [get]
+func (h *BookHandler) GetBook(c *gin.Context) {}
+
+// @Router /books/{id} [delete]
+// @Router /books [post]
+// @Router /books/search [get]
+`)
+	assert.Empty(t, Drift(annotated, spec.Routes()))
+
+	annotated = append(annotated[:1], Route{Method: "GET", Path: "/errors"})
+	assert.Equal(t, []string{
+		"GET /errors is missing from the spec",
+		"POST /books is in the spec but no handler documents it",
+		"GET /books/search is in the spec but no handler documents it",
+		"DELETE /books/{id} is in the spec but no handler documents it",
+	}, Drift(annotated, spec.Routes()))
+}
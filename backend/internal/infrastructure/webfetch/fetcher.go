@@ -0,0 +1,140 @@
+// Package webfetch downloads documents named by API clients, such as sitemaps and covers, with
+// the limits that makes safe: a size cap, a timeout, optionally a set of content types, and no
+// access to private networks by default.
+package webfetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"library-management-system/internal/infrastructure/tracing"
+)
+
+// ErrPrivateAddress is returned for URLs resolving to loopback, private or link-local addresses
+var ErrPrivateAddress = errors.New("refusing to fetch from a private address")
+
+// ErrContentType is returned for documents of a content type the fetcher does not accept
+var ErrContentType = errors.New("unexpected content type")
+
+// maxRedirects bounds the redirects followed per fetch
+const maxRedirects = 5
+
+// Fetcher downloads documents over HTTP(S)
+type Fetcher struct {
+	client   *http.Client
+	maxBytes int64
+	// contentTypes lists the media types accepted, any when empty
+	contentTypes []string
+}
+
+// NewFetcher creates a fetcher for documents of up to maxBytes taking at most timeout. Unless
+// allowPrivate is set, it refuses to connect to private addresses, including after redirects,
+// so clients cannot reach internal services through it.
+func NewFetcher(maxBytes int64, timeout time.Duration, allowPrivate bool) *Fetcher {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		// Checked on the resolved address at connect time, so DNS cannot point past it
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isPrivate(ip) {
+				return ErrPrivateAddress
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &Fetcher{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: &tracing.Transport{Base: transport},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= maxRedirects {
+					return fmt.Errorf("stopped after %d redirects", maxRedirects)
+				}
+				return nil
+			},
+		},
+		maxBytes: maxBytes,
+	}
+}
+
+// SetContentTypes restricts the documents fetched to some media types, such as image/png.
+// Responses of other types are refused by their Content-Type before their body is read.
+func (f *Fetcher) SetContentTypes(contentTypes ...string) {
+	f.contentTypes = contentTypes
+}
+
+// Fetch downloads a document, failing on non-2xx statuses, documents over the size limit and
+// documents of a content type not accepted
+func (f *Fetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(f.contentTypes) > 0 {
+		req.Header.Set("Accept", strings.Join(f.contentTypes, ", "))
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrPrivateAddress) {
+			return nil, ErrPrivateAddress
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if !f.accepts(resp.Header.Get("Content-Type")) {
+		return nil, fmt.Errorf("%w %q", ErrContentType, resp.Header.Get("Content-Type"))
+	}
+	if resp.ContentLength > f.maxBytes {
+		return nil, fmt.Errorf("document is larger than %d bytes", f.maxBytes)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, f.maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > f.maxBytes {
+		return nil, fmt.Errorf("document is larger than %d bytes", f.maxBytes)
+	}
+	return data, nil
+}
+
+// accepts reports whether a document of a content type is accepted
+func (f *Fetcher) accepts(contentType string) bool {
+	if len(f.contentTypes) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, accepted := range f.contentTypes {
+		if mediaType == accepted {
+			return true
+		}
+	}
+	return false
+}
+
+// isPrivate reports whether an address belongs to this host or an internal network
+func isPrivate(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified()
+}
@@ -0,0 +1,94 @@
+package webfetch
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetcher_Fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sitemap.xml":
+			w.Write([]byte("<urlset/>"))
+		case "/large.xml":
+			w.Write([]byte(strings.Repeat("x", 64)))
+		case "/moved.xml":
+			http.Redirect(w, r, "/sitemap.xml", http.StatusMovedPermanently)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	// The test server listens on loopback
+	fetcher := NewFetcher(32, time.Second, true)
+
+	data, err := fetcher.Fetch(context.Background(), server.URL+"/sitemap.xml")
+	require.NoError(t, err)
+	assert.Equal(t, "<urlset/>", string(data))
+
+	data, err = fetcher.Fetch(context.Background(), server.URL+"/moved.xml")
+	require.NoError(t, err)
+	assert.Equal(t, "<urlset/>", string(data))
+
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/large.xml")
+	assert.EqualError(t, err, "document is larger than 32 bytes")
+
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/missing.xml")
+	assert.EqualError(t, err, "unexpected status 404")
+}
+
+func TestFetcher_ContentTypes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "image/png, image/jpeg", r.Header.Get("Accept"))
+		switch r.URL.Path {
+		case "/cover.png":
+			w.Header().Set("Content-Type", "image/png")
+		case "/cover.jpg":
+			w.Header().Set("Content-Type", "image/jpeg; charset=binary")
+		default:
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		}
+		w.Write([]byte("image"))
+	}))
+	defer server.Close()
+	fetcher := NewFetcher(32, time.Second, true)
+	fetcher.SetContentTypes("image/png", "image/jpeg")
+
+	for _, path := range []string{"/cover.png", "/cover.jpg"} {
+		data, err := fetcher.Fetch(context.Background(), server.URL+path)
+		require.NoError(t, err, path)
+		assert.Equal(t, "image", string(data))
+	}
+
+	_, err := fetcher.Fetch(context.Background(), server.URL+"/cover.html")
+	assert.ErrorIs(t, err, ErrContentType)
+	assert.EqualError(t, err, `unexpected content type "text/html; charset=utf-8"`)
+}
+
+func TestFetcher_RefusesPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("private server was reached")
+	}))
+	defer server.Close()
+	fetcher := NewFetcher(1024, time.Second, false)
+
+	_, err := fetcher.Fetch(context.Background(), server.URL+"/sitemap.xml")
+	assert.ErrorIs(t, err, ErrPrivateAddress)
+}
+
+func TestIsPrivate(t *testing.T) {
+	for _, address := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "::1", "fd00::1", "0.0.0.0"} {
+		assert.True(t, isPrivate(net.ParseIP(address)), address)
+	}
+	for _, address := range []string{"93.184.216.34", "2606:4700::1111"} {
+		assert.False(t, isPrivate(net.ParseIP(address)), address)
+	}
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// AccessTokenRepositoryImpl implements the AccessTokenRepository interface
+type AccessTokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAccessTokenRepository creates a new access token repository
+func NewAccessTokenRepository(db *gorm.DB) repositories.AccessTokenRepository {
+	return &AccessTokenRepositoryImpl{db: db}
+}
+
+// Create creates a new access token
+func (r *AccessTokenRepositoryImpl) Create(token *entities.AccessToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetByID retrieves an access token by ID
+func (r *AccessTokenRepositoryImpl) GetByID(id string) (*entities.AccessToken, error) {
+	return r.first("id = ?", id)
+}
+
+// FindByHash finds the access token with a hash
+func (r *AccessTokenRepositoryImpl) FindByHash(hash string) (*entities.AccessToken, error) {
+	return r.first("token_hash = ?", hash)
+}
+
+// ListByUser lists the access tokens of a user, newest first
+func (r *AccessTokenRepositoryImpl) ListByUser(userID string) ([]entities.AccessToken, error) {
+	var tokens []entities.AccessToken
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC, id DESC").Find(&tokens).Error
+	return tokens, err
+}
+
+// Update updates an existing access token
+func (r *AccessTokenRepositoryImpl) Update(token *entities.AccessToken) error {
+	return r.db.Save(token).Error
+}
+
+// first returns the first access token matching a condition, nil when none does
+func (r *AccessTokenRepositoryImpl) first(query string, value string) (*entities.AccessToken, error) {
+	var token entities.AccessToken
+	err := r.db.Where(query, value).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
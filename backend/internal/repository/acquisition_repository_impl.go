@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// AcquisitionRepositoryImpl implements the AcquisitionRepository interface
+type AcquisitionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAcquisitionRepository creates a new acquisition repository
+func NewAcquisitionRepository(db *gorm.DB) repositories.AcquisitionRepository {
+	return &AcquisitionRepositoryImpl{db: db}
+}
+
+// Create creates a new acquisition
+func (r *AcquisitionRepositoryImpl) Create(acquisition *entities.Acquisition) error {
+	return r.db.Create(acquisition).Error
+}
+
+// GetByID retrieves an acquisition by ID
+func (r *AcquisitionRepositoryImpl) GetByID(id string) (*entities.Acquisition, error) {
+	var acquisition entities.Acquisition
+	err := r.db.Where("id = ?", id).First(&acquisition).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &acquisition, nil
+}
+
+// List retrieves acquisitions oldest first, optionally filtered by status
+func (r *AcquisitionRepositoryImpl) List(status string) ([]entities.Acquisition, error) {
+	var acquisitions []entities.Acquisition
+	query := r.db
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("created_at ASC").Find(&acquisitions).Error
+	return acquisitions, err
+}
+
+// Update updates an existing acquisition
+func (r *AcquisitionRepositoryImpl) Update(acquisition *entities.Acquisition) error {
+	return r.db.Save(acquisition).Error
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// analyticsKey identifies the hourly count of an event
+type analyticsKey struct {
+	hour time.Time
+	kind string
+	name string
+}
+
+// AnalyticsCounter counts usage events in memory and adds them to the hourly counts every flush
+// interval, so that requests do not write to the database. A flush that fails keeps its counts
+// for the next one. Events counted since the last flush are lost if the process exits without
+// Close.
+type AnalyticsCounter struct {
+	repo          repositories.AnalyticsRepository
+	flushInterval time.Duration
+	clock         clock.Clock
+
+	mu     sync.Mutex
+	counts map[analyticsKey]int64
+	closed bool
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// NewAnalyticsCounter creates a counter in front of repo and starts flushing it every flushInterval
+func NewAnalyticsCounter(repo repositories.AnalyticsRepository, flushInterval time.Duration) *AnalyticsCounter {
+	if flushInterval <= 0 {
+		flushInterval = time.Minute
+	}
+	c := &AnalyticsCounter{
+		repo:          repo,
+		flushInterval: flushInterval,
+		clock:         clock.System{},
+		counts:        make(map[analyticsKey]int64),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go c.run()
+	return c
+}
+
+// SetClock replaces the clock that events are dated with
+func (c *AnalyticsCounter) SetClock(clk clock.Clock) {
+	c.mu.Lock()
+	c.clock = clk
+	c.mu.Unlock()
+}
+
+// Record counts an event in the current hour
+func (c *AnalyticsCounter) Record(kind, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[analyticsKey{hour: c.clock.Now().UTC().Truncate(time.Hour), kind: kind, name: name}]++
+}
+
+// Flush adds the events counted so far to the hourly counts
+func (c *AnalyticsCounter) Flush() error {
+	c.mu.Lock()
+	counts := c.counts
+	c.counts = make(map[analyticsKey]int64)
+	c.mu.Unlock()
+
+	events := make([]entities.AnalyticsEvent, 0, len(counts))
+	for key, count := range counts {
+		events = append(events, entities.AnalyticsEvent{Hour: key.hour, Kind: key.kind, Name: key.name, Count: count})
+	}
+	if err := c.repo.Add(events); err != nil {
+		c.mu.Lock()
+		for key, count := range counts {
+			c.counts[key] += count
+		}
+		c.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Close stops the periodic flushes and flushes the events left, until ctx ends
+func (c *AnalyticsCounter) Close(ctx context.Context) error {
+	c.mu.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.stop)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run flushes the counts every flush interval and once more when the counter is closed
+func (c *AnalyticsCounter) run() {
+	defer close(c.done)
+	ticker := time.NewTicker(c.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.Flush(); err != nil {
+				log.Printf("Failed to flush usage analytics, retrying next time: %v", err)
+			}
+		case <-c.stop:
+			if err := c.Flush(); err != nil {
+				log.Printf("Dropped usage analytics on shutdown: %v", err)
+			}
+			return
+		}
+	}
+}
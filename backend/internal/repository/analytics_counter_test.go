@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAnalyticsRepository adds up the events written to it and fails while failing is set
+type fakeAnalyticsRepository struct {
+	mu      sync.Mutex
+	events  map[analyticsKey]int64
+	failing bool
+}
+
+func (r *fakeAnalyticsRepository) Add(events []entities.AnalyticsEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failing {
+		return errors.New("database unavailable")
+	}
+	if r.events == nil {
+		r.events = make(map[analyticsKey]int64)
+	}
+	for _, event := range events {
+		r.events[analyticsKey{hour: event.Hour, kind: event.Kind, name: event.Name}] += event.Count
+	}
+	return nil
+}
+
+func (r *fakeAnalyticsRepository) Totals(kind string, since time.Time) ([]entities.AnalyticsCount, error) {
+	return nil, nil
+}
+
+func TestAnalyticsCounter_CountsEventsPerHour(t *testing.T) {
+	repo := &fakeAnalyticsRepository{failing: true}
+	now := clock.NewFixed(time.Date(2026, 10, 16, 9, 59, 0, 0, time.UTC))
+	counter := NewAnalyticsCounter(repo, time.Hour)
+	counter.SetClock(now)
+
+	counter.Record(entities.AnalyticsEndpoint, "GET /api/books/:id")
+	counter.Record(entities.AnalyticsEndpoint, "GET /api/books/:id")
+	counter.Record(entities.AnalyticsFeature, "hal")
+	assert.Error(t, counter.Flush())
+
+	repo.mu.Lock()
+	repo.failing = false
+	repo.mu.Unlock()
+	now.Advance(time.Minute)
+	counter.Record(entities.AnalyticsEndpoint, "GET /api/books/:id")
+	require.NoError(t, counter.Close(context.Background()))
+
+	nine := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, map[analyticsKey]int64{
+		{hour: nine, kind: entities.AnalyticsEndpoint, name: "GET /api/books/:id"}:                2,
+		{hour: nine, kind: entities.AnalyticsFeature, name: "hal"}:                                1,
+		{hour: nine.Add(time.Hour), kind: entities.AnalyticsEndpoint, name: "GET /api/books/:id"}: 1,
+	}, repo.events)
+}
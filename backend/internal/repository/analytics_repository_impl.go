@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AnalyticsRepositoryImpl implements the AnalyticsRepository interface
+type AnalyticsRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAnalyticsRepository creates a new analytics repository
+func NewAnalyticsRepository(db *gorm.DB) repositories.AnalyticsRepository {
+	return &AnalyticsRepositoryImpl{db: db}
+}
+
+// Add adds the counts of events to those already recorded, creating the rows missing
+func (r *AnalyticsRepositoryImpl) Add(events []entities.AnalyticsEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "hour"}, {Name: "kind"}, {Name: "name"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"count": gorm.Expr("analytics_events.count + EXCLUDED.count"),
+		}),
+	}).Create(&events).Error
+}
+
+// Totals returns the counts of the events of a kind since a time, most used first
+func (r *AnalyticsRepositoryImpl) Totals(kind string, since time.Time) ([]entities.AnalyticsCount, error) {
+	var totals []entities.AnalyticsCount
+	err := r.db.Model(&entities.AnalyticsEvent{}).
+		Select("name, SUM(count) AS count").
+		Where("kind = ? AND hour >= ?", kind, since).
+		Group("name").
+		Order("count DESC, name").
+		Scan(&totals).Error
+	return totals, err
+}
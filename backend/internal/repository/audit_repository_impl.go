@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// AuditRepositoryImpl implements the AuditRepository interface
+type AuditRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository creates a new audit repository
+func NewAuditRepository(db *gorm.DB) repositories.AuditRepository {
+	return &AuditRepositoryImpl{db: db}
+}
+
+// Create creates a new audit entry
+func (r *AuditRepositoryImpl) Create(entry *entities.AuditEntry) error {
+	return r.db.Create(entry).Error
+}
+
+// CreateBatch creates several audit entries in one insert
+func (r *AuditRepositoryImpl) CreateBatch(entries []entities.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return r.db.Create(&entries).Error
+}
+
+// ListByEntity retrieves the audit trail of an entity, oldest first
+func (r *AuditRepositoryImpl) ListByEntity(entityType, entityID string) ([]entities.AuditEntry, error) {
+	var entries []entities.AuditEntry
+	err := r.db.Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("created_at ASC").
+		Find(&entries).Error
+	return entries, err
+}
+
+// ListByAction retrieves the entries of an action made from from until to, oldest first
+func (r *AuditRepositoryImpl) ListByAction(action string, from, to time.Time) ([]entities.AuditEntry, error) {
+	var entries []entities.AuditEntry
+	err := r.db.Where("action = ? AND created_at >= ? AND created_at < ?", action, from, to).
+		Order("created_at ASC").
+		Find(&entries).Error
+	return entries, err
+}
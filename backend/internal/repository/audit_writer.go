@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// AuditWriter writes audit entries behind the requests that record them: Create only puts the
+// entry in a bounded buffer, and a background writer inserts the buffer in batches. When the
+// buffer is full, entries are dropped rather than slowing requests down, and a batch that cannot
+// be written after maxAttempts is dropped too; both are counted. Reads go straight to the
+// repository, so an entry shows up in them once its batch is written.
+type AuditWriter struct {
+	repo          repositories.AuditRepository
+	entries       chan entities.AuditEntry
+	batchSize     int
+	flushInterval time.Duration
+	maxAttempts   int
+
+	// mu guards closed, so that no entry is sent once the buffer is closed
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+
+	written       atomic.Int64
+	droppedFull   atomic.Int64
+	droppedFailed atomic.Int64
+	failedWrites  atomic.Int64
+}
+
+// NewAuditWriter creates a writer buffering up to size entries in front of repo and starts its
+// background writer. Batches of up to batchSize entries are written at least every flushInterval.
+func NewAuditWriter(repo repositories.AuditRepository, size, batchSize int, flushInterval time.Duration, maxAttempts int) *AuditWriter {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	w := &AuditWriter{
+		repo:          repo,
+		entries:       make(chan entities.AuditEntry, size),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		maxAttempts:   maxAttempts,
+		done:          make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Create buffers an audit entry, stamping it with the current time. Once the writer is closed,
+// entries are written synchronously instead.
+func (w *AuditWriter) Create(entry *entities.AuditEntry) error {
+	if err := entry.BeforeCreate(nil); err != nil {
+		return err
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = entities.Now()
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return w.repo.Create(entry)
+	}
+	select {
+	case w.entries <- *entry:
+	default:
+		// Log the first drop of every thousand, so a full buffer does not flood the logs too
+		if dropped := w.droppedFull.Add(1); dropped%1000 == 1 {
+			log.Printf("Audit buffer is full, %d audit entries dropped so far", dropped)
+		}
+	}
+	return nil
+}
+
+// CreateBatch writes several audit entries synchronously
+func (w *AuditWriter) CreateBatch(entries []entities.AuditEntry) error {
+	return w.repo.CreateBatch(entries)
+}
+
+// ListByEntity retrieves the written audit trail of an entity, oldest first
+func (w *AuditWriter) ListByEntity(entityType, entityID string) ([]entities.AuditEntry, error) {
+	return w.repo.ListByEntity(entityType, entityID)
+}
+
+// ListByAction retrieves the written entries of an action made from from until to, oldest first
+func (w *AuditWriter) ListByAction(action string, from, to time.Time) ([]entities.AuditEntry, error) {
+	return w.repo.ListByAction(action, from, to)
+}
+
+// Stats returns the buffer usage of the writer and its counts since it started
+func (w *AuditWriter) Stats() entities.AuditWriterStats {
+	return entities.AuditWriterStats{
+		Enabled:       true,
+		Buffered:      len(w.entries),
+		Capacity:      cap(w.entries),
+		Written:       w.written.Load(),
+		DroppedFull:   w.droppedFull.Load(),
+		DroppedFailed: w.droppedFailed.Load(),
+		FailedWrites:  w.failedWrites.Load(),
+	}
+}
+
+// Close stops buffering and waits for the buffered entries to be written, until ctx ends. Entries
+// recorded afterwards are written synchronously.
+func (w *AuditWriter) Close(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.entries)
+	}
+	w.mu.Unlock()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		log.Printf("Gave up draining the audit buffer with %d entries left", len(w.entries))
+		return ctx.Err()
+	}
+}
+
+// run collects buffered entries into batches and writes them when full, every flush interval
+// and once the buffer is closed
+func (w *AuditWriter) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]entities.AuditEntry, 0, w.batchSize)
+	for {
+		select {
+		case entry, ok := <-w.entries:
+			if !ok {
+				w.write(batch)
+				return
+			}
+			batch = append(batch, entry)
+			if len(batch) >= w.batchSize {
+				w.write(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			w.write(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+// write inserts a batch, retrying after a flush interval when the database is unavailable, and
+// drops it after the last attempt
+func (w *AuditWriter) write(batch []entities.AuditEntry) {
+	if len(batch) == 0 {
+		return
+	}
+	for attempt := 1; ; attempt++ {
+		err := w.repo.CreateBatch(batch)
+		if err == nil {
+			w.written.Add(int64(len(batch)))
+			return
+		}
+		w.failedWrites.Add(1)
+		if attempt >= w.maxAttempts {
+			w.droppedFailed.Add(int64(len(batch)))
+			log.Printf("Dropped %d audit entries after %d failed attempts: %v", len(batch), attempt, err)
+			return
+		}
+		time.Sleep(w.flushInterval)
+	}
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAuditRepository records the batches written to it and fails while failing is set
+type fakeAuditRepository struct {
+	mu      sync.Mutex
+	batches [][]entities.AuditEntry
+	failing bool
+	// block, when set, holds every batch write until it is closed
+	block chan struct{}
+}
+
+func (r *fakeAuditRepository) Create(entry *entities.AuditEntry) error {
+	return r.CreateBatch([]entities.AuditEntry{*entry})
+}
+
+func (r *fakeAuditRepository) CreateBatch(entries []entities.AuditEntry) error {
+	if r.block != nil {
+		<-r.block
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failing {
+		return errors.New("database unavailable")
+	}
+	r.batches = append(r.batches, append([]entities.AuditEntry(nil), entries...))
+	return nil
+}
+
+func (r *fakeAuditRepository) ListByEntity(entityType, entityID string) ([]entities.AuditEntry, error) {
+	return nil, nil
+}
+
+func (r *fakeAuditRepository) ListByAction(action string, from, to time.Time) ([]entities.AuditEntry, error) {
+	return nil, nil
+}
+
+func (r *fakeAuditRepository) written() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sizes := make([]int, len(r.batches))
+	for i, batch := range r.batches {
+		sizes[i] = len(batch)
+	}
+	return sizes
+}
+
+func auditEntry(id string) *entities.AuditEntry {
+	return &entities.AuditEntry{EntityType: "book", EntityID: id, Action: entities.AuditActionCreated}
+}
+
+func TestAuditWriter_WritesInBatches(t *testing.T) {
+	repo := &fakeAuditRepository{}
+	writer := NewAuditWriter(repo, 10, 2, time.Hour, 1)
+
+	for _, id := range []string{"1", "2", "3"} {
+		entry := auditEntry(id)
+		require.NoError(t, writer.Create(entry))
+		assert.NotEmpty(t, entry.ID)
+		assert.False(t, entry.CreatedAt.IsZero())
+	}
+
+	// The full batch is written straight away, the last entry only once the buffer is drained
+	assert.Eventually(t, func() bool { return len(repo.written()) == 1 }, time.Second, time.Millisecond)
+	require.NoError(t, writer.Close(context.Background()))
+	assert.Equal(t, []int{2, 1}, repo.written())
+	assert.Equal(t, int64(3), writer.Stats().Written)
+}
+
+func TestAuditWriter_FlushesPartialBatches(t *testing.T) {
+	repo := &fakeAuditRepository{}
+	writer := NewAuditWriter(repo, 10, 100, 5*time.Millisecond, 1)
+	defer writer.Close(context.Background())
+
+	require.NoError(t, writer.Create(auditEntry("1")))
+
+	assert.Eventually(t, func() bool { return len(repo.written()) == 1 }, time.Second, time.Millisecond)
+}
+
+func TestAuditWriter_DropsWhenBufferIsFull(t *testing.T) {
+	repo := &fakeAuditRepository{block: make(chan struct{})}
+	writer := NewAuditWriter(repo, 1, 1, time.Hour, 1)
+
+	// The first entry is taken by the writer, which blocks on it; the second fills the buffer
+	require.NoError(t, writer.Create(auditEntry("1")))
+	assert.Eventually(t, func() bool { return writer.Stats().Buffered == 0 }, time.Second, time.Millisecond)
+	require.NoError(t, writer.Create(auditEntry("2")))
+	require.NoError(t, writer.Create(auditEntry("3")))
+
+	stats := writer.Stats()
+	assert.True(t, stats.Enabled)
+	assert.Equal(t, 1, stats.Buffered)
+	assert.Equal(t, 1, stats.Capacity)
+	assert.Equal(t, int64(1), stats.DroppedFull)
+
+	close(repo.block)
+	require.NoError(t, writer.Close(context.Background()))
+	assert.Equal(t, int64(2), writer.Stats().Written)
+}
+
+func TestAuditWriter_DropsBatchAfterFailedAttempts(t *testing.T) {
+	repo := &fakeAuditRepository{failing: true}
+	writer := NewAuditWriter(repo, 10, 2, time.Millisecond, 3)
+
+	require.NoError(t, writer.Create(auditEntry("1")))
+	require.NoError(t, writer.Create(auditEntry("2")))
+	require.NoError(t, writer.Close(context.Background()))
+
+	stats := writer.Stats()
+	assert.Equal(t, int64(0), stats.Written)
+	assert.Equal(t, int64(2), stats.DroppedFailed)
+	assert.Equal(t, int64(3), stats.FailedWrites)
+	assert.Empty(t, repo.written())
+}
+
+func TestAuditWriter_WritesSynchronouslyOnceClosed(t *testing.T) {
+	repo := &fakeAuditRepository{}
+	writer := NewAuditWriter(repo, 10, 10, time.Hour, 1)
+	require.NoError(t, writer.Close(context.Background()))
+
+	require.NoError(t, writer.Create(auditEntry("1")))
+
+	assert.Equal(t, []int{1}, repo.written())
+}
+
+func TestAuditWriter_CloseGivesUpWhenContextEnds(t *testing.T) {
+	repo := &fakeAuditRepository{block: make(chan struct{})}
+	defer close(repo.block)
+	writer := NewAuditWriter(repo, 10, 1, time.Hour, 1)
+	require.NoError(t, writer.Create(auditEntry("1")))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, writer.Close(ctx), context.DeadlineExceeded)
+}
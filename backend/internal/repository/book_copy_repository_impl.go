@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// BookCopyRepositoryImpl implements the BookCopyRepository interface
+type BookCopyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewBookCopyRepository creates a new book copy repository
+func NewBookCopyRepository(db *gorm.DB) repositories.BookCopyRepository {
+	return &BookCopyRepositoryImpl{db: db}
+}
+
+// NextAccessionNumber increments the counter of a prefix in a single statement, creating it on
+// first use, so concurrent callers are serialized by the row lock and each gets its own number
+func (r *BookCopyRepositoryImpl) NextAccessionNumber(prefix string) (int64, error) {
+	var value int64
+	err := r.db.Raw(
+		`INSERT INTO accession_counters (prefix, value) VALUES (?, 1)
+		ON CONFLICT (prefix) DO UPDATE SET value = accession_counters.value + 1
+		RETURNING value`, prefix,
+	).Scan(&value).Error
+	return value, err
+}
+
+// Create creates a new book copy
+func (r *BookCopyRepositoryImpl) Create(bookCopy *entities.BookCopy) error {
+	return r.db.Create(bookCopy).Error
+}
+
+// ListByBook lists the copies of a book by accession number
+func (r *BookCopyRepositoryImpl) ListByBook(bookID string) ([]entities.BookCopy, error) {
+	var copies []entities.BookCopy
+	err := r.db.Where("book_id = ?", bookID).Order("accession_number ASC").Find(&copies).Error
+	return copies, err
+}
+
+// FindByAccessionNumber finds the copy with an accession number
+func (r *BookCopyRepositoryImpl) FindByAccessionNumber(number string) (*entities.BookCopy, error) {
+	var bookCopy entities.BookCopy
+	err := r.db.Where("accession_number = ?", number).First(&bookCopy).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &bookCopy, nil
+}
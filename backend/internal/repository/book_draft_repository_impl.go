@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// BookDraftRepositoryImpl implements the BookDraftRepository interface
+type BookDraftRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewBookDraftRepository creates a new book draft repository
+func NewBookDraftRepository(db *gorm.DB) repositories.BookDraftRepository {
+	return &BookDraftRepositoryImpl{db: db}
+}
+
+// Save creates or replaces the draft of a book
+func (r *BookDraftRepositoryImpl) Save(draft *entities.BookDraft) error {
+	return r.db.Save(draft).Error
+}
+
+// GetByBookID retrieves the draft of a book
+func (r *BookDraftRepositoryImpl) GetByBookID(bookID string) (*entities.BookDraft, error) {
+	var draft entities.BookDraft
+	err := r.db.Where("book_id = ?", bookID).First(&draft).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &draft, nil
+}
+
+// Delete deletes the draft of a book
+func (r *BookDraftRepositoryImpl) Delete(bookID string) error {
+	return r.db.Where("book_id = ?", bookID).Delete(&entities.BookDraft{}).Error
+}
+
+// Publish updates a book with its draft applied and deletes the draft, in one transaction
+func (r *BookDraftRepositoryImpl) Publish(book *entities.Book) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(book).Select(editableBookColumns).Updates(book).Error; err != nil {
+			return err
+		}
+		return tx.Where("book_id = ?", book.ID).Delete(&entities.BookDraft{}).Error
+	})
+}
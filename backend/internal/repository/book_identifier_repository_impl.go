@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// BookIdentifierRepositoryImpl implements the BookIdentifierRepository interface
+type BookIdentifierRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewBookIdentifierRepository creates a new book identifier repository
+func NewBookIdentifierRepository(db *gorm.DB) repositories.BookIdentifierRepository {
+	return &BookIdentifierRepositoryImpl{db: db}
+}
+
+// Create creates a new book identifier
+func (r *BookIdentifierRepositoryImpl) Create(identifier *entities.BookIdentifier) error {
+	return r.db.Create(identifier).Error
+}
+
+// ListByBook lists the identifiers of a book by type and value
+func (r *BookIdentifierRepositoryImpl) ListByBook(bookID string) ([]entities.BookIdentifier, error) {
+	var identifiers []entities.BookIdentifier
+	err := r.db.Where("book_id = ?", bookID).Order("type ASC, value ASC").Find(&identifiers).Error
+	return identifiers, err
+}
+
+// FindByValue finds the identifier of a type with a normalized value
+func (r *BookIdentifierRepositoryImpl) FindByValue(kind, value string) (*entities.BookIdentifier, error) {
+	var identifier entities.BookIdentifier
+	err := r.db.Where("type = ? AND value = ?", kind, value).First(&identifier).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &identifier, nil
+}
+
+// Delete deletes an identifier of a book, reporting whether the book had it
+func (r *BookIdentifierRepositoryImpl) Delete(bookID, id string) (bool, error) {
+	result := r.db.Where("id = ? AND book_id = ?", id, bookID).Delete(&entities.BookIdentifier{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// DeleteByBook deletes every identifier of a book
+func (r *BookIdentifierRepositoryImpl) DeleteByBook(bookID string) error {
+	return r.db.Where("book_id = ?", bookID).Delete(&entities.BookIdentifier{}).Error
+}
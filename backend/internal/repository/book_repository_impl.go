@@ -2,13 +2,28 @@ package repository
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"time"
 
 	"library-management-system/internal/domain/entities"
 	"library-management-system/internal/domain/repositories"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// editableBookColumns are the columns updates write, selected so optional fields such as
+// publisher_id can be cleared; updated_at is set automatically without affecting created_at
+var editableBookColumns = []string{"title", "author", "year", "isbn", "slug", "publisher_id", "series_id", "series_position", "status", "metadata", "updated_at"}
+
+// upsertByISBN overwrites the catalog fields of the book whose ISBN an insert takes; the book
+// keeps its ID, slug, status and place in series and works
+var upsertByISBN = clause.OnConflict{
+	Columns:   []clause.Column{{Name: "isbn"}},
+	DoUpdates: clause.AssignmentColumns([]string{"title", "author", "year", "publisher_id", "metadata", "updated_at"}),
+}
+
 // BookRepositoryImpl implements the BookRepository interface
 type BookRepositoryImpl struct {
 	db *gorm.DB
@@ -24,6 +39,80 @@ func (r *BookRepositoryImpl) Create(book *entities.Book) error {
 	return r.db.Create(book).Error
 }
 
+// maxInsertRows bounds the rows of an INSERT statement, as Postgres binds at most 65535
+// parameters a statement; larger chunks take several statements within their transaction
+const maxInsertRows = 1000
+
+// CreateBatch inserts books in chunks, one transaction per chunk, handling taken ISBNs as onConflict says
+func (r *BookRepositoryImpl) CreateBatch(books []entities.Book, chunkSize int, onConflict repositories.BatchConflict) (int64, error) {
+	if chunkSize < 1 {
+		chunkSize = len(books)
+	}
+
+	conflict := clause.OnConflict{Columns: []clause.Column{{Name: "isbn"}}, DoNothing: true}
+	if onConflict == repositories.BatchConflictUpsert {
+		conflict = upsertByISBN
+	}
+
+	var affected int64
+	for start := 0; start < len(books); start += chunkSize {
+		chunk := books[start:min(start+chunkSize, len(books))]
+		var rows int64
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			result := tx.Clauses(conflict).CreateInBatches(chunk, min(chunkSize, maxInsertRows))
+			rows = result.RowsAffected
+			return result.Error
+		})
+		if err != nil {
+			return affected, err
+		}
+		affected += rows
+	}
+	return affected, nil
+}
+
+// Upsert inserts books or overwrites the books having their ISBN, in one transaction. Each book
+// is filled with its stored row, so a book was created when it kept the ID it was given. The
+// overwrite of a book with a version is conditional on updated_at, checked by the same statement,
+// so a book changed after the caller read it is left as it is and the transaction rolled back.
+func (r *BookRepositoryImpl) Upsert(books []entities.Book, versions []*time.Time) ([]bool, error) {
+	created := make([]bool, len(books))
+	var stale []int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range books {
+			book := &books[i]
+			if err := book.BeforeCreate(tx); err != nil {
+				return err
+			}
+			id := book.ID
+			conflict := upsertByISBN
+			if i < len(versions) && versions[i] != nil {
+				conflict.Where = clause.Where{Exprs: []clause.Expression{
+					clause.Lte{Column: clause.Column{Table: "books", Name: "updated_at"}, Value: *versions[i]},
+				}}
+			}
+			result := tx.Clauses(conflict, clause.Returning{}).Create(book)
+			if result.Error != nil {
+				return result.Error
+			}
+			// The conditional update returns no row when the stored book is newer
+			if result.RowsAffected == 0 {
+				stale = append(stale, i)
+				continue
+			}
+			created[i] = book.ID == id
+		}
+		if len(stale) > 0 {
+			return &repositories.StaleUpsertError{Stale: stale}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
 // GetByID retrieves a book by ID
 func (r *BookRepositoryImpl) GetByID(id string) (*entities.Book, error) {
 	var book entities.Book
@@ -37,6 +126,19 @@ func (r *BookRepositoryImpl) GetByID(id string) (*entities.Book, error) {
 	return &book, nil
 }
 
+// GetByIDInBranch retrieves a book by ID if it belongs to the branch
+func (r *BookRepositoryImpl) GetByIDInBranch(id, branchID string) (*entities.Book, error) {
+	var book entities.Book
+	err := r.db.Where("id = ? AND branch_id = ?", id, branchID).First(&book).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &book, nil
+}
+
 // GetAll retrieves all books
 func (r *BookRepositoryImpl) GetAll() ([]entities.Book, error) {
 	var books []entities.Book
@@ -46,14 +148,27 @@ func (r *BookRepositoryImpl) GetAll() ([]entities.Book, error) {
 
 // Update updates a book
 func (r *BookRepositoryImpl) Update(book *entities.Book) error {
-	// Use Updates with a struct to only update non-zero fields and preserve timestamps
-	// This automatically sets updated_at without affecting created_at
-	return r.db.Model(book).Updates(book).Error
+	return r.db.Model(book).Select(editableBookColumns).Updates(book).Error
 }
 
-// Delete deletes a book (soft delete)
+// Delete soft deletes a book, like DeleteBatch: it keeps its row and ISBN so it can be restored.
+// DeletedAt is not a gorm.DeletedAt, so db.Delete would remove the row.
 func (r *BookRepositoryImpl) Delete(id string) error {
-	return r.db.Delete(&entities.Book{}, "id = ?", id).Error
+	return r.db.Model(&entities.Book{}).Where("id = ? AND deleted_at IS NULL", id).Update("deleted_at", entities.Now()).Error
+}
+
+// DeleteBatch soft deletes books in one transaction, rolling back unless every one was live
+func (r *BookRepositoryImpl) DeleteBatch(ids []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.Book{}).Where("id IN ? AND deleted_at IS NULL", ids).Update("deleted_at", entities.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(ids)) {
+			return fmt.Errorf("deleted %d of %d books, as the others changed meanwhile", result.RowsAffected, len(ids))
+		}
+		return nil
+	})
 }
 
 // HardDelete permanently deletes a book
@@ -95,6 +210,62 @@ func (r *BookRepositoryImpl) FindByISBN(isbn string) (*entities.Book, error) {
 	return &book, nil
 }
 
+// FindBySlug finds a book by slug
+func (r *BookRepositoryImpl) FindBySlug(slug string) (*entities.Book, error) {
+	var book entities.Book
+	err := r.db.Unscoped().Where("slug = ?", slug).First(&book).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &book, nil
+}
+
+// FindByPublishers finds books published by any of the given publishers
+func (r *BookRepositoryImpl) FindByPublishers(publisherIDs []string) ([]entities.Book, error) {
+	var books []entities.Book
+	err := r.db.Where("publisher_id IN ?", publisherIDs).Find(&books).Error
+	return books, err
+}
+
+// FindByMetadata finds the books having each metadata key with the value, compared as text
+func (r *BookRepositoryImpl) FindByMetadata(filters map[string]string) ([]entities.Book, error) {
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	query := r.db
+	for _, key := range keys {
+		query = query.Where("metadata ->> ? = ?", key, filters[key])
+	}
+	var books []entities.Book
+	err := query.Find(&books).Error
+	return books, err
+}
+
+// FindBySeries finds the books of a series ordered by their position
+func (r *BookRepositoryImpl) FindBySeries(seriesID string) ([]entities.Book, error) {
+	var books []entities.Book
+	err := r.db.Where("series_id = ?", seriesID).Order("series_position ASC NULLS LAST").Find(&books).Error
+	return books, err
+}
+
+// FindByWork finds the editions of a work, oldest first
+func (r *BookRepositoryImpl) FindByWork(workID string) ([]entities.Book, error) {
+	var books []entities.Book
+	err := r.db.Where("work_id = ?", workID).Order("year ASC").Find(&books).Error
+	return books, err
+}
+
+// SetWork links books to a work, or unlinks them when workID is nil
+func (r *BookRepositoryImpl) SetWork(bookIDs []string, workID *string) error {
+	return r.db.Model(&entities.Book{}).Where("id IN ?", bookIDs).Update("work_id", workID).Error
+}
+
 // GetDeletedBooks retrieves all soft-deleted books
 func (r *BookRepositoryImpl) GetDeletedBooks() ([]entities.Book, error) {
 	var books []entities.Book
@@ -102,7 +273,47 @@ func (r *BookRepositoryImpl) GetDeletedBooks() ([]entities.Book, error) {
 	return books, err
 }
 
+// FindScheduled finds the books waiting for their publication, soonest first
+func (r *BookRepositoryImpl) FindScheduled() ([]entities.Book, error) {
+	var books []entities.Book
+	err := r.db.Where("publish_at IS NOT NULL").Order("publish_at ASC").Find(&books).Error
+	return books, err
+}
+
+// MarkPublished clears the publish_at of a scheduled book, making it live
+func (r *BookRepositoryImpl) MarkPublished(id string) error {
+	return r.db.Model(&entities.Book{}).Where("id = ?", id).Update("publish_at", nil).Error
+}
+
+// SetCoverPalette records the colours of the cover of a book, or clears them when nil; the
+// column is selected so that a nil palette is written too
+func (r *BookRepositoryImpl) SetCoverPalette(id string, palette []string) error {
+	return r.db.Model(&entities.Book{ID: id}).Select("cover_palette", "updated_at").Updates(&entities.Book{CoverPalette: palette}).Error
+}
+
 // Restore restores a soft-deleted book
 func (r *BookRepositoryImpl) Restore(id string) error {
 	return r.db.Unscoped().Model(&entities.Book{}).Where("id = ?", id).Update("deleted_at", nil).Error
 }
+
+// RestoreBatch restores soft-deleted books in one transaction, rolling back unless every one was
+// deleted
+func (r *BookRepositoryImpl) RestoreBatch(ids []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&entities.Book{}).Where("id IN ? AND deleted_at IS NOT NULL", ids).Update("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(ids)) {
+			return fmt.Errorf("restored %d of %d books, as the others changed meanwhile", result.RowsAffected, len(ids))
+		}
+		return nil
+	})
+}
+
+// FindByIDsWithDeleted finds the books with the IDs, deleted or not
+func (r *BookRepositoryImpl) FindByIDsWithDeleted(ids []string) ([]entities.Book, error) {
+	var books []entities.Book
+	err := r.db.Unscoped().Where("id IN ?", ids).Find(&books).Error
+	return books, err
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestBookRepositoryImpl_DeleteKeepsTheRow(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	require.NoError(t, err)
+	var statements []string
+	record := func(tx *gorm.DB) { statements = append(statements, tx.Statement.SQL.String()) }
+	require.NoError(t, db.Callback().Update().After("gorm:update").Register("test:record", record))
+	require.NoError(t, db.Callback().Delete().After("gorm:delete").Register("test:record", record))
+	repo := &BookRepositoryImpl{db: db}
+
+	require.NoError(t, repo.Delete("book-1"))
+
+	// A deleted book keeps its row, and so its ISBN, to be restored, as with DeleteBatch
+	require.Len(t, statements, 1)
+	assert.Regexp(t, `^UPDATE "books" SET "deleted_at"=\$1,"updated_at"=\$2 WHERE id = \$3 AND deleted_at IS NULL`, statements[0])
+}
+
+// dryRunPool lets dry runs open transactions
+type dryRunPool struct{}
+
+func (p *dryRunPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	return p, nil
+}
+func (p *dryRunPool) Commit() error   { return nil }
+func (p *dryRunPool) Rollback() error { return nil }
+func (p *dryRunPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, errors.New("dry run")
+}
+func (p *dryRunPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, errors.New("dry run")
+}
+func (p *dryRunPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New("dry run")
+}
+func (p *dryRunPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+
+func TestBookRepositoryImpl_UpsertOverwritesOnlyTheCheckedVersion(t *testing.T) {
+	pool := &dryRunPool{}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: pool}), &gorm.Config{DryRun: true})
+	require.NoError(t, err)
+	var statements []string
+	require.NoError(t, db.Callback().Create().After("gorm:create").Register("test:record", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	}))
+	repo := &BookRepositoryImpl{db: db}
+	checked := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	// A dry run writes no row, as when the stored book was updated after the checked version
+	_, err = repo.Upsert([]entities.Book{{Title: "Mort", ISBN: "9780062225719"}}, []*time.Time{&checked})
+
+	var stale *repositories.StaleUpsertError
+	require.ErrorAs(t, err, &stale)
+	assert.Equal(t, []int{0}, stale.Stale)
+	require.Len(t, statements, 1)
+	assert.Regexp(t, `ON CONFLICT \("isbn"\) DO UPDATE SET .*"updated_at"="excluded"\."updated_at" WHERE "books"\."updated_at" <= \$\d+\s+RETURNING`, statements[0])
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"maps"
+	"sync"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// BookRepositoryISBNCache caches the results of FindByISBN, books not found included, so that the
+// duplicate checks of creates, updates and imports looking up the same ISBNs again skip the
+// database. Writes through it drop the entries of the books and ISBNs they touch; writes of other
+// instances show once entries expire. A stale entry can at worst let a duplicate ISBN through to
+// the unique index of the database, which refuses it.
+type BookRepositoryISBNCache struct {
+	repositories.BookRepository
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]isbnCacheEntry
+	// byID maps the books cached to their ISBN, for writes naming books by ID
+	byID map[string]string
+	// generation counts invalidations, so that a lookup racing a write does not cache what it
+	// read before the write
+	generation uint64
+	now        func() time.Time
+}
+
+type isbnCacheEntry struct {
+	// book is nil for ISBNs no book has
+	book      *entities.Book
+	expiresAt time.Time
+}
+
+// NewBookRepositoryWithISBNCache wraps repo, caching its ISBN lookups for ttl, at most maxEntries
+// of them
+func NewBookRepositoryWithISBNCache(repo repositories.BookRepository, ttl time.Duration, maxEntries int) repositories.BookRepository {
+	return &BookRepositoryISBNCache{
+		BookRepository: repo,
+		ttl:            ttl,
+		maxEntries:     maxEntries,
+		entries:        make(map[string]isbnCacheEntry),
+		byID:           make(map[string]string),
+		now:            time.Now,
+	}
+}
+
+// FindByISBN finds a book by ISBN, from the cache while its entry is fresh
+func (r *BookRepositoryISBNCache) FindByISBN(isbn string) (*entities.Book, error) {
+	r.mu.Lock()
+	entry, ok := r.entries[isbn]
+	if ok && r.now().Before(entry.expiresAt) {
+		r.mu.Unlock()
+		return copyBook(entry.book), nil
+	}
+	generation := r.generation
+	r.mu.Unlock()
+
+	book, err := r.BookRepository.FindByISBN(isbn)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.generation == generation {
+		r.store(isbn, copyBook(book))
+	}
+	return book, nil
+}
+
+// store caches a lookup, making room for it when the cache is full: expired entries are dropped
+// first, then arbitrary ones
+func (r *BookRepositoryISBNCache) store(isbn string, book *entities.Book) {
+	now := r.now()
+	if _, ok := r.entries[isbn]; !ok && len(r.entries) >= r.maxEntries {
+		for key, entry := range r.entries {
+			if !now.Before(entry.expiresAt) {
+				r.drop(key)
+			}
+		}
+		for key := range r.entries {
+			if len(r.entries) < r.maxEntries {
+				break
+			}
+			r.drop(key)
+		}
+	}
+	r.drop(isbn)
+	r.entries[isbn] = isbnCacheEntry{book: book, expiresAt: now.Add(r.ttl)}
+	if book != nil {
+		r.byID[book.ID] = isbn
+	}
+}
+
+// drop removes the entry of an ISBN
+func (r *BookRepositoryISBNCache) drop(isbn string) {
+	if entry, ok := r.entries[isbn]; ok && entry.book != nil {
+		delete(r.byID, entry.book.ID)
+	}
+	delete(r.entries, isbn)
+}
+
+// invalidate drops the entries of the ISBNs and of the books with the IDs after a write. Lookups
+// that read the database before the write, and finish after it, see the generation change and
+// leave their result uncached.
+func (r *BookRepositoryISBNCache) invalidate(isbns []string, ids []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.generation++
+	for _, isbn := range isbns {
+		r.drop(isbn)
+	}
+	for _, id := range ids {
+		if isbn, ok := r.byID[id]; ok {
+			r.drop(isbn)
+		}
+	}
+}
+
+// invalidateBooks drops the entries of books written, by their ISBN and, as a write may change the
+// ISBN of a book, by their ID
+func (r *BookRepositoryISBNCache) invalidateBooks(books ...entities.Book) {
+	isbns := make([]string, 0, len(books))
+	ids := make([]string, 0, len(books))
+	for _, book := range books {
+		isbns = append(isbns, book.ISBN)
+		if book.ID != "" {
+			ids = append(ids, book.ID)
+		}
+	}
+	r.invalidate(isbns, ids)
+}
+
+// copyBook copies a cached book, so that callers changing it leave the cache alone
+func copyBook(book *entities.Book) *entities.Book {
+	if book == nil {
+		return nil
+	}
+	copied := *book
+	copied.Metadata = maps.Clone(book.Metadata)
+	return &copied
+}
+
+// Create calls Create of the wrapped repository, then invalidates what it wrote
+func (r *BookRepositoryISBNCache) Create(book *entities.Book) error {
+	defer func() { r.invalidateBooks(*book) }()
+	return r.BookRepository.Create(book)
+}
+
+// CreateBatch calls CreateBatch of the wrapped repository, then invalidates what it wrote
+func (r *BookRepositoryISBNCache) CreateBatch(books []entities.Book, chunkSize int, onConflict repositories.BatchConflict) (int64, error) {
+	defer r.invalidateBooks(books...)
+	return r.BookRepository.CreateBatch(books, chunkSize, onConflict)
+}
+
+// Upsert calls Upsert of the wrapped repository, then invalidates what it wrote
+func (r *BookRepositoryISBNCache) Upsert(books []entities.Book, versions []*time.Time) ([]bool, error) {
+	defer r.invalidateBooks(books...)
+	return r.BookRepository.Upsert(books, versions)
+}
+
+// Update calls Update of the wrapped repository, then invalidates what it wrote
+func (r *BookRepositoryISBNCache) Update(book *entities.Book) error {
+	defer func() { r.invalidateBooks(*book) }()
+	return r.BookRepository.Update(book)
+}
+
+// Delete calls Delete of the wrapped repository, then invalidates what it wrote
+func (r *BookRepositoryISBNCache) Delete(id string) error {
+	defer r.invalidate(nil, []string{id})
+	return r.BookRepository.Delete(id)
+}
+
+// DeleteBatch calls DeleteBatch of the wrapped repository, then invalidates what it wrote
+func (r *BookRepositoryISBNCache) DeleteBatch(ids []string) error {
+	defer r.invalidate(nil, ids)
+	return r.BookRepository.DeleteBatch(ids)
+}
+
+// HardDelete calls HardDelete of the wrapped repository, then invalidates what it wrote
+func (r *BookRepositoryISBNCache) HardDelete(id string) error {
+	defer r.invalidate(nil, []string{id})
+	return r.BookRepository.HardDelete(id)
+}
+
+// SetWork calls SetWork of the wrapped repository, then invalidates what it wrote
+func (r *BookRepositoryISBNCache) SetWork(bookIDs []string, workID *string) error {
+	defer r.invalidate(nil, bookIDs)
+	return r.BookRepository.SetWork(bookIDs, workID)
+}
+
+// MarkPublished calls MarkPublished of the wrapped repository, then invalidates what it wrote
+func (r *BookRepositoryISBNCache) MarkPublished(id string) error {
+	defer r.invalidate(nil, []string{id})
+	return r.BookRepository.MarkPublished(id)
+}
+
+// SetCoverPalette calls SetCoverPalette of the wrapped repository, then invalidates what it wrote
+func (r *BookRepositoryISBNCache) SetCoverPalette(id string, palette []string) error {
+	defer r.invalidate(nil, []string{id})
+	return r.BookRepository.SetCoverPalette(id, palette)
+}
+
+// Restore calls Restore of the wrapped repository, then invalidates what it wrote
+func (r *BookRepositoryISBNCache) Restore(id string) error {
+	defer r.invalidate(nil, []string{id})
+	return r.BookRepository.Restore(id)
+}
+
+// RestoreBatch calls RestoreBatch of the wrapped repository, then invalidates what it wrote
+func (r *BookRepositoryISBNCache) RestoreBatch(ids []string) error {
+	defer r.invalidate(nil, ids)
+	return r.BookRepository.RestoreBatch(ids)
+}
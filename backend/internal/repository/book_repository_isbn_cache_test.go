@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// isbnBookRepository keeps books by ISBN and counts the lookups reaching it; its other methods are
+// not implemented
+type isbnBookRepository struct {
+	repositories.BookRepository
+	books   map[string]entities.Book
+	lookups int
+}
+
+func (r *isbnBookRepository) FindByISBN(isbn string) (*entities.Book, error) {
+	r.lookups++
+	book, ok := r.books[isbn]
+	if !ok {
+		return nil, nil
+	}
+	return &book, nil
+}
+
+func (r *isbnBookRepository) Create(book *entities.Book) error {
+	book.ID = "new"
+	r.books[book.ISBN] = *book
+	return nil
+}
+
+func (r *isbnBookRepository) Update(book *entities.Book) error {
+	for isbn, stored := range r.books {
+		if stored.ID == book.ID {
+			delete(r.books, isbn)
+		}
+	}
+	r.books[book.ISBN] = *book
+	return nil
+}
+
+func TestBookRepositoryISBNCache(t *testing.T) {
+	inner := &isbnBookRepository{books: map[string]entities.Book{
+		"9780441172719": {ID: "dune", Title: "Dune", ISBN: "9780441172719", Metadata: map[string]interface{}{"shelf": "A1"}},
+	}}
+	repo := NewBookRepositoryWithISBNCache(inner, time.Minute, 2).(*BookRepositoryISBNCache)
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	repo.now = func() time.Time { return now }
+
+	// Books found and ISBNs no book has are both cached
+	book, err := repo.FindByISBN("9780441172719")
+	require.NoError(t, err)
+	book.Metadata["shelf"] = "B2"
+	book, err = repo.FindByISBN("9780441172719")
+	require.NoError(t, err)
+	assert.Equal(t, "A1", book.Metadata["shelf"], "callers changing a book leave the cache alone")
+	book, err = repo.FindByISBN("9780000000002")
+	require.NoError(t, err)
+	assert.Nil(t, book)
+	_, _ = repo.FindByISBN("9780000000002")
+	assert.Equal(t, 2, inner.lookups)
+
+	// Creating a book drops the entry of its ISBN
+	require.NoError(t, repo.Create(&entities.Book{Title: "Placeholder", ISBN: "9780000000002"}))
+	book, err = repo.FindByISBN("9780000000002")
+	require.NoError(t, err)
+	require.NotNil(t, book)
+	assert.Equal(t, "Placeholder", book.Title)
+	assert.Equal(t, 3, inner.lookups)
+
+	// Changing the ISBN of a book drops the entry of its former ISBN too
+	require.NoError(t, repo.Update(&entities.Book{ID: "new", Title: "Placeholder", ISBN: "9780000000019"}))
+	book, err = repo.FindByISBN("9780000000002")
+	require.NoError(t, err)
+	assert.Nil(t, book)
+	assert.Equal(t, 4, inner.lookups)
+
+	// Entries expire, and the cache keeps at most its size
+	now = now.Add(time.Minute)
+	_, _ = repo.FindByISBN("9780441172719")
+	assert.Equal(t, 5, inner.lookups)
+	_, _ = repo.FindByISBN("9780000000019")
+	_, _ = repo.FindByISBN("9780000000026")
+	assert.Len(t, repo.entries, 2)
+	assert.Len(t, repo.byID, len(repo.entries)-1, "books are indexed along with their entries")
+}
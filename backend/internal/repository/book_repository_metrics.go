@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+	"library-management-system/internal/infrastructure/metrics"
+)
+
+// BookRepositoryMetrics records the calls, durations and errors of each method of a book
+// repository to a metrics registry, as books.<Method>. Books not found count as errors.
+type BookRepositoryMetrics struct {
+	repo     repositories.BookRepository
+	registry *metrics.Registry
+}
+
+// NewBookRepositoryWithMetrics wraps repo, recording its calls to registry
+func NewBookRepositoryWithMetrics(repo repositories.BookRepository, registry *metrics.Registry) repositories.BookRepository {
+	return &BookRepositoryMetrics{repo: repo, registry: registry}
+}
+
+// observe records a call of a method that started at start
+func (r *BookRepositoryMetrics) observe(method string, start time.Time, err error) {
+	r.registry.Observe("books."+method, time.Since(start), err)
+}
+
+// Create calls Create of the wrapped repository
+func (r *BookRepositoryMetrics) Create(book *entities.Book) error {
+	start := time.Now()
+	err := r.repo.Create(book)
+	r.observe("Create", start, err)
+	return err
+}
+
+// CreateBatch calls CreateBatch of the wrapped repository
+func (r *BookRepositoryMetrics) CreateBatch(books []entities.Book, chunkSize int, onConflict repositories.BatchConflict) (int64, error) {
+	start := time.Now()
+	n, err := r.repo.CreateBatch(books, chunkSize, onConflict)
+	r.observe("CreateBatch", start, err)
+	return n, err
+}
+
+// Upsert calls Upsert of the wrapped repository
+func (r *BookRepositoryMetrics) Upsert(books []entities.Book, versions []*time.Time) ([]bool, error) {
+	start := time.Now()
+	created, err := r.repo.Upsert(books, versions)
+	r.observe("Upsert", start, err)
+	return created, err
+}
+
+// GetByID calls GetByID of the wrapped repository
+func (r *BookRepositoryMetrics) GetByID(id string) (*entities.Book, error) {
+	start := time.Now()
+	book, err := r.repo.GetByID(id)
+	r.observe("GetByID", start, err)
+	return book, err
+}
+
+// GetByIDInBranch calls GetByIDInBranch of the wrapped repository
+func (r *BookRepositoryMetrics) GetByIDInBranch(id, branchID string) (*entities.Book, error) {
+	start := time.Now()
+	book, err := r.repo.GetByIDInBranch(id, branchID)
+	r.observe("GetByIDInBranch", start, err)
+	return book, err
+}
+
+// GetAll calls GetAll of the wrapped repository
+func (r *BookRepositoryMetrics) GetAll() ([]entities.Book, error) {
+	start := time.Now()
+	books, err := r.repo.GetAll()
+	r.observe("GetAll", start, err)
+	return books, err
+}
+
+// Update calls Update of the wrapped repository
+func (r *BookRepositoryMetrics) Update(book *entities.Book) error {
+	start := time.Now()
+	err := r.repo.Update(book)
+	r.observe("Update", start, err)
+	return err
+}
+
+// Delete calls Delete of the wrapped repository
+func (r *BookRepositoryMetrics) Delete(id string) error {
+	start := time.Now()
+	err := r.repo.Delete(id)
+	r.observe("Delete", start, err)
+	return err
+}
+
+// DeleteBatch calls DeleteBatch of the wrapped repository
+func (r *BookRepositoryMetrics) DeleteBatch(ids []string) error {
+	start := time.Now()
+	err := r.repo.DeleteBatch(ids)
+	r.observe("DeleteBatch", start, err)
+	return err
+}
+
+// HardDelete calls HardDelete of the wrapped repository
+func (r *BookRepositoryMetrics) HardDelete(id string) error {
+	start := time.Now()
+	err := r.repo.HardDelete(id)
+	r.observe("HardDelete", start, err)
+	return err
+}
+
+// FindByTitle calls FindByTitle of the wrapped repository
+func (r *BookRepositoryMetrics) FindByTitle(title string) ([]entities.Book, error) {
+	start := time.Now()
+	books, err := r.repo.FindByTitle(title)
+	r.observe("FindByTitle", start, err)
+	return books, err
+}
+
+// FindByAuthor calls FindByAuthor of the wrapped repository
+func (r *BookRepositoryMetrics) FindByAuthor(author string) ([]entities.Book, error) {
+	start := time.Now()
+	books, err := r.repo.FindByAuthor(author)
+	r.observe("FindByAuthor", start, err)
+	return books, err
+}
+
+// FindByYear calls FindByYear of the wrapped repository
+func (r *BookRepositoryMetrics) FindByYear(year int) ([]entities.Book, error) {
+	start := time.Now()
+	books, err := r.repo.FindByYear(year)
+	r.observe("FindByYear", start, err)
+	return books, err
+}
+
+// FindByISBN calls FindByISBN of the wrapped repository
+func (r *BookRepositoryMetrics) FindByISBN(isbn string) (*entities.Book, error) {
+	start := time.Now()
+	book, err := r.repo.FindByISBN(isbn)
+	r.observe("FindByISBN", start, err)
+	return book, err
+}
+
+// FindBySlug calls FindBySlug of the wrapped repository
+func (r *BookRepositoryMetrics) FindBySlug(slug string) (*entities.Book, error) {
+	start := time.Now()
+	book, err := r.repo.FindBySlug(slug)
+	r.observe("FindBySlug", start, err)
+	return book, err
+}
+
+// FindByPublishers calls FindByPublishers of the wrapped repository
+func (r *BookRepositoryMetrics) FindByPublishers(publisherIDs []string) ([]entities.Book, error) {
+	start := time.Now()
+	books, err := r.repo.FindByPublishers(publisherIDs)
+	r.observe("FindByPublishers", start, err)
+	return books, err
+}
+
+// FindByMetadata calls FindByMetadata of the wrapped repository
+func (r *BookRepositoryMetrics) FindByMetadata(filters map[string]string) ([]entities.Book, error) {
+	start := time.Now()
+	books, err := r.repo.FindByMetadata(filters)
+	r.observe("FindByMetadata", start, err)
+	return books, err
+}
+
+// FindBySeries calls FindBySeries of the wrapped repository
+func (r *BookRepositoryMetrics) FindBySeries(seriesID string) ([]entities.Book, error) {
+	start := time.Now()
+	books, err := r.repo.FindBySeries(seriesID)
+	r.observe("FindBySeries", start, err)
+	return books, err
+}
+
+// FindByWork calls FindByWork of the wrapped repository
+func (r *BookRepositoryMetrics) FindByWork(workID string) ([]entities.Book, error) {
+	start := time.Now()
+	books, err := r.repo.FindByWork(workID)
+	r.observe("FindByWork", start, err)
+	return books, err
+}
+
+// SetWork calls SetWork of the wrapped repository
+func (r *BookRepositoryMetrics) SetWork(bookIDs []string, workID *string) error {
+	start := time.Now()
+	err := r.repo.SetWork(bookIDs, workID)
+	r.observe("SetWork", start, err)
+	return err
+}
+
+// GetDeletedBooks calls GetDeletedBooks of the wrapped repository
+func (r *BookRepositoryMetrics) GetDeletedBooks() ([]entities.Book, error) {
+	start := time.Now()
+	books, err := r.repo.GetDeletedBooks()
+	r.observe("GetDeletedBooks", start, err)
+	return books, err
+}
+
+// FindScheduled calls FindScheduled of the wrapped repository
+func (r *BookRepositoryMetrics) FindScheduled() ([]entities.Book, error) {
+	start := time.Now()
+	books, err := r.repo.FindScheduled()
+	r.observe("FindScheduled", start, err)
+	return books, err
+}
+
+// MarkPublished calls MarkPublished of the wrapped repository
+func (r *BookRepositoryMetrics) MarkPublished(id string) error {
+	start := time.Now()
+	err := r.repo.MarkPublished(id)
+	r.observe("MarkPublished", start, err)
+	return err
+}
+
+// SetCoverPalette calls SetCoverPalette of the wrapped repository
+func (r *BookRepositoryMetrics) SetCoverPalette(id string, palette []string) error {
+	start := time.Now()
+	err := r.repo.SetCoverPalette(id, palette)
+	r.observe("SetCoverPalette", start, err)
+	return err
+}
+
+// Restore calls Restore of the wrapped repository
+func (r *BookRepositoryMetrics) Restore(id string) error {
+	start := time.Now()
+	err := r.repo.Restore(id)
+	r.observe("Restore", start, err)
+	return err
+}
+
+// RestoreBatch calls RestoreBatch of the wrapped repository
+func (r *BookRepositoryMetrics) RestoreBatch(ids []string) error {
+	start := time.Now()
+	err := r.repo.RestoreBatch(ids)
+	r.observe("RestoreBatch", start, err)
+	return err
+}
+
+// FindByIDsWithDeleted calls FindByIDsWithDeleted of the wrapped repository
+func (r *BookRepositoryMetrics) FindByIDsWithDeleted(ids []string) ([]entities.Book, error) {
+	start := time.Now()
+	books, err := r.repo.FindByIDsWithDeleted(ids)
+	r.observe("FindByIDsWithDeleted", start, err)
+	return books, err
+}
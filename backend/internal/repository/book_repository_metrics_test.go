@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+	"library-management-system/internal/infrastructure/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBookRepository finds the book with ID 1 and fails to create books; its other methods are
+// not implemented
+type fakeBookRepository struct {
+	repositories.BookRepository
+}
+
+func (fakeBookRepository) GetByID(id string) (*entities.Book, error) {
+	if id != "1" {
+		return nil, errors.New("record not found")
+	}
+	return &entities.Book{ID: id, Title: "Dune"}, nil
+}
+
+func (fakeBookRepository) Create(book *entities.Book) error {
+	return errors.New("database unavailable")
+}
+
+func TestBookRepositoryMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	repo := NewBookRepositoryWithMetrics(fakeBookRepository{}, registry)
+
+	book, err := repo.GetByID("1")
+	require.NoError(t, err)
+	assert.Equal(t, "Dune", book.Title, "results are passed through")
+	_, err = repo.GetByID("2")
+	assert.EqualError(t, err, "record not found", "errors are passed through")
+	assert.Error(t, repo.Create(&entities.Book{}))
+
+	stats := registry.Snapshot()
+	require.Len(t, stats, 2)
+	assert.Equal(t, "books.Create", stats[0].Name)
+	assert.Equal(t, int64(1), stats[0].Calls)
+	assert.Equal(t, 1.0, stats[0].ErrorRate)
+	assert.Equal(t, "books.GetByID", stats[1].Name)
+	assert.Equal(t, int64(2), stats[1].Calls)
+	assert.Equal(t, int64(1), stats[1].Errors)
+	assert.Equal(t, 0.5, stats[1].ErrorRate)
+	assert.GreaterOrEqual(t, stats[1].TotalMs, stats[1].MaxMs)
+}
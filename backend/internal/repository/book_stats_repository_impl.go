@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BookStatsRepositoryImpl implements the BookStatsRepository interface
+type BookStatsRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewBookStatsRepository creates a new book stats repository
+func NewBookStatsRepository(db *gorm.DB) repositories.BookStatsRepository {
+	return &BookStatsRepositoryImpl{db: db}
+}
+
+// AddViews adds view counts to the stats of the books on a day, creating the rows missing
+func (r *BookStatsRepositoryImpl) AddViews(day time.Time, views map[string]int64) error {
+	if len(views) == 0 {
+		return nil
+	}
+	day = truncateToDay(day)
+	rows := make([]entities.BookDailyStats, 0, len(views))
+	for bookID, count := range views {
+		rows = append(rows, entities.BookDailyStats{BookID: bookID, Day: day, Views: count})
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "book_id"}, {Name: "day"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"views":      gorm.Expr("book_daily_stats.views + EXCLUDED.views"),
+			"updated_at": gorm.Expr("EXCLUDED.updated_at"),
+		}),
+	}).Create(&rows).Error
+}
+
+// RefreshPopularity recounts the daily loans since a day and recomputes the popularity of every
+// book from the stats since then, in one transaction
+func (r *BookStatsRepositoryImpl) RefreshPopularity(since time.Time, loanWeight int64) error {
+	since = truncateToDay(since)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("day < ?", since).Delete(&entities.BookDailyStats{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`
+			INSERT INTO book_daily_stats (book_id, day, views, loans, updated_at)
+			SELECT book_id, DATE(borrowed_at), 0, COUNT(*), NOW()
+			FROM `+allLoans+` loans
+			WHERE borrowed_at >= ?
+			GROUP BY book_id, DATE(borrowed_at)
+			ON CONFLICT (book_id, day) DO UPDATE SET loans = EXCLUDED.loans, updated_at = EXCLUDED.updated_at`,
+			since).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`
+			UPDATE books SET popularity = COALESCE((
+				SELECT SUM(stats.views + stats.loans * ?)
+				FROM book_daily_stats stats
+				WHERE stats.book_id = books.id
+			), 0)`,
+			loanWeight).Error
+	})
+}
+
+// truncateToDay returns the start of the UTC day of t
+func truncateToDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// BranchRepositoryImpl implements the BranchRepository interface
+type BranchRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewBranchRepository creates a new branch repository
+func NewBranchRepository(db *gorm.DB) repositories.BranchRepository {
+	return &BranchRepositoryImpl{db: db}
+}
+
+// Create creates a new branch
+func (r *BranchRepositoryImpl) Create(branch *entities.Branch) error {
+	return r.db.Create(branch).Error
+}
+
+// GetByID retrieves a branch by ID
+func (r *BranchRepositoryImpl) GetByID(id string) (*entities.Branch, error) {
+	var branch entities.Branch
+	err := r.db.Where("id = ?", id).First(&branch).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &branch, nil
+}
+
+// Update updates an existing branch
+func (r *BranchRepositoryImpl) Update(branch *entities.Branch) error {
+	return r.db.Save(branch).Error
+}
+
+// GetAll retrieves all branches ordered by name
+func (r *BranchRepositoryImpl) GetAll() ([]entities.Branch, error) {
+	var branches []entities.Branch
+	err := r.db.Order("name ASC").Find(&branches).Error
+	return branches, err
+}
+
+// FindByName finds a branch by its exact name
+func (r *BranchRepositoryImpl) FindByName(name string) (*entities.Branch, error) {
+	var branch entities.Branch
+	err := r.db.Where("name = ?", name).First(&branch).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &branch, nil
+}
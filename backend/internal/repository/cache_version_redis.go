@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"errors"
+	"strconv"
+
+	"library-management-system/internal/domain/repositories"
+	"library-management-system/internal/infrastructure/redis"
+)
+
+// RedisCacheVersion implements the CacheVersion interface on a Redis counter, shared by every instance
+type RedisCacheVersion struct {
+	client *redis.Client
+	key    string
+}
+
+// NewRedisCacheVersion creates a new cache version stored in Redis under key
+func NewRedisCacheVersion(client *redis.Client, key string) repositories.CacheVersion {
+	return &RedisCacheVersion{client: client, key: key}
+}
+
+// Get returns the current version, zero until it is first bumped
+func (v *RedisCacheVersion) Get() (int64, error) {
+	value, err := v.client.Get(v.key)
+	if errors.Is(err, redis.ErrNil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// Bump increments the version
+func (v *RedisCacheVersion) Bump() error {
+	_, err := v.client.Incr(v.key)
+	return err
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// CalendarRepositoryImpl implements the CalendarRepository interface
+type CalendarRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCalendarRepository creates a new calendar repository
+func NewCalendarRepository(db *gorm.DB) repositories.CalendarRepository {
+	return &CalendarRepositoryImpl{db: db}
+}
+
+// ListHours retrieves the business hours of the library and of every branch
+func (r *CalendarRepositoryImpl) ListHours() ([]entities.BusinessHours, error) {
+	var hours []entities.BusinessHours
+	err := r.db.Order("created_at ASC").Find(&hours).Error
+	return hours, err
+}
+
+// ReplaceHours deletes the business hours of a branch, or of the whole library, and inserts the
+// new ones in the same transaction
+func (r *CalendarRepositoryImpl) ReplaceHours(branchID *string, hours []entities.BusinessHours) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		scope := tx.Where("branch_id IS NULL")
+		if branchID != nil {
+			scope = tx.Where("branch_id = ?", *branchID)
+		}
+		if err := scope.Delete(&entities.BusinessHours{}).Error; err != nil {
+			return err
+		}
+		if len(hours) == 0 {
+			return nil
+		}
+		return tx.Create(&hours).Error
+	})
+}
+
+// ListClosedDays retrieves the closed days of the library and of every branch, by date
+func (r *CalendarRepositoryImpl) ListClosedDays() ([]entities.ClosedDay, error) {
+	var days []entities.ClosedDay
+	err := r.db.Order("date ASC").Find(&days).Error
+	return days, err
+}
+
+// CreateClosedDay creates a new closed day
+func (r *CalendarRepositoryImpl) CreateClosedDay(day *entities.ClosedDay) error {
+	return r.db.Create(day).Error
+}
+
+// DeleteClosedDay deletes a closed day and reports whether it existed
+func (r *CalendarRepositoryImpl) DeleteClosedDay(id string) (bool, error) {
+	result := r.db.Where("id = ?", id).Delete(&entities.ClosedDay{})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// CollectionRepositoryImpl implements the CollectionRepository interface
+type CollectionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCollectionRepository creates a new collection repository
+func NewCollectionRepository(db *gorm.DB) repositories.CollectionRepository {
+	return &CollectionRepositoryImpl{db: db}
+}
+
+// Create creates a new collection
+func (r *CollectionRepositoryImpl) Create(collection *entities.Collection) error {
+	return r.db.Create(collection).Error
+}
+
+// GetByID retrieves a collection by ID
+func (r *CollectionRepositoryImpl) GetByID(id string) (*entities.Collection, error) {
+	return r.first("id = ?", id)
+}
+
+// GetByShareToken retrieves a shared collection by its share token
+func (r *CollectionRepositoryImpl) GetByShareToken(token string) (*entities.Collection, error) {
+	return r.first("share_token = ?", token)
+}
+
+// ListByOwner retrieves the collections of an owner, newest first
+func (r *CollectionRepositoryImpl) ListByOwner(ownerID string) ([]entities.Collection, error) {
+	var collections []entities.Collection
+	err := r.db.Where("owner_id = ?", ownerID).Order("created_at DESC").Find(&collections).Error
+	return collections, err
+}
+
+// Update updates an existing collection
+func (r *CollectionRepositoryImpl) Update(collection *entities.Collection) error {
+	return r.db.Save(collection).Error
+}
+
+// Delete deletes a collection and its memberships
+func (r *CollectionRepositoryImpl) Delete(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("collection_id = ?", id).Delete(&entities.CollectionBook{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&entities.Collection{}).Error
+	})
+}
+
+// ListBookIDs retrieves the IDs of the books of a collection in their order
+func (r *CollectionRepositoryImpl) ListBookIDs(collectionID string) ([]string, error) {
+	var bookIDs []string
+	err := r.db.Model(&entities.CollectionBook{}).
+		Where("collection_id = ?", collectionID).
+		Order("position ASC").
+		Pluck("book_id", &bookIDs).Error
+	return bookIDs, err
+}
+
+// SetBooks replaces the books of a collection, keeping the given order
+func (r *CollectionRepositoryImpl) SetBooks(collectionID string, bookIDs []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("collection_id = ?", collectionID).Delete(&entities.CollectionBook{}).Error; err != nil {
+			return err
+		}
+		if len(bookIDs) == 0 {
+			return nil
+		}
+
+		members := make([]entities.CollectionBook, len(bookIDs))
+		for i, bookID := range bookIDs {
+			members[i] = entities.CollectionBook{CollectionID: collectionID, BookID: bookID, Position: i + 1}
+		}
+		return tx.Create(&members).Error
+	})
+}
+
+// first retrieves the first collection matching the condition
+func (r *CollectionRepositoryImpl) first(query string, value string) (*entities.Collection, error) {
+	var collection entities.Collection
+	err := r.db.Where(query, value).First(&collection).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &collection, nil
+}
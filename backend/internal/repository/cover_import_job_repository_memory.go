@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"sync"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// InMemoryCoverImportJobRepository implements the CoverImportJobRepository interface in process.
+// The covers a job sets outlive it, so only the latest maxJobs are kept.
+type InMemoryCoverImportJobRepository struct {
+	mu      sync.Mutex
+	jobs    map[string]entities.CoverImportJob
+	order   []string
+	maxJobs int
+}
+
+// NewInMemoryCoverImportJobRepository creates a new in-memory cover import job repository keeping at most maxJobs jobs
+func NewInMemoryCoverImportJobRepository(maxJobs int) repositories.CoverImportJobRepository {
+	return &InMemoryCoverImportJobRepository{jobs: make(map[string]entities.CoverImportJob), maxJobs: maxJobs}
+}
+
+// Create stores a new job, dropping the oldest ones beyond the limit
+func (r *InMemoryCoverImportJobRepository) Create(job *entities.CoverImportJob) error {
+	job.EnsureID()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs[job.ID] = *job
+	r.order = append(r.order, job.ID)
+	for len(r.order) > r.maxJobs && len(r.order) > 1 {
+		delete(r.jobs, r.order[0])
+		r.order = r.order[1:]
+	}
+	return nil
+}
+
+// GetByID retrieves a job by ID, or nil when it does not exist or was dropped
+func (r *InMemoryCoverImportJobRepository) GetByID(id string) (*entities.CoverImportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	return &job, nil
+}
+
+// Update replaces a stored job
+func (r *InMemoryCoverImportJobRepository) Update(job *entities.CoverImportJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.jobs[job.ID]; !ok {
+		return domainerr.ErrCoverImportNotFound
+	}
+	r.jobs[job.ID] = *job
+	return nil
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CoverRepositoryImpl implements the CoverRepository interface, counting the references to each
+// image in the database and keeping the images in a directory, one file per hash. Files are
+// written and deleted while the row of their hash is locked, so an image released by one book
+// cannot be deleted from under another book attaching it at the same time.
+type CoverRepositoryImpl struct {
+	db  *gorm.DB
+	dir string
+}
+
+// NewCoverRepository creates a new cover repository storing images under dir
+func NewCoverRepository(db *gorm.DB, dir string) repositories.CoverRepository {
+	return &CoverRepositoryImpl{db: db, dir: dir}
+}
+
+// Attach makes an image the cover of a book and releases its previous cover, in one transaction
+func (r *CoverRepositoryImpl) Attach(bookID, hash, perceptualHash, contentType string, data []byte, scan *entities.ScanResult) (*entities.BookCover, error) {
+	cover := &entities.BookCover{BookID: bookID, Hash: hash}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var previous entities.BookCover
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("book_id = ?", bookID).First(&previous).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+		case err != nil:
+			return err
+		case previous.Hash == hash:
+			// The book already has this cover
+			return tx.Save(cover).Error
+		}
+
+		object := entities.CoverObject{Hash: hash, ContentType: contentType, Size: int64(len(data)), RefCount: 1, PerceptualHash: perceptualHash}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "hash"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"ref_count": gorm.Expr("cover_objects.ref_count + 1"),
+				// Images stored before hashes were computed get one when uploaded again
+				"perceptual_hash": gorm.Expr("COALESCE(NULLIF(cover_objects.perceptual_hash, ''), EXCLUDED.perceptual_hash)"),
+			}),
+		}).Create(&object).Error; err != nil {
+			return err
+		}
+		if err := r.write(hash, data); err != nil {
+			return err
+		}
+
+		if err := tx.Save(cover).Error; err != nil {
+			return err
+		}
+		if previous.Hash != "" {
+			return r.release(tx, previous.Hash)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByBookID(bookID)
+}
+
+// Detach removes the cover of a book and releases its image, in one transaction
+func (r *CoverRepositoryImpl) Detach(bookID string) (bool, error) {
+	detached := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var cover entities.BookCover
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("book_id = ?", bookID).First(&cover).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Delete(&cover).Error; err != nil {
+			return err
+		}
+		detached = true
+		return r.release(tx, cover.Hash)
+	})
+	return detached, err
+}
+
+// GetByBookID returns the cover of a book with the details of its image
+func (r *CoverRepositoryImpl) GetByBookID(bookID string) (*entities.BookCover, error) {
+	var cover entities.BookCover
+	err := r.db.Where("book_id = ?", bookID).First(&cover).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var object entities.CoverObject
+	if err := r.db.Where("hash = ?", cover.Hash).First(&object).Error; err != nil {
+		return nil, err
+	}
+	cover.ContentType = object.ContentType
+	cover.Size = object.Size
+	cover.References = object.RefCount
+	cover.PerceptualHash = object.PerceptualHash
+	cover.Scan = object.Scan
+	return &cover, nil
+}
+
+// ListAttached returns the covers of the books not deleted with the details of their images,
+// ordered by book
+func (r *CoverRepositoryImpl) ListAttached() ([]entities.BookCover, error) {
+	var rows []struct {
+		BookID         string
+		Hash           string
+		UpdatedAt      time.Time
+		ContentType    string
+		Size           int64
+		RefCount       int
+		PerceptualHash string
+	}
+	err := r.db.Table("book_covers").
+		Select("book_covers.book_id, book_covers.hash, book_covers.updated_at, cover_objects.content_type, cover_objects.size, cover_objects.ref_count, cover_objects.perceptual_hash").
+		Joins("JOIN cover_objects ON cover_objects.hash = book_covers.hash").
+		Joins("JOIN books ON books.id = book_covers.book_id AND books.deleted_at IS NULL").
+		Order("book_covers.book_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	covers := make([]entities.BookCover, len(rows))
+	for i, row := range rows {
+		covers[i] = entities.BookCover{
+			BookID:         row.BookID,
+			Hash:           row.Hash,
+			UpdatedAt:      row.UpdatedAt,
+			ContentType:    row.ContentType,
+			Size:           row.Size,
+			References:     row.RefCount,
+			PerceptualHash: row.PerceptualHash,
+		}
+	}
+	return covers, nil
+}
+
+// Open reads a stored image
+func (r *CoverRepositoryImpl) Open(hash string) ([]byte, error) {
+	return os.ReadFile(r.path(hash))
+}
+
+// release drops a reference to an image, deleting it with its last reference
+func (r *CoverRepositoryImpl) release(tx *gorm.DB, hash string) error {
+	var object entities.CoverObject
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("hash = ?", hash).First(&object).Error; err != nil {
+		return err
+	}
+	if object.RefCount > 1 {
+		return tx.Model(&object).Update("ref_count", gorm.Expr("ref_count - 1")).Error
+	}
+	if err := tx.Delete(&object).Error; err != nil {
+		return err
+	}
+	if err := os.Remove(r.path(hash)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete cover image %s: %w", hash, err)
+	}
+	return nil
+}
+
+// write stores an image unless it is stored already, through a temporary file so that readers
+// never see part of it
+func (r *CoverRepositoryImpl) write(hash string, data []byte) error {
+	path := r.path(hash)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), hash+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// path is the file of an image, in a subdirectory named after the first two characters of its
+// hash so that no directory holds too many files
+func (r *CoverRepositoryImpl) path(hash string) string {
+	return filepath.Join(r.dir, hash[:2], hash)
+}
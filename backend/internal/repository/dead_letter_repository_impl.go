@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// DeadLetterRepositoryImpl implements the DeadLetterRepository interface
+type DeadLetterRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewDeadLetterRepository creates a new dead letter repository
+func NewDeadLetterRepository(db *gorm.DB) repositories.DeadLetterRepository {
+	return &DeadLetterRepositoryImpl{db: db}
+}
+
+// Create stores a dead letter
+func (r *DeadLetterRepositoryImpl) Create(deadLetter *entities.DeadLetter) error {
+	return r.db.Create(deadLetter).Error
+}
+
+// GetByID retrieves a dead letter by ID
+func (r *DeadLetterRepositoryImpl) GetByID(id string) (*entities.DeadLetter, error) {
+	var deadLetter entities.DeadLetter
+	err := r.db.Where("id = ?", id).First(&deadLetter).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &deadLetter, nil
+}
+
+// List retrieves the latest dead letters, optionally of a single queue and after a position
+func (r *DeadLetterRepositoryImpl) List(queue string, after *repositories.PageAfter, limit int) ([]entities.DeadLetter, error) {
+	var deadLetters []entities.DeadLetter
+	query := r.db.Order("failed_at DESC, id DESC").Limit(limit)
+	if queue != "" {
+		query = query.Where("queue = ?", queue)
+	}
+	if after != nil {
+		query = query.Where("(failed_at, id) < (?, ?)", after.Time, after.ID)
+	}
+	err := query.Find(&deadLetters).Error
+	return deadLetters, err
+}
+
+// Delete deletes a dead letter
+func (r *DeadLetterRepositoryImpl) Delete(id string) error {
+	return r.db.Where("id = ?", id).Delete(&entities.DeadLetter{}).Error
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"sync"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// InMemoryEnrichmentJobRepository implements the EnrichmentJobRepository interface in process.
+// The changes of a job outlive it as drafts and audit entries, so only the latest maxJobs are kept.
+type InMemoryEnrichmentJobRepository struct {
+	mu      sync.Mutex
+	jobs    map[string]entities.EnrichmentJob
+	order   []string
+	maxJobs int
+}
+
+// NewInMemoryEnrichmentJobRepository creates a new in-memory enrichment job repository keeping at most maxJobs jobs
+func NewInMemoryEnrichmentJobRepository(maxJobs int) repositories.EnrichmentJobRepository {
+	return &InMemoryEnrichmentJobRepository{jobs: make(map[string]entities.EnrichmentJob), maxJobs: maxJobs}
+}
+
+// Create stores a new job, dropping the oldest ones beyond the limit
+func (r *InMemoryEnrichmentJobRepository) Create(job *entities.EnrichmentJob) error {
+	job.EnsureID()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs[job.ID] = *job
+	r.order = append(r.order, job.ID)
+	for len(r.order) > r.maxJobs && len(r.order) > 1 {
+		delete(r.jobs, r.order[0])
+		r.order = r.order[1:]
+	}
+	return nil
+}
+
+// GetByID retrieves a job by ID, or nil when it does not exist or was dropped
+func (r *InMemoryEnrichmentJobRepository) GetByID(id string) (*entities.EnrichmentJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	return &job, nil
+}
+
+// Update replaces a stored job
+func (r *InMemoryEnrichmentJobRepository) Update(job *entities.EnrichmentJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.jobs[job.ID]; !ok {
+		return domainerr.ErrEnrichmentJobNotFound
+	}
+	r.jobs[job.ID] = *job
+	return nil
+}
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// exploreTable maps an entity of the query explorer to its table, or the tables it spans, the rows
+// it covers and the SQL of its fields. Only these constant expressions are written into queries; values are always
+// bound as parameters.
+type exploreTable struct {
+	name   string
+	scope  string
+	fields map[string]string
+}
+
+// exploreTables covers every entity and field of entities.ExploreEntities
+var exploreTables = map[string]exploreTable{
+	"books": {
+		name:  "books",
+		scope: "deleted_at IS NULL",
+		fields: map[string]string{
+			"author":        "author",
+			"year":          "year",
+			"status":        "status",
+			"publisher_id":  "publisher_id",
+			"series_id":     "series_id",
+			"work_id":       "work_id",
+			"created_at":    "created_at",
+			"created_month": "to_char(date_trunc('month', created_at), 'YYYY-MM')",
+		},
+	},
+	"loans": {
+		name: allLoans + " loans",
+		fields: map[string]string{
+			"tenant_id":      "tenant_id",
+			"user_id":        "user_id",
+			"book_id":        "book_id",
+			"borrowed_at":    "borrowed_at",
+			"borrowed_month": "to_char(date_trunc('month', borrowed_at), 'YYYY-MM')",
+			"due_at":         "due_at",
+			"returned_at":    "returned_at",
+			"returned":       "(returned_at IS NOT NULL)",
+			"renewals":       "renewals",
+		},
+	},
+	"acquisitions": {
+		name: "acquisitions",
+		fields: map[string]string{
+			"status":        "status",
+			"vendor":        "vendor",
+			"suggested_by":  "suggested_by",
+			"year":          "year",
+			"created_at":    "created_at",
+			"created_month": "to_char(date_trunc('month', created_at), 'YYYY-MM')",
+			"received_at":   "received_at",
+		},
+	},
+}
+
+// exploreOperators maps the filter operators to their SQL
+var exploreOperators = map[string]string{
+	entities.ExploreEq:  "= ?",
+	entities.ExploreNe:  "<> ?",
+	entities.ExploreIn:  "IN ?",
+	entities.ExploreGt:  "> ?",
+	entities.ExploreGte: ">= ?",
+	entities.ExploreLt:  "< ?",
+	entities.ExploreLte: "<= ?",
+}
+
+// ExploreRepositoryImpl implements the ExploreRepository interface
+type ExploreRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewExploreRepository creates a new query explorer repository
+func NewExploreRepository(db *gorm.DB) repositories.ExploreRepository {
+	return &ExploreRepositoryImpl{db: db}
+}
+
+// Run builds the query from the SQL of the fields it names, refusing any name it does not know
+func (r *ExploreRepositoryImpl) Run(query *entities.ExploreQuery) (*entities.ExploreResult, error) {
+	table, ok := exploreTables[query.Entity]
+	if !ok {
+		return nil, fmt.Errorf("unknown entity %q", query.Entity)
+	}
+	field := func(name string) (string, error) {
+		expr, ok := table.fields[name]
+		if !ok {
+			return "", fmt.Errorf("unknown field %q of %s", name, query.Entity)
+		}
+		return expr, nil
+	}
+
+	tx := r.db.Table(table.name)
+	if table.scope != "" {
+		tx = tx.Where(table.scope)
+	}
+	for _, filter := range query.Filters {
+		expr, err := field(filter.Field)
+		if err != nil {
+			return nil, err
+		}
+		op, ok := exploreOperators[filter.Op]
+		if !ok {
+			return nil, fmt.Errorf("unknown operator %q", filter.Op)
+		}
+		tx = tx.Where(expr+" "+op, filter.Value)
+	}
+
+	result := &entities.ExploreResult{}
+	var selects, groups []string
+	for _, name := range query.GroupBy {
+		expr, err := field(name)
+		if err != nil {
+			return nil, err
+		}
+		selects = append(selects, expr+" AS "+name)
+		groups = append(groups, expr)
+		result.Columns = append(result.Columns, name)
+	}
+	for _, aggregate := range query.Aggregates {
+		expr := ""
+		if aggregate.Field != "" {
+			var err error
+			if expr, err = field(aggregate.Field); err != nil {
+				return nil, err
+			}
+		}
+		sql, err := aggregateSQL(aggregate.Func, expr)
+		if err != nil {
+			return nil, err
+		}
+		selects = append(selects, sql+" AS "+aggregate.Column())
+		result.Columns = append(result.Columns, aggregate.Column())
+	}
+
+	tx = tx.Select(strings.Join(selects, ", "))
+	if len(groups) > 0 {
+		tx = tx.Group(strings.Join(groups, ", ")).Order(strings.Join(groups, ", "))
+	}
+	rows, err := tx.Limit(query.Limit + 1).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result.Rows = [][]interface{}{}
+	for rows.Next() {
+		row := make([]interface{}, len(result.Columns))
+		dest := make([]interface{}, len(row))
+		for i := range row {
+			dest[i] = &row[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for i, value := range row {
+			// Text columns can come back as bytes
+			if b, ok := value.([]byte); ok {
+				row[i] = string(b)
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result, rows.Err()
+}
+
+// aggregateSQL returns the SQL of an aggregate function over a field expression
+func aggregateSQL(fn, expr string) (string, error) {
+	switch fn {
+	case entities.ExploreCount:
+		if expr == "" {
+			return "COUNT(*)", nil
+		}
+		return "COUNT(" + expr + ")", nil
+	case entities.ExploreCountDistinct:
+		return "COUNT(DISTINCT " + expr + ")", nil
+	case entities.ExploreSum:
+		return "SUM(" + expr + ")::bigint", nil
+	case entities.ExploreAvg:
+		return "AVG(" + expr + ")::float8", nil
+	case entities.ExploreMin:
+		return "MIN(" + expr + ")", nil
+	case entities.ExploreMax:
+		return "MAX(" + expr + ")", nil
+	}
+	return "", fmt.Errorf("unknown aggregate %q", fn)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// FineRepositoryImpl implements the FineRepository interface
+type FineRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewFineRepository creates a new fine repository
+func NewFineRepository(db *gorm.DB) repositories.FineRepository {
+	return &FineRepositoryImpl{db: db}
+}
+
+// ListByUser retrieves the fines charged to a user, newest first
+func (r *FineRepositoryImpl) ListByUser(userID string) ([]entities.Fine, error) {
+	var fines []entities.Fine
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&fines).Error
+	return fines, err
+}
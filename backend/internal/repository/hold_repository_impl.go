@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// HoldRepositoryImpl implements the HoldRepository interface
+type HoldRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewHoldRepository creates a new hold repository
+func NewHoldRepository(db *gorm.DB) repositories.HoldRepository {
+	return &HoldRepositoryImpl{db: db}
+}
+
+// CountPending counts the waiting and ready holds on a book, leaving out those of one user
+func (r *HoldRepositoryImpl) CountPending(bookID, exceptUserID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.Hold{}).
+		Where("book_id = ? AND user_id <> ?", bookID, exceptUserID).
+		Where("status IN ?", []string{entities.HoldWaiting, entities.HoldReady}).
+		Count(&count).Error
+	return count, err
+}
+
+// ListPendingByUser retrieves the waiting and ready holds of a user, oldest first
+func (r *HoldRepositoryImpl) ListPendingByUser(userID string) ([]entities.Hold, error) {
+	var holds []entities.Hold
+	err := r.db.Where("user_id = ?", userID).
+		Where("status IN ?", []string{entities.HoldWaiting, entities.HoldReady}).
+		Order("created_at ASC").
+		Find(&holds).Error
+	return holds, err
+}
+
+// ListWaiting retrieves the waiting holds on a book in queue order, oldest first
+func (r *HoldRepositoryImpl) ListWaiting(bookID string) ([]entities.Hold, error) {
+	var holds []entities.Hold
+	err := r.db.Where("book_id = ? AND status = ?", bookID, entities.HoldWaiting).
+		Order("created_at ASC, id ASC").
+		Find(&holds).Error
+	return holds, err
+}
+
+// Update updates an existing hold
+func (r *HoldRepositoryImpl) Update(hold *entities.Hold) error {
+	return r.db.Save(hold).Error
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// ImportProfileRepositoryImpl implements the ImportProfileRepository interface
+type ImportProfileRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewImportProfileRepository creates a new import profile repository
+func NewImportProfileRepository(db *gorm.DB) repositories.ImportProfileRepository {
+	return &ImportProfileRepositoryImpl{db: db}
+}
+
+// Save creates or replaces a profile
+func (r *ImportProfileRepositoryImpl) Save(profile *entities.ImportProfile) error {
+	return r.db.Save(profile).Error
+}
+
+// Get retrieves a profile of a tenant by name, nil when there is none
+func (r *ImportProfileRepositoryImpl) Get(tenantID, name string) (*entities.ImportProfile, error) {
+	var profile entities.ImportProfile
+	err := r.db.Where("tenant_id = ? AND name = ?", tenantID, name).First(&profile).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// ListByTenant lists the profiles of a tenant ordered by name
+func (r *ImportProfileRepositoryImpl) ListByTenant(tenantID string) ([]entities.ImportProfile, error) {
+	var profiles []entities.ImportProfile
+	err := r.db.Where("tenant_id = ?", tenantID).Order("name ASC").Find(&profiles).Error
+	return profiles, err
+}
+
+// Delete removes a profile and reports whether it existed
+func (r *ImportProfileRepositoryImpl) Delete(tenantID, name string) (bool, error) {
+	result := r.db.Where("tenant_id = ? AND name = ?", tenantID, name).Delete(&entities.ImportProfile{})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// ImportRunRepositoryImpl implements the ImportRunRepository interface
+type ImportRunRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewImportRunRepository creates a new import run repository
+func NewImportRunRepository(db *gorm.DB) repositories.ImportRunRepository {
+	return &ImportRunRepositoryImpl{db: db}
+}
+
+// Create stores an import run
+func (r *ImportRunRepositoryImpl) Create(run *entities.ImportRun) error {
+	return r.db.Create(run).Error
+}
+
+// GetByID retrieves an import run by ID
+func (r *ImportRunRepositoryImpl) GetByID(id string) (*entities.ImportRun, error) {
+	var run entities.ImportRun
+	err := r.db.Where("id = ?", id).First(&run).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+// List retrieves the latest import runs, optionally of a single tenant and after a position
+func (r *ImportRunRepositoryImpl) List(tenantID string, after *repositories.PageAfter, limit int) ([]entities.ImportRun, error) {
+	var runs []entities.ImportRun
+	query := r.db.Order("created_at DESC, id DESC").Limit(limit)
+	if tenantID != "" {
+		query = query.Where("tenant_id = ?", tenantID)
+	}
+	if after != nil {
+		query = query.Where("(created_at, id) < (?, ?)", after.Time, after.ID)
+	}
+	err := query.Find(&runs).Error
+	return runs, err
+}
+
+// FindCompleted retrieves the latest completed run of a tenant with the file hash since a time
+func (r *ImportRunRepositoryImpl) FindCompleted(tenantID, fileHash string, since time.Time) (*entities.ImportRun, error) {
+	var run entities.ImportRun
+	err := r.db.Where("tenant_id = ? AND file_hash = ? AND status = ? AND created_at >= ?", tenantID, fileHash, entities.ImportRunCompleted, since).
+		Order("created_at DESC").
+		First(&run).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
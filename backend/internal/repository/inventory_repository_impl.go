@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// InventoryRepositoryImpl implements the InventoryRepository interface
+type InventoryRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewInventoryRepository creates a new inventory repository
+func NewInventoryRepository(db *gorm.DB) repositories.InventoryRepository {
+	return &InventoryRepositoryImpl{db: db}
+}
+
+// CreateSession creates a new inventory session
+func (r *InventoryRepositoryImpl) CreateSession(session *entities.InventorySession) error {
+	return r.db.Create(session).Error
+}
+
+// GetSession retrieves an inventory session by ID
+func (r *InventoryRepositoryImpl) GetSession(id string) (*entities.InventorySession, error) {
+	var session entities.InventorySession
+	err := r.db.Where("id = ?", id).First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListSessions retrieves all inventory sessions, newest first
+func (r *InventoryRepositoryImpl) ListSessions() ([]entities.InventorySession, error) {
+	var sessions []entities.InventorySession
+	err := r.db.Order("created_at DESC").Find(&sessions).Error
+	return sessions, err
+}
+
+// UpdateSession updates an existing inventory session
+func (r *InventoryRepositoryImpl) UpdateSession(session *entities.InventorySession) error {
+	return r.db.Save(session).Error
+}
+
+// LastClosedSession retrieves the most recently closed inventory session
+func (r *InventoryRepositoryImpl) LastClosedSession() (*entities.InventorySession, error) {
+	var session entities.InventorySession
+	err := r.db.Where("status = ?", entities.InventorySessionClosed).Order("closed_at DESC").First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// AddScans records a batch of scans
+func (r *InventoryRepositoryImpl) AddScans(scans []entities.InventoryScan) error {
+	if len(scans) == 0 {
+		return nil
+	}
+	return r.db.Create(&scans).Error
+}
+
+// ListScans retrieves the scans of a session in scan order
+func (r *InventoryRepositoryImpl) ListScans(sessionID string) ([]entities.InventoryScan, error) {
+	var scans []entities.InventoryScan
+	err := r.db.Where("session_id = ?", sessionID).Order("scanned_at ASC").Find(&scans).Error
+	return scans, err
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JobLockRepositoryImpl implements the JobLockRepository interface
+type JobLockRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewJobLockRepository creates a new job lock repository
+func NewJobLockRepository(db *gorm.DB) repositories.JobLockRepository {
+	return &JobLockRepositoryImpl{db: db}
+}
+
+// Acquire inserts the lock, or takes it over when it expired or already belongs to the owner,
+// in a single statement so two instances cannot both win
+func (r *JobLockRepositoryImpl) Acquire(jobName, owner string, now, expiresAt time.Time) (bool, error) {
+	lock := entities.JobLock{JobName: jobName, Owner: owner, AcquiredAt: now, ExpiresAt: expiresAt}
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "job_name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"owner", "acquired_at", "expires_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "job_locks.expires_at <= ? OR job_locks.owner = ?", Vars: []interface{}{now, owner}},
+		}},
+	}).Create(&lock)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Release moves the expiry of a lock held by the owner
+func (r *JobLockRepositoryImpl) Release(jobName, owner string, until time.Time) error {
+	return r.db.Model(&entities.JobLock{}).
+		Where("job_name = ? AND owner = ?", jobName, owner).
+		Update("expires_at", until).Error
+}
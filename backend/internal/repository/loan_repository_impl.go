@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// loanColumns are the columns loans and archived loans share
+const loanColumns = "id, tenant_id, user_id, book_id, borrowed_at, due_at, returned_at, renewals, created_at, updated_at"
+
+// allLoans is a table expression of the current loans together with the archived ones, for
+// statistics counting every loan ever made
+const allLoans = "(SELECT " + loanColumns + " FROM loans UNION ALL SELECT " + loanColumns + " FROM loans_history)"
+
+// LoanRepositoryImpl implements the LoanRepository interface
+type LoanRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewLoanRepository creates a new loan repository
+func NewLoanRepository(db *gorm.DB) repositories.LoanRepository {
+	return &LoanRepositoryImpl{db: db}
+}
+
+// Create creates a new loan
+func (r *LoanRepositoryImpl) Create(loan *entities.Loan) error {
+	return r.db.Create(loan).Error
+}
+
+// GetByID retrieves a loan by ID, looking among archived loans when it is not a current one
+func (r *LoanRepositoryImpl) GetByID(id string) (*entities.Loan, error) {
+	var loan entities.Loan
+	err := r.db.Where("id = ?", id).First(&loan).Error
+	if err == nil {
+		return &loan, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	var archived entities.ArchivedLoan
+	err = r.db.Where("id = ?", id).First(&archived).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &archived.Loan, nil
+}
+
+// ListActiveByUser retrieves the loans a user has not returned yet, soonest due first
+func (r *LoanRepositoryImpl) ListActiveByUser(userID string) ([]entities.Loan, error) {
+	var loans []entities.Loan
+	err := r.db.Where("user_id = ? AND returned_at IS NULL", userID).
+		Order("due_at ASC").
+		Find(&loans).Error
+	return loans, err
+}
+
+// ListActiveByBook retrieves the loans of a book not returned yet, soonest due first
+func (r *LoanRepositoryImpl) ListActiveByBook(bookID string) ([]entities.Loan, error) {
+	var loans []entities.Loan
+	err := r.db.Where("book_id = ? AND returned_at IS NULL", bookID).
+		Order("due_at ASC").
+		Find(&loans).Error
+	return loans, err
+}
+
+// ListByBook retrieves every loan of a book, archived or not, most recently borrowed first
+func (r *LoanRepositoryImpl) ListByBook(bookID string) ([]entities.Loan, error) {
+	var loans []entities.Loan
+	err := r.db.Raw(`SELECT `+loanColumns+` FROM loans WHERE book_id = ?
+		UNION ALL
+		SELECT `+loanColumns+` FROM loans_history WHERE book_id = ?
+		ORDER BY borrowed_at DESC`,
+		bookID, bookID).Scan(&loans).Error
+	return loans, err
+}
+
+// ListDueBetween retrieves the loans not returned yet that are due from a time until another,
+// soonest due first
+func (r *LoanRepositoryImpl) ListDueBetween(from, to time.Time) ([]entities.Loan, error) {
+	var loans []entities.Loan
+	err := r.db.Where("returned_at IS NULL AND due_at >= ? AND due_at < ?", from, to).
+		Order("due_at ASC").
+		Find(&loans).Error
+	return loans, err
+}
+
+// ListByUser retrieves the loans a user borrowed from a time until another, returned or not, most
+// recently borrowed first. Archived loans are only read when the range reaches back to the newest
+// of them, so recent ranges never touch the history table.
+func (r *LoanRepositoryImpl) ListByUser(userID string, from, to time.Time) ([]entities.Loan, error) {
+	var newestArchived sql.NullTime
+	if err := r.db.Raw("SELECT MAX(borrowed_at) FROM loans_history").Scan(&newestArchived).Error; err != nil {
+		return nil, err
+	}
+
+	var loans []entities.Loan
+	if !newestArchived.Valid || newestArchived.Time.Before(from) {
+		err := r.db.Where("user_id = ? AND borrowed_at >= ? AND borrowed_at < ?", userID, from, to).
+			Order("borrowed_at DESC").
+			Find(&loans).Error
+		return loans, err
+	}
+	err := r.db.Raw(`SELECT `+loanColumns+` FROM loans WHERE user_id = ? AND borrowed_at >= ? AND borrowed_at < ?
+		UNION ALL
+		SELECT `+loanColumns+` FROM loans_history WHERE user_id = ? AND borrowed_at >= ? AND borrowed_at < ?
+		ORDER BY borrowed_at DESC`,
+		userID, from, to, userID, from, to).Scan(&loans).Error
+	return loans, err
+}
+
+// Archive moves up to limit loans returned before a time to the history table, those returned
+// first first, and returns how many it moved
+func (r *LoanRepositoryImpl) Archive(returnedBefore time.Time, limit int) (int64, error) {
+	result := r.db.Exec(`WITH moved AS (
+			DELETE FROM loans
+			WHERE id IN (SELECT id FROM loans WHERE returned_at < ? ORDER BY returned_at LIMIT ?)
+			RETURNING `+loanColumns+`
+		)
+		INSERT INTO loans_history (`+loanColumns+`, archived_at)
+		SELECT `+loanColumns+`, ? FROM moved`,
+		returnedBefore, limit, entities.Now())
+	return result.RowsAffected, result.Error
+}
+
+// Update updates an existing loan
+func (r *LoanRepositoryImpl) Update(loan *entities.Loan) error {
+	return r.db.Save(loan).Error
+}
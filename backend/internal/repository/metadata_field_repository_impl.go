@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// MetadataFieldRepositoryImpl implements the MetadataFieldRepository interface
+type MetadataFieldRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewMetadataFieldRepository creates a new metadata field repository
+func NewMetadataFieldRepository(db *gorm.DB) repositories.MetadataFieldRepository {
+	return &MetadataFieldRepositoryImpl{db: db}
+}
+
+// Save creates or replaces the definition of a field
+func (r *MetadataFieldRepositoryImpl) Save(field *entities.MetadataField) error {
+	return r.db.Save(field).Error
+}
+
+// ListByTenant lists the field definitions of a tenant ordered by key
+func (r *MetadataFieldRepositoryImpl) ListByTenant(tenantID string) ([]entities.MetadataField, error) {
+	var fields []entities.MetadataField
+	err := r.db.Where("tenant_id = ?", tenantID).Order("key ASC").Find(&fields).Error
+	return fields, err
+}
+
+// Delete removes the definition of a field and reports whether it existed
+func (r *MetadataFieldRepositoryImpl) Delete(tenantID, key string) (bool, error) {
+	result := r.db.Where("tenant_id = ? AND key = ?", tenantID, key).Delete(&entities.MetadataField{})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// NotificationRepositoryImpl implements the NotificationRepository interface
+type NotificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *gorm.DB) repositories.NotificationRepository {
+	return &NotificationRepositoryImpl{db: db}
+}
+
+// Create creates a new notification
+func (r *NotificationRepositoryImpl) Create(notification *entities.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+// GetByID retrieves a notification by ID
+func (r *NotificationRepositoryImpl) GetByID(id string) (*entities.Notification, error) {
+	var notification entities.Notification
+	err := r.db.Where("id = ?", id).First(&notification).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// ListByRecipient retrieves the notifications of a recipient, newest first
+func (r *NotificationRepositoryImpl) ListByRecipient(recipientID string, unreadOnly bool) ([]entities.Notification, error) {
+	var notifications []entities.Notification
+	query := r.db.Where("recipient_id = ?", recipientID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	err := query.Order("created_at DESC").Find(&notifications).Error
+	return notifications, err
+}
+
+// CountUnread counts the unread notifications of a recipient
+func (r *NotificationRepositoryImpl) CountUnread(recipientID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.Notification{}).
+		Where("recipient_id = ? AND read_at IS NULL", recipientID).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead marks a single notification as read
+func (r *NotificationRepositoryImpl) MarkRead(id string) error {
+	return r.db.Model(&entities.Notification{}).
+		Where("id = ? AND read_at IS NULL", id).
+		Update("read_at", r.db.NowFunc()).Error
+}
+
+// MarkAllRead marks every unread notification of a recipient as read
+func (r *NotificationRepositoryImpl) MarkAllRead(recipientID string) error {
+	return r.db.Model(&entities.Notification{}).
+		Where("recipient_id = ? AND read_at IS NULL", recipientID).
+		Update("read_at", r.db.NowFunc()).Error
+}
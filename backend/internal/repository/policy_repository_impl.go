@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// PolicyRepositoryImpl implements the PolicyRepository interface
+type PolicyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPolicyRepository creates a new policy repository
+func NewPolicyRepository(db *gorm.DB) repositories.PolicyRepository {
+	return &PolicyRepositoryImpl{db: db}
+}
+
+// Get retrieves a policy of a tenant
+func (r *PolicyRepositoryImpl) Get(tenantID, key string) (*entities.Policy, error) {
+	var policy entities.Policy
+	err := r.db.Where("tenant_id = ? AND key = ?", tenantID, key).First(&policy).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
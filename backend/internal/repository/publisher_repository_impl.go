@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// PublisherRepositoryImpl implements the PublisherRepository interface
+type PublisherRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPublisherRepository creates a new publisher repository
+func NewPublisherRepository(db *gorm.DB) repositories.PublisherRepository {
+	return &PublisherRepositoryImpl{db: db}
+}
+
+// Create creates a new publisher
+func (r *PublisherRepositoryImpl) Create(publisher *entities.Publisher) error {
+	return r.db.Create(publisher).Error
+}
+
+// GetByID retrieves a publisher by ID
+func (r *PublisherRepositoryImpl) GetByID(id string) (*entities.Publisher, error) {
+	var publisher entities.Publisher
+	err := r.db.Where("id = ?", id).First(&publisher).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &publisher, nil
+}
+
+// GetAll retrieves all publishers ordered by name
+func (r *PublisherRepositoryImpl) GetAll() ([]entities.Publisher, error) {
+	var publishers []entities.Publisher
+	err := r.db.Order("name ASC").Find(&publishers).Error
+	return publishers, err
+}
+
+// Update updates a publisher
+func (r *PublisherRepositoryImpl) Update(publisher *entities.Publisher) error {
+	// Save writes every column so an imprint can be detached from its parent
+	return r.db.Save(publisher).Error
+}
+
+// Delete deletes a publisher
+func (r *PublisherRepositoryImpl) Delete(id string) error {
+	return r.db.Delete(&entities.Publisher{}, "id = ?", id).Error
+}
+
+// FindByName finds a publisher by its exact name
+func (r *PublisherRepositoryImpl) FindByName(name string) (*entities.Publisher, error) {
+	var publisher entities.Publisher
+	err := r.db.Where("name = ?", name).First(&publisher).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &publisher, nil
+}
+
+// ListImprints retrieves the direct imprints of a publisher ordered by name
+func (r *PublisherRepositoryImpl) ListImprints(parentID string) ([]entities.Publisher, error) {
+	var publishers []entities.Publisher
+	err := r.db.Where("parent_id = ?", parentID).Order("name ASC").Find(&publishers).Error
+	return publishers, err
+}
@@ -0,0 +1,60 @@
+//go:build integration
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/infrastructure/database"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// TestQueryPlans checks that the queries an index is meant to serve still use one. It needs the
+// database of DB_HOST and DB_NAME, which it migrates: run it with make test-plans.
+func TestQueryPlans(t *testing.T) {
+	conn, err := database.NewDatabase()
+	require.NoError(t, err)
+	db := conn.GetDB()
+
+	// Sequential scans are priced out on the only connection, so the planner picks one only when
+	// no index can serve the query, however small the tables of the test database are
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	require.NoError(t, db.Exec("SET enable_seqscan = off").Error)
+
+	var scans []database.SeqScan
+	require.NoError(t, db.Use(&database.PlanGuard{Report: func(_ *gorm.DB, scan database.SeqScan) {
+		scans = append(scans, scan)
+	}}))
+
+	books := NewBookRepository(db)
+	loans := NewLoanRepository(db)
+	id := "00000000-0000-0000-0000-000000000001"
+	indexed := map[string]func() error{
+		"book by ID":           func() error { _, err := books.GetByID(id); return err },
+		"book by ISBN":         func() error { _, err := books.FindByISBN("9780000000000"); return err },
+		"book by slug":         func() error { _, err := books.FindBySlug("the-hobbit"); return err },
+		"books by year":        func() error { _, err := books.FindByYear(1937); return err },
+		"books by publishers":  func() error { _, err := books.FindByPublishers([]string{id}); return err },
+		"books by series":      func() error { _, err := books.FindBySeries(id); return err },
+		"books by work":        func() error { _, err := books.FindByWork(id); return err },
+		"active loans of user": func() error { _, err := loans.ListActiveByUser(id); return err },
+		"active loans of book": func() error { _, err := loans.ListActiveByBook(id); return err },
+		"recent loans of user": func() error {
+			_, err := loans.ListByUser(id, time.Now().AddDate(0, -1, 0), time.Now())
+			return err
+		},
+	}
+	for name, run := range indexed {
+		t.Run(name, func(t *testing.T) {
+			scans = nil
+			require.NoError(t, run())
+			assert.Empty(t, scans, "the query regressed to a full scan")
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// relatedBookSignals are the queries pairing listed books by each signal, each naming all the
+// columns since any of them may come first in the union
+var relatedBookSignals = map[string]string{
+	entities.RelatedSameAuthor: `
+		SELECT a.id AS book_id, b.id AS related_id, 1 AS same_author, 0 AS same_series, 0 AS shared_category, 0 AS co_borrowers
+		FROM listed a JOIN listed b ON b.author = a.author AND b.id <> a.id`,
+	entities.RelatedSameSeries: `
+		SELECT a.id AS book_id, b.id AS related_id, 0 AS same_author, 1 AS same_series, 0 AS shared_category, 0 AS co_borrowers
+		FROM listed a JOIN listed b ON b.series_id = a.series_id AND b.id <> a.id`,
+	entities.RelatedSharedCategory: `
+		SELECT a.id AS book_id, b.id AS related_id, 0 AS same_author, 0 AS same_series, 1 AS shared_category, 0 AS co_borrowers
+		FROM listed a JOIN listed b ON b.category = a.category AND b.id <> a.id
+		WHERE a.category <> ''`,
+	entities.RelatedCoBorrowed: `
+		SELECT a.book_id, b.book_id AS related_id, 0 AS same_author, 0 AS same_series, 0 AS shared_category, COUNT(DISTINCT a.user_id) AS co_borrowers
+		FROM ` + allLoans + ` a JOIN ` + allLoans + ` b ON b.user_id = a.user_id AND b.book_id <> a.book_id
+		WHERE a.book_id IN (SELECT id FROM listed) AND b.book_id IN (SELECT id FROM listed)
+		GROUP BY a.book_id, b.book_id`,
+}
+
+// RelatedBookRepositoryImpl implements the RelatedBookRepository interface
+type RelatedBookRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRelatedBookRepository creates a new related book repository
+func NewRelatedBookRepository(db *gorm.DB) repositories.RelatedBookRepository {
+	return &RelatedBookRepositoryImpl{db: db}
+}
+
+// Refresh empties and refills the related books in one transaction, so readers see either the
+// old rankings or the new ones. Signals weighing zero are not queried at all.
+func (r *RelatedBookRepositoryImpl) Refresh(at time.Time, weights entities.RelatedBookWeights, limit int) (int64, error) {
+	var signals []string
+	for _, signal := range []struct {
+		name   string
+		weight int
+	}{
+		{entities.RelatedSameAuthor, weights.SameAuthor},
+		{entities.RelatedSameSeries, weights.SameSeries},
+		{entities.RelatedSharedCategory, weights.SharedCategory},
+		{entities.RelatedCoBorrowed, weights.CoBorrowed},
+	} {
+		if signal.weight > 0 {
+			signals = append(signals, relatedBookSignals[signal.name])
+		}
+	}
+
+	var kept int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM related_books").Error; err != nil {
+			return err
+		}
+		if len(signals) == 0 {
+			return nil
+		}
+		result := tx.Exec(`
+			INSERT INTO related_books (book_id, related_id, rank, score, same_author, same_series, shared_category, co_borrowers, refreshed_at)
+			WITH listed AS (
+				SELECT id, author, series_id, COALESCE(metadata->>'`+entities.CategoryMetadataKey+`', '') AS category
+				FROM books WHERE `+listedBooks+`
+			), signals AS (`+strings.Join(signals, " UNION ALL ")+`
+			), scored AS (
+				SELECT book_id, related_id,
+					MAX(same_author) = 1 AS same_author, MAX(same_series) = 1 AS same_series,
+					MAX(shared_category) = 1 AS shared_category, SUM(co_borrowers) AS co_borrowers,
+					? * MAX(same_author) + ? * MAX(same_series) + ? * MAX(shared_category) + ? * SUM(co_borrowers) AS score
+				FROM signals
+				GROUP BY book_id, related_id
+			)
+			SELECT book_id, related_id, rank, score, same_author, same_series, shared_category, co_borrowers, ?
+			FROM (
+				SELECT scored.*, ROW_NUMBER() OVER (PARTITION BY book_id ORDER BY score DESC, related_id) AS rank
+				FROM scored
+			) ranked
+			WHERE rank <= ?`,
+			weights.SameAuthor, weights.SameSeries, weights.SharedCategory, weights.CoBorrowed, at, limit)
+		kept = result.RowsAffected
+		return result.Error
+	})
+	return kept, err
+}
+
+// ListByBookID returns the related books of a book by rank
+func (r *RelatedBookRepositoryImpl) ListByBookID(bookID string) ([]entities.RelatedBook, error) {
+	var related []entities.RelatedBook
+	err := r.db.Where("book_id = ?", bookID).Order("rank").Find(&related).Error
+	return related, err
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// ReportSubscriptionRepositoryImpl implements the ReportSubscriptionRepository interface
+type ReportSubscriptionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewReportSubscriptionRepository creates a new report subscription repository
+func NewReportSubscriptionRepository(db *gorm.DB) repositories.ReportSubscriptionRepository {
+	return &ReportSubscriptionRepositoryImpl{db: db}
+}
+
+// Create creates a new report subscription
+func (r *ReportSubscriptionRepositoryImpl) Create(subscription *entities.ReportSubscription) error {
+	return r.db.Create(subscription).Error
+}
+
+// GetByID retrieves a report subscription by ID
+func (r *ReportSubscriptionRepositoryImpl) GetByID(id string) (*entities.ReportSubscription, error) {
+	var subscription entities.ReportSubscription
+	err := r.db.Where("id = ?", id).First(&subscription).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// List retrieves all report subscriptions, oldest first
+func (r *ReportSubscriptionRepositoryImpl) List() ([]entities.ReportSubscription, error) {
+	var subscriptions []entities.ReportSubscription
+	err := r.db.Order("created_at ASC").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// Update updates an existing report subscription
+func (r *ReportSubscriptionRepositoryImpl) Update(subscription *entities.ReportSubscription) error {
+	return r.db.Save(subscription).Error
+}
+
+// Delete deletes a report subscription and its run history
+func (r *ReportSubscriptionRepositoryImpl) Delete(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&entities.ReportSubscriptionRun{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&entities.ReportSubscription{}).Error
+	})
+}
+
+// ListDue retrieves the enabled subscriptions whose next run is at or before now
+func (r *ReportSubscriptionRepositoryImpl) ListDue(now time.Time) ([]entities.ReportSubscription, error) {
+	var subscriptions []entities.ReportSubscription
+	err := r.db.Where("enabled = ? AND next_run_at <= ?", true, now).Order("next_run_at ASC").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// AddRun records a run of a subscription
+func (r *ReportSubscriptionRepositoryImpl) AddRun(run *entities.ReportSubscriptionRun) error {
+	return r.db.Create(run).Error
+}
+
+// ListRuns retrieves the latest runs of a subscription, newest first
+func (r *ReportSubscriptionRepositoryImpl) ListRuns(subscriptionID string, limit int) ([]entities.ReportSubscriptionRun, error) {
+	var runs []entities.ReportSubscriptionRun
+	err := r.db.Where("subscription_id = ?", subscriptionID).Order("started_at DESC").Limit(limit).Find(&runs).Error
+	return runs, err
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// sandboxBaselineSchema is the schema the baseline of a sandbox is copied to
+const sandboxBaselineSchema = "sandbox_baseline"
+
+// sandboxKeptTables are left alone by resets: the applied migrations, which describe the schema
+// rather than data, and the job locks, one of which the reset job holds while it runs
+var sandboxKeptTables = map[string]bool{
+	"migrations": true,
+	"job_locks":  true,
+}
+
+// SandboxRepositoryImpl implements the SandboxRepository interface
+type SandboxRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSandboxRepository creates a new sandbox repository
+func NewSandboxRepository(db *gorm.DB) repositories.SandboxRepository {
+	return &SandboxRepositoryImpl{db: db}
+}
+
+// HasBaseline reports whether a baseline has been saved
+func (r *SandboxRepositoryImpl) HasBaseline() (bool, error) {
+	var exists bool
+	err := r.db.Raw("SELECT EXISTS (SELECT 1 FROM information_schema.schemata WHERE schema_name = ?)", sandboxBaselineSchema).
+		Scan(&exists).Error
+	return exists, err
+}
+
+// SaveBaseline replaces the baseline with a copy of the data of every table
+func (r *SandboxRepositoryImpl) SaveBaseline() (int, error) {
+	var saved int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		tables, err := sandboxTables(tx)
+		if err != nil {
+			return err
+		}
+		schema := quoteIdentifier(sandboxBaselineSchema)
+		if err := tx.Exec("DROP SCHEMA IF EXISTS " + schema + " CASCADE").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+			return err
+		}
+		for _, table := range tables {
+			if err := tx.Exec(fmt.Sprintf("CREATE TABLE %s.%s AS TABLE %s", schema, quoteIdentifier(table), quoteIdentifier(table))).Error; err != nil {
+				return err
+			}
+		}
+		saved = len(tables)
+		return nil
+	})
+	return saved, err
+}
+
+// Restore empties every table and refills it from the baseline. Tables are refilled parents first,
+// so foreign keys hold at every insert, with the columns they share with their copy: columns added
+// since the baseline was saved get their default. Sequences are not restarted, so rows created
+// after the reset never take the IDs of the rows of the baseline.
+func (r *SandboxRepositoryImpl) Restore() (int, error) {
+	var restored int
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		tables, err := sandboxTables(tx)
+		if err != nil {
+			return err
+		}
+		if len(tables) == 0 {
+			return nil
+		}
+		quoted := make([]string, len(tables))
+		for i, table := range tables {
+			quoted[i] = quoteIdentifier(table)
+		}
+		if err := tx.Exec("TRUNCATE " + strings.Join(quoted, ", ") + " CASCADE").Error; err != nil {
+			return err
+		}
+
+		var parents []struct{ Child, Parent string }
+		err = tx.Raw(`SELECT child.relname AS child, parent.relname AS parent
+			FROM pg_constraint c
+			JOIN pg_class child ON child.oid = c.conrelid
+			JOIN pg_class parent ON parent.oid = c.confrelid
+			WHERE c.contype = 'f' AND c.connamespace = current_schema()::regnamespace`).Scan(&parents).Error
+		if err != nil {
+			return err
+		}
+		dependencies := make(map[string][]string)
+		for _, fk := range parents {
+			dependencies[fk.Child] = append(dependencies[fk.Child], fk.Parent)
+		}
+
+		for _, table := range insertOrder(tables, dependencies) {
+			var columns []string
+			err := tx.Raw(`SELECT column_name FROM information_schema.columns
+				WHERE table_schema = current_schema() AND table_name = ? AND is_generated = 'NEVER'
+				AND column_name IN (SELECT column_name FROM information_schema.columns WHERE table_schema = ? AND table_name = ?)
+				ORDER BY ordinal_position`, table, sandboxBaselineSchema, table).Scan(&columns).Error
+			if err != nil {
+				return err
+			}
+			if len(columns) == 0 {
+				// The table is newer than the baseline
+				continue
+			}
+			for i, column := range columns {
+				columns[i] = quoteIdentifier(column)
+			}
+			list := strings.Join(columns, ", ")
+			err = tx.Exec(fmt.Sprintf("INSERT INTO %s (%s) OVERRIDING SYSTEM VALUE SELECT %s FROM %s.%s",
+				quoteIdentifier(table), list, list, quoteIdentifier(sandboxBaselineSchema), quoteIdentifier(table))).Error
+			if err != nil {
+				return fmt.Errorf("restore %s: %w", table, err)
+			}
+			restored++
+		}
+		return nil
+	})
+	return restored, err
+}
+
+// sandboxTables lists the tables of the current schema resets copy and refill, by name
+func sandboxTables(db *gorm.DB) ([]string, error) {
+	var all []string
+	err := db.Raw(`SELECT table_name FROM information_schema.tables
+		WHERE table_schema = current_schema() AND table_type = 'BASE TABLE'
+		ORDER BY table_name`).Scan(&all).Error
+	if err != nil {
+		return nil, err
+	}
+	tables := all[:0]
+	for _, table := range all {
+		if !sandboxKeptTables[table] {
+			tables = append(tables, table)
+		}
+	}
+	return tables, nil
+}
+
+// insertOrder orders tables so that each comes after the tables it references, given by
+// dependencies. Tables referencing themselves are only ordered by their other references, and
+// cycles of references are broken at their first table by name.
+func insertOrder(tables []string, dependencies map[string][]string) []string {
+	pending := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		pending[table] = true
+	}
+	ordered := make([]string, 0, len(tables))
+	for len(pending) > 0 {
+		var ready []string
+		for table := range pending {
+			blocked := false
+			for _, parent := range dependencies[table] {
+				if parent != table && pending[parent] {
+					blocked = true
+					break
+				}
+			}
+			if !blocked {
+				ready = append(ready, table)
+			}
+		}
+		if len(ready) == 0 {
+			// Only cycles are left blocked, or tables waiting on them: one table of a cycle goes
+			// first to break it
+			ready = append(ready, cycleBreaker(pending, dependencies))
+		}
+		sort.Strings(ready)
+		for _, table := range ready {
+			delete(pending, table)
+		}
+		ordered = append(ordered, ready...)
+	}
+	return ordered
+}
+
+// cycleBreaker returns the first table by name of the pending tables referencing themselves
+// through other pending tables
+func cycleBreaker(pending map[string]bool, dependencies map[string][]string) string {
+	names := make([]string, 0, len(pending))
+	for table := range pending {
+		names = append(names, table)
+	}
+	sort.Strings(names)
+	for _, table := range names {
+		seen := map[string]bool{}
+		stack := []string{table}
+		for len(stack) > 0 {
+			current := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, parent := range dependencies[current] {
+				if parent == table && current != table {
+					return table
+				}
+				if parent != current && pending[parent] && !seen[parent] {
+					seen[parent] = true
+					stack = append(stack, parent)
+				}
+			}
+		}
+	}
+	return names[0]
+}
+
+// quoteIdentifier quotes a name for use as a Postgres identifier
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertOrder(t *testing.T) {
+	tables := []string{"book_copies", "books", "branches", "loans", "users", "works"}
+	dependencies := map[string][]string{
+		"book_copies": {"books", "branches"},
+		"books":       {"works", "books"},
+		"loans":       {"book_copies", "users"},
+		// A cycle
+		"users":    {"branches"},
+		"branches": {"users"},
+	}
+	assert.Equal(t, []string{"works", "books", "branches", "book_copies", "users", "loans"}, insertOrder(tables, dependencies))
+}
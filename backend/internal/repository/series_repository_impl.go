@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// SeriesRepositoryImpl implements the SeriesRepository interface
+type SeriesRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSeriesRepository creates a new series repository
+func NewSeriesRepository(db *gorm.DB) repositories.SeriesRepository {
+	return &SeriesRepositoryImpl{db: db}
+}
+
+// Create creates a new series
+func (r *SeriesRepositoryImpl) Create(series *entities.Series) error {
+	return r.db.Create(series).Error
+}
+
+// GetByID retrieves a series by ID
+func (r *SeriesRepositoryImpl) GetByID(id string) (*entities.Series, error) {
+	var series entities.Series
+	err := r.db.Where("id = ?", id).First(&series).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &series, nil
+}
+
+// GetAll retrieves all series ordered by name
+func (r *SeriesRepositoryImpl) GetAll() ([]entities.Series, error) {
+	var series []entities.Series
+	err := r.db.Order("name ASC").Find(&series).Error
+	return series, err
+}
+
+// FindByName finds a series by its exact name
+func (r *SeriesRepositoryImpl) FindByName(name string) (*entities.Series, error) {
+	var series entities.Series
+	err := r.db.Where("name = ?", name).First(&series).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &series, nil
+}
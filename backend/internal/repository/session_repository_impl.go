@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// SessionRepositoryImpl implements the SessionRepository interface
+type SessionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSessionRepository creates a new session repository
+func NewSessionRepository(db *gorm.DB) repositories.SessionRepository {
+	return &SessionRepositoryImpl{db: db}
+}
+
+// Create creates a new session
+func (r *SessionRepositoryImpl) Create(session *entities.Session) error {
+	return r.db.Create(session).Error
+}
+
+// GetByID retrieves a session by ID
+func (r *SessionRepositoryImpl) GetByID(id string) (*entities.Session, error) {
+	return r.first("id = ?", id)
+}
+
+// FindByHash finds the session with a token hash
+func (r *SessionRepositoryImpl) FindByHash(hash string) (*entities.Session, error) {
+	return r.first("token_hash = ?", hash)
+}
+
+// ListActiveByUser lists the active sessions of a user, last seen first
+func (r *SessionRepositoryImpl) ListActiveByUser(userID string, at time.Time) ([]entities.Session, error) {
+	var sessions []entities.Session
+	err := r.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, at).
+		Order("last_seen_at DESC, id DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// Update updates an existing session
+func (r *SessionRepositoryImpl) Update(session *entities.Session) error {
+	return r.db.Save(session).Error
+}
+
+// RevokeOthers revokes the active sessions of a user but keepID in one statement
+func (r *SessionRepositoryImpl) RevokeOthers(userID, keepID string, at time.Time) (int64, error) {
+	query := r.db.Model(&entities.Session{}).Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, at)
+	if keepID != "" {
+		query = query.Where("id <> ?", keepID)
+	}
+	result := query.Update("revoked_at", at)
+	return result.RowsAffected, result.Error
+}
+
+// ListImpersonations lists the impersonation sessions started since a time, newest first
+func (r *SessionRepositoryImpl) ListImpersonations(since time.Time) ([]entities.Session, error) {
+	var sessions []entities.Session
+	err := r.db.Where("impersonator_id IS NOT NULL AND created_at >= ?", since).
+		Order("created_at DESC, id DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// first returns the first session matching a condition, nil when none does
+func (r *SessionRepositoryImpl) first(query string, value string) (*entities.Session, error) {
+	var session entities.Session
+	err := r.db.Where(query, value).First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// bootstrapLockKey is the Postgres advisory lock serializing concurrent bootstrap attempts
+const bootstrapLockKey = 0x626f6f74 // "boot"
+
+// SetupRepositoryImpl implements the SetupRepository interface
+type SetupRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSetupRepository creates a new setup repository
+func NewSetupRepository(db *gorm.DB) repositories.SetupRepository {
+	return &SetupRepositoryImpl{db: db}
+}
+
+// IsBootstrapped reports whether the deployment already has users
+func (r *SetupRepositoryImpl) IsBootstrapped() (bool, error) {
+	return hasUsers(r.db)
+}
+
+// Bootstrap creates the tenant, its admin and its policies in one transaction
+func (r *SetupRepositoryImpl) Bootstrap(bootstrap *entities.Bootstrap) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", bootstrapLockKey).Error; err != nil {
+			return err
+		}
+		bootstrapped, err := hasUsers(tx)
+		if err != nil {
+			return err
+		}
+		if bootstrapped {
+			return domainerr.ErrAlreadyBootstrapped
+		}
+
+		if err := tx.Create(&bootstrap.Tenant).Error; err != nil {
+			return err
+		}
+		bootstrap.Admin.TenantID = bootstrap.Tenant.ID
+		if err := tx.Create(&bootstrap.Admin).Error; err != nil {
+			return err
+		}
+		for i := range bootstrap.Policies {
+			bootstrap.Policies[i].TenantID = bootstrap.Tenant.ID
+		}
+		if len(bootstrap.Policies) == 0 {
+			return nil
+		}
+		return tx.Create(&bootstrap.Policies).Error
+	})
+}
+
+func hasUsers(db *gorm.DB) (bool, error) {
+	var count int64
+	err := db.Model(&entities.User{}).Limit(1).Count(&count).Error
+	return count > 0, err
+}
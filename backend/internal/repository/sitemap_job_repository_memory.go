@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"sync"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// InMemorySitemapJobRepository implements the SitemapJobRepository interface in process. Jobs
+// and their artifacts are short-lived, so only the latest maxJobs are kept.
+type InMemorySitemapJobRepository struct {
+	mu      sync.Mutex
+	jobs    map[string]entities.SitemapJob
+	order   []string
+	maxJobs int
+}
+
+// NewInMemorySitemapJobRepository creates a new in-memory sitemap job repository keeping at most maxJobs jobs
+func NewInMemorySitemapJobRepository(maxJobs int) repositories.SitemapJobRepository {
+	return &InMemorySitemapJobRepository{jobs: make(map[string]entities.SitemapJob), maxJobs: maxJobs}
+}
+
+// Create stores a new job, dropping the oldest ones beyond the limit
+func (r *InMemorySitemapJobRepository) Create(job *entities.SitemapJob) error {
+	job.EnsureID()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs[job.ID] = *job
+	r.order = append(r.order, job.ID)
+	for len(r.order) > r.maxJobs && len(r.order) > 1 {
+		delete(r.jobs, r.order[0])
+		r.order = r.order[1:]
+	}
+	return nil
+}
+
+// GetByID retrieves a job by ID, or nil when it does not exist or was dropped
+func (r *InMemorySitemapJobRepository) GetByID(id string) (*entities.SitemapJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	return &job, nil
+}
+
+// Update replaces a stored job
+func (r *InMemorySitemapJobRepository) Update(job *entities.SitemapJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.jobs[job.ID]; !ok {
+		return domainerr.ErrSitemapJobNotFound
+	}
+	r.jobs[job.ID] = *job
+	return nil
+}
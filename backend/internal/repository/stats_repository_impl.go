@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// listedBooks is the SQL condition of the books the catalog lists: not deleted, drafted or scheduled
+const listedBooks = "deleted_at IS NULL AND status <> 'draft' AND publish_at IS NULL"
+
+// StatsRepositoryImpl implements the StatsRepository interface
+type StatsRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewStatsRepository creates a new stats repository
+func NewStatsRepository(db *gorm.DB) repositories.StatsRepository {
+	return &StatsRepositoryImpl{db: db}
+}
+
+// Refresh empties and refills every stats table, then records the refreshes, in one transaction
+// so readers see either the old figures or the new ones
+func (r *StatsRepositoryImpl) Refresh(at time.Time, topAuthors int, timezone string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		refills := map[string]string{
+			entities.StatsBooksPerYear: `
+				INSERT INTO books_per_year (year, books)
+				SELECT year, COUNT(*) FROM books
+				WHERE ` + listedBooks + `
+				GROUP BY year`,
+			entities.StatsLoansPerMonth: `
+				INSERT INTO loans_per_month (month, loans, returned)
+				SELECT to_char(loans.borrowed_at AT TIME ZONE COALESCE(NULLIF(branches.timezone, ''), NULLIF(tenants.timezone, ''), ?), 'YYYY-MM'),
+					COUNT(*), COUNT(loans.returned_at)
+				FROM ` + allLoans + ` loans
+				LEFT JOIN tenants ON tenants.id = loans.tenant_id
+				LEFT JOIN books ON books.id = loans.book_id
+				LEFT JOIN branches ON branches.id = books.branch_id
+				GROUP BY 1`,
+			entities.StatsTopAuthors: `
+				INSERT INTO top_authors (rank, author, books, loans)
+				SELECT ROW_NUMBER() OVER (ORDER BY loans DESC, books DESC, author), author, books, loans
+				FROM (
+					SELECT books.author, COUNT(DISTINCT books.id) AS books, COUNT(loans.id) AS loans
+					FROM books LEFT JOIN ` + allLoans + ` loans ON loans.book_id = books.id
+					WHERE books.` + listedBooks + `
+					GROUP BY books.author
+				) authors
+				ORDER BY loans DESC, books DESC, author
+				LIMIT ?`,
+		}
+
+		refreshes := make([]entities.StatsRefresh, 0, len(entities.StatsTables))
+		for _, table := range entities.StatsTables {
+			if err := tx.Exec("DELETE FROM " + table).Error; err != nil {
+				return err
+			}
+			var args []interface{}
+			switch table {
+			case entities.StatsLoansPerMonth:
+				args = append(args, timezone)
+			case entities.StatsTopAuthors:
+				args = append(args, topAuthors)
+			}
+			result := tx.Exec(refills[table], args...)
+			if result.Error != nil {
+				return result.Error
+			}
+			refreshes = append(refreshes, entities.StatsRefresh{Name: table, RefreshedAt: at, Rows: result.RowsAffected})
+		}
+
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"refreshed_at", "rows"}),
+		}).Create(&refreshes).Error
+	})
+}
+
+// BooksPerYear returns the books per year, oldest year first
+func (r *StatsRepositoryImpl) BooksPerYear() ([]entities.BooksPerYear, error) {
+	var rows []entities.BooksPerYear
+	err := r.db.Order("year").Find(&rows).Error
+	return rows, err
+}
+
+// LoansPerMonth returns the loans per month, oldest month first
+func (r *StatsRepositoryImpl) LoansPerMonth() ([]entities.LoansPerMonth, error) {
+	var rows []entities.LoansPerMonth
+	err := r.db.Order("month").Find(&rows).Error
+	return rows, err
+}
+
+// TopAuthors returns the top authors by rank
+func (r *StatsRepositoryImpl) TopAuthors() ([]entities.TopAuthor, error) {
+	var rows []entities.TopAuthor
+	err := r.db.Order("rank").Find(&rows).Error
+	return rows, err
+}
+
+// Refreshes returns the last refresh of each table that has been refreshed
+func (r *StatsRepositoryImpl) Refreshes() ([]entities.StatsRefresh, error) {
+	var refreshes []entities.StatsRefresh
+	err := r.db.Order("name").Find(&refreshes).Error
+	return refreshes, err
+}
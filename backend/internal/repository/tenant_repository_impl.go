@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// TenantRepositoryImpl implements the TenantRepository interface
+type TenantRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewTenantRepository creates a new tenant repository
+func NewTenantRepository(db *gorm.DB) repositories.TenantRepository {
+	return &TenantRepositoryImpl{db: db}
+}
+
+// GetByID retrieves a tenant by ID
+func (r *TenantRepositoryImpl) GetByID(id string) (*entities.Tenant, error) {
+	var tenant entities.Tenant
+	err := r.db.Where("id = ?", id).First(&tenant).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &tenant, nil
+}
+
+// Update updates an existing tenant
+func (r *TenantRepositoryImpl) Update(tenant *entities.Tenant) error {
+	return r.db.Save(tenant).Error
+}
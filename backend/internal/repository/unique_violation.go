@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// uniqueViolationState is the SQLSTATE of Postgres for a write breaking a unique index
+const uniqueViolationState = "23505"
+
+// isUniqueViolation reports whether a write failed because it broke a unique index: by the
+// SQLSTATE of Postgres errors, by the message of SQLite, or as translated by GORM
+func isUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	var state interface{ SQLState() string }
+	if errors.As(err, &state) {
+		return state.SQLState() == uniqueViolationState
+	}
+	return strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// sqlStateError is an error with a SQLSTATE, like those of the Postgres driver
+type sqlStateError string
+
+func (e sqlStateError) Error() string    { return "ERROR: SQLSTATE " + string(e) }
+func (e sqlStateError) SQLState() string { return string(e) }
+
+func TestIsUniqueViolation(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "postgres unique violation", err: fmt.Errorf("saving user: %w", sqlStateError("23505")), expected: true},
+		{name: "postgres other error", err: sqlStateError("23502")},
+		{name: "sqlite unique violation", err: errors.New("UNIQUE constraint failed: index 'idx_users_email_lower'"), expected: true},
+		{name: "translated by gorm", err: gorm.ErrDuplicatedKey, expected: true},
+		{name: "other error", err: errors.New("connection refused")},
+		{name: "no error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isUniqueViolation(tt.err))
+		})
+	}
+}
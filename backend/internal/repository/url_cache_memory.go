@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"library-management-system/internal/domain/repositories"
+)
+
+// InMemoryURLCache implements the URLCache interface in process, for single instances
+type InMemoryURLCache struct {
+	mu         sync.Mutex
+	entries    map[string]urlCacheEntry
+	maxEntries int
+}
+
+type urlCacheEntry struct {
+	processedURL string
+	expiresAt    time.Time
+}
+
+// NewInMemoryURLCache creates a new in-memory URL cache holding at most maxEntries results
+func NewInMemoryURLCache(maxEntries int) repositories.URLCache {
+	return &InMemoryURLCache{entries: make(map[string]urlCacheEntry), maxEntries: maxEntries}
+}
+
+// Get returns the processed URL cached under a key unless it expired
+func (c *InMemoryURLCache) Get(key string) (string, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return "", false, nil
+	}
+	if !time.Now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return "", false, nil
+	}
+	return entry.processedURL, true, nil
+}
+
+// Set caches a processed URL. When the cache is full, expired results are dropped first, then
+// arbitrary ones.
+func (c *InMemoryURLCache) Set(key, processedURL string, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		for k := range c.entries {
+			if len(c.entries) < c.maxEntries {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = urlCacheEntry{processedURL: processedURL, expiresAt: now.Add(ttl)}
+	return nil
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"library-management-system/internal/domain/repositories"
+	"library-management-system/internal/infrastructure/redis"
+)
+
+// urlCachePrefix namespaces the keys of the URL cache in a shared Redis database
+const urlCachePrefix = "url:"
+
+// RedisURLCache implements the URLCache interface on Redis, shared by every instance
+type RedisURLCache struct {
+	client *redis.Client
+}
+
+// NewRedisURLCache creates a new URL cache stored in Redis
+func NewRedisURLCache(client *redis.Client) repositories.URLCache {
+	return &RedisURLCache{client: client}
+}
+
+// Get returns the processed URL cached under a key; Redis drops expired keys itself
+func (c *RedisURLCache) Get(key string) (string, bool, error) {
+	processedURL, err := c.client.Get(urlCachePrefix + key)
+	if errors.Is(err, redis.ErrNil) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return processedURL, true, nil
+}
+
+// Set caches a processed URL for ttl
+func (c *RedisURLCache) Set(key, processedURL string, ttl time.Duration) error {
+	return c.client.Set(urlCachePrefix+key, processedURL, ttl)
+}
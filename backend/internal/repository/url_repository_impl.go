@@ -1,23 +1,78 @@
 package repository
 
 import (
+	"errors"
+	"time"
+	"unicode/utf8"
+
 	"library-management-system/internal/domain/entities"
 	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
 )
 
-// URLRepositoryImpl implements the URLRepository interface
-type URLRepositoryImpl struct{}
+// URLRepositoryImpl implements the URLRepository interface, keeping the history in the database
+// and the results in a cache
+type URLRepositoryImpl struct {
+	db *gorm.DB
+	// cache holds the results of recent processing, nil when caching is off
+	cache repositories.URLCache
+}
+
+// NewURLRepository creates a new URL repository caching results in cache, which may be nil
+func NewURLRepository(db *gorm.DB, cache repositories.URLCache) repositories.URLRepository {
+	return &URLRepositoryImpl{db: db, cache: cache}
+}
+
+// SaveResult caches a processed URL and records it in the history. Both are attempted whatever
+// the other does, so a cache outage does not lose the history nor the other way round.
+func (r *URLRepositoryImpl) SaveResult(result *entities.URLResult, ttl time.Duration) error {
+	var cacheErr error
+	if r.cache != nil && ttl > 0 {
+		cacheErr = r.cache.Set(result.Key, result.ProcessedURL, ttl)
+	}
+	return errors.Join(cacheErr, r.db.Create(result).Error)
+}
+
+// GetCached returns the processed URL cached under a key, never finding one without a cache
+func (r *URLRepositoryImpl) GetCached(key string) (string, bool, error) {
+	if r.cache == nil {
+		return "", false, nil
+	}
+	return r.cache.Get(key)
+}
+
+// History returns the latest results matching a filter, newest first, after a position
+func (r *URLRepositoryImpl) History(filter repositories.URLHistoryFilter, after *repositories.PageAfter, limit int) ([]entities.URLResult, error) {
+	var results []entities.URLResult
+	err := r.historyQuery(filter, after, limit).Find(&results).Error
+	return results, err
+}
 
-// NewURLRepository creates a new URL repository
-func NewURLRepository() repositories.URLRepository {
-	return &URLRepositoryImpl{}
+// historyQuery builds the query of a page of the history
+func (r *URLRepositoryImpl) historyQuery(filter repositories.URLHistoryFilter, after *repositories.PageAfter, limit int) *gorm.DB {
+	query := r.db.Order("created_at DESC, id DESC").Limit(limit)
+	if filter.Domain != "" {
+		// right counts characters, not bytes, which international hosts have more of
+		query = query.Where("(host = ? OR right(host, ?) = ?)", filter.Domain, utf8.RuneCountInString(filter.Domain)+1, "."+filter.Domain)
+	}
+	if filter.Operation != "" {
+		query = query.Where("? = ANY(string_to_array(operations, ','))", filter.Operation)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+	if after != nil {
+		query = query.Where("(created_at, id) < (?, ?)", after.Time, after.ID)
+	}
+	return query
 }
 
-// ProcessURL processes a URL according to the specified operation
-func (r *URLRepositoryImpl) ProcessURL(request *entities.URLRequest) (*entities.URLResponse, error) {
-	// This is a simple implementation that delegates to the use case
-	// In a real application, this might involve external services or caching
-	return &entities.URLResponse{
-		ProcessedURL: request.URL, // Placeholder - actual processing is done in use case
-	}, nil
+// DeleteBefore deletes up to limit results recorded before a time, oldest first
+func (r *URLRepositoryImpl) DeleteBefore(before time.Time, limit int) (int64, error) {
+	result := r.db.Exec("DELETE FROM url_results WHERE id IN (SELECT id FROM url_results WHERE created_at < ? ORDER BY created_at LIMIT ?)", before, limit)
+	return result.RowsAffected, result.Error
 }
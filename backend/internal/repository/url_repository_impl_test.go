@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"testing"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestURLRepositoryImpl_HistoryDomain(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+	repo := &URLRepositoryImpl{db: db}
+
+	// Subdomains are matched by the characters of the domain, not its bytes
+	for domain, length := range map[string]int{"byfood.com": 11, "bücher.de": 10} {
+		var results []entities.URLResult
+		query := repo.historyQuery(repositories.URLHistoryFilter{Domain: domain}, nil, 10).Find(&results)
+		assert.Contains(t, query.Statement.SQL.String(), "(host = $1 OR right(host, $2) = $3)")
+		assert.Equal(t, []interface{}{domain, length, "." + domain, 10}, query.Statement.Vars, domain)
+	}
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"sync"
+
+	"library-management-system/internal/domain/repositories"
+)
+
+// InMemoryUsageRepository implements the UsageRepository interface with in-process counters
+type InMemoryUsageRepository struct {
+	mu       sync.Mutex
+	counters map[string]int64
+}
+
+// NewInMemoryUsageRepository creates a new in-memory usage repository
+func NewInMemoryUsageRepository() repositories.UsageRepository {
+	return &InMemoryUsageRepository{counters: make(map[string]int64)}
+}
+
+// Increment increments the counter of a subject in a period and returns the new value
+func (r *InMemoryUsageRepository) Increment(subject, period string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := period + "|" + subject
+	r.counters[key]++
+	return r.counters[key], nil
+}
+
+// Get returns the counter of a subject in a period
+func (r *InMemoryUsageRepository) Get(subject, period string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.counters[period+"|"+subject], nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"library-management-system/internal/domain/repositories"
+	"library-management-system/internal/infrastructure/redis"
+)
+
+// usagePrefix namespaces the usage counters in a shared Redis database
+const usagePrefix = "usage:"
+
+// usageTTL keeps a counter past the end of its monthly period, then lets Redis drop it
+const usageTTL = 40 * 24 * time.Hour
+
+// incrementWithTTL increments a counter and sets its expiry when it is created, in one step
+const incrementWithTTL = `local count = redis.call('INCR', KEYS[1])
+if count == 1 then redis.call('PEXPIRE', KEYS[1], ARGV[1]) end
+return count`
+
+// RedisUsageRepository implements the UsageRepository interface on Redis counters, shared by every instance
+type RedisUsageRepository struct {
+	client *redis.Client
+}
+
+// NewRedisUsageRepository creates a new usage repository stored in Redis
+func NewRedisUsageRepository(client *redis.Client) repositories.UsageRepository {
+	return &RedisUsageRepository{client: client}
+}
+
+// Increment increments the counter of a subject in a period and returns the new value
+func (r *RedisUsageRepository) Increment(subject, period string) (int64, error) {
+	reply, err := r.client.Eval(incrementWithTTL, []string{usageKey(subject, period)}, strconv.FormatInt(usageTTL.Milliseconds(), 10))
+	if err != nil {
+		return 0, err
+	}
+	count, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected usage counter %v", reply)
+	}
+	return count, nil
+}
+
+// Get returns the counter of a subject in a period
+func (r *RedisUsageRepository) Get(subject, period string) (int64, error) {
+	value, err := r.client.Get(usageKey(subject, period))
+	if errors.Is(err, redis.ErrNil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+func usageKey(subject, period string) string {
+	return usagePrefix + period + ":" + subject
+}
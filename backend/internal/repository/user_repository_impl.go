@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// UserRepositoryImpl implements the UserRepository interface
+type UserRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewUserRepository creates a new user repository
+func NewUserRepository(db *gorm.DB) repositories.UserRepository {
+	return &UserRepositoryImpl{db: db}
+}
+
+// FindByEmail finds a user by email, whatever its case, on the index of lower-cased emails
+func (r *UserRepositoryImpl) FindByEmail(email string) (*entities.User, error) {
+	var user entities.User
+	err := r.db.Where("LOWER(email) = ?", entities.NormalizeEmail(email)).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetByID retrieves a user by ID
+func (r *UserRepositoryImpl) GetByID(id string) (*entities.User, error) {
+	var user entities.User
+	err := r.db.Where("id = ?", id).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Update updates an existing user. An email another user has in any case, taken between the
+// check of the use case and the write, fails with domainerr.ErrDuplicateEmail.
+func (r *UserRepositoryImpl) Update(user *entities.User) error {
+	err := r.db.Save(user).Error
+	if isUniqueViolation(err) {
+		return domainerr.ErrDuplicateEmail
+	}
+	return err
+}
+
+// ListDeactivatedBefore lists the users deactivated before a time and not anonymized yet
+func (r *UserRepositoryImpl) ListDeactivatedBefore(at time.Time) ([]entities.User, error) {
+	var users []entities.User
+	err := r.db.Where("deactivated_at < ? AND anonymized_at IS NULL", at).Order("deactivated_at ASC").Find(&users).Error
+	return users, err
+}
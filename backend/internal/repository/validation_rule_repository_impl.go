@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// ValidationRuleRepositoryImpl implements the ValidationRuleRepository interface
+type ValidationRuleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewValidationRuleRepository creates a new validation rule repository
+func NewValidationRuleRepository(db *gorm.DB) repositories.ValidationRuleRepository {
+	return &ValidationRuleRepositoryImpl{db: db}
+}
+
+// Create creates a new validation rule
+func (r *ValidationRuleRepositoryImpl) Create(rule *entities.ValidationRule) error {
+	return r.db.Create(rule).Error
+}
+
+// GetByID retrieves a validation rule by ID
+func (r *ValidationRuleRepositoryImpl) GetByID(id string) (*entities.ValidationRule, error) {
+	var rule entities.ValidationRule
+	err := r.db.Where("id = ?", id).First(&rule).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// List retrieves all validation rules, oldest first
+func (r *ValidationRuleRepositoryImpl) List() ([]entities.ValidationRule, error) {
+	var rules []entities.ValidationRule
+	err := r.db.Order("created_at ASC").Find(&rules).Error
+	return rules, err
+}
+
+// ListEnabled retrieves the enabled validation rules, oldest first
+func (r *ValidationRuleRepositoryImpl) ListEnabled() ([]entities.ValidationRule, error) {
+	var rules []entities.ValidationRule
+	err := r.db.Where("enabled = ?", true).Order("created_at ASC").Find(&rules).Error
+	return rules, err
+}
+
+// Update updates an existing validation rule
+func (r *ValidationRuleRepositoryImpl) Update(rule *entities.ValidationRule) error {
+	return r.db.Save(rule).Error
+}
+
+// Delete deletes a validation rule
+func (r *ValidationRuleRepositoryImpl) Delete(id string) error {
+	return r.db.Where("id = ?", id).Delete(&entities.ValidationRule{}).Error
+}
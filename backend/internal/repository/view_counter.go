@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"sync"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/repositories"
+)
+
+// ViewCounter counts book views in memory and adds them to the daily stats of the books every
+// flush interval, so that reading a book does not write to the database. A flush that fails keeps
+// its counts for the next one. Views counted since the last flush are lost if the process exits
+// without Close.
+//
+// A visitor's views of a book are counted once per dedup window. Visitors are only remembered in
+// memory, as a hash of the visitor and the book, until their window ends; the database only
+// gets the number of views of each book per day.
+type ViewCounter struct {
+	repo          repositories.BookStatsRepository
+	flushInterval time.Duration
+	dedupWindow   time.Duration
+	clock         clock.Clock
+
+	mu     sync.Mutex
+	counts map[string]int64
+	// seen holds when each visit was last counted, by visitKey
+	seen   map[string]time.Time
+	closed bool
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// NewViewCounter creates a counter in front of repo and starts flushing it every flushInterval.
+// A zero dedupWindow counts every view.
+func NewViewCounter(repo repositories.BookStatsRepository, flushInterval, dedupWindow time.Duration) *ViewCounter {
+	if flushInterval <= 0 {
+		flushInterval = time.Minute
+	}
+	c := &ViewCounter{
+		repo:          repo,
+		flushInterval: flushInterval,
+		dedupWindow:   dedupWindow,
+		clock:         clock.System{},
+		counts:        make(map[string]int64),
+		seen:          make(map[string]time.Time),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+	go c.run()
+	return c
+}
+
+// SetClock replaces the clock that views are dated and deduplicated with
+func (c *ViewCounter) SetClock(clk clock.Clock) {
+	c.mu.Lock()
+	c.clock = clk
+	c.mu.Unlock()
+}
+
+// RecordView counts a view of a book by a visitor, unless the visitor's last view of the book
+// was counted less than the dedup window ago. Views of anonymous visitors, with an empty
+// visitor, are always counted. It reports whether the view was counted.
+func (c *ViewCounter) RecordView(bookID, visitor string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.dedupWindow > 0 && visitor != "" {
+		now := c.clock.Now()
+		key := visitKey(bookID, visitor)
+		if last, ok := c.seen[key]; ok && now.Sub(last) < c.dedupWindow {
+			return false
+		}
+		c.seen[key] = now
+	}
+	c.counts[bookID]++
+	return true
+}
+
+// visitKey identifies the views of a book by a visitor without keeping the visitor
+func visitKey(bookID, visitor string) string {
+	sum := sha256.Sum256([]byte(visitor + "\x00" + bookID))
+	return hex.EncodeToString(sum[:16])
+}
+
+// Flush adds the views counted so far to the stats of the current day and forgets the visitors
+// whose dedup window has ended
+func (c *ViewCounter) Flush() error {
+	c.mu.Lock()
+	counts := c.counts
+	c.counts = make(map[string]int64)
+	now := c.clock.Now()
+	for key, last := range c.seen {
+		if now.Sub(last) >= c.dedupWindow {
+			delete(c.seen, key)
+		}
+	}
+	c.mu.Unlock()
+
+	if err := c.repo.AddViews(now, counts); err != nil {
+		c.mu.Lock()
+		for bookID, count := range counts {
+			c.counts[bookID] += count
+		}
+		c.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Close stops the periodic flushes and flushes the views left, until ctx ends
+func (c *ViewCounter) Close(ctx context.Context) error {
+	c.mu.Lock()
+	if !c.closed {
+		c.closed = true
+		close(c.stop)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run flushes the counts every flush interval and once more when the counter is closed
+func (c *ViewCounter) run() {
+	defer close(c.done)
+	ticker := time.NewTicker(c.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.Flush(); err != nil {
+				log.Printf("Failed to flush book view counts, retrying next time: %v", err)
+			}
+		case <-c.stop:
+			if err := c.Flush(); err != nil {
+				log.Printf("Dropped book view counts on shutdown: %v", err)
+			}
+			return
+		}
+	}
+}
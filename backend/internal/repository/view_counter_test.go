@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBookStatsRepository adds up the views written to it and fails while failing is set
+type fakeBookStatsRepository struct {
+	mu      sync.Mutex
+	views   map[string]int64
+	failing bool
+}
+
+func (r *fakeBookStatsRepository) AddViews(day time.Time, views map[string]int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failing {
+		return errors.New("database unavailable")
+	}
+	if r.views == nil {
+		r.views = make(map[string]int64)
+	}
+	for bookID, count := range views {
+		r.views[bookID] += count
+	}
+	return nil
+}
+
+func (r *fakeBookStatsRepository) RefreshPopularity(since time.Time, loanWeight int64) error {
+	return nil
+}
+
+func (r *fakeBookStatsRepository) written() map[string]int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	written := make(map[string]int64, len(r.views))
+	for bookID, count := range r.views {
+		written[bookID] = count
+	}
+	return written
+}
+
+func TestViewCounter_KeepsCountsOfFailedFlushes(t *testing.T) {
+	repo := &fakeBookStatsRepository{failing: true}
+	counter := NewViewCounter(repo, time.Hour, 0)
+	counter.RecordView("book-1", "")
+	counter.RecordView("book-1", "")
+	counter.RecordView("book-2", "")
+
+	assert.Error(t, counter.Flush())
+	assert.Empty(t, repo.written())
+
+	repo.mu.Lock()
+	repo.failing = false
+	repo.mu.Unlock()
+	counter.RecordView("book-1", "")
+	require.NoError(t, counter.Flush())
+	assert.Equal(t, map[string]int64{"book-1": 3, "book-2": 1}, repo.written())
+
+	// Counts are not written twice
+	require.NoError(t, counter.Flush())
+	assert.Equal(t, map[string]int64{"book-1": 3, "book-2": 1}, repo.written())
+	require.NoError(t, counter.Close(context.Background()))
+}
+
+func TestViewCounter_FlushesPeriodicallyAndOnClose(t *testing.T) {
+	repo := &fakeBookStatsRepository{}
+	counter := NewViewCounter(repo, 10*time.Millisecond, 0)
+	counter.RecordView("book-1", "")
+	assert.Eventually(t, func() bool {
+		return repo.written()["book-1"] == 1
+	}, time.Second, 5*time.Millisecond)
+
+	counter.RecordView("book-2", "")
+	require.NoError(t, counter.Close(context.Background()))
+	assert.Equal(t, int64(1), repo.written()["book-2"])
+}
+
+func TestViewCounter_CountsAVisitorOncePerDedupWindow(t *testing.T) {
+	repo := &fakeBookStatsRepository{}
+	now := clock.NewFixed(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC))
+	counter := NewViewCounter(repo, time.Hour, 30*time.Minute)
+	counter.SetClock(now)
+
+	assert.True(t, counter.RecordView("book-1", "ip:10.0.0.1"))
+	assert.False(t, counter.RecordView("book-1", "ip:10.0.0.1"))
+	assert.True(t, counter.RecordView("book-2", "ip:10.0.0.1"))
+	assert.True(t, counter.RecordView("book-1", "session:abc"))
+	assert.True(t, counter.RecordView("book-1", ""))
+	assert.True(t, counter.RecordView("book-1", ""))
+
+	now.Advance(29 * time.Minute)
+	assert.False(t, counter.RecordView("book-1", "ip:10.0.0.1"))
+	now.Advance(time.Minute)
+	assert.True(t, counter.RecordView("book-1", "ip:10.0.0.1"))
+
+	require.NoError(t, counter.Close(context.Background()))
+	assert.Equal(t, map[string]int64{"book-1": 5, "book-2": 1}, repo.written())
+}
+
+func TestViewCounter_ForgetsVisitorsOnFlush(t *testing.T) {
+	repo := &fakeBookStatsRepository{}
+	now := clock.NewFixed(time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC))
+	counter := NewViewCounter(repo, time.Hour, 30*time.Minute)
+	counter.SetClock(now)
+	counter.RecordView("book-1", "ip:10.0.0.1")
+	counter.RecordView("book-2", "ip:10.0.0.1")
+
+	now.Advance(10 * time.Minute)
+	counter.RecordView("book-3", "ip:10.0.0.1")
+	now.Advance(25 * time.Minute)
+	require.NoError(t, counter.Flush())
+
+	counter.mu.Lock()
+	assert.Len(t, counter.seen, 1)
+	assert.NotContains(t, counter.seen, "ip:10.0.0.1")
+	counter.mu.Unlock()
+	require.NoError(t, counter.Close(context.Background()))
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+// WorkRepositoryImpl implements the WorkRepository interface
+type WorkRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewWorkRepository creates a new work repository
+func NewWorkRepository(db *gorm.DB) repositories.WorkRepository {
+	return &WorkRepositoryImpl{db: db}
+}
+
+// Create creates a new work
+func (r *WorkRepositoryImpl) Create(work *entities.Work) error {
+	return r.db.Create(work).Error
+}
+
+// GetByID retrieves a work by ID
+func (r *WorkRepositoryImpl) GetByID(id string) (*entities.Work, error) {
+	var work entities.Work
+	err := r.db.Where("id = ?", id).First(&work).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &work, nil
+}
+
+// GetAll retrieves all works ordered by title
+func (r *WorkRepositoryImpl) GetAll() ([]entities.Work, error) {
+	var works []entities.Work
+	err := r.db.Order("title ASC").Find(&works).Error
+	return works, err
+}
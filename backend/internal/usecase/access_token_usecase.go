@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+const (
+	// maxAccessTokenNameLength bounds the names users give their tokens
+	maxAccessTokenNameLength = 100
+	// lastUsedResolution is how stale the last use of a token may get before it is written again,
+	// so busy tokens do not cost a write per request
+	lastUsedResolution = time.Minute
+)
+
+// AccessTokenRequest holds what a user asks of a new token
+type AccessTokenRequest struct {
+	Name   string
+	Scopes []string
+	// ExpiresInDays is the lifetime of the token, the default lifetime when zero
+	ExpiresInDays int
+}
+
+// AccessTokenUseCase issues the personal access tokens users sign requests in with, and signs
+// them in
+type AccessTokenUseCase struct {
+	tokenRepo repositories.AccessTokenRepository
+	userRepo  repositories.UserRepository
+	clock     clock.Clock
+	// lifetime is how long tokens last unless asked otherwise, and maxLifetime how long they may;
+	// zero lets them last forever
+	lifetime    time.Duration
+	maxLifetime time.Duration
+}
+
+// NewAccessTokenUseCase creates a new access token use case
+func NewAccessTokenUseCase(tokenRepo repositories.AccessTokenRepository, userRepo repositories.UserRepository, lifetime, maxLifetime time.Duration) *AccessTokenUseCase {
+	return &AccessTokenUseCase{
+		tokenRepo:   tokenRepo,
+		userRepo:    userRepo,
+		clock:       clock.System{},
+		lifetime:    lifetime,
+		maxLifetime: maxLifetime,
+	}
+}
+
+// SetClock replaces the clock tokens expire and are used by
+func (uc *AccessTokenUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// Create issues a token to a user and returns it with the token itself, which is not stored and
+// cannot be shown again. Only admins can grant admin:*.
+func (uc *AccessTokenUseCase) Create(userID string, req AccessTokenRequest) (*entities.AccessToken, string, error) {
+	user, err := uc.user(userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !user.Active() {
+		return nil, "", domainerr.ErrMemberDeactivated
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxAccessTokenNameLength {
+		return nil, "", fmt.Errorf("token name must be between 1 and %d characters", maxAccessTokenNameLength)
+	}
+	scopes, err := accessTokenScopes(req.Scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, scope := range scopes {
+		if scope == entities.ScopeAdmin && user.Role != entities.UserRoleAdmin {
+			return nil, "", domainerr.ErrScopeNotAllowed
+		}
+	}
+
+	if req.ExpiresInDays < 0 {
+		return nil, "", errors.New("expires_in_days cannot be negative")
+	}
+	lifetime := uc.lifetime
+	if req.ExpiresInDays > 0 {
+		lifetime = time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	}
+	if uc.maxLifetime > 0 && (lifetime == 0 || lifetime > uc.maxLifetime) {
+		return nil, "", fmt.Errorf("tokens expire within %d days at most", int(uc.maxLifetime.Hours()/24))
+	}
+
+	secret := entities.NewAccessTokenSecret()
+	token := &entities.AccessToken{
+		UserID:    user.ID,
+		Name:      name,
+		Hint:      entities.AccessTokenHint(secret),
+		TokenHash: entities.HashAccessToken(secret),
+		Scopes:    scopes,
+	}
+	if lifetime > 0 {
+		expiresAt := uc.clock.Now().Add(lifetime)
+		token.ExpiresAt = &expiresAt
+	}
+	if err := uc.tokenRepo.Create(token); err != nil {
+		return nil, "", err
+	}
+	return token, secret, nil
+}
+
+// List lists the tokens of a user, newest first, revoked and expired ones included
+func (uc *AccessTokenUseCase) List(userID string) ([]entities.AccessToken, error) {
+	if _, err := uc.user(userID); err != nil {
+		return nil, err
+	}
+	return uc.tokenRepo.ListByUser(userID)
+}
+
+// Revoke revokes a token of a user for good; revoking it again changes nothing
+func (uc *AccessTokenUseCase) Revoke(userID, id string) (*entities.AccessToken, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	token, err := uc.tokenRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || token.UserID != userID {
+		return nil, domainerr.ErrAccessTokenNotFound
+	}
+	if token.RevokedAt != nil {
+		return token, nil
+	}
+
+	now := uc.clock.Now()
+	token.RevokedAt = &now
+	if err := uc.tokenRepo.Update(token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Authenticate returns the active token a request presents and its user, recording its use.
+// Unknown, expired and revoked tokens, and those of deleted and deactivated users, fail with
+// domainerr.ErrInvalidAccessToken.
+func (uc *AccessTokenUseCase) Authenticate(secret string) (*entities.AccessToken, *entities.User, error) {
+	if !strings.HasPrefix(secret, entities.AccessTokenPrefix) {
+		return nil, nil, domainerr.ErrInvalidAccessToken
+	}
+	token, err := uc.tokenRepo.FindByHash(entities.HashAccessToken(secret))
+	if err != nil {
+		return nil, nil, err
+	}
+	now := uc.clock.Now()
+	if token == nil || !token.Active(now) {
+		return nil, nil, domainerr.ErrInvalidAccessToken
+	}
+	user, err := uc.userRepo.GetByID(token.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil || !user.Active() {
+		return nil, nil, domainerr.ErrInvalidAccessToken
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= lastUsedResolution {
+		token.LastUsedAt = &now
+		// The request is let through even if its use cannot be recorded
+		if err := uc.tokenRepo.Update(token); err != nil {
+			log.Printf("Failed to record the use of access token %s: %v", token.ID, err)
+		}
+	}
+	return token, user, nil
+}
+
+// user returns the user a token belongs to
+func (uc *AccessTokenUseCase) user(userID string) (*entities.User, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	user, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domainerr.ErrMemberNotFound
+	}
+	return user, nil
+}
+
+// accessTokenScopes checks the scopes asked for a token, dropping repeated ones
+func accessTokenScopes(requested []string) ([]string, error) {
+	scopes := make([]string, 0, len(requested))
+	seen := make(map[string]bool, len(requested))
+	for _, scope := range requested {
+		scope = strings.TrimSpace(scope)
+		if !entities.IsAccessTokenScope(scope) {
+			return nil, fmt.Errorf("unknown scope %q, expected one of %s", scope, strings.Join(entities.AccessTokenScopes, ", "))
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, errors.New("at least one scope is required")
+	}
+	return scopes, nil
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAccessTokenRepository keeps access tokens in memory, counting the updates
+type memoryAccessTokenRepository struct {
+	tokens  []entities.AccessToken
+	updates int
+}
+
+func (r *memoryAccessTokenRepository) Create(token *entities.AccessToken) error {
+	token.ID = "token-" + strconv.Itoa(len(r.tokens)+1)
+	r.tokens = append(r.tokens, *token)
+	return nil
+}
+
+func (r *memoryAccessTokenRepository) GetByID(id string) (*entities.AccessToken, error) {
+	for _, token := range r.tokens {
+		if token.ID == id {
+			return &token, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryAccessTokenRepository) FindByHash(hash string) (*entities.AccessToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == hash {
+			return &token, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryAccessTokenRepository) ListByUser(userID string) ([]entities.AccessToken, error) {
+	var tokens []entities.AccessToken
+	for _, token := range r.tokens {
+		if token.UserID == userID {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func (r *memoryAccessTokenRepository) Update(token *entities.AccessToken) error {
+	r.updates++
+	for i := range r.tokens {
+		if r.tokens[i].ID == token.ID {
+			r.tokens[i] = *token
+		}
+	}
+	return nil
+}
+
+func TestAccessTokenUseCase_Create(t *testing.T) {
+	now := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		userID          string
+		req             AccessTokenRequest
+		expectedScopes  []string
+		expectedExpires *time.Time
+		expectedError   string
+	}{
+		{
+			name:            "expires after the default lifetime",
+			userID:          "member-1",
+			req:             AccessTokenRequest{Name: " Sync ", Scopes: []string{"books:read", "books:write", "books:read"}},
+			expectedScopes:  []string{"books:read", "books:write"},
+			expectedExpires: timePtr(now.AddDate(0, 0, 90)),
+		},
+		{
+			name:            "expires after the days asked",
+			userID:          "admin-1",
+			req:             AccessTokenRequest{Name: "Ops", Scopes: []string{"admin:*"}, ExpiresInDays: 7},
+			expectedScopes:  []string{"admin:*"},
+			expectedExpires: timePtr(now.AddDate(0, 0, 7)),
+		},
+		{
+			name:          "rejects admin:* for members",
+			userID:        "member-1",
+			req:           AccessTokenRequest{Name: "Ops", Scopes: []string{"admin:*"}},
+			expectedError: domainerr.ErrScopeNotAllowed.Error(),
+		},
+		{
+			name:          "rejects unknown scopes",
+			userID:        "member-1",
+			req:           AccessTokenRequest{Name: "Sync", Scopes: []string{"books:delete"}},
+			expectedError: `unknown scope "books:delete", expected one of books:read, books:write, url:process, admin:*`,
+		},
+		{
+			name:          "rejects tokens without scopes",
+			userID:        "member-1",
+			req:           AccessTokenRequest{Name: "Sync"},
+			expectedError: "at least one scope is required",
+		},
+		{
+			name:          "rejects lifetimes past the longest",
+			userID:        "member-1",
+			req:           AccessTokenRequest{Name: "Sync", Scopes: []string{"url:process"}, ExpiresInDays: 366},
+			expectedError: "tokens expire within 365 days at most",
+		},
+		{
+			name:          "rejects unknown users",
+			userID:        "missing",
+			req:           AccessTokenRequest{Name: "Sync", Scopes: []string{"url:process"}},
+			expectedError: domainerr.ErrMemberNotFound.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &MockUserRepository{}
+			users.On("GetByID", "member-1").Return(&entities.User{ID: "member-1", Role: entities.UserRoleMember}, nil)
+			users.On("GetByID", "admin-1").Return(&entities.User{ID: "admin-1", Role: entities.UserRoleAdmin}, nil)
+			users.On("GetByID", "missing").Return(nil, nil)
+			useCase := NewAccessTokenUseCase(&memoryAccessTokenRepository{}, users, 90*24*time.Hour, 365*24*time.Hour)
+			useCase.SetClock(clock.NewFixed(now))
+
+			token, secret, err := useCase.Create(tt.userID, tt.req)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedScopes, token.Scopes)
+			assert.Equal(t, tt.expectedExpires, token.ExpiresAt)
+			assert.Equal(t, entities.HashAccessToken(secret), token.TokenHash)
+			assert.Equal(t, secret[:12], token.Hint)
+			assert.Contains(t, secret, entities.AccessTokenPrefix)
+		})
+	}
+}
+
+func TestAccessTokenUseCase_Authenticate(t *testing.T) {
+	now := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+	fixed := clock.NewFixed(now)
+	users := &MockUserRepository{}
+	users.On("GetByID", "member-1").Return(&entities.User{ID: "member-1", Role: entities.UserRoleMember}, nil)
+	repo := &memoryAccessTokenRepository{}
+	useCase := NewAccessTokenUseCase(repo, users, 24*time.Hour, 0)
+	useCase.SetClock(fixed)
+
+	token, secret, err := useCase.Create("member-1", AccessTokenRequest{Name: "Sync", Scopes: []string{"books:read"}})
+	require.NoError(t, err)
+
+	_, user, err := useCase.Authenticate(secret)
+	require.NoError(t, err)
+	assert.Equal(t, "member-1", user.ID)
+	assert.Equal(t, 1, repo.updates)
+
+	// Uses within a minute of the last recorded one are not written again
+	fixed.Advance(30 * time.Second)
+	_, _, err = useCase.Authenticate(secret)
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.updates)
+	fixed.Advance(time.Minute)
+	used, _, err := useCase.Authenticate(secret)
+	require.NoError(t, err)
+	assert.Equal(t, 2, repo.updates)
+	assert.Equal(t, now.Add(90*time.Second), *used.LastUsedAt)
+
+	_, _, err = useCase.Authenticate("lms_pat_guessed")
+	assert.ErrorIs(t, err, domainerr.ErrInvalidAccessToken)
+	_, _, err = useCase.Authenticate("Bearer " + secret)
+	assert.ErrorIs(t, err, domainerr.ErrInvalidAccessToken)
+
+	_, err = useCase.Revoke("member-2", token.ID)
+	assert.ErrorIs(t, err, domainerr.ErrAccessTokenNotFound)
+	revoked, err := useCase.Revoke("member-1", token.ID)
+	require.NoError(t, err)
+	assert.Equal(t, fixed.Now(), *revoked.RevokedAt)
+	_, _, err = useCase.Authenticate(secret)
+	assert.ErrorIs(t, err, domainerr.ErrInvalidAccessToken)
+
+	// Expired tokens are refused too
+	_, expiring, err := useCase.Create("member-1", AccessTokenRequest{Name: "Short", Scopes: []string{"books:read"}, ExpiresInDays: 1})
+	require.NoError(t, err)
+	fixed.Advance(24 * time.Hour)
+	_, _, err = useCase.Authenticate(expiring)
+	assert.ErrorIs(t, err, domainerr.ErrInvalidAccessToken)
+}
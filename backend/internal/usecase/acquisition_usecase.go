@@ -0,0 +1,267 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/events"
+	"library-management-system/internal/domain/repositories"
+)
+
+// AcquisitionUseCase runs the purchasing workflow: member suggestions, librarian review,
+// order tracking and adding received books to the catalog
+type AcquisitionUseCase struct {
+	acquisitionRepo repositories.AcquisitionRepository
+	bookRepo        repositories.BookRepository
+	bookUseCase     *BookUseCase
+	eventBus        events.Bus
+	clock           clock.Clock
+}
+
+// NewAcquisitionUseCase creates a new acquisition use case; received books are created through the book use case
+func NewAcquisitionUseCase(acquisitionRepo repositories.AcquisitionRepository, bookRepo repositories.BookRepository, bookUseCase *BookUseCase) *AcquisitionUseCase {
+	return &AcquisitionUseCase{
+		acquisitionRepo: acquisitionRepo,
+		bookRepo:        bookRepo,
+		bookUseCase:     bookUseCase,
+		clock:           clock.System{},
+	}
+}
+
+// SetClock replaces the clock used to timestamp orders and receipts
+func (uc *AcquisitionUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// SetEventBus enables publishing approvals, so members are told their suggestion was accepted
+func (uc *AcquisitionUseCase) SetEventBus(bus events.Bus) {
+	uc.eventBus = bus
+}
+
+// Suggest records a member's suggestion to acquire a book
+func (uc *AcquisitionUseCase) Suggest(memberID string, acquisition *entities.Acquisition) error {
+	if memberID == "" {
+		return errors.New("member ID is required")
+	}
+
+	acquisition.Title = strings.TrimSpace(acquisition.Title)
+	acquisition.Author = strings.TrimSpace(acquisition.Author)
+	acquisition.ISBN = entities.NormalizeISBN(acquisition.ISBN)
+	acquisition.Note = strings.TrimSpace(acquisition.Note)
+	if acquisition.Title == "" {
+		return errors.New("title is required")
+	}
+	if acquisition.Author == "" {
+		return errors.New("author is required")
+	}
+	profile := entities.DefaultValidationProfile
+	if uc.bookUseCase != nil {
+		profile = uc.bookUseCase.ValidationProfile("")
+	}
+	if acquisition.Year != 0 && (acquisition.Year < profile.MinYear || acquisition.Year > profile.MaxYear) {
+		return fmt.Errorf("year must be between %d and %d", profile.MinYear, profile.MaxYear)
+	}
+	if acquisition.ISBN != "" {
+		if len(acquisition.ISBN) < entities.MinISBNLength || len(acquisition.ISBN) > entities.MaxISBNLength {
+			return fmt.Errorf("ISBN must be between %d and %d characters", entities.MinISBNLength, entities.MaxISBNLength)
+		}
+		existingBook, err := uc.bookRepo.FindByISBN(acquisition.ISBN)
+		if err != nil {
+			return err
+		}
+		if existingBook != nil {
+			return domainerr.ErrISBNInCatalog
+		}
+	}
+
+	acquisition.Status = entities.AcquisitionSuggested
+	acquisition.SuggestedBy = memberID
+	acquisition.ReviewedBy = ""
+	acquisition.Vendor = ""
+	acquisition.Reason = ""
+	acquisition.BookID = nil
+	acquisition.OrderedAt = nil
+	acquisition.ReceivedAt = nil
+	return uc.acquisitionRepo.Create(acquisition)
+}
+
+// ListAcquisitions retrieves the acquisitions queue, optionally only those with the given status
+func (uc *AcquisitionUseCase) ListAcquisitions(status string) ([]entities.Acquisition, error) {
+	if status != "" && !entities.IsAcquisitionStatus(status) {
+		return nil, fmt.Errorf("unknown acquisition status %q", status)
+	}
+
+	return uc.acquisitionRepo.List(status)
+}
+
+// GetAcquisition retrieves an acquisition by ID
+func (uc *AcquisitionUseCase) GetAcquisition(id string) (*entities.Acquisition, error) {
+	if id == "" {
+		return nil, errors.New("acquisition ID is required")
+	}
+
+	acquisition, err := uc.acquisitionRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if acquisition == nil {
+		return nil, domainerr.ErrAcquisitionNotFound
+	}
+	return acquisition, nil
+}
+
+// Approve accepts a suggestion for ordering and tells the member who made it
+func (uc *AcquisitionUseCase) Approve(librarianID, id string) (*entities.Acquisition, error) {
+	acquisition, err := uc.moveTo(librarianID, id, entities.AcquisitionApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.save(acquisition); err != nil {
+		return nil, err
+	}
+	uc.publishApproval(acquisition)
+	return acquisition, nil
+}
+
+// publishApproval tells subscribers, such as notifications, that a member's suggestion, their
+// request to change the catalog, was approved
+func (uc *AcquisitionUseCase) publishApproval(acquisition *entities.Acquisition) {
+	if uc.eventBus == nil || acquisition.SuggestedBy == "" {
+		return
+	}
+	uc.eventBus.Publish(events.Event{
+		Type:        events.ChangeRequestApproved,
+		RecipientID: acquisition.SuggestedBy,
+		Payload: map[string]string{
+			"acquisition_id": acquisition.ID,
+			"message":        fmt.Sprintf("Your suggestion of %q by %s will be ordered.", acquisition.Title, acquisition.Author),
+		},
+	})
+}
+
+// Reject turns a suggestion down
+func (uc *AcquisitionUseCase) Reject(librarianID, id, reason string) (*entities.Acquisition, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.New("reason is required")
+	}
+
+	acquisition, err := uc.moveTo(librarianID, id, entities.AcquisitionRejected)
+	if err != nil {
+		return nil, err
+	}
+
+	acquisition.Reason = reason
+	return uc.save(acquisition)
+}
+
+// AcquisitionOrder holds the details of an order; the ISBN and year complete or correct the suggestion
+type AcquisitionOrder struct {
+	Vendor string
+	ISBN   string
+	Year   int
+}
+
+// Order records that an approved acquisition was ordered from a vendor
+func (uc *AcquisitionUseCase) Order(librarianID, id string, order AcquisitionOrder) (*entities.Acquisition, error) {
+	order.Vendor = strings.TrimSpace(order.Vendor)
+	order.ISBN = strings.TrimSpace(order.ISBN)
+	if order.Vendor == "" {
+		return nil, errors.New("vendor is required")
+	}
+
+	acquisition, err := uc.moveTo(librarianID, id, entities.AcquisitionOrdered)
+	if err != nil {
+		return nil, err
+	}
+
+	orderedAt := uc.clock.Now().UTC()
+	acquisition.Vendor = order.Vendor
+	acquisition.OrderedAt = &orderedAt
+	if order.ISBN != "" {
+		acquisition.ISBN = order.ISBN
+	}
+	if order.Year != 0 {
+		acquisition.Year = order.Year
+	}
+	return uc.save(acquisition)
+}
+
+// Receive records the arrival of an ordered book and adds it to the catalog.
+// A book whose ISBN is already in the catalog is linked instead of created twice.
+func (uc *AcquisitionUseCase) Receive(librarianID, id string) (*entities.Acquisition, error) {
+	acquisition, err := uc.moveTo(librarianID, id, entities.AcquisitionReceived)
+	if err != nil {
+		return nil, err
+	}
+
+	book, err := uc.bookRepo.FindByISBN(acquisition.ISBN)
+	if err != nil {
+		return nil, err
+	}
+	if book == nil {
+		book = &entities.Book{
+			Title:  acquisition.Title,
+			Author: acquisition.Author,
+			Year:   acquisition.Year,
+			ISBN:   acquisition.ISBN,
+		}
+		if err := uc.bookUseCase.CreateBook(book); err != nil {
+			return nil, fmt.Errorf("cannot add the book to the catalog: %w", err)
+		}
+	}
+
+	receivedAt := uc.clock.Now().UTC()
+	bookID := book.ID
+	acquisition.BookID = &bookID
+	acquisition.ReceivedAt = &receivedAt
+	return uc.save(acquisition)
+}
+
+// Cancel stops an approved or ordered acquisition
+func (uc *AcquisitionUseCase) Cancel(librarianID, id, reason string) (*entities.Acquisition, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, errors.New("reason is required")
+	}
+
+	acquisition, err := uc.moveTo(librarianID, id, entities.AcquisitionCancelled)
+	if err != nil {
+		return nil, err
+	}
+
+	acquisition.Reason = reason
+	return uc.save(acquisition)
+}
+
+// save persists an acquisition after a status change
+func (uc *AcquisitionUseCase) save(acquisition *entities.Acquisition) (*entities.Acquisition, error) {
+	if err := uc.acquisitionRepo.Update(acquisition); err != nil {
+		return nil, err
+	}
+	return acquisition, nil
+}
+
+// moveTo loads an acquisition and moves it to the given status on behalf of a librarian, without saving it
+func (uc *AcquisitionUseCase) moveTo(librarianID, id, status string) (*entities.Acquisition, error) {
+	if librarianID == "" {
+		return nil, errors.New("librarian ID is required")
+	}
+
+	acquisition, err := uc.GetAcquisition(id)
+	if err != nil {
+		return nil, err
+	}
+	if !acquisition.CanMoveTo(status) {
+		return nil, fmt.Errorf("cannot move a %s acquisition to %s", acquisition.Status, status)
+	}
+
+	acquisition.Status = status
+	acquisition.ReviewedBy = librarianID
+	return acquisition, nil
+}
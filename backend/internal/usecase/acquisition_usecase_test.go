@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/infrastructure/eventbus"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAcquisitionRepository is a mock implementation of AcquisitionRepository
+type MockAcquisitionRepository struct {
+	mock.Mock
+}
+
+func (m *MockAcquisitionRepository) Create(acquisition *entities.Acquisition) error {
+	args := m.Called(acquisition)
+	return args.Error(0)
+}
+
+func (m *MockAcquisitionRepository) GetByID(id string) (*entities.Acquisition, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Acquisition), args.Error(1)
+}
+
+func (m *MockAcquisitionRepository) List(status string) ([]entities.Acquisition, error) {
+	args := m.Called(status)
+	return args.Get(0).([]entities.Acquisition), args.Error(1)
+}
+
+func (m *MockAcquisitionRepository) Update(acquisition *entities.Acquisition) error {
+	args := m.Called(acquisition)
+	return args.Error(0)
+}
+
+func TestAcquisitionUseCase_Transitions(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        string
+		move          func(uc *AcquisitionUseCase) (*entities.Acquisition, error)
+		expected      string
+		expectedError string
+	}{
+		{
+			name:     "approve a suggestion",
+			status:   entities.AcquisitionSuggested,
+			move:     func(uc *AcquisitionUseCase) (*entities.Acquisition, error) { return uc.Approve("librarian-1", "acq-1") },
+			expected: entities.AcquisitionApproved,
+		},
+		{
+			name:   "reject a suggestion",
+			status: entities.AcquisitionSuggested,
+			move: func(uc *AcquisitionUseCase) (*entities.Acquisition, error) {
+				return uc.Reject("librarian-1", "acq-1", "Out of print")
+			},
+			expected: entities.AcquisitionRejected,
+		},
+		{
+			name:   "order a suggestion",
+			status: entities.AcquisitionSuggested,
+			move: func(uc *AcquisitionUseCase) (*entities.Acquisition, error) {
+				return uc.Order("librarian-1", "acq-1", AcquisitionOrder{Vendor: "Book Depot"})
+			},
+			expectedError: "cannot move a suggested acquisition to ordered",
+		},
+		{
+			name:   "cancel an order",
+			status: entities.AcquisitionOrdered,
+			move: func(uc *AcquisitionUseCase) (*entities.Acquisition, error) {
+				return uc.Cancel("librarian-1", "acq-1", "Vendor backorder")
+			},
+			expected: entities.AcquisitionCancelled,
+		},
+		{
+			name:          "approve a rejected suggestion",
+			status:        entities.AcquisitionRejected,
+			move:          func(uc *AcquisitionUseCase) (*entities.Acquisition, error) { return uc.Approve("librarian-1", "acq-1") },
+			expectedError: "cannot move a rejected acquisition to approved",
+		},
+		{
+			name:   "reject without a reason",
+			status: entities.AcquisitionSuggested,
+			move: func(uc *AcquisitionUseCase) (*entities.Acquisition, error) {
+				return uc.Reject("librarian-1", "acq-1", " ")
+			},
+			expectedError: "reason is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockAcquisitionRepository{}
+			mockRepo.On("GetByID", "acq-1").Return(&entities.Acquisition{ID: "acq-1", Status: tt.status}, nil)
+			mockRepo.On("Update", mock.AnythingOfType("*entities.Acquisition")).Return(nil)
+
+			acquisition, err := tt.move(NewAcquisitionUseCase(mockRepo, &MockBookRepository{}, nil))
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, acquisition.Status)
+			assert.Equal(t, "librarian-1", acquisition.ReviewedBy)
+		})
+	}
+}
+
+func TestAcquisitionUseCase_ApproveNotifiesMember(t *testing.T) {
+	mockRepo := &MockAcquisitionRepository{}
+	mockRepo.On("GetByID", "acq-1").Return(&entities.Acquisition{
+		ID: "acq-1", Status: entities.AcquisitionSuggested, SuggestedBy: "member-1",
+		Title: "Mort", Author: "Terry Pratchett",
+	}, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*entities.Acquisition")).Return(nil)
+
+	mockNotificationRepo := &MockNotificationRepository{}
+	mockNotificationRepo.On("Create", mock.MatchedBy(func(n *entities.Notification) bool {
+		return n.RecipientID == "member-1" &&
+			n.Type == "change_request.approved" &&
+			n.Message == `Your suggestion of "Mort" by Terry Pratchett will be ordered.`
+	})).Return(nil)
+
+	bus := eventbus.NewInMemoryBus()
+	NewNotificationUseCase(mockNotificationRepo).Subscribe(bus)
+	useCase := NewAcquisitionUseCase(mockRepo, &MockBookRepository{}, nil)
+	useCase.SetEventBus(bus)
+
+	_, err := useCase.Approve("librarian-1", "acq-1")
+	assert.NoError(t, err)
+	mockNotificationRepo.AssertNumberOfCalls(t, "Create", 1)
+	mockNotificationRepo.AssertExpectations(t)
+}
+
+func TestAcquisitionUseCase_Receive(t *testing.T) {
+	receivedAt := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("creates the book", func(t *testing.T) {
+		mockRepo := &MockAcquisitionRepository{}
+		mockBookRepo := &MockBookRepository{}
+		mockRepo.On("GetByID", "acq-1").Return(&entities.Acquisition{
+			ID: "acq-1", Status: entities.AcquisitionOrdered,
+			Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "9780062225719",
+		}, nil)
+		mockRepo.On("Update", mock.AnythingOfType("*entities.Acquisition")).Return(nil)
+		mockBookRepo.On("FindByISBN", "9780062225719").Return(nil, nil)
+		mockBookRepo.On("FindBySlug", "mort").Return(nil, nil)
+		mockBookRepo.On("Create", mock.AnythingOfType("*entities.Book")).Run(func(args mock.Arguments) {
+			args.Get(0).(*entities.Book).ID = "book-1"
+		}).Return(nil)
+
+		useCase := NewAcquisitionUseCase(mockRepo, mockBookRepo, NewBookUseCase(mockBookRepo))
+		useCase.SetClock(clock.NewFixed(receivedAt))
+
+		acquisition, err := useCase.Receive("librarian-1", "acq-1")
+		assert.NoError(t, err)
+		assert.Equal(t, entities.AcquisitionReceived, acquisition.Status)
+		assert.Equal(t, "book-1", *acquisition.BookID)
+		assert.Equal(t, receivedAt, *acquisition.ReceivedAt)
+	})
+
+	t.Run("links a book already in the catalog", func(t *testing.T) {
+		mockRepo := &MockAcquisitionRepository{}
+		mockBookRepo := &MockBookRepository{}
+		mockRepo.On("GetByID", "acq-1").Return(&entities.Acquisition{ID: "acq-1", Status: entities.AcquisitionOrdered, ISBN: "9780062225719"}, nil)
+		mockRepo.On("Update", mock.AnythingOfType("*entities.Acquisition")).Return(nil)
+		mockBookRepo.On("FindByISBN", "9780062225719").Return(&entities.Book{ID: "existing"}, nil)
+
+		acquisition, err := NewAcquisitionUseCase(mockRepo, mockBookRepo, NewBookUseCase(mockBookRepo)).Receive("librarian-1", "acq-1")
+		assert.NoError(t, err)
+		assert.Equal(t, "existing", *acquisition.BookID)
+		mockBookRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("keeps the order open when the book is invalid", func(t *testing.T) {
+		mockRepo := &MockAcquisitionRepository{}
+		mockBookRepo := &MockBookRepository{}
+		mockRepo.On("GetByID", "acq-1").Return(&entities.Acquisition{ID: "acq-1", Status: entities.AcquisitionOrdered, Title: "Mort", Author: "Terry Pratchett", Year: 1987}, nil)
+		mockBookRepo.On("FindByISBN", "").Return(nil, nil)
+
+		_, err := NewAcquisitionUseCase(mockRepo, mockBookRepo, NewBookUseCase(mockBookRepo)).Receive("librarian-1", "acq-1")
+		assert.EqualError(t, err, "cannot add the book to the catalog: book ISBN is required")
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
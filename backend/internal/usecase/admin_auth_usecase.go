@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// missingUserHash is compared against when no user has the email, so unknown emails take as
+// long to reject as wrong passwords
+var missingUserHash, _ = bcrypt.GenerateFromPassword([]byte("no user has this password"), bcrypt.DefaultCost)
+
+// AdminAuthUseCase signs in the admins of the deployment
+type AdminAuthUseCase struct {
+	userRepo repositories.UserRepository
+}
+
+// NewAdminAuthUseCase creates a new admin auth use case
+func NewAdminAuthUseCase(userRepo repositories.UserRepository) *AdminAuthUseCase {
+	return &AdminAuthUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Authenticate returns the admin with an email and password. Unknown emails, wrong passwords,
+// deactivated accounts and users who are not admins all fail with domainerr.ErrInvalidCredentials.
+func (uc *AdminAuthUseCase) Authenticate(email, password string) (*entities.User, error) {
+	user, err := checkPassword(uc.userRepo, email, password)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != entities.UserRoleAdmin {
+		return nil, domainerr.ErrInvalidCredentials
+	}
+	return user, nil
+}
+
+// checkPassword returns the active user with an email and password. Unknown emails, wrong
+// passwords and deactivated accounts all fail with domainerr.ErrInvalidCredentials.
+func checkPassword(userRepo repositories.UserRepository, email, password string) (*entities.User, error) {
+	user, err := userRepo.FindByEmail(entities.NormalizeEmail(email))
+	if err != nil {
+		return nil, err
+	}
+
+	hash := missingUserHash
+	if user != nil {
+		hash = []byte(user.PasswordHash)
+	}
+	if bcrypt.CompareHashAndPassword(hash, []byte(password)) != nil || user == nil || !user.Active() {
+		return nil, domainerr.ErrInvalidCredentials
+	}
+	return user, nil
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MockUserRepository is a mock implementation of UserRepository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) FindByEmail(email string) (*entities.User, error) {
+	args := m.Called(email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) GetByID(id string) (*entities.User, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Update(user *entities.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ListDeactivatedBefore(at time.Time) ([]entities.User, error) {
+	args := m.Called(at)
+	return args.Get(0).([]entities.User), args.Error(1)
+}
+
+func TestAdminAuthUseCase_Authenticate(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse battery staple"), bcrypt.MinCost)
+	require.NoError(t, err)
+	repo := &MockUserRepository{}
+	repo.On("FindByEmail", "admin@example.com").Return(&entities.User{ID: "1", Role: entities.UserRoleAdmin, PasswordHash: string(hash)}, nil)
+	repo.On("FindByEmail", "member@example.com").Return(&entities.User{ID: "2", Role: entities.UserRoleMember, PasswordHash: string(hash)}, nil)
+	repo.On("FindByEmail", "nobody@example.com").Return(nil, nil)
+	useCase := NewAdminAuthUseCase(repo)
+
+	user, err := useCase.Authenticate(" Admin@Example.com ", "correct horse battery staple")
+	require.NoError(t, err)
+	assert.Equal(t, "1", user.ID)
+
+	for _, tc := range []struct{ email, password string }{
+		{"admin@example.com", "wrong password"},
+		{"member@example.com", "correct horse battery staple"},
+		{"nobody@example.com", "correct horse battery staple"},
+	} {
+		_, err := useCase.Authenticate(tc.email, tc.password)
+		assert.ErrorIs(t, err, domainerr.ErrInvalidCredentials, tc.email)
+	}
+}
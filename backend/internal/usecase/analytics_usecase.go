@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// Analytics report window, in days
+const (
+	defaultAnalyticsDays = 30
+	maxAnalyticsDays     = 365
+)
+
+// AnalyticsUseCase reports how the API is used, from anonymous hourly counts of the endpoints
+// called and the optional features used
+type AnalyticsUseCase struct {
+	analyticsRepo repositories.AnalyticsRepository
+	// enabled is false when usage analytics are opted out of and no longer recorded
+	enabled bool
+	clock   clock.Clock
+}
+
+// NewAnalyticsUseCase creates a new analytics use case
+func NewAnalyticsUseCase(analyticsRepo repositories.AnalyticsRepository, enabled bool) *AnalyticsUseCase {
+	return &AnalyticsUseCase{
+		analyticsRepo: analyticsRepo,
+		enabled:       enabled,
+		clock:         clock.System{},
+	}
+}
+
+// SetClock replaces the clock the report window ends at
+func (uc *AnalyticsUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// Report summarizes the use of the API over the last days, 30 by default
+func (uc *AnalyticsUseCase) Report(days int) (*entities.AnalyticsReport, error) {
+	if days == 0 {
+		days = defaultAnalyticsDays
+	}
+	if days < 1 || days > maxAnalyticsDays {
+		return nil, errors.New("days must be between 1 and 365")
+	}
+
+	since := uc.clock.Now().UTC().Truncate(time.Hour).AddDate(0, 0, -days)
+	endpoints, err := uc.analyticsRepo.Totals(entities.AnalyticsEndpoint, since)
+	if err != nil {
+		return nil, err
+	}
+	features, err := uc.analyticsRepo.Totals(entities.AnalyticsFeature, since)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entities.AnalyticsReport{
+		Enabled:   uc.enabled,
+		Since:     since,
+		Endpoints: endpoints,
+		Features:  features,
+	}
+	if report.Endpoints == nil {
+		report.Endpoints = []entities.AnalyticsCount{}
+	}
+	if report.Features == nil {
+		report.Features = []entities.AnalyticsCount{}
+	}
+	for _, endpoint := range endpoints {
+		report.Requests += endpoint.Count
+	}
+	return report, nil
+}
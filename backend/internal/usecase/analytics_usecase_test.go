@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAnalyticsRepository is a mock implementation of AnalyticsRepository
+type MockAnalyticsRepository struct {
+	mock.Mock
+}
+
+func (m *MockAnalyticsRepository) Add(events []entities.AnalyticsEvent) error {
+	args := m.Called(events)
+	return args.Error(0)
+}
+
+func (m *MockAnalyticsRepository) Totals(kind string, since time.Time) ([]entities.AnalyticsCount, error) {
+	args := m.Called(kind, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.AnalyticsCount), args.Error(1)
+}
+
+func TestAnalyticsUseCase_Report(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 25, 0, 0, time.UTC)
+	since := time.Date(2026, 10, 9, 14, 0, 0, 0, time.UTC)
+	repo := &MockAnalyticsRepository{}
+	repo.On("Totals", entities.AnalyticsEndpoint, since).Return([]entities.AnalyticsCount{
+		{Name: "GET /api/books/:id", Count: 120},
+		{Name: "GET /api/books/search", Count: 30},
+	}, nil)
+	repo.On("Totals", entities.AnalyticsFeature, since).Return(nil, nil)
+	useCase := NewAnalyticsUseCase(repo, false)
+	useCase.SetClock(clock.NewFixed(now))
+
+	report, err := useCase.Report(7)
+	require.NoError(t, err)
+	assert.False(t, report.Enabled)
+	assert.Equal(t, since, report.Since)
+	assert.Equal(t, int64(150), report.Requests)
+	assert.Len(t, report.Endpoints, 2)
+	assert.NotNil(t, report.Features)
+	repo.AssertExpectations(t)
+}
+
+func TestAnalyticsUseCase_ReportDays(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 25, 0, 0, time.UTC)
+	repo := &MockAnalyticsRepository{}
+	repo.On("Totals", mock.Anything, time.Date(2026, 9, 16, 14, 0, 0, 0, time.UTC)).Return([]entities.AnalyticsCount{}, nil)
+	useCase := NewAnalyticsUseCase(repo, true)
+	useCase.SetClock(clock.NewFixed(now))
+
+	// 30 days by default
+	_, err := useCase.Report(0)
+	assert.NoError(t, err)
+
+	for _, days := range []int{-1, 366} {
+		_, err := useCase.Report(days)
+		assert.EqualError(t, err, "days must be between 1 and 365")
+	}
+}
+
+func TestAnalyticsUseCase_ReportFailure(t *testing.T) {
+	repo := &MockAnalyticsRepository{}
+	repo.On("Totals", entities.AnalyticsEndpoint, mock.Anything).Return(nil, errors.New("database unavailable"))
+	useCase := NewAnalyticsUseCase(repo, true)
+
+	_, err := useCase.Report(1)
+	assert.EqualError(t, err, "database unavailable")
+}
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/events"
+	"library-management-system/internal/domain/repositories"
+)
+
+// BookAvailability is whether a book of the catalog is on the shelf
+type BookAvailability struct {
+	BookID    string `json:"book_id"`
+	Available bool   `json:"available"`
+}
+
+// AvailabilityUseCase lets clients wait for availability changes of a book, which it learns of
+// from the event bus
+type AvailabilityUseCase struct {
+	bookRepo repositories.BookRepository
+
+	mu      sync.Mutex
+	waiters map[string][]chan struct{}
+}
+
+// NewAvailabilityUseCase creates a new availability use case
+func NewAvailabilityUseCase(bookRepo repositories.BookRepository) *AvailabilityUseCase {
+	return &AvailabilityUseCase{
+		bookRepo: bookRepo,
+		waiters:  make(map[string][]chan struct{}),
+	}
+}
+
+// Subscribe registers the use case on the event bus for availability changes. Clients may wait on
+// any instance, so it hears of the changes made on all of them when the bus broadcasts.
+func (uc *AvailabilityUseCase) Subscribe(bus events.Bus) {
+	if broadcaster, ok := bus.(events.Broadcaster); ok {
+		broadcaster.SubscribeBroadcast(events.BookAvailabilityChanged, uc.HandleEvent)
+		return
+	}
+	bus.Subscribe(events.BookAvailabilityChanged, uc.HandleEvent)
+}
+
+// HandleEvent wakes the clients waiting on the book of an availability change
+func (uc *AvailabilityUseCase) HandleEvent(event events.Event) error {
+	if event.BookID == "" {
+		return errors.New("event has no book")
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	for _, waiter := range uc.waiters[event.BookID] {
+		close(waiter)
+	}
+	delete(uc.waiters, event.BookID)
+	return nil
+}
+
+// availability returns the current availability of a book; books out of the catalog are unavailable
+func (uc *AvailabilityUseCase) availability(bookID string) (*BookAvailability, error) {
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, err
+	}
+	return &BookAvailability{BookID: bookID, Available: book != nil && book.DeletedAt == nil}, nil
+}
+
+// WaitForChange waits until the availability of a book differs from known, or until ctx is done.
+// It returns at once when known is already stale; with known nil it waits for the next change.
+// The returned flag tells whether the availability changed, and is false when ctx ended the wait.
+func (uc *AvailabilityUseCase) WaitForChange(ctx context.Context, bookID string, known *bool) (*BookAvailability, bool, error) {
+	if bookID == "" {
+		return nil, false, errors.New("book ID is required")
+	}
+
+	// Books must be in the catalog to be waited on; once waiting, a book leaving it is a change
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, false, err
+	}
+	if book == nil {
+		return nil, false, domainerr.ErrBookNotFound
+	}
+
+	baseline := book.DeletedAt == nil
+	if known != nil {
+		baseline = *known
+	}
+	for {
+		// Watch before reading, so a change between the read and the wait is not missed
+		waiter := uc.watch(bookID)
+		current, err := uc.availability(bookID)
+		if err != nil {
+			uc.unwatch(bookID, waiter)
+			return nil, false, err
+		}
+		if current.Available != baseline {
+			uc.unwatch(bookID, waiter)
+			return current, true, nil
+		}
+
+		select {
+		case <-waiter:
+		case <-ctx.Done():
+			uc.unwatch(bookID, waiter)
+			return current, false, nil
+		}
+	}
+}
+
+// watch registers a waiter closed on the next availability change of a book
+func (uc *AvailabilityUseCase) watch(bookID string) chan struct{} {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	waiter := make(chan struct{})
+	uc.waiters[bookID] = append(uc.waiters[bookID], waiter)
+	return waiter
+}
+
+// unwatch removes a waiter that is no longer waited on
+func (uc *AvailabilityUseCase) unwatch(bookID string, waiter chan struct{}) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	waiters := uc.waiters[bookID]
+	for i, w := range waiters {
+		if w == waiter {
+			waiters = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+	if len(waiters) == 0 {
+		delete(uc.waiters, bookID)
+	} else {
+		uc.waiters[bookID] = waiters
+	}
+}
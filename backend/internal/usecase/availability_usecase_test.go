@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/infrastructure/eventbus"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvailabilityUseCase_ReturnsStaleKnownStateAtOnce(t *testing.T) {
+	mockRepo := &MockBookRepository{}
+	mockRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1"}, nil)
+	useCase := NewAvailabilityUseCase(mockRepo)
+
+	known := false
+	availability, changed, err := useCase.WaitForChange(context.Background(), "book-1", &known)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, &BookAvailability{BookID: "book-1", Available: true}, availability)
+}
+
+func TestAvailabilityUseCase_WakesOnAvailabilityEvent(t *testing.T) {
+	mockRepo := &MockBookRepository{}
+	mockRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1"}, nil).Twice()
+	mockRepo.On("GetByID", "book-1").Return(nil, nil)
+	useCase := NewAvailabilityUseCase(mockRepo)
+	bus := eventbus.NewInMemoryBus()
+	useCase.Subscribe(bus)
+
+	bookUseCase := NewBookUseCase(mockRepo)
+	bookUseCase.SetEventBus(bus)
+	go func() {
+		waitForWaiters(t, useCase, "book-1")
+		bookUseCase.publishAvailability("book-1", false)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	availability, changed, err := useCase.WaitForChange(ctx, "book-1", nil)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.False(t, availability.Available)
+	assert.Empty(t, useCase.waiters)
+}
+
+func TestAvailabilityUseCase_TimesOutWithoutChange(t *testing.T) {
+	mockRepo := &MockBookRepository{}
+	mockRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1"}, nil)
+	useCase := NewAvailabilityUseCase(mockRepo)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	known := true
+	availability, changed, err := useCase.WaitForChange(ctx, "book-1", &known)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.True(t, availability.Available)
+	assert.Empty(t, useCase.waiters)
+}
+
+func TestAvailabilityUseCase_BookNotFound(t *testing.T) {
+	mockRepo := &MockBookRepository{}
+	mockRepo.On("GetByID", "missing").Return(nil, nil)
+	useCase := NewAvailabilityUseCase(mockRepo)
+
+	_, _, err := useCase.WaitForChange(context.Background(), "missing", nil)
+	assert.EqualError(t, err, "book not found")
+}
+
+// waitForWaiters blocks until a client waits on the book
+func waitForWaiters(t *testing.T, useCase *AvailabilityUseCase, bookID string) {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		useCase.mu.Lock()
+		waiting := len(useCase.waiters[bookID]) > 0
+		useCase.mu.Unlock()
+		if waiting {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Error("nobody waits on the book")
+}
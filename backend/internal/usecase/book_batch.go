@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+)
+
+// Results of deleting or restoring a book of a batch
+const (
+	BookBatchDeleted  = "deleted"
+	BookBatchRestored = "restored"
+	BookBatchRejected = "rejected"
+	// BookBatchAborted means the book could have been changed but was not, as other books of an
+	// atomic batch were rejected
+	BookBatchAborted = "aborted"
+)
+
+// BookBatchResult reports what a batch deletion or restoration did with one of its books
+type BookBatchResult struct {
+	// Index is the position of the ID in the batch
+	Index  int
+	ID     string
+	Result string
+	Error  string
+}
+
+// DeleteBooks soft deletes books by ID, such as those of a bad import. Books that are missing,
+// already deleted, of another branch than the librarian's or out on loan are rejected one by one;
+// the others are deleted together, all or nothing, and with atomic only when none was rejected.
+// Each deletion is audited.
+func (uc *BookUseCase) DeleteBooks(ids []string, atomic bool) ([]BookBatchResult, error) {
+	return uc.changeBooks(ids, atomic, BookBatchDeleted, func(book *entities.Book) error {
+		if book.DeletedAt != nil {
+			return domainerr.ErrBookNotFound
+		}
+		return uc.checkNotOnLoan(book.ID)
+	})
+}
+
+// RestoreBooks restores soft-deleted books by ID. Books that are missing, not deleted or of
+// another branch than the librarian's are rejected one by one; the others are restored together,
+// all or nothing, and with atomic only when none was rejected. Each restoration is audited.
+func (uc *BookUseCase) RestoreBooks(ids []string, atomic bool) ([]BookBatchResult, error) {
+	return uc.changeBooks(ids, atomic, BookBatchRestored, func(book *entities.Book) error {
+		if book.DeletedAt == nil {
+			return domainerr.ErrBookNotDeleted
+		}
+		return nil
+	})
+}
+
+// changeBooks deletes or restores the books of a batch that pass check, rejecting the others
+func (uc *BookUseCase) changeBooks(ids []string, atomic bool, change string, check func(book *entities.Book) error) ([]BookBatchResult, error) {
+	books, err := uc.bookRepo.FindByIDsWithDeleted(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*entities.Book, len(books))
+	for i := range books {
+		byID[books[i].ID] = &books[i]
+	}
+
+	results := make([]BookBatchResult, len(ids))
+	valid := make([]string, 0, len(ids))
+	positions := make([]int, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for i, id := range ids {
+		results[i] = BookBatchResult{Index: i, ID: id}
+		err := uc.checkBatchBook(id, byID[id], seen, check)
+		if err != nil {
+			results[i].Result = BookBatchRejected
+			results[i].Error = err.Error()
+			continue
+		}
+		valid = append(valid, id)
+		positions = append(positions, i)
+	}
+	if len(valid) == 0 {
+		return results, nil
+	}
+	if atomic && len(valid) < len(ids) {
+		for _, position := range positions {
+			results[position].Result = BookBatchAborted
+		}
+		return results, nil
+	}
+
+	action, available := entities.AuditActionDeleted, false
+	write := uc.bookRepo.DeleteBatch
+	if change == BookBatchRestored {
+		action, available = entities.AuditActionRestored, true
+		write = uc.bookRepo.RestoreBatch
+	}
+	if err := write(valid); err != nil {
+		return nil, err
+	}
+	uc.queryCache.Invalidate()
+
+	for j, id := range valid {
+		results[positions[j]].Result = change
+		uc.recordAudit(id, action, nil)
+		uc.publishAvailability(id, available)
+	}
+	return results, nil
+}
+
+// checkBatchBook checks a book of a batch deletion or restoration, found by its ID or nil
+func (uc *BookUseCase) checkBatchBook(id string, book *entities.Book, seen map[string]bool, check func(book *entities.Book) error) error {
+	if id == "" {
+		return errors.New("book ID is required")
+	}
+	if seen[id] {
+		return errors.New("book ID appears more than once in the batch")
+	}
+	seen[id] = true
+	if book == nil {
+		return domainerr.ErrBookNotFound
+	}
+	if !uc.actor.InBranch(book.BranchID) {
+		return domainerr.ErrBranchAccessDenied
+	}
+	return check(book)
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+)
+
+const (
+	// defaultAccessionPrefix and defaultAccessionDigits write accession numbers such as ACC-000042
+	// unless configured otherwise
+	defaultAccessionPrefix = "ACC-"
+	defaultAccessionDigits = 6
+)
+
+// errCopiesDisabled is returned while no copy repository is set
+var errCopiesDisabled = errors.New("copies are not enabled")
+
+// ListCopies lists the copies of a book by accession number
+func (uc *BookUseCase) ListCopies(bookID string) ([]entities.BookCopy, error) {
+	if uc.copyRepo == nil {
+		return nil, errCopiesDisabled
+	}
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, err
+	}
+	if book == nil {
+		return nil, domainerr.ErrBookNotFound
+	}
+	return uc.copyRepo.ListByBook(bookID)
+}
+
+// AddCopy adds a copy of a book and gives it the next accession number of its branch. The copy
+// goes to the branch of the librarian adding it, else to the branch of the book, unless it names
+// one; librarians of a branch can only add copies to theirs. Branches without an accession prefix
+// share the sequence of the library.
+func (uc *BookUseCase) AddCopy(bookID string, bookCopy *entities.BookCopy) error {
+	if uc.copyRepo == nil {
+		return errCopiesDisabled
+	}
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return err
+	}
+	if book == nil {
+		return domainerr.ErrBookNotFound
+	}
+
+	if bookCopy.BranchID == nil {
+		if uc.actor.BranchScoped() {
+			branchID := uc.actor.BranchID
+			bookCopy.BranchID = &branchID
+		} else {
+			bookCopy.BranchID = book.BranchID
+		}
+	}
+	if !uc.actor.InBranch(bookCopy.BranchID) {
+		return domainerr.ErrBranchAccessDenied
+	}
+	prefix, err := uc.accessionPrefixOf(bookCopy.BranchID)
+	if err != nil {
+		return err
+	}
+
+	// The number is taken before the copy is created; if creating it fails, the number is skipped
+	n, err := uc.copyRepo.NextAccessionNumber(prefix)
+	if err != nil {
+		return err
+	}
+	bookCopy.ID = ""
+	bookCopy.BookID = bookID
+	bookCopy.AccessionNumber = entities.FormatAccessionNumber(prefix, n, uc.accessionDigits)
+	if err := uc.copyRepo.Create(bookCopy); err != nil {
+		return err
+	}
+	uc.recordAudit(bookID, entities.AuditActionUpdated, map[string]interface{}{
+		"copy": map[string]interface{}{"from": nil, "to": bookCopy.AccessionNumber},
+	})
+	return nil
+}
+
+// FindCopyByAccessionNumber finds the copy with an accession number and its book
+func (uc *BookUseCase) FindCopyByAccessionNumber(number string) (*entities.BookCopy, *entities.Book, error) {
+	if uc.copyRepo == nil {
+		return nil, nil, errCopiesDisabled
+	}
+	number = strings.TrimSpace(number)
+	if number == "" {
+		return nil, nil, errors.New("accession number is required")
+	}
+
+	bookCopy, err := uc.copyRepo.FindByAccessionNumber(number)
+	if err != nil {
+		return nil, nil, err
+	}
+	if bookCopy == nil {
+		return nil, nil, domainerr.ErrCopyNotFound
+	}
+	book, err := uc.bookRepo.GetByID(bookCopy.BookID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if book == nil {
+		return nil, nil, domainerr.ErrCopyNotFound
+	}
+	return bookCopy, book, nil
+}
+
+// accessionPrefixOf returns the accession prefix of a branch, the library's for copies of the
+// whole library and branches without one
+func (uc *BookUseCase) accessionPrefixOf(branchID *string) (string, error) {
+	if branchID == nil || uc.branchRepo == nil {
+		return uc.accessionPrefix, nil
+	}
+	branch, err := uc.branchRepo.GetByID(*branchID)
+	if err != nil {
+		return "", err
+	}
+	if branch == nil {
+		return "", domainerr.ErrBranchNotFound
+	}
+	if branch.AccessionPrefix != "" {
+		return branch.AccessionPrefix, nil
+	}
+	return uc.accessionPrefix, nil
+}
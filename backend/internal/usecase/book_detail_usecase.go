@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// BookDetail is a book with everything its page shows about it, assembled in one read
+type BookDetail struct {
+	Book entities.Book
+	// Authors lists the authors credited on the book, in the order credited
+	Authors []string
+	// Categories lists the category of the book from its metadata, empty when it has none
+	Categories []string
+	// Publisher and Series are nil when the book names none
+	Publisher   *entities.Publisher
+	Series      *entities.Series
+	Identifiers []entities.BookIdentifier
+	Copies      []entities.BookCopy
+	// Availability is how the book circulates, like the holdings of GET /availability
+	Availability Holding
+}
+
+// BookDetailUseCase assembles the detail of a book. The repositories it reads are independent of
+// one another once the book is found, so it reads them in parallel.
+type BookDetailUseCase struct {
+	bookRepo       repositories.BookRepository
+	publisherRepo  repositories.PublisherRepository
+	seriesRepo     repositories.SeriesRepository
+	identifierRepo repositories.BookIdentifierRepository
+	copyRepo       repositories.BookCopyRepository
+	loanRepo       repositories.LoanRepository
+	holdRepo       repositories.HoldRepository
+}
+
+// NewBookDetailUseCase creates a new book detail use case
+func NewBookDetailUseCase(bookRepo repositories.BookRepository, publisherRepo repositories.PublisherRepository, seriesRepo repositories.SeriesRepository, identifierRepo repositories.BookIdentifierRepository, copyRepo repositories.BookCopyRepository, loanRepo repositories.LoanRepository, holdRepo repositories.HoldRepository) *BookDetailUseCase {
+	return &BookDetailUseCase{
+		bookRepo:       bookRepo,
+		publisherRepo:  publisherRepo,
+		seriesRepo:     seriesRepo,
+		identifierRepo: identifierRepo,
+		copyRepo:       copyRepo,
+		loanRepo:       loanRepo,
+		holdRepo:       holdRepo,
+	}
+}
+
+// GetBookDetail returns the detail of a book, nil when there is no such book. It fails with the
+// first error of the reads it runs.
+func (uc *BookDetailUseCase) GetBookDetail(id string) (*BookDetail, error) {
+	if id == "" {
+		return nil, errors.New("book ID is required")
+	}
+	book, err := uc.bookRepo.GetByID(id)
+	if err != nil || book == nil {
+		return nil, err
+	}
+
+	detail := &BookDetail{
+		Book:        *book,
+		Authors:     bookAuthors(book.Author),
+		Categories:  []string{},
+		Identifiers: []entities.BookIdentifier{},
+		Copies:      []entities.BookCopy{},
+		Availability: Holding{
+			BookID:    book.ID,
+			BranchID:  book.BranchID,
+			Available: book.DeletedAt == nil && book.Status != entities.BookStatusArchived,
+		},
+	}
+	if category := entities.MetadataText(book.Metadata[entities.CategoryMetadataKey]); category != "" {
+		detail.Categories = []string{category}
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	read := func(run func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := run(); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	// Each read fills its own fields of the detail, so they need no lock
+	if book.PublisherID != nil {
+		read(func() (err error) {
+			detail.Publisher, err = uc.publisherRepo.GetByID(*book.PublisherID)
+			return err
+		})
+	}
+	if book.SeriesID != nil {
+		read(func() (err error) {
+			detail.Series, err = uc.seriesRepo.GetByID(*book.SeriesID)
+			return err
+		})
+	}
+	read(func() error {
+		identifiers, err := uc.identifierRepo.ListByBook(book.ID)
+		if identifiers != nil {
+			detail.Identifiers = identifiers
+		}
+		return err
+	})
+	read(func() error {
+		copies, err := uc.copyRepo.ListByBook(book.ID)
+		if copies != nil {
+			detail.Copies = copies
+		}
+		return err
+	})
+	read(func() error {
+		loans, err := uc.loanRepo.ListActiveByBook(book.ID)
+		if err != nil {
+			return err
+		}
+		detail.Availability.Loans = len(loans)
+		// Active loans come soonest due first
+		if len(loans) > 0 {
+			dueBack := loans[0].DueAt
+			detail.Availability.DueBack = &dueBack
+		}
+		return nil
+	})
+	read(func() error {
+		holds, err := uc.holdRepo.ListWaiting(book.ID)
+		detail.Availability.Holds = len(holds)
+		return err
+	})
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return detail, nil
+}
+
+// bookAuthors splits the author of a book into the authors it credits, which are separated by
+// semicolons or " and "
+func bookAuthors(author string) []string {
+	authors := []string{}
+	for _, part := range strings.Split(author, ";") {
+		for _, name := range strings.Split(part, " and ") {
+			if name = strings.TrimSpace(name); name != "" {
+				authors = append(authors, name)
+			}
+		}
+	}
+	return authors
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookDetailUseCase_GetBookDetail(t *testing.T) {
+	due := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	publisherID := "penguin"
+	seriesID := "sandman"
+	bookRepo := new(MockBookRepository)
+	bookRepo.On("GetByID", "good-omens").Return(&entities.Book{
+		ID:          "good-omens",
+		Author:      "Terry Pratchett and Neil Gaiman",
+		Status:      entities.BookStatusActive,
+		PublisherID: &publisherID,
+		SeriesID:    &seriesID,
+		Metadata:    map[string]interface{}{entities.CategoryMetadataKey: "Fantasy"},
+	}, nil)
+	bookRepo.On("GetByID", "missing").Return(nil, nil)
+	bookRepo.On("GetByID", "broken").Return(&entities.Book{ID: "broken"}, nil)
+	publisherRepo := new(MockPublisherRepository)
+	publisherRepo.On("GetByID", publisherID).Return(&entities.Publisher{ID: publisherID, Name: "Penguin"}, nil)
+	seriesRepo := new(MockSeriesRepository)
+	seriesRepo.On("GetByID", seriesID).Return(&entities.Series{ID: seriesID, Name: "Sandman"}, nil)
+	identifierRepo := new(MockBookIdentifierRepository)
+	identifierRepo.On("ListByBook", "good-omens").Return([]entities.BookIdentifier{{ID: "doi", Type: "doi"}}, nil)
+	identifierRepo.On("ListByBook", "broken").Return([]entities.BookIdentifier(nil), nil)
+	copyRepo := new(MockBookCopyRepository)
+	copyRepo.On("ListByBook", "good-omens").Return([]entities.BookCopy{{ID: "c1"}, {ID: "c2"}}, nil)
+	copyRepo.On("ListByBook", "broken").Return([]entities.BookCopy(nil), errors.New("connection reset"))
+	loanRepo := new(MockLoanRepository)
+	loanRepo.On("ListActiveByBook", "good-omens").Return([]entities.Loan{{DueAt: due}, {DueAt: due.AddDate(0, 0, 7)}}, nil)
+	loanRepo.On("ListActiveByBook", "broken").Return([]entities.Loan{}, nil)
+	holdRepo := new(MockHoldRepository)
+	holdRepo.On("ListWaiting", "good-omens").Return([]entities.Hold{{ID: "h1"}}, nil)
+	holdRepo.On("ListWaiting", "broken").Return([]entities.Hold{}, nil)
+	useCase := NewBookDetailUseCase(bookRepo, publisherRepo, seriesRepo, identifierRepo, copyRepo, loanRepo, holdRepo)
+
+	detail, err := useCase.GetBookDetail("good-omens")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Terry Pratchett", "Neil Gaiman"}, detail.Authors)
+	assert.Equal(t, []string{"Fantasy"}, detail.Categories)
+	assert.Equal(t, "Penguin", detail.Publisher.Name)
+	assert.Equal(t, "Sandman", detail.Series.Name)
+	assert.Len(t, detail.Identifiers, 1)
+	assert.Len(t, detail.Copies, 2)
+	assert.Equal(t, Holding{BookID: "good-omens", Available: true, Loans: 2, Holds: 1, DueBack: &due}, detail.Availability)
+
+	detail, err = useCase.GetBookDetail("missing")
+	require.NoError(t, err)
+	assert.Nil(t, detail)
+
+	_, err = useCase.GetBookDetail("broken")
+	assert.EqualError(t, err, "connection reset")
+}
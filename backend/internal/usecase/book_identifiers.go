@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"errors"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+)
+
+// errIdentifiersDisabled is returned while no identifier repository is set
+var errIdentifiersDisabled = errors.New("identifiers are not enabled")
+
+// ListIdentifiers lists the identifiers of a book besides its ISBN, by type and value
+func (uc *BookUseCase) ListIdentifiers(bookID string) ([]entities.BookIdentifier, error) {
+	if uc.identifierRepo == nil {
+		return nil, errIdentifiersDisabled
+	}
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, err
+	}
+	if book == nil {
+		return nil, domainerr.ErrBookNotFound
+	}
+	return uc.identifierRepo.ListByBook(bookID)
+}
+
+// AddIdentifier gives a book an identifier, stored normalized. A value of a type identifies one
+// book, deleted books included until they are purged.
+func (uc *BookUseCase) AddIdentifier(bookID string, identifier *entities.BookIdentifier) error {
+	if uc.identifierRepo == nil {
+		return errIdentifiersDisabled
+	}
+	value, err := normalizeIdentifier(identifier.Type, identifier.Value, false)
+	if err != nil {
+		return err
+	}
+	book, err := uc.bookForChange(bookID)
+	if err != nil {
+		return err
+	}
+	if book == nil {
+		return domainerr.ErrBookNotFound
+	}
+
+	existing, err := uc.identifierRepo.FindByValue(identifier.Type, value)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return domainerr.ErrDuplicateIdentifier
+	}
+
+	identifier.ID = ""
+	identifier.BookID = bookID
+	identifier.Value = value
+	if err := uc.identifierRepo.Create(identifier); err != nil {
+		return err
+	}
+	uc.recordAudit(bookID, entities.AuditActionUpdated, map[string]interface{}{
+		"identifier": map[string]interface{}{"from": nil, "to": identifier.Type + ":" + value},
+	})
+	return nil
+}
+
+// RemoveIdentifier removes an identifier from a book
+func (uc *BookUseCase) RemoveIdentifier(bookID, identifierID string) error {
+	if uc.identifierRepo == nil {
+		return errIdentifiersDisabled
+	}
+	book, err := uc.bookForChange(bookID)
+	if err != nil {
+		return err
+	}
+	if book == nil {
+		return domainerr.ErrBookNotFound
+	}
+
+	identifiers, err := uc.identifierRepo.ListByBook(bookID)
+	if err != nil {
+		return err
+	}
+	for _, identifier := range identifiers {
+		if identifier.ID != identifierID {
+			continue
+		}
+		if _, err := uc.identifierRepo.Delete(bookID, identifierID); err != nil {
+			return err
+		}
+		uc.recordAudit(bookID, entities.AuditActionUpdated, map[string]interface{}{
+			"identifier": map[string]interface{}{"from": identifier.Type + ":" + identifier.Value, "to": nil},
+		})
+		return nil
+	}
+	return domainerr.ErrIdentifierNotFound
+}
+
+// FindBookByIdentifier finds the book having an identifier of a type, or its ISBN with type
+// isbn, written in any form. Deleted books are not found.
+func (uc *BookUseCase) FindBookByIdentifier(kind, value string) (*entities.Book, error) {
+	normalized, err := normalizeIdentifier(kind, value, true)
+	if err != nil {
+		return nil, err
+	}
+	if kind == entities.IdentifierISBN {
+		return liveBook(uc.bookRepo.FindByISBN(normalized))
+	}
+	if uc.identifierRepo == nil {
+		return nil, errIdentifiersDisabled
+	}
+	return uc.bookWithIdentifier(kind, normalized)
+}
+
+// SearchBooksByIdentifier finds the books having a value as their ISBN or as an identifier of any
+// type, read as each type would write it
+func (uc *BookUseCase) SearchBooksByIdentifier(value string) ([]entities.Book, error) {
+	if value == "" {
+		return nil, errors.New("identifier is required for search")
+	}
+
+	books := []entities.Book{}
+	seen := make(map[string]bool)
+	add := func(book *entities.Book, err error) error {
+		if err == nil && book != nil && !seen[book.ID] {
+			seen[book.ID] = true
+			books = append(books, *book)
+		}
+		return err
+	}
+	if err := add(liveBook(uc.bookRepo.FindByISBN(entities.NormalizeISBN(value)))); err != nil {
+		return nil, err
+	}
+	if uc.identifierRepo != nil {
+		for _, kind := range entities.IdentifierTypes {
+			normalized, err := entities.NormalizeIdentifier(kind, value)
+			if err != nil {
+				continue
+			}
+			if err := add(uc.bookWithIdentifier(kind, normalized)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return publishedBooks(books, nil)
+}
+
+// bookWithIdentifier finds the live book having a normalized identifier
+func (uc *BookUseCase) bookWithIdentifier(kind, value string) (*entities.Book, error) {
+	identifier, err := uc.identifierRepo.FindByValue(kind, value)
+	if err != nil || identifier == nil {
+		return nil, err
+	}
+	return liveBook(uc.bookRepo.GetByID(identifier.BookID))
+}
+
+// normalizeIdentifier checks the type of an identifier, isbn included for lookups, and returns
+// its value normalized
+func normalizeIdentifier(kind, value string, lookup bool) (string, error) {
+	if lookup && kind == entities.IdentifierISBN {
+		if value == "" {
+			return "", errors.New("identifier value is required")
+		}
+		return entities.NormalizeISBN(value), nil
+	}
+	if !entities.IsIdentifierType(kind) {
+		if lookup {
+			return "", errors.New("identifier type must be isbn, issn, asin, doi or internal")
+		}
+		return "", errors.New("identifier type must be issn, asin, doi or internal")
+	}
+	return entities.NormalizeIdentifier(kind, value)
+}
+
+// liveBook drops a deleted book found by a lookup, passing a lookup error through
+func liveBook(book *entities.Book, err error) (*entities.Book, error) {
+	if err != nil || book == nil || book.DeletedAt != nil {
+		return nil, err
+	}
+	return book, nil
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import "library-management-system/internal/domain/entities"
+
+// BookNeighbors places a book in a listing, for detail pages to link to the books around it
+type BookNeighbors struct {
+	// PreviousID and NextID are the books listed right before and after, null at either end
+	PreviousID *string `json:"previous_id"`
+	NextID     *string `json:"next_id"`
+	// Position is where the book is listed, from 1, out of Total books
+	Position int `json:"position"`
+	Total    int `json:"total"`
+}
+
+// Neighbors finds the books listed around a book, and reports whether the book is listed at all
+func Neighbors(books []entities.Book, id string) (BookNeighbors, bool) {
+	for i, book := range books {
+		if book.ID != id {
+			continue
+		}
+		neighbors := BookNeighbors{Position: i + 1, Total: len(books)}
+		if i > 0 {
+			neighbors.PreviousID = &books[i-1].ID
+		}
+		if i < len(books)-1 {
+			neighbors.NextID = &books[i+1].ID
+		}
+		return neighbors, true
+	}
+	return BookNeighbors{}, false
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"testing"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNeighbors(t *testing.T) {
+	books := []entities.Book{{ID: "mort"}, {ID: "eric"}, {ID: "hogfather"}}
+	mort, eric, hogfather := "mort", "eric", "hogfather"
+
+	tests := []struct {
+		name      string
+		id        string
+		neighbors BookNeighbors
+		listed    bool
+	}{
+		{name: "first", id: "mort", neighbors: BookNeighbors{NextID: &eric, Position: 1, Total: 3}, listed: true},
+		{name: "middle", id: "eric", neighbors: BookNeighbors{PreviousID: &mort, NextID: &hogfather, Position: 2, Total: 3}, listed: true},
+		{name: "last", id: "hogfather", neighbors: BookNeighbors{PreviousID: &eric, Position: 3, Total: 3}, listed: true},
+		{name: "not listed", id: "sourcery"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			neighbors, listed := Neighbors(books, tt.id)
+			assert.Equal(t, tt.listed, listed)
+			assert.Equal(t, tt.neighbors, neighbors)
+		})
+	}
+}
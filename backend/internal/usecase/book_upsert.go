@@ -0,0 +1,312 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// Results of upserting a book
+const (
+	BookUpsertCreated  = "created"
+	BookUpsertUpdated  = "updated"
+	BookUpsertRejected = "rejected"
+	// BookUpsertConflict means the book changed on the server and was kept as it is
+	BookUpsertConflict = "conflict"
+	// BookUpsertAborted means the book was valid but not written, as other books of an atomic
+	// upsert were rejected or conflicted
+	BookUpsertAborted = "aborted"
+)
+
+// Policies resolving an upsert based on a book that has changed on the server since
+const (
+	// ConflictClientWins overwrites the server's changes; it is the default
+	ConflictClientWins = "client-wins"
+	// ConflictServerWins keeps the book as it is on the server
+	ConflictServerWins = "server-wins"
+	// ConflictMerge takes the fields the client changed and keeps the server's other fields
+	ConflictMerge = "merge"
+)
+
+// upsertFields are the fields an upsert overwrites, as named in the audit trail
+var upsertFields = []string{"title", "author", "year", "publisher_id", "metadata"}
+
+// BookUpsert is a book to upsert along with the version of it the client's changes are based on
+type BookUpsert struct {
+	Book entities.Book
+	// BaseUpdatedAt is the updated_at of the book the client last synced; without it the book
+	// is written without checking for conflicts
+	BaseUpdatedAt *time.Time
+	// ChangedFields are the fields the client changed since its base version, used by merges
+	ChangedFields []string
+}
+
+// FieldConflict is a field whose value differs between the client and a book that changed on
+// the server since the client's base version
+type FieldConflict struct {
+	Field  string      `json:"field"`
+	Server interface{} `json:"server"`
+	Client interface{} `json:"client"`
+	// Kept is the side whose value was kept, server or client
+	Kept string `json:"kept"`
+}
+
+// BookUpsertResult reports what an upsert did with one of its books
+type BookUpsertResult struct {
+	// Index is the position of the book in the upsert
+	Index  int
+	ISBN   string
+	Result string
+	// Book is the stored book, unless it was rejected
+	Book      *entities.Book
+	Conflicts []FieldConflict
+	Error     string
+}
+
+// UpsertBooks creates books or overwrites the catalog fields of the books having their ISBN,
+// for clients that know books by ISBN only. Invalid books are rejected one by one; the others
+// are written together, all or nothing, and with atomic only when no book was rejected or
+// conflicted. Overwritten books keep their ID, slug and status; books with the ISBN of a deleted
+// book are rejected, as the deleted book would be overwritten and stay deleted.
+//
+// A book that changed on the server after the client's base version is resolved with policy,
+// and the fields where the client and the server differ are reported as conflicts. A book that
+// changes again before it is written is kept as it is and reported as a conflict, whatever the
+// policy.
+func (uc *BookUseCase) UpsertBooks(upserts []BookUpsert, policy string, atomic bool) ([]BookUpsertResult, error) {
+	switch policy {
+	case "":
+		policy = ConflictClientWins
+	case ConflictClientWins, ConflictServerWins, ConflictMerge:
+	default:
+		return nil, errors.New("conflict_policy must be client-wins, server-wins or merge")
+	}
+
+	results := make([]BookUpsertResult, len(upserts))
+	valid := make([]entities.Book, 0, len(upserts))
+	positions := make([]int, 0, len(upserts))
+	existing := make([]*entities.Book, 0, len(upserts))
+	versions := make([]*time.Time, 0, len(upserts))
+	isbns := make(map[string]bool, len(upserts))
+	slugs := make(map[string]bool, len(upserts))
+	for i := range upserts {
+		book := &upserts[i].Book
+		results[i] = BookUpsertResult{Index: i, ISBN: book.ISBN}
+		book.ID = ""
+		if err := uc.prepareBatchBook(book, isbns, slugs); err != nil {
+			results[i].Result = BookUpsertRejected
+			results[i].Error = err.Error()
+			continue
+		}
+
+		current, err := uc.bookRepo.FindByISBN(book.ISBN)
+		if err != nil {
+			return nil, err
+		}
+		if current != nil && current.DeletedAt != nil {
+			results[i].Result = BookUpsertRejected
+			results[i].Error = domainerr.ErrISBNOfDeletedBook.Error()
+			continue
+		}
+		if current != nil && !uc.actor.InBranch(current.BranchID) {
+			results[i].Result = BookUpsertRejected
+			results[i].Error = domainerr.ErrBranchAccessDenied.Error()
+			continue
+		}
+		if current != nil && upserts[i].BaseUpdatedAt != nil && current.UpdatedAt.After(*upserts[i].BaseUpdatedAt) {
+			conflicts := resolveConflicts(current, book, upserts[i].ChangedFields, policy)
+			results[i].Conflicts = conflicts
+			if policy == ConflictServerWins && len(conflicts) > 0 {
+				results[i].Result = BookUpsertConflict
+				results[i].Book = current
+				continue
+			}
+		}
+
+		valid = append(valid, *book)
+		positions = append(positions, i)
+		existing = append(existing, current)
+		versions = append(versions, checkedVersion(current, upserts[i].BaseUpdatedAt))
+	}
+	if len(valid) == 0 {
+		return results, nil
+	}
+	if atomic && len(valid) < len(upserts) {
+		for _, position := range positions {
+			results[position].Result = BookUpsertAborted
+		}
+		return results, nil
+	}
+
+	// Books changed by another request since they were checked are conflicts, and the others
+	// are written again without them
+	var created []bool
+	for {
+		books := append([]entities.Book(nil), valid...)
+		var err error
+		created, err = uc.bookRepo.Upsert(books, versions)
+		var stale *repositories.StaleUpsertError
+		if !errors.As(err, &stale) {
+			if err != nil {
+				return nil, err
+			}
+			valid = books
+			break
+		}
+
+		isStale := make(map[int]bool, len(stale.Stale))
+		for _, j := range stale.Stale {
+			isStale[j] = true
+			if err := uc.staleConflict(&results[positions[j]], &valid[j], upserts[positions[j]].ChangedFields); err != nil {
+				return nil, err
+			}
+		}
+		kept := len(valid) - len(stale.Stale)
+		keptValid, keptPositions := make([]entities.Book, 0, kept), make([]int, 0, kept)
+		keptExisting, keptVersions := make([]*entities.Book, 0, kept), make([]*time.Time, 0, kept)
+		for j := range valid {
+			if !isStale[j] {
+				keptValid, keptPositions = append(keptValid, valid[j]), append(keptPositions, positions[j])
+				keptExisting, keptVersions = append(keptExisting, existing[j]), append(keptVersions, versions[j])
+			}
+		}
+		valid, positions, existing, versions = keptValid, keptPositions, keptExisting, keptVersions
+		if atomic {
+			for _, position := range positions {
+				results[position].Result = BookUpsertAborted
+			}
+			return results, nil
+		}
+		if len(valid) == 0 {
+			return results, nil
+		}
+	}
+	uc.queryCache.Invalidate()
+
+	for j := range valid {
+		book := &valid[j]
+		result := &results[positions[j]]
+		result.Book = book
+		fields := map[string]interface{}{
+			"title":  book.Title,
+			"author": book.Author,
+			"year":   book.Year,
+			"isbn":   book.ISBN,
+		}
+		if created[j] {
+			result.Result = BookUpsertCreated
+			uc.recordAudit(book.ID, entities.AuditActionCreated, fields)
+			continue
+		}
+		result.Result = BookUpsertUpdated
+		// The book may have been created by another request since it was looked up
+		if existing[j] != nil {
+			fields = upsertChanges(existing[j], book)
+		}
+		if len(fields) > 0 {
+			uc.recordAudit(book.ID, entities.AuditActionUpdated, fields)
+		}
+	}
+	return results, nil
+}
+
+// checkedVersion is the version a stored book must still be at to be overwritten: the one its
+// conflicts were resolved against or, when there was no book, the client's base version. Books
+// upserted without a base version are overwritten whatever their version.
+func checkedVersion(current *entities.Book, base *time.Time) *time.Time {
+	if base == nil {
+		return nil
+	}
+	if current == nil {
+		return base
+	}
+	updatedAt := current.UpdatedAt
+	return &updatedAt
+}
+
+// staleConflict reports a book that changed on the server after it was checked, which the
+// upsert kept as it is
+func (uc *BookUseCase) staleConflict(result *BookUpsertResult, book *entities.Book, changedFields []string) error {
+	current, err := uc.bookRepo.FindByISBN(book.ISBN)
+	if err != nil {
+		return err
+	}
+	result.Result = BookUpsertConflict
+	result.Book = current
+	if current != nil {
+		result.Conflicts = resolveConflicts(current, book, changedFields, ConflictServerWins)
+	}
+	return nil
+}
+
+// resolveConflicts lists the fields where the client's book differs from the server's. With
+// the merge policy, the server's values are put back into the client's book for the fields the
+// client did not change.
+func resolveConflicts(server, client *entities.Book, changedFields []string, policy string) []FieldConflict {
+	changes := upsertChanges(server, client)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	clientChanged := make(map[string]bool, len(changedFields))
+	for _, field := range changedFields {
+		clientChanged[field] = true
+	}
+
+	conflicts := make([]FieldConflict, 0, len(changes))
+	for _, field := range upsertFields {
+		change, ok := changes[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		kept := "client"
+		if policy == ConflictServerWins || (policy == ConflictMerge && !clientChanged[field]) {
+			kept = "server"
+		}
+		if policy == ConflictMerge && kept == "server" {
+			copyUpsertField(client, server, field)
+		}
+		conflicts = append(conflicts, FieldConflict{Field: field, Server: change["from"], Client: change["to"], Kept: kept})
+	}
+	return conflicts
+}
+
+// upsertChanges lists the fields an upsert of book changes on current
+func upsertChanges(current, book *entities.Book) map[string]interface{} {
+	changes := bookChanges(current, book)
+	for field := range changes {
+		if !isUpsertField(field) {
+			delete(changes, field)
+		}
+	}
+	return changes
+}
+
+// isUpsertField reports whether an upsert overwrites the field
+func isUpsertField(field string) bool {
+	for _, upsertField := range upsertFields {
+		if field == upsertField {
+			return true
+		}
+	}
+	return false
+}
+
+// copyUpsertField copies a field an upsert overwrites from one book to another
+func copyUpsertField(to, from *entities.Book, field string) {
+	switch field {
+	case "title":
+		to.Title = from.Title
+	case "author":
+		to.Author = from.Author
+	case "year":
+		to.Year = from.Year
+	case "publisher_id":
+		to.PublisherID = from.PublisherID
+	case "metadata":
+		to.Metadata = from.Metadata
+	}
+}
@@ -1,31 +1,213 @@
 package usecase
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"reflect"
 	"strconv"
+	"strings"
+	"time"
 
+	"library-management-system/internal/domain/domainerr"
 	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/events"
 	"library-management-system/internal/domain/repositories"
+	"library-management-system/internal/domain/urlnorm"
 )
 
 // BookUseCase implements book business logic
 type BookUseCase struct {
-	bookRepo repositories.BookRepository
+	bookRepo      repositories.BookRepository
+	auditRepo     repositories.AuditRepository
+	publisherRepo repositories.PublisherRepository
+	seriesRepo    repositories.SeriesRepository
+	draftRepo     repositories.BookDraftRepository
+	coverRepo     repositories.CoverRepository
+	ruleRepo      repositories.ValidationRuleRepository
+	branchRepo    repositories.BranchRepository
+	// identifierRepo keeps the identifiers of books besides their ISBN, when set
+	identifierRepo repositories.BookIdentifierRepository
+	// copyRepo keeps the physical copies of books, when set
+	copyRepo repositories.BookCopyRepository
+	// loanRepo tells the books out on loan, which batch deletions leave alone, when set
+	loanRepo repositories.LoanRepository
+	eventBus events.Bus
+	// actor is who changes are made for; see As
+	actor entities.Actor
+	// queryCache holds the results of listings and searches when set
+	queryCache *QueryCache
+	// importChunkSize is the number of books ImportBooks writes per transaction
+	importChunkSize int
+	// accessionPrefix and accessionDigits write the accession numbers of copies of branches
+	// without a prefix of their own
+	accessionPrefix string
+	accessionDigits int
+	// validationProfiles hold the thresholds books are validated with, by tenant
+	validationProfiles entities.ValidationProfiles
+}
+
+// defaultImportChunkSize is the number of books imported per transaction unless configured otherwise
+const defaultImportChunkSize = 500
+
+// BookImportResult reports what an import did with the books it received
+type BookImportResult struct {
+	Received int `json:"received"`
+	// Imported is the number of books inserted, or inserted and overwritten with upsert
+	Imported int64 `json:"imported"`
+	// Skipped is the number of valid books left out because their ISBN was taken
+	Skipped  int64             `json:"skipped"`
+	Rejected []BookImportError `json:"rejected"`
+	// RunID is the import run the import is recorded as, when the import history is kept
+	RunID string `json:"run_id,omitempty"`
+	// DuplicateOf is the earlier run of the same file, when duplicates are only warned about
+	DuplicateOf string `json:"duplicate_of,omitempty"`
+	// Aborted is set when the import was atomic and books were rejected, so none was written
+	Aborted bool `json:"aborted,omitempty"`
+}
+
+// ImportProgress reports how far an import got
+type ImportProgress struct {
+	// Processed counts the books rejected and the books of the chunks written so far
+	Processed int `json:"processed"`
+	Total     int `json:"total"`
+	// Imported is the number of books inserted or overwritten so far
+	Imported int64 `json:"imported"`
+}
+
+// BookImportError explains why a book of an import was rejected
+type BookImportError struct {
+	// Index is the position of the book in the import
+	Index int    `json:"index"`
+	ISBN  string `json:"isbn"`
+	Error string `json:"error"`
 }
 
 // NewBookUseCase creates a new book use case
 func NewBookUseCase(bookRepo repositories.BookRepository) *BookUseCase {
 	return &BookUseCase{
-		bookRepo: bookRepo,
+		bookRepo:        bookRepo,
+		importChunkSize: defaultImportChunkSize,
+		accessionPrefix: defaultAccessionPrefix,
+		accessionDigits: defaultAccessionDigits,
+	}
+}
+
+// SetAuditRepository enables recording an audit trail of book changes
+func (uc *BookUseCase) SetAuditRepository(auditRepo repositories.AuditRepository) {
+	uc.auditRepo = auditRepo
+}
+
+// SetPublisherRepository enables linking books to publishers and searching by publisher
+func (uc *BookUseCase) SetPublisherRepository(publisherRepo repositories.PublisherRepository) {
+	uc.publisherRepo = publisherRepo
+}
+
+// SetSeriesRepository enables placing books in series
+func (uc *BookUseCase) SetSeriesRepository(seriesRepo repositories.SeriesRepository) {
+	uc.seriesRepo = seriesRepo
+}
+
+// SetDraftRepository enables drafts, changes to books that are reviewed before they go live
+func (uc *BookUseCase) SetDraftRepository(draftRepo repositories.BookDraftRepository) {
+	uc.draftRepo = draftRepo
+}
+
+// SetCoverRepository releases the covers of books when they are permanently deleted
+func (uc *BookUseCase) SetCoverRepository(coverRepo repositories.CoverRepository) {
+	uc.coverRepo = coverRepo
+}
+
+// SetValidationRuleRepository enables the validation rules admins add on top of the built-in checks
+func (uc *BookUseCase) SetValidationRuleRepository(ruleRepo repositories.ValidationRuleRepository) {
+	uc.ruleRepo = ruleRepo
+}
+
+// SetBranchRepository enables checking that the branches given to books exist
+func (uc *BookUseCase) SetBranchRepository(branchRepo repositories.BranchRepository) {
+	uc.branchRepo = branchRepo
+}
+
+// SetIdentifierRepository enables identifiers of books besides their ISBN
+func (uc *BookUseCase) SetIdentifierRepository(identifierRepo repositories.BookIdentifierRepository) {
+	uc.identifierRepo = identifierRepo
+}
+
+// SetCopyRepository enables the physical copies of books and their accession numbers
+func (uc *BookUseCase) SetCopyRepository(copyRepo repositories.BookCopyRepository) {
+	uc.copyRepo = copyRepo
+}
+
+// SetLoanRepository makes batch deletions refuse the books out on loan
+func (uc *BookUseCase) SetLoanRepository(loanRepo repositories.LoanRepository) {
+	uc.loanRepo = loanRepo
+}
+
+// As returns the use case making its changes for actor. Librarians of a branch can only change
+// the books of their branch, which their lookups are scoped to, and their new books go to it.
+func (uc *BookUseCase) As(actor entities.Actor) *BookUseCase {
+	scoped := *uc
+	scoped.actor = actor
+	return &scoped
+}
+
+// SetQueryCache caches the results of listings and searches; every change to books invalidates them
+func (uc *BookUseCase) SetQueryCache(cache *QueryCache) {
+	uc.queryCache = cache
+}
+
+// SetImportChunkSize sets the number of books ImportBooks writes per transaction
+func (uc *BookUseCase) SetImportChunkSize(size int) {
+	if size > 0 {
+		uc.importChunkSize = size
+	}
+}
+
+// SetAccessionFormat sets the prefix of the accession numbers of copies of branches without a
+// prefix of their own, and the digits numbers are padded to with zeros
+func (uc *BookUseCase) SetAccessionFormat(prefix string, digits int) {
+	uc.accessionPrefix = prefix
+	if digits > 0 {
+		uc.accessionDigits = digits
 	}
 }
 
+// SetValidationProfiles sets the thresholds books are validated with, by tenant
+func (uc *BookUseCase) SetValidationProfiles(profiles entities.ValidationProfiles) {
+	uc.validationProfiles = profiles
+}
+
+// ValidationProfile returns the thresholds the books of a tenant are validated with
+func (uc *BookUseCase) ValidationProfile(tenantID string) entities.ValidationProfile {
+	return uc.validationProfiles.For(tenantID)
+}
+
+// SetEventBus enables publishing availability changes of books
+func (uc *BookUseCase) SetEventBus(bus events.Bus) {
+	uc.eventBus = bus
+}
+
 // CreateBook creates a new book
 func (uc *BookUseCase) CreateBook(book *entities.Book) error {
 	// Validate book data
 	if err := uc.validateBook(book); err != nil {
 		return err
 	}
+	if err := uc.validatePublisher(book); err != nil {
+		return err
+	}
+	if err := uc.validateSeries("", book); err != nil {
+		return err
+	}
+	if err := uc.assignBranch(book); err != nil {
+		return err
+	}
+
+	if err := setInitialStatus(book); err != nil {
+		return err
+	}
 
 	// Check if ISBN already exists
 	existingBook, err := uc.bookRepo.FindByISBN(book.ISBN)
@@ -33,10 +215,145 @@ func (uc *BookUseCase) CreateBook(book *entities.Book) error {
 		return err
 	}
 	if existingBook != nil {
-		return errors.New("book with this ISBN already exists")
+		return isbnTaken(existingBook)
+	}
+
+	if err := uc.assignSlug(book, ""); err != nil {
+		return err
+	}
+
+	// A publication time that has already passed publishes the book right away
+	if book.PublishAt != nil && !book.PublishAt.After(entities.Now()) {
+		book.PublishAt = nil
 	}
 
-	return uc.bookRepo.Create(book)
+	if err := uc.bookRepo.Create(book); err != nil {
+		return err
+	}
+	uc.queryCache.Invalidate()
+
+	uc.recordAudit(book.ID, entities.AuditActionCreated, map[string]interface{}{
+		"title":  book.Title,
+		"author": book.Author,
+		"year":   book.Year,
+		"isbn":   book.ISBN,
+	})
+	return nil
+}
+
+// ImportBooks creates many books at once, writing them in chunks with a batch insert. Invalid
+// books are rejected one by one while the others are imported, or with atomic none is imported
+// and all are written in one transaction; a taken ISBN is skipped or overwritten as onConflict
+// says, except that books with the ISBN of a deleted book are rejected rather than overwriting it.
+// Imported books are not recorded in the audit trail one by one.
+func (uc *BookUseCase) ImportBooks(books []entities.Book, onConflict string, atomic bool) (*BookImportResult, error) {
+	return uc.ImportBooksWithProgress(books, onConflict, atomic, nil)
+}
+
+// ImportBooksWithProgress imports books like ImportBooks, reporting the progress of the import to
+// progress, when not nil, once the books are validated and after each chunk is written
+func (uc *BookUseCase) ImportBooksWithProgress(books []entities.Book, onConflict string, atomic bool, progress func(ImportProgress)) (*BookImportResult, error) {
+	conflict := repositories.BatchConflict(onConflict)
+	switch conflict {
+	case "":
+		conflict = repositories.BatchConflictSkip
+	case repositories.BatchConflictSkip, repositories.BatchConflictUpsert:
+	default:
+		return nil, errors.New("on_conflict must be skip or upsert")
+	}
+
+	result := &BookImportResult{Received: len(books), Rejected: []BookImportError{}}
+	valid := make([]entities.Book, 0, len(books))
+	isbns := make(map[string]bool, len(books))
+	slugs := make(map[string]bool, len(books))
+	for i := range books {
+		book := &books[i]
+		err := uc.prepareBatchBook(book, isbns, slugs)
+		if err == nil && conflict == repositories.BatchConflictUpsert {
+			err = uc.checkOverwrite(book.ISBN)
+		}
+		if err != nil {
+			result.Rejected = append(result.Rejected, BookImportError{Index: i, ISBN: book.ISBN, Error: err.Error()})
+			continue
+		}
+		valid = append(valid, *book)
+	}
+	report := func(processed int, imported int64) {
+		if progress != nil {
+			progress(ImportProgress{Processed: processed, Total: len(books), Imported: imported})
+		}
+	}
+	report(len(result.Rejected), 0)
+	if atomic && len(result.Rejected) > 0 {
+		result.Aborted = true
+		return result, nil
+	}
+	if len(valid) == 0 {
+		return result, nil
+	}
+
+	// Chunks are written one by one rather than in a single CreateBatch, which also writes a
+	// transaction per chunk, to report the progress in between. Atomic imports are one chunk.
+	chunkSize := uc.importChunkSize
+	if chunkSize < 1 || atomic {
+		chunkSize = len(valid)
+	}
+	var affected int64
+	var err error
+	for start := 0; start < len(valid); start += chunkSize {
+		end := min(start+chunkSize, len(valid))
+		var rows int64
+		rows, err = uc.bookRepo.CreateBatch(valid[start:end], chunkSize, conflict)
+		affected += rows
+		if err != nil {
+			break
+		}
+		report(len(result.Rejected)+end, affected)
+	}
+	if affected > 0 {
+		uc.queryCache.Invalidate()
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Imported = affected
+	result.Skipped = int64(len(valid)) - affected
+	return result, nil
+}
+
+// prepareBatchBook validates a book of a batch and gives it a slug, checking its ISBN and slug
+// against those of the books before it in the batch too
+func (uc *BookUseCase) prepareBatchBook(book *entities.Book, isbns, slugs map[string]bool) error {
+	if err := uc.validateBook(book); err != nil {
+		return err
+	}
+	if err := uc.validatePublisher(book); err != nil {
+		return err
+	}
+	if err := uc.assignBranch(book); err != nil {
+		return err
+	}
+	if err := setInitialStatus(book); err != nil {
+		return err
+	}
+	if isbns[book.ISBN] {
+		return errors.New("ISBN appears earlier in the batch")
+	}
+
+	slug, err := urlnorm.UniqueSlug(book.SlugBase(), func(slug string) (bool, error) {
+		if slugs[slug] {
+			return true, nil
+		}
+		existing, err := uc.bookRepo.FindBySlug(slug)
+		return existing != nil, err
+	})
+	if err != nil {
+		return err
+	}
+	book.Slug = slug
+	isbns[book.ISBN] = true
+	slugs[slug] = true
+	return nil
 }
 
 // GetBook retrieves a book by ID
@@ -48,49 +365,243 @@ func (uc *BookUseCase) GetBook(id string) (*entities.Book, error) {
 	return uc.bookRepo.GetByID(id)
 }
 
-// GetAllBooks retrieves all books
+// GetBookBySlug retrieves a book by slug; deleted books and books that are not listed are not found
+func (uc *BookUseCase) GetBookBySlug(slug string) (*entities.Book, error) {
+	if slug == "" {
+		return nil, errors.New("slug is required")
+	}
+
+	book, err := uc.bookRepo.FindBySlug(slug)
+	if err != nil || book == nil || book.DeletedAt != nil || !book.Listed() {
+		return nil, err
+	}
+	return book, nil
+}
+
+// DublinCore describes a book in Dublin Core, naming its publisher when publishers are enabled
+func (uc *BookUseCase) DublinCore(book *entities.Book) (*entities.DublinCore, error) {
+	return dublinCore(uc.publisherRepo, book)
+}
+
+// GetAllBooks retrieves all listed books
 func (uc *BookUseCase) GetAllBooks() ([]entities.Book, error) {
-	return uc.bookRepo.GetAll()
+	return uc.queryCache.get("all", func() ([]entities.Book, error) {
+		return liveBooks(uc.bookRepo.GetAll())
+	})
 }
 
 // UpdateBook updates an existing book
 func (uc *BookUseCase) UpdateBook(id string, book *entities.Book) error {
+	existingBook, changes, err := uc.prepareUpdate(id, book)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.bookRepo.Update(existingBook); err != nil {
+		return err
+	}
+	uc.queryCache.Invalidate()
+
+	if len(changes) > 0 {
+		uc.recordAudit(id, entities.AuditActionUpdated, changes)
+	}
+	return nil
+}
+
+// prepareUpdate validates the update of a book and returns the book with the update applied,
+// not yet saved, and the changes it makes
+func (uc *BookUseCase) prepareUpdate(id string, book *entities.Book) (*entities.Book, map[string]interface{}, error) {
 	if id == "" {
-		return errors.New("book ID is required")
+		return nil, nil, errors.New("book ID is required")
 	}
 
 	// Validate book data
 	if err := uc.validateBook(book); err != nil {
-		return err
+		return nil, nil, err
+	}
+	if err := uc.validatePublisher(book); err != nil {
+		return nil, nil, err
+	}
+	if err := uc.validateSeries(id, book); err != nil {
+		return nil, nil, err
 	}
 
 	// Check if book exists
-	existingBook, err := uc.bookRepo.GetByID(id)
+	existingBook, err := uc.bookForChange(id)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	if existingBook == nil {
-		return errors.New("book not found")
+		return nil, nil, domainerr.ErrBookNotFound
+	}
+	if existingBook.Status == entities.BookStatusArchived {
+		return nil, nil, domainerr.ErrBookArchived
+	}
+
+	// Books keep their branch unless the update moves them to another one
+	if book.BranchID == nil {
+		book.BranchID = existingBook.BranchID
+	}
+	if err := uc.assignBranch(book); err != nil {
+		return nil, nil, err
 	}
 
 	// Check if ISBN is being changed and if it already exists
 	if book.ISBN != existingBook.ISBN {
 		bookWithISBN, err := uc.bookRepo.FindByISBN(book.ISBN)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 		if bookWithISBN != nil {
-			return errors.New("book with this ISBN already exists")
+			return nil, nil, isbnTaken(bookWithISBN)
 		}
 	}
 
+	changes := bookChanges(existingBook, book)
+
 	// Preserve existing data and update only the provided fields
 	existingBook.Title = book.Title
 	existingBook.Author = book.Author
 	existingBook.Year = book.Year
 	existingBook.ISBN = book.ISBN
+	existingBook.Description = book.Description
+	existingBook.PublisherID = book.PublisherID
+	existingBook.SeriesID = book.SeriesID
+	existingBook.SeriesPosition = book.SeriesPosition
+	existingBook.BranchID = book.BranchID
+	existingBook.Metadata = book.Metadata
+	if err := uc.assignSlug(existingBook, existingBook.Slug); err != nil {
+		return nil, nil, err
+	}
+	return existingBook, changes, nil
+}
+
+// SaveDraft stores proposed changes to a book without touching the live record, replacing any
+// draft the book has. Drafts are validated like updates.
+func (uc *BookUseCase) SaveDraft(id string, book *entities.Book) (*entities.BookDraft, error) {
+	if uc.draftRepo == nil {
+		return nil, errors.New("drafts are not enabled")
+	}
+	// The book with the changes applied keeps the updated_at of the live record
+	updatedBook, changes, err := uc.prepareUpdate(id, book)
+	if err != nil {
+		return nil, err
+	}
 
-	return uc.bookRepo.Update(existingBook)
+	draft := &entities.BookDraft{
+		BookID:         id,
+		Title:          book.Title,
+		Author:         book.Author,
+		Year:           book.Year,
+		ISBN:           book.ISBN,
+		Description:    book.Description,
+		PublisherID:    book.PublisherID,
+		SeriesID:       book.SeriesID,
+		SeriesPosition: book.SeriesPosition,
+		Metadata:       book.Metadata,
+		BaseUpdatedAt:  updatedBook.UpdatedAt,
+	}
+	if err := uc.draftRepo.Save(draft); err != nil {
+		return nil, err
+	}
+	draft.Changes = changes
+	return draft, nil
+}
+
+// GetDraft retrieves the draft of a book with its changes to the live record
+func (uc *BookUseCase) GetDraft(id string) (*entities.BookDraft, error) {
+	if uc.draftRepo == nil {
+		return nil, errors.New("drafts are not enabled")
+	}
+	existingBook, err := uc.bookRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if existingBook == nil {
+		return nil, domainerr.ErrBookNotFound
+	}
+	draft, err := uc.draftRepo.GetByBookID(id)
+	if err != nil {
+		return nil, err
+	}
+	if draft == nil {
+		return nil, domainerr.ErrBookDraftNotFound
+	}
+	draft.Changes = bookChanges(existingBook, draft.Book())
+	return draft, nil
+}
+
+// PublishDraft applies the draft of a book to the live record and deletes the draft, atomically.
+// Drafts of books updated since the draft was saved are refused, so changes are not lost.
+func (uc *BookUseCase) PublishDraft(id string) (*entities.Book, error) {
+	draft, err := uc.GetDraft(id)
+	if err != nil {
+		return nil, err
+	}
+	book, changes, err := uc.prepareUpdate(id, draft.Book())
+	if err != nil {
+		return nil, err
+	}
+	if !book.UpdatedAt.Equal(draft.BaseUpdatedAt) {
+		return nil, domainerr.ErrStaleBookDraft
+	}
+
+	if err := uc.draftRepo.Publish(book); err != nil {
+		return nil, err
+	}
+	uc.queryCache.Invalidate()
+
+	if len(changes) > 0 {
+		uc.recordAudit(id, entities.AuditActionUpdated, changes)
+	}
+	return book, nil
+}
+
+// DiscardDraft deletes the draft of a book
+func (uc *BookUseCase) DiscardDraft(id string) error {
+	if _, err := uc.GetDraft(id); err != nil {
+		return err
+	}
+	if _, err := uc.bookForChange(id); err != nil {
+		return err
+	}
+	return uc.draftRepo.Delete(id)
+}
+
+// ChangeBookStatus moves a book to another status, e.g. archives it
+func (uc *BookUseCase) ChangeBookStatus(id, status string) (*entities.Book, error) {
+	if !entities.IsBookStatus(status) {
+		return nil, fmt.Errorf("unknown book status %q", status)
+	}
+
+	if id == "" {
+		return nil, errors.New("book ID is required")
+	}
+	book, err := uc.bookForChange(id)
+	if err != nil {
+		return nil, err
+	}
+	if book == nil {
+		return nil, domainerr.ErrBookNotFound
+	}
+	if !book.CanMoveTo(status) {
+		return nil, fmt.Errorf("cannot move book from %s to %s", book.Status, status)
+	}
+
+	previous := book.Status
+	book.Status = status
+	if err := uc.bookRepo.Update(book); err != nil {
+		return nil, err
+	}
+	uc.queryCache.Invalidate()
+
+	uc.recordAudit(id, entities.AuditActionStatusChanged, map[string]interface{}{
+		"status": map[string]interface{}{"from": previous, "to": status},
+	})
+	if previous == entities.BookStatusArchived || status == entities.BookStatusArchived {
+		uc.publishAvailability(id, status != entities.BookStatusArchived)
+	}
+	return book, nil
 }
 
 // DeleteBook deletes a book (soft delete)
@@ -100,15 +611,41 @@ func (uc *BookUseCase) DeleteBook(id string) error {
 	}
 
 	// Check if book exists
-	existingBook, err := uc.bookRepo.GetByID(id)
+	existingBook, err := uc.bookForChange(id)
 	if err != nil {
 		return err
 	}
 	if existingBook == nil {
-		return errors.New("book not found")
+		return domainerr.ErrBookNotFound
+	}
+	if err := uc.checkNotOnLoan(id); err != nil {
+		return err
+	}
+
+	if err := uc.bookRepo.Delete(id); err != nil {
+		return err
 	}
+	uc.queryCache.Invalidate()
+
+	uc.recordAudit(id, entities.AuditActionDeleted, nil)
+	uc.publishAvailability(id, false)
+	return nil
+}
 
-	return uc.bookRepo.Delete(id)
+// checkNotOnLoan returns ErrBookOnLoan for a book with active loans, which cannot be deleted
+// until they are returned. Without loans, no book is on loan.
+func (uc *BookUseCase) checkNotOnLoan(bookID string) error {
+	if uc.loanRepo == nil {
+		return nil
+	}
+	loans, err := uc.loanRepo.ListActiveByBook(bookID)
+	if err != nil {
+		return err
+	}
+	if len(loans) > 0 {
+		return domainerr.ErrBookOnLoan
+	}
+	return nil
 }
 
 // HardDeleteBook permanently deletes a book
@@ -118,15 +655,43 @@ func (uc *BookUseCase) HardDeleteBook(id string) error {
 	}
 
 	// Check if book exists
-	existingBook, err := uc.bookRepo.GetByID(id)
+	existingBook, err := uc.bookForChange(id)
 	if err != nil {
 		return err
 	}
 	if existingBook == nil {
-		return errors.New("book not found")
+		return domainerr.ErrBookNotFound
+	}
+	if err := uc.checkNotOnLoan(id); err != nil {
+		return err
 	}
 
-	return uc.bookRepo.HardDelete(id)
+	if err := uc.bookRepo.HardDelete(id); err != nil {
+		return err
+	}
+	uc.queryCache.Invalidate()
+	uc.deleteDraft(id)
+	uc.deleteCover(id)
+	uc.deleteIdentifiers(id)
+
+	uc.recordAudit(id, entities.AuditActionHardDeleted, nil)
+	uc.publishAvailability(id, false)
+	return nil
+}
+
+// maxCheapSearchTermLength is the longest title or author term searched without throttling
+const maxCheapSearchTermLength = 64
+
+// IsExpensiveSearchTerm reports whether searching a title or author term is likely to be slow.
+// Terms are matched with LIKE and their wildcards are not escaped, so a term starting with % or _
+// scans every row and one with several wildcards backtracks heavily; very long terms are costly
+// to compare too.
+func IsExpensiveSearchTerm(term string) bool {
+	if term == "" {
+		return false
+	}
+	return strings.ContainsAny(term[:1], "%_") || strings.Count(term, "%")+strings.Count(term, "_") > 1 ||
+		len(term) > maxCheapSearchTermLength
 }
 
 // SearchBooksByTitle searches books by title
@@ -135,7 +700,10 @@ func (uc *BookUseCase) SearchBooksByTitle(title string) ([]entities.Book, error)
 		return nil, errors.New("title is required for search")
 	}
 
-	return uc.bookRepo.FindByTitle(title)
+	// Titles are compared case-insensitively, so searches differing in case share a result
+	return uc.queryCache.get("title:"+strings.ToLower(title), func() ([]entities.Book, error) {
+		return publishedBooks(uc.bookRepo.FindByTitle(title))
+	})
 }
 
 // SearchBooksByAuthor searches books by author
@@ -144,7 +712,9 @@ func (uc *BookUseCase) SearchBooksByAuthor(author string) ([]entities.Book, erro
 		return nil, errors.New("author is required for search")
 	}
 
-	return uc.bookRepo.FindByAuthor(author)
+	return uc.queryCache.get("author:"+strings.ToLower(author), func() ([]entities.Book, error) {
+		return publishedBooks(uc.bookRepo.FindByAuthor(author))
+	})
 }
 
 // SearchBooksByYear searches books by year
@@ -158,7 +728,63 @@ func (uc *BookUseCase) SearchBooksByYear(yearStr string) ([]entities.Book, error
 		return nil, errors.New("invalid year format")
 	}
 
-	return uc.bookRepo.FindByYear(year)
+	return uc.queryCache.get("year:"+strconv.Itoa(year), func() ([]entities.Book, error) {
+		return publishedBooks(uc.bookRepo.FindByYear(year))
+	})
+}
+
+// SearchBooksByPublisher searches books of a publisher, including the books of its imprints
+func (uc *BookUseCase) SearchBooksByPublisher(publisherID string) ([]entities.Book, error) {
+	if publisherID == "" {
+		return nil, errors.New("publisher is required for search")
+	}
+	if uc.publisherRepo == nil {
+		return nil, errors.New("publishers are not enabled")
+	}
+
+	family, err := publisherFamily(uc.publisherRepo, publisherID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The family is looked up every time, so changes to imprints apply right away
+	return uc.queryCache.get("publishers:"+strings.Join(family, ","), func() ([]entities.Book, error) {
+		return publishedBooks(uc.bookRepo.FindByPublishers(family))
+	})
+}
+
+// SearchBooksByStatus searches books by status, drafts included
+func (uc *BookUseCase) SearchBooksByStatus(status string) ([]entities.Book, error) {
+	if status == "" {
+		return nil, errors.New("status is required for search")
+	}
+
+	if !entities.IsBookStatus(status) {
+		return nil, fmt.Errorf("unknown book status %q", status)
+	}
+
+	return uc.queryCache.get("status:"+status, func() ([]entities.Book, error) {
+		books, err := publishedBooks(uc.bookRepo.GetAll())
+		if err != nil {
+			return nil, err
+		}
+		return FilterByStatus(books, status), nil
+	})
+}
+
+// SearchBooksByMetadata searches books having each metadata key with the value, compared as text
+func (uc *BookUseCase) SearchBooksByMetadata(filters map[string]string) ([]entities.Book, error) {
+	if len(filters) == 0 {
+		return nil, errors.New("metadata is required for search")
+	}
+
+	query := url.Values{}
+	for key, value := range filters {
+		query.Set(key, value)
+	}
+	return uc.queryCache.get("metadata:"+query.Encode(), func() ([]entities.Book, error) {
+		return publishedBooks(uc.bookRepo.FindByMetadata(filters))
+	})
 }
 
 // GetDeletedBooks retrieves all soft-deleted books
@@ -166,32 +792,454 @@ func (uc *BookUseCase) GetDeletedBooks() ([]entities.Book, error) {
 	return uc.bookRepo.GetDeletedBooks()
 }
 
+// DeletedBookWithISBN retrieves the deleted book having an ISBN, written in any form, or nil when
+// no deleted book has it
+func (uc *BookUseCase) DeletedBookWithISBN(isbn string) (*entities.Book, error) {
+	book, err := uc.bookRepo.FindByISBN(entities.NormalizeISBN(isbn))
+	if err != nil || book == nil || book.DeletedAt == nil {
+		return nil, err
+	}
+	return book, nil
+}
+
 // RestoreBook restores a soft-deleted book
 func (uc *BookUseCase) RestoreBook(id string) error {
 	if id == "" {
 		return errors.New("book ID is required")
 	}
+	// Restore tells missing books itself, so only librarians of a branch need a lookup
+	if uc.actor.BranchScoped() {
+		if _, err := uc.bookForChange(id); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.bookRepo.Restore(id); err != nil {
+		return err
+	}
+	uc.queryCache.Invalidate()
+
+	uc.recordAudit(id, entities.AuditActionRestored, nil)
+	uc.publishAvailability(id, true)
+	return nil
+}
+
+// GetScheduledBooks retrieves the books waiting for their publication, soonest first
+func (uc *BookUseCase) GetScheduledBooks() ([]entities.Book, error) {
+	return uc.bookRepo.FindScheduled()
+}
+
+// PublishScheduledBooks makes the scheduled books whose publication time is not after now live
+// and returns how many were published
+func (uc *BookUseCase) PublishScheduledBooks(now time.Time) (int, error) {
+	books, err := uc.bookRepo.FindScheduled()
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, book := range books {
+		if book.PublishAt == nil || book.PublishAt.After(now) {
+			continue
+		}
+		if err := uc.bookRepo.MarkPublished(book.ID); err != nil {
+			return published, err
+		}
+		uc.queryCache.Invalidate()
+		uc.recordAudit(book.ID, entities.AuditActionPublished, nil)
+		uc.publishAvailability(book.ID, true)
+		published++
+	}
+	return published, nil
+}
+
+// PurgeDeletedBooks permanently deletes the books that were soft-deleted before a cutoff and returns how many were purged
+func (uc *BookUseCase) PurgeDeletedBooks(deletedBefore time.Time) (int, error) {
+	books, err := uc.bookRepo.GetDeletedBooks()
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, book := range books {
+		if book.DeletedAt == nil || !book.DeletedAt.Before(deletedBefore) {
+			continue
+		}
+		if err := uc.bookRepo.HardDelete(book.ID); err != nil {
+			return purged, err
+		}
+		uc.deleteDraft(book.ID)
+		uc.deleteCover(book.ID)
+		uc.deleteIdentifiers(book.ID)
+		uc.recordAudit(book.ID, entities.AuditActionHardDeleted, nil)
+		purged++
+	}
+	return purged, nil
+}
+
+// FilterByStatus keeps the books with a known status; without one it keeps the listed books,
+// leaving drafts out
+func FilterByStatus(books []entities.Book, status string) []entities.Book {
+	kept, _ := filterBooks(books, nil, func(book entities.Book) bool {
+		if status == "" {
+			return book.Listed()
+		}
+		return book.Status == status
+	})
+	return kept
+}
+
+// FilterByMetadata keeps the books having each metadata key with the value, compared as text
+func FilterByMetadata(books []entities.Book, filters map[string]string) []entities.Book {
+	kept, _ := filterBooks(books, nil, func(book entities.Book) bool { return book.MetadataMatches(filters) })
+	return kept
+}
+
+// liveBooks keeps the books public listings show, see entities.Book.Listed
+func liveBooks(books []entities.Book, err error) ([]entities.Book, error) {
+	return filterBooks(books, err, func(book entities.Book) bool { return book.Listed() })
+}
+
+// publishedBooks drops the books waiting for their publication from search results
+func publishedBooks(books []entities.Book, err error) ([]entities.Book, error) {
+	return filterBooks(books, err, func(book entities.Book) bool { return !book.Scheduled() })
+}
+
+// filterBooks keeps the books that match, passing a lookup error through
+func filterBooks(books []entities.Book, err error, keep func(entities.Book) bool) ([]entities.Book, error) {
+	if err != nil {
+		return nil, err
+	}
+	kept := make([]entities.Book, 0, len(books))
+	for _, book := range books {
+		if keep(book) {
+			kept = append(kept, book)
+		}
+	}
+	return kept, nil
+}
+
+// deleteDraft drops the draft of a book that was permanently deleted. Failures are logged; the
+// draft of a missing book can never be read or published.
+func (uc *BookUseCase) deleteDraft(bookID string) {
+	if uc.draftRepo == nil {
+		return
+	}
+	if err := uc.draftRepo.Delete(bookID); err != nil {
+		log.Printf("Failed to delete draft of book %s: %v", bookID, err)
+	}
+}
+
+// deleteCover releases the cover of a book that was permanently deleted, if covers are enabled.
+// Failures are logged; the book is already gone.
+func (uc *BookUseCase) deleteCover(bookID string) {
+	if uc.coverRepo == nil {
+		return
+	}
+	if _, err := uc.coverRepo.Detach(bookID); err != nil {
+		log.Printf("Failed to delete cover of book %s: %v", bookID, err)
+	}
+}
+
+// deleteIdentifiers frees the identifiers of a book that was permanently deleted for other books.
+// Failures are logged; the book is already gone.
+func (uc *BookUseCase) deleteIdentifiers(bookID string) {
+	if uc.identifierRepo == nil {
+		return
+	}
+	if err := uc.identifierRepo.DeleteByBook(bookID); err != nil {
+		log.Printf("Failed to delete identifiers of book %s: %v", bookID, err)
+	}
+}
+
+// recordAudit writes an audit entry for a book when auditing is enabled.
+// Audit failures are logged rather than failing a change that already happened.
+func (uc *BookUseCase) recordAudit(bookID, action string, changes map[string]interface{}) {
+	if uc.auditRepo == nil {
+		return
+	}
+
+	entry := &entities.AuditEntry{
+		EntityType:     "book",
+		EntityID:       bookID,
+		Action:         action,
+		ImpersonatorID: uc.actor.ImpersonatorID,
+	}
+	if changes != nil {
+		data, err := json.Marshal(changes)
+		if err == nil {
+			entry.Changes = string(data)
+		}
+	}
+
+	if err := uc.auditRepo.Create(entry); err != nil {
+		log.Printf("Failed to record audit entry for book %s: %v", bookID, err)
+	}
+}
+
+// publishAvailability tells subscribers, such as long-polling clients, that a book became
+// available or unavailable
+func (uc *BookUseCase) publishAvailability(bookID string, available bool) {
+	if uc.eventBus == nil {
+		return
+	}
+	uc.eventBus.Publish(events.Event{
+		Type:    events.BookAvailabilityChanged,
+		BookID:  bookID,
+		Payload: map[string]string{"available": strconv.FormatBool(available)},
+	})
+}
+
+// bookChanges lists the fields that differ between two versions of a book
+func bookChanges(before, after *entities.Book) map[string]interface{} {
+	changes := make(map[string]interface{})
+	if before.Title != after.Title {
+		changes["title"] = map[string]interface{}{"from": before.Title, "to": after.Title}
+	}
+	if before.Author != after.Author {
+		changes["author"] = map[string]interface{}{"from": before.Author, "to": after.Author}
+	}
+	if before.Year != after.Year {
+		changes["year"] = map[string]interface{}{"from": before.Year, "to": after.Year}
+	}
+	if before.ISBN != after.ISBN {
+		changes["isbn"] = map[string]interface{}{"from": before.ISBN, "to": after.ISBN}
+	}
+	if before.Description != after.Description {
+		changes["description"] = map[string]interface{}{"from": before.Description, "to": after.Description}
+	}
+	if optionalID(before.PublisherID) != optionalID(after.PublisherID) {
+		changes["publisher_id"] = map[string]interface{}{"from": optionalID(before.PublisherID), "to": optionalID(after.PublisherID)}
+	}
+	if optionalID(before.BranchID) != optionalID(after.BranchID) {
+		changes["branch_id"] = map[string]interface{}{"from": optionalID(before.BranchID), "to": optionalID(after.BranchID)}
+	}
+	if optionalID(before.SeriesID) != optionalID(after.SeriesID) {
+		changes["series_id"] = map[string]interface{}{"from": optionalID(before.SeriesID), "to": optionalID(after.SeriesID)}
+	}
+	if seriesPosition(before.SeriesPosition) != seriesPosition(after.SeriesPosition) {
+		changes["series_position"] = map[string]interface{}{"from": seriesPosition(before.SeriesPosition), "to": seriesPosition(after.SeriesPosition)}
+	}
+	if (len(before.Metadata) > 0 || len(after.Metadata) > 0) && !reflect.DeepEqual(before.Metadata, after.Metadata) {
+		changes["metadata"] = map[string]interface{}{"from": before.Metadata, "to": after.Metadata}
+	}
+	return changes
+}
+
+// optionalID dereferences an optional reference, empty meaning none
+func optionalID(id *string) string {
+	if id == nil {
+		return ""
+	}
+	return *id
+}
+
+// bookForChange retrieves a book the actor is about to change. Librarians of a branch look it up
+// within their branch, and are denied the books found outside of it.
+func (uc *BookUseCase) bookForChange(id string) (*entities.Book, error) {
+	if !uc.actor.BranchScoped() {
+		return uc.bookRepo.GetByID(id)
+	}
+	book, err := uc.bookRepo.GetByIDInBranch(id, uc.actor.BranchID)
+	if err != nil || book != nil {
+		return book, err
+	}
+	other, err := uc.bookRepo.GetByID(id)
+	if err != nil || other == nil {
+		return nil, err
+	}
+	return nil, domainerr.ErrBranchAccessDenied
+}
+
+// assignBranch checks the branch of a book being created or updated: it must exist, and
+// librarians of a branch can only put books in theirs, which new books go to by default
+func (uc *BookUseCase) assignBranch(book *entities.Book) error {
+	if book.BranchID == nil && uc.actor.BranchScoped() {
+		branchID := uc.actor.BranchID
+		book.BranchID = &branchID
+	}
+	if !uc.actor.InBranch(book.BranchID) {
+		return domainerr.ErrBranchAccessDenied
+	}
+	if book.BranchID == nil || uc.branchRepo == nil {
+		return nil
+	}
+	branch, err := uc.branchRepo.GetByID(*book.BranchID)
+	if err != nil {
+		return err
+	}
+	if branch == nil {
+		return domainerr.ErrBranchNotFound
+	}
+	return nil
+}
 
-	return uc.bookRepo.Restore(id)
+// checkOverwrite denies overwriting a deleted book by its ISBN, which would leave it deleted, and
+// librarians of a branch overwriting a book of another branch
+func (uc *BookUseCase) checkOverwrite(isbn string) error {
+	existing, err := uc.bookRepo.FindByISBN(isbn)
+	if err != nil || existing == nil {
+		return err
+	}
+	if existing.DeletedAt != nil {
+		return domainerr.ErrISBNOfDeletedBook
+	}
+	if !uc.actor.InBranch(existing.BranchID) {
+		return domainerr.ErrBranchAccessDenied
+	}
+	return nil
 }
 
-// validateBook validates book data
+// isbnTaken tells why the ISBN of existing cannot be given to another book: a deleted book keeps
+// its ISBN, so that it can be restored, until it is purged
+func isbnTaken(existing *entities.Book) error {
+	if existing.DeletedAt != nil {
+		return domainerr.ErrISBNOfDeletedBook
+	}
+	return domainerr.ErrDuplicateISBN
+}
+
+// validatePublisher checks that the publisher a book is linked to exists
+func (uc *BookUseCase) validatePublisher(book *entities.Book) error {
+	if book.PublisherID == nil {
+		return nil
+	}
+	if uc.publisherRepo == nil {
+		return errors.New("publishers are not enabled")
+	}
+
+	publisher, err := uc.publisherRepo.GetByID(*book.PublisherID)
+	if err != nil {
+		return err
+	}
+	if publisher == nil {
+		return domainerr.ErrPublisherNotFound
+	}
+	return nil
+}
+
+// dublinCore describes a book in Dublin Core, looking up the name of its publisher when there is a
+// publisher repository; a publisher that no longer exists is left out of the record
+func dublinCore(publisherRepo repositories.PublisherRepository, book *entities.Book) (*entities.DublinCore, error) {
+	var name string
+	if publisherRepo != nil && book.PublisherID != nil {
+		publisher, err := publisherRepo.GetByID(*book.PublisherID)
+		if err != nil {
+			return nil, err
+		}
+		if publisher != nil {
+			name = publisher.Name
+		}
+	}
+	return entities.NewDublinCore(book, name), nil
+}
+
+// seriesPosition dereferences an optional series position, zero meaning none
+func seriesPosition(position *int) int {
+	if position == nil {
+		return 0
+	}
+	return *position
+}
+
+// validateSeries checks that the series of a book exists and that no other book holds its position
+func (uc *BookUseCase) validateSeries(bookID string, book *entities.Book) error {
+	if book.SeriesID == nil {
+		if book.SeriesPosition != nil {
+			return errors.New("series position requires a series")
+		}
+		return nil
+	}
+	if uc.seriesRepo == nil {
+		return errors.New("series are not enabled")
+	}
+
+	series, err := uc.seriesRepo.GetByID(*book.SeriesID)
+	if err != nil {
+		return err
+	}
+	if series == nil {
+		return domainerr.ErrSeriesNotFound
+	}
+
+	if book.SeriesPosition == nil {
+		return nil
+	}
+	if *book.SeriesPosition < 1 {
+		return errors.New("series position must be at least 1")
+	}
+
+	books, err := uc.bookRepo.FindBySeries(*book.SeriesID)
+	if err != nil {
+		return err
+	}
+	for _, other := range books {
+		if other.ID != bookID && other.SeriesPosition != nil && *other.SeriesPosition == *book.SeriesPosition {
+			return domainerr.ErrSeriesPositionTaken
+		}
+	}
+	return nil
+}
+
+// assignSlug derives the slug of a book from its title, unique across books including deleted
+// ones. A book keeps its current slug while its title still leads to it, so page URLs only
+// change with the title.
+func (uc *BookUseCase) assignSlug(book *entities.Book, current string) error {
+	base := book.SlugBase()
+	if current != "" && urlnorm.HasSlugBase(current, base) {
+		return nil
+	}
+
+	slug, err := urlnorm.UniqueSlug(base, func(slug string) (bool, error) {
+		existing, err := uc.bookRepo.FindBySlug(slug)
+		return existing != nil && existing.ID != book.ID, err
+	})
+	if err != nil {
+		return err
+	}
+	book.Slug = slug
+	return nil
+}
+
+// setInitialStatus makes a new book active unless it is created as a draft
+func setInitialStatus(book *entities.Book) error {
+	switch book.Status {
+	case "":
+		book.Status = entities.BookStatusActive
+	case entities.BookStatusDraft, entities.BookStatusActive:
+	default:
+		return errors.New("new books must be draft or active")
+	}
+	return nil
+}
+
+// validateBook validates book data, normalizing its ISBN first. Titles, years and ISBNs are
+// checked with the validation profile of the actor's tenant.
 func (uc *BookUseCase) validateBook(book *entities.Book) error {
+	book.ISBN = entities.NormalizeISBN(book.ISBN)
 	if book.Title == "" {
 		return errors.New("book title is required")
 	}
 	if book.Author == "" {
 		return errors.New("book author is required")
 	}
-	if book.Year < 1000 || book.Year > 2100 {
-		return errors.New("book year must be between 1000 and 2100")
-	}
-	if book.ISBN == "" {
-		return errors.New("book ISBN is required")
+	if err := uc.validationProfiles.For(uc.actor.TenantID).CheckBook(book); err != nil {
+		return err
 	}
-	if len(book.ISBN) < 10 || len(book.ISBN) > 13 {
-		return errors.New("book ISBN must be between 10 and 13 characters")
+
+	if err := validateMetadata(book.Metadata); err != nil {
+		return err
 	}
 
+	if uc.ruleRepo == nil {
+		return nil
+	}
+	rules, err := uc.ruleRepo.ListEnabled()
+	if err != nil {
+		return err
+	}
+	if violations := evaluateRules(rules, book); len(violations) > 0 {
+		return errors.New(violations[0].Message)
+	}
 	return nil
 }
@@ -1,12 +1,20 @@
 package usecase
 
 import (
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"library-management-system/internal/domain/domainerr"
 	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/events"
+	"library-management-system/internal/domain/repositories"
+	"library-management-system/internal/infrastructure/eventbus"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockBookRepository is a mock implementation of BookRepository
@@ -19,6 +27,19 @@ func (m *MockBookRepository) Create(book *entities.Book) error {
 	return args.Error(0)
 }
 
+func (m *MockBookRepository) CreateBatch(books []entities.Book, chunkSize int, onConflict repositories.BatchConflict) (int64, error) {
+	args := m.Called(books, chunkSize, onConflict)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockBookRepository) Upsert(books []entities.Book, versions []*time.Time) ([]bool, error) {
+	args := m.Called(books, versions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]bool), args.Error(1)
+}
+
 func (m *MockBookRepository) GetByID(id string) (*entities.Book, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
@@ -27,6 +48,14 @@ func (m *MockBookRepository) GetByID(id string) (*entities.Book, error) {
 	return args.Get(0).(*entities.Book), args.Error(1)
 }
 
+func (m *MockBookRepository) GetByIDInBranch(id, branchID string) (*entities.Book, error) {
+	args := m.Called(id, branchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Book), args.Error(1)
+}
+
 func (m *MockBookRepository) GetAll() ([]entities.Book, error) {
 	args := m.Called()
 	return args.Get(0).([]entities.Book), args.Error(1)
@@ -70,6 +99,39 @@ func (m *MockBookRepository) FindByISBN(isbn string) (*entities.Book, error) {
 	return args.Get(0).(*entities.Book), args.Error(1)
 }
 
+func (m *MockBookRepository) FindBySlug(slug string) (*entities.Book, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Book), args.Error(1)
+}
+
+func (m *MockBookRepository) FindByPublishers(publisherIDs []string) ([]entities.Book, error) {
+	args := m.Called(publisherIDs)
+	return args.Get(0).([]entities.Book), args.Error(1)
+}
+
+func (m *MockBookRepository) FindByMetadata(filters map[string]string) ([]entities.Book, error) {
+	args := m.Called(filters)
+	return args.Get(0).([]entities.Book), args.Error(1)
+}
+
+func (m *MockBookRepository) FindBySeries(seriesID string) ([]entities.Book, error) {
+	args := m.Called(seriesID)
+	return args.Get(0).([]entities.Book), args.Error(1)
+}
+
+func (m *MockBookRepository) FindByWork(workID string) ([]entities.Book, error) {
+	args := m.Called(workID)
+	return args.Get(0).([]entities.Book), args.Error(1)
+}
+
+func (m *MockBookRepository) SetWork(bookIDs []string, workID *string) error {
+	args := m.Called(bookIDs, workID)
+	return args.Error(0)
+}
+
 func (m *MockBookRepository) GetDeletedBooks() ([]entities.Book, error) {
 	args := m.Called()
 	return args.Get(0).([]entities.Book), args.Error(1)
@@ -80,6 +142,36 @@ func (m *MockBookRepository) Restore(id string) error {
 	return args.Error(0)
 }
 
+func (m *MockBookRepository) FindScheduled() ([]entities.Book, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.Book), args.Error(1)
+}
+
+func (m *MockBookRepository) SetCoverPalette(id string, palette []string) error {
+	args := m.Called(id, palette)
+	return args.Error(0)
+}
+
+func (m *MockBookRepository) MarkPublished(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockBookRepository) DeleteBatch(ids []string) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
+func (m *MockBookRepository) RestoreBatch(ids []string) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
+func (m *MockBookRepository) FindByIDsWithDeleted(ids []string) ([]entities.Book, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]entities.Book), args.Error(1)
+}
+
 func TestNewBookUseCase(t *testing.T) {
 	mockRepo := &MockBookRepository{}
 	useCase := NewBookUseCase(mockRepo)
@@ -105,6 +197,7 @@ func TestBookUseCase_CreateBook(t *testing.T) {
 			},
 			mockSetup: func(repo *MockBookRepository) {
 				repo.On("FindByISBN", "1234567890").Return((*entities.Book)(nil), nil)
+				repo.On("FindBySlug", "test-book").Return((*entities.Book)(nil), nil)
 				repo.On("Create", mock.AnythingOfType("*entities.Book")).Return(nil)
 			},
 			expectedError: "",
@@ -123,6 +216,35 @@ func TestBookUseCase_CreateBook(t *testing.T) {
 			},
 			expectedError: "book with this ISBN already exists",
 		},
+		{
+			name: "ISBN already exists written differently",
+			book: &entities.Book{
+				Title:  "Test Book",
+				Author: "Test Author",
+				Year:   2024,
+				ISBN:   "1-234-56789-x",
+			},
+			mockSetup: func(repo *MockBookRepository) {
+				existingBook := &entities.Book{ID: "existing-id", ISBN: "123456789X"}
+				repo.On("FindByISBN", "123456789X").Return(existingBook, nil)
+			},
+			expectedError: "book with this ISBN already exists",
+		},
+		{
+			name: "ISBN of a deleted book",
+			book: &entities.Book{
+				Title:  "Test Book",
+				Author: "Test Author",
+				Year:   2024,
+				ISBN:   "1234567890",
+			},
+			mockSetup: func(repo *MockBookRepository) {
+				deletedAt := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+				deletedBook := &entities.Book{ID: "deleted-id", ISBN: "1234567890", DeletedAt: &deletedAt}
+				repo.On("FindByISBN", "1234567890").Return(deletedBook, nil)
+			},
+			expectedError: "book with this ISBN was deleted",
+		},
 		{
 			name: "invalid book data",
 			book: &entities.Book{
@@ -271,6 +393,7 @@ func TestBookUseCase_UpdateBook(t *testing.T) {
 					ISBN:   "1234567890",
 				}
 				repo.On("GetByID", "test-id").Return(existingBook, nil)
+				repo.On("FindBySlug", "updated-book").Return((*entities.Book)(nil), nil)
 				repo.On("Update", mock.AnythingOfType("*entities.Book")).Return(nil)
 			},
 			expectedError: "",
@@ -328,6 +451,423 @@ func TestBookUseCase_UpdateBook(t *testing.T) {
 	}
 }
 
+func TestBookUseCase_AssignsSlugs(t *testing.T) {
+	t.Run("suffixes slugs already taken", func(t *testing.T) {
+		mockRepo := &MockBookRepository{}
+		mockRepo.On("FindByISBN", "1234567890").Return(nil, nil)
+		mockRepo.On("FindBySlug", "cafe-deja-vu").Return(&entities.Book{ID: "other"}, nil)
+		mockRepo.On("FindBySlug", "cafe-deja-vu-2").Return(nil, nil)
+		mockRepo.On("Create", mock.AnythingOfType("*entities.Book")).Return(nil)
+		useCase := NewBookUseCase(mockRepo)
+
+		book := &entities.Book{Title: "Café Déjà Vu", Author: "Author", Year: 2024, ISBN: "1234567890"}
+		assert.NoError(t, useCase.CreateBook(book))
+		assert.Equal(t, "cafe-deja-vu-2", book.Slug)
+	})
+
+	t.Run("keeps the slug while the title leads to it", func(t *testing.T) {
+		mockRepo := &MockBookRepository{}
+		mockRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1", Title: "Mort", Slug: "mort-2", ISBN: "1234567890"}, nil)
+		mockRepo.On("Update", mock.AnythingOfType("*entities.Book")).Return(nil)
+		useCase := NewBookUseCase(mockRepo)
+
+		book := &entities.Book{Title: "MORT!", Author: "Author", Year: 2024, ISBN: "1234567890"}
+		assert.NoError(t, useCase.UpdateBook("book-1", book))
+		mockRepo.AssertCalled(t, "Update", mock.MatchedBy(func(book *entities.Book) bool { return book.Slug == "mort-2" }))
+		mockRepo.AssertNotCalled(t, "FindBySlug", mock.Anything)
+	})
+
+	t.Run("changes the slug with the title", func(t *testing.T) {
+		mockRepo := &MockBookRepository{}
+		mockRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1", Title: "Mort", Slug: "mort", ISBN: "1234567890"}, nil)
+		mockRepo.On("FindBySlug", "reaper-man").Return(&entities.Book{ID: "book-1"}, nil)
+		mockRepo.On("Update", mock.AnythingOfType("*entities.Book")).Return(nil)
+		useCase := NewBookUseCase(mockRepo)
+
+		book := &entities.Book{Title: "Reaper Man", Author: "Author", Year: 2024, ISBN: "1234567890"}
+		assert.NoError(t, useCase.UpdateBook("book-1", book))
+		mockRepo.AssertCalled(t, "Update", mock.MatchedBy(func(book *entities.Book) bool { return book.Slug == "reaper-man" }))
+	})
+
+	t.Run("falls back for titles without Latin letters", func(t *testing.T) {
+		mockRepo := &MockBookRepository{}
+		mockRepo.On("FindByISBN", "1234567890").Return(nil, nil)
+		mockRepo.On("FindBySlug", "book").Return(nil, nil)
+		mockRepo.On("Create", mock.AnythingOfType("*entities.Book")).Return(nil)
+		useCase := NewBookUseCase(mockRepo)
+
+		book := &entities.Book{Title: "ノルウェイの森", Author: "Author", Year: 2024, ISBN: "1234567890"}
+		assert.NoError(t, useCase.CreateBook(book))
+		assert.Equal(t, "book", book.Slug)
+	})
+}
+
+func TestBookUseCase_ImportBooks(t *testing.T) {
+	t.Run("imports valid books in chunks and rejects the others", func(t *testing.T) {
+		mockRepo := &MockBookRepository{}
+		mockRepo.On("FindBySlug", "mort").Return(nil, nil)
+		mockRepo.On("FindBySlug", "mort-2").Return(nil, nil)
+		mockRepo.On("CreateBatch", mock.Anything, 2, repositories.BatchConflictSkip).Return(int64(1), nil)
+		useCase := NewBookUseCase(mockRepo)
+		useCase.SetImportChunkSize(2)
+
+		result, err := useCase.ImportBooks([]entities.Book{
+			{Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "9780062225719"},
+			{Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "9780552131063"},
+			{Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "9780062225719"},
+			{Title: "", Author: "Terry Pratchett", Year: 1987, ISBN: "9780552134637"},
+		}, "", false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &BookImportResult{
+			Received: 4,
+			Imported: 1,
+			Skipped:  1,
+			Rejected: []BookImportError{
+				{Index: 2, ISBN: "9780062225719", Error: "ISBN appears earlier in the batch"},
+				{Index: 3, ISBN: "9780552134637", Error: "book title is required"},
+			},
+		}, result)
+		mockRepo.AssertCalled(t, "CreateBatch", mock.MatchedBy(func(books []entities.Book) bool {
+			return len(books) == 2 && books[0].Slug == "mort" && books[1].Slug == "mort-2" && books[1].Status == entities.BookStatusActive
+		}), 2, repositories.BatchConflictSkip)
+	})
+
+	t.Run("rejects an unknown conflict policy", func(t *testing.T) {
+		useCase := NewBookUseCase(&MockBookRepository{})
+
+		_, err := useCase.ImportBooks([]entities.Book{{Title: "Mort"}}, "replace", false)
+		assert.EqualError(t, err, "on_conflict must be skip or upsert")
+	})
+
+	t.Run("does not write when every book is rejected", func(t *testing.T) {
+		mockRepo := &MockBookRepository{}
+		useCase := NewBookUseCase(mockRepo)
+
+		result, err := useCase.ImportBooks([]entities.Book{{Title: "Mort"}}, string(repositories.BatchConflictUpsert), false)
+		assert.NoError(t, err)
+		assert.Len(t, result.Rejected, 1)
+		mockRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("atomic imports write nothing when a book is rejected", func(t *testing.T) {
+		mockRepo := &MockBookRepository{}
+		mockRepo.On("FindBySlug", "mort").Return(nil, nil)
+		useCase := NewBookUseCase(mockRepo)
+
+		result, err := useCase.ImportBooks([]entities.Book{
+			{Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "9780062225719"},
+			{Title: "", Author: "Terry Pratchett", Year: 1987, ISBN: "9780552134637"},
+		}, "", true)
+
+		assert.NoError(t, err)
+		assert.True(t, result.Aborted)
+		assert.Zero(t, result.Imported)
+		assert.Len(t, result.Rejected, 1)
+		mockRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("upserts do not overwrite deleted books", func(t *testing.T) {
+		deletedAt := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+		mockRepo := &MockBookRepository{}
+		mockRepo.On("FindBySlug", "mort").Return(nil, nil)
+		mockRepo.On("FindByISBN", "9780062225719").Return(&entities.Book{ID: "book-1", ISBN: "9780062225719", DeletedAt: &deletedAt}, nil)
+		useCase := NewBookUseCase(mockRepo)
+
+		result, err := useCase.ImportBooks([]entities.Book{
+			{Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "9780062225719"},
+		}, string(repositories.BatchConflictUpsert), false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []BookImportError{{Index: 0, ISBN: "9780062225719", Error: "book with this ISBN was deleted"}}, result.Rejected)
+		mockRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("atomic imports are written in one chunk", func(t *testing.T) {
+		mockRepo := &MockBookRepository{}
+		mockRepo.On("FindBySlug", "mort").Return(nil, nil)
+		mockRepo.On("FindBySlug", "eric").Return(nil, nil)
+		mockRepo.On("CreateBatch", mock.Anything, 2, repositories.BatchConflictSkip).Return(int64(2), nil)
+		useCase := NewBookUseCase(mockRepo)
+		useCase.SetImportChunkSize(1)
+
+		result, err := useCase.ImportBooks([]entities.Book{
+			{Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "9780062225719"},
+			{Title: "Eric", Author: "Terry Pratchett", Year: 1990, ISBN: "9780062225726"},
+		}, "", true)
+
+		assert.NoError(t, err)
+		assert.False(t, result.Aborted)
+		assert.Equal(t, int64(2), result.Imported)
+		mockRepo.AssertNumberOfCalls(t, "CreateBatch", 1)
+	})
+}
+
+func TestBookUseCase_UpsertBooks(t *testing.T) {
+	mockRepo := &MockBookRepository{}
+	auditRepo := &MockAuditRepository{}
+	useCase := NewBookUseCase(mockRepo)
+	useCase.SetAuditRepository(auditRepo)
+	mockRepo.On("FindBySlug", "mort").Return(nil, nil)
+	mockRepo.On("FindBySlug", "eric").Return(nil, nil)
+	mockRepo.On("FindByISBN", "9780062225719").Return(nil, nil)
+	mockRepo.On("FindByISBN", "9780062225726").Return(&entities.Book{ID: "book-2", Title: "Eric!", Author: "Terry Pratchett", Year: 1990, ISBN: "9780062225726"}, nil)
+	mockRepo.On("Upsert", mock.MatchedBy(func(books []entities.Book) bool { return len(books) == 2 }), mock.Anything).
+		Run(func(args mock.Arguments) {
+			books := args.Get(0).([]entities.Book)
+			books[0].ID, books[1].ID = "book-1", "book-2"
+		}).
+		Return([]bool{true, false}, nil)
+	auditRepo.On("Create", mock.MatchedBy(func(entry *entities.AuditEntry) bool {
+		return entry.EntityID == "book-1" && entry.Action == entities.AuditActionCreated
+	})).Return(nil)
+	auditRepo.On("Create", mock.MatchedBy(func(entry *entities.AuditEntry) bool {
+		return entry.EntityID == "book-2" && entry.Action == entities.AuditActionUpdated &&
+			entry.Changes == `{"title":{"from":"Eric!","to":"Eric"}}`
+	})).Return(nil)
+
+	results, err := useCase.UpsertBooks([]BookUpsert{
+		{Book: entities.Book{Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "9780062225719"}},
+		{Book: entities.Book{Title: "Eric", Author: "Terry Pratchett", Year: 1990, ISBN: "9780062225726"}},
+		{Book: entities.Book{Title: "Eric", Author: "Terry Pratchett", Year: 1990, ISBN: "12"}},
+	}, "", false)
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, BookUpsertCreated, results[0].Result)
+	assert.Equal(t, "book-1", results[0].Book.ID)
+	assert.Equal(t, BookUpsertUpdated, results[1].Result)
+	assert.Equal(t, BookUpsertRejected, results[2].Result)
+	assert.Equal(t, "book ISBN must be between 10 and 13 characters", results[2].Error)
+	assert.Nil(t, results[2].Book)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestBookUseCase_UpsertBooksOfDeletedBooks(t *testing.T) {
+	deletedAt := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	mockRepo := &MockBookRepository{}
+	useCase := NewBookUseCase(mockRepo)
+	mockRepo.On("FindBySlug", "mort").Return(nil, nil)
+	mockRepo.On("FindByISBN", "9780062225719").Return(&entities.Book{ID: "book-1", Title: "Mort", ISBN: "9780062225719", DeletedAt: &deletedAt}, nil)
+
+	results, err := useCase.UpsertBooks([]BookUpsert{
+		{Book: entities.Book{Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "978-0-06-222571-9"}},
+	}, "", false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, BookUpsertRejected, results[0].Result)
+	assert.Equal(t, "book with this ISBN was deleted", results[0].Error)
+	mockRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+}
+
+func TestBookUseCase_UpsertBooksConflicts(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	server := entities.Book{ID: "book-1", Title: "Mort (Discworld)", Author: "Terry Pratchett", Year: 1987, ISBN: "9780062225719", UpdatedAt: base.Add(time.Hour)}
+	upsert := func() []BookUpsert {
+		return []BookUpsert{{
+			Book:          entities.Book{Title: "Mort", Author: "Terry Pratchett", Year: 1988, ISBN: "9780062225719"},
+			BaseUpdatedAt: &base,
+			ChangedFields: []string{"year"},
+		}}
+	}
+	newUseCase := func() (*BookUseCase, *MockBookRepository) {
+		mockRepo := &MockBookRepository{}
+		mockRepo.On("FindBySlug", "mort").Return(nil, nil)
+		current := server
+		mockRepo.On("FindByISBN", "9780062225719").Return(&current, nil)
+		mockRepo.On("Upsert", mock.Anything, mock.Anything).Return([]bool{false}, nil)
+		return NewBookUseCase(mockRepo), mockRepo
+	}
+
+	t.Run("server wins keeps the book as it is", func(t *testing.T) {
+		useCase, mockRepo := newUseCase()
+
+		results, err := useCase.UpsertBooks(upsert(), ConflictServerWins, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, BookUpsertConflict, results[0].Result)
+		assert.Equal(t, "Mort (Discworld)", results[0].Book.Title)
+		assert.Equal(t, []FieldConflict{
+			{Field: "title", Server: "Mort (Discworld)", Client: "Mort", Kept: "server"},
+			{Field: "year", Server: 1987, Client: 1988, Kept: "server"},
+		}, results[0].Conflicts)
+		mockRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+	})
+
+	t.Run("client wins overwrites the book", func(t *testing.T) {
+		useCase, mockRepo := newUseCase()
+
+		results, err := useCase.UpsertBooks(upsert(), "", false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, BookUpsertUpdated, results[0].Result)
+		assert.Len(t, results[0].Conflicts, 2)
+		mockRepo.AssertCalled(t, "Upsert", mock.MatchedBy(func(books []entities.Book) bool {
+			return books[0].Title == "Mort" && books[0].Year == 1988
+		}), mock.Anything)
+	})
+
+	t.Run("merge takes only the fields the client changed", func(t *testing.T) {
+		useCase, mockRepo := newUseCase()
+
+		results, err := useCase.UpsertBooks(upsert(), ConflictMerge, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, BookUpsertUpdated, results[0].Result)
+		assert.Equal(t, []FieldConflict{
+			{Field: "title", Server: "Mort (Discworld)", Client: "Mort", Kept: "server"},
+			{Field: "year", Server: 1987, Client: 1988, Kept: "client"},
+		}, results[0].Conflicts)
+		mockRepo.AssertCalled(t, "Upsert", mock.MatchedBy(func(books []entities.Book) bool {
+			return books[0].Title == "Mort (Discworld)" && books[0].Year == 1988
+		}), mock.Anything)
+	})
+
+	t.Run("books unchanged since the base version have no conflicts", func(t *testing.T) {
+		useCase, _ := newUseCase()
+		upserts := upsert()
+		later := base.Add(2 * time.Hour)
+		upserts[0].BaseUpdatedAt = &later
+
+		results, err := useCase.UpsertBooks(upserts, ConflictServerWins, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, BookUpsertUpdated, results[0].Result)
+		assert.Empty(t, results[0].Conflicts)
+	})
+
+	t.Run("atomic upserts write nothing on a conflict", func(t *testing.T) {
+		useCase, mockRepo := newUseCase()
+		upserts := append(upsert(), BookUpsert{Book: entities.Book{Title: "Eric", Author: "Terry Pratchett", Year: 1990, ISBN: "12"}})
+
+		results, err := useCase.UpsertBooks(upserts, ConflictServerWins, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, BookUpsertConflict, results[0].Result)
+		assert.Equal(t, BookUpsertRejected, results[1].Result)
+		mockRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+	})
+
+	t.Run("atomic upserts write nothing when a book is rejected", func(t *testing.T) {
+		useCase, mockRepo := newUseCase()
+		upserts := append(upsert(), BookUpsert{Book: entities.Book{Title: "Eric", Author: "Terry Pratchett", Year: 1990, ISBN: "12"}})
+
+		results, err := useCase.UpsertBooks(upserts, "", true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, BookUpsertAborted, results[0].Result)
+		assert.Equal(t, BookUpsertRejected, results[1].Result)
+		mockRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+	})
+}
+
+func TestBookUseCase_UpsertBooksChangedBeforeTheWrite(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	checked := entities.Book{ID: "book-1", Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "9780062225719", UpdatedAt: base.Add(time.Hour)}
+	changed := checked
+	changed.Title, changed.UpdatedAt = "Mort (Discworld)", base.Add(2*time.Hour)
+	upserts := func() []BookUpsert {
+		return []BookUpsert{
+			{Book: entities.Book{Title: "Mort", Author: "Terry Pratchett", Year: 1988, ISBN: "9780062225719"}, BaseUpdatedAt: &base, ChangedFields: []string{"year"}},
+			{Book: entities.Book{Title: "Eric", Author: "Terry Pratchett", Year: 1990, ISBN: "9780062225726"}},
+		}
+	}
+	newUseCase := func() (*BookUseCase, *MockBookRepository) {
+		mockRepo := &MockBookRepository{}
+		mockRepo.On("FindBySlug", "mort").Return(nil, nil)
+		mockRepo.On("FindBySlug", "eric").Return(nil, nil)
+		mockRepo.On("FindByISBN", "9780062225719").Return(&checked, nil).Once()
+		mockRepo.On("FindByISBN", "9780062225719").Return(&changed, nil)
+		mockRepo.On("FindByISBN", "9780062225726").Return(nil, nil)
+		// The first book changes between the check and the write
+		mockRepo.On("Upsert", mock.MatchedBy(func(books []entities.Book) bool { return len(books) == 2 }), mock.Anything).
+			Return(nil, &repositories.StaleUpsertError{Stale: []int{0}})
+		mockRepo.On("Upsert", mock.MatchedBy(func(books []entities.Book) bool { return len(books) == 1 }), mock.Anything).
+			Run(func(args mock.Arguments) { args.Get(0).([]entities.Book)[0].ID = "book-2" }).
+			Return([]bool{true}, nil)
+		return NewBookUseCase(mockRepo), mockRepo
+	}
+
+	t.Run("books are written only at the version they were checked at", func(t *testing.T) {
+		useCase, mockRepo := newUseCase()
+
+		_, err := useCase.UpsertBooks(upserts(), "", false)
+
+		require.NoError(t, err)
+		mockRepo.AssertCalled(t, "Upsert", mock.Anything, []*time.Time{&checked.UpdatedAt, nil})
+	})
+
+	t.Run("the changed book is a conflict and the others are written", func(t *testing.T) {
+		useCase, mockRepo := newUseCase()
+
+		results, err := useCase.UpsertBooks(upserts(), "", false)
+
+		require.NoError(t, err)
+		assert.Equal(t, BookUpsertConflict, results[0].Result)
+		assert.Equal(t, "Mort (Discworld)", results[0].Book.Title)
+		assert.Equal(t, []FieldConflict{
+			{Field: "title", Server: "Mort (Discworld)", Client: "Mort", Kept: "server"},
+			{Field: "year", Server: 1987, Client: 1988, Kept: "server"},
+		}, results[0].Conflicts)
+		assert.Equal(t, BookUpsertCreated, results[1].Result)
+		assert.Equal(t, "book-2", results[1].Book.ID)
+		mockRepo.AssertNumberOfCalls(t, "Upsert", 2)
+	})
+
+	t.Run("atomic upserts write nothing", func(t *testing.T) {
+		useCase, mockRepo := newUseCase()
+
+		results, err := useCase.UpsertBooks(upserts(), "", true)
+
+		require.NoError(t, err)
+		assert.Equal(t, BookUpsertConflict, results[0].Result)
+		assert.Equal(t, BookUpsertAborted, results[1].Result)
+		mockRepo.AssertNumberOfCalls(t, "Upsert", 1)
+	})
+}
+
+func TestBookUseCase_GetBookBySlug(t *testing.T) {
+	deletedAt := time.Now()
+	mockRepo := &MockBookRepository{}
+	mockRepo.On("FindBySlug", "mort").Return(&entities.Book{ID: "book-1", Slug: "mort"}, nil)
+	mockRepo.On("FindBySlug", "reaper-man").Return(&entities.Book{ID: "book-2", Slug: "reaper-man", DeletedAt: &deletedAt}, nil)
+	mockRepo.On("FindBySlug", "missing").Return(nil, nil)
+	useCase := NewBookUseCase(mockRepo)
+
+	book, err := useCase.GetBookBySlug("mort")
+	assert.NoError(t, err)
+	assert.Equal(t, "book-1", book.ID)
+
+	for _, slug := range []string{"reaper-man", "missing"} {
+		book, err = useCase.GetBookBySlug(slug)
+		assert.NoError(t, err)
+		assert.Nil(t, book, slug)
+	}
+
+	_, err = useCase.GetBookBySlug("")
+	assert.EqualError(t, err, "slug is required")
+}
+
+func TestBookUseCase_PublishesAvailabilityChanges(t *testing.T) {
+	mockRepo := &MockBookRepository{}
+	mockRepo.On("GetByID", "test-id").Return(&entities.Book{ID: "test-id"}, nil)
+	mockRepo.On("Delete", "test-id").Return(nil)
+	mockRepo.On("Restore", "test-id").Return(nil)
+	useCase := NewBookUseCase(mockRepo)
+	bus := eventbus.NewInMemoryBus()
+	useCase.SetEventBus(bus)
+
+	var published []string
+	bus.Subscribe(events.BookAvailabilityChanged, func(event events.Event) error {
+		published = append(published, event.BookID+"="+event.Payload["available"])
+		return nil
+	})
+
+	assert.NoError(t, useCase.DeleteBook("test-id"))
+	assert.NoError(t, useCase.RestoreBook("test-id"))
+	assert.Equal(t, []string{"test-id=false", "test-id=true"}, published)
+}
+
 func TestBookUseCase_DeleteBook(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -392,6 +932,175 @@ func TestBookUseCase_DeleteBook(t *testing.T) {
 	}
 }
 
+func TestBookUseCase_DeleteBookOnLoan(t *testing.T) {
+	bookRepo := &MockBookRepository{}
+	bookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1"}, nil)
+	loanRepo := &MockLoanRepository{}
+	loanRepo.On("ListActiveByBook", "book-1").Return([]entities.Loan{{ID: "loan-1", BookID: "book-1"}}, nil)
+	useCase := NewBookUseCase(bookRepo)
+	useCase.SetLoanRepository(loanRepo)
+
+	// Books out on loan are kept, like in batches
+	assert.ErrorIs(t, useCase.DeleteBook("book-1"), domainerr.ErrBookOnLoan)
+	assert.ErrorIs(t, useCase.HardDeleteBook("book-1"), domainerr.ErrBookOnLoan)
+	bookRepo.AssertNotCalled(t, "Delete", "book-1")
+	bookRepo.AssertNotCalled(t, "HardDelete", "book-1")
+}
+
+func TestBookUseCase_PurgeDeletedBooks(t *testing.T) {
+	cutoff := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	old := cutoff.Add(-48 * time.Hour)
+	recent := cutoff.Add(time.Hour)
+
+	t.Run("purges books deleted before the cutoff", func(t *testing.T) {
+		mockRepo := &MockBookRepository{}
+		useCase := NewBookUseCase(mockRepo)
+		mockRepo.On("GetDeletedBooks").Return([]entities.Book{
+			{ID: "old", DeletedAt: &old},
+			{ID: "recent", DeletedAt: &recent},
+		}, nil)
+		mockRepo.On("HardDelete", "old").Return(nil)
+
+		purged, err := useCase.PurgeDeletedBooks(cutoff)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, purged)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "HardDelete", "recent")
+	})
+
+	t.Run("stops at the first failure", func(t *testing.T) {
+		mockRepo := &MockBookRepository{}
+		useCase := NewBookUseCase(mockRepo)
+		mockRepo.On("GetDeletedBooks").Return([]entities.Book{
+			{ID: "first", DeletedAt: &old},
+			{ID: "second", DeletedAt: &old},
+		}, nil)
+		mockRepo.On("HardDelete", "first").Return(errors.New("database error"))
+
+		purged, err := useCase.PurgeDeletedBooks(cutoff)
+
+		assert.EqualError(t, err, "database error")
+		assert.Equal(t, 0, purged)
+		mockRepo.AssertNotCalled(t, "HardDelete", "second")
+	})
+}
+
+func TestBookUseCase_ScheduledPublication(t *testing.T) {
+	now := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	due := now.Add(-time.Minute)
+	later := now.Add(time.Hour)
+
+	t.Run("hides scheduled books from listings", func(t *testing.T) {
+		mockRepo := &MockBookRepository{}
+		useCase := NewBookUseCase(mockRepo)
+		mockRepo.On("GetAll").Return([]entities.Book{{ID: "live"}, {ID: "scheduled", PublishAt: &later}}, nil)
+		mockRepo.On("FindBySlug", "scheduled").Return(&entities.Book{ID: "scheduled", PublishAt: &later}, nil)
+
+		books, err := useCase.GetAllBooks()
+		assert.NoError(t, err)
+		assert.Equal(t, []entities.Book{{ID: "live"}}, books)
+
+		book, err := useCase.GetBookBySlug("scheduled")
+		assert.NoError(t, err)
+		assert.Nil(t, book)
+	})
+
+	t.Run("publishes the books that are due", func(t *testing.T) {
+		mockRepo := &MockBookRepository{}
+		useCase := NewBookUseCase(mockRepo)
+		mockRepo.On("FindScheduled").Return([]entities.Book{
+			{ID: "due", PublishAt: &due},
+			{ID: "later", PublishAt: &later},
+		}, nil)
+		mockRepo.On("MarkPublished", "due").Return(nil)
+
+		published, err := useCase.PublishScheduledBooks(now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, published)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "MarkPublished", "later")
+	})
+
+	t.Run("publishes books scheduled in the past right away", func(t *testing.T) {
+		mockRepo := &MockBookRepository{}
+		useCase := NewBookUseCase(mockRepo)
+		mockRepo.On("FindByISBN", "1234567890").Return(nil, nil)
+		mockRepo.On("FindBySlug", "jazz").Return(nil, nil)
+		mockRepo.On("Create", mock.Anything).Return(nil)
+		book := &entities.Book{Title: "Jazz", Author: "Toni Morrison", Year: 1992, ISBN: "1234567890", PublishAt: &due}
+
+		assert.NoError(t, useCase.CreateBook(book))
+		assert.Nil(t, book.PublishAt)
+	})
+}
+
+func TestBookUseCase_ChangeBookStatus(t *testing.T) {
+	t.Run("archives an active book and records the change", func(t *testing.T) {
+		mockRepo := &MockBookRepository{}
+		auditRepo := &MockAuditRepository{}
+		useCase := NewBookUseCase(mockRepo)
+		useCase.SetAuditRepository(auditRepo)
+		mockRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1", Status: entities.BookStatusActive}, nil)
+		mockRepo.On("Update", mock.MatchedBy(func(book *entities.Book) bool {
+			return book.Status == entities.BookStatusArchived
+		})).Return(nil)
+		auditRepo.On("Create", mock.MatchedBy(func(entry *entities.AuditEntry) bool {
+			return entry.Action == entities.AuditActionStatusChanged &&
+				entry.Changes == `{"status":{"from":"active","to":"archived"}}`
+		})).Return(nil)
+
+		book, err := useCase.ChangeBookStatus("book-1", entities.BookStatusArchived)
+
+		assert.NoError(t, err)
+		assert.Equal(t, entities.BookStatusArchived, book.Status)
+		mockRepo.AssertExpectations(t)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("refuses transitions that are not allowed", func(t *testing.T) {
+		mockRepo := &MockBookRepository{}
+		useCase := NewBookUseCase(mockRepo)
+		mockRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1", Status: entities.BookStatusDraft}, nil)
+
+		_, err := useCase.ChangeBookStatus("book-1", entities.BookStatusArchived)
+
+		assert.EqualError(t, err, "cannot move book from draft to archived")
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("refuses unknown statuses", func(t *testing.T) {
+		useCase := NewBookUseCase(&MockBookRepository{})
+
+		_, err := useCase.ChangeBookStatus("book-1", "lost")
+
+		assert.EqualError(t, err, `unknown book status "lost"`)
+	})
+
+	t.Run("archived books cannot be edited", func(t *testing.T) {
+		mockRepo := &MockBookRepository{}
+		useCase := NewBookUseCase(mockRepo)
+		mockRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1", Status: entities.BookStatusArchived}, nil)
+
+		err := useCase.UpdateBook("book-1", &entities.Book{Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "1234567890"})
+
+		assert.ErrorIs(t, err, domainerr.ErrBookArchived)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("drafts are left out of listings", func(t *testing.T) {
+		books := []entities.Book{
+			{ID: "draft", Status: entities.BookStatusDraft},
+			{ID: "active", Status: entities.BookStatusActive},
+			{ID: "archived", Status: entities.BookStatusArchived},
+		}
+
+		assert.Equal(t, books[1:], FilterByStatus(books, ""))
+		assert.Equal(t, books[:1], FilterByStatus(books, entities.BookStatusDraft))
+	})
+}
+
 func TestBookUseCase_SearchBooksByTitle(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -452,6 +1161,26 @@ func TestBookUseCase_SearchBooksByTitle(t *testing.T) {
 	}
 }
 
+func TestIsExpensiveSearchTerm(t *testing.T) {
+	tests := []struct {
+		term      string
+		expensive bool
+	}{
+		{term: "", expensive: false},
+		{term: "Gatsby", expensive: false},
+		{term: "Great%", expensive: false},
+		{term: "%Gatsby", expensive: true},
+		{term: "_atsby", expensive: true},
+		{term: "G%a%t", expensive: true},
+		{term: strings.Repeat("a", 64), expensive: false},
+		{term: strings.Repeat("a", 65), expensive: true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expensive, IsExpensiveSearchTerm(tt.term), tt.term)
+	}
+}
+
 func TestBookUseCase_validateBook(t *testing.T) {
 	useCase := &BookUseCase{}
 
@@ -552,3 +1281,435 @@ func TestBookUseCase_validateBook(t *testing.T) {
 		})
 	}
 }
+
+func TestBookUseCase_validateBookWithTenantProfile(t *testing.T) {
+	useCase := &BookUseCase{}
+	useCase.SetValidationProfiles(entities.ValidationProfiles{
+		Default: entities.DefaultValidationProfile,
+		Tenants: map[string]entities.ValidationProfile{
+			"archive": {MinYear: 1450, MaxYear: 2100, ISBNCheck: entities.ISBNCheckChecksum, MinTitleLength: 1},
+		},
+	})
+	book := func() *entities.Book {
+		return &entities.Book{Title: "Test Book", Author: "Test Author", Year: 1200, ISBN: "978-0-306-40615-8"}
+	}
+
+	assert.NoError(t, useCase.validateBook(book()))
+	assert.EqualError(t, useCase.As(entities.Actor{TenantID: "archive"}).validateBook(book()), "book year must be between 1450 and 2100")
+
+	modern := book()
+	modern.Year = 1990
+	assert.EqualError(t, useCase.As(entities.Actor{TenantID: "archive"}).validateBook(modern), "book ISBN must be an ISBN-10 or ISBN-13 with a valid check digit")
+	assert.NoError(t, useCase.As(entities.Actor{TenantID: "other"}).validateBook(modern))
+}
+
+// MockBookDraftRepository is a mock implementation of BookDraftRepository
+type MockBookDraftRepository struct {
+	mock.Mock
+}
+
+func (m *MockBookDraftRepository) Save(draft *entities.BookDraft) error {
+	args := m.Called(draft)
+	return args.Error(0)
+}
+
+func (m *MockBookDraftRepository) GetByBookID(bookID string) (*entities.BookDraft, error) {
+	args := m.Called(bookID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.BookDraft), args.Error(1)
+}
+
+func (m *MockBookDraftRepository) Delete(bookID string) error {
+	args := m.Called(bookID)
+	return args.Error(0)
+}
+
+func (m *MockBookDraftRepository) Publish(book *entities.Book) error {
+	args := m.Called(book)
+	return args.Error(0)
+}
+
+func TestBookUseCase_Drafts(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	live := func() *entities.Book {
+		return &entities.Book{ID: "book-1", Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "9780062225719", Slug: "mort", UpdatedAt: updatedAt}
+	}
+	proposed := &entities.Book{Title: "Mort", Author: "Terry Pratchett", Year: 1988, ISBN: "9780062225719"}
+
+	t.Run("saving leaves the live book untouched", func(t *testing.T) {
+		bookRepo := &MockBookRepository{}
+		draftRepo := &MockBookDraftRepository{}
+		bookRepo.On("GetByID", "book-1").Return(live(), nil)
+		draftRepo.On("Save", mock.AnythingOfType("*entities.BookDraft")).Return(nil)
+		useCase := NewBookUseCase(bookRepo)
+		useCase.SetDraftRepository(draftRepo)
+
+		draft, err := useCase.SaveDraft("book-1", proposed)
+		assert.NoError(t, err)
+		assert.Equal(t, updatedAt, draft.BaseUpdatedAt)
+		assert.Equal(t, map[string]interface{}{"year": map[string]interface{}{"from": 1987, "to": 1988}}, draft.Changes)
+		bookRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("publishing applies the draft", func(t *testing.T) {
+		bookRepo := &MockBookRepository{}
+		draftRepo := &MockBookDraftRepository{}
+		bookRepo.On("GetByID", "book-1").Return(live(), nil)
+		draftRepo.On("GetByBookID", "book-1").Return(&entities.BookDraft{BookID: "book-1", Title: "Mort", Author: "Terry Pratchett", Year: 1988, ISBN: "9780062225719", BaseUpdatedAt: updatedAt}, nil)
+		draftRepo.On("Publish", mock.MatchedBy(func(book *entities.Book) bool { return book.Year == 1988 && book.Slug == "mort" })).Return(nil)
+		useCase := NewBookUseCase(bookRepo)
+		useCase.SetDraftRepository(draftRepo)
+
+		book, err := useCase.PublishDraft("book-1")
+		assert.NoError(t, err)
+		assert.Equal(t, 1988, book.Year)
+		draftRepo.AssertExpectations(t)
+	})
+
+	t.Run("publishing refuses drafts of books updated since", func(t *testing.T) {
+		bookRepo := &MockBookRepository{}
+		draftRepo := &MockBookDraftRepository{}
+		bookRepo.On("GetByID", "book-1").Return(live(), nil)
+		draftRepo.On("GetByBookID", "book-1").Return(&entities.BookDraft{BookID: "book-1", Title: "Mort", Author: "Terry Pratchett", Year: 1988, ISBN: "9780062225719", BaseUpdatedAt: updatedAt.Add(-time.Hour)}, nil)
+		useCase := NewBookUseCase(bookRepo)
+		useCase.SetDraftRepository(draftRepo)
+
+		_, err := useCase.PublishDraft("book-1")
+		assert.ErrorIs(t, err, domainerr.ErrStaleBookDraft)
+		draftRepo.AssertNotCalled(t, "Publish", mock.Anything)
+	})
+
+	t.Run("missing drafts", func(t *testing.T) {
+		bookRepo := &MockBookRepository{}
+		draftRepo := &MockBookDraftRepository{}
+		bookRepo.On("GetByID", "book-1").Return(live(), nil)
+		draftRepo.On("GetByBookID", "book-1").Return(nil, nil)
+		useCase := NewBookUseCase(bookRepo)
+		useCase.SetDraftRepository(draftRepo)
+
+		_, err := useCase.GetDraft("book-1")
+		assert.ErrorIs(t, err, domainerr.ErrBookDraftNotFound)
+		assert.ErrorIs(t, useCase.DiscardDraft("book-1"), domainerr.ErrBookDraftNotFound)
+		draftRepo.AssertNotCalled(t, "Delete", mock.Anything)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		_, err := NewBookUseCase(&MockBookRepository{}).SaveDraft("book-1", proposed)
+		assert.EqualError(t, err, "drafts are not enabled")
+	})
+}
+
+// MockBookIdentifierRepository is a mock implementation of BookIdentifierRepository
+type MockBookIdentifierRepository struct {
+	mock.Mock
+}
+
+func (m *MockBookIdentifierRepository) Create(identifier *entities.BookIdentifier) error {
+	args := m.Called(identifier)
+	return args.Error(0)
+}
+
+func (m *MockBookIdentifierRepository) ListByBook(bookID string) ([]entities.BookIdentifier, error) {
+	args := m.Called(bookID)
+	return args.Get(0).([]entities.BookIdentifier), args.Error(1)
+}
+
+func (m *MockBookIdentifierRepository) FindByValue(kind, value string) (*entities.BookIdentifier, error) {
+	args := m.Called(kind, value)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.BookIdentifier), args.Error(1)
+}
+
+func (m *MockBookIdentifierRepository) Delete(bookID, id string) (bool, error) {
+	args := m.Called(bookID, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBookIdentifierRepository) DeleteByBook(bookID string) error {
+	args := m.Called(bookID)
+	return args.Error(0)
+}
+
+func TestBookUseCase_Identifiers(t *testing.T) {
+	deletedAt := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	book := &entities.Book{ID: "book-1", Title: "Nature", Author: "Various", Year: 1869, ISBN: "9780062225719"}
+	doi := &entities.BookIdentifier{ID: "identifier-1", BookID: "book-1", Type: entities.IdentifierDOI, Value: "10.1038/nature"}
+
+	t.Run("adding stores the normalized value", func(t *testing.T) {
+		bookRepo := &MockBookRepository{}
+		identifierRepo := &MockBookIdentifierRepository{}
+		bookRepo.On("GetByID", "book-1").Return(book, nil)
+		identifierRepo.On("FindByValue", entities.IdentifierISSN, "0028-0836").Return(nil, nil)
+		identifierRepo.On("Create", mock.MatchedBy(func(i *entities.BookIdentifier) bool { return i.BookID == "book-1" && i.Value == "0028-0836" })).Return(nil)
+		useCase := NewBookUseCase(bookRepo)
+		useCase.SetIdentifierRepository(identifierRepo)
+
+		err := useCase.AddIdentifier("book-1", &entities.BookIdentifier{Type: entities.IdentifierISSN, Value: "00280836"})
+		assert.NoError(t, err)
+		identifierRepo.AssertExpectations(t)
+	})
+
+	t.Run("adding refuses values other books have", func(t *testing.T) {
+		bookRepo := &MockBookRepository{}
+		identifierRepo := &MockBookIdentifierRepository{}
+		bookRepo.On("GetByID", "book-2").Return(&entities.Book{ID: "book-2"}, nil)
+		identifierRepo.On("FindByValue", entities.IdentifierDOI, "10.1038/nature").Return(doi, nil)
+		useCase := NewBookUseCase(bookRepo)
+		useCase.SetIdentifierRepository(identifierRepo)
+
+		err := useCase.AddIdentifier("book-2", &entities.BookIdentifier{Type: entities.IdentifierDOI, Value: "https://doi.org/10.1038/Nature"})
+		assert.ErrorIs(t, err, domainerr.ErrDuplicateIdentifier)
+		identifierRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("adding refuses unknown types", func(t *testing.T) {
+		useCase := NewBookUseCase(&MockBookRepository{})
+		useCase.SetIdentifierRepository(&MockBookIdentifierRepository{})
+
+		err := useCase.AddIdentifier("book-1", &entities.BookIdentifier{Type: entities.IdentifierISBN, Value: "9780062225719"})
+		assert.EqualError(t, err, "identifier type must be issn, asin, doi or internal")
+	})
+
+	t.Run("lookups skip deleted books", func(t *testing.T) {
+		bookRepo := &MockBookRepository{}
+		identifierRepo := &MockBookIdentifierRepository{}
+		bookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1", DeletedAt: &deletedAt}, nil)
+		identifierRepo.On("FindByValue", entities.IdentifierDOI, "10.1038/nature").Return(doi, nil)
+		useCase := NewBookUseCase(bookRepo)
+		useCase.SetIdentifierRepository(identifierRepo)
+
+		found, err := useCase.FindBookByIdentifier(entities.IdentifierDOI, "doi:10.1038/nature")
+		assert.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("lookups by ISBN", func(t *testing.T) {
+		bookRepo := &MockBookRepository{}
+		bookRepo.On("FindByISBN", "9780062225719").Return(book, nil)
+
+		found, err := NewBookUseCase(bookRepo).FindBookByIdentifier(entities.IdentifierISBN, "978-0-06-222571-9")
+		assert.NoError(t, err)
+		assert.Equal(t, "book-1", found.ID)
+	})
+
+	t.Run("searching reads the value as every type", func(t *testing.T) {
+		bookRepo := &MockBookRepository{}
+		identifierRepo := &MockBookIdentifierRepository{}
+		bookRepo.On("FindByISBN", "B000FC1PJI").Return(nil, nil)
+		bookRepo.On("GetByID", "book-1").Return(book, nil)
+		identifierRepo.On("FindByValue", entities.IdentifierASIN, "B000FC1PJI").Return(&entities.BookIdentifier{BookID: "book-1"}, nil)
+		identifierRepo.On("FindByValue", entities.IdentifierInternal, "b000fc1pji").Return(&entities.BookIdentifier{BookID: "book-1"}, nil)
+		useCase := NewBookUseCase(bookRepo)
+		useCase.SetIdentifierRepository(identifierRepo)
+
+		books, err := useCase.SearchBooksByIdentifier("b000fc1pji")
+		assert.NoError(t, err)
+		assert.Len(t, books, 1, "the book is found once")
+	})
+
+	t.Run("removing an identifier of another book", func(t *testing.T) {
+		bookRepo := &MockBookRepository{}
+		identifierRepo := &MockBookIdentifierRepository{}
+		bookRepo.On("GetByID", "book-1").Return(book, nil)
+		identifierRepo.On("ListByBook", "book-1").Return([]entities.BookIdentifier{*doi}, nil)
+		useCase := NewBookUseCase(bookRepo)
+		useCase.SetIdentifierRepository(identifierRepo)
+
+		assert.ErrorIs(t, useCase.RemoveIdentifier("book-1", "identifier-2"), domainerr.ErrIdentifierNotFound)
+		identifierRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		_, err := NewBookUseCase(&MockBookRepository{}).ListIdentifiers("book-1")
+		assert.EqualError(t, err, "identifiers are not enabled")
+	})
+}
+
+// MockBookCopyRepository is a mock implementation of BookCopyRepository
+type MockBookCopyRepository struct {
+	mock.Mock
+}
+
+func (m *MockBookCopyRepository) NextAccessionNumber(prefix string) (int64, error) {
+	args := m.Called(prefix)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockBookCopyRepository) Create(bookCopy *entities.BookCopy) error {
+	args := m.Called(bookCopy)
+	return args.Error(0)
+}
+
+func (m *MockBookCopyRepository) ListByBook(bookID string) ([]entities.BookCopy, error) {
+	args := m.Called(bookID)
+	return args.Get(0).([]entities.BookCopy), args.Error(1)
+}
+
+func (m *MockBookCopyRepository) FindByAccessionNumber(number string) (*entities.BookCopy, error) {
+	args := m.Called(number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.BookCopy), args.Error(1)
+}
+
+func TestBookUseCase_Copies(t *testing.T) {
+	harbor := "branch-harbor"
+	downtown := "branch-downtown"
+	librarian := entities.Actor{UserID: "user-1", Role: entities.UserRoleLibrarian, BranchID: downtown}
+
+	newUseCase := func() (*BookUseCase, *MockBookCopyRepository) {
+		bookRepo := &MockBookRepository{}
+		branchRepo := &MockBranchRepository{}
+		copyRepo := &MockBookCopyRepository{}
+		bookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1", BranchID: &harbor}, nil)
+		bookRepo.On("GetByID", "book-2").Return(&entities.Book{ID: "book-2"}, nil)
+		branchRepo.On("GetByID", harbor).Return(&entities.Branch{ID: harbor, AccessionPrefix: "HB-"}, nil)
+		branchRepo.On("GetByID", downtown).Return(&entities.Branch{ID: downtown}, nil)
+		copyRepo.On("Create", mock.AnythingOfType("*entities.BookCopy")).Return(nil)
+		useCase := NewBookUseCase(bookRepo)
+		useCase.SetBranchRepository(branchRepo)
+		useCase.SetCopyRepository(copyRepo)
+		return useCase, copyRepo
+	}
+
+	t.Run("copies are numbered in the sequence of their branch", func(t *testing.T) {
+		useCase, copyRepo := newUseCase()
+		copyRepo.On("NextAccessionNumber", "HB-").Return(int64(42), nil)
+
+		bookCopy := &entities.BookCopy{}
+		require.NoError(t, useCase.AddCopy("book-1", bookCopy))
+		assert.Equal(t, "HB-000042", bookCopy.AccessionNumber)
+		assert.Equal(t, &harbor, bookCopy.BranchID, "the copy goes to the branch of the book")
+	})
+
+	t.Run("branches without a prefix share the library's", func(t *testing.T) {
+		useCase, copyRepo := newUseCase()
+		useCase.SetAccessionFormat("LIB", 8)
+		copyRepo.On("NextAccessionNumber", "LIB").Return(int64(7), nil)
+
+		bookCopy := &entities.BookCopy{}
+		require.NoError(t, useCase.As(librarian).AddCopy("book-2", bookCopy))
+		assert.Equal(t, "LIB00000007", bookCopy.AccessionNumber)
+		assert.Equal(t, &downtown, bookCopy.BranchID, "the copy goes to the branch of the librarian")
+	})
+
+	t.Run("librarians only add copies to their branch", func(t *testing.T) {
+		useCase, copyRepo := newUseCase()
+
+		err := useCase.As(librarian).AddCopy("book-2", &entities.BookCopy{BranchID: &harbor})
+		assert.ErrorIs(t, err, domainerr.ErrBranchAccessDenied)
+		copyRepo.AssertNotCalled(t, "NextAccessionNumber", mock.Anything)
+	})
+
+	t.Run("numbers of copies failing to be created are skipped", func(t *testing.T) {
+		useCase, _ := newUseCase()
+		copyRepo := &MockBookCopyRepository{}
+		copyRepo.On("NextAccessionNumber", "HB-").Return(int64(43), nil).Once()
+		copyRepo.On("NextAccessionNumber", "HB-").Return(int64(44), nil).Once()
+		copyRepo.On("Create", mock.AnythingOfType("*entities.BookCopy")).Return(errors.New("database error")).Once()
+		copyRepo.On("Create", mock.AnythingOfType("*entities.BookCopy")).Return(nil).Once()
+		useCase.SetCopyRepository(copyRepo)
+
+		assert.EqualError(t, useCase.AddCopy("book-1", &entities.BookCopy{}), "database error")
+		bookCopy := &entities.BookCopy{}
+		require.NoError(t, useCase.AddCopy("book-1", bookCopy))
+		assert.Equal(t, "HB-000044", bookCopy.AccessionNumber)
+	})
+
+	t.Run("lookups by accession number", func(t *testing.T) {
+		useCase, copyRepo := newUseCase()
+		copyRepo.On("FindByAccessionNumber", "HB-000042").Return(&entities.BookCopy{BookID: "book-1", AccessionNumber: "HB-000042"}, nil)
+		copyRepo.On("FindByAccessionNumber", "HB-000099").Return(nil, nil)
+
+		bookCopy, book, err := useCase.FindCopyByAccessionNumber(" HB-000042 ")
+		require.NoError(t, err)
+		assert.Equal(t, "HB-000042", bookCopy.AccessionNumber)
+		assert.Equal(t, "book-1", book.ID)
+		_, _, err = useCase.FindCopyByAccessionNumber("HB-000099")
+		assert.ErrorIs(t, err, domainerr.ErrCopyNotFound)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		_, err := NewBookUseCase(&MockBookRepository{}).ListCopies("book-1")
+		assert.EqualError(t, err, "copies are not enabled")
+	})
+}
+
+func TestBookUseCase_BatchDeleteAndRestore(t *testing.T) {
+	harbor := "branch-harbor"
+	downtown := "branch-downtown"
+	deletedAt := time.Now()
+	books := []entities.Book{
+		{ID: "book-1", BranchID: &downtown},
+		{ID: "book-2", BranchID: &downtown},
+		{ID: "book-3", BranchID: &harbor},
+		{ID: "book-4", BranchID: &downtown, DeletedAt: &deletedAt},
+	}
+	ids := []string{"book-1", "book-2", "book-3", "book-4", "book-5", "book-1"}
+
+	newUseCase := func() (*BookUseCase, *MockBookRepository, *MockAuditRepository) {
+		bookRepo := &MockBookRepository{}
+		bookRepo.On("FindByIDsWithDeleted", ids).Return(books, nil)
+		loanRepo := &MockLoanRepository{}
+		loanRepo.On("ListActiveByBook", "book-2").Return([]entities.Loan{{ID: "loan-1", BookID: "book-2"}}, nil)
+		loanRepo.On("ListActiveByBook", mock.Anything).Return([]entities.Loan{}, nil)
+		auditRepo := &MockAuditRepository{}
+		auditRepo.On("Create", mock.AnythingOfType("*entities.AuditEntry")).Return(nil)
+		useCase := NewBookUseCase(bookRepo)
+		useCase.SetLoanRepository(loanRepo)
+		useCase.SetAuditRepository(auditRepo)
+		return useCase.As(entities.Actor{UserID: "user-1", Role: entities.UserRoleLibrarian, BranchID: downtown}), bookRepo, auditRepo
+	}
+
+	t.Run("deletes the books passing the checks together", func(t *testing.T) {
+		useCase, bookRepo, auditRepo := newUseCase()
+		bookRepo.On("DeleteBatch", []string{"book-1"}).Return(nil)
+
+		results, err := useCase.DeleteBooks(ids, false)
+		require.NoError(t, err)
+		assert.Equal(t, []BookBatchResult{
+			{Index: 0, ID: "book-1", Result: BookBatchDeleted},
+			{Index: 1, ID: "book-2", Result: BookBatchRejected, Error: domainerr.ErrBookOnLoan.Error()},
+			{Index: 2, ID: "book-3", Result: BookBatchRejected, Error: domainerr.ErrBranchAccessDenied.Error()},
+			{Index: 3, ID: "book-4", Result: BookBatchRejected, Error: domainerr.ErrBookNotFound.Error()},
+			{Index: 4, ID: "book-5", Result: BookBatchRejected, Error: domainerr.ErrBookNotFound.Error()},
+			{Index: 5, ID: "book-1", Result: BookBatchRejected, Error: "book ID appears more than once in the batch"},
+		}, results)
+		auditRepo.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("atomic batches change nothing unless every book passes", func(t *testing.T) {
+		useCase, bookRepo, auditRepo := newUseCase()
+
+		results, err := useCase.DeleteBooks(ids, true)
+		require.NoError(t, err)
+		assert.Equal(t, BookBatchAborted, results[0].Result)
+		bookRepo.AssertNotCalled(t, "DeleteBatch", mock.Anything)
+		auditRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("restores deleted books only", func(t *testing.T) {
+		useCase, bookRepo, _ := newUseCase()
+		bookRepo.On("RestoreBatch", []string{"book-4"}).Return(nil)
+
+		results, err := useCase.RestoreBooks(ids, false)
+		require.NoError(t, err)
+		assert.Equal(t, BookBatchRejected, results[0].Result)
+		assert.Equal(t, domainerr.ErrBookNotDeleted.Error(), results[1].Error)
+		assert.Equal(t, BookBatchRestored, results[3].Result)
+	})
+
+	t.Run("failed writes fail the batch", func(t *testing.T) {
+		useCase, bookRepo, auditRepo := newUseCase()
+		bookRepo.On("DeleteBatch", []string{"book-1"}).Return(errors.New("database error"))
+
+		_, err := useCase.DeleteBooks(ids, false)
+		assert.EqualError(t, err, "database error")
+		auditRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+}
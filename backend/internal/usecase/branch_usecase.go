@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// BranchUseCase handles the branches of the library
+type BranchUseCase struct {
+	branchRepo repositories.BranchRepository
+}
+
+// NewBranchUseCase creates a new branch use case
+func NewBranchUseCase(branchRepo repositories.BranchRepository) *BranchUseCase {
+	return &BranchUseCase{branchRepo: branchRepo}
+}
+
+// CreateBranch creates a new branch
+func (uc *BranchUseCase) CreateBranch(branch *entities.Branch) error {
+	branch.Name = strings.TrimSpace(branch.Name)
+	if branch.Name == "" {
+		return errors.New("branch name is required")
+	}
+
+	branch.AccessionPrefix = strings.ToUpper(strings.TrimSpace(branch.AccessionPrefix))
+	if !validAccessionPrefix(branch.AccessionPrefix) {
+		return errors.New("accession prefix must be up to 20 letters, digits and hyphens")
+	}
+
+	existing, err := uc.branchRepo.FindByName(branch.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return domainerr.ErrDuplicateBranchName
+	}
+
+	return uc.branchRepo.Create(branch)
+}
+
+// GetBranch retrieves a branch by ID
+func (uc *BranchUseCase) GetBranch(id string) (*entities.Branch, error) {
+	if id == "" {
+		return nil, errors.New("branch ID is required")
+	}
+
+	branch, err := uc.branchRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if branch == nil {
+		return nil, domainerr.ErrBranchNotFound
+	}
+	return branch, nil
+}
+
+// ListBranches retrieves all branches by name
+func (uc *BranchUseCase) ListBranches() ([]entities.Branch, error) {
+	return uc.branchRepo.GetAll()
+}
+
+// validAccessionPrefix reports whether a prefix, empty or upper case, fits accession numbers printed
+// on barcode labels
+func validAccessionPrefix(prefix string) bool {
+	if len(prefix) > 20 {
+		return false
+	}
+	for _, r := range prefix {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
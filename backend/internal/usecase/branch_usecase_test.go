@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"testing"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockBranchRepository is a mock implementation of BranchRepository
+type MockBranchRepository struct {
+	mock.Mock
+}
+
+func (m *MockBranchRepository) Create(branch *entities.Branch) error {
+	args := m.Called(branch)
+	return args.Error(0)
+}
+
+func (m *MockBranchRepository) GetByID(id string) (*entities.Branch, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Branch), args.Error(1)
+}
+
+func (m *MockBranchRepository) Update(branch *entities.Branch) error {
+	args := m.Called(branch)
+	return args.Error(0)
+}
+
+func (m *MockBranchRepository) GetAll() ([]entities.Branch, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.Branch), args.Error(1)
+}
+
+func (m *MockBranchRepository) FindByName(name string) (*entities.Branch, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Branch), args.Error(1)
+}
+
+func TestBranchUseCase_CreateBranch(t *testing.T) {
+	branchRepo := &MockBranchRepository{}
+	branchRepo.On("FindByName", "Downtown").Return(nil, nil)
+	branchRepo.On("FindByName", "Harbor").Return(&entities.Branch{ID: "branch-1", Name: "Harbor"}, nil)
+	branchRepo.On("Create", mock.AnythingOfType("*entities.Branch")).Return(nil)
+	useCase := NewBranchUseCase(branchRepo)
+
+	branch := &entities.Branch{Name: " Downtown "}
+	require.NoError(t, useCase.CreateBranch(branch))
+	assert.Equal(t, "Downtown", branch.Name)
+
+	assert.ErrorIs(t, useCase.CreateBranch(&entities.Branch{Name: "Harbor"}), domainerr.ErrDuplicateBranchName)
+	assert.EqualError(t, useCase.CreateBranch(&entities.Branch{Name: " "}), "branch name is required")
+	assert.EqualError(t, useCase.CreateBranch(&entities.Branch{Name: "Downtown", AccessionPrefix: "DT/"}), "accession prefix must be up to 20 letters, digits and hyphens")
+	branchRepo.AssertNumberOfCalls(t, "Create", 1)
+
+	prefixed := &entities.Branch{Name: "Downtown", AccessionPrefix: " dt-"}
+	require.NoError(t, useCase.CreateBranch(prefixed))
+	assert.Equal(t, "DT-", prefixed.AccessionPrefix)
+}
+
+func TestBookUseCase_BranchScoping(t *testing.T) {
+	branchA := "branch-a"
+	branchB := "branch-b"
+	librarian := entities.Actor{UserID: "user-1", Role: entities.UserRoleLibrarian, BranchID: branchA}
+
+	newUseCase := func() (*BookUseCase, *MockBookRepository) {
+		bookRepo := &MockBookRepository{}
+		branchRepo := &MockBranchRepository{}
+		branchRepo.On("GetByID", branchA).Return(&entities.Branch{ID: branchA}, nil)
+		branchRepo.On("GetByID", branchB).Return(&entities.Branch{ID: branchB}, nil)
+		branchRepo.On("GetByID", "missing").Return(nil, nil)
+		bookRepo.On("GetByIDInBranch", "book-a", branchA).Return(&entities.Book{ID: "book-a", BranchID: &branchA}, nil)
+		bookRepo.On("GetByIDInBranch", "book-b", branchA).Return(nil, nil)
+		bookRepo.On("GetByIDInBranch", "missing", branchA).Return(nil, nil)
+		bookRepo.On("GetByID", "book-a").Return(&entities.Book{ID: "book-a", BranchID: &branchA}, nil)
+		bookRepo.On("GetByID", "book-b").Return(&entities.Book{ID: "book-b", BranchID: &branchB}, nil)
+		bookRepo.On("GetByID", "missing").Return(nil, nil)
+		useCase := NewBookUseCase(bookRepo)
+		useCase.SetBranchRepository(branchRepo)
+		return useCase, bookRepo
+	}
+
+	t.Run("librarians only change the books of their branch", func(t *testing.T) {
+		useCase, bookRepo := newUseCase()
+		bookRepo.On("Delete", "book-a").Return(nil)
+
+		assert.NoError(t, useCase.As(librarian).DeleteBook("book-a"))
+		assert.ErrorIs(t, useCase.As(librarian).DeleteBook("book-b"), domainerr.ErrBranchAccessDenied)
+		assert.ErrorIs(t, useCase.As(librarian).DeleteBook("missing"), domainerr.ErrBookNotFound)
+		_, err := useCase.As(librarian).ChangeBookStatus("book-b", entities.BookStatusArchived)
+		assert.ErrorIs(t, err, domainerr.ErrBranchAccessDenied)
+		bookRepo.AssertNotCalled(t, "Delete", "book-b")
+		bookRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+
+	t.Run("admins and library-wide librarians are not restricted", func(t *testing.T) {
+		useCase, bookRepo := newUseCase()
+		bookRepo.On("Delete", "book-b").Return(nil)
+
+		assert.NoError(t, useCase.As(entities.Actor{Role: entities.UserRoleAdmin, BranchID: branchA}).DeleteBook("book-b"))
+		assert.NoError(t, useCase.As(entities.Actor{Role: entities.UserRoleLibrarian}).DeleteBook("book-b"))
+		assert.NoError(t, useCase.DeleteBook("book-b"))
+		bookRepo.AssertNotCalled(t, "GetByIDInBranch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("new books go to the branch of their librarian", func(t *testing.T) {
+		useCase, bookRepo := newUseCase()
+		bookRepo.On("FindByISBN", "1234567890").Return(nil, nil)
+		bookRepo.On("FindBySlug", "mort").Return(nil, nil)
+		bookRepo.On("Create", mock.AnythingOfType("*entities.Book")).Return(nil)
+
+		book := &entities.Book{Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "1234567890"}
+		require.NoError(t, useCase.As(librarian).CreateBook(book))
+		require.NotNil(t, book.BranchID)
+		assert.Equal(t, branchA, *book.BranchID)
+
+		other := &entities.Book{Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "1234567890", BranchID: &branchB}
+		assert.ErrorIs(t, useCase.As(librarian).CreateBook(other), domainerr.ErrBranchAccessDenied)
+		missing := "missing"
+		unknown := &entities.Book{Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "1234567890", BranchID: &missing}
+		assert.ErrorIs(t, useCase.CreateBook(unknown), domainerr.ErrBranchNotFound)
+		bookRepo.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("librarians cannot move books to another branch", func(t *testing.T) {
+		useCase, bookRepo := newUseCase()
+
+		err := useCase.As(librarian).UpdateBook("book-a", &entities.Book{Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "1234567890", BranchID: &branchB})
+
+		assert.ErrorIs(t, err, domainerr.ErrBranchAccessDenied)
+		bookRepo.AssertNotCalled(t, "Update", mock.Anything)
+	})
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+	"library-management-system/internal/domain/sortedjson"
+)
+
+// bundleFormatVersion is bumped whenever the layout of a book bundle changes
+const bundleFormatVersion = 2
+
+// BundleManifest describes the contents of a book bundle
+type BundleManifest struct {
+	FormatVersion int       `json:"format_version"`
+	BookID        string    `json:"book_id"`
+	ExportedAt    time.Time `json:"exported_at"`
+	Files         []string  `json:"files"`
+}
+
+// BundleUseCase exports a single book and its related records as a zip archive
+type BundleUseCase struct {
+	bookRepo      repositories.BookRepository
+	auditRepo     repositories.AuditRepository
+	publisherRepo repositories.PublisherRepository
+	covers        *CoverUseCase
+	clock         clock.Clock
+}
+
+// NewBundleUseCase creates a new bundle use case
+func NewBundleUseCase(bookRepo repositories.BookRepository, auditRepo repositories.AuditRepository) *BundleUseCase {
+	return &BundleUseCase{
+		bookRepo:  bookRepo,
+		auditRepo: auditRepo,
+		clock:     clock.System{},
+	}
+}
+
+// SetClock replaces the clock used to timestamp exports
+func (uc *BundleUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// SetPublisherRepository enables publisher names in the Dublin Core records of exported books
+func (uc *BundleUseCase) SetPublisherRepository(publisherRepo repositories.PublisherRepository) {
+	uc.publisherRepo = publisherRepo
+}
+
+// SetCoverUseCase adds the cover images of books to their bundles
+func (uc *BundleUseCase) SetCoverUseCase(covers *CoverUseCase) {
+	uc.covers = covers
+}
+
+// DublinCore describes an exported book in Dublin Core, a lighter alternative to the full bundle
+func (uc *BundleUseCase) DublinCore(book *entities.Book) (*entities.DublinCore, error) {
+	return dublinCore(uc.publisherRepo, book)
+}
+
+// GetBook retrieves the book a bundle would be built for, so callers can fail before writing output
+func (uc *BundleUseCase) GetBook(id string) (*entities.Book, error) {
+	if id == "" {
+		return nil, errors.New("book ID is required")
+	}
+
+	book, err := uc.bookRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if book == nil {
+		return nil, domainerr.ErrBookNotFound
+	}
+	return book, nil
+}
+
+// WriteBundle writes the zip bundle of a book: the book record, its audit history, its cover image
+// if it has one and a manifest. With sortKeys, the keys of the JSON documents are sorted so that
+// bundles can be diffed.
+func (uc *BundleUseCase) WriteBundle(book *entities.Book, w io.Writer, sortKeys bool) error {
+	history, err := uc.auditRepo.ListByEntity("book", book.ID)
+	if err != nil {
+		return err
+	}
+	if history == nil {
+		history = []entities.AuditEntry{}
+	}
+	cover, image, err := uc.openCover(book.ID)
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		name    string
+		content interface{}
+	}{
+		{name: "book.json", content: book},
+		{name: "history.json", content: history},
+	}
+
+	manifest := BundleManifest{
+		FormatVersion: bundleFormatVersion,
+		BookID:        book.ID,
+		ExportedAt:    uc.clock.Now().UTC(),
+	}
+	for _, file := range files {
+		manifest.Files = append(manifest.Files, file.name)
+	}
+	if cover != nil {
+		manifest.Files = append(manifest.Files, coverFileName(cover.ContentType))
+	}
+
+	archive := zip.NewWriter(w)
+	if err := writeJSONFile(archive, "manifest.json", manifest, sortKeys); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := writeJSONFile(archive, file.name, file.content, sortKeys); err != nil {
+			return err
+		}
+	}
+	if cover != nil {
+		file, err := archive.Create(coverFileName(cover.ContentType))
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(image); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// openCover returns the cover of a book along with its image, or nil when the book has none or
+// bundles leave covers out
+func (uc *BundleUseCase) openCover(bookID string) (*entities.BookCover, []byte, error) {
+	if uc.covers == nil {
+		return nil, nil, nil
+	}
+	cover, image, err := uc.covers.OpenCover(bookID)
+	if errors.Is(err, domainerr.ErrCoverNotFound) {
+		return nil, nil, nil
+	}
+	return cover, image, err
+}
+
+// coverFileName names the cover image of a bundle after its type, e.g. cover.png
+func coverFileName(contentType string) string {
+	if format := strings.TrimPrefix(contentType, "image/"); format != contentType && format != "" {
+		return "cover." + format
+	}
+	return "cover"
+}
+
+// writeJSONFile adds an indented JSON document to the archive
+func writeJSONFile(archive *zip.Writer, name string, content interface{}, sortKeys bool) error {
+	file, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if sortKeys {
+		data, err := sortedjson.Marshal(content, "  ")
+		if err != nil {
+			return err
+		}
+		_, err = file.Write(append(data, '\n'))
+		return err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(content)
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleUseCase_WriteBundle(t *testing.T) {
+	bookRepo := &MockBookRepository{}
+	auditRepo := &MockAuditRepository{}
+	auditRepo.On("ListByEntity", "book", "book-1").Return([]entities.AuditEntry{
+		{EntityType: "book", EntityID: "book-1", Action: entities.AuditActionCreated},
+	}, nil)
+
+	bookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1"}, nil)
+	coverRepo := &MockCoverRepository{}
+	coverRepo.On("GetByBookID", "book-1").Return(&entities.BookCover{BookID: "book-1", Hash: "abc", ContentType: "image/png"}, nil)
+	coverRepo.On("Open", "abc").Return([]byte("png bytes"), nil)
+
+	useCase := NewBundleUseCase(bookRepo, auditRepo)
+	useCase.SetCoverUseCase(NewCoverUseCase(bookRepo, coverRepo, 1024, 10, 100))
+	book := &entities.Book{ID: "book-1", Title: "Test Book", ISBN: "1234567890"}
+
+	var buf bytes.Buffer
+	require.NoError(t, useCase.WriteBundle(book, &buf, false))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	contents := make(map[string][]byte)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+		var data bytes.Buffer
+		_, err = data.ReadFrom(reader)
+		require.NoError(t, err)
+		contents[file.Name] = data.Bytes()
+	}
+
+	assert.Contains(t, contents, "manifest.json")
+	assert.Contains(t, string(contents["book.json"]), `"title": "Test Book"`)
+	assert.Contains(t, string(contents["history.json"]), `"action": "created"`)
+	assert.Equal(t, "png bytes", string(contents["cover.png"]))
+
+	var manifest BundleManifest
+	require.NoError(t, json.Unmarshal(contents["manifest.json"], &manifest))
+	assert.Equal(t, "book-1", manifest.BookID)
+	assert.Equal(t, []string{"book.json", "history.json", "cover.png"}, manifest.Files)
+}
+
+func TestBundleUseCase_WriteBundleWithoutCover(t *testing.T) {
+	bookRepo := &MockBookRepository{}
+	bookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1"}, nil)
+	auditRepo := &MockAuditRepository{}
+	auditRepo.On("ListByEntity", "book", "book-1").Return([]entities.AuditEntry{}, nil)
+	coverRepo := &MockCoverRepository{}
+	coverRepo.On("GetByBookID", "book-1").Return(nil, nil)
+
+	useCase := NewBundleUseCase(bookRepo, auditRepo)
+	useCase.SetCoverUseCase(NewCoverUseCase(bookRepo, coverRepo, 1024, 10, 100))
+
+	var buf bytes.Buffer
+	require.NoError(t, useCase.WriteBundle(&entities.Book{ID: "book-1"}, &buf, false))
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	assert.Len(t, archive.File, 3)
+}
+
+func TestBundleUseCase_WriteBundleSorted(t *testing.T) {
+	auditRepo := &MockAuditRepository{}
+	auditRepo.On("ListByEntity", "book", "book-1").Return([]entities.AuditEntry{}, nil)
+	useCase := NewBundleUseCase(&MockBookRepository{}, auditRepo)
+	useCase.SetClock(clock.NewFixed(time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)))
+
+	var buf bytes.Buffer
+	require.NoError(t, useCase.WriteBundle(&entities.Book{ID: "book-1", Title: "Emma"}, &buf, true))
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	reader, err := archive.File[0].Open()
+	require.NoError(t, err)
+	var manifest bytes.Buffer
+	_, err = manifest.ReadFrom(reader)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{
+  "book_id": "book-1",
+  "exported_at": "2026-10-16T09:00:00Z",
+  "files": [
+    "book.json",
+    "history.json"
+  ],
+  "format_version": 2
+}
+`, manifest.String())
+}
+
+func TestBundleUseCase_GetBook_NotFound(t *testing.T) {
+	bookRepo := &MockBookRepository{}
+	bookRepo.On("GetByID", "missing").Return(nil, nil)
+
+	useCase := NewBundleUseCase(bookRepo, &MockAuditRepository{})
+
+	_, err := useCase.GetBook("missing")
+	assert.EqualError(t, err, "book not found")
+}
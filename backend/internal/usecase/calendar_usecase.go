@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// CalendarUseCase keeps the business hours and closed days of the library and its branches, and
+// moves due dates off the days they are closed
+type CalendarUseCase struct {
+	calendarRepo repositories.CalendarRepository
+	branchRepo   repositories.BranchRepository
+}
+
+// NewCalendarUseCase creates a new calendar use case
+func NewCalendarUseCase(calendarRepo repositories.CalendarRepository, branchRepo repositories.BranchRepository) *CalendarUseCase {
+	return &CalendarUseCase{
+		calendarRepo: calendarRepo,
+		branchRepo:   branchRepo,
+	}
+}
+
+// Calendar returns the calendar a branch, or the whole library for an empty branch ID, follows.
+// Branches without hours of their own keep the hours of the library, and close on the closed days
+// of the library as well as on their own.
+func (uc *CalendarUseCase) Calendar(branchID string) (*entities.Calendar, error) {
+	if err := uc.checkBranch(branchID); err != nil {
+		return nil, err
+	}
+	return uc.calendar(branchRef(branchID))
+}
+
+// SetHours replaces the business hours of a branch, or of the whole library for an empty branch
+// ID, and returns the calendar it then follows. Each weekday may have hours once; no hours at all
+// leave a branch with the hours of the library, and the library open every day.
+func (uc *CalendarUseCase) SetHours(branchID string, hours []entities.BusinessHours) (*entities.Calendar, error) {
+	if err := uc.checkBranch(branchID); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for i := range hours {
+		if err := hours[i].Validate(); err != nil {
+			return nil, err
+		}
+		if seen[hours[i].Weekday] {
+			return nil, fmt.Errorf("%s has hours more than once", hours[i].Weekday)
+		}
+		seen[hours[i].Weekday] = true
+		hours[i].ID = ""
+		hours[i].BranchID = branchRef(branchID)
+	}
+
+	if err := uc.calendarRepo.ReplaceHours(branchRef(branchID), hours); err != nil {
+		return nil, err
+	}
+	return uc.calendar(branchRef(branchID))
+}
+
+// AddClosedDay closes a branch, or the whole library when the day has no branch, on a date, or
+// every year on its month and day when it is yearly
+func (uc *CalendarUseCase) AddClosedDay(day *entities.ClosedDay) error {
+	day.Date = strings.TrimSpace(day.Date)
+	if _, err := time.Parse(entities.ClosedDayLayout, day.Date); err != nil {
+		return fmt.Errorf("date must be YYYY-MM-DD, got %q", day.Date)
+	}
+	day.Name = strings.TrimSpace(day.Name)
+	if day.BranchID != nil {
+		if err := uc.checkBranch(*day.BranchID); err != nil {
+			return err
+		}
+	}
+	return uc.calendarRepo.CreateClosedDay(day)
+}
+
+// DeleteClosedDay opens again on a closed day
+func (uc *CalendarUseCase) DeleteClosedDay(id string) error {
+	if id == "" {
+		return errors.New("closed day ID is required")
+	}
+
+	deleted, err := uc.calendarRepo.DeleteClosedDay(id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return domainerr.ErrClosedDayNotFound
+	}
+	return nil
+}
+
+// DueDate moves a due date forward to the first day the branch of a book, or the whole library
+// for books without a branch, is open. Days are those of the location of the due date, which
+// should be the timezone of the branch.
+func (uc *CalendarUseCase) DueDate(branchID *string, due time.Time) (time.Time, error) {
+	calendar, err := uc.calendar(branchID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return calendar.NextOpenDay(due), nil
+}
+
+// calendar assembles the calendar of a branch, nil for the whole library
+func (uc *CalendarUseCase) calendar(branchID *string) (*entities.Calendar, error) {
+	hours, err := uc.calendarRepo.ListHours()
+	if err != nil {
+		return nil, err
+	}
+	days, err := uc.calendarRepo.ListClosedDays()
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &entities.Calendar{BranchID: branchID, Hours: []entities.BusinessHours{}, ClosedDays: []entities.ClosedDay{}}
+	var libraryHours []entities.BusinessHours
+	for _, h := range hours {
+		switch {
+		case h.BranchID == nil:
+			libraryHours = append(libraryHours, h)
+		case branchID != nil && *h.BranchID == *branchID:
+			calendar.Hours = append(calendar.Hours, h)
+		}
+	}
+	if len(calendar.Hours) == 0 && libraryHours != nil {
+		calendar.Hours = libraryHours
+	}
+	for _, day := range days {
+		if day.BranchID == nil || (branchID != nil && *day.BranchID == *branchID) {
+			calendar.ClosedDays = append(calendar.ClosedDays, day)
+		}
+	}
+	return calendar, nil
+}
+
+// checkBranch checks that a branch exists; the empty ID of the whole library always does
+func (uc *CalendarUseCase) checkBranch(branchID string) error {
+	if branchID == "" {
+		return nil
+	}
+	branch, err := uc.branchRepo.GetByID(branchID)
+	if err != nil {
+		return err
+	}
+	if branch == nil {
+		return domainerr.ErrBranchNotFound
+	}
+	return nil
+}
+
+// branchRef references a branch by ID, nil for the empty ID of the whole library
+func branchRef(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
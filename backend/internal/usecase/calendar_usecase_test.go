@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCalendarRepository keeps business hours and closed days in memory
+type memoryCalendarRepository struct {
+	hours []entities.BusinessHours
+	days  []entities.ClosedDay
+}
+
+func (r *memoryCalendarRepository) ListHours() ([]entities.BusinessHours, error) {
+	return r.hours, nil
+}
+
+func (r *memoryCalendarRepository) ReplaceHours(branchID *string, hours []entities.BusinessHours) error {
+	kept := []entities.BusinessHours{}
+	for _, h := range r.hours {
+		if (branchID == nil) != (h.BranchID == nil) || (branchID != nil && *branchID != *h.BranchID) {
+			kept = append(kept, h)
+		}
+	}
+	r.hours = append(kept, hours...)
+	return nil
+}
+
+func (r *memoryCalendarRepository) ListClosedDays() ([]entities.ClosedDay, error) {
+	return r.days, nil
+}
+
+func (r *memoryCalendarRepository) CreateClosedDay(day *entities.ClosedDay) error {
+	day.ID = day.Date
+	r.days = append(r.days, *day)
+	return nil
+}
+
+func (r *memoryCalendarRepository) DeleteClosedDay(id string) (bool, error) {
+	for i, day := range r.days {
+		if day.ID == id {
+			r.days = append(r.days[:i], r.days[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestCalendarUseCase_DueDate(t *testing.T) {
+	branchRepo := &MockBranchRepository{}
+	branchRepo.On("GetByID", "harbor").Return(&entities.Branch{ID: "harbor", Name: "Harbor"}, nil)
+	branchRepo.On("GetByID", "missing").Return(nil, nil)
+	useCase := NewCalendarUseCase(&memoryCalendarRepository{}, branchRepo)
+	harbor := "harbor"
+	// Friday 16 October 2026, 9:00
+	friday := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	// Without hours the library is open every day
+	due, err := useCase.DueDate(nil, friday.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, friday.AddDate(0, 0, 1), due)
+
+	weekdays := []entities.BusinessHours{}
+	for _, day := range []string{"Monday", "tuesday", "wednesday", "thursday", "friday"} {
+		weekdays = append(weekdays, entities.BusinessHours{Weekday: day, Opens: "09:00", Closes: "18:00"})
+	}
+	calendar, err := useCase.SetHours("", weekdays)
+	require.NoError(t, err)
+	assert.Len(t, calendar.Hours, 5)
+	assert.Equal(t, "monday", calendar.Hours[0].Weekday)
+
+	// Weekends move to Monday, for the branch too since it keeps the hours of the library
+	due, err = useCase.DueDate(nil, friday.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, friday.AddDate(0, 0, 3), due)
+
+	// The branch opens on Saturdays, but closes on a holiday of its own and on those of the library
+	_, err = useCase.SetHours("harbor", append(weekdays, entities.BusinessHours{Weekday: "saturday", Opens: "10:00", Closes: "14:00"}))
+	require.NoError(t, err)
+	require.NoError(t, useCase.AddClosedDay(&entities.ClosedDay{Date: "2020-10-19", Name: "Founders' Day", Yearly: true}))
+	require.NoError(t, useCase.AddClosedDay(&entities.ClosedDay{BranchID: &harbor, Date: "2026-10-20", Name: "Inventory"}))
+	due, err = useCase.DueDate(&harbor, friday.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, friday.AddDate(0, 0, 1), due)
+	due, err = useCase.DueDate(&harbor, friday.AddDate(0, 0, 2))
+	require.NoError(t, err)
+	assert.Equal(t, friday.AddDate(0, 0, 5), due, "Sunday, the yearly holiday and the branch closure are skipped")
+	due, err = useCase.DueDate(nil, friday.AddDate(0, 0, 2))
+	require.NoError(t, err)
+	assert.Equal(t, friday.AddDate(0, 0, 4), due, "the closure of the branch leaves the library open")
+
+	calendar, err = useCase.Calendar("harbor")
+	require.NoError(t, err)
+	assert.Len(t, calendar.Hours, 6)
+	assert.Len(t, calendar.ClosedDays, 2)
+
+	require.NoError(t, useCase.DeleteClosedDay("2026-10-20"))
+	assert.ErrorIs(t, useCase.DeleteClosedDay("2026-10-20"), domainerr.ErrClosedDayNotFound)
+	_, err = useCase.Calendar("missing")
+	assert.ErrorIs(t, err, domainerr.ErrBranchNotFound)
+}
+
+func TestCalendarUseCase_Validation(t *testing.T) {
+	useCase := NewCalendarUseCase(&memoryCalendarRepository{}, &MockBranchRepository{})
+
+	_, err := useCase.SetHours("", []entities.BusinessHours{{Weekday: "someday", Opens: "09:00", Closes: "17:00"}})
+	assert.EqualError(t, err, "weekday must be one of sunday, monday, tuesday, wednesday, thursday, friday, saturday")
+	_, err = useCase.SetHours("", []entities.BusinessHours{{Weekday: "monday", Opens: "9am", Closes: "17:00"}})
+	assert.EqualError(t, err, `opens must be HH:MM, got "9am"`)
+	_, err = useCase.SetHours("", []entities.BusinessHours{{Weekday: "monday", Opens: "17:00", Closes: "09:00"}})
+	assert.EqualError(t, err, "monday must open before it closes")
+	_, err = useCase.SetHours("", []entities.BusinessHours{
+		{Weekday: "monday", Opens: "09:00", Closes: "12:00"},
+		{Weekday: "monday", Opens: "13:00", Closes: "17:00"},
+	})
+	assert.EqualError(t, err, "monday has hours more than once")
+	assert.EqualError(t, useCase.AddClosedDay(&entities.ClosedDay{Date: "25/12/2026"}), `date must be YYYY-MM-DD, got "25/12/2026"`)
+}
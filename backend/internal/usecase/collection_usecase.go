@@ -0,0 +1,275 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// CollectionUseCase handles reading lists that users curate and share
+type CollectionUseCase struct {
+	collectionRepo repositories.CollectionRepository
+	bookRepo       repositories.BookRepository
+}
+
+// NewCollectionUseCase creates a new collection use case
+func NewCollectionUseCase(collectionRepo repositories.CollectionRepository, bookRepo repositories.BookRepository) *CollectionUseCase {
+	return &CollectionUseCase{
+		collectionRepo: collectionRepo,
+		bookRepo:       bookRepo,
+	}
+}
+
+// CreateCollection creates an empty collection owned by the caller
+func (uc *CollectionUseCase) CreateCollection(ownerID string, collection *entities.Collection) error {
+	if ownerID == "" {
+		return errors.New("owner ID is required")
+	}
+	if err := normalizeCollection(collection); err != nil {
+		return err
+	}
+
+	collection.OwnerID = ownerID
+	collection.ShareToken = nil
+	return uc.collectionRepo.Create(collection)
+}
+
+// ListCollections retrieves the collections of an owner
+func (uc *CollectionUseCase) ListCollections(ownerID string) ([]entities.Collection, error) {
+	if ownerID == "" {
+		return nil, errors.New("owner ID is required")
+	}
+
+	return uc.collectionRepo.ListByOwner(ownerID)
+}
+
+// GetCollection retrieves a collection of the caller with its books in order
+func (uc *CollectionUseCase) GetCollection(ownerID, id string) (*entities.Collection, []entities.Book, error) {
+	collection, err := uc.requireOwned(ownerID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	books, err := uc.books(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return collection, books, nil
+}
+
+// UpdateCollection changes the title and description of a collection
+func (uc *CollectionUseCase) UpdateCollection(ownerID, id string, changes *entities.Collection) error {
+	collection, err := uc.requireOwned(ownerID, id)
+	if err != nil {
+		return err
+	}
+	if err := normalizeCollection(changes); err != nil {
+		return err
+	}
+
+	collection.Title = changes.Title
+	collection.Description = changes.Description
+	return uc.collectionRepo.Update(collection)
+}
+
+// DeleteCollection deletes a collection; its books stay in the catalog
+func (uc *CollectionUseCase) DeleteCollection(ownerID, id string) error {
+	if _, err := uc.requireOwned(ownerID, id); err != nil {
+		return err
+	}
+
+	return uc.collectionRepo.Delete(id)
+}
+
+// AddBook adds a book at a 1-based position of a collection; position 0 appends it
+func (uc *CollectionUseCase) AddBook(ownerID, id, bookID string, position int) error {
+	if _, err := uc.requireOwned(ownerID, id); err != nil {
+		return err
+	}
+
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return err
+	}
+	if book == nil || book.DeletedAt != nil {
+		return domainerr.ErrBookNotFound
+	}
+
+	bookIDs, err := uc.collectionRepo.ListBookIDs(id)
+	if err != nil {
+		return err
+	}
+	for _, memberID := range bookIDs {
+		if memberID == bookID {
+			return domainerr.ErrBookInCollection
+		}
+	}
+
+	if position < 0 || position > len(bookIDs)+1 {
+		return errors.New("position is out of range")
+	}
+	if position == 0 {
+		position = len(bookIDs) + 1
+	}
+
+	bookIDs = append(bookIDs[:position-1], append([]string{bookID}, bookIDs[position-1:]...)...)
+	return uc.collectionRepo.SetBooks(id, bookIDs)
+}
+
+// RemoveBook removes a book from a collection
+func (uc *CollectionUseCase) RemoveBook(ownerID, id, bookID string) error {
+	if _, err := uc.requireOwned(ownerID, id); err != nil {
+		return err
+	}
+
+	bookIDs, err := uc.collectionRepo.ListBookIDs(id)
+	if err != nil {
+		return err
+	}
+
+	remaining := make([]string, 0, len(bookIDs))
+	for _, memberID := range bookIDs {
+		if memberID != bookID {
+			remaining = append(remaining, memberID)
+		}
+	}
+	if len(remaining) == len(bookIDs) {
+		return errors.New("book is not in the collection")
+	}
+
+	return uc.collectionRepo.SetBooks(id, remaining)
+}
+
+// ReorderBooks puts the books of a collection in the given order, which must list every book exactly once
+func (uc *CollectionUseCase) ReorderBooks(ownerID, id string, bookIDs []string) error {
+	if _, err := uc.requireOwned(ownerID, id); err != nil {
+		return err
+	}
+
+	current, err := uc.collectionRepo.ListBookIDs(id)
+	if err != nil {
+		return err
+	}
+	if len(bookIDs) != len(current) {
+		return errors.New("order must list every book of the collection exactly once")
+	}
+
+	members := make(map[string]bool, len(current))
+	for _, memberID := range current {
+		members[memberID] = true
+	}
+	for _, bookID := range bookIDs {
+		if !members[bookID] {
+			return errors.New("order must list every book of the collection exactly once")
+		}
+		delete(members, bookID)
+	}
+
+	return uc.collectionRepo.SetBooks(id, bookIDs)
+}
+
+// Share gives a collection a share token; a collection that is already shared keeps its token
+func (uc *CollectionUseCase) Share(ownerID, id string) (*entities.Collection, error) {
+	collection, err := uc.requireOwned(ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if collection.IsShared() {
+		return collection, nil
+	}
+
+	token := entities.NewShareToken()
+	collection.ShareToken = &token
+	if err := uc.collectionRepo.Update(collection); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// Unshare revokes the share token of a collection, disabling its public view
+func (uc *CollectionUseCase) Unshare(ownerID, id string) (*entities.Collection, error) {
+	collection, err := uc.requireOwned(ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !collection.IsShared() {
+		return collection, nil
+	}
+
+	collection.ShareToken = nil
+	if err := uc.collectionRepo.Update(collection); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// GetSharedCollection retrieves a shared collection with its books through its share token
+func (uc *CollectionUseCase) GetSharedCollection(token string) (*entities.Collection, []entities.Book, error) {
+	if token == "" {
+		return nil, nil, domainerr.ErrCollectionNotFound
+	}
+
+	collection, err := uc.collectionRepo.GetByShareToken(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if collection == nil {
+		return nil, nil, domainerr.ErrCollectionNotFound
+	}
+
+	books, err := uc.books(collection.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return collection, books, nil
+}
+
+// requireOwned retrieves a collection of the caller
+func (uc *CollectionUseCase) requireOwned(ownerID, id string) (*entities.Collection, error) {
+	if ownerID == "" {
+		return nil, errors.New("owner ID is required")
+	}
+
+	collection, err := uc.collectionRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	// Collections of other users are reported as missing rather than forbidden
+	if collection == nil || collection.OwnerID != ownerID {
+		return nil, domainerr.ErrCollectionNotFound
+	}
+	return collection, nil
+}
+
+// books retrieves the books of a collection in order, skipping deleted books
+func (uc *CollectionUseCase) books(id string) ([]entities.Book, error) {
+	bookIDs, err := uc.collectionRepo.ListBookIDs(id)
+	if err != nil {
+		return nil, err
+	}
+
+	books := make([]entities.Book, 0, len(bookIDs))
+	for _, bookID := range bookIDs {
+		book, err := uc.bookRepo.GetByID(bookID)
+		if err != nil {
+			return nil, err
+		}
+		if book != nil && book.DeletedAt == nil {
+			books = append(books, *book)
+		}
+	}
+	return books, nil
+}
+
+// normalizeCollection trims the user-provided fields of a collection and validates them
+func normalizeCollection(collection *entities.Collection) error {
+	collection.Title = strings.TrimSpace(collection.Title)
+	collection.Description = strings.TrimSpace(collection.Description)
+	if collection.Title == "" {
+		return errors.New("collection title is required")
+	}
+	return nil
+}
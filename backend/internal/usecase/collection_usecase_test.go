@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCollectionRepository is a mock implementation of CollectionRepository
+type MockCollectionRepository struct {
+	mock.Mock
+}
+
+func (m *MockCollectionRepository) Create(collection *entities.Collection) error {
+	args := m.Called(collection)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepository) GetByID(id string) (*entities.Collection, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Collection), args.Error(1)
+}
+
+func (m *MockCollectionRepository) GetByShareToken(token string) (*entities.Collection, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Collection), args.Error(1)
+}
+
+func (m *MockCollectionRepository) ListByOwner(ownerID string) ([]entities.Collection, error) {
+	args := m.Called(ownerID)
+	return args.Get(0).([]entities.Collection), args.Error(1)
+}
+
+func (m *MockCollectionRepository) Update(collection *entities.Collection) error {
+	args := m.Called(collection)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepository) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockCollectionRepository) ListBookIDs(collectionID string) ([]string, error) {
+	args := m.Called(collectionID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockCollectionRepository) SetBooks(collectionID string, bookIDs []string) error {
+	args := m.Called(collectionID, bookIDs)
+	return args.Error(0)
+}
+
+func TestCollectionUseCase_AddBook(t *testing.T) {
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		ownerID       string
+		bookID        string
+		position      int
+		expected      []string
+		expectedError string
+	}{
+		{name: "append", ownerID: "member-1", bookID: "book-3", expected: []string{"book-1", "book-2", "book-3"}},
+		{name: "insert first", ownerID: "member-1", bookID: "book-3", position: 1, expected: []string{"book-3", "book-1", "book-2"}},
+		{name: "insert in the middle", ownerID: "member-1", bookID: "book-3", position: 2, expected: []string{"book-1", "book-3", "book-2"}},
+		{name: "position out of range", ownerID: "member-1", bookID: "book-3", position: 4, expectedError: "position is out of range"},
+		{name: "already a member", ownerID: "member-1", bookID: "book-1", expectedError: "book is already in the collection"},
+		{name: "deleted book", ownerID: "member-1", bookID: "deleted", expectedError: "book not found"},
+		{name: "collection of another user", ownerID: "member-2", bookID: "book-3", expectedError: "collection not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockCollectionRepository{}
+			mockBookRepo := &MockBookRepository{}
+			mockRepo.On("GetByID", "list-1").Return(&entities.Collection{ID: "list-1", OwnerID: "member-1"}, nil)
+			mockRepo.On("ListBookIDs", "list-1").Return([]string{"book-1", "book-2"}, nil)
+			mockRepo.On("SetBooks", "list-1", mock.Anything).Return(nil)
+			mockBookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1"}, nil)
+			mockBookRepo.On("GetByID", "book-3").Return(&entities.Book{ID: "book-3"}, nil)
+			mockBookRepo.On("GetByID", "deleted").Return(&entities.Book{ID: "deleted", DeletedAt: &deletedAt}, nil)
+
+			err := NewCollectionUseCase(mockRepo, mockBookRepo).AddBook(tt.ownerID, "list-1", tt.bookID, tt.position)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				mockRepo.AssertNotCalled(t, "SetBooks", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				mockRepo.AssertCalled(t, "SetBooks", "list-1", tt.expected)
+			}
+		})
+	}
+}
+
+func TestCollectionUseCase_ReorderBooks(t *testing.T) {
+	tests := []struct {
+		name          string
+		bookIDs       []string
+		expectedError string
+	}{
+		{name: "permutation", bookIDs: []string{"book-2", "book-1"}},
+		{name: "missing book", bookIDs: []string{"book-2"}, expectedError: "order must list every book of the collection exactly once"},
+		{name: "duplicate book", bookIDs: []string{"book-2", "book-2"}, expectedError: "order must list every book of the collection exactly once"},
+		{name: "unknown book", bookIDs: []string{"book-2", "book-3"}, expectedError: "order must list every book of the collection exactly once"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockCollectionRepository{}
+			mockRepo.On("GetByID", "list-1").Return(&entities.Collection{ID: "list-1", OwnerID: "member-1"}, nil)
+			mockRepo.On("ListBookIDs", "list-1").Return([]string{"book-1", "book-2"}, nil)
+			mockRepo.On("SetBooks", "list-1", tt.bookIDs).Return(nil)
+
+			err := NewCollectionUseCase(mockRepo, &MockBookRepository{}).ReorderBooks("member-1", "list-1", tt.bookIDs)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCollectionUseCase_Share(t *testing.T) {
+	token := "existing-token"
+	mockRepo := &MockCollectionRepository{}
+	mockRepo.On("GetByID", "private").Return(&entities.Collection{ID: "private", OwnerID: "member-1"}, nil)
+	mockRepo.On("GetByID", "shared").Return(&entities.Collection{ID: "shared", OwnerID: "member-1", ShareToken: &token}, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*entities.Collection")).Return(nil)
+
+	useCase := NewCollectionUseCase(mockRepo, &MockBookRepository{})
+
+	collection, err := useCase.Share("member-1", "private")
+	assert.NoError(t, err)
+	assert.True(t, collection.IsShared())
+	assert.NotEmpty(t, *collection.ShareToken)
+
+	collection, err = useCase.Share("member-1", "shared")
+	assert.NoError(t, err)
+	assert.Equal(t, "existing-token", *collection.ShareToken)
+	mockRepo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestCollectionUseCase_GetSharedCollection(t *testing.T) {
+	deletedAt := time.Now()
+	token := "token-1"
+	mockRepo := &MockCollectionRepository{}
+	mockBookRepo := &MockBookRepository{}
+	mockRepo.On("GetByShareToken", "token-1").Return(&entities.Collection{ID: "list-1", ShareToken: &token}, nil)
+	mockRepo.On("GetByShareToken", "revoked").Return(nil, nil)
+	mockRepo.On("ListBookIDs", "list-1").Return([]string{"book-2", "deleted", "book-1"}, nil)
+	mockBookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1"}, nil)
+	mockBookRepo.On("GetByID", "book-2").Return(&entities.Book{ID: "book-2"}, nil)
+	mockBookRepo.On("GetByID", "deleted").Return(&entities.Book{ID: "deleted", DeletedAt: &deletedAt}, nil)
+
+	useCase := NewCollectionUseCase(mockRepo, mockBookRepo)
+
+	_, books, err := useCase.GetSharedCollection("token-1")
+	assert.NoError(t, err)
+	assert.Len(t, books, 2)
+	assert.Equal(t, "book-2", books[0].ID)
+	assert.Equal(t, "book-1", books[1].ID)
+
+	_, _, err = useCase.GetSharedCollection("revoked")
+	assert.EqualError(t, err, "collection not found")
+}
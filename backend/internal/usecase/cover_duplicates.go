@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/infrastructure/imaging"
+)
+
+// Bounds of the distance of the cover duplicate report, in bits of the 64 of a perceptual hash
+const (
+	DefaultCoverDuplicateDistance = 6
+	maxCoverDuplicateDistance     = 16
+)
+
+// ErrInvalidCoverDistance is returned for a duplicate report distance out of bounds
+var ErrInvalidCoverDistance = fmt.Errorf("max_distance must be between 0 and %d", maxCoverDuplicateDistance)
+
+// DuplicateReport groups the books whose covers are the same image, or images whose perceptual
+// hashes differ by at most maxDistance bits, and reports the groups of unrelated books: those not
+// all of one work or one series, which are expected to share artwork. Images close to each other
+// are chained, so a group may hold images further apart than maxDistance.
+func (uc *CoverUseCase) DuplicateReport(maxDistance int) (*entities.CoverDuplicateReport, error) {
+	if maxDistance < 0 || maxDistance > maxCoverDuplicateDistance {
+		return nil, ErrInvalidCoverDistance
+	}
+	covers, err := uc.coverRepo.ListAttached()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entities.CoverDuplicateReport{
+		MaxDistance: maxDistance,
+		Covers:      len(covers),
+		Groups:      []entities.CoverDuplicateGroup{},
+		GeneratedAt: uc.clock.Now().UTC(),
+	}
+
+	// Covers are grouped by image first, then images are compared by their perceptual hashes once
+	// each, however many books share them
+	groups := newUnionFind(len(covers))
+	byHash := map[string]int{}
+	byPerceptualHash := map[string]int{}
+	var perceptualHashes []string
+	for i, cover := range covers {
+		if first, ok := byHash[cover.Hash]; ok {
+			groups.union(first, i)
+		} else {
+			byHash[cover.Hash] = i
+		}
+		if cover.PerceptualHash == "" {
+			report.Unhashed++
+			continue
+		}
+		if first, ok := byPerceptualHash[cover.PerceptualHash]; ok {
+			groups.union(first, i)
+			continue
+		}
+		byPerceptualHash[cover.PerceptualHash] = i
+		perceptualHashes = append(perceptualHashes, cover.PerceptualHash)
+	}
+	for i, a := range perceptualHashes {
+		for _, b := range perceptualHashes[i+1:] {
+			if distance := imaging.HashDistance(a, b); distance >= 0 && distance <= maxDistance {
+				groups.union(byPerceptualHash[a], byPerceptualHash[b])
+			}
+		}
+	}
+
+	members := map[int][]entities.BookCover{}
+	var bookIDs []string
+	for i, cover := range covers {
+		root := groups.find(i)
+		members[root] = append(members[root], cover)
+	}
+	for _, group := range members {
+		if len(group) > 1 {
+			for _, cover := range group {
+				bookIDs = append(bookIDs, cover.BookID)
+			}
+		}
+	}
+	if len(bookIDs) == 0 {
+		return report, nil
+	}
+	books, err := uc.bookRepo.FindByIDsWithDeleted(bookIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]entities.Book, len(books))
+	for _, book := range books {
+		byID[book.ID] = book
+	}
+
+	for _, group := range members {
+		if len(group) < 2 || relatedBooks(group, byID) {
+			continue
+		}
+		report.Groups = append(report.Groups, duplicateGroup(group, byID))
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if len(a.Books) != len(b.Books) {
+			return len(a.Books) > len(b.Books)
+		}
+		return a.Books[0].BookID < b.Books[0].BookID
+	})
+	return report, nil
+}
+
+// relatedBooks reports whether the books of a group all belong to one work or one series
+func relatedBooks(group []entities.BookCover, books map[string]entities.Book) bool {
+	sameWork, sameSeries := true, true
+	first := books[group[0].BookID]
+	for _, cover := range group {
+		book := books[cover.BookID]
+		sameWork = sameWork && book.WorkID != nil && first.WorkID != nil && *book.WorkID == *first.WorkID
+		sameSeries = sameSeries && book.SeriesID != nil && first.SeriesID != nil && *book.SeriesID == *first.SeriesID
+	}
+	return sameWork || sameSeries
+}
+
+// duplicateGroup describes a group of covers, its books ordered by ID
+func duplicateGroup(group []entities.BookCover, books map[string]entities.Book) entities.CoverDuplicateGroup {
+	result := entities.CoverDuplicateGroup{Kind: entities.CoverDuplicateIdentical}
+	for i, cover := range group {
+		book := books[cover.BookID]
+		result.Books = append(result.Books, entities.CoverDuplicateBook{
+			BookID:         cover.BookID,
+			Title:          book.Title,
+			Author:         book.Author,
+			ISBN:           book.ISBN,
+			Hash:           cover.Hash,
+			PerceptualHash: cover.PerceptualHash,
+		})
+		if cover.Hash != group[0].Hash {
+			result.Kind = entities.CoverDuplicateSimilar
+		}
+		for _, other := range group[:i] {
+			result.Distance = max(result.Distance, imaging.HashDistance(cover.PerceptualHash, other.PerceptualHash))
+		}
+	}
+	sort.Slice(result.Books, func(i, j int) bool { return result.Books[i].BookID < result.Books[j].BookID })
+	return result
+}
+
+// unionFind partitions indices into groups, merged by union
+type unionFind []int
+
+func newUnionFind(n int) unionFind {
+	parents := make(unionFind, n)
+	for i := range parents {
+		parents[i] = i
+	}
+	return parents
+}
+
+// find returns the index standing for the group of i
+func (u unionFind) find(i int) int {
+	for u[i] != i {
+		u[i] = u[u[i]]
+		i = u[i]
+	}
+	return i
+}
+
+// union merges the groups of i and j
+func (u unionFind) union(i, j int) {
+	u[u.find(i)] = u.find(j)
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoverUseCase_DuplicateReport(t *testing.T) {
+	discworld := "discworld"
+	coverRepo := new(MockCoverRepository)
+	coverRepo.On("ListAttached").Return([]entities.BookCover{
+		// The same image on two unrelated books
+		{BookID: "emma", Hash: "h1", PerceptualHash: "c4d2e8f0b1a39587"},
+		{BookID: "pride", Hash: "h1", PerceptualHash: "c4d2e8f0b1a39587"},
+		// Two files of artwork looking alike
+		{BookID: "eric", Hash: "h2", PerceptualHash: "00000000000000ff"},
+		{BookID: "mort", Hash: "h3", PerceptualHash: "00000000000000fc"},
+		// Volumes of a series sharing their artwork
+		{BookID: "colour", Hash: "h4", PerceptualHash: "ffff0000ffff0000"},
+		{BookID: "light", Hash: "h4", PerceptualHash: "ffff0000ffff0000"},
+		{BookID: "sourcery", Hash: "h5"},
+		{BookID: "wyrd", Hash: "h6", PerceptualHash: "0f0f0f0f0f0f0f0f"},
+	}, nil)
+	bookRepo := new(MockBookRepository)
+	bookRepo.On("FindByIDsWithDeleted", mock.Anything).Return([]entities.Book{
+		{ID: "emma", Title: "Emma", Author: "Jane Austen", ISBN: "9780141439587"},
+		{ID: "pride", Title: "Pride and Prejudice", Author: "Jane Austen", ISBN: "9780141439518"},
+		{ID: "eric", Title: "Eric", Author: "Terry Pratchett", ISBN: "9780575046368"},
+		{ID: "mort", Title: "Mort", Author: "Terry Pratchett", ISBN: "9780552131063"},
+		{ID: "colour", Title: "The Colour of Magic", SeriesID: &discworld},
+		{ID: "light", Title: "The Light Fantastic", SeriesID: &discworld},
+	}, nil)
+	useCase := NewCoverUseCase(bookRepo, coverRepo, 1024, 0, 0)
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	useCase.SetClock(clock.NewFixed(now))
+
+	report, err := useCase.DuplicateReport(DefaultCoverDuplicateDistance)
+	require.NoError(t, err)
+	assert.Equal(t, &entities.CoverDuplicateReport{
+		MaxDistance: DefaultCoverDuplicateDistance,
+		Covers:      8,
+		Unhashed:    1,
+		Groups: []entities.CoverDuplicateGroup{
+			{Kind: entities.CoverDuplicateIdentical, Distance: 0, Books: []entities.CoverDuplicateBook{
+				{BookID: "emma", Title: "Emma", Author: "Jane Austen", ISBN: "9780141439587", Hash: "h1", PerceptualHash: "c4d2e8f0b1a39587"},
+				{BookID: "pride", Title: "Pride and Prejudice", Author: "Jane Austen", ISBN: "9780141439518", Hash: "h1", PerceptualHash: "c4d2e8f0b1a39587"},
+			}},
+			{Kind: entities.CoverDuplicateSimilar, Distance: 2, Books: []entities.CoverDuplicateBook{
+				{BookID: "eric", Title: "Eric", Author: "Terry Pratchett", ISBN: "9780575046368", Hash: "h2", PerceptualHash: "00000000000000ff"},
+				{BookID: "mort", Title: "Mort", Author: "Terry Pratchett", ISBN: "9780552131063", Hash: "h3", PerceptualHash: "00000000000000fc"},
+			}},
+		},
+		GeneratedAt: now,
+	}, report)
+
+	// A distance of 1 tells the two files apart
+	report, err = useCase.DuplicateReport(1)
+	require.NoError(t, err)
+	assert.Len(t, report.Groups, 1)
+
+	_, err = useCase.DuplicateReport(17)
+	assert.ErrorIs(t, err, ErrInvalidCoverDistance)
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"path"
+	"strings"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// CoverImportRequest starts a cover import
+type CoverImportRequest struct {
+	// Archive is the ZIP archive of the images, each named by the ISBN of its book
+	Archive []byte
+	// Actor is who the covers are set for; librarians of a branch can only set those of its books
+	Actor entities.Actor
+}
+
+// CoverImportUseCase sets the covers of many books at once from a ZIP archive of images named by
+// ISBN, in the background. Each image is checked and stored like an upload to
+// PUT /books/{id}/cover, and the job reports on each.
+type CoverImportUseCase struct {
+	jobRepo      repositories.CoverImportJobRepository
+	bookRepo     repositories.BookRepository
+	coverUseCase *CoverUseCase
+	runner       JobRunner
+	clock        clock.Clock
+	// maxBytes bounds the archive and maxFiles the images it holds
+	maxBytes int64
+	maxFiles int
+}
+
+// NewCoverImportUseCase creates a new cover import use case for archives of up to maxBytes
+// holding at most maxFiles images
+func NewCoverImportUseCase(jobRepo repositories.CoverImportJobRepository, bookRepo repositories.BookRepository, coverUseCase *CoverUseCase, maxBytes int64, maxFiles int) *CoverImportUseCase {
+	return &CoverImportUseCase{
+		jobRepo:      jobRepo,
+		bookRepo:     bookRepo,
+		coverUseCase: coverUseCase,
+		clock:        clock.System{},
+		maxBytes:     maxBytes,
+		maxFiles:     maxFiles,
+	}
+}
+
+// SetRunner sets where jobs run; without one they run before Submit returns
+func (uc *CoverImportUseCase) SetRunner(runner JobRunner) {
+	uc.runner = runner
+}
+
+// SetClock replaces the clock jobs are timestamped with
+func (uc *CoverImportUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// MaxBytes returns the size limit of archives
+func (uc *CoverImportUseCase) MaxBytes() int64 {
+	return uc.maxBytes
+}
+
+// Submit checks an archive and starts a job importing its images. The archive is refused before
+// anything is queued when it is not a ZIP file, is too large or holds too many images.
+func (uc *CoverImportUseCase) Submit(ctx context.Context, request *CoverImportRequest) (*entities.CoverImportJob, error) {
+	if int64(len(request.Archive)) > uc.maxBytes {
+		return nil, domainerr.ErrCoverArchiveTooLarge
+	}
+	archive, err := zip.NewReader(bytes.NewReader(request.Archive), int64(len(request.Archive)))
+	if err != nil {
+		return nil, domainerr.ErrInvalidCoverArchive
+	}
+	images := coverImages(archive)
+	switch {
+	case len(images) == 0:
+		return nil, domainerr.ErrInvalidCoverArchive
+	case len(images) > uc.maxFiles:
+		return nil, domainerr.ErrCoverArchiveTooLarge
+	}
+
+	job := &entities.CoverImportJob{
+		Status:      entities.CoverImportQueued,
+		Files:       len(images),
+		Results:     []entities.CoverImportFile{},
+		RequestedBy: request.Actor.UserID,
+		CreatedAt:   uc.clock.Now().UTC(),
+	}
+	if err := uc.jobRepo.Create(job); err != nil {
+		return nil, err
+	}
+
+	actor := request.Actor
+	run := func(ctx context.Context) error {
+		uc.run(ctx, job.ID, images, actor)
+		return nil
+	}
+	if uc.runner == nil {
+		run(ctx)
+		return uc.GetJob(job.ID)
+	}
+	if err := uc.runner(ctx, "cover_import:"+job.ID, run); err != nil {
+		uc.finish(job, err)
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetJob retrieves a cover import job
+func (uc *CoverImportUseCase) GetJob(id string) (*entities.CoverImportJob, error) {
+	job, err := uc.jobRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, domainerr.ErrCoverImportNotFound
+	}
+	return job, nil
+}
+
+// coverImages lists the files of an archive, leaving out directories and the hidden files archivers
+// add, such as __MACOSX/ and .DS_Store
+func coverImages(archive *zip.Reader) []*zip.File {
+	var images []*zip.File
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || strings.HasPrefix(file.Name, "__MACOSX/") || strings.HasPrefix(path.Base(file.Name), ".") {
+			continue
+		}
+		images = append(images, file)
+	}
+	return images
+}
+
+// run imports the images of a job one at a time, recording the outcome of each
+func (uc *CoverImportUseCase) run(ctx context.Context, id string, images []*zip.File, actor entities.Actor) {
+	job, err := uc.GetJob(id)
+	if err != nil {
+		return
+	}
+	job.Status = entities.CoverImportRunning
+	uc.jobRepo.Update(job)
+
+	covers := uc.coverUseCase.As(actor)
+	for i, image := range images {
+		if err := ctx.Err(); err != nil {
+			uc.finish(job, fmt.Errorf("stopped after %d of %d images: %w", i, len(images), err))
+			return
+		}
+		result := uc.importCover(covers, image)
+		if result.Status == entities.CoverImportFileImported {
+			job.Imported++
+		} else {
+			job.Failed++
+		}
+		job.Results = append(job.Results, result)
+		// Progress shows while the job runs
+		uc.jobRepo.Update(job)
+	}
+	uc.finish(job, nil)
+}
+
+// importCover sets the cover of the book an image is named after
+func (uc *CoverImportUseCase) importCover(covers *CoverUseCase, image *zip.File) entities.CoverImportFile {
+	name := path.Base(image.Name)
+	extension := path.Ext(name)
+	result := entities.CoverImportFile{File: image.Name, ISBN: entities.NormalizeISBN(strings.TrimSuffix(name, extension))}
+	fail := func(err error) entities.CoverImportFile {
+		result.Status = entities.CoverImportFileFailed
+		result.Error = err.Error()
+		if e, ok := domainerr.Lookup(err); ok {
+			result.Code = e.Code
+		}
+		return result
+	}
+
+	if !isbnLike(result.ISBN) {
+		return fail(domainerr.ErrNoISBNInFileName)
+	}
+	book, err := uc.bookRepo.FindByISBN(result.ISBN)
+	if err != nil {
+		return fail(err)
+	}
+	if book == nil {
+		return fail(domainerr.ErrBookNotFound)
+	}
+	result.BookID = book.ID
+
+	// The archive header tells the size, and as it may lie, reading also stops one byte past the limit
+	if image.UncompressedSize64 > uint64(covers.MaxBytes()) {
+		return fail(domainerr.ErrCoverTooLarge)
+	}
+	file, err := image.Open()
+	if err != nil {
+		return fail(fmt.Errorf("failed to read %s: %w", image.Name, err))
+	}
+	data, err := io.ReadAll(io.LimitReader(file, covers.MaxBytes()+1))
+	file.Close()
+	if err != nil {
+		return fail(fmt.Errorf("failed to read %s: %w", image.Name, err))
+	}
+
+	// The extension stands in for the content type an upload would declare
+	if _, err := covers.SetCover(book.ID, data, mime.TypeByExtension(strings.ToLower(extension))); err != nil {
+		return fail(err)
+	}
+	result.Status = entities.CoverImportFileImported
+	return result
+}
+
+// isbnLike reports whether a normalized ISBN has the length and characters of an ISBN-10 or
+// ISBN-13, whatever its check digit
+func isbnLike(isbn string) bool {
+	switch len(isbn) {
+	case 10:
+		return strings.Trim(isbn[:9], "0123456789") == "" && strings.Trim(isbn[9:], "0123456789X") == ""
+	case 13:
+		return strings.Trim(isbn, "0123456789") == ""
+	}
+	return false
+}
+
+// finish records the outcome of a job
+func (uc *CoverImportUseCase) finish(job *entities.CoverImportJob, err error) {
+	completedAt := uc.clock.Now().UTC()
+	job.CompletedAt = &completedAt
+	job.Status = entities.CoverImportCompleted
+	if err != nil {
+		job.Status = entities.CoverImportFailed
+		job.Error = err.Error()
+	}
+	uc.jobRepo.Update(job)
+	log.Printf("Cover import %s %s: %d of %d images imported, %d failed.", job.ID, job.Status, job.Imported, job.Files, job.Failed)
+}
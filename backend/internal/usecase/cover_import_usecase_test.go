@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// zipArchive packs files into a ZIP archive by name, in the order given
+func zipArchive(t *testing.T, files ...[2]string) []byte {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range files {
+		w, err := archive.Create(file[0])
+		require.NoError(t, err)
+		_, err = w.Write([]byte(file[1]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	return buf.Bytes()
+}
+
+func TestCoverImportUseCase_Submit(t *testing.T) {
+	bookRepo := new(MockBookRepository)
+	bookRepo.On("FindByISBN", "9780141439518").Return(&entities.Book{ID: "pride"}, nil)
+	bookRepo.On("FindByISBN", "9780141439600").Return(&entities.Book{ID: "emma"}, nil)
+	bookRepo.On("FindByISBN", "9780000000002").Return(nil, nil)
+	bookRepo.On("GetByID", "pride").Return(&entities.Book{ID: "pride"}, nil)
+	bookRepo.On("GetByID", "emma").Return(&entities.Book{ID: "emma"}, nil)
+	bookRepo.On("SetCoverPalette", "pride", []string{"#000000"}).Return(nil)
+	image := encodeCover(t, 4, 6)
+	sum := sha256.Sum256(image)
+	coverRepo := new(MockCoverRepository)
+	coverRepo.On("Attach", "pride", hex.EncodeToString(sum[:]), "0000000000000000", "image/png", image, (*entities.ScanResult)(nil)).Return(&entities.BookCover{BookID: "pride"}, nil)
+	useCase := NewCoverImportUseCase(repository.NewInMemoryCoverImportJobRepository(10), bookRepo, NewCoverUseCase(bookRepo, coverRepo, 1024, 10, 100), 1<<20, 10)
+
+	archive := zipArchive(t,
+		[2]string{"covers/978-0-14-143951-8.png", string(image)},
+		[2]string{"covers/", ""},
+		[2]string{"__MACOSX/covers/._978-0-14-143951-8.png", "resource fork"},
+		[2]string{"covers/.DS_Store", "finder"},
+		[2]string{"covers/9780141439600.jpg", string(image)},
+		[2]string{"covers/9780000000002.png", string(image)},
+		[2]string{"README.txt", "Covers of the autumn catalog"},
+	)
+	job, err := useCase.Submit(context.Background(), &CoverImportRequest{Archive: archive, Actor: entities.Actor{UserID: "librarian-1"}})
+	require.NoError(t, err)
+	assert.Equal(t, entities.CoverImportCompleted, job.Status)
+	assert.Equal(t, "librarian-1", job.RequestedBy)
+	assert.Equal(t, 4, job.Files)
+	assert.Equal(t, 1, job.Imported)
+	assert.Equal(t, 3, job.Failed)
+	assert.Equal(t, []entities.CoverImportFile{
+		{File: "covers/978-0-14-143951-8.png", ISBN: "9780141439518", BookID: "pride", Status: entities.CoverImportFileImported},
+		{File: "covers/9780141439600.jpg", ISBN: "9780141439600", BookID: "emma", Status: entities.CoverImportFileFailed, Code: "cover_type_mismatch", Error: domainerr.ErrCoverTypeMismatch.Error()},
+		{File: "covers/9780000000002.png", ISBN: "9780000000002", Status: entities.CoverImportFileFailed, Code: "book_not_found", Error: domainerr.ErrBookNotFound.Error()},
+		{File: "README.txt", ISBN: "README", Status: entities.CoverImportFileFailed, Code: "no_isbn_in_file_name", Error: domainerr.ErrNoISBNInFileName.Error()},
+	}, job.Results)
+	coverRepo.AssertNumberOfCalls(t, "Attach", 1)
+
+	stored, err := useCase.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, job, stored)
+	_, err = useCase.GetJob("missing")
+	assert.ErrorIs(t, err, domainerr.ErrCoverImportNotFound)
+}
+
+func TestCoverImportUseCase_SubmitQueued(t *testing.T) {
+	bookRepo := new(MockBookRepository)
+	bookRepo.On("FindByISBN", mock.Anything).Return(nil, nil)
+	useCase := NewCoverImportUseCase(repository.NewInMemoryCoverImportJobRepository(10), bookRepo, NewCoverUseCase(bookRepo, new(MockCoverRepository), 1024, 10, 100), 1<<20, 10)
+	var queued func(ctx context.Context) error
+	useCase.SetRunner(func(ctx context.Context, name string, run func(ctx context.Context) error) error {
+		queued = run
+		return nil
+	})
+
+	job, err := useCase.Submit(context.Background(), &CoverImportRequest{Archive: zipArchive(t, [2]string{"9780000000002.png", "png"})})
+	require.NoError(t, err)
+	assert.Equal(t, entities.CoverImportQueued, job.Status)
+	require.NoError(t, queued(context.Background()))
+	job, err = useCase.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.CoverImportCompleted, job.Status)
+	assert.Equal(t, 1, job.Failed)
+
+	// Jobs the runner refuses are failed right away
+	useCase.SetRunner(func(ctx context.Context, name string, run func(ctx context.Context) error) error {
+		return domainerr.ErrQueueFull
+	})
+	_, err = useCase.Submit(context.Background(), &CoverImportRequest{Archive: zipArchive(t, [2]string{"9780000000002.png", "png"})})
+	assert.ErrorIs(t, err, domainerr.ErrQueueFull)
+}
+
+func TestCoverImportUseCase_SubmitInvalid(t *testing.T) {
+	bookRepo := new(MockBookRepository)
+	useCase := NewCoverImportUseCase(repository.NewInMemoryCoverImportJobRepository(10), bookRepo, NewCoverUseCase(bookRepo, new(MockCoverRepository), 1024, 10, 100), 1024, 2)
+
+	tests := []struct {
+		name    string
+		archive []byte
+		err     error
+	}{
+		{name: "not a ZIP file", archive: []byte("PK but not quite"), err: domainerr.ErrInvalidCoverArchive},
+		{name: "only directories and hidden files", archive: zipArchive(t, [2]string{"covers/", ""}, [2]string{".DS_Store", ""}), err: domainerr.ErrInvalidCoverArchive},
+		{name: "too many images", archive: zipArchive(t, [2]string{"a.png", ""}, [2]string{"b.png", ""}, [2]string{"c.png", ""}), err: domainerr.ErrCoverArchiveTooLarge},
+		{name: "too large", archive: make([]byte, 1025), err: domainerr.ErrCoverArchiveTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := useCase.Submit(context.Background(), &CoverImportRequest{Archive: tt.archive})
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
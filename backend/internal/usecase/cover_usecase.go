@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/url"
+	"strings"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+	"library-management-system/internal/infrastructure/imaging"
+)
+
+// CoverFetcher downloads cover images by URL
+type CoverFetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+// coverPaletteSize is the number of colours kept of each cover
+const coverPaletteSize = 5
+
+// CoverUseCase handles the cover images of books. Images are addressed by the SHA-256 of their
+// content, so uploading the same artwork for several books stores it once.
+type CoverUseCase struct {
+	bookRepo  repositories.BookRepository
+	coverRepo repositories.CoverRepository
+	// maxBytes is the size of the largest image accepted
+	maxBytes int64
+	// maxDimension and maxPixels bound the decoded image, zero leaving it unbounded
+	maxDimension int
+	maxPixels    int64
+	// scans checks images for malware before they are stored when set
+	scans *UploadScanUseCase
+	// fetcher downloads the images of covers set by URL when set
+	fetcher CoverFetcher
+	// actor is who covers are changed for; see As
+	actor entities.Actor
+	clock clock.Clock
+}
+
+// NewCoverUseCase creates a new cover use case
+func NewCoverUseCase(bookRepo repositories.BookRepository, coverRepo repositories.CoverRepository, maxBytes int64, maxDimension int, maxPixels int64) *CoverUseCase {
+	return &CoverUseCase{
+		bookRepo:     bookRepo,
+		coverRepo:    coverRepo,
+		maxBytes:     maxBytes,
+		maxDimension: maxDimension,
+		maxPixels:    maxPixels,
+		clock:        clock.System{},
+	}
+}
+
+// SetClock replaces the clock reports are dated with
+func (uc *CoverUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// SetUploadScanUseCase enables scanning uploaded images for malware
+func (uc *CoverUseCase) SetUploadScanUseCase(scans *UploadScanUseCase) {
+	uc.scans = scans
+}
+
+// SetFetcher enables setting covers from the URL of their image
+func (uc *CoverUseCase) SetFetcher(fetcher CoverFetcher) {
+	uc.fetcher = fetcher
+}
+
+// As returns the use case changing covers for actor; librarians of a branch can only change the
+// covers of its books
+func (uc *CoverUseCase) As(actor entities.Actor) *CoverUseCase {
+	scoped := *uc
+	scoped.actor = actor
+	return &scoped
+}
+
+// MaxBytes returns the size of the largest image accepted
+func (uc *CoverUseCase) MaxBytes() int64 {
+	return uc.maxBytes
+}
+
+// SetCover makes an image the cover of a book, replacing its previous cover. declaredType is the
+// content type the client sent, if any; an image type must match the content.
+func (uc *CoverUseCase) SetCover(bookID string, data []byte, declaredType string) (*entities.BookCover, error) {
+	if err := uc.requireChangeableBook(bookID); err != nil {
+		return nil, err
+	}
+	// The file is scanned as uploaded, before anything is made of it
+	var scan *entities.ScanResult
+	if uc.scans != nil {
+		var err error
+		if scan, err = uc.scans.Scan("cover of book "+bookID, data); err != nil {
+			return nil, err
+		}
+	}
+	data, info, err := uc.checkImage(data, declaredType)
+	if err != nil {
+		return nil, err
+	}
+
+	// The palette lets pages paint a placeholder in the colours of the cover while it loads
+	palette, err := imaging.Palette(data, info.Format, coverPaletteSize)
+	if err != nil {
+		return nil, coverImageError(err)
+	}
+	// The perceptual hash finds the same artwork under another file, for the duplicate report
+	perceptualHash, err := imaging.PerceptualHash(data, info.Format)
+	if err != nil {
+		return nil, coverImageError(err)
+	}
+
+	// Hashing the stripped image lets copies differing only by their metadata share a file
+	sum := sha256.Sum256(data)
+	cover, err := uc.coverRepo.Attach(bookID, hex.EncodeToString(sum[:]), perceptualHash, info.Format.ContentType(), data, scan)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.bookRepo.SetCoverPalette(bookID, palette); err != nil {
+		return nil, err
+	}
+	return cover, nil
+}
+
+// SetCoverFromURL downloads an image and makes it the cover of a book, checked like an upload
+func (uc *CoverUseCase) SetCoverFromURL(ctx context.Context, bookID, imageURL string) (*entities.BookCover, error) {
+	parsedURL, err := url.Parse(imageURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return nil, domainerr.ErrInvalidCoverURL
+	}
+	if uc.fetcher == nil {
+		return nil, domainerr.ErrCoverFetchDisabled
+	}
+	// Nothing is downloaded for books the cover could not be set on
+	if err := uc.requireChangeableBook(bookID); err != nil {
+		return nil, err
+	}
+
+	data, err := uc.fetcher.Fetch(ctx, imageURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainerr.ErrCoverFetchFailed, err)
+	}
+	return uc.SetCover(bookID, data, "")
+}
+
+// checkImage validates an uploaded image and returns it without its metadata. Its format comes
+// from its magic bytes and its dimensions from its header, which are checked before the image is
+// decoded so that a small file expanding to a huge bitmap is refused without being decoded.
+func (uc *CoverUseCase) checkImage(data []byte, declaredType string) ([]byte, imaging.Info, error) {
+	if int64(len(data)) > uc.maxBytes {
+		return nil, imaging.Info{}, domainerr.ErrCoverTooLarge
+	}
+	info, err := imaging.Inspect(data)
+	if err != nil {
+		return nil, imaging.Info{}, coverImageError(err)
+	}
+	if declared, _, err := mime.ParseMediaType(declaredType); err == nil && strings.HasPrefix(declared, "image/") &&
+		declared != info.Format.ContentType() && !(declared == "image/jpg" && info.Format == imaging.JPEG) {
+		return nil, imaging.Info{}, domainerr.ErrCoverTypeMismatch
+	}
+	if uc.maxDimension > 0 && (info.Width > uc.maxDimension || info.Height > uc.maxDimension) ||
+		uc.maxPixels > 0 && info.Pixels() > uc.maxPixels {
+		return nil, imaging.Info{}, domainerr.ErrCoverDimensionsTooLarge
+	}
+	if err := imaging.Verify(data, info); err != nil {
+		return nil, imaging.Info{}, coverImageError(err)
+	}
+	stripped, err := imaging.StripMetadata(data, info.Format)
+	if err != nil {
+		return nil, imaging.Info{}, coverImageError(err)
+	}
+	return stripped, info, nil
+}
+
+// coverImageError maps the errors of the imaging package to the errors of cover uploads
+func coverImageError(err error) error {
+	if errors.Is(err, imaging.ErrUnknownFormat) {
+		return domainerr.ErrUnsupportedCoverType
+	}
+	return domainerr.ErrMalformedCover
+}
+
+// GetCover returns the cover of a book
+func (uc *CoverUseCase) GetCover(bookID string) (*entities.BookCover, error) {
+	if err := uc.requireBook(bookID); err != nil {
+		return nil, err
+	}
+	cover, err := uc.coverRepo.GetByBookID(bookID)
+	if err != nil {
+		return nil, err
+	}
+	if cover == nil {
+		return nil, domainerr.ErrCoverNotFound
+	}
+	return cover, nil
+}
+
+// OpenCover returns the cover of a book along with its image
+func (uc *CoverUseCase) OpenCover(bookID string) (*entities.BookCover, []byte, error) {
+	cover, err := uc.GetCover(bookID)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := uc.coverRepo.Open(cover.Hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cover, data, nil
+}
+
+// DeleteCover removes the cover of a book; its image is deleted unless other books use it
+func (uc *CoverUseCase) DeleteCover(bookID string) error {
+	if err := uc.requireChangeableBook(bookID); err != nil {
+		return err
+	}
+	detached, err := uc.coverRepo.Detach(bookID)
+	if err != nil {
+		return err
+	}
+	if !detached {
+		return domainerr.ErrCoverNotFound
+	}
+	return uc.bookRepo.SetCoverPalette(bookID, nil)
+}
+
+// requireBook checks that a book exists and is not deleted
+func (uc *CoverUseCase) requireBook(bookID string) error {
+	if bookID == "" {
+		return errors.New("book ID is required")
+	}
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return err
+	}
+	if book == nil {
+		return domainerr.ErrBookNotFound
+	}
+	return nil
+}
+
+// requireChangeableBook checks that a book exists and that the actor may change it
+func (uc *CoverUseCase) requireChangeableBook(bookID string) error {
+	if err := uc.requireBook(bookID); err != nil {
+		return err
+	}
+	if !uc.actor.BranchScoped() {
+		return nil
+	}
+	book, err := uc.bookRepo.GetByIDInBranch(bookID, uc.actor.BranchID)
+	if err != nil {
+		return err
+	}
+	if book == nil {
+		return domainerr.ErrBranchAccessDenied
+	}
+	return nil
+}
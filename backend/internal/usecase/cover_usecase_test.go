@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/png"
+	"testing"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockCoverRepository is a mock implementation of CoverRepository
+type MockCoverRepository struct {
+	mock.Mock
+}
+
+func (m *MockCoverRepository) Attach(bookID, hash, perceptualHash, contentType string, data []byte, scan *entities.ScanResult) (*entities.BookCover, error) {
+	args := m.Called(bookID, hash, perceptualHash, contentType, data, scan)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.BookCover), args.Error(1)
+}
+
+func (m *MockCoverRepository) Detach(bookID string) (bool, error) {
+	args := m.Called(bookID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCoverRepository) GetByBookID(bookID string) (*entities.BookCover, error) {
+	args := m.Called(bookID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.BookCover), args.Error(1)
+}
+
+func (m *MockCoverRepository) ListAttached() ([]entities.BookCover, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.BookCover), args.Error(1)
+}
+
+func (m *MockCoverRepository) Open(hash string) ([]byte, error) {
+	args := m.Called(hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+// encodeCover encodes a blank PNG image of the given size
+func encodeCover(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))))
+	return buf.Bytes()
+}
+
+func TestCoverUseCase_SetCover(t *testing.T) {
+	bookRepo := new(MockBookRepository)
+	coverRepo := new(MockCoverRepository)
+	bookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1"}, nil)
+	data := encodeCover(t, 4, 6)
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	cover := &entities.BookCover{BookID: "book-1", Hash: hash, ContentType: "image/png", References: 2}
+	coverRepo.On("Attach", "book-1", hash, "0000000000000000", "image/png", data, (*entities.ScanResult)(nil)).Return(cover, nil)
+	bookRepo.On("SetCoverPalette", "book-1", []string{"#000000"}).Return(nil)
+	useCase := NewCoverUseCase(bookRepo, coverRepo, 1024, 10, 100)
+
+	result, err := useCase.SetCover("book-1", data, "image/png")
+	require.NoError(t, err)
+	assert.Equal(t, cover, result)
+	coverRepo.AssertExpectations(t)
+	bookRepo.AssertExpectations(t)
+}
+
+func TestCoverUseCase_SetCoverRejected(t *testing.T) {
+	bookRepo := new(MockBookRepository)
+	coverRepo := new(MockCoverRepository)
+	bookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1"}, nil)
+	bookRepo.On("GetByID", "missing").Return(nil, nil)
+	useCase := NewCoverUseCase(bookRepo, coverRepo, 1024, 10, 60)
+	data := encodeCover(t, 4, 6)
+
+	_, err := useCase.SetCover("missing", data, "")
+	assert.ErrorIs(t, err, domainerr.ErrBookNotFound)
+	_, err = useCase.SetCover("book-1", make([]byte, 1025), "")
+	assert.ErrorIs(t, err, domainerr.ErrCoverTooLarge)
+	_, err = useCase.SetCover("book-1", []byte("%PDF-1.7"), "image/png")
+	assert.ErrorIs(t, err, domainerr.ErrUnsupportedCoverType)
+	_, err = useCase.SetCover("book-1", data, "image/jpeg")
+	assert.ErrorIs(t, err, domainerr.ErrCoverTypeMismatch)
+	_, err = useCase.SetCover("book-1", data[:len(data)-16], "")
+	assert.ErrorIs(t, err, domainerr.ErrMalformedCover)
+	_, err = useCase.SetCover("book-1", encodeCover(t, 11, 2), "")
+	assert.ErrorIs(t, err, domainerr.ErrCoverDimensionsTooLarge)
+	_, err = useCase.SetCover("book-1", encodeCover(t, 8, 8), "")
+	assert.ErrorIs(t, err, domainerr.ErrCoverDimensionsTooLarge)
+	coverRepo.AssertNotCalled(t, "Attach", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCoverUseCase_SetCoverFromURL(t *testing.T) {
+	bookRepo := new(MockBookRepository)
+	coverRepo := new(MockCoverRepository)
+	bookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1"}, nil)
+	bookRepo.On("GetByID", "missing").Return(nil, nil)
+	data := encodeCover(t, 4, 6)
+	sum := sha256.Sum256(data)
+	cover := &entities.BookCover{BookID: "book-1", Hash: hex.EncodeToString(sum[:]), ContentType: "image/png"}
+	coverRepo.On("Attach", "book-1", cover.Hash, "0000000000000000", "image/png", data, (*entities.ScanResult)(nil)).Return(cover, nil)
+	bookRepo.On("SetCoverPalette", "book-1", []string{"#000000"}).Return(nil)
+	useCase := NewCoverUseCase(bookRepo, coverRepo, 1024, 10, 100)
+
+	_, err := useCase.SetCoverFromURL(context.Background(), "book-1", "https://covers.example.org/cover.png")
+	assert.ErrorIs(t, err, domainerr.ErrCoverFetchDisabled)
+
+	var fetched []string
+	useCase.SetFetcher(fetchFunc(func(ctx context.Context, url string) ([]byte, error) {
+		fetched = append(fetched, url)
+		if url == "https://covers.example.org/cover.png" {
+			return data, nil
+		}
+		return nil, errors.New("unexpected status 404")
+	}))
+
+	result, err := useCase.SetCoverFromURL(context.Background(), "book-1", "https://covers.example.org/cover.png")
+	require.NoError(t, err)
+	assert.Equal(t, cover, result)
+
+	_, err = useCase.SetCoverFromURL(context.Background(), "book-1", "https://covers.example.org/missing.png")
+	assert.ErrorIs(t, err, domainerr.ErrCoverFetchFailed)
+	assert.EqualError(t, err, "cover could not be downloaded: unexpected status 404")
+	_, err = useCase.SetCoverFromURL(context.Background(), "missing", "https://covers.example.org/cover.png")
+	assert.ErrorIs(t, err, domainerr.ErrBookNotFound)
+	for _, url := range []string{"file:///etc/passwd", "covers.example.org/cover.png", "https:///cover.png"} {
+		_, err = useCase.SetCoverFromURL(context.Background(), "book-1", url)
+		assert.ErrorIs(t, err, domainerr.ErrInvalidCoverURL, url)
+	}
+	assert.Len(t, fetched, 2)
+}
+
+func TestCoverUseCase_DeleteCover(t *testing.T) {
+	bookRepo := new(MockBookRepository)
+	coverRepo := new(MockCoverRepository)
+	bookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1"}, nil)
+	coverRepo.On("Detach", "book-1").Return(true, nil).Once()
+	coverRepo.On("Detach", "book-1").Return(false, nil).Once()
+	bookRepo.On("SetCoverPalette", "book-1", []string(nil)).Return(nil).Once()
+	useCase := NewCoverUseCase(bookRepo, coverRepo, 1024, 0, 0)
+
+	require.NoError(t, useCase.DeleteCover("book-1"))
+	assert.ErrorIs(t, useCase.DeleteCover("book-1"), domainerr.ErrCoverNotFound)
+	bookRepo.AssertExpectations(t)
+}
+
+func TestBookUseCase_HardDeleteBookReleasesCover(t *testing.T) {
+	bookRepo := new(MockBookRepository)
+	coverRepo := new(MockCoverRepository)
+	bookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1"}, nil)
+	bookRepo.On("HardDelete", "book-1").Return(nil)
+	coverRepo.On("Detach", "book-1").Return(true, nil)
+	useCase := NewBookUseCase(bookRepo)
+	useCase.SetCoverRepository(coverRepo)
+
+	require.NoError(t, useCase.HardDeleteBook("book-1"))
+	coverRepo.AssertExpectations(t)
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidDateRange is returned for a range not written as dates, or ending before it starts
+var ErrInvalidDateRange = errors.New("from and to must be dates such as 2024-01-15, from no later than to")
+
+// dateRange parses a range of UTC dates, both included, into the time it starts at and the time
+// it ends before. Without from it starts at the zero time, and without to it ends with today.
+func dateRange(from, to string, now time.Time) (start, end time.Time, err error) {
+	if from != "" {
+		if start, err = time.Parse(time.DateOnly, from); err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidDateRange
+		}
+	}
+	end = now.UTC().Truncate(24 * time.Hour)
+	if to != "" {
+		if end, err = time.Parse(time.DateOnly, to); err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidDateRange
+		}
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+	return start, end.AddDate(0, 0, 1), nil
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/pagetoken"
+	"library-management-system/internal/domain/repositories"
+)
+
+// defaultDeadLetters is how many dead letters are listed by default
+const defaultDeadLetters = 50
+
+// JobRequeuer enqueues a job again from its kind and payload
+type JobRequeuer interface {
+	Requeue(kind, name string, payload []byte, maxAttempts int) error
+}
+
+// DeadLetterUseCase keeps background jobs that failed all their attempts and lets admins requeue or discard them
+type DeadLetterUseCase struct {
+	deadLetterRepo repositories.DeadLetterRepository
+	queues         map[string]JobRequeuer
+	clock          clock.Clock
+	cursors        pageCursors
+}
+
+// NewDeadLetterUseCase creates a new dead letter use case
+func NewDeadLetterUseCase(deadLetterRepo repositories.DeadLetterRepository) *DeadLetterUseCase {
+	return &DeadLetterUseCase{
+		deadLetterRepo: deadLetterRepo,
+		queues:         make(map[string]JobRequeuer),
+		clock:          clock.System{},
+		cursors:        pageCursors{listing: "dead_letters"},
+	}
+}
+
+// SetCursorSigner signs the cursors of the pages of dead letters with signer
+func (uc *DeadLetterUseCase) SetCursorSigner(signer *pagetoken.Signer) {
+	uc.cursors.signer = signer
+}
+
+// SetClock replaces the clock failures are timestamped with
+func (uc *DeadLetterUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// AddQueue makes the dead letters of a queue requeueable on it
+func (uc *DeadLetterUseCase) AddQueue(name string, queue JobRequeuer) {
+	uc.queues[name] = queue
+}
+
+// Record stores a failed job
+func (uc *DeadLetterUseCase) Record(deadLetter *entities.DeadLetter) error {
+	if deadLetter.Queue == "" || deadLetter.Kind == "" {
+		return errors.New("queue and kind are required")
+	}
+	if deadLetter.FailedAt.IsZero() {
+		deadLetter.FailedAt = uc.clock.Now().UTC()
+	}
+	return uc.deadLetterRepo.Create(deadLetter)
+}
+
+// ListDeadLetters retrieves the latest dead letters, optionally of a single queue, from the start
+// or after the page a cursor ends. It returns the cursor of the next page, empty on the last page.
+func (uc *DeadLetterUseCase) ListDeadLetters(queue string, limit int, cursor string) ([]entities.DeadLetter, string, error) {
+	if limit < 1 {
+		limit = defaultDeadLetters
+	}
+	query := pagetoken.Query("queue=" + queue)
+	after, err := uc.cursors.after(cursor, query)
+	if err != nil {
+		return nil, "", err
+	}
+
+	deadLetters, err := uc.deadLetterRepo.List(queue, after, limit+1)
+	if err != nil || len(deadLetters) <= limit {
+		return deadLetters, "", err
+	}
+	deadLetters = deadLetters[:limit]
+	last := deadLetters[limit-1]
+	return deadLetters, uc.cursors.next(query, last.FailedAt, last.ID), nil
+}
+
+// GetDeadLetter retrieves a dead letter by ID
+func (uc *DeadLetterUseCase) GetDeadLetter(id string) (*entities.DeadLetter, error) {
+	if id == "" {
+		return nil, errors.New("dead letter ID is required")
+	}
+
+	deadLetter, err := uc.deadLetterRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if deadLetter == nil {
+		return nil, domainerr.ErrDeadLetterNotFound
+	}
+	return deadLetter, nil
+}
+
+// RequeueDeadLetter enqueues a failed job again with its original attempts and removes it from the dead letters.
+// If it fails again it comes back as a new dead letter.
+func (uc *DeadLetterUseCase) RequeueDeadLetter(id string) error {
+	deadLetter, err := uc.GetDeadLetter(id)
+	if err != nil {
+		return err
+	}
+
+	queue, ok := uc.queues[deadLetter.Queue]
+	if !ok {
+		return fmt.Errorf("queue %s is not available", deadLetter.Queue)
+	}
+	if err := queue.Requeue(deadLetter.Kind, deadLetter.Name, []byte(deadLetter.Payload), deadLetter.MaxAttempts); err != nil {
+		return err
+	}
+	return uc.deadLetterRepo.Delete(id)
+}
+
+// DiscardDeadLetter deletes a failed job for good
+func (uc *DeadLetterUseCase) DiscardDeadLetter(id string) error {
+	if _, err := uc.GetDeadLetter(id); err != nil {
+		return err
+	}
+	return uc.deadLetterRepo.Delete(id)
+}
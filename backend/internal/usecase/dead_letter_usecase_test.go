@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDeadLetterRepository is a mock implementation of DeadLetterRepository
+type MockDeadLetterRepository struct {
+	mock.Mock
+}
+
+func (m *MockDeadLetterRepository) Create(deadLetter *entities.DeadLetter) error {
+	args := m.Called(deadLetter)
+	return args.Error(0)
+}
+
+func (m *MockDeadLetterRepository) GetByID(id string) (*entities.DeadLetter, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.DeadLetter), args.Error(1)
+}
+
+func (m *MockDeadLetterRepository) List(queue string, after *repositories.PageAfter, limit int) ([]entities.DeadLetter, error) {
+	args := m.Called(queue, after, limit)
+	return args.Get(0).([]entities.DeadLetter), args.Error(1)
+}
+
+func (m *MockDeadLetterRepository) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+// recordingRequeuer records the jobs it is asked to requeue and fails when err is set
+type recordingRequeuer struct {
+	err      error
+	requeued []string
+}
+
+func (r *recordingRequeuer) Requeue(kind, name string, payload []byte, maxAttempts int) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.requeued = append(r.requeued, kind+":"+name+":"+string(payload))
+	return nil
+}
+
+func TestDeadLetterUseCase_Record(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	repo := &MockDeadLetterRepository{}
+	uc := NewDeadLetterUseCase(repo)
+	uc.SetClock(clock.NewFixed(now))
+
+	repo.On("Create", mock.MatchedBy(func(d *entities.DeadLetter) bool { return d.FailedAt.Equal(now) })).Return(nil)
+	assert.NoError(t, uc.Record(&entities.DeadLetter{Queue: "notifications", Kind: "notification", Name: "notify:webhook"}))
+	assert.EqualError(t, uc.Record(&entities.DeadLetter{Queue: "notifications"}), "queue and kind are required")
+	repo.AssertExpectations(t)
+}
+
+func TestDeadLetterUseCase_ListDeadLetters(t *testing.T) {
+	repo := &MockDeadLetterRepository{}
+	uc := NewDeadLetterUseCase(repo)
+
+	repo.On("List", "", (*repositories.PageAfter)(nil), defaultDeadLetters+1).Return([]entities.DeadLetter{}, nil)
+	repo.On("List", "notifications", (*repositories.PageAfter)(nil), 1001).Return([]entities.DeadLetter{}, nil)
+
+	_, next, err := uc.ListDeadLetters("", 0, "")
+	assert.NoError(t, err)
+	assert.Empty(t, next)
+	_, _, err = uc.ListDeadLetters("notifications", 1000, "")
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestDeadLetterUseCase_RequeueDeadLetter(t *testing.T) {
+	deadLetter := &entities.DeadLetter{ID: "dl-1", Queue: "notifications", Kind: "notification", Name: "notify:webhook", Payload: `{"channel":"webhook"}`, MaxAttempts: 3}
+
+	tests := []struct {
+		name          string
+		id            string
+		queueErr      error
+		mockSetup     func(*MockDeadLetterRepository)
+		expectedError string
+		requeued      int
+	}{
+		{
+			name: "requeues and removes the dead letter",
+			id:   "dl-1",
+			mockSetup: func(repo *MockDeadLetterRepository) {
+				repo.On("GetByID", "dl-1").Return(deadLetter, nil)
+				repo.On("Delete", "dl-1").Return(nil)
+			},
+			requeued: 1,
+		},
+		{
+			name:     "keeps the dead letter when the queue refuses it",
+			id:       "dl-1",
+			queueErr: errors.New("job queue is full"),
+			mockSetup: func(repo *MockDeadLetterRepository) {
+				repo.On("GetByID", "dl-1").Return(deadLetter, nil)
+			},
+			expectedError: "job queue is full",
+		},
+		{
+			name: "unknown queue",
+			id:   "dl-2",
+			mockSetup: func(repo *MockDeadLetterRepository) {
+				repo.On("GetByID", "dl-2").Return(&entities.DeadLetter{ID: "dl-2", Queue: "imports", Kind: "import"}, nil)
+			},
+			expectedError: "queue imports is not available",
+		},
+		{
+			name: "not found",
+			id:   "missing",
+			mockSetup: func(repo *MockDeadLetterRepository) {
+				repo.On("GetByID", "missing").Return(nil, nil)
+			},
+			expectedError: "dead letter not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockDeadLetterRepository{}
+			tt.mockSetup(repo)
+			requeuer := &recordingRequeuer{err: tt.queueErr}
+			uc := NewDeadLetterUseCase(repo)
+			uc.AddQueue("notifications", requeuer)
+
+			err := uc.RequeueDeadLetter(tt.id)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Len(t, requeuer.requeued, tt.requeued)
+			repo.AssertExpectations(t)
+			if tt.expectedError != "" {
+				repo.AssertNotCalled(t, "Delete", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestDeadLetterUseCase_DiscardDeadLetter(t *testing.T) {
+	repo := &MockDeadLetterRepository{}
+	uc := NewDeadLetterUseCase(repo)
+
+	repo.On("GetByID", "dl-1").Return(&entities.DeadLetter{ID: "dl-1"}, nil)
+	repo.On("Delete", "dl-1").Return(nil)
+	repo.On("GetByID", "missing").Return(nil, nil)
+
+	assert.NoError(t, uc.DiscardDeadLetter("dl-1"))
+	assert.EqualError(t, uc.DiscardDeadLetter("missing"), "dead letter not found")
+	repo.AssertExpectations(t)
+}
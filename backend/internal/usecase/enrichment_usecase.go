@@ -0,0 +1,351 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/events"
+	"library-management-system/internal/domain/repositories"
+)
+
+// Enrichment job limits
+const (
+	// maxEnrichmentUpdates and maxEnrichmentFailures bound the books listed on a job
+	maxEnrichmentUpdates  = 100
+	maxEnrichmentFailures = 20
+)
+
+// MetadataProvider looks up what an external catalog knows of a book by its ISBN, returning nil
+// for books it does not know
+type MetadataProvider interface {
+	Lookup(ctx context.Context, isbn string) (*entities.BookMetadata, error)
+}
+
+// EnrichmentRequest starts an enrichment job
+type EnrichmentRequest struct {
+	// Apply updates the books right away instead of saving their changes as drafts
+	Apply bool
+	// Limit bounds the books looked up, the most the use case allows when zero
+	Limit int
+	// RequestedBy is the user notified when the job completes, if any
+	RequestedBy string
+}
+
+// EnrichmentUseCase fills in the descriptions, publishers and covers missing from the catalog
+// with what a metadata provider knows, in the background and at a limited rate
+type EnrichmentUseCase struct {
+	jobRepo       repositories.EnrichmentJobRepository
+	bookRepo      repositories.BookRepository
+	bookUseCase   *BookUseCase
+	coverUseCase  *CoverUseCase
+	publisherRepo repositories.PublisherRepository
+	provider      MetadataProvider
+	coverFetcher  CoverFetcher
+	runner        JobRunner
+	eventBus      events.Bus
+	clock         clock.Clock
+	// interval is the least time between two lookups
+	interval time.Duration
+	maxBooks int
+}
+
+// NewEnrichmentUseCase creates a new enrichment use case looking up at most maxBooks books per
+// job, one every interval
+func NewEnrichmentUseCase(jobRepo repositories.EnrichmentJobRepository, bookRepo repositories.BookRepository, bookUseCase *BookUseCase, coverUseCase *CoverUseCase, publisherRepo repositories.PublisherRepository, provider MetadataProvider, interval time.Duration, maxBooks int) *EnrichmentUseCase {
+	return &EnrichmentUseCase{
+		jobRepo:       jobRepo,
+		bookRepo:      bookRepo,
+		bookUseCase:   bookUseCase,
+		coverUseCase:  coverUseCase,
+		publisherRepo: publisherRepo,
+		provider:      provider,
+		clock:         clock.System{},
+		interval:      interval,
+		maxBooks:      maxBooks,
+	}
+}
+
+// SetCoverFetcher enables downloading the covers of books missing one
+func (uc *EnrichmentUseCase) SetCoverFetcher(fetcher CoverFetcher) {
+	uc.coverFetcher = fetcher
+}
+
+// SetRunner sets where jobs run; without one they run before Submit returns
+func (uc *EnrichmentUseCase) SetRunner(runner JobRunner) {
+	uc.runner = runner
+}
+
+// SetEventBus enables notifying the requester of a job of its summary
+func (uc *EnrichmentUseCase) SetEventBus(bus events.Bus) {
+	uc.eventBus = bus
+}
+
+// SetClock replaces the clock jobs are timestamped with
+func (uc *EnrichmentUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// Submit starts a job enriching the books missing a description, publisher or cover
+func (uc *EnrichmentUseCase) Submit(ctx context.Context, request *EnrichmentRequest) (*entities.EnrichmentJob, error) {
+	limit := request.Limit
+	switch {
+	case limit < 0:
+		return nil, errors.New("limit must not be negative")
+	case limit > uc.maxBooks:
+		return nil, fmt.Errorf("limit must be at most %d", uc.maxBooks)
+	case limit == 0:
+		limit = uc.maxBooks
+	}
+
+	job := &entities.EnrichmentJob{
+		Status:      entities.EnrichmentJobQueued,
+		Apply:       request.Apply,
+		RequestedBy: request.RequestedBy,
+		CreatedAt:   uc.clock.Now().UTC(),
+	}
+	if err := uc.jobRepo.Create(job); err != nil {
+		return nil, err
+	}
+
+	run := func(ctx context.Context) error {
+		uc.run(ctx, job.ID, limit)
+		return nil
+	}
+	if uc.runner == nil {
+		run(ctx)
+		return uc.GetJob(job.ID)
+	}
+	if err := uc.runner(ctx, "enrichment:"+job.ID, run); err != nil {
+		uc.finish(job, err)
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetJob retrieves an enrichment job
+func (uc *EnrichmentUseCase) GetJob(id string) (*entities.EnrichmentJob, error) {
+	job, err := uc.jobRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, domainerr.ErrEnrichmentJobNotFound
+	}
+	return job, nil
+}
+
+// run looks up the books of a job missing metadata, one at a time, and records the outcome
+func (uc *EnrichmentUseCase) run(ctx context.Context, id string, limit int) {
+	job, err := uc.GetJob(id)
+	if err != nil {
+		return
+	}
+	job.Status = entities.EnrichmentJobRunning
+	uc.jobRepo.Update(job)
+
+	books, err := uc.candidates(limit)
+	if err != nil {
+		uc.finish(job, err)
+		return
+	}
+	job.Candidates = len(books)
+
+	publishers := make(map[string]string)
+	for i, candidate := range books {
+		if i > 0 && uc.interval > 0 {
+			timer := time.NewTimer(uc.interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				uc.finish(job, fmt.Errorf("stopped after %d of %d books: %w", i, len(books), ctx.Err()))
+				return
+			case <-timer.C:
+			}
+		}
+		if err := uc.enrich(ctx, job, candidate, publishers); err != nil && len(job.Failures) < maxEnrichmentFailures {
+			job.Failures = append(job.Failures, entities.EnrichmentFailure{BookID: candidate.book.ID, Error: err.Error()})
+		}
+	}
+	uc.finish(job, nil)
+}
+
+// enrichmentCandidate is a book missing metadata
+type enrichmentCandidate struct {
+	book         entities.Book
+	missingCover bool
+}
+
+// candidates lists the live, editable books with an ISBN that miss a description, publisher or cover
+func (uc *EnrichmentUseCase) candidates(limit int) ([]enrichmentCandidate, error) {
+	books, err := uc.bookRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []enrichmentCandidate
+	for _, book := range books {
+		if len(candidates) == limit {
+			break
+		}
+		if book.DeletedAt != nil || book.Status == entities.BookStatusArchived || book.ISBN == "" {
+			continue
+		}
+		missingCover := false
+		if uc.coverUseCase != nil && uc.coverFetcher != nil {
+			_, err := uc.coverUseCase.GetCover(book.ID)
+			if err != nil && !errors.Is(err, domainerr.ErrCoverNotFound) {
+				return nil, err
+			}
+			missingCover = err != nil
+		}
+		if book.Description == "" || book.PublisherID == nil || missingCover {
+			candidates = append(candidates, enrichmentCandidate{book: book, missingCover: missingCover})
+		}
+	}
+	return candidates, nil
+}
+
+// enrich looks a book up and drafts or applies what the provider knows that the book misses
+func (uc *EnrichmentUseCase) enrich(ctx context.Context, job *entities.EnrichmentJob, candidate enrichmentCandidate, publishers map[string]string) error {
+	book := candidate.book
+	metadata, err := uc.provider.Lookup(ctx, book.ISBN)
+	if err != nil {
+		return fmt.Errorf("failed to look up ISBN %s: %w", book.ISBN, err)
+	}
+	if metadata == nil {
+		job.NotFound++
+		return nil
+	}
+
+	var fields []string
+	if book.Description == "" && metadata.Description != "" {
+		book.Description = metadata.Description
+		fields = append(fields, "description")
+	}
+	if book.PublisherID == nil && metadata.Publisher != "" {
+		publisherID, err := uc.publisherID(metadata.Publisher, publishers)
+		if err != nil {
+			return err
+		}
+		book.PublisherID = &publisherID
+		fields = append(fields, "publisher_id")
+	}
+	coverURL := ""
+	if candidate.missingCover && metadata.CoverURL != "" {
+		coverURL = metadata.CoverURL
+	}
+	if len(fields) == 0 && coverURL == "" {
+		job.Unchanged++
+		return nil
+	}
+
+	update := entities.EnrichmentUpdate{BookID: book.ID}
+	if job.Apply {
+		if len(fields) > 0 {
+			if err := uc.bookUseCase.UpdateBook(book.ID, &book); err != nil {
+				return err
+			}
+		}
+		if coverURL != "" {
+			if err := uc.applyCover(ctx, book.ID, coverURL); err != nil {
+				return err
+			}
+			fields = append(fields, "cover")
+		}
+		job.Applied++
+	} else {
+		if len(fields) > 0 {
+			// A draft staff are working on is not replaced
+			if _, err := uc.bookUseCase.GetDraft(book.ID); !errors.Is(err, domainerr.ErrBookDraftNotFound) {
+				if err != nil {
+					return err
+				}
+				job.Skipped++
+				return nil
+			}
+			if _, err := uc.bookUseCase.SaveDraft(book.ID, &book); err != nil {
+				return err
+			}
+		}
+		if coverURL != "" {
+			update.CoverURL = coverURL
+			fields = append(fields, "cover")
+		}
+		job.Drafted++
+	}
+
+	update.Fields = fields
+	if len(job.Updates) < maxEnrichmentUpdates {
+		job.Updates = append(job.Updates, update)
+	}
+	return nil
+}
+
+// publisherID returns the ID of the publisher with a name, creating the publisher when the
+// catalog does not have it yet
+func (uc *EnrichmentUseCase) publisherID(name string, publishers map[string]string) (string, error) {
+	if id, ok := publishers[name]; ok {
+		return id, nil
+	}
+	publisher, err := uc.publisherRepo.FindByName(name)
+	if err != nil {
+		return "", err
+	}
+	if publisher == nil {
+		publisher = &entities.Publisher{Name: name}
+		if err := uc.publisherRepo.Create(publisher); err != nil {
+			return "", err
+		}
+	}
+	publishers[name] = publisher.ID
+	return publisher.ID, nil
+}
+
+// applyCover downloads a cover and sets it, checked like an upload
+func (uc *EnrichmentUseCase) applyCover(ctx context.Context, bookID, coverURL string) error {
+	data, err := uc.coverFetcher.Fetch(ctx, coverURL)
+	if err != nil {
+		return fmt.Errorf("failed to download cover: %w", err)
+	}
+	_, err = uc.coverUseCase.SetCover(bookID, data, "")
+	return err
+}
+
+// finish records the outcome of a job and reports its summary
+func (uc *EnrichmentUseCase) finish(job *entities.EnrichmentJob, err error) {
+	completedAt := uc.clock.Now().UTC()
+	job.CompletedAt = &completedAt
+	job.Status = entities.EnrichmentJobCompleted
+	if err != nil {
+		job.Status = entities.EnrichmentJobFailed
+		job.Error = err.Error()
+	}
+	uc.jobRepo.Update(job)
+
+	summary := fmt.Sprintf("Enrichment job %s %s: %d books looked up, %d drafted, %d applied, %d not found, %d unchanged, %d skipped, %d failed.",
+		job.ID, job.Status, job.Candidates, job.Drafted, job.Applied, job.NotFound, job.Unchanged, job.Skipped, len(job.Failures))
+	log.Print(summary)
+	if uc.eventBus == nil || job.RequestedBy == "" {
+		return
+	}
+	uc.eventBus.Publish(events.Event{
+		Type:        events.EnrichmentCompleted,
+		RecipientID: job.RequestedBy,
+		Payload: map[string]string{
+			"job_id":     job.ID,
+			"status":     job.Status,
+			"candidates": strconv.Itoa(job.Candidates),
+			"drafted":    strconv.Itoa(job.Drafted),
+			"applied":    strconv.Itoa(job.Applied),
+			"message":    summary,
+		},
+		OccurredAt: completedAt,
+	})
+}
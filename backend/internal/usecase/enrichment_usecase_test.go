@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/events"
+	"library-management-system/internal/infrastructure/eventbus"
+	"library-management-system/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubMetadataProvider knows the metadata of books by ISBN
+type stubMetadataProvider map[string]*entities.BookMetadata
+
+func (p stubMetadataProvider) Lookup(ctx context.Context, isbn string) (*entities.BookMetadata, error) {
+	return p[isbn], nil
+}
+
+// stubCoverFetcher serves cover images by URL
+type stubCoverFetcher map[string][]byte
+
+func (f stubCoverFetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	return f[url], nil
+}
+
+// newEnrichmentUseCase catalogs four books: mort misses its description and publisher, guards is
+// complete, sourcery misses its cover, and eric is unknown to the provider
+func newEnrichmentUseCase(t *testing.T) (*EnrichmentUseCase, *MockBookRepository, *MockBookDraftRepository, *MockCoverRepository) {
+	publisherID := "publisher-1"
+	books := []entities.Book{
+		{ID: "mort", Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "9780062225719", Slug: "mort"},
+		{ID: "guards", Title: "Guards! Guards!", Author: "Terry Pratchett", Year: 1989, ISBN: "9780062225757", Slug: "guards-guards", Description: "The Watch.", PublisherID: &publisherID},
+		{ID: "sourcery", Title: "Sourcery", Author: "Terry Pratchett", Year: 1988, ISBN: "9780062225733", Slug: "sourcery", Description: "The eighth son.", PublisherID: &publisherID},
+		{ID: "eric", Title: "Eric", Author: "Terry Pratchett", Year: 1990, ISBN: "9780062225771", Slug: "eric"},
+	}
+	bookRepo := &MockBookRepository{}
+	bookRepo.On("GetAll").Return(books, nil)
+	for i := range books {
+		book := books[i]
+		bookRepo.On("GetByID", book.ID).Return(&book, nil)
+	}
+	coverRepo := &MockCoverRepository{}
+	for _, id := range []string{"mort", "guards", "eric"} {
+		coverRepo.On("GetByBookID", id).Return(&entities.BookCover{BookID: id}, nil)
+	}
+	coverRepo.On("GetByBookID", "sourcery").Return(nil, nil)
+	publisherRepo := &MockPublisherRepository{}
+	publisherRepo.On("FindByName", "HarperTorch").Return(nil, nil)
+	publisherRepo.On("Create", mock.AnythingOfType("*entities.Publisher")).Run(func(args mock.Arguments) {
+		args.Get(0).(*entities.Publisher).ID = "publisher-2"
+	}).Return(nil)
+	publisherRepo.On("GetByID", "publisher-2").Return(&entities.Publisher{ID: "publisher-2", Name: "HarperTorch"}, nil)
+	draftRepo := &MockBookDraftRepository{}
+
+	provider := stubMetadataProvider{
+		"9780062225719": {Description: "Death takes an apprentice.", Publisher: "HarperTorch"},
+		"9780062225757": {Description: "Dragons.", Publisher: "Corgi", CoverURL: "https://covers.example.org/guards.jpg"},
+		"9780062225733": {Description: "Wizards.", CoverURL: "https://covers.example.org/sourcery.jpg"},
+	}
+	bookUseCase := NewBookUseCase(bookRepo)
+	bookUseCase.SetDraftRepository(draftRepo)
+	bookUseCase.SetPublisherRepository(publisherRepo)
+	coverUseCase := NewCoverUseCase(bookRepo, coverRepo, 1024, 10, 100)
+	useCase := NewEnrichmentUseCase(repository.NewInMemoryEnrichmentJobRepository(10), bookRepo, bookUseCase, coverUseCase, publisherRepo, provider, 0, 100)
+	useCase.SetCoverFetcher(stubCoverFetcher{"https://covers.example.org/sourcery.jpg": encodeCover(t, 4, 6)})
+	return useCase, bookRepo, draftRepo, coverRepo
+}
+
+func TestEnrichmentUseCase_Drafts(t *testing.T) {
+	useCase, bookRepo, draftRepo, coverRepo := newEnrichmentUseCase(t)
+	draftRepo.On("GetByBookID", "mort").Return(nil, nil)
+	var drafts []*entities.BookDraft
+	draftRepo.On("Save", mock.AnythingOfType("*entities.BookDraft")).Run(func(args mock.Arguments) {
+		drafts = append(drafts, args.Get(0).(*entities.BookDraft))
+	}).Return(nil)
+	bus := eventbus.NewInMemoryBus()
+	useCase.SetEventBus(bus)
+	var published []events.Event
+	bus.Subscribe(events.EnrichmentCompleted, func(event events.Event) error {
+		published = append(published, event)
+		return nil
+	})
+
+	job, err := useCase.Submit(context.Background(), &EnrichmentRequest{RequestedBy: "admin-1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, entities.EnrichmentJobCompleted, job.Status)
+	assert.Equal(t, 3, job.Candidates)
+	assert.Equal(t, 2, job.Drafted)
+	assert.Equal(t, 1, job.NotFound)
+	assert.Equal(t, []entities.EnrichmentUpdate{
+		{BookID: "mort", Fields: []string{"description", "publisher_id"}},
+		{BookID: "sourcery", Fields: []string{"cover"}, CoverURL: "https://covers.example.org/sourcery.jpg"},
+	}, job.Updates)
+	require.Len(t, drafts, 1)
+	assert.Equal(t, "Death takes an apprentice.", drafts[0].Description)
+	assert.Equal(t, "publisher-2", *drafts[0].PublisherID)
+	bookRepo.AssertNotCalled(t, "Update", mock.Anything)
+	coverRepo.AssertNotCalled(t, "Attach", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	require.Len(t, published, 1)
+	assert.Equal(t, "admin-1", published[0].RecipientID)
+	assert.Equal(t, job.ID, published[0].Payload["job_id"])
+	assert.Contains(t, published[0].Payload["message"], "3 books looked up, 2 drafted, 0 applied, 1 not found")
+}
+
+func TestEnrichmentUseCase_Apply(t *testing.T) {
+	useCase, bookRepo, draftRepo, coverRepo := newEnrichmentUseCase(t)
+	var updated *entities.Book
+	bookRepo.On("Update", mock.AnythingOfType("*entities.Book")).Run(func(args mock.Arguments) {
+		updated = args.Get(0).(*entities.Book)
+	}).Return(nil)
+	coverRepo.On("Attach", "sourcery", mock.Anything, "0000000000000000", "image/png", mock.Anything, (*entities.ScanResult)(nil)).Return(&entities.BookCover{BookID: "sourcery"}, nil)
+	bookRepo.On("SetCoverPalette", "sourcery", []string{"#000000"}).Return(nil)
+
+	job, err := useCase.Submit(context.Background(), &EnrichmentRequest{Apply: true, Limit: 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, job.Candidates)
+	assert.Equal(t, 2, job.Applied)
+	assert.Equal(t, []entities.EnrichmentUpdate{
+		{BookID: "mort", Fields: []string{"description", "publisher_id"}},
+		{BookID: "sourcery", Fields: []string{"cover"}},
+	}, job.Updates)
+	require.NotNil(t, updated)
+	assert.Equal(t, "Death takes an apprentice.", updated.Description)
+	coverRepo.AssertNumberOfCalls(t, "Attach", 1)
+	draftRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestEnrichmentUseCase_KeepsExistingDrafts(t *testing.T) {
+	useCase, _, draftRepo, _ := newEnrichmentUseCase(t)
+	draftRepo.On("GetByBookID", "mort").Return(&entities.BookDraft{BookID: "mort", Title: "Mort", Author: "Terry Pratchett", Year: 1987, ISBN: "9780062225719"}, nil)
+
+	job, err := useCase.Submit(context.Background(), &EnrichmentRequest{Limit: 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, job.Skipped)
+	assert.Empty(t, job.Updates)
+	draftRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestEnrichmentUseCase_SubmitInvalid(t *testing.T) {
+	useCase, _, _, _ := newEnrichmentUseCase(t)
+
+	_, err := useCase.Submit(context.Background(), &EnrichmentRequest{Limit: 101})
+	assert.EqualError(t, err, "limit must be at most 100")
+	_, err = useCase.GetJob("missing")
+	assert.ErrorIs(t, err, domainerr.ErrEnrichmentJobNotFound)
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// Bounds of the queries of the query explorer
+const (
+	maxExploreFilters   = 10
+	maxExploreGroupBy   = 3
+	maxExploreInValues  = 100
+	defaultExploreLimit = 100
+	maxExploreLimit     = 1000
+)
+
+// ExploreUseCase runs the ad-hoc reports admins build from the fields of entities.ExploreEntities
+type ExploreUseCase struct {
+	exploreRepo repositories.ExploreRepository
+}
+
+// NewExploreUseCase creates a new query explorer use case
+func NewExploreUseCase(exploreRepo repositories.ExploreRepository) *ExploreUseCase {
+	return &ExploreUseCase{
+		exploreRepo: exploreRepo,
+	}
+}
+
+// Entities returns the entities and fields queries can name
+func (uc *ExploreUseCase) Entities() []entities.ExploreEntity {
+	return entities.ExploreEntities
+}
+
+// Validate checks a query against the entities and fields it can name, converts the filter
+// values to the types of their fields and applies the default limit
+func (uc *ExploreUseCase) Validate(query *entities.ExploreQuery) error {
+	var entity *entities.ExploreEntity
+	for i := range entities.ExploreEntities {
+		if entities.ExploreEntities[i].Name == query.Entity {
+			entity = &entities.ExploreEntities[i]
+		}
+	}
+	if entity == nil {
+		return fmt.Errorf("unknown entity %q", query.Entity)
+	}
+
+	if len(query.Filters) > maxExploreFilters {
+		return fmt.Errorf("a query takes at most %d filters", maxExploreFilters)
+	}
+	for i := range query.Filters {
+		if err := prepareExploreFilter(entity, &query.Filters[i]); err != nil {
+			return err
+		}
+	}
+
+	if len(query.GroupBy) > maxExploreGroupBy {
+		return fmt.Errorf("a query groups by at most %d fields", maxExploreGroupBy)
+	}
+	seen := make(map[string]bool)
+	for _, name := range query.GroupBy {
+		if entity.Field(name) == nil {
+			return fmt.Errorf("unknown field %q of %s", name, entity.Name)
+		}
+		if seen[name] {
+			return fmt.Errorf("field %q is grouped by twice", name)
+		}
+		seen[name] = true
+	}
+
+	if len(query.Aggregates) == 0 {
+		query.Aggregates = []entities.ExploreAggregate{{Func: entities.ExploreCount}}
+	}
+	for _, aggregate := range query.Aggregates {
+		if err := checkExploreAggregate(entity, aggregate); err != nil {
+			return err
+		}
+		if seen[aggregate.Column()] {
+			return fmt.Errorf("column %q is selected twice", aggregate.Column())
+		}
+		seen[aggregate.Column()] = true
+	}
+
+	switch {
+	case query.Limit == 0:
+		query.Limit = defaultExploreLimit
+	case query.Limit < 0 || query.Limit > maxExploreLimit:
+		return fmt.Errorf("limit must be between 1 and %d", maxExploreLimit)
+	}
+	return nil
+}
+
+// Explore validates and runs a query, flagging results cut at its limit
+func (uc *ExploreUseCase) Explore(query *entities.ExploreQuery) (*entities.ExploreResult, error) {
+	if err := uc.Validate(query); err != nil {
+		return nil, err
+	}
+
+	result, err := uc.exploreRepo.Run(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Rows) > query.Limit {
+		result.Rows = result.Rows[:query.Limit]
+		result.Truncated = true
+	}
+	return result, nil
+}
+
+// prepareExploreFilter checks a filter applies to its field and converts its value to the type of
+// the field
+func prepareExploreFilter(entity *entities.ExploreEntity, filter *entities.ExploreFilter) error {
+	field := entity.Field(filter.Field)
+	if field == nil {
+		return fmt.Errorf("unknown field %q of %s", filter.Field, entity.Name)
+	}
+
+	switch filter.Op {
+	case entities.ExploreEq, entities.ExploreNe:
+	case entities.ExploreIn:
+		values, ok := filter.Value.([]interface{})
+		if !ok || len(values) == 0 || len(values) > maxExploreInValues {
+			return fmt.Errorf("in filters on %q need a list of 1 to %d values", field.Name, maxExploreInValues)
+		}
+		converted := make([]interface{}, len(values))
+		for i, value := range values {
+			var err error
+			if converted[i], err = exploreValue(field, value); err != nil {
+				return err
+			}
+		}
+		filter.Value = converted
+		return nil
+	case entities.ExploreGt, entities.ExploreGte, entities.ExploreLt, entities.ExploreLte:
+		if field.Type != entities.ExploreInteger && field.Type != entities.ExploreTime {
+			return fmt.Errorf("operator %q needs an integer or time field, %q is a %s", filter.Op, field.Name, field.Type)
+		}
+	default:
+		return fmt.Errorf("unknown operator %q", filter.Op)
+	}
+
+	value, err := exploreValue(field, filter.Value)
+	if err != nil {
+		return err
+	}
+	filter.Value = value
+	return nil
+}
+
+// exploreValue converts a JSON value to the type of a field; values already converted are kept,
+// so a query can be validated again
+func exploreValue(field *entities.ExploreField, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		switch field.Type {
+		case entities.ExploreString:
+			return v, nil
+		case entities.ExploreTime:
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, fmt.Errorf("field %q needs an RFC 3339 time: %v", field.Name, err)
+			}
+			return t, nil
+		}
+	case float64:
+		if field.Type == entities.ExploreInteger && v == math.Trunc(v) {
+			return int64(v), nil
+		}
+	case int64:
+		if field.Type == entities.ExploreInteger {
+			return v, nil
+		}
+	case bool:
+		if field.Type == entities.ExploreBoolean {
+			return v, nil
+		}
+	case time.Time:
+		if field.Type == entities.ExploreTime {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("field %q needs a value of type %s", field.Name, field.Type)
+}
+
+// checkExploreAggregate checks an aggregate applies to its field: sums and averages need integers,
+// minimums and maximums integers or times
+func checkExploreAggregate(entity *entities.ExploreEntity, aggregate entities.ExploreAggregate) error {
+	if aggregate.Func == entities.ExploreCount && aggregate.Field == "" {
+		return nil
+	}
+	if aggregate.Field == "" {
+		return fmt.Errorf("aggregate %q needs a field", aggregate.Func)
+	}
+	field := entity.Field(aggregate.Field)
+	if field == nil {
+		return fmt.Errorf("unknown field %q of %s", aggregate.Field, entity.Name)
+	}
+
+	switch aggregate.Func {
+	case entities.ExploreCount, entities.ExploreCountDistinct:
+		return nil
+	case entities.ExploreSum, entities.ExploreAvg:
+		if field.Type == entities.ExploreInteger {
+			return nil
+		}
+	case entities.ExploreMin, entities.ExploreMax:
+		if field.Type == entities.ExploreInteger || field.Type == entities.ExploreTime {
+			return nil
+		}
+	default:
+		return fmt.Errorf("unknown aggregate %q", aggregate.Func)
+	}
+	return fmt.Errorf("aggregate %q cannot be applied to %s field %q", aggregate.Func, field.Type, field.Name)
+}
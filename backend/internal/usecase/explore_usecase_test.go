@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockExploreRepository is a mock implementation of ExploreRepository
+type MockExploreRepository struct {
+	mock.Mock
+}
+
+func (m *MockExploreRepository) Run(query *entities.ExploreQuery) (*entities.ExploreResult, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ExploreResult), args.Error(1)
+}
+
+func TestExploreUseCase_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         entities.ExploreQuery
+		expectedError string
+	}{
+		{name: "count by month", query: entities.ExploreQuery{Entity: "loans", GroupBy: []string{"borrowed_month"}}},
+		{
+			name: "filters and aggregates",
+			query: entities.ExploreQuery{
+				Entity: "loans",
+				Filters: []entities.ExploreFilter{
+					{Field: "borrowed_at", Op: entities.ExploreGte, Value: "2024-01-01T00:00:00Z"},
+					{Field: "returned", Op: entities.ExploreEq, Value: false},
+					{Field: "tenant_id", Op: entities.ExploreIn, Value: []interface{}{"tenant-1", "tenant-2"}},
+				},
+				GroupBy:    []string{"book_id"},
+				Aggregates: []entities.ExploreAggregate{{Func: entities.ExploreCount}, {Func: entities.ExploreAvg, Field: "renewals"}, {Func: entities.ExploreMax, Field: "due_at"}},
+			},
+		},
+		{name: "unknown entity", query: entities.ExploreQuery{Entity: "users"}, expectedError: `unknown entity "users"`},
+		{name: "unknown group field", query: entities.ExploreQuery{Entity: "books", GroupBy: []string{"title; DROP TABLE books"}}, expectedError: `unknown field "title; DROP TABLE books" of books`},
+		{name: "field of another entity", query: entities.ExploreQuery{Entity: "books", Filters: []entities.ExploreFilter{{Field: "renewals", Op: entities.ExploreEq, Value: 1.0}}}, expectedError: `unknown field "renewals" of books`},
+		{name: "ordering a string", query: entities.ExploreQuery{Entity: "books", Filters: []entities.ExploreFilter{{Field: "author", Op: entities.ExploreGt, Value: "M"}}}, expectedError: `operator "gt" needs an integer or time field, "author" is a string`},
+		{name: "unknown operator", query: entities.ExploreQuery{Entity: "books", Filters: []entities.ExploreFilter{{Field: "year", Op: "like", Value: 1.0}}}, expectedError: `unknown operator "like"`},
+		{name: "fractional integer", query: entities.ExploreQuery{Entity: "books", Filters: []entities.ExploreFilter{{Field: "year", Op: entities.ExploreEq, Value: 1999.5}}}, expectedError: `field "year" needs a value of type integer`},
+		{name: "bad time", query: entities.ExploreQuery{Entity: "loans", Filters: []entities.ExploreFilter{{Field: "due_at", Op: entities.ExploreLt, Value: "tomorrow"}}}, expectedError: `field "due_at" needs an RFC 3339 time`},
+		{name: "empty in", query: entities.ExploreQuery{Entity: "books", Filters: []entities.ExploreFilter{{Field: "status", Op: entities.ExploreIn, Value: []interface{}{}}}}, expectedError: `in filters on "status" need a list of 1 to 100 values`},
+		{name: "sum of strings", query: entities.ExploreQuery{Entity: "books", Aggregates: []entities.ExploreAggregate{{Func: entities.ExploreSum, Field: "author"}}}, expectedError: `aggregate "sum" cannot be applied to string field "author"`},
+		{name: "aggregate without field", query: entities.ExploreQuery{Entity: "books", Aggregates: []entities.ExploreAggregate{{Func: entities.ExploreMin}}}, expectedError: `aggregate "min" needs a field`},
+		{name: "duplicate group", query: entities.ExploreQuery{Entity: "books", GroupBy: []string{"year", "year"}}, expectedError: `field "year" is grouped by twice`},
+		{name: "too many groups", query: entities.ExploreQuery{Entity: "books", GroupBy: []string{"author", "year", "status", "work_id"}}, expectedError: "a query groups by at most 3 fields"},
+		{name: "limit too high", query: entities.ExploreQuery{Entity: "books", Limit: 5000}, expectedError: "limit must be between 1 and 1000"},
+	}
+
+	uc := NewExploreUseCase(new(MockExploreRepository))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := uc.Validate(&tt.query)
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestExploreUseCase_ValidateConvertsValues(t *testing.T) {
+	uc := NewExploreUseCase(new(MockExploreRepository))
+	query := entities.ExploreQuery{
+		Entity: "loans",
+		Filters: []entities.ExploreFilter{
+			{Field: "renewals", Op: entities.ExploreGte, Value: 2.0},
+			{Field: "borrowed_at", Op: entities.ExploreLt, Value: "2024-06-01T00:00:00Z"},
+		},
+	}
+
+	require.NoError(t, uc.Validate(&query))
+	assert.Equal(t, int64(2), query.Filters[0].Value)
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), query.Filters[1].Value)
+	assert.Equal(t, []entities.ExploreAggregate{{Func: entities.ExploreCount}}, query.Aggregates)
+	assert.Equal(t, defaultExploreLimit, query.Limit)
+
+	// Converted values pass again
+	assert.NoError(t, uc.Validate(&query))
+}
+
+func TestExploreUseCase_Explore(t *testing.T) {
+	repo := new(MockExploreRepository)
+	uc := NewExploreUseCase(repo)
+	query := entities.ExploreQuery{Entity: "books", GroupBy: []string{"status"}, Limit: 2}
+	repo.On("Run", &query).Return(&entities.ExploreResult{
+		Columns: []string{"status", "count"},
+		Rows:    [][]interface{}{{"active", int64(40)}, {"archived", int64(3)}, {"draft", int64(1)}},
+	}, nil)
+
+	result, err := uc.Explore(&query)
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]interface{}{{"active", int64(40)}, {"archived", int64(3)}}, result.Rows)
+	assert.True(t, result.Truncated)
+	repo.AssertExpectations(t)
+}
+
+func TestExploreUseCase_ExploreRejectsInvalidQuery(t *testing.T) {
+	repo := new(MockExploreRepository)
+	uc := NewExploreUseCase(repo)
+
+	_, err := uc.Explore(&entities.ExploreQuery{Entity: "books", GroupBy: []string{"isbn"}})
+
+	assert.EqualError(t, err, `unknown field "isbn" of books`)
+	repo.AssertNotCalled(t, "Run", mock.Anything)
+}
@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// Feed is the latest additions to the catalog, newest first
+type Feed struct {
+	// Updated is when the most recently changed book of the feed changed, or now for empty feeds
+	Updated time.Time
+	// Total is how many books match, of which Items may be a page
+	Total int
+	Items []FeedItem
+}
+
+// FeedItem is a book of a feed, with its cover when it has one
+type FeedItem struct {
+	Book  entities.Book
+	Cover *entities.BookCover
+}
+
+// FeedAuthor is an author of listed books
+type FeedAuthor struct {
+	Name  string
+	Books int
+}
+
+// FeedUseCase lists the catalog for other sites and apps: its new arrivals, and the pages of books,
+// authors and search results OPDS readers browse. Books are read from the cached listing, so
+// feeds cost a cover lookup per item.
+type FeedUseCase struct {
+	bookUseCase *BookUseCase
+	coverRepo   repositories.CoverRepository
+	clock       clock.Clock
+	// items is the length of feeds requested without a limit
+	items    int
+	maxItems int
+}
+
+// NewFeedUseCase creates a new feed use case listing items books by default and at most maxItems
+func NewFeedUseCase(bookUseCase *BookUseCase, coverRepo repositories.CoverRepository, items, maxItems int) *FeedUseCase {
+	return &FeedUseCase{
+		bookUseCase: bookUseCase,
+		coverRepo:   coverRepo,
+		clock:       clock.System{},
+		items:       items,
+		maxItems:    maxItems,
+	}
+}
+
+// SetClock replaces the clock empty feeds are dated with
+func (uc *FeedUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// Limit validates the length requested of a feed, returning the default length for zero
+func (uc *FeedUseCase) Limit(requested int) (int, error) {
+	switch {
+	case requested < 0:
+		return 0, errors.New("limit must not be negative")
+	case requested > uc.maxItems:
+		return 0, fmt.Errorf("limit must be at most %d", uc.maxItems)
+	case requested == 0:
+		return uc.items, nil
+	}
+	return requested, nil
+}
+
+// NewArrivals returns the limit listed books most recently added to the catalog
+func (uc *FeedUseCase) NewArrivals(limit int) (*Feed, error) {
+	books, err := uc.listed()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(books, func(i, j int) bool { return books[i].CreatedAt.After(books[j].CreatedAt) })
+	if len(books) > limit {
+		books = books[:limit]
+	}
+	return uc.feed(books, len(books))
+}
+
+// Catalog returns a page of the listed books, by title
+func (uc *FeedUseCase) Catalog(page, pageSize int) (*Feed, error) {
+	books, err := uc.listed()
+	if err != nil {
+		return nil, err
+	}
+	return uc.page(books, page, pageSize)
+}
+
+// ByAuthor returns a page of the listed books of an author, by title. Authors are matched
+// case-insensitively.
+func (uc *FeedUseCase) ByAuthor(author string, page, pageSize int) (*Feed, error) {
+	if strings.TrimSpace(author) == "" {
+		return nil, errors.New("author is required")
+	}
+	books, err := uc.listed()
+	if err != nil {
+		return nil, err
+	}
+	return uc.page(keepBooks(books, func(book entities.Book) bool {
+		return strings.EqualFold(book.Author, strings.TrimSpace(author))
+	}), page, pageSize)
+}
+
+// Search returns a page of the listed books whose title or author contains query, by title
+func (uc *FeedUseCase) Search(query string, page, pageSize int) (*Feed, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil, errors.New("search query is required")
+	}
+	books, err := uc.listed()
+	if err != nil {
+		return nil, err
+	}
+	return uc.page(keepBooks(books, func(book entities.Book) bool {
+		return strings.Contains(strings.ToLower(book.Title), query) || strings.Contains(strings.ToLower(book.Author), query)
+	}), page, pageSize)
+}
+
+// Authors returns a page of the authors of listed books, by name, and how many authors there are
+func (uc *FeedUseCase) Authors(page, pageSize int) ([]FeedAuthor, int, error) {
+	if err := validatePage(page, pageSize); err != nil {
+		return nil, 0, err
+	}
+	books, err := uc.listed()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Authors written in different cases are one author, named as first seen
+	byName := make(map[string]int)
+	var authors []FeedAuthor
+	for _, book := range books {
+		key := strings.ToLower(book.Author)
+		if i, ok := byName[key]; ok {
+			authors[i].Books++
+			continue
+		}
+		byName[key] = len(authors)
+		authors = append(authors, FeedAuthor{Name: book.Author, Books: 1})
+	}
+	sort.Slice(authors, func(i, j int) bool { return strings.ToLower(authors[i].Name) < strings.ToLower(authors[j].Name) })
+
+	start, end := pageBounds(len(authors), page, pageSize)
+	return authors[start:end], len(authors), nil
+}
+
+// Updated returns when the most recently changed listed book changed, or now without listed books
+func (uc *FeedUseCase) Updated() (time.Time, error) {
+	books, err := uc.bookUseCase.GetAllBooks()
+	if err != nil {
+		return time.Time{}, err
+	}
+	var updated time.Time
+	for _, book := range books {
+		if book.UpdatedAt.After(updated) {
+			updated = book.UpdatedAt
+		}
+	}
+	if updated.IsZero() {
+		updated = uc.clock.Now()
+	}
+	return updated.UTC(), nil
+}
+
+// listed returns a copy of the cached listing, which is shared with its other readers and so
+// never sorted in place
+func (uc *FeedUseCase) listed() ([]entities.Book, error) {
+	books, err := uc.bookUseCase.GetAllBooks()
+	if err != nil {
+		return nil, err
+	}
+	return append([]entities.Book(nil), books...), nil
+}
+
+// page sorts books by title and returns a page of them
+func (uc *FeedUseCase) page(books []entities.Book, page, pageSize int) (*Feed, error) {
+	if err := validatePage(page, pageSize); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(books, func(i, j int) bool { return strings.ToLower(books[i].Title) < strings.ToLower(books[j].Title) })
+	start, end := pageBounds(len(books), page, pageSize)
+	return uc.feed(books[start:end], len(books))
+}
+
+// feed looks up the covers of books, out of total matching books
+func (uc *FeedUseCase) feed(books []entities.Book, total int) (*Feed, error) {
+	feed := &Feed{Total: total, Items: make([]FeedItem, len(books))}
+	for i, book := range books {
+		cover, err := uc.coverRepo.GetByBookID(book.ID)
+		if err != nil {
+			return nil, err
+		}
+		feed.Items[i] = FeedItem{Book: book, Cover: cover}
+		if book.UpdatedAt.After(feed.Updated) {
+			feed.Updated = book.UpdatedAt
+		}
+	}
+	if len(feed.Items) == 0 {
+		feed.Updated = uc.clock.Now()
+	}
+	feed.Updated = feed.Updated.UTC()
+	return feed, nil
+}
+
+// validatePage checks a page number, counted from 1, and the page size
+func validatePage(page, pageSize int) error {
+	switch {
+	case page < 1:
+		return errors.New("page must be at least 1")
+	case pageSize < 1:
+		return errors.New("page size must be at least 1")
+	}
+	return nil
+}
+
+// pageBounds returns where a page starts and ends among total items
+func pageBounds(total, page, pageSize int) (int, int) {
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// keepBooks returns the books that match
+func keepBooks(books []entities.Book, keep func(entities.Book) bool) []entities.Book {
+	kept, _ := filterBooks(books, nil, keep)
+	return kept
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedUseCase_NewArrivals(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 9, 0, 0, 0, time.UTC) }
+	publishAt := day(20)
+	bookRepo := new(MockBookRepository)
+	bookRepo.On("GetAll").Return([]entities.Book{
+		{ID: "mort", Status: entities.BookStatusActive, CreatedAt: day(1), UpdatedAt: day(14)},
+		{ID: "eric", Status: entities.BookStatusActive, CreatedAt: day(3), UpdatedAt: day(3)},
+		{ID: "sourcery", Status: entities.BookStatusActive, CreatedAt: day(2), UpdatedAt: day(2)},
+		{ID: "hogfather", Status: entities.BookStatusDraft, CreatedAt: day(4), UpdatedAt: day(4)},
+		{ID: "jingo", Status: entities.BookStatusActive, CreatedAt: day(5), UpdatedAt: day(5), PublishAt: &publishAt},
+	}, nil)
+	coverRepo := new(MockCoverRepository)
+	coverRepo.On("GetByBookID", "eric").Return(&entities.BookCover{BookID: "eric", ContentType: "image/png"}, nil)
+	coverRepo.On("GetByBookID", "sourcery").Return(nil, nil)
+	coverRepo.On("GetByBookID", "mort").Return(nil, nil)
+	useCase := NewFeedUseCase(NewBookUseCase(bookRepo), coverRepo, 2, 3)
+
+	limit, err := useCase.Limit(0)
+	require.NoError(t, err)
+	feed, err := useCase.NewArrivals(limit)
+	require.NoError(t, err)
+	require.Len(t, feed.Items, 2)
+	assert.Equal(t, "eric", feed.Items[0].Book.ID)
+	assert.Equal(t, "image/png", feed.Items[0].Cover.ContentType)
+	assert.Equal(t, "sourcery", feed.Items[1].Book.ID)
+	assert.Nil(t, feed.Items[1].Cover)
+	assert.Equal(t, day(3), feed.Updated)
+
+	feed, err = useCase.NewArrivals(3)
+	require.NoError(t, err)
+	require.Len(t, feed.Items, 3)
+	assert.Equal(t, "mort", feed.Items[2].Book.ID)
+	assert.Equal(t, day(14), feed.Updated, "updated follows the latest change, not the latest addition")
+
+	_, err = useCase.Limit(4)
+	assert.EqualError(t, err, "limit must be at most 3")
+	_, err = useCase.Limit(-1)
+	assert.EqualError(t, err, "limit must not be negative")
+}
+
+func TestFeedUseCase_Empty(t *testing.T) {
+	bookRepo := new(MockBookRepository)
+	bookRepo.On("GetAll").Return([]entities.Book{}, nil)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	useCase := NewFeedUseCase(NewBookUseCase(bookRepo), new(MockCoverRepository), 20, 50)
+	useCase.SetClock(clock.NewFixed(now))
+
+	feed, err := useCase.NewArrivals(20)
+	require.NoError(t, err)
+	assert.Empty(t, feed.Items)
+	assert.Equal(t, now, feed.Updated)
+}
+
+func TestFeedUseCase_Browse(t *testing.T) {
+	bookRepo := new(MockBookRepository)
+	bookRepo.On("GetAll").Return([]entities.Book{
+		{ID: "mort", Title: "Mort", Author: "Terry Pratchett", Status: entities.BookStatusActive},
+		{ID: "eric", Title: "Eric", Author: "terry pratchett", Status: entities.BookStatusActive},
+		{ID: "good-omens", Title: "Good Omens", Author: "Neil Gaiman", Status: entities.BookStatusActive},
+		{ID: "hogfather", Title: "Hogfather", Author: "Terry Pratchett", Status: entities.BookStatusDraft},
+	}, nil)
+	coverRepo := new(MockCoverRepository)
+	for _, id := range []string{"mort", "eric", "good-omens"} {
+		coverRepo.On("GetByBookID", id).Return(nil, nil)
+	}
+	useCase := NewFeedUseCase(NewBookUseCase(bookRepo), coverRepo, 20, 50)
+	ids := func(feed *Feed) []string {
+		var ids []string
+		for _, item := range feed.Items {
+			ids = append(ids, item.Book.ID)
+		}
+		return ids
+	}
+
+	feed, err := useCase.Catalog(1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, feed.Total)
+	assert.Equal(t, []string{"eric", "good-omens"}, ids(feed))
+	feed, err = useCase.Catalog(2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mort"}, ids(feed))
+	feed, err = useCase.Catalog(3, 2)
+	require.NoError(t, err)
+	assert.Empty(t, feed.Items)
+
+	feed, err = useCase.ByAuthor("Terry Pratchett", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eric", "mort"}, ids(feed))
+
+	feed, err = useCase.Search("OMEN", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"good-omens"}, ids(feed))
+	feed, err = useCase.Search("gaiman", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"good-omens"}, ids(feed))
+	_, err = useCase.Search(" ", 1, 10)
+	assert.EqualError(t, err, "search query is required")
+
+	authors, total, err := useCase.Authors(1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []FeedAuthor{{Name: "Neil Gaiman", Books: 1}, {Name: "Terry Pratchett", Books: 2}}, authors)
+
+	_, err = useCase.Catalog(0, 10)
+	assert.EqualError(t, err, "page must be at least 1")
+}
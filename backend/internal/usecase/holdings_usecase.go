@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// Holding is what the library holds of an ISBN and how it circulates, for discovery services
+// showing availability next to their own records
+type Holding struct {
+	BookID string `json:"book_id"`
+	// BranchID is the branch holding the book, absent for books of the whole library
+	BranchID *string `json:"branch_id,omitempty"`
+	// Available tells whether the book can be borrowed, like the available field of books
+	Available bool `json:"available"`
+	// Loans counts the copies out on loan and Holds the members waiting for one
+	Loans int `json:"loans"`
+	Holds int `json:"holds"`
+	// DueBack is when the first copy on loan is due back
+	DueBack *time.Time `json:"due_back,omitempty"`
+}
+
+// HoldingsUseCase looks up the holdings of batches of ISBNs. It reads the cached listing of the
+// catalog, so that the frequent polls of discovery services only query the circulation of the
+// books the library holds.
+type HoldingsUseCase struct {
+	bookUseCase *BookUseCase
+	loanRepo    repositories.LoanRepository
+	holdRepo    repositories.HoldRepository
+	maxISBNs    int
+}
+
+// NewHoldingsUseCase creates a new holdings use case answering for at most maxISBNs ISBNs at once
+func NewHoldingsUseCase(bookUseCase *BookUseCase, loanRepo repositories.LoanRepository, holdRepo repositories.HoldRepository, maxISBNs int) *HoldingsUseCase {
+	return &HoldingsUseCase{
+		bookUseCase: bookUseCase,
+		loanRepo:    loanRepo,
+		holdRepo:    holdRepo,
+		maxISBNs:    maxISBNs,
+	}
+}
+
+// ISBNs normalizes the ISBNs of a request, given as values that may each list several separated
+// by commas, and drops repeated ones. At least one ISBN, and at most the configured number, must
+// be given.
+func (uc *HoldingsUseCase) ISBNs(values []string) ([]string, error) {
+	var isbns []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		for _, isbn := range strings.Split(value, ",") {
+			isbn = entities.NormalizeISBN(isbn)
+			if isbn == "" || seen[isbn] {
+				continue
+			}
+			seen[isbn] = true
+			isbns = append(isbns, isbn)
+		}
+	}
+	if len(isbns) == 0 {
+		return nil, errors.New("isbns is required")
+	}
+	if len(isbns) > uc.maxISBNs {
+		return nil, fmt.Errorf("at most %d isbns can be looked up at once", uc.maxISBNs)
+	}
+	return isbns, nil
+}
+
+// Holdings returns the holding of each of the normalized ISBNs, nil for ISBNs the library does not
+// hold. Drafts, scheduled and deleted books are not held.
+func (uc *HoldingsUseCase) Holdings(isbns []string) (map[string]*Holding, error) {
+	books, err := uc.bookUseCase.GetAllBooks()
+	if err != nil {
+		return nil, err
+	}
+	byISBN := make(map[string]entities.Book, len(books))
+	for _, book := range books {
+		byISBN[book.ISBN] = book
+	}
+
+	holdings := make(map[string]*Holding, len(isbns))
+	for _, isbn := range isbns {
+		book, ok := byISBN[isbn]
+		if !ok {
+			holdings[isbn] = nil
+			continue
+		}
+		holding, err := uc.holding(book)
+		if err != nil {
+			return nil, err
+		}
+		holdings[isbn] = holding
+	}
+	return holdings, nil
+}
+
+// holding looks up the circulation of a book the library holds
+func (uc *HoldingsUseCase) holding(book entities.Book) (*Holding, error) {
+	loans, err := uc.loanRepo.ListActiveByBook(book.ID)
+	if err != nil {
+		return nil, err
+	}
+	holds, err := uc.holdRepo.ListWaiting(book.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	holding := &Holding{
+		BookID:    book.ID,
+		BranchID:  book.BranchID,
+		Available: book.Status != entities.BookStatusArchived,
+		Loans:     len(loans),
+		Holds:     len(holds),
+	}
+	// Active loans come soonest due first
+	if len(loans) > 0 {
+		dueBack := loans[0].DueAt
+		holding.DueBack = &dueBack
+	}
+	return holding, nil
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHoldingsUseCase_Holdings(t *testing.T) {
+	due := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+	branch := "north"
+	bookRepo := new(MockBookRepository)
+	bookRepo.On("GetAll").Return([]entities.Book{
+		{ID: "mort", ISBN: "9780062225719", Status: entities.BookStatusActive, BranchID: &branch},
+		{ID: "eric", ISBN: "9780552152938", Status: entities.BookStatusArchived},
+		{ID: "hogfather", ISBN: "9780552154284", Status: entities.BookStatusDraft},
+	}, nil)
+	loanRepo := new(MockLoanRepository)
+	loanRepo.On("ListActiveByBook", "mort").Return([]entities.Loan{{DueAt: due}, {DueAt: due.AddDate(0, 0, 7)}}, nil)
+	loanRepo.On("ListActiveByBook", "eric").Return([]entities.Loan{}, nil)
+	holdRepo := new(MockHoldRepository)
+	holdRepo.On("ListWaiting", "mort").Return([]entities.Hold{{ID: "h1"}}, nil)
+	holdRepo.On("ListWaiting", "eric").Return([]entities.Hold{}, nil)
+	useCase := NewHoldingsUseCase(NewBookUseCase(bookRepo), loanRepo, holdRepo, 3)
+
+	isbns, err := useCase.ISBNs([]string{"978-0-06-222571-9, 9780552152938", "9780062225719", "9780552154284"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"9780062225719", "9780552152938", "9780552154284"}, isbns)
+
+	holdings, err := useCase.Holdings(isbns)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*Holding{
+		"9780062225719": {BookID: "mort", BranchID: &branch, Available: true, Loans: 2, Holds: 1, DueBack: &due},
+		"9780552152938": {BookID: "eric"},
+		"9780552154284": nil,
+	}, holdings)
+
+	_, err = useCase.ISBNs([]string{" , "})
+	assert.EqualError(t, err, "isbns is required")
+	_, err = useCase.ISBNs([]string{"1,2,3,4"})
+	assert.EqualError(t, err, "at most 3 isbns can be looked up at once")
+}
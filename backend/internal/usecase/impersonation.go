@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+const (
+	// maxImpersonationReasonLength bounds the reason an admin gives for impersonating a member
+	maxImpersonationReasonLength = 255
+	// maxImpersonationReportDays is the longest window the impersonation report covers
+	maxImpersonationReportDays = 365
+)
+
+// ErrInvalidReportDays is returned for an impersonation report window out of range
+var ErrInvalidReportDays = errors.New("days must be between 1 and " + strconv.Itoa(maxImpersonationReportDays))
+
+// SetAuditRepository records admins starting and ending impersonations, and the requests changing
+// anything they make while impersonating
+func (uc *SessionUseCase) SetAuditRepository(auditRepo repositories.AuditRepository) {
+	uc.auditRepo = auditRepo
+}
+
+// SetImpersonationLifetime sets how long the sessions admins start to impersonate members last
+func (uc *SessionUseCase) SetImpersonationLifetime(lifetime time.Duration) {
+	uc.impersonationLifetime = lifetime
+}
+
+// Impersonate starts a session for an admin to act as a member, such as to reproduce an issue
+// they report, and returns it with its token. The session expires after the impersonation
+// lifetime, however long sessions last, and the requests changing anything made with it are
+// audited flagged with the admin. Only active admins can impersonate, and only active members.
+func (uc *SessionUseCase) Impersonate(adminID, memberID, reason string, client SessionClient) (*entities.Session, string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, "", errors.New("reason is required")
+	}
+	if len(reason) > maxImpersonationReasonLength {
+		return nil, "", errors.New("reason must be at most " + strconv.Itoa(maxImpersonationReasonLength) + " characters")
+	}
+	if err := uc.checkImpersonator(adminID); err != nil {
+		return nil, "", err
+	}
+	member, err := uc.userRepo.GetByID(memberID)
+	if err != nil {
+		return nil, "", err
+	}
+	if member == nil {
+		return nil, "", domainerr.ErrMemberNotFound
+	}
+	if member.Role != entities.UserRoleMember {
+		return nil, "", domainerr.ErrImpersonationNotAllowed
+	}
+	if !member.Active() {
+		return nil, "", domainerr.ErrMemberDeactivated
+	}
+
+	now := uc.clock.Now()
+	secret := entities.NewSessionSecret()
+	session := &entities.Session{
+		UserID:              member.ID,
+		TokenHash:           entities.HashAccessToken(secret),
+		Device:              sessionDevice(client.Device),
+		IP:                  client.IP,
+		LastSeenAt:          now,
+		ExpiresAt:           now.Add(uc.impersonationLifetime),
+		ImpersonatorID:      &adminID,
+		ImpersonationReason: reason,
+	}
+	if err := uc.sessionRepo.Create(session); err != nil {
+		return nil, "", err
+	}
+	uc.recordImpersonation(session, entities.AuditActionImpersonationStarted, adminID, map[string]interface{}{
+		"member_id":  member.ID,
+		"reason":     reason,
+		"expires_at": session.ExpiresAt,
+	})
+	return session, secret, nil
+}
+
+// EndImpersonation revokes an active impersonation session before it expires
+func (uc *SessionUseCase) EndImpersonation(adminID, id string) (*entities.Session, error) {
+	if err := uc.checkImpersonator(adminID); err != nil {
+		return nil, err
+	}
+	session, err := uc.sessionRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	now := uc.clock.Now()
+	if session == nil || !session.Impersonated() || !session.Active(now) {
+		return nil, domainerr.ErrImpersonationNotFound
+	}
+
+	session.RevokedAt = &now
+	if err := uc.sessionRepo.Update(session); err != nil {
+		return nil, err
+	}
+	uc.recordImpersonation(session, entities.AuditActionImpersonationEnded, adminID, nil)
+	return session, nil
+}
+
+// RecordImpersonatedRequest audits a request made with an impersonation session, under the member
+// it acts as and flagged with the admin impersonating them
+func (uc *SessionUseCase) RecordImpersonatedRequest(session *entities.Session, method, route string, status int) {
+	uc.recordImpersonation(session, entities.AuditActionImpersonatedRequest, session.UserID, map[string]interface{}{
+		"method": method,
+		"route":  route,
+		"status": status,
+	})
+}
+
+// ImpersonationReport lists the impersonations started in the last days, newest first, each with
+// its audit entries: its start, the requests changing anything made with it, and its end
+func (uc *SessionUseCase) ImpersonationReport(days int) ([]entities.Impersonation, error) {
+	if days < 1 || days > maxImpersonationReportDays {
+		return nil, ErrInvalidReportDays
+	}
+	sessions, err := uc.sessionRepo.ListImpersonations(uc.clock.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]entities.Impersonation, len(sessions))
+	for i, session := range sessions {
+		report[i] = entities.Impersonation{Session: session, Actions: []entities.AuditEntry{}}
+		if uc.auditRepo == nil {
+			continue
+		}
+		actions, err := uc.auditRepo.ListByEntity(entities.AuditEntitySession, session.ID)
+		if err != nil {
+			return nil, err
+		}
+		if actions != nil {
+			report[i].Actions = actions
+		}
+	}
+	return report, nil
+}
+
+// checkImpersonator refuses to let anyone but an active admin impersonate members
+func (uc *SessionUseCase) checkImpersonator(adminID string) error {
+	if adminID == "" {
+		return domainerr.ErrImpersonationNotAllowed
+	}
+	admin, err := uc.userRepo.GetByID(adminID)
+	if err != nil {
+		return err
+	}
+	if admin == nil || admin.Role != entities.UserRoleAdmin || !admin.Active() {
+		return domainerr.ErrImpersonationNotAllowed
+	}
+	return nil
+}
+
+// recordImpersonation audits an event of an impersonation session. Audit failures are logged
+// rather than failing what already happened.
+func (uc *SessionUseCase) recordImpersonation(session *entities.Session, action, actor string, changes map[string]interface{}) {
+	if uc.auditRepo == nil {
+		return
+	}
+	entry := &entities.AuditEntry{
+		EntityType:     entities.AuditEntitySession,
+		EntityID:       session.ID,
+		Action:         action,
+		Actor:          actor,
+		CreatedAt:      uc.clock.Now(),
+		ImpersonatorID: *session.ImpersonatorID,
+	}
+	if changes != nil {
+		if data, err := json.Marshal(changes); err == nil {
+			entry.Changes = string(data)
+		}
+	}
+	if err := uc.auditRepo.Create(entry); err != nil {
+		log.Printf("Failed to record %s of session %s: %v", action, session.ID, err)
+	}
+}
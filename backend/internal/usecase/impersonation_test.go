@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionUseCase_Impersonate(t *testing.T) {
+	now := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+	fixed := clock.NewFixed(now)
+	deactivatedAt := now.AddDate(0, 0, -1)
+	users := &MockUserRepository{}
+	users.On("GetByID", "admin-1").Return(&entities.User{ID: "admin-1", Role: entities.UserRoleAdmin}, nil)
+	users.On("GetByID", "librarian-1").Return(&entities.User{ID: "librarian-1", Role: entities.UserRoleLibrarian}, nil)
+	users.On("GetByID", "member-1").Return(&entities.User{ID: "member-1", Role: entities.UserRoleMember}, nil)
+	users.On("GetByID", "member-2").Return(&entities.User{ID: "member-2", Role: entities.UserRoleMember, DeactivatedAt: &deactivatedAt}, nil)
+	users.On("GetByID", "missing").Return(nil, nil)
+
+	var recorded []entities.AuditEntry
+	auditRepo := &MockAuditRepository{}
+	auditRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		recorded = append(recorded, *args.Get(0).(*entities.AuditEntry))
+	}).Return(nil)
+
+	repo := &memorySessionRepository{}
+	useCase := NewSessionUseCase(repo, users, 30*24*time.Hour)
+	useCase.SetClock(fixed)
+	useCase.SetAuditRepository(auditRepo)
+	useCase.SetImpersonationLifetime(15 * time.Minute)
+
+	tests := []struct {
+		name          string
+		adminID       string
+		memberID      string
+		reason        string
+		expectedError error
+	}{
+		{name: "librarians cannot impersonate", adminID: "librarian-1", memberID: "member-1", reason: "Ticket 4821", expectedError: domainerr.ErrImpersonationNotAllowed},
+		{name: "staff cannot be impersonated", adminID: "admin-1", memberID: "librarian-1", reason: "Ticket 4821", expectedError: domainerr.ErrImpersonationNotAllowed},
+		{name: "unknown members", adminID: "admin-1", memberID: "missing", reason: "Ticket 4821", expectedError: domainerr.ErrMemberNotFound},
+		{name: "deactivated members", adminID: "admin-1", memberID: "member-2", reason: "Ticket 4821", expectedError: domainerr.ErrMemberDeactivated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := useCase.Impersonate(tt.adminID, tt.memberID, tt.reason, SessionClient{})
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+	_, _, err := useCase.Impersonate("admin-1", "member-1", " ", SessionClient{})
+	assert.EqualError(t, err, "reason is required")
+
+	session, secret, err := useCase.Impersonate("admin-1", "member-1", " Ticket 4821 ", SessionClient{Device: "Firefox", IP: "192.0.2.1"})
+	require.NoError(t, err)
+	assert.Equal(t, "member-1", session.UserID)
+	assert.Equal(t, "admin-1", *session.ImpersonatorID)
+	assert.Equal(t, "Ticket 4821", session.ImpersonationReason)
+	assert.Equal(t, now.Add(15*time.Minute), session.ExpiresAt)
+
+	// The session signs requests in as the member, and its requests are flagged with the admin
+	authenticated, user, err := useCase.Authenticate(secret, "192.0.2.1")
+	require.NoError(t, err)
+	assert.Equal(t, "member-1", user.ID)
+	useCase.RecordImpersonatedRequest(authenticated, "POST", "/api/me/loans/:id/renew", 200)
+
+	ended, err := useCase.EndImpersonation("admin-1", session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, now, *ended.RevokedAt)
+	_, err = useCase.EndImpersonation("admin-1", session.ID)
+	assert.ErrorIs(t, err, domainerr.ErrImpersonationNotFound)
+	_, _, err = useCase.Authenticate(secret, "192.0.2.1")
+	assert.ErrorIs(t, err, domainerr.ErrInvalidSession)
+
+	auditRepo.On("ListByEntity", entities.AuditEntitySession, session.ID).Return(recorded, nil)
+	report, err := useCase.ImpersonationReport(30)
+	require.NoError(t, err)
+	require.Len(t, report, 1)
+	assert.Equal(t, session.ID, report[0].ID)
+	require.Len(t, report[0].Actions, 3)
+	for i, action := range []string{entities.AuditActionImpersonationStarted, entities.AuditActionImpersonatedRequest, entities.AuditActionImpersonationEnded} {
+		assert.Equal(t, action, report[0].Actions[i].Action)
+		assert.Equal(t, "admin-1", report[0].Actions[i].ImpersonatorID)
+	}
+	assert.Equal(t, "member-1", report[0].Actions[1].Actor)
+	assert.JSONEq(t, `{"method":"POST","route":"/api/me/loans/:id/renew","status":200}`, report[0].Actions[1].Changes)
+
+	_, err = useCase.ImpersonationReport(0)
+	assert.ErrorIs(t, err, ErrInvalidReportDays)
+}
+
+func TestSessionUseCase_MaxPerUserKeepsImpersonations(t *testing.T) {
+	now := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+	adminID := "admin-1"
+	repo := &memorySessionRepository{sessions: []entities.Session{
+		{ID: "impersonation", UserID: "member-1", LastSeenAt: now, ExpiresAt: now.Add(time.Hour), ImpersonatorID: &adminID},
+		{ID: "phone", UserID: "member-1", LastSeenAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Hour)},
+	}}
+	useCase := NewSessionUseCase(repo, &MockUserRepository{}, time.Hour)
+	useCase.SetClock(clock.NewFixed(now))
+	useCase.SetMaxPerUser(2)
+
+	require.NoError(t, useCase.makeRoom("member-1", now))
+	assert.Nil(t, repo.sessions[0].RevokedAt)
+	assert.Nil(t, repo.sessions[1].RevokedAt)
+
+	useCase.SetMaxPerUser(1)
+	require.NoError(t, useCase.makeRoom("member-1", now))
+	assert.Nil(t, repo.sessions[0].RevokedAt)
+	assert.NotNil(t, repo.sessions[1].RevokedAt)
+}
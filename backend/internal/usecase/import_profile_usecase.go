@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// maxCSVImportBooks bounds the rows of a CSV import, like the books of a JSON import
+const maxCSVImportBooks = 5000
+
+// importProfileNamePattern matches import profile names, which are used in URLs and query parameters
+var importProfileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// ImportProfileUseCase handles the column mappings tenants save for CSV imports, and reads CSV
+// imports with them
+type ImportProfileUseCase struct {
+	profileRepo repositories.ImportProfileRepository
+}
+
+// NewImportProfileUseCase creates a new import profile use case
+func NewImportProfileUseCase(profileRepo repositories.ImportProfileRepository) *ImportProfileUseCase {
+	return &ImportProfileUseCase{
+		profileRepo: profileRepo,
+	}
+}
+
+// ListProfiles retrieves the import profiles of a tenant ordered by name
+func (uc *ImportProfileUseCase) ListProfiles(tenantID string) ([]entities.ImportProfile, error) {
+	if tenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	return uc.profileRepo.ListByTenant(tenantID)
+}
+
+// GetProfile retrieves an import profile of a tenant by name
+func (uc *ImportProfileUseCase) GetProfile(tenantID, name string) (*entities.ImportProfile, error) {
+	if tenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	profile, err := uc.profileRepo.Get(tenantID, name)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, domainerr.ErrImportProfileNotFound
+	}
+	return profile, nil
+}
+
+// SaveProfile creates or replaces an import profile of a tenant
+func (uc *ImportProfileUseCase) SaveProfile(profile *entities.ImportProfile) error {
+	if profile.TenantID == "" {
+		return errors.New("tenant ID is required")
+	}
+	if !importProfileNamePattern.MatchString(profile.Name) {
+		return errors.New("import profile names must be lowercase letters, digits, dashes and underscores")
+	}
+	if len(profile.Columns) == 0 {
+		return errors.New("import profiles must map at least one column")
+	}
+
+	columns := make(map[string]string, len(profile.Columns))
+	mapped := make(map[string]string, len(profile.Columns))
+	for column, field := range profile.Columns {
+		column = strings.TrimSpace(column)
+		if column == "" {
+			return errors.New("import profile columns must have a header")
+		}
+		if !entities.IsImportField(field) {
+			return fmt.Errorf("column %s must map to title, author, year, isbn, publisher_id, status or metadata.{key}", column)
+		}
+		if key := strings.TrimPrefix(field, entities.ImportMetadataPrefix); key != field && !metadataKeyPattern.MatchString(key) {
+			return errors.New("metadata keys must be lowercase letters, digits and underscores, starting with a letter")
+		}
+		if other, ok := mapped[field]; ok {
+			return fmt.Errorf("columns %s and %s both map to %s", other, column, field)
+		}
+		mapped[field] = column
+		columns[column] = field
+	}
+	profile.Columns = columns
+
+	if profile.DateFormat != "" && !strings.Contains(profile.DateFormat, "2006") {
+		return errors.New("date format must be a Go time layout with the year 2006, e.g. 02/01/2006")
+	}
+	if profile.Delimiter != "" && !validDelimiter(profile.Delimiter) {
+		return errors.New("delimiter must be a single character other than a quote or line break")
+	}
+
+	return uc.profileRepo.Save(profile)
+}
+
+// DeleteProfile removes an import profile of a tenant
+func (uc *ImportProfileUseCase) DeleteProfile(tenantID, name string) error {
+	if tenantID == "" {
+		return errors.New("tenant ID is required")
+	}
+
+	deleted, err := uc.profileRepo.Delete(tenantID, name)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return domainerr.ErrImportProfileNotFound
+	}
+	return nil
+}
+
+// ReadBooks reads the books of a CSV import with the named profile of the tenant. Without a
+// profile, the headers of the columns are the field names themselves. A row that cannot be read
+// fails the whole import, while invalid books are left for ImportBooks to reject one by one.
+func (uc *ImportProfileUseCase) ReadBooks(tenantID, profileName string, r io.Reader) ([]entities.Book, error) {
+	profile := &entities.ImportProfile{}
+	if profileName != "" {
+		var err error
+		if profile, err = uc.GetProfile(tenantID, profileName); err != nil {
+			return nil, err
+		}
+	}
+
+	reader := csv.NewReader(r)
+	if profile.Delimiter != "" {
+		reader.Comma, _ = utf8.DecodeRuneInString(profile.Delimiter)
+	}
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("the CSV has no header row")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	fields, err := columnFields(profile, header)
+	if err != nil {
+		return nil, err
+	}
+
+	var books []entities.Book
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		if len(books) == maxCSVImportBooks {
+			return nil, fmt.Errorf("CSV imports are limited to %d books", maxCSVImportBooks)
+		}
+		book, err := readBook(fields, record, profile.DateFormat)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		books = append(books, book)
+	}
+	if len(books) == 0 {
+		return nil, errors.New("the CSV has no books")
+	}
+	return books, nil
+}
+
+// columnFields returns the book field of each column of a CSV header, empty for the columns
+// that are not imported. Every column of the profile must be in the header.
+func columnFields(profile *entities.ImportProfile, header []string) ([]string, error) {
+	fields := make([]string, len(header))
+	found := make(map[string]bool, len(header))
+	for i, column := range header {
+		column = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+		found[column] = true
+		if profile.Columns == nil {
+			if entities.IsImportField(column) {
+				fields[i] = column
+			}
+			continue
+		}
+		fields[i] = profile.Columns[column]
+	}
+	for column := range profile.Columns {
+		if !found[column] {
+			return nil, fmt.Errorf("column %s of the import profile is missing from the CSV header", column)
+		}
+	}
+	return fields, nil
+}
+
+// readBook builds the book of a CSV row from the fields of its columns
+func readBook(fields, record []string, dateFormat string) (entities.Book, error) {
+	var book entities.Book
+	for i, value := range record {
+		if i >= len(fields) || fields[i] == "" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch field := fields[i]; field {
+		case entities.ImportFieldTitle:
+			book.Title = value
+		case entities.ImportFieldAuthor:
+			book.Author = value
+		case entities.ImportFieldISBN:
+			book.ISBN = value
+		case entities.ImportFieldStatus:
+			book.Status = value
+		case entities.ImportFieldPublisherID:
+			if value != "" {
+				book.PublisherID = &value
+			}
+		case entities.ImportFieldYear:
+			year, err := readYear(value, dateFormat)
+			if err != nil {
+				return book, err
+			}
+			book.Year = year
+		default:
+			if value == "" {
+				continue
+			}
+			if book.Metadata == nil {
+				book.Metadata = make(map[string]interface{})
+			}
+			book.Metadata[strings.TrimPrefix(field, entities.ImportMetadataPrefix)] = value
+		}
+	}
+	return book, nil
+}
+
+// readYear reads the year of a book from a plain year, or from a date in the date format when set
+func readYear(value, dateFormat string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if dateFormat != "" {
+		date, err := time.Parse(dateFormat, value)
+		if err != nil {
+			return 0, fmt.Errorf("year %q does not match the date format %s", value, dateFormat)
+		}
+		return date.Year(), nil
+	}
+	year, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("year %q is not a number", value)
+	}
+	return year, nil
+}
+
+// validDelimiter reports whether a CSV delimiter is one character that can separate fields
+func validDelimiter(delimiter string) bool {
+	r, size := utf8.DecodeRuneInString(delimiter)
+	if size != len(delimiter) || r == utf8.RuneError {
+		return false
+	}
+	switch r {
+	case '"', '\r', '\n':
+		return false
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockImportProfileRepository is a mock implementation of ImportProfileRepository
+type MockImportProfileRepository struct {
+	mock.Mock
+}
+
+func (m *MockImportProfileRepository) Save(profile *entities.ImportProfile) error {
+	args := m.Called(profile)
+	return args.Error(0)
+}
+
+func (m *MockImportProfileRepository) Get(tenantID, name string) (*entities.ImportProfile, error) {
+	args := m.Called(tenantID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ImportProfile), args.Error(1)
+}
+
+func (m *MockImportProfileRepository) ListByTenant(tenantID string) ([]entities.ImportProfile, error) {
+	args := m.Called(tenantID)
+	return args.Get(0).([]entities.ImportProfile), args.Error(1)
+}
+
+func (m *MockImportProfileRepository) Delete(tenantID, name string) (bool, error) {
+	args := m.Called(tenantID, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestImportProfileUseCase_SaveProfile(t *testing.T) {
+	tests := []struct {
+		name          string
+		profile       entities.ImportProfile
+		expectedError string
+	}{
+		{name: "valid profile", profile: entities.ImportProfile{TenantID: "tenant-1", Name: "publisher-feed", Columns: map[string]string{" Book Title ": "title", "Shelf": "metadata.shelf_code"}, DateFormat: "02/01/2006", Delimiter: ";"}},
+		{name: "no tenant", profile: entities.ImportProfile{Name: "feed", Columns: map[string]string{"Title": "title"}}, expectedError: "tenant ID is required"},
+		{name: "invalid name", profile: entities.ImportProfile{TenantID: "tenant-1", Name: "Publisher Feed", Columns: map[string]string{"Title": "title"}}, expectedError: "import profile names must be lowercase letters, digits, dashes and underscores"},
+		{name: "no columns", profile: entities.ImportProfile{TenantID: "tenant-1", Name: "feed"}, expectedError: "import profiles must map at least one column"},
+		{name: "unknown field", profile: entities.ImportProfile{TenantID: "tenant-1", Name: "feed", Columns: map[string]string{"Pages": "pages"}}, expectedError: "column Pages must map to title, author, year, isbn, publisher_id, status or metadata.{key}"},
+		{name: "invalid metadata key", profile: entities.ImportProfile{TenantID: "tenant-1", Name: "feed", Columns: map[string]string{"Shelf": "metadata.Shelf Code"}}, expectedError: "metadata keys must be lowercase letters, digits and underscores, starting with a letter"},
+		{name: "field mapped twice", profile: entities.ImportProfile{TenantID: "tenant-1", Name: "feed", Columns: map[string]string{"Title": "title", "Name": "title"}}, expectedError: "both map to title"},
+		{name: "date format without year", profile: entities.ImportProfile{TenantID: "tenant-1", Name: "feed", Columns: map[string]string{"Date": "year"}, DateFormat: "DD/MM/YYYY"}, expectedError: "date format must be a Go time layout with the year 2006, e.g. 02/01/2006"},
+		{name: "invalid delimiter", profile: entities.ImportProfile{TenantID: "tenant-1", Name: "feed", Columns: map[string]string{"Title": "title"}, Delimiter: ";;"}, expectedError: "delimiter must be a single character other than a quote or line break"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profileRepo := &MockImportProfileRepository{}
+			profileRepo.On("Save", mock.Anything).Return(nil)
+			useCase := NewImportProfileUseCase(profileRepo)
+
+			profile := tt.profile
+			err := useCase.SaveProfile(&profile)
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				profileRepo.AssertNotCalled(t, "Save", mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"Book Title": "title", "Shelf": "metadata.shelf_code"}, profile.Columns)
+			profileRepo.AssertCalled(t, "Save", &profile)
+		})
+	}
+}
+
+func TestImportProfileUseCase_DeleteProfile(t *testing.T) {
+	profileRepo := &MockImportProfileRepository{}
+	profileRepo.On("Delete", "tenant-1", "feed").Return(true, nil)
+	profileRepo.On("Delete", "tenant-1", "missing").Return(false, nil)
+	useCase := NewImportProfileUseCase(profileRepo)
+
+	assert.NoError(t, useCase.DeleteProfile("tenant-1", "feed"))
+	assert.ErrorIs(t, useCase.DeleteProfile("tenant-1", "missing"), domainerr.ErrImportProfileNotFound)
+	assert.EqualError(t, useCase.DeleteProfile("", "feed"), "tenant ID is required")
+}
+
+func TestImportProfileUseCase_ReadBooks(t *testing.T) {
+	profileRepo := &MockImportProfileRepository{}
+	profileRepo.On("Get", "tenant-1", "publisher-feed").Return(&entities.ImportProfile{
+		TenantID:   "tenant-1",
+		Name:       "publisher-feed",
+		Columns:    map[string]string{"Book Title": "title", "Writer": "author", "Published": "year", "EAN": "isbn", "Shelf": "metadata.shelf_code"},
+		DateFormat: "02/01/2006",
+		Delimiter:  ";",
+	}, nil)
+	profileRepo.On("Get", "tenant-1", "missing").Return(nil, nil)
+	useCase := NewImportProfileUseCase(profileRepo)
+
+	t.Run("maps the columns with the profile", func(t *testing.T) {
+		books, err := useCase.ReadBooks("tenant-1", "publisher-feed", strings.NewReader(
+			"\ufeffBook Title;Writer;Published;EAN;Shelf;Price\n"+
+				"Moby-Dick;Herman Melville;18/10/1851;9781503280786;M-3;12.50\n"+
+				"\"Emma; a Novel\";Jane Austen;23/12/1815;9780141439587;;9.99\n"))
+		require.NoError(t, err)
+		assert.Equal(t, []entities.Book{
+			{Title: "Moby-Dick", Author: "Herman Melville", Year: 1851, ISBN: "9781503280786", Metadata: map[string]interface{}{"shelf_code": "M-3"}},
+			{Title: "Emma; a Novel", Author: "Jane Austen", Year: 1815, ISBN: "9780141439587"},
+		}, books)
+	})
+
+	t.Run("reads field names as headers without a profile", func(t *testing.T) {
+		books, err := useCase.ReadBooks("", "", strings.NewReader("title,author,year,isbn,metadata.signed,notes\nEmma,Jane Austen,1815,9780141439587,yes,ignored\n"))
+		require.NoError(t, err)
+		assert.Equal(t, []entities.Book{
+			{Title: "Emma", Author: "Jane Austen", Year: 1815, ISBN: "9780141439587", Metadata: map[string]interface{}{"signed": "yes"}},
+		}, books)
+	})
+
+	errorTests := []struct {
+		name          string
+		profile       string
+		csv           string
+		expectedError string
+	}{
+		{name: "date not in the format", profile: "publisher-feed", csv: "Book Title;Writer;Published;EAN;Shelf\nEmma;Jane Austen;1815-12-23;9780141439587;\n", expectedError: "row 2: year \"1815-12-23\" does not match the date format 02/01/2006"},
+		{name: "year not a number", csv: "title,year\nEmma,circa 1815\n", expectedError: "row 2: year \"circa 1815\" is not a number"},
+		{name: "column of the profile missing", profile: "publisher-feed", csv: "Book Title;Writer;Published;EAN\nEmma;Jane Austen;23/12/1815;9780141439587\n", expectedError: "column Shelf of the import profile is missing from the CSV header"},
+		{name: "no books", csv: "title,year\n", expectedError: "the CSV has no books"},
+		{name: "empty", csv: "", expectedError: "the CSV has no header row"},
+		{name: "malformed", csv: "title,year\n\"Emma,1815\n", expectedError: "invalid CSV"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := useCase.ReadBooks("tenant-1", tt.profile, strings.NewReader(tt.csv))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+
+	t.Run("unknown profile", func(t *testing.T) {
+		_, err := useCase.ReadBooks("tenant-1", "missing", strings.NewReader("title\nEmma\n"))
+		assert.ErrorIs(t, err, domainerr.ErrImportProfileNotFound)
+	})
+
+	t.Run("profile without tenant", func(t *testing.T) {
+		_, err := useCase.ReadBooks("", "publisher-feed", strings.NewReader("title\nEmma\n"))
+		assert.EqualError(t, err, "tenant ID is required")
+	})
+}
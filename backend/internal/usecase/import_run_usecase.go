@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/pagetoken"
+	"library-management-system/internal/domain/repositories"
+)
+
+// defaultImportRuns is the number of runs listed unless the request asks for another
+const defaultImportRuns = 50
+
+// Actions on a file imported again within the duplicate window
+const (
+	DuplicateImportReject = "reject"
+	DuplicateImportWarn   = "warn"
+)
+
+// ImportRunUseCase keeps the history of book imports and recognizes files imported again
+type ImportRunUseCase struct {
+	runRepo repositories.ImportRunRepository
+	// window is how long a completed import of a file is remembered, zero turns detection off
+	window time.Duration
+	// action is DuplicateImportReject or DuplicateImportWarn
+	action  string
+	clock   clock.Clock
+	cursors pageCursors
+}
+
+// NewImportRunUseCase creates a new import run use case; files imported again within window are
+// rejected or only warned about as action says
+func NewImportRunUseCase(runRepo repositories.ImportRunRepository, window time.Duration, action string) *ImportRunUseCase {
+	return &ImportRunUseCase{
+		runRepo: runRepo,
+		window:  window,
+		action:  action,
+		clock:   clock.System{},
+		cursors: pageCursors{listing: "imports"},
+	}
+}
+
+// SetCursorSigner signs the cursors of the pages of runs with signer
+func (uc *ImportRunUseCase) SetCursorSigner(signer *pagetoken.Signer) {
+	uc.cursors.signer = signer
+}
+
+// SetClock replaces the clock runs are dated with
+func (uc *ImportRunUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// FileHash returns the hash import runs identify a file by
+func FileHash(file []byte) string {
+	sum := sha256.Sum256(file)
+	return hex.EncodeToString(sum[:])
+}
+
+// CheckDuplicate looks for a completed import of the same file for the tenant within the
+// duplicate window. It returns the earlier run, with ErrDuplicateImport unless duplicates are
+// only warned about.
+func (uc *ImportRunUseCase) CheckDuplicate(tenantID, fileHash string) (*entities.ImportRun, error) {
+	if uc.window <= 0 {
+		return nil, nil
+	}
+
+	previous, err := uc.runRepo.FindCompleted(tenantID, fileHash, uc.clock.Now().Add(-uc.window))
+	if err != nil || previous == nil {
+		return nil, err
+	}
+	if uc.action == DuplicateImportWarn {
+		return previous, nil
+	}
+	return previous, domainerr.ErrDuplicateImport
+}
+
+// Record stores an import run
+func (uc *ImportRunUseCase) Record(run *entities.ImportRun) error {
+	if run.FileHash == "" || run.Status == "" {
+		return errors.New("file hash and status are required")
+	}
+	if run.CreatedAt.IsZero() {
+		run.CreatedAt = uc.clock.Now().UTC()
+	}
+	if run.Errors == nil {
+		run.Errors = []entities.ImportRunError{}
+	}
+	return uc.runRepo.Create(run)
+}
+
+// ListRuns retrieves the latest import runs, optionally of a single tenant, from the start or
+// after the page a cursor ends. It returns the cursor of the next page, empty on the last page.
+func (uc *ImportRunUseCase) ListRuns(tenantID string, limit int, cursor string) ([]entities.ImportRun, string, error) {
+	if limit < 1 {
+		limit = defaultImportRuns
+	}
+	query := pagetoken.Query("tenant=" + tenantID)
+	after, err := uc.cursors.after(cursor, query)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// One run more than the page tells whether there is a next page
+	runs, err := uc.runRepo.List(tenantID, after, limit+1)
+	if err != nil || len(runs) <= limit {
+		return runs, "", err
+	}
+	runs = runs[:limit]
+	last := runs[limit-1]
+	return runs, uc.cursors.next(query, last.CreatedAt, last.ID), nil
+}
+
+// GetRun retrieves an import run by ID
+func (uc *ImportRunUseCase) GetRun(id string) (*entities.ImportRun, error) {
+	if id == "" {
+		return nil, errors.New("import run ID is required")
+	}
+
+	run, err := uc.runRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if run == nil {
+		return nil, domainerr.ErrImportRunNotFound
+	}
+	return run, nil
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/pagetoken"
+	"library-management-system/internal/domain/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockImportRunRepository is a mock implementation of ImportRunRepository
+type MockImportRunRepository struct {
+	mock.Mock
+}
+
+func (m *MockImportRunRepository) Create(run *entities.ImportRun) error {
+	args := m.Called(run)
+	return args.Error(0)
+}
+
+func (m *MockImportRunRepository) GetByID(id string) (*entities.ImportRun, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ImportRun), args.Error(1)
+}
+
+func (m *MockImportRunRepository) List(tenantID string, after *repositories.PageAfter, limit int) ([]entities.ImportRun, error) {
+	args := m.Called(tenantID, after, limit)
+	return args.Get(0).([]entities.ImportRun), args.Error(1)
+}
+
+func (m *MockImportRunRepository) FindCompleted(tenantID, fileHash string, since time.Time) (*entities.ImportRun, error) {
+	args := m.Called(tenantID, fileHash, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ImportRun), args.Error(1)
+}
+
+func TestImportRunUseCase_CheckDuplicate(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	previous := &entities.ImportRun{ID: "run-1", FileHash: "abc", Status: entities.ImportRunCompleted}
+	runRepo := &MockImportRunRepository{}
+	runRepo.On("FindCompleted", "tenant-1", "abc", now.Add(-24*time.Hour)).Return(previous, nil)
+	runRepo.On("FindCompleted", "tenant-1", "def", now.Add(-24*time.Hour)).Return(nil, nil)
+
+	rejecting := NewImportRunUseCase(runRepo, 24*time.Hour, DuplicateImportReject)
+	rejecting.SetClock(clock.NewFixed(now))
+	run, err := rejecting.CheckDuplicate("tenant-1", "abc")
+	assert.ErrorIs(t, err, domainerr.ErrDuplicateImport)
+	assert.Equal(t, previous, run)
+
+	run, err = rejecting.CheckDuplicate("tenant-1", "def")
+	require.NoError(t, err)
+	assert.Nil(t, run)
+
+	warning := NewImportRunUseCase(runRepo, 24*time.Hour, DuplicateImportWarn)
+	warning.SetClock(clock.NewFixed(now))
+	run, err = warning.CheckDuplicate("tenant-1", "abc")
+	require.NoError(t, err)
+	assert.Equal(t, previous, run)
+
+	// A zero window turns detection off
+	disabled := NewImportRunUseCase(runRepo, 0, DuplicateImportReject)
+	run, err = disabled.CheckDuplicate("tenant-1", "abc")
+	require.NoError(t, err)
+	assert.Nil(t, run)
+	runRepo.AssertNumberOfCalls(t, "FindCompleted", 3)
+}
+
+func TestImportRunUseCase_Record(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	runRepo := &MockImportRunRepository{}
+	runRepo.On("Create", mock.Anything).Return(nil)
+	useCase := NewImportRunUseCase(runRepo, time.Hour, DuplicateImportReject)
+	useCase.SetClock(clock.NewFixed(now))
+
+	run := &entities.ImportRun{FileHash: FileHash([]byte("title\nEmma\n")), Format: entities.ImportFormatCSV, Status: entities.ImportRunCompleted}
+	require.NoError(t, useCase.Record(run))
+	assert.Equal(t, now, run.CreatedAt)
+	assert.Equal(t, []entities.ImportRunError{}, run.Errors)
+	assert.Len(t, run.FileHash, 64)
+
+	assert.EqualError(t, useCase.Record(&entities.ImportRun{Status: entities.ImportRunFailed}), "file hash and status are required")
+	runRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestImportRunUseCase_ListAndGetRuns(t *testing.T) {
+	runRepo := &MockImportRunRepository{}
+	runRepo.On("List", "", (*repositories.PageAfter)(nil), defaultImportRuns+1).Return([]entities.ImportRun{{ID: "run-2"}, {ID: "run-1"}}, nil)
+	runRepo.On("List", "tenant-1", (*repositories.PageAfter)(nil), 1001).Return([]entities.ImportRun{}, nil)
+	runRepo.On("GetByID", "run-1").Return(&entities.ImportRun{ID: "run-1"}, nil)
+	runRepo.On("GetByID", "missing").Return(nil, nil)
+	useCase := NewImportRunUseCase(runRepo, time.Hour, DuplicateImportReject)
+
+	runs, next, err := useCase.ListRuns("", 0, "")
+	require.NoError(t, err)
+	assert.Len(t, runs, 2)
+	assert.Empty(t, next, "the last page has no next cursor")
+	_, _, err = useCase.ListRuns("tenant-1", 1000, "")
+	require.NoError(t, err)
+
+	run, err := useCase.GetRun("run-1")
+	require.NoError(t, err)
+	assert.Equal(t, "run-1", run.ID)
+	_, err = useCase.GetRun("missing")
+	assert.ErrorIs(t, err, domainerr.ErrImportRunNotFound)
+}
+
+func TestImportRunUseCase_ListRunsByCursor(t *testing.T) {
+	at := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	runRepo := &MockImportRunRepository{}
+	runRepo.On("List", "tenant-1", (*repositories.PageAfter)(nil), 3).
+		Return([]entities.ImportRun{{ID: "run-3", CreatedAt: at}, {ID: "run-2", CreatedAt: at}, {ID: "run-1", CreatedAt: at}}, nil)
+	runRepo.On("List", "tenant-1", &repositories.PageAfter{Time: at, ID: "run-2"}, 3).
+		Return([]entities.ImportRun{{ID: "run-1", CreatedAt: at}}, nil)
+	useCase := NewImportRunUseCase(runRepo, time.Hour, DuplicateImportReject)
+	useCase.SetCursorSigner(pagetoken.NewSigner("secret"))
+
+	runs, next, err := useCase.ListRuns("tenant-1", 2, "")
+	require.NoError(t, err)
+	assert.Len(t, runs, 2)
+	require.NotEmpty(t, next)
+
+	runs, last, err := useCase.ListRuns("tenant-1", 2, next)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.ImportRun{{ID: "run-1", CreatedAt: at}}, runs)
+	assert.Empty(t, last)
+
+	_, _, err = useCase.ListRuns("tenant-2", 2, next)
+	assert.ErrorIs(t, err, domainerr.ErrCursorMismatch, "the cursor of another tenant")
+	_, _, err = useCase.ListRuns("tenant-1", 2, next+"x")
+	assert.ErrorIs(t, err, domainerr.ErrInvalidCursor)
+	_, _, err = NewImportRunUseCase(runRepo, time.Hour, DuplicateImportReject).ListRuns("tenant-1", 2, next)
+	assert.ErrorIs(t, err, domainerr.ErrInvalidCursor, "cursors are refused without a signer")
+}
@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// maxScansPerBatch bounds the barcodes accepted by a single scan request
+const maxScansPerBatch = 1000
+
+// ScanBatchResult summarizes a batch of scans
+type ScanBatchResult struct {
+	Recorded int `json:"recorded"`
+	// Matched counts the barcodes of the batch that belong to a catalog book
+	Matched int `json:"matched"`
+	// Unmatched lists the barcodes of the batch that belong to no catalog book
+	Unmatched []string `json:"unmatched"`
+	// Total is the number of scans of the session so far
+	Total int `json:"total"`
+}
+
+// InventoryUseCase runs shelf audits: librarians scan the barcodes (ISBNs) of the books they find,
+// possibly over several visits, and the session is reconciled with the catalog when it closes
+type InventoryUseCase struct {
+	inventoryRepo repositories.InventoryRepository
+	bookRepo      repositories.BookRepository
+	clock         clock.Clock
+}
+
+// NewInventoryUseCase creates a new inventory use case
+func NewInventoryUseCase(inventoryRepo repositories.InventoryRepository, bookRepo repositories.BookRepository) *InventoryUseCase {
+	return &InventoryUseCase{
+		inventoryRepo: inventoryRepo,
+		bookRepo:      bookRepo,
+		clock:         clock.System{},
+	}
+}
+
+// SetClock replaces the clock used to timestamp reports
+func (uc *InventoryUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// OpenSession starts an inventory session on behalf of a librarian
+func (uc *InventoryUseCase) OpenSession(librarianID string, session *entities.InventorySession) error {
+	if librarianID == "" {
+		return errors.New("librarian ID is required")
+	}
+	session.Name = strings.TrimSpace(session.Name)
+	if session.Name == "" {
+		return errors.New("session name is required")
+	}
+
+	session.Status = entities.InventorySessionOpen
+	session.OpenedBy = librarianID
+	session.ClosedAt = nil
+	session.Report = ""
+	return uc.inventoryRepo.CreateSession(session)
+}
+
+// ListSessions retrieves all inventory sessions
+func (uc *InventoryUseCase) ListSessions() ([]entities.InventorySession, error) {
+	return uc.inventoryRepo.ListSessions()
+}
+
+// GetSession retrieves an inventory session by ID
+func (uc *InventoryUseCase) GetSession(id string) (*entities.InventorySession, error) {
+	if id == "" {
+		return nil, errors.New("inventory session ID is required")
+	}
+
+	session, err := uc.inventoryRepo.GetSession(id)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, domainerr.ErrInventorySessionNotFound
+	}
+	return session, nil
+}
+
+// RecordScans adds a batch of barcodes scanned at a location to an open session
+func (uc *InventoryUseCase) RecordScans(id, location string, barcodes []string) (*ScanBatchResult, error) {
+	session, err := uc.GetSession(id)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsOpen() {
+		return nil, errors.New("inventory session is closed")
+	}
+	if len(barcodes) == 0 {
+		return nil, errors.New("at least one barcode is required")
+	}
+	if len(barcodes) > maxScansPerBatch {
+		return nil, errors.New("too many barcodes in one batch")
+	}
+
+	catalog, err := uc.catalogByBarcode()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ScanBatchResult{Unmatched: []string{}}
+	scans := make([]entities.InventoryScan, 0, len(barcodes))
+	for _, raw := range barcodes {
+		barcode := normalizeBarcode(raw)
+		if barcode == "" {
+			continue
+		}
+
+		scan := entities.InventoryScan{
+			SessionID: id,
+			Barcode:   barcode,
+			Location:  strings.TrimSpace(location),
+		}
+		if book, ok := catalog[barcode]; ok {
+			bookID := book.ID
+			scan.BookID = &bookID
+			result.Matched++
+		} else {
+			result.Unmatched = append(result.Unmatched, barcode)
+		}
+		scans = append(scans, scan)
+	}
+	if len(scans) == 0 {
+		return nil, errors.New("at least one barcode is required")
+	}
+
+	if err := uc.inventoryRepo.AddScans(scans); err != nil {
+		return nil, err
+	}
+
+	all, err := uc.inventoryRepo.ListScans(id)
+	if err != nil {
+		return nil, err
+	}
+	result.Recorded = len(scans)
+	result.Total = len(all)
+	return result, nil
+}
+
+// GetReport returns the stored report of a closed session, or a preview of an open one
+func (uc *InventoryUseCase) GetReport(id string) (*entities.InventoryReport, error) {
+	session, err := uc.GetSession(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !session.IsOpen() {
+		var report entities.InventoryReport
+		if err := json.Unmarshal([]byte(session.Report), &report); err != nil {
+			return nil, err
+		}
+		return &report, nil
+	}
+
+	return uc.reconcile(session)
+}
+
+// CloseSession reconciles a session with the catalog and stores the final report
+func (uc *InventoryUseCase) CloseSession(id string) (*entities.InventoryReport, error) {
+	session, err := uc.GetSession(id)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsOpen() {
+		return nil, errors.New("inventory session is closed")
+	}
+
+	report, err := uc.reconcile(session)
+	if err != nil {
+		return nil, err
+	}
+	report.Final = true
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+
+	closedAt := report.GeneratedAt
+	session.Status = entities.InventorySessionClosed
+	session.ClosedAt = &closedAt
+	session.Report = string(data)
+	if err := uc.inventoryRepo.UpdateSession(session); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// reconcile compares the scans of a session with the catalog and the locations of the previous audit
+func (uc *InventoryUseCase) reconcile(session *entities.InventorySession) (*entities.InventoryReport, error) {
+	catalog, err := uc.catalogByBarcode()
+	if err != nil {
+		return nil, err
+	}
+	scans, err := uc.inventoryRepo.ListScans(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := uc.previousLocations()
+	if err != nil {
+		return nil, err
+	}
+
+	// The latest scan of a barcode tells where it is now
+	found := make(map[string]string)
+	var order []string
+	for _, scan := range scans {
+		if _, seen := found[scan.Barcode]; !seen {
+			order = append(order, scan.Barcode)
+		}
+		found[scan.Barcode] = scan.Location
+	}
+
+	report := &entities.InventoryReport{
+		SessionID:   session.ID,
+		GeneratedAt: uc.clock.Now().UTC(),
+		Scanned:     len(scans),
+		Missing:     []entities.InventoryItem{},
+		Misplaced:   []entities.InventoryItem{},
+		Unexpected:  []entities.InventoryItem{},
+	}
+
+	for _, barcode := range order {
+		location := found[barcode]
+		book, ok := catalog[barcode]
+		if !ok {
+			report.Unexpected = append(report.Unexpected, entities.InventoryItem{Barcode: barcode, FoundLocation: location})
+			continue
+		}
+
+		report.Found++
+		if previous, ok := expected[book.ID]; ok && previous != location {
+			report.Misplaced = append(report.Misplaced, entities.InventoryItem{
+				BookID:           book.ID,
+				Title:            book.Title,
+				Barcode:          barcode,
+				ExpectedLocation: previous,
+				FoundLocation:    location,
+			})
+		}
+	}
+
+	for barcode, book := range catalog {
+		if _, ok := found[barcode]; ok {
+			continue
+		}
+		report.Missing = append(report.Missing, entities.InventoryItem{
+			BookID:           book.ID,
+			Title:            book.Title,
+			Barcode:          barcode,
+			ExpectedLocation: expected[book.ID],
+		})
+	}
+	sort.Slice(report.Missing, func(i, j int) bool {
+		if report.Missing[i].Title != report.Missing[j].Title {
+			return report.Missing[i].Title < report.Missing[j].Title
+		}
+		return report.Missing[i].Barcode < report.Missing[j].Barcode
+	})
+
+	return report, nil
+}
+
+// previousLocations returns where the last closed audit found each book
+func (uc *InventoryUseCase) previousLocations() (map[string]string, error) {
+	locations := make(map[string]string)
+
+	previous, err := uc.inventoryRepo.LastClosedSession()
+	if err != nil || previous == nil {
+		return locations, err
+	}
+
+	scans, err := uc.inventoryRepo.ListScans(previous.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, scan := range scans {
+		if scan.BookID != nil {
+			locations[*scan.BookID] = scan.Location
+		}
+	}
+	return locations, nil
+}
+
+// catalogByBarcode indexes the books on the shelves by their normalized ISBN
+func (uc *InventoryUseCase) catalogByBarcode() (map[string]entities.Book, error) {
+	books, err := uc.bookRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := make(map[string]entities.Book, len(books))
+	for _, book := range books {
+		if book.DeletedAt == nil {
+			catalog[normalizeBarcode(book.ISBN)] = book
+		}
+	}
+	return catalog, nil
+}
+
+// normalizeBarcode strips separators from a scanned ISBN and upper-cases its check digit
+func normalizeBarcode(barcode string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(barcode)))
+}
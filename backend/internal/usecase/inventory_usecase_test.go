@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockInventoryRepository is a mock implementation of InventoryRepository
+type MockInventoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockInventoryRepository) CreateSession(session *entities.InventorySession) error {
+	args := m.Called(session)
+	return args.Error(0)
+}
+
+func (m *MockInventoryRepository) GetSession(id string) (*entities.InventorySession, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.InventorySession), args.Error(1)
+}
+
+func (m *MockInventoryRepository) ListSessions() ([]entities.InventorySession, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.InventorySession), args.Error(1)
+}
+
+func (m *MockInventoryRepository) UpdateSession(session *entities.InventorySession) error {
+	args := m.Called(session)
+	return args.Error(0)
+}
+
+func (m *MockInventoryRepository) LastClosedSession() (*entities.InventorySession, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.InventorySession), args.Error(1)
+}
+
+func (m *MockInventoryRepository) AddScans(scans []entities.InventoryScan) error {
+	args := m.Called(scans)
+	return args.Error(0)
+}
+
+func (m *MockInventoryRepository) ListScans(sessionID string) ([]entities.InventoryScan, error) {
+	args := m.Called(sessionID)
+	return args.Get(0).([]entities.InventoryScan), args.Error(1)
+}
+
+func TestInventoryUseCase_RecordScans(t *testing.T) {
+	mockRepo := &MockInventoryRepository{}
+	mockBookRepo := &MockBookRepository{}
+	mockRepo.On("GetSession", "open").Return(&entities.InventorySession{ID: "open", Status: entities.InventorySessionOpen}, nil)
+	mockRepo.On("GetSession", "closed").Return(&entities.InventorySession{ID: "closed", Status: entities.InventorySessionClosed}, nil)
+	mockRepo.On("AddScans", mock.Anything).Return(nil)
+	mockRepo.On("ListScans", "open").Return(make([]entities.InventoryScan, 4), nil)
+	mockBookRepo.On("GetAll").Return([]entities.Book{{ID: "book-1", ISBN: "9780306406157"}}, nil)
+
+	useCase := NewInventoryUseCase(mockRepo, mockBookRepo)
+
+	result, err := useCase.RecordScans("open", " Shelf 3 ", []string{"978-0-306-40615-7", "", "12345"})
+	assert.NoError(t, err)
+	assert.Equal(t, &ScanBatchResult{Recorded: 2, Matched: 1, Unmatched: []string{"12345"}, Total: 4}, result)
+
+	scans := mockRepo.Calls[len(mockRepo.Calls)-2].Arguments.Get(0).([]entities.InventoryScan)
+	assert.Equal(t, "9780306406157", scans[0].Barcode)
+	assert.Equal(t, "Shelf 3", scans[0].Location)
+	assert.Equal(t, "book-1", *scans[0].BookID)
+	assert.Nil(t, scans[1].BookID)
+
+	_, err = useCase.RecordScans("closed", "Shelf 3", []string{"9780306406157"})
+	assert.EqualError(t, err, "inventory session is closed")
+
+	_, err = useCase.RecordScans("open", "Shelf 3", []string{" "})
+	assert.EqualError(t, err, "at least one barcode is required")
+}
+
+func TestInventoryUseCase_CloseSession(t *testing.T) {
+	closedAt := time.Date(2024, time.June, 1, 17, 0, 0, 0, time.UTC)
+	deletedAt := time.Now()
+
+	mockRepo := &MockInventoryRepository{}
+	mockBookRepo := &MockBookRepository{}
+	mockRepo.On("GetSession", "summer").Return(&entities.InventorySession{ID: "summer", Status: entities.InventorySessionOpen}, nil)
+	mockRepo.On("LastClosedSession").Return(&entities.InventorySession{ID: "spring", Status: entities.InventorySessionClosed}, nil)
+	mockRepo.On("ListScans", "spring").Return([]entities.InventoryScan{
+		{Barcode: "1111", Location: "Shelf 1", BookID: stringPtr("in-place")},
+		{Barcode: "2222", Location: "Shelf 1", BookID: stringPtr("moved")},
+		{Barcode: "3333", Location: "Shelf 2", BookID: stringPtr("lost")},
+	}, nil)
+	mockRepo.On("ListScans", "summer").Return([]entities.InventoryScan{
+		{Barcode: "1111", Location: "Shelf 1"},
+		{Barcode: "2222", Location: "Shelf 1"},
+		{Barcode: "2222", Location: "Shelf 4"},
+		{Barcode: "9999", Location: "Shelf 4"},
+	}, nil)
+	mockRepo.On("UpdateSession", mock.AnythingOfType("*entities.InventorySession")).Return(nil)
+	mockBookRepo.On("GetAll").Return([]entities.Book{
+		{ID: "in-place", Title: "In place", ISBN: "1111"},
+		{ID: "moved", Title: "Moved", ISBN: "2222"},
+		{ID: "lost", Title: "Lost", ISBN: "3333"},
+		{ID: "deleted", Title: "Deleted", ISBN: "4444", DeletedAt: &deletedAt},
+	}, nil)
+
+	useCase := NewInventoryUseCase(mockRepo, mockBookRepo)
+	useCase.SetClock(clock.NewFixed(closedAt))
+
+	report, err := useCase.CloseSession("summer")
+	assert.NoError(t, err)
+	assert.True(t, report.Final)
+	assert.Equal(t, 4, report.Scanned)
+	assert.Equal(t, 2, report.Found)
+	assert.Equal(t, []entities.InventoryItem{{BookID: "lost", Title: "Lost", Barcode: "3333", ExpectedLocation: "Shelf 2"}}, report.Missing)
+	assert.Equal(t, []entities.InventoryItem{{BookID: "moved", Title: "Moved", Barcode: "2222", ExpectedLocation: "Shelf 1", FoundLocation: "Shelf 4"}}, report.Misplaced)
+	assert.Equal(t, []entities.InventoryItem{{Barcode: "9999", FoundLocation: "Shelf 4"}}, report.Unexpected)
+
+	session := mockRepo.Calls[len(mockRepo.Calls)-1].Arguments.Get(0).(*entities.InventorySession)
+	assert.False(t, session.IsOpen())
+	assert.Equal(t, closedAt, *session.ClosedAt)
+	assert.NotEmpty(t, session.Report)
+
+	mockRepo.On("GetSession", "closed").Return(session, nil)
+	stored, err := useCase.GetReport("closed")
+	assert.NoError(t, err)
+	assert.Equal(t, report, stored)
+}
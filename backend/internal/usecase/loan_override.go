@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+)
+
+// Operations policies are overridden for
+const (
+	OverrideCheckout = "checkout"
+	OverrideRenewal  = "renewal"
+)
+
+// ErrInvalidWeek is returned for a report week not written as an ISO week
+var ErrInvalidWeek = errors.New("week must be an ISO week such as 2024-W03")
+
+// Override lets staff lend or renew a book past the circulation policies blocking it. Without a
+// reason the policies apply as usual.
+type Override struct {
+	Staff  entities.Actor
+	Reason string
+}
+
+// checkOverride returns nil when override lets staff past a policy block, and the block itself
+// when there is no override
+func (uc *LoanUseCase) checkOverride(override Override, block error) error {
+	if strings.TrimSpace(override.Reason) == "" {
+		return block
+	}
+	staff := override.Staff.Role == entities.UserRoleAdmin || override.Staff.Role == entities.UserRoleLibrarian
+	if !staff || override.Staff.UserID == "" {
+		return domainerr.ErrOverrideNotAllowed
+	}
+	if uc.auditRepo == nil {
+		return errors.New("policy overrides are not enabled")
+	}
+	return nil
+}
+
+// recordOverrides records in the audit trail each policy block staff lent or renewed a loan past,
+// with their reason and the shift they made it in
+func (uc *LoanUseCase) recordOverrides(loan *entities.Loan, operation string, blocks []error, override Override) {
+	if len(blocks) == 0 {
+		return
+	}
+
+	now := uc.clock.Now()
+	shift := entities.ShiftAt(uc.shifts, now.In(uc.shiftLocation))
+	entries := make([]entities.AuditEntry, 0, len(blocks))
+	for _, block := range blocks {
+		code := block.Error()
+		if e, ok := domainerr.Lookup(block); ok {
+			code = e.Code
+		}
+		changes, err := json.Marshal(map[string]string{
+			"operation": operation,
+			"block":     code,
+			"member_id": loan.UserID,
+			"book_id":   loan.BookID,
+			"reason":    strings.TrimSpace(override.Reason),
+			"shift":     shift,
+		})
+		if err != nil {
+			continue
+		}
+		entries = append(entries, entities.AuditEntry{
+			EntityType:     entities.AuditEntityLoan,
+			EntityID:       loan.ID,
+			Action:         entities.AuditActionPolicyOverridden,
+			Actor:          override.Staff.UserID,
+			Changes:        string(changes),
+			CreatedAt:      now,
+			ImpersonatorID: override.Staff.ImpersonatorID,
+		})
+	}
+
+	// CreateBatch writes right away, even behind the write-behind buffer, which may drop entries
+	if err := uc.auditRepo.CreateBatch(entries); err != nil {
+		log.Printf("Failed to record policy overrides of loan %s: %v", loan.ID, err)
+	}
+}
+
+// OverrideReport summarizes the policy overrides of an ISO week, e.g. 2024-W03, in the timezone
+// of the shifts; the current week when week is empty
+func (uc *LoanUseCase) OverrideReport(week string) (*entities.OverrideReport, error) {
+	if uc.auditRepo == nil {
+		return nil, errors.New("policy overrides are not enabled")
+	}
+	from, err := uc.weekStart(week)
+	if err != nil {
+		return nil, err
+	}
+	to := from.AddDate(0, 0, 7)
+
+	entries, err := uc.auditRepo.ListByAction(entities.AuditActionPolicyOverridden, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	year, number := from.ISOWeek()
+	report := &entities.OverrideReport{
+		Week:      fmt.Sprintf("%04d-W%02d", year, number),
+		From:      from,
+		To:        to,
+		ByBlock:   map[string]int{},
+		ByShift:   map[string]int{},
+		ByStaff:   []entities.StaffOverrides{},
+		Overrides: []entities.PolicyOverride{},
+	}
+	byStaff := map[string]int{}
+	for _, entry := range entries {
+		var changes map[string]string
+		if err := json.Unmarshal([]byte(entry.Changes), &changes); err != nil {
+			continue
+		}
+		override := entities.PolicyOverride{
+			LoanID:       entry.EntityID,
+			Operation:    changes["operation"],
+			Block:        changes["block"],
+			MemberID:     changes["member_id"],
+			BookID:       changes["book_id"],
+			StaffID:      entry.Actor,
+			Reason:       changes["reason"],
+			Shift:        changes["shift"],
+			OverriddenAt: entry.CreatedAt,
+		}
+		report.Overrides = append(report.Overrides, override)
+		report.ByBlock[override.Block]++
+		report.ByShift[override.Shift]++
+		byStaff[override.StaffID]++
+	}
+	report.Total = len(report.Overrides)
+
+	for staffID, count := range byStaff {
+		report.ByStaff = append(report.ByStaff, entities.StaffOverrides{StaffID: staffID, Count: count})
+	}
+	sort.Slice(report.ByStaff, func(i, j int) bool {
+		if report.ByStaff[i].Count != report.ByStaff[j].Count {
+			return report.ByStaff[i].Count > report.ByStaff[j].Count
+		}
+		return report.ByStaff[i].StaffID < report.ByStaff[j].StaffID
+	})
+	return report, nil
+}
+
+// weekStart returns the Monday midnight an ISO week starts at, in the timezone of the shifts
+func (uc *LoanUseCase) weekStart(week string) (time.Time, error) {
+	var year, number int
+	if week == "" {
+		year, number = uc.clock.Now().In(uc.shiftLocation).ISOWeek()
+	} else if n, err := fmt.Sscanf(week, "%4d-W%2d", &year, &number); err != nil || n != 2 || len(week) != len("2006-W01") {
+		return time.Time{}, ErrInvalidWeek
+	}
+
+	// January 4th is always in the first week of its year
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, uc.shiftLocation)
+	start := jan4.AddDate(0, 0, -((int(jan4.Weekday())+6)%7)+(number-1)*7)
+	if y, w := start.ISOWeek(); y != year || w != number {
+		return time.Time{}, ErrInvalidWeek
+	}
+	return start, nil
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newCheckoutUseCase lends book-1 to member-1, who has one book out, with a loan limit of one
+func newCheckoutUseCase(t *testing.T, now time.Time) (*LoanUseCase, *MockLoanRepository, *MockAuditRepository) {
+	bookRepo := &MockBookRepository{}
+	bookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1", Status: entities.BookStatusActive}, nil)
+	loanRepo := &MockLoanRepository{}
+	loanRepo.On("ListActiveByUser", "member-1").Return([]entities.Loan{{ID: "loan-0"}}, nil)
+	loanRepo.On("Create", mock.AnythingOfType("*entities.Loan")).Run(func(args mock.Arguments) {
+		args.Get(0).(*entities.Loan).ID = "loan-1"
+	}).Return(nil)
+	holdRepo := &MockHoldRepository{}
+	holdRepo.On("CountPending", "book-1", "member-1").Return(int64(0), nil)
+	holdRepo.On("ListPendingByUser", "member-1").Return([]entities.Hold{}, nil)
+	auditRepo := &MockAuditRepository{}
+
+	morning, err := entities.ParseShift("morning", "08:00-13:00")
+	require.NoError(t, err)
+	useCase := NewLoanUseCase(loanRepo, holdRepo, &MockFineRepository{}, stubPolicyRepository{entities.PolicyMaxActiveLoans: "1"})
+	useCase.SetClock(clock.NewFixed(now))
+	useCase.SetBookRepository(bookRepo)
+	useCase.SetAuditRepository(auditRepo)
+	useCase.SetShifts([]entities.Shift{morning}, time.UTC)
+	return useCase, loanRepo, auditRepo
+}
+
+func TestLoanUseCase_CheckoutOverride(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	librarian := entities.Actor{UserID: "staff-1", Role: entities.UserRoleLibrarian}
+
+	t.Run("the loan limit blocks checkouts without an override", func(t *testing.T) {
+		useCase, loanRepo, _ := newCheckoutUseCase(t, now)
+
+		_, err := useCase.Checkout("tenant-1", "member-1", "book-1", Override{Staff: librarian})
+
+		assert.ErrorIs(t, err, domainerr.ErrLoanLimitReached)
+		loanRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("only staff can override", func(t *testing.T) {
+		useCase, loanRepo, _ := newCheckoutUseCase(t, now)
+
+		_, err := useCase.Checkout("tenant-1", "member-1", "book-1", Override{
+			Staff:  entities.Actor{UserID: "member-1", Role: entities.UserRoleMember},
+			Reason: "Please",
+		})
+
+		assert.ErrorIs(t, err, domainerr.ErrOverrideNotAllowed)
+		loanRepo.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("staff overrides are recorded with their reason and shift", func(t *testing.T) {
+		useCase, _, auditRepo := newCheckoutUseCase(t, now)
+		var recorded []entities.AuditEntry
+		auditRepo.On("CreateBatch", mock.Anything).Run(func(args mock.Arguments) {
+			recorded = args.Get(0).([]entities.AuditEntry)
+		}).Return(nil)
+
+		loan, err := useCase.Checkout("tenant-1", "member-1", "book-1", Override{Staff: librarian, Reason: " Course reserve "})
+
+		require.NoError(t, err)
+		assert.Equal(t, now.AddDate(0, 0, 14), loan.DueAt)
+		require.Len(t, recorded, 1)
+		assert.Equal(t, "loan-1", recorded[0].EntityID)
+		assert.Equal(t, entities.AuditActionPolicyOverridden, recorded[0].Action)
+		assert.Equal(t, "staff-1", recorded[0].Actor)
+		var changes map[string]string
+		require.NoError(t, json.Unmarshal([]byte(recorded[0].Changes), &changes))
+		assert.Equal(t, map[string]string{
+			"operation": OverrideCheckout,
+			"block":     "loan_limit_reached",
+			"member_id": "member-1",
+			"book_id":   "book-1",
+			"reason":    "Course reserve",
+			"shift":     "morning",
+		}, changes)
+	})
+}
+
+func TestLoanUseCase_OverrideReport(t *testing.T) {
+	// A Friday; its ISO week runs from Monday 12 October
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	from := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	auditRepo := &MockAuditRepository{}
+	auditRepo.On("ListByAction", entities.AuditActionPolicyOverridden, from, from.AddDate(0, 0, 7)).Return([]entities.AuditEntry{
+		{EntityID: "loan-1", Actor: "staff-1", Changes: `{"operation":"checkout","block":"loan_limit_reached","reason":"Course reserve","shift":"morning"}`, CreatedAt: from.Add(10 * time.Hour)},
+		{EntityID: "loan-2", Actor: "staff-2", Changes: `{"operation":"renewal","block":"loan_on_hold","reason":"Hold placed by mistake","shift":"evening"}`, CreatedAt: from.Add(30 * time.Hour)},
+		{EntityID: "loan-3", Actor: "staff-2", Changes: `{"operation":"checkout","block":"loan_limit_reached","reason":"Visiting researcher","shift":"morning"}`, CreatedAt: from.Add(50 * time.Hour)},
+	}, nil)
+	useCase := NewLoanUseCase(&MockLoanRepository{}, &MockHoldRepository{}, &MockFineRepository{}, stubPolicyRepository{})
+	useCase.SetClock(clock.NewFixed(now))
+	useCase.SetAuditRepository(auditRepo)
+
+	for _, week := range []string{"", "2026-W42"} {
+		report, err := useCase.OverrideReport(week)
+		require.NoError(t, err)
+		assert.Equal(t, "2026-W42", report.Week)
+		assert.Equal(t, from, report.From)
+		assert.Equal(t, 3, report.Total)
+		assert.Equal(t, map[string]int{"loan_limit_reached": 2, "loan_on_hold": 1}, report.ByBlock)
+		assert.Equal(t, map[string]int{"morning": 2, "evening": 1}, report.ByShift)
+		assert.Equal(t, []entities.StaffOverrides{{StaffID: "staff-2", Count: 2}, {StaffID: "staff-1", Count: 1}}, report.ByStaff)
+		assert.Equal(t, "Hold placed by mistake", report.Overrides[1].Reason)
+	}
+
+	for _, week := range []string{"2026-42", "2026-W54", "2026-W1", "last week"} {
+		_, err := useCase.OverrideReport(week)
+		assert.EqualError(t, err, "week must be an ISO week such as 2024-W03", week)
+	}
+}
+
+func TestShiftAt(t *testing.T) {
+	day, err := entities.ParseShift("day", "08:00-18:00")
+	require.NoError(t, err)
+	night, err := entities.ParseShift("night", "22:00-06:00")
+	require.NoError(t, err)
+	_, err = entities.ParseShift("late", "22:00")
+	assert.Error(t, err)
+
+	at := func(hour, minute int) time.Time { return time.Date(2026, 10, 16, hour, minute, 0, 0, time.UTC) }
+	shifts := []entities.Shift{day, night}
+	assert.Equal(t, "day", entities.ShiftAt(shifts, at(8, 0)))
+	assert.Equal(t, "night", entities.ShiftAt(shifts, at(23, 15)))
+	assert.Equal(t, "night", entities.ShiftAt(shifts, at(5, 59)))
+	assert.Equal(t, entities.ShiftOffHours, entities.ShiftAt(shifts, at(18, 0)))
+}
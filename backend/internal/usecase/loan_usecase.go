@@ -0,0 +1,643 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/events"
+	"library-management-system/internal/domain/repositories"
+)
+
+// LoanUseCase lets staff lend books and members follow their loans and fines, and enforces the
+// circulation policies of their tenant when books are lent and renewed
+type LoanUseCase struct {
+	loanRepo   repositories.LoanRepository
+	holdRepo   repositories.HoldRepository
+	fineRepo   repositories.FineRepository
+	policyRepo repositories.PolicyRepository
+	bookRepo   repositories.BookRepository
+	auditRepo  repositories.AuditRepository
+	userRepo   repositories.UserRepository
+	eventBus   events.Bus
+	clock      clock.Clock
+	// calendar moves due dates off the days the library is closed
+	calendar *CalendarUseCase
+	// timezones tell the timezone of the branch of a book, which due dates are counted in
+	timezones *TimezoneUseCase
+	// shifts and shiftLocation tell the shift staff override policies in
+	shifts        []entities.Shift
+	shiftLocation *time.Location
+}
+
+// NewLoanUseCase creates a new loan use case
+func NewLoanUseCase(loanRepo repositories.LoanRepository, holdRepo repositories.HoldRepository, fineRepo repositories.FineRepository, policyRepo repositories.PolicyRepository) *LoanUseCase {
+	return &LoanUseCase{
+		loanRepo:      loanRepo,
+		holdRepo:      holdRepo,
+		fineRepo:      fineRepo,
+		policyRepo:    policyRepo,
+		clock:         clock.System{},
+		shiftLocation: time.UTC,
+	}
+}
+
+// HoldsEnabled reports whether members queue for books out on loan, kept for them once returned
+func (uc *LoanUseCase) HoldsEnabled() bool {
+	return uc.holdRepo != nil
+}
+
+// SetBookRepository enables lending books
+func (uc *LoanUseCase) SetBookRepository(bookRepo repositories.BookRepository) {
+	uc.bookRepo = bookRepo
+}
+
+// SetAuditRepository enables policy overrides, which are recorded in the audit trail
+func (uc *LoanUseCase) SetAuditRepository(auditRepo repositories.AuditRepository) {
+	uc.auditRepo = auditRepo
+}
+
+// SetUserRepository keeps members with a deactivated account from borrowing and renewing
+func (uc *LoanUseCase) SetUserRepository(userRepo repositories.UserRepository) {
+	uc.userRepo = userRepo
+}
+
+// SetShifts sets the shifts of the staff, in the clock of location, that policy overrides are
+// recorded and reported by
+func (uc *LoanUseCase) SetShifts(shifts []entities.Shift, location *time.Location) {
+	uc.shifts = shifts
+	uc.shiftLocation = location
+}
+
+// SetCalendar makes due dates follow the business hours and closed days of the branches of books
+func (uc *LoanUseCase) SetCalendar(calendar *CalendarUseCase) {
+	uc.calendar = calendar
+}
+
+// SetTimezones makes due dates fall on the days of the timezone of the branch of the book, or of
+// the tenant, rather than on those of UTC
+func (uc *LoanUseCase) SetTimezones(timezones *TimezoneUseCase) {
+	uc.timezones = timezones
+}
+
+// SetClock replaces the clock renewals are dated with
+func (uc *LoanUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// SetEventBus enables publishing renewals, due dates coming up and holds kept for members, so
+// members are notified of them
+func (uc *LoanUseCase) SetEventBus(bus events.Bus) {
+	uc.eventBus = bus
+}
+
+// MemberLoans retrieves the loans a member has not returned yet, soonest due first
+func (uc *LoanUseCase) MemberLoans(memberID string) ([]entities.Loan, error) {
+	if memberID == "" {
+		return nil, errors.New("member ID is required")
+	}
+
+	loans, err := uc.loanRepo.ListActiveByUser(memberID)
+	if err != nil {
+		return nil, err
+	}
+	if loans == nil {
+		loans = []entities.Loan{}
+	}
+	return loans, nil
+}
+
+// loanArchiveBatch is how many loans the archive moves per statement, so no statement holds the
+// loans table for long
+const loanArchiveBatch = 1000
+
+// MemberLoanHistory retrieves the loans a member borrowed from one UTC date to another, both
+// included, returned or not, most recently borrowed first. Without from the history starts with
+// the first loan, and without to it ends today. Archived loans are included.
+func (uc *LoanUseCase) MemberLoanHistory(memberID, from, to string) ([]entities.Loan, error) {
+	if memberID == "" {
+		return nil, errors.New("member ID is required")
+	}
+
+	start, end, err := dateRange(from, to, uc.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	loans, err := uc.loanRepo.ListByUser(memberID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if loans == nil {
+		loans = []entities.Loan{}
+	}
+	return loans, nil
+}
+
+// ArchiveLoans moves the loans returned before a time to the loan history, a batch at a time, and
+// returns how many it moved
+func (uc *LoanUseCase) ArchiveLoans(returnedBefore time.Time) (int64, error) {
+	var archived int64
+	for {
+		moved, err := uc.loanRepo.Archive(returnedBefore, loanArchiveBatch)
+		archived += moved
+		if err != nil || moved < loanArchiveBatch {
+			return archived, err
+		}
+	}
+}
+
+// Checkout lends a book to a member of a tenant for the loan period of the tenant. A member
+// cannot borrow more books than the max_active_loans policy allows, nor a book other members
+// hold, unless staff override the policy.
+func (uc *LoanUseCase) Checkout(tenantID, memberID, bookID string, override Override) (*entities.Loan, error) {
+	if uc.bookRepo == nil {
+		return nil, errors.New("checkouts are not enabled")
+	}
+	if tenantID == "" || memberID == "" || bookID == "" {
+		return nil, errors.New("tenant, member and book IDs are required")
+	}
+
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, err
+	}
+	if book == nil || book.DeletedAt != nil {
+		return nil, domainerr.ErrBookNotFound
+	}
+	if book.Status == entities.BookStatusArchived {
+		return nil, domainerr.ErrBookArchived
+	}
+	if err := uc.checkMemberActive(memberID); err != nil {
+		return nil, err
+	}
+
+	var blocks []error
+	maxActive, err := uc.policyInt(tenantID, entities.PolicyMaxActiveLoans)
+	if err != nil {
+		return nil, err
+	}
+	active, err := uc.loanRepo.ListActiveByUser(memberID)
+	if err != nil {
+		return nil, err
+	}
+	if len(active) >= maxActive {
+		if err := uc.checkOverride(override, domainerr.ErrLoanLimitReached); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, domainerr.ErrLoanLimitReached)
+	}
+
+	pending, err := uc.holdRepo.CountPending(bookID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if pending > 0 {
+		if err := uc.checkOverride(override, domainerr.ErrBookOnHold); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, domainerr.ErrBookOnHold)
+	}
+
+	periodDays, err := uc.policyInt(tenantID, entities.PolicyLoanPeriodDays)
+	if err != nil {
+		return nil, err
+	}
+	location, err := uc.location(tenantID, book.BranchID)
+	if err != nil {
+		return nil, err
+	}
+	now := uc.clock.Now()
+	dueAt, err := uc.dueDate(book.BranchID, now.In(location).AddDate(0, 0, periodDays))
+	if err != nil {
+		return nil, err
+	}
+	loan := &entities.Loan{
+		TenantID:   tenantID,
+		UserID:     memberID,
+		BookID:     bookID,
+		BorrowedAt: now,
+		DueAt:      dueAt,
+	}
+	if err := uc.loanRepo.Create(loan); err != nil {
+		return nil, err
+	}
+	if err := uc.fulfilHold(memberID, bookID); err != nil {
+		return nil, err
+	}
+
+	uc.recordOverrides(loan, OverrideCheckout, blocks, override)
+	return loan, nil
+}
+
+// fulfilHold closes the pending hold of a member on a book they borrowed, so it no longer keeps
+// the book from other members
+func (uc *LoanUseCase) fulfilHold(memberID, bookID string) error {
+	holds, err := uc.holdRepo.ListPendingByUser(memberID)
+	if err != nil {
+		return err
+	}
+	for _, hold := range holds {
+		if hold.BookID != bookID {
+			continue
+		}
+		hold.Status = entities.HoldFulfilled
+		if err := uc.holdRepo.Update(&hold); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Return records that a lent book came back. The copy is kept for the next member in line for the
+// book, whose hold becomes ready, and they are told it is waiting for them.
+func (uc *LoanUseCase) Return(loanID string) (*entities.Loan, error) {
+	if loanID == "" {
+		return nil, errors.New("loan ID is required")
+	}
+
+	loan, err := uc.loanRepo.GetByID(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, domainerr.ErrLoanNotFound
+	}
+	if loan.ReturnedAt != nil {
+		return nil, domainerr.ErrLoanReturned
+	}
+
+	returnedAt := uc.clock.Now()
+	loan.ReturnedAt = &returnedAt
+	if err := uc.loanRepo.Update(loan); err != nil {
+		return nil, err
+	}
+
+	queue, err := uc.holdRepo.ListWaiting(loan.BookID)
+	if err != nil {
+		return nil, err
+	}
+	if len(queue) == 0 {
+		return loan, nil
+	}
+	hold := queue[0]
+	hold.Status = entities.HoldReady
+	if err := uc.holdRepo.Update(&hold); err != nil {
+		return nil, err
+	}
+	uc.publishHoldAvailable(hold)
+	return loan, nil
+}
+
+// publishHoldAvailable tells subscribers, such as notifications, that a copy is kept for a hold
+func (uc *LoanUseCase) publishHoldAvailable(hold entities.Hold) {
+	if uc.eventBus == nil {
+		return
+	}
+	uc.eventBus.Publish(events.Event{
+		Type:        events.HoldAvailable,
+		RecipientID: hold.UserID,
+		BookID:      hold.BookID,
+		Payload: map[string]string{
+			"hold_id": hold.ID,
+			"message": "A copy was returned and is kept for you at the desk.",
+		},
+	})
+}
+
+// NotifyDueSoon tells the members whose loans fall due on the day that is days from now that
+// they should return or renew them, and returns how many it told. Run once a day, it tells each
+// member once per due date.
+func (uc *LoanUseCase) NotifyDueSoon(days int) (int, error) {
+	if days < 0 {
+		return 0, errors.New("days must not be negative")
+	}
+
+	from := uc.clock.Now().AddDate(0, 0, days)
+	loans, err := uc.loanRepo.ListDueBetween(from, from.AddDate(0, 0, 1))
+	if err != nil {
+		return 0, err
+	}
+	if uc.eventBus == nil {
+		return 0, nil
+	}
+	for _, loan := range loans {
+		uc.eventBus.Publish(events.Event{
+			Type:        events.LoanDueSoon,
+			RecipientID: loan.UserID,
+			BookID:      loan.BookID,
+			Payload: map[string]string{
+				"loan_id": loan.ID,
+				"due_at":  loan.DueAt.Format(time.RFC3339),
+				"message": "It is due on " + loan.DueAt.Format("2006-01-02") + ". Return or renew it by then.",
+			},
+		})
+	}
+	return len(loans), nil
+}
+
+// Renew pushes the due date of a loan back by the loan period of the tenant, counted from the
+// due date or from now if the loan is overdue. A loan cannot be renewed more often than the
+// max_renewals policy allows, nor while other members hold the book, unless staff override the
+// policy.
+func (uc *LoanUseCase) Renew(loanID string, override Override) (*entities.Loan, error) {
+	if loanID == "" {
+		return nil, errors.New("loan ID is required")
+	}
+
+	loan, err := uc.loanRepo.GetByID(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil {
+		return nil, domainerr.ErrLoanNotFound
+	}
+	return uc.renew(loan, override)
+}
+
+// RenewMemberLoan renews one of a member's own loans, as Renew does
+func (uc *LoanUseCase) RenewMemberLoan(memberID, loanID string) (*entities.Loan, error) {
+	if memberID == "" {
+		return nil, errors.New("member ID is required")
+	}
+
+	loan, err := uc.loanRepo.GetByID(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan == nil || loan.UserID != memberID {
+		return nil, domainerr.ErrLoanNotFound
+	}
+	return uc.renew(loan, Override{})
+}
+
+// renew checks the circulation policies of the tenant, moves the due date and tells the member
+func (uc *LoanUseCase) renew(loan *entities.Loan, override Override) (*entities.Loan, error) {
+	if loan.ReturnedAt != nil {
+		return nil, domainerr.ErrLoanReturned
+	}
+	if err := uc.checkMemberActive(loan.UserID); err != nil {
+		return nil, err
+	}
+
+	var blocks []error
+	maxRenewals, err := uc.policyInt(loan.TenantID, entities.PolicyMaxRenewals)
+	if err != nil {
+		return nil, err
+	}
+	if loan.Renewals >= maxRenewals {
+		if err := uc.checkOverride(override, domainerr.ErrRenewalLimitReached); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, domainerr.ErrRenewalLimitReached)
+	}
+
+	pending, err := uc.holdRepo.CountPending(loan.BookID, loan.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if pending > 0 {
+		if err := uc.checkOverride(override, domainerr.ErrLoanOnHold); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, domainerr.ErrLoanOnHold)
+	}
+
+	periodDays, err := uc.policyInt(loan.TenantID, entities.PolicyLoanPeriodDays)
+	if err != nil {
+		return nil, err
+	}
+
+	from := loan.DueAt
+	if now := uc.clock.Now(); now.After(from) {
+		from = now
+	}
+	branchID, err := uc.bookBranch(loan.BookID)
+	if err != nil {
+		return nil, err
+	}
+	location, err := uc.location(loan.TenantID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	dueAt, err := uc.dueDate(branchID, from.In(location).AddDate(0, 0, periodDays))
+	if err != nil {
+		return nil, err
+	}
+	loan.DueAt = dueAt
+	loan.Renewals++
+	if err := uc.loanRepo.Update(loan); err != nil {
+		return nil, err
+	}
+	uc.recordOverrides(loan, OverrideRenewal, blocks, override)
+
+	uc.publishRenewal(loan)
+	return loan, nil
+}
+
+// publishRenewal tells subscribers, such as notifications, the new due date of a loan
+func (uc *LoanUseCase) publishRenewal(loan *entities.Loan) {
+	if uc.eventBus == nil {
+		return
+	}
+	uc.eventBus.Publish(events.Event{
+		Type:        events.LoanRenewed,
+		RecipientID: loan.UserID,
+		BookID:      loan.BookID,
+		Payload: map[string]string{
+			"loan_id":  loan.ID,
+			"due_at":   loan.DueAt.Format(time.RFC3339),
+			"renewals": strconv.Itoa(loan.Renewals),
+			"message":  "It is now due on " + loan.DueAt.Format("2006-01-02") + ".",
+		},
+	})
+}
+
+// MemberFines retrieves the fines charged to a member, newest first, with the unpaid total
+func (uc *LoanUseCase) MemberFines(memberID string) (*entities.MemberFines, error) {
+	if memberID == "" {
+		return nil, errors.New("member ID is required")
+	}
+
+	fines, err := uc.fineRepo.ListByUser(memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entities.MemberFines{Items: []entities.Fine{}}
+	for _, fine := range fines {
+		result.Items = append(result.Items, fine)
+		if fine.PaidAt == nil {
+			result.OutstandingCents += fine.AmountCents
+		}
+	}
+	return result, nil
+}
+
+// MemberHolds retrieves the pending holds of a member, oldest first, with their place in the
+// queue and when a copy should come back for them. Both are worked out from the current loans on
+// every call, so they follow renewals and returns.
+func (uc *LoanUseCase) MemberHolds(memberID string) ([]entities.HoldPosition, error) {
+	if memberID == "" {
+		return nil, errors.New("member ID is required")
+	}
+
+	holds, err := uc.holdRepo.ListPendingByUser(memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := []entities.HoldPosition{}
+	for _, hold := range holds {
+		position := entities.HoldPosition{Hold: hold}
+		if hold.Status == entities.HoldWaiting {
+			if err := uc.placeInQueue(&position); err != nil {
+				return nil, err
+			}
+		}
+		positions = append(positions, position)
+	}
+	return positions, nil
+}
+
+// placeInQueue finds where a waiting hold is in the queue for its book and estimates when it is
+// served. Copies come back on the due dates of their loans, or now for overdue ones, and each
+// copy goes to the next hold in line for a loan period before the hold after it can get it.
+func (uc *LoanUseCase) placeInQueue(position *entities.HoldPosition) error {
+	queue, err := uc.holdRepo.ListWaiting(position.BookID)
+	if err != nil {
+		return err
+	}
+	position.QueuePosition = len(queue) + 1
+	for i, hold := range queue {
+		if hold.ID == position.ID {
+			position.QueuePosition = i + 1
+			break
+		}
+	}
+
+	loans, err := uc.loanRepo.ListActiveByBook(position.BookID)
+	if err != nil {
+		return err
+	}
+	if len(loans) == 0 {
+		return nil
+	}
+	periodDays, err := uc.policyInt(position.TenantID, entities.PolicyLoanPeriodDays)
+	if err != nil {
+		return err
+	}
+
+	branchID, err := uc.bookBranch(position.BookID)
+	if err != nil {
+		return err
+	}
+	location, err := uc.location(position.TenantID, branchID)
+	if err != nil {
+		return err
+	}
+
+	now := uc.clock.Now()
+	returns := make([]time.Time, len(loans))
+	for i, loan := range loans {
+		returns[i] = loan.DueAt
+		if now.After(loan.DueAt) {
+			returns[i] = now
+		}
+	}
+
+	var availableAt time.Time
+	for served := 0; served < position.QueuePosition; served++ {
+		next := 0
+		for i := range returns {
+			if returns[i].Before(returns[next]) {
+				next = i
+			}
+		}
+		availableAt = returns[next]
+		if returns[next], err = uc.dueDate(branchID, availableAt.In(location).AddDate(0, 0, periodDays)); err != nil {
+			return err
+		}
+	}
+	position.EstimatedAvailableAt = &availableAt
+	return nil
+}
+
+// checkMemberActive refuses members whose account is deactivated; staff cannot override it.
+// Members without an account are left to the other checks.
+func (uc *LoanUseCase) checkMemberActive(memberID string) error {
+	if uc.userRepo == nil {
+		return nil
+	}
+	user, err := uc.userRepo.GetByID(memberID)
+	if err != nil {
+		return err
+	}
+	if user != nil && !user.Active() {
+		return domainerr.ErrMemberDeactivated
+	}
+	return nil
+}
+
+// dueDate moves a due date off the days the branch of the book is closed, when there is a calendar
+func (uc *LoanUseCase) dueDate(branchID *string, due time.Time) (time.Time, error) {
+	if uc.calendar == nil {
+		return due, nil
+	}
+	return uc.calendar.DueDate(branchID, due)
+}
+
+// location returns the timezone a tenant counts the due dates of the books of a branch in, nil
+// for books of the whole library; UTC without timezones
+func (uc *LoanUseCase) location(tenantID string, branchID *string) (*time.Location, error) {
+	if uc.timezones == nil {
+		return time.UTC, nil
+	}
+	return uc.timezones.Location(tenantID, branchID)
+}
+
+// bookBranch looks up the branch of a lent book for its due dates, nil for books of the whole
+// library and when due dates follow neither a calendar nor the timezones of branches
+func (uc *LoanUseCase) bookBranch(bookID string) (*string, error) {
+	if (uc.calendar == nil && uc.timezones == nil) || uc.bookRepo == nil {
+		return nil, nil
+	}
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil || book == nil {
+		return nil, err
+	}
+	return book.BranchID, nil
+}
+
+// policyInt reads a numeric policy of a tenant, falling back to the value tenants are
+// bootstrapped with when the tenant has not set it
+func (uc *LoanUseCase) policyInt(tenantID, key string) (int, error) {
+	value := defaultPolicyValue(key)
+	policy, err := uc.policyRepo.Get(tenantID, key)
+	if err != nil {
+		return 0, err
+	}
+	if policy != nil {
+		value = policy.Value
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("policy %s of tenant %s is not a number: %q", key, tenantID, value)
+	}
+	return n, nil
+}
+
+// defaultPolicyValue returns the value a bootstrapped tenant starts with for a policy
+func defaultPolicyValue(key string) string {
+	for _, policy := range defaultPolicies {
+		if policy.Key == key {
+			return policy.Value
+		}
+	}
+	return ""
+}
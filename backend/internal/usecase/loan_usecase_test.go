@@ -0,0 +1,509 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/events"
+	"library-management-system/internal/infrastructure/eventbus"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockLoanRepository is a mock implementation of LoanRepository
+type MockLoanRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoanRepository) Create(loan *entities.Loan) error {
+	args := m.Called(loan)
+	return args.Error(0)
+}
+
+func (m *MockLoanRepository) GetByID(id string) (*entities.Loan, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) ListActiveByUser(userID string) ([]entities.Loan, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]entities.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) ListActiveByBook(bookID string) ([]entities.Loan, error) {
+	args := m.Called(bookID)
+	return args.Get(0).([]entities.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) ListByBook(bookID string) ([]entities.Loan, error) {
+	args := m.Called(bookID)
+	return args.Get(0).([]entities.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) ListDueBetween(from, to time.Time) ([]entities.Loan, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]entities.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) ListByUser(userID string, from, to time.Time) ([]entities.Loan, error) {
+	args := m.Called(userID, from, to)
+	return args.Get(0).([]entities.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) Archive(returnedBefore time.Time, limit int) (int64, error) {
+	args := m.Called(returnedBefore, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockLoanRepository) Update(loan *entities.Loan) error {
+	args := m.Called(loan)
+	return args.Error(0)
+}
+
+// MockHoldRepository is a mock implementation of HoldRepository
+type MockHoldRepository struct {
+	mock.Mock
+}
+
+func (m *MockHoldRepository) CountPending(bookID, exceptUserID string) (int64, error) {
+	args := m.Called(bookID, exceptUserID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockHoldRepository) ListPendingByUser(userID string) ([]entities.Hold, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]entities.Hold), args.Error(1)
+}
+
+func (m *MockHoldRepository) ListWaiting(bookID string) ([]entities.Hold, error) {
+	args := m.Called(bookID)
+	return args.Get(0).([]entities.Hold), args.Error(1)
+}
+
+func (m *MockHoldRepository) Update(hold *entities.Hold) error {
+	args := m.Called(hold)
+	return args.Error(0)
+}
+
+// MockFineRepository is a mock implementation of FineRepository
+type MockFineRepository struct {
+	mock.Mock
+}
+
+func (m *MockFineRepository) ListByUser(userID string) ([]entities.Fine, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]entities.Fine), args.Error(1)
+}
+
+// stubPolicyRepository returns the policies it holds by key, for any tenant
+type stubPolicyRepository map[string]string
+
+func (r stubPolicyRepository) Get(tenantID, key string) (*entities.Policy, error) {
+	value, ok := r[key]
+	if !ok {
+		return nil, nil
+	}
+	return &entities.Policy{TenantID: tenantID, Key: key, Value: value}, nil
+}
+
+func TestLoanUseCase_RenewMemberLoan(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	returnedAt := now.Add(-time.Hour)
+
+	tests := []struct {
+		name          string
+		loan          *entities.Loan
+		policies      stubPolicyRepository
+		pendingHolds  int64
+		expectedDue   time.Time
+		expectedError error
+	}{
+		{
+			name:        "extends from the due date",
+			loan:        &entities.Loan{DueAt: now.AddDate(0, 0, 3)},
+			expectedDue: now.AddDate(0, 0, 17),
+		},
+		{
+			name:        "extends an overdue loan from now",
+			loan:        &entities.Loan{DueAt: now.AddDate(0, 0, -2)},
+			expectedDue: now.AddDate(0, 0, 14),
+		},
+		{
+			name:        "uses the loan period of the tenant",
+			loan:        &entities.Loan{DueAt: now},
+			policies:    stubPolicyRepository{entities.PolicyLoanPeriodDays: "7"},
+			expectedDue: now.AddDate(0, 0, 7),
+		},
+		{
+			name:          "stops at the renewal limit",
+			loan:          &entities.Loan{DueAt: now, Renewals: 2},
+			expectedError: domainerr.ErrRenewalLimitReached,
+		},
+		{
+			name:          "stops at the renewal limit of the tenant",
+			loan:          &entities.Loan{DueAt: now, Renewals: 1},
+			policies:      stubPolicyRepository{entities.PolicyMaxRenewals: "1"},
+			expectedError: domainerr.ErrRenewalLimitReached,
+		},
+		{
+			name:          "is blocked by holds of other members",
+			loan:          &entities.Loan{DueAt: now},
+			pendingHolds:  1,
+			expectedError: domainerr.ErrLoanOnHold,
+		},
+		{
+			name:          "rejects returned loans",
+			loan:          &entities.Loan{DueAt: now, ReturnedAt: &returnedAt},
+			expectedError: domainerr.ErrLoanReturned,
+		},
+		{
+			name:          "hides loans of other members",
+			loan:          &entities.Loan{DueAt: now, UserID: "member-2"},
+			expectedError: domainerr.ErrLoanNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.loan.ID = "loan-1"
+			tt.loan.TenantID = "tenant-1"
+			tt.loan.BookID = "book-1"
+			if tt.loan.UserID == "" {
+				tt.loan.UserID = "member-1"
+			}
+			renewals := tt.loan.Renewals
+
+			loanRepo := &MockLoanRepository{}
+			loanRepo.On("GetByID", "loan-1").Return(tt.loan, nil)
+			loanRepo.On("Update", tt.loan).Return(nil)
+			holdRepo := &MockHoldRepository{}
+			holdRepo.On("CountPending", "book-1", "member-1").Return(tt.pendingHolds, nil)
+			policies := tt.policies
+			if policies == nil {
+				policies = stubPolicyRepository{}
+			}
+			useCase := NewLoanUseCase(loanRepo, holdRepo, &MockFineRepository{}, policies)
+			useCase.SetClock(clock.NewFixed(now))
+
+			loan, err := useCase.RenewMemberLoan("member-1", "loan-1")
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				loanRepo.AssertNotCalled(t, "Update", mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedDue, loan.DueAt)
+			assert.Equal(t, renewals+1, loan.Renewals)
+			loanRepo.AssertCalled(t, "Update", tt.loan)
+		})
+	}
+}
+
+func TestLoanUseCase_Renew(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	loan := &entities.Loan{ID: "loan-1", TenantID: "tenant-1", UserID: "member-1", BookID: "book-1", DueAt: now.AddDate(0, 0, 1)}
+	loanRepo := &MockLoanRepository{}
+	loanRepo.On("GetByID", "loan-1").Return(loan, nil)
+	loanRepo.On("GetByID", "missing").Return(nil, nil)
+	loanRepo.On("Update", loan).Return(nil)
+	holdRepo := &MockHoldRepository{}
+	holdRepo.On("CountPending", "book-1", "member-1").Return(int64(0), nil)
+	useCase := NewLoanUseCase(loanRepo, holdRepo, &MockFineRepository{}, stubPolicyRepository{})
+	useCase.SetClock(clock.NewFixed(now))
+	bus := eventbus.NewInMemoryBus()
+	useCase.SetEventBus(bus)
+
+	var published []events.Event
+	bus.Subscribe(events.LoanRenewed, func(event events.Event) error {
+		published = append(published, event)
+		return nil
+	})
+
+	_, err := useCase.Renew("missing", Override{})
+	assert.ErrorIs(t, err, domainerr.ErrLoanNotFound)
+	assert.Empty(t, published)
+
+	renewed, err := useCase.Renew("loan-1", Override{})
+	require.NoError(t, err)
+	assert.Equal(t, now.AddDate(0, 0, 15), renewed.DueAt)
+	require.Len(t, published, 1)
+	assert.Equal(t, "member-1", published[0].RecipientID)
+	assert.Equal(t, "book-1", published[0].BookID)
+	assert.Equal(t, "2026-10-31T09:00:00Z", published[0].Payload["due_at"])
+	assert.Equal(t, "1", published[0].Payload["renewals"])
+}
+
+func TestLoanUseCase_Return(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	loan := &entities.Loan{ID: "loan-1", UserID: "member-1", BookID: "book-1", DueAt: now.AddDate(0, 0, 1)}
+	loanRepo := &MockLoanRepository{}
+	loanRepo.On("GetByID", "loan-1").Return(loan, nil)
+	loanRepo.On("Update", loan).Return(nil)
+	holdRepo := &MockHoldRepository{}
+	holdRepo.On("ListWaiting", "book-1").Return([]entities.Hold{
+		{ID: "hold-1", UserID: "member-2", BookID: "book-1", Status: entities.HoldWaiting},
+		{ID: "hold-2", UserID: "member-3", BookID: "book-1", Status: entities.HoldWaiting},
+	}, nil)
+	holdRepo.On("Update", mock.AnythingOfType("*entities.Hold")).Return(nil)
+	useCase := NewLoanUseCase(loanRepo, holdRepo, &MockFineRepository{}, stubPolicyRepository{})
+	useCase.SetClock(clock.NewFixed(now))
+	bus := eventbus.NewInMemoryBus()
+	useCase.SetEventBus(bus)
+
+	var published []events.Event
+	bus.Subscribe(events.HoldAvailable, func(event events.Event) error {
+		published = append(published, event)
+		return nil
+	})
+
+	returned, err := useCase.Return("loan-1")
+	require.NoError(t, err)
+	assert.Equal(t, now, *returned.ReturnedAt)
+
+	// Only the first member in line gets the copy
+	holdRepo.AssertNumberOfCalls(t, "Update", 1)
+	ready := holdRepo.Calls[1].Arguments.Get(0).(*entities.Hold)
+	assert.Equal(t, "hold-1", ready.ID)
+	assert.Equal(t, entities.HoldReady, ready.Status)
+	require.Len(t, published, 1)
+	assert.Equal(t, "member-2", published[0].RecipientID)
+	assert.Equal(t, "book-1", published[0].BookID)
+	assert.Equal(t, "hold-1", published[0].Payload["hold_id"])
+
+	_, err = useCase.Return("loan-1")
+	assert.ErrorIs(t, err, domainerr.ErrLoanReturned)
+	assert.Len(t, published, 1)
+}
+
+func TestLoanUseCase_CheckoutFulfilsHold(t *testing.T) {
+	bookRepo := &MockBookRepository{}
+	bookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1", Status: entities.BookStatusActive}, nil)
+	loanRepo := &MockLoanRepository{}
+	loanRepo.On("ListActiveByUser", "member-2").Return([]entities.Loan{}, nil)
+	loanRepo.On("Create", mock.AnythingOfType("*entities.Loan")).Return(nil)
+	holdRepo := &MockHoldRepository{}
+	holdRepo.On("CountPending", "book-1", "member-2").Return(int64(0), nil)
+	holdRepo.On("ListPendingByUser", "member-2").Return([]entities.Hold{
+		{ID: "hold-1", UserID: "member-2", BookID: "book-1", Status: entities.HoldReady},
+		{ID: "hold-3", UserID: "member-2", BookID: "book-2", Status: entities.HoldWaiting},
+	}, nil)
+	holdRepo.On("Update", mock.AnythingOfType("*entities.Hold")).Return(nil)
+	useCase := NewLoanUseCase(loanRepo, holdRepo, &MockFineRepository{}, stubPolicyRepository{})
+	useCase.SetBookRepository(bookRepo)
+
+	_, err := useCase.Checkout("tenant-1", "member-2", "book-1", Override{})
+	require.NoError(t, err)
+	holdRepo.AssertNumberOfCalls(t, "Update", 1)
+	holdRepo.AssertCalled(t, "Update", mock.MatchedBy(func(hold *entities.Hold) bool {
+		return hold.ID == "hold-1" && hold.Status == entities.HoldFulfilled
+	}))
+}
+
+func TestLoanUseCase_NotifyDueSoon(t *testing.T) {
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	loanRepo := &MockLoanRepository{}
+	loanRepo.On("ListDueBetween", now.AddDate(0, 0, 2), now.AddDate(0, 0, 3)).Return([]entities.Loan{
+		{ID: "loan-1", UserID: "member-1", BookID: "book-1", DueAt: time.Date(2026, 10, 18, 17, 0, 0, 0, time.UTC)},
+	}, nil)
+	useCase := NewLoanUseCase(loanRepo, &MockHoldRepository{}, &MockFineRepository{}, stubPolicyRepository{})
+	useCase.SetClock(clock.NewFixed(now))
+	bus := eventbus.NewInMemoryBus()
+	useCase.SetEventBus(bus)
+
+	var published []events.Event
+	bus.Subscribe(events.LoanDueSoon, func(event events.Event) error {
+		published = append(published, event)
+		return nil
+	})
+
+	reminded, err := useCase.NotifyDueSoon(2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reminded)
+	require.Len(t, published, 1)
+	assert.Equal(t, "member-1", published[0].RecipientID)
+	assert.Equal(t, "loan-1", published[0].Payload["loan_id"])
+	assert.Equal(t, "2026-10-18T17:00:00Z", published[0].Payload["due_at"])
+
+	_, err = useCase.NotifyDueSoon(-1)
+	assert.Error(t, err)
+}
+
+func TestLoanUseCase_MemberFines(t *testing.T) {
+	paidAt := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	fineRepo := &MockFineRepository{}
+	fineRepo.On("ListByUser", "member-1").Return([]entities.Fine{
+		{ID: "fine-3", AmountCents: 150, Reason: "Late return"},
+		{ID: "fine-2", AmountCents: 500, Reason: "Damaged cover", PaidAt: &paidAt},
+		{ID: "fine-1", AmountCents: 75, Reason: "Late return"},
+	}, nil)
+	fineRepo.On("ListByUser", "member-2").Return([]entities.Fine(nil), nil)
+	useCase := NewLoanUseCase(&MockLoanRepository{}, &MockHoldRepository{}, fineRepo, stubPolicyRepository{})
+
+	fines, err := useCase.MemberFines("member-1")
+	require.NoError(t, err)
+	assert.Len(t, fines.Items, 3)
+	assert.Equal(t, int64(225), fines.OutstandingCents)
+
+	fines, err = useCase.MemberFines("member-2")
+	require.NoError(t, err)
+	assert.NotNil(t, fines.Items)
+	assert.Zero(t, fines.OutstandingCents)
+}
+
+func TestLoanUseCase_MemberHolds(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	holdRepo := &MockHoldRepository{}
+	holdRepo.On("ListPendingByUser", "member-1").Return([]entities.Hold{
+		{ID: "hold-1", TenantID: "tenant-1", UserID: "member-1", BookID: "book-1", Status: entities.HoldWaiting},
+		{ID: "hold-2", TenantID: "tenant-1", UserID: "member-1", BookID: "book-2", Status: entities.HoldReady},
+		{ID: "hold-3", TenantID: "tenant-1", UserID: "member-1", BookID: "book-3", Status: entities.HoldWaiting},
+	}, nil)
+	holdRepo.On("ListWaiting", "book-1").Return([]entities.Hold{{ID: "hold-a"}, {ID: "hold-b"}, {ID: "hold-1"}}, nil)
+	holdRepo.On("ListWaiting", "book-3").Return([]entities.Hold{{ID: "hold-3"}}, nil)
+	loanRepo := &MockLoanRepository{}
+	// One copy is overdue and comes back now, the other in two days
+	loanRepo.On("ListActiveByBook", "book-1").Return([]entities.Loan{
+		{BookID: "book-1", DueAt: now.AddDate(0, 0, -1)},
+		{BookID: "book-1", DueAt: now.AddDate(0, 0, 2)},
+	}, nil)
+	loanRepo.On("ListActiveByBook", "book-3").Return([]entities.Loan{}, nil)
+	useCase := NewLoanUseCase(loanRepo, holdRepo, &MockFineRepository{}, stubPolicyRepository{})
+	useCase.SetClock(clock.NewFixed(now))
+
+	holds, err := useCase.MemberHolds("member-1")
+	require.NoError(t, err)
+	require.Len(t, holds, 3)
+
+	// The two holds ahead get the copies coming back now and in two days, and the first of them
+	// is returned again after the 14 day loan period
+	assert.Equal(t, 3, holds[0].QueuePosition)
+	require.NotNil(t, holds[0].EstimatedAvailableAt)
+	assert.Equal(t, now.AddDate(0, 0, 14), *holds[0].EstimatedAvailableAt)
+
+	assert.Equal(t, 0, holds[1].QueuePosition)
+	assert.Nil(t, holds[1].EstimatedAvailableAt)
+
+	assert.Equal(t, 1, holds[2].QueuePosition)
+	assert.Nil(t, holds[2].EstimatedAvailableAt)
+}
+
+func TestLoanUseCase_RenewOnClosedDay(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	loan := &entities.Loan{ID: "loan-1", TenantID: "tenant-1", UserID: "member-1", BookID: "book-1", DueAt: now.AddDate(0, 0, 1)}
+	loanRepo := &MockLoanRepository{}
+	loanRepo.On("GetByID", "loan-1").Return(loan, nil)
+	loanRepo.On("Update", loan).Return(nil)
+	holdRepo := &MockHoldRepository{}
+	holdRepo.On("CountPending", "book-1", "member-1").Return(int64(0), nil)
+	calendar := NewCalendarUseCase(&memoryCalendarRepository{days: []entities.ClosedDay{{Date: "2026-10-31"}}}, &MockBranchRepository{})
+	useCase := NewLoanUseCase(loanRepo, holdRepo, &MockFineRepository{}, stubPolicyRepository{})
+	useCase.SetClock(clock.NewFixed(now))
+	useCase.SetCalendar(calendar)
+
+	// Due on the 31st, when the library is closed
+	renewed, err := useCase.Renew("loan-1", Override{})
+	require.NoError(t, err)
+	assert.Equal(t, now.AddDate(0, 0, 16), renewed.DueAt)
+}
+
+func TestLoanUseCase_CheckoutInBranchTimezone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	harbor := "harbor"
+	bookRepo := &MockBookRepository{}
+	bookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1", Status: entities.BookStatusActive, BranchID: &harbor}, nil)
+	loanRepo := &MockLoanRepository{}
+	loanRepo.On("ListActiveByUser", "member-1").Return([]entities.Loan{}, nil)
+	loanRepo.On("Create", mock.AnythingOfType("*entities.Loan")).Return(nil)
+	holdRepo := &MockHoldRepository{}
+	holdRepo.On("CountPending", "book-1", "member-1").Return(int64(0), nil)
+	holdRepo.On("ListPendingByUser", "member-1").Return([]entities.Hold{}, nil)
+	tenantRepo := &MockTenantRepository{}
+	tenantRepo.On("GetByID", "tenant-1").Return(&entities.Tenant{ID: "tenant-1"}, nil)
+	branchRepo := &MockBranchRepository{}
+	branchRepo.On("GetByID", "harbor").Return(&entities.Branch{ID: "harbor", Timezone: "Europe/Berlin"}, nil)
+
+	// 23:30 UTC on Friday 16 October 2026 is already Saturday in Berlin
+	now := time.Date(2026, 10, 16, 23, 30, 0, 0, time.UTC)
+	useCase := NewLoanUseCase(loanRepo, holdRepo, &MockFineRepository{}, stubPolicyRepository{})
+	useCase.SetClock(clock.NewFixed(now))
+	useCase.SetBookRepository(bookRepo)
+	useCase.SetCalendar(NewCalendarUseCase(&memoryCalendarRepository{days: []entities.ClosedDay{{Date: "2026-10-31"}}}, branchRepo))
+	useCase.SetTimezones(NewTimezoneUseCase(tenantRepo, branchRepo, time.UTC))
+
+	// Fourteen days on is the 31st in Berlin, when the library is closed, and clocks went back an
+	// hour on the way, so the loan is due at the same time of day on the 1st of November there
+	loan, err := useCase.Checkout("tenant-1", "member-1", "book-1", Override{})
+	require.NoError(t, err)
+	assert.True(t, time.Date(2026, 11, 1, 1, 30, 0, 0, berlin).Equal(loan.DueAt), "due at %s", loan.DueAt)
+	assert.Equal(t, berlin, loan.DueAt.Location())
+}
+
+func TestLoanUseCase_DeactivatedMember(t *testing.T) {
+	deactivatedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	bookRepo := &MockBookRepository{}
+	bookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1", Status: entities.BookStatusActive}, nil)
+	loanRepo := &MockLoanRepository{}
+	loanRepo.On("GetByID", "loan-1").Return(&entities.Loan{ID: "loan-1", UserID: "member-1", BookID: "book-1"}, nil)
+	userRepo := &MockUserRepository{}
+	userRepo.On("GetByID", "member-1").Return(&entities.User{ID: "member-1", DeactivatedAt: &deactivatedAt}, nil)
+	useCase := NewLoanUseCase(loanRepo, &MockHoldRepository{}, &MockFineRepository{}, stubPolicyRepository{})
+	useCase.SetBookRepository(bookRepo)
+	useCase.SetUserRepository(userRepo)
+
+	// Staff cannot override a deactivation
+	_, err := useCase.Checkout("tenant-1", "member-1", "book-1", Override{Staff: entities.Actor{UserID: "librarian-1", Role: entities.UserRoleLibrarian}, Reason: "Course reserve"})
+	assert.ErrorIs(t, err, domainerr.ErrMemberDeactivated)
+
+	_, err = useCase.RenewMemberLoan("member-1", "loan-1")
+	assert.ErrorIs(t, err, domainerr.ErrMemberDeactivated)
+	loanRepo.AssertNotCalled(t, "Create", mock.Anything)
+	loanRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestLoanUseCase_MemberLoanHistory(t *testing.T) {
+	loanRepo := &MockLoanRepository{}
+	loanRepo.On("ListByUser", "member-1", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)).
+		Return([]entities.Loan{{ID: "loan-2"}, {ID: "loan-1"}}, nil)
+	loanRepo.On("ListByUser", "member-1", time.Time{}, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)).
+		Return([]entities.Loan(nil), nil)
+	useCase := NewLoanUseCase(loanRepo, &MockHoldRepository{}, &MockFineRepository{}, stubPolicyRepository{})
+	useCase.SetClock(clock.NewFixed(time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)))
+
+	// Both days are included
+	loans, err := useCase.MemberLoanHistory("member-1", "2024-01-01", "2024-12-31")
+	require.NoError(t, err)
+	assert.Len(t, loans, 2)
+
+	// Without a range, the history runs from the first loan to today
+	loans, err = useCase.MemberLoanHistory("member-1", "", "")
+	require.NoError(t, err)
+	assert.NotNil(t, loans)
+
+	_, err = useCase.MemberLoanHistory("member-1", "2024-12-31", "2024-01-01")
+	assert.ErrorIs(t, err, ErrInvalidDateRange)
+	_, err = useCase.MemberLoanHistory("member-1", "last year", "")
+	assert.ErrorIs(t, err, ErrInvalidDateRange)
+}
+
+func TestLoanUseCase_ArchiveLoans(t *testing.T) {
+	before := time.Date(2025, 10, 16, 0, 0, 0, 0, time.UTC)
+	loanRepo := &MockLoanRepository{}
+	loanRepo.On("Archive", before, loanArchiveBatch).Return(int64(loanArchiveBatch), nil).Twice()
+	loanRepo.On("Archive", before, loanArchiveBatch).Return(int64(20), nil).Once()
+	useCase := NewLoanUseCase(loanRepo, &MockHoldRepository{}, &MockFineRepository{}, stubPolicyRepository{})
+
+	// Batches are moved until one comes up short
+	archived, err := useCase.ArchiveLoans(before)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2*loanArchiveBatch+20), archived)
+	loanRepo.AssertNumberOfCalls(t, "Archive", 3)
+}
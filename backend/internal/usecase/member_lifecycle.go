@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"log"
+	"time"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+)
+
+// Deactivate deactivates the account of a member, who can then neither sign in nor borrow; their
+// loans and fines are kept. Unless reactivated, the account is anonymized once it has been
+// deactivated for the retention window. Deactivating it again changes nothing.
+func (uc *MemberUseCase) Deactivate(actor entities.Actor, memberID string) (*entities.User, error) {
+	user, err := uc.GetProfile(memberID)
+	if err != nil {
+		return nil, err
+	}
+	if user.AnonymizedAt != nil {
+		return nil, domainerr.ErrMemberAnonymized
+	}
+	if !user.Active() {
+		return user, nil
+	}
+
+	now := uc.clock.Now()
+	user.DeactivatedAt = &now
+	if err := uc.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+	uc.recordAudit(actor, user.ID, entities.AuditActionDeactivated, now)
+	return user, nil
+}
+
+// Reactivate reactivates a deactivated account, as long as it has not been anonymized
+func (uc *MemberUseCase) Reactivate(actor entities.Actor, memberID string) (*entities.User, error) {
+	user, err := uc.GetProfile(memberID)
+	if err != nil {
+		return nil, err
+	}
+	if user.AnonymizedAt != nil {
+		return nil, domainerr.ErrMemberAnonymized
+	}
+	if user.Active() {
+		return user, nil
+	}
+
+	user.DeactivatedAt = nil
+	if err := uc.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+	uc.recordAudit(actor, user.ID, entities.AuditActionReactivated, uc.clock.Now())
+	return user, nil
+}
+
+// Erase anonymizes the account of a member right away, on their request to be forgotten, rather
+// than after the retention window. Members with books on loan are refused until they return them.
+// Erasing an anonymized account again changes nothing.
+func (uc *MemberUseCase) Erase(actor entities.Actor, memberID string) (*entities.User, error) {
+	user, err := uc.GetProfile(memberID)
+	if err != nil {
+		return nil, err
+	}
+	if user.AnonymizedAt != nil {
+		return user, nil
+	}
+	onLoan, err := uc.hasLoans(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if onLoan {
+		return nil, domainerr.ErrMemberHasLoans
+	}
+
+	if err := uc.anonymize(actor, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// AnonymizeDeactivated anonymizes the accounts deactivated before a time, the end of their
+// retention window, and returns how many it anonymized. Accounts of members with books on loan
+// are left for a later run.
+func (uc *MemberUseCase) AnonymizeDeactivated(before time.Time) (int, error) {
+	users, err := uc.userRepo.ListDeactivatedBefore(before)
+	if err != nil {
+		return 0, err
+	}
+
+	anonymized := 0
+	for i := range users {
+		onLoan, err := uc.hasLoans(users[i].ID)
+		if err != nil {
+			return anonymized, err
+		}
+		if onLoan {
+			continue
+		}
+		if err := uc.anonymize(entities.Actor{}, &users[i]); err != nil {
+			return anonymized, err
+		}
+		anonymized++
+	}
+	return anonymized, nil
+}
+
+// anonymize erases the personal details of an account for good
+func (uc *MemberUseCase) anonymize(actor entities.Actor, user *entities.User) error {
+	now := uc.clock.Now()
+	user.Anonymize(now)
+	if err := uc.userRepo.Update(user); err != nil {
+		return err
+	}
+	uc.recordAudit(actor, user.ID, entities.AuditActionAnonymized, now)
+	return nil
+}
+
+// hasLoans reports whether a member has books on loan
+func (uc *MemberUseCase) hasLoans(memberID string) (bool, error) {
+	if uc.loanRepo == nil {
+		return false, nil
+	}
+	loans, err := uc.loanRepo.ListActiveByUser(memberID)
+	if err != nil {
+		return false, err
+	}
+	return len(loans) > 0, nil
+}
+
+// recordAudit records a change of the state of an account, without the personal details it had
+func (uc *MemberUseCase) recordAudit(actor entities.Actor, userID, action string, at time.Time) {
+	if uc.auditRepo == nil {
+		return
+	}
+	entry := &entities.AuditEntry{
+		EntityType:     entities.AuditEntityUser,
+		EntityID:       userID,
+		Action:         action,
+		Actor:          actor.UserID,
+		CreatedAt:      at,
+		ImpersonatorID: actor.ImpersonatorID,
+	}
+	if err := uc.auditRepo.Create(entry); err != nil {
+		log.Printf("Failed to record %s of account %s: %v", action, userID, err)
+	}
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// phonePattern accepts international and local phone numbers with common separators
+var phonePattern = regexp.MustCompile(`^\+?[0-9][0-9 ()-]{4,19}$`)
+
+// ContactUpdate holds the contact details a member changes; nil fields are left as they are
+type ContactUpdate struct {
+	Name  *string
+	Email *string
+	// Phone is cleared when empty
+	Phone *string
+}
+
+// MemberUseCase lets members see and maintain their own account, and deactivates, reactivates
+// and anonymizes accounts
+type MemberUseCase struct {
+	userRepo  repositories.UserRepository
+	loanRepo  repositories.LoanRepository
+	auditRepo repositories.AuditRepository
+	clock     clock.Clock
+}
+
+// NewMemberUseCase creates a new member use case
+func NewMemberUseCase(userRepo repositories.UserRepository) *MemberUseCase {
+	return &MemberUseCase{
+		userRepo: userRepo,
+		clock:    clock.System{},
+	}
+}
+
+// SetLoanRepository keeps the accounts of members with books on loan from being anonymized
+func (uc *MemberUseCase) SetLoanRepository(loanRepo repositories.LoanRepository) {
+	uc.loanRepo = loanRepo
+}
+
+// SetAuditRepository records the deactivations, reactivations and anonymizations of accounts
+func (uc *MemberUseCase) SetAuditRepository(auditRepo repositories.AuditRepository) {
+	uc.auditRepo = auditRepo
+}
+
+// SetClock replaces the clock accounts are deactivated and anonymized by
+func (uc *MemberUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// GetProfile retrieves the account of a member
+func (uc *MemberUseCase) GetProfile(memberID string) (*entities.User, error) {
+	if memberID == "" {
+		return nil, errors.New("member ID is required")
+	}
+
+	user, err := uc.userRepo.GetByID(memberID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domainerr.ErrMemberNotFound
+	}
+	return user, nil
+}
+
+// UpdateContact changes the name, email or phone of a member
+func (uc *MemberUseCase) UpdateContact(memberID string, update ContactUpdate) (*entities.User, error) {
+	user, err := uc.GetProfile(memberID)
+	if err != nil {
+		return nil, err
+	}
+	if user.AnonymizedAt != nil {
+		return nil, domainerr.ErrMemberAnonymized
+	}
+
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if name == "" {
+			return nil, errors.New("name cannot be empty")
+		}
+		user.Name = name
+	}
+
+	if update.Email != nil {
+		email := entities.NormalizeEmail(*update.Email)
+		if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+			return nil, errors.New("email is invalid")
+		}
+		if email != user.Email {
+			existing, err := uc.userRepo.FindByEmail(email)
+			if err != nil {
+				return nil, err
+			}
+			// Emails stored before they were normalized can differ from their own only by case
+			if existing != nil && existing.ID != user.ID {
+				return nil, domainerr.ErrDuplicateEmail
+			}
+			user.Email = email
+		}
+	}
+
+	if update.Phone != nil {
+		phone := strings.TrimSpace(*update.Phone)
+		if phone != "" && !phonePattern.MatchString(phone) {
+			return nil, errors.New("phone is not a valid phone number")
+		}
+		user.Phone = phone
+	}
+
+	if err := uc.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
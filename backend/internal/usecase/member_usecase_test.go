@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemberUseCase_UpdateContact(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name          string
+		update        ContactUpdate
+		expected      entities.User
+		expectedError string
+	}{
+		{
+			name:     "updates the given fields",
+			update:   ContactUpdate{Email: strPtr(" Ada@Example.com "), Phone: strPtr("+44 20 7946 0958")},
+			expected: entities.User{Name: "Ada", Email: "ada@example.com", Phone: "+44 20 7946 0958"},
+		},
+		{
+			name:     "clears the phone",
+			update:   ContactUpdate{Name: strPtr("Ada Lovelace"), Phone: strPtr("")},
+			expected: entities.User{Name: "Ada Lovelace", Email: "member@example.com"},
+		},
+		{
+			name:          "rejects an email taken by another user",
+			update:        ContactUpdate{Email: strPtr("admin@example.com")},
+			expectedError: domainerr.ErrDuplicateEmail.Error(),
+		},
+		{
+			name:          "rejects an invalid email",
+			update:        ContactUpdate{Email: strPtr("Ada <ada@example.com>")},
+			expectedError: "email is invalid",
+		},
+		{
+			name:          "rejects an invalid phone",
+			update:        ContactUpdate{Phone: strPtr("call me")},
+			expectedError: "phone is not a valid phone number",
+		},
+		{
+			name:          "rejects an empty name",
+			update:        ContactUpdate{Name: strPtr("  ")},
+			expectedError: "name cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			member := &entities.User{ID: "member-1", Name: "Ada", Email: "member@example.com", Phone: "555-0100", Role: entities.UserRoleMember}
+			repo := &MockUserRepository{}
+			repo.On("GetByID", "member-1").Return(member, nil)
+			repo.On("FindByEmail", "admin@example.com").Return(&entities.User{ID: "admin-1"}, nil)
+			repo.On("FindByEmail", "ada@example.com").Return(nil, nil)
+			repo.On("Update", member).Return(nil)
+			useCase := NewMemberUseCase(repo)
+
+			user, err := useCase.UpdateContact("member-1", tt.update)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				repo.AssertNotCalled(t, "Update", mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected.Name, user.Name)
+			assert.Equal(t, tt.expected.Email, user.Email)
+			assert.Equal(t, tt.expected.Phone, user.Phone)
+		})
+	}
+}
+
+func TestMemberUseCase_UpdateContact_NormalizesOwnEmail(t *testing.T) {
+	// Stored before emails were normalized, so the lookup finds the member themselves
+	member := &entities.User{ID: "member-1", Name: "Ada", Email: "Ada@Example.com", Role: entities.UserRoleMember}
+	repo := &MockUserRepository{}
+	repo.On("GetByID", "member-1").Return(member, nil)
+	repo.On("FindByEmail", "ada@example.com").Return(member, nil)
+	repo.On("Update", member).Return(nil)
+
+	email := " ADA@example.com"
+	user, err := NewMemberUseCase(repo).UpdateContact("member-1", ContactUpdate{Email: &email})
+	require.NoError(t, err)
+	assert.Equal(t, "ada@example.com", user.Email)
+}
+
+func TestMemberUseCase_GetProfile_NotFound(t *testing.T) {
+	repo := &MockUserRepository{}
+	repo.On("GetByID", "missing").Return(nil, nil)
+
+	_, err := NewMemberUseCase(repo).GetProfile("missing")
+	assert.ErrorIs(t, err, domainerr.ErrMemberNotFound)
+}
+
+func TestMemberUseCase_DeactivateAndReactivate(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	member := &entities.User{ID: "member-1", Name: "Ada", Email: "ada@example.com", Role: entities.UserRoleMember}
+	repo := &MockUserRepository{}
+	repo.On("GetByID", "member-1").Return(member, nil)
+	repo.On("Update", member).Return(nil)
+	auditRepo := &MockAuditRepository{}
+	auditRepo.On("Create", mock.AnythingOfType("*entities.AuditEntry")).Return(nil)
+	useCase := NewMemberUseCase(repo)
+	useCase.SetAuditRepository(auditRepo)
+	useCase.SetClock(clock.NewFixed(now))
+	admin := entities.Actor{UserID: "admin-1", Role: entities.UserRoleAdmin}
+
+	user, err := useCase.Deactivate(admin, "member-1")
+	require.NoError(t, err)
+	require.NotNil(t, user.DeactivatedAt)
+	assert.Equal(t, now, *user.DeactivatedAt)
+	assert.False(t, user.Active())
+
+	// Deactivating again keeps the first deactivation
+	_, err = useCase.Deactivate(admin, "member-1")
+	require.NoError(t, err)
+	repo.AssertNumberOfCalls(t, "Update", 1)
+
+	user, err = useCase.Reactivate(admin, "member-1")
+	require.NoError(t, err)
+	assert.True(t, user.Active())
+
+	actions := []string{}
+	for _, call := range auditRepo.Calls {
+		entry := call.Arguments.Get(0).(*entities.AuditEntry)
+		assert.Equal(t, entities.AuditEntityUser, entry.EntityType)
+		assert.Equal(t, "admin-1", entry.Actor)
+		actions = append(actions, entry.Action)
+	}
+	assert.Equal(t, []string{entities.AuditActionDeactivated, entities.AuditActionReactivated}, actions)
+}
+
+func TestMemberUseCase_Erase(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		loans         []entities.Loan
+		expectedError error
+	}{
+		{
+			name: "anonymizes the account",
+		},
+		{
+			name:          "refuses a member with books on loan",
+			loans:         []entities.Loan{{ID: "loan-1"}},
+			expectedError: domainerr.ErrMemberHasLoans,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			member := &entities.User{ID: "member-1", Name: "Ada", Email: "ada@example.com", Phone: "555-0100", PasswordHash: "hash", Role: entities.UserRoleMember}
+			repo := &MockUserRepository{}
+			repo.On("GetByID", "member-1").Return(member, nil)
+			repo.On("Update", member).Return(nil)
+			loanRepo := &MockLoanRepository{}
+			loanRepo.On("ListActiveByUser", "member-1").Return(append([]entities.Loan{}, tt.loans...), nil)
+			useCase := NewMemberUseCase(repo)
+			useCase.SetLoanRepository(loanRepo)
+			useCase.SetClock(clock.NewFixed(now))
+
+			user, err := useCase.Erase(entities.Actor{}, "member-1")
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				repo.AssertNotCalled(t, "Update", mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Deleted member", user.Name)
+			assert.Equal(t, "deleted-member-1@anonymized.invalid", user.Email)
+			assert.Empty(t, user.Phone)
+			assert.Empty(t, user.PasswordHash)
+			assert.False(t, user.Active())
+			require.NotNil(t, user.AnonymizedAt)
+
+			// Anonymized accounts stay anonymized
+			_, err = useCase.Reactivate(entities.Actor{}, "member-1")
+			assert.ErrorIs(t, err, domainerr.ErrMemberAnonymized)
+		})
+	}
+}
+
+func TestMemberUseCase_AnonymizeDeactivated(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	deactivatedAt := now.AddDate(-1, 0, -1)
+	cutoff := now.AddDate(-1, 0, 0)
+	repo := &MockUserRepository{}
+	repo.On("ListDeactivatedBefore", cutoff).Return([]entities.User{
+		{ID: "member-1", Name: "Ada", DeactivatedAt: &deactivatedAt},
+		{ID: "member-2", Name: "Grace", DeactivatedAt: &deactivatedAt},
+	}, nil)
+	repo.On("Update", mock.AnythingOfType("*entities.User")).Return(nil)
+	loanRepo := &MockLoanRepository{}
+	loanRepo.On("ListActiveByUser", "member-1").Return([]entities.Loan{}, nil)
+	loanRepo.On("ListActiveByUser", "member-2").Return([]entities.Loan{{ID: "loan-1"}}, nil)
+	useCase := NewMemberUseCase(repo)
+	useCase.SetLoanRepository(loanRepo)
+	useCase.SetClock(clock.NewFixed(now))
+
+	anonymized, err := useCase.AnonymizeDeactivated(cutoff)
+	require.NoError(t, err)
+	assert.Equal(t, 1, anonymized)
+	repo.AssertNumberOfCalls(t, "Update", 1)
+	updated := repo.Calls[len(repo.Calls)-1].Arguments.Get(0).(*entities.User)
+	assert.Equal(t, "member-1", updated.ID)
+	assert.Equal(t, deactivatedAt, *updated.DeactivatedAt)
+	assert.Equal(t, now, *updated.AnonymizedAt)
+}
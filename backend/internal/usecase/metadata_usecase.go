@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// Limits of the custom metadata of a book
+const (
+	maxMetadataKeys        = 50
+	maxMetadataValueLength = 1000
+)
+
+// metadataKeyPattern matches metadata keys: lowercase snake case, so they can be used in query
+// parameters such as meta.shelf_code
+var metadataKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+// MetadataUseCase handles the metadata fields tenants define for their books
+type MetadataUseCase struct {
+	fieldRepo repositories.MetadataFieldRepository
+}
+
+// NewMetadataUseCase creates a new metadata use case
+func NewMetadataUseCase(fieldRepo repositories.MetadataFieldRepository) *MetadataUseCase {
+	return &MetadataUseCase{
+		fieldRepo: fieldRepo,
+	}
+}
+
+// ListFields retrieves the metadata fields of a tenant ordered by key
+func (uc *MetadataUseCase) ListFields(tenantID string) ([]entities.MetadataField, error) {
+	if tenantID == "" {
+		return nil, errors.New("tenant ID is required")
+	}
+
+	return uc.fieldRepo.ListByTenant(tenantID)
+}
+
+// SaveField creates or replaces a metadata field of a tenant
+func (uc *MetadataUseCase) SaveField(field *entities.MetadataField) error {
+	if field.TenantID == "" {
+		return errors.New("tenant ID is required")
+	}
+	if !metadataKeyPattern.MatchString(field.Key) {
+		return errors.New("metadata keys must be lowercase letters, digits and underscores, starting with a letter")
+	}
+	if !entities.IsMetadataType(field.Type) {
+		return errors.New("metadata field type must be string, number or boolean")
+	}
+	field.Label = strings.TrimSpace(field.Label)
+
+	return uc.fieldRepo.Save(field)
+}
+
+// DeleteField removes a metadata field of a tenant. Books keep the values they have.
+func (uc *MetadataUseCase) DeleteField(tenantID, key string) error {
+	if tenantID == "" {
+		return errors.New("tenant ID is required")
+	}
+
+	deleted, err := uc.fieldRepo.Delete(tenantID, key)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return domainerr.ErrMetadataFieldNotFound
+	}
+	return nil
+}
+
+// Validate checks the metadata of a book against the fields of a tenant: only defined keys, with
+// values of their type, and every required field. Without a tenant or definitions, any
+// metadata is accepted.
+func (uc *MetadataUseCase) Validate(tenantID string, metadata map[string]interface{}) error {
+	if tenantID == "" {
+		return nil
+	}
+
+	fields, err := uc.fieldRepo.ListByTenant(tenantID)
+	if err != nil {
+		return err
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	defined := make(map[string]entities.MetadataField, len(fields))
+	for _, field := range fields {
+		defined[field.Key] = field
+		if _, ok := metadata[field.Key]; field.Required && !ok {
+			return fmt.Errorf("metadata field %s is required", field.Key)
+		}
+	}
+	for key, value := range metadata {
+		field, ok := defined[key]
+		if !ok {
+			return fmt.Errorf("metadata field %s is not defined", key)
+		}
+		if !field.Accepts(value) {
+			return fmt.Errorf("metadata field %s must be a %s", key, field.Type)
+		}
+	}
+	return nil
+}
+
+// validateMetadata checks the shape of the metadata of a book, whatever the tenant: well-formed
+// keys and string, number or boolean values
+func validateMetadata(metadata map[string]interface{}) error {
+	if len(metadata) > maxMetadataKeys {
+		return fmt.Errorf("books can have at most %d metadata fields", maxMetadataKeys)
+	}
+	for key, value := range metadata {
+		if !metadataKeyPattern.MatchString(key) {
+			return errors.New("metadata keys must be lowercase letters, digits and underscores, starting with a letter")
+		}
+		switch v := value.(type) {
+		case string:
+			if len(v) > maxMetadataValueLength {
+				return fmt.Errorf("metadata field %s must be at most %d characters", key, maxMetadataValueLength)
+			}
+		case float64, bool:
+		default:
+			return fmt.Errorf("metadata field %s must be a string, number or boolean", key)
+		}
+	}
+	return nil
+}
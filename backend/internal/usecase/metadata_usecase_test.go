@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"testing"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMetadataFieldRepository is a mock implementation of MetadataFieldRepository
+type MockMetadataFieldRepository struct {
+	mock.Mock
+}
+
+func (m *MockMetadataFieldRepository) Save(field *entities.MetadataField) error {
+	args := m.Called(field)
+	return args.Error(0)
+}
+
+func (m *MockMetadataFieldRepository) ListByTenant(tenantID string) ([]entities.MetadataField, error) {
+	args := m.Called(tenantID)
+	return args.Get(0).([]entities.MetadataField), args.Error(1)
+}
+
+func (m *MockMetadataFieldRepository) Delete(tenantID, key string) (bool, error) {
+	args := m.Called(tenantID, key)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestMetadataUseCase_Validate(t *testing.T) {
+	fieldRepo := &MockMetadataFieldRepository{}
+	fieldRepo.On("ListByTenant", "tenant-1").Return([]entities.MetadataField{
+		{TenantID: "tenant-1", Key: "shelf_code", Type: entities.MetadataTypeString, Required: true},
+		{TenantID: "tenant-1", Key: "copies", Type: entities.MetadataTypeNumber},
+	}, nil)
+	fieldRepo.On("ListByTenant", "tenant-2").Return([]entities.MetadataField{}, nil)
+	useCase := NewMetadataUseCase(fieldRepo)
+
+	tests := []struct {
+		name          string
+		tenantID      string
+		metadata      map[string]interface{}
+		expectedError string
+	}{
+		{name: "defined fields", tenantID: "tenant-1", metadata: map[string]interface{}{"shelf_code": "M-12", "copies": float64(2)}},
+		{name: "missing required field", tenantID: "tenant-1", metadata: map[string]interface{}{"copies": float64(2)}, expectedError: "metadata field shelf_code is required"},
+		{name: "undefined field", tenantID: "tenant-1", metadata: map[string]interface{}{"shelf_code": "M-12", "signed": true}, expectedError: "metadata field signed is not defined"},
+		{name: "wrong type", tenantID: "tenant-1", metadata: map[string]interface{}{"shelf_code": "M-12", "copies": "two"}, expectedError: "metadata field copies must be a number"},
+		{name: "tenant without fields", tenantID: "tenant-2", metadata: map[string]interface{}{"anything": true}},
+		{name: "no tenant", metadata: map[string]interface{}{"anything": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := useCase.Validate(tt.tenantID, tt.metadata)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMetadataUseCase_SaveField(t *testing.T) {
+	t.Run("saves a valid field", func(t *testing.T) {
+		fieldRepo := &MockMetadataFieldRepository{}
+		fieldRepo.On("Save", mock.Anything).Return(nil)
+		useCase := NewMetadataUseCase(fieldRepo)
+
+		err := useCase.SaveField(&entities.MetadataField{TenantID: "tenant-1", Key: "shelf_code", Type: entities.MetadataTypeString, Label: " Shelf code "})
+
+		assert.NoError(t, err)
+		fieldRepo.AssertCalled(t, "Save", mock.MatchedBy(func(field *entities.MetadataField) bool { return field.Label == "Shelf code" }))
+	})
+
+	t.Run("refuses malformed keys", func(t *testing.T) {
+		useCase := NewMetadataUseCase(&MockMetadataFieldRepository{})
+
+		err := useCase.SaveField(&entities.MetadataField{TenantID: "tenant-1", Key: "Shelf Code", Type: entities.MetadataTypeString})
+
+		assert.EqualError(t, err, "metadata keys must be lowercase letters, digits and underscores, starting with a letter")
+	})
+
+	t.Run("deleting a missing field is not found", func(t *testing.T) {
+		fieldRepo := &MockMetadataFieldRepository{}
+		fieldRepo.On("Delete", "tenant-1", "shelf_code").Return(false, nil)
+		useCase := NewMetadataUseCase(fieldRepo)
+
+		assert.ErrorIs(t, useCase.DeleteField("tenant-1", "shelf_code"), domainerr.ErrMetadataFieldNotFound)
+	})
+}
+
+func TestValidateMetadata(t *testing.T) {
+	assert.NoError(t, validateMetadata(map[string]interface{}{"shelf_code": "M-12", "copies": float64(2), "signed": true}))
+	assert.EqualError(t, validateMetadata(map[string]interface{}{"location": map[string]interface{}{"shelf": "M-12"}}),
+		"metadata field location must be a string, number or boolean")
+	assert.EqualError(t, validateMetadata(map[string]interface{}{"2nd_copy": true}),
+		"metadata keys must be lowercase letters, digits and underscores, starting with a letter")
+}
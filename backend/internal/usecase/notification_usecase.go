@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/events"
+	"library-management-system/internal/domain/repositories"
+)
+
+// inAppEventTypes lists the domain events that produce in-app notifications
+var inAppEventTypes = []events.EventType{
+	events.HoldAvailable,
+	events.LoanDueSoon,
+	events.ChangeRequestApproved,
+	events.LoanRenewed,
+	events.EnrichmentCompleted,
+}
+
+// NotificationUseCase handles in-app notification business logic
+type NotificationUseCase struct {
+	notificationRepo repositories.NotificationRepository
+}
+
+// NewNotificationUseCase creates a new notification use case
+func NewNotificationUseCase(notificationRepo repositories.NotificationRepository) *NotificationUseCase {
+	return &NotificationUseCase{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// Subscribe registers the use case on the event bus for every event that produces a notification
+func (uc *NotificationUseCase) Subscribe(bus events.Bus) {
+	for _, eventType := range inAppEventTypes {
+		bus.Subscribe(eventType, uc.HandleEvent)
+	}
+}
+
+// HandleEvent turns a domain event into a notification for its recipient
+func (uc *NotificationUseCase) HandleEvent(event events.Event) error {
+	if !isInAppEvent(event.Type) {
+		return fmt.Errorf("no in-app notification for event %s", event.Type)
+	}
+	if event.RecipientID == "" {
+		return errors.New("event has no recipient")
+	}
+
+	notification := &entities.Notification{
+		RecipientID: event.RecipientID,
+		Type:        string(event.Type),
+		Title:       event.Type.Summary(),
+		Message:     event.Payload["message"],
+	}
+	if event.BookID != "" {
+		bookID := event.BookID
+		notification.BookID = &bookID
+	}
+
+	return uc.notificationRepo.Create(notification)
+}
+
+// ListNotifications retrieves the notifications of a recipient
+func (uc *NotificationUseCase) ListNotifications(recipientID string, unreadOnly bool) ([]entities.Notification, error) {
+	if recipientID == "" {
+		return nil, errors.New("recipient ID is required")
+	}
+
+	return uc.notificationRepo.ListByRecipient(recipientID, unreadOnly)
+}
+
+// UnreadCount returns the number of unread notifications of a recipient
+func (uc *NotificationUseCase) UnreadCount(recipientID string) (int64, error) {
+	if recipientID == "" {
+		return 0, errors.New("recipient ID is required")
+	}
+
+	return uc.notificationRepo.CountUnread(recipientID)
+}
+
+// MarkAsRead marks a notification as read on behalf of its recipient
+func (uc *NotificationUseCase) MarkAsRead(recipientID, id string) error {
+	if recipientID == "" {
+		return errors.New("recipient ID is required")
+	}
+	if id == "" {
+		return errors.New("notification ID is required")
+	}
+
+	notification, err := uc.notificationRepo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	// Notifications of other recipients are reported as missing rather than forbidden
+	if notification == nil || notification.RecipientID != recipientID {
+		return domainerr.ErrNotificationNotFound
+	}
+
+	return uc.notificationRepo.MarkRead(id)
+}
+
+// MarkAllAsRead marks every notification of a recipient as read
+func (uc *NotificationUseCase) MarkAllAsRead(recipientID string) error {
+	if recipientID == "" {
+		return errors.New("recipient ID is required")
+	}
+
+	return uc.notificationRepo.MarkAllRead(recipientID)
+}
+
+// isInAppEvent reports whether the event type produces in-app notifications
+func isInAppEvent(eventType events.EventType) bool {
+	for _, t := range inAppEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
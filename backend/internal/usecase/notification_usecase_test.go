@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"testing"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/events"
+	"library-management-system/internal/infrastructure/eventbus"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockNotificationRepository is a mock implementation of NotificationRepository
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) Create(notification *entities.Notification) error {
+	args := m.Called(notification)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) GetByID(id string) (*entities.Notification, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) ListByRecipient(recipientID string, unreadOnly bool) ([]entities.Notification, error) {
+	args := m.Called(recipientID, unreadOnly)
+	return args.Get(0).([]entities.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) CountUnread(recipientID string) (int64, error) {
+	args := m.Called(recipientID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkRead(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) MarkAllRead(recipientID string) error {
+	args := m.Called(recipientID)
+	return args.Error(0)
+}
+
+func TestNotificationUseCase_Subscribe(t *testing.T) {
+	mockRepo := &MockNotificationRepository{}
+	useCase := NewNotificationUseCase(mockRepo)
+	bus := eventbus.NewInMemoryBus()
+	useCase.Subscribe(bus)
+
+	mockRepo.On("Create", mock.MatchedBy(func(n *entities.Notification) bool {
+		return n.RecipientID == "member-1" &&
+			n.Type == string(events.HoldAvailable) &&
+			n.BookID != nil && *n.BookID == "book-1" &&
+			n.Message == "Pick it up at the front desk"
+	})).Return(nil)
+
+	bus.Publish(events.Event{
+		Type:        events.HoldAvailable,
+		RecipientID: "member-1",
+		BookID:      "book-1",
+		Payload:     map[string]string{"message": "Pick it up at the front desk"},
+	})
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNotificationUseCase_HandleEvent(t *testing.T) {
+	tests := []struct {
+		name          string
+		event         events.Event
+		mockSetup     func(*MockNotificationRepository)
+		expectedError string
+	}{
+		{
+			name:  "loan due soon",
+			event: events.Event{Type: events.LoanDueSoon, RecipientID: "member-1"},
+			mockSetup: func(repo *MockNotificationRepository) {
+				repo.On("Create", mock.AnythingOfType("*entities.Notification")).Return(nil)
+			},
+			expectedError: "",
+		},
+		{
+			name:          "missing recipient",
+			event:         events.Event{Type: events.LoanDueSoon},
+			expectedError: "event has no recipient",
+		},
+		{
+			name:          "unknown event type",
+			event:         events.Event{Type: "book.created", RecipientID: "member-1"},
+			expectedError: "no in-app notification",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockNotificationRepository{}
+			useCase := NewNotificationUseCase(mockRepo)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockRepo)
+			}
+
+			err := useCase.HandleEvent(tt.event)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNotificationUseCase_MarkAsRead(t *testing.T) {
+	tests := []struct {
+		name          string
+		recipientID   string
+		id            string
+		mockSetup     func(*MockNotificationRepository)
+		expectedError string
+	}{
+		{
+			name:        "successful mark as read",
+			recipientID: "member-1",
+			id:          "notification-1",
+			mockSetup: func(repo *MockNotificationRepository) {
+				repo.On("GetByID", "notification-1").Return(&entities.Notification{ID: "notification-1", RecipientID: "member-1"}, nil)
+				repo.On("MarkRead", "notification-1").Return(nil)
+			},
+			expectedError: "",
+		},
+		{
+			name:        "notification of another recipient",
+			recipientID: "member-2",
+			id:          "notification-1",
+			mockSetup: func(repo *MockNotificationRepository) {
+				repo.On("GetByID", "notification-1").Return(&entities.Notification{ID: "notification-1", RecipientID: "member-1"}, nil)
+			},
+			expectedError: "notification not found",
+		},
+		{
+			name:        "notification not found",
+			recipientID: "member-1",
+			id:          "missing",
+			mockSetup: func(repo *MockNotificationRepository) {
+				repo.On("GetByID", "missing").Return(nil, nil)
+			},
+			expectedError: "notification not found",
+		},
+		{
+			name:          "empty recipient",
+			recipientID:   "",
+			id:            "notification-1",
+			expectedError: "recipient ID is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockNotificationRepository{}
+			useCase := NewNotificationUseCase(mockRepo)
+
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockRepo)
+			}
+
+			err := useCase.MarkAsRead(tt.recipientID, tt.id)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNotificationUseCase_UnreadCount(t *testing.T) {
+	mockRepo := &MockNotificationRepository{}
+	useCase := NewNotificationUseCase(mockRepo)
+	mockRepo.On("CountUnread", "member-1").Return(int64(2), nil)
+
+	count, err := useCase.UnreadCount("member-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	mockRepo.AssertExpectations(t)
+}
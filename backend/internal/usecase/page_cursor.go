@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/pagetoken"
+	"library-management-system/internal/domain/repositories"
+)
+
+// pageCursors reads and issues the cursors of a listing sorted newest first, whose sort keys are
+// the time and ID of its records. Without a signer no cursor is issued, and none is accepted.
+type pageCursors struct {
+	signer  *pagetoken.Signer
+	listing string
+}
+
+// after returns the position a cursor continues the query from, nil for the first page
+func (p pageCursors) after(cursor, query string) (*repositories.PageAfter, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	if p.signer == nil {
+		return nil, domainerr.ErrInvalidCursor
+	}
+
+	decoded, err := p.signer.Decode(cursor, p.listing, query, 2)
+	if err != nil {
+		return nil, err
+	}
+	at, err := time.Parse(time.RFC3339Nano, decoded.Keys[0])
+	if err != nil {
+		return nil, domainerr.ErrInvalidCursor
+	}
+	return &repositories.PageAfter{Time: at, ID: decoded.Keys[1]}, nil
+}
+
+// next returns the cursor of the page after the record at a time with an ID
+func (p pageCursors) next(query string, at time.Time, id string) string {
+	if p.signer == nil {
+		return ""
+	}
+	return p.signer.Encode(pagetoken.Cursor{Listing: p.listing, Query: query, Keys: []string{at.UTC().Format(time.RFC3339Nano), id}})
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"sort"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// PopularityUseCase ranks books by how often they were viewed and loaned recently
+type PopularityUseCase struct {
+	statsRepo repositories.BookStatsRepository
+	// windowDays is how many days of views and loans count towards popularity
+	windowDays int
+	// loanWeight is how many views a loan is worth
+	loanWeight int64
+	clock      clock.Clock
+}
+
+// NewPopularityUseCase creates a new popularity use case
+func NewPopularityUseCase(statsRepo repositories.BookStatsRepository, windowDays int, loanWeight int64) *PopularityUseCase {
+	return &PopularityUseCase{
+		statsRepo:  statsRepo,
+		windowDays: windowDays,
+		loanWeight: loanWeight,
+		clock:      clock.System{},
+	}
+}
+
+// SetClock replaces the clock the popularity window ends at
+func (uc *PopularityUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// Refresh recomputes the popularity of every book from its views and loans of the last days
+func (uc *PopularityUseCase) Refresh() error {
+	since := uc.clock.Now().AddDate(0, 0, -uc.windowDays)
+	return uc.statsRepo.RefreshPopularity(since, uc.loanWeight)
+}
+
+// SortByPopularity orders books most popular first, keeping the order of equally popular books
+func SortByPopularity(books []entities.Book) {
+	sort.SliceStable(books, func(i, j int) bool {
+		return books[i].Popularity > books[j].Popularity
+	})
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBookStatsRepository is a mock implementation of BookStatsRepository
+type MockBookStatsRepository struct {
+	mock.Mock
+}
+
+func (m *MockBookStatsRepository) AddViews(day time.Time, views map[string]int64) error {
+	args := m.Called(day, views)
+	return args.Error(0)
+}
+
+func (m *MockBookStatsRepository) RefreshPopularity(since time.Time, loanWeight int64) error {
+	args := m.Called(since, loanWeight)
+	return args.Error(0)
+}
+
+func TestPopularityUseCase_Refresh(t *testing.T) {
+	now := time.Date(2026, 10, 16, 2, 15, 0, 0, time.UTC)
+	repo := &MockBookStatsRepository{}
+	repo.On("RefreshPopularity", now.AddDate(0, 0, -30), int64(10)).Return(nil)
+	useCase := NewPopularityUseCase(repo, 30, 10)
+	useCase.SetClock(clock.NewFixed(now))
+
+	assert.NoError(t, useCase.Refresh())
+	repo.AssertExpectations(t)
+}
+
+func TestSortByPopularity(t *testing.T) {
+	books := []entities.Book{
+		{ID: "quiet", Popularity: 0},
+		{ID: "loaned", Popularity: 40},
+		{ID: "also-quiet", Popularity: 0},
+		{ID: "viewed", Popularity: 7},
+	}
+
+	SortByPopularity(books)
+
+	var ids []string
+	for _, book := range books {
+		ids = append(ids, book.ID)
+	}
+	assert.Equal(t, []string{"loaned", "viewed", "quiet", "also-quiet"}, ids)
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// PublisherUseCase handles publisher and imprint business logic
+type PublisherUseCase struct {
+	publisherRepo repositories.PublisherRepository
+	bookRepo      repositories.BookRepository
+}
+
+// NewPublisherUseCase creates a new publisher use case
+func NewPublisherUseCase(publisherRepo repositories.PublisherRepository, bookRepo repositories.BookRepository) *PublisherUseCase {
+	return &PublisherUseCase{
+		publisherRepo: publisherRepo,
+		bookRepo:      bookRepo,
+	}
+}
+
+// CreatePublisher creates a new publisher, or an imprint when a parent is given
+func (uc *PublisherUseCase) CreatePublisher(publisher *entities.Publisher) error {
+	publisher.Name = strings.TrimSpace(publisher.Name)
+	if publisher.Name == "" {
+		return errors.New("publisher name is required")
+	}
+
+	existing, err := uc.publisherRepo.FindByName(publisher.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return domainerr.ErrDuplicatePublisherName
+	}
+
+	if publisher.ParentID != nil {
+		if err := uc.validateParent("", *publisher.ParentID); err != nil {
+			return err
+		}
+	}
+
+	return uc.publisherRepo.Create(publisher)
+}
+
+// GetPublisher retrieves a publisher by ID
+func (uc *PublisherUseCase) GetPublisher(id string) (*entities.Publisher, error) {
+	if id == "" {
+		return nil, errors.New("publisher ID is required")
+	}
+
+	return uc.publisherRepo.GetByID(id)
+}
+
+// ListPublishers retrieves all publishers and imprints
+func (uc *PublisherUseCase) ListPublishers() ([]entities.Publisher, error) {
+	return uc.publisherRepo.GetAll()
+}
+
+// ListImprints retrieves the direct imprints of a publisher
+func (uc *PublisherUseCase) ListImprints(id string) ([]entities.Publisher, error) {
+	if _, err := uc.requirePublisher(id); err != nil {
+		return nil, err
+	}
+
+	return uc.publisherRepo.ListImprints(id)
+}
+
+// UpdatePublisher renames a publisher or moves it under another parent
+func (uc *PublisherUseCase) UpdatePublisher(id string, publisher *entities.Publisher) error {
+	existing, err := uc.requirePublisher(id)
+	if err != nil {
+		return err
+	}
+
+	publisher.Name = strings.TrimSpace(publisher.Name)
+	if publisher.Name == "" {
+		return errors.New("publisher name is required")
+	}
+
+	if publisher.Name != existing.Name {
+		withName, err := uc.publisherRepo.FindByName(publisher.Name)
+		if err != nil {
+			return err
+		}
+		if withName != nil {
+			return domainerr.ErrDuplicatePublisherName
+		}
+	}
+
+	if publisher.ParentID != nil {
+		if err := uc.validateParent(id, *publisher.ParentID); err != nil {
+			return err
+		}
+	}
+
+	existing.Name = publisher.Name
+	existing.ParentID = publisher.ParentID
+	if err := uc.publisherRepo.Update(existing); err != nil {
+		return err
+	}
+
+	*publisher = *existing
+	return nil
+}
+
+// DeletePublisher deletes a publisher that has neither imprints nor books
+func (uc *PublisherUseCase) DeletePublisher(id string) error {
+	if _, err := uc.requirePublisher(id); err != nil {
+		return err
+	}
+
+	imprints, err := uc.publisherRepo.ListImprints(id)
+	if err != nil {
+		return err
+	}
+	if len(imprints) > 0 {
+		return errors.New("publisher has imprints")
+	}
+
+	books, err := uc.bookRepo.FindByPublishers([]string{id})
+	if err != nil {
+		return err
+	}
+	if len(books) > 0 {
+		return errors.New("publisher has books")
+	}
+
+	return uc.publisherRepo.Delete(id)
+}
+
+// requirePublisher retrieves a publisher and fails when it does not exist
+func (uc *PublisherUseCase) requirePublisher(id string) (*entities.Publisher, error) {
+	if id == "" {
+		return nil, errors.New("publisher ID is required")
+	}
+
+	publisher, err := uc.publisherRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if publisher == nil {
+		return nil, domainerr.ErrPublisherNotFound
+	}
+	return publisher, nil
+}
+
+// validateParent checks that the parent exists and that attaching the publisher to it keeps the hierarchy a tree
+func (uc *PublisherUseCase) validateParent(id, parentID string) error {
+	if parentID == id {
+		return errors.New("publisher cannot be its own imprint")
+	}
+
+	seen := make(map[string]bool)
+	for current := parentID; current != ""; {
+		if seen[current] {
+			break
+		}
+		seen[current] = true
+
+		parent, err := uc.publisherRepo.GetByID(current)
+		if err != nil {
+			return err
+		}
+		if parent == nil {
+			return domainerr.ErrParentPublisherNotFound
+		}
+		if parent.ParentID == nil {
+			break
+		}
+		if *parent.ParentID == id {
+			return errors.New("publisher cannot be an imprint of its own imprint")
+		}
+		current = *parent.ParentID
+	}
+	return nil
+}
+
+// publisherFamily returns the ID of a publisher followed by the IDs of all its imprints, at any depth
+func publisherFamily(publisherRepo repositories.PublisherRepository, id string) ([]string, error) {
+	family := []string{id}
+	seen := map[string]bool{id: true}
+	for i := 0; i < len(family); i++ {
+		imprints, err := publisherRepo.ListImprints(family[i])
+		if err != nil {
+			return nil, err
+		}
+		for _, imprint := range imprints {
+			if !seen[imprint.ID] {
+				seen[imprint.ID] = true
+				family = append(family, imprint.ID)
+			}
+		}
+	}
+	return family, nil
+}
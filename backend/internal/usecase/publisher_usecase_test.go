@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"testing"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPublisherRepository is a mock implementation of PublisherRepository
+type MockPublisherRepository struct {
+	mock.Mock
+}
+
+func (m *MockPublisherRepository) Create(publisher *entities.Publisher) error {
+	args := m.Called(publisher)
+	return args.Error(0)
+}
+
+func (m *MockPublisherRepository) GetByID(id string) (*entities.Publisher, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Publisher), args.Error(1)
+}
+
+func (m *MockPublisherRepository) GetAll() ([]entities.Publisher, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.Publisher), args.Error(1)
+}
+
+func (m *MockPublisherRepository) Update(publisher *entities.Publisher) error {
+	args := m.Called(publisher)
+	return args.Error(0)
+}
+
+func (m *MockPublisherRepository) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockPublisherRepository) FindByName(name string) (*entities.Publisher, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Publisher), args.Error(1)
+}
+
+func (m *MockPublisherRepository) ListImprints(parentID string) ([]entities.Publisher, error) {
+	args := m.Called(parentID)
+	return args.Get(0).([]entities.Publisher), args.Error(1)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestPublisherUseCase_CreatePublisher(t *testing.T) {
+	tests := []struct {
+		name          string
+		publisher     *entities.Publisher
+		mockSetup     func(*MockPublisherRepository)
+		expectedError string
+	}{
+		{
+			name:      "successful creation",
+			publisher: &entities.Publisher{Name: "  Penguin Random House  "},
+			mockSetup: func(repo *MockPublisherRepository) {
+				repo.On("FindByName", "Penguin Random House").Return(nil, nil)
+				repo.On("Create", mock.AnythingOfType("*entities.Publisher")).Return(nil)
+			},
+		},
+		{
+			name:      "imprint of an existing publisher",
+			publisher: &entities.Publisher{Name: "Vintage", ParentID: stringPtr("prh")},
+			mockSetup: func(repo *MockPublisherRepository) {
+				repo.On("FindByName", "Vintage").Return(nil, nil)
+				repo.On("GetByID", "prh").Return(&entities.Publisher{ID: "prh", Name: "Penguin Random House"}, nil)
+				repo.On("Create", mock.AnythingOfType("*entities.Publisher")).Return(nil)
+			},
+		},
+		{
+			name:          "missing name",
+			publisher:     &entities.Publisher{Name: " "},
+			mockSetup:     func(repo *MockPublisherRepository) {},
+			expectedError: "publisher name is required",
+		},
+		{
+			name:      "duplicate name",
+			publisher: &entities.Publisher{Name: "Vintage"},
+			mockSetup: func(repo *MockPublisherRepository) {
+				repo.On("FindByName", "Vintage").Return(&entities.Publisher{ID: "vintage", Name: "Vintage"}, nil)
+			},
+			expectedError: "publisher with this name already exists",
+		},
+		{
+			name:      "unknown parent",
+			publisher: &entities.Publisher{Name: "Vintage", ParentID: stringPtr("missing")},
+			mockSetup: func(repo *MockPublisherRepository) {
+				repo.On("FindByName", "Vintage").Return(nil, nil)
+				repo.On("GetByID", "missing").Return(nil, nil)
+			},
+			expectedError: "parent publisher not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockPublisherRepository{}
+			tt.mockSetup(mockRepo)
+
+			useCase := NewPublisherUseCase(mockRepo, &MockBookRepository{})
+			err := useCase.CreatePublisher(tt.publisher)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPublisherUseCase_UpdatePublisher_RejectsCycles(t *testing.T) {
+	mockRepo := &MockPublisherRepository{}
+	mockRepo.On("GetByID", "prh").Return(&entities.Publisher{ID: "prh", Name: "Penguin Random House"}, nil)
+	mockRepo.On("GetByID", "vintage").Return(&entities.Publisher{ID: "vintage", Name: "Vintage", ParentID: stringPtr("prh")}, nil)
+
+	useCase := NewPublisherUseCase(mockRepo, &MockBookRepository{})
+
+	err := useCase.UpdatePublisher("prh", &entities.Publisher{Name: "Penguin Random House", ParentID: stringPtr("prh")})
+	assert.EqualError(t, err, "publisher cannot be its own imprint")
+
+	err = useCase.UpdatePublisher("prh", &entities.Publisher{Name: "Penguin Random House", ParentID: stringPtr("vintage")})
+	assert.EqualError(t, err, "publisher cannot be an imprint of its own imprint")
+}
+
+func TestPublisherUseCase_DeletePublisher(t *testing.T) {
+	mockRepo := &MockPublisherRepository{}
+	mockBookRepo := &MockBookRepository{}
+	mockRepo.On("GetByID", "prh").Return(&entities.Publisher{ID: "prh"}, nil)
+	mockRepo.On("ListImprints", "prh").Return([]entities.Publisher{{ID: "vintage"}}, nil)
+	mockRepo.On("GetByID", "vintage").Return(&entities.Publisher{ID: "vintage", ParentID: stringPtr("prh")}, nil)
+	mockRepo.On("ListImprints", "vintage").Return([]entities.Publisher{}, nil)
+	mockBookRepo.On("FindByPublishers", []string{"vintage"}).Return([]entities.Book{{ID: "book-1"}}, nil)
+	mockRepo.On("GetByID", "missing").Return(nil, nil)
+
+	useCase := NewPublisherUseCase(mockRepo, mockBookRepo)
+
+	assert.EqualError(t, useCase.DeletePublisher("prh"), "publisher has imprints")
+	assert.EqualError(t, useCase.DeletePublisher("vintage"), "publisher has books")
+	assert.EqualError(t, useCase.DeletePublisher("missing"), "publisher not found")
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestBookUseCase_SearchBooksByPublisher_IncludesImprints(t *testing.T) {
+	mockRepo := &MockBookRepository{}
+	mockPublisherRepo := &MockPublisherRepository{}
+	mockPublisherRepo.On("ListImprints", "prh").Return([]entities.Publisher{{ID: "vintage"}, {ID: "knopf"}}, nil)
+	mockPublisherRepo.On("ListImprints", "vintage").Return([]entities.Publisher{{ID: "anchor"}}, nil)
+	mockPublisherRepo.On("ListImprints", "knopf").Return([]entities.Publisher{}, nil)
+	mockPublisherRepo.On("ListImprints", "anchor").Return([]entities.Publisher{}, nil)
+	mockRepo.On("FindByPublishers", []string{"prh", "vintage", "knopf", "anchor"}).Return([]entities.Book{{ID: "book-1"}}, nil)
+
+	useCase := NewBookUseCase(mockRepo)
+	useCase.SetPublisherRepository(mockPublisherRepo)
+
+	books, err := useCase.SearchBooksByPublisher("prh")
+	assert.NoError(t, err)
+	assert.Len(t, books, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestBookUseCase_CreateBook_UnknownPublisher(t *testing.T) {
+	mockPublisherRepo := &MockPublisherRepository{}
+	mockPublisherRepo.On("GetByID", "missing").Return(nil, nil)
+
+	useCase := NewBookUseCase(&MockBookRepository{})
+	useCase.SetPublisherRepository(mockPublisherRepo)
+
+	err := useCase.CreateBook(&entities.Book{
+		Title:       "Test Book",
+		Author:      "Test Author",
+		Year:        2024,
+		ISBN:        "1234567890",
+		PublisherID: stringPtr("missing"),
+	})
+	assert.EqualError(t, err, "publisher not found")
+}
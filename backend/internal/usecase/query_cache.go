@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// QueryCache caches the results of book listings and searches with stale-while-revalidate: a
+// result is served as is while fresh, then served stale for a while longer as it is refreshed in
+// the background, and loaded again once too old. Any change to books invalidates every result.
+// It is held in memory, so each instance has its own; with a shared version, an invalidation
+// by any instance drops the results of all of them.
+type QueryCache struct {
+	fresh      time.Duration
+	stale      time.Duration
+	maxEntries int
+	clock      clock.Clock
+	shared     repositories.CacheVersion
+
+	mu      sync.Mutex
+	entries map[string]*queryCacheEntry
+	// generation is bumped by every invalidation, so refreshes started before one are dropped
+	generation uint64
+	// sharedVersion is the shared version the entries were cached at
+	sharedVersion int64
+	refreshes     sync.WaitGroup
+}
+
+// queryCacheEntry is a cached result and when it was loaded
+type queryCacheEntry struct {
+	books      []entities.Book
+	loadedAt   time.Time
+	refreshing bool
+}
+
+// NewQueryCache creates a cache serving results as fresh for fresh, then stale for stale more,
+// holding at most maxEntries results
+func NewQueryCache(fresh, stale time.Duration, maxEntries int) *QueryCache {
+	return &QueryCache{
+		fresh:      fresh,
+		stale:      stale,
+		maxEntries: maxEntries,
+		clock:      clock.System{},
+		entries:    make(map[string]*queryCacheEntry),
+	}
+}
+
+// SetClock replaces the clock the age of results is measured with
+func (c *QueryCache) SetClock(clk clock.Clock) {
+	c.clock = clk
+}
+
+// SetSharedVersion shares invalidations with the other instances through version: each one
+// bumps it, and results cached at an older version are dropped. While version cannot be read,
+// results are loaded without the cache.
+func (c *QueryCache) SetSharedVersion(version repositories.CacheVersion) {
+	c.shared = version
+}
+
+// Invalidate drops every cached result, and the results of the refreshes in progress. A nil
+// cache does nothing.
+func (c *QueryCache) Invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.reset()
+	c.mu.Unlock()
+
+	if c.shared != nil {
+		if err := c.shared.Bump(); err != nil {
+			log.Printf("Failed to invalidate the query caches of other instances: %v", err)
+		}
+	}
+}
+
+// reset drops every cached result; c.mu must be held
+func (c *QueryCache) reset() {
+	c.entries = make(map[string]*queryCacheEntry)
+	c.generation++
+}
+
+// Wait blocks until the background refreshes in progress are done
+func (c *QueryCache) Wait() {
+	c.refreshes.Wait()
+}
+
+// get returns the result cached under a key, loading it when missing or too old. Stale results
+// are returned right away, and refreshed in the background once at a time. A nil cache always loads.
+func (c *QueryCache) get(key string, load func() ([]entities.Book, error)) ([]entities.Book, error) {
+	if c == nil {
+		return load()
+	}
+	now := c.clock.Now()
+
+	var version int64
+	if c.shared != nil {
+		var err error
+		if version, err = c.shared.Get(); err != nil {
+			log.Printf("Failed to read the shared query cache version, loading without the cache: %v", err)
+			return load()
+		}
+	}
+
+	c.mu.Lock()
+	if version != c.sharedVersion {
+		c.reset()
+		c.sharedVersion = version
+	}
+	entry, ok := c.entries[key]
+	if ok {
+		age := now.Sub(entry.loadedAt)
+		if age < c.fresh {
+			c.mu.Unlock()
+			return copyBooks(entry.books), nil
+		}
+		if age < c.fresh+c.stale {
+			if !entry.refreshing {
+				entry.refreshing = true
+				c.refreshes.Add(1)
+				go c.refresh(key, entry, c.generation, load)
+			}
+			c.mu.Unlock()
+			return copyBooks(entry.books), nil
+		}
+	}
+	generation := c.generation
+	c.mu.Unlock()
+
+	books, err := load()
+	if err != nil {
+		return nil, err
+	}
+	c.store(key, books, now, generation)
+	return copyBooks(books), nil
+}
+
+// refresh loads a stale result again in the background. On failure the stale result is kept
+// until it is too old, and the next request retries.
+func (c *QueryCache) refresh(key string, entry *queryCacheEntry, generation uint64, load func() ([]entities.Book, error)) {
+	defer c.refreshes.Done()
+	loadedAt := c.clock.Now()
+	books, err := load()
+	if err != nil {
+		c.mu.Lock()
+		entry.refreshing = false
+		c.mu.Unlock()
+		return
+	}
+	c.store(key, books, loadedAt, generation)
+}
+
+// store caches a result loaded at loadedAt, unless the cache was invalidated since the load started.
+// When the cache is full, the oldest result is dropped; zero max entries means unbounded.
+func (c *QueryCache) store(key string, books []entities.Book, loadedAt time.Time, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	if _, ok := c.entries[key]; !ok && c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		oldest := ""
+		for k, entry := range c.entries {
+			if oldest == "" || entry.loadedAt.Before(c.entries[oldest].loadedAt) {
+				oldest = k
+			}
+		}
+		delete(c.entries, oldest)
+	}
+	c.entries[key] = &queryCacheEntry{books: books, loadedAt: loadedAt}
+}
+
+// copyBooks copies a cached result, so callers filtering or reordering it leave the cache intact
+func copyBooks(books []entities.Book) []entities.Book {
+	if books == nil {
+		return nil
+	}
+	return append([]entities.Book(nil), books...)
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// countingLoader returns a loader of the books titled after the number of the load
+func countingLoader(loads *int) func() ([]entities.Book, error) {
+	return func() ([]entities.Book, error) {
+		*loads++
+		return []entities.Book{{Title: "load " + string(rune('0'+*loads))}}, nil
+	}
+}
+
+func TestQueryCache_StaleWhileRevalidate(t *testing.T) {
+	fixed := clock.NewFixed(time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC))
+	cache := NewQueryCache(5*time.Second, 30*time.Second, 10)
+	cache.SetClock(fixed)
+	loads := 0
+	load := countingLoader(&loads)
+
+	books, err := cache.get("all", load)
+	assert.NoError(t, err)
+	assert.Equal(t, "load 1", books[0].Title)
+
+	fixed.Advance(4 * time.Second)
+	books, _ = cache.get("all", load)
+	assert.Equal(t, "load 1", books[0].Title, "fresh results are served without loading")
+	assert.Equal(t, 1, loads)
+
+	fixed.Advance(10 * time.Second)
+	books, _ = cache.get("all", load)
+	assert.Equal(t, "load 1", books[0].Title, "stale results are served right away")
+	cache.Wait()
+	assert.Equal(t, 2, loads, "stale results are refreshed in the background")
+	books, _ = cache.get("all", load)
+	assert.Equal(t, "load 2", books[0].Title)
+
+	fixed.Advance(time.Minute)
+	books, _ = cache.get("all", load)
+	assert.Equal(t, "load 3", books[0].Title, "results too old are loaded again")
+}
+
+func TestQueryCache_Invalidate(t *testing.T) {
+	fixed := clock.NewFixed(time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC))
+	cache := NewQueryCache(5*time.Second, 30*time.Second, 10)
+	cache.SetClock(fixed)
+	loads := 0
+	load := countingLoader(&loads)
+
+	cache.get("all", load)
+	cache.Invalidate()
+	books, _ := cache.get("all", load)
+	assert.Equal(t, "load 2", books[0].Title)
+
+	fixed.Advance(10 * time.Second)
+	release := make(chan struct{})
+	cache.get("all", func() ([]entities.Book, error) {
+		<-release
+		return []entities.Book{{Title: "before the change"}}, nil
+	})
+	cache.Invalidate()
+	close(release)
+	cache.Wait()
+	books, _ = cache.get("all", load)
+	assert.Equal(t, "load 3", books[0].Title, "refreshes started before an invalidation are dropped")
+}
+
+func TestQueryCache_KeepsStaleResultsWhenRefreshFails(t *testing.T) {
+	fixed := clock.NewFixed(time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC))
+	cache := NewQueryCache(5*time.Second, 30*time.Second, 10)
+	cache.SetClock(fixed)
+	cache.get("all", func() ([]entities.Book, error) { return []entities.Book{{Title: "cached"}}, nil })
+
+	fixed.Advance(10 * time.Second)
+	failing := func() ([]entities.Book, error) { return nil, errors.New("database is down") }
+	books, err := cache.get("all", failing)
+	cache.Wait()
+	assert.NoError(t, err)
+	assert.Equal(t, "cached", books[0].Title)
+	books, _ = cache.get("all", failing)
+	cache.Wait()
+	assert.Equal(t, "cached", books[0].Title)
+
+	fixed.Advance(time.Minute)
+	_, err = cache.get("all", failing)
+	assert.EqualError(t, err, "database is down")
+}
+
+// sharedVersion is a CacheVersion shared by the caches of a test, failing while failing is set
+type sharedVersion struct {
+	version int64
+	failing bool
+}
+
+func (v *sharedVersion) Get() (int64, error) {
+	if v.failing {
+		return 0, errors.New("redis is down")
+	}
+	return v.version, nil
+}
+
+func (v *sharedVersion) Bump() error {
+	v.version++
+	return nil
+}
+
+func TestQueryCache_SharedVersion(t *testing.T) {
+	version := &sharedVersion{}
+	caches := []*QueryCache{NewQueryCache(time.Minute, 0, 10), NewQueryCache(time.Minute, 0, 10)}
+	for _, cache := range caches {
+		cache.SetSharedVersion(version)
+	}
+	loads := 0
+	load := countingLoader(&loads)
+
+	caches[0].get("all", load)
+	caches[1].get("all", load)
+	caches[1].get("all", load)
+	assert.Equal(t, 2, loads)
+
+	caches[0].Invalidate()
+	books, _ := caches[1].get("all", load)
+	assert.Equal(t, "load 3", books[0].Title, "invalidations reach the other instances")
+	caches[1].get("all", load)
+	assert.Equal(t, 3, loads)
+
+	version.failing = true
+	caches[1].get("all", load)
+	assert.Equal(t, 4, loads, "results are loaded without the cache while the version cannot be read")
+}
+
+func TestQueryCache_DropsOldestWhenFull(t *testing.T) {
+	fixed := clock.NewFixed(time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC))
+	cache := NewQueryCache(time.Minute, 0, 2)
+	cache.SetClock(fixed)
+	loads := 0
+	load := countingLoader(&loads)
+
+	cache.get("title:a", load)
+	fixed.Advance(time.Second)
+	cache.get("title:b", load)
+	cache.get("title:c", load)
+	cache.get("title:b", load)
+	assert.Equal(t, 3, loads)
+	cache.get("title:a", load)
+	assert.Equal(t, 4, loads, "the oldest result was dropped")
+}
+
+func TestBookUseCase_QueryCache(t *testing.T) {
+	mockRepo := &MockBookRepository{}
+	mockRepo.On("FindByTitle", "Gatsby").Return([]entities.Book{{ID: "1", Title: "The Great Gatsby", Status: entities.BookStatusActive}}, nil)
+	mockRepo.On("FindByISBN", mock.Anything).Return(nil, nil)
+	mockRepo.On("FindBySlug", mock.Anything).Return(nil, nil)
+	mockRepo.On("Create", mock.Anything).Return(nil)
+	useCase := NewBookUseCase(mockRepo)
+	useCase.SetQueryCache(NewQueryCache(time.Minute, time.Minute, 10))
+
+	_, err := useCase.SearchBooksByTitle("Gatsby")
+	assert.NoError(t, err)
+	_, err = useCase.SearchBooksByTitle("gatsby")
+	assert.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "FindByTitle", 1)
+
+	err = useCase.CreateBook(&entities.Book{Title: "Tender Is the Night", Author: "F. Scott Fitzgerald", Year: 1934, ISBN: "9780684801544"})
+	assert.NoError(t, err)
+	_, err = useCase.SearchBooksByTitle("Gatsby")
+	assert.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "FindByTitle", 2)
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"log"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// RelatedBookUseCase ranks the books related to each book by author, series, category and the
+// members who borrowed both. Rankings are read from a table the stats_refresh job recomputes, so
+// serving them costs a lookup however many loans there are.
+type RelatedBookUseCase struct {
+	relatedRepo repositories.RelatedBookRepository
+	bookRepo    repositories.BookRepository
+	weights     entities.RelatedBookWeights
+	// limit is how many related books are kept per book
+	limit int
+	clock clock.Clock
+}
+
+// NewRelatedBookUseCase creates a new related book use case
+func NewRelatedBookUseCase(relatedRepo repositories.RelatedBookRepository, bookRepo repositories.BookRepository, weights entities.RelatedBookWeights, limit int) *RelatedBookUseCase {
+	return &RelatedBookUseCase{
+		relatedRepo: relatedRepo,
+		bookRepo:    bookRepo,
+		weights:     weights,
+		limit:       limit,
+		clock:       clock.System{},
+	}
+}
+
+// SetClock replaces the clock refreshes are timed with
+func (uc *RelatedBookUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// Refresh recomputes the related books of every listed book
+func (uc *RelatedBookUseCase) Refresh() error {
+	kept, err := uc.relatedRepo.Refresh(uc.clock.Now().UTC(), uc.weights, uc.limit)
+	if err != nil {
+		return err
+	}
+	log.Printf("Ranked %d related book(s)", kept)
+	return nil
+}
+
+// Related returns the books related to a book by rank, as of the last refresh. Books deleted or
+// unlisted since are left out.
+func (uc *RelatedBookUseCase) Related(bookID string) ([]entities.RelatedBook, error) {
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, err
+	}
+	if book == nil || book.DeletedAt != nil {
+		return nil, domainerr.ErrBookNotFound
+	}
+
+	rows, err := uc.relatedRepo.ListByBookID(bookID)
+	if err != nil {
+		return nil, err
+	}
+	related := make([]entities.RelatedBook, 0, len(rows))
+	for _, row := range rows {
+		book, err := uc.bookRepo.GetByID(row.RelatedID)
+		if err != nil {
+			return nil, err
+		}
+		if book == nil || book.DeletedAt != nil || !book.Listed() {
+			continue
+		}
+		row.Book = book
+		related = append(related, row)
+	}
+	return related, nil
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRelatedBookRepository is a mock implementation of RelatedBookRepository
+type MockRelatedBookRepository struct {
+	mock.Mock
+}
+
+func (m *MockRelatedBookRepository) Refresh(at time.Time, weights entities.RelatedBookWeights, limit int) (int64, error) {
+	args := m.Called(at, weights, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRelatedBookRepository) ListByBookID(bookID string) ([]entities.RelatedBook, error) {
+	args := m.Called(bookID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.RelatedBook), args.Error(1)
+}
+
+func TestRelatedBookUseCase_Refresh(t *testing.T) {
+	relatedRepo := new(MockRelatedBookRepository)
+	weights := entities.RelatedBookWeights{SameAuthor: 3, SameSeries: 5, SharedCategory: 2, CoBorrowed: 1}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	relatedRepo.On("Refresh", now, weights, 10).Return(int64(42), nil)
+	useCase := NewRelatedBookUseCase(relatedRepo, new(MockBookRepository), weights, 10)
+	useCase.SetClock(clock.NewFixed(now))
+
+	require.NoError(t, useCase.Refresh())
+	relatedRepo.AssertExpectations(t)
+}
+
+func TestRelatedBookUseCase_Related(t *testing.T) {
+	relatedRepo := new(MockRelatedBookRepository)
+	bookRepo := new(MockBookRepository)
+	deletedAt := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	bookRepo.On("GetByID", "mort").Return(&entities.Book{ID: "mort", Status: entities.BookStatusActive}, nil)
+	bookRepo.On("GetByID", "reaper-man").Return(&entities.Book{ID: "reaper-man", Status: entities.BookStatusActive}, nil)
+	bookRepo.On("GetByID", "soul-music").Return(&entities.Book{ID: "soul-music", Status: entities.BookStatusActive, DeletedAt: &deletedAt}, nil)
+	bookRepo.On("GetByID", "hogfather").Return(&entities.Book{ID: "hogfather", Status: entities.BookStatusDraft}, nil)
+	bookRepo.On("GetByID", "missing").Return(nil, nil)
+	relatedRepo.On("ListByBookID", "mort").Return([]entities.RelatedBook{
+		{BookID: "mort", RelatedID: "soul-music", Rank: 1, Score: 9, SameAuthor: true, SameSeries: true, CoBorrowers: 1},
+		{BookID: "mort", RelatedID: "reaper-man", Rank: 2, Score: 8, SameAuthor: true, SameSeries: true},
+		{BookID: "mort", RelatedID: "hogfather", Rank: 3, Score: 8, SameAuthor: true, SameSeries: true},
+	}, nil)
+	useCase := NewRelatedBookUseCase(relatedRepo, bookRepo, entities.RelatedBookWeights{SameAuthor: 3, SameSeries: 5}, 10)
+
+	related, err := useCase.Related("mort")
+	require.NoError(t, err)
+	require.Len(t, related, 1)
+	assert.Equal(t, "reaper-man", related[0].Book.ID)
+	assert.Equal(t, []string{entities.RelatedSameAuthor, entities.RelatedSameSeries}, related[0].Reasons())
+
+	_, err = useCase.Related("missing")
+	assert.ErrorIs(t, err, domainerr.ErrBookNotFound)
+}
@@ -0,0 +1,345 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/cron"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// Report subscription defaults
+const (
+	weeklyStatsDays         = 7
+	defaultSubscriptionRuns = 20
+	// maxReportSummaryLines bounds the rows listed in the text summary of a report
+	maxReportSummaryLines = 20
+)
+
+// ReportMessage is a generated report ready to be delivered
+type ReportMessage struct {
+	Report  string
+	Subject string
+	// Body is a plain-text summary, used as the email body
+	Body string
+	// Data is the report itself, posted as JSON to webhooks
+	Data        interface{}
+	GeneratedAt time.Time
+}
+
+// ReportSender delivers generated reports to an email address or a webhook
+type ReportSender interface {
+	SendReport(ctx context.Context, channel, target string, message ReportMessage) error
+}
+
+// ReportSubscriptionUseCase manages scheduled report deliveries and runs the ones that are due
+type ReportSubscriptionUseCase struct {
+	subscriptionRepo repositories.ReportSubscriptionRepository
+	reportUseCase    *ReportUseCase
+	sender           ReportSender
+	// timezones tell the timezone of the tenant of a subscription, which its schedule is in
+	timezones *TimezoneUseCase
+	clock     clock.Clock
+}
+
+// NewReportSubscriptionUseCase creates a new report subscription use case
+func NewReportSubscriptionUseCase(subscriptionRepo repositories.ReportSubscriptionRepository, reportUseCase *ReportUseCase, sender ReportSender) *ReportSubscriptionUseCase {
+	return &ReportSubscriptionUseCase{
+		subscriptionRepo: subscriptionRepo,
+		reportUseCase:    reportUseCase,
+		sender:           sender,
+		clock:            clock.System{},
+	}
+}
+
+// SetClock replaces the clock schedules are evaluated with
+func (uc *ReportSubscriptionUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// SetTimezones makes schedules run in the timezone of the tenant of their subscription rather
+// than in UTC
+func (uc *ReportSubscriptionUseCase) SetTimezones(timezones *TimezoneUseCase) {
+	uc.timezones = timezones
+}
+
+// CreateSubscription subscribes a target to a report on behalf of an admin, on a schedule in the
+// timezone of the tenant of the subscription if it has one
+func (uc *ReportSubscriptionUseCase) CreateSubscription(adminID string, subscription *entities.ReportSubscription) error {
+	if adminID == "" {
+		return errors.New("admin ID is required")
+	}
+	if subscription.TenantID != nil && uc.timezones != nil {
+		if _, err := uc.timezones.Timezone(*subscription.TenantID, ""); err != nil {
+			return err
+		}
+	}
+	if err := uc.prepare(subscription); err != nil {
+		return err
+	}
+
+	subscription.CreatedBy = adminID
+	subscription.LastRunAt = nil
+	subscription.LastStatus = ""
+	return uc.subscriptionRepo.Create(subscription)
+}
+
+// ListSubscriptions retrieves all report subscriptions
+func (uc *ReportSubscriptionUseCase) ListSubscriptions() ([]entities.ReportSubscription, error) {
+	return uc.subscriptionRepo.List()
+}
+
+// GetSubscription retrieves a report subscription by ID
+func (uc *ReportSubscriptionUseCase) GetSubscription(id string) (*entities.ReportSubscription, error) {
+	if id == "" {
+		return nil, errors.New("subscription ID is required")
+	}
+
+	subscription, err := uc.subscriptionRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		return nil, domainerr.ErrSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+// UpdateSubscription replaces the report, delivery and schedule of a subscription, which stays
+// with its tenant
+func (uc *ReportSubscriptionUseCase) UpdateSubscription(id string, changes *entities.ReportSubscription) (*entities.ReportSubscription, error) {
+	existing, err := uc.GetSubscription(id)
+	if err != nil {
+		return nil, err
+	}
+	changes.TenantID = existing.TenantID
+	if err := uc.prepare(changes); err != nil {
+		return nil, err
+	}
+
+	existing.Report = changes.Report
+	existing.Channel = changes.Channel
+	existing.Target = changes.Target
+	existing.Schedule = changes.Schedule
+	existing.Enabled = changes.Enabled
+	existing.NextRunAt = changes.NextRunAt
+	if err := uc.subscriptionRepo.Update(existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// DeleteSubscription deletes a subscription and its run history
+func (uc *ReportSubscriptionUseCase) DeleteSubscription(id string) error {
+	if _, err := uc.GetSubscription(id); err != nil {
+		return err
+	}
+	return uc.subscriptionRepo.Delete(id)
+}
+
+// ListRuns retrieves the latest runs of a subscription, newest first
+func (uc *ReportSubscriptionUseCase) ListRuns(id string, limit int) ([]entities.ReportSubscriptionRun, error) {
+	if _, err := uc.GetSubscription(id); err != nil {
+		return nil, err
+	}
+	if limit < 1 {
+		limit = defaultSubscriptionRuns
+	}
+	return uc.subscriptionRepo.ListRuns(id, limit)
+}
+
+// RunNow delivers a subscription immediately without moving its schedule
+func (uc *ReportSubscriptionUseCase) RunNow(ctx context.Context, id string) (*entities.ReportSubscriptionRun, error) {
+	subscription, err := uc.GetSubscription(id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.run(ctx, subscription)
+}
+
+// RunDue delivers every subscription whose next run has come and returns how many ran.
+// The next run is moved forward before delivering, so a slow delivery is not picked up twice.
+func (uc *ReportSubscriptionUseCase) RunDue(ctx context.Context) (int, error) {
+	now := uc.clock.Now().UTC()
+	due, err := uc.subscriptionRepo.ListDue(now)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range due {
+		subscription := &due[i]
+		schedule, err := cron.Parse(subscription.Schedule)
+		if err != nil {
+			return i, err
+		}
+		location, err := uc.location(subscription)
+		if err != nil {
+			return i, err
+		}
+		subscription.NextRunAt = nextRun(schedule, now.In(location))
+		if err := uc.subscriptionRepo.Update(subscription); err != nil {
+			return i, err
+		}
+
+		if _, err := uc.run(ctx, subscription); err != nil {
+			return i, err
+		}
+	}
+	return len(due), nil
+}
+
+// run generates and delivers the report of a subscription and records the outcome.
+// Delivery failures are recorded in the run; only storage failures are returned.
+func (uc *ReportSubscriptionUseCase) run(ctx context.Context, subscription *entities.ReportSubscription) (*entities.ReportSubscriptionRun, error) {
+	run := &entities.ReportSubscriptionRun{
+		SubscriptionID: subscription.ID,
+		StartedAt:      uc.clock.Now().UTC(),
+	}
+
+	err := uc.deliver(ctx, subscription)
+	run.FinishedAt = uc.clock.Now().UTC()
+	run.Status = entities.ReportRunSucceeded
+	if err != nil {
+		run.Status = entities.ReportRunFailed
+		run.Error = err.Error()
+	}
+
+	if err := uc.subscriptionRepo.AddRun(run); err != nil {
+		return nil, err
+	}
+
+	subscription.LastRunAt = &run.StartedAt
+	subscription.LastStatus = run.Status
+	if err := uc.subscriptionRepo.Update(subscription); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// deliver generates the report of a subscription and sends it
+func (uc *ReportSubscriptionUseCase) deliver(ctx context.Context, subscription *entities.ReportSubscription) error {
+	if uc.sender == nil {
+		return errors.New("report delivery is not configured")
+	}
+
+	location, err := uc.location(subscription)
+	if err != nil {
+		return err
+	}
+	message, err := uc.generate(subscription.Report, location)
+	if err != nil {
+		return err
+	}
+	return uc.sender.SendReport(ctx, subscription.Channel, subscription.Target, *message)
+}
+
+// generate builds the message of a report, dating it in location
+func (uc *ReportSubscriptionUseCase) generate(report string, location *time.Location) (*ReportMessage, error) {
+	message := &ReportMessage{Report: report, GeneratedAt: uc.clock.Now().UTC()}
+
+	switch report {
+	case entities.ReportWeeklyStats:
+		stats, err := uc.reportUseCase.CatalogStats(weeklyStatsDays)
+		if err != nil {
+			return nil, err
+		}
+		message.Subject = "Weekly catalog stats"
+		message.Body = fmt.Sprintf("From %s to %s: %d books added, %d updated, %d deleted. The catalog holds %d books.",
+			stats.From.In(location).Format("2006-01-02"), stats.To.In(location).Format("2006-01-02"), stats.Added, stats.Updated, stats.Deleted, stats.TotalBooks)
+		message.Data = stats
+
+	case entities.ReportWeeding:
+		weeding, err := uc.reportUseCase.WeedingCandidates(0)
+		if err != nil {
+			return nil, err
+		}
+		lines := []string{fmt.Sprintf("%d books were not loaned since %s.", weeding.Total, weeding.Cutoff.In(location).Format("2006-01-02"))}
+		for i, candidate := range weeding.Items {
+			if i == maxReportSummaryLines {
+				lines = append(lines, fmt.Sprintf("... and %d more", weeding.Total-i))
+				break
+			}
+			lines = append(lines, fmt.Sprintf("- %s by %s (%s)", candidate.Title, candidate.Author, candidate.ISBN))
+		}
+		message.Subject = fmt.Sprintf("Weeding report: %d books", weeding.Total)
+		message.Body = strings.Join(lines, "\n")
+		message.Data = weeding
+
+	default:
+		return nil, fmt.Errorf("unknown report %q", report)
+	}
+
+	return message, nil
+}
+
+// prepare validates a subscription and computes its next run
+func (uc *ReportSubscriptionUseCase) prepare(subscription *entities.ReportSubscription) error {
+	subscription.Report = strings.TrimSpace(subscription.Report)
+	subscription.Channel = strings.TrimSpace(subscription.Channel)
+	subscription.Target = strings.TrimSpace(subscription.Target)
+	subscription.Schedule = strings.TrimSpace(subscription.Schedule)
+
+	if !entities.IsSubscribableReport(subscription.Report) {
+		return errors.New("report must be weekly_stats or weeding")
+	}
+
+	switch subscription.Channel {
+	case entities.ReportChannelEmail:
+		if _, err := mail.ParseAddress(subscription.Target); err != nil {
+			return errors.New("target must be an email address")
+		}
+	case entities.ReportChannelWebhook:
+		target, err := url.Parse(subscription.Target)
+		if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			return errors.New("target must be an http or https URL")
+		}
+	default:
+		return errors.New("channel must be email or webhook")
+	}
+
+	schedule, err := cron.Parse(subscription.Schedule)
+	if err != nil {
+		return fmt.Errorf("invalid schedule: %w", err)
+	}
+
+	location, err := uc.location(subscription)
+	if err != nil {
+		return err
+	}
+	subscription.NextRunAt = nil
+	if subscription.Enabled {
+		subscription.NextRunAt = nextRun(schedule, uc.clock.Now().In(location))
+		if subscription.NextRunAt == nil {
+			return errors.New("invalid schedule: it never runs")
+		}
+	}
+	return nil
+}
+
+// location returns the timezone the schedule of a subscription is in: that of its tenant, else
+// the default one, and UTC without timezones
+func (uc *ReportSubscriptionUseCase) location(subscription *entities.ReportSubscription) (*time.Location, error) {
+	if uc.timezones == nil {
+		return time.UTC, nil
+	}
+	return uc.timezones.Location(optionalID(subscription.TenantID), nil)
+}
+
+// nextRun returns the next run in UTC of a schedule after now, evaluated in the location of now,
+// or nil when it never runs again
+func nextRun(schedule *cron.Schedule, now time.Time) *time.Time {
+	next := schedule.Next(now)
+	if next.IsZero() {
+		return nil
+	}
+	next = next.UTC()
+	return &next
+}
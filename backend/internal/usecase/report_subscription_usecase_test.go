@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReportSubscriptionRepository is a mock implementation of ReportSubscriptionRepository
+type MockReportSubscriptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportSubscriptionRepository) Create(subscription *entities.ReportSubscription) error {
+	args := m.Called(subscription)
+	return args.Error(0)
+}
+
+func (m *MockReportSubscriptionRepository) GetByID(id string) (*entities.ReportSubscription, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ReportSubscription), args.Error(1)
+}
+
+func (m *MockReportSubscriptionRepository) List() ([]entities.ReportSubscription, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.ReportSubscription), args.Error(1)
+}
+
+func (m *MockReportSubscriptionRepository) Update(subscription *entities.ReportSubscription) error {
+	args := m.Called(subscription)
+	return args.Error(0)
+}
+
+func (m *MockReportSubscriptionRepository) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockReportSubscriptionRepository) ListDue(now time.Time) ([]entities.ReportSubscription, error) {
+	args := m.Called(now)
+	return args.Get(0).([]entities.ReportSubscription), args.Error(1)
+}
+
+func (m *MockReportSubscriptionRepository) AddRun(run *entities.ReportSubscriptionRun) error {
+	args := m.Called(run)
+	return args.Error(0)
+}
+
+func (m *MockReportSubscriptionRepository) ListRuns(subscriptionID string, limit int) ([]entities.ReportSubscriptionRun, error) {
+	args := m.Called(subscriptionID, limit)
+	return args.Get(0).([]entities.ReportSubscriptionRun), args.Error(1)
+}
+
+// recordingReportSender records the reports it is asked to send and fails when err is set
+type recordingReportSender struct {
+	err     error
+	targets []string
+	sent    []ReportMessage
+}
+
+func (s *recordingReportSender) SendReport(ctx context.Context, channel, target string, message ReportMessage) error {
+	s.targets = append(s.targets, channel+":"+target)
+	s.sent = append(s.sent, message)
+	return s.err
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestReportSubscriptionUseCase_CreateSubscription(t *testing.T) {
+	// Wednesday
+	now := time.Date(2024, time.January, 17, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		subscription  entities.ReportSubscription
+		expectedNext  *time.Time
+		expectedError string
+	}{
+		{
+			name:         "weekly webhook",
+			subscription: entities.ReportSubscription{Report: "weekly_stats", Channel: "webhook", Target: "https://example.com/hook", Schedule: "0 8 * * mon", Enabled: true},
+			expectedNext: timePtr(time.Date(2024, time.January, 22, 8, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:         "disabled email",
+			subscription: entities.ReportSubscription{Report: "weeding", Channel: "email", Target: "admin@example.com", Schedule: "@monthly"},
+		},
+		{
+			name:          "unknown report",
+			subscription:  entities.ReportSubscription{Report: "overdue", Channel: "email", Target: "admin@example.com", Schedule: "@daily"},
+			expectedError: "report must be weekly_stats or weeding",
+		},
+		{
+			name:          "invalid email",
+			subscription:  entities.ReportSubscription{Report: "weeding", Channel: "email", Target: "admin", Schedule: "@daily"},
+			expectedError: "target must be an email address",
+		},
+		{
+			name:          "invalid webhook",
+			subscription:  entities.ReportSubscription{Report: "weeding", Channel: "webhook", Target: "ftp://example.com", Schedule: "@daily"},
+			expectedError: "target must be an http or https URL",
+		},
+		{
+			name:          "invalid schedule",
+			subscription:  entities.ReportSubscription{Report: "weeding", Channel: "email", Target: "admin@example.com", Schedule: "0 25 * * *"},
+			expectedError: `invalid schedule: invalid hour: "25"`,
+		},
+		{
+			name:          "schedule that never runs",
+			subscription:  entities.ReportSubscription{Report: "weeding", Channel: "email", Target: "admin@example.com", Schedule: "0 0 30 2 *", Enabled: true},
+			expectedError: "invalid schedule: it never runs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockReportSubscriptionRepository{}
+			mockRepo.On("Create", mock.AnythingOfType("*entities.ReportSubscription")).Return(nil)
+
+			useCase := NewReportSubscriptionUseCase(mockRepo, nil, nil)
+			useCase.SetClock(clock.NewFixed(now))
+
+			subscription := tt.subscription
+			err := useCase.CreateSubscription("admin-1", &subscription)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "admin-1", subscription.CreatedBy)
+			assert.Equal(t, tt.expectedNext, subscription.NextRunAt)
+		})
+	}
+}
+
+func TestReportSubscriptionUseCase_RunDue(t *testing.T) {
+	now := time.Date(2024, time.January, 22, 8, 0, 30, 0, time.UTC)
+	added := now.AddDate(0, 0, -2)
+
+	mockRepo := &MockReportSubscriptionRepository{}
+	mockBookRepo := &MockBookRepository{}
+	mockRepo.On("ListDue", now).Return([]entities.ReportSubscription{
+		{ID: "weekly", Report: "weekly_stats", Channel: "webhook", Target: "https://example.com/hook", Schedule: "0 8 * * mon", Enabled: true},
+	}, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*entities.ReportSubscription")).Return(nil)
+	mockRepo.On("AddRun", mock.AnythingOfType("*entities.ReportSubscriptionRun")).Return(nil)
+	mockBookRepo.On("GetAll").Return([]entities.Book{
+		{ID: "new", CreatedAt: added, UpdatedAt: added},
+		{ID: "old", CreatedAt: now.AddDate(-1, 0, 0), UpdatedAt: now.AddDate(-1, 0, 0)},
+	}, nil)
+
+	reportUseCase := NewReportUseCase(mockBookRepo, nil)
+	reportUseCase.SetClock(clock.NewFixed(now))
+	sender := &recordingReportSender{}
+	useCase := NewReportSubscriptionUseCase(mockRepo, reportUseCase, sender)
+	useCase.SetClock(clock.NewFixed(now))
+
+	ran, err := useCase.RunDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, ran)
+
+	assert.Equal(t, []string{"webhook:https://example.com/hook"}, sender.targets)
+	assert.Equal(t, "From 2024-01-15 to 2024-01-22: 1 books added, 0 updated, 0 deleted. The catalog holds 2 books.", sender.sent[0].Body)
+
+	run := mockRepo.Calls[2].Arguments.Get(0).(*entities.ReportSubscriptionRun)
+	assert.Equal(t, entities.ReportRunSucceeded, run.Status)
+
+	subscription := mockRepo.Calls[3].Arguments.Get(0).(*entities.ReportSubscription)
+	assert.Equal(t, time.Date(2024, time.January, 29, 8, 0, 0, 0, time.UTC), *subscription.NextRunAt)
+	assert.Equal(t, now, *subscription.LastRunAt)
+	assert.Equal(t, entities.ReportRunSucceeded, subscription.LastStatus)
+}
+
+func TestReportSubscriptionUseCase_ScheduleInTenantTimezone(t *testing.T) {
+	// Monday 17:00 in Tokyo
+	now := time.Date(2024, time.January, 22, 8, 0, 0, 0, time.UTC)
+	tenantID := "tenant-1"
+
+	mockRepo := &MockReportSubscriptionRepository{}
+	mockRepo.On("Create", mock.AnythingOfType("*entities.ReportSubscription")).Return(nil)
+	tenantRepo := &MockTenantRepository{}
+	tenantRepo.On("GetByID", "tenant-1").Return(&entities.Tenant{ID: "tenant-1", Timezone: "Asia/Tokyo"}, nil)
+	tenantRepo.On("GetByID", "missing").Return(nil, nil)
+	useCase := NewReportSubscriptionUseCase(mockRepo, nil, nil)
+	useCase.SetClock(clock.NewFixed(now))
+	useCase.SetTimezones(NewTimezoneUseCase(tenantRepo, &MockBranchRepository{}, time.UTC))
+
+	subscription := &entities.ReportSubscription{TenantID: &tenantID, Report: "weekly_stats", Channel: "webhook", Target: "https://example.com/hook", Schedule: "0 8 * * mon", Enabled: true}
+	assert.NoError(t, useCase.CreateSubscription("admin-1", subscription))
+	// 8:00 next Monday in Tokyo is still Sunday in UTC
+	assert.Equal(t, time.Date(2024, time.January, 28, 23, 0, 0, 0, time.UTC), *subscription.NextRunAt)
+
+	// Without a tenant the schedule is in the default timezone
+	subscription = &entities.ReportSubscription{Report: "weekly_stats", Channel: "webhook", Target: "https://example.com/hook", Schedule: "0 8 * * mon", Enabled: true}
+	assert.NoError(t, useCase.CreateSubscription("admin-1", subscription))
+	assert.Equal(t, time.Date(2024, time.January, 29, 8, 0, 0, 0, time.UTC), *subscription.NextRunAt)
+
+	missing := "missing"
+	subscription = &entities.ReportSubscription{TenantID: &missing, Report: "weekly_stats", Channel: "webhook", Target: "https://example.com/hook", Schedule: "0 8 * * mon", Enabled: true}
+	assert.ErrorIs(t, useCase.CreateSubscription("admin-1", subscription), domainerr.ErrTenantNotFound)
+}
+
+func TestReportSubscriptionUseCase_RunNowRecordsFailures(t *testing.T) {
+	now := time.Date(2024, time.January, 22, 8, 0, 0, 0, time.UTC)
+	next := now.AddDate(0, 0, 7)
+
+	mockRepo := &MockReportSubscriptionRepository{}
+	mockBookRepo := &MockBookRepository{}
+	mockRepo.On("GetByID", "weeding").Return(&entities.ReportSubscription{ID: "weeding", Report: "weeding", Channel: "email", Target: "admin@example.com", NextRunAt: &next}, nil)
+	mockRepo.On("Update", mock.AnythingOfType("*entities.ReportSubscription")).Return(nil)
+	mockRepo.On("AddRun", mock.AnythingOfType("*entities.ReportSubscriptionRun")).Return(nil)
+	mockBookRepo.On("GetAll").Return([]entities.Book{}, nil)
+
+	reportUseCase := NewReportUseCase(mockBookRepo, nil)
+	useCase := NewReportSubscriptionUseCase(mockRepo, reportUseCase, &recordingReportSender{err: errors.New("smtp unavailable")})
+	useCase.SetClock(clock.NewFixed(now))
+
+	run, err := useCase.RunNow(context.Background(), "weeding")
+	assert.NoError(t, err)
+	assert.Equal(t, entities.ReportRunFailed, run.Status)
+	assert.Equal(t, "smtp unavailable", run.Error)
+
+	subscription := mockRepo.Calls[len(mockRepo.Calls)-1].Arguments.Get(0).(*entities.ReportSubscription)
+	assert.Equal(t, next, *subscription.NextRunAt)
+	assert.Equal(t, entities.ReportRunFailed, subscription.LastStatus)
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// Weeding report defaults
+const (
+	defaultWeedingYears   = 5
+	DefaultReportPageSize = 50
+)
+
+// BookCirculation summarizes the holdings and loan history of a book
+type BookCirculation struct {
+	Copies       int
+	LastLoanedAt *time.Time
+}
+
+// CirculationSource reports the circulation of books to the reports
+type CirculationSource interface {
+	BookCirculation(bookID string) (BookCirculation, error)
+}
+
+// ReportUseCase builds reports that help librarians manage the collection
+type ReportUseCase struct {
+	bookRepo    repositories.BookRepository
+	auditRepo   repositories.AuditRepository
+	circulation CirculationSource
+	clock       clock.Clock
+}
+
+// NewReportUseCase creates a new report use case
+func NewReportUseCase(bookRepo repositories.BookRepository, auditRepo repositories.AuditRepository) *ReportUseCase {
+	return &ReportUseCase{
+		bookRepo:  bookRepo,
+		auditRepo: auditRepo,
+		clock:     clock.System{},
+	}
+}
+
+// SetCirculationSource enables loan-based reports. Without a source, every book counts as
+// a single copy that was never loaned.
+func (uc *ReportUseCase) SetCirculationSource(source CirculationSource) {
+	uc.circulation = source
+}
+
+// SetClock replaces the clock the report cutoffs are computed from
+func (uc *ReportUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// WeedingReport returns one page of the books never loaned or not loaned in the last years years
+func (uc *ReportUseCase) WeedingReport(years, page, pageSize int) (*entities.WeedingReport, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultReportPageSize
+	}
+
+	report, err := uc.WeedingCandidates(years)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := report.Items
+	report.Items = []entities.WeedingCandidate{}
+	report.Page = page
+	report.PageSize = pageSize
+
+	start := (page - 1) * pageSize
+	if start < len(candidates) {
+		end := start + pageSize
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+		report.Items = candidates[start:end]
+	}
+
+	return report, nil
+}
+
+// WeedingCandidates returns every book never loaned or not loaned in the last years years, stalest first.
+// Books added to the catalog after the cutoff have not had the chance to circulate and are left out.
+func (uc *ReportUseCase) WeedingCandidates(years int) (*entities.WeedingReport, error) {
+	if years == 0 {
+		years = defaultWeedingYears
+	}
+	if years < 1 {
+		return nil, errors.New("years must be at least 1")
+	}
+
+	cutoff := uc.clock.Now().UTC().AddDate(-years, 0, 0)
+
+	books, err := uc.bookRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []entities.WeedingCandidate{}
+	for _, book := range books {
+		if book.DeletedAt != nil || book.CreatedAt.After(cutoff) {
+			continue
+		}
+
+		circulation, err := uc.bookCirculation(book.ID)
+		if err != nil {
+			return nil, err
+		}
+		if circulation.LastLoanedAt != nil && circulation.LastLoanedAt.After(cutoff) {
+			continue
+		}
+
+		lastActivity, err := uc.lastActivity(book, circulation)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, entities.WeedingCandidate{
+			BookID:         book.ID,
+			Title:          book.Title,
+			Author:         book.Author,
+			Year:           book.Year,
+			ISBN:           book.ISBN,
+			Copies:         circulation.Copies,
+			LastLoanedAt:   circulation.LastLoanedAt,
+			LastActivityAt: lastActivity,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if !candidates[i].LastActivityAt.Equal(candidates[j].LastActivityAt) {
+			return candidates[i].LastActivityAt.Before(candidates[j].LastActivityAt)
+		}
+		return candidates[i].Title < candidates[j].Title
+	})
+
+	return &entities.WeedingReport{
+		Years:  years,
+		Cutoff: cutoff,
+		Items:  candidates,
+		Total:  len(candidates),
+	}, nil
+}
+
+// CatalogStats counts the books added, updated and deleted in the last days days
+func (uc *ReportUseCase) CatalogStats(days int) (*entities.CatalogStats, error) {
+	if days < 1 {
+		return nil, errors.New("days must be at least 1")
+	}
+
+	to := uc.clock.Now().UTC()
+	from := to.AddDate(0, 0, -days)
+
+	books, err := uc.bookRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &entities.CatalogStats{From: from, To: to}
+	for _, book := range books {
+		if book.DeletedAt != nil {
+			if !book.DeletedAt.Before(from) {
+				stats.Deleted++
+			}
+			continue
+		}
+
+		stats.TotalBooks++
+		switch {
+		case !book.CreatedAt.Before(from):
+			stats.Added++
+		case !book.UpdatedAt.Before(from):
+			stats.Updated++
+		}
+	}
+	return stats, nil
+}
+
+// bookCirculation asks the circulation source about a book, defaulting to one copy never loaned
+func (uc *ReportUseCase) bookCirculation(bookID string) (BookCirculation, error) {
+	if uc.circulation == nil {
+		return BookCirculation{Copies: 1}, nil
+	}
+	return uc.circulation.BookCirculation(bookID)
+}
+
+// lastActivity is the latest of the book's last loan, last update and last audit entry
+func (uc *ReportUseCase) lastActivity(book entities.Book, circulation BookCirculation) (time.Time, error) {
+	latest := book.UpdatedAt
+	if circulation.LastLoanedAt != nil && circulation.LastLoanedAt.After(latest) {
+		latest = *circulation.LastLoanedAt
+	}
+
+	if uc.auditRepo != nil {
+		entries, err := uc.auditRepo.ListByEntity("book", book.ID)
+		if err != nil {
+			return time.Time{}, err
+		}
+		for _, entry := range entries {
+			if entry.CreatedAt.After(latest) {
+				latest = entry.CreatedAt
+			}
+		}
+	}
+
+	return latest.UTC(), nil
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// staticCirculationSource returns fixed circulation per book
+type staticCirculationSource map[string]BookCirculation
+
+func (s staticCirculationSource) BookCirculation(bookID string) (BookCirculation, error) {
+	return s[bookID], nil
+}
+
+func TestReportUseCase_WeedingReport(t *testing.T) {
+	year := func(y int) time.Time { return time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC) }
+	deletedAt := year(2023)
+
+	bookRepo := &MockBookRepository{}
+	bookRepo.On("GetAll").Return([]entities.Book{
+		{ID: "never-loaned", Title: "Never Loaned", CreatedAt: year(2010), UpdatedAt: year(2012)},
+		{ID: "stale", Title: "Stale", CreatedAt: year(2005), UpdatedAt: year(2005)},
+		{ID: "popular", Title: "Popular", CreatedAt: year(2005), UpdatedAt: year(2005)},
+		{ID: "new", Title: "New", CreatedAt: year(2023), UpdatedAt: year(2023)},
+		{ID: "deleted", Title: "Deleted", CreatedAt: year(2005), UpdatedAt: year(2005), DeletedAt: &deletedAt},
+	}, nil)
+
+	auditRepo := &MockAuditRepository{}
+	auditRepo.On("ListByEntity", "book", "never-loaned").Return([]entities.AuditEntry{{CreatedAt: year(2014)}}, nil)
+	auditRepo.On("ListByEntity", "book", mock.Anything).Return([]entities.AuditEntry{}, nil)
+
+	staleLoan := year(2008)
+	recentLoan := year(2022)
+	circulation := staticCirculationSource{
+		"never-loaned": {Copies: 2},
+		"stale":        {Copies: 1, LastLoanedAt: &staleLoan},
+		"popular":      {Copies: 3, LastLoanedAt: &recentLoan},
+	}
+
+	useCase := NewReportUseCase(bookRepo, auditRepo)
+	useCase.SetCirculationSource(circulation)
+	useCase.SetClock(clock.NewFixed(year(2024)))
+
+	report, err := useCase.WeedingReport(5, 1, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, year(2019), report.Cutoff)
+	assert.Equal(t, 2, report.Total)
+	assert.Equal(t, "stale", report.Items[0].BookID)
+	assert.Equal(t, year(2008), report.Items[0].LastActivityAt)
+	assert.Equal(t, "never-loaned", report.Items[1].BookID)
+	assert.Equal(t, 2, report.Items[1].Copies)
+	assert.Nil(t, report.Items[1].LastLoanedAt)
+	assert.Equal(t, year(2014), report.Items[1].LastActivityAt)
+
+	page, err := useCase.WeedingReport(5, 2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, page.Total)
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, "never-loaned", page.Items[0].BookID)
+
+	_, err = useCase.WeedingReport(-1, 1, 10)
+	assert.EqualError(t, err, "years must be at least 1")
+}
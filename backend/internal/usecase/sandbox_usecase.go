@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// SandboxUseCase keeps a sandbox deployment, where integrators try the API out, to a known
+// dataset: the baseline, which resets restore
+type SandboxUseCase struct {
+	sandboxRepo repositories.SandboxRepository
+	clock       clock.Clock
+}
+
+// NewSandboxUseCase creates a new sandbox use case
+func NewSandboxUseCase(sandboxRepo repositories.SandboxRepository) *SandboxUseCase {
+	return &SandboxUseCase{
+		sandboxRepo: sandboxRepo,
+		clock:       clock.System{},
+	}
+}
+
+// SetClock replaces the clock baselines are dated with
+func (uc *SandboxUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// SaveBaseline makes the current data the baseline resets restore
+func (uc *SandboxUseCase) SaveBaseline() (*entities.SandboxBaseline, error) {
+	tables, err := uc.sandboxRepo.SaveBaseline()
+	if err != nil {
+		return nil, err
+	}
+	return &entities.SandboxBaseline{Tables: tables, SavedAt: uc.clock.Now().UTC()}, nil
+}
+
+// Reset restores the baseline, discarding every change made since, and returns how many tables it
+// restored. Without a baseline, the first reset saves the current data as the baseline instead, so
+// a sandbox loaded with a dataset keeps it from then on.
+func (uc *SandboxUseCase) Reset() (restored int, saved bool, err error) {
+	exists, err := uc.sandboxRepo.HasBaseline()
+	if err != nil {
+		return 0, false, err
+	}
+	if !exists {
+		tables, err := uc.sandboxRepo.SaveBaseline()
+		return tables, true, err
+	}
+	restored, err = uc.sandboxRepo.Restore()
+	return restored, false, err
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockSandboxRepository is a mock implementation of SandboxRepository
+type MockSandboxRepository struct {
+	mock.Mock
+}
+
+func (m *MockSandboxRepository) HasBaseline() (bool, error) {
+	args := m.Called()
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSandboxRepository) SaveBaseline() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSandboxRepository) Restore() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func TestSandboxUseCase_Reset(t *testing.T) {
+	// The first reset keeps the data the sandbox was loaded with as its baseline
+	repo := &MockSandboxRepository{}
+	repo.On("HasBaseline").Return(false, nil)
+	repo.On("SaveBaseline").Return(42, nil)
+	tables, saved, err := NewSandboxUseCase(repo).Reset()
+	require.NoError(t, err)
+	assert.Equal(t, 42, tables)
+	assert.True(t, saved)
+	repo.AssertNotCalled(t, "Restore")
+
+	// Later resets restore it
+	repo = &MockSandboxRepository{}
+	repo.On("HasBaseline").Return(true, nil)
+	repo.On("Restore").Return(41, nil)
+	tables, saved, err = NewSandboxUseCase(repo).Reset()
+	require.NoError(t, err)
+	assert.Equal(t, 41, tables)
+	assert.False(t, saved)
+	repo.AssertNotCalled(t, "SaveBaseline")
+}
+
+func TestSandboxUseCase_SaveBaseline(t *testing.T) {
+	repo := &MockSandboxRepository{}
+	repo.On("SaveBaseline").Return(42, nil)
+	uc := NewSandboxUseCase(repo)
+	now := time.Date(2026, 10, 16, 4, 0, 0, 0, time.UTC)
+	uc.SetClock(clock.NewFixed(now))
+
+	baseline, err := uc.SaveBaseline()
+	require.NoError(t, err)
+	assert.Equal(t, &entities.SandboxBaseline{Tables: 42, SavedAt: now}, baseline)
+}
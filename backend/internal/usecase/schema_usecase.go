@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// SchemaUseCase describes the fields of entities from their struct tags and validation
+type SchemaUseCase struct {
+	ruleRepo  repositories.ValidationRuleRepository
+	fieldRepo repositories.MetadataFieldRepository
+	// profiles are the thresholds of the built-in validation, by tenant
+	profiles entities.ValidationProfiles
+}
+
+// NewSchemaUseCase creates a new schema use case
+func NewSchemaUseCase(ruleRepo repositories.ValidationRuleRepository, fieldRepo repositories.MetadataFieldRepository) *SchemaUseCase {
+	return &SchemaUseCase{
+		ruleRepo:  ruleRepo,
+		fieldRepo: fieldRepo,
+	}
+}
+
+// SetValidationProfiles sets the thresholds of the built-in validation the schema reports, by tenant
+func (uc *SchemaUseCase) SetValidationProfiles(profiles entities.ValidationProfiles) {
+	uc.profiles = profiles
+}
+
+// BookSchema describes the fields of books: the types and keys their struct tags declare, the
+// bounds of the built-in validation of the tenant, the enabled validation rules and the metadata
+// fields of the tenant, if any. Filters and sorts are left to the caller serving the searches.
+func (uc *SchemaUseCase) BookSchema(tenantID string) (*entities.EntitySchema, error) {
+	schema := &entities.EntitySchema{
+		Entity:   "book",
+		Fields:   describeFields(reflect.TypeOf(entities.Book{})),
+		Metadata: []entities.FieldSchema{},
+		Sorts:    []string{},
+	}
+	profile := uc.profiles.For(tenantID)
+	for i := range schema.Fields {
+		constrainBookField(&schema.Fields[i], profile)
+	}
+
+	if tenantID != "" {
+		fields, err := uc.fieldRepo.ListByTenant(tenantID)
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range fields {
+			schema.Metadata = append(schema.Metadata, describeMetadataField(field))
+		}
+	}
+
+	rules, err := uc.ruleRepo.ListEnabled()
+	if err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		attachRule(schema, &rules[i])
+	}
+	return schema, nil
+}
+
+// describeFields describes the JSON fields of a struct from their Go types and tags
+func describeFields(t reflect.Type) []entities.FieldSchema {
+	fields := make([]entities.FieldSchema, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" || !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		field := entities.FieldSchema{Name: name}
+		fieldType := sf.Type
+		if fieldType.Kind() == reflect.Pointer {
+			field.Nullable = true
+			fieldType = fieldType.Elem()
+		}
+		field.Type, field.Format = jsonType(fieldType)
+
+		for _, setting := range strings.Split(sf.Tag.Get("gorm"), ";") {
+			key, value, _ := strings.Cut(setting, ":")
+			switch key {
+			case "primaryKey", "autoCreateTime", "autoUpdateTime":
+				field.ReadOnly = true
+			case "uniqueIndex":
+				// Named unique indexes span several columns
+				field.Unique = field.Unique || value == ""
+			case "type":
+				if value == "uuid" {
+					field.Format = "uuid"
+				}
+			case "size":
+				if size, err := strconv.Atoi(value); err == nil {
+					field.MaxLength = &size
+				}
+			}
+		}
+		if sf.Tag.Get("schema") == "read_only" {
+			field.ReadOnly = true
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// jsonType returns the JSON Schema type and format of a Go type
+func jsonType(t reflect.Type) (string, string) {
+	if t == reflect.TypeOf(time.Time{}) {
+		return entities.FieldTypeString, "date-time"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return entities.FieldTypeBoolean, ""
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return entities.FieldTypeInteger, ""
+	case reflect.Float32, reflect.Float64:
+		return entities.FieldTypeNumber, ""
+	case reflect.Map, reflect.Struct:
+		return entities.FieldTypeObject, ""
+	case reflect.Slice:
+		return entities.FieldTypeArray, ""
+	}
+	return entities.FieldTypeString, ""
+}
+
+// constrainBookField adds the bounds validateBook checks with a profile to a field of books
+func constrainBookField(field *entities.FieldSchema, profile entities.ValidationProfile) {
+	switch field.Name {
+	case "title":
+		field.Required = true
+		field.MinLength = intPtr(profile.MinTitleLength)
+		if profile.MaxTitleLength != 0 {
+			field.MaxLength = intPtr(profile.MaxTitleLength)
+		}
+	case "author":
+		field.Required = true
+		field.MinLength = intPtr(1)
+	case "year":
+		field.Required = true
+		field.Minimum = intPtr(profile.MinYear)
+		field.Maximum = intPtr(profile.MaxYear)
+	case "isbn":
+		field.Required = true
+		field.MinLength = intPtr(entities.MinISBNLength)
+		field.MaxLength = intPtr(entities.MaxISBNLength)
+		if profile.ISBNCheck == entities.ISBNCheckChecksum {
+			field.Description = "ISBN-10 or ISBN-13 with a valid check digit"
+		}
+	case "status":
+		field.Enum = []string{entities.BookStatusDraft, entities.BookStatusActive, entities.BookStatusArchived}
+	case "metadata":
+		field.Description = "Custom fields as string, number or boolean values, described under metadata"
+	}
+}
+
+// describeMetadataField describes a metadata field of a tenant
+func describeMetadataField(field entities.MetadataField) entities.FieldSchema {
+	schema := entities.FieldSchema{
+		Name:        field.Key,
+		Type:        field.Type,
+		Description: field.Label,
+		Required:    field.Required,
+	}
+	if field.Type == entities.MetadataTypeString {
+		schema.MaxLength = intPtr(maxMetadataValueLength)
+	}
+	return schema
+}
+
+// attachRule lists a validation rule on the field it checks; rules on metadata keys the tenant
+// has not defined are listed on the metadata object
+func attachRule(schema *entities.EntitySchema, rule *entities.ValidationRule) {
+	fieldRule := entities.FieldRule{
+		RuleID:   rule.ID,
+		Name:     rule.Name,
+		Kind:     rule.Kind,
+		Category: rule.Category,
+		Message:  ruleMessage(rule),
+	}
+
+	name := ""
+	switch rule.Kind {
+	case entities.ValidationRuleISBNPrefix:
+		name = "isbn"
+	case entities.ValidationRuleYearRange:
+		name = "year"
+	case entities.ValidationRuleRequiredMetadata:
+		for i := range schema.Metadata {
+			if field := &schema.Metadata[i]; field.Name == rule.MetadataKey {
+				field.Rules = append(field.Rules, fieldRule)
+				field.Required = field.Required || rule.Category == ""
+				return
+			}
+		}
+		name = "metadata"
+	}
+	for i := range schema.Fields {
+		if field := &schema.Fields[i]; field.Name == name {
+			field.Rules = append(field.Rules, fieldRule)
+			return
+		}
+	}
+}
+
+// intPtr returns a pointer to n
+func intPtr(n int) *int {
+	return &n
+}
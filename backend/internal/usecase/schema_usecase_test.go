@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"testing"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// schemaField returns the field of a schema with the name
+func schemaField(t *testing.T, fields []entities.FieldSchema, name string) entities.FieldSchema {
+	t.Helper()
+	for _, field := range fields {
+		if field.Name == name {
+			return field
+		}
+	}
+	t.Fatalf("no field %s", name)
+	return entities.FieldSchema{}
+}
+
+func TestSchemaUseCase_BookSchema(t *testing.T) {
+	ruleRepo := new(MockValidationRuleRepository)
+	fieldRepo := new(MockMetadataFieldRepository)
+	ruleRepo.On("ListEnabled").Return([]entities.ValidationRule{
+		{ID: "rule-1", Name: "Comics from 1930", Kind: entities.ValidationRuleYearRange, Category: "comics", MinYear: intPtr(1930), Enabled: true},
+		{ID: "rule-2", Name: "Shelved", Kind: entities.ValidationRuleRequiredMetadata, MetadataKey: "shelf_code", Enabled: true},
+		{ID: "rule-3", Name: "Cataloged", Kind: entities.ValidationRuleRequiredMetadata, MetadataKey: "catalog_number", Enabled: true},
+	}, nil)
+	fieldRepo.On("ListByTenant", "tenant-a").Return([]entities.MetadataField{
+		{TenantID: "tenant-a", Key: "shelf_code", Label: "Shelf code", Type: entities.MetadataTypeString},
+		{TenantID: "tenant-a", Key: "signed", Type: entities.MetadataTypeBoolean},
+	}, nil)
+
+	schema, err := NewSchemaUseCase(ruleRepo, fieldRepo).BookSchema("tenant-a")
+	require.NoError(t, err)
+	assert.Equal(t, "book", schema.Entity)
+
+	// Fields come from the JSON names of books, hidden ones left out
+	names := make([]string, len(schema.Fields))
+	for i, field := range schema.Fields {
+		names[i] = field.Name
+	}
+	assert.Equal(t, []string{"id", "title", "author", "year", "isbn", "slug", "description", "publisher_id", "series_id", "series_position", "work_id", "status", "branch_id", "metadata", "cover_palette", "publish_at", "created_at", "updated_at", "deleted_at"}, names)
+
+	id := schemaField(t, schema.Fields, "id")
+	assert.Equal(t, "uuid", id.Format)
+	assert.True(t, id.ReadOnly)
+
+	year := schemaField(t, schema.Fields, "year")
+	assert.Equal(t, entities.FieldTypeInteger, year.Type)
+	assert.True(t, year.Required)
+	assert.Equal(t, 1000, *year.Minimum)
+	assert.Equal(t, 2100, *year.Maximum)
+	assert.Equal(t, []entities.FieldRule{{RuleID: "rule-1", Name: "Comics from 1930", Kind: entities.ValidationRuleYearRange, Category: "comics", Message: "book year must be 1930 or later for category comics"}}, year.Rules)
+
+	isbn := schemaField(t, schema.Fields, "isbn")
+	assert.True(t, isbn.Unique)
+	assert.Equal(t, 10, *isbn.MinLength)
+	assert.Equal(t, 13, *isbn.MaxLength)
+
+	seriesPosition := schemaField(t, schema.Fields, "series_position")
+	assert.True(t, seriesPosition.Nullable)
+	assert.False(t, seriesPosition.Unique, "unique together with the series only")
+
+	assert.True(t, schemaField(t, schema.Fields, "slug").ReadOnly)
+	assert.Equal(t, "date-time", schemaField(t, schema.Fields, "publish_at").Format)
+	assert.Equal(t, []string{"draft", "active", "archived"}, schemaField(t, schema.Fields, "status").Enum)
+	assert.Equal(t, "Cataloged", schemaField(t, schema.Fields, "metadata").Rules[0].Name, "rules on undefined keys stay on the metadata object")
+
+	shelfCode := schemaField(t, schema.Metadata, "shelf_code")
+	assert.True(t, shelfCode.Required, "required by a rule for every category")
+	assert.Equal(t, 1000, *shelfCode.MaxLength)
+	assert.Equal(t, "Shelf code", shelfCode.Description)
+	assert.Equal(t, entities.FieldTypeBoolean, schemaField(t, schema.Metadata, "signed").Type)
+}
+
+func TestSchemaUseCase_BookSchemaWithoutTenant(t *testing.T) {
+	ruleRepo := new(MockValidationRuleRepository)
+	fieldRepo := new(MockMetadataFieldRepository)
+	ruleRepo.On("ListEnabled").Return([]entities.ValidationRule{}, nil)
+
+	schema, err := NewSchemaUseCase(ruleRepo, fieldRepo).BookSchema("")
+	require.NoError(t, err)
+	assert.Empty(t, schema.Metadata)
+	fieldRepo.AssertNotCalled(t, "ListByTenant", "")
+}
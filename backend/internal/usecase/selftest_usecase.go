@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+)
+
+// selfTestTimeout bounds each check of a self-test
+const selfTestTimeout = 5 * time.Second
+
+// SelfTestProbe is a check of a self-test: what it exercises and how
+type SelfTestProbe struct {
+	Name string
+	// Check returns what passed, or the error failing the check; a SkippedCheck error skips it
+	Check func(ctx context.Context) (string, error)
+}
+
+// SkippedCheck is returned by the checks of what the deployment does not use, naming why
+type SkippedCheck string
+
+func (s SkippedCheck) Error() string {
+	return string(s)
+}
+
+// SelfTestUseCase checks that a deployment reaches everything it depends on, for post-deploy
+// verification
+type SelfTestUseCase struct {
+	probes []SelfTestProbe
+	clock  clock.Clock
+}
+
+// NewSelfTestUseCase creates a new self-test use case running probes
+func NewSelfTestUseCase(probes []SelfTestProbe) *SelfTestUseCase {
+	return &SelfTestUseCase{
+		probes: probes,
+		clock:  clock.System{},
+	}
+}
+
+// SetClock replaces the clock self-tests are timed with
+func (uc *SelfTestUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// Run runs every check at once, each within selfTestTimeout, and reports them in the order of the
+// probes
+func (uc *SelfTestUseCase) Run(ctx context.Context) *entities.SelfTestReport {
+	report := &entities.SelfTestReport{
+		Passed: true,
+		Checks: make([]entities.SelfTestCheck, len(uc.probes)),
+		RanAt:  uc.clock.Now().UTC(),
+	}
+	var wg sync.WaitGroup
+	for i, probe := range uc.probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = uc.check(ctx, probe)
+		}()
+	}
+	wg.Wait()
+
+	for _, check := range report.Checks {
+		if check.Status == entities.SelfTestFail {
+			report.Passed = false
+		}
+	}
+	return report
+}
+
+// check runs a probe
+func (uc *SelfTestUseCase) check(ctx context.Context, probe SelfTestProbe) entities.SelfTestCheck {
+	ctx, cancel := context.WithTimeout(ctx, selfTestTimeout)
+	defer cancel()
+
+	started := uc.clock.Now()
+	detail, err := probe.Check(ctx)
+	check := entities.SelfTestCheck{
+		Name:       probe.Name,
+		Status:     entities.SelfTestPass,
+		Detail:     detail,
+		DurationMS: uc.clock.Now().Sub(started).Milliseconds(),
+	}
+	var skipped SkippedCheck
+	switch {
+	case errors.As(err, &skipped):
+		check.Status, check.Detail = entities.SelfTestSkip, skipped.Error()
+	case err != nil:
+		check.Status, check.Detail = entities.SelfTestFail, err.Error()
+	}
+	return check
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelfTestUseCase_Run(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	uc := NewSelfTestUseCase([]SelfTestProbe{
+		{Name: "database", Check: func(ctx context.Context) (string, error) { return "connected", nil }},
+		{Name: "cache", Check: func(ctx context.Context) (string, error) { return "", SkippedCheck("memory backend") }},
+		{Name: "email", Check: func(ctx context.Context) (string, error) {
+			_, ok := ctx.Deadline()
+			assert.True(t, ok, "checks are bounded")
+			return "", errors.New("dial tcp: connection refused")
+		}},
+	})
+	uc.SetClock(clock.NewFixed(now))
+
+	assert.Equal(t, &entities.SelfTestReport{
+		Passed: false,
+		Checks: []entities.SelfTestCheck{
+			{Name: "database", Status: entities.SelfTestPass, Detail: "connected"},
+			{Name: "cache", Status: entities.SelfTestSkip, Detail: "memory backend"},
+			{Name: "email", Status: entities.SelfTestFail, Detail: "dial tcp: connection refused"},
+		},
+		RanAt: now,
+	}, uc.Run(context.Background()))
+
+	// Skipped checks do not fail the self-test
+	uc = NewSelfTestUseCase([]SelfTestProbe{
+		{Name: "cache", Check: func(ctx context.Context) (string, error) { return "", SkippedCheck("memory backend") }},
+	})
+	assert.True(t, uc.Run(context.Background()).Passed)
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// SeriesUseCase handles book series business logic
+type SeriesUseCase struct {
+	seriesRepo repositories.SeriesRepository
+	bookRepo   repositories.BookRepository
+}
+
+// NewSeriesUseCase creates a new series use case
+func NewSeriesUseCase(seriesRepo repositories.SeriesRepository, bookRepo repositories.BookRepository) *SeriesUseCase {
+	return &SeriesUseCase{
+		seriesRepo: seriesRepo,
+		bookRepo:   bookRepo,
+	}
+}
+
+// CreateSeries creates a new series, ordered by position unless told otherwise
+func (uc *SeriesUseCase) CreateSeries(series *entities.Series) error {
+	series.Name = strings.TrimSpace(series.Name)
+	if series.Name == "" {
+		return errors.New("series name is required")
+	}
+
+	switch series.Ordering {
+	case "":
+		series.Ordering = entities.SeriesOrderingPosition
+	case entities.SeriesOrderingPosition, entities.SeriesOrderingPublication:
+	default:
+		return errors.New("series ordering must be position or publication")
+	}
+
+	existing, err := uc.seriesRepo.FindByName(series.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return domainerr.ErrDuplicateSeriesName
+	}
+
+	return uc.seriesRepo.Create(series)
+}
+
+// GetSeries retrieves a series by ID
+func (uc *SeriesUseCase) GetSeries(id string) (*entities.Series, error) {
+	if id == "" {
+		return nil, errors.New("series ID is required")
+	}
+
+	return uc.seriesRepo.GetByID(id)
+}
+
+// ListSeries retrieves all series
+func (uc *SeriesUseCase) ListSeries() ([]entities.Series, error) {
+	return uc.seriesRepo.GetAll()
+}
+
+// ListBooks retrieves the live books of a series in the series' ordering
+func (uc *SeriesUseCase) ListBooks(id string) ([]entities.Book, error) {
+	series, err := uc.GetSeries(id)
+	if err != nil {
+		return nil, err
+	}
+	if series == nil {
+		return nil, domainerr.ErrSeriesNotFound
+	}
+
+	books, err := liveBooks(uc.bookRepo.FindBySeries(id))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(books, func(i, j int) bool {
+		if series.Ordering == entities.SeriesOrderingPublication && books[i].Year != books[j].Year {
+			return books[i].Year < books[j].Year
+		}
+		return positionLess(books[i].SeriesPosition, books[j].SeriesPosition)
+	})
+	return books, nil
+}
+
+// positionLess orders series positions ascending with unpositioned books last
+func positionLess(a, b *int) bool {
+	if a == nil || b == nil {
+		return a != nil && b == nil
+	}
+	return *a < *b
+}
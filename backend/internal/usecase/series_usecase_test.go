@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"testing"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSeriesRepository is a mock implementation of SeriesRepository
+type MockSeriesRepository struct {
+	mock.Mock
+}
+
+func (m *MockSeriesRepository) Create(series *entities.Series) error {
+	args := m.Called(series)
+	return args.Error(0)
+}
+
+func (m *MockSeriesRepository) GetByID(id string) (*entities.Series, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Series), args.Error(1)
+}
+
+func (m *MockSeriesRepository) GetAll() ([]entities.Series, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.Series), args.Error(1)
+}
+
+func (m *MockSeriesRepository) FindByName(name string) (*entities.Series, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Series), args.Error(1)
+}
+
+func TestSeriesUseCase_CreateSeries(t *testing.T) {
+	mockRepo := &MockSeriesRepository{}
+	mockRepo.On("FindByName", "Discworld").Return(nil, nil)
+	mockRepo.On("Create", mock.AnythingOfType("*entities.Series")).Return(nil)
+
+	useCase := NewSeriesUseCase(mockRepo, &MockBookRepository{})
+
+	series := &entities.Series{Name: " Discworld "}
+	assert.NoError(t, useCase.CreateSeries(series))
+	assert.Equal(t, "Discworld", series.Name)
+	assert.Equal(t, entities.SeriesOrderingPosition, series.Ordering)
+
+	assert.EqualError(t, useCase.CreateSeries(&entities.Series{Name: "Dune", Ordering: "alphabetical"}),
+		"series ordering must be position or publication")
+}
+
+func TestSeriesUseCase_ListBooks(t *testing.T) {
+	books := []entities.Book{
+		{ID: "unpositioned", Year: 1980},
+		{ID: "second", Year: 1970, SeriesPosition: intPtr(2)},
+		{ID: "first", Year: 1990, SeriesPosition: intPtr(1)},
+	}
+
+	tests := []struct {
+		name     string
+		ordering string
+		expected []string
+	}{
+		{name: "by position", ordering: entities.SeriesOrderingPosition, expected: []string{"first", "second", "unpositioned"}},
+		{name: "by publication", ordering: entities.SeriesOrderingPublication, expected: []string{"second", "unpositioned", "first"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockSeriesRepository{}
+			mockBookRepo := &MockBookRepository{}
+			mockRepo.On("GetByID", "series-1").Return(&entities.Series{ID: "series-1", Ordering: tt.ordering}, nil)
+			mockBookRepo.On("FindBySeries", "series-1").Return(append([]entities.Book(nil), books...), nil)
+
+			result, err := NewSeriesUseCase(mockRepo, mockBookRepo).ListBooks("series-1")
+			assert.NoError(t, err)
+
+			var ids []string
+			for _, book := range result {
+				ids = append(ids, book.ID)
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+}
+
+func TestBookUseCase_ValidateSeries(t *testing.T) {
+	tests := []struct {
+		name          string
+		bookID        string
+		book          *entities.Book
+		expectedError string
+	}{
+		{name: "free position", book: &entities.Book{SeriesID: stringPtr("series-1"), SeriesPosition: intPtr(3)}},
+		{name: "own position on update", bookID: "book-1", book: &entities.Book{SeriesID: stringPtr("series-1"), SeriesPosition: intPtr(1)}},
+		{name: "duplicate position", bookID: "book-2", book: &entities.Book{SeriesID: stringPtr("series-1"), SeriesPosition: intPtr(1)}, expectedError: "series position is already taken"},
+		{name: "position without series", book: &entities.Book{SeriesPosition: intPtr(1)}, expectedError: "series position requires a series"},
+		{name: "position below one", book: &entities.Book{SeriesID: stringPtr("series-1"), SeriesPosition: intPtr(0)}, expectedError: "series position must be at least 1"},
+		{name: "unknown series", book: &entities.Book{SeriesID: stringPtr("missing")}, expectedError: "series not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockBookRepository{}
+			mockSeriesRepo := &MockSeriesRepository{}
+			mockSeriesRepo.On("GetByID", "series-1").Return(&entities.Series{ID: "series-1"}, nil)
+			mockSeriesRepo.On("GetByID", "missing").Return(nil, nil)
+			mockRepo.On("FindBySeries", "series-1").Return([]entities.Book{{ID: "book-1", SeriesPosition: intPtr(1)}}, nil)
+
+			useCase := NewBookUseCase(mockRepo)
+			useCase.SetSeriesRepository(mockSeriesRepo)
+
+			err := useCase.validateSeries(tt.bookID, tt.book)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
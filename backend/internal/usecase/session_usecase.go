@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// maxSessionDeviceLength bounds the User-Agent kept to name the device of a session
+const maxSessionDeviceLength = 255
+
+// SessionClient is the device and client IP a session is signed in or seen from
+type SessionClient struct {
+	Device string
+	IP     string
+}
+
+// SessionUseCase signs users in with their email and password, keeping a session per device in
+// the server-side session store, and lets them see and revoke their sessions
+type SessionUseCase struct {
+	sessionRepo repositories.SessionRepository
+	userRepo    repositories.UserRepository
+	clock       clock.Clock
+	// lifetime is how long sessions last after signing in
+	lifetime time.Duration
+	// maxPerUser is how many active sessions a user may have; zero leaves it unlimited
+	maxPerUser int
+	auditRepo  repositories.AuditRepository
+	// impersonationLifetime is how long the sessions admins start to impersonate members last
+	impersonationLifetime time.Duration
+}
+
+// NewSessionUseCase creates a new session use case
+func NewSessionUseCase(sessionRepo repositories.SessionRepository, userRepo repositories.UserRepository, lifetime time.Duration) *SessionUseCase {
+	return &SessionUseCase{
+		sessionRepo: sessionRepo,
+		userRepo:    userRepo,
+		clock:       clock.System{},
+		lifetime:    lifetime,
+		// Impersonations are kept short unless configured otherwise
+		impersonationLifetime: 30 * time.Minute,
+	}
+}
+
+// SetClock replaces the clock sessions expire and are seen by
+func (uc *SessionUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// SetMaxPerUser limits how many active sessions a user may have; signing in past the limit signs
+// out the sessions seen least recently. Zero leaves it unlimited.
+func (uc *SessionUseCase) SetMaxPerUser(n int) {
+	uc.maxPerUser = n
+}
+
+// SignIn starts a session for the active user with an email and password, and returns it with
+// its token, which is not stored and cannot be shown again. Unknown emails, wrong passwords and
+// deactivated accounts fail with domainerr.ErrInvalidCredentials.
+func (uc *SessionUseCase) SignIn(email, password string, client SessionClient) (*entities.Session, string, error) {
+	user, err := checkPassword(uc.userRepo, email, password)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := uc.clock.Now()
+	if err := uc.makeRoom(user.ID, now); err != nil {
+		return nil, "", err
+	}
+	secret := entities.NewSessionSecret()
+	session := &entities.Session{
+		UserID:     user.ID,
+		TokenHash:  entities.HashAccessToken(secret),
+		Device:     sessionDevice(client.Device),
+		IP:         client.IP,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(uc.lifetime),
+	}
+	if err := uc.sessionRepo.Create(session); err != nil {
+		return nil, "", err
+	}
+	return session, secret, nil
+}
+
+// makeRoom signs out the sessions of a user seen least recently until one more fits under the
+// limit. Admins impersonating the user neither count nor are signed out.
+func (uc *SessionUseCase) makeRoom(userID string, now time.Time) error {
+	if uc.maxPerUser <= 0 {
+		return nil
+	}
+	sessions, err := uc.sessionRepo.ListActiveByUser(userID, now)
+	if err != nil {
+		return err
+	}
+	// Sessions are listed last seen first, so the ones past the limit are the stalest
+	kept := 0
+	for i := range sessions {
+		if sessions[i].Impersonated() {
+			continue
+		}
+		if kept++; kept < uc.maxPerUser {
+			continue
+		}
+		sessions[i].RevokedAt = &now
+		if err := uc.sessionRepo.Update(&sessions[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List lists the active sessions of a user, last seen first
+func (uc *SessionUseCase) List(userID string) ([]entities.Session, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	return uc.sessionRepo.ListActiveByUser(userID, uc.clock.Now())
+}
+
+// Revoke signs out one of the active sessions of a user
+func (uc *SessionUseCase) Revoke(userID, id string) (*entities.Session, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	session, err := uc.sessionRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	now := uc.clock.Now()
+	if session == nil || session.UserID != userID || !session.Active(now) {
+		return nil, domainerr.ErrSessionNotFound
+	}
+
+	session.RevokedAt = &now
+	if err := uc.sessionRepo.Update(session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// RevokeOthers signs out every active session of a user but the one the request came from, which
+// is empty for requests not signed in with a session, and returns how many it signed out
+func (uc *SessionUseCase) RevokeOthers(userID, currentID string) (int64, error) {
+	if userID == "" {
+		return 0, errors.New("user ID is required")
+	}
+	return uc.sessionRepo.RevokeOthers(userID, currentID, uc.clock.Now())
+}
+
+// Authenticate returns the active session a request presents and its user, recording when and
+// from where it was seen. Unknown, expired and revoked sessions, and those of deleted and
+// deactivated users, fail with domainerr.ErrInvalidSession.
+func (uc *SessionUseCase) Authenticate(secret, ip string) (*entities.Session, *entities.User, error) {
+	if !strings.HasPrefix(secret, entities.SessionTokenPrefix) {
+		return nil, nil, domainerr.ErrInvalidSession
+	}
+	session, err := uc.sessionRepo.FindByHash(entities.HashAccessToken(secret))
+	if err != nil {
+		return nil, nil, err
+	}
+	now := uc.clock.Now()
+	if session == nil || !session.Active(now) {
+		return nil, nil, domainerr.ErrInvalidSession
+	}
+	user, err := uc.userRepo.GetByID(session.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil || !user.Active() {
+		return nil, nil, domainerr.ErrInvalidSession
+	}
+
+	if now.Sub(session.LastSeenAt) >= lastUsedResolution || session.IP != ip {
+		session.LastSeenAt = now
+		session.IP = ip
+		// The request is let through even if the session cannot be marked seen
+		if err := uc.sessionRepo.Update(session); err != nil {
+			log.Printf("Failed to record the use of session %s: %v", session.ID, err)
+		}
+	}
+	return session, user, nil
+}
+
+// sessionDevice trims the User-Agent of a session to the length kept
+func sessionDevice(userAgent string) string {
+	device := strings.TrimSpace(userAgent)
+	if len(device) > maxSessionDeviceLength {
+		device = strings.ToValidUTF8(device[:maxSessionDeviceLength], "")
+	}
+	return device
+}
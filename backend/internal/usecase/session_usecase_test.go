@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// memorySessionRepository keeps sessions in memory, counting the updates
+type memorySessionRepository struct {
+	sessions []entities.Session
+	updates  int
+}
+
+func (r *memorySessionRepository) Create(session *entities.Session) error {
+	session.ID = "session-" + strconv.Itoa(len(r.sessions)+1)
+	session.CreatedAt = session.LastSeenAt
+	r.sessions = append(r.sessions, *session)
+	return nil
+}
+
+func (r *memorySessionRepository) GetByID(id string) (*entities.Session, error) {
+	for _, session := range r.sessions {
+		if session.ID == id {
+			return &session, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memorySessionRepository) FindByHash(hash string) (*entities.Session, error) {
+	for _, session := range r.sessions {
+		if session.TokenHash == hash {
+			return &session, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memorySessionRepository) ListActiveByUser(userID string, at time.Time) ([]entities.Session, error) {
+	var sessions []entities.Session
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.Active(at) {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt) })
+	return sessions, nil
+}
+
+func (r *memorySessionRepository) Update(session *entities.Session) error {
+	r.updates++
+	for i := range r.sessions {
+		if r.sessions[i].ID == session.ID {
+			r.sessions[i] = *session
+		}
+	}
+	return nil
+}
+
+func (r *memorySessionRepository) RevokeOthers(userID, keepID string, at time.Time) (int64, error) {
+	var revoked int64
+	for i := range r.sessions {
+		if r.sessions[i].UserID == userID && r.sessions[i].ID != keepID && r.sessions[i].Active(at) {
+			r.sessions[i].RevokedAt = &at
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+func (r *memorySessionRepository) ListImpersonations(since time.Time) ([]entities.Session, error) {
+	var sessions []entities.Session
+	for i := len(r.sessions) - 1; i >= 0; i-- {
+		session := r.sessions[i]
+		if session.Impersonated() && !session.CreatedAt.Before(since) {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func TestSessionUseCase(t *testing.T) {
+	now := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+	fixed := clock.NewFixed(now)
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse battery staple"), bcrypt.MinCost)
+	require.NoError(t, err)
+	member := &entities.User{ID: "member-1", Email: "ada@example.com", Role: entities.UserRoleMember, PasswordHash: string(hash)}
+	users := &MockUserRepository{}
+	users.On("FindByEmail", "ada@example.com").Return(member, nil)
+	users.On("GetByID", "member-1").Return(member, nil)
+	repo := &memorySessionRepository{}
+	useCase := NewSessionUseCase(repo, users, 30*24*time.Hour)
+	useCase.SetClock(fixed)
+
+	_, _, err = useCase.SignIn("ada@example.com", "guessed", SessionClient{})
+	assert.ErrorIs(t, err, domainerr.ErrInvalidCredentials)
+
+	phone, phoneSecret, err := useCase.SignIn(" Ada@Example.com ", "correct horse battery staple", SessionClient{Device: strings.Repeat("x", 300), IP: "192.0.2.1"})
+	require.NoError(t, err)
+	assert.Len(t, phone.Device, maxSessionDeviceLength)
+	assert.Equal(t, now.AddDate(0, 0, 30), phone.ExpiresAt)
+	laptop, laptopSecret, err := useCase.SignIn("ada@example.com", "correct horse battery staple", SessionClient{Device: "Firefox", IP: "192.0.2.2"})
+	require.NoError(t, err)
+
+	// Sessions seen from another IP record it right away, else at most once a minute
+	fixed.Advance(30 * time.Second)
+	_, user, err := useCase.Authenticate(phoneSecret, "192.0.2.1")
+	require.NoError(t, err)
+	assert.Equal(t, "member-1", user.ID)
+	assert.Equal(t, 0, repo.updates)
+	seen, _, err := useCase.Authenticate(phoneSecret, "198.51.100.7")
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.updates)
+	assert.Equal(t, "198.51.100.7", seen.IP)
+
+	_, err = useCase.Revoke("member-2", phone.ID)
+	assert.ErrorIs(t, err, domainerr.ErrSessionNotFound)
+
+	// Signing out the other sessions keeps the current one
+	revoked, err := useCase.RevokeOthers("member-1", laptop.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), revoked)
+	_, _, err = useCase.Authenticate(phoneSecret, "192.0.2.1")
+	assert.ErrorIs(t, err, domainerr.ErrInvalidSession)
+	_, err = useCase.Revoke("member-1", phone.ID)
+	assert.ErrorIs(t, err, domainerr.ErrSessionNotFound)
+	active, err := useCase.List("member-1")
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, laptop.ID, active[0].ID)
+
+	// Sessions of deactivated accounts and expired sessions are refused
+	deactivatedAt := fixed.Now()
+	member.DeactivatedAt = &deactivatedAt
+	_, _, err = useCase.Authenticate(laptopSecret, "192.0.2.2")
+	assert.ErrorIs(t, err, domainerr.ErrInvalidSession)
+	member.DeactivatedAt = nil
+	fixed.Advance(30 * 24 * time.Hour)
+	_, _, err = useCase.Authenticate(laptopSecret, "192.0.2.2")
+	assert.ErrorIs(t, err, domainerr.ErrInvalidSession)
+
+	_, _, err = useCase.Authenticate("lms_pat_00000000000000000000000000000074", "192.0.2.2")
+	assert.ErrorIs(t, err, domainerr.ErrInvalidSession)
+}
+
+func TestSessionUseCase_MaxPerUser(t *testing.T) {
+	now := time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)
+	fixed := clock.NewFixed(now)
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse battery staple"), bcrypt.MinCost)
+	require.NoError(t, err)
+	member := &entities.User{ID: "member-1", Email: "ada@example.com", Role: entities.UserRoleMember, PasswordHash: string(hash)}
+	users := &MockUserRepository{}
+	users.On("FindByEmail", "ada@example.com").Return(member, nil)
+	users.On("GetByID", "member-1").Return(member, nil)
+	useCase := NewSessionUseCase(&memorySessionRepository{}, users, 30*24*time.Hour)
+	useCase.SetClock(fixed)
+	useCase.SetMaxPerUser(2)
+
+	_, phoneSecret, err := useCase.SignIn("ada@example.com", "correct horse battery staple", SessionClient{Device: "Phone"})
+	require.NoError(t, err)
+	fixed.Advance(time.Hour)
+	_, tabletSecret, err := useCase.SignIn("ada@example.com", "correct horse battery staple", SessionClient{Device: "Tablet"})
+	require.NoError(t, err)
+	// The phone is seen after the tablet signed in, so the tablet is the stalest
+	fixed.Advance(time.Hour)
+	_, _, err = useCase.Authenticate(phoneSecret, "")
+	require.NoError(t, err)
+
+	fixed.Advance(time.Hour)
+	_, _, err = useCase.SignIn("ada@example.com", "correct horse battery staple", SessionClient{Device: "Laptop"})
+	require.NoError(t, err)
+
+	_, _, err = useCase.Authenticate(tabletSecret, "")
+	assert.ErrorIs(t, err, domainerr.ErrInvalidSession)
+	active, err := useCase.List("member-1")
+	require.NoError(t, err)
+	require.Len(t, active, 2)
+	assert.Equal(t, "Laptop", active[0].Device)
+	assert.Equal(t, "Phone", active[1].Device)
+}
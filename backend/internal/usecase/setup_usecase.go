@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// tenantSlugPattern restricts tenant slugs to lowercase words separated by hyphens
+var tenantSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// defaultPolicies are the circulation rules a bootstrapped tenant starts with
+var defaultPolicies = []entities.Policy{
+	{Key: entities.PolicyLoanPeriodDays, Value: "14"},
+	{Key: entities.PolicyMaxActiveLoans, Value: "5"},
+	{Key: entities.PolicyMaxRenewals, Value: "2"},
+	{Key: entities.PolicyHoldExpiryDays, Value: "7"},
+}
+
+// BootstrapInput describes the first tenant and admin of a deployment
+type BootstrapInput struct {
+	TenantName    string
+	TenantSlug    string
+	AdminEmail    string
+	AdminName     string
+	AdminPassword string
+}
+
+// SetupUseCase provisions fresh deployments
+type SetupUseCase struct {
+	setupRepo repositories.SetupRepository
+	// token guards Bootstrap; bootstrap is disabled when it is empty
+	token string
+	// passwordPolicy is what the admin password must look like
+	passwordPolicy entities.PasswordPolicy
+}
+
+// NewSetupUseCase creates a new setup use case
+func NewSetupUseCase(setupRepo repositories.SetupRepository, token string) *SetupUseCase {
+	return &SetupUseCase{
+		setupRepo:      setupRepo,
+		token:          token,
+		passwordPolicy: entities.PasswordPolicy{MinLength: 12},
+	}
+}
+
+// SetPasswordPolicy replaces the policy the admin password is checked against
+func (uc *SetupUseCase) SetPasswordPolicy(policy entities.PasswordPolicy) {
+	uc.passwordPolicy = policy
+}
+
+// Enabled reports whether a setup token is configured
+func (uc *SetupUseCase) Enabled() bool {
+	return uc.token != ""
+}
+
+// IsBootstrapped reports whether the deployment has been bootstrapped
+func (uc *SetupUseCase) IsBootstrapped() (bool, error) {
+	return uc.setupRepo.IsBootstrapped()
+}
+
+// Bootstrap creates the first tenant, its admin and the default policies. It only succeeds once,
+// and only with the configured setup token.
+func (uc *SetupUseCase) Bootstrap(token string, input BootstrapInput) (*entities.Bootstrap, error) {
+	if !uc.Enabled() {
+		return nil, domainerr.ErrBootstrapDisabled
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(uc.token)) != 1 {
+		return nil, domainerr.ErrInvalidSetupToken
+	}
+
+	input.TenantName = strings.TrimSpace(input.TenantName)
+	input.TenantSlug = strings.TrimSpace(input.TenantSlug)
+	input.AdminEmail = entities.NormalizeEmail(input.AdminEmail)
+	if input.TenantSlug == "" {
+		input.TenantSlug = slugify(input.TenantName)
+	}
+	if err := uc.validateBootstrap(input); err != nil {
+		return nil, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(input.AdminPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	bootstrap := &entities.Bootstrap{
+		Tenant: entities.Tenant{Name: input.TenantName, Slug: input.TenantSlug},
+		Admin: entities.User{
+			Email:        input.AdminEmail,
+			Name:         strings.TrimSpace(input.AdminName),
+			Role:         entities.UserRoleAdmin,
+			PasswordHash: string(hash),
+		},
+		Policies: append([]entities.Policy(nil), defaultPolicies...),
+	}
+	if err := uc.setupRepo.Bootstrap(bootstrap); err != nil {
+		return nil, err
+	}
+	return bootstrap, nil
+}
+
+func (uc *SetupUseCase) validateBootstrap(input BootstrapInput) error {
+	if input.TenantName == "" {
+		return errors.New("tenant name is required")
+	}
+	if !tenantSlugPattern.MatchString(input.TenantSlug) {
+		return errors.New("tenant slug must be lowercase letters, digits and hyphens")
+	}
+	if address, err := mail.ParseAddress(input.AdminEmail); err != nil || address.Address != input.AdminEmail {
+		return errors.New("admin email is invalid")
+	}
+	if err := uc.passwordPolicy.Check(input.AdminPassword); err != nil {
+		return errors.New("admin " + err.Error())
+	}
+	return nil
+}
+
+// slugify derives a tenant slug from its name, e.g. "City Library" becomes city-library
+func slugify(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	return strings.Join(words, "-")
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"testing"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MockSetupRepository is a mock implementation of SetupRepository
+type MockSetupRepository struct {
+	mock.Mock
+}
+
+func (m *MockSetupRepository) IsBootstrapped() (bool, error) {
+	args := m.Called()
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSetupRepository) Bootstrap(bootstrap *entities.Bootstrap) error {
+	args := m.Called(bootstrap)
+	return args.Error(0)
+}
+
+func validBootstrapInput() BootstrapInput {
+	return BootstrapInput{
+		TenantName:    "City Library",
+		AdminEmail:    " Admin@Example.com ",
+		AdminName:     "Ada Admin",
+		AdminPassword: "correct horse battery staple",
+	}
+}
+
+func TestSetupUseCase_Bootstrap(t *testing.T) {
+	repo := &MockSetupRepository{}
+	uc := NewSetupUseCase(repo, "secret")
+
+	var created *entities.Bootstrap
+	repo.On("Bootstrap", mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(0).(*entities.Bootstrap)
+	}).Return(nil)
+
+	bootstrap, err := uc.Bootstrap("secret", validBootstrapInput())
+	require.NoError(t, err)
+	assert.Same(t, created, bootstrap)
+	assert.Equal(t, "city-library", bootstrap.Tenant.Slug)
+	assert.Equal(t, "admin@example.com", bootstrap.Admin.Email)
+	assert.Equal(t, entities.UserRoleAdmin, bootstrap.Admin.Role)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(bootstrap.Admin.PasswordHash), []byte("correct horse battery staple")))
+	assert.Len(t, bootstrap.Policies, len(defaultPolicies))
+	repo.AssertExpectations(t)
+}
+
+func TestSetupUseCase_BootstrapRejections(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		configured    string
+		input         func(*BootstrapInput)
+		expectedError string
+	}{
+		{name: "disabled without a setup token", token: "", configured: "", expectedError: "bootstrap is disabled"},
+		{name: "wrong token", token: "guess", configured: "secret", expectedError: "invalid setup token"},
+		{name: "missing tenant name", token: "secret", configured: "secret", input: func(in *BootstrapInput) { in.TenantName = " " }, expectedError: "tenant name is required"},
+		{name: "invalid slug", token: "secret", configured: "secret", input: func(in *BootstrapInput) { in.TenantSlug = "City Library" }, expectedError: "tenant slug must be lowercase letters, digits and hyphens"},
+		{name: "invalid email", token: "secret", configured: "secret", input: func(in *BootstrapInput) { in.AdminEmail = "admin" }, expectedError: "admin email is invalid"},
+		{name: "short password", token: "secret", configured: "secret", input: func(in *BootstrapInput) { in.AdminPassword = "short" }, expectedError: "admin password must have at least 12 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockSetupRepository{}
+			uc := NewSetupUseCase(repo, tt.configured)
+			input := validBootstrapInput()
+			if tt.input != nil {
+				tt.input(&input)
+			}
+
+			_, err := uc.Bootstrap(tt.token, input)
+
+			assert.EqualError(t, err, tt.expectedError)
+			repo.AssertNotCalled(t, "Bootstrap", mock.Anything)
+		})
+	}
+}
+
+func TestSetupUseCase_BootstrapPasswordPolicy(t *testing.T) {
+	repo := &MockSetupRepository{}
+	uc := NewSetupUseCase(repo, "secret")
+	uc.SetPasswordPolicy(entities.PasswordPolicy{MinLength: 8, RequireDigit: true, RequireSymbol: true})
+	input := validBootstrapInput()
+	input.AdminPassword = "correct horse"
+
+	_, err := uc.Bootstrap("secret", input)
+	assert.EqualError(t, err, "admin password must have a digit, a symbol")
+	repo.AssertNotCalled(t, "Bootstrap", mock.Anything)
+}
+
+func TestSetupUseCase_BootstrapOnlyOnce(t *testing.T) {
+	repo := &MockSetupRepository{}
+	uc := NewSetupUseCase(repo, "secret")
+	repo.On("Bootstrap", mock.Anything).Return(domainerr.ErrAlreadyBootstrapped)
+
+	_, err := uc.Bootstrap("secret", validBootstrapInput())
+	assert.ErrorIs(t, err, domainerr.ErrAlreadyBootstrapped)
+}
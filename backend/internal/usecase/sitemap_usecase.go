@@ -0,0 +1,334 @@
+package usecase
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// Sitemap processing defaults
+const (
+	// defaultSitemapProfile canonicalizes sitemaps submitted without operation or profile
+	defaultSitemapProfile = "seo"
+	// maxSitemapFailures bounds the failed entries reported on a job
+	maxSitemapFailures = 20
+	sitemapNamespace   = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	sitemapUpload      = "upload"
+)
+
+// SitemapFetcher downloads a sitemap from its URL
+type SitemapFetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+// JobRunner runs work in the background, e.g. on a worker pool. It returns an error when the
+// work could not be accepted.
+type JobRunner func(ctx context.Context, name string, run func(ctx context.Context) error) error
+
+// SitemapRequest submits a sitemap for canonicalization, from a URL or an upload
+type SitemapRequest struct {
+	URL       string
+	Upload    []byte
+	Operation string
+	Profile   string
+}
+
+// SitemapUseCase canonicalizes every entry of a sitemap through the URL processor, in the
+// background, and keeps the rewritten sitemap as the artifact of the job
+type SitemapUseCase struct {
+	jobRepo    repositories.SitemapJobRepository
+	urlUseCase *URLUseCase
+	maxBytes   int64
+	maxEntries int
+	fetcher    SitemapFetcher
+	runner     JobRunner
+	clock      clock.Clock
+}
+
+// NewSitemapUseCase creates a new sitemap use case for sitemaps of up to maxBytes, uncompressed,
+// and maxEntries entries
+func NewSitemapUseCase(jobRepo repositories.SitemapJobRepository, urlUseCase *URLUseCase, maxBytes int64, maxEntries int) *SitemapUseCase {
+	return &SitemapUseCase{
+		jobRepo:    jobRepo,
+		urlUseCase: urlUseCase,
+		maxBytes:   maxBytes,
+		maxEntries: maxEntries,
+		clock:      clock.System{},
+	}
+}
+
+// SetFetcher enables submitting sitemaps by URL
+func (uc *SitemapUseCase) SetFetcher(fetcher SitemapFetcher) {
+	uc.fetcher = fetcher
+}
+
+// SetRunner sets where jobs run; without one they run before Submit returns
+func (uc *SitemapUseCase) SetRunner(runner JobRunner) {
+	uc.runner = runner
+}
+
+// SetClock replaces the clock jobs are timestamped with
+func (uc *SitemapUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// MaxBytes returns the size limit of sitemaps
+func (uc *SitemapUseCase) MaxBytes() int64 {
+	return uc.maxBytes
+}
+
+// Submit validates a request and starts a job canonicalizing its sitemap. Without operation or
+// profile, entries get the seo profile.
+func (uc *SitemapUseCase) Submit(ctx context.Context, request *SitemapRequest) (*entities.SitemapJob, error) {
+	source := sitemapUpload
+	switch {
+	case request.URL != "" && request.Upload != nil:
+		return nil, errors.New("sitemap url and upload cannot be combined")
+	case request.URL != "":
+		parsedURL, err := url.Parse(request.URL)
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+			return nil, errors.New("invalid sitemap URL")
+		}
+		if uc.fetcher == nil {
+			return nil, errors.New("fetching sitemaps is disabled, upload the sitemap instead")
+		}
+		source = request.URL
+	case len(request.Upload) == 0:
+		return nil, errors.New("sitemap url or upload is required")
+	}
+	if int64(len(request.Upload)) > uc.maxBytes {
+		return nil, fmt.Errorf("sitemap is larger than %d bytes", uc.maxBytes)
+	}
+
+	if request.Operation == "" && request.Profile == "" {
+		request.Profile = defaultSitemapProfile
+	}
+	if _, err := uc.urlUseCase.resolve(request.Operation, request.Profile); err != nil {
+		return nil, err
+	}
+
+	job := &entities.SitemapJob{
+		Status:    entities.SitemapJobQueued,
+		Source:    source,
+		Operation: request.Operation,
+		Profile:   request.Profile,
+		CreatedAt: uc.clock.Now().UTC(),
+	}
+	if err := uc.jobRepo.Create(job); err != nil {
+		return nil, err
+	}
+
+	upload := request.Upload
+	run := func(ctx context.Context) error {
+		uc.run(ctx, job.ID, upload)
+		return nil
+	}
+	if uc.runner == nil {
+		run(ctx)
+		return uc.GetJob(job.ID)
+	}
+	if err := uc.runner(ctx, "sitemap:"+job.ID, run); err != nil {
+		uc.finish(job, err)
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetJob retrieves a sitemap job
+func (uc *SitemapUseCase) GetJob(id string) (*entities.SitemapJob, error) {
+	job, err := uc.jobRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, domainerr.ErrSitemapJobNotFound
+	}
+	return job, nil
+}
+
+// GetArtifact returns the rewritten sitemap of a completed job
+func (uc *SitemapUseCase) GetArtifact(id string) ([]byte, error) {
+	job, err := uc.GetJob(id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != entities.SitemapJobCompleted {
+		return nil, domainerr.ErrSitemapNotReady
+	}
+	return job.Artifact, nil
+}
+
+// run fetches or decompresses the sitemap of a job, rewrites it and records the outcome
+func (uc *SitemapUseCase) run(ctx context.Context, id string, upload []byte) {
+	job, err := uc.GetJob(id)
+	if err != nil {
+		return
+	}
+	job.Status = entities.SitemapJobRunning
+	uc.jobRepo.Update(job)
+
+	data := upload
+	if job.Source != sitemapUpload {
+		if data, err = uc.fetcher.Fetch(ctx, job.Source); err != nil {
+			uc.finish(job, fmt.Errorf("failed to fetch sitemap: %w", err))
+			return
+		}
+	}
+	if data, err = uc.decompress(data); err != nil {
+		uc.finish(job, err)
+		return
+	}
+
+	rewritten, err := rewriteSitemap(data, uc.maxEntries, func(loc string) (string, error) {
+		response, err := uc.urlUseCase.ProcessURL(&entities.URLRequest{URL: loc, Operation: job.Operation, Profile: job.Profile})
+		if err != nil {
+			return "", err
+		}
+		return response.ProcessedURL, nil
+	})
+	if err != nil {
+		uc.finish(job, err)
+		return
+	}
+	job.Entries = rewritten.entries
+	job.Rewritten = rewritten.rewritten
+	job.Failures = rewritten.failures
+	job.Artifact = rewritten.sitemap
+	uc.finish(job, nil)
+}
+
+// finish records the outcome of a job
+func (uc *SitemapUseCase) finish(job *entities.SitemapJob, err error) {
+	completedAt := uc.clock.Now().UTC()
+	job.CompletedAt = &completedAt
+	job.Status = entities.SitemapJobCompleted
+	if err != nil {
+		job.Status = entities.SitemapJobFailed
+		job.Error = err.Error()
+	}
+	uc.jobRepo.Update(job)
+}
+
+// decompress unzips gzipped sitemaps (sitemap.xml.gz), refusing ones that inflate past the size limit
+func (uc *SitemapUseCase) decompress(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		return data, nil
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid gzipped sitemap: %w", err)
+	}
+	inflated, err := io.ReadAll(io.LimitReader(reader, uc.maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("invalid gzipped sitemap: %w", err)
+	}
+	if int64(len(inflated)) > uc.maxBytes {
+		return nil, fmt.Errorf("sitemap is larger than %d bytes", uc.maxBytes)
+	}
+	return inflated, nil
+}
+
+// errNotSitemap is returned for XML documents other than sitemaps and sitemap indexes
+var errNotSitemap = errors.New("not a sitemap: expected a urlset or sitemapindex document")
+
+// rewrittenSitemap is a sitemap with its entries processed
+type rewrittenSitemap struct {
+	sitemap   []byte
+	entries   int
+	rewritten int
+	failures  []entities.SitemapFailure
+}
+
+// rewriteSitemap processes the <loc> of every <url> of a urlset, or <sitemap> of a sitemap
+// index. Only the locations are replaced, so the rest of the document (lastmod, image
+// extensions, comments, formatting) is kept byte for byte. Entries that fail to process are
+// left unchanged and reported.
+func rewriteSitemap(data []byte, maxEntries int, process func(loc string) (string, error)) (*rewrittenSitemap, error) {
+	result := &rewrittenSitemap{}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var (
+		out      bytes.Buffer
+		copied   int64
+		stack    []string
+		inLoc    bool
+		locStart int64
+		text     strings.Builder
+		sawRoot  bool
+	)
+	for {
+		before := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid sitemap XML: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			inSitemapNamespace := t.Name.Space == "" || t.Name.Space == sitemapNamespace
+			if len(stack) == 0 {
+				if sawRoot || !inSitemapNamespace || (t.Name.Local != "urlset" && t.Name.Local != "sitemapindex") {
+					return nil, errNotSitemap
+				}
+				sawRoot = true
+			}
+			if len(stack) == 2 && t.Name.Local == "loc" && inSitemapNamespace && (stack[1] == "url" || stack[1] == "sitemap") {
+				inLoc = true
+				locStart = decoder.InputOffset()
+				text.Reset()
+			}
+			stack = append(stack, t.Name.Local)
+		case xml.CharData:
+			if inLoc {
+				text.Write(t)
+			}
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+			if !inLoc || len(stack) != 2 {
+				continue
+			}
+			inLoc = false
+
+			result.entries++
+			if result.entries > maxEntries {
+				return nil, fmt.Errorf("sitemap has more than %d entries", maxEntries)
+			}
+			loc := strings.TrimSpace(text.String())
+			processed, err := process(loc)
+			if err != nil {
+				if len(result.failures) < maxSitemapFailures {
+					result.failures = append(result.failures, entities.SitemapFailure{Loc: loc, Error: err.Error()})
+				}
+				continue
+			}
+			if processed == loc {
+				continue
+			}
+			result.rewritten++
+			out.Write(data[copied:locStart])
+			xml.EscapeText(&out, []byte(processed))
+			copied = before
+		}
+	}
+	if !sawRoot {
+		return nil, errNotSitemap
+	}
+
+	out.Write(data[copied:])
+	result.sitemap = out.Bytes()
+	return result, nil
+}
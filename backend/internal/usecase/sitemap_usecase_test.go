@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fetchFunc adapts a function to SitemapFetcher
+type fetchFunc func(ctx context.Context, url string) ([]byte, error)
+
+func (f fetchFunc) Fetch(ctx context.Context, url string) ([]byte, error) {
+	return f(ctx, url)
+}
+
+const testSitemap = `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:image="http://www.google.com/schemas/sitemap-image/1.1">
+  <!-- tours -->
+  <url>
+    <loc>https://BYFOOD.com/Tours/?utm_source=ads&amp;page=2</loc>
+    <lastmod>2024-05-01</lastmod>
+    <image:image><image:loc>https://cdn.byfood.com/Tour.JPG?w=800</image:loc></image:image>
+  </url>
+  <url><loc>https://www.byfood.com/about</loc></url>
+  <url><loc>%zz</loc></url>
+</urlset>
+`
+
+func newTestSitemapUseCase() *SitemapUseCase {
+	uc := NewSitemapUseCase(repository.NewInMemorySitemapJobRepository(10), NewURLUseCase(&memoryURLRepository{}), 1<<20, 100)
+	uc.SetClock(clock.NewFixed(time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)))
+	return uc
+}
+
+func TestSitemapUseCase_SubmitUpload(t *testing.T) {
+	uc := newTestSitemapUseCase()
+
+	job, err := uc.Submit(context.Background(), &SitemapRequest{Upload: []byte(testSitemap)})
+	require.NoError(t, err)
+	assert.Equal(t, entities.SitemapJobCompleted, job.Status)
+	assert.Equal(t, "upload", job.Source)
+	assert.Equal(t, "seo", job.Profile, "sitemaps default to the seo profile")
+	assert.Equal(t, 3, job.Entries)
+	assert.Equal(t, 1, job.Rewritten)
+	assert.Equal(t, []entities.SitemapFailure{{Loc: "%zz", Error: "invalid URL format"}}, job.Failures)
+
+	artifact, err := uc.GetArtifact(job.ID)
+	require.NoError(t, err)
+	// Only the page locations change; image locations, comments and layout are kept
+	expected := strings.Replace(testSitemap, "https://BYFOOD.com/Tours/?utm_source=ads&amp;page=2", "https://www.byfood.com/tours", 1)
+	assert.Equal(t, expected, string(artifact))
+}
+
+func TestSitemapUseCase_SubmitURL(t *testing.T) {
+	uc := newTestSitemapUseCase()
+
+	_, err := uc.Submit(context.Background(), &SitemapRequest{URL: "https://byfood.com/sitemap.xml"})
+	assert.EqualError(t, err, "fetching sitemaps is disabled, upload the sitemap instead")
+
+	var gzipped bytes.Buffer
+	writer := gzip.NewWriter(&gzipped)
+	writer.Write([]byte(`<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><sitemap><loc>http://BYFOOD.com/sitemap-tours.xml#top</loc></sitemap></sitemapindex>`))
+	writer.Close()
+	uc.SetFetcher(fetchFunc(func(ctx context.Context, url string) ([]byte, error) {
+		if url == "https://byfood.com/sitemap.xml.gz" {
+			return gzipped.Bytes(), nil
+		}
+		return nil, errors.New("unexpected status 404")
+	}))
+
+	// Jobs queued on the runner complete in the background
+	var queued func(ctx context.Context) error
+	uc.SetRunner(func(ctx context.Context, name string, run func(ctx context.Context) error) error {
+		assert.True(t, strings.HasPrefix(name, "sitemap:"))
+		queued = run
+		return nil
+	})
+	job, err := uc.Submit(context.Background(), &SitemapRequest{URL: "https://byfood.com/sitemap.xml.gz", Profile: "strict"})
+	require.NoError(t, err)
+	assert.Equal(t, entities.SitemapJobQueued, job.Status)
+	_, err = uc.GetArtifact(job.ID)
+	assert.ErrorIs(t, err, domainerr.ErrSitemapNotReady)
+
+	require.NoError(t, queued(context.Background()))
+	artifact, err := uc.GetArtifact(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><sitemap><loc>https://www.byfood.com/sitemap-tours.xml</loc></sitemap></sitemapindex>`, string(artifact))
+
+	// Fetch failures fail the job
+	job, err = uc.Submit(context.Background(), &SitemapRequest{URL: "https://byfood.com/missing.xml"})
+	require.NoError(t, err)
+	require.NoError(t, queued(context.Background()))
+	job, err = uc.GetJob(job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.SitemapJobFailed, job.Status)
+	assert.Equal(t, "failed to fetch sitemap: unexpected status 404", job.Error)
+
+	// Jobs the runner refuses are failed right away
+	uc.SetRunner(func(ctx context.Context, name string, run func(ctx context.Context) error) error {
+		return domainerr.ErrQueueFull
+	})
+	_, err = uc.Submit(context.Background(), &SitemapRequest{Upload: []byte(testSitemap)})
+	assert.ErrorIs(t, err, domainerr.ErrQueueFull)
+}
+
+func TestSitemapUseCase_SubmitInvalid(t *testing.T) {
+	uc := newTestSitemapUseCase()
+	uc.SetFetcher(fetchFunc(func(ctx context.Context, url string) ([]byte, error) { return nil, nil }))
+
+	tests := []struct {
+		name    string
+		request *SitemapRequest
+		err     string
+	}{
+		{"nothing", &SitemapRequest{}, "sitemap url or upload is required"},
+		{"both", &SitemapRequest{URL: "https://byfood.com/sitemap.xml", Upload: []byte(testSitemap)}, "sitemap url and upload cannot be combined"},
+		{"file URL", &SitemapRequest{URL: "file:///etc/passwd"}, "invalid sitemap URL"},
+		{"too large", &SitemapRequest{Upload: make([]byte, 1<<20+1)}, "sitemap is larger than 1048576 bytes"},
+		{"unknown profile", &SitemapRequest{Upload: []byte(testSitemap), Profile: "aggressive"}, "unknown profile"},
+		{"operation and profile", &SitemapRequest{Upload: []byte(testSitemap), Operation: "all", Profile: "seo"}, "operation and profile cannot be combined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Submit(context.Background(), tt.request)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+
+	_, err := uc.GetJob("missing")
+	assert.ErrorIs(t, err, domainerr.ErrSitemapJobNotFound)
+}
+
+func TestSitemapUseCase_InvalidDocuments(t *testing.T) {
+	uc := newTestSitemapUseCase()
+
+	tests := map[string]string{
+		`<html><body>Not found</body></html>`:        "not a sitemap: expected a urlset or sitemapindex document",
+		`plain text`:                                 "not a sitemap: expected a urlset or sitemapindex document",
+		`<urlset><url><loc>https://byfood.com</loc>`: "invalid sitemap XML: XML syntax error on line 1: unexpected EOF",
+		"<urlset>" + strings.Repeat("<url><loc>https://byfood.com</loc></url>", 101) + "</urlset>": "sitemap has more than 100 entries",
+	}
+	for document, expected := range tests {
+		job, err := uc.Submit(context.Background(), &SitemapRequest{Upload: []byte(document)})
+		require.NoError(t, err)
+		assert.Equal(t, entities.SitemapJobFailed, job.Status)
+		assert.Equal(t, expected, job.Error)
+	}
+}
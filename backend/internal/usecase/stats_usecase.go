@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// StatsUseCase serves the catalog and circulation stats from tables a scheduled job refreshes,
+// so reading them costs the same however large the library grows
+type StatsUseCase struct {
+	statsRepo repositories.StatsRepository
+	// topAuthors is how many authors the top authors table keeps
+	topAuthors int
+	// maxAge is how old the stats may get before they are reported stale
+	maxAge time.Duration
+	// timezone is the timezone of the months of loans whose branch and tenant have none
+	timezone *time.Location
+	clock    clock.Clock
+}
+
+// NewStatsUseCase creates a new stats use case
+func NewStatsUseCase(statsRepo repositories.StatsRepository, topAuthors int, maxAge time.Duration) *StatsUseCase {
+	return &StatsUseCase{
+		statsRepo:  statsRepo,
+		topAuthors: topAuthors,
+		maxAge:     maxAge,
+		timezone:   time.UTC,
+		clock:      clock.System{},
+	}
+}
+
+// SetClock replaces the clock refreshes are timed and the age of the stats measured with
+func (uc *StatsUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// SetTimezone sets the timezone loans are counted in the months of when neither the branch of
+// their book nor their tenant has a timezone
+func (uc *StatsUseCase) SetTimezone(location *time.Location) {
+	uc.timezone = location
+}
+
+// Refresh recomputes the stats tables from the books and loans
+func (uc *StatsUseCase) Refresh() error {
+	return uc.statsRepo.Refresh(uc.clock.Now().UTC(), uc.topAuthors, uc.timezone.String())
+}
+
+// Stats returns the stats as of their last refresh, stale when a table was never refreshed or
+// not within the maximum age
+func (uc *StatsUseCase) Stats() (*entities.LibraryStats, error) {
+	refreshes, err := uc.statsRepo.Refreshes()
+	if err != nil {
+		return nil, err
+	}
+	booksPerYear, err := uc.statsRepo.BooksPerYear()
+	if err != nil {
+		return nil, err
+	}
+	loansPerMonth, err := uc.statsRepo.LoansPerMonth()
+	if err != nil {
+		return nil, err
+	}
+	topAuthors, err := uc.statsRepo.TopAuthors()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &entities.LibraryStats{
+		BooksPerYear:  booksPerYear,
+		LoansPerMonth: loansPerMonth,
+		TopAuthors:    topAuthors,
+		Refreshes:     refreshes,
+	}
+	if stats.BooksPerYear == nil {
+		stats.BooksPerYear = []entities.BooksPerYear{}
+	}
+	if stats.LoansPerMonth == nil {
+		stats.LoansPerMonth = []entities.LoansPerMonth{}
+	}
+	if stats.TopAuthors == nil {
+		stats.TopAuthors = []entities.TopAuthor{}
+	}
+	if stats.Refreshes == nil {
+		stats.Refreshes = []entities.StatsRefresh{}
+	}
+
+	refreshed := make(map[string]time.Time, len(refreshes))
+	for _, refresh := range refreshes {
+		refreshed[refresh.Name] = refresh.RefreshedAt
+	}
+	var oldest time.Time
+	for _, table := range entities.StatsTables {
+		at, ok := refreshed[table]
+		if !ok {
+			stats.Stale = true
+			return stats, nil
+		}
+		if oldest.IsZero() || at.Before(oldest) {
+			oldest = at
+		}
+	}
+	stats.RefreshedAt = &oldest
+	stats.Stale = uc.maxAge > 0 && uc.clock.Now().Sub(oldest) > uc.maxAge
+	return stats, nil
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockStatsRepository is a mock implementation of StatsRepository
+type MockStatsRepository struct {
+	mock.Mock
+}
+
+func (m *MockStatsRepository) Refresh(at time.Time, topAuthors int, timezone string) error {
+	args := m.Called(at, topAuthors, timezone)
+	return args.Error(0)
+}
+
+func (m *MockStatsRepository) BooksPerYear() ([]entities.BooksPerYear, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.BooksPerYear), args.Error(1)
+}
+
+func (m *MockStatsRepository) LoansPerMonth() ([]entities.LoansPerMonth, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.LoansPerMonth), args.Error(1)
+}
+
+func (m *MockStatsRepository) TopAuthors() ([]entities.TopAuthor, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.TopAuthor), args.Error(1)
+}
+
+func (m *MockStatsRepository) Refreshes() ([]entities.StatsRefresh, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.StatsRefresh), args.Error(1)
+}
+
+// statsRepositoryRefreshedAt mocks stats tables refreshed at the given times, by table
+func statsRepositoryRefreshedAt(times map[string]time.Time) *MockStatsRepository {
+	var refreshes []entities.StatsRefresh
+	for name, at := range times {
+		refreshes = append(refreshes, entities.StatsRefresh{Name: name, RefreshedAt: at})
+	}
+	repo := &MockStatsRepository{}
+	repo.On("Refreshes").Return(refreshes, nil)
+	repo.On("BooksPerYear").Return([]entities.BooksPerYear{{Year: 1925, Books: 2}}, nil)
+	repo.On("LoansPerMonth").Return(nil, nil)
+	repo.On("TopAuthors").Return(nil, nil)
+	return repo
+}
+
+func TestStatsUseCase_Refresh(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 25, 0, 0, time.UTC)
+	repo := &MockStatsRepository{}
+	repo.On("Refresh", now, 10, "UTC").Return(nil)
+	repo.On("Refresh", now, 10, "Asia/Tokyo").Return(nil)
+	useCase := NewStatsUseCase(repo, 10, 30*time.Minute)
+	useCase.SetClock(clock.NewFixed(now))
+
+	require.NoError(t, useCase.Refresh())
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	useCase.SetTimezone(tokyo)
+	require.NoError(t, useCase.Refresh())
+	repo.AssertExpectations(t)
+}
+
+func TestStatsUseCase_StatsFresh(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 25, 0, 0, time.UTC)
+	oldest := now.Add(-20 * time.Minute)
+	repo := statsRepositoryRefreshedAt(map[string]time.Time{
+		entities.StatsBooksPerYear:  now.Add(-5 * time.Minute),
+		entities.StatsLoansPerMonth: oldest,
+		entities.StatsTopAuthors:    now.Add(-5 * time.Minute),
+	})
+	useCase := NewStatsUseCase(repo, 10, 30*time.Minute)
+	useCase.SetClock(clock.NewFixed(now))
+
+	stats, err := useCase.Stats()
+	require.NoError(t, err)
+	require.NotNil(t, stats.RefreshedAt)
+	assert.Equal(t, oldest, *stats.RefreshedAt)
+	assert.False(t, stats.Stale)
+	assert.Len(t, stats.BooksPerYear, 1)
+	assert.NotNil(t, stats.LoansPerMonth)
+	assert.NotNil(t, stats.TopAuthors)
+}
+
+func TestStatsUseCase_StatsStale(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 25, 0, 0, time.UTC)
+	refreshed := map[string]time.Time{
+		entities.StatsBooksPerYear:  now.Add(-time.Hour),
+		entities.StatsLoansPerMonth: now,
+		entities.StatsTopAuthors:    now,
+	}
+	useCase := NewStatsUseCase(statsRepositoryRefreshedAt(refreshed), 10, 30*time.Minute)
+	useCase.SetClock(clock.NewFixed(now))
+
+	stats, err := useCase.Stats()
+	require.NoError(t, err)
+	assert.True(t, stats.Stale)
+
+	// Without a maximum age, old stats are never stale
+	useCase = NewStatsUseCase(statsRepositoryRefreshedAt(refreshed), 10, 0)
+	useCase.SetClock(clock.NewFixed(now))
+	stats, err = useCase.Stats()
+	require.NoError(t, err)
+	assert.False(t, stats.Stale)
+}
+
+func TestStatsUseCase_StatsNeverRefreshed(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 25, 0, 0, time.UTC)
+	useCase := NewStatsUseCase(statsRepositoryRefreshedAt(map[string]time.Time{
+		entities.StatsBooksPerYear: now,
+	}), 10, 0)
+	useCase.SetClock(clock.NewFixed(now))
+
+	stats, err := useCase.Stats()
+	require.NoError(t, err)
+	assert.Nil(t, stats.RefreshedAt)
+	assert.True(t, stats.Stale)
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"sync"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/events"
+)
+
+// StorageSource is an area of storage and how to measure it
+type StorageSource struct {
+	Name string
+	// Path is the directory of the area, empty for the database
+	Path string
+	Size func(ctx context.Context) (int64, error)
+}
+
+// StorageUseCase watches the disk usage of the storage areas against soft quotas
+type StorageUseCase struct {
+	sources []StorageSource
+	// limits are the soft quotas of the areas in bytes, by name
+	limits map[string]int64
+	// warnPercent is the share of its limit at which an area gets a warning
+	warnPercent int
+	eventBus    events.Bus
+	clock       clock.Clock
+
+	mu sync.Mutex
+	// levels are the levels of the areas at the last check, to notify each crossing once
+	levels map[string]string
+}
+
+// NewStorageUseCase creates a new storage use case
+func NewStorageUseCase(sources []StorageSource, limits map[string]int64, warnPercent int) *StorageUseCase {
+	return &StorageUseCase{
+		sources:     sources,
+		limits:      limits,
+		warnPercent: warnPercent,
+		clock:       clock.System{},
+		levels:      make(map[string]string),
+	}
+}
+
+// SetEventBus enables notifying the areas that cross a threshold
+func (uc *StorageUseCase) SetEventBus(bus events.Bus) {
+	uc.eventBus = bus
+}
+
+// SetClock replaces the clock reports are dated with
+func (uc *StorageUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// Report measures every storage area
+func (uc *StorageUseCase) Report(ctx context.Context) *entities.StorageReport {
+	report := &entities.StorageReport{
+		CheckedAt:   uc.clock.Now(),
+		WarnPercent: uc.warnPercent,
+		Areas:       make([]entities.StorageArea, 0, len(uc.sources)),
+	}
+	for _, source := range uc.sources {
+		report.Areas = append(report.Areas, uc.measure(ctx, source))
+	}
+	return report
+}
+
+// Check measures every storage area and warns about the areas whose level rose since the last
+// check, in the log and with a StorageThresholdCrossed event. An area going back down is only
+// remembered, so that crossing the threshold again warns again.
+func (uc *StorageUseCase) Check(ctx context.Context) *entities.StorageReport {
+	report := uc.Report(ctx)
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	for _, area := range report.Areas {
+		if area.Error != "" {
+			log.Printf("Failed to measure the %s storage: %s", area.Name, area.Error)
+			continue
+		}
+		previous, ok := uc.levels[area.Name]
+		if !ok {
+			previous = entities.StorageOK
+		}
+		uc.levels[area.Name] = area.Level
+		if storageLevelRank(area.Level) > storageLevelRank(previous) {
+			uc.warn(area)
+		}
+	}
+	return report
+}
+
+// measure returns the usage of an area against its limit
+func (uc *StorageUseCase) measure(ctx context.Context, source StorageSource) entities.StorageArea {
+	area := entities.StorageArea{
+		Name:       source.Name,
+		Path:       source.Path,
+		LimitBytes: uc.limits[source.Name],
+		Level:      entities.StorageOK,
+	}
+	used, err := source.Size(ctx)
+	if err != nil {
+		area.Error = err.Error()
+		return area
+	}
+	area.UsedBytes = used
+	if area.LimitBytes <= 0 {
+		return area
+	}
+
+	area.UsedPercent = math.Round(float64(used)*10000/float64(area.LimitBytes)) / 100
+	switch {
+	case used >= area.LimitBytes:
+		area.Level = entities.StorageExceeded
+	case used*100 >= area.LimitBytes*int64(uc.warnPercent):
+		area.Level = entities.StorageWarning
+	}
+	return area
+}
+
+// warn reports an area whose level rose
+func (uc *StorageUseCase) warn(area entities.StorageArea) {
+	message := fmt.Sprintf("The %s storage uses %s of its %s limit (%.2f%%).", area.Name, formatBytes(area.UsedBytes), formatBytes(area.LimitBytes), area.UsedPercent)
+	log.Printf("Storage %s: %s", area.Level, message)
+	if uc.eventBus == nil {
+		return
+	}
+	uc.eventBus.Publish(events.Event{
+		Type: events.StorageThresholdCrossed,
+		Payload: map[string]string{
+			"area":        area.Name,
+			"level":       area.Level,
+			"used_bytes":  strconv.FormatInt(area.UsedBytes, 10),
+			"limit_bytes": strconv.FormatInt(area.LimitBytes, 10),
+			"message":     message,
+		},
+		OccurredAt: uc.clock.Now(),
+	})
+}
+
+// storageLevelRank orders the storage levels from ok to exceeded
+func storageLevelRank(level string) int {
+	switch level {
+	case entities.StorageWarning:
+		return 1
+	case entities.StorageExceeded:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// formatBytes renders a size in binary units, such as 1.5 GiB
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/events"
+	"library-management-system/internal/infrastructure/eventbus"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedSize is a storage source that uses *size bytes
+func fixedSize(size *int64) func(ctx context.Context) (int64, error) {
+	return func(ctx context.Context) (int64, error) {
+		return *size, nil
+	}
+}
+
+func TestStorageUseCase_Report(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	database, covers, backups := int64(850), int64(120), int64(2000)
+	useCase := NewStorageUseCase([]StorageSource{
+		{Name: "database", Size: fixedSize(&database)},
+		{Name: "covers", Path: "/srv/covers", Size: fixedSize(&covers)},
+		{Name: "backups", Path: "/srv/backups", Size: fixedSize(&backups)},
+		{Name: "exports", Path: "/srv/exports", Size: func(ctx context.Context) (int64, error) {
+			return 0, errors.New("permission denied")
+		}},
+	}, map[string]int64{"database": 1000, "backups": 1000}, 80)
+	useCase.SetClock(clock.NewFixed(now))
+
+	report := useCase.Report(context.Background())
+	assert.Equal(t, now, report.CheckedAt)
+	assert.Equal(t, 80, report.WarnPercent)
+	assert.Equal(t, []entities.StorageArea{
+		{Name: "database", UsedBytes: 850, LimitBytes: 1000, UsedPercent: 85, Level: entities.StorageWarning},
+		{Name: "covers", Path: "/srv/covers", UsedBytes: 120, Level: entities.StorageOK},
+		{Name: "backups", Path: "/srv/backups", UsedBytes: 2000, LimitBytes: 1000, UsedPercent: 200, Level: entities.StorageExceeded},
+		{Name: "exports", Path: "/srv/exports", Level: entities.StorageOK, Error: "permission denied"},
+	}, report.Areas)
+}
+
+func TestStorageUseCase_CheckNotifiesCrossingsOnce(t *testing.T) {
+	used := int64(500)
+	useCase := NewStorageUseCase([]StorageSource{{Name: "database", Size: fixedSize(&used)}}, map[string]int64{"database": 1 << 30}, 80)
+	bus := eventbus.NewInMemoryBus()
+	useCase.SetEventBus(bus)
+	var published []events.Event
+	bus.Subscribe(events.StorageThresholdCrossed, func(event events.Event) error {
+		published = append(published, event)
+		return nil
+	})
+
+	useCase.Check(context.Background())
+	assert.Empty(t, published)
+
+	used = 900 << 20
+	useCase.Check(context.Background())
+	useCase.Check(context.Background())
+	require.Len(t, published, 1)
+	assert.Equal(t, map[string]string{
+		"area":        "database",
+		"level":       entities.StorageWarning,
+		"used_bytes":  "943718400",
+		"limit_bytes": "1073741824",
+		"message":     "The database storage uses 900.0 MiB of its 1.0 GiB limit (87.89%).",
+	}, published[0].Payload)
+
+	used = 2 << 30
+	useCase.Check(context.Background())
+	require.Len(t, published, 2)
+	assert.Equal(t, entities.StorageExceeded, published[1].Payload["level"])
+
+	// Going back down and up again warns again
+	used = 100
+	useCase.Check(context.Background())
+	used = 900 << 20
+	useCase.Check(context.Background())
+	require.Len(t, published, 3)
+	assert.Equal(t, entities.StorageWarning, published[2].Payload["level"])
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// DefaultTimelinePageSize is the number of events per page when the request does not say
+const DefaultTimelinePageSize = 20
+
+// TimelineSource contributes events to a book's activity stream
+type TimelineSource interface {
+	BookEvents(bookID string) ([]entities.TimelineEvent, error)
+}
+
+// TimelineUseCase assembles a chronological activity stream for a book from several sources
+type TimelineUseCase struct {
+	bookRepo repositories.BookRepository
+	sources  []TimelineSource
+}
+
+// NewTimelineUseCase creates a new timeline use case
+func NewTimelineUseCase(bookRepo repositories.BookRepository, sources ...TimelineSource) *TimelineUseCase {
+	return &TimelineUseCase{
+		bookRepo: bookRepo,
+		sources:  sources,
+	}
+}
+
+// GetTimeline returns one page of the book's activity stream, newest first
+func (uc *TimelineUseCase) GetTimeline(bookID string, page, pageSize int) (*entities.TimelinePage, error) {
+	if bookID == "" {
+		return nil, errors.New("book ID is required")
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultTimelinePageSize
+	}
+
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return nil, err
+	}
+	if book == nil {
+		return nil, domainerr.ErrBookNotFound
+	}
+
+	var timeline []entities.TimelineEvent
+	for _, source := range uc.sources {
+		events, err := source.BookEvents(bookID)
+		if err != nil {
+			return nil, err
+		}
+		timeline = append(timeline, events...)
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].OccurredAt.After(timeline[j].OccurredAt)
+	})
+
+	result := &entities.TimelinePage{
+		BookID:   bookID,
+		Items:    []entities.TimelineEvent{},
+		Page:     page,
+		PageSize: pageSize,
+		Total:    len(timeline),
+	}
+
+	start := (page - 1) * pageSize
+	if start < len(timeline) {
+		end := start + pageSize
+		if end > len(timeline) {
+			end = len(timeline)
+		}
+		result.Items = timeline[start:end]
+	}
+
+	return result, nil
+}
+
+// AuditTimelineSource exposes a book's audit trail as timeline events
+type AuditTimelineSource struct {
+	auditRepo repositories.AuditRepository
+}
+
+// NewAuditTimelineSource creates a new audit timeline source
+func NewAuditTimelineSource(auditRepo repositories.AuditRepository) *AuditTimelineSource {
+	return &AuditTimelineSource{auditRepo: auditRepo}
+}
+
+// auditSummaries describes each audited book action
+var auditSummaries = map[string]string{
+	entities.AuditActionCreated:       "Book added to the catalog",
+	entities.AuditActionUpdated:       "Book details updated",
+	entities.AuditActionDeleted:       "Book moved to trash",
+	entities.AuditActionRestored:      "Book restored from trash",
+	entities.AuditActionHardDeleted:   "Book permanently deleted",
+	entities.AuditActionPublished:     "Scheduled book published",
+	entities.AuditActionStatusChanged: "Book status changed",
+}
+
+// BookEvents returns the audit entries of a book as timeline events
+func (s *AuditTimelineSource) BookEvents(bookID string) ([]entities.TimelineEvent, error) {
+	entries, err := s.auditRepo.ListByEntity("book", bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]entities.TimelineEvent, 0, len(entries))
+	for _, entry := range entries {
+		summary, ok := auditSummaries[entry.Action]
+		if !ok {
+			summary = entry.Action
+		}
+
+		event := entities.TimelineEvent{
+			Type:       entities.TimelineTypeAudit,
+			Action:     entry.Action,
+			Summary:    summary,
+			OccurredAt: entry.CreatedAt,
+		}
+		if details := changedFields(entry.Changes); len(details) > 0 {
+			event.Details = details
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// Loan timeline actions
+const (
+	TimelineActionCheckedOut = "checked_out"
+	TimelineActionReturned   = "returned"
+)
+
+// LoanTimelineSource exposes the loans of a book, current and archived, as timeline events. The
+// members who borrowed the book are left out, as timelines are not theirs to share.
+type LoanTimelineSource struct {
+	loanRepo repositories.LoanRepository
+}
+
+// NewLoanTimelineSource creates a new loan timeline source
+func NewLoanTimelineSource(loanRepo repositories.LoanRepository) *LoanTimelineSource {
+	return &LoanTimelineSource{loanRepo: loanRepo}
+}
+
+// BookEvents returns an event for each time the book was lent and each time it came back
+func (s *LoanTimelineSource) BookEvents(bookID string) ([]entities.TimelineEvent, error) {
+	loans, err := s.loanRepo.ListByBook(bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]entities.TimelineEvent, 0, 2*len(loans))
+	for _, loan := range loans {
+		events = append(events, entities.TimelineEvent{
+			Type:    entities.TimelineTypeLoan,
+			Action:  TimelineActionCheckedOut,
+			Summary: "Book lent",
+			Details: map[string]string{
+				"loan_id":  loan.ID,
+				"due_at":   loan.DueAt.UTC().Format(time.RFC3339),
+				"renewals": strconv.Itoa(loan.Renewals),
+			},
+			OccurredAt: loan.BorrowedAt,
+		})
+		if loan.ReturnedAt != nil {
+			events = append(events, entities.TimelineEvent{
+				Type:       entities.TimelineTypeLoan,
+				Action:     TimelineActionReturned,
+				Summary:    "Book returned",
+				Details:    map[string]string{"loan_id": loan.ID},
+				OccurredAt: *loan.ReturnedAt,
+			})
+		}
+	}
+
+	return events, nil
+}
+
+// changedFields flattens recorded audit changes into field -> new value pairs
+func changedFields(changes string) map[string]string {
+	if changes == "" {
+		return nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(changes), &raw); err != nil {
+		return nil
+	}
+
+	details := make(map[string]string, len(raw))
+	for field, value := range raw {
+		var diff struct {
+			To json.RawMessage `json:"to"`
+		}
+		if err := json.Unmarshal(value, &diff); err == nil && diff.To != nil {
+			value = diff.To
+		}
+
+		var text string
+		if err := json.Unmarshal(value, &text); err != nil {
+			text = string(value)
+		}
+		details[field] = text
+	}
+	return details
+}
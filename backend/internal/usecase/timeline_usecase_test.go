@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuditRepository is a mock implementation of AuditRepository
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Create(entry *entities.AuditEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) CreateBatch(entries []entities.AuditEntry) error {
+	args := m.Called(entries)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) ListByEntity(entityType, entityID string) ([]entities.AuditEntry, error) {
+	args := m.Called(entityType, entityID)
+	return args.Get(0).([]entities.AuditEntry), args.Error(1)
+}
+
+func (m *MockAuditRepository) ListByAction(action string, from, to time.Time) ([]entities.AuditEntry, error) {
+	args := m.Called(action, from, to)
+	return args.Get(0).([]entities.AuditEntry), args.Error(1)
+}
+
+func TestTimelineUseCase_GetTimeline(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	bookRepo := &MockBookRepository{}
+	bookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1"}, nil)
+
+	auditRepo := &MockAuditRepository{}
+	auditRepo.On("ListByEntity", "book", "book-1").Return([]entities.AuditEntry{
+		{Action: entities.AuditActionCreated, CreatedAt: day(1)},
+		{Action: entities.AuditActionUpdated, Changes: `{"title":{"from":"Old","to":"New"},"year":{"from":2020,"to":2021}}`, CreatedAt: day(3)},
+	}, nil)
+
+	returnedAt := day(4)
+	loanRepo := &MockLoanRepository{}
+	loanRepo.On("ListByBook", "book-1").Return([]entities.Loan{
+		{ID: "loan-1", UserID: "member-1", BookID: "book-1", BorrowedAt: day(2), DueAt: day(16), ReturnedAt: &returnedAt},
+	}, nil)
+
+	useCase := NewTimelineUseCase(bookRepo, NewAuditTimelineSource(auditRepo), NewLoanTimelineSource(loanRepo))
+
+	timeline, err := useCase.GetTimeline("book-1", 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, timeline.Total)
+	assert.Len(t, timeline.Items, 2)
+	assert.Equal(t, entities.TimelineTypeLoan, timeline.Items[0].Type)
+	assert.Equal(t, TimelineActionReturned, timeline.Items[0].Action)
+	assert.Equal(t, entities.AuditActionUpdated, timeline.Items[1].Action)
+	assert.Equal(t, map[string]string{"title": "New", "year": "2021"}, timeline.Items[1].Details)
+
+	timeline, err = useCase.GetTimeline("book-1", 2, 2)
+	assert.NoError(t, err)
+	assert.Len(t, timeline.Items, 2)
+	assert.Equal(t, TimelineActionCheckedOut, timeline.Items[0].Action)
+	assert.Equal(t, map[string]string{"loan_id": "loan-1", "due_at": "2024-01-16T00:00:00Z", "renewals": "0"}, timeline.Items[0].Details)
+	assert.Equal(t, "Book added to the catalog", timeline.Items[1].Summary)
+
+	timeline, err = useCase.GetTimeline("book-1", 5, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, timeline.Items)
+}
+
+func TestTimelineUseCase_GetTimeline_BookNotFound(t *testing.T) {
+	bookRepo := &MockBookRepository{}
+	bookRepo.On("GetByID", "missing").Return(nil, nil)
+
+	useCase := NewTimelineUseCase(bookRepo)
+
+	_, err := useCase.GetTimeline("missing", 1, 20)
+	assert.EqualError(t, err, "book not found")
+}
+
+func TestBookUseCase_RecordsAudit(t *testing.T) {
+	bookRepo := &MockBookRepository{}
+	auditRepo := &MockAuditRepository{}
+	useCase := NewBookUseCase(bookRepo)
+	useCase.SetAuditRepository(auditRepo)
+
+	existing := &entities.Book{ID: "book-1", Title: "Old", Author: "Author", Year: 2020, ISBN: "1234567890"}
+	bookRepo.On("GetByID", "book-1").Return(existing, nil)
+	bookRepo.On("FindBySlug", "new").Return(nil, nil)
+	bookRepo.On("Update", mock.AnythingOfType("*entities.Book")).Return(nil)
+	auditRepo.On("Create", mock.MatchedBy(func(entry *entities.AuditEntry) bool {
+		return entry.EntityID == "book-1" &&
+			entry.Action == entities.AuditActionUpdated &&
+			entry.Changes == `{"title":{"from":"Old","to":"New"}}`
+	})).Return(nil)
+
+	err := useCase.UpdateBook("book-1", &entities.Book{Title: "New", Author: "Author", Year: 2020, ISBN: "1234567890"})
+
+	assert.NoError(t, err)
+	auditRepo.AssertExpectations(t)
+}
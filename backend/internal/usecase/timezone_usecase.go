@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// TimezoneUseCase keeps the timezones of tenants and branches, which due dates, stats and report
+// schedules are worked out in instead of the clock of the server
+type TimezoneUseCase struct {
+	tenantRepo repositories.TenantRepository
+	branchRepo repositories.BranchRepository
+	// fallback is the timezone of tenants without one of their own
+	fallback *time.Location
+}
+
+// NewTimezoneUseCase creates a new timezone use case, in which tenants without a timezone are in
+// fallback
+func NewTimezoneUseCase(tenantRepo repositories.TenantRepository, branchRepo repositories.BranchRepository, fallback *time.Location) *TimezoneUseCase {
+	return &TimezoneUseCase{
+		tenantRepo: tenantRepo,
+		branchRepo: branchRepo,
+		fallback:   fallback,
+	}
+}
+
+// Default returns the timezone of tenants without one of their own
+func (uc *TimezoneUseCase) Default() *time.Location {
+	return uc.fallback
+}
+
+// Location returns the timezone of a branch of a tenant: that of the branch, else that of the
+// tenant, else the default. Tenants and branches that do not exist are in the default timezone,
+// so work for them is not held up.
+func (uc *TimezoneUseCase) Location(tenantID string, branchID *string) (*time.Location, error) {
+	timezone, err := uc.resolve(tenantID, branchID)
+	if err != nil {
+		return nil, err
+	}
+	if timezone.Source == entities.TimezoneFromDefault {
+		return uc.fallback, nil
+	}
+	return entities.LoadTimezone(timezone.Name)
+}
+
+// Timezone returns the timezone of a tenant, or with a branch ID of a branch as seen from the
+// tenant, and where it comes from
+func (uc *TimezoneUseCase) Timezone(tenantID, branchID string) (*entities.Timezone, error) {
+	if err := uc.check(tenantID, branchID); err != nil {
+		return nil, err
+	}
+	return uc.resolve(tenantID, branchRef(branchID))
+}
+
+// SetTimezone sets the timezone of a tenant, or with a branch ID of a branch, by its IANA name.
+// An empty name clears it, leaving the branch in the timezone of the tenant and the tenant in the
+// default timezone.
+func (uc *TimezoneUseCase) SetTimezone(tenantID, branchID, name string) (*entities.Timezone, error) {
+	if err := uc.check(tenantID, branchID); err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	if name != "" {
+		if _, err := entities.LoadTimezone(name); err != nil {
+			return nil, err
+		}
+	}
+
+	if branchID != "" {
+		branch, err := uc.branchRepo.GetByID(branchID)
+		if err != nil {
+			return nil, err
+		}
+		branch.Timezone = name
+		if err := uc.branchRepo.Update(branch); err != nil {
+			return nil, err
+		}
+	} else {
+		tenant, err := uc.tenantRepo.GetByID(tenantID)
+		if err != nil {
+			return nil, err
+		}
+		tenant.Timezone = name
+		if err := uc.tenantRepo.Update(tenant); err != nil {
+			return nil, err
+		}
+	}
+	return uc.resolve(tenantID, branchRef(branchID))
+}
+
+// resolve works out the timezone of a branch of a tenant, nil for the tenant itself
+func (uc *TimezoneUseCase) resolve(tenantID string, branchID *string) (*entities.Timezone, error) {
+	timezone := &entities.Timezone{TenantID: tenantID, BranchID: branchID}
+	if branchID != nil {
+		branch, err := uc.branchRepo.GetByID(*branchID)
+		if err != nil {
+			return nil, err
+		}
+		if branch != nil && branch.Timezone != "" {
+			timezone.Name, timezone.Source = branch.Timezone, entities.TimezoneFromBranch
+			return timezone, nil
+		}
+	}
+	if tenantID != "" {
+		tenant, err := uc.tenantRepo.GetByID(tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if tenant != nil && tenant.Timezone != "" {
+			timezone.Name, timezone.Source = tenant.Timezone, entities.TimezoneFromTenant
+			return timezone, nil
+		}
+	}
+	timezone.Name, timezone.Source = uc.fallback.String(), entities.TimezoneFromDefault
+	return timezone, nil
+}
+
+// check checks that the tenant and the branch exist, leaving out those with an empty ID; at least
+// one of them is required
+func (uc *TimezoneUseCase) check(tenantID, branchID string) error {
+	if tenantID == "" && branchID == "" {
+		return errors.New("tenant or branch ID is required")
+	}
+	if tenantID != "" {
+		tenant, err := uc.tenantRepo.GetByID(tenantID)
+		if err != nil {
+			return err
+		}
+		if tenant == nil {
+			return domainerr.ErrTenantNotFound
+		}
+	}
+	if branchID != "" {
+		branch, err := uc.branchRepo.GetByID(branchID)
+		if err != nil {
+			return err
+		}
+		if branch == nil {
+			return domainerr.ErrBranchNotFound
+		}
+	}
+	return nil
+}
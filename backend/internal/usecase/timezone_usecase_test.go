@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockTenantRepository is a mock implementation of TenantRepository
+type MockTenantRepository struct {
+	mock.Mock
+}
+
+func (m *MockTenantRepository) GetByID(id string) (*entities.Tenant, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Tenant), args.Error(1)
+}
+
+func (m *MockTenantRepository) Update(tenant *entities.Tenant) error {
+	args := m.Called(tenant)
+	return args.Error(0)
+}
+
+func TestTimezoneUseCase_Location(t *testing.T) {
+	tenantRepo := &MockTenantRepository{}
+	tenantRepo.On("GetByID", "tokyo").Return(&entities.Tenant{ID: "tokyo", Timezone: "Asia/Tokyo"}, nil)
+	tenantRepo.On("GetByID", "plain").Return(&entities.Tenant{ID: "plain"}, nil)
+	tenantRepo.On("GetByID", "missing").Return(nil, nil)
+	branchRepo := &MockBranchRepository{}
+	branchRepo.On("GetByID", "harbor").Return(&entities.Branch{ID: "harbor", Timezone: "Europe/Berlin"}, nil)
+	branchRepo.On("GetByID", "downtown").Return(&entities.Branch{ID: "downtown"}, nil)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	useCase := NewTimezoneUseCase(tenantRepo, branchRepo, newYork)
+	harbor, downtown := "harbor", "downtown"
+
+	tests := []struct {
+		name     string
+		tenantID string
+		branchID *string
+		expected string
+	}{
+		{name: "branch timezone", tenantID: "tokyo", branchID: &harbor, expected: "Europe/Berlin"},
+		{name: "branch in the timezone of the tenant", tenantID: "tokyo", branchID: &downtown, expected: "Asia/Tokyo"},
+		{name: "tenant timezone", tenantID: "tokyo", expected: "Asia/Tokyo"},
+		{name: "tenant without a timezone", tenantID: "plain", branchID: &downtown, expected: "America/New_York"},
+		{name: "unknown tenant", tenantID: "missing", expected: "America/New_York"},
+		{name: "no tenant", expected: "America/New_York"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, err := useCase.Location(tt.tenantID, tt.branchID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, location.String())
+		})
+	}
+}
+
+func TestTimezoneUseCase_SetTimezone(t *testing.T) {
+	tenant := &entities.Tenant{ID: "tenant-1"}
+	branch := &entities.Branch{ID: "harbor"}
+	tenantRepo := &MockTenantRepository{}
+	tenantRepo.On("GetByID", "tenant-1").Return(tenant, nil)
+	tenantRepo.On("GetByID", "missing").Return(nil, nil)
+	tenantRepo.On("Update", tenant).Return(nil)
+	branchRepo := &MockBranchRepository{}
+	branchRepo.On("GetByID", "harbor").Return(branch, nil)
+	branchRepo.On("GetByID", "missing").Return(nil, nil)
+	branchRepo.On("Update", branch).Return(nil)
+	useCase := NewTimezoneUseCase(tenantRepo, branchRepo, time.UTC)
+
+	timezone, err := useCase.SetTimezone("tenant-1", "", " Asia/Tokyo ")
+	require.NoError(t, err)
+	assert.Equal(t, &entities.Timezone{TenantID: "tenant-1", Name: "Asia/Tokyo", Source: entities.TimezoneFromTenant}, timezone)
+	assert.Equal(t, "Asia/Tokyo", tenant.Timezone)
+
+	timezone, err = useCase.Timezone("tenant-1", "harbor")
+	require.NoError(t, err)
+	assert.Equal(t, entities.TimezoneFromTenant, timezone.Source)
+	timezone, err = useCase.SetTimezone("tenant-1", "harbor", "Europe/Berlin")
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", timezone.Name)
+	assert.Equal(t, entities.TimezoneFromBranch, timezone.Source)
+
+	// Clearing the timezone of the tenant leaves it in the default one
+	timezone, err = useCase.SetTimezone("tenant-1", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", timezone.Name)
+	assert.Equal(t, entities.TimezoneFromDefault, timezone.Source)
+
+	for _, name := range []string{"Mars/Olympus_Mons", "Local"} {
+		_, err = useCase.SetTimezone("tenant-1", "", name)
+		assert.EqualError(t, err, `timezone must be an IANA name such as Asia/Tokyo, got "`+name+`"`)
+	}
+	_, err = useCase.SetTimezone("missing", "", "UTC")
+	assert.ErrorIs(t, err, domainerr.ErrTenantNotFound)
+	_, err = useCase.SetTimezone("tenant-1", "missing", "UTC")
+	assert.ErrorIs(t, err, domainerr.ErrBranchNotFound)
+	_, err = useCase.Timezone("", "")
+	assert.EqualError(t, err, "tenant or branch ID is required")
+	tenantRepo.AssertNumberOfCalls(t, "Update", 2)
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+)
+
+// Scanner checks files for malware
+type Scanner interface {
+	// Name identifies the scanner in scan results
+	Name() string
+	// Scan returns the name of the malware found in data, empty when it is clean
+	Scan(ctx context.Context, data []byte) (string, error)
+}
+
+// disabledScanner is implemented by scanners that check nothing, such as the one used when
+// scanning is off; their files are recorded as skipped rather than clean
+type disabledScanner interface {
+	Disabled() bool
+}
+
+// What to do with an uploaded file the scanner finds malware in, or fails to check
+const (
+	// ScanActionReject refuses the upload
+	ScanActionReject = "reject"
+	// ScanActionAccept stores the upload anyway, recording the scan result on it
+	ScanActionAccept = "accept"
+)
+
+// UploadScanUseCase scans uploaded files before they are stored, and decides whether infected
+// files and files the scanner fails to check are rejected or only recorded as such
+type UploadScanUseCase struct {
+	scanner Scanner
+	timeout time.Duration
+	// onInfected and onError are ScanActionReject or ScanActionAccept
+	onInfected string
+	onError    string
+	clock      clock.Clock
+}
+
+// NewUploadScanUseCase creates a new upload scan use case; scans taking longer than timeout fail
+func NewUploadScanUseCase(scanner Scanner, timeout time.Duration, onInfected, onError string) *UploadScanUseCase {
+	return &UploadScanUseCase{
+		scanner:    scanner,
+		timeout:    timeout,
+		onInfected: onInfected,
+		onError:    onError,
+		clock:      clock.System{},
+	}
+}
+
+// SetClock replaces the clock scans are dated with
+func (uc *UploadScanUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// Scan checks an uploaded file and returns the result to record on its upload record. The result
+// comes with ErrFileInfected or ErrScanUnavailable when the file is to be rejected.
+func (uc *UploadScanUseCase) Scan(kind string, data []byte) (*entities.ScanResult, error) {
+	result := &entities.ScanResult{Scanner: uc.scanner.Name(), ScannedAt: uc.clock.Now()}
+	if disabled, ok := uc.scanner.(disabledScanner); ok && disabled.Disabled() {
+		result.Status = entities.ScanSkipped
+		return result, nil
+	}
+
+	ctx := context.Background()
+	if uc.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, uc.timeout)
+		defer cancel()
+	}
+	signature, err := uc.scanner.Scan(ctx, data)
+	switch {
+	case err != nil:
+		result.Status = entities.ScanFailed
+		result.Error = err.Error()
+		log.Printf("Failed to scan uploaded %s: %v", kind, err)
+		if uc.onError != ScanActionAccept {
+			return result, domainerr.ErrScanUnavailable
+		}
+	case signature != "":
+		result.Status = entities.ScanInfected
+		result.Signature = signature
+		log.Printf("Found %s in uploaded %s", signature, kind)
+		if uc.onInfected != ScanActionAccept {
+			return result, domainerr.ErrFileInfected
+		}
+	default:
+		result.Status = entities.ScanClean
+	}
+	return result, nil
+}
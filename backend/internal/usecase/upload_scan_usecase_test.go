@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeScanner finds the signature it is given in every file, or fails with err
+type fakeScanner struct {
+	signature string
+	err       error
+	disabled  bool
+}
+
+func (s fakeScanner) Name() string { return "fake" }
+
+func (s fakeScanner) Scan(ctx context.Context, data []byte) (string, error) {
+	return s.signature, s.err
+}
+
+func (s fakeScanner) Disabled() bool { return s.disabled }
+
+func TestUploadScanUseCase_Scan(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		scanner    fakeScanner
+		onInfected string
+		onError    string
+		want       entities.ScanResult
+		wantErr    error
+	}{
+		{"clean", fakeScanner{}, ScanActionReject, ScanActionReject, entities.ScanResult{Status: entities.ScanClean}, nil},
+		{"infected rejected", fakeScanner{signature: "Eicar-Test-Signature"}, ScanActionReject, ScanActionAccept,
+			entities.ScanResult{Status: entities.ScanInfected, Signature: "Eicar-Test-Signature"}, domainerr.ErrFileInfected},
+		{"infected accepted", fakeScanner{signature: "Eicar-Test-Signature"}, ScanActionAccept, ScanActionReject,
+			entities.ScanResult{Status: entities.ScanInfected, Signature: "Eicar-Test-Signature"}, nil},
+		{"failure rejected", fakeScanner{err: errors.New("connection refused")}, ScanActionAccept, ScanActionReject,
+			entities.ScanResult{Status: entities.ScanFailed, Error: "connection refused"}, domainerr.ErrScanUnavailable},
+		{"failure accepted", fakeScanner{err: errors.New("connection refused")}, ScanActionReject, ScanActionAccept,
+			entities.ScanResult{Status: entities.ScanFailed, Error: "connection refused"}, nil},
+		{"disabled", fakeScanner{disabled: true, signature: "ignored"}, ScanActionReject, ScanActionReject,
+			entities.ScanResult{Status: entities.ScanSkipped}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := NewUploadScanUseCase(tt.scanner, time.Second, tt.onInfected, tt.onError)
+			useCase.SetClock(clock.NewFixed(now))
+
+			result, err := useCase.Scan("file", []byte("data"))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			tt.want.Scanner = "fake"
+			tt.want.ScannedAt = now
+			assert.Equal(t, &tt.want, result)
+		})
+	}
+}
+
+func TestCoverUseCase_SetCoverRejectsInfectedImages(t *testing.T) {
+	bookRepo := new(MockBookRepository)
+	coverRepo := new(MockCoverRepository)
+	bookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1"}, nil)
+	useCase := NewCoverUseCase(bookRepo, coverRepo, 1024, 0, 0)
+	useCase.SetUploadScanUseCase(NewUploadScanUseCase(fakeScanner{signature: "Eicar-Test-Signature"}, 0, ScanActionReject, ScanActionReject))
+
+	_, err := useCase.SetCover("book-1", encodeCover(t, 2, 2), "")
+	assert.ErrorIs(t, err, domainerr.ErrFileInfected)
+	coverRepo.AssertNotCalled(t, "Attach", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
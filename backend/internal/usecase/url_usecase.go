@@ -1,94 +1,569 @@
 package usecase
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"log"
 	"net/url"
+	"regexp"
+	"sort"
 	"strings"
+	"sync/atomic"
+	"time"
 
+	"library-management-system/internal/domain/clock"
 	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/pagetoken"
 	"library-management-system/internal/domain/repositories"
+	"library-management-system/internal/domain/urlnorm"
 )
 
+// URLProfile is a named bundle of URL operations applied in order, so clients can ask for an
+// outcome instead of maintaining operation lists
+type URLProfile struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Operations  []string `json:"operations"`
+}
+
+// defaultURLProfiles are the profiles available without configuration
+var defaultURLProfiles = []URLProfile{
+	{Name: "seo", Description: "Canonical www.byfood.com URL for search engines and sitemaps", Operations: []string{"canonical", "redirection"}},
+	{Name: "analytics-clean", Description: "Drops tracking parameters and fragments, keeping the rest of the URL", Operations: []string{"strip_tracking", "strip_fragment"}},
+	{Name: "strict", Description: "HTTPS, canonical www.byfood.com URL without query or fragment", Operations: []string{"https", "strip_fragment", "canonical", "redirection"}},
+}
+
+// trackingParameters are the query parameters of ad and analytics attribution; utm_* ones are dropped too
+var trackingParameters = map[string]bool{
+	"gclid": true, "dclid": true, "fbclid": true, "msclkid": true, "yclid": true,
+	"igshid": true, "mc_cid": true, "mc_eid": true, "_ga": true, "_gl": true,
+}
+
+// schemePattern matches URLs starting with a scheme and an authority, such as https://
+var schemePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*://`)
+
+// defaultURLHistory is how many processed URLs are listed by default
+const defaultURLHistory = 50
+
+// urlHistoryBatch is how many processed URLs exports read and prunes delete at a time
+const urlHistoryBatch = 1000
+
+// ErrUnknownOperation is returned for a history filtered by an operation that does not exist
+var ErrUnknownOperation = errors.New("unknown operation")
+
+// URLHistoryQuery filters the history of processed URLs; empty fields match every URL
+type URLHistoryQuery struct {
+	// Domain matches the URLs of a host and of its subdomains
+	Domain string
+	// Operation matches the URLs an operation was applied to, alone or within a profile
+	Operation string
+	// From and To are UTC dates, both included, such as 2024-01-15
+	From string
+	To   string
+}
+
+// URLCacheStats reports how well the cache of processed URLs works
+type URLCacheStats struct {
+	Enabled    bool    `json:"enabled"`
+	Backend    string  `json:"backend,omitempty"`
+	TTLSeconds int     `json:"ttl_seconds"`
+	Hits       int64   `json:"hits"`
+	Misses     int64   `json:"misses"`
+	Errors     int64   `json:"errors"`
+	HitRatio   float64 `json:"hit_ratio"`
+}
+
 // URLUseCase handles URL processing business logic
 type URLUseCase struct {
-	urlRepo repositories.URLRepository
+	urlRepo  repositories.URLRepository
+	profiles map[string]URLProfile
+	// defaultOperation is applied to requests with neither operation nor profile
+	defaultOperation string
+	// strict rejects URLs without a scheme instead of guessing one
+	strict bool
+
+	cacheBackend string
+	cacheTTL     time.Duration
+	cacheHits    atomic.Int64
+	cacheMisses  atomic.Int64
+	cacheErrors  atomic.Int64
+
+	clock   clock.Clock
+	cursors pageCursors
 }
 
 // NewURLUseCase creates a new URL use case
 func NewURLUseCase(urlRepo repositories.URLRepository) *URLUseCase {
+	profiles := make(map[string]URLProfile, len(defaultURLProfiles))
+	for _, profile := range defaultURLProfiles {
+		profiles[profile.Name] = profile
+	}
 	return &URLUseCase{
-		urlRepo: urlRepo,
+		urlRepo:  urlRepo,
+		profiles: profiles,
+		clock:    clock.System{},
+		cursors:  pageCursors{listing: "url_history"},
 	}
 }
 
-// ProcessURL processes a URL according to the specified operation
+// SetCursorSigner signs the cursors of the pages of the history with signer
+func (uc *URLUseCase) SetCursorSigner(signer *pagetoken.Signer) {
+	uc.cursors.signer = signer
+}
+
+// SetClock replaces the clock the dates of history filters are read with
+func (uc *URLUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// SetProfiles adds profiles mapping a name to its operations, replacing defaults of the same name
+func (uc *URLUseCase) SetProfiles(profiles map[string][]string) error {
+	for name, operations := range profiles {
+		if len(operations) == 0 {
+			return fmt.Errorf("profile %s has no operations", name)
+		}
+		for _, operation := range operations {
+			if uc.operation(operation) == nil {
+				return fmt.Errorf("profile %s: invalid operation type %s", name, operation)
+			}
+		}
+		uc.profiles[name] = URLProfile{Name: name, Operations: operations}
+	}
+	return nil
+}
+
+// SetDefaultOperation applies operation to requests naming neither an operation nor a profile;
+// empty requires one of them
+func (uc *URLUseCase) SetDefaultOperation(operation string) error {
+	if operation != "" && uc.operation(operation) == nil {
+		return fmt.Errorf("invalid operation type %s", operation)
+	}
+	uc.defaultOperation = operation
+	return nil
+}
+
+// SetStrict rejects URLs without a scheme, such as byfood.com/tours, which are otherwise taken
+// for https ones
+func (uc *URLUseCase) SetStrict(strict bool) {
+	uc.strict = strict
+}
+
+// SetCache keeps processed URLs in the cache of the repository for ttl, so repeated lookups skip
+// processing. backend names the cache in the stats.
+func (uc *URLUseCase) SetCache(backend string, ttl time.Duration) {
+	uc.cacheBackend = backend
+	uc.cacheTTL = ttl
+}
+
+// CacheStats returns the hits and misses of the cache since the server started
+func (uc *URLUseCase) CacheStats() URLCacheStats {
+	stats := URLCacheStats{
+		Enabled:    uc.cacheTTL > 0,
+		Backend:    uc.cacheBackend,
+		TTLSeconds: int(uc.cacheTTL.Seconds()),
+		Hits:       uc.cacheHits.Load(),
+		Misses:     uc.cacheMisses.Load(),
+		Errors:     uc.cacheErrors.Load(),
+	}
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(lookups)
+	}
+	return stats
+}
+
+// ListProfiles returns the URL profiles sorted by name
+func (uc *URLUseCase) ListProfiles() []URLProfile {
+	profiles := make([]URLProfile, 0, len(uc.profiles))
+	for _, profile := range uc.profiles {
+		profiles = append(profiles, profile)
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+	return profiles
+}
+
+// ProcessURL processes a URL according to the specified operation, or the operations of a profile
 func (uc *URLUseCase) ProcessURL(request *entities.URLRequest) (*entities.URLResponse, error) {
+	result, err := uc.process(request)
+	if err != nil {
+		return nil, err
+	}
+	uc.save(result)
+	return &entities.URLResponse{ProcessedURL: result.ProcessedURL, Cached: result.Cached}, nil
+}
+
+// URLBatchResult is what became of a URL of a batch: its response, or the error it failed with
+type URLBatchResult struct {
+	// Index is the position of the URL in the batch
+	Index    int
+	Response *entities.URLResponse
+	Err      error
+	// Aborted is set when the URL was processed but dropped, as other URLs of an atomic batch failed
+	Aborted bool
+}
+
+// ProcessURLs processes the URLs of a batch one by one, as ProcessURL does. With atomic, a URL
+// failing drops the results of the others, and none is recorded.
+func (uc *URLUseCase) ProcessURLs(requests []entities.URLRequest, atomic bool) []URLBatchResult {
+	results := make([]URLBatchResult, len(requests))
+	processed := make([]*entities.URLResult, len(requests))
+	failed := false
+	for i := range requests {
+		results[i].Index = i
+		processed[i], results[i].Err = uc.process(&requests[i])
+		failed = failed || results[i].Err != nil
+	}
+
+	for i, result := range processed {
+		switch {
+		case result == nil:
+		case atomic && failed:
+			results[i].Aborted = true
+		default:
+			uc.save(result)
+			results[i].Response = &entities.URLResponse{ProcessedURL: result.ProcessedURL, Cached: result.Cached}
+		}
+	}
+	return results
+}
+
+// process works out the result of a request, from the cache when it has it
+func (uc *URLUseCase) process(request *entities.URLRequest) (*entities.URLResult, error) {
 	// Validate input
 	if request.URL == "" {
 		return nil, errors.New("URL is required")
 	}
 
-	if request.Operation == "" {
-		return nil, errors.New("operation is required")
+	operations, err := uc.resolve(request.Operation, request.Profile)
+	if err != nil {
+		return nil, err
 	}
 
-	// Validate operation type
-	validOperations := []string{"canonical", "redirection", "all"}
-	isValidOperation := false
-	for _, op := range validOperations {
-		if request.Operation == op {
-			isValidOperation = true
-			break
-		}
+	options, err := checkOptions(request.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	rawURL, err := uc.withScheme(request.URL)
+	if err != nil {
+		return nil, err
 	}
-	if !isValidOperation {
-		return nil, errors.New("invalid operation type")
+
+	result := &entities.URLResult{
+		Key:        cacheKey(rawURL, operations, options),
+		URL:        request.URL,
+		Host:       urlHost(rawURL),
+		Operations: strings.Join(operations, ","),
+		Profile:    request.Profile,
+	}
+	if processedURL, ok := uc.cached(result.Key); ok {
+		result.ProcessedURL, result.Cached = processedURL, true
+		return result, nil
 	}
 
 	// Parse the URL
-	parsedURL, err := url.Parse(request.URL)
+	parsedURL, err := url.Parse(rawURL)
 	if err != nil {
 		return nil, errors.New("invalid URL format")
 	}
 
-	var processedURL string
+	// Each operation works on the URL the previous one produced
+	processedURL := uc.apply(operations[0], parsedURL, options)
+	for _, operation := range operations[1:] {
+		parsedURL, err := url.Parse(processedURL)
+		if err != nil {
+			return nil, errors.New("invalid URL format")
+		}
+		processedURL = uc.apply(operation, parsedURL, options)
+	}
+
+	result.ProcessedURL = processedURL
+	return result, nil
+}
+
+// History returns the latest processed URLs matching a query, newest first, and the cursor of the
+// next page, empty on the last one
+func (uc *URLUseCase) History(query URLHistoryQuery, limit int, cursor string) ([]entities.URLResult, string, error) {
+	if limit < 1 {
+		limit = defaultURLHistory
+	}
+	filter, err := uc.historyFilter(query)
+	if err != nil {
+		return nil, "", err
+	}
+	fingerprint := pagetoken.Query("domain="+filter.Domain, "operation="+filter.Operation, "from="+query.From, "to="+query.To)
+	after, err := uc.cursors.after(cursor, fingerprint)
+	if err != nil {
+		return nil, "", err
+	}
+
+	results, err := uc.urlRepo.History(filter, after, limit+1)
+	if err != nil || len(results) <= limit {
+		return results, "", err
+	}
+	results = results[:limit]
+	last := results[limit-1]
+	return results, uc.cursors.next(fingerprint, last.CreatedAt, last.ID), nil
+}
+
+// ExportHistory reads every processed URL matching a query, newest first, handing them to write
+// a batch at a time so exports of any size are not held in memory. The query is checked before
+// the first batch is read.
+func (uc *URLUseCase) ExportHistory(query URLHistoryQuery, write func([]entities.URLResult) error) error {
+	filter, err := uc.historyFilter(query)
+	if err != nil {
+		return err
+	}
+	var after *repositories.PageAfter
+	for {
+		results, err := uc.urlRepo.History(filter, after, urlHistoryBatch)
+		if err != nil {
+			return err
+		}
+		if len(results) > 0 {
+			if err := write(results); err != nil {
+				return err
+			}
+		}
+		if len(results) < urlHistoryBatch {
+			return nil
+		}
+		last := results[len(results)-1]
+		after = &repositories.PageAfter{Time: last.CreatedAt, ID: last.ID}
+	}
+}
+
+// CheckHistoryQuery reports whether a query of the history is valid
+func (uc *URLUseCase) CheckHistoryQuery(query URLHistoryQuery) error {
+	_, err := uc.historyFilter(query)
+	return err
+}
+
+// PruneHistory deletes the processed URLs recorded before a time, a batch at a time, and returns
+// how many it deleted
+func (uc *URLUseCase) PruneHistory(before time.Time) (int64, error) {
+	var pruned int64
+	for {
+		deleted, err := uc.urlRepo.DeleteBefore(before, urlHistoryBatch)
+		pruned += deleted
+		if err != nil || deleted < urlHistoryBatch {
+			return pruned, err
+		}
+	}
+}
+
+// historyFilter turns a query of the history into the filter of the repository
+func (uc *URLUseCase) historyFilter(query URLHistoryQuery) (repositories.URLHistoryFilter, error) {
+	filter := repositories.URLHistoryFilter{
+		Domain:    strings.TrimSuffix(strings.ToLower(strings.TrimSpace(query.Domain)), "."),
+		Operation: strings.TrimSpace(query.Operation),
+	}
+	if filter.Operation != "" && uc.operation(filter.Operation) == nil {
+		return filter, ErrUnknownOperation
+	}
+	if query.From != "" || query.To != "" {
+		from, to, err := dateRange(query.From, query.To, uc.clock.Now())
+		if err != nil {
+			return filter, err
+		}
+		filter.From, filter.To = from, to
+	}
+	return filter, nil
+}
+
+// urlHost returns the lower-case host of a URL, empty when it has none
+func urlHost(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
+
+// resolve returns the operations a request applies: the one given, or those of a profile
+func (uc *URLUseCase) resolve(operation, profile string) ([]string, error) {
+	switch {
+	case operation != "" && profile != "":
+		return nil, errors.New("operation and profile cannot be combined")
+	case profile != "":
+		bundle, ok := uc.profiles[profile]
+		if !ok {
+			return nil, errors.New("unknown profile")
+		}
+		return bundle.Operations, nil
+	case operation != "":
+		if uc.operation(operation) == nil {
+			return nil, errors.New("invalid operation type")
+		}
+		return []string{operation}, nil
+	case uc.defaultOperation != "":
+		return []string{uc.defaultOperation}, nil
+	}
+	return nil, errors.New("operation is required")
+}
+
+// withScheme returns a URL with its scheme. URLs without one, such as byfood.com/tours or
+// //byfood.com/tours, are rejected in strict mode and taken for https ones otherwise; paths
+// such as /tours, and malformed schemes, are left for parsing to deal with.
+func (uc *URLUseCase) withScheme(rawURL string) (string, error) {
+	if schemePattern.MatchString(rawURL) {
+		return rawURL, nil
+	}
+	if uc.strict {
+		return "", errors.New("URL must start with a scheme such as https://")
+	}
+	if strings.Contains(rawURL, "://") || strings.HasPrefix(rawURL, "/") && !strings.HasPrefix(rawURL, "//") {
+		return rawURL, nil
+	}
+	return "https://" + strings.TrimPrefix(rawURL, "//"), nil
+}
+
+// cached returns the cached result of a lookup. Cache failures count as misses, so processing
+// still works while the cache is down.
+func (uc *URLUseCase) cached(key string) (string, bool) {
+	if uc.cacheTTL <= 0 {
+		return "", false
+	}
+	processedURL, ok, err := uc.urlRepo.GetCached(key)
+	if err != nil {
+		uc.cacheErrors.Add(1)
+		log.Printf("Failed to read URL cache: %v", err)
+	}
+	if err != nil || !ok {
+		uc.cacheMisses.Add(1)
+		return "", false
+	}
+	uc.cacheHits.Add(1)
+	return processedURL, true
+}
+
+// save records a result in the history, caching it unless it came from the cache. Failures are
+// only logged, as the URL has been processed all the same.
+func (uc *URLUseCase) save(result *entities.URLResult) {
+	ttl := uc.cacheTTL
+	if result.Cached {
+		ttl = 0
+	}
+	if err := uc.urlRepo.SaveResult(result, ttl); err != nil {
+		if ttl > 0 {
+			uc.cacheErrors.Add(1)
+		}
+		log.Printf("Failed to save processed URL: %v", err)
+	}
+}
+
+// pathEncodings maps the path encodings of requests to those of lowercasing
+var pathEncodings = map[string]urlnorm.PathEncoding{
+	"":                             urlnorm.EncodingLowercased,
+	entities.PathEncodingLowercase: urlnorm.EncodingLowercased,
+	entities.PathEncodingStrict:    urlnorm.EncodingNormalized,
+	entities.PathEncodingDecode:    urlnorm.EncodingDecoded,
+}
+
+// urlOptions are the options of a request once checked
+type urlOptions struct {
+	canonical    urlnorm.CanonicalOptions
+	pathEncoding urlnorm.PathEncoding
+}
 
-	switch request.Operation {
+// checkOptions checks the options of a request, nil standing for the defaults
+func checkOptions(options *entities.URLOptions) (urlOptions, error) {
+	if options == nil {
+		return urlOptions{}, nil
+	}
+	if options.RootPath != "" && options.RootPath != entities.RootPathSlash && options.RootPath != entities.RootPathEmpty {
+		return urlOptions{}, errors.New("root_path must be slash or empty")
+	}
+	pathEncoding, ok := pathEncodings[options.PathEncoding]
+	if !ok {
+		return urlOptions{}, errors.New("path_encoding must be lowercase, strict or decode")
+	}
+	return urlOptions{
+		canonical: urlnorm.CanonicalOptions{
+			StripDefaultPort:  options.StripDefaultPort,
+			CollapseSlashes:   options.CollapseSlashes,
+			RemoveDotSegments: options.RemoveDotSegments,
+			EmptyRoot:         options.RootPath == entities.RootPathEmpty,
+		},
+		pathEncoding: pathEncoding,
+	}, nil
+}
+
+// cacheKey identifies a lookup by the URL and the operations it resolves to rather than the
+// profile or operation named, so requests amounting to the same processing share results.
+// Options only count when set, leaving the keys of lookups without them as they were.
+func cacheKey(rawURL string, operations []string, options urlOptions) string {
+	material := strings.Join(operations, ",") + "\n" + rawURL
+	if options != (urlOptions{}) {
+		material += fmt.Sprintf("\n%+v", options)
+	}
+	sum := sha256.Sum256([]byte(material))
+	return hex.EncodeToString(sum[:])
+}
+
+// apply runs an operation on a URL, canonical processing and lowercasing following options
+func (uc *URLUseCase) apply(operation string, parsedURL *url.URL, options urlOptions) string {
+	switch operation {
 	case "canonical":
-		processedURL = uc.processCanonical(parsedURL)
+		return uc.canonicalize(parsedURL, options.canonical)
 	case "redirection":
-		processedURL = uc.processRedirection(parsedURL)
+		return uc.redirect(parsedURL, options.pathEncoding)
 	case "all":
-		processedURL = uc.processAll(parsedURL)
+		canonicalParsedURL, _ := url.Parse(uc.canonicalize(parsedURL, options.canonical))
+		return uc.redirect(canonicalParsedURL, options.pathEncoding)
 	}
+	return uc.operation(operation)(parsedURL)
+}
 
-	return &entities.URLResponse{
-		ProcessedURL: processedURL,
-	}, nil
+// operation returns the processing of an operation type, or nil for invalid types
+func (uc *URLUseCase) operation(operation string) func(*url.URL) string {
+	switch operation {
+	case "canonical":
+		return uc.processCanonical
+	case "redirection":
+		return uc.processRedirection
+	case "all":
+		return uc.processAll
+	case "strip_tracking":
+		return uc.processStripTracking
+	case "strip_fragment":
+		return uc.processStripFragment
+	case "https":
+		return uc.processHTTPS
+	case "sort_query":
+		return uc.processSortQuery
+	}
+	return nil
 }
 
 // processCanonical removes query parameters and trailing slashes
 func (uc *URLUseCase) processCanonical(parsedURL *url.URL) string {
-	// Remove query parameters
-	parsedURL.RawQuery = ""
+	return uc.canonicalize(parsedURL, urlnorm.CanonicalOptions{})
+}
 
-	// Remove trailing slashes from path
-	path := strings.TrimRight(parsedURL.Path, "/")
-	if path == "" {
-		path = "/"
-	}
-	parsedURL.Path = path
+// canonicalize removes query parameters and trailing slashes, and whatever else options ask for
+func (uc *URLUseCase) canonicalize(parsedURL *url.URL, options urlnorm.CanonicalOptions) string {
+	urlnorm.Canonicalize(parsedURL, options)
 
 	return parsedURL.String()
 }
 
 // processRedirection ensures domain is www.byfood.com and converts to lowercase
 func (uc *URLUseCase) processRedirection(parsedURL *url.URL) string {
+	return uc.redirect(parsedURL, urlnorm.EncodingLowercased)
+}
+
+// redirect ensures domain is www.byfood.com and converts to lowercase, treating the
+// percent-encodings of the path by encoding
+func (uc *URLUseCase) redirect(parsedURL *url.URL, encoding urlnorm.PathEncoding) string {
 	// Set domain to www.byfood.com
 	parsedURL.Host = "www.byfood.com"
 
 	// Convert entire URL to lowercase
-	return strings.ToLower(parsedURL.String())
+	return urlnorm.Lowercase(parsedURL, encoding)
 }
 
 // processAll applies both canonical and redirection processing
@@ -102,3 +577,49 @@ func (uc *URLUseCase) processAll(parsedURL *url.URL) string {
 	// Then apply redirection processing
 	return uc.processRedirection(canonicalParsedURL)
 }
+
+// processStripTracking removes utm_* and click ID parameters, keeping the order of the others
+func (uc *URLUseCase) processStripTracking(parsedURL *url.URL) string {
+	var kept []string
+	for _, parameter := range strings.Split(parsedURL.RawQuery, "&") {
+		if parameter == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(parameter, "=")
+		if unescaped, err := url.QueryUnescape(name); err == nil {
+			name = unescaped
+		}
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, "utm_") || trackingParameters[name] {
+			continue
+		}
+		kept = append(kept, parameter)
+	}
+	parsedURL.RawQuery = strings.Join(kept, "&")
+
+	return parsedURL.String()
+}
+
+// processStripFragment removes the fragment
+func (uc *URLUseCase) processStripFragment(parsedURL *url.URL) string {
+	parsedURL.Fragment = ""
+	parsedURL.RawFragment = ""
+
+	return parsedURL.String()
+}
+
+// processHTTPS upgrades http URLs to https
+func (uc *URLUseCase) processHTTPS(parsedURL *url.URL) string {
+	if strings.EqualFold(parsedURL.Scheme, "http") {
+		parsedURL.Scheme = "https"
+	}
+
+	return parsedURL.String()
+}
+
+// processSortQuery orders the query parameters by name, so equivalent URLs compare equal
+func (uc *URLUseCase) processSortQuery(parsedURL *url.URL) string {
+	parsedURL.RawQuery = parsedURL.Query().Encode()
+
+	return parsedURL.String()
+}
@@ -1,10 +1,18 @@
 package usecase
 
 import (
+	"errors"
+	"fmt"
 	"net/url"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"library-management-system/internal/domain/clock"
 	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+	"library-management-system/internal/domain/urlnorm"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -15,24 +23,74 @@ type MockURLRepository struct {
 	mock.Mock
 }
 
-func (m *MockURLRepository) ProcessURL(request *entities.URLRequest) (*entities.URLResponse, error) {
-	args := m.Called(request)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
+func (m *MockURLRepository) SaveResult(result *entities.URLResult, ttl time.Duration) error {
+	args := m.Called(result, ttl)
+	return args.Error(0)
+}
+
+func (m *MockURLRepository) GetCached(key string) (string, bool, error) {
+	args := m.Called(key)
+	return args.String(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockURLRepository) History(filter repositories.URLHistoryFilter, after *repositories.PageAfter, limit int) ([]entities.URLResult, error) {
+	args := m.Called(filter, after, limit)
+	return args.Get(0).([]entities.URLResult), args.Error(1)
+}
+
+func (m *MockURLRepository) DeleteBefore(before time.Time, limit int) (int64, error) {
+	args := m.Called(before, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// memoryURLRepository records processed URLs newest first, without a cache
+type memoryURLRepository struct {
+	results []entities.URLResult
+}
+
+func (r *memoryURLRepository) SaveResult(result *entities.URLResult, ttl time.Duration) error {
+	r.results = append([]entities.URLResult{*result}, r.results...)
+	return nil
+}
+
+func (r *memoryURLRepository) GetCached(key string) (string, bool, error) {
+	return "", false, nil
+}
+
+func (r *memoryURLRepository) History(filter repositories.URLHistoryFilter, after *repositories.PageAfter, limit int) ([]entities.URLResult, error) {
+	var results []entities.URLResult
+	for _, result := range r.results {
+		if after != nil && (result.CreatedAt.After(after.Time) || result.CreatedAt.Equal(after.Time) && result.ID >= after.ID) {
+			continue
+		}
+		if filter.Domain != "" && result.Host != filter.Domain && !strings.HasSuffix(result.Host, "."+filter.Domain) {
+			continue
+		}
+		if filter.Operation != "" && !slices.Contains(strings.Split(result.Operations, ","), filter.Operation) {
+			continue
+		}
+		if !filter.From.IsZero() && result.CreatedAt.Before(filter.From) || !filter.To.IsZero() && !result.CreatedAt.Before(filter.To) {
+			continue
+		}
+		results = append(results, result)
 	}
-	return args.Get(0).(*entities.URLResponse), args.Error(1)
+	return results[:min(limit, len(results))], nil
+}
+
+func (r *memoryURLRepository) DeleteBefore(before time.Time, limit int) (int64, error) {
+	return 0, nil
 }
 
 func TestNewURLUseCase(t *testing.T) {
-	mockRepo := &MockURLRepository{}
-	useCase := NewURLUseCase(mockRepo)
+	repo := &memoryURLRepository{}
+	useCase := NewURLUseCase(repo)
 
 	assert.NotNil(t, useCase)
-	assert.Equal(t, mockRepo, useCase.urlRepo)
+	assert.Equal(t, repo, useCase.urlRepo)
 }
 
 func TestURLUseCase_ProcessURL(t *testing.T) {
-	useCase := NewURLUseCase(&MockURLRepository{})
+	useCase := NewURLUseCase(&memoryURLRepository{})
 
 	tests := []struct {
 		name           string
@@ -390,3 +448,431 @@ func TestURLUseCase_processAll(t *testing.T) {
 		})
 	}
 }
+
+func TestURLUseCase_ProcessURLWithProfile(t *testing.T) {
+	useCase := NewURLUseCase(&memoryURLRepository{})
+
+	tests := []struct {
+		name          string
+		request       *entities.URLRequest
+		expectedURL   string
+		expectedError string
+	}{
+		{
+			name:        "seo profile",
+			request:     &entities.URLRequest{URL: "https://BYFOOD.com/food-EXPeriences?query=abc/", Profile: "seo"},
+			expectedURL: "https://www.byfood.com/food-experiences",
+		},
+		{
+			name:        "analytics-clean profile",
+			request:     &entities.URLRequest{URL: "https://byfood.com/tours?city=Kyoto&utm_source=newsletter&UTM_Medium=email&gclid=abc&page=2#reviews", Profile: "analytics-clean"},
+			expectedURL: "https://byfood.com/tours?city=Kyoto&page=2",
+		},
+		{
+			name:        "strict profile",
+			request:     &entities.URLRequest{URL: "http://BYFOOD.com/Tours/?utm_source=ads#top", Profile: "strict"},
+			expectedURL: "https://www.byfood.com/tours",
+		},
+		{
+			name:          "unknown profile",
+			request:       &entities.URLRequest{URL: "https://byfood.com", Profile: "aggressive"},
+			expectedError: "unknown profile",
+		},
+		{
+			name:          "profile with operation",
+			request:       &entities.URLRequest{URL: "https://byfood.com", Operation: "canonical", Profile: "seo"},
+			expectedError: "operation and profile cannot be combined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := useCase.ProcessURL(tt.request)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedURL, result.ProcessedURL)
+		})
+	}
+}
+
+func TestURLUseCase_SetProfiles(t *testing.T) {
+	useCase := NewURLUseCase(&memoryURLRepository{})
+
+	assert.NoError(t, useCase.SetProfiles(map[string][]string{
+		"share": {"strip_tracking", "sort_query"},
+		"seo":   {"canonical"},
+	}))
+	result, err := useCase.ProcessURL(&entities.URLRequest{URL: "https://byfood.com/tours?page=2&city=Kyoto&fbclid=x", Profile: "share"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://byfood.com/tours?city=Kyoto&page=2", result.ProcessedURL)
+
+	result, err = useCase.ProcessURL(&entities.URLRequest{URL: "https://BYFOOD.com/Tours/?page=2", Profile: "seo"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://BYFOOD.com/Tours", result.ProcessedURL, "configured profiles replace defaults")
+
+	names := []string{}
+	for _, profile := range useCase.ListProfiles() {
+		names = append(names, profile.Name)
+	}
+	assert.Equal(t, []string{"analytics-clean", "seo", "share", "strict"}, names)
+
+	assert.EqualError(t, useCase.SetProfiles(map[string][]string{"broken": {"shorten"}}), "profile broken: invalid operation type shorten")
+	assert.EqualError(t, useCase.SetProfiles(map[string][]string{"empty": {}}), "profile empty has no operations")
+}
+
+func TestURLUseCase_SetDefaultOperation(t *testing.T) {
+	useCase := NewURLUseCase(&memoryURLRepository{})
+
+	assert.EqualError(t, useCase.SetDefaultOperation("shorten"), "invalid operation type shorten")
+	assert.NoError(t, useCase.SetDefaultOperation("all"))
+	result, err := useCase.ProcessURL(&entities.URLRequest{URL: "https://BYFOOD.com/Tours/?page=2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://www.byfood.com/tours", result.ProcessedURL)
+
+	result, err = useCase.ProcessURL(&entities.URLRequest{URL: "https://BYFOOD.com/Tours/?page=2", Operation: "canonical"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://BYFOOD.com/Tours", result.ProcessedURL, "an operation sent overrides the default")
+
+	assert.NoError(t, useCase.SetDefaultOperation(""))
+	_, err = useCase.ProcessURL(&entities.URLRequest{URL: "https://BYFOOD.com/Tours/?page=2"})
+	assert.EqualError(t, err, "operation is required")
+}
+
+func TestURLUseCase_SetStrict(t *testing.T) {
+	repo := &memoryURLRepository{}
+	useCase := NewURLUseCase(repo)
+
+	// Lenient processing takes URLs without a scheme for https ones
+	for _, rawURL := range []string{"BYFOOD.com/Tours/?page=2", "//BYFOOD.com/Tours/?page=2"} {
+		result, err := useCase.ProcessURL(&entities.URLRequest{URL: rawURL, Operation: "canonical"})
+		assert.NoError(t, err)
+		assert.Equal(t, "https://BYFOOD.com/Tours", result.ProcessedURL, rawURL)
+	}
+	history, _, _ := useCase.History(URLHistoryQuery{}, 1, "")
+	assert.Equal(t, "//BYFOOD.com/Tours/?page=2", history[0].URL, "the history keeps the URL as sent")
+	assert.Equal(t, "byfood.com", history[0].Host)
+
+	useCase.SetStrict(true)
+	for _, rawURL := range []string{"BYFOOD.com/Tours/?page=2", "//BYFOOD.com/Tours/?page=2", "/tours"} {
+		_, err := useCase.ProcessURL(&entities.URLRequest{URL: rawURL, Operation: "canonical"})
+		assert.EqualError(t, err, "URL must start with a scheme such as https://", rawURL)
+	}
+	result, err := useCase.ProcessURL(&entities.URLRequest{URL: "http://BYFOOD.com/Tours/?page=2", Operation: "canonical"})
+	assert.NoError(t, err)
+	assert.Equal(t, "http://BYFOOD.com/Tours", result.ProcessedURL)
+}
+
+func TestURLUseCase_Cache(t *testing.T) {
+	repo := &MockURLRepository{}
+	useCase := NewURLUseCase(repo)
+	useCase.SetCache("memory", time.Hour)
+
+	rawURL := "https://BYFOOD.com/Tours/?page=2"
+	key := cacheKey(rawURL, []string{"canonical", "redirection"}, urlOptions{})
+	repo.On("GetCached", key).Return("", false, nil).Once()
+	repo.On("SaveResult", &entities.URLResult{Key: key, URL: rawURL, Host: "byfood.com", Operations: "canonical,redirection", Profile: "seo", ProcessedURL: "https://www.byfood.com/tours"}, time.Hour).Return(nil).Once()
+
+	result, err := useCase.ProcessURL(&entities.URLRequest{URL: rawURL, Profile: "seo"})
+	assert.NoError(t, err)
+	assert.Equal(t, &entities.URLResponse{ProcessedURL: "https://www.byfood.com/tours"}, result)
+
+	// A profile and the operations it stands for share the cached result, which is recorded
+	// without caching it again
+	useCase.SetProfiles(map[string][]string{"seo-copy": {"canonical", "redirection"}})
+	repo.On("GetCached", key).Return("https://www.byfood.com/tours", true, nil).Once()
+	repo.On("SaveResult", &entities.URLResult{Key: key, URL: rawURL, Host: "byfood.com", Operations: "canonical,redirection", Profile: "seo-copy", ProcessedURL: "https://www.byfood.com/tours", Cached: true}, time.Duration(0)).Return(nil).Once()
+	result, err = useCase.ProcessURL(&entities.URLRequest{URL: rawURL, Profile: "seo-copy"})
+	assert.NoError(t, err)
+	assert.Equal(t, &entities.URLResponse{ProcessedURL: "https://www.byfood.com/tours", Cached: true}, result)
+
+	// Cache failures fall back to processing
+	otherKey := cacheKey(rawURL, []string{"canonical"}, urlOptions{})
+	repo.On("GetCached", otherKey).Return("", false, errors.New("connection refused")).Once()
+	repo.On("SaveResult", mock.MatchedBy(func(result *entities.URLResult) bool { return result.Key == otherKey }), time.Hour).Return(errors.New("connection refused")).Once()
+	result, err = useCase.ProcessURL(&entities.URLRequest{URL: rawURL, Operation: "canonical"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://BYFOOD.com/Tours", result.ProcessedURL)
+
+	// Invalid requests never reach the repository
+	_, err = useCase.ProcessURL(&entities.URLRequest{URL: rawURL, Operation: "shorten"})
+	assert.EqualError(t, err, "invalid operation type")
+
+	repo.AssertExpectations(t)
+	assert.Equal(t, URLCacheStats{Enabled: true, Backend: "memory", TTLSeconds: 3600, Hits: 1, Misses: 2, Errors: 2, HitRatio: 1.0 / 3}, useCase.CacheStats())
+	assert.Equal(t, URLCacheStats{}, NewURLUseCase(&memoryURLRepository{}).CacheStats())
+}
+
+func TestURLUseCase_History(t *testing.T) {
+	useCase := NewURLUseCase(&memoryURLRepository{})
+
+	_, err := useCase.ProcessURL(&entities.URLRequest{URL: "https://byfood.com/tours/", Operation: "canonical"})
+	assert.NoError(t, err)
+	_, err = useCase.ProcessURL(&entities.URLRequest{URL: "https://byfood.com", Operation: "shorten"})
+	assert.Error(t, err)
+	_, err = useCase.ProcessURL(&entities.URLRequest{URL: "http://byfood.com/tours#top", Profile: "strict"})
+	assert.NoError(t, err)
+
+	results, next, err := useCase.History(URLHistoryQuery{}, 0, "")
+	assert.NoError(t, err)
+	assert.Empty(t, next)
+	assert.Equal(t, []entities.URLResult{
+		{Key: cacheKey("http://byfood.com/tours#top", []string{"https", "strip_fragment", "canonical", "redirection"}, urlOptions{}), URL: "http://byfood.com/tours#top", Host: "byfood.com", Operations: "https,strip_fragment,canonical,redirection", Profile: "strict", ProcessedURL: "https://www.byfood.com/tours"},
+		{Key: cacheKey("https://byfood.com/tours/", []string{"canonical"}, urlOptions{}), URL: "https://byfood.com/tours/", Host: "byfood.com", Operations: "canonical", ProcessedURL: "https://byfood.com/tours"},
+	}, results, "only processed URLs are recorded, newest first")
+
+	results, _, err = useCase.History(URLHistoryQuery{}, 1, "")
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+}
+
+func TestURLUseCase_HistoryFilters(t *testing.T) {
+	repo := &MockURLRepository{}
+	useCase := NewURLUseCase(repo)
+	useCase.SetClock(clock.NewFixed(time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)))
+
+	// Domains are matched in lower case, and dates from the start of from to the end of today
+	filter := repositories.URLHistoryFilter{Domain: "byfood.com", Operation: "canonical", From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC)}
+	repo.On("History", filter, (*repositories.PageAfter)(nil), 3).Return([]entities.URLResult{{ID: "url-3"}, {ID: "url-2"}, {ID: "url-1"}}, nil).Once()
+	results, _, err := useCase.History(URLHistoryQuery{Domain: "ByFood.com.", Operation: "canonical", From: "2024-01-01"}, 2, "")
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	_, _, err = useCase.History(URLHistoryQuery{Operation: "shorten"}, 0, "")
+	assert.ErrorIs(t, err, ErrUnknownOperation)
+	_, _, err = useCase.History(URLHistoryQuery{From: "2024-01-31", To: "2024-01-01"}, 0, "")
+	assert.ErrorIs(t, err, ErrInvalidDateRange)
+	assert.ErrorIs(t, useCase.CheckHistoryQuery(URLHistoryQuery{From: "yesterday"}), ErrInvalidDateRange)
+	repo.AssertExpectations(t)
+}
+
+func TestURLUseCase_HistoryInternationalDomain(t *testing.T) {
+	useCase := NewURLUseCase(&memoryURLRepository{})
+	for _, rawURL := range []string{"https://Bücher.de/", "https://shop.Bücher.de/romane", "https://xbücher.de/"} {
+		_, err := useCase.ProcessURL(&entities.URLRequest{URL: rawURL, Operation: "canonical"})
+		assert.NoError(t, err)
+	}
+
+	// International hosts are kept in Unicode and matched whatever their case, subdomains included
+	results, _, err := useCase.History(URLHistoryQuery{Domain: "BÜCHER.de"}, 0, "")
+	assert.NoError(t, err)
+	hosts := []string{}
+	for _, result := range results {
+		hosts = append(hosts, result.Host)
+	}
+	assert.ElementsMatch(t, []string{"bücher.de", "shop.bücher.de"}, hosts)
+}
+
+func TestURLUseCase_ExportHistory(t *testing.T) {
+	createdAt := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	full := make([]entities.URLResult, urlHistoryBatch)
+	for i := range full {
+		full[i] = entities.URLResult{ID: fmt.Sprintf("url-%04d", urlHistoryBatch-i), CreatedAt: createdAt}
+	}
+	repo := &MockURLRepository{}
+	repo.On("History", repositories.URLHistoryFilter{}, (*repositories.PageAfter)(nil), urlHistoryBatch).Return(full, nil).Once()
+	repo.On("History", repositories.URLHistoryFilter{}, &repositories.PageAfter{Time: createdAt, ID: "url-0001"}, urlHistoryBatch).Return([]entities.URLResult{{ID: "url-0000"}}, nil).Once()
+	useCase := NewURLUseCase(repo)
+
+	// Batches continue after the last result of the one before, until one comes up short
+	exported := 0
+	err := useCase.ExportHistory(URLHistoryQuery{}, func(results []entities.URLResult) error {
+		exported += len(results)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, urlHistoryBatch+1, exported)
+	repo.AssertExpectations(t)
+}
+
+func TestURLUseCase_PruneHistory(t *testing.T) {
+	before := time.Date(2023, 10, 17, 0, 0, 0, 0, time.UTC)
+	repo := &MockURLRepository{}
+	repo.On("DeleteBefore", before, urlHistoryBatch).Return(int64(urlHistoryBatch), nil).Once()
+	repo.On("DeleteBefore", before, urlHistoryBatch).Return(int64(3), nil).Once()
+	useCase := NewURLUseCase(repo)
+
+	pruned, err := useCase.PruneHistory(before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(urlHistoryBatch+3), pruned)
+	repo.AssertExpectations(t)
+}
+
+func TestURLUseCase_ProcessURLs(t *testing.T) {
+	requests := []entities.URLRequest{
+		{URL: "https://byfood.com/tours/", Operation: "canonical"},
+		{URL: "https://byfood.com", Operation: "shorten"},
+	}
+
+	t.Run("processes each URL on its own", func(t *testing.T) {
+		repo := &memoryURLRepository{}
+		useCase := NewURLUseCase(repo)
+
+		results := useCase.ProcessURLs(requests, false)
+
+		assert.Len(t, results, 2)
+		assert.Equal(t, &entities.URLResponse{ProcessedURL: "https://byfood.com/tours"}, results[0].Response)
+		assert.NoError(t, results[0].Err)
+		assert.Equal(t, 1, results[1].Index)
+		assert.EqualError(t, results[1].Err, "invalid operation type")
+		history, _, _ := useCase.History(URLHistoryQuery{}, 0, "")
+		assert.Len(t, history, 1)
+	})
+
+	t.Run("atomic batches drop every URL when one fails", func(t *testing.T) {
+		useCase := NewURLUseCase(&memoryURLRepository{})
+
+		results := useCase.ProcessURLs(requests, true)
+
+		assert.True(t, results[0].Aborted)
+		assert.Nil(t, results[0].Response)
+		assert.Error(t, results[1].Err)
+		history, _, _ := useCase.History(URLHistoryQuery{}, 0, "")
+		assert.Empty(t, history, "nothing of the batch is recorded")
+	})
+}
+
+func TestURLUseCase_ProcessURLWithOptions(t *testing.T) {
+	useCase := NewURLUseCase(&memoryURLRepository{})
+	const messy = "https://BYFOOD.com:443//food-experiences/./kyoto/../tokyo/?page=2"
+
+	tests := []struct {
+		name          string
+		request       *entities.URLRequest
+		expectedURL   string
+		expectedError string
+	}{
+		{
+			name:        "canonical without options",
+			request:     &entities.URLRequest{URL: messy, Operation: "canonical"},
+			expectedURL: "https://BYFOOD.com:443//food-experiences/./kyoto/../tokyo",
+		},
+		{
+			name:        "canonical with every option",
+			request:     &entities.URLRequest{URL: messy, Operation: "canonical", Options: &entities.URLOptions{StripDefaultPort: true, CollapseSlashes: true, RemoveDotSegments: true}},
+			expectedURL: "https://BYFOOD.com/food-experiences/tokyo",
+		},
+		{
+			name:        "all with dot-segments removed",
+			request:     &entities.URLRequest{URL: messy, Operation: "all", Options: &entities.URLOptions{RemoveDotSegments: true}},
+			expectedURL: "https://www.byfood.com//food-experiences/tokyo",
+		},
+		{
+			name:        "profile with options",
+			request:     &entities.URLRequest{URL: "http://byfood.com:80//tours/", Profile: "strict", Options: &entities.URLOptions{CollapseSlashes: true}},
+			expectedURL: "https://www.byfood.com/tours",
+		},
+		{
+			name:        "root kept",
+			request:     &entities.URLRequest{URL: "https://byfood.com/?page=2", Operation: "canonical", Options: &entities.URLOptions{RootPath: entities.RootPathSlash}},
+			expectedURL: "https://byfood.com/",
+		},
+		{
+			name:        "root dropped",
+			request:     &entities.URLRequest{URL: "https://byfood.com/?page=2", Operation: "canonical", Options: &entities.URLOptions{RootPath: entities.RootPathEmpty}},
+			expectedURL: "https://byfood.com",
+		},
+		{
+			name:        "options only tune canonical processing",
+			request:     &entities.URLRequest{URL: "https://byfood.com:443//tours/?utm_source=ads", Operation: "strip_tracking", Options: &entities.URLOptions{StripDefaultPort: true, CollapseSlashes: true}},
+			expectedURL: "https://byfood.com:443//tours/",
+		},
+		{
+			name:        "redirection decoding the path",
+			request:     &entities.URLRequest{URL: "https://byfood.com/Tours/KY%C5%8CTO/Caf%C3%A9?Lang=JA", Operation: "redirection", Options: &entities.URLOptions{PathEncoding: entities.PathEncodingDecode}},
+			expectedURL: "https://www.byfood.com/tours/kyōto/café?lang=ja",
+		},
+		{
+			name:        "all keeping RFC 3986 encodings",
+			request:     &entities.URLRequest{URL: "https://byfood.com/Tours/KY%C5%8CTO/%7Eguide/", Operation: "all", Options: &entities.URLOptions{PathEncoding: entities.PathEncodingStrict}},
+			expectedURL: "https://www.byfood.com/tours/ky%C5%8Cto/~guide",
+		},
+		{
+			name:        "profile lowercasing encodings",
+			request:     &entities.URLRequest{URL: "https://byfood.com/Tours/KY%C5%8CTO/", Profile: "seo", Options: &entities.URLOptions{PathEncoding: entities.PathEncodingLowercase}},
+			expectedURL: "https://www.byfood.com/tours/ky%c5%8cto",
+		},
+		{
+			name:          "invalid path encoding",
+			request:       &entities.URLRequest{URL: "https://byfood.com/", Operation: "redirection", Options: &entities.URLOptions{PathEncoding: "raw"}},
+			expectedError: "path_encoding must be lowercase, strict or decode",
+		},
+		{
+			name:          "invalid root path",
+			request:       &entities.URLRequest{URL: "https://byfood.com/", Operation: "canonical", Options: &entities.URLOptions{RootPath: "none"}},
+			expectedError: "root_path must be slash or empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := useCase.ProcessURL(tt.request)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedURL, result.ProcessedURL)
+		})
+	}
+
+	operations := []string{"canonical"}
+	assert.Equal(t, cacheKey(messy, operations, urlOptions{}), cacheKey(messy, operations, urlOptions{}))
+	assert.NotEqual(t, cacheKey(messy, operations, urlOptions{}), cacheKey(messy, operations, urlOptions{canonical: urlnorm.CanonicalOptions{EmptyRoot: true}}), "options are part of the cache key")
+}
+
+func TestURLUseCase_InternationalizedURLs(t *testing.T) {
+	useCase := NewURLUseCase(&memoryURLRepository{})
+
+	tests := []struct {
+		name        string
+		request     *entities.URLRequest
+		expectedURL string
+	}{
+		{
+			name:        "punycode host kept by canonical",
+			request:     &entities.URLRequest{URL: "https://xn--bcher-kva.example/B%C3%BCcher/?page=2", Operation: "canonical"},
+			expectedURL: "https://xn--bcher-kva.example/B%C3%BCcher",
+		},
+		{
+			name:        "punycode host kept by https and strip_tracking",
+			request:     &entities.URLRequest{URL: "http://xn--r8jz45g.jp/%E6%97%A5%E6%9C%AC?utm_source=ads&id=7", Profile: "analytics-clean"},
+			expectedURL: "http://xn--r8jz45g.jp/%E6%97%A5%E6%9C%AC?id=7",
+		},
+		{
+			name:        "internationalized host replaced by redirection",
+			request:     &entities.URLRequest{URL: "https://xn--bcher-kva.example/Tours", Operation: "redirection"},
+			expectedURL: "https://www.byfood.com/tours",
+		},
+		{
+			name:        "unicode path encoded by canonical",
+			request:     &entities.URLRequest{URL: "https://byfood.com/食べ物/Café/", Operation: "canonical"},
+			expectedURL: "https://byfood.com/%E9%A3%9F%E3%81%B9%E7%89%A9/Caf%C3%A9",
+		},
+		{
+			name:        "unicode path lowercased by default",
+			request:     &entities.URLRequest{URL: "https://byfood.com/食べ物/CAFÉ/", Operation: "all"},
+			expectedURL: "https://www.byfood.com/%e9%a3%9f%e3%81%b9%e7%89%a9/caf%c3%89",
+		},
+		{
+			name:        "unicode path lowercased for display",
+			request:     &entities.URLRequest{URL: "https://byfood.com/食べ物/CAFÉ/", Operation: "all", Options: &entities.URLOptions{PathEncoding: entities.PathEncodingDecode}},
+			expectedURL: "https://www.byfood.com/食べ物/café",
+		},
+		{
+			name:        "encoded slashes kept for display",
+			request:     &entities.URLRequest{URL: "https://byfood.com/A%2FB%20Tours", Operation: "redirection", Options: &entities.URLOptions{PathEncoding: entities.PathEncodingDecode}},
+			expectedURL: "https://www.byfood.com/a%2Fb%20tours",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := useCase.ProcessURL(tt.request)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedURL, result.ProcessedURL)
+		})
+	}
+}
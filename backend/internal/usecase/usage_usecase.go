@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// UsageUseCase tracks API consumption against monthly quotas
+type UsageUseCase struct {
+	usageRepo    repositories.UsageRepository
+	monthlyLimit int64
+	overrides    map[string]int64
+	clock        clock.Clock
+}
+
+// NewUsageUseCase creates a new usage use case. A limit of zero means unlimited.
+func NewUsageUseCase(usageRepo repositories.UsageRepository, monthlyLimit int64, overrides map[string]int64) *UsageUseCase {
+	return &UsageUseCase{
+		usageRepo:    usageRepo,
+		monthlyLimit: monthlyLimit,
+		overrides:    overrides,
+		clock:        clock.System{},
+	}
+}
+
+// SetClock replaces the clock used to determine the current billing period
+func (uc *UsageUseCase) SetClock(c clock.Clock) {
+	uc.clock = c
+}
+
+// RecordRequest counts a request for the subject and returns its usage for the current period
+func (uc *UsageUseCase) RecordRequest(subject string) (*entities.Usage, error) {
+	if subject == "" {
+		return nil, errors.New("quota subject is required")
+	}
+
+	period, resetsAt := uc.currentPeriod()
+	used, err := uc.usageRepo.Increment(subject, period)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.buildUsage(subject, period, resetsAt, used), nil
+}
+
+// GetUsage returns the usage of the subject for the current period without counting a request
+func (uc *UsageUseCase) GetUsage(subject string) (*entities.Usage, error) {
+	if subject == "" {
+		return nil, errors.New("quota subject is required")
+	}
+
+	period, resetsAt := uc.currentPeriod()
+	used, err := uc.usageRepo.Get(subject, period)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.buildUsage(subject, period, resetsAt, used), nil
+}
+
+// limitFor returns the monthly limit of a subject
+func (uc *UsageUseCase) limitFor(subject string) int64 {
+	if limit, ok := uc.overrides[subject]; ok {
+		return limit
+	}
+	return uc.monthlyLimit
+}
+
+// currentPeriod returns the current monthly period key and when it ends
+func (uc *UsageUseCase) currentPeriod() (string, time.Time) {
+	now := uc.clock.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start.Format("2006-01"), start.AddDate(0, 1, 0)
+}
+
+func (uc *UsageUseCase) buildUsage(subject, period string, resetsAt time.Time, used int64) *entities.Usage {
+	limit := uc.limitFor(subject)
+	remaining := int64(0)
+	if limit > 0 && used < limit {
+		remaining = limit - used
+	}
+
+	return &entities.Usage{
+		Subject:   subject,
+		Period:    period,
+		Used:      used,
+		Limit:     limit,
+		Remaining: remaining,
+		ResetsAt:  resetsAt,
+	}
+}
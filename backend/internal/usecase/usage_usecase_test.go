@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"library-management-system/internal/domain/clock"
+	"library-management-system/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestUsageUseCase(limit int64, overrides map[string]int64) *UsageUseCase {
+	useCase := NewUsageUseCase(repository.NewInMemoryUsageRepository(), limit, overrides)
+	useCase.SetClock(clock.NewFixed(time.Date(2024, 2, 10, 12, 0, 0, 0, time.UTC)))
+	return useCase
+}
+
+func TestUsageUseCase_RecordRequest(t *testing.T) {
+	useCase := newTestUsageUseCase(2, nil)
+
+	usage, err := useCase.RecordRequest("key:abc")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-02", usage.Period)
+	assert.Equal(t, int64(1), usage.Used)
+	assert.Equal(t, int64(1), usage.Remaining)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), usage.ResetsAt)
+	assert.False(t, usage.Exceeded())
+
+	usage, _ = useCase.RecordRequest("key:abc")
+	assert.False(t, usage.Exceeded())
+	assert.Equal(t, int64(0), usage.Remaining)
+
+	usage, _ = useCase.RecordRequest("key:abc")
+	assert.True(t, usage.Exceeded())
+
+	// Other subjects are counted separately
+	usage, _ = useCase.RecordRequest("tenant:acme")
+	assert.Equal(t, int64(1), usage.Used)
+}
+
+func TestUsageUseCase_Overrides(t *testing.T) {
+	useCase := newTestUsageUseCase(1, map[string]int64{"key:vip": 0})
+
+	for i := 0; i < 5; i++ {
+		usage, err := useCase.RecordRequest("key:vip")
+		assert.NoError(t, err)
+		assert.False(t, usage.Exceeded(), "an override of zero means unlimited")
+	}
+}
+
+func TestUsageUseCase_GetUsage(t *testing.T) {
+	useCase := newTestUsageUseCase(10, nil)
+	_, _ = useCase.RecordRequest("key:abc")
+
+	usage, err := useCase.GetUsage("key:abc")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), usage.Used)
+	assert.Equal(t, int64(9), usage.Remaining)
+
+	_, err = useCase.GetUsage("")
+	assert.EqualError(t, err, "quota subject is required")
+}
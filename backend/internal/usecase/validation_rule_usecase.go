@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// RuleViolation is a validation rule a book fails
+type RuleViolation struct {
+	RuleID   string `json:"rule_id"`
+	RuleName string `json:"rule_name"`
+	Message  string `json:"message"`
+}
+
+// ValidationRuleUseCase manages the validation rules admins add for books
+type ValidationRuleUseCase struct {
+	ruleRepo repositories.ValidationRuleRepository
+	// profiles are the thresholds of the built-in validation, by tenant
+	profiles entities.ValidationProfiles
+}
+
+// NewValidationRuleUseCase creates a new validation rule use case
+func NewValidationRuleUseCase(ruleRepo repositories.ValidationRuleRepository) *ValidationRuleUseCase {
+	return &ValidationRuleUseCase{
+		ruleRepo: ruleRepo,
+	}
+}
+
+// SetValidationProfiles sets the thresholds of the built-in validation books can be tested
+// against along with the rules, by tenant
+func (uc *ValidationRuleUseCase) SetValidationProfiles(profiles entities.ValidationProfiles) {
+	uc.profiles = profiles
+}
+
+// ValidationProfileRule names the violations of the built-in checks of a validation profile
+const ValidationProfileRule = "validation_profile"
+
+// CreateRule creates a new validation rule
+func (uc *ValidationRuleUseCase) CreateRule(rule *entities.ValidationRule) error {
+	if err := prepareValidationRule(rule); err != nil {
+		return err
+	}
+	return uc.ruleRepo.Create(rule)
+}
+
+// ListRules retrieves all validation rules
+func (uc *ValidationRuleUseCase) ListRules() ([]entities.ValidationRule, error) {
+	return uc.ruleRepo.List()
+}
+
+// GetRule retrieves a validation rule by ID
+func (uc *ValidationRuleUseCase) GetRule(id string) (*entities.ValidationRule, error) {
+	if id == "" {
+		return nil, errors.New("validation rule ID is required")
+	}
+
+	rule, err := uc.ruleRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, domainerr.ErrValidationRuleNotFound
+	}
+	return rule, nil
+}
+
+// UpdateRule replaces the check of a validation rule
+func (uc *ValidationRuleUseCase) UpdateRule(id string, changes *entities.ValidationRule) (*entities.ValidationRule, error) {
+	existing, err := uc.GetRule(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := prepareValidationRule(changes); err != nil {
+		return nil, err
+	}
+
+	existing.Name = changes.Name
+	existing.Kind = changes.Kind
+	existing.Category = changes.Category
+	existing.Pattern = changes.Pattern
+	existing.MetadataKey = changes.MetadataKey
+	existing.MinYear = changes.MinYear
+	existing.MaxYear = changes.MaxYear
+	existing.Message = changes.Message
+	existing.Enabled = changes.Enabled
+	if err := uc.ruleRepo.Update(existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// DeleteRule deletes a validation rule
+func (uc *ValidationRuleUseCase) DeleteRule(id string) error {
+	if _, err := uc.GetRule(id); err != nil {
+		return err
+	}
+	return uc.ruleRepo.Delete(id)
+}
+
+// TestBook evaluates the enabled rules against a book without saving anything, and returns
+// every rule it fails
+func (uc *ValidationRuleUseCase) TestBook(book *entities.Book) ([]RuleViolation, error) {
+	rules, err := uc.ruleRepo.ListEnabled()
+	if err != nil {
+		return nil, err
+	}
+	return evaluateRules(rules, book), nil
+}
+
+// TestBookWithProfile evaluates the built-in checks of the tenant's validation profile before
+// the enabled rules, reporting the first check the book fails as a violation of
+// validation_profile, so that a book passes as it would be saved
+func (uc *ValidationRuleUseCase) TestBookWithProfile(tenantID string, book *entities.Book) ([]RuleViolation, error) {
+	violations, err := uc.TestBook(book)
+	if err != nil {
+		return nil, err
+	}
+	normalized := *book
+	normalized.ISBN = entities.NormalizeISBN(book.ISBN)
+	if err := uc.profiles.For(tenantID).CheckBook(&normalized); err != nil {
+		violations = append([]RuleViolation{{RuleName: ValidationProfileRule, Message: err.Error()}}, violations...)
+	}
+	return violations, nil
+}
+
+// prepareValidationRule validates a rule and clears the settings its kind does not use
+func prepareValidationRule(rule *entities.ValidationRule) error {
+	rule.Name = strings.TrimSpace(rule.Name)
+	rule.Category = strings.TrimSpace(rule.Category)
+	rule.Message = strings.TrimSpace(rule.Message)
+	if rule.Name == "" {
+		return errors.New("validation rule name is required")
+	}
+
+	switch rule.Kind {
+	case entities.ValidationRuleISBNPrefix:
+		if rule.Pattern == "" {
+			return errors.New("isbn_prefix rules need a pattern")
+		}
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return fmt.Errorf("validation rule pattern is not a valid regular expression: %v", err)
+		}
+		rule.MetadataKey, rule.MinYear, rule.MaxYear = "", nil, nil
+	case entities.ValidationRuleRequiredMetadata:
+		if !metadataKeyPattern.MatchString(rule.MetadataKey) {
+			return errors.New("required_metadata rules need a metadata key of lowercase letters, digits and underscores")
+		}
+		rule.Pattern, rule.MinYear, rule.MaxYear = "", nil, nil
+	case entities.ValidationRuleYearRange:
+		if rule.MinYear == nil && rule.MaxYear == nil {
+			return errors.New("year_range rules need a min_year, a max_year or both")
+		}
+		if rule.MinYear != nil && rule.MaxYear != nil && *rule.MinYear > *rule.MaxYear {
+			return errors.New("min_year cannot be after max_year")
+		}
+		rule.Pattern, rule.MetadataKey = "", ""
+	default:
+		return fmt.Errorf("unknown validation rule kind %q", rule.Kind)
+	}
+	return nil
+}
+
+// evaluateRules returns the rules a book fails, in the order of the rules
+func evaluateRules(rules []entities.ValidationRule, book *entities.Book) []RuleViolation {
+	violations := []RuleViolation{}
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.AppliesTo(book) {
+			continue
+		}
+		if message, ok := checkRule(rule, book); !ok {
+			violations = append(violations, RuleViolation{RuleID: rule.ID, RuleName: rule.Name, Message: message})
+		}
+	}
+	return violations
+}
+
+// checkRule evaluates one rule against a book, returning the message to report when it fails
+func checkRule(rule *entities.ValidationRule, book *entities.Book) (string, bool) {
+	switch rule.Kind {
+	case entities.ValidationRuleISBNPrefix:
+		// Patterns are checked when saved; one that no longer compiles is not held against books
+		pattern, err := regexp.Compile(`^(?:` + rule.Pattern + `)`)
+		if err != nil || pattern.MatchString(book.ISBN) {
+			return "", true
+		}
+	case entities.ValidationRuleRequiredMetadata:
+		if value, ok := book.Metadata[rule.MetadataKey]; ok && entities.MetadataText(value) != "" {
+			return "", true
+		}
+	case entities.ValidationRuleYearRange:
+		tooEarly := rule.MinYear != nil && book.Year < *rule.MinYear
+		tooLate := rule.MaxYear != nil && book.Year > *rule.MaxYear
+		if !tooEarly && !tooLate {
+			return "", true
+		}
+	default:
+		return "", true
+	}
+	return ruleMessage(rule), false
+}
+
+// ruleMessage returns the message reported for books failing a rule
+func ruleMessage(rule *entities.ValidationRule) string {
+	if rule.Message != "" {
+		return rule.Message
+	}
+
+	var message string
+	switch rule.Kind {
+	case entities.ValidationRuleISBNPrefix:
+		message = fmt.Sprintf("book ISBN must start with %s", rule.Pattern)
+	case entities.ValidationRuleRequiredMetadata:
+		message = fmt.Sprintf("metadata field %s is required", rule.MetadataKey)
+	case entities.ValidationRuleYearRange:
+		switch {
+		case rule.MinYear != nil && rule.MaxYear != nil:
+			message = fmt.Sprintf("book year must be between %d and %d", *rule.MinYear, *rule.MaxYear)
+		case rule.MinYear != nil:
+			message = fmt.Sprintf("book year must be %d or later", *rule.MinYear)
+		default:
+			message = fmt.Sprintf("book year must be %d or earlier", *rule.MaxYear)
+		}
+	}
+	if rule.Category != "" {
+		message += " for category " + rule.Category
+	}
+	return message
+}
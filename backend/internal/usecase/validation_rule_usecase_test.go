@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"testing"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockValidationRuleRepository is a mock implementation of ValidationRuleRepository
+type MockValidationRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockValidationRuleRepository) Create(rule *entities.ValidationRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockValidationRuleRepository) GetByID(id string) (*entities.ValidationRule, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.ValidationRule), args.Error(1)
+}
+
+func (m *MockValidationRuleRepository) List() ([]entities.ValidationRule, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.ValidationRule), args.Error(1)
+}
+
+func (m *MockValidationRuleRepository) ListEnabled() ([]entities.ValidationRule, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.ValidationRule), args.Error(1)
+}
+
+func (m *MockValidationRuleRepository) Update(rule *entities.ValidationRule) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockValidationRuleRepository) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func TestValidationRuleUseCase_CreateRule(t *testing.T) {
+	tests := []struct {
+		name          string
+		rule          entities.ValidationRule
+		expectedError string
+	}{
+		{name: "isbn prefix", rule: entities.ValidationRule{Name: "Bookland", Kind: entities.ValidationRuleISBNPrefix, Pattern: "97[89]"}},
+		{name: "required metadata", rule: entities.ValidationRule{Name: "Shelf", Kind: entities.ValidationRuleRequiredMetadata, MetadataKey: "shelf_code"}},
+		{name: "open year range", rule: entities.ValidationRule{Name: "Modern", Kind: entities.ValidationRuleYearRange, MinYear: intPtr(1900)}},
+		{name: "missing name", rule: entities.ValidationRule{Name: " ", Kind: entities.ValidationRuleYearRange, MinYear: intPtr(1900)}, expectedError: "validation rule name is required"},
+		{name: "unknown kind", rule: entities.ValidationRule{Name: "Titles", Kind: "title_length"}, expectedError: `unknown validation rule kind "title_length"`},
+		{name: "missing pattern", rule: entities.ValidationRule{Name: "Bookland", Kind: entities.ValidationRuleISBNPrefix}, expectedError: "isbn_prefix rules need a pattern"},
+		{name: "malformed metadata key", rule: entities.ValidationRule{Name: "Shelf", Kind: entities.ValidationRuleRequiredMetadata, MetadataKey: "Shelf Code"}, expectedError: "required_metadata rules need a metadata key of lowercase letters, digits and underscores"},
+		{name: "missing years", rule: entities.ValidationRule{Name: "Modern", Kind: entities.ValidationRuleYearRange}, expectedError: "year_range rules need a min_year, a max_year or both"},
+		{name: "inverted years", rule: entities.ValidationRule{Name: "Modern", Kind: entities.ValidationRuleYearRange, MinYear: intPtr(2000), MaxYear: intPtr(1900)}, expectedError: "min_year cannot be after max_year"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ruleRepo := &MockValidationRuleRepository{}
+			ruleRepo.On("Create", mock.Anything).Return(nil)
+			useCase := NewValidationRuleUseCase(ruleRepo)
+
+			err := useCase.CreateRule(&tt.rule)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				ruleRepo.AssertNotCalled(t, "Create", mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("clears settings the kind does not use", func(t *testing.T) {
+		ruleRepo := &MockValidationRuleRepository{}
+		ruleRepo.On("Create", mock.Anything).Return(nil)
+		useCase := NewValidationRuleUseCase(ruleRepo)
+		rule := &entities.ValidationRule{Name: "Bookland", Kind: entities.ValidationRuleISBNPrefix, Pattern: "978", MetadataKey: "shelf_code", MinYear: intPtr(1900)}
+
+		assert.NoError(t, useCase.CreateRule(rule))
+		assert.Empty(t, rule.MetadataKey)
+		assert.Nil(t, rule.MinYear)
+	})
+}
+
+func TestValidationRuleUseCase_TestBook(t *testing.T) {
+	ruleRepo := &MockValidationRuleRepository{}
+	ruleRepo.On("ListEnabled").Return([]entities.ValidationRule{
+		{ID: "rule-1", Name: "Bookland", Kind: entities.ValidationRuleISBNPrefix, Pattern: "97[89]"},
+		{ID: "rule-2", Name: "Comics shelf", Kind: entities.ValidationRuleRequiredMetadata, MetadataKey: "shelf_code", Category: "comics"},
+		{ID: "rule-3", Name: "Old comics", Kind: entities.ValidationRuleYearRange, MaxYear: intPtr(1960), Category: "comics", Message: "only golden age comics"},
+	}, nil)
+	useCase := NewValidationRuleUseCase(ruleRepo)
+
+	t.Run("reports every failed rule", func(t *testing.T) {
+		violations, err := useCase.TestBook(&entities.Book{ISBN: "0743273567", Year: 1975, Metadata: map[string]interface{}{"category": "comics", "shelf_code": ""}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []RuleViolation{
+			{RuleID: "rule-1", RuleName: "Bookland", Message: "book ISBN must start with 97[89]"},
+			{RuleID: "rule-2", RuleName: "Comics shelf", Message: "metadata field shelf_code is required for category comics"},
+			{RuleID: "rule-3", RuleName: "Old comics", Message: "only golden age comics"},
+		}, violations)
+	})
+
+	t.Run("skips rules of other categories", func(t *testing.T) {
+		violations, err := useCase.TestBook(&entities.Book{ISBN: "9780743273565", Year: 1975, Metadata: map[string]interface{}{"category": "novels"}})
+
+		assert.NoError(t, err)
+		assert.Empty(t, violations)
+	})
+}
+
+func TestValidationRuleUseCase_GetRuleNotFound(t *testing.T) {
+	ruleRepo := &MockValidationRuleRepository{}
+	ruleRepo.On("GetByID", "missing").Return(nil, nil)
+	useCase := NewValidationRuleUseCase(ruleRepo)
+
+	_, err := useCase.GetRule("missing")
+
+	assert.ErrorIs(t, err, domainerr.ErrValidationRuleNotFound)
+}
+
+func TestBookUseCase_CreateBookWithValidationRules(t *testing.T) {
+	mockRepo := &MockBookRepository{}
+	ruleRepo := &MockValidationRuleRepository{}
+	ruleRepo.On("ListEnabled").Return([]entities.ValidationRule{
+		{ID: "rule-1", Name: "Modern", Kind: entities.ValidationRuleYearRange, MinYear: intPtr(1950), MaxYear: intPtr(2030)},
+	}, nil)
+	useCase := NewBookUseCase(mockRepo)
+	useCase.SetValidationRuleRepository(ruleRepo)
+
+	err := useCase.CreateBook(&entities.Book{Title: "The Great Gatsby", Author: "F. Scott Fitzgerald", Year: 1925, ISBN: "9780743273565"})
+
+	assert.EqualError(t, err, "book year must be between 1950 and 2030")
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
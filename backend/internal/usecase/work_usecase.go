@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+
+	"library-management-system/internal/domain/domainerr"
+	"library-management-system/internal/domain/entities"
+	"library-management-system/internal/domain/repositories"
+)
+
+// WorkUseCase groups the editions of a book under a common work
+type WorkUseCase struct {
+	workRepo repositories.WorkRepository
+	bookRepo repositories.BookRepository
+	// queryCache is invalidated when editions move, since listings can be collapsed by work
+	queryCache *QueryCache
+}
+
+// NewWorkUseCase creates a new work use case
+func NewWorkUseCase(workRepo repositories.WorkRepository, bookRepo repositories.BookRepository) *WorkUseCase {
+	return &WorkUseCase{
+		workRepo: workRepo,
+		bookRepo: bookRepo,
+	}
+}
+
+// SetQueryCache sets the cache of book listings and searches to invalidate when editions move
+func (uc *WorkUseCase) SetQueryCache(cache *QueryCache) {
+	uc.queryCache = cache
+}
+
+// CreateWork creates a work and groups the given books under it as editions.
+// The title and author default to those of the first edition.
+func (uc *WorkUseCase) CreateWork(work *entities.Work, bookIDs []string) error {
+	editions, err := uc.loadEditions(bookIDs)
+	if err != nil {
+		return err
+	}
+
+	work.Title = strings.TrimSpace(work.Title)
+	work.Author = strings.TrimSpace(work.Author)
+	if work.Title == "" && len(editions) > 0 {
+		work.Title = editions[0].Title
+	}
+	if work.Author == "" && len(editions) > 0 {
+		work.Author = editions[0].Author
+	}
+	if work.Title == "" {
+		return errors.New("work title is required")
+	}
+	if work.Author == "" {
+		return errors.New("work author is required")
+	}
+
+	if err := uc.workRepo.Create(work); err != nil {
+		return err
+	}
+	if len(bookIDs) == 0 {
+		return nil
+	}
+	return uc.setWork(bookIDs, &work.ID)
+}
+
+// GetWork retrieves a work with its live editions, oldest first
+func (uc *WorkUseCase) GetWork(id string) (*entities.Work, []entities.Book, error) {
+	work, err := uc.requireWork(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	editions, err := liveBooks(uc.bookRepo.FindByWork(id))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return work, editions, nil
+}
+
+// ListWorks retrieves all works
+func (uc *WorkUseCase) ListWorks() ([]entities.Work, error) {
+	return uc.workRepo.GetAll()
+}
+
+// GroupEditions adds books to a work; books of another work are moved over
+func (uc *WorkUseCase) GroupEditions(id string, bookIDs []string) error {
+	if _, err := uc.requireWork(id); err != nil {
+		return err
+	}
+	if len(bookIDs) == 0 {
+		return errors.New("at least one book ID is required")
+	}
+	if _, err := uc.loadEditions(bookIDs); err != nil {
+		return err
+	}
+
+	return uc.setWork(bookIDs, &id)
+}
+
+// UngroupEdition removes a book from a work
+func (uc *WorkUseCase) UngroupEdition(id, bookID string) error {
+	if _, err := uc.requireWork(id); err != nil {
+		return err
+	}
+
+	book, err := uc.bookRepo.GetByID(bookID)
+	if err != nil {
+		return err
+	}
+	if book == nil || book.WorkID == nil || *book.WorkID != id {
+		return errors.New("book is not an edition of this work")
+	}
+
+	return uc.setWork([]string{bookID}, nil)
+}
+
+// setWork moves books to a work, or out of their work when workID is nil
+func (uc *WorkUseCase) setWork(bookIDs []string, workID *string) error {
+	if err := uc.bookRepo.SetWork(bookIDs, workID); err != nil {
+		return err
+	}
+	uc.queryCache.Invalidate()
+	return nil
+}
+
+// requireWork retrieves a work and fails when it does not exist
+func (uc *WorkUseCase) requireWork(id string) (*entities.Work, error) {
+	if id == "" {
+		return nil, errors.New("work ID is required")
+	}
+
+	work, err := uc.workRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if work == nil {
+		return nil, domainerr.ErrWorkNotFound
+	}
+	return work, nil
+}
+
+// loadEditions retrieves the given books and fails when one of them does not exist
+func (uc *WorkUseCase) loadEditions(bookIDs []string) ([]entities.Book, error) {
+	editions := make([]entities.Book, 0, len(bookIDs))
+	for _, bookID := range bookIDs {
+		book, err := uc.bookRepo.GetByID(bookID)
+		if err != nil {
+			return nil, err
+		}
+		if book == nil {
+			return nil, errors.New("book not found: " + bookID)
+		}
+		editions = append(editions, *book)
+	}
+	return editions, nil
+}
+
+// CollapseByWork keeps the first edition of every work in the results and counts the editions found per work.
+// Books without a work are kept as they are.
+func CollapseByWork(books []entities.Book) ([]entities.Book, map[string]int) {
+	collapsed := make([]entities.Book, 0, len(books))
+	editionCounts := make(map[string]int)
+	for _, book := range books {
+		if book.WorkID == nil {
+			collapsed = append(collapsed, book)
+			continue
+		}
+		if editionCounts[*book.WorkID] == 0 {
+			collapsed = append(collapsed, book)
+		}
+		editionCounts[*book.WorkID]++
+	}
+	return collapsed, editionCounts
+}
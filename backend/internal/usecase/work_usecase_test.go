@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"testing"
+
+	"library-management-system/internal/domain/entities"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWorkRepository is a mock implementation of WorkRepository
+type MockWorkRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorkRepository) Create(work *entities.Work) error {
+	args := m.Called(work)
+	return args.Error(0)
+}
+
+func (m *MockWorkRepository) GetByID(id string) (*entities.Work, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Work), args.Error(1)
+}
+
+func (m *MockWorkRepository) GetAll() ([]entities.Work, error) {
+	args := m.Called()
+	return args.Get(0).([]entities.Work), args.Error(1)
+}
+
+func TestWorkUseCase_CreateWork(t *testing.T) {
+	mockRepo := &MockWorkRepository{}
+	mockBookRepo := &MockBookRepository{}
+	mockBookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1", Title: "Dune", Author: "Frank Herbert"}, nil)
+	mockBookRepo.On("GetByID", "missing").Return(nil, nil)
+	mockRepo.On("Create", mock.AnythingOfType("*entities.Work")).Run(func(args mock.Arguments) {
+		args.Get(0).(*entities.Work).ID = "work-1"
+	}).Return(nil)
+	mockBookRepo.On("SetWork", []string{"book-1"}, stringPtr("work-1")).Return(nil)
+
+	useCase := NewWorkUseCase(mockRepo, mockBookRepo)
+
+	work := &entities.Work{}
+	assert.NoError(t, useCase.CreateWork(work, []string{"book-1"}))
+	assert.Equal(t, "Dune", work.Title)
+	assert.Equal(t, "Frank Herbert", work.Author)
+	mockBookRepo.AssertCalled(t, "SetWork", []string{"book-1"}, stringPtr("work-1"))
+
+	assert.EqualError(t, useCase.CreateWork(&entities.Work{}, nil), "work title is required")
+	assert.EqualError(t, useCase.CreateWork(&entities.Work{}, []string{"missing"}), "book not found: missing")
+}
+
+func TestWorkUseCase_UngroupEdition(t *testing.T) {
+	tests := []struct {
+		name          string
+		workID        string
+		bookID        string
+		expectedError string
+	}{
+		{name: "edition of the work", workID: "work-1", bookID: "book-1"},
+		{name: "edition of another work", workID: "work-1", bookID: "book-2", expectedError: "book is not an edition of this work"},
+		{name: "unknown book", workID: "work-1", bookID: "missing", expectedError: "book is not an edition of this work"},
+		{name: "unknown work", workID: "missing", bookID: "book-1", expectedError: "work not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockWorkRepository{}
+			mockBookRepo := &MockBookRepository{}
+			mockRepo.On("GetByID", "work-1").Return(&entities.Work{ID: "work-1"}, nil)
+			mockRepo.On("GetByID", "missing").Return(nil, nil)
+			mockBookRepo.On("GetByID", "book-1").Return(&entities.Book{ID: "book-1", WorkID: stringPtr("work-1")}, nil)
+			mockBookRepo.On("GetByID", "book-2").Return(&entities.Book{ID: "book-2", WorkID: stringPtr("work-2")}, nil)
+			mockBookRepo.On("GetByID", "missing").Return(nil, nil)
+			mockBookRepo.On("SetWork", []string{"book-1"}, (*string)(nil)).Return(nil)
+
+			err := NewWorkUseCase(mockRepo, mockBookRepo).UngroupEdition(tt.workID, tt.bookID)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCollapseByWork(t *testing.T) {
+	books := []entities.Book{
+		{ID: "hardcover", WorkID: stringPtr("work-1")},
+		{ID: "standalone"},
+		{ID: "paperback", WorkID: stringPtr("work-1")},
+		{ID: "other", WorkID: stringPtr("work-2")},
+	}
+
+	collapsed, editionCounts := CollapseByWork(books)
+
+	var ids []string
+	for _, book := range collapsed {
+		ids = append(ids, book.ID)
+	}
+	assert.Equal(t, []string{"hardcover", "standalone", "other"}, ids)
+	assert.Equal(t, map[string]int{"work-1": 2, "work-2": 1}, editionCounts)
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Session is a signed-in session of a user
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	Device     string    `json:"device"`
+	IP         string    `json:"ip"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// sessionCreated is the response of a sign-in, the only one showing the session token
+type sessionCreated struct {
+	Session
+	Token string `json:"token"`
+}
+
+// SignIn signs in with the email and password of a user and signs the requests that follow in
+// with the session it starts, as SetToken does with its token. Like the other setters, call it
+// before sharing the client.
+func (c *Client) SignIn(ctx context.Context, email, password string) (*Session, error) {
+	if email == "" || password == "" {
+		return nil, errors.New("email and password are required")
+	}
+	credentials := base64.StdEncoding.EncodeToString([]byte(email + ":" + password))
+
+	var created sessionCreated
+	if err := c.doAuthorized(ctx, http.MethodPost, "/auth/sessions", nil, nil, &created, "Basic "+credentials); err != nil {
+		return nil, err
+	}
+	c.SetToken(created.Token)
+	return &created.Session, nil
+}
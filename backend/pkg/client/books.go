@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Book is a book of the catalog
+type Book struct {
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	Author         string     `json:"author"`
+	Year           int        `json:"year"`
+	ISBN           string     `json:"isbn"`
+	PublisherID    *string    `json:"publisher_id,omitempty"`
+	SeriesID       *string    `json:"series_id,omitempty"`
+	SeriesPosition *int       `json:"series_position,omitempty"`
+	WorkID         *string    `json:"work_id,omitempty"`
+	Available      bool       `json:"available"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+}
+
+// BookInput is the body of book creations and updates
+type BookInput struct {
+	Title          string  `json:"title"`
+	Author         string  `json:"author"`
+	Year           int     `json:"year"`
+	ISBN           string  `json:"isbn"`
+	PublisherID    *string `json:"publisher_id,omitempty"`
+	SeriesID       *string `json:"series_id,omitempty"`
+	SeriesPosition *int    `json:"series_position,omitempty"`
+}
+
+// BookSearch filters a book search. The API applies the first non-empty field in the order
+// title, author, year, publisher.
+type BookSearch struct {
+	Title       string
+	Author      string
+	Year        int
+	PublisherID string
+}
+
+// ListBooks retrieves all books
+func (c *Client) ListBooks(ctx context.Context) ([]Book, error) {
+	var books []Book
+	err := c.do(ctx, http.MethodGet, "/books", nil, nil, &books)
+	return books, err
+}
+
+// ListDeletedBooks retrieves the books in the trash
+func (c *Client) ListDeletedBooks(ctx context.Context) ([]Book, error) {
+	var books []Book
+	err := c.do(ctx, http.MethodGet, "/books/deleted", nil, nil, &books)
+	return books, err
+}
+
+// GetBook retrieves a book by ID
+func (c *Client) GetBook(ctx context.Context, id string) (*Book, error) {
+	if id == "" {
+		return nil, errMissingID
+	}
+	var book Book
+	if err := c.do(ctx, http.MethodGet, "/books/"+escape(id), nil, nil, &book); err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+// SearchBooks finds books matching the search
+func (c *Client) SearchBooks(ctx context.Context, search BookSearch) ([]Book, error) {
+	query := url.Values{}
+	if search.Title != "" {
+		query.Set("title", search.Title)
+	}
+	if search.Author != "" {
+		query.Set("author", search.Author)
+	}
+	if search.Year != 0 {
+		query.Set("year", strconv.Itoa(search.Year))
+	}
+	if search.PublisherID != "" {
+		query.Set("publisher", search.PublisherID)
+	}
+
+	var books []Book
+	err := c.do(ctx, http.MethodGet, "/books/search", query, nil, &books)
+	return books, err
+}
+
+// CreateBook adds a book to the catalog
+func (c *Client) CreateBook(ctx context.Context, input BookInput) (*Book, error) {
+	var book Book
+	if err := c.do(ctx, http.MethodPost, "/books", nil, input, &book); err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+// UpdateBook replaces the fields of a book
+func (c *Client) UpdateBook(ctx context.Context, id string, input BookInput) (*Book, error) {
+	if id == "" {
+		return nil, errMissingID
+	}
+	var book Book
+	if err := c.do(ctx, http.MethodPut, "/books/"+escape(id), nil, input, &book); err != nil {
+		return nil, err
+	}
+	return &book, nil
+}
+
+// DeleteBook moves a book to the trash
+func (c *Client) DeleteBook(ctx context.Context, id string) error {
+	if id == "" {
+		return errMissingID
+	}
+	return c.do(ctx, http.MethodDelete, "/books/"+escape(id), nil, nil, nil)
+}
+
+// RestoreBook takes a book out of the trash
+func (c *Client) RestoreBook(ctx context.Context, id string) error {
+	if id == "" {
+		return errMissingID
+	}
+	return c.do(ctx, http.MethodPost, "/books/"+escape(id)+"/restore", nil, nil, nil)
+}
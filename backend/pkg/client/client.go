@@ -0,0 +1,241 @@
+// Package client is a Go SDK for the Library Management System REST API, so other services do not
+// hand-roll HTTP calls. It retries transient failures, returns *APIError for error responses and
+// walks paginated endpoints with iterators.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers identifying the caller. The API does not meter quotas by them, as callers are free to
+// change them, but by the access token a request signs in with, else the tenant of its session's
+// user, else its client IP. The identity headers are only to be trusted in deployments behind a
+// gateway that sets them; elsewhere sign in with SetToken or SignIn, whose user replaces them.
+const (
+	apiKeyHeader = "X-API-Key"
+	tenantHeader = "X-Tenant-ID"
+	userIDHeader = "X-User-ID"
+)
+
+// bearerPrefix starts the Authorization header of requests signed in with a token
+const bearerPrefix = "Bearer "
+
+// Retry defaults
+const (
+	defaultMaxRetries = 2
+	defaultBackoff    = 200 * time.Millisecond
+	maxRetryAfter     = 30 * time.Second
+)
+
+// Client calls the API. It is safe for concurrent use once configured.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	apiKey     string
+	tenantID   string
+	userID     string
+	token      string
+	maxRetries int
+	backoff    time.Duration
+}
+
+// New creates a client for the API mounted at baseURL, e.g. http://localhost:8080/api
+func New(baseURL string) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultBackoff,
+	}
+}
+
+// SetHTTPClient replaces the HTTP client requests are sent with
+func (c *Client) SetHTTPClient(httpClient *http.Client) {
+	c.httpClient = httpClient
+}
+
+// SetAPIKey sends the X-API-Key header, which the API counts in its analytics but does not meter
+// quotas by
+func (c *Client) SetAPIKey(apiKey string) {
+	c.apiKey = apiKey
+}
+
+// SetTenant sends the tenant requests are made for, e.g. to pick its field naming; requests
+// signed in take their tenant from their user instead. The API only trusts it behind a gateway
+// that sets it.
+func (c *Client) SetTenant(tenantID string) {
+	c.tenantID = tenantID
+}
+
+// SetUserID sends the user requests are made on behalf of, e.g. for notifications. Only
+// deployments behind a gateway that authenticates users and sets X-User-ID should rely on it;
+// other callers sign in with SetToken or SignIn.
+func (c *Client) SetUserID(userID string) {
+	c.userID = userID
+}
+
+// SetToken signs requests in with an access token or session token, sent as
+// "Authorization: Bearer <token>"; the user of the token is the caller, whatever the identity
+// headers say. An empty token signs out.
+func (c *Client) SetToken(token string) {
+	c.token = token
+}
+
+// SetRetry sets how many times a failed request is retried and the delay before the first retry,
+// which doubles on every attempt. A maxRetries of 0 disables retries.
+func (c *Client) SetRetry(maxRetries int, backoff time.Duration) {
+	c.maxRetries = maxRetries
+	c.backoff = backoff
+}
+
+// do sends a request and decodes the JSON response into out, when not nil. Transient failures
+// are retried: network errors and 502/504 for idempotent methods, 429 and 503 for every method
+// since the API refuses those requests before handling them.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	authorization := ""
+	if c.token != "" {
+		authorization = bearerPrefix + c.token
+	}
+	return c.doAuthorized(ctx, method, path, query, body, out, authorization)
+}
+
+// doAuthorized is do with the Authorization header to send, if any
+func (c *Client) doAuthorized(ctx context.Context, method, path string, query url.Values, body, out interface{}, authorization string) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, target, payload, authorization)
+		if err != nil {
+			if attempt < c.maxRetries && idempotent(method) && ctx.Err() == nil {
+				if err := c.wait(ctx, attempt, 0); err != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			defer resp.Body.Close()
+			if out == nil {
+				return nil
+			}
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+			return nil
+		}
+
+		apiErr := newAPIError(resp)
+		if attempt < c.maxRetries && retryable(method, resp.StatusCode) {
+			if err := c.wait(ctx, attempt, retryAfter(resp)); err != nil {
+				return err
+			}
+			continue
+		}
+		return apiErr
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, target string, payload []byte, authorization string) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	if c.apiKey != "" {
+		req.Header.Set(apiKeyHeader, c.apiKey)
+	}
+	if c.tenantID != "" {
+		req.Header.Set(tenantHeader, c.tenantID)
+	}
+	if c.userID != "" {
+		req.Header.Set(userIDHeader, c.userID)
+	}
+	return c.httpClient.Do(req)
+}
+
+// wait sleeps before the next attempt, preferring the delay the server asked for
+func (c *Client) wait(ctx context.Context, attempt int, after time.Duration) error {
+	delay := c.backoff << attempt
+	if after > 0 {
+		delay = after
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func retryable(method string, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent(method)
+	}
+	return false
+}
+
+// retryAfter reads the Retry-After header in seconds, capped to keep callers responsive
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	if delay := time.Duration(seconds) * time.Second; delay < maxRetryAfter {
+		return delay
+	}
+	return maxRetryAfter
+}
+
+// escape encodes a path segment such as an ID
+func escape(segment string) string {
+	return url.PathEscape(segment)
+}
+
+// errMissingID is returned before calling the API with an empty ID
+var errMissingID = errors.New("id is required")
@@ -0,0 +1,242 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c := New(server.URL + "/api/")
+	c.SetRetry(2, time.Millisecond)
+	return c
+}
+
+func TestClient_CreateBook(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/books", r.URL.Path)
+		assert.Equal(t, "key-1", r.Header.Get("X-API-Key"))
+		assert.Equal(t, "tenant-1", r.Header.Get("X-Tenant-ID"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var input BookInput
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(Book{ID: "book-1", Title: input.Title, ISBN: input.ISBN})
+	})
+	c.SetAPIKey("key-1")
+	c.SetTenant("tenant-1")
+
+	book, err := c.CreateBook(context.Background(), BookInput{Title: "Dune", Author: "Frank Herbert", Year: 1965, ISBN: "9780441013593"})
+	require.NoError(t, err)
+	assert.Equal(t, "book-1", book.ID)
+	assert.Equal(t, "Dune", book.Title)
+}
+
+func TestClient_SetToken(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer lms_pat_1", r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(Book{ID: "book-1"})
+	})
+	c.SetToken("lms_pat_1")
+
+	_, err := c.GetBook(context.Background(), "book-1")
+	require.NoError(t, err)
+}
+
+func TestClient_SignIn(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth/sessions" {
+			assert.Equal(t, http.MethodPost, r.Method)
+			email, password, ok := r.BasicAuth()
+			assert.True(t, ok)
+			assert.Equal(t, "ada@example.com", email)
+			assert.Equal(t, "s3cret:pass", password)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]string{"id": "session-1", "user_id": "user-1", "token": "lms_sess_1"})
+			return
+		}
+		assert.Equal(t, "Bearer lms_sess_1", r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(Book{ID: "book-1"})
+	})
+
+	session, err := c.SignIn(context.Background(), "ada@example.com", "s3cret:pass")
+	require.NoError(t, err)
+	assert.Equal(t, "session-1", session.ID)
+	assert.Equal(t, "user-1", session.UserID)
+
+	_, err = c.GetBook(context.Background(), "book-1")
+	require.NoError(t, err)
+}
+
+func TestClient_SignInFailure(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid email or password"})
+	})
+
+	_, err := c.SignIn(context.Background(), "ada@example.com", "wrong")
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.Empty(t, c.token)
+}
+
+func TestClient_SearchBooks(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/books/search", r.URL.Path)
+		assert.Equal(t, "herbert", r.URL.Query().Get("author"))
+		assert.Equal(t, "1965", r.URL.Query().Get("year"))
+		json.NewEncoder(w).Encode([]Book{{ID: "book-1"}})
+	})
+
+	books, err := c.SearchBooks(context.Background(), BookSearch{Author: "herbert", Year: 1965})
+	require.NoError(t, err)
+	assert.Len(t, books, 1)
+}
+
+func TestClient_ProcessURLWithProfile(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"url": "http://byfood.com/Tours/#top", "profile": ProfileStrict}, body)
+		w.Write([]byte(`{"processed_url":"https://www.byfood.com/tours"}`))
+	})
+
+	processed, err := c.ProcessURLWithProfile(context.Background(), "http://byfood.com/Tours/#top", ProfileStrict)
+	require.NoError(t, err)
+	assert.Equal(t, "https://www.byfood.com/tours", processed)
+}
+
+func TestClient_TypedErrors(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"book not found"}`))
+	})
+
+	_, err := c.GetBook(context.Background(), "missing")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "book_not_found", apiErr.Code)
+	assert.EqualError(t, err, "api error 404 (book_not_found): book not found")
+
+	_, err = c.GetBook(context.Background(), "")
+	assert.EqualError(t, err, "id is required")
+}
+
+func TestClient_ProblemErrors(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"type":"https://docs.example.com/errors#book_not_found","title":"Not Found","status":404,"detail":"book not found","code":"book_not_found"}`))
+	})
+
+	_, err := c.GetBook(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.EqualError(t, err, "api error 404 (book_not_found): book not found")
+}
+
+func TestClient_RetriesTransientFailures(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":"server is busy, retry later"}`))
+			return
+		}
+		json.NewEncoder(w).Encode([]Book{})
+	})
+
+	_, err := c.ListBooks(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestClient_GivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":"monthly quota exceeded"}`))
+	})
+
+	_, err := c.ListBooks(context.Background())
+	assert.True(t, errors.Is(err, ErrQuotaExceeded))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestClient_DoesNotRetryNonIdempotentGatewayErrors(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	_, err := c.ProcessURL(context.Background(), "https://example.com/?utm_source=x", OperationCanonical)
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "Bad Gateway", apiErr.Message)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestClient_StopsRetryingWhenContextIsDone(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	c.SetRetry(5, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := c.ListBooks(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTimelineIterator(t *testing.T) {
+	events := []TimelineEvent{{Action: "e1"}, {Action: "e2"}, {Action: "e3"}, {Action: "e4"}, {Action: "e5"}}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/books/book-1/timeline", r.URL.Path)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+		start := (page - 1) * pageSize
+		end := start + pageSize
+		if end > len(events) {
+			end = len(events)
+		}
+		json.NewEncoder(w).Encode(TimelinePage{BookID: "book-1", Items: events[start:end], Page: page, PageSize: pageSize, Total: len(events)})
+	})
+
+	it := c.Timeline(context.Background(), "book-1", 2)
+	actions := []string{}
+	for it.Next() {
+		actions = append(actions, it.Event().Action)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"e1", "e2", "e3", "e4", "e5"}, actions)
+	assert.Equal(t, 5, it.Total())
+}
+
+func TestTimelineIterator_StopsOnError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"book not found"}`))
+	})
+
+	it := c.Timeline(context.Background(), "missing", 20)
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), ErrNotFound)
+}
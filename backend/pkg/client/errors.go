@@ -0,0 +1,86 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"library-management-system/internal/domain/domainerr"
+)
+
+// Error classes matched by errors.Is against an *APIError
+var (
+	ErrBadRequest    = errors.New("bad request")
+	ErrNotFound      = errors.New("not found")
+	ErrQuotaExceeded = errors.New("quota exceeded")
+	ErrUnavailable   = errors.New("service unavailable")
+)
+
+// APIError is an error response of the API
+type APIError struct {
+	StatusCode int
+	// Code is the stable code of the error from GET /api/errors, empty for errors outside the catalog
+	Code    string
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("api error %d (%s): %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("api error %d: %s", e.StatusCode, e.Message)
+}
+
+// Is matches the error class of the status code, e.g. errors.Is(err, client.ErrNotFound)
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrQuotaExceeded:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrUnavailable:
+		return e.StatusCode == http.StatusServiceUnavailable
+	}
+	return false
+}
+
+// codes maps error messages to their catalog code
+var codes = func() map[string]string {
+	m := map[string]string{}
+	for _, e := range domainerr.Catalog() {
+		m[e.Message] = e.Code
+	}
+	return m
+}()
+
+// newAPIError reads an error response and closes its body
+func newAPIError(resp *http.Response) *APIError {
+	defer resp.Body.Close()
+	apiErr := &APIError{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+
+	// v1 errors carry an "error" message, problem+json errors a "detail" and their "code"
+	var body struct {
+		Error  string `json:"error"`
+		Detail string `json:"detail"`
+		Code   string `json:"code"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if json.Unmarshal(data, &body) != nil {
+		return apiErr
+	}
+	if body.Error == "" {
+		body.Error = body.Detail
+	}
+	if body.Error != "" {
+		apiErr.Message = body.Error
+		apiErr.Code = codes[body.Error]
+	}
+	if body.Code != "" {
+		apiErr.Code = body.Code
+	}
+	return apiErr
+}
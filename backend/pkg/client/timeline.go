@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// TimelineEvent is an entry of a book's activity stream
+type TimelineEvent struct {
+	Type       string            `json:"type"`
+	Action     string            `json:"action"`
+	Summary    string            `json:"summary"`
+	Details    map[string]string `json:"details,omitempty"`
+	OccurredAt time.Time         `json:"occurred_at"`
+}
+
+// TimelinePage is one page of a book's activity stream
+type TimelinePage struct {
+	BookID   string          `json:"book_id"`
+	Items    []TimelineEvent `json:"items"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"page_size"`
+	Total    int             `json:"total"`
+}
+
+// GetTimelinePage retrieves one page of a book's activity stream, newest first
+func (c *Client) GetTimelinePage(ctx context.Context, bookID string, page, pageSize int) (*TimelinePage, error) {
+	if bookID == "" {
+		return nil, errMissingID
+	}
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("page_size", strconv.Itoa(pageSize))
+
+	var timeline TimelinePage
+	if err := c.do(ctx, http.MethodGet, "/books/"+escape(bookID)+"/timeline", query, nil, &timeline); err != nil {
+		return nil, err
+	}
+	return &timeline, nil
+}
+
+// TimelineIterator walks a book's activity stream page by page:
+//
+//	it := c.Timeline(ctx, bookID, 50)
+//	for it.Next() {
+//		event := it.Event()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type TimelineIterator struct {
+	ctx      context.Context
+	client   *Client
+	bookID   string
+	pageSize int
+
+	page    int
+	items   []TimelineEvent
+	index   int
+	fetched int
+	total   int
+	done    bool
+	err     error
+}
+
+// Timeline returns an iterator over a book's activity stream, fetching pageSize events at a time
+func (c *Client) Timeline(ctx context.Context, bookID string, pageSize int) *TimelineIterator {
+	return &TimelineIterator{ctx: ctx, client: c, bookID: bookID, pageSize: pageSize, index: -1}
+}
+
+// Next advances to the next event, fetching the next page when needed. It returns false at the
+// end of the stream or on error.
+func (it *TimelineIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	it.index++
+	if it.index < len(it.items) {
+		return true
+	}
+	if it.done {
+		return false
+	}
+
+	it.page++
+	timeline, err := it.client.GetTimelinePage(it.ctx, it.bookID, it.page, it.pageSize)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.items = timeline.Items
+	it.index = 0
+	it.fetched += len(timeline.Items)
+	it.total = timeline.Total
+	it.done = len(timeline.Items) == 0 || it.fetched >= timeline.Total
+	return len(it.items) > 0
+}
+
+// Event returns the current event
+func (it *TimelineIterator) Event() TimelineEvent {
+	return it.items[it.index]
+}
+
+// Total returns the number of events in the stream, known once the first page is fetched
+func (it *TimelineIterator) Total() int {
+	return it.total
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *TimelineIterator) Err() error {
+	return it.err
+}
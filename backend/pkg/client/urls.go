@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// URL processing operations
+const (
+	OperationCanonical   = "canonical"
+	OperationRedirection = "redirection"
+	OperationAll         = "all"
+	// Operations used mostly through profiles
+	OperationStripTracking = "strip_tracking"
+	OperationStripFragment = "strip_fragment"
+	OperationHTTPS         = "https"
+	OperationSortQuery     = "sort_query"
+)
+
+// Built-in URL profiles; servers can configure more
+const (
+	ProfileSEO            = "seo"
+	ProfileAnalyticsClean = "analytics-clean"
+	ProfileStrict         = "strict"
+)
+
+// URLProfile is a named bundle of URL operations
+type URLProfile struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Operations  []string `json:"operations"`
+}
+
+// ProcessURL cleans up a URL with one of the URL processing operations
+func (c *Client) ProcessURL(ctx context.Context, rawURL, operation string) (string, error) {
+	return c.processURL(ctx, urlRequest{URL: rawURL, Operation: operation})
+}
+
+// ProcessURLWithProfile cleans up a URL with the operations of a profile
+func (c *Client) ProcessURLWithProfile(ctx context.Context, rawURL, profile string) (string, error) {
+	return c.processURL(ctx, urlRequest{URL: rawURL, Profile: profile})
+}
+
+// ListURLProfiles retrieves the profiles the server offers
+func (c *Client) ListURLProfiles(ctx context.Context) ([]URLProfile, error) {
+	var profiles []URLProfile
+	err := c.do(ctx, http.MethodGet, "/url/profiles", nil, nil, &profiles)
+	return profiles, err
+}
+
+// urlRequest is the body of URL processing requests
+type urlRequest struct {
+	URL       string `json:"url"`
+	Operation string `json:"operation,omitempty"`
+	Profile   string `json:"profile,omitempty"`
+}
+
+func (c *Client) processURL(ctx context.Context, request urlRequest) (string, error) {
+	var response struct {
+		ProcessedURL string `json:"processed_url"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/url/process", nil, request, &response); err != nil {
+		return "", err
+	}
+	return response.ProcessedURL, nil
+}
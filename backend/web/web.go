@@ -0,0 +1,4 @@
+// Package web holds the static frontend build served by the backend itself. The build is
+// embedded only in binaries built with the embedfrontend tag, from the dist directory, which
+// `make build-embedded` fills with the Next.js export.
+package web
@@ -1,22 +1,32 @@
 /** @type {import('next').NextConfig} */
+// NEXT_OUTPUT=export builds static files into out/, for the backend to embed and serve itself
+const staticExport = process.env.NEXT_OUTPUT === 'export';
+
 const nextConfig = {
   experimental: {
     appDir: true,
   },
-  async rewrites() {
-    const apiUrl = process.env.NEXT_PUBLIC_API_URL || 'http://localhost:8080';
-    const apiPrefix = process.env.NEXT_PUBLIC_API_PREFIX || '/api';
-    
-    return [
-      {
-        source: `${apiPrefix}/:path*`,
-        destination: `${apiUrl}${apiPrefix}/:path*`,
-      },
-    ]
-  },
+  ...(staticExport && {
+    output: 'export',
+    images: { unoptimized: true },
+  }),
+  // Rewrites need the Next.js server; a static export calls the API on its own origin
+  ...(!staticExport && {
+    async rewrites() {
+      const apiUrl = process.env.NEXT_PUBLIC_API_URL || 'http://localhost:8080';
+      const apiPrefix = process.env.NEXT_PUBLIC_API_PREFIX || '/api';
+
+      return [
+        {
+          source: `${apiPrefix}/:path*`,
+          destination: `${apiUrl}${apiPrefix}/:path*`,
+        },
+      ]
+    },
+  }),
   env: {
     CUSTOM_KEY: process.env.NEXT_PUBLIC_CUSTOM_KEY,
   },
 }
 
-module.exports = nextConfig 
\ No newline at end of file
+module.exports = nextConfig 
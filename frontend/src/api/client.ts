@@ -0,0 +1,2938 @@
+// Code generated by cmd/genclient from docs/swagger.json. DO NOT EDIT.
+
+import type { AxiosInstance } from 'axios';
+
+export interface AccessToken {
+  created_at?: string;
+  /** ExpiresAt is nil for tokens that never expire */
+  expires_at?: string;
+  /** Hint is the start of the token, for telling tokens apart */
+  hint?: string;
+  id?: string;
+  /** LastUsedAt is updated at most once a minute */
+  last_used_at?: string;
+  name?: string;
+  revoked_at?: string;
+  scopes?: string[];
+  user_id?: string;
+}
+
+export interface AccessTokenCreatedResponse {
+  created_at?: string;
+  /** ExpiresAt is nil for tokens that never expire */
+  expires_at?: string;
+  /** Hint is the start of the token, for telling tokens apart */
+  hint?: string;
+  id?: string;
+  /** LastUsedAt is updated at most once a minute */
+  last_used_at?: string;
+  name?: string;
+  revoked_at?: string;
+  scopes?: string[];
+  token?: string;
+  user_id?: string;
+}
+
+export interface Acquisition {
+  author?: string;
+  /** BookID is the catalog book the acquisition was received as */
+  book_id?: string;
+  created_at?: string;
+  id?: string;
+  isbn?: string;
+  note?: string;
+  ordered_at?: string;
+  /** Reason explains a rejection or cancellation */
+  reason?: string;
+  received_at?: string;
+  /** ReviewedBy is the librarian who last moved the acquisition along */
+  reviewed_by?: string;
+  status?: string;
+  /** SuggestedBy is the member who submitted the suggestion */
+  suggested_by?: string;
+  title?: string;
+  updated_at?: string;
+  vendor?: string;
+  year?: number;
+}
+
+export interface AcquisitionReasonRequest {
+  reason: string;
+}
+
+export interface AddBookCopyRequest {
+  /** BranchID is the branch holding the copy; by default the branch of the librarian, else of the book */
+  branch_id?: string;
+}
+
+export interface AddBookIdentifierRequest {
+  /** issn, asin, doi or internal */
+  type: string;
+  value: string;
+}
+
+export interface AddCollectionBookRequest {
+  book_id: string;
+  /** 1-based position to insert the book at; the book is appended when omitted */
+  position?: number;
+}
+
+export interface AnalyticsCount {
+  count?: number;
+  name?: string;
+}
+
+export interface AnalyticsReport {
+  /** Enabled is false when ANALYTICS_ENABLED opted out; events recorded before are still reported */
+  enabled?: boolean;
+  endpoints?: AnalyticsCount[];
+  features?: AnalyticsCount[];
+  requests?: number;
+  since?: string;
+}
+
+export interface AuditEntry {
+  action?: string;
+  actor?: string;
+  changes?: string;
+  created_at?: string;
+  entity_id?: string;
+  entity_type?: string;
+  id?: string;
+  /** ImpersonatorID flags the changes an admin made while impersonating the actor */
+  impersonator_id?: string;
+}
+
+export interface AuditWriterStats {
+  buffered?: number;
+  capacity?: number;
+  /** DroppedFailed is the number of entries dropped after every attempt to write their batch failed */
+  dropped_failed?: number;
+  /** DroppedFull is the number of entries dropped because the buffer was full */
+  dropped_full?: number;
+  /** Enabled is false when audit entries are written during requests */
+  enabled?: boolean;
+  /** FailedWrites is the number of batch writes that failed, retried or not */
+  failed_writes?: number;
+  written?: number;
+}
+
+export interface AuthCapabilities {
+  /** Admin routes only accept requests from allowed networks */
+  admin_networks_restricted?: boolean;
+  /** Admins sign in to the HTML admin pages with their email and password */
+  admin_ui?: boolean;
+  /** Ways callers are identified: identity_headers (X-User-ID and X-User-Role set by a trusted
+gateway), sessions and access_tokens (bearer tokens)
+example: ["identity_headers","sessions","access_tokens"] */
+  modes?: string[];
+}
+
+export interface AvailabilityPoll {
+  available?: boolean;
+  book_id?: string;
+  /** Changed is false when the poll timed out without a change */
+  changed?: boolean;
+}
+
+export interface Ban {
+  key?: string;
+  /** Strikes is the number of times the key was banned in a row, which sets the length of its ban */
+  strikes?: number;
+  until?: string;
+}
+
+export interface BatchItem {
+  /** Code is the error code of failed items, when it is in the error catalog */
+  code?: string;
+  error?: string;
+  /** Index is the position of the item in the batch */
+  index?: number;
+  /** Status is the HTTP status the item would have had on its own; 424 for items of an atomic
+batch left unwritten because others failed */
+  status?: number;
+}
+
+export interface BookBatchChangeBatchResponse {
+  atomic?: boolean;
+  failed?: number;
+  items?: BookBatchChangeResponse[];
+  /** succeeded when every item did, failed when none did, partial otherwise. Atomic batches are
+succeeded or failed, as nothing is written when an item fails. */
+  status?: string;
+  succeeded?: number;
+  total?: number;
+}
+
+export interface BookBatchChangeResponse {
+  /** Code is the error code of failed items, when it is in the error catalog */
+  code?: string;
+  error?: string;
+  id?: string;
+  /** Index is the position of the item in the batch */
+  index?: number;
+  /** deleted, restored, rejected or aborted */
+  result?: string;
+  /** Status is the HTTP status the item would have had on its own; 424 for items of an atomic
+batch left unwritten because others failed */
+  status?: number;
+}
+
+export interface BookCopy {
+  /** AccessionNumber is the prefix of the branch or library followed by the next number of its
+sequence, e.g. ACC-000042 */
+  accession_number?: string;
+  book_id?: string;
+  /** BranchID is the branch holding the copy, empty for copies of the whole library */
+  branch_id?: string;
+  created_at?: string;
+  id?: string;
+}
+
+export interface BookCopyLookupResponse {
+  book?: BookResponse;
+  copy?: BookCopy;
+}
+
+export interface BookCover {
+  book_id?: string;
+  /** ContentType and Size describe the image, which References books share */
+  content_type?: string;
+  hash?: string;
+  perceptual_hash?: string;
+  references?: number;
+  /** Scan is the malware scan of the image */
+  scan?: unknown;
+  size?: number;
+  updated_at?: string;
+}
+
+export interface BookDetailResponse {
+  /** Authors credited on the book, split from its author
+example: ["Terry Pratchett","Neil Gaiman"] */
+  authors?: string[];
+  availability?: Holding;
+  book?: BookResponse;
+  /** Category of the book from its metadata, empty when it has none
+example: ["Fantasy"] */
+  categories?: string[];
+  copies?: BookCopy[];
+  identifiers?: BookIdentifier[];
+  /** Publisher of the book, null when it names none */
+  publisher?: unknown;
+  /** Series of the book, null when it is not part of one */
+  series?: unknown;
+}
+
+export interface BookDraft {
+  author?: string;
+  /** BaseUpdatedAt is when the book had last been updated as the draft was saved; drafts of
+books that changed since cannot be published */
+  base_updated_at?: string;
+  book_id?: string;
+  /** Changes is the difference with the live book, filled in when the draft is read */
+  changes?: Record<string, unknown>;
+  created_at?: string;
+  description?: string;
+  isbn?: string;
+  metadata?: Record<string, unknown>;
+  publisher_id?: string;
+  series_id?: string;
+  series_position?: number;
+  title?: string;
+  updated_at?: string;
+  year?: number;
+}
+
+export interface BookIDBatchRequest {
+  ids: string[];
+}
+
+export interface BookIdentifier {
+  book_id?: string;
+  created_at?: string;
+  id?: string;
+  type?: string;
+  /** Value is stored normalized, see NormalizeIdentifier */
+  value?: string;
+}
+
+export interface BookImportError {
+  error?: string;
+  /** Index is the position of the book in the import */
+  index?: number;
+  isbn?: string;
+}
+
+export interface BookImportResponse {
+  /** Aborted is set when the import was atomic and books were rejected, so none was written */
+  aborted?: boolean;
+  atomic?: boolean;
+  /** DuplicateOf is the earlier run of the same file, when duplicates are only warned about */
+  duplicate_of?: string;
+  failed?: number;
+  /** Imported is the number of books inserted, or inserted and overwritten with upsert */
+  imported?: number;
+  /** Books imported or skipped are 200, rejected books 400 or the status of their error, and
+valid books of an aborted atomic import 424 */
+  items?: BatchItem[];
+  received?: number;
+  rejected?: BookImportError[];
+  /** RunID is the import run the import is recorded as, when the import history is kept */
+  run_id?: string;
+  /** Skipped is the number of valid books left out because their ISBN was taken */
+  skipped?: number;
+  /** succeeded when every item did, failed when none did, partial otherwise. Atomic batches are
+succeeded or failed, as nothing is written when an item fails. */
+  status?: string;
+  succeeded?: number;
+  total?: number;
+}
+
+export interface BookNeighbors {
+  next_id?: string;
+  /** Position is where the book is listed, from 1, out of Total books */
+  position?: number;
+  /** PreviousID and NextID are the books listed right before and after, null at either end */
+  previous_id?: string;
+  total?: number;
+}
+
+export interface BookResponse {
+  /** Links to the book and what can be done with it, only present with Accept: application/hal+json.
+Books have self, update, delete and timeline links; deleted books restore and purge links. */
+  _links?: Record<string, HALLink>;
+  /** Years since publication, only present with include=computed
+example: 99 */
+  age_years?: number;
+  /** example: F. Scott Fitzgerald */
+  author?: string;
+  /** Whether the book is on the shelf; deleted and archived books are never available
+example: true */
+  available?: boolean;
+  /** Only present when the book belongs to a branch rather than the whole library
+example: 0f8fad5b-d9cb-469f-a165-70867728950e */
+  branch_id?: string;
+  /** Dominant colour of the cover, for placeholder backgrounds while it loads; only present
+when the book has a JPEG, PNG or GIF cover
+example: #1e3a8a */
+  cover_color?: string;
+  /** Most common colours of the cover, the dominant one first
+example: ["#1e3a8a","#f59e0b","#f8fafc"] */
+  cover_palette?: string[];
+  created_at?: string;
+  /** Only present when a timezone was requested */
+  date_metadata?: unknown;
+  /** Whole days since the book was added, only present with include=computed
+example: 12 */
+  days_in_catalog?: number;
+  /** Only present on the trash endpoint */
+  deleted_at?: string;
+  /** Only present when the book has a description */
+  description?: string;
+  /** Number of editions of the work in the results, only present with collapse=work
+example: 3 */
+  edition_count?: number;
+  /** example: 550e8400-e29b-41d4-a716-446655440000 */
+  id?: string;
+  /** example: 9780743273565 */
+  isbn?: string;
+  /** Custom fields of the library, only present when the book has some */
+  metadata?: Record<string, unknown>;
+  /** Only present while the book is scheduled; it is hidden from public listings until then */
+  publish_at?: string;
+  /** Only present when the book is linked to a publisher
+example: 7c9e6679-7425-40de-944b-e07fc1f90ae7 */
+  publisher_id?: string;
+  /** Only present when the book belongs to a series
+example: 1b4e28ba-2fa1-11d2-883f-0016d3cca427 */
+  series_id?: string;
+  /** example: 1 */
+  series_position?: number;
+  /** Names the book in page URLs, see GET /api/books/by-slug/{slug}
+example: the-great-gatsby */
+  slug?: string;
+  /** draft, active or archived; drafts are hidden from public listings
+example: active */
+  status?: string;
+  /** example: The Great Gatsby */
+  title?: string;
+  updated_at?: string;
+  /** Only present when the book is an edition of a work
+example: 9b2e8f4c-6d1a-4f3b-8c7e-2a5d9e0f1b3c */
+  work_id?: string;
+  /** example: 1925 */
+  year?: number;
+}
+
+export interface BookSlugResponse {
+  /** example: 550e8400-e29b-41d4-a716-446655440000 */
+  book_id?: string;
+  /** example: the-great-gatsby */
+  slug?: string;
+}
+
+export interface BookUpsertBatchResponse {
+  atomic?: boolean;
+  failed?: number;
+  items?: BookUpsertResponse[];
+  /** succeeded when every item did, failed when none did, partial otherwise. Atomic batches are
+succeeded or failed, as nothing is written when an item fails. */
+  status?: string;
+  succeeded?: number;
+  total?: number;
+}
+
+export interface BookUpsertResponse {
+  book?: BookResponse;
+  /** Code is the error code of failed items, when it is in the error catalog */
+  code?: string;
+  /** Fields where the client and a book changed on the server since base_updated_at differ */
+  conflicts?: FieldConflict[];
+  error?: string;
+  /** Index is the position of the item in the batch */
+  index?: number;
+  isbn?: string;
+  /** created, updated, conflict, rejected or aborted */
+  result?: string;
+  /** Status is the HTTP status the item would have had on its own; 424 for items of an atomic
+batch left unwritten because others failed */
+  status?: number;
+}
+
+export interface BooksPerYear {
+  books?: number;
+  year?: number;
+}
+
+export interface Bootstrap {
+  admin?: User;
+  policies?: Policy[];
+  tenant?: Tenant;
+}
+
+export interface BootstrapRequest {
+  admin: { email: string; name?: string; password: string };
+  tenant: { name: string; slug?: string };
+}
+
+export interface BootstrapResponse {
+  /** Public configuration, the feature flags under features included */
+  config?: unknown;
+  /** Unread notifications of the caller, null when there is no user
+example: 3 */
+  unread_notifications?: number;
+  /** The caller, null when the request is anonymous or its user does not exist */
+  user?: unknown;
+}
+
+export interface Branch {
+  /** AccessionPrefix starts the accession numbers of the branch's copies, which then have a
+sequence of their own; empty when they share the library's */
+  accession_prefix?: string;
+  created_at?: string;
+  id?: string;
+  name?: string;
+  /** Timezone is the IANA timezone of the branch, empty when it is in the timezone of its tenant */
+  timezone?: string;
+  updated_at?: string;
+}
+
+export interface BruteForceStats {
+  /** BanSeconds is the length of a first ban, doubled with each strike up to MaxBanSeconds */
+  ban_seconds?: number;
+  bans?: Ban[];
+  max_ban_seconds?: number;
+  max_failures?: number;
+  window_seconds?: number;
+}
+
+export interface BusinessHours {
+  /** BranchID is the branch the hours are of, nil for the hours of the whole library */
+  branch_id?: string;
+  closes?: string;
+  created_at?: string;
+  id?: string;
+  /** Opens and Closes are HH:MM */
+  opens?: string;
+  updated_at?: string;
+  /** Weekday is the lowercase English name of the day, e.g. monday */
+  weekday?: string;
+}
+
+export interface BusinessHoursRequest {
+  /** example: 17:00 */
+  closes: string;
+  /** example: 09:00 */
+  opens: string;
+  /** example: monday */
+  weekday: string;
+}
+
+export interface Calendar {
+  branch_id?: string;
+  closed_days?: ClosedDay[];
+  hours?: BusinessHours[];
+}
+
+export interface Capabilities {
+  auth?: AuthCapabilities;
+  /** Books are enriched from Open Library by admin jobs */
+  enrichment?: boolean;
+  /** Members borrow, renew and return books */
+  loans?: boolean;
+  /** Channels events are delivered through
+example: ["email","webhook"] */
+  notifications?: string[];
+  /** Members place holds on books out on loan, kept for them once returned */
+  reservations?: boolean;
+  /** The server is a sandbox, whose data is reset nightly */
+  sandbox?: boolean;
+  search?: SearchCapabilities;
+  /** Where instances keep the state they share, such as rate limits and events: memory (each
+instance on its own) or redis
+example: memory */
+  state?: string;
+  storage?: StorageCapabilities;
+  /** Cache of processed URLs: memory, redis or none
+example: memory */
+  url_cache?: string;
+  /** Events are delivered to the webhook channel */
+  webhooks?: boolean;
+}
+
+export interface Change {
+  /** Routes are the routes affected, as METHOD /path under the API prefix */
+  routes?: string[];
+  summary?: string;
+  type?: string;
+}
+
+export interface ChangeBookStatusRequest {
+  /** draft, active or archived */
+  status: string;
+}
+
+export interface ChangelogResponse {
+  /** Version of the API this server runs
+example: 1.1 */
+  current?: string;
+  /** Versions newest first, with their changes since the previous version */
+  versions?: Version[];
+}
+
+export interface CheckoutRequest {
+  book_id: string;
+  member_id: string;
+  /** OverrideReason lends the book past the loan limit and holds of other members */
+  override_reason?: string;
+}
+
+export interface ClosedDay {
+  /** BranchID is the branch that closes, nil when the whole library does */
+  branch_id?: string;
+  created_at?: string;
+  /** Date is YYYY-MM-DD */
+  date?: string;
+  id?: string;
+  name?: string;
+  /** Yearly closed days are holidays falling on the same month and day every year; the year of
+their date is when they were first observed */
+  yearly?: boolean;
+}
+
+export interface Collection {
+  created_at?: string;
+  description?: string;
+  id?: string;
+  owner_id?: string;
+  /** ShareToken exposes a read-only view of the collection while it is set */
+  share_token?: string;
+  title?: string;
+  updated_at?: string;
+}
+
+export interface CollectionRequest {
+  description?: string;
+  title: string;
+}
+
+export interface CollectionResponse {
+  books?: BookResponse[];
+  created_at?: string;
+  description?: string;
+  id?: string;
+  owner_id?: string;
+  /** ShareToken exposes a read-only view of the collection while it is set */
+  share_token?: string;
+  title?: string;
+  updated_at?: string;
+}
+
+export interface ContactRequest {
+  email?: string;
+  name?: string;
+  /** Phone is cleared when empty */
+  phone?: string;
+}
+
+export interface CoverDuplicateBook {
+  author?: string;
+  book_id?: string;
+  hash?: string;
+  isbn?: string;
+  perceptual_hash?: string;
+  title?: string;
+}
+
+export interface CoverDuplicateGroup {
+  books?: CoverDuplicateBook[];
+  /** Distance is the largest number of bits the perceptual hashes of the group differ by */
+  distance?: number;
+  kind?: string;
+}
+
+export interface CoverDuplicateReport {
+  /** Covers counts the books with a cover compared, and Unhashed those compared by content only */
+  covers?: number;
+  generated_at?: string;
+  groups?: CoverDuplicateGroup[];
+  /** MaxDistance is the number of bits perceptual hashes may differ by for images to look alike */
+  max_distance?: number;
+  unhashed?: number;
+}
+
+export interface CoverImportFile {
+  book_id?: string;
+  /** Code is the error code of failed images, when it is in the error catalog */
+  code?: string;
+  error?: string;
+  /** File is the path of the image in the archive */
+  file?: string;
+  /** ISBN is read from the file name, normalized */
+  isbn?: string;
+  status?: string;
+}
+
+export interface CoverImportJob {
+  completed_at?: string;
+  created_at?: string;
+  error?: string;
+  failed?: number;
+  /** Files counts the images in the archive, Imported those set as covers and Failed the others */
+  files?: number;
+  id?: string;
+  imported?: number;
+  requested_by?: string;
+  /** Results reports on each image, in the order of the archive, once the job has run */
+  results?: CoverImportFile[];
+  status?: string;
+}
+
+export interface CoverURLRequest {
+  url: string;
+}
+
+export interface CreateAccessTokenRequest {
+  /** ExpiresInDays is the lifetime of the token; ACCESS_TOKEN_LIFETIME when omitted */
+  expires_in_days?: number;
+  name: string;
+  /** Scopes are books:read, books:write, url:process or admin:* */
+  scopes: string[];
+}
+
+export interface CreateBookRequest {
+  author: string;
+  /** Branch the book belongs to; librarians of a branch may leave it out to use theirs */
+  branch_id?: string;
+  description?: string;
+  isbn: string;
+  /** Custom fields as string, number or boolean values; tenants can restrict them with metadata fields */
+  metadata?: Record<string, unknown>;
+  /** Hides the book from public listings until this time, RFC 3339; a past time publishes right away */
+  publish_at?: string;
+  publisher_id?: string;
+  series_id?: string;
+  series_position?: number;
+  /** draft or active (the default) */
+  status?: string;
+  title: string;
+  year: number;
+}
+
+export interface CreateBranchRequest {
+  /** AccessionPrefix gives the copies of the branch accession numbers of their own, e.g. HB- */
+  accession_prefix?: string;
+  name: string;
+}
+
+export interface CreateClosedDayRequest {
+  /** BranchID is the branch that closes; without it the whole library does */
+  branch_id?: string;
+  /** example: 2026-12-25 */
+  date: string;
+  /** example: Christmas Day */
+  name?: string;
+  yearly?: boolean;
+}
+
+export interface CreateSeriesRequest {
+  name: string;
+  /** How books are listed: position (default) or publication */
+  ordering?: string;
+}
+
+export interface CreateWorkRequest {
+  /** Defaults to the author of the first edition */
+  author?: string;
+  book_ids?: string[];
+  /** Defaults to the title of the first edition */
+  title?: string;
+}
+
+export interface DatabaseHealth {
+  checked_at?: string;
+  /** Failures is the number of checks in a row that failed */
+  failures?: number;
+  last_error?: string;
+  /** Reconnects is the number of times the connection came back after it was lost */
+  reconnects?: number;
+  /** Since is when the connection entered its state */
+  since?: string;
+  state?: string;
+}
+
+export interface DateMetadata {
+  /** ISO 8601 week of the creation date
+example: 2024-W03 */
+  created_at_iso_week?: string;
+  /** Timezone the timestamps are expressed in
+example: Asia/Tokyo */
+  timezone?: string;
+  /** ISO 8601 week of the last update
+example: 2024-W03 */
+  updated_at_iso_week?: string;
+}
+
+export interface DeadLetter {
+  attempts?: number;
+  error?: string;
+  failed_at?: string;
+  id?: string;
+  /** Kind selects the handler that runs the job from its payload */
+  kind?: string;
+  max_attempts?: number;
+  name?: string;
+  /** Payload is the JSON input of the job */
+  payload?: string;
+  /** Queue is the worker pool the job ran on, e.g. notifications */
+  queue?: string;
+}
+
+export interface EnrichmentFailure {
+  book_id?: string;
+  error?: string;
+}
+
+export interface EnrichmentJob {
+  applied?: number;
+  /** Apply updates the books; otherwise their changes are saved as drafts and covers only proposed */
+  apply?: boolean;
+  /** Candidates counts the books missing a description, publisher or cover that were looked up */
+  candidates?: number;
+  completed_at?: string;
+  created_at?: string;
+  drafted?: number;
+  error?: string;
+  /** Failures lists the books that could not be enriched, up to a limit */
+  failures?: EnrichmentFailure[];
+  id?: string;
+  /** NotFound counts the books the provider does not know, Unchanged those it knew nothing new of */
+  not_found?: number;
+  /** RequestedBy is the user notified of the summary when the job completes */
+  requested_by?: string;
+  /** Skipped counts the books left alone because they already have a draft */
+  skipped?: number;
+  status?: string;
+  unchanged?: number;
+  /** Updates lists the books given new fields, up to a limit */
+  updates?: EnrichmentUpdate[];
+}
+
+export interface EnrichmentRequest {
+  /** Apply updates the books right away; by default their changes are saved as drafts for review */
+  apply?: boolean;
+  /** Limit bounds the books looked up, ENRICH_MAX_BOOKS by default */
+  limit?: number;
+}
+
+export interface EnrichmentUpdate {
+  book_id?: string;
+  /** CoverURL is the cover proposed for a book whose changes were drafted, drafts having no cover */
+  cover_url?: string;
+  fields?: string[];
+}
+
+export interface EntitySchema {
+  entity?: string;
+  fields?: FieldSchema[];
+  /** Metadata are the custom fields the tenant defined for the metadata object */
+  metadata?: FieldSchema[];
+  /** Sorts are the orders search results can be put in with the sort parameter */
+  sorts?: string[];
+}
+
+export interface ErrorCode {
+  /** Stable machine-readable code
+example: book_not_found */
+  code?: string;
+  /** What the error means */
+  description?: string;
+  /** Link to the documentation of the error */
+  docs?: string;
+  /** Message returned in the "error" field of the response
+example: book not found */
+  message?: string;
+  /** HTTP status the error is usually returned with
+example: 404 */
+  status?: number;
+}
+
+export interface ErrorResponse {
+  /** Error message
+example: invalid request payload */
+  error?: string;
+}
+
+export interface ExploreAggregate {
+  field?: string;
+  func?: string;
+}
+
+export interface ExploreEntity {
+  description?: string;
+  fields?: ExploreField[];
+  name?: string;
+}
+
+export interface ExploreField {
+  description?: string;
+  name?: string;
+  /** Type is string, integer, boolean or time; month fields are strings formatted as 2006-01 */
+  type?: string;
+}
+
+export interface ExploreFilter {
+  field?: string;
+  op?: string;
+  value?: unknown;
+}
+
+export interface ExploreQuery {
+  aggregates?: ExploreAggregate[];
+  entity?: string;
+  filters?: ExploreFilter[];
+  group_by?: string[];
+  limit?: number;
+}
+
+export interface ExploreResult {
+  /** Columns are the group-by fields followed by the aggregates */
+  columns?: string[];
+  rows?: unknown[][];
+  /** Truncated is set when more groups than the limit matched */
+  truncated?: boolean;
+}
+
+export interface FieldConflict {
+  client?: unknown;
+  field?: string;
+  /** Kept is the side whose value was kept, server or client */
+  kept?: string;
+  server?: unknown;
+}
+
+export interface FieldRule {
+  category?: string;
+  kind?: string;
+  /** Message is the error books failing the rule get */
+  message?: string;
+  name?: string;
+  rule_id?: string;
+}
+
+export interface FieldSchema {
+  description?: string;
+  enum?: string[];
+  /** Filter is the query parameter of searches filtering on the field */
+  filter?: string;
+  filterable?: boolean;
+  /** Format refines the type, e.g. uuid or date-time */
+  format?: string;
+  max_length?: number;
+  maximum?: number;
+  min_length?: number;
+  minimum?: number;
+  name?: string;
+  nullable?: boolean;
+  /** ReadOnly fields are set by the server and ignored when sent */
+  read_only?: boolean;
+  required?: boolean;
+  /** Rules are the enabled validation rules checking the field, some only in a category */
+  rules?: FieldRule[];
+  sortable?: boolean;
+  type?: string;
+  unique?: boolean;
+}
+
+export interface Fine {
+  /** AmountCents is the amount in the smallest unit of the library's currency */
+  amount_cents?: number;
+  created_at?: string;
+  id?: string;
+  /** LoanID is the loan the fine was charged for, if any */
+  loan_id?: string;
+  paid_at?: string;
+  reason?: string;
+  tenant_id?: string;
+  updated_at?: string;
+  user_id?: string;
+}
+
+export interface GroupEditionsRequest {
+  book_ids: string[];
+}
+
+export interface HALLink {
+  /** example: /api/books/550e8400-e29b-41d4-a716-446655440000 */
+  href?: string;
+  /** HTTP method to follow the link with, a hint for links other than GET
+example: PUT */
+  method?: string;
+}
+
+export interface HoldPosition {
+  book_id?: string;
+  created_at?: string;
+  /** EstimatedAvailableAt is when a copy should come back for the hold, going by the due dates of
+the loans of the book. It is empty for ready holds, and when no copy is out on loan. */
+  estimated_available_at?: string;
+  id?: string;
+  /** QueuePosition is 1 for the next member to get a copy back, and 0 once the hold is ready */
+  queue_position?: number;
+  status?: string;
+  tenant_id?: string;
+  updated_at?: string;
+  user_id?: string;
+}
+
+export interface Holding {
+  /** Available tells whether the book can be borrowed, like the available field of books */
+  available?: boolean;
+  book_id?: string;
+  /** BranchID is the branch holding the book, absent for books of the whole library */
+  branch_id?: string;
+  /** DueBack is when the first copy on loan is due back */
+  due_back?: string;
+  holds?: number;
+  /** Loans counts the copies out on loan and Holds the members waiting for one */
+  loans?: number;
+}
+
+export interface ImpersonateRequest {
+  reason: string;
+}
+
+export interface Impersonation {
+  /** Actions are the audit entries of the changes made during the impersonation, oldest first */
+  actions?: AuditEntry[];
+  created_at?: string;
+  /** Device is the User-Agent the session was signed in with */
+  device?: string;
+  expires_at?: string;
+  id?: string;
+  /** ImpersonationReason is why the admin impersonates the user, such as the issue to reproduce */
+  impersonation_reason?: string;
+  /** ImpersonatorID is the admin who started the session to act as its user, nil for sessions the
+user signed in to themselves */
+  impersonator_id?: string;
+  /** IP is the client IP the session was last seen from */
+  ip?: string;
+  /** LastSeenAt is updated at most once a minute */
+  last_seen_at?: string;
+  revoked_at?: string;
+  user_id?: string;
+}
+
+export interface ImportBookRequest {
+  author?: string;
+  isbn?: string;
+  metadata?: Record<string, unknown>;
+  publisher_id?: string;
+  /** draft or active (the default) */
+  status?: string;
+  title?: string;
+  year?: number;
+}
+
+export interface ImportBooksRequest {
+  books: ImportBookRequest[];
+  /** skip (the default) keeps the existing book when an ISBN is taken, upsert overwrites it */
+  on_conflict?: "skip" | "upsert";
+}
+
+export interface ImportProfile {
+  /** Columns maps the CSV header of each imported column to a book field; other columns are ignored */
+  columns?: Record<string, string>;
+  created_at?: string;
+  /** DateFormat is the Go time layout of the year column, e.g. 02/01/2006, when the feed has
+dates rather than years; empty reads plain years */
+  date_format?: string;
+  /** Delimiter separates the columns, a comma when empty */
+  delimiter?: string;
+  name?: string;
+  tenant_id?: string;
+  updated_at?: string;
+}
+
+export interface ImportRun {
+  created_at?: string;
+  /** DuplicateOf is the earlier run of the same file, when a duplicate was let through with a warning */
+  duplicate_of?: string;
+  /** Error is why a failed run imported nothing, or stopped partway */
+  error?: string;
+  /** Errors lists the books a completed run rejected */
+  errors?: ImportRunError[];
+  /** FileHash is the hex SHA-256 of the request body */
+  file_hash?: string;
+  format?: string;
+  id?: string;
+  imported?: number;
+  /** Profile is the import profile a CSV file was read with */
+  profile?: string;
+  /** Received, Imported, Skipped and Rejected count the books of a completed run */
+  received?: number;
+  rejected?: number;
+  /** Scan is the malware scan of the file, when it was scanned */
+  scan?: unknown;
+  skipped?: number;
+  status?: string;
+  /** TenantID is the tenant the file was imported for, empty without one */
+  tenant_id?: string;
+}
+
+export interface ImportRunError {
+  error?: string;
+  /** Index is the position of the book in the file, from 0 */
+  index?: number;
+  isbn?: string;
+}
+
+export interface InventoryItem {
+  barcode?: string;
+  book_id?: string;
+  expected_location?: string;
+  found_location?: string;
+  title?: string;
+}
+
+export interface InventoryReport {
+  /** Final is false for previews of sessions that are still open */
+  final?: boolean;
+  found?: number;
+  generated_at?: string;
+  /** GeneratedAtISOWeek is the ISO 8601 week of the report in the timezone it was asked in,
+filled in by the API and not stored */
+  generated_at_iso_week?: string;
+  /** Misplaced books were found somewhere other than where the previous audit found them */
+  misplaced?: InventoryItem[];
+  /** Missing books are in the catalog but were not scanned */
+  missing?: InventoryItem[];
+  scanned?: number;
+  session_id?: string;
+  /** Unexpected barcodes do not belong to any book of the catalog */
+  unexpected?: InventoryItem[];
+}
+
+export interface InventorySession {
+  closed_at?: string;
+  created_at?: string;
+  id?: string;
+  name?: string;
+  opened_by?: string;
+  status?: string;
+  updated_at?: string;
+}
+
+export interface JSONFeed {
+  feed_url?: string;
+  home_page_url?: string;
+  items?: JSONFeedItem[];
+  title?: string;
+  version?: string;
+}
+
+export interface JSONFeedAuthor {
+  name?: string;
+}
+
+export interface JSONFeedItem {
+  /** Book is the book as the API lists it, an extension of the format */
+  _book?: unknown;
+  authors?: JSONFeedAuthor[];
+  content_text?: string;
+  date_modified?: string;
+  date_published?: string;
+  id?: string;
+  /** Image is the cover of the book, left out when it has none */
+  image?: string;
+  title?: string;
+  /** URL is the page of the book on the library site */
+  url?: string;
+}
+
+export interface JobPriorityStats {
+  /** AvgWaitMs and MaxWaitMs are how long attempts waited for a worker, in milliseconds */
+  avg_wait_ms?: number;
+  enqueued?: number;
+  failed?: number;
+  max_wait_ms?: number;
+  /** Priority is interactive, scheduled or backfill */
+  priority?: string;
+  queued?: number;
+  rejected?: number;
+  /** Succeeded and Failed count the jobs done, failed ones after all their attempts */
+  succeeded?: number;
+  /** Weight is the share of the workers the priority gets while other priorities wait too */
+  weight?: number;
+}
+
+export interface LibraryStats {
+  books_per_year?: BooksPerYear[];
+  loans_per_month?: LoansPerMonth[];
+  /** RefreshedAt is when the least recently refreshed table was refreshed, nil until every
+table has been refreshed once */
+  refreshed_at?: string;
+  /** Refreshes tells when each table was last refreshed */
+  refreshes?: StatsRefresh[];
+  /** Stale is set when a table was not refreshed within the maximum age of the stats */
+  stale?: boolean;
+  top_authors?: TopAuthor[];
+}
+
+export interface Loan {
+  book_id?: string;
+  borrowed_at?: string;
+  created_at?: string;
+  due_at?: string;
+  id?: string;
+  /** Renewals counts how many times the due date was pushed back */
+  renewals?: number;
+  returned_at?: string;
+  tenant_id?: string;
+  updated_at?: string;
+  user_id?: string;
+}
+
+export interface LoansPerMonth {
+  loans?: number;
+  month?: string;
+  returned?: number;
+}
+
+export interface MemberFines {
+  items?: Fine[];
+  outstanding_cents?: number;
+}
+
+export interface MessageResponse {
+  /** Informational message
+example: operation completed successfully */
+  message?: string;
+}
+
+export interface MetadataField {
+  created_at?: string;
+  key?: string;
+  /** Label is how the field is shown to librarians */
+  label?: string;
+  /** Required fields must be present on every book saved for the tenant */
+  required?: boolean;
+  tenant_id?: string;
+  type?: string;
+  updated_at?: string;
+}
+
+export interface Notification {
+  book_id?: string;
+  created_at?: string;
+  id?: string;
+  message?: string;
+  read_at?: string;
+  recipient_id?: string;
+  title?: string;
+  type?: string;
+}
+
+export interface OpenInventorySessionRequest {
+  /** example: Spring 2024 audit */
+  name: string;
+}
+
+export interface OperationStats {
+  avg_ms?: number;
+  calls?: number;
+  /** ErrorRate is errors over calls */
+  error_rate?: number;
+  errors?: number;
+  max_ms?: number;
+  /** Name is the operation, e.g. books.GetByID */
+  name?: string;
+  /** TotalMs, AvgMs and MaxMs are the total, average and longest duration of the calls in
+milliseconds */
+  total_ms?: number;
+}
+
+export interface OrderAcquisitionRequest {
+  /** Completes or corrects the ISBN of the suggestion */
+  isbn?: string;
+  vendor: string;
+  /** Completes or corrects the year of the suggestion */
+  year?: number;
+}
+
+export interface OverrideReport {
+  /** ByBlock and ByShift count the overrides by block and by shift */
+  by_block?: Record<string, number>;
+  by_shift?: Record<string, number>;
+  /** ByStaff counts the overrides of each member of staff, the most first */
+  by_staff?: StaffOverrides[];
+  from?: string;
+  overrides?: PolicyOverride[];
+  to?: string;
+  total?: number;
+  /** Week is the ISO week of the report, e.g. 2024-W03 */
+  week?: string;
+}
+
+export interface PageSizes {
+  /** example: 20 */
+  default?: number;
+  /** example: 100 */
+  max?: number;
+}
+
+export interface PasswordPolicy {
+  /** example: 12 */
+  min_length?: number;
+  require_digit?: boolean;
+  require_lowercase?: boolean;
+  /** Symbols are any character that is not a letter, a digit or a space */
+  require_symbol?: boolean;
+  require_uppercase?: boolean;
+}
+
+export interface Policy {
+  key?: string;
+  tenant_id?: string;
+  updated_at?: string;
+  value?: string;
+}
+
+export interface PolicyOverride {
+  /** Block is the code of the error the policy would have answered, e.g. loan_limit_reached */
+  block?: string;
+  book_id?: string;
+  loan_id?: string;
+  member_id?: string;
+  /** Operation is checkout or renewal */
+  operation?: string;
+  overridden_at?: string;
+  reason?: string;
+  /** Shift is the shift of the staff at the time of the override */
+  shift?: string;
+  staff_id?: string;
+}
+
+export interface ProcessURLsRequest {
+  requests: URLRequest[];
+}
+
+export interface PublicAuthPolicy {
+  /** Default and longest lifetime of personal access tokens, in seconds; zero lets them last forever
+example: 7776000 */
+  access_token_lifetime_seconds?: number;
+  /** example: 31536000 */
+  access_token_max_lifetime_seconds?: number;
+  /** Active sessions a user may have; signing in past it signs out the sessions seen least recently.
+Zero leaves them unlimited.
+example: 10 */
+  max_sessions_per_user?: number;
+  password?: PasswordPolicy;
+  /** example: 2592000 */
+  session_lifetime_seconds?: number;
+}
+
+export interface PublicConfig {
+  /** example: /api */
+  api_prefix?: string;
+  /** example: v1 */
+  api_version?: string;
+  auth?: PublicAuthPolicy;
+  features?: PublicFeatures;
+  /** Locales the API writes messages in
+example: ["en"] */
+  locales?: string[];
+  /** Longest a long poll is held open, in seconds
+example: 30 */
+  long_poll_timeout_seconds?: number;
+  /** Page sizes of the paginated listings, by listing */
+  pagination?: Record<string, PageSizes>;
+}
+
+export interface PublicFeatures {
+  admin_ui?: boolean;
+  /** The v2 response format is served under /v2 and through the Accept header */
+  api_v2?: boolean;
+  /** Requests are metered against a monthly quota, per signed-in user or else per client IP */
+  quota?: boolean;
+  /** The server is a sandbox, whose data is reset nightly */
+  sandbox?: boolean;
+  swagger?: boolean;
+  /** The anonymous URL processor requires an X-URL-Token: none, token or captcha
+example: none */
+  url_token_mode?: string;
+}
+
+export interface Publisher {
+  created_at?: string;
+  id?: string;
+  name?: string;
+  parent_id?: string;
+  updated_at?: string;
+}
+
+export interface PublisherRequest {
+  name: string;
+  /** Parent publisher ID; set it to make the publisher an imprint */
+  parent_id?: string;
+}
+
+export interface ReadinessResponse {
+  database?: DatabaseHealth;
+  /** ready, read_only, unavailable or draining */
+  status?: string;
+}
+
+export interface RelatedBookResponse {
+  book?: BookResponse;
+  /** CoBorrowers counts the members who borrowed both books */
+  co_borrowers?: number;
+  rank?: number;
+  /** Reasons lists the signals relating the books: same_author, same_series, shared_category
+and co_borrowed */
+  reasons?: string[];
+  score?: number;
+}
+
+export interface RelatedBooksResponse {
+  book_id?: string;
+  /** RefreshedAt is when the ranking was computed, nil when the book has no related books */
+  refreshed_at?: string;
+  related?: RelatedBookResponse[];
+}
+
+export interface RenewRequest {
+  /** OverrideReason renews the loan past the renewal limit and holds of other members */
+  override_reason?: string;
+}
+
+export interface ReorderCollectionRequest {
+  book_ids: string[];
+}
+
+export interface ReportSubscription {
+  channel?: string;
+  created_at?: string;
+  created_by?: string;
+  enabled?: boolean;
+  id?: string;
+  last_run_at?: string;
+  last_status?: string;
+  next_run_at?: string;
+  report?: string;
+  /** Schedule is a five-field cron expression evaluated in the timezone of the tenant, e.g. "0 8 * * mon" */
+  schedule?: string;
+  /** Target is the email address or the webhook URL the report is delivered to */
+  target?: string;
+  /** TenantID is the tenant whose timezone the schedule is in, nil for the default timezone */
+  tenant_id?: string;
+  updated_at?: string;
+}
+
+export interface ReportSubscriptionRequest {
+  /** email or webhook
+example: webhook */
+  channel: string;
+  /** Defaults to true */
+  enabled?: boolean;
+  /** weekly_stats or weeding
+example: weekly_stats */
+  report: string;
+  /** Cron expression evaluated in the timezone of the tenant
+example: 0 8 * * mon */
+  schedule: string;
+  /** Email address or webhook URL
+example: https://example.com/hooks/reports */
+  target: string;
+}
+
+export interface ReportSubscriptionRun {
+  error?: string;
+  finished_at?: string;
+  id?: string;
+  started_at?: string;
+  status?: string;
+  subscription_id?: string;
+}
+
+export interface ResizeWorkerPoolRequest {
+  /** example: 8 */
+  workers: number;
+}
+
+export interface RevokedSessionsResponse {
+  revoked?: number;
+}
+
+export interface RuleViolation {
+  message?: string;
+  rule_id?: string;
+  rule_name?: string;
+}
+
+export interface SandboxBaseline {
+  /** example: 2026-10-16T04:00:00Z */
+  saved_at?: string;
+  /** Tables copied into the baseline
+example: 42 */
+  tables?: number;
+}
+
+export interface SaveImportProfileRequest {
+  /** CSV header of each imported column mapped to title, author, year, isbn, publisher_id,
+status or metadata.{key} */
+  columns: Record<string, string>;
+  /** Go time layout of the year column when it holds dates, e.g. 02/01/2006 */
+  date_format?: string;
+  /** Column separator, a comma by default */
+  delimiter?: string;
+}
+
+export interface SaveMetadataFieldRequest {
+  label?: string;
+  required?: boolean;
+  /** string, number or boolean */
+  type: string;
+}
+
+export interface ScanBatchResult {
+  /** Matched counts the barcodes of the batch that belong to a catalog book */
+  matched?: number;
+  recorded?: number;
+  /** Total is the number of scans of the session so far */
+  total?: number;
+  /** Unmatched lists the barcodes of the batch that belong to no catalog book */
+  unmatched?: string[];
+}
+
+export interface ScanRequest {
+  /** ISBNs read from the books, hyphens are ignored */
+  barcodes: string[];
+  /** Shelf or room the barcodes were scanned at
+example: Fiction A-F */
+  location?: string;
+}
+
+export interface ScanResult {
+  /** Error is why a failed scan failed */
+  error?: string;
+  scanned_at?: string;
+  /** Scanner names the scanner, none when scanning is off */
+  scanner?: string;
+  /** Signature is the malware the scanner found in an infected file */
+  signature?: string;
+  status?: string;
+}
+
+export interface ScheduledJob {
+  description?: string;
+  enabled?: boolean;
+  /** LastDurationMs is how long the last run took, in milliseconds */
+  last_duration_ms?: number;
+  last_error?: string;
+  last_run_at?: string;
+  last_status?: string;
+  /** LockErrorCount is how many runs failed because the lock could not be checked */
+  lock_error_count?: number;
+  /** LockMissCount is how many runs were left to another instance holding the lock */
+  lock_miss_count?: number;
+  name?: string;
+  next_run_at?: string;
+  /** Priority is the priority the job runs with on the job queue */
+  priority?: string;
+  /** RunCount is how many times this instance ran the job since it started */
+  run_count?: number;
+  running?: boolean;
+  /** Schedule is a five-field cron expression evaluated in UTC */
+  schedule?: string;
+}
+
+export interface SearchCapabilities {
+  /** example: postgres */
+  backend?: string;
+  /** Listings and searches are served from a cache, refreshed in the background once stale */
+  cached?: boolean;
+  /** Expensive searches are limited per caller and may get 429 */
+  throttled?: boolean;
+}
+
+export interface SelfTestCheck {
+  /** What passed, why the check failed or why it was skipped
+example: connected */
+  detail?: string;
+  duration_ms?: number;
+  /** example: database */
+  name?: string;
+  /** pass, fail or skip
+example: pass */
+  status?: string;
+}
+
+export interface SelfTestReport {
+  checks?: SelfTestCheck[];
+  passed?: boolean;
+  ran_at?: string;
+}
+
+export interface Series {
+  created_at?: string;
+  id?: string;
+  name?: string;
+  /** Ordering is either "position" (series_position) or "publication" (year) */
+  ordering?: string;
+  updated_at?: string;
+}
+
+export interface Session {
+  created_at?: string;
+  /** Device is the User-Agent the session was signed in with */
+  device?: string;
+  expires_at?: string;
+  id?: string;
+  /** ImpersonationReason is why the admin impersonates the user, such as the issue to reproduce */
+  impersonation_reason?: string;
+  /** ImpersonatorID is the admin who started the session to act as its user, nil for sessions the
+user signed in to themselves */
+  impersonator_id?: string;
+  /** IP is the client IP the session was last seen from */
+  ip?: string;
+  /** LastSeenAt is updated at most once a minute */
+  last_seen_at?: string;
+  revoked_at?: string;
+  user_id?: string;
+}
+
+export interface SessionCreatedResponse {
+  created_at?: string;
+  /** Device is the User-Agent the session was signed in with */
+  device?: string;
+  expires_at?: string;
+  id?: string;
+  /** ImpersonationReason is why the admin impersonates the user, such as the issue to reproduce */
+  impersonation_reason?: string;
+  /** ImpersonatorID is the admin who started the session to act as its user, nil for sessions the
+user signed in to themselves */
+  impersonator_id?: string;
+  /** IP is the client IP the session was last seen from */
+  ip?: string;
+  /** LastSeenAt is updated at most once a minute */
+  last_seen_at?: string;
+  revoked_at?: string;
+  token?: string;
+  user_id?: string;
+}
+
+export interface SessionResponse {
+  created_at?: string;
+  /** Current marks the session the request was signed in with */
+  current?: boolean;
+  /** Device is the User-Agent the session was signed in with */
+  device?: string;
+  expires_at?: string;
+  id?: string;
+  /** ImpersonationReason is why the admin impersonates the user, such as the issue to reproduce */
+  impersonation_reason?: string;
+  /** ImpersonatorID is the admin who started the session to act as its user, nil for sessions the
+user signed in to themselves */
+  impersonator_id?: string;
+  /** IP is the client IP the session was last seen from */
+  ip?: string;
+  /** LastSeenAt is updated at most once a minute */
+  last_seen_at?: string;
+  revoked_at?: string;
+  user_id?: string;
+}
+
+export interface SetBusinessHoursRequest {
+  hours?: BusinessHoursRequest[];
+}
+
+export interface SetTimezoneRequest {
+  /** IANA name of the timezone; empty to clear it
+example: Asia/Tokyo */
+  timezone?: string;
+}
+
+export interface SetupStatus {
+  /** Whether the deployment already has users */
+  bootstrapped?: boolean;
+  /** Whether a setup token is configured */
+  enabled?: boolean;
+}
+
+export interface SharedCollectionResponse {
+  books?: BookResponse[];
+  /** example: Books to take to the beach */
+  description?: string;
+  /** example: Summer reading */
+  title?: string;
+  updated_at?: string;
+}
+
+export interface SitemapFailure {
+  error?: string;
+  loc?: string;
+}
+
+export interface SitemapJob {
+  completed_at?: string;
+  created_at?: string;
+  entries?: number;
+  error?: string;
+  /** Failures lists entries left unchanged because they could not be processed, up to a limit */
+  failures?: SitemapFailure[];
+  id?: string;
+  operation?: string;
+  profile?: string;
+  /** Rewritten counts the entries whose URL changed */
+  rewritten?: number;
+  /** Source is the URL the sitemap was fetched from, or "upload" */
+  source?: string;
+  status?: string;
+}
+
+export interface SitemapSubmission {
+  operation?: string;
+  profile?: string;
+  url?: string;
+}
+
+export interface StaffOverrides {
+  count?: number;
+  staff_id?: string;
+}
+
+export interface StatsRefresh {
+  name?: string;
+  refreshed_at?: string;
+  rows?: number;
+}
+
+export interface StorageArea {
+  /** Error is why the area could not be measured */
+  error?: string;
+  level?: string;
+  /** LimitBytes is the soft quota of the area, zero without one */
+  limit_bytes?: number;
+  name?: string;
+  /** Path is the directory of the area, empty for the database */
+  path?: string;
+  used_bytes?: number;
+  used_percent?: number;
+}
+
+export interface StorageCapabilities {
+  /** Covers can be set from a URL the server fetches */
+  cover_fetch?: boolean;
+  /** Where cover images are stored
+example: filesystem */
+  covers?: string;
+  /** Storage areas are served read-only over WebDAV */
+  webdav?: boolean;
+}
+
+export interface StorageReport {
+  areas?: StorageArea[];
+  checked_at?: string;
+  warn_percent?: number;
+}
+
+export interface SuggestionRequest {
+  author: string;
+  isbn?: string;
+  /** Why the member would like the library to have the book */
+  note?: string;
+  title: string;
+  year?: number;
+}
+
+export interface Tenant {
+  created_at?: string;
+  id?: string;
+  name?: string;
+  slug?: string;
+  /** Timezone is the IANA timezone due dates, stats and report schedules of the tenant are in,
+empty for the default timezone of the deployment */
+  timezone?: string;
+  updated_at?: string;
+}
+
+export interface TimelineEvent {
+  action?: string;
+  details?: Record<string, string>;
+  occurred_at?: string;
+  summary?: string;
+  type?: string;
+}
+
+export interface TimelinePage {
+  book_id?: string;
+  items?: TimelineEvent[];
+  page?: number;
+  page_size?: number;
+  total?: number;
+}
+
+export interface Timezone {
+  branch_id?: string;
+  /** Source is branch, tenant or default */
+  source?: string;
+  tenant_id?: string;
+  /** Name is the IANA name of the timezone, e.g. Asia/Tokyo */
+  timezone?: string;
+}
+
+export interface TopAuthor {
+  author?: string;
+  books?: number;
+  loans?: number;
+  rank?: number;
+}
+
+export interface URLBatchItem {
+  cached?: boolean;
+  /** Code is the error code of failed items, when it is in the error catalog */
+  code?: string;
+  error?: string;
+  /** Index is the position of the item in the batch */
+  index?: number;
+  processed_url?: string;
+  /** Status is the HTTP status the item would have had on its own; 424 for items of an atomic
+batch left unwritten because others failed */
+  status?: number;
+}
+
+export interface URLBatchResponse {
+  atomic?: boolean;
+  failed?: number;
+  items?: URLBatchItem[];
+  /** succeeded when every item did, failed when none did, partial otherwise. Atomic batches are
+succeeded or failed, as nothing is written when an item fails. */
+  status?: string;
+  succeeded?: number;
+  total?: number;
+}
+
+export interface URLCacheStats {
+  backend?: string;
+  enabled?: boolean;
+  errors?: number;
+  hit_ratio?: number;
+  hits?: number;
+  misses?: number;
+  ttl_seconds?: number;
+}
+
+export interface URLGuardStats {
+  /** IPLimit is the number of requests an IP may make per window, zero when unlimited */
+  ip_limit?: number;
+  /** Rejected counts the rejected requests by error code */
+  rejected?: Record<string, number>;
+  rejected_total?: number;
+  token_required?: boolean;
+  window_seconds?: number;
+}
+
+export interface URLOptions {
+  /** CollapseSlashes turns runs of slashes in the path into one */
+  collapse_slashes?: boolean;
+  /** PathEncoding is lowercase to lowercase percent-encodings too (default), strict to keep them
+upper-case as RFC 3986 does, or decode to show the characters they stand for */
+  path_encoding?: string;
+  /** RemoveDotSegments resolves "." and ".." segments of the path */
+  remove_dot_segments?: boolean;
+  /** RootPath is slash to keep "/" for the root (default) or empty to drop it */
+  root_path?: string;
+  /** StripDefaultPort drops :80 from http URLs and :443 from https ones */
+  strip_default_port?: boolean;
+}
+
+export interface URLProfile {
+  description?: string;
+  name?: string;
+  operations?: string[];
+}
+
+export interface URLRequest {
+  operation?: string;
+  /** Options tune canonical processing and lowercasing, by the canonical, redirection and all
+operations or within a profile */
+  options?: unknown;
+  /** Profile names a bundle of operations to apply instead of a single operation */
+  profile?: string;
+  url?: string;
+}
+
+export interface URLResponse {
+  processed_url?: string;
+}
+
+export interface URLResult {
+  /** Cached is set when the result was served from the cache */
+  cached?: boolean;
+  created_at?: string;
+  /** Host is the lower-case host of the URL, which the history is filtered by domain with */
+  host?: string;
+  id?: string;
+  /** Operations are the operations applied in order, joined with commas */
+  operations?: string;
+  processed_url?: string;
+  /** Profile is the profile the operations came from, if any */
+  profile?: string;
+  url?: string;
+}
+
+export interface UnreadCountResponse {
+  /** Number of unread notifications
+example: 3 */
+  unread?: number;
+}
+
+export interface UpdateBookRequest {
+  author: string;
+  /** Moves the book to another branch; left out, the book keeps its branch */
+  branch_id?: string;
+  description?: string;
+  isbn: string;
+  /** Custom fields as string, number or boolean values; tenants can restrict them with metadata fields */
+  metadata?: Record<string, unknown>;
+  publisher_id?: string;
+  series_id?: string;
+  series_position?: number;
+  title: string;
+  year: number;
+}
+
+export interface UpsertBookRequest {
+  author?: string;
+  /** The updated_at of the book when the client last synced it; the book is checked for
+conflicting changes on the server since then */
+  base_updated_at?: string;
+  /** Fields the client changed since then, whose values conflict_policy=merge takes */
+  changed_fields?: string[];
+  isbn?: string;
+  metadata?: Record<string, unknown>;
+  publisher_id?: string;
+  /** draft or active (the default) */
+  status?: string;
+  title?: string;
+  year?: number;
+}
+
+export interface Usage {
+  limit?: number;
+  period?: string;
+  remaining?: number;
+  resets_at?: string;
+  subject?: string;
+  used?: number;
+}
+
+export interface User {
+  /** AnonymizedAt is set once the personal details of a deactivated account are erased for good */
+  anonymized_at?: string;
+  /** BranchID is the branch a librarian works for, nil for staff of the whole library. Requests
+signed in as the user carry it in place of any X-User-Branch header the client sent. */
+  branch_id?: string;
+  created_at?: string;
+  /** DeactivatedAt is set while the account is deactivated: its user can neither sign in nor
+borrow, and their loans and fines are kept */
+  deactivated_at?: string;
+  /** Email is stored normalized and is unique whatever its case, see NormalizeEmail */
+  email?: string;
+  id?: string;
+  name?: string;
+  /** Phone is where the library reaches a member besides email */
+  phone?: string;
+  role?: string;
+  tenant_id?: string;
+  updated_at?: string;
+}
+
+export interface ValidationRule {
+  /** Category limits the rule to books whose category metadata equals it; empty applies the
+rule to every book */
+  category?: string;
+  created_at?: string;
+  enabled?: boolean;
+  id?: string;
+  kind?: string;
+  max_year?: number;
+  /** Message replaces the default error message of the rule */
+  message?: string;
+  /** MetadataKey is the metadata key that must be set, for required_metadata rules */
+  metadata_key?: string;
+  /** MinYear and MaxYear bound the year, inclusive, for year_range rules; either may be open */
+  min_year?: number;
+  name?: string;
+  /** Pattern is the regular expression the start of the ISBN must match, for isbn_prefix rules */
+  pattern?: string;
+  updated_at?: string;
+}
+
+export interface ValidationRuleRequest {
+  /** Limits the rule to books whose category metadata equals it
+example: comics */
+  category?: string;
+  /** Defaults to true */
+  enabled?: boolean;
+  /** isbn_prefix, required_metadata or year_range
+example: isbn_prefix */
+  kind: string;
+  max_year?: number;
+  /** Replaces the default error message */
+  message?: string;
+  /** Metadata key that must be set, for required_metadata rules */
+  metadata_key?: string;
+  /** Inclusive year bounds, for year_range rules */
+  min_year?: number;
+  /** example: Publisher ISBN range */
+  name: string;
+  /** Regular expression the start of the ISBN must match, for isbn_prefix rules
+example: 97[89] */
+  pattern?: string;
+}
+
+export interface ValidationRuleTestRequest {
+  author?: string;
+  isbn?: string;
+  metadata?: Record<string, unknown>;
+  title?: string;
+  year?: number;
+}
+
+export interface ValidationRuleTestResponse {
+  valid?: boolean;
+  violations?: RuleViolation[];
+}
+
+export interface Version {
+  changes?: Change[];
+  date?: string;
+  version?: string;
+}
+
+export interface WeedingCandidate {
+  author?: string;
+  book_id?: string;
+  copies?: number;
+  isbn?: string;
+  /** LastActivityAt is the latest loan or catalog change of the book */
+  last_activity_at?: string;
+  last_activity_at_iso_week?: string;
+  /** LastLoanedAt is empty for books that were never loaned */
+  last_loaned_at?: string;
+  /** ISO 8601 weeks of the last loan and activity, e.g. 2024-W03, in the timezone the report was
+asked in; filled in by the API for spreadsheet users */
+  last_loaned_at_iso_week?: string;
+  title?: string;
+  year?: number;
+}
+
+export interface WeedingReport {
+  cutoff?: string;
+  /** CutoffISOWeek is the ISO 8601 week of the cutoff, filled in by the API */
+  cutoff_iso_week?: string;
+  items?: WeedingCandidate[];
+  page?: number;
+  page_size?: number;
+  total?: number;
+  years?: number;
+}
+
+export interface Work {
+  author?: string;
+  created_at?: string;
+  id?: string;
+  title?: string;
+  updated_at?: string;
+}
+
+export interface WorkResponse {
+  author?: string;
+  created_at?: string;
+  editions?: BookResponse[];
+  id?: string;
+  title?: string;
+  updated_at?: string;
+}
+
+export interface WorkerPool {
+  /** Capacity is the number of jobs that can wait before producers block */
+  capacity?: number;
+  description?: string;
+  name?: string;
+  /** Priorities break the jobs of the pool down by priority, highest first */
+  priorities?: JobPriorityStats[];
+  /** Queued is the number of jobs waiting for a worker */
+  queued?: number;
+  /** Rejected is the number of jobs refused because the queue was full */
+  rejected?: number;
+  workers?: number;
+}
+
+export const createClient = (http: AxiosInstance) => ({
+  /** List acquisitions */
+  listAcquisitions: async (query: { status?: string } = {}): Promise<Acquisition[]> => {
+    const response = await http.request({ method: 'get', url: `/api/acquisitions`, params: query });
+    return response.data;
+  },
+  /** Suggest a book */
+  suggestBook: async (suggestion: SuggestionRequest): Promise<Acquisition> => {
+    const response = await http.request({ method: 'post', url: `/api/acquisitions/suggestions`, data: suggestion });
+    return response.data;
+  },
+  /** Get an acquisition */
+  getAcquisition: async (id: string): Promise<Acquisition> => {
+    const response = await http.request({ method: 'get', url: `/api/acquisitions/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Approve a suggestion */
+  approveSuggestion: async (id: string): Promise<Acquisition> => {
+    const response = await http.request({ method: 'post', url: `/api/acquisitions/${encodeURIComponent(String(id))}/approve` });
+    return response.data;
+  },
+  /** Cancel an acquisition */
+  cancelAcquisition: async (id: string, reason: AcquisitionReasonRequest): Promise<Acquisition> => {
+    const response = await http.request({ method: 'post', url: `/api/acquisitions/${encodeURIComponent(String(id))}/cancel`, data: reason });
+    return response.data;
+  },
+  /** Order an acquisition */
+  orderAcquisition: async (id: string, order: OrderAcquisitionRequest): Promise<Acquisition> => {
+    const response = await http.request({ method: 'post', url: `/api/acquisitions/${encodeURIComponent(String(id))}/order`, data: order });
+    return response.data;
+  },
+  /** Receive an order */
+  receiveOrder: async (id: string): Promise<Acquisition> => {
+    const response = await http.request({ method: 'post', url: `/api/acquisitions/${encodeURIComponent(String(id))}/receive` });
+    return response.data;
+  },
+  /** Reject a suggestion */
+  rejectSuggestion: async (id: string, reason: AcquisitionReasonRequest): Promise<Acquisition> => {
+    const response = await http.request({ method: 'post', url: `/api/acquisitions/${encodeURIComponent(String(id))}/reject`, data: reason });
+    return response.data;
+  },
+  /** Get usage analytics */
+  getUsageAnalytics: async (query: { days?: number } = {}): Promise<AnalyticsReport> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/analytics`, params: query });
+    return response.data;
+  },
+  /** Get the audit writer */
+  getAuditWriter: async (): Promise<AuditWriterStats> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/audit-writer` });
+    return response.data;
+  },
+  /** List branches */
+  listBranches: async (): Promise<Branch[]> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/branches` });
+    return response.data;
+  },
+  /** Create a branch */
+  createBranch: async (branch: CreateBranchRequest): Promise<Branch> => {
+    const response = await http.request({ method: 'post', url: `/api/admin/branches`, data: branch });
+    return response.data;
+  },
+  /** Get a branch by ID */
+  getBranchByID: async (id: string): Promise<Branch> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/branches/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Get the calendar of the library or a branch */
+  getCalendarOfLibraryOrBranch: async (query: { branch_id?: string } = {}): Promise<Calendar> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/calendar`, params: query });
+    return response.data;
+  },
+  /** Close on a day */
+  closeOnDay: async (day: CreateClosedDayRequest): Promise<ClosedDay> => {
+    const response = await http.request({ method: 'post', url: `/api/admin/calendar/closed-days`, data: day });
+    return response.data;
+  },
+  /** Open again on a closed day */
+  openAgainOnClosedDay: async (id: string): Promise<void> => {
+    const response = await http.request({ method: 'delete', url: `/api/admin/calendar/closed-days/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Replace business hours */
+  replaceBusinessHours: async (hours: SetBusinessHoursRequest, query: { branch_id?: string } = {}): Promise<Calendar> => {
+    const response = await http.request({ method: 'put', url: `/api/admin/calendar/hours`, data: hours, params: query });
+    return response.data;
+  },
+  /** List dead letters */
+  listDeadLetters: async (query: { queue?: string; limit?: number; cursor?: string } = {}): Promise<DeadLetter[]> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/dead-letters`, params: query });
+    return response.data;
+  },
+  /** Discard a dead letter */
+  discardDeadLetter: async (id: string): Promise<MessageResponse> => {
+    const response = await http.request({ method: 'delete', url: `/api/admin/dead-letters/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Get a dead letter */
+  getDeadLetter: async (id: string): Promise<DeadLetter> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/dead-letters/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Requeue a dead letter */
+  requeueDeadLetter: async (id: string): Promise<MessageResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/admin/dead-letters/${encodeURIComponent(String(id))}/requeue` });
+    return response.data;
+  },
+  /** Enrich the metadata of books */
+  enrichMetadataOfBooks: async (request?: EnrichmentRequest): Promise<EnrichmentJob> => {
+    const response = await http.request({ method: 'post', url: `/api/admin/enrich`, data: request });
+    return response.data;
+  },
+  /** Get an enrichment job */
+  getEnrichmentJob: async (id: string): Promise<EnrichmentJob> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/enrich/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** End an impersonation */
+  endImpersonation: async (id: string): Promise<Session> => {
+    const response = await http.request({ method: 'delete', url: `/api/admin/impersonations/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** List scheduled jobs */
+  listScheduledJobs: async (): Promise<ScheduledJob[]> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/jobs` });
+    return response.data;
+  },
+  /** Deactivate a member */
+  deactivateMember: async (id: string): Promise<User> => {
+    const response = await http.request({ method: 'post', url: `/api/admin/members/${encodeURIComponent(String(id))}/deactivate` });
+    return response.data;
+  },
+  /** Erase a member */
+  eraseMember: async (id: string): Promise<User> => {
+    const response = await http.request({ method: 'post', url: `/api/admin/members/${encodeURIComponent(String(id))}/erase` });
+    return response.data;
+  },
+  /** Impersonate a member */
+  impersonateMember: async (id: string, impersonation: ImpersonateRequest): Promise<SessionCreatedResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/admin/members/${encodeURIComponent(String(id))}/impersonate`, data: impersonation });
+    return response.data;
+  },
+  /** Reactivate a member */
+  reactivateMember: async (id: string): Promise<User> => {
+    const response = await http.request({ method: 'post', url: `/api/admin/members/${encodeURIComponent(String(id))}/reactivate` });
+    return response.data;
+  },
+  /** List report subscriptions */
+  listReportSubscriptions: async (): Promise<ReportSubscription[]> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/report-subscriptions` });
+    return response.data;
+  },
+  /** Subscribe to a report */
+  subscribeToReport: async (subscription: ReportSubscriptionRequest): Promise<ReportSubscription> => {
+    const response = await http.request({ method: 'post', url: `/api/admin/report-subscriptions`, data: subscription });
+    return response.data;
+  },
+  /** Delete a report subscription */
+  deleteReportSubscription: async (id: string): Promise<MessageResponse> => {
+    const response = await http.request({ method: 'delete', url: `/api/admin/report-subscriptions/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Get a report subscription */
+  getReportSubscription: async (id: string): Promise<ReportSubscription> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/report-subscriptions/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Update a report subscription */
+  updateReportSubscription: async (id: string, subscription: ReportSubscriptionRequest): Promise<ReportSubscription> => {
+    const response = await http.request({ method: 'put', url: `/api/admin/report-subscriptions/${encodeURIComponent(String(id))}`, data: subscription });
+    return response.data;
+  },
+  /** Deliver a report now */
+  deliverReportNow: async (id: string): Promise<ReportSubscriptionRun> => {
+    const response = await http.request({ method: 'post', url: `/api/admin/report-subscriptions/${encodeURIComponent(String(id))}/run` });
+    return response.data;
+  },
+  /** Report subscription history */
+  reportSubscriptionHistory: async (id: string, query: { limit?: number } = {}): Promise<ReportSubscriptionRun[]> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/report-subscriptions/${encodeURIComponent(String(id))}/runs`, params: query });
+    return response.data;
+  },
+  /** Duplicate cover report */
+  duplicateCoverReport: async (query: { max_distance?: number } = {}): Promise<CoverDuplicateReport> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/reports/cover-duplicates`, params: query });
+    return response.data;
+  },
+  /** List what the query explorer can report on */
+  listWhatQueryExplorerCanReportOn: async (): Promise<ExploreEntity[]> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/reports/explore` });
+    return response.data;
+  },
+  /** Run an ad-hoc report */
+  runAdHocReport: async (query: ExploreQuery): Promise<ExploreResult> => {
+    const response = await http.request({ method: 'post', url: `/api/admin/reports/explore`, data: query });
+    return response.data;
+  },
+  /** Impersonation report */
+  impersonationReport: async (query: { days?: number } = {}): Promise<Impersonation[]> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/reports/impersonations`, params: query });
+    return response.data;
+  },
+  /** Weekly policy override report */
+  weeklyPolicyOverrideReport: async (query: { week?: string } = {}): Promise<OverrideReport> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/reports/overrides`, params: query });
+    return response.data;
+  },
+  /** Weeding report */
+  weedingReport: async (query: { years?: number; page?: number; page_size?: number; format?: string; sorted?: boolean; tz?: string } = {}): Promise<WeedingReport> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/reports/weeding`, params: query });
+    return response.data;
+  },
+  /** Get repository metrics */
+  getRepositoryMetrics: async (): Promise<OperationStats[]> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/repository-metrics` });
+    return response.data;
+  },
+  /** Save the sandbox baseline */
+  saveSandboxBaseline: async (): Promise<SandboxBaseline> => {
+    const response = await http.request({ method: 'post', url: `/api/admin/sandbox/baseline` });
+    return response.data;
+  },
+  /** Run the self-test */
+  runSelfTest: async (): Promise<SelfTestReport> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/selftest` });
+    return response.data;
+  },
+  /** List sign-in bans */
+  listSignInBans: async (): Promise<BruteForceStats> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/sign-in-bans` });
+    return response.data;
+  },
+  /** Lift a sign-in ban */
+  liftSignInBan: async (key: string): Promise<MessageResponse> => {
+    const response = await http.request({ method: 'delete', url: `/api/admin/sign-in-bans/${encodeURIComponent(String(key))}` });
+    return response.data;
+  },
+  /** Get storage usage */
+  getStorageUsage: async (): Promise<StorageReport> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/storage` });
+    return response.data;
+  },
+  /** Get the timezone of a tenant or a branch */
+  getTimezoneOfTenantOrBranch: async (query: { branch_id?: string } = {}): Promise<Timezone> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/timezone`, params: query });
+    return response.data;
+  },
+  /** Set the timezone of a tenant or a branch */
+  setTimezoneOfTenantOrBranch: async (timezone: SetTimezoneRequest, query: { branch_id?: string } = {}): Promise<Timezone> => {
+    const response = await http.request({ method: 'put', url: `/api/admin/timezone`, data: timezone, params: query });
+    return response.data;
+  },
+  /** Get the URL cache statistics */
+  getURLCacheStatistics: async (): Promise<URLCacheStats> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/url-cache` });
+    return response.data;
+  },
+  /** Get the URL processor's abuse protection */
+  getURLProcessorSAbuseProtection: async (): Promise<URLGuardStats> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/url-guard` });
+    return response.data;
+  },
+  /** List processed URLs */
+  listProcessedURLs: async (query: { domain?: string; operation?: string; from?: string; to?: string; limit?: number; cursor?: string; format?: string } = {}): Promise<URLResult[]> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/url-history`, params: query });
+    return response.data;
+  },
+  /** List validation rules */
+  listValidationRules: async (): Promise<ValidationRule[]> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/validation-rules` });
+    return response.data;
+  },
+  /** Add a validation rule */
+  addValidationRule: async (rule: ValidationRuleRequest): Promise<ValidationRule> => {
+    const response = await http.request({ method: 'post', url: `/api/admin/validation-rules`, data: rule });
+    return response.data;
+  },
+  /** Test a book against the validation rules */
+  testBookAgainstValidationRules: async (book: ValidationRuleTestRequest, query: { profile?: boolean } = {}): Promise<ValidationRuleTestResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/admin/validation-rules/test`, data: book, params: query });
+    return response.data;
+  },
+  /** Delete a validation rule */
+  deleteValidationRule: async (id: string): Promise<MessageResponse> => {
+    const response = await http.request({ method: 'delete', url: `/api/admin/validation-rules/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Get a validation rule */
+  getValidationRule: async (id: string): Promise<ValidationRule> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/validation-rules/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Update a validation rule */
+  updateValidationRule: async (id: string, rule: ValidationRuleRequest): Promise<ValidationRule> => {
+    const response = await http.request({ method: 'put', url: `/api/admin/validation-rules/${encodeURIComponent(String(id))}`, data: rule });
+    return response.data;
+  },
+  /** List worker pools */
+  listWorkerPools: async (): Promise<WorkerPool[]> => {
+    const response = await http.request({ method: 'get', url: `/api/admin/worker-pools` });
+    return response.data;
+  },
+  /** Resize a worker pool */
+  resizeWorkerPool: async (name: string, pool: ResizeWorkerPoolRequest): Promise<WorkerPool> => {
+    const response = await http.request({ method: 'put', url: `/api/admin/worker-pools/${encodeURIComponent(String(name))}`, data: pool });
+    return response.data;
+  },
+  /** Sign in */
+  signIn: async (): Promise<SessionCreatedResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/auth/sessions` });
+    return response.data;
+  },
+  /** Look up the availability of ISBNs */
+  lookUpAvailabilityOfISBNs: async (query: { isbns: string }): Promise<Record<string, Holding>> => {
+    const response = await http.request({ method: 'get', url: `/api/availability`, params: query });
+    return response.data;
+  },
+  /** Get all books */
+  getAllBooks: async (query: { tz?: string; include?: string; collapse?: string } = {}): Promise<BookResponse[]> => {
+    const response = await http.request({ method: 'get', url: `/api/books`, params: query });
+    return response.data;
+  },
+  /** Create a new book */
+  createNewBook: async (book: CreateBookRequest, query: { include?: string } = {}): Promise<BookResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/books`, data: book, params: query });
+    return response.data;
+  },
+  /** Delete books by ID */
+  deleteBooksByID: async (ids: BookIDBatchRequest, query: { atomic?: boolean } = {}): Promise<BookBatchChangeBatchResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/books/batch-delete`, data: ids, params: query });
+    return response.data;
+  },
+  /** Restore books by ID */
+  restoreBooksByID: async (ids: BookIDBatchRequest, query: { atomic?: boolean } = {}): Promise<BookBatchChangeBatchResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/books/batch-restore`, data: ids, params: query });
+    return response.data;
+  },
+  /** Get a book by slug */
+  getBookBySlug: async (slug: string, query: { tz?: string; include?: string } = {}): Promise<BookResponse> => {
+    const response = await http.request({ method: 'get', url: `/api/books/by-slug/${encodeURIComponent(String(slug))}`, params: query });
+    return response.data;
+  },
+  /** Import book covers from a ZIP archive */
+  importBookCoversFromZIPArchive: async (): Promise<CoverImportJob> => {
+    const response = await http.request({ method: 'post', url: `/api/books/covers/import` });
+    return response.data;
+  },
+  /** Get a cover import */
+  getCoverImport: async (id: string): Promise<CoverImportJob> => {
+    const response = await http.request({ method: 'get', url: `/api/books/covers/import/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Get deleted books */
+  getDeletedBooks: async (query: { tz?: string; include?: string } = {}): Promise<BookResponse[]> => {
+    const response = await http.request({ method: 'get', url: `/api/books/deleted`, params: query });
+    return response.data;
+  },
+  /** Import books in bulk */
+  importBooksInBulk: async (books: ImportBooksRequest, query: { profile?: string; on_conflict?: string; atomic?: boolean } = {}): Promise<BookImportResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/books/import`, data: books, params: query });
+    return response.data;
+  },
+  /** Look a book up by identifier */
+  lookBookUpByIdentifier: async (query: { type: string; value: string; tz?: string; include?: string }): Promise<BookResponse> => {
+    const response = await http.request({ method: 'get', url: `/api/books/lookup`, params: query });
+    return response.data;
+  },
+  /** Get scheduled books */
+  getScheduledBooks: async (query: { tz?: string; include?: string } = {}): Promise<BookResponse[]> => {
+    const response = await http.request({ method: 'get', url: `/api/books/scheduled`, params: query });
+    return response.data;
+  },
+  /** Search books */
+  searchBooks: async (query: { title?: string; author?: string; year?: number; publisher?: string; identifier?: string; status?: string; "meta.{key}"?: string; tz?: string; include?: string; collapse?: string; sort?: string } = {}): Promise<BookResponse[]> => {
+    const response = await http.request({ method: 'get', url: `/api/books/search`, params: query });
+    return response.data;
+  },
+  /** Create or update books by ISBN */
+  createOrUpdateBooksByISBN: async (book: UpsertBookRequest, query: { conflict_policy?: string; atomic?: boolean } = {}): Promise<BookUpsertBatchResponse> => {
+    const response = await http.request({ method: 'put', url: `/api/books/upsert`, data: book, params: query });
+    return response.data;
+  },
+  /** Delete a book */
+  deleteBook: async (id: string): Promise<MessageResponse> => {
+    const response = await http.request({ method: 'delete', url: `/api/books/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Get a book by ID */
+  getBookByID: async (id: string, query: { tz?: string; include?: string; format?: string } = {}): Promise<BookResponse> => {
+    const response = await http.request({ method: 'get', url: `/api/books/${encodeURIComponent(String(id))}`, params: query });
+    return response.data;
+  },
+  /** Update a book */
+  updateBook: async (id: string, book: UpdateBookRequest, query: { include?: string } = {}): Promise<BookResponse> => {
+    const response = await http.request({ method: 'put', url: `/api/books/${encodeURIComponent(String(id))}`, data: book, params: query });
+    return response.data;
+  },
+  /** Wait for a book's availability to change */
+  waitForBookSAvailabilityToChange: async (id: string, query: { available?: boolean; timeout?: number } = {}): Promise<AvailabilityPoll> => {
+    const response = await http.request({ method: 'get', url: `/api/books/${encodeURIComponent(String(id))}/availability/poll`, params: query });
+    return response.data;
+  },
+  /** Export a book bundle */
+  exportBookBundle: async (id: string, query: { sorted?: boolean; format?: string } = {}): Promise<Blob> => {
+    const response = await http.request({ method: 'get', url: `/api/books/${encodeURIComponent(String(id))}/bundle`, params: query });
+    return response.data;
+  },
+  /** List the copies of a book */
+  listCopiesOfBook: async (id: string): Promise<BookCopy[]> => {
+    const response = await http.request({ method: 'get', url: `/api/books/${encodeURIComponent(String(id))}/copies` });
+    return response.data;
+  },
+  /** Add a copy of a book */
+  addCopyOfBook: async (id: string, copy?: AddBookCopyRequest): Promise<BookCopy> => {
+    const response = await http.request({ method: 'post', url: `/api/books/${encodeURIComponent(String(id))}/copies`, data: copy });
+    return response.data;
+  },
+  /** Delete a book cover */
+  deleteBookCover: async (id: string): Promise<MessageResponse> => {
+    const response = await http.request({ method: 'delete', url: `/api/books/${encodeURIComponent(String(id))}/cover` });
+    return response.data;
+  },
+  /** Get a book cover */
+  getBookCover: async (id: string): Promise<Blob> => {
+    const response = await http.request({ method: 'get', url: `/api/books/${encodeURIComponent(String(id))}/cover` });
+    return response.data;
+  },
+  /** Upload a book cover */
+  uploadBookCover: async (id: string, request?: CoverURLRequest): Promise<BookCover> => {
+    const response = await http.request({ method: 'put', url: `/api/books/${encodeURIComponent(String(id))}/cover`, data: request });
+    return response.data;
+  },
+  /** Discard a book draft */
+  discardBookDraft: async (id: string): Promise<MessageResponse> => {
+    const response = await http.request({ method: 'delete', url: `/api/books/${encodeURIComponent(String(id))}/draft` });
+    return response.data;
+  },
+  /** Get a book draft */
+  getBookDraft: async (id: string): Promise<BookDraft> => {
+    const response = await http.request({ method: 'get', url: `/api/books/${encodeURIComponent(String(id))}/draft` });
+    return response.data;
+  },
+  /** Save a book draft */
+  saveBookDraft: async (id: string, draft: UpdateBookRequest): Promise<BookDraft> => {
+    const response = await http.request({ method: 'put', url: `/api/books/${encodeURIComponent(String(id))}/draft`, data: draft });
+    return response.data;
+  },
+  /** Publish a book draft */
+  publishBookDraft: async (id: string): Promise<BookResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/books/${encodeURIComponent(String(id))}/draft/publish` });
+    return response.data;
+  },
+  /** Get the detail of a book */
+  getDetailOfBook: async (id: string, query: { tz?: string } = {}): Promise<BookDetailResponse> => {
+    const response = await http.request({ method: 'get', url: `/api/books/${encodeURIComponent(String(id))}/full`, params: query });
+    return response.data;
+  },
+  /** List the identifiers of a book */
+  listIdentifiersOfBook: async (id: string): Promise<BookIdentifier[]> => {
+    const response = await http.request({ method: 'get', url: `/api/books/${encodeURIComponent(String(id))}/identifiers` });
+    return response.data;
+  },
+  /** Add an identifier to a book */
+  addIdentifierToBook: async (id: string, identifier: AddBookIdentifierRequest): Promise<BookIdentifier> => {
+    const response = await http.request({ method: 'post', url: `/api/books/${encodeURIComponent(String(id))}/identifiers`, data: identifier });
+    return response.data;
+  },
+  /** Remove an identifier from a book */
+  removeIdentifierFromBook: async (id: string, identifierId: string): Promise<MessageResponse> => {
+    const response = await http.request({ method: 'delete', url: `/api/books/${encodeURIComponent(String(id))}/identifiers/${encodeURIComponent(String(identifierId))}` });
+    return response.data;
+  },
+  /** Get the books around a book in a listing */
+  getBooksAroundBookInListing: async (id: string, query: { title?: string; author?: string; year?: number; publisher?: string; identifier?: string; status?: string; "meta.{key}"?: string; collapse?: string; sort?: string } = {}): Promise<BookNeighbors> => {
+    const response = await http.request({ method: 'get', url: `/api/books/${encodeURIComponent(String(id))}/neighbors`, params: query });
+    return response.data;
+  },
+  /** Permanently delete a book */
+  permanentlyDeleteBook: async (id: string): Promise<MessageResponse> => {
+    const response = await http.request({ method: 'delete', url: `/api/books/${encodeURIComponent(String(id))}/permanent` });
+    return response.data;
+  },
+  /** Get related books */
+  getRelatedBooks: async (id: string): Promise<RelatedBooksResponse> => {
+    const response = await http.request({ method: 'get', url: `/api/books/${encodeURIComponent(String(id))}/related` });
+    return response.data;
+  },
+  /** Restore a deleted book */
+  restoreDeletedBook: async (id: string): Promise<MessageResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/books/${encodeURIComponent(String(id))}/restore` });
+    return response.data;
+  },
+  /** Get the slug of a book */
+  getSlugOfBook: async (id: string): Promise<BookSlugResponse> => {
+    const response = await http.request({ method: 'get', url: `/api/books/${encodeURIComponent(String(id))}/slug` });
+    return response.data;
+  },
+  /** Change the status of a book */
+  changeStatusOfBook: async (id: string, status: ChangeBookStatusRequest): Promise<BookResponse> => {
+    const response = await http.request({ method: 'put', url: `/api/books/${encodeURIComponent(String(id))}/status`, data: status });
+    return response.data;
+  },
+  /** Get a book's timeline */
+  getBookSTimeline: async (id: string, query: { page?: number; page_size?: number } = {}): Promise<TimelinePage> => {
+    const response = await http.request({ method: 'get', url: `/api/books/${encodeURIComponent(String(id))}/timeline`, params: query });
+    return response.data;
+  },
+  /** Count a view of a book */
+  countViewOfBook: async (id: string): Promise<void> => {
+    const response = await http.request({ method: 'post', url: `/api/books/${encodeURIComponent(String(id))}/view` });
+    return response.data;
+  },
+  /** Bootstrap a frontend */
+  bootstrapFrontend: async (): Promise<BootstrapResponse> => {
+    const response = await http.request({ method: 'get', url: `/api/bootstrap` });
+    return response.data;
+  },
+  /** Get the capabilities of the deployment */
+  getCapabilitiesOfDeployment: async (): Promise<Capabilities> => {
+    const response = await http.request({ method: 'get', url: `/api/capabilities` });
+    return response.data;
+  },
+  /** Get the API changelog */
+  getAPIChangelog: async (query: { since?: string } = {}): Promise<ChangelogResponse> => {
+    const response = await http.request({ method: 'get', url: `/api/changelog`, params: query });
+    return response.data;
+  },
+  /** List collections */
+  listCollections: async (): Promise<Collection[]> => {
+    const response = await http.request({ method: 'get', url: `/api/collections` });
+    return response.data;
+  },
+  /** Create a collection */
+  createCollection: async (collection: CollectionRequest): Promise<CollectionResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/collections`, data: collection });
+    return response.data;
+  },
+  /** Delete a collection */
+  deleteCollection: async (id: string): Promise<MessageResponse> => {
+    const response = await http.request({ method: 'delete', url: `/api/collections/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Get a collection */
+  getCollection: async (id: string): Promise<CollectionResponse> => {
+    const response = await http.request({ method: 'get', url: `/api/collections/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Update a collection */
+  updateCollection: async (id: string, collection: CollectionRequest): Promise<CollectionResponse> => {
+    const response = await http.request({ method: 'put', url: `/api/collections/${encodeURIComponent(String(id))}`, data: collection });
+    return response.data;
+  },
+  /** Add a book to a collection */
+  addBookToCollection: async (id: string, book: AddCollectionBookRequest): Promise<CollectionResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/collections/${encodeURIComponent(String(id))}/books`, data: book });
+    return response.data;
+  },
+  /** Reorder the books of a collection */
+  reorderBooksOfCollection: async (id: string, order: ReorderCollectionRequest): Promise<CollectionResponse> => {
+    const response = await http.request({ method: 'put', url: `/api/collections/${encodeURIComponent(String(id))}/books`, data: order });
+    return response.data;
+  },
+  /** Remove a book from a collection */
+  removeBookFromCollection: async (id: string, bookId: string): Promise<CollectionResponse> => {
+    const response = await http.request({ method: 'delete', url: `/api/collections/${encodeURIComponent(String(id))}/books/${encodeURIComponent(String(bookId))}` });
+    return response.data;
+  },
+  /** Stop sharing a collection */
+  stopSharingCollection: async (id: string): Promise<Collection> => {
+    const response = await http.request({ method: 'delete', url: `/api/collections/${encodeURIComponent(String(id))}/share` });
+    return response.data;
+  },
+  /** Share a collection */
+  shareCollection: async (id: string): Promise<Collection> => {
+    const response = await http.request({ method: 'post', url: `/api/collections/${encodeURIComponent(String(id))}/share` });
+    return response.data;
+  },
+  /** Get the public configuration */
+  getPublicConfiguration: async (): Promise<PublicConfig> => {
+    const response = await http.request({ method: 'get', url: `/api/config/public` });
+    return response.data;
+  },
+  /** Look a copy up by accession number */
+  lookCopyUpByAccessionNumber: async (accessionNumber: string): Promise<BookCopyLookupResponse> => {
+    const response = await http.request({ method: 'get', url: `/api/copies/${encodeURIComponent(String(accessionNumber))}` });
+    return response.data;
+  },
+  /** List error codes */
+  listErrorCodes: async (): Promise<ErrorCode[]> => {
+    const response = await http.request({ method: 'get', url: `/api/errors` });
+    return response.data;
+  },
+  /** Get the new arrivals as Atom */
+  getNewArrivalsAsAtom: async (query: { limit?: number } = {}): Promise<Blob> => {
+    const response = await http.request({ method: 'get', url: `/api/feeds/catalog.atom`, params: query });
+    return response.data;
+  },
+  /** Get the new arrivals as JSON Feed */
+  getNewArrivalsAsJSONFeed: async (query: { limit?: number } = {}): Promise<JSONFeed> => {
+    const response = await http.request({ method: 'get', url: `/api/feeds/catalog.json`, params: query });
+    return response.data;
+  },
+  /** Get the new arrivals as RSS */
+  getNewArrivalsAsRSS: async (query: { limit?: number } = {}): Promise<Blob> => {
+    const response = await http.request({ method: 'get', url: `/api/feeds/catalog.rss`, params: query });
+    return response.data;
+  },
+  /** Get import profiles */
+  getImportProfiles: async (): Promise<ImportProfile[]> => {
+    const response = await http.request({ method: 'get', url: `/api/import-profiles` });
+    return response.data;
+  },
+  /** Delete an import profile */
+  deleteImportProfile: async (name: string): Promise<MessageResponse> => {
+    const response = await http.request({ method: 'delete', url: `/api/import-profiles/${encodeURIComponent(String(name))}` });
+    return response.data;
+  },
+  /** Get an import profile */
+  getImportProfile: async (name: string): Promise<ImportProfile> => {
+    const response = await http.request({ method: 'get', url: `/api/import-profiles/${encodeURIComponent(String(name))}` });
+    return response.data;
+  },
+  /** Save an import profile */
+  saveImportProfile: async (name: string, profile: SaveImportProfileRequest): Promise<ImportProfile> => {
+    const response = await http.request({ method: 'put', url: `/api/import-profiles/${encodeURIComponent(String(name))}`, data: profile });
+    return response.data;
+  },
+  /** List import runs */
+  listImportRuns: async (query: { limit?: number; cursor?: string } = {}): Promise<ImportRun[]> => {
+    const response = await http.request({ method: 'get', url: `/api/imports`, params: query });
+    return response.data;
+  },
+  /** Get an import run */
+  getImportRun: async (id: string): Promise<ImportRun> => {
+    const response = await http.request({ method: 'get', url: `/api/imports/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** List inventory sessions */
+  listInventorySessions: async (): Promise<InventorySession[]> => {
+    const response = await http.request({ method: 'get', url: `/api/inventory/sessions` });
+    return response.data;
+  },
+  /** Open an inventory session */
+  openInventorySession: async (session: OpenInventorySessionRequest): Promise<InventorySession> => {
+    const response = await http.request({ method: 'post', url: `/api/inventory/sessions`, data: session });
+    return response.data;
+  },
+  /** Get an inventory session */
+  getInventorySession: async (id: string): Promise<InventorySession> => {
+    const response = await http.request({ method: 'get', url: `/api/inventory/sessions/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Close an inventory session */
+  closeInventorySession: async (id: string): Promise<InventoryReport> => {
+    const response = await http.request({ method: 'post', url: `/api/inventory/sessions/${encodeURIComponent(String(id))}/close` });
+    return response.data;
+  },
+  /** Get a reconciliation report */
+  getReconciliationReport: async (id: string, query: { format?: string; sorted?: boolean; tz?: string } = {}): Promise<InventoryReport> => {
+    const response = await http.request({ method: 'get', url: `/api/inventory/sessions/${encodeURIComponent(String(id))}/report`, params: query });
+    return response.data;
+  },
+  /** Record scans */
+  recordScans: async (id: string, scans: ScanRequest): Promise<ScanBatchResult> => {
+    const response = await http.request({ method: 'post', url: `/api/inventory/sessions/${encodeURIComponent(String(id))}/scans`, data: scans });
+    return response.data;
+  },
+  /** Lend a book */
+  lendBook: async (loan: CheckoutRequest): Promise<Loan> => {
+    const response = await http.request({ method: 'post', url: `/api/loans`, data: loan });
+    return response.data;
+  },
+  /** Renew a loan */
+  renewLoan: async (id: string, renewal?: RenewRequest): Promise<Loan> => {
+    const response = await http.request({ method: 'post', url: `/api/loans/${encodeURIComponent(String(id))}/renew`, data: renewal });
+    return response.data;
+  },
+  /** Return a loan */
+  returnLoan: async (id: string): Promise<Loan> => {
+    const response = await http.request({ method: 'post', url: `/api/loans/${encodeURIComponent(String(id))}/return` });
+    return response.data;
+  },
+  /** Update my contact details */
+  updateMyContactDetails: async (contact: ContactRequest): Promise<User> => {
+    const response = await http.request({ method: 'patch', url: `/api/me/contact`, data: contact });
+    return response.data;
+  },
+  /** Deactivate my account */
+  deactivateMyAccount: async (): Promise<User> => {
+    const response = await http.request({ method: 'post', url: `/api/me/deactivate` });
+    return response.data;
+  },
+  /** List my fines */
+  listMyFines: async (): Promise<MemberFines> => {
+    const response = await http.request({ method: 'get', url: `/api/me/fines` });
+    return response.data;
+  },
+  /** List my holds */
+  listMyHolds: async (): Promise<HoldPosition[]> => {
+    const response = await http.request({ method: 'get', url: `/api/me/holds` });
+    return response.data;
+  },
+  /** List my loans */
+  listMyLoans: async (query: { from?: string; to?: string } = {}): Promise<Loan[]> => {
+    const response = await http.request({ method: 'get', url: `/api/me/loans`, params: query });
+    return response.data;
+  },
+  /** Renew a loan */
+  renewLoan2: async (id: string): Promise<Loan> => {
+    const response = await http.request({ method: 'post', url: `/api/me/loans/${encodeURIComponent(String(id))}/renew` });
+    return response.data;
+  },
+  /** Get my profile */
+  getMyProfile: async (): Promise<User> => {
+    const response = await http.request({ method: 'get', url: `/api/me/profile` });
+    return response.data;
+  },
+  /** Revoke my other sessions */
+  revokeMyOtherSessions: async (): Promise<RevokedSessionsResponse> => {
+    const response = await http.request({ method: 'delete', url: `/api/me/sessions` });
+    return response.data;
+  },
+  /** List my sessions */
+  listMySessions: async (): Promise<SessionResponse[]> => {
+    const response = await http.request({ method: 'get', url: `/api/me/sessions` });
+    return response.data;
+  },
+  /** Revoke a session */
+  revokeSession: async (id: string): Promise<Session> => {
+    const response = await http.request({ method: 'delete', url: `/api/me/sessions/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** List my access tokens */
+  listMyAccessTokens: async (): Promise<AccessToken[]> => {
+    const response = await http.request({ method: 'get', url: `/api/me/tokens` });
+    return response.data;
+  },
+  /** Create an access token */
+  createAccessToken: async (token: CreateAccessTokenRequest): Promise<AccessTokenCreatedResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/me/tokens`, data: token });
+    return response.data;
+  },
+  /** Revoke an access token */
+  revokeAccessToken: async (id: string): Promise<AccessToken> => {
+    const response = await http.request({ method: 'delete', url: `/api/me/tokens/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Get API usage */
+  getAPIUsage: async (): Promise<Usage> => {
+    const response = await http.request({ method: 'get', url: `/api/me/usage` });
+    return response.data;
+  },
+  /** Get metadata fields */
+  getMetadataFields: async (): Promise<MetadataField[]> => {
+    const response = await http.request({ method: 'get', url: `/api/metadata-fields` });
+    return response.data;
+  },
+  /** Delete a metadata field */
+  deleteMetadataField: async (key: string): Promise<MessageResponse> => {
+    const response = await http.request({ method: 'delete', url: `/api/metadata-fields/${encodeURIComponent(String(key))}` });
+    return response.data;
+  },
+  /** Define a metadata field */
+  defineMetadataField: async (key: string, field: SaveMetadataFieldRequest): Promise<MetadataField> => {
+    const response = await http.request({ method: 'put', url: `/api/metadata-fields/${encodeURIComponent(String(key))}`, data: field });
+    return response.data;
+  },
+  /** List notifications */
+  listNotifications: async (query: { unread?: boolean } = {}): Promise<Notification[]> => {
+    const response = await http.request({ method: 'get', url: `/api/notifications`, params: query });
+    return response.data;
+  },
+  /** Mark all notifications as read */
+  markAllNotificationsAsRead: async (): Promise<MessageResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/notifications/read-all` });
+    return response.data;
+  },
+  /** Count unread notifications */
+  countUnreadNotifications: async (): Promise<UnreadCountResponse> => {
+    const response = await http.request({ method: 'get', url: `/api/notifications/unread-count` });
+    return response.data;
+  },
+  /** Mark a notification as read */
+  markNotificationAsRead: async (id: string): Promise<MessageResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/notifications/${encodeURIComponent(String(id))}/read` });
+    return response.data;
+  },
+  /** Get all publishers */
+  getAllPublishers: async (): Promise<Publisher[]> => {
+    const response = await http.request({ method: 'get', url: `/api/publishers` });
+    return response.data;
+  },
+  /** Create a publisher */
+  createPublisher: async (publisher: PublisherRequest): Promise<Publisher> => {
+    const response = await http.request({ method: 'post', url: `/api/publishers`, data: publisher });
+    return response.data;
+  },
+  /** Delete a publisher */
+  deletePublisher: async (id: string): Promise<MessageResponse> => {
+    const response = await http.request({ method: 'delete', url: `/api/publishers/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Get a publisher by ID */
+  getPublisherByID: async (id: string): Promise<Publisher> => {
+    const response = await http.request({ method: 'get', url: `/api/publishers/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Update a publisher */
+  updatePublisher: async (id: string, publisher: PublisherRequest): Promise<Publisher> => {
+    const response = await http.request({ method: 'put', url: `/api/publishers/${encodeURIComponent(String(id))}`, data: publisher });
+    return response.data;
+  },
+  /** List imprints */
+  listImprints: async (id: string): Promise<Publisher[]> => {
+    const response = await http.request({ method: 'get', url: `/api/publishers/${encodeURIComponent(String(id))}/imprints` });
+    return response.data;
+  },
+  /** Check readiness */
+  checkReadiness: async (): Promise<ReadinessResponse> => {
+    const response = await http.request({ method: 'get', url: `/api/readyz` });
+    return response.data;
+  },
+  /** Get the schema of books */
+  getSchemaOfBooks: async (): Promise<EntitySchema> => {
+    const response = await http.request({ method: 'get', url: `/api/schema/books` });
+    return response.data;
+  },
+  /** Get all series */
+  getAllSeries: async (): Promise<Series[]> => {
+    const response = await http.request({ method: 'get', url: `/api/series` });
+    return response.data;
+  },
+  /** Create a series */
+  createSeries: async (series: CreateSeriesRequest): Promise<Series> => {
+    const response = await http.request({ method: 'post', url: `/api/series`, data: series });
+    return response.data;
+  },
+  /** Get a series by ID */
+  getSeriesByID: async (id: string): Promise<Series> => {
+    const response = await http.request({ method: 'get', url: `/api/series/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** List the books of a series */
+  listBooksOfSeries: async (id: string): Promise<BookResponse[]> => {
+    const response = await http.request({ method: 'get', url: `/api/series/${encodeURIComponent(String(id))}/books` });
+    return response.data;
+  },
+  /** Bootstrap the deployment */
+  bootstrapDeployment: async (request: BootstrapRequest): Promise<Bootstrap> => {
+    const response = await http.request({ method: 'post', url: `/api/setup/bootstrap`, data: request });
+    return response.data;
+  },
+  /** Get setup status */
+  getSetupStatus: async (): Promise<SetupStatus> => {
+    const response = await http.request({ method: 'get', url: `/api/setup/status` });
+    return response.data;
+  },
+  /** View a shared collection */
+  viewSharedCollection: async (token: string): Promise<SharedCollectionResponse> => {
+    const response = await http.request({ method: 'get', url: `/api/shared/collections/${encodeURIComponent(String(token))}` });
+    return response.data;
+  },
+  /** Get library stats */
+  getLibraryStats: async (): Promise<LibraryStats> => {
+    const response = await http.request({ method: 'get', url: `/api/stats` });
+    return response.data;
+  },
+  /** Process URLs in a batch */
+  processURLsInBatch: async (request: ProcessURLsRequest, query: { atomic?: boolean } = {}): Promise<URLBatchResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/url/batch`, data: request, params: query });
+    return response.data;
+  },
+  /** Process URL */
+  processURL: async (request: URLRequest): Promise<URLResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/url/process`, data: request });
+    return response.data;
+  },
+  /** List URL profiles */
+  listURLProfiles: async (): Promise<URLProfile[]> => {
+    const response = await http.request({ method: 'get', url: `/api/url/profiles` });
+    return response.data;
+  },
+  /** Canonicalize a sitemap */
+  canonicalizeSitemap: async (request?: SitemapSubmission): Promise<SitemapJob> => {
+    const response = await http.request({ method: 'post', url: `/api/url/sitemap`, data: request });
+    return response.data;
+  },
+  /** Get a sitemap job */
+  getSitemapJob: async (id: string): Promise<SitemapJob> => {
+    const response = await http.request({ method: 'get', url: `/api/url/sitemap/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Download a rewritten sitemap */
+  downloadRewrittenSitemap: async (id: string): Promise<Blob> => {
+    const response = await http.request({ method: 'get', url: `/api/url/sitemap/${encodeURIComponent(String(id))}/sitemap.xml` });
+    return response.data;
+  },
+  /** Get all works */
+  getAllWorks: async (): Promise<Work[]> => {
+    const response = await http.request({ method: 'get', url: `/api/works` });
+    return response.data;
+  },
+  /** Create a work */
+  createWork: async (work: CreateWorkRequest): Promise<WorkResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/works`, data: work });
+    return response.data;
+  },
+  /** Get a work by ID */
+  getWorkByID: async (id: string): Promise<WorkResponse> => {
+    const response = await http.request({ method: 'get', url: `/api/works/${encodeURIComponent(String(id))}` });
+    return response.data;
+  },
+  /** Group editions */
+  groupEditions: async (id: string, editions: GroupEditionsRequest): Promise<WorkResponse> => {
+    const response = await http.request({ method: 'post', url: `/api/works/${encodeURIComponent(String(id))}/editions`, data: editions });
+    return response.data;
+  },
+  /** Ungroup an edition */
+  ungroupEdition: async (id: string, bookId: string): Promise<WorkResponse> => {
+    const response = await http.request({ method: 'delete', url: `/api/works/${encodeURIComponent(String(id))}/editions/${encodeURIComponent(String(bookId))}` });
+    return response.data;
+  },
+});
+
+export type Client = ReturnType<typeof createClient>;
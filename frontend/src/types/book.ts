@@ -4,8 +4,13 @@ export interface Book {
   author: string;
   year: number;
   isbn: string;
+  slug: string;
+  status: 'draft' | 'active' | 'archived';
+  available: boolean;
   created_at: string;
   updated_at: string;
+  publish_at?: string;
+  metadata?: Record<string, string | number | boolean>;
 }
 
 export interface CreateBookRequest {